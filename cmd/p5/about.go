@@ -0,0 +1,249 @@
+package main
+
+import (
+	"cmp"
+	"maps"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// showAbout shows the about panel and fetches the Pulumi diagnostics again, since
+// plugins may have been installed since the last time
+func (m *Model) showAbout() tea.Cmd {
+	m.ui.About.SetSections(m.aboutSections())
+	m.ui.About.ResetScroll()
+	m.ui.About.Show()
+	m.ui.Focus.Push(ui.FocusAbout)
+	return m.fetchAbout()
+}
+
+// hideAbout hides the about panel and pops focus
+func (m *Model) hideAbout() {
+	m.ui.About.Hide()
+	m.ui.Focus.Remove(ui.FocusAbout)
+}
+
+// fetchAbout reads the Pulumi CLI version, backend and plugins, the p5.toml in use
+// and, for Go programs, the provider SDKs in go.mod
+func (m *Model) fetchAbout() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	cwd := m.ctx.Cwd
+	goProgram := m.state.Runtime == "go"
+	workspaceReader := m.deps.WorkspaceReader
+	logger := m.deps.Logger
+	appCtx := m.appCtx
+	opts := pulumi.ReadOptions{Env: m.deps.Env}
+	m.state.AboutFetchedFor = workDir
+
+	return func() tea.Msg {
+		info, err := workspaceReader.GetAbout(appCtx, workDir, opts)
+		msg := aboutMsg{Info: info, Err: err}
+		if cwd != "" {
+			_, msg.ConfigPath, _ = plugins.LoadGlobalConfig(cwd)
+		}
+		if goProgram {
+			var modErr error
+			if msg.GoMod, modErr = pulumi.GoModProviderVersions(workDir); modErr != nil {
+				logger.Warn("failed to read provider versions from go.mod", "workDir", workDir, "error", modErr)
+			}
+		}
+		return msg
+	}
+}
+
+// handleAbout shows the fetched Pulumi diagnostics and checks the provider versions
+// in state against them
+func (m Model) handleAbout(msg aboutMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	about := AboutDiagnostics(msg)
+	m.state.About = &about
+	m.updateProviderMismatches()
+	m.ui.About.SetSections(m.aboutSections())
+	return m, nil
+}
+
+// updateProviderMismatches compares the provider versions in state with the installed
+// plugins and go.mod, and flags diverging ones in the header
+func (m *Model) updateProviderMismatches() {
+	m.state.ProviderMismatches = nil
+	if about := m.state.About; about != nil && about.Err == nil && about.Info != nil {
+		m.state.ProviderMismatches = pulumi.ProviderVersionMismatches(m.state.StackResources, about.Info.Plugins, about.GoMod)
+	}
+	m.ui.Header.SetProviderMismatches(len(m.state.ProviderMismatches))
+}
+
+// updateAboutPanel handles keys when the about panel has focus
+func (m Model) updateAboutPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	panel := m.ui.About
+	switch {
+	case key.Matches(msg, ui.Keys.CopyResource):
+		return m, ui.CopyToClipboardWithCountCmd(panel.Text(), 0)
+	case key.Matches(msg, ui.Keys.Up):
+		panel.Scroll(-1)
+	case key.Matches(msg, ui.Keys.Down):
+		panel.Scroll(1)
+	case key.Matches(msg, ui.Keys.PageUp):
+		panel.Scroll(-10)
+	case key.Matches(msg, ui.Keys.PageDown):
+		panel.Scroll(10)
+	case key.Matches(msg, ui.Keys.Home):
+		panel.Scroll(-panel.LineCount())
+	case key.Matches(msg, ui.Keys.End):
+		panel.Scroll(panel.LineCount())
+	case key.Matches(msg, ui.Keys.Escape), key.Matches(msg, ui.Keys.ViewAbout), key.Matches(msg, ui.Keys.Quit):
+		m.hideAbout()
+	case key.Matches(msg, ui.Keys.Help):
+		m.showHelp()
+	}
+	return m, nil
+}
+
+// aboutSections lists what p5 knows about itself, the Pulumi CLI, the workspace
+// and the loaded plugins
+func (m *Model) aboutSections() []ui.AboutSection {
+	sections := []ui.AboutSection{{
+		Title: "p5",
+		Fields: []ui.AboutField{
+			{Name: i18n.T("Version"), Value: p5Version()},
+			{Name: "Go", Value: runtime.Version()},
+			{Name: "OS", Value: runtime.GOOS + "/" + runtime.GOARCH},
+		},
+	}}
+
+	pulumiSection := ui.AboutSection{Title: "Pulumi"}
+	var pulumiPlugins []pulumi.PluginVersion
+	switch about := m.state.About; {
+	case about == nil:
+		pulumiSection.Fields = []ui.AboutField{{Value: i18n.T("Loading...")}}
+	case about.Err != nil:
+		pulumiSection.Fields = []ui.AboutField{{Value: i18n.Tf("Failed to read Pulumi details: %v", about.Err)}}
+	default:
+		backend, user := about.Info.BackendURL, about.Info.User
+		if backend == "" {
+			backend = i18n.T("not logged in")
+		}
+		pulumiSection.Fields = []ui.AboutField{
+			{Name: "CLI", Value: about.Info.CLIVersion},
+			{Name: i18n.T("Backend"), Value: backend},
+		}
+		if user != "" {
+			pulumiSection.Fields = append(pulumiSection.Fields, ui.AboutField{Name: i18n.T("User"), Value: user})
+		}
+		pulumiPlugins = about.Info.Plugins
+	}
+	sections = append(sections, pulumiSection)
+
+	sections = append(sections, ui.AboutSection{
+		Title: i18n.T("Project"),
+		Fields: []ui.AboutField{
+			{Name: i18n.T("Name"), Value: m.state.ProgramName},
+			{Name: i18n.T("Runtime"), Value: m.state.Runtime},
+			{Name: i18n.T("Stack"), Value: m.ctx.StackName},
+			{Name: i18n.T("Directory"), Value: m.ctx.WorkDir},
+		},
+	})
+
+	if m.state.About != nil && m.state.About.Err == nil {
+		section := ui.AboutSection{Title: i18n.T("Pulumi Plugins")}
+		for _, p := range pulumiPlugins {
+			section.Fields = append(section.Fields, ui.AboutField{Name: p.Name, Value: strings.TrimSpace(p.Kind + " " + p.Version)})
+		}
+		if len(section.Fields) == 0 {
+			section.Fields = []ui.AboutField{{Value: i18n.T("No plugins installed")}}
+		}
+		sections = append(sections, section)
+	}
+
+	if section, ok := m.providerVersionsSection(); ok {
+		sections = append(sections, section)
+	}
+
+	configPath := i18n.T("none")
+	if m.state.About != nil && m.state.About.ConfigPath != "" {
+		configPath = m.state.About.ConfigPath
+	}
+	sections = append(sections, ui.AboutSection{
+		Title: i18n.T("p5 Config"),
+		Fields: []ui.AboutField{
+			{Name: "p5.toml", Value: configPath},
+			{Name: "Pulumi.yaml", Value: filepath.Join(m.ctx.WorkDir, "Pulumi.yaml")},
+			{Name: i18n.T("Data"), Value: filepath.Join(m.ctx.WorkDir, ".p5")},
+		},
+	})
+
+	return append(sections, ui.AboutSection{Title: i18n.T("p5 Plugins"), Fields: m.aboutPluginFields()})
+}
+
+// providerVersionsSection lists the provider versions in state, with the installed
+// plugins or go.mod versions of diverging ones. There is none without providers.
+func (m *Model) providerVersionsSection() (ui.AboutSection, bool) {
+	stateVersions := pulumi.StateProviderVersions(m.state.StackResources)
+	if len(stateVersions) == 0 {
+		return ui.AboutSection{}, false
+	}
+
+	section := ui.AboutSection{Title: i18n.T("Provider Versions")}
+	for _, pkg := range slices.Sorted(maps.Keys(stateVersions)) {
+		section.Fields = append(section.Fields, ui.AboutField{Name: pkg, Value: strings.Join(stateVersions[pkg], ", ")})
+	}
+	for _, mm := range m.state.ProviderMismatches {
+		section.Fields = append(section.Fields, ui.AboutField{Value: i18n.Tf("%s %s in state, %s %s",
+			mm.Package, mm.StateVersion, mm.Source, strings.Join(mm.Versions, ", ")), Warning: true})
+	}
+	return section, true
+}
+
+// aboutPluginFields lists the configured p5 plugins with their capabilities
+func (m *Model) aboutPluginFields() []ui.AboutField {
+	var config *plugins.P5Config
+	if m.deps.PluginProvider != nil {
+		config = m.deps.PluginProvider.GetMergedConfig()
+	}
+	if config == nil || len(config.Plugins) == 0 {
+		return []ui.AboutField{{Value: i18n.T("No plugins configured")}}
+	}
+
+	fields := make([]ui.AboutField, 0, len(config.Plugins))
+	for name, pc := range config.Plugins {
+		source := pc.Cmd
+		if source == "" && plugins.IsBuiltin(name) {
+			source = "builtin"
+		}
+		parts := []string{source}
+		for _, c := range []struct {
+			on   bool
+			name string
+		}{
+			{pc.ImportHelper, "import_helper"},
+			{pc.ResourceOpener, "resource_opener"},
+			{pc.PostOperation, "post_operation"},
+			{pc.StatusBadge, "status_badge"},
+			{pc.UseAuthEnv, "use_auth_env"},
+		} {
+			if c.on {
+				parts = append(parts, c.name)
+			}
+		}
+		fields = append(fields, ui.AboutField{Name: name, Value: strings.TrimSpace(strings.Join(parts, " "))})
+	}
+	slices.SortFunc(fields, func(a, b ui.AboutField) int { return cmp.Compare(a.Name, b.Name) })
+	return fields
+}
+
+// p5Version returns the module version p5 was built from, "(devel)" for local builds
+func p5Version() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
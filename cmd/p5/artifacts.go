@@ -0,0 +1,247 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// RunRecord collects what happens during an up, refresh or destroy so it can be
+// written to an artifacts directory when the operation finishes
+type RunRecord struct {
+	Operation  pulumi.OperationType
+	WorkDir    string
+	StackName  string
+	Targets    []string
+	Replaces   []string
+	Excludes   []string
+	Started    time.Time
+	Finished   time.Time
+	Transcript []string // Timestamped event lines
+	Err        error    // Error that ended the operation, if any
+}
+
+// NewRunRecord starts recording an operation. Only the flags are kept from opts,
+// the environment may contain credentials.
+func NewRunRecord(op pulumi.OperationType, workDir, stackName string, opts pulumi.OperationOptions, started time.Time) *RunRecord {
+	return &RunRecord{
+		Operation: op,
+		WorkDir:   workDir,
+		StackName: stackName,
+		Targets:   opts.Targets,
+		Replaces:  opts.Replaces,
+		Excludes:  opts.Excludes,
+		Started:   started,
+	}
+}
+
+// Record appends an operation event to the transcript
+func (r *RunRecord) Record(event pulumi.OperationEvent, at time.Time) {
+	stamp := at.Format("15:04:05")
+	switch {
+	case event.Error != nil:
+		r.Transcript = append(r.Transcript, fmt.Sprintf("%s error: %v", stamp, event.Error))
+	case event.Done:
+		r.Transcript = append(r.Transcript, stamp+" done")
+	case event.Message != "":
+		r.Transcript = append(r.Transcript, fmt.Sprintf("%s %s", stamp, strings.TrimRight(event.Message, "\n")))
+	case event.URN != "":
+		r.Transcript = append(r.Transcript, fmt.Sprintf("%s %s %s %s", stamp, stepStatusName(event.Status), event.Op, event.URN))
+	}
+}
+
+// Finish marks the operation as ended, with err set if it failed
+func (r *RunRecord) Finish(err error, at time.Time) {
+	r.Err = err
+	r.Finished = at
+}
+
+// stepStatusName returns the transcript name of an execution step status
+func stepStatusName(status pulumi.StepStatus) string {
+	switch status {
+	case pulumi.StepRunning:
+		return "running"
+	case pulumi.StepSuccess:
+		return "success"
+	case pulumi.StepFailed:
+		return "failed"
+	default:
+		return "pending"
+	}
+}
+
+// itemStatusName returns the artifact name of a resource item status
+func itemStatusName(status ui.ItemStatus) string {
+	switch status {
+	case ui.StatusPending:
+		return "pending"
+	case ui.StatusRunning:
+		return "running"
+	case ui.StatusSuccess:
+		return "success"
+	case ui.StatusFailed:
+		return "failed"
+	default:
+		return ""
+	}
+}
+
+// RunDirName returns the timestamped directory name for a run
+func RunDirName(run *RunRecord) string {
+	stack := strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(run.StackName)
+	return fmt.Sprintf("%s-%s-%s", run.Started.Format("20060102-150405"), strings.ToLower(run.Operation.String()), stack)
+}
+
+// runPlanStep is one resource in plan.json
+type runPlanStep struct {
+	URN         string         `json:"urn"`
+	Type        string         `json:"type"`
+	Name        string         `json:"name"`
+	Op          string         `json:"op"`
+	Status      string         `json:"status,omitempty"`
+	Parent      string         `json:"parent,omitempty"`
+	Inputs      map[string]any `json:"inputs,omitempty"`
+	Outputs     map[string]any `json:"outputs,omitempty"`
+	OldInputs   map[string]any `json:"oldInputs,omitempty"`
+	OldOutputs  map[string]any `json:"oldOutputs,omitempty"`
+	ReplaceKeys []string       `json:"replaceKeys,omitempty"`
+}
+
+// WriteRunArtifacts writes plan.json, transcript.log, summary.md and, when the run
+// failed, error.txt into a new timestamped directory under baseDir. Returns the directory.
+func WriteRunArtifacts(baseDir string, run *RunRecord, items []ui.ResourceItem) (string, error) {
+	dir := filepath.Join(baseDir, RunDirName(run))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create artifacts directory: %w", err)
+	}
+
+	steps := make([]runPlanStep, 0, len(items))
+	for _, item := range items {
+		steps = append(steps, runPlanStep{
+			URN:         item.URN,
+			Type:        item.Type,
+			Name:        item.Name,
+			Op:          string(item.Op),
+			Status:      itemStatusName(item.Status),
+			Parent:      item.Parent,
+			Inputs:      item.Inputs,
+			Outputs:     item.Outputs,
+			OldInputs:   item.OldInputs,
+			OldOutputs:  item.OldOutputs,
+			ReplaceKeys: item.ReplaceKeys,
+		})
+	}
+	plan, err := json.MarshalIndent(steps, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode plan: %w", err)
+	}
+
+	files := map[string][]byte{
+		"plan.json":      plan,
+		"transcript.log": []byte(strings.Join(run.Transcript, "\n") + "\n"),
+		"summary.md":     []byte(FormatRunSummary(run, items)),
+	}
+	if details := FormatRunErrors(run, items); details != "" {
+		files["error.txt"] = []byte(details)
+	}
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if err := os.WriteFile(filepath.Join(dir, name), files[name], 0o600); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return dir, nil
+}
+
+// runFailed reports whether the operation errored or any resource failed
+func runFailed(run *RunRecord, items []ui.ResourceItem) bool {
+	if run.Err != nil {
+		return true
+	}
+	return slices.ContainsFunc(items, func(item ui.ResourceItem) bool {
+		return item.Status == ui.StatusFailed
+	})
+}
+
+// FormatRunSummary renders the markdown summary of a run
+func FormatRunSummary(run *RunRecord, items []ui.ResourceItem) string {
+	var b strings.Builder
+
+	result := "succeeded"
+	if runFailed(run, items) {
+		result = "failed"
+	}
+
+	fmt.Fprintf(&b, "# p5 %s: %s\n\n", strings.ToLower(run.Operation.String()), run.StackName)
+	b.WriteString("| | |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Project | `%s` |\n", run.WorkDir)
+	fmt.Fprintf(&b, "| Stack | %s |\n", run.StackName)
+	fmt.Fprintf(&b, "| Operation | %s |\n", run.Operation.String())
+	fmt.Fprintf(&b, "| Started | %s |\n", run.Started.Format(time.RFC3339))
+	fmt.Fprintf(&b, "| Finished | %s |\n", run.Finished.Format(time.RFC3339))
+	fmt.Fprintf(&b, "| Duration | %s |\n", run.Finished.Sub(run.Started).Round(time.Second))
+	fmt.Fprintf(&b, "| Result | %s |\n", result)
+
+	counts := make(map[string]int)
+	for _, item := range items {
+		counts[string(item.Op)]++
+	}
+	if len(counts) > 0 {
+		b.WriteString("\n## Changes\n\n| Operation | Resources |\n|---|---|\n")
+		for _, op := range slices.Sorted(maps.Keys(counts)) {
+			fmt.Fprintf(&b, "| %s | %d |\n", op, counts[op])
+		}
+	}
+
+	writeURNList(&b, "Targets", run.Targets)
+	writeURNList(&b, "Replaces", run.Replaces)
+	writeURNList(&b, "Excludes", run.Excludes)
+
+	var failed []string
+	for _, item := range items {
+		if item.Status == ui.StatusFailed {
+			failed = append(failed, item.URN)
+		}
+	}
+	writeURNList(&b, "Failed resources", failed)
+
+	if run.Err != nil {
+		b.WriteString("\n## Error\n\nSee `error.txt`.\n")
+	}
+	return b.String()
+}
+
+// writeURNList writes a markdown section listing URNs, if there are any
+func writeURNList(b *strings.Builder, title string, urns []string) {
+	if len(urns) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", title)
+	for _, urn := range urns {
+		fmt.Fprintf(b, "- `%s`\n", urn)
+	}
+}
+
+// FormatRunErrors returns the error details of a failed run, or "" if it succeeded
+func FormatRunErrors(run *RunRecord, items []ui.ResourceItem) string {
+	if !runFailed(run, items) {
+		return ""
+	}
+	var b strings.Builder
+	if run.Err != nil {
+		fmt.Fprintf(&b, "%v\n", run.Err)
+	}
+	for _, item := range items {
+		if item.Status == ui.StatusFailed {
+			fmt.Fprintf(&b, "failed: %s\n", item.URN)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// fetchCloudStacks returns a command listing the stacks of every project in the
+// organizations of the logged in user. Only Pulumi Cloud lists stacks across projects.
+func (m *Model) fetchCloudStacks() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackReader := m.deps.StackReader
+	workspaceReader := m.deps.WorkspaceReader
+	appCtx := m.appCtx
+	opts := pulumi.ReadOptions{Env: m.deps.Env}
+	return func() tea.Msg {
+		if info, err := workspaceReader.GetWhoAmI(appCtx, workDir, opts); err == nil && info != nil && !pulumi.IsCloudBackend(info.URL) {
+			return cloudStacksMsg{Err: fmt.Errorf("%s: %s", i18n.T("stacks of other projects can only be browsed on Pulumi Cloud"), info.URL)}
+		}
+		stacks, err := stackReader.GetCloudStacks(appCtx, workDir, opts)
+		return cloudStacksMsg{Stacks: stacks, Err: err}
+	}
+}
+
+// handleCloudStacks lists the stacks of the organization in the browser
+func (m Model) handleCloudStacks(msg cloudStacksMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if msg.Err != nil {
+		m.ui.CloudStackBrowser.SetError(msg.Err)
+		return m, nil
+	}
+	items := make([]ui.CloudStackItem, 0, len(msg.Stacks))
+	for _, s := range msg.Stacks {
+		items = append(items, ui.CloudStackItem{
+			Name:             s.Name,
+			Org:              s.Org,
+			Project:          s.Project,
+			Stack:            s.Stack,
+			LastUpdate:       s.LastUpdate,
+			UpdateInProgress: s.UpdateInProgress,
+			ResourceCount:    s.ResourceCount,
+			Current:          s.Name == m.ctx.CloudStack,
+		})
+	}
+	m.ui.CloudStackBrowser.SetStacks(items)
+	return m, nil
+}
+
+// updateCloudStackBrowser handles keys when the cloud stack browser has focus
+func (m Model) updateCloudStackBrowser(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	selected, cmd := m.ui.CloudStackBrowser.Update(msg)
+	if selected {
+		item := m.ui.CloudStackBrowser.SelectedItem()
+		m.hideCloudStackBrowser()
+		if item != nil {
+			return m, m.openCloudStack(*item)
+		}
+	}
+	// Check if browser was dismissed (ESC pressed)
+	if !m.ui.CloudStackBrowser.Visible() {
+		m.ui.Focus.Remove(ui.FocusCloudStackBrowser)
+	}
+	return m, cmd
+}
+
+// openCloudStack switches to browsing a stack of the organization read-only,
+// reading its state from the backend as its program may not be checked out
+func (m *Model) openCloudStack(item ui.CloudStackItem) tea.Cmd {
+	m.ctx.StartView = "state"
+	m.ctx.CloudStack = item.Name
+	m.ctx.StateFiles = nil
+	m.hideDetailsPanel()
+	m.cancelQuickCheck()
+
+	// Spinners only tick while they have something loading
+	var ticks []tea.Cmd
+	if !m.ui.ResourceList.IsLoading() {
+		ticks = append(ticks, m.ui.ResourceList.Spinner().Tick)
+	}
+	if !m.ui.Header.IsLoading() {
+		ticks = append(ticks, m.ui.Header.Spinner().Tick)
+	}
+	m.ui.ResourceList.Clear()
+	m.ui.ResourceList.SetLoading(true, i18n.T("Loading stack state..."))
+	m.ui.ResourceList.SetShowAllOps(true)
+	m.ui.Header.SetLastResult("")
+	m.ui.Header.SetData(&ui.HeaderData{
+		ProgramName: item.Project,
+		StackName:   item.Stack,
+		Source:      item.Name,
+	})
+	m.ui.Header.SetSummary(ui.ResourceSummary{}, ui.HeaderLoading)
+
+	workDir := m.ctx.WorkDir
+	stackReader := m.deps.StackReader
+	appCtx := m.appCtx
+	opts := pulumi.ReadOptions{Env: m.deps.Env}
+	name := item.Name
+	return tea.Batch(append(ticks, func() tea.Msg {
+		resources, err := stackReader.ExportCloudStack(appCtx, workDir, name, opts)
+		return cloudStackStateMsg{Name: name, Resources: resources, Err: err}
+	})...)
+}
+
+// handleCloudStackState shows the resources of the browsed stack, unless another
+// one was opened meanwhile
+func (m Model) handleCloudStackState(msg cloudStackStateMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if msg.Name != m.ctx.CloudStack {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.ui.Header.SetError(msg.Err)
+		m.ui.ResourceList.SetError(msg.Err)
+		return m, nil
+	}
+	m.ui.ResourceList.SetItems(ConvertResourcesToItems(msg.Resources))
+	m.ui.Header.SetSummary(m.ui.ResourceList.Summary(), ui.HeaderDone)
+	return m, nil
+}
+
+// leaveCloudStack returns from a browsed stack of the organization to the stack
+// of the workspace
+func (m Model) leaveCloudStack() (tea.Model, tea.Cmd) {
+	m.ctx.StartView = "stack"
+	m.ctx.CloudStack = ""
+	return m.handleStackSelected(stackSelectedMsg(m.ctx.StackName))
+}
@@ -3,13 +3,23 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"maps"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pkg/browser"
+	"golang.org/x/sync/errgroup"
 
+	"github.com/rfhold/p5/internal/i18n"
 	"github.com/rfhold/p5/internal/plugins"
 	"github.com/rfhold/p5/internal/pulumi"
 	"github.com/rfhold/p5/internal/ui"
@@ -110,7 +120,7 @@ func (m Model) initPreview(op pulumi.OperationType) tea.Cmd {
 
 // loadStackResources fetches stack resources
 func (m *Model) loadStackResources() tea.Cmd {
-	m.ui.ResourceList.SetLoading(true, "Loading stack resources...")
+	m.ui.ResourceList.SetLoading(true, i18n.T("Loading stack resources..."))
 	m.ui.ResourceList.SetShowAllOps(true)
 	workDir := m.ctx.WorkDir
 	stackName := m.ctx.StackName
@@ -128,6 +138,32 @@ func (m *Model) loadStackResources() tea.Cmd {
 
 // startPreview starts a preview operation
 func (m *Model) startPreview(op pulumi.OperationType) tea.Cmd {
+	return m.startPreviewWithPlan(op, "")
+}
+
+// startPlanPreview runs an up preview that saves an update plan, so executing up
+// from the preview applies exactly what was previewed
+func (m *Model) startPlanPreview() tea.Cmd {
+	planPath := PlanFile(m.ctx.WorkDir, m.ctx.StackName, time.Now())
+	return tea.Batch(
+		m.startPreviewWithPlan(pulumi.OperationUp, planPath),
+		m.removeStalePlans(planPath),
+	)
+}
+
+// removeStalePlans deletes the stack's saved plans other than keep. Failures are
+// ignored; the plans are only kept around to be applied.
+func (m *Model) removeStalePlans(keep string) tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	return func() tea.Msg {
+		_ = RemovePlans(workDir, stackName, keep)
+		return nil
+	}
+}
+
+// startPreviewWithPlan starts a preview, writing an update plan to planPath if set
+func (m *Model) startPreviewWithPlan(op pulumi.OperationType, planPath string) tea.Cmd {
 	// Transition operation state
 	m.transitionOpTo(OpStarting)
 
@@ -138,14 +174,17 @@ func (m *Model) startPreview(op pulumi.OperationType) tea.Cmd {
 	m.ui.Details.Hide() // Close details panel when view changes
 	m.ui.ResourceList.Clear()
 	m.ui.ResourceList.SetShowAllOps(false) // Hide unchanged resources
-	m.ui.ResourceList.SetLoading(true, fmt.Sprintf("Running %s preview...", op.String()))
+	m.ui.ResourceList.SetLoading(true, i18n.Tf("Running %s preview...", op.String()))
+	m.setDriftMode(false)
+	m.state.PreviewWarnings = nil
+	m.ui.Diagnostics.Clear()
+	m.state.PlanPath = planPath
+	m.state.PlanSaved = false
 
 	// Build options from flags
-	opts := pulumi.OperationOptions{
-		Targets:  m.ui.ResourceList.GetTargetURNs(),
-		Replaces: m.ui.ResourceList.GetReplaceURNs(),
-		Excludes: m.ui.ResourceList.GetExcludeURNs(),
-	}
+	opts := m.operationOptions()
+	m.state.PlanFlags = opts
+	opts.Plan = planPath
 
 	// Merge base env with plugin credentials
 	opts.Env = mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())
@@ -159,36 +198,412 @@ func (m *Model) startPreview(op pulumi.OperationType) tea.Cmd {
 	m.previewCancel = previewCancel
 	m.previewCh = m.deps.StackOperator.Preview(previewCtx, workDir, stackName, op, opts)
 
-	return waitForPreviewEvent(m.previewCh)
+	// Re-read the checkout, so the header is current for the up that may follow
+	return tea.Batch(waitForPreviewEvent(m.previewCh), m.fetchGitInfo())
 }
 
-// maybeConfirmExecution checks if confirmation is needed before executing
-// Confirmation is needed if the user is not on the preview screen for the requested operation
+// maybeConfirmExecution checks if confirmation is needed before executing, by the
+// operation's confirm mode for the stack. By default confirmation is needed if the
+// user is not on the preview screen for the requested operation. With auto-approval
+// on and no "always" mode, running from the preview needs confirmation unless its
+// changes are small and the flags are those it ran with.
 func (m *Model) maybeConfirmExecution(op pulumi.OperationType) tea.Cmd {
 	// Don't start execution if an operation is already running (prevents race with preview)
 	if m.state.OpState.IsActive() {
 		return nil
 	}
-	// If we're on the preview screen for this exact operation, execute directly
-	if m.ui.ViewMode == ui.ViewPreview && m.state.Operation == op {
+	previewed := m.ui.ViewMode == ui.ViewPreview && m.state.Operation == op
+	var confirm bool
+	var problems []string
+	switch mode := m.state.Confirm.Mode(strings.ToLower(op.String())); {
+	case mode == plugins.ConfirmNever:
+		confirm = false
+	case mode == plugins.ConfirmAlways:
+		confirm = true
+	case previewed && m.state.AutoApprove != nil:
+		switch {
+		case m.state.OpState != OpComplete:
+			problems = []string{i18n.T("The preview did not finish")}
+		case m.flagsChangedSincePreview():
+			problems = []string{i18n.T("Flags changed since the preview")}
+		default:
+			problems = AutoApproveProblems(m.state.AutoApprove, m.ui.ResourceList.Items())
+		}
+		confirm = len(problems) > 0
+	default:
+		confirm = !previewed
+	}
+	// Protected stacks ask for the stack name instead
+	if !confirm || m.requiresStackName(op) {
 		return m.startExecution(op)
 	}
 
 	// Otherwise, show confirmation modal
 	m.state.PendingOperation = &op
-	m.ui.ConfirmModal.SetLabels("Cancel", "Execute")
+	m.ui.ConfirmModal.SetLabels(i18n.T("Cancel"), i18n.T("Execute"))
 	m.ui.ConfirmModal.SetKeys("n", "y")
+	message := i18n.Tf("Run %s without previewing changes first?", op.String())
+	if previewed {
+		message = i18n.Tf("Run %s with the changes previewed?", op.String())
+	}
+	if len(problems) > 0 {
+		message += "\n\n" + i18n.T("Not auto-approved:") + "\n- " + strings.Join(problems, "\n- ")
+	}
+	var radius []string
+	if op == pulumi.OperationDestroy && len(m.state.StackResources) > 0 {
+		var summary string
+		summary, radius = DescribeBlastRadius(m.state.StackResources, m.operationOptions().Targets)
+		message += "\n\n" + summary
+	}
 	m.ui.ConfirmModal.Show(
-		"Execute "+op.String(),
-		fmt.Sprintf("Run %s without previewing changes first?", op.String()),
-		"This will apply changes to your infrastructure.",
+		i18n.Tf("Execute %s", op.String()),
+		message,
+		i18n.T("This will apply changes to your infrastructure."),
 	)
+	m.ui.ConfirmModal.SetDetails(radius)
+	m.showConfirmModal()
+	return nil
+}
+
+// startDriftDetection runs a refresh preview and shows only the resources whose
+// live state drifted from the last deployment
+func (m *Model) startDriftDetection() tea.Cmd {
+	cmd := m.startPreview(pulumi.OperationRefresh)
+	m.setDriftMode(true)
+	m.ui.ResourceList.SetLoading(true, i18n.T("Detecting drift..."))
+	return cmd
+}
+
+// setDriftMode switches the preview between showing all changes and showing drift only
+func (m *Model) setDriftMode(on bool) {
+	m.state.DriftMode = on
+	m.ui.ResourceList.SetDriftOnly(on)
+	if on {
+		m.ui.Header.SetDrift(&ui.DriftSummary{})
+	} else {
+		m.ui.Header.SetDrift(nil)
+	}
+}
+
+// markDrift records the drifted properties of a refreshed resource and updates the drift counts
+func (m *Model) markDrift(urn string) {
+	items := m.ui.ResourceList.Items()
+	for i := range items {
+		if items[i].URN == urn {
+			m.ui.ResourceList.SetDriftedKeys(urn, DriftedKeys(m.state.DiffIgnore.WithoutIgnored(items[i])))
+			break
+		}
+	}
+	summary := SummarizeDrift(m.ui.ResourceList.Items())
+	m.ui.Header.SetDrift(&summary)
+}
+
+// confirmDriftAction asks the user to confirm accepting (refresh) or reverting
+// (up) the drift of the selected drifted resources, or of all of them
+func (m *Model) confirmDriftAction(op pulumi.OperationType) tea.Cmd {
+	// Only once the drift detection finished, so the drifted resources are known
+	if !m.state.DriftMode || m.state.OpState != OpComplete {
+		return nil
+	}
+	targets := m.ui.ResourceList.GetDriftedURNs()
+	if len(targets) == 0 {
+		return m.ui.Toast.Show(i18n.T("No drifted resources"))
+	}
+	action := &DriftAction{Op: op, Targets: targets}
+	// Protected stacks ask for the stack name instead
+	if m.requiresStackName(op) {
+		return m.startExecutionWithOptions(op, action.Options())
+	}
+	m.state.PendingDriftAction = action
+	m.ui.ConfirmModal.SetLabels(i18n.T("Cancel"), i18n.T("Execute"))
+	m.ui.ConfirmModal.SetKeys("n", "y")
+	if op == pulumi.OperationRefresh {
+		m.ui.ConfirmModal.Show(
+			i18n.T("Accept Drift"),
+			i18n.Tf("Refresh %d drifted resources, updating the state to match their live configuration?", len(targets)),
+			"",
+		)
+	} else {
+		m.ui.ConfirmModal.Show(
+			i18n.T("Revert Drift"),
+			i18n.Tf("Update %d drifted resources to match the program?", len(targets)),
+			i18n.T("This will apply changes to your infrastructure."),
+		)
+	}
 	m.showConfirmModal()
 	return nil
 }
 
+// nextQueueStep starts the next step of the operation queue, first asking the
+// user to confirm it if the step needs approval
+func (m *Model) nextQueueStep() tea.Cmd {
+	if m.state.OperationQueue.Step().Approve {
+		m.confirmQueueStep()
+		return nil
+	}
+	return m.runQueueStep()
+}
+
+// confirmQueueStep asks the user to confirm the next step of the operation queue
+func (m *Model) confirmQueueStep() {
+	q := m.state.OperationQueue
+	step := q.Step()
+	warning := ""
+	if step.Execute {
+		warning = i18n.T("This will apply changes to your infrastructure.")
+	}
+	title := i18n.Tf("Operation Queue (%d/%d)", q.Current+1, len(q.Steps))
+	if q.Name != "" {
+		title = i18n.Tf("Workflow %s (%d/%d)", q.Name, q.Current+1, len(q.Steps))
+	}
+	m.ui.ConfirmModal.SetLabels(i18n.T("Stop"), i18n.T("Continue"))
+	m.ui.ConfirmModal.SetKeys("n", "y")
+	m.ui.ConfirmModal.Show(
+		title,
+		i18n.Tf("Run %s next?\n\n%s", step.Label(), q.Summary()),
+		warning,
+	)
+	m.showConfirmModal()
+}
+
+// runQueueStep starts the next step of the operation queue
+func (m *Model) runQueueStep() tea.Cmd {
+	step := m.state.OperationQueue.Start()
+	if step.Execute {
+		return m.startExecution(step.Op)
+	}
+	return m.startPreview(step.Op)
+}
+
+// advanceQueue moves the operation queue past the step that just finished,
+// starting the next step or reporting that the queue is done
+func (m *Model) advanceQueue() tea.Cmd {
+	if m.state.OperationQueue.Advance() {
+		return m.nextQueueStep()
+	}
+	name := m.state.OperationQueue.Name
+	m.state.OperationQueue = nil
+	if name != "" {
+		return m.ui.Toast.Show(i18n.Tf("Workflow %s finished", name))
+	}
+	return m.ui.Toast.Show(i18n.T("Operation queue finished"))
+}
+
+// stopQueue drops the remaining steps of the operation queue
+func (m *Model) stopQueue(reason string) tea.Cmd {
+	m.state.OperationQueue = nil
+	return m.ui.Toast.Show(reason)
+}
+
+// operationOptions builds operation options from the selected flags, or from
+// the running queue step's own flags when it sets any
+func (m *Model) operationOptions() pulumi.OperationOptions {
+	if q := m.state.OperationQueue; q != nil && !q.Awaiting && q.Step().HasFlags() {
+		step := q.Step()
+		return pulumi.OperationOptions{
+			Targets:  step.Targets,
+			Replaces: step.Replaces,
+			Excludes: step.Excludes,
+			Refresh:  m.state.RefreshOnUp,
+
+			ContinueOnError: m.state.ContinueOnError,
+		}
+	}
+	return pulumi.OperationOptions{
+		Targets:  m.ui.ResourceList.GetTargetURNs(),
+		Replaces: m.ui.ResourceList.GetReplaceURNs(),
+		Excludes: m.ui.ResourceList.GetExcludeURNs(),
+		Refresh:  m.state.RefreshOnUp,
+
+		ContinueOnError: m.state.ContinueOnError,
+	}
+}
+
+// toggleRefreshOnUp switches whether up and its preview refresh the state first
+func (m *Model) toggleRefreshOnUp() tea.Cmd {
+	m.state.RefreshOnUp = !m.state.RefreshOnUp
+	m.ui.Header.SetRefresh(m.state.RefreshOnUp)
+	if m.state.RefreshOnUp {
+		return m.ui.Toast.Show(i18n.T("Up refreshes the state first"))
+	}
+	return m.ui.Toast.Show(i18n.T("Up no longer refreshes the state first"))
+}
+
+// toggleContinueOnError switches whether up and destroy carry on past failed resources
+func (m *Model) toggleContinueOnError() tea.Cmd {
+	m.state.ContinueOnError = !m.state.ContinueOnError
+	m.ui.Header.SetContinueOnError(m.state.ContinueOnError)
+	if m.state.ContinueOnError {
+		return m.ui.Toast.Show(i18n.T("Up and destroy continue past failed resources"))
+	}
+	return m.ui.Toast.Show(i18n.T("Up and destroy stop at the first failed resource"))
+}
+
 // startExecution starts an execution operation
 func (m *Model) startExecution(op pulumi.OperationType) tea.Cmd {
+	// Build options from flags
+	opts := m.operationOptions()
+	if op == pulumi.OperationUp {
+		opts.Plan = m.savedPlan()
+	}
+	return m.startExecutionWithOptions(op, opts)
+}
+
+// savedPlan returns the update plan saved by the up preview being shown, or ""
+func (m *Model) savedPlan() string {
+	m.dropChangedPlan()
+	if m.ui.ViewMode != ui.ViewPreview || m.state.Operation != pulumi.OperationUp || !m.state.PlanSaved {
+		return ""
+	}
+	return m.state.PlanPath
+}
+
+// dropChangedPlan forgets the saved plan once the flags changed since its preview,
+// as it no longer matches what up would do
+func (m *Model) dropChangedPlan() {
+	if m.state.PlanSaved && m.flagsChangedSincePreview() {
+		m.state.PlanSaved = false
+	}
+}
+
+// flagsChangedSincePreview returns whether the target, replace, exclude or refresh
+// flags differ from those the current preview ran with
+func (m *Model) flagsChangedSincePreview() bool {
+	opts := m.operationOptions()
+	return !sameURNs(opts.Targets, m.state.PlanFlags.Targets) ||
+		!sameURNs(opts.Replaces, m.state.PlanFlags.Replaces) ||
+		!sameURNs(opts.Excludes, m.state.PlanFlags.Excludes) ||
+		opts.Refresh != m.state.PlanFlags.Refresh
+}
+
+// sameURNs returns whether a and b hold the same URNs in any order
+func sameURNs(a, b []string) bool {
+	return slices.Equal(slices.Sorted(slices.Values(a)), slices.Sorted(slices.Values(b)))
+}
+
+// updateUpdateMessageModal handles keys when the update message prompt has focus
+func (m Model) updateUpdateMessageModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.UpdateMessageModal.Update(msg)
+	switch action {
+	case ui.StepModalActionConfirm:
+		pending := m.state.PendingExecution
+		m.state.PendingExecution = nil
+		m.hideUpdateMessageModal()
+		if pending == nil || m.state.OpState.IsActive() {
+			return m, nil
+		}
+		opts := pending.Opts
+		opts.Message = m.ui.UpdateMessageModal.Message()
+		return m, m.runExecution(pending.Op, opts)
+	case ui.StepModalActionCancel:
+		m.state.PendingExecution = nil
+		m.hideUpdateMessageModal()
+	}
+	return m, cmd
+}
+
+// updateGitGuardModal handles keys when the git guard warning has focus
+func (m Model) updateGitGuardModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.GitGuardModal.Update(msg)
+	switch action {
+	case ui.StepModalActionConfirm:
+		pending := m.state.PendingExecution
+		m.state.PendingExecution = nil
+		m.hideGitGuardModal()
+		if pending == nil || m.state.OpState.IsActive() {
+			return m, nil
+		}
+		return m, m.continueExecution(pending.Op, pending.Opts)
+	case ui.StepModalActionCancel:
+		m.state.PendingExecution = nil
+		m.hideGitGuardModal()
+		if m.state.OperationQueue != nil {
+			return m, m.stopQueue(i18n.T("Operation queue stopped: up was not confirmed"))
+		}
+	}
+	return m, cmd
+}
+
+// startExecutionWithOptions starts an execution operation with the given options
+func (m *Model) startExecutionWithOptions(op pulumi.OperationType, opts pulumi.OperationOptions) tea.Cmd {
+	// Don't ask for confirmations when another p5 is already operating on the stack
+	if held, _ := m.deps.StackLocker.Holder(m.ctx.WorkDir, m.ctx.StackName); held != nil {
+		return m.showStackBusy(op, held)
+	}
+	// Ask for the stack name to be typed before changing a highly protected stack
+	if m.requiresStackName(op) {
+		m.state.PendingExecution = &PendingExecution{Op: op, Opts: opts}
+		m.confirmProtectedExecution(op, opts)
+		return nil
+	}
+	return m.guardExecution(op, opts)
+}
+
+// requiresStackName returns whether op must be confirmed by typing the stack name
+func (m *Model) requiresStackName(op pulumi.OperationType) bool {
+	return m.state.Protection == plugins.ProtectionHigh &&
+		(op == pulumi.OperationUp || op == pulumi.OperationDestroy)
+}
+
+// confirmProtectedExecution asks for the stack name to be typed before op runs
+// on a highly protected stack
+func (m *Model) confirmProtectedExecution(op pulumi.OperationType, opts pulumi.OperationOptions) {
+	message := i18n.Tf("%s is a protected stack. Run %s on it?", m.ctx.StackName, op.String())
+	var radius []string
+	if op == pulumi.OperationDestroy && len(m.state.StackResources) > 0 {
+		var summary string
+		summary, radius = DescribeBlastRadius(m.state.StackResources, opts.Targets)
+		message += "\n\n" + summary
+	}
+	m.ui.ConfirmModal.SetLabels(i18n.T("Cancel"), i18n.T("Execute"))
+	m.ui.ConfirmModal.Show(
+		i18n.Tf("Execute %s", op.String()),
+		message,
+		i18n.T("This will apply changes to your infrastructure."),
+	)
+	m.ui.ConfirmModal.SetDetails(radius)
+	m.ui.ConfirmModal.RequirePhrase(m.ctx.StackName)
+	m.showConfirmModal()
+}
+
+// guardExecution starts an execution operation that was confirmed, first checking
+// the git checkout it deploys from
+func (m *Model) guardExecution(op pulumi.OperationType, opts pulumi.OperationOptions) tea.Cmd {
+	// Warn before deploying a guarded stack from a dirty or unexpected checkout
+	if op == pulumi.OperationUp {
+		if problems := GitGuardProblems(m.state.GitGuard, m.state.Git, m.ctx.StackName); len(problems) > 0 {
+			m.state.PendingExecution = &PendingExecution{Op: op, Opts: opts}
+			m.showGitGuardModal(problems)
+			return nil
+		}
+	}
+	return m.continueExecution(op, opts)
+}
+
+// continueExecution starts an execution operation that passed the git guard
+func (m *Model) continueExecution(op pulumi.OperationType, opts pulumi.OperationOptions) tea.Cmd {
+	// Ask what the update is for first, unless it's a step of a queue running unattended
+	if m.ctx.UpdateMessage && opts.Message == "" && m.state.OperationQueue == nil &&
+		(op == pulumi.OperationUp || op == pulumi.OperationDestroy) {
+		m.state.PendingExecution = &PendingExecution{Op: op, Opts: opts}
+		m.showUpdateMessageModal(op)
+		return nil
+	}
+	return m.runExecution(op, opts)
+}
+
+// runExecution starts an execution operation, once its update message is known
+func (m *Model) runExecution(op pulumi.OperationType, opts pulumi.OperationOptions) tea.Cmd {
+	// Lock the stack, unless another p5 took it while the operation was being confirmed
+	held, lockErr := m.deps.StackLocker.Acquire(m.ctx.WorkDir, m.ctx.StackName, op)
+	if held != nil {
+		return m.showStackBusy(op, held)
+	}
+	var lockWarning tea.Cmd
+	if lockErr != nil {
+		lockWarning = m.ui.Toast.Show(i18n.Tf("Failed to lock stack: %v", lockErr))
+	}
+
 	// Transition operation state
 	m.transitionOpTo(OpStarting)
 
@@ -201,17 +616,18 @@ func (m *Model) startExecution(op pulumi.OperationType) tea.Cmd {
 	// Clear the list and show events as they stream in
 	m.ui.ResourceList.Clear()
 	m.ui.ResourceList.SetShowAllOps(false)
-	m.ui.ResourceList.SetLoading(true, fmt.Sprintf("Executing %s...", op.String()))
-
-	// Build options from flags
-	opts := pulumi.OperationOptions{
-		Targets:  m.ui.ResourceList.GetTargetURNs(),
-		Replaces: m.ui.ResourceList.GetReplaceURNs(),
-		Excludes: m.ui.ResourceList.GetExcludeURNs(),
-	}
+	m.ui.ResourceList.SetLoading(true, i18n.Tf("Executing %s...", op.String()))
+	m.setDriftMode(false)
 
 	// Merge base env with plugin credentials
 	opts.Env = mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())
+	// Correlate the update with the commit it was run from in the stack's history
+	if opts.Message != "" && m.state.Git != nil {
+		opts.Message += " (" + m.state.Git.Ref() + ")"
+	}
+
+	m.state.CurrentRun = NewRunRecord(op, m.ctx.WorkDir, m.ctx.StackName, opts, time.Now())
+	m.setStackResult("in-progress")
 
 	// Create cancellable context as child of app context
 	m.operationCtx, m.operationCancel = context.WithCancel(m.appCtx)
@@ -230,7 +646,21 @@ func (m *Model) startExecution(op pulumi.OperationType) tea.Cmd {
 		m.operationCh = stackOperator.Destroy(m.operationCtx, workDir, stackName, opts)
 	}
 
-	return waitForOperationEvent(m.operationCh)
+	return tea.Batch(lockWarning, waitForOperationEvent(m.operationCh))
+}
+
+// showStackBusy explains that op can't run because another p5 process is
+// operating on the stack, stopping any running queue
+func (m *Model) showStackBusy(op pulumi.OperationType, held *pulumi.StackLock) tea.Cmd {
+	m.showErrorModal(
+		i18n.T("Stack Busy"),
+		i18n.Tf("Another p5 is running %s on %s, so %s can't run until it finishes.", held.Operation, m.ctx.StackName, op.String()),
+		i18n.Tf("Held by %s\nStarted %s ago\nLock file: %s", held.Owner(), ui.FormatDuration(time.Since(held.Started)), pulumi.LocksDir),
+	)
+	if m.state.OperationQueue != nil {
+		return m.stopQueue(i18n.Tf("Operation queue stopped: %s is busy", m.ctx.StackName))
+	}
+	return nil
 }
 
 // switchToStackView switches back to stack view
@@ -242,6 +672,7 @@ func (m *Model) switchToStackView() tea.Cmd {
 	m.ui.Header.SetViewMode(m.ui.ViewMode)
 	m.ui.Details.Hide() // Close details panel when view changes
 	m.ui.ResourceList.Clear()
+	m.setDriftMode(false)
 	m.ui.ResourceList.SetShowAllOps(true)
 	return m.loadStackResources()
 }
@@ -252,8 +683,11 @@ func (m *Model) switchToHistoryView() tea.Cmd {
 	m.ui.Header.SetViewMode(m.ui.ViewMode)
 	m.ui.Details.Hide() // Close resource details panel when switching views
 	m.ui.HistoryList.Clear()
-	m.ui.HistoryList.SetLoading(true, "Loading stack history...")
-	return m.fetchStackHistory()
+	m.ui.HistoryList.SetLoading(true, i18n.T("Loading stack history..."))
+	m.state.History = nil
+	m.state.HistoryPage = 0
+	m.state.HistoryChanges = nil
+	return m.fetchStackHistory(pulumi.DefaultHistoryPage)
 }
 
 // executeStateDelete runs the pulumi state delete command
@@ -289,10 +723,81 @@ func (m *Model) executeStateDelete() tea.Cmd {
 	}
 }
 
-// executeBulkStateDelete runs pulumi state delete for multiple resources
-// It processes each resource sequentially and reports partial failures
+// showStateDeleteConfirm asks to confirm removing the pending selection from state,
+// with the resources that depend on it when included
+func (m *Model) showStateDeleteConfirm() {
+	pending := m.state.PendingStateDelete
+	dependents := m.stateDeleteDependents(pending.Selected)
+	resources := pending.Selected
+	if pending.IncludeDependents {
+		resources = append(slices.Clone(resources), dependents...)
+	}
+
+	var note string
+	switch {
+	case len(dependents) == 0:
+	case pending.IncludeDependents:
+		note = "\n\n" + i18n.Tf("Includes %d resources that depend on the selection. Press d to leave them out.", len(dependents))
+	default:
+		note = "\n\n" + i18n.Tf("%d resources depend on the selection. Press d to remove them too.", len(dependents))
+	}
+
+	m.ui.ConfirmModal.SetLabels(i18n.T("Cancel"), i18n.T("Delete"))
+	if len(resources) == 1 {
+		// Single resource - use existing single-item flow
+		m.ui.ConfirmModal.ShowWithContext(
+			i18n.T("Delete from State"),
+			i18n.Tf("Remove '%s' from Pulumi state?\n\nType: %s", resources[0].Name, resources[0].Type)+note,
+			i18n.T("This will NOT delete the actual resource.\nThe resource will become unmanaged by Pulumi."),
+			resources[0].URN,
+			resources[0].Name,
+			resources[0].Type,
+		)
+		return
+	}
+	// Multiple resources - use bulk flow
+	m.ui.ConfirmModal.ShowBulkWithContext(
+		i18n.T("Delete from State"),
+		i18n.Tf("Remove %d resources from Pulumi state?", len(resources))+note,
+		i18n.T("This will NOT delete the actual resources.\nThey will become unmanaged by Pulumi."),
+		resources,
+	)
+	m.ui.ConfirmModal.SetDetails(StateDeleteLines(resources))
+	m.ui.ConfirmModal.ExpandDetails()
+}
+
+// stateDeleteDependents returns the resources in the last loaded state that depend
+// on the selection, transitively, and aren't selected themselves
+func (m *Model) stateDeleteDependents(selected []ui.SelectedResource) []ui.SelectedResource {
+	urns := make([]string, len(selected))
+	for i, res := range selected {
+		urns[i] = res.URN
+	}
+	var dependents []ui.SelectedResource
+	for _, r := range pulumi.BlastRadius(m.state.StackResources, urns) {
+		if !slices.Contains(urns, r.URN) && r.Type != "pulumi:pulumi:Stack" {
+			dependents = append(dependents, ui.SelectedResource{URN: r.URN, Name: r.Name, Type: r.Type})
+		}
+	}
+	return dependents
+}
+
+// executeBulkStateDelete removes the confirmed resources from state one at a time,
+// dependents before the resources they depend on, reporting progress after each
+// and partial failures at the end
 func (m *Model) executeBulkStateDelete() tea.Cmd {
-	resources := m.ui.ConfirmModal.GetBulkResources()
+	resources := OrderStateDeletes(m.state.StackResources, m.ui.ConfirmModal.GetBulkResources())
+	m.hideConfirmModal()
+	m.state.BulkStateDelete = &BulkStateDelete{Resources: resources}
+	return tea.Batch(
+		m.ui.Toast.Show(i18n.Tf("Removing from state (%d/%d): %s", 1, len(resources), resources[0].Name)),
+		m.deleteNextFromState(),
+	)
+}
+
+// deleteNextFromState removes the next resource of the running bulk state delete
+func (m *Model) deleteNextFromState() tea.Cmd {
+	res := m.state.BulkStateDelete.Resources[m.state.BulkStateDelete.Done]
 
 	// Build options with plugin env vars
 	opts := pulumi.StateDeleteOptions{}
@@ -306,78 +811,333 @@ func (m *Model) executeBulkStateDelete() tea.Cmd {
 	appCtx := m.appCtx
 
 	return func() tea.Msg {
-		var succeeded, failed int
-		var errors []string
-
-		for _, res := range resources {
-			result, err := resourceImporter.StateDelete(
-				appCtx,
-				workDir,
-				stackName,
-				res.URN,
-				opts,
-			)
-			if err != nil {
-				failed++
-				errors = append(errors, fmt.Sprintf("%s: %v", res.Name, err))
-				continue
-			}
-			if result.Success {
-				succeeded++
-			} else {
-				failed++
-				errMsg := "unknown error"
-				if result.Error != nil {
-					errMsg = result.Error.Error()
-				}
-				errors = append(errors, fmt.Sprintf("%s: %s", res.Name, errMsg))
+		result, err := resourceImporter.StateDelete(appCtx, workDir, stackName, res.URN, opts)
+		if err == nil && !result.Success {
+			err = result.Error
+			if err == nil {
+				err = errors.New("unknown error")
 			}
 		}
+		return stateDeleteStepMsg{URN: res.URN, Err: err}
+	}
+}
+
+// writeRunArtifacts writes the finished run's artifacts if the project enables them
+func (m *Model) writeRunArtifacts() tea.Cmd {
+	run := m.state.CurrentRun
+	m.state.CurrentRun = nil
+	if run == nil {
+		return nil
+	}
+	items := slices.Clone(m.ui.ResourceList.Items())
+
+	return func() tea.Msg {
+		cfg, err := plugins.LoadArtifactsConfig(run.WorkDir)
+		if err != nil {
+			return runArtifactsMsg{Err: err}
+		}
+		if cfg == nil || !cfg.Enabled {
+			return nil
+		}
+		dir, err := WriteRunArtifacts(cfg.ArtifactsDir(run.WorkDir), run, items)
+		if err != nil {
+			return runArtifactsMsg{Err: err}
+		}
+		if rel, err := filepath.Rel(run.WorkDir, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			dir = rel
+		}
+		return runArtifactsMsg{Dir: dir}
+	}
+}
+
+// runPostOperationHooks sends the finished run's summary to plugins with post-operation
+// hooks. Call it before writeRunArtifacts, which clears the run.
+func (m *Model) runPostOperationHooks(cancelled bool) tea.Cmd {
+	run := m.state.CurrentRun
+	if run == nil || !m.deps.PluginProvider.HasPostOperationHooks() {
+		return nil
+	}
+
+	result := plugins.OperationSucceeded
+	failed := run.Err != nil || slices.ContainsFunc(m.ui.ResourceList.Items(), func(item ui.ResourceItem) bool { return item.Status == ui.StatusFailed })
+	switch {
+	case cancelled:
+		result = plugins.OperationCancelled
+	case failed:
+		result = plugins.OperationFailed
+	}
+	counts := m.ui.ResourceList.Summary()
+	summary := &plugins.OperationSummary{
+		WorkDir:     run.WorkDir,
+		ProgramName: m.state.ProgramName,
+		StackName:   run.StackName,
+		Operation:   strings.ToLower(run.Operation.String()),
+		Result:      result,
+		Changes: map[string]int{
+			"create":  counts.Create,
+			"update":  counts.Update,
+			"delete":  counts.Delete,
+			"replace": counts.Replace,
+		},
+		Duration: run.Finished.Sub(run.Started),
+	}
+	if run.Err != nil {
+		summary.Error = run.Err.Error()
+	}
+	pluginProvider := m.deps.PluginProvider
+	appCtx := m.appCtx
+
+	return func() tea.Msg {
+		return postOperationHooksMsg{Results: pluginProvider.RunPostOperationHooks(appCtx, summary)}
+	}
+}
+
+// loadSavedFlags loads the flags saved for the current stack if the project enables saving them
+func (m *Model) loadSavedFlags() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+
+	return func() tea.Msg {
+		msg := savedFlagsMsg{WorkDir: workDir, StackName: stackName}
+		persist, err := plugins.LoadPersistFlags(workDir)
+		if err != nil || !persist {
+			msg.Err = err
+			return msg
+		}
+		msg.Persist = true
+		msg.Flags, msg.Err = LoadSavedFlags(workDir, stackName)
+		return msg
+	}
+}
+
+// loadStackProtection loads the protection level, confirmation modes and
+// auto-approval configured for the current stack
+func (m *Model) loadStackProtection() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
 
-		return bulkStateDeleteResultMsg{
-			Succeeded: succeeded,
-			Failed:    failed,
-			Errors:    errors,
+	return func() tea.Msg {
+		msg := stackProtectionMsg{WorkDir: workDir, StackName: stackName}
+		msg.Level, msg.Err = plugins.LoadStackProtection(workDir, stackName)
+		if msg.Err == nil {
+			msg.Confirm, msg.Err = plugins.LoadStackConfirm(workDir, stackName)
+		}
+		if msg.Err == nil {
+			msg.AutoApprove, msg.Err = plugins.LoadAutoApprove(workDir, stackName)
 		}
+		return msg
+	}
+}
+
+// loadKeyBindings loads the keybindings configured for the current workspace
+func (m *Model) loadKeyBindings() tea.Cmd {
+	workDir := m.ctx.WorkDir
+
+	return func() tea.Msg {
+		bindings, err := plugins.LoadKeyBindings(workDir)
+		return keyBindingsMsg{WorkDir: workDir, Bindings: bindings, Err: err}
+	}
+}
+
+// saveFlags saves the current stack's flags, reporting back only on failure
+func (m *Model) saveFlags() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	flags := FlagsWithPrefix(m.state.Flags, StackURNPrefix(m.state.StackURN, stackName))
+
+	return func() tea.Msg {
+		if err := SaveFlags(workDir, stackName, flags); err != nil {
+			return flagsSavedMsg{StackName: stackName, Err: err}
+		}
+		return nil
+	}
+}
+
+// clearSavedFlags removes the flags saved for the current stack
+func (m *Model) clearSavedFlags() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+
+	return func() tea.Msg {
+		err := SaveFlags(workDir, stackName, nil)
+		return flagsSavedMsg{StackName: stackName, Cleared: true, Err: err}
+	}
+}
+
+// requestUpdateCancel asks the backend to cancel the running update, like `pulumi cancel`
+func (m *Model) requestUpdateCancel() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	stackOperator := m.deps.StackOperator
+	appCtx := m.appCtx
+	opts := pulumi.OperationOptions{Env: mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())}
+
+	return func() tea.Msg {
+		return updateCancelMsg{Err: stackOperator.Cancel(appCtx, workDir, stackName, opts)}
+	}
+}
+
+// checkPendingOperations looks for operations a cancelled update left in state
+func (m *Model) checkPendingOperations() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	stackReader := m.deps.StackReader
+	appCtx := m.appCtx
+	opts := pulumi.ReadOptions{Env: mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())}
+
+	return func() tea.Msg {
+		ops, err := stackReader.GetPendingOperations(appCtx, workDir, stackName, opts)
+		return pendingOperationsMsg{Ops: ops, Err: err}
+	}
+}
+
+// recoverStack cancels the stack's in-progress update and clears its pending operations
+func (m *Model) recoverStack() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	stateManager := m.deps.StackStateManager
+	appCtx := m.appCtx
+	opts := pulumi.CancelPendingOptions{Env: mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())}
+
+	return func() tea.Msg {
+		result, err := stateManager.CancelPending(appCtx, workDir, stackName, opts)
+		return stackRecoveredMsg{Result: result, Err: err}
+	}
+}
+
+// clearPendingOperations clears the pending operations a cancelled update left in
+// state, leaving any update that took the lock since alone
+func (m *Model) clearPendingOperations() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	stateManager := m.deps.StackStateManager
+	appCtx := m.appCtx
+	opts := pulumi.CancelPendingOptions{Env: mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())}
+
+	return func() tea.Msg {
+		result, err := stateManager.ClearPendingOperations(appCtx, workDir, stackName, opts)
+		return stackRecoveredMsg{Result: result, Err: err}
+	}
+}
+
+// awaitDetailsResizeEnd saves the details panel width once no resize key was
+// pressed for detailsResizeDelay, rather than on every press
+func (m *Model) awaitDetailsResizeEnd() tea.Cmd {
+	m.state.DetailsResizeSeq++
+	seq := m.state.DetailsResizeSeq
+	return tea.Tick(detailsResizeDelay, func(time.Time) tea.Msg {
+		return detailsResizeEndedMsg{Seq: seq}
+	})
+}
+
+// saveDetailsWidth saves the details panel width to the user's preferences
+func (m *Model) saveDetailsWidth() tea.Cmd {
+	width := m.ui.DetailsWidth
+
+	return func() tea.Msg {
+		return detailsWidthSavedMsg{Err: plugins.SaveDetailsWidth(width)}
+	}
+}
+
+// loadNotes loads the resource notes saved in the project
+func (m *Model) loadNotes() tea.Cmd {
+	workDir := m.ctx.WorkDir
+
+	return func() tea.Msg {
+		notes, err := LoadNotes(workDir)
+		return notesMsg{WorkDir: workDir, Notes: notes, Err: err}
+	}
+}
+
+// saveNote saves the note of a resource, removing it when note is empty
+func (m *Model) saveNote(urn, note string) tea.Cmd {
+	workDir := m.ctx.WorkDir
+
+	return func() tea.Msg {
+		err := SaveNote(workDir, urn, note)
+		return noteSavedMsg{URN: urn, Note: note, Err: err}
+	}
+}
+
+// loadDiffIgnore loads the property paths the project de-emphasizes in diffs
+func (m *Model) loadDiffIgnore() tea.Cmd {
+	workDir := m.ctx.WorkDir
+
+	return func() tea.Msg {
+		rules, err := plugins.LoadDiffIgnore(workDir)
+		return diffIgnoreMsg{WorkDir: workDir, Rules: rules, Err: err}
+	}
+}
+
+// loadPinned loads the resources pinned in the project
+func (m *Model) loadPinned() tea.Cmd {
+	workDir := m.ctx.WorkDir
+
+	return func() tea.Msg {
+		pinned, err := LoadPinned(workDir)
+		return pinnedMsg{WorkDir: workDir, Pinned: pinned, Err: err}
+	}
+}
+
+// savePinned saves the resources pinned in the project
+func (m *Model) savePinned() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	pinned := maps.Clone(m.state.Pinned)
+
+	return func() tea.Msg {
+		return pinnedSavedMsg{Err: SavePinned(workDir, pinned)}
+	}
+}
+
+// executeStateRepair applies the fixes chosen in the state repair modal, or only
+// reports their effect when dryRun is set
+func (m *Model) executeStateRepair(dryRun bool) tea.Cmd {
+	fixes := m.ui.StateRepairModal.Fixes()
+
+	// Build options with plugin env vars
+	opts := pulumi.StateRepairOptions{DryRun: dryRun}
+	if m.deps != nil && m.deps.PluginProvider != nil {
+		opts.Env = m.deps.PluginProvider.GetAllEnv()
+	}
+
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	resourceImporter := m.deps.ResourceImporter
+	appCtx := m.appCtx
+
+	return func() tea.Msg {
+		report, err := resourceImporter.RepairState(appCtx, workDir, stackName, fixes, opts)
+		return stateRepairResultMsg{Report: report, DryRun: dryRun, Err: err}
 	}
 }
 
-// executeProtect runs the pulumi state protect or unprotect command
-func (m *Model) executeProtect(urn, name string, protect bool) tea.Cmd {
+// executeProtect runs the pulumi state protect or unprotect command for all resources at once
+func (m *Model) executeProtect(resources []ui.SelectedResource, protect bool) tea.Cmd {
 	// Build options with plugin env vars
 	opts := pulumi.StateProtectOptions{}
 	if m.deps != nil && m.deps.PluginProvider != nil {
 		opts.Env = m.deps.PluginProvider.GetAllEnv()
 	}
 
+	urns := make([]string, len(resources))
+	for i, res := range resources {
+		urns[i] = res.URN
+	}
+
 	workDir := m.ctx.WorkDir
 	stackName := m.ctx.StackName
-	resourceImporter := m.deps.ResourceImporter
+	stateEditor := m.deps.ResourceImporter
 	appCtx := m.appCtx
 
 	return func() tea.Msg {
-		var result *pulumi.CommandResult
-		var err error
-
-		if protect {
-			result, err = resourceImporter.Protect(appCtx, workDir, stackName, urn, opts)
-		} else {
-			result, err = resourceImporter.Unprotect(appCtx, workDir, stackName, urn, opts)
-		}
-
+		result, err := stateEditor.SetProtect(appCtx, workDir, stackName, urns, protect, opts)
 		if err != nil {
-			return protectResultMsg{
-				Result:    &pulumi.CommandResult{Success: false, Error: err},
-				Protected: protect,
-				URN:       urn,
-				Name:      name,
-			}
+			result = &pulumi.CommandResult{Success: false, Error: err}
 		}
 		return protectResultMsg{
 			Result:    result,
 			Protected: protect,
-			URN:       urn,
-			Name:      name,
+			Resources: resources,
 		}
 	}
 }
@@ -421,19 +1181,162 @@ func (m *Model) executeImport() tea.Cmd {
 	}
 }
 
-// fetchStackHistory returns a command to load the stack history
-func (m *Model) fetchStackHistory() tea.Cmd {
+// previewImport runs a preview-only import of the typed ID, so the modal can show
+// the state that would be adopted before the import is confirmed
+func (m *Model) previewImport() tea.Cmd {
+	spec := pulumi.ImportSpec{
+		Type:      m.ui.ImportModal.GetResourceType(),
+		Name:      m.ui.ImportModal.GetResourceName(),
+		ID:        m.ui.ImportModal.GetImportID(),
+		ParentURN: m.ui.ImportModal.GetParentURN(),
+	}
+
+	opts := pulumi.ImportOptions{}
+	if m.deps != nil && m.deps.PluginProvider != nil {
+		opts.Env = m.deps.PluginProvider.GetAllEnv()
+	}
+
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	resourceImporter := m.deps.ResourceImporter
+	appCtx := m.appCtx
+
+	return func() tea.Msg {
+		preview, err := resourceImporter.PreviewImport(appCtx, workDir, stackName, spec, opts)
+		return importPreviewMsg{ImportID: spec.ID, Preview: preview, Err: err}
+	}
+}
+
+// executeBulkImport imports the selected bulk import resources in one pulumi import operation
+func (m *Model) executeBulkImport() tea.Cmd {
+	specs := BuildImportSpecs(m.ui.BulkImportModal.GetResourceType(), m.ui.BulkImportModal.SelectedItems())
+
+	// Build import options with plugin env vars
+	opts := pulumi.ImportOptions{}
+	if m.deps != nil && m.deps.PluginProvider != nil {
+		opts.Env = m.deps.PluginProvider.GetAllEnv()
+	}
+
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	resourceImporter := m.deps.ResourceImporter
+	appCtx := m.appCtx
+
+	return func() tea.Msg {
+		result, err := resourceImporter.ImportBatch(appCtx, workDir, stackName, specs, opts)
+		if err != nil {
+			result = &pulumi.CommandResult{Success: false, Error: err}
+		}
+		return bulkImportResultMsg{Count: len(specs), Result: result}
+	}
+}
+
+// fetchStackHistory returns a command to load a page of the stack history
+func (m *Model) fetchStackHistory(page int) tea.Cmd {
 	workDir := m.ctx.WorkDir
 	stackName := m.ctx.StackName
 	stackReader := m.deps.StackReader
 	appCtx := m.appCtx
+	pageSize := m.historyPageSize()
 	opts := pulumi.ReadOptions{Env: m.deps.Env}
 	return func() tea.Msg {
-		history, err := stackReader.GetHistory(appCtx, workDir, stackName, pulumi.DefaultHistoryPageSize, pulumi.DefaultHistoryPage, opts)
-		if err != nil {
+		history, err := stackReader.GetHistory(appCtx, workDir, stackName, pageSize, page, opts)
+		if err != nil && page == pulumi.DefaultHistoryPage {
 			return errMsg(err)
 		}
-		return stackHistoryMsg(history)
+		return stackHistoryMsg{Page: page, History: history, Err: err}
+	}
+}
+
+// loadMoreHistory returns a command to load the next page of the stack history
+// once the history list is scrolled near its end, or nil
+func (m *Model) loadMoreHistory() tea.Cmd {
+	if m.ui.ViewMode != ui.ViewHistory || !m.ui.HistoryList.NeedsMore() {
+		return nil
+	}
+	m.ui.HistoryList.SetLoadingMore(true)
+	return m.fetchStackHistory(m.state.HistoryPage + 1)
+}
+
+// loadHistoryChanges returns a command to load the resources changed by the update
+// shown in the history details panel, or nil when they are already known
+func (m *Model) loadHistoryChanges() tea.Cmd {
+	if m.ui.ViewMode != ui.ViewHistory || !m.ui.Focus.Has(ui.FocusDetailsPanel) {
+		return nil
+	}
+	version, ok := m.ui.HistoryDetails.NeedsChanges()
+	if !ok {
+		return nil
+	}
+	if items, ok := m.state.HistoryChanges[version]; ok {
+		m.ui.HistoryDetails.SetChanges(version, items)
+		return nil
+	}
+	m.ui.HistoryDetails.SetChangesLoading(version)
+	return m.fetchHistoryDiff(version)
+}
+
+// historyPageSize returns how many updates the history view loads at a time
+func (m *Model) historyPageSize() int {
+	if m.ctx.HistoryPageSize > 0 {
+		return m.ctx.HistoryPageSize
+	}
+	return pulumi.DefaultHistoryPageSize
+}
+
+// fetchHistoryDiff returns a command to load the deployment snapshots for an update
+// and the update before it
+func (m *Model) fetchHistoryDiff(version int) tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	stackReader := m.deps.StackReader
+	appCtx := m.appCtx
+	opts := pulumi.ReadOptions{Env: m.deps.Env}
+	return func() tea.Msg {
+		after, err := stackReader.ExportDeploymentAtVersion(appCtx, workDir, stackName, version, opts)
+		if err != nil {
+			return historyDiffErrMsg{Version: version, Error: err}
+		}
+		before, err := stackReader.ExportDeploymentAtVersion(appCtx, workDir, stackName, version-1, opts)
+		if err != nil {
+			return historyDiffErrMsg{Version: version, Error: err}
+		}
+		return historyDiffMsg{Version: version, Before: before, After: after}
+	}
+}
+
+// fetchStackEnvironments returns a command to resolve the ESC environments imported
+// by the current stack. Previously resolved environment variables are cleared from
+// the plugin provider so they don't leak across stacks.
+func (m *Model) fetchStackEnvironments() tea.Cmd {
+	m.ui.Environments.SetLoading()
+	if m.deps.PluginProvider != nil {
+		m.deps.PluginProvider.SetEnvironmentEnv(nil)
+	}
+	if m.deps.EnvironmentReader == nil {
+		m.ui.Environments.SetEnvironments(nil)
+		return nil
+	}
+
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	envReader := m.deps.EnvironmentReader
+	appCtx := m.appCtx
+	opts := pulumi.ReadOptions{Env: m.deps.Env}
+	return func() tea.Msg {
+		names, err := envReader.ListEnvironments(workDir, stackName)
+		if err != nil {
+			return stackEnvironmentsErrMsg{StackName: stackName, Error: err}
+		}
+		envs := make([]*pulumi.StackEnvironment, 0, len(names))
+		for _, name := range names {
+			env, err := envReader.OpenEnvironment(appCtx, workDir, name, opts)
+			if err != nil {
+				return stackEnvironmentsErrMsg{StackName: stackName, Error: err}
+			}
+			envs = append(envs, env)
+		}
+		return stackEnvironmentsMsg{StackName: stackName, Environments: envs}
 	}
 }
 
@@ -488,18 +1391,88 @@ func (m *Model) fetchImportSuggestions(resourceType, resourceName, resourceURN,
 
 		suggestions, err := pluginProvider.GetImportSuggestions(appCtx, req)
 		if err != nil {
-			return importSuggestionsErrMsg(err)
+			return importSuggestionsErrMsg{Err: err}
 		}
 		return importSuggestionsMsg(suggestions)
 	}
 }
 
+// scheduleImportIDValidation forgets the verdict on the previous import ID and asks
+// plugins to check the new one once typing pauses
+func (m *Model) scheduleImportIDValidation() tea.Cmd {
+	m.state.ImportValidationSeq++
+	m.ui.ImportModal.ClearValidation()
+	if m.ui.ImportModal.GetImportID() == "" || m.deps == nil || m.deps.PluginProvider == nil ||
+		!m.deps.PluginProvider.HasImportHelpers() {
+		return nil
+	}
+	seq := m.state.ImportValidationSeq
+	return tea.Tick(ImportValidationDelay, func(time.Time) tea.Msg {
+		return importValidateTickMsg{Seq: seq}
+	})
+}
+
+// validateImportID asks plugins to check the import ID typed in the import modal
+func (m *Model) validateImportID() tea.Cmd {
+	req := &plugins.ValidateImportIdRequest{
+		ResourceType: m.ui.ImportModal.GetResourceType(),
+		ResourceName: m.ui.ImportModal.GetResourceName(),
+		ResourceUrn:  m.ui.ImportModal.GetResourceURN(),
+		ImportId:     m.ui.ImportModal.GetImportID(),
+	}
+	for _, item := range m.ui.ResourceList.Items() {
+		if item.URN == req.ResourceUrn {
+			req.Inputs = stringifyValues(item.Inputs)
+			req.ProviderUrn = item.Provider
+			req.ProviderInputs = stringifyValues(item.ProviderInputs)
+			break
+		}
+	}
+
+	seq := m.state.ImportValidationSeq
+	appCtx := m.appCtx
+	pluginProvider := m.deps.PluginProvider
+	return func() tea.Msg {
+		result, err := pluginProvider.ValidateImportID(appCtx, req)
+		return importIDValidatedMsg{Seq: seq, Result: result, Err: err}
+	}
+}
+
+// fetchImportableResources queries plugins for existing resources of item's type.
+// A nil pageTokens fetches the first page.
+func (m *Model) fetchImportableResources(item ui.ResourceItem, pageTokens map[string]string) tea.Cmd {
+	if m.deps == nil || m.deps.PluginProvider == nil {
+		return func() tea.Msg {
+			return importableResourcesMsg(&plugins.ImportableResourcesPage{})
+		}
+	}
+
+	req := &plugins.ListImportableResourcesRequest{
+		ResourceType:   item.Type,
+		Inputs:         stringifyValues(item.Inputs),
+		ProviderUrn:    item.Provider,
+		ProviderInputs: stringifyValues(item.ProviderInputs),
+		PageSize:       BulkImportPageSize,
+	}
+
+	appCtx := m.appCtx
+	pluginProvider := m.deps.PluginProvider
+	return func() tea.Msg {
+		page, err := pluginProvider.ListImportableResources(appCtx, req, pageTokens)
+		if err != nil {
+			return importableResourcesErrMsg{Err: err}
+		}
+		return importableResourcesMsg(page)
+	}
+}
+
 // authenticatePluginsWithLock sets the busy lock, queues an operation, and runs auth.
 // When auth completes (success or error), the lock is released and pending ops execute.
 func (m *Model) authenticatePluginsWithLock(pendingOp PendingOperation) tea.Cmd {
 	// Set busy lock before starting auth
 	m.state.SetBusy("auth")
 	m.state.QueueOperation(pendingOp)
+	m.clearStatusBadges()
 
 	if m.deps == nil || m.deps.PluginProvider == nil {
 		// No plugin provider - return completion immediately to release lock
@@ -570,6 +1543,23 @@ func (m *Model) fetchProjectInfo() tea.Cmd {
 	}
 }
 
+// fetchGitInfo returns a command to read the workspace's git checkout and the
+// guard checking it before up
+func (m *Model) fetchGitInfo() tea.Cmd {
+	if m.deps.GitReader == nil {
+		return nil
+	}
+	workDir := m.ctx.WorkDir
+	gitReader := m.deps.GitReader
+	appCtx := m.appCtx
+	return func() tea.Msg {
+		msg := gitInfoMsg{WorkDir: workDir}
+		msg.Info, msg.Err = gitReader.ReadGitInfo(appCtx, workDir)
+		msg.Guard, msg.GuardErr = plugins.LoadGitGuard(workDir)
+		return msg
+	}
+}
+
 // fetchStacksList returns a command to load the list of available stacks from both backend and config files
 func (m *Model) fetchStacksList() tea.Cmd {
 	workDir := m.ctx.WorkDir
@@ -591,6 +1581,54 @@ func (m *Model) fetchStacksList() tea.Cmd {
 	}
 }
 
+// fetchInitData returns a command that loads what init needs to pick a stack: the
+// stack list, stack files, backend info and project info. Each read goes through
+// Pulumi, so they run concurrently; only a project info failure is fatal.
+func (m *Model) fetchInitData() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	stackReader := m.deps.StackReader
+	workspaceReader := m.deps.WorkspaceReader
+	appCtx := m.appCtx
+	opts := pulumi.ReadOptions{Env: m.deps.Env}
+	return func() tea.Msg {
+		msg := initDataMsg{WhoAmI: &pulumi.WhoAmIInfo{}}
+		var whoAmIErr error
+		g, ctx := errgroup.WithContext(appCtx)
+		g.Go(func() error {
+			// Non-fatal - we can still show file-based stacks
+			msg.Stacks, _ = stackReader.GetStacks(ctx, workDir, opts)
+			return nil
+		})
+		g.Go(func() error {
+			msg.Files, _ = workspaceReader.ListStackFiles(workDir)
+			return nil
+		})
+		g.Go(func() error {
+			info, err := workspaceReader.GetWhoAmI(ctx, workDir, opts)
+			if err == nil && info != nil {
+				msg.WhoAmI = info
+			}
+			whoAmIErr = err
+			return nil
+		})
+		g.Go(func() error {
+			info, err := workspaceReader.GetProjectInfo(ctx, workDir, stackName, opts)
+			msg.ProjectInfo = info
+			return err
+		})
+		err := g.Wait()
+		// Without a backend login everything else fails too, so ask for one
+		if pulumi.IsLoginRequiredError(whoAmIErr) {
+			return errMsg(whoAmIErr)
+		}
+		if err != nil {
+			return errMsg(err)
+		}
+		return msg
+	}
+}
+
 // selectStack returns a command that triggers stack selection.
 // This does NOT call Pulumi's SelectStack API because:
 // 1. Plugin auth needs to happen first to get correct env vars
@@ -606,13 +1644,54 @@ func (m *Model) selectStack(name string) tea.Cmd {
 func (m *Model) fetchWorkspacesList() tea.Cmd {
 	cwd := m.ctx.Cwd
 	workDir := m.ctx.WorkDir
+	search := m.ctx.WorkspaceSearch
 	workspaceReader := m.deps.WorkspaceReader
 	return func() tea.Msg {
-		workspaces, err := workspaceReader.FindWorkspaces(cwd, workDir)
+		workspaces, err := workspaceReader.FindWorkspaces(cwd, workDir, search)
 		if err != nil {
 			return errMsg(err)
 		}
-		return workspacesListMsg(workspaces)
+		// Recent workspaces are a convenience, so the discovered ones are listed
+		// even when they can't be loaded
+		recent, _ := loadRecentWorkspaces(workspaceReader)
+		return workspacesListMsg{Workspaces: workspaces, Recent: recent}
+	}
+}
+
+// loadRecentWorkspaces loads the recent workspaces, skipping moved or deleted ones
+func loadRecentWorkspaces(workspaceReader pulumi.WorkspaceReader) ([]plugins.RecentWorkspace, error) {
+	recent, err := plugins.LoadRecentWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(recent, func(r plugins.RecentWorkspace) bool {
+		return !workspaceReader.IsWorkspace(r.Path)
+	}), nil
+}
+
+// pinWorkspace returns a command to pin or unpin a workspace in the recent workspaces
+func (m *Model) pinWorkspace(path string, pinned bool) tea.Cmd {
+	workspaceReader := m.deps.WorkspaceReader
+	return func() tea.Msg {
+		if err := plugins.SetWorkspacePinned(path, pinned); err != nil {
+			return workspacePinnedMsg{Path: path, Pinned: pinned, Err: err}
+		}
+		recent, err := loadRecentWorkspaces(workspaceReader)
+		return workspacePinnedMsg{Path: path, Pinned: pinned, Recent: recent, Err: err}
+	}
+}
+
+// recordRecentWorkspace returns a command to remember the workspace and stack as
+// recently opened. Failing to save them is logged, as it doesn't affect the stack.
+func (m *Model) recordRecentWorkspace() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	logger := m.deps.Logger
+	return func() tea.Msg {
+		if err := plugins.RecordRecentWorkspace(workDir, stackName, time.Now()); err != nil {
+			logger.Warn("failed to save recent workspace", "workDir", workDir, "error", err)
+		}
+		return nil
 	}
 }
 
@@ -623,6 +1702,103 @@ func selectWorkspace(path string) tea.Cmd {
 	}
 }
 
+// dashboardConcurrency bounds the number of concurrent Pulumi reads while loading the dashboard
+const dashboardConcurrency = 4
+
+// fetchDashboard returns a command that loads every stack of the current workspace,
+// or of all workspaces under the launch directory when not inside one
+func (m *Model) fetchDashboard() tea.Cmd {
+	m.ui.Dashboard.SetLoading(true, "")
+	cwd := m.ctx.Cwd
+	workDir := m.ctx.WorkDir
+	search := m.ctx.WorkspaceSearch
+	workspaceReader := m.deps.WorkspaceReader
+	stackReader := m.deps.StackReader
+	appCtx := m.appCtx
+	opts := pulumi.ReadOptions{Env: m.deps.Env}
+	return func() tea.Msg {
+		startDir := cwd
+		if workspaceReader.IsWorkspace(workDir) {
+			startDir = workDir
+		}
+		workspaces, err := workspaceReader.FindWorkspaces(startDir, workDir, search)
+		if err != nil {
+			return dashboardErrMsg{Err: err}
+		}
+		return dashboardMsg(loadDashboardRows(appCtx, stackReader, workspaces, cwd, opts))
+	}
+}
+
+// loadDashboardRows lists the stacks of each workspace and then reads each stack's
+// history and resources, running at most dashboardConcurrency reads at once.
+// Rows keep workspace and stack order; failures are reported on the row.
+func loadDashboardRows(ctx context.Context, reader pulumi.StackReader, workspaces []pulumi.WorkspaceInfo, cwd string, opts pulumi.ReadOptions) []ui.DashboardRow {
+	sem := make(chan struct{}, dashboardConcurrency)
+	var wg sync.WaitGroup
+
+	stacks := make([][]pulumi.StackInfo, len(workspaces))
+	stackErrs := make([]error, len(workspaces))
+	for i, ws := range workspaces {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			stacks[i], stackErrs[i] = reader.GetStacks(ctx, ws.Path, opts)
+		})
+	}
+	wg.Wait()
+
+	var rows []ui.DashboardRow
+	var targets []DashboardStack
+	var targetRows []int
+	for i, ws := range workspaces {
+		if stackErrs[i] != nil {
+			row := NewDashboardRow(DashboardStack{Workspace: ws}, cwd, nil, 0)
+			row.Err = stackErrs[i]
+			rows = append(rows, row)
+			continue
+		}
+		for _, stack := range stacks[i] {
+			targets = append(targets, DashboardStack{Workspace: ws, Stack: stack})
+			targetRows = append(targetRows, len(rows))
+			rows = append(rows, ui.DashboardRow{})
+		}
+	}
+
+	for i, target := range targets {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			rows[targetRows[i]] = loadDashboardRow(ctx, reader, target, cwd, opts)
+		})
+	}
+	wg.Wait()
+
+	return rows
+}
+
+// loadDashboardRow reads a single stack's history and resources
+func loadDashboardRow(ctx context.Context, reader pulumi.StackReader, target DashboardStack, cwd string, opts pulumi.ReadOptions) ui.DashboardRow {
+	workDir, stackName := target.Workspace.Path, target.Stack.Name
+	history, err := reader.GetHistory(ctx, workDir, stackName, DashboardHistoryPageSize, pulumi.DefaultHistoryPage, opts)
+	if err == nil {
+		var resources []pulumi.ResourceInfo
+		resources, err = reader.GetResources(ctx, workDir, stackName, opts)
+		if err == nil {
+			return NewDashboardRow(target, cwd, history, len(resources))
+		}
+	}
+	row := NewDashboardRow(target, cwd, nil, 0)
+	row.Err = err
+	return row
+}
+
+// selectDashboardStack opens a stack chosen on the dashboard
+func selectDashboardStack(workDir, stackName string) tea.Cmd {
+	return func() tea.Msg {
+		return dashboardStackSelectedMsg{WorkDir: workDir, StackName: stackName}
+	}
+}
+
 // fetchWhoAmI returns a command to get backend connection info
 func (m *Model) fetchWhoAmI() tea.Cmd {
 	workDir := m.ctx.WorkDir
@@ -654,7 +1830,7 @@ func (m *Model) fetchStackFiles() tea.Cmd {
 }
 
 // initStack creates a new stack
-func (m *Model) initStack(name, secretsProvider, passphrase string) tea.Cmd {
+func (m *Model) initStack(name string, opts pulumi.InitStackOptions) tea.Cmd {
 	workDir := m.ctx.WorkDir
 	stackInitializer := m.deps.StackInitializer
 	appCtx := m.appCtx
@@ -663,20 +1839,30 @@ func (m *Model) initStack(name, secretsProvider, passphrase string) tea.Cmd {
 	if m.deps != nil && m.deps.PluginProvider != nil {
 		pluginEnv = m.deps.PluginProvider.GetAllEnv()
 	}
-	env := mergeEnvMaps(m.deps.Env, pluginEnv)
+	opts.Env = mergeEnvMaps(m.deps.Env, pluginEnv)
 	return func() tea.Msg {
-		opts := pulumi.InitStackOptions{
-			SecretsProvider: secretsProvider,
-			Passphrase:      passphrase,
-			Env:             env,
-		}
+		copied, err := stackInitializer.InitStack(appCtx, workDir, name, opts)
+		return stackInitResultMsg{StackName: name, Copied: copied, Error: err}
+	}
+}
 
-		err := stackInitializer.InitStack(appCtx, workDir, name, opts)
-		if err != nil {
-			return stackInitResultMsg{StackName: name, Error: err}
-		}
-		return stackInitResultMsg{StackName: name, Error: nil}
+// newProject returns a command scaffolding a project and its stack from a template
+func (m *Model) newProject(dir string, opts pulumi.NewProjectOptions) tea.Cmd {
+	m.state.CreatingProject = true
+	stackInitializer := m.deps.StackInitializer
+	appCtx := m.appCtx
+	var pluginEnv map[string]string
+	if m.deps != nil && m.deps.PluginProvider != nil {
+		pluginEnv = m.deps.PluginProvider.GetAllEnv()
 	}
+	opts.Env = mergeEnvMaps(m.deps.Env, pluginEnv)
+	return tea.Batch(
+		m.ui.Toast.Show(i18n.Tf("Creating project from %s...", opts.Template)),
+		func() tea.Msg {
+			err := stackInitializer.NewProject(appCtx, dir, opts)
+			return newProjectResultMsg{Dir: dir, Name: opts.Name, Err: err}
+		},
+	)
 }
 
 // fetchOpenResourceAction queries plugins for an action to open the resource
@@ -687,77 +1873,63 @@ func (m *Model) fetchOpenResourceAction(resourceType, resourceName, resourceURN,
 		}
 	}
 
-	// Convert inputs to string map for proto
-	inputStrings := make(map[string]string)
-	for k, v := range inputs {
-		switch val := v.(type) {
-		case string:
-			inputStrings[k] = val
-		default:
-			if b, err := json.Marshal(val); err == nil {
-				inputStrings[k] = string(b)
-			}
+	req := buildOpenResourceRequest(resourceType, resourceName, resourceURN, providerURN, inputs, outputs, providerInputs)
+	appCtx := m.appCtx
+	pluginProvider := m.deps.PluginProvider
+	return func() tea.Msg {
+		resp, pluginName, err := pluginProvider.OpenResource(appCtx, req)
+		if err != nil {
+			return openResourceErrMsg{Err: err}
 		}
+		return openResourceActionMsg{Response: resp, PluginName: pluginName}
 	}
+}
 
-	// Convert outputs to string map for proto
-	outputStrings := make(map[string]string)
-	for k, v := range outputs {
-		switch val := v.(type) {
-		case string:
-			outputStrings[k] = val
-		default:
-			if b, err := json.Marshal(val); err == nil {
-				outputStrings[k] = string(b)
-			}
-		}
+// buildOpenResourceRequest builds a plugin open request, encoding non-string values as JSON
+func buildOpenResourceRequest(resourceType, resourceName, resourceURN, providerURN string, inputs, outputs, providerInputs map[string]any) *plugins.OpenResourceRequest {
+	return &plugins.OpenResourceRequest{
+		ResourceType:   resourceType,
+		ResourceName:   resourceName,
+		ResourceUrn:    resourceURN,
+		ProviderUrn:    providerURN,
+		ProviderInputs: stringifyValues(providerInputs),
+		Inputs:         stringifyValues(inputs),
+		Outputs:        stringifyValues(outputs),
 	}
+}
 
-	// Convert provider inputs to string map for proto
-	providerInputStrings := make(map[string]string)
-	for k, v := range providerInputs {
+// stringifyValues converts a property map to a string map for proto, encoding non-string values as JSON
+func stringifyValues(values map[string]any) map[string]string {
+	result := make(map[string]string)
+	for k, v := range values {
 		switch val := v.(type) {
 		case string:
-			providerInputStrings[k] = val
+			result[k] = val
 		default:
 			if b, err := json.Marshal(val); err == nil {
-				providerInputStrings[k] = string(b)
+				result[k] = string(b)
 			}
 		}
 	}
-
-	appCtx := m.appCtx
-	pluginProvider := m.deps.PluginProvider
-	return func() tea.Msg {
-		req := &plugins.OpenResourceRequest{
-			ResourceType:   resourceType,
-			ResourceName:   resourceName,
-			ResourceUrn:    resourceURN,
-			ProviderUrn:    providerURN,
-			ProviderInputs: providerInputStrings,
-			Inputs:         inputStrings,
-			Outputs:        outputStrings,
-		}
-
-		resp, pluginName, err := pluginProvider.OpenResource(appCtx, req)
-		if err != nil {
-			return openResourceErrMsg(err)
-		}
-		return openResourceActionMsg{Response: resp, PluginName: pluginName}
-	}
+	return result
 }
 
 // openInBrowser opens a URL in the default browser
 func openInBrowser(url string) tea.Cmd {
 	return func() tea.Msg {
 		if err := browser.OpenURL(url); err != nil {
-			return openResourceErrMsg(fmt.Errorf("failed to open browser: %w", err))
+			return openResourceErrMsg{Err: fmt.Errorf("failed to open browser: %w", err)}
 		}
 		return nil
 	}
 }
 
-// openWithExec launches an alternate screen program using tea.ExecProcess
+// maxExecStderr is how much of an exec open action's error output is kept to report
+const maxExecStderr = 4096
+
+// openWithExec suspends the TUI to run a program attached to the terminal using
+// tea.ExecProcess, resuming once it exits. Error output still reaches the terminal,
+// and its end is kept to report a failure after the TUI is back.
 func openWithExec(command string, args []string, env map[string]string) tea.Cmd {
 	cmd := exec.Command(command, args...)
 
@@ -765,12 +1937,33 @@ func openWithExec(command string, args []string, env map[string]string) tea.Cmd
 	if len(env) > 0 {
 		cmd.Env = append(cmd.Environ(), mapToEnvSlice(env)...)
 	}
+	stderr := &tailWriter{max: maxExecStderr}
+	cmd.Stderr = io.MultiWriter(os.Stderr, stderr)
 
 	return tea.ExecProcess(cmd, func(err error) tea.Msg {
-		return openResourceExecDoneMsg{Error: err}
+		return openResourceExecDoneMsg{Command: command, Stderr: stderr.String(), Error: err}
 	})
 }
 
+// tailWriter keeps the last max bytes written to it
+type tailWriter struct {
+	max int
+	buf []byte
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	if over := len(w.buf) - w.max; over > 0 {
+		w.buf = w.buf[over:]
+	}
+	return len(p), nil
+}
+
+// String returns the kept output without surrounding whitespace
+func (w *tailWriter) String() string {
+	return strings.TrimSpace(string(w.buf))
+}
+
 // mapToEnvSlice converts a map to a slice of KEY=VALUE strings
 func mapToEnvSlice(m map[string]string) []string {
 	result := make([]string, 0, len(m))
@@ -0,0 +1,70 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// openConfigCopy prompts for the stack to copy config from and lists the stack
+// files, or returns nil when there is no stack to copy config to
+func (m *Model) openConfigCopy() tea.Cmd {
+	// Block while busy (e.g., waiting for auth) or while an operation runs
+	if m.state.IsBusy() || m.state.OpState.IsActive() ||
+		m.ctx.StackName == "" || m.ctx.StartView == "state" {
+		return nil
+	}
+	m.showConfigCopyModal()
+	return m.fetchStackFiles()
+}
+
+// copyConfig copies the config in fromStack's file to the current stack
+func (m *Model) copyConfig(fromStack string) tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	stackInitializer := m.deps.StackInitializer
+	appCtx := m.appCtx
+	opts := pulumi.ConfigCopyOptions{Env: mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())}
+
+	return func() tea.Msg {
+		result, err := stackInitializer.CopyConfig(appCtx, workDir, fromStack, stackName, opts)
+		return configCopiedMsg{StackName: stackName, Result: result, Err: err}
+	}
+}
+
+// updateConfigCopyModal handles keys when the config copy prompt has focus
+func (m Model) updateConfigCopyModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.ConfigCopyModal.Update(msg)
+	switch action {
+	case ui.StepModalActionConfirm:
+		// Block copying while busy (e.g., waiting for auth)
+		if m.state.IsBusy() {
+			return m, nil
+		}
+		from := m.ui.ConfigCopyModal.Source()
+		m.hideConfigCopyModal()
+		return m, tea.Batch(
+			m.ui.Toast.Show(i18n.Tf("Copying config from %s...", from)),
+			m.copyConfig(from),
+		)
+	case ui.StepModalActionCancel:
+		m.hideConfigCopyModal()
+	}
+	return m, cmd
+}
+
+// handleConfigCopied reports how much config was copied, and the secrets that
+// couldn't be
+func (m Model) handleConfigCopied(msg configCopiedMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to copy config: %v", msg.Err))
+	}
+	r := msg.Result
+	if r.SkippedSecrets > 0 {
+		m.deps.Logger.Warn("secrets not copied", "from", r.From, "stack", msg.StackName, "error", r.SecretsErr)
+		return m, m.ui.Toast.Show(i18n.Tf("Copied %d config values from %s, but %d secrets couldn't be decrypted", r.Copied, r.From, r.SkippedSecrets))
+	}
+	return m, m.ui.Toast.Show(i18n.Tf("Copied %d config values from %s to %s", r.Copied, r.From, msg.StackName))
+}
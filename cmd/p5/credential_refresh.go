@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// minCredentialRefreshDelay keeps credentials that are already due from being
+// refreshed in a tight loop
+const minCredentialRefreshDelay = 5 * time.Second
+
+// scheduleCredentialRefresh schedules refreshing plugin credentials before they
+// expire. Scheduling again replaces the pending refresh.
+func (m *Model) scheduleCredentialRefresh() tea.Cmd {
+	if m.deps == nil || m.deps.PluginProvider == nil {
+		return nil
+	}
+	m.state.CredentialRefreshSeq++
+	next := m.deps.PluginProvider.NextCredentialRefresh()
+	if next.IsZero() {
+		return nil
+	}
+	seq := m.state.CredentialRefreshSeq
+	return tea.Tick(max(time.Until(next), minCredentialRefreshDelay), func(time.Time) tea.Msg {
+		return credentialRefreshMsg{Seq: seq}
+	})
+}
+
+// handleCredentialRefresh re-authenticates plugins whose credentials are about to
+// expire. It runs while operations are in progress, so the steps that follow them
+// get fresh credentials, but not during a full authentication, which reschedules
+// the refresh when it completes.
+func (m Model) handleCredentialRefresh(msg credentialRefreshMsg) (tea.Model, tea.Cmd) {
+	if msg.Seq != m.state.CredentialRefreshSeq || m.state.IsBusy() {
+		return m, nil
+	}
+	pluginProvider := m.deps.PluginProvider
+	appCtx := m.appCtx
+	return m, func() tea.Msg {
+		return credentialsRefreshedMsg(pluginProvider.RefreshCredentials(appCtx))
+	}
+}
+
+// handleCredentialsRefreshed applies refreshed credentials to the process and
+// reports plugins that failed to refresh
+func (m Model) handleCredentialsRefreshed(msg credentialsRefreshedMsg) (tea.Model, tea.Cmd) {
+	m.deps.PluginProvider.ApplyEnvToProcess()
+	if m.ui.PluginStatusModal.Visible() {
+		m.ui.PluginStatusModal.SetEntries(m.pluginStatusEntries())
+	}
+
+	cmds := []tea.Cmd{m.scheduleCredentialRefresh()}
+	summary := SummarizePluginAuthResults(msg)
+	if summary.HasErrors {
+		cmds = append(cmds, m.ui.Toast.Show(i18n.T("Credential refresh failed: ")+strings.Join(summary.ErrorMessages, "; ")))
+	} else if len(summary.AuthenticatedPlugins) > 0 {
+		m.deps.Logger.Debug("refreshed plugin credentials", "plugins", summary.AuthenticatedPlugins)
+	}
+	return m, tea.Batch(cmds...)
+}
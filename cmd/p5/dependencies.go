@@ -7,19 +7,28 @@ import (
 
 	"github.com/rfhold/p5/internal/plugins"
 	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/telemetry"
 )
 
 // Dependencies holds all external dependencies for the application.
 // These can be replaced with test doubles for unit testing.
 type Dependencies struct {
-	StackOperator    pulumi.StackOperator
-	StackReader      pulumi.StackReader
-	WorkspaceReader  pulumi.WorkspaceReader
-	StackInitializer pulumi.StackInitializer
-	ResourceImporter pulumi.ResourceImporter
-	PluginProvider   plugins.PluginProvider
-	Logger           *slog.Logger
-	Env              map[string]string // Environment variables to pass to Pulumi
+	StackOperator        pulumi.StackOperator
+	StackReader          pulumi.StackReader
+	WorkspaceReader      pulumi.WorkspaceReader
+	EnvironmentReader    pulumi.EnvironmentReader
+	GitReader            pulumi.GitReader
+	StackInitializer     pulumi.StackInitializer
+	ResourceImporter     pulumi.ResourceImporter
+	StackStateManager    pulumi.StackStateManager
+	StateTransferer      pulumi.StateTransferer
+	BackendAuthenticator pulumi.BackendAuthenticator
+	StackLocker          pulumi.StackLocker
+	PluginProvider       plugins.PluginProvider
+	PluginInstaller      plugins.PluginInstaller
+	Logger               *slog.Logger
+	Logs                 *telemetry.LogBuffer // Captured log records for the log viewer (nil unless debugging)
+	Env                  map[string]string    // Environment variables to pass to Pulumi
 }
 
 // NewProductionDependencies creates dependencies configured for production use.
@@ -33,12 +42,19 @@ func NewProductionDependencies(workDir string, logger *slog.Logger) *Dependencie
 	}
 
 	return &Dependencies{
-		StackOperator:    pulumi.NewStackOperator(),
-		StackReader:      pulumi.NewStackReader(),
-		WorkspaceReader:  pulumi.NewWorkspaceReader(),
-		StackInitializer: pulumi.NewStackInitializer(),
-		ResourceImporter: pulumi.NewResourceImporter(),
-		PluginProvider:   pluginMgr,
-		Logger:           logger,
+		StackOperator:        pulumi.NewStackOperator(),
+		StackReader:          pulumi.NewStackReader(),
+		WorkspaceReader:      pulumi.NewWorkspaceReader(),
+		EnvironmentReader:    pulumi.NewEnvironmentReader(),
+		GitReader:            pulumi.NewGitReader(),
+		StackInitializer:     pulumi.NewStackInitializer(),
+		ResourceImporter:     pulumi.NewResourceImporter(),
+		StackStateManager:    pulumi.NewStackStateManager(),
+		StateTransferer:      pulumi.NewStateTransferer(),
+		BackendAuthenticator: pulumi.NewBackendAuthenticator(),
+		StackLocker:          pulumi.NewStackLocker(),
+		PluginProvider:       pluginMgr,
+		PluginInstaller:      plugins.NewInstaller(),
+		Logger:               logger,
 	}
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// DriftAction is accepting or reverting drift found by drift detection
+type DriftAction struct {
+	Op      pulumi.OperationType // Refresh accepts the live state, up reverts it to the program
+	Targets []string             // Drifted resources to act on
+}
+
+// Options returns the operation options that run the action on its targets
+func (a DriftAction) Options() pulumi.OperationOptions {
+	return pulumi.OperationOptions{
+		Targets: a.Targets,
+		// Up diffs the program against the state, so the state has to be
+		// refreshed first for the up to see and revert the drift
+		Refresh: a.Op == pulumi.OperationUp,
+	}
+}
+
+// DriftedKeys returns the top-level properties of a refreshed resource whose live
+// value differs from the state of the last deployment, in sorted order.
+// Engine-internal properties (prefixed with "__") are not drift.
+func DriftedKeys(item ui.ResourceItem) []string {
+	if item.OldOutputs == nil || item.Op == ui.OpDelete {
+		return nil
+	}
+	var keys []string
+	for k := range collectDriftKeys(item.OldOutputs, item.Outputs) {
+		if strings.HasPrefix(k, "__") {
+			continue
+		}
+		if !reflect.DeepEqual(item.OldOutputs[k], item.Outputs[k]) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// collectDriftKeys returns the union of the keys of the state and live outputs
+func collectDriftKeys(old, live map[string]any) map[string]bool {
+	keys := make(map[string]bool, len(old))
+	for k := range old {
+		keys[k] = true
+	}
+	for k := range live {
+		keys[k] = true
+	}
+	return keys
+}
+
+// SummarizeDrift counts the drifted resources and properties among refreshed items
+func SummarizeDrift(items []ui.ResourceItem) ui.DriftSummary {
+	var summary ui.DriftSummary
+	for _, item := range items {
+		if !item.Drifted() {
+			continue
+		}
+		summary.Resources++
+		summary.Properties += len(item.DriftedKeys)
+		if item.Op == ui.OpDelete {
+			summary.Deleted++
+		}
+	}
+	return summary
+}
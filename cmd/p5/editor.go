@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// defaultEditor is run when neither $VISUAL nor $EDITOR is set
+const defaultEditor = "vi"
+
+// editorCommand returns the command opening path in the user's editor, from $VISUAL
+// or $EDITOR. A line above zero is passed as +line, which most editors jump to.
+func editorCommand(path string, line int) *exec.Cmd {
+	fields := strings.Fields(os.Getenv("VISUAL"))
+	if len(fields) == 0 {
+		fields = strings.Fields(os.Getenv("EDITOR"))
+	}
+	if len(fields) == 0 {
+		fields = []string{defaultEditor}
+	}
+	args := fields[1:]
+	if line > 0 {
+		args = append(args, "+"+strconv.Itoa(line))
+	}
+	args = append(args, path)
+	return exec.Command(fields[0], args...)
+}
+
+// openSource opens the file that declared item in the editor, at the line recorded
+// in the stack's state
+func (m *Model) openSource(item *ui.ResourceItem) tea.Cmd {
+	var pos string
+	for _, r := range m.state.StackResources {
+		if r.URN == item.URN && !r.PendingDelete {
+			pos = r.SourcePosition
+			break
+		}
+	}
+	if pos == "" {
+		return m.ui.Toast.Show(i18n.Tf("No source position recorded for '%s'", item.Name))
+	}
+	loc, err := pulumi.ParseSourcePosition(pos, m.ctx.WorkDir)
+	if err != nil {
+		return m.ui.Toast.Show(err.Error())
+	}
+	if _, err := os.Stat(loc.Path); err != nil {
+		return m.ui.Toast.Show(i18n.Tf("Source file not found: %s", loc.Path))
+	}
+	return tea.ExecProcess(editorCommand(loc.Path, loc.Line), func(err error) tea.Msg {
+		return sourceEditorDoneMsg{Err: err}
+	})
+}
+
+// handleSourceEditorDone reports an editor that failed to run
+func (m Model) handleSourceEditorDone(msg sourceEditorDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Editor failed: %v", msg.Err))
+	}
+	return m, nil
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strconv"
+)
+
+// openEventStream opens where --events-json writes events: a file descriptor
+// number inherited from the parent process, or a file appended to
+func openEventStream(target string) (io.WriteCloser, error) {
+	if fd, err := strconv.Atoi(target); err == nil && fd > 2 {
+		return os.NewFile(uintptr(fd), "events-json"), nil
+	}
+	return os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600) //nolint:gosec // G304: path given by the user
+}
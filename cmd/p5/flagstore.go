@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// SavedFlagsFile is where resource flags are saved, relative to the project directory
+const SavedFlagsFile = ".p5/flags.json"
+
+// savedFlags is the on-disk form of a resource's flags
+type savedFlags struct {
+	Target  bool `json:"target,omitempty"`
+	Replace bool `json:"replace,omitempty"`
+	Exclude bool `json:"exclude,omitempty"`
+}
+
+// savedFlagsFile holds the saved flags of each stack, keyed by stack name then URN
+type savedFlagsFile struct {
+	Stacks map[string]map[string]savedFlags `json:"stacks"`
+}
+
+// readSavedFlagsFile reads the saved flags file of a project, returning an empty
+// file when none has been written yet
+func readSavedFlagsFile(workDir string) (*savedFlagsFile, error) {
+	file := &savedFlagsFile{}
+	data, err := os.ReadFile(filepath.Join(workDir, SavedFlagsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read saved flags: %w", err)
+	}
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", SavedFlagsFile, err)
+	}
+	return file, nil
+}
+
+// LoadSavedFlags returns the flags saved for a stack, or nil if none were saved
+func LoadSavedFlags(workDir, stackName string) (map[string]ui.ResourceFlags, error) {
+	file, err := readSavedFlagsFile(workDir)
+	if err != nil {
+		return nil, err
+	}
+	saved := file.Stacks[stackName]
+	if len(saved) == 0 {
+		return nil, nil
+	}
+	flags := make(map[string]ui.ResourceFlags, len(saved))
+	for urn, f := range saved {
+		flags[urn] = ui.ResourceFlags{Target: f.Target, Replace: f.Replace, Exclude: f.Exclude}
+	}
+	return flags, nil
+}
+
+// SaveFlags replaces the flags saved for a stack. Saving no flags removes the
+// stack from the file; flags of other stacks are kept.
+func SaveFlags(workDir, stackName string, flags map[string]ui.ResourceFlags) error {
+	file, err := readSavedFlagsFile(workDir)
+	if err != nil {
+		return err
+	}
+	if file.Stacks == nil {
+		file.Stacks = make(map[string]map[string]savedFlags)
+	}
+
+	saved := make(map[string]savedFlags, len(flags))
+	for urn, f := range flags {
+		if f.Target || f.Replace || f.Exclude {
+			saved[urn] = savedFlags{Target: f.Target, Replace: f.Replace, Exclude: f.Exclude}
+		}
+	}
+	if len(saved) == 0 {
+		if _, ok := file.Stacks[stackName]; !ok {
+			return nil
+		}
+		delete(file.Stacks, stackName)
+	} else {
+		file.Stacks[stackName] = saved
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode saved flags: %w", err)
+	}
+	path := filepath.Join(workDir, SavedFlagsFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(SavedFlagsFile), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write saved flags: %w", err)
+	}
+	return nil
+}
+
+// StackURNPrefix returns the prefix shared by the URNs of a stack's resources.
+// It is taken from the root stack resource when known, since that also carries
+// the project; otherwise it is built from the stack name alone.
+func StackURNPrefix(stackURN, stackName string) string {
+	if i := strings.Index(stackURN, "::pulumi:pulumi:Stack::"); i >= 0 {
+		return stackURN[:i+2]
+	}
+	// Fully qualified names (org/project/stack) only use the stack in URNs
+	if i := strings.LastIndex(stackName, "/"); i >= 0 {
+		stackName = stackName[i+1:]
+	}
+	return "urn:pulumi:" + stackName + "::"
+}
+
+// FlagsWithPrefix returns a copy of the flags whose URN starts with prefix
+func FlagsWithPrefix(flags map[string]ui.ResourceFlags, prefix string) map[string]ui.ResourceFlags {
+	result := make(map[string]ui.ResourceFlags)
+	for urn, f := range flags {
+		if strings.HasPrefix(urn, prefix) {
+			result[urn] = f
+		}
+	}
+	return result
+}
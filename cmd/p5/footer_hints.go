@@ -0,0 +1,227 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// footerAction is an action the footer offers: the binding that runs it and a
+// short description. The expanded footer describes it with the binding's help.
+type footerAction struct {
+	Binding key.Binding
+	Desc    string
+}
+
+// footerActions lists the actions valid in the current view, state of operations
+// and selection, most useful first. Actions contributed by plugins are listed when
+// a loaded plugin handles the selected resource. Help and quit are left out, as
+// the footer always shows them.
+func (m Model) footerActions() []footerAction {
+	action := func(binding key.Binding, desc string) footerAction {
+		return footerAction{Binding: binding, Desc: desc}
+	}
+	item := m.ui.ResourceList.SelectedItem()
+
+	if m.ctx.StartView == "state" {
+		actions := []footerAction{
+			action(ui.Keys.ToggleDetails, "details"),
+			action(ui.Keys.CopyResource, "copy"),
+			action(ui.Keys.Filter, "filter"),
+			action(ui.Keys.BrowseCloudStacks, "org stacks"),
+		}
+		if m.ctx.CloudStack != "" && m.ctx.StackName != "" {
+			actions = append(actions, action(ui.Keys.Escape, "back"))
+		}
+		return actions
+	}
+
+	if m.ui.ResourceList.VisualMode() {
+		return []footerAction{
+			action(ui.Keys.ToggleTarget, "target"),
+			action(ui.Keys.ToggleReplace, "replace"),
+			action(ui.Keys.ToggleExclude, "exclude"),
+			action(ui.Keys.ClearFlags, "clear"),
+			action(ui.Keys.Escape, "cancel"),
+		}
+	}
+
+	var actions []footerAction
+	opActive := m.state.OpState.IsActive()
+	switch {
+	case m.state.DriftMode && !opActive:
+		actions = append(actions,
+			action(ui.Keys.AcceptDrift, "accept"),
+			action(ui.Keys.RevertDrift, "revert"),
+			action(ui.Keys.Escape, "back"),
+		)
+	case m.ui.ViewMode == ui.ViewStack:
+		actions = append(actions,
+			action(ui.Keys.PreviewUp, "up"),
+			action(ui.Keys.PreviewRefresh, "refresh"),
+			action(ui.Keys.PreviewDestroy, "destroy"),
+		)
+		if m.state.QuickCheck == nil || m.state.QuickCheck.Done {
+			actions = append(actions, action(ui.Keys.QuickCheck, "check"))
+		}
+		if m.state.StateOutdated {
+			actions = append(actions, action(ui.Keys.ReloadStack, "reload"))
+		}
+		if len(m.state.StateIssues) > 0 {
+			actions = append(actions, action(ui.Keys.RepairState, "repair"))
+		}
+		if len(m.ui.ResourceList.GetSelectedResourcesForStateDelete()) > 0 {
+			actions = append(actions, action(ui.Keys.DeleteFromState, "delete"))
+		}
+		if item != nil && item.Type != "pulumi:pulumi:Stack" {
+			if item.Protected {
+				actions = append(actions, action(ui.Keys.Unprotect, "unprotect"))
+			} else {
+				actions = append(actions, action(ui.Keys.Protect, "protect"))
+			}
+		}
+	case m.ui.ViewMode == ui.ViewPreview && opActive:
+		actions = append(actions, action(ui.Keys.Escape, "cancel"))
+	case m.ui.ViewMode == ui.ViewPreview:
+		actions = append(actions, action(ui.Keys.ExecuteUp, "execute"))
+		if CanImportResource(m.ui.ViewMode, item) {
+			actions = append(actions, action(ui.Keys.Import, "import"))
+			if m.hasPlugins() && m.deps.PluginProvider.HasImportHelpers() {
+				actions = append(actions, action(ui.Keys.BulkImport, "bulk import"))
+			}
+		}
+		if len(m.state.PreviewWarnings) > 0 {
+			actions = append(actions, action(ui.Keys.ViewWarnings, "warnings"))
+		}
+		actions = append(actions, action(ui.Keys.Escape, "back"))
+	case m.ui.ViewMode == ui.ViewExecute && opActive:
+		actions = append(actions, action(ui.Keys.Escape, "cancel"))
+	case m.ui.ViewMode == ui.ViewExecute:
+		actions = append(actions,
+			action(ui.Keys.ViewTimings, "timings"),
+			action(ui.Keys.Escape, "back"),
+		)
+	case m.ui.ViewMode == ui.ViewHistory:
+		if h := m.ui.HistoryList.SelectedItem(); h != nil && h.BackendVersion > 0 {
+			actions = append(actions, action(ui.Keys.HistoryDiff, "diff"))
+		}
+		actions = append(actions,
+			action(ui.Keys.FilterHistory, "filter"),
+			action(ui.Keys.Escape, "back"),
+		)
+	}
+
+	if m.ui.ViewMode != ui.ViewHistory && m.ui.ResourceList.CanUndoFlags() {
+		actions = append(actions, action(ui.Keys.UndoFlags, "undo"))
+	}
+	if m.ui.ViewMode == ui.ViewPreview && m.ui.Diagnostics.Height() > 0 {
+		actions = append(actions, action(ui.Keys.ToggleDiagnostics, "engine log"))
+	}
+
+	// Actions on the selected resource, including those of plugins
+	if m.ui.ViewMode != ui.ViewHistory && item != nil {
+		if m.hasPlugins() && CanOpenResource(m.ui.ViewMode, item, m.deps.PluginProvider.HasResourceOpeners()) {
+			actions = append(actions, action(ui.Keys.OpenResource, "open"))
+		}
+		if item.Type == pulumi.StackReferenceType && !opActive {
+			actions = append(actions, action(ui.Keys.FollowReference, "follow"))
+		}
+		actions = append(actions, action(ui.Keys.CopyResource, "copy"))
+		if m.state.Pinned[item.URN] {
+			actions = append(actions, action(ui.Keys.PinResource, "unpin"))
+		} else {
+			actions = append(actions, action(ui.Keys.PinResource, "pin"))
+		}
+	}
+
+	if m.ui.ViewMode != ui.ViewHistory {
+		actions = append(actions,
+			action(ui.Keys.VisualMode, "select"),
+			action(ui.Keys.TargetSets, "target sets"),
+			action(ui.Keys.CycleGrouping, "group"),
+		)
+		if m.ui.ResourceList.CanCollapse() {
+			actions = append(actions, action(ui.Keys.ToggleCollapse, "collapse"))
+		}
+	}
+	actions = append(actions, action(ui.Keys.ToggleDetails, "details"))
+	if !opActive {
+		actions = append(actions,
+			action(ui.Keys.SelectStack, "stack"),
+			action(ui.Keys.SelectWorkspace, "workspace"),
+		)
+		if m.ui.ViewMode != ui.ViewHistory {
+			actions = append(actions, action(ui.Keys.ViewHistory, "history"))
+		}
+	}
+	return actions
+}
+
+// hasPlugins returns whether plugins are available to contribute actions
+func (m Model) hasPlugins() bool {
+	return m.deps != nil && m.deps.PluginProvider != nil
+}
+
+// renderFooterHints renders the actions of the footer. Compact, they take a single
+// line of the given width, dropping the least useful actions that don't fit.
+// Expanded, every action is described by its binding's help, over as many lines
+// of the full width as needed.
+func (m Model) renderFooterHints(actions []footerAction, width int) []string {
+	tail := []string{footerHint(ui.Keys.Help, "help"), footerHint(ui.Keys.Quit, "quit")}
+
+	if m.ui.ExpandedHints {
+		hints := make([]string, 0, len(actions)+3)
+		for _, a := range actions {
+			hints = append(hints, ui.DimStyle.Render(a.Binding.Help().Key+" "+i18n.T(a.Binding.Help().Desc)))
+		}
+		hints = append(hints, footerHint(ui.Keys.ToggleHints, "fewer keys"))
+		return wrapFooterHints(append(hints, tail...), width)
+	}
+
+	// Each hint takes its width and the separator before the next one
+	cost := func(hint string) int { return ansi.StringWidth(hint) + 2 }
+	budget := width - ansi.StringWidth(strings.Join(tail, "  "))
+	hints := make([]string, len(actions))
+	total := 0
+	for i, a := range actions {
+		hints[i] = footerHint(a.Binding, a.Desc)
+		total += cost(hints[i])
+	}
+	if total > budget {
+		more := footerHint(ui.Keys.ToggleHints, "more")
+		budget -= cost(more)
+		n, used := 0, 0
+		for n < len(hints) && used+cost(hints[n]) <= budget {
+			used += cost(hints[n])
+			n++
+		}
+		hints = append(hints[:n:n], more)
+	}
+	return []string{joinWithSeparator(append(hints, tail...), "  ")}
+}
+
+// wrapFooterHints joins hints into lines no wider than width
+func wrapFooterHints(hints []string, width int) []string {
+	var lines []string
+	var line string
+	for _, hint := range hints {
+		switch {
+		case line == "":
+			line = hint
+		case ansi.StringWidth(line)+2+ansi.StringWidth(hint) > width:
+			lines = append(lines, line)
+			line = hint
+		default:
+			line += "  " + hint
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
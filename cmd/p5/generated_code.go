@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// GeneratedCodeDir is where generated code is saved by default, relative to the project directory
+const GeneratedCodeDir = ".p5/generated"
+
+// CodeLanguage returns the language Pulumi generates code in for a project runtime,
+// and the file extension for it
+func CodeLanguage(runtime string) (name, ext string) {
+	switch runtime {
+	case "nodejs":
+		return "TypeScript", ".ts"
+	case "python":
+		return "Python", ".py"
+	case "go":
+		return "Go", ".go"
+	case "dotnet":
+		return "C#", ".cs"
+	case "java":
+		return "Java", ".java"
+	case "yaml":
+		return "YAML", ".yaml"
+	}
+	return "", ".txt"
+}
+
+// GeneratedCodeFile returns the file, relative to the project directory, to save
+// the code generated for an imported resource to
+func GeneratedCodeFile(resourceName, runtime string) string {
+	_, ext := CodeLanguage(runtime)
+	name := strings.NewReplacer("/", "_", string(filepath.Separator), "_", " ", "_").Replace(resourceName)
+	return filepath.Join(GeneratedCodeDir, name+ext)
+}
+
+// resourceTypeName returns the name part of a resource type (e.g., "Bucket" for
+// "aws:s3/bucket:Bucket"), naming code generated for several resources of the type
+func resourceTypeName(resourceType string) string {
+	return resourceType[strings.LastIndex(resourceType, ":")+1:]
+}
+
+// showGeneratedCode shows the code Pulumi generated for the imported resources
+// named name, or does nothing when no code was generated
+func (m *Model) showGeneratedCode(name, code string) {
+	if strings.TrimSpace(code) == "" {
+		return
+	}
+	language, _ := CodeLanguage(m.state.Runtime)
+	m.state.GeneratedCodeName = name
+	m.ui.Code.Show(i18n.Tf("Generated Code for %s", name), language, code)
+	m.ui.Focus.Push(ui.FocusCode)
+}
+
+// hideGeneratedCode hides the generated code panel and pops focus
+func (m *Model) hideGeneratedCode() {
+	m.ui.Code.Hide()
+	m.ui.Focus.Remove(ui.FocusCode)
+}
+
+// updateCodePanel handles keys when the generated code panel has focus
+func (m Model) updateCodePanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	panel := m.ui.Code
+	switch {
+	case key.Matches(msg, ui.Keys.CopyResource):
+		return m, ui.CopyToClipboardWithCountCmd(panel.Code(), 0)
+	case msg.String() == "s":
+		m.ui.SaveFileModal.Show(
+			i18n.T("Save Generated Code"),
+			i18n.T("Save the generated code to a file"),
+			GeneratedCodeFile(m.state.GeneratedCodeName, m.state.Runtime),
+		)
+		m.ui.Focus.Push(ui.FocusSaveFileModal)
+	case key.Matches(msg, ui.Keys.Up):
+		panel.Scroll(-1)
+	case key.Matches(msg, ui.Keys.Down):
+		panel.Scroll(1)
+	case key.Matches(msg, ui.Keys.PageUp):
+		panel.Scroll(-10)
+	case key.Matches(msg, ui.Keys.PageDown):
+		panel.Scroll(10)
+	case key.Matches(msg, ui.Keys.Home):
+		panel.Scroll(-panel.LineCount())
+	case key.Matches(msg, ui.Keys.End):
+		panel.Scroll(panel.LineCount())
+	case key.Matches(msg, ui.Keys.Escape), key.Matches(msg, ui.Keys.Quit):
+		m.hideGeneratedCode()
+	case key.Matches(msg, ui.Keys.Help):
+		m.showHelp()
+	}
+	return m, nil
+}
+
+// hideSaveFileModal hides the save file prompt and pops focus
+func (m *Model) hideSaveFileModal() {
+	m.ui.SaveFileModal.Hide()
+	m.ui.Focus.Remove(ui.FocusSaveFileModal)
+	m.state.ExportingResources = false
+}
+
+// updateSaveFileModal handles keys when the save file prompt has focus
+func (m Model) updateSaveFileModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.SaveFileModal.Update(msg)
+	switch action {
+	case ui.StepModalActionConfirm:
+		m.ui.SaveFileModal.ClearError()
+		path := resolveStatePath(m.ctx.WorkDir, m.ui.SaveFileModal.Path())
+		content := m.ui.Code.Code()
+		if m.state.ExportingResources {
+			var err error
+			if content, err = m.ui.ResourceList.ExportResources(path); err != nil {
+				m.ui.SaveFileModal.SetError(err)
+				return m, nil
+			}
+		}
+		return m, saveFile(path, content)
+	case ui.StepModalActionCancel:
+		m.hideSaveFileModal()
+	}
+	return m, cmd
+}
+
+// saveFile writes content to a new file at path, creating its directory
+func saveFile(path, content string) tea.Cmd {
+	return func() tea.Msg {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fileSavedMsg{Path: path, Err: err}
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) //nolint:gosec // G304: path entered by the user
+		if err != nil {
+			if errors.Is(err, os.ErrExist) {
+				err = errors.New(i18n.Tf("%s already exists", path))
+			}
+			return fileSavedMsg{Path: path, Err: err}
+		}
+		_, err = f.WriteString(content + "\n")
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		return fileSavedMsg{Path: path, Err: err}
+	}
+}
+
+// handleFileSaved reports where the file was saved, or keeps the prompt open with
+// the reason it couldn't be
+func (m Model) handleFileSaved(msg fileSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		if m.ui.SaveFileModal.Visible() {
+			m.ui.SaveFileModal.SetError(msg.Err)
+			return m, nil
+		}
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to save file: %v", msg.Err))
+	}
+	m.hideSaveFileModal()
+	return m, m.ui.Toast.Show(i18n.Tf("Saved %s", msg.Path))
+}
@@ -2,9 +2,11 @@ package main
 
 import (
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/rfhold/p5/internal/plugins"
 	"github.com/rfhold/p5/internal/ui"
 )
 
@@ -45,6 +47,170 @@ func (m *Model) hideImportModal() {
 	m.ui.Focus.Remove(ui.FocusImportModal)
 }
 
+// showBulkImportModal shows the bulk import modal for resources of item's type
+// and pushes focus to it
+func (m *Model) showBulkImportModal(item ui.ResourceItem) {
+	m.state.PendingBulkImport = &PendingBulkImport{Item: item}
+	m.ui.BulkImportModal.Show(item.Type)
+	m.ui.Focus.Push(ui.FocusBulkImportModal)
+}
+
+// hideBulkImportModal hides the bulk import modal and pops focus
+func (m *Model) hideBulkImportModal() {
+	m.state.PendingBulkImport = nil
+	m.ui.BulkImportModal.Hide()
+	m.ui.Focus.Remove(ui.FocusBulkImportModal)
+}
+
+// showStateRepairModal shows the state repair modal for the issues found on load
+func (m *Model) showStateRepairModal() {
+	m.ui.StateRepairModal.Show(m.state.StateIssues, m.state.StackURN)
+	m.ui.Focus.Push(ui.FocusStateRepairModal)
+}
+
+// hideStateRepairModal hides the state repair modal and pops focus
+func (m *Model) hideStateRepairModal() {
+	m.ui.StateRepairModal.Hide()
+	m.ui.Focus.Remove(ui.FocusStateRepairModal)
+}
+
+// showPluginIndexModal shows the plugin index modal while the index is fetched
+func (m *Model) showPluginIndexModal() {
+	m.ui.PluginIndexModal.Show()
+	m.ui.Focus.Push(ui.FocusPluginIndexModal)
+}
+
+// showPluginStatusModal shows the authentication state of each loaded plugin
+func (m *Model) showPluginStatusModal() {
+	m.ui.PluginStatusModal.Show(m.pluginStatusEntries())
+	m.ui.Focus.Push(ui.FocusPluginStatusModal)
+}
+
+// hidePluginStatusModal hides the plugin status modal and pops focus
+func (m *Model) hidePluginStatusModal() {
+	m.ui.PluginStatusModal.Hide()
+	m.ui.Focus.Remove(ui.FocusPluginStatusModal)
+}
+
+// showNoteModal shows the note modal for a resource and pushes focus to it
+func (m *Model) showNoteModal(item *ui.ResourceItem) {
+	m.ui.NoteModal.Show(item.Type, item.Name, item.URN, m.state.Notes[item.URN])
+	m.ui.Focus.Push(ui.FocusNoteModal)
+}
+
+// hideNoteModal hides the note modal and pops focus
+func (m *Model) hideNoteModal() {
+	m.ui.NoteModal.Hide()
+	m.ui.Focus.Remove(ui.FocusNoteModal)
+}
+
+// showGotoModal prompts for the resource to move the cursor to
+func (m *Model) showGotoModal() {
+	m.ui.GotoModal.ShowPrompt()
+	m.ui.Focus.Push(ui.FocusGotoModal)
+}
+
+// hideGotoModal hides the goto prompt and pops focus
+func (m *Model) hideGotoModal() {
+	m.ui.GotoModal.Hide()
+	m.ui.Focus.Remove(ui.FocusGotoModal)
+}
+
+// showTagsModal shows the stack tags modal while the tags are fetched
+func (m *Model) showTagsModal() {
+	m.ui.TagsModal.Show(m.ctx.StackName)
+	m.ui.Focus.Push(ui.FocusTagsModal)
+}
+
+// hideTagsModal hides the stack tags modal and pops focus
+func (m *Model) hideTagsModal() {
+	m.ui.TagsModal.Hide()
+	m.ui.Focus.Remove(ui.FocusTagsModal)
+}
+
+// showHistoryFilterModal shows the history filter modal, offering the users who
+// ran the loaded updates
+func (m *Model) showHistoryFilterModal() {
+	m.ui.HistoryFilterModal.Show(m.ui.HistoryList.HistoryFilter(), m.ui.HistoryList.Users())
+	m.ui.Focus.Push(ui.FocusHistoryFilterModal)
+}
+
+// hideHistoryFilterModal hides the history filter modal and pops focus
+func (m *Model) hideHistoryFilterModal() {
+	m.ui.HistoryFilterModal.Hide()
+	m.ui.Focus.Remove(ui.FocusHistoryFilterModal)
+}
+
+// showStateFileModal shows the state file prompt for exporting or importing state
+func (m *Model) showStateFileModal(importing bool) {
+	if importing {
+		m.ui.StateFileModal.ShowImport(m.ctx.StackName)
+	} else {
+		m.ui.StateFileModal.ShowExport(m.ctx.StackName, StateExportFile(m.ctx.StackName, time.Now()))
+	}
+	m.ui.Focus.Push(ui.FocusStateFileModal)
+}
+
+// hideStateFileModal hides the state file prompt and pops focus
+func (m *Model) hideStateFileModal() {
+	m.ui.StateFileModal.Hide()
+	m.ui.Focus.Remove(ui.FocusStateFileModal)
+}
+
+// showGitGuardModal warns about the problems with the checkout up would deploy from
+func (m *Model) showGitGuardModal(problems []string) {
+	m.ui.GitGuardModal.Show(m.ctx.StackName, m.state.Git, problems)
+	m.ui.Focus.Push(ui.FocusGitGuardModal)
+}
+
+// hideGitGuardModal hides the git guard warning and pops focus
+func (m *Model) hideGitGuardModal() {
+	m.ui.GitGuardModal.Hide()
+	m.ui.Focus.Remove(ui.FocusGitGuardModal)
+}
+
+// showUpdateMessageModal shows the prompt for the message recorded with op
+func (m *Model) showUpdateMessageModal(op ui.OperationType) {
+	m.ui.UpdateMessageModal.Show(op, m.ctx.StackName)
+	m.ui.Focus.Push(ui.FocusUpdateMessageModal)
+}
+
+// hideUpdateMessageModal hides the update message prompt and pops focus
+func (m *Model) hideUpdateMessageModal() {
+	m.ui.UpdateMessageModal.Hide()
+	m.ui.Focus.Remove(ui.FocusUpdateMessageModal)
+}
+
+// showConfigCopyModal shows the prompt for the stack to copy config from
+func (m *Model) showConfigCopyModal() {
+	m.ui.ConfigCopyModal.Show(m.ctx.StackName)
+	m.ui.Focus.Push(ui.FocusConfigCopyModal)
+}
+
+// hideConfigCopyModal hides the config copy prompt and pops focus
+func (m *Model) hideConfigCopyModal() {
+	m.ui.ConfigCopyModal.Hide()
+	m.ui.Focus.Remove(ui.FocusConfigCopyModal)
+}
+
+// showLoginModal prompts to log in to a backend after err needed a login
+func (m *Model) showLoginModal(err error) {
+	m.ui.LoginModal.Show(err)
+	m.ui.Focus.Push(ui.FocusLoginModal)
+}
+
+// hideLoginModal hides the login prompt and pops focus
+func (m *Model) hideLoginModal() {
+	m.ui.LoginModal.Hide()
+	m.ui.Focus.Remove(ui.FocusLoginModal)
+}
+
+// hidePluginIndexModal hides the plugin index modal and pops focus
+func (m *Model) hidePluginIndexModal() {
+	m.ui.PluginIndexModal.Hide()
+	m.ui.Focus.Remove(ui.FocusPluginIndexModal)
+}
+
 // showStackInitModal shows the stack init modal and pushes focus to it
 func (m *Model) showStackInitModal() {
 	m.ui.StackInitModal.Show()
@@ -57,6 +223,19 @@ func (m *Model) hideStackInitModal() {
 	m.ui.Focus.Remove(ui.FocusStackInitModal)
 }
 
+// showNewProjectModal shows the new project modal, creating projects relative to
+// the directory p5 was started in
+func (m *Model) showNewProjectModal() {
+	m.ui.NewProjectModal.Show(m.ctx.Cwd)
+	m.ui.Focus.Push(ui.FocusNewProjectModal)
+}
+
+// hideNewProjectModal hides the new project modal and pops focus
+func (m *Model) hideNewProjectModal() {
+	m.ui.NewProjectModal.Hide()
+	m.ui.Focus.Remove(ui.FocusNewProjectModal)
+}
+
 // showStackSelector shows the stack selector and pushes focus to it
 func (m *Model) showStackSelector() {
 	m.ui.StackSelector.SetLoading(true)
@@ -83,6 +262,57 @@ func (m *Model) hideWorkspaceSelector() {
 	m.ui.Focus.Remove(ui.FocusWorkspaceSelector)
 }
 
+// showWorkflowSelector shows the workflow selector and pushes focus to it
+func (m *Model) showWorkflowSelector() {
+	m.ui.WorkflowSelector.SetLoading(true)
+	m.ui.WorkflowSelector.Show()
+	m.ui.Focus.Push(ui.FocusWorkflowSelector)
+}
+
+// showCloudStackBrowser shows the cloud stack browser and pushes focus to it
+func (m *Model) showCloudStackBrowser() {
+	m.ui.CloudStackBrowser.SetLoading(true)
+	m.ui.CloudStackBrowser.Show()
+	m.ui.Focus.Push(ui.FocusCloudStackBrowser)
+}
+
+// hideCloudStackBrowser hides the cloud stack browser and pops focus
+func (m *Model) hideCloudStackBrowser() {
+	m.ui.CloudStackBrowser.Hide()
+	m.ui.Focus.Remove(ui.FocusCloudStackBrowser)
+}
+
+// showTargetSetSelector shows the target set selector and pushes focus to it
+func (m *Model) showTargetSetSelector() {
+	m.ui.TargetSetSelector.SetLoading(true)
+	m.ui.TargetSetSelector.Show()
+	m.ui.Focus.Push(ui.FocusTargetSetSelector)
+}
+
+// hideTargetSetSelector hides the target set selector and pops focus
+func (m *Model) hideTargetSetSelector() {
+	m.ui.TargetSetSelector.Hide()
+	m.ui.Focus.Remove(ui.FocusTargetSetSelector)
+}
+
+// showSecretSelector lists the hidden secrets of a resource to choose one to reveal
+func (m *Model) showSecretSelector(urn string, paths []string) {
+	m.ui.SecretSelector.ShowSecrets(urn, paths)
+	m.ui.Focus.Push(ui.FocusSecretSelector)
+}
+
+// hideSecretSelector hides the secret selector and pops focus
+func (m *Model) hideSecretSelector() {
+	m.ui.SecretSelector.Hide()
+	m.ui.Focus.Remove(ui.FocusSecretSelector)
+}
+
+// hideWorkflowSelector hides the workflow selector and pops focus
+func (m *Model) hideWorkflowSelector() {
+	m.ui.WorkflowSelector.Hide()
+	m.ui.Focus.Remove(ui.FocusWorkflowSelector)
+}
+
 // showHelp shows the help dialog and pushes focus to it
 func (m *Model) showHelp() {
 	m.ui.Focus.Push(ui.FocusHelp)
@@ -93,6 +323,92 @@ func (m *Model) hideHelp() {
 	m.ui.Focus.Remove(ui.FocusHelp)
 }
 
+// showHistoryDiff shows the history diff panel in its loading state and pushes focus to it
+func (m *Model) showHistoryDiff(version int) {
+	m.ui.HistoryDiff.SetLoading(version)
+	m.ui.HistoryDiff.Show()
+	m.ui.Focus.Push(ui.FocusHistoryDiff)
+}
+
+// showHistoryChangeDiff opens the history diff panel on the resource selected in
+// the history details panel, reusing the changes it already loaded
+func (m *Model) showHistoryChangeDiff() {
+	change := m.ui.HistoryDetails.SelectedChange()
+	if change == nil {
+		return
+	}
+	version := m.ui.HistoryDetails.ChangesVersion()
+	m.showHistoryDiff(version)
+	m.ui.HistoryDiff.SetDiff(version, m.ui.HistoryDetails.Changes())
+	m.ui.HistoryDiff.FocusResource(change.URN)
+}
+
+// hideHistoryDiff hides the history diff panel and pops focus
+func (m *Model) hideHistoryDiff() {
+	m.ui.HistoryDiff.Hide()
+	m.ui.Focus.Remove(ui.FocusHistoryDiff)
+}
+
+// toggleRawJSONDiff switches the details and history diff panels between diffing
+// JSON strings structurally and as plain strings
+func (m *Model) toggleRawJSONDiff() {
+	raw := !m.ui.Details.RawJSON()
+	m.ui.Details.SetRawJSON(raw)
+	m.ui.HistoryDiff.SetRawJSON(raw)
+}
+
+// showEnvironments shows the ESC environments panel and pushes focus to it
+func (m *Model) showEnvironments() {
+	m.ui.Environments.Show()
+	m.ui.Focus.Push(ui.FocusEnvironments)
+}
+
+// hideEnvironments hides the ESC environments panel and pops focus
+func (m *Model) hideEnvironments() {
+	m.ui.Environments.Hide()
+	m.ui.Focus.Remove(ui.FocusEnvironments)
+}
+
+// showWarnings shows the preview warnings panel and pushes focus to it
+func (m *Model) showWarnings() {
+	m.ui.Warnings.SetWarnings(m.state.PreviewWarnings)
+	m.ui.Warnings.Show()
+	m.ui.Focus.Push(ui.FocusWarnings)
+}
+
+// hideWarnings hides the preview warnings panel and pops focus
+func (m *Model) hideWarnings() {
+	m.ui.Warnings.Hide()
+	m.ui.Focus.Remove(ui.FocusWarnings)
+}
+
+// showTimings shows the slowest resources of the last operation and pushes focus to it
+func (m *Model) showTimings() {
+	m.ui.Timings.SetItems(m.ui.ResourceList.Items())
+	m.ui.Timings.Show()
+	m.ui.Focus.Push(ui.FocusTimings)
+}
+
+// hideTimings hides the slowest resources panel and pops focus
+func (m *Model) hideTimings() {
+	m.ui.Timings.Hide()
+	m.ui.Focus.Remove(ui.FocusTimings)
+}
+
+// showDashboard shows the multi-stack dashboard and pushes focus to it.
+// closable is false when p5 was started on the dashboard.
+func (m *Model) showDashboard(closable bool) {
+	m.ui.Dashboard.SetClosable(closable)
+	m.ui.Dashboard.Show()
+	m.ui.Focus.Push(ui.FocusDashboard)
+}
+
+// hideDashboard hides the multi-stack dashboard and pops focus
+func (m *Model) hideDashboard() {
+	m.ui.Dashboard.Hide()
+	m.ui.Focus.Remove(ui.FocusDashboard)
+}
+
 // showDetailsPanel shows the details panel and pushes focus to it
 func (m *Model) showDetailsPanel() {
 	if m.ui.ViewMode == ui.ViewHistory {
@@ -121,6 +437,33 @@ func (m *Model) toggleDetailsPanel() {
 	}
 }
 
+// detailsWidthStep is how much the details panel width changes per key press, in percent
+const detailsWidthStep = 5
+
+// detailsResizeDelay is how long after the last resize key press the width is saved
+const detailsResizeDelay = time.Second
+
+// detailsPanelWidth returns the width of the details panel in columns
+func (m Model) detailsPanelWidth() int {
+	return m.ui.Width * m.ui.DetailsWidth / 100
+}
+
+// detailsPanelX returns the column the details panel starts at
+func (m Model) detailsPanelX() int {
+	return m.ui.Width - m.detailsPanelWidth()
+}
+
+// resizeDetailsPanel sets the details panel width in percent, clamped to the allowed
+// range. Returns false if the width is unchanged.
+func (m *Model) resizeDetailsPanel(width int) bool {
+	width = min(max(width, plugins.MinDetailsWidth), plugins.MaxDetailsWidth)
+	if width == m.ui.DetailsWidth {
+		return false
+	}
+	m.ui.DetailsWidth = width
+	return true
+}
+
 // joinWithSeparator joins strings with a separator
 func joinWithSeparator(parts []string, sep string) string {
 	if len(parts) == 0 {
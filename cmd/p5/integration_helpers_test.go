@@ -106,10 +106,14 @@ func createTestModel(t *testing.T, opts ...testModelOption) Model {
 				StackName:   "dev",
 			},
 		},
-		StackInitializer: &pulumi.FakeStackInitializer{},
-		ResourceImporter: &pulumi.FakeResourceImporter{},
-		PluginProvider:   &plugins.FakePluginProvider{},
-		Logger:           slog.New(slog.NewTextHandler(io.Discard, nil)),
+		EnvironmentReader:    &pulumi.FakeEnvironmentReader{},
+		StackInitializer:     &pulumi.FakeStackInitializer{},
+		ResourceImporter:     &pulumi.FakeResourceImporter{},
+		StackStateManager:    &pulumi.FakeStackStateManager{},
+		StateTransferer:      &pulumi.FakeStateTransferer{},
+		BackendAuthenticator: &pulumi.FakeBackendAuthenticator{},
+		PluginProvider:       &plugins.FakePluginProvider{},
+		Logger:               slog.New(slog.NewTextHandler(io.Discard, nil)),
 	}
 
 	appCtx := AppContext{
@@ -625,14 +629,18 @@ func (te *TestEnvironment) DeployStack(ctx context.Context) error {
 
 func (te *TestEnvironment) CreateModel(startView string) Model {
 	deps := &Dependencies{
-		StackOperator:    pulumi.NewStackOperator(),
-		StackReader:      pulumi.NewStackReader(),
-		WorkspaceReader:  pulumi.NewWorkspaceReader(),
-		StackInitializer: pulumi.NewStackInitializer(),
-		ResourceImporter: pulumi.NewResourceImporter(),
-		PluginProvider:   &plugins.FakePluginProvider{AllEnv: te.Env},
-		Env:              te.Env,
-		Logger:           slog.New(slog.NewTextHandler(io.Discard, nil)),
+		StackOperator:        pulumi.NewStackOperator(),
+		StackReader:          pulumi.NewStackReader(),
+		WorkspaceReader:      pulumi.NewWorkspaceReader(),
+		EnvironmentReader:    pulumi.NewEnvironmentReader(),
+		StackInitializer:     pulumi.NewStackInitializer(),
+		ResourceImporter:     pulumi.NewResourceImporter(),
+		StackStateManager:    pulumi.NewStackStateManager(),
+		StateTransferer:      pulumi.NewStateTransferer(),
+		BackendAuthenticator: pulumi.NewBackendAuthenticator(),
+		PluginProvider:       &plugins.FakePluginProvider{AllEnv: te.Env},
+		Env:                  te.Env,
+		Logger:               slog.New(slog.NewTextHandler(io.Discard, nil)),
 	}
 
 	appCtx := AppContext{
@@ -63,8 +63,8 @@ func TestProtect_ProtectResource(t *testing.T) {
 	// Use filter to navigate to base-id resource reliably
 	filterToResource(h, "base-id")
 
-	// Protect the resource with 'P' - should execute immediately without confirmation
-	h.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	// Protect the resource with 'p' - should execute immediately without confirmation
+	h.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
 
 	// Wait for protected badge to appear on the resource
 	h.WaitFor("Protected", 10*time.Second)
@@ -96,7 +96,7 @@ func TestProtect_UnprotectResourceFlow(t *testing.T) {
 	filterToResource(h, "base-id")
 
 	// First protect the resource
-	h.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	h.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
 	h.WaitFor("Protected", 10*time.Second)
 
 	// After protect completes, the list reloads - use filter again to find resource
@@ -144,7 +144,7 @@ func TestProtect_UnprotectModalCancel(t *testing.T) {
 	filterToResource(h, "base-id")
 
 	// Protect the resource first
-	h.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	h.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
 	h.WaitFor("Protected", 10*time.Second)
 
 	// After protect completes, the list reloads - use filter again
@@ -189,7 +189,7 @@ func TestProtect_CannotProtectStackRoot(t *testing.T) {
 
 	// Stay on the root stack resource (first item, pulumi:pulumi:Stack)
 	// Try to protect - should do nothing since root stack cannot be protected
-	h.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	h.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
 
 	// Wait a moment and verify no Protected badge appears
 	time.Sleep(500 * time.Millisecond)
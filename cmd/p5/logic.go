@@ -1,8 +1,20 @@
 package main
 
 import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/rfhold/p5/internal/i18n"
 	"github.com/rfhold/p5/internal/plugins"
 	"github.com/rfhold/p5/internal/pulumi"
 	"github.com/rfhold/p5/internal/ui"
@@ -18,6 +30,12 @@ type PreviewEventResult struct {
 
 	// Resource item to add (nil if none)
 	Item *ui.ResourceItem
+
+	// Warning to collect for the warnings panel (nil if none)
+	Warning *pulumi.PreviewWarning
+
+	// Engine output to append to the diagnostics strip (nil if none)
+	Diagnostic *pulumi.EngineDiagnostic
 }
 
 // ProcessPreviewEvent processes a preview event and returns state changes.
@@ -55,6 +73,8 @@ func ProcessPreviewEvent(event pulumi.PreviewEvent, currentOpState OperationStat
 	if event.Step != nil {
 		result.Item = convertPreviewStepToItem(event.Step)
 	}
+	result.Warning = event.Warning
+	result.Diagnostic = event.Diagnostic
 
 	return result
 }
@@ -81,17 +101,18 @@ func convertPreviewStepToItem(step *pulumi.PreviewStep) *ui.ResourceItem {
 	}
 
 	return &ui.ResourceItem{
-		URN:        step.URN,
-		Type:       step.Type,
-		Name:       step.Name,
-		Op:         step.Op,
-		Status:     ui.StatusNone,
-		Parent:     step.Parent,
-		Sequence:   step.Sequence,
-		Inputs:     inputs,
-		Outputs:    outputs,
-		OldInputs:  oldInputs,
-		OldOutputs: oldOutputs,
+		URN:         step.URN,
+		Type:        step.Type,
+		Name:        step.Name,
+		Op:          step.Op,
+		Status:      ui.StatusNone,
+		Parent:      step.Parent,
+		Sequence:    step.Sequence,
+		Inputs:      inputs,
+		Outputs:     outputs,
+		OldInputs:   oldInputs,
+		OldOutputs:  oldOutputs,
+		ReplaceKeys: step.ReplaceKeys,
 	}
 }
 
@@ -144,29 +165,36 @@ func ProcessOperationEvent(event pulumi.OperationEvent, currentOpState Operation
 // convertOperationEventToItem converts an OperationEvent to a ResourceItem.
 func convertOperationEventToItem(event pulumi.OperationEvent) *ui.ResourceItem {
 	var status ui.ItemStatus
+	var started, finished time.Time
 	switch event.Status {
 	case pulumi.StepPending:
 		status = ui.StatusPending
 	case pulumi.StepRunning:
 		status = ui.StatusRunning
+		started = event.Time
 	case pulumi.StepSuccess:
 		status = ui.StatusSuccess
+		finished = event.Time
 	case pulumi.StepFailed:
 		status = ui.StatusFailed
+		finished = event.Time
 	}
 
 	return &ui.ResourceItem{
-		URN:        event.URN,
-		Type:       event.Type,
-		Name:       event.Name,
-		Op:         event.Op,
-		Parent:     event.Parent,
-		Sequence:   event.Sequence,
-		Status:     status,
-		Inputs:     event.Inputs,
-		Outputs:    event.Outputs,
-		OldInputs:  event.OldInputs,
-		OldOutputs: event.OldOutputs,
+		URN:         event.URN,
+		Type:        event.Type,
+		Name:        event.Name,
+		Op:          event.Op,
+		Parent:      event.Parent,
+		Sequence:    event.Sequence,
+		Status:      status,
+		Inputs:      event.Inputs,
+		Outputs:     event.Outputs,
+		OldInputs:   event.OldInputs,
+		OldOutputs:  event.OldOutputs,
+		StartedAt:   started,
+		FinishedAt:  finished,
+		ReplaceKeys: event.ReplaceKeys,
 	}
 }
 
@@ -192,6 +220,61 @@ func ConvertResourcesToItems(resources []pulumi.ResourceInfo) []ui.ResourceItem
 	return items
 }
 
+// DiffStateSnapshots compares two snapshots of a stack's state, marking resources
+// only in after as creates, only in before as deletes, and resources whose inputs
+// or outputs differ as updates. Unchanged resources are kept with OpSame when
+// includeUnchanged is set, and left out otherwise. Old copies of replaced resources
+// are left out, they share a URN with the live copy. Items follow the order of the
+// newer snapshot, with deleted resources appended.
+func DiffStateSnapshots(before, after []pulumi.ResourceInfo, includeUnchanged bool) []ui.ResourceItem {
+	previous := make(map[string]pulumi.ResourceInfo, len(before))
+	for _, r := range before {
+		if _, ok := previous[r.URN]; !ok && !r.PendingDelete {
+			previous[r.URN] = r
+		}
+	}
+
+	var live []pulumi.ResourceInfo
+	current := make(map[string]bool, len(after))
+	for _, r := range after {
+		if !current[r.URN] && !r.PendingDelete {
+			current[r.URN] = true
+			live = append(live, r)
+		}
+	}
+
+	items := make([]ui.ResourceItem, 0, len(live))
+	for _, item := range ConvertResourcesToItems(live) {
+		old, ok := previous[item.URN]
+		switch {
+		case !ok:
+			item.Op = pulumi.OpCreate
+		case !reflect.DeepEqual(old.Inputs, item.Inputs) || !reflect.DeepEqual(old.Outputs, item.Outputs):
+			item.Op = pulumi.OpUpdate
+			item.OldInputs = old.Inputs
+			item.OldOutputs = old.Outputs
+		case !includeUnchanged:
+			continue
+		}
+		items = append(items, item)
+	}
+
+	var deleted []pulumi.ResourceInfo
+	for _, r := range before {
+		if old, ok := previous[r.URN]; ok && !current[r.URN] {
+			deleted = append(deleted, old)
+			current[r.URN] = true // Add each URN once
+		}
+	}
+	for _, item := range ConvertResourcesToItems(deleted) {
+		item.Op = pulumi.OpDelete
+		item.OldInputs, item.OldOutputs = item.Inputs, item.Outputs
+		item.Inputs, item.Outputs = nil, nil
+		items = append(items, item)
+	}
+	return items
+}
+
 // ConvertHistoryToItems converts pulumi UpdateSummary slice to UI HistoryItems.
 // For local backends where Version may be 0, it calculates version from index.
 func ConvertHistoryToItems(history []pulumi.UpdateSummary) []ui.HistoryItem {
@@ -206,6 +289,7 @@ func ConvertHistoryToItems(history []pulumi.UpdateSummary) []ui.HistoryItem {
 		}
 		items = append(items, ui.HistoryItem{
 			Version:         version,
+			BackendVersion:  h.Version,
 			Kind:            h.Kind,
 			StartTime:       h.StartTime,
 			EndTime:         h.EndTime,
@@ -219,6 +303,43 @@ func ConvertHistoryToItems(history []pulumi.UpdateSummary) []ui.HistoryItem {
 	return items
 }
 
+// HashPreviewItems returns a hash of each item's planned change keyed by URN.
+// The hash covers the operation and the old and new inputs, so it changes whenever
+// the diff shown for a resource would change.
+func HashPreviewItems(items []ui.ResourceItem) map[string]string {
+	hashes := make(map[string]string, len(items))
+	for i := range items {
+		item := &items[i]
+		data, err := json.Marshal(struct {
+			Op        pulumi.ResourceOp `json:"op"`
+			Inputs    map[string]any    `json:"inputs,omitempty"`
+			OldInputs map[string]any    `json:"oldInputs,omitempty"`
+		}{item.Op, item.Inputs, item.OldInputs})
+		if err != nil {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		hashes[item.URN] = hex.EncodeToString(sum[:])
+	}
+	return hashes
+}
+
+// DetermineChangedDiffs returns the URNs whose hash differs from the previous preview.
+// Resources that are new to the preview count as changed. With no previous preview
+// there is nothing to compare against, so nothing is reported.
+func DetermineChangedDiffs(previous, current map[string]string) map[string]bool {
+	changed := make(map[string]bool)
+	if previous == nil {
+		return changed
+	}
+	for urn, hash := range current {
+		if previous[urn] != hash {
+			changed[urn] = true
+		}
+	}
+	return changed
+}
+
 // ConvertImportSuggestions converts plugin import suggestions to UI format.
 func ConvertImportSuggestions(suggestions []*plugins.AggregatedImportSuggestion) []ui.ImportSuggestion {
 	items := make([]ui.ImportSuggestion, 0, len(suggestions))
@@ -233,6 +354,64 @@ func ConvertImportSuggestions(suggestions []*plugins.AggregatedImportSuggestion)
 	return items
 }
 
+// ConvertImportIDValidation converts plugins' verdict on an import ID to UI format
+func ConvertImportIDValidation(result *plugins.ImportIDValidation) *ui.ImportIDValidation {
+	if result == nil {
+		return nil
+	}
+	return &ui.ImportIDValidation{Valid: result.Valid, Message: result.Message, PluginName: result.PluginName}
+}
+
+// ImportValidationDelay is how long typing must pause before plugins check the import ID
+const ImportValidationDelay = 400 * time.Millisecond
+
+// BulkImportPageSize is the number of importable resources requested from each plugin per page
+const BulkImportPageSize = 50
+
+// BuildBulkImportItems converts plugin importable resources to bulk import rows.
+// A resource whose suggested name matches a pending create of the same type is
+// imported as that resource, under its parent; others keep the suggested name.
+func BuildBulkImportItems(resources []*plugins.AggregatedImportableResource, resourceType string, previewItems []ui.ResourceItem) []ui.BulkImportItem {
+	creates := make(map[string]ui.ResourceItem)
+	for _, item := range previewItems {
+		if item.Op == pulumi.OpCreate && item.Type == resourceType {
+			creates[item.Name] = item
+		}
+	}
+
+	items := make([]ui.BulkImportItem, 0, len(resources))
+	for _, r := range resources {
+		name := cmp.Or(r.Resource.Name, r.Resource.Label, r.Resource.Id)
+		item := ui.BulkImportItem{
+			ID:          r.Resource.Id,
+			Name:        name,
+			Label:       cmp.Or(r.Resource.Label, r.Resource.Id),
+			Description: r.Resource.Description,
+			PluginName:  r.PluginName,
+		}
+		if create, ok := creates[name]; ok {
+			item.ParentURN = create.Parent
+			item.InProgram = true
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// BuildImportSpecs converts selected bulk import rows to a batch of import specs.
+func BuildImportSpecs(resourceType string, items []ui.BulkImportItem) []pulumi.ImportSpec {
+	specs := make([]pulumi.ImportSpec, 0, len(items))
+	for _, item := range items {
+		specs = append(specs, pulumi.ImportSpec{
+			Type:      resourceType,
+			Name:      item.Name,
+			ID:        item.ID,
+			ParentURN: item.ParentURN,
+		})
+	}
+	return specs
+}
+
 // StacksConversionResult holds the result of converting stacks
 type StacksConversionResult struct {
 	Items            []ui.StackItem
@@ -316,6 +495,77 @@ func ConvertWorkspacesToItems(workspaces []pulumi.WorkspaceInfo, cwd string) []u
 	return items
 }
 
+// GroupWorkspaceItems lists workspaces under the first of groups they belong to, in
+// the order of groups, followed by the rest under "Other". Without groups, the
+// workspaces are returned unchanged.
+func GroupWorkspaceItems(items []ui.WorkspaceItem, groups []plugins.WorkspaceGroupConfig) []ui.WorkspaceItem {
+	if len(groups) == 0 {
+		return items
+	}
+	byGroup := make([][]ui.WorkspaceItem, len(groups)+1) // Last for workspaces in no group
+	for _, item := range items {
+		group := len(groups)
+		item.Section = i18n.T("Other")
+		for i := range groups {
+			if groups[i].Contains(item.Path) {
+				group, item.Section = i, groups[i].Name
+				break
+			}
+		}
+		byGroup[group] = append(byGroup[group], item)
+	}
+	return slices.Concat(byGroup...)
+}
+
+// MergeRecentWorkspaces lists recent workspaces in a "Recent" section above the
+// discovered ones, pinned first. Recent workspaces are named after the discovered
+// workspace at the same path, or their directory when outside the search.
+// Discovered workspaces are only listed once, and those in no group are listed
+// under "Workspaces".
+func MergeRecentWorkspaces(items []ui.WorkspaceItem, recent []plugins.RecentWorkspace, cwd, workDir string) []ui.WorkspaceItem {
+	if len(recent) == 0 {
+		return items
+	}
+
+	byPath := make(map[string]ui.WorkspaceItem, len(items))
+	for _, item := range items {
+		byPath[item.Path] = item
+	}
+
+	merged := make([]ui.WorkspaceItem, 0, len(items)+len(recent))
+	listed := make(map[string]bool, len(recent))
+	for _, r := range recent {
+		item, ok := byPath[r.Path]
+		if !ok {
+			item = ui.WorkspaceItem{
+				Path:         r.Path,
+				RelativePath: r.Path,
+				Name:         filepath.Base(r.Path),
+				Current:      r.Path == workDir,
+			}
+			if cwd != "" {
+				if rel, err := filepath.Rel(cwd, r.Path); err == nil {
+					item.RelativePath = rel
+				}
+			}
+		}
+		item.Section = i18n.T("Recent")
+		item.Pinned = r.Pinned
+		item.LastStack = r.Stack
+		merged = append(merged, item)
+		listed[r.Path] = true
+	}
+	for _, item := range items {
+		if !listed[item.Path] {
+			if item.Section == "" {
+				item.Section = i18n.T("Workspaces")
+			}
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
 // CanImportResource determines if the current selection can be imported.
 // Import is only valid for create operations in preview view.
 func CanImportResource(viewMode ui.ViewMode, selectedItem *ui.ResourceItem) bool {
@@ -328,6 +578,120 @@ func CanImportResource(viewMode ui.ViewMode, selectedItem *ui.ResourceItem) bool
 	return selectedItem.Op == pulumi.OpCreate
 }
 
+// DescribeBlastRadius summarizes the resources destroying targets affects, or the
+// whole stack with no targets, and lists them one per line for the confirmation modal
+func DescribeBlastRadius(resources []pulumi.ResourceInfo, targets []string) (summary string, lines []string) {
+	radius := pulumi.BlastRadius(resources, targets)
+	targeted, protected := 0, 0
+	lines = make([]string, 0, len(radius))
+	for _, r := range radius {
+		line := ui.DimStyle.Render(r.Type) + "  " + ui.ValueStyle.Render(r.Name)
+		if slices.Contains(targets, r.URN) {
+			targeted++
+			line += ui.DimStyle.Render(" (" + i18n.T("target") + ")")
+		}
+		if r.Protected {
+			protected++
+			line += " " + ui.FlagProtectStyle.Render("[protected]")
+		}
+		lines = append(lines, line)
+	}
+
+	if len(targets) == 0 {
+		summary = i18n.Tf("Destroys all %d resources in the stack", len(radius))
+	} else {
+		summary = i18n.Tf("Destroys %d resources: %d targeted, %d children and dependents", len(radius), targeted, len(radius)-targeted)
+	}
+	if protected > 0 {
+		summary += "\n" + i18n.Tf("%d are protected, which stops the destroy", protected)
+	}
+	return summary, lines
+}
+
+// GitGuardProblems lists why up on stackName shouldn't run from the git checkout,
+// or nil when the stack isn't guarded or the checkout is unknown
+func GitGuardProblems(guard *plugins.GitGuardConfig, git *pulumi.GitInfo, stackName string) []string {
+	if guard == nil || git == nil || !guard.Guards(stackName) {
+		return nil
+	}
+	var problems []string
+	if git.Dirty && !guard.AllowDirty {
+		problems = append(problems, i18n.T("The working tree has uncommitted changes"))
+	}
+	if expected := guard.ExpectedBranch(); git.Branch != expected {
+		branch := git.Branch
+		if branch == "" {
+			branch = i18n.T("(detached)")
+		}
+		problems = append(problems, i18n.Tf("Deploying from %s instead of %s", branch, expected))
+	}
+	return problems
+}
+
+// AutoApproveProblems lists the auto-approval rules a preview's changes break, or
+// nil when they may run without confirmation. A replacement is counted once,
+// though the preview lists its create and delete steps.
+func AutoApproveProblems(rule *plugins.AutoApproveConfig, items []ui.ResourceItem) []string {
+	changed := make(map[string]bool)
+	var creates, deletes, replaces int
+	for _, item := range items {
+		switch item.Op {
+		case ui.OpSame, ui.OpRead, "":
+			continue
+		case ui.OpCreate:
+			creates++
+		case ui.OpDelete:
+			deletes++
+		case ui.OpReplace, ui.OpCreateReplace, ui.OpDeleteReplace:
+			if !changed[item.URN] {
+				replaces++
+			}
+		}
+		changed[item.URN] = true
+	}
+
+	var problems []string
+	if rule.MaxChanges > 0 && len(changed) > rule.MaxChanges {
+		problems = append(problems, i18n.Tf("%d changes, more than the %d allowed", len(changed), rule.MaxChanges))
+	}
+	if creates > 0 && !rule.Creates {
+		problems = append(problems, i18n.Tf("%d to create", creates))
+	}
+	if deletes > 0 && !rule.Deletes {
+		problems = append(problems, i18n.Tf("%d to delete", deletes))
+	}
+	if replaces > 0 && !rule.Replaces {
+		problems = append(problems, i18n.Tf("%d to replace", replaces))
+	}
+	return problems
+}
+
+// StateDeleteLines lists the resources a state delete removes, one per line for
+// the confirmation modal
+func StateDeleteLines(resources []ui.SelectedResource) []string {
+	lines := make([]string, 0, len(resources))
+	for _, r := range resources {
+		lines = append(lines, ui.DimStyle.Render(r.Type)+"  "+ui.ValueStyle.Render(r.Name))
+	}
+	return lines
+}
+
+// OrderStateDeletes orders resources to remove from state so children and
+// dependents are removed before the resources they refer to
+func OrderStateDeletes(stackResources []pulumi.ResourceInfo, resources []ui.SelectedResource) []ui.SelectedResource {
+	byURN := make(map[string]ui.SelectedResource, len(resources))
+	urns := make([]string, len(resources))
+	for i, res := range resources {
+		byURN[res.URN] = res
+		urns[i] = res.URN
+	}
+	ordered := make([]ui.SelectedResource, 0, len(resources))
+	for _, urn := range pulumi.StateDeleteOrder(stackResources, urns) {
+		ordered = append(ordered, byURN[urn])
+	}
+	return ordered
+}
+
 // CanDeleteFromState determines if the current selection can be deleted from state.
 // State delete is only valid in stack view and not for the root stack resource.
 func CanDeleteFromState(viewMode ui.ViewMode, selectedItem *ui.ResourceItem) bool {
@@ -482,28 +846,117 @@ func FormatClipboardMessage(count int, selectedItemName string) string {
 	switch {
 	case count == 1:
 		if selectedItemName != "" {
-			return "Copied " + selectedItemName
+			return i18n.Tf("Copied %s", selectedItemName)
 		}
-		return "Copied resource"
+		return i18n.T("Copied resource")
 	case count > 1:
-		return "Copied " + itoa(count) + " resources"
+		return i18n.Tf("Copied %d resources", count)
+	default:
+		return i18n.T("Copied to clipboard")
+	}
+}
+
+// MergeEnvironmentVariables combines environment variables from ESC environments.
+// Later environments override earlier ones, matching ESC import semantics.
+func MergeEnvironmentVariables(envs []*pulumi.StackEnvironment) map[string]string {
+	merged := make(map[string]string)
+	for _, env := range envs {
+		if env == nil {
+			continue
+		}
+		maps.Copy(merged, env.EnvironmentVariables)
+	}
+	return merged
+}
+
+// ResolveResource finds a stack resource by URN or logical name.
+// A URN match is exact; a name must match exactly one resource.
+func ResolveResource(resources []pulumi.ResourceInfo, query string) (*pulumi.ResourceInfo, error) {
+	var matches []*pulumi.ResourceInfo
+	for i := range resources {
+		r := &resources[i]
+		if r.URN == query {
+			return r, nil
+		}
+		if r.Name == query {
+			matches = append(matches, r)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("resource %q not found in stack", query)
+	case 1:
+		return matches[0], nil
 	default:
-		return "Copied to clipboard"
+		var b strings.Builder
+		fmt.Fprintf(&b, "resource name %q matches %d resources, use a URN instead:", query, len(matches))
+		for _, r := range matches {
+			b.WriteString("\n  ")
+			b.WriteString(r.URN)
+		}
+		return nil, errors.New(b.String())
 	}
 }
 
-// itoa is a simple int-to-string without importing strconv.
-func itoa(i int) string {
-	if i == 0 {
-		return "0"
+// DashboardStack identifies a stack shown on the multi-stack dashboard
+type DashboardStack struct {
+	Workspace pulumi.WorkspaceInfo
+	Stack     pulumi.StackInfo
+}
+
+// DashboardHistoryPageSize is how many updates are read per stack to find the
+// last update and the most recent refresh
+const DashboardHistoryPageSize = 10
+
+// NewDashboardRow builds a dashboard row from a stack's history (newest first)
+// and resource count
+func NewDashboardRow(target DashboardStack, cwd string, history []pulumi.UpdateSummary, resourceCount int) ui.DashboardRow {
+	relPath := target.Workspace.Path
+	if cwd != "" {
+		if rel, err := filepath.Rel(cwd, target.Workspace.Path); err == nil {
+			relPath = rel
+		}
+	}
+
+	row := ui.DashboardRow{
+		WorkDir:       target.Workspace.Path,
+		Workspace:     relPath,
+		Project:       target.Workspace.Name,
+		Stack:         target.Stack.Name,
+		Current:       target.Stack.Current,
+		ResourceCount: resourceCount,
+		Drift:         DetectDrift(history),
 	}
-	if i < 0 {
-		return "-" + itoa(-i)
+	if len(history) > 0 {
+		row.LastKind = history[0].Kind
+		row.LastResult = history[0].Result
+		row.LastTime = history[0].StartTime
 	}
-	var digits []byte
-	for i > 0 {
-		digits = append([]byte{byte('0' + i%10)}, digits...)
-		i /= 10
+	return row
+}
+
+// DetectDrift reports drift from the most recent refresh in history (newest first).
+// A refresh only counts if no other state-changing update ran after it; previews
+// and failed refreshes are skipped.
+func DetectDrift(history []pulumi.UpdateSummary) ui.DriftStatus {
+	for _, h := range history {
+		switch h.Kind {
+		case "preview":
+			continue
+		case "refresh":
+			if h.Result != "succeeded" {
+				continue
+			}
+			for op, count := range h.ResourceChanges {
+				if op != "same" && count > 0 {
+					return ui.DriftDetected
+				}
+			}
+			return ui.DriftNone
+		default:
+			return ui.DriftUnknown
+		}
 	}
-	return string(digits)
+	return ui.DriftUnknown
 }
@@ -0,0 +1,79 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// loginOptions returns the options to log in to the backend entered in the login modal
+func (m *Model) loginOptions() pulumi.LoginOptions {
+	return pulumi.LoginOptions{
+		URL:   m.ui.LoginModal.Backend(),
+		Token: m.ui.LoginModal.Token(),
+		Env:   mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv()),
+	}
+}
+
+// login logs in to the backend entered in the login modal. Pulumi Cloud without a
+// token hands the terminal to `pulumi login`, which opens the browser.
+func (m *Model) login() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	authenticator := m.deps.BackendAuthenticator
+	appCtx := m.appCtx
+	opts := m.loginOptions()
+
+	if m.ui.LoginModal.NeedsToken() && opts.Token == "" {
+		return tea.ExecProcess(authenticator.LoginCommand(workDir, opts), func(err error) tea.Msg {
+			return loginDoneMsg{Err: err}
+		})
+	}
+	return tea.Batch(
+		m.ui.Toast.Show(i18n.Tf("Logging in to %s...", opts.URL)),
+		func() tea.Msg {
+			info, err := authenticator.Login(appCtx, workDir, opts)
+			return loginDoneMsg{Info: info, Err: err}
+		},
+	)
+}
+
+// updateLoginModal handles keys when the login modal has focus
+func (m Model) updateLoginModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.LoginModal.Update(msg)
+	switch action {
+	case ui.StepModalActionNext:
+		// Self-managed backends don't take a token
+		if m.ui.LoginModal.NeedsToken() {
+			return m, cmd
+		}
+		fallthrough
+	case ui.StepModalActionConfirm:
+		m.hideLoginModal()
+		return m, m.login()
+	case ui.StepModalActionCancel:
+		m.hideLoginModal()
+	}
+	return m, cmd
+}
+
+// handleLoginDone starts over loading the workspace once logged in, or prompts
+// again with the error
+func (m Model) handleLoginDone(msg loginDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.showLoginModal(msg.Err)
+		m.ui.LoginModal.SetError(msg.Err)
+		return m, nil
+	}
+	toast := i18n.T("Logged in")
+	if msg.Info != nil && msg.Info.User != "" {
+		toast = i18n.Tf("Logged in to %s as %s", msg.Info.URL, msg.Info.User)
+	}
+
+	m.state.Err = nil
+	m.ui.Header.ClearError()
+	m.ui.ResourceList.ClearError()
+	model, cmd := m.handleWorkspaceCheck(true)
+	return model, tea.Batch(m.ui.Toast.Show(toast), cmd)
+}
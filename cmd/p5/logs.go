@@ -0,0 +1,45 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// logsRefreshInterval is how often the open log viewer picks up new records
+const logsRefreshInterval = time.Second
+
+// showLogs shows the debug log viewer and pushes focus to it. The returned
+// command keeps the viewer showing new records while it is open.
+func (m *Model) showLogs() tea.Cmd {
+	m.ui.Logs.SetEntries(m.deps.Logs.Entries())
+	m.ui.Logs.Show()
+	m.ui.Focus.Push(ui.FocusLogs)
+	m.state.LogsRefreshSeq++
+	return refreshLogsAfter(logsRefreshInterval, m.state.LogsRefreshSeq)
+}
+
+// hideLogs hides the debug log viewer and pops focus
+func (m *Model) hideLogs() {
+	m.ui.Logs.Hide()
+	m.ui.Focus.Remove(ui.FocusLogs)
+}
+
+// refreshLogsAfter refreshes the log viewer once d has passed
+func refreshLogsAfter(d time.Duration, seq int) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return logsTickMsg{Seq: seq}
+	})
+}
+
+// handleLogsTick shows the records logged since the last refresh while the
+// log viewer is open
+func (m Model) handleLogsTick(msg logsTickMsg) (tea.Model, tea.Cmd) {
+	if msg.Seq != m.state.LogsRefreshSeq || !m.ui.Focus.Has(ui.FocusLogs) {
+		return m, nil
+	}
+	m.ui.Logs.SetEntries(m.deps.Logs.Entries())
+	return m, refreshLogsAfter(logsRefreshInterval, msg.Seq)
+}
@@ -7,11 +7,16 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/plugins"
 	_ "github.com/rfhold/p5/internal/plugins/builtins" // Register builtin plugins
+	"github.com/rfhold/p5/internal/pulumi"
 	"github.com/rfhold/p5/internal/telemetry"
+	"github.com/rfhold/p5/internal/ui"
 )
 
 // Package-level variables for CLI argument parsing.
@@ -19,9 +24,12 @@ import (
 var argWorkDir string
 var argStackName string
 var argDebug bool
+var argEventsJSON string
 
 // Update handles messages
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer recordUpdate(msg, time.Now())
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		return m.handleWindowSize(msg)
@@ -44,12 +52,22 @@ func run() int {
 	flag.StringVar(&argStackName, "s", "", "Select the Pulumi `stack` to use")
 	flag.StringVar(&argStackName, "stack", "", "Select the Pulumi `stack` to use")
 	flag.BoolVar(&argDebug, "debug", false, "Enable debug logging")
+	flag.StringVar(&argEventsJSON, "events-json", "", "Write preview and operation events as JSON lines to a `file` or fd number")
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: p5 [flags] [command]\n\n")
 		fmt.Fprintf(os.Stderr, "Commands:\n")
 		fmt.Fprintf(os.Stderr, "  up        Start with up preview\n")
 		fmt.Fprintf(os.Stderr, "  refresh   Start with refresh preview\n")
 		fmt.Fprintf(os.Stderr, "  destroy   Start with destroy preview\n")
+		fmt.Fprintf(os.Stderr, "  dashboard Start with an overview of every stack\n")
+		fmt.Fprintf(os.Stderr, "  run <workflow>\n")
+		fmt.Fprintf(os.Stderr, "            Run a workflow defined in p5.toml\n")
+		fmt.Fprintf(os.Stderr, "  open <resource>\n")
+		fmt.Fprintf(os.Stderr, "            Open a resource by name or URN using a plugin action\n")
+		fmt.Fprintf(os.Stderr, "  state <file|url> [file|url]\n")
+		fmt.Fprintf(os.Stderr, "            Browse exported stack state read-only, or diff two exports\n")
+		fmt.Fprintf(os.Stderr, "  preview [up|refresh|destroy] [--format=github|markdown]\n")
+		fmt.Fprintf(os.Stderr, "            Run a preview without the TUI and report it for CI\n")
 		fmt.Fprintf(os.Stderr, "\nFlags:\n")
 		flag.PrintDefaults()
 	}
@@ -91,8 +109,155 @@ func run() int {
 		ctx.WorkDir = argWorkDir
 	}
 
+	// `p5 state` browses exported state files instead of a workspace
+	if ctx.StartView == "state" {
+		if len(args) < 2 || len(args) > 3 {
+			fmt.Fprintf(os.Stderr, "Error: state requires one or two state files or URLs\n")
+			return 1
+		}
+		ctx.StateFiles = args[1:]
+	}
+
+	// `p5 run <workflow>` starts the workflow once the stack has loaded
+	if ctx.StartView == "run" {
+		if len(args) != 2 {
+			fmt.Fprintf(os.Stderr, "Error: run requires exactly one workflow name\n")
+			return 1
+		}
+		workflows, err := plugins.LoadWorkflows(ctx.WorkDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if _, ok := workflows[args[1]]; !ok {
+			fmt.Fprintf(os.Stderr, "Error: workflow %q is not defined in p5.toml\n", args[1])
+			return 1
+		}
+		ctx.Workflow = args[1]
+	}
+
+	// Select the UI language before any components are constructed
+	setupLocale(ctx.WorkDir)
+
+	// Apply the color theme before any components copy its styles
+	if err := setupTheme(ctx.WorkDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// Remap keybindings from p5.toml and Pulumi.yaml, when configured
+	if err := setupKeyBindings(ctx.WorkDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// Match list filters fuzzily before any components are constructed, when configured
+	fuzzyFilter, err := plugins.LoadFuzzyFilter(ctx.WorkDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	ui.SetFuzzyFilters(fuzzyFilter)
+
+	// Copy over OSC52 in SSH sessions and when the native clipboard fails, or as configured
+	clipboard, err := plugins.LoadClipboard(ctx.WorkDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if err := ui.SetClipboard(clipboard); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: clipboard: %v\n", err)
+		return 1
+	}
+
+	// Lock the TUI after inactivity on protected stacks, when configured
+	idleLock, err := plugins.LoadIdleLockConfig(ctx.WorkDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	ctx.IdleLock = idleLock
+
+	// Restore the details panel width saved in p5.toml
+	detailsWidth, err := plugins.LoadDetailsWidth(ctx.WorkDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	ctx.DetailsWidth = detailsWidth
+
+	// Check for stack updates made elsewhere while idle, when configured
+	pollInterval, err := plugins.LoadPollInterval(ctx.WorkDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	ctx.PollInterval = pollInterval
+
+	// Load stack history in pages of the configured size
+	historyPageSize, err := plugins.LoadHistoryPageSize(ctx.WorkDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	ctx.HistoryPageSize = historyPageSize
+
+	// Refresh the state as part of up by default, when configured
+	refreshOnUp, err := plugins.LoadRefreshOnUp(ctx.WorkDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	ctx.RefreshOnUp = refreshOnUp
+
+	// Ask for a message to record with each up and destroy, when configured
+	updateMessage, err := plugins.LoadUpdateMessage(ctx.WorkDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	ctx.UpdateMessage = updateMessage
+
+	// Limit and parallelize the search for workspaces, when configured
+	workspaceSearch, err := setupWorkspaceSearch(ctx.WorkDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	ctx.WorkspaceSearch = workspaceSearch
+
+	// Group the workspace selector by the workspace groups, when configured
+	workspaceGroups, err := plugins.LoadWorkspaceGroups(ctx.WorkDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	ctx.WorkspaceGroups = workspaceGroups
+
+	// Export UI and operation metrics alongside traces, when configured
+	metrics, err := plugins.LoadMetrics(ctx.WorkDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if metrics {
+		if err := tel.StartMetrics(context.Background()); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to setup metrics: %v\n", err)
+		}
+	}
+
 	// Create production dependencies
 	deps := NewProductionDependencies(ctx.WorkDir, tel.Logger)
+	deps.Logs = tel.Logs
+	if argEventsJSON != "" {
+		events, err := openEventStream(argEventsJSON)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to open event stream: %v\n", err)
+			return 1
+		}
+		defer events.Close()
+		deps.StackOperator = pulumi.NewEventStreamOperator(deps.StackOperator, events)
+	}
 
 	// Create application-level context with cancellation for graceful shutdown.
 	// This context is passed through to all async operations, enabling them to
@@ -107,6 +272,30 @@ func run() int {
 		appCancel()
 	}()
 
+	// `p5 open <resource>` runs the plugin open action without starting the TUI
+	if ctx.StartView == "open" {
+		defer appCancel()
+		if len(args) != 2 {
+			fmt.Fprintf(os.Stderr, "Error: open requires exactly one resource name or URN\n")
+			return 1
+		}
+		if err := runOpen(appCtx, ctx, deps, args[1]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	// `p5 preview` reports a preview for CI without starting the TUI
+	if ctx.StartView == "preview" {
+		defer appCancel()
+		code, err := runPreviewReport(appCtx, ctx, deps, args[1:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		return code
+	}
+
 	p := tea.NewProgram(initialModel(appCtx, ctx, deps), tea.WithAltScreen(), tea.WithMouseCellMotion())
 	_, err = p.Run()
 	appCancel() // Cancel context before potential exit
@@ -116,3 +305,55 @@ func run() int {
 	}
 	return 0
 }
+
+// setupLocale sets the UI locale from p5.toml, falling back to the environment
+func setupLocale(workDir string) {
+	var configured string
+	if cfg, _, err := plugins.LoadGlobalConfig(workDir); err == nil {
+		configured = cfg.Locale
+	}
+	i18n.SetLocale(i18n.Detect(configured))
+}
+
+// setupWorkspaceSearch loads the workspace search options configured in p5.toml
+func setupWorkspaceSearch(workDir string) (pulumi.WorkspaceSearchOptions, error) {
+	cfg, err := plugins.LoadWorkspaceSearch(workDir)
+	if err != nil {
+		return pulumi.WorkspaceSearchOptions{}, err
+	}
+	if _, err := pulumi.ParseIgnorePatterns(cfg.Ignore); err != nil {
+		return pulumi.WorkspaceSearchOptions{}, fmt.Errorf("workspace_search: %w", err)
+	}
+	return pulumi.WorkspaceSearchOptions{
+		MaxDepth:    cfg.MaxDepth,
+		Ignore:      cfg.Ignore,
+		Parallelism: cfg.Parallelism,
+	}, nil
+}
+
+// setupTheme applies the color theme, resource icons and syntax highlighting
+// configured in p5.toml
+func setupTheme(workDir string) error {
+	cfg, _, err := plugins.LoadGlobalConfig(workDir)
+	if err != nil {
+		return err
+	}
+	if err := ui.ApplyTheme(cfg.Theme.Name(), cfg.Theme.Colors()); err != nil {
+		return fmt.Errorf("theme: %w", err)
+	}
+	ui.SetIcons(cfg.Icons)
+	ui.SetSyntaxHighlighting(cfg.SyntaxHighlight == nil || *cfg.SyntaxHighlight)
+	return nil
+}
+
+// setupKeyBindings applies the keybindings configured for the workspace
+func setupKeyBindings(workDir string) error {
+	bindings, err := plugins.LoadKeyBindings(workDir)
+	if err != nil {
+		return err
+	}
+	if err := ui.ApplyKeyBindings(bindings); err != nil {
+		return fmt.Errorf("keys: %w", err)
+	}
+	return nil
+}
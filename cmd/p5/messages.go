@@ -3,40 +3,265 @@ package main
 import (
 	"github.com/rfhold/p5/internal/plugins"
 	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
 )
 
 // Messages for data fetching
 type projectInfoMsg *pulumi.ProjectInfo
 type errMsg error
 type previewEventMsg pulumi.PreviewEvent
+
+// quickCheckEventMsg wraps an event of a quick check, with the channel it came
+// from so the events of a replaced check can be told apart
+type quickCheckEventMsg struct {
+	ch    <-chan pulumi.PreviewEvent
+	Event pulumi.PreviewEvent
+}
 type operationEventMsg pulumi.OperationEvent
 type stackResourcesMsg []pulumi.ResourceInfo
 type stacksListMsg struct {
 	Stacks []pulumi.StackInfo
 	Files  []pulumi.StackFileInfo
 }
+type initDataMsg struct {
+	Stacks      []pulumi.StackInfo
+	Files       []pulumi.StackFileInfo
+	WhoAmI      *pulumi.WhoAmIInfo // Nil when not fetched, e.g. for the stack selector
+	ProjectInfo *pulumi.ProjectInfo
+}
 type stackSelectedMsg string
-type workspacesListMsg []pulumi.WorkspaceInfo
+type workspacesListMsg struct {
+	Workspaces []pulumi.WorkspaceInfo
+	Recent     []plugins.RecentWorkspace
+}
+type workspacePinnedMsg struct {
+	Path   string
+	Pinned bool
+	Recent []plugins.RecentWorkspace // Recent workspaces after the change
+	Err    error
+}
 type workspaceSelectedMsg string
 type workspaceCheckMsg bool // true if current dir is a valid workspace
-type stackHistoryMsg []pulumi.UpdateSummary
+type dashboardMsg []ui.DashboardRow
+type dashboardErrMsg struct{ Err error }
+type dashboardStackSelectedMsg struct {
+	WorkDir   string
+	StackName string
+}
+type stackReferenceResolvedMsg struct {
+	WorkDir   string // Workspace of the referenced stack
+	StackName string // Referenced stack as written in the reference
+	Err       error
+}
+type idleCheckMsg struct{}
+type statePollMsg struct{}
+type stateVersionMsg struct {
+	WorkDir   string
+	StackName string
+	Revision  string // Revision of the stack's latest update, empty when it has none
+}
+type gitInfoMsg struct {
+	WorkDir  string
+	Info     *pulumi.GitInfo // Nil outside of a git repository
+	Err      error
+	Guard    *plugins.GitGuardConfig // Nil when up isn't guarded
+	GuardErr error
+}
+
+// stackHistoryMsg is a page of the stack's history. Errors loading the first page
+// are reported as an errMsg instead.
+type stackHistoryMsg struct {
+	Page    int
+	History []pulumi.UpdateSummary
+	Err     error
+}
+type historyDiffMsg struct {
+	Version int
+	Before  []pulumi.ResourceInfo // Snapshot from the previous update
+	After   []pulumi.ResourceInfo // Snapshot from the selected update
+}
+type historyDiffErrMsg struct {
+	Version int
+	Error   error
+}
+type stackEnvironmentsMsg struct {
+	StackName    string
+	Environments []*pulumi.StackEnvironment
+}
+type stackEnvironmentsErrMsg struct {
+	StackName string
+	Error     error
+}
 type importResultMsg *pulumi.CommandResult
 type stateDeleteResultMsg *pulumi.CommandResult
-type bulkStateDeleteResultMsg struct {
-	Succeeded int
-	Failed    int
-	Errors    []string // Error messages for failed deletions
+type stateDeleteStepMsg struct {
+	URN string // Resource removed by this step of a bulk state delete
+	Err error
+}
+type runArtifactsMsg struct {
+	Dir string // Written directory, relative to the project when inside it
+	Err error
+}
+type postOperationHooksMsg struct {
+	Results []plugins.PostOperationResult
+}
+type stateFilesMsg struct {
+	States [][]pulumi.ResourceInfo // Resources of each file, in the order given
+	Err    error
+}
+type savedFlagsMsg struct {
+	WorkDir   string
+	StackName string
+	Persist   bool // Project enables saving flags
+	Flags     map[string]ui.ResourceFlags
+	Err       error
+}
+type stackProtectionMsg struct {
+	WorkDir     string
+	StackName   string
+	Level       plugins.ProtectionLevel
+	Confirm     plugins.ConfirmConfig
+	AutoApprove *plugins.AutoApproveConfig // nil when small changes aren't auto-approved
+	Err         error
+}
+type keyBindingsMsg struct {
+	WorkDir  string
+	Bindings map[string][]string // Keys by action name
+	Err      error
+}
+type updateCancelMsg struct {
+	Err error
+}
+type pendingOperationsMsg struct {
+	Ops []pulumi.PendingOperation
+	Err error
+}
+type stackRecoveredMsg struct {
+	Result *pulumi.CancelPendingResult
+	Err    error
+}
+
+// stackResultsMsg is sent with the result of each stack's most recent update
+type stackResultsMsg struct {
+	WorkDir string
+	Results map[string]string
+}
+
+// stackRemovedMsg is sent when removing a stack after a destroy finished
+type stackRemovedMsg struct {
+	StackName string
+	Err       error
+}
+type stateExportedMsg struct {
+	File *pulumi.DeploymentFile
+	Err  error
+}
+type stateFileCheckedMsg struct {
+	File *pulumi.DeploymentFile
+	Err  error
+}
+type stateImportedMsg struct {
+	StackName string
+	File      *pulumi.DeploymentFile
+	Err       error
+}
+type resourceStateMsg struct {
+	URN   string
+	Name  string
+	State []byte
+	Err   error
+}
+type resourceStateEditedMsg struct {
+	Err error // Error running the editor
+}
+type resourceStateWrittenMsg struct {
+	Name string
+	Err  error
+}
+type sourceEditorDoneMsg struct {
+	Err error // Error running the editor
+}
+type aboutMsg AboutDiagnostics
+type loginDoneMsg struct {
+	Info *pulumi.WhoAmIInfo // Nil after an interactive login
+	Err  error
+}
+type configCopiedMsg struct {
+	StackName string
+	Result    *pulumi.ConfigCopyResult
+	Err       error
+}
+type detailsResizeEndedMsg struct {
+	Seq int // Resize key press the wait started after
+}
+type detailsWidthSavedMsg struct {
+	Err error
+}
+type flagsSavedMsg struct {
+	StackName string
+	Cleared   bool // Saved flags were cleared by the user
+	Err       error
+}
+type notesMsg struct {
+	WorkDir string
+	Notes   map[string]string
+	Err     error
+}
+type noteSavedMsg struct {
+	URN  string
+	Note string // Saved note, empty when it was removed
+	Err  error
+}
+type diffIgnoreMsg struct {
+	WorkDir string
+	Rules   ui.DiffIgnoreRules
+	Err     error
+}
+type pinnedMsg struct {
+	WorkDir string
+	Pinned  map[string]bool
+	Err     error
+}
+type pinnedSavedMsg struct {
+	Err error
+}
+type stateRepairResultMsg struct {
+	Report *pulumi.StateRepairReport
+	DryRun bool
+	Err    error
 }
 type protectResultMsg struct {
 	Result    *pulumi.CommandResult
-	Protected bool   // true if protecting, false if unprotecting
-	URN       string // the resource URN
-	Name      string // the resource name (for toast message)
+	Protected bool                  // true if protecting, false if unprotecting
+	Resources []ui.SelectedResource // the resources changed (names for toast message)
 }
 
 // Plugin-related messages
 type pluginAuthResultMsg []plugins.AuthenticateResult
-type pluginAuthErrorMsg error
+
+// credentialRefreshMsg is sent when plugin credentials are due for refresh
+type credentialRefreshMsg struct {
+	Seq int
+}
+
+// credentialsRefreshedMsg holds the results of refreshing plugin credentials
+type credentialsRefreshedMsg []plugins.AuthenticateResult
+
+// logsTickMsg is sent when the open log viewer is due to show new log records
+type logsTickMsg struct {
+	Seq int
+}
+
+// statusBadgeTickMsg is sent when plugin status badges are due to be polled
+type statusBadgeTickMsg struct {
+	Seq int
+}
+
+// statusBadgesMsg holds the status badges returned by plugins
+type statusBadgesMsg struct {
+	Seq    int
+	Badges []plugins.StatusBadge
+}
 
 // authCompleteMsg is sent when plugin authentication completes (success or error)
 // This message always releases the auth busy lock and executes pending operations
@@ -51,6 +276,85 @@ type pluginInitDoneMsg struct {
 	err     error
 }
 
+// stackTagsMsg is sent when the stack's tags have been fetched
+type stackTagsMsg struct {
+	StackName string
+	Tags      map[string]string
+	Err       error
+}
+
+// stackTagSetMsg is sent when a stack tag has been set or removed
+type stackTagSetMsg struct {
+	Key   string
+	Value string
+	Err   error
+}
+
+// pluginIndexMsg is sent when the plugin index has been fetched
+type pluginIndexMsg struct {
+	Index *plugins.PluginIndex
+	Err   error
+}
+
+// pluginInstalledMsg is sent when a plugin from the index finished installing
+type pluginInstalledMsg struct {
+	Name   string
+	Result *plugins.InstallResult
+	Err    error
+}
+
+// cloudStacksMsg is sent when the stacks of every project in the organization have been listed
+type cloudStacksMsg struct {
+	Stacks []pulumi.CloudStack
+	Err    error
+}
+
+// cloudStackStateMsg is sent when the state of a stack of the organization has been read
+type cloudStackStateMsg struct {
+	Name      string
+	Resources []pulumi.ResourceInfo
+	Err       error
+}
+
+// workflowsMsg is sent when the workflows in p5.toml have been loaded
+type workflowsMsg struct {
+	Workflows map[string]plugins.WorkflowConfig
+	Err       error
+}
+
+// targetSetsMsg is sent when the target sets in p5.toml have been loaded
+type targetSetsMsg struct {
+	Sets map[string]plugins.TargetSetConfig
+	Err  error
+}
+
+// targetSetSavedMsg is sent when a target set has been saved to p5.toml
+type targetSetSavedMsg struct {
+	Name string
+	Path string
+	Err  error
+}
+
+// secretRevealedMsg is sent when a secret of a resource has been decrypted
+type secretRevealedMsg struct {
+	URN   string
+	Path  string
+	Value any
+	Err   error
+}
+
+// pagerDoneMsg is sent when $PAGER exits after showing a large value
+type pagerDoneMsg struct {
+	Err error
+}
+
+// workflowReadyMsg is sent when a workflow's queue is ready to run
+type workflowReadyMsg struct {
+	Name  string
+	Queue *OperationQueue
+	Err   error
+}
+
 // initPreviewMsg is sent to start a preview from Init
 type initPreviewMsg struct {
 	op pulumi.OperationType
@@ -59,13 +363,51 @@ type initPreviewMsg struct {
 
 // Import suggestion messages
 type importSuggestionsMsg []*plugins.AggregatedImportSuggestion
-type importSuggestionsErrMsg error
+type importSuggestionsErrMsg struct{ Err error }
+
+// Import ID validation messages
+type importValidateTickMsg struct{ Seq int }
+type importIDValidatedMsg struct {
+	Seq    int
+	Result *plugins.ImportIDValidation
+	Err    error
+}
+
+// importPreviewMsg carries the preview of importing ImportID
+type importPreviewMsg struct {
+	ImportID string
+	Preview  *pulumi.ImportPreview
+	Err      error
+}
+
+// fileSavedMsg reports the result of saving generated content to a file
+type fileSavedMsg struct {
+	Path string
+	Err  error
+}
+
+// Bulk import messages
+type importableResourcesMsg *plugins.ImportableResourcesPage
+type importableResourcesErrMsg struct{ Err error }
+type bulkImportResultMsg struct {
+	Count  int
+	Result *pulumi.CommandResult
+}
 
 // Stack init messages
 type whoAmIMsg *pulumi.WhoAmIInfo
 type stackFilesMsg []pulumi.StackFileInfo
+
+// newProjectResultMsg is sent when scaffolding a project from a template finished
+type newProjectResultMsg struct {
+	Dir  string
+	Name string
+	Err  error
+}
+
 type stackInitResultMsg struct {
 	StackName string
+	Copied    *pulumi.ConfigCopyResult // Config copied from another stack, if any
 	Error     error
 }
 
@@ -74,7 +416,12 @@ type openResourceActionMsg struct {
 	Response   *plugins.OpenResourceResponse
 	PluginName string
 }
-type openResourceErrMsg error
+type openResourceErrMsg struct{ Err error }
 type openResourceExecDoneMsg struct {
-	Error error
+	Command string
+	Stderr  string // End of the program's error output
+	Error   error
 }
+
+// pluginReauthenticatedMsg is sent when a plugin was re-authenticated from the plugin status modal
+type pluginReauthenticatedMsg plugins.AuthenticateResult
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/rfhold/p5/internal/telemetry"
+)
+
+// UI responsiveness metrics, exported when metrics are enabled in p5.toml. Creating
+// instruments only fails for invalid names, and a failed instrument still records
+// as a no-op, so errors are ignored.
+var (
+	updateDuration, _ = telemetry.Meter().Float64Histogram("p5.ui.update.duration",
+		metric.WithDescription("Time spent handling a message in the update loop"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(telemetry.DurationBuckets...),
+	)
+	renderDuration, _ = telemetry.Meter().Float64Histogram("p5.ui.render.duration",
+		metric.WithDescription("Time spent rendering a frame"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(telemetry.DurationBuckets...),
+	)
+	messagesHandled, _ = telemetry.Meter().Int64Counter("p5.ui.messages",
+		metric.WithDescription("Messages handled by the update loop"),
+		metric.WithUnit("{message}"),
+	)
+)
+
+// recordUpdate records handling msg in the update loop, by message type
+func recordUpdate(msg tea.Msg, started time.Time) {
+	attrs := metric.WithAttributes(attribute.String("p5.message", fmt.Sprintf("%T", msg)))
+	ctx := context.Background()
+	messagesHandled.Add(ctx, 1, attrs)
+	updateDuration.Record(ctx, time.Since(started).Seconds(), attrs)
+}
+
+// recordRender records rendering a frame
+func recordRender(started time.Time) {
+	renderDuration.Record(context.Background(), time.Since(started).Seconds())
+}
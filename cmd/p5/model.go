@@ -2,9 +2,12 @@ package main
 
 import (
 	"context"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/plugins"
 	"github.com/rfhold/p5/internal/pulumi"
 	"github.com/rfhold/p5/internal/ui"
 )
@@ -98,7 +101,29 @@ type AppContext struct {
 	Cwd       string // Current working directory (where app was launched from)
 	WorkDir   string // Working directory (Pulumi project root)
 	StackName string // Currently selected stack name
-	StartView string // Initial view mode ("stack", "up", "refresh", "destroy")
+	StartView string // Initial view mode ("stack", "up", "refresh", "destroy", "dashboard", "state", "run")
+	Workflow  string // Workflow from p5.toml started once the stack has loaded ("run" view)
+	// Exported state browsed read-only in the "state" view; a second file is diffed against the first
+	StateFiles []string
+	// Fully qualified name of the stack of the organization browsed read-only in the
+	// "state" view, e.g. "acme/app/prod"
+	CloudStack string
+	// Locks the TUI after inactivity on protected stacks, from p5.toml (nil disables locking)
+	IdleLock *plugins.IdleLockConfig
+	// Percentage of the screen width taken by the details panel, from p5.toml (0 uses the default)
+	DetailsWidth int
+	// How often the stack is checked for updates made elsewhere, from p5.toml (0 disables polling)
+	PollInterval time.Duration
+	// How many updates the history view loads at a time, from p5.toml (0 uses the default)
+	HistoryPageSize int
+	// Whether up and its preview refresh the state by default, from p5.toml
+	RefreshOnUp bool
+	// Whether up and destroy ask for a message recorded in the stack's history, from p5.toml
+	UpdateMessage bool
+	// How workspaces are searched for below Cwd, from p5.toml
+	WorkspaceSearch pulumi.WorkspaceSearchOptions
+	// Groups the workspace selector lists workspaces in, from p5.toml
+	WorkspaceGroups []plugins.WorkspaceGroupConfig
 }
 
 // Model is the main application model coordinating application state, UI state, and async operations.
@@ -115,6 +140,10 @@ type Model struct {
 	// Preview context for cancellation
 	previewCancel context.CancelFunc
 
+	// Events of the running quick check, and its cancellation
+	quickCheckCh     <-chan pulumi.PreviewEvent
+	quickCheckCancel context.CancelFunc
+
 	// Operation context for cancellation
 	operationCtx    context.Context
 	operationCancel context.CancelFunc
@@ -122,7 +151,7 @@ type Model struct {
 
 func initialModel(appCtx context.Context, ctx AppContext, deps *Dependencies) Model {
 	state := NewAppState()
-	uiState := NewUIState(state.Flags)
+	uiState := NewUIState(state.Flags, state.Notes, state.Pinned)
 
 	m := Model{
 		appCtx: appCtx,
@@ -145,7 +174,19 @@ func initialModel(appCtx context.Context, ctx AppContext, deps *Dependencies) Mo
 		m.ui.ViewMode = ui.ViewPreview
 		m.state.Operation = pulumi.OperationDestroy
 		m.ui.ResourceList.SetShowAllOps(false)
+	case "dashboard":
+		m.showDashboard(false)
+	case "state":
+		// Diffs list changed resources only, like a preview
+		m.ui.ResourceList.SetShowAllOps(len(ctx.StateFiles) < 2)
+		m.ui.ResourceList.SetLoading(true, i18n.T("Loading exported state..."))
+	}
+
+	if ctx.DetailsWidth > 0 {
+		m.ui.DetailsWidth = ctx.DetailsWidth
 	}
+	m.state.RefreshOnUp = ctx.RefreshOnUp
+	m.ui.Header.SetRefresh(ctx.RefreshOnUp)
 
 	m.ui.Header.SetViewMode(m.ui.ViewMode)
 	m.ui.Header.SetOperation(m.state.Operation)
@@ -161,6 +202,25 @@ func (m Model) Init() tea.Cmd {
 		m.ui.HistoryList.Spinner().Tick,
 	}
 
+	if m.ctx.IdleLock != nil {
+		cmds = append(cmds, checkIdleAfter(m.ctx.IdleLock.TimeoutDuration()))
+	}
+	if m.ctx.PollInterval > 0 {
+		cmds = append(cmds, pollStateAfter(m.ctx.PollInterval))
+	}
+
+	// The dashboard loads every stack itself; init starts once a stack is chosen
+	if m.ctx.StartView == "dashboard" {
+		cmds = append(cmds, m.ui.Dashboard.Spinner().Tick, m.fetchDashboard())
+		return tea.Batch(cmds...)
+	}
+
+	// Exported state is browsed without a workspace
+	if m.ctx.StartView == "state" {
+		cmds = append(cmds, m.loadStateFiles())
+		return tea.Batch(cmds...)
+	}
+
 	// First check if we're in a valid Pulumi workspace
 	cmds = append(cmds, m.checkWorkspace())
 
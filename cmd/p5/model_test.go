@@ -2,25 +2,60 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/plugins/proto"
 	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/telemetry"
 	"github.com/rfhold/p5/internal/ui"
 )
 
+// TestMain keeps tests from reading or saving the user's preferences, such as
+// recent workspaces
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "p5-config")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("XDG_CONFIG_HOME", dir)
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 // newTestDependencies creates a Dependencies struct with all fakes for testing.
 // This is the primary way to create testable model instances.
 func newTestDependencies() *Dependencies {
 	return &Dependencies{
-		StackOperator:    &pulumi.FakeStackOperator{},
-		StackReader:      &pulumi.FakeStackReader{},
-		WorkspaceReader:  &pulumi.FakeWorkspaceReader{ValidWorkDir: true},
-		StackInitializer: &pulumi.FakeStackInitializer{},
-		ResourceImporter: &pulumi.FakeResourceImporter{},
-		PluginProvider:   &plugins.FakePluginProvider{},
-		Logger:           slog.New(slog.NewTextHandler(discardWriter{}, nil)),
+		StackOperator:        &pulumi.FakeStackOperator{},
+		StackReader:          &pulumi.FakeStackReader{},
+		WorkspaceReader:      &pulumi.FakeWorkspaceReader{ValidWorkDir: true},
+		EnvironmentReader:    &pulumi.FakeEnvironmentReader{},
+		GitReader:            &pulumi.FakeGitReader{},
+		StackInitializer:     &pulumi.FakeStackInitializer{},
+		ResourceImporter:     &pulumi.FakeResourceImporter{},
+		StackStateManager:    &pulumi.FakeStackStateManager{},
+		StateTransferer:      &pulumi.FakeStateTransferer{},
+		BackendAuthenticator: &pulumi.FakeBackendAuthenticator{},
+		StackLocker:          &pulumi.FakeStackLocker{},
+		PluginProvider:       &plugins.FakePluginProvider{},
+		PluginInstaller:      &plugins.FakePluginInstaller{},
+		Logger:               slog.New(slog.NewTextHandler(discardWriter{}, nil)),
 	}
 }
 
@@ -407,6 +442,71 @@ func TestHandlePluginInitDoneWithoutStackName(t *testing.T) {
 	}
 }
 
+// TestFetchInitData verifies init loads stacks, stack files, backend and project
+// info into a single message, and that only a project info failure is fatal.
+func TestFetchInitData(t *testing.T) {
+	deps := newTestDependencies()
+	deps.StackReader = &pulumi.FakeStackReader{
+		GetStacksFunc: func(ctx context.Context, workDir string, opts pulumi.ReadOptions) ([]pulumi.StackInfo, error) {
+			return nil, errors.New("backend unavailable")
+		},
+	}
+	workspaceReader := &pulumi.FakeWorkspaceReader{
+		ValidWorkDir: true,
+		StackFiles:   []pulumi.StackFileInfo{{Name: "dev"}},
+		WhoAmI:       &pulumi.WhoAmIInfo{User: "alice", URL: "https://api.pulumi.com"},
+		ProjectInfo:  &pulumi.ProjectInfo{ProgramName: "app", StackName: "dev"},
+	}
+	deps.WorkspaceReader = workspaceReader
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path"}, deps)
+
+	msg, ok := m.fetchInitData()().(initDataMsg)
+	if !ok {
+		t.Fatal("expected initDataMsg")
+	}
+	if len(msg.Stacks) != 0 || len(msg.Files) != 1 || msg.WhoAmI.User != "alice" || msg.ProjectInfo.ProgramName != "app" {
+		t.Errorf("unexpected init data: %+v", msg)
+	}
+
+	workspaceReader.GetProjectInfoFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.ReadOptions) (*pulumi.ProjectInfo, error) {
+		return nil, errors.New("no Pulumi.yaml")
+	}
+	if _, ok := m.fetchInitData()().(errMsg); !ok {
+		t.Error("expected errMsg when project info fails")
+	}
+}
+
+// TestHandleInitData verifies the prefetched data is used without fetching it again.
+func TestHandleInitData(t *testing.T) {
+	deps := newTestDependencies()
+	workspaceReader := &pulumi.FakeWorkspaceReader{
+		ValidWorkDir: true,
+		ProjectInfo:  &pulumi.ProjectInfo{ProgramName: "app", StackName: "dev"},
+	}
+	deps.WorkspaceReader = workspaceReader
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path"}, deps)
+	m.transitionTo(InitLoadingStacks)
+
+	result, cmd := m.handleInitData(initDataMsg{
+		Stacks:      []pulumi.StackInfo{{Name: "dev", Current: true}, {Name: "prod"}},
+		WhoAmI:      &pulumi.WhoAmIInfo{},
+		ProjectInfo: &pulumi.ProjectInfo{ProgramName: "app", StackName: "dev"},
+	})
+	m = result.(Model)
+	runCmds(cmd)
+
+	if m.state.InitState != InitLoadingResources || m.ctx.StackName != "dev" {
+		t.Fatalf("expected to load the current stack, got state %v stack %q", m.state.InitState, m.ctx.StackName)
+	}
+	if m.state.ProgramName != "app" {
+		t.Errorf("expected program name from project info, got %q", m.state.ProgramName)
+	}
+	// Plugin auth reads the project itself; the header must not fetch it again
+	if len(workspaceReader.Calls.GetProjectInfo) != 1 {
+		t.Errorf("expected project info to be read only by plugin auth, got %d calls", len(workspaceReader.Calls.GetProjectInfo))
+	}
+}
+
 // testError is a simple error type for testing.
 type testError string
 
@@ -536,6 +636,25 @@ func TestProcessPreviewEvent_MergesOldState(t *testing.T) {
 	}
 }
 
+// TestProcessPreviewEvent_ReplaceKeys verifies the properties forcing a replace are kept on the item.
+func TestProcessPreviewEvent_ReplaceKeys(t *testing.T) {
+	event := pulumi.PreviewEvent{
+		Step: &pulumi.PreviewStep{
+			URN:         "urn:pulumi:dev::test::aws:s3:Bucket::mybucket",
+			Type:        "aws:s3:Bucket",
+			Name:        "mybucket",
+			Op:          pulumi.OpReplace,
+			ReplaceKeys: []string{"bucket"},
+		},
+	}
+
+	result := ProcessPreviewEvent(event, OpRunning, InitLoadingResources)
+
+	if result.Item == nil || !slices.Equal(result.Item.ReplaceKeys, []string{"bucket"}) {
+		t.Fatalf("expected ReplaceKeys [bucket], got %+v", result.Item)
+	}
+}
+
 // TestProcessPreviewEvent_DeleteUsesOldState verifies delete ops use old state as current.
 func TestProcessPreviewEvent_DeleteUsesOldState(t *testing.T) {
 	event := pulumi.PreviewEvent{
@@ -662,6 +781,31 @@ func TestProcessOperationEvent_StatusMapping(t *testing.T) {
 	}
 }
 
+// TestProcessOperationEvent_Timing verifies running events start timing a resource
+// and finished events stop it, across both steps of a replace.
+func TestProcessOperationEvent_Timing(t *testing.T) {
+	urn := "urn:pulumi:dev::test::aws:s3:Bucket::mybucket"
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	list := ui.NewResourceList(make(map[string]ui.ResourceFlags))
+	for _, event := range []pulumi.OperationEvent{
+		{URN: urn, Op: pulumi.OpCreateReplace, Status: pulumi.StepRunning, Time: start},
+		{URN: urn, Op: pulumi.OpCreateReplace, Status: pulumi.StepSuccess, Time: start.Add(4 * time.Second)},
+		{URN: urn, Op: pulumi.OpDeleteReplace, Status: pulumi.StepRunning, Time: start.Add(5 * time.Second)},
+	} {
+		list.AddItem(*ProcessOperationEvent(event, OpRunning).Item)
+	}
+
+	item := list.Items()[0]
+	if elapsed, ok := item.Elapsed(start.Add(7 * time.Second)); !ok || elapsed != 7*time.Second {
+		t.Errorf("expected the running replace to be timed from its first step, got %v, %v", elapsed, ok)
+	}
+
+	list.AddItem(*ProcessOperationEvent(pulumi.OperationEvent{URN: urn, Op: pulumi.OpDeleteReplace, Status: pulumi.StepSuccess, Time: start.Add(9 * time.Second)}, OpRunning).Item)
+	if elapsed, ok := list.Items()[0].Elapsed(start.Add(time.Minute)); !ok || elapsed != 9*time.Second {
+		t.Errorf("expected the replace to take 9s, got %v, %v", elapsed, ok)
+	}
+}
+
 // TestProcessOperationEvent_TransitionsFromStarting verifies Starting→Running transition.
 func TestProcessOperationEvent_TransitionsFromStarting(t *testing.T) {
 	event := pulumi.OperationEvent{
@@ -772,6 +916,273 @@ func TestConvertHistoryToItems_Basic(t *testing.T) {
 	}
 }
 
+// TestDiffStateSnapshots_Basic verifies created, updated, and deleted resources are detected.
+func TestDiffStateSnapshots_Basic(t *testing.T) {
+	before := []pulumi.ResourceInfo{
+		{URN: "urn:same", Name: "same", Inputs: map[string]any{"a": "1"}},
+		{URN: "urn:updated", Name: "updated", Inputs: map[string]any{"size": "small"}},
+		{URN: "urn:deleted", Name: "deleted", Inputs: map[string]any{"x": "y"}},
+	}
+	after := []pulumi.ResourceInfo{
+		{URN: "urn:same", Name: "same", Inputs: map[string]any{"a": "1"}},
+		{URN: "urn:updated", Name: "updated", Inputs: map[string]any{"size": "large"}},
+		{URN: "urn:created", Name: "created"},
+	}
+
+	items := DiffStateSnapshots(before, after, false)
+
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+
+	expected := []struct {
+		urn string
+		op  pulumi.ResourceOp
+	}{
+		{"urn:updated", pulumi.OpUpdate},
+		{"urn:created", pulumi.OpCreate},
+		{"urn:deleted", pulumi.OpDelete},
+	}
+	for i, e := range expected {
+		if items[i].URN != e.urn || items[i].Op != e.op {
+			t.Errorf("item %d: expected %s (%s), got %s (%s)", i, e.urn, e.op, items[i].URN, items[i].Op)
+		}
+	}
+
+	if items[0].OldInputs["size"] != "small" || items[0].Inputs["size"] != "large" {
+		t.Error("expected update to carry old and new inputs")
+	}
+	if items[2].OldInputs["x"] != "y" || items[2].Inputs != nil {
+		t.Error("expected delete to carry only old inputs")
+	}
+}
+
+// TestDiffStateSnapshots_FirstVersion verifies an empty previous snapshot marks everything created.
+func TestDiffStateSnapshots_FirstVersion(t *testing.T) {
+	after := []pulumi.ResourceInfo{
+		{URN: "urn:a", Name: "a"},
+		{URN: "urn:b", Name: "b"},
+	}
+
+	items := DiffStateSnapshots(nil, after, false)
+
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+	for _, item := range items {
+		if item.Op != pulumi.OpCreate {
+			t.Errorf("expected %s to be created, got %s", item.URN, item.Op)
+		}
+	}
+}
+
+// TestDiffStateSnapshots_NoChanges verifies identical snapshots produce no items.
+func TestDiffStateSnapshots_NoChanges(t *testing.T) {
+	snapshot := []pulumi.ResourceInfo{
+		{URN: "urn:a", Name: "a", Outputs: map[string]any{"id": "1"}},
+	}
+
+	if items := DiffStateSnapshots(snapshot, snapshot, false); len(items) != 0 {
+		t.Errorf("expected no items, got %d", len(items))
+	}
+}
+
+// TestHistoryDiffKeyFetchesSnapshots verifies the diff key exports the selected and previous versions.
+func TestHistoryDiffKeyFetchesSnapshots(t *testing.T) {
+	deps := newTestDependencies()
+	reader := &pulumi.FakeStackReader{
+		Deployments: map[int][]pulumi.ResourceInfo{
+			2: {{URN: "urn:a", Name: "a"}},
+			3: {{URN: "urn:a", Name: "a"}, {URN: "urn:b", Name: "b"}},
+		},
+	}
+	deps.StackReader = reader
+	ctx := AppContext{
+		WorkDir:   "/fake/path",
+		StackName: "dev",
+		StartView: "stack",
+	}
+	m := initialModel(context.Background(), ctx, deps)
+	m.ui.ViewMode = ui.ViewHistory
+	m.ui.HistoryList.SetItems([]ui.HistoryItem{{Version: 3, BackendVersion: 3, Kind: "update"}}, false)
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	resultModel, ok := result.(Model)
+	if !ok {
+		t.Fatal("expected result to be Model")
+	}
+	if resultModel.ui.Focus.Current() != ui.FocusHistoryDiff {
+		t.Fatalf("expected focus %v, got %v", ui.FocusHistoryDiff, resultModel.ui.Focus.Current())
+	}
+	if cmd == nil {
+		t.Fatal("expected a command to fetch snapshots")
+	}
+
+	msg, ok := cmd().(historyDiffMsg)
+	if !ok {
+		t.Fatal("expected historyDiffMsg")
+	}
+	if len(reader.Calls.ExportDeploymentAtVersion) != 2 {
+		t.Fatalf("expected 2 export calls, got %d", len(reader.Calls.ExportDeploymentAtVersion))
+	}
+	if reader.Calls.ExportDeploymentAtVersion[0].Version != 3 || reader.Calls.ExportDeploymentAtVersion[1].Version != 2 {
+		t.Error("expected exports for versions 3 and 2")
+	}
+	if msg.Version != 3 || len(msg.After) != 2 || len(msg.Before) != 1 {
+		t.Errorf("unexpected snapshot message: %+v", msg)
+	}
+}
+
+// TestHistoryDiffKeyWithoutVersions verifies updates from backends that don't track
+// versions aren't diffed, as their numbers are only positions in the history.
+func TestHistoryDiffKeyWithoutVersions(t *testing.T) {
+	deps := newTestDependencies()
+	reader := &pulumi.FakeStackReader{}
+	deps.StackReader = reader
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ViewMode = ui.ViewHistory
+	m.ui.HistoryList.SetItems(ConvertHistoryToItems([]pulumi.UpdateSummary{{Kind: "update"}, {Kind: "update"}}), false)
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	runCmds(cmd)
+	if m.ui.HistoryDiff.Visible() || len(reader.Calls.ExportDeploymentAtVersion) != 0 {
+		t.Error("expected no diff for an update without a backend version")
+	}
+	if !strings.Contains(m.ui.Toast.View(120), "doesn't track update versions") {
+		t.Errorf("expected a toast explaining why, got %q", m.ui.Toast.View(120))
+	}
+}
+
+// TestHandleHistoryDiffIgnoresStaleVersion verifies results for another version are dropped.
+func TestHandleHistoryDiffIgnoresStaleVersion(t *testing.T) {
+	deps := newTestDependencies()
+	ctx := AppContext{
+		WorkDir:   "/fake/path",
+		StartView: "stack",
+	}
+	m := initialModel(context.Background(), ctx, deps)
+	m.showHistoryDiff(5)
+
+	result, _ := m.handleHistoryDiffError(historyDiffErrMsg{Version: 4, Error: testError("stale")})
+	resultModel, ok := result.(Model)
+	if !ok {
+		t.Fatal("expected result to be Model")
+	}
+	if resultModel.ui.HistoryDiff.Version() != 5 {
+		t.Errorf("expected panel to stay on version 5, got %d", resultModel.ui.HistoryDiff.Version())
+	}
+}
+
+// TestHashPreviewItems_StableForEqualDiffs verifies identical diffs hash the same and differing ones do not.
+func TestHashPreviewItems_StableForEqualDiffs(t *testing.T) {
+	a := []ui.ResourceItem{{URN: "urn:a", Op: pulumi.OpUpdate, Inputs: map[string]any{"x": "1", "y": "2"}}}
+	b := []ui.ResourceItem{{URN: "urn:a", Op: pulumi.OpUpdate, Inputs: map[string]any{"y": "2", "x": "1"}}}
+	c := []ui.ResourceItem{{URN: "urn:a", Op: pulumi.OpUpdate, Inputs: map[string]any{"x": "1", "y": "3"}}}
+
+	if HashPreviewItems(a)["urn:a"] != HashPreviewItems(b)["urn:a"] {
+		t.Error("expected equal diffs to hash the same")
+	}
+	if HashPreviewItems(a)["urn:a"] == HashPreviewItems(c)["urn:a"] {
+		t.Error("expected different inputs to change the hash")
+	}
+}
+
+// TestDetermineChangedDiffs verifies changed and new resources are reported.
+// TestDestroyConfirmationShowsBlastRadius verifies destroying targets from the stack view
+// lists the targets, their children and their dependents in the confirmation.
+func TestDestroyConfirmationShowsBlastRadius(t *testing.T) {
+	const (
+		stack  = "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"
+		vpc    = "urn:pulumi:dev::app::aws:ec2/vpc:Vpc::vpc"
+		subnet = "urn:pulumi:dev::app::aws:ec2/subnet:Subnet::subnet"
+		db     = "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+		bucket = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	)
+	m := initialModel(context.Background(), AppContext{WorkDir: t.TempDir(), StackName: "dev"}, newTestDependencies())
+	resources := []pulumi.ResourceInfo{
+		{URN: stack, Type: "pulumi:pulumi:Stack", Name: "app-dev"},
+		{URN: vpc, Type: "aws:ec2/vpc:Vpc", Name: "vpc", Parent: stack},
+		{URN: subnet, Type: "aws:ec2/subnet:Subnet", Name: "subnet", Parent: vpc},
+		{URN: db, Type: "aws:rds/instance:Instance", Name: "db", Parent: stack, Dependencies: []string{subnet}, Protected: true},
+		{URN: bucket, Type: "aws:s3/bucket:Bucket", Name: "logs", Parent: stack},
+	}
+	model, _ := m.handleStackResources(stackResourcesMsg(resources))
+	m = model.(Model)
+	m.state.Flags[vpc] = ui.ResourceFlags{Target: true}
+
+	m.maybeConfirmExecution(pulumi.OperationDestroy)
+
+	view := m.ui.ConfirmModal.View()
+	if !strings.Contains(view, "Destroys 3 resources: 1 targeted, 2 children and dependents") {
+		t.Errorf("expected the blast radius summary, got:\n%s", view)
+	}
+	if !strings.Contains(view, "1 are protected") {
+		t.Errorf("expected the protected dependent to be called out, got:\n%s", view)
+	}
+	m.ui.ConfirmModal.Update(tea.KeyMsg{Type: tea.KeyTab})
+	view = m.ui.ConfirmModal.View()
+	if !strings.Contains(view, "subnet") || strings.Contains(view, "logs") {
+		t.Errorf("expected the expanded list to hold only affected resources, got:\n%s", view)
+	}
+}
+
+func TestDetermineChangedDiffs(t *testing.T) {
+	previous := map[string]string{"urn:same": "h1", "urn:changed": "h2", "urn:gone": "h3"}
+	current := map[string]string{"urn:same": "h1", "urn:changed": "h2b", "urn:new": "h4"}
+
+	changed := DetermineChangedDiffs(previous, current)
+
+	if len(changed) != 2 || !changed["urn:changed"] || !changed["urn:new"] {
+		t.Errorf("expected urn:changed and urn:new, got %v", changed)
+	}
+}
+
+// TestDetermineChangedDiffs_NoPrevious verifies the first preview highlights nothing.
+func TestDetermineChangedDiffs_NoPrevious(t *testing.T) {
+	changed := DetermineChangedDiffs(nil, map[string]string{"urn:a": "h1"})
+
+	if len(changed) != 0 {
+		t.Errorf("expected no changes without a previous preview, got %v", changed)
+	}
+}
+
+// TestRecordPreviewHashes_HighlightsChangedDiffs verifies a re-preview marks only resources whose diff changed.
+func TestRecordPreviewHashes_HighlightsChangedDiffs(t *testing.T) {
+	deps := newTestDependencies()
+	ctx := AppContext{
+		WorkDir:   "/fake/path",
+		StartView: "up",
+	}
+	m := initialModel(context.Background(), ctx, deps)
+
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{
+		{URN: "urn:a", Name: "a", Op: pulumi.OpUpdate, Inputs: map[string]any{"v": "1"}},
+		{URN: "urn:b", Name: "b", Op: pulumi.OpCreate},
+	})
+	m.recordPreviewHashes()
+	for _, item := range m.ui.ResourceList.Items() {
+		if item.DiffChanged {
+			t.Errorf("expected no highlight on first preview, got %s", item.URN)
+		}
+	}
+
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{
+		{URN: "urn:a", Name: "a", Op: pulumi.OpUpdate, Inputs: map[string]any{"v": "2"}},
+		{URN: "urn:b", Name: "b", Op: pulumi.OpCreate},
+	})
+	m.recordPreviewHashes()
+
+	for _, item := range m.ui.ResourceList.Items() {
+		want := item.URN == "urn:a"
+		if item.DiffChanged != want {
+			t.Errorf("expected DiffChanged=%v for %s, got %v", want, item.URN, item.DiffChanged)
+		}
+	}
+}
+
 // TestConvertHistoryToItems_LocalBackendVersioning verifies version calculation for local backend.
 func TestConvertHistoryToItems_LocalBackendVersioning(t *testing.T) {
 	// Local backend returns history newest-first with Version=0
@@ -1047,6 +1458,41 @@ func TestConvertWorkspacesToItems_EmptyCwd(t *testing.T) {
 	}
 }
 
+// TestGroupWorkspaceItems verifies workspaces are listed under the first group they
+// belong to, in group order, with the rest under Other and recent ones above.
+func TestGroupWorkspaceItems(t *testing.T) {
+	groups := []plugins.WorkspaceGroupConfig{
+		{Name: "platform", Paths: []string{"/repo/infra", "/repo/services/net*"}},
+		{Name: "apps", Paths: []string{"/repo/apps/*", "/repo/infra/legacy"}},
+	}
+	items := ConvertWorkspacesToItems([]pulumi.WorkspaceInfo{
+		{Path: "/repo/apps/web", Name: "web"},
+		{Path: "/repo/infra/cluster", Name: "cluster"},
+		{Path: "/repo/infra/legacy", Name: "legacy"},
+		{Path: "/repo/services/network", Name: "network"},
+		{Path: "/repo/tools", Name: "tools"},
+	}, "/repo")
+
+	items = GroupWorkspaceItems(items, groups)
+	var got []string
+	for _, item := range items {
+		got = append(got, item.Section+":"+item.Name)
+	}
+	want := []string{"platform:cluster", "platform:legacy", "platform:network", "apps:web", "Other:tools"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	merged := MergeRecentWorkspaces(items, []plugins.RecentWorkspace{{Path: "/repo/apps/web", Stack: "dev"}}, "/repo", "/repo/tools")
+	if len(merged) != len(items) || merged[0].Name != "web" || merged[0].Section != "Recent" || merged[1].Section != "platform" {
+		t.Errorf("expected the recent workspace above the groups, got %+v", merged)
+	}
+
+	if grouped := GroupWorkspaceItems(items[:1], nil); grouped[0].Section != "platform" {
+		t.Errorf("expected workspaces unchanged without groups, got %+v", grouped)
+	}
+}
+
 // TestConvertWorkspacesToItems_Empty verifies empty slice input.
 func TestConvertWorkspacesToItems_Empty(t *testing.T) {
 	items := ConvertWorkspacesToItems(nil, "/home/user")
@@ -1784,3 +2230,4760 @@ func TestSummarizePluginAuthResults_MultipleErrors(t *testing.T) {
 		}
 	}
 }
+
+// TestMergeEnvironmentVariables verifies later ESC environments override earlier ones.
+func TestMergeEnvironmentVariables(t *testing.T) {
+	envs := []*pulumi.StackEnvironment{
+		{Name: "base", EnvironmentVariables: map[string]string{"AWS_REGION": "us-east-1", "A": "1"}},
+		nil,
+		{Name: "dev", EnvironmentVariables: map[string]string{"AWS_REGION": "us-west-2"}},
+	}
+
+	merged := MergeEnvironmentVariables(envs)
+
+	if merged["AWS_REGION"] != "us-west-2" {
+		t.Errorf("expected later environment to win, got %q", merged["AWS_REGION"])
+	}
+	if merged["A"] != "1" {
+		t.Errorf("expected A=1, got %q", merged["A"])
+	}
+}
+
+// TestEnvironmentsKeyOpensStackEnvironments verifies the environments key resolves each imported environment.
+func TestEnvironmentsKeyOpensStackEnvironments(t *testing.T) {
+	deps := newTestDependencies()
+	reader := &pulumi.FakeEnvironmentReader{
+		Names: []string{"base", "dev"},
+		Environments: map[string]*pulumi.StackEnvironment{
+			"dev": {Name: "dev", EnvironmentVariables: map[string]string{"TOKEN": "secret"}},
+		},
+	}
+	deps.EnvironmentReader = reader
+	ctx := AppContext{
+		WorkDir:   "/fake/path",
+		StackName: "dev",
+		StartView: "stack",
+	}
+	m := initialModel(context.Background(), ctx, deps)
+	m.state.InitState = InitComplete
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	resultModel, ok := result.(Model)
+	if !ok {
+		t.Fatal("expected result to be Model")
+	}
+	if resultModel.ui.Focus.Current() != ui.FocusEnvironments {
+		t.Fatalf("expected focus %v, got %v", ui.FocusEnvironments, resultModel.ui.Focus.Current())
+	}
+	if cmd == nil {
+		t.Fatal("expected a command to resolve environments")
+	}
+
+	msg, ok := cmd().(stackEnvironmentsMsg)
+	if !ok {
+		t.Fatal("expected stackEnvironmentsMsg")
+	}
+	if len(reader.Calls.OpenEnvironment) != 2 {
+		t.Fatalf("expected 2 open calls, got %d", len(reader.Calls.OpenEnvironment))
+	}
+	if msg.StackName != "dev" || len(msg.Environments) != 2 {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+}
+
+// TestHandleStackEnvironmentsSharesEnvWithPlugins verifies resolved variables reach the plugin provider.
+func TestHandleStackEnvironmentsSharesEnvWithPlugins(t *testing.T) {
+	deps := newTestDependencies()
+	provider := &plugins.FakePluginProvider{}
+	deps.PluginProvider = provider
+	ctx := AppContext{
+		WorkDir:   "/fake/path",
+		StackName: "dev",
+		StartView: "stack",
+	}
+	m := initialModel(context.Background(), ctx, deps)
+
+	envs := []*pulumi.StackEnvironment{{Name: "dev", EnvironmentVariables: map[string]string{"TOKEN": "secret"}}}
+	result, _ := m.handleStackEnvironments(stackEnvironmentsMsg{StackName: "dev", Environments: envs})
+	resultModel, ok := result.(Model)
+	if !ok {
+		t.Fatal("expected result to be Model")
+	}
+
+	if len(resultModel.ui.Environments.Environments()) != 1 {
+		t.Error("expected panel to hold the resolved environment")
+	}
+	if len(provider.Calls.SetEnvironmentEnv) != 1 || provider.Calls.SetEnvironmentEnv[0]["TOKEN"] != "secret" {
+		t.Errorf("expected plugin provider to receive ESC env, got %v", provider.Calls.SetEnvironmentEnv)
+	}
+}
+
+// TestHandleStackEnvironmentsIgnoresOtherStack verifies results for a previously selected stack are dropped.
+func TestHandleStackEnvironmentsIgnoresOtherStack(t *testing.T) {
+	deps := newTestDependencies()
+	provider := &plugins.FakePluginProvider{}
+	deps.PluginProvider = provider
+	ctx := AppContext{
+		WorkDir:   "/fake/path",
+		StackName: "prod",
+		StartView: "stack",
+	}
+	m := initialModel(context.Background(), ctx, deps)
+
+	envs := []*pulumi.StackEnvironment{{Name: "dev"}}
+	result, _ := m.handleStackEnvironments(stackEnvironmentsMsg{StackName: "dev", Environments: envs})
+	resultModel, ok := result.(Model)
+	if !ok {
+		t.Fatal("expected result to be Model")
+	}
+
+	if len(resultModel.ui.Environments.Environments()) != 0 {
+		t.Error("expected stale environments to be ignored")
+	}
+	if len(provider.Calls.SetEnvironmentEnv) != 0 {
+		t.Error("expected plugin provider to be untouched")
+	}
+}
+
+func TestResolveResource(t *testing.T) {
+	resources := []pulumi.ResourceInfo{
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Type: "aws:s3/bucket:Bucket", Name: "logs"},
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets", Type: "aws:s3/bucket:Bucket", Name: "assets"},
+		{URN: "urn:pulumi:dev::app::aws:iam/role:Role::assets", Type: "aws:iam/role:Role", Name: "assets"},
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		wantURN string
+		wantErr bool
+	}{
+		{"by urn", "urn:pulumi:dev::app::aws:iam/role:Role::assets", "urn:pulumi:dev::app::aws:iam/role:Role::assets", false},
+		{"by unique name", "logs", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", false},
+		{"ambiguous name", "assets", "", true},
+		{"not found", "missing", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := ResolveResource(resources, tt.query)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", res)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.URN != tt.wantURN {
+				t.Errorf("expected %s, got %s", tt.wantURN, res.URN)
+			}
+		})
+	}
+}
+
+// TestResolveOpenAction verifies the open command resolves the resource and
+// asks plugins for an action with the resource's details.
+func TestResolveOpenAction(t *testing.T) {
+	deps := newTestDependencies()
+	deps.WorkspaceReader = &pulumi.FakeWorkspaceReader{
+		ProjectInfo: &pulumi.ProjectInfo{ProgramName: "app", StackName: "dev"},
+	}
+	deps.StackReader = &pulumi.FakeStackReader{
+		Resources: []pulumi.ResourceInfo{{
+			URN:     "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs",
+			Type:    "aws:s3/bucket:Bucket",
+			Name:    "logs",
+			Outputs: map[string]any{"bucket": "logs-123"},
+		}},
+	}
+	action := &proto.OpenAction{Type: proto.OpenActionType_OPEN_ACTION_TYPE_BROWSER, Url: "https://example.com/logs-123"}
+	provider := &plugins.FakePluginProvider{
+		OpenResourceResponse: &plugins.OpenResourceResponse{CanOpen: true, Action: action},
+	}
+	deps.PluginProvider = provider
+
+	got, err := resolveOpenAction(context.Background(), AppContext{WorkDir: "/fake/path"}, deps, "logs", discardWriter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != action {
+		t.Errorf("expected plugin action, got %v", got)
+	}
+	if len(provider.Calls.Initialize) != 1 {
+		t.Errorf("expected plugins to be initialized once, got %d", len(provider.Calls.Initialize))
+	}
+	if len(provider.Calls.OpenResource) != 1 {
+		t.Fatalf("expected one OpenResource call, got %d", len(provider.Calls.OpenResource))
+	}
+	req := provider.Calls.OpenResource[0]
+	if req.ResourceName != "logs" || req.Outputs["bucket"] != "logs-123" {
+		t.Errorf("unexpected request: %+v", req)
+	}
+}
+
+func TestResolveOpenAction_NoPlugin(t *testing.T) {
+	deps := newTestDependencies()
+	deps.WorkspaceReader = &pulumi.FakeWorkspaceReader{
+		ProjectInfo: &pulumi.ProjectInfo{ProgramName: "app", StackName: "dev"},
+	}
+	deps.StackReader = &pulumi.FakeStackReader{
+		Resources: []pulumi.ResourceInfo{{URN: "urn:pulumi:dev::app::random:index:RandomId::id", Type: "random:index:RandomId", Name: "id"}},
+	}
+
+	if _, err := resolveOpenAction(context.Background(), AppContext{WorkDir: "/fake/path"}, deps, "id", discardWriter{}); err == nil {
+		t.Error("expected error when no plugin can open the resource")
+	}
+}
+
+func TestDetectDrift(t *testing.T) {
+	tests := []struct {
+		name    string
+		history []pulumi.UpdateSummary
+		want    ui.DriftStatus
+	}{
+		{"no history", nil, ui.DriftUnknown},
+		{"update since refresh", []pulumi.UpdateSummary{
+			{Kind: "update", Result: "succeeded"},
+			{Kind: "refresh", Result: "succeeded", ResourceChanges: map[string]int{"update": 1}},
+		}, ui.DriftUnknown},
+		{"refresh with changes", []pulumi.UpdateSummary{
+			{Kind: "preview", Result: "succeeded"},
+			{Kind: "refresh", Result: "succeeded", ResourceChanges: map[string]int{"same": 3, "update": 1}},
+		}, ui.DriftDetected},
+		{"clean refresh", []pulumi.UpdateSummary{
+			{Kind: "refresh", Result: "succeeded", ResourceChanges: map[string]int{"same": 4}},
+		}, ui.DriftNone},
+		{"failed refresh skipped", []pulumi.UpdateSummary{
+			{Kind: "refresh", Result: "failed"},
+			{Kind: "refresh", Result: "succeeded", ResourceChanges: map[string]int{"delete": 1}},
+		}, ui.DriftDetected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectDrift(tt.history); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestLoadDashboardRows verifies rows keep workspace and stack order and report
+// per-stack failures without dropping other stacks.
+func TestLoadDashboardRows(t *testing.T) {
+	reader := &pulumi.FakeStackReader{
+		GetStacksFunc: func(ctx context.Context, workDir string, opts pulumi.ReadOptions) ([]pulumi.StackInfo, error) {
+			switch workDir {
+			case "/repo/app":
+				return []pulumi.StackInfo{{Name: "dev", Current: true}, {Name: "prod"}}, nil
+			default:
+				return nil, errors.New("backend unavailable")
+			}
+		},
+		GetHistoryFunc: func(ctx context.Context, workDir, stackName string, pageSize, page int, opts pulumi.ReadOptions) ([]pulumi.UpdateSummary, error) {
+			if stackName == "prod" {
+				return nil, errors.New("access denied")
+			}
+			return []pulumi.UpdateSummary{{Kind: "update", Result: "succeeded", StartTime: "2024-01-15T10:30:00Z"}}, nil
+		},
+		Resources: []pulumi.ResourceInfo{{URN: "a"}, {URN: "b"}},
+	}
+	workspaces := []pulumi.WorkspaceInfo{
+		{Path: "/repo/app", Name: "app"},
+		{Path: "/repo/dns", Name: "dns"},
+	}
+
+	rows := loadDashboardRows(context.Background(), reader, workspaces, "/repo", pulumi.ReadOptions{})
+
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	if rows[0].Stack != "dev" || rows[0].Err != nil || rows[0].ResourceCount != 2 || rows[0].LastKind != "update" || rows[0].Workspace != "app" {
+		t.Errorf("unexpected dev row: %+v", rows[0])
+	}
+	if rows[1].Stack != "prod" || rows[1].Err == nil {
+		t.Errorf("expected prod row to carry its error, got %+v", rows[1])
+	}
+	if rows[2].Project != "dns" || rows[2].Stack != "" || rows[2].Err == nil {
+		t.Errorf("expected dns workspace row to carry the listing error, got %+v", rows[2])
+	}
+}
+
+func TestDashboardStartView(t *testing.T) {
+	deps := newTestDependencies()
+	ctx := AppContext{
+		Cwd:       "/repo",
+		WorkDir:   "/repo",
+		StartView: "dashboard",
+	}
+	m := initialModel(context.Background(), ctx, deps)
+
+	if m.ui.Focus.Current() != ui.FocusDashboard {
+		t.Fatalf("expected dashboard focus, got %v", m.ui.Focus.Current())
+	}
+	if m.ui.Dashboard.Closable() {
+		t.Error("expected dashboard started from the command to not be closable")
+	}
+}
+
+// TestDashboardStackSelectedOtherWorkspace verifies opening a stack from another
+// workspace restarts init with the stack preselected.
+func TestDashboardStackSelectedOtherWorkspace(t *testing.T) {
+	deps := newTestDependencies()
+	ctx := AppContext{
+		Cwd:       "/repo",
+		WorkDir:   "/repo",
+		StartView: "dashboard",
+	}
+	m := initialModel(context.Background(), ctx, deps)
+
+	result, cmd := m.handleDashboardStackSelected(dashboardStackSelectedMsg{WorkDir: "/repo/app", StackName: "prod"})
+	resultModel, ok := result.(Model)
+	if !ok {
+		t.Fatal("expected result to be Model")
+	}
+
+	if resultModel.ctx.WorkDir != "/repo/app" || resultModel.ctx.StackName != "prod" {
+		t.Errorf("expected context to switch to /repo/app prod, got %s %s", resultModel.ctx.WorkDir, resultModel.ctx.StackName)
+	}
+	if resultModel.state.InitState != InitLoadingPlugins {
+		t.Errorf("expected InitLoadingPlugins, got %v", resultModel.state.InitState)
+	}
+	if resultModel.ui.Focus.Has(ui.FocusDashboard) {
+		t.Error("expected dashboard to be closed")
+	}
+	if cmd == nil {
+		t.Error("expected plugin authentication command")
+	}
+}
+
+// TestFollowStackReference verifies following a stack reference opens the referenced
+// stack, in the workspace of its project when that is another project
+func TestFollowStackReference(t *testing.T) {
+	deps := newTestDependencies()
+	deps.WorkspaceReader.(*pulumi.FakeWorkspaceReader).Workspaces = []pulumi.WorkspaceInfo{
+		{Path: "/fake/path", Name: "app", Current: true},
+		{Path: "/fake/network", Name: "network"},
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	result, _ = m.Update(projectInfoMsg(&pulumi.ProjectInfo{ProgramName: "app", StackName: "dev"}))
+	m = result.(Model)
+	m.transitionTo(InitComplete)
+
+	follow := func(m Model, name string) Model {
+		t.Helper()
+		m.state.ClearBusy() // Skip the plugin authentication of the previous switch
+		m.ui.ResourceList.SetItems([]ui.ResourceItem{{
+			URN:     "urn:pulumi:dev::app::pulumi:pulumi:StackReference::" + name,
+			Type:    pulumi.StackReferenceType,
+			Name:    name,
+			Outputs: map[string]any{"name": name},
+		}})
+		result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+		m = result.(Model)
+		for _, msg := range runCmds(cmd) {
+			if _, ok := msg.(stackReferenceResolvedMsg); ok {
+				result, _ = m.Update(msg)
+				m = result.(Model)
+			}
+		}
+		return m
+	}
+
+	m = follow(m, "acme/app/prod")
+	if m.ctx.WorkDir != "/fake/path" || m.ctx.StackName != "acme/app/prod" {
+		t.Errorf("expected the stack of the same project to be selected, got %s %s", m.ctx.WorkDir, m.ctx.StackName)
+	}
+
+	m = follow(m, "acme/network/dev")
+	if m.ctx.WorkDir != "/fake/network" || m.ctx.StackName != "acme/network/dev" {
+		t.Errorf("expected the network workspace to be opened, got %s %s", m.ctx.WorkDir, m.ctx.StackName)
+	}
+	if m.state.InitState != InitLoadingPlugins {
+		t.Errorf("expected the workspace to be initialized, got %v", m.state.InitState)
+	}
+
+	m.transitionTo(InitComplete)
+	m = follow(m, "acme/billing/dev")
+	if m.ctx.WorkDir != "/fake/network" || !strings.Contains(m.ui.Toast.View(120), "no workspace found for project billing") {
+		t.Errorf("expected a toast for the missing workspace, got %q", m.ui.Toast.View(120))
+	}
+}
+
+// TestBuildBulkImportItems verifies discovered resources are matched to pending creates by name.
+func TestBuildBulkImportItems(t *testing.T) {
+	resources := []*plugins.AggregatedImportableResource{
+		{PluginName: "kubernetes", Resource: plugins.NewImportableResource("default/api", "api", "api", "Namespace: default")},
+		{PluginName: "kubernetes", Resource: plugins.NewImportableResource("default/web", "web", "web", "")},
+		{PluginName: "other", Resource: plugins.NewImportableResource("id-only", "", "", "")},
+	}
+	previewItems := []ui.ResourceItem{
+		{Name: "api", Type: "kubernetes:apps/v1:Deployment", Op: pulumi.OpCreate, Parent: "urn:parent"},
+		{Name: "web", Type: "kubernetes:core/v1:Service", Op: pulumi.OpCreate},
+	}
+
+	items := BuildBulkImportItems(resources, "kubernetes:apps/v1:Deployment", previewItems)
+
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+	if !items[0].InProgram || items[0].ParentURN != "urn:parent" {
+		t.Errorf("expected api to match its pending create, got %+v", items[0])
+	}
+	if items[1].InProgram {
+		t.Error("expected web not to match a create of another type")
+	}
+	if items[2].Name != "id-only" || items[2].Label != "id-only" {
+		t.Errorf("expected name and label to fall back to the ID, got %+v", items[2])
+	}
+
+	specs := BuildImportSpecs("kubernetes:apps/v1:Deployment", items[:1])
+	want := pulumi.ImportSpec{Type: "kubernetes:apps/v1:Deployment", Name: "api", ID: "default/api", ParentURN: "urn:parent"}
+	if len(specs) != 1 || specs[0] != want {
+		t.Errorf("expected %+v, got %+v", want, specs)
+	}
+}
+
+// TestBulkImportFlow verifies paging through discovered resources and importing
+// the selection in a single batch.
+func TestBulkImportFlow(t *testing.T) {
+	deps := newTestDependencies()
+	provider := &plugins.FakePluginProvider{HasImportHelper: true}
+	provider.ListImportableResourcesFunc = func(ctx context.Context, req *plugins.ListImportableResourcesRequest, pageTokens map[string]string) (*plugins.ImportableResourcesPage, error) {
+		if pageTokens == nil {
+			return &plugins.ImportableResourcesPage{
+				Resources:      []*plugins.AggregatedImportableResource{{PluginName: "kubernetes", Resource: plugins.NewImportableResource("default/api", "api", "api", "")}},
+				NextPageTokens: map[string]string{"kubernetes": "1"},
+			}, nil
+		}
+		return &plugins.ImportableResourcesPage{
+			Resources: []*plugins.AggregatedImportableResource{{PluginName: "kubernetes", Resource: plugins.NewImportableResource("default/web", "web", "web", "")}},
+		}, nil
+	}
+	deps.PluginProvider = provider
+	importer := &pulumi.FakeResourceImporter{}
+	deps.ResourceImporter = importer
+
+	ctx := AppContext{WorkDir: "/fake/path", StackName: "dev", StartView: "up"}
+	m := initialModel(context.Background(), ctx, deps)
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{
+		{URN: "urn:api", Name: "api", Type: "kubernetes:apps/v1:Deployment", Op: pulumi.OpCreate},
+	})
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})
+	m = result.(Model)
+	if !m.ui.Focus.Has(ui.FocusBulkImportModal) || cmd == nil {
+		t.Fatal("expected bulk import modal to open and fetch resources")
+	}
+	result, _ = m.Update(cmd())
+	m = result.(Model)
+
+	// Moving to the last row loads the next page with the plugin's token
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("expected next page to be requested")
+	}
+	result, _ = m.Update(cmd())
+	m = result.(Model)
+	if calls := provider.Calls.ListImportableResources; len(calls) != 2 || calls[1].PageTokens["kubernetes"] != "1" {
+		t.Fatalf("expected second page request with token, got %+v", calls)
+	}
+	if got := len(m.ui.BulkImportModal.Items()); got != 2 {
+		t.Fatalf("expected 2 items after paging, got %d", got)
+	}
+
+	// Select all and import
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = result.(Model)
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("expected import command")
+	}
+	// Run the import directly; the batched toast command would wait for its timer
+	result, _ = m.Update(m.executeBulkImport()())
+	m = result.(Model)
+
+	if len(importer.Calls.ImportBatch) != 1 || len(importer.Calls.ImportBatch[0].Specs) != 2 {
+		t.Fatalf("expected one batch import of 2 resources, got %+v", importer.Calls.ImportBatch)
+	}
+	if m.ui.Focus.Has(ui.FocusBulkImportModal) {
+		t.Error("expected bulk import modal to close after import")
+	}
+}
+
+func TestStateRepairFlow(t *testing.T) {
+	const stackURN = "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"
+	deps := newTestDependencies()
+	importer := &pulumi.FakeResourceImporter{
+		RepairStateFunc: func(ctx context.Context, workDir, stackName string, fixes []pulumi.StateFix, opts pulumi.StateRepairOptions) (*pulumi.StateRepairReport, error) {
+			return &pulumi.StateRepairReport{
+				DryRun:  opts.DryRun,
+				Changes: []pulumi.StateChange{{Action: fixes[0].Action, URN: fixes[0].Issue.URN, NewParent: fixes[0].NewParent}},
+			}, nil
+		},
+	}
+	deps.ResourceImporter = importer
+
+	ctx := AppContext{WorkDir: "/fake/path", StackName: "dev"}
+	m := initialModel(context.Background(), ctx, deps)
+
+	// Loading state with an orphaned child reports the issue
+	result, cmd := m.Update(stackResourcesMsg{
+		{URN: stackURN, Type: "pulumi:pulumi:Stack", Name: "app-dev"},
+		{URN: "urn:pulumi:dev::app::my:Component$random:index/randomId:RandomId::id", Type: "random:index/randomId:RandomId", Name: "id", Parent: "urn:pulumi:dev::app::my:Component::web"},
+	})
+	m = result.(Model)
+	if len(m.state.StateIssues) != 1 || m.state.StackURN != stackURN || cmd == nil {
+		t.Fatalf("expected one state issue and a toast, got %+v", m.state.StateIssues)
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	m = result.(Model)
+	if !m.ui.Focus.Has(ui.FocusStateRepairModal) {
+		t.Fatal("expected state repair modal to open")
+	}
+
+	// Choose re-parent and run the dry run
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	m = result.(Model)
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("expected dry run command")
+	}
+	result, _ = m.Update(cmd())
+	m = result.(Model)
+	if calls := importer.Calls.RepairState; len(calls) != 1 || !calls[0].Opts.DryRun || calls[0].Fixes[0].NewParent != stackURN {
+		t.Fatalf("expected dry run re-parenting to the stack, got %+v", calls)
+	}
+	if m.ui.StateRepairModal.Report() == nil {
+		t.Fatal("expected dry-run report to be shown")
+	}
+
+	// Confirm the report to write state
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("expected repair command")
+	}
+	result, _ = m.Update(cmd())
+	m = result.(Model)
+	if calls := importer.Calls.RepairState; len(calls) != 2 || calls[1].Opts.DryRun {
+		t.Fatalf("expected state to be written, got %+v", calls)
+	}
+	if m.ui.Focus.Has(ui.FocusStateRepairModal) {
+		t.Error("expected state repair modal to close after repair")
+	}
+}
+
+func TestBulkProtectFlow(t *testing.T) {
+	deps := newTestDependencies()
+	importer := &pulumi.FakeResourceImporter{}
+	deps.ResourceImporter = importer
+
+	ctx := AppContext{WorkDir: "/fake/path", StackName: "dev"}
+	m := initialModel(context.Background(), ctx, deps)
+
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	result, _ = m.Update(stackResourcesMsg{
+		{URN: "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev", Type: "pulumi:pulumi:Stack", Name: "app-dev"},
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Type: "aws:s3/bucket:Bucket", Name: "logs"},
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::data", Type: "aws:s3/bucket:Bucket", Name: "data", Protected: true},
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets", Type: "aws:s3/bucket:Bucket", Name: "assets", Protected: true},
+	})
+	m = result.(Model)
+
+	// Select every resource in visual mode
+	for _, r := range []rune{'v', 'G'} {
+		result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+	}
+
+	// Protecting runs immediately for the unprotected resources only
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("expected protect command")
+	}
+	result, _ = m.Update(cmd())
+	m = result.(Model)
+	if calls := importer.Calls.SetProtect; len(calls) != 1 || !calls[0].Protect ||
+		!slices.Equal(calls[0].URNs, []string{"urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"}) {
+		t.Fatalf("expected logs to be protected, got %+v", calls)
+	}
+
+	// Unprotecting several resources asks for confirmation first
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	m = result.(Model)
+	if !m.ui.Focus.Has(ui.FocusConfirmModal) || m.state.PendingProtectAction == nil {
+		t.Fatal("expected unprotect confirmation")
+	}
+	if len(importer.Calls.SetProtect) != 1 {
+		t.Fatal("expected no unprotect before confirmation")
+	}
+
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("expected unprotect command")
+	}
+	m.Update(cmd())
+	if calls := importer.Calls.SetProtect; len(calls) != 2 || calls[1].Protect || len(calls[1].URNs) != 2 {
+		t.Fatalf("expected data and assets to be unprotected together, got %+v", calls)
+	}
+}
+
+func TestPreviewWarningsFlow(t *testing.T) {
+	const bucketURN = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	deps := newTestDependencies()
+	ctx := AppContext{WorkDir: "/fake/path", StackName: "dev"}
+	m := initialModel(context.Background(), ctx, deps)
+
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.startPreview(pulumi.OperationUp)
+
+	warning := pulumi.PreviewWarning{Severity: pulumi.WarningSeverityWarning, URN: bucketURN, Message: "aws:s3/bucket:Bucket is deprecated"}
+	for _, event := range []pulumi.PreviewEvent{
+		{Step: &pulumi.PreviewStep{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::data", Op: pulumi.OpCreate, Type: "aws:s3/bucket:Bucket", Name: "data"}},
+		{Step: &pulumi.PreviewStep{URN: bucketURN, Op: pulumi.OpCreate, Type: "aws:s3/bucket:Bucket", Name: "logs"}},
+		{Warning: &warning},
+		{Warning: &warning}, // Repeated diagnostics are collected once
+	} {
+		result, _ = m.Update(previewEventMsg(event))
+		m = result.(Model)
+	}
+	result, cmd := m.Update(previewEventMsg{Done: true})
+	m = result.(Model)
+	if len(m.state.PreviewWarnings) != 1 {
+		t.Fatalf("expected one collected warning, got %+v", m.state.PreviewWarnings)
+	}
+	if cmd == nil {
+		t.Error("expected a toast announcing the warnings")
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	m = result.(Model)
+	if !m.ui.Focus.Has(ui.FocusWarnings) {
+		t.Fatal("expected warnings panel to open")
+	}
+
+	// Enter jumps to the resource the warning is about
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.ui.Focus.Has(ui.FocusWarnings) {
+		t.Error("expected warnings panel to close after jumping")
+	}
+	if item := m.ui.ResourceList.SelectedItem(); item == nil || item.URN != bucketURN {
+		t.Errorf("expected cursor on logs bucket, got %+v", item)
+	}
+
+	// A new preview starts with no warnings
+	m.startPreview(pulumi.OperationUp)
+	if len(m.state.PreviewWarnings) != 0 {
+		t.Error("expected warnings to be cleared for a new preview")
+	}
+}
+
+func TestPreviewDiagnosticsStrip(t *testing.T) {
+	deps := newTestDependencies()
+	ctx := AppContext{WorkDir: "/fake/path", StackName: "dev"}
+	m := initialModel(context.Background(), ctx, deps)
+
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.startPreview(pulumi.OperationUp)
+
+	for _, event := range []pulumi.PreviewEvent{
+		{Diagnostic: &pulumi.EngineDiagnostic{Severity: "info", Message: "Synthesizing stack"}},
+		{
+			Warning:    &pulumi.PreviewWarning{Severity: pulumi.WarningSeverityWarning, Message: "provider is deprecated"},
+			Diagnostic: &pulumi.EngineDiagnostic{Severity: "warning", Message: "provider is deprecated"},
+		},
+	} {
+		result, _ = m.Update(previewEventMsg(event))
+		m = result.(Model)
+	}
+	if got := len(m.ui.Diagnostics.Diagnostics()); got != 2 {
+		t.Fatalf("expected 2 diagnostics in the strip, got %d", got)
+	}
+	if !strings.Contains(m.View(), "provider is deprecated") {
+		t.Error("expected the strip to show the latest diagnostic")
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = result.(Model)
+	if !m.ui.Diagnostics.Expanded() {
+		t.Fatal("expected l to expand the strip")
+	}
+	if !strings.Contains(m.View(), "Synthesizing stack") {
+		t.Error("expected the expanded strip to show earlier diagnostics")
+	}
+
+	// A new preview starts with an empty log
+	m.startPreview(pulumi.OperationUp)
+	if len(m.ui.Diagnostics.Diagnostics()) != 0 {
+		t.Error("expected diagnostics to be cleared for a new preview")
+	}
+}
+
+// TestQuickCheck verifies a quick check counts the pending changes of an up
+// preview in the header, without leaving the stack view
+func TestQuickCheck(t *testing.T) {
+	deps := newTestDependencies()
+	deps.StackOperator.(*pulumi.FakeStackOperator).WithPreviewEvents(
+		pulumi.PreviewEvent{Step: &pulumi.PreviewStep{URN: "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev", Op: pulumi.OpSame}},
+		pulumi.PreviewEvent{Step: &pulumi.PreviewStep{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Op: pulumi.OpCreate}},
+		pulumi.PreviewEvent{Step: &pulumi.PreviewStep{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::data", Op: pulumi.OpUpdate}},
+		// Outputs of the same resource are counted once
+		pulumi.PreviewEvent{Step: &pulumi.PreviewStep{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::data", Op: pulumi.OpUpdate}},
+	)
+	ctx := AppContext{WorkDir: "/fake/path", StackName: "dev"}
+	m := initialModel(context.Background(), ctx, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ResourceList.AddItem(ui.ResourceItem{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::data", Name: "data", Op: ui.OpSame})
+	m.ui.Header.SetData(&ui.HeaderData{ProgramName: "app", StackName: "dev"})
+	m.ui.Header.SetSummary(m.ui.ResourceList.Summary(), ui.HeaderDone)
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	m = result.(Model)
+	if m.state.QuickCheck == nil {
+		t.Fatal("expected a quick check to start")
+	}
+	for cmd != nil {
+		var next tea.Cmd
+		for _, msg := range runCmds(cmd) {
+			if msg, ok := msg.(quickCheckEventMsg); ok {
+				result, next = m.Update(msg)
+				m = result.(Model)
+			}
+		}
+		cmd = next
+		if m.state.QuickCheck.Done {
+			break
+		}
+	}
+
+	if !m.state.QuickCheck.Done {
+		t.Fatal("expected the quick check to finish")
+	}
+	if got := quickCheckMessage(m.state.QuickCheck.Summary()); got != "Pending changes: 1 to create, 1 to update" {
+		t.Errorf("unexpected summary %q", got)
+	}
+	if m.ui.ViewMode != ui.ViewStack || m.ui.ResourceList.Summary().Total != 1 {
+		t.Error("expected the stack view and its resources to be left as is")
+	}
+	if header := m.ui.Header.View(); !strings.Contains(header, "pending:") || !strings.Contains(header, "+1 ~1") {
+		t.Errorf("expected the header to show the pending changes, got:\n%s", header)
+	}
+
+	// Switching stacks drops the result
+	result, _ = m.handleStackSelected(stackSelectedMsg("prod"))
+	m = result.(Model)
+	if m.state.QuickCheck != nil || strings.Contains(m.ui.Header.View(), "pending:") {
+		t.Error("expected the quick check to be cleared when the stack changes")
+	}
+}
+
+// TestStackResultBadges verifies the result of each stack's latest update is read
+// when the stacks are listed and shown in the stack selector and the header
+func TestStackResultBadges(t *testing.T) {
+	deps := newTestDependencies()
+	reader := deps.StackReader.(*pulumi.FakeStackReader)
+	reader.GetHistoryFunc = func(ctx context.Context, workDir, stackName string, pageSize, page int, opts pulumi.ReadOptions) ([]pulumi.UpdateSummary, error) {
+		switch stackName {
+		case "dev":
+			return []pulumi.UpdateSummary{{Version: 3, Result: "succeeded"}}, nil
+		case "prod":
+			return []pulumi.UpdateSummary{{Version: 9, Result: "failed"}}, nil
+		}
+		return nil, nil
+	}
+	ctx := AppContext{WorkDir: "/fake/path", StackName: "dev"}
+	m := initialModel(context.Background(), ctx, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.Header.SetData(&ui.HeaderData{ProgramName: "app", StackName: "prod"})
+
+	stacks := []pulumi.StackInfo{{Name: "dev"}, {Name: "prod", Current: true}, {Name: "test"}}
+	result, _ = m.handleStacksList(stacksListMsg{Stacks: stacks})
+	m = result.(Model)
+	m.ctx.StackName = "prod"
+
+	msg := m.fetchStackResults(stacks)()
+	result, _ = m.Update(msg)
+	m = result.(Model)
+
+	for _, call := range reader.Calls.GetHistory {
+		if call.PageSize != 1 {
+			t.Errorf("expected only the latest update to be read, got page size %d", call.PageSize)
+		}
+	}
+	got := make(map[string]string)
+	for _, item := range m.ui.StackSelector.Items() {
+		if !item.IsNewItem {
+			got[item.Name] = item.LastResult
+		}
+	}
+	if want := map[string]string{"dev": "succeeded", "prod": "failed", "test": ""}; !maps.Equal(got, want) {
+		t.Errorf("got results %v, want %v", got, want)
+	}
+	if !strings.Contains(m.ui.Header.View(), "prod ✗") {
+		t.Errorf("expected a failed badge next to the stack in the header, got:\n%s", m.ui.Header.View())
+	}
+
+	// An update run from p5 keeps the badge current
+	m.setStackResult("succeeded")
+	if !strings.Contains(m.ui.Header.View(), "prod ✓") {
+		t.Errorf("expected a succeeded badge after the update, got:\n%s", m.ui.Header.View())
+	}
+}
+
+func TestRunRecord_Record(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	run := NewRunRecord(pulumi.OperationUp, "/fake/path", "org/dev", pulumi.OperationOptions{
+		Targets: []string{"urn:a"},
+		Env:     map[string]string{"SECRET": "value"},
+	}, at)
+
+	run.Record(pulumi.OperationEvent{URN: "urn:a", Op: pulumi.OpCreate, Status: pulumi.StepSuccess}, at)
+	run.Record(pulumi.OperationEvent{Message: "warning: something\n"}, at)
+	run.Record(pulumi.OperationEvent{Done: true}, at)
+
+	want := []string{
+		"03:04:05 success create urn:a",
+		"03:04:05 warning: something",
+		"03:04:05 done",
+	}
+	if !slices.Equal(run.Transcript, want) {
+		t.Errorf("transcript = %q, want %q", run.Transcript, want)
+	}
+	if got := RunDirName(run); got != "20260102-030405-up-org_dev" {
+		t.Errorf("RunDirName = %q", got)
+	}
+}
+
+func TestWriteRunArtifacts(t *testing.T) {
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	items := []ui.ResourceItem{
+		{URN: "urn:a", Type: "random:index/randomId:RandomId", Name: "a", Op: pulumi.OpCreate, Status: ui.StatusSuccess},
+		{URN: "urn:b", Type: "random:index/randomId:RandomId", Name: "b", Op: pulumi.OpUpdate, Status: ui.StatusFailed},
+	}
+
+	t.Run("failed run", func(t *testing.T) {
+		run := NewRunRecord(pulumi.OperationUp, "/fake/path", "dev", pulumi.OperationOptions{}, started)
+		run.Finish(errors.New("update failed"), started.Add(90*time.Second))
+
+		dir, err := WriteRunArtifacts(t.TempDir(), run, items)
+		if err != nil {
+			t.Fatalf("WriteRunArtifacts failed: %v", err)
+		}
+		for _, name := range []string{"plan.json", "transcript.log", "summary.md", "error.txt"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+				t.Errorf("expected %s: %v", name, err)
+			}
+		}
+
+		var plan []map[string]any
+		data, _ := os.ReadFile(filepath.Join(dir, "plan.json"))
+		if err := json.Unmarshal(data, &plan); err != nil || len(plan) != 2 || plan[1]["status"] != "failed" {
+			t.Errorf("unexpected plan.json: %s", data)
+		}
+
+		summary, _ := os.ReadFile(filepath.Join(dir, "summary.md"))
+		for _, want := range []string{"| Result | failed |", "| Duration | 1m30s |", "| create | 1 |", "- `urn:b`"} {
+			if !strings.Contains(string(summary), want) {
+				t.Errorf("summary missing %q:\n%s", want, summary)
+			}
+		}
+	})
+
+	t.Run("successful run has no error details", func(t *testing.T) {
+		run := NewRunRecord(pulumi.OperationRefresh, "/fake/path", "dev", pulumi.OperationOptions{}, started)
+		run.Finish(nil, started)
+
+		dir, err := WriteRunArtifacts(t.TempDir(), run, items[:1])
+		if err != nil {
+			t.Fatalf("WriteRunArtifacts failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "error.txt")); !os.IsNotExist(err) {
+			t.Errorf("expected no error.txt, got %v", err)
+		}
+	})
+}
+
+// TestSaveFlags verifies saved flags round-trip per stack
+func TestSaveFlags(t *testing.T) {
+	dir := t.TempDir()
+	dev := map[string]ui.ResourceFlags{
+		"urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs": {Target: true},
+		"urn:pulumi:dev::app::aws:s3/bucket:Bucket::data": {},
+	}
+	if err := SaveFlags(dir, "dev", dev); err != nil {
+		t.Fatalf("SaveFlags failed: %v", err)
+	}
+	if err := SaveFlags(dir, "prod", map[string]ui.ResourceFlags{"urn:pulumi:prod::app::aws:s3/bucket:Bucket::logs": {Exclude: true}}); err != nil {
+		t.Fatalf("SaveFlags failed: %v", err)
+	}
+
+	flags, err := LoadSavedFlags(dir, "dev")
+	if err != nil {
+		t.Fatalf("LoadSavedFlags failed: %v", err)
+	}
+	if len(flags) != 1 || !flags["urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"].Target {
+		t.Errorf("expected only the flagged resource to be saved, got %+v", flags)
+	}
+
+	// Clearing one stack keeps the others
+	if err := SaveFlags(dir, "dev", nil); err != nil {
+		t.Fatalf("SaveFlags failed: %v", err)
+	}
+	if flags, _ := LoadSavedFlags(dir, "dev"); flags != nil {
+		t.Errorf("expected dev flags to be cleared, got %+v", flags)
+	}
+	if flags, _ := LoadSavedFlags(dir, "prod"); len(flags) != 1 {
+		t.Errorf("expected prod flags to be kept, got %+v", flags)
+	}
+
+	// A project without a saved flags file has no flags
+	if flags, err := LoadSavedFlags(t.TempDir(), "dev"); err != nil || flags != nil {
+		t.Errorf("expected no flags, got %+v, %v", flags, err)
+	}
+}
+
+// TestStackURNPrefix verifies the URN prefix used to pick a stack's flags
+func TestStackURNPrefix(t *testing.T) {
+	tests := []struct {
+		stackURN  string
+		stackName string
+		want      string
+	}{
+		{"urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev", "dev", "urn:pulumi:dev::app::"},
+		{"", "dev", "urn:pulumi:dev::"},
+		{"", "acme/app/dev", "urn:pulumi:dev::"},
+	}
+	for _, tt := range tests {
+		if got := StackURNPrefix(tt.stackURN, tt.stackName); got != tt.want {
+			t.Errorf("StackURNPrefix(%q, %q) = %q, want %q", tt.stackURN, tt.stackName, got, tt.want)
+		}
+	}
+}
+
+// TestPersistedFlagsFlow verifies flags are restored, saved on change and cleared with X
+func TestPersistedFlagsFlow(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Pulumi.yaml"), []byte("name: app\nruntime: go\np5:\n  persist_flags: true\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	logs := "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	if err := SaveFlags(dir, "dev", map[string]ui.ResourceFlags{logs: {Replace: true}}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := AppContext{WorkDir: dir, StackName: "dev"}
+	m := initialModel(context.Background(), ctx, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	result, _ = m.Update(stackResourcesMsg{
+		{URN: "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev", Type: "pulumi:pulumi:Stack", Name: "app-dev"},
+		{URN: logs, Type: "aws:s3/bucket:Bucket", Name: "logs"},
+	})
+	m = result.(Model)
+	if m.state.SavedFlagsLoadedFor == "" {
+		t.Fatal("expected saved flags to be loaded with the stack")
+	}
+
+	// Saved flags are restored into the shared flags map
+	result, _ = m.Update(m.loadSavedFlags()())
+	m = result.(Model)
+	if !m.state.PersistFlags || !m.state.Flags[logs].Replace {
+		t.Fatalf("expected restored replace flag, got persist=%v flags=%+v", m.state.PersistFlags, m.state.Flags)
+	}
+
+	// Changing a flag saves the stack's flags
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'E'}})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("expected save command")
+	}
+	if msg := cmd(); msg != nil {
+		t.Fatalf("expected save to succeed, got %+v", msg)
+	}
+	if flags, _ := LoadSavedFlags(dir, "dev"); !flags[logs].Exclude {
+		t.Errorf("expected exclude flag to be saved, got %+v", flags)
+	}
+
+	// X clears the saved flags and the current ones
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	m = result.(Model)
+	if cmd == nil || len(m.state.Flags) != 0 {
+		t.Fatalf("expected flags to be cleared, got %+v", m.state.Flags)
+	}
+	if msg, ok := cmd().(flagsSavedMsg); !ok || !msg.Cleared || msg.Err != nil {
+		t.Errorf("expected cleared message, got %+v", msg)
+	}
+	if flags, _ := LoadSavedFlags(dir, "dev"); flags != nil {
+		t.Errorf("expected saved flags to be removed, got %+v", flags)
+	}
+}
+
+// TestSaveNote verifies notes are saved per resource and removed when emptied
+func TestSaveNote(t *testing.T) {
+	dir := t.TempDir()
+	db := "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+	queue := "urn:pulumi:dev::app::aws:sqs/queue:Queue::jobs"
+	if err := SaveNote(dir, db, "manually resized, do not replace"); err != nil {
+		t.Fatalf("SaveNote failed: %v", err)
+	}
+	if err := SaveNote(dir, queue, "owned by the data team"); err != nil {
+		t.Fatalf("SaveNote failed: %v", err)
+	}
+
+	notes, err := LoadNotes(dir)
+	if err != nil {
+		t.Fatalf("LoadNotes failed: %v", err)
+	}
+	if len(notes) != 2 || notes[db] != "manually resized, do not replace" {
+		t.Errorf("expected both notes to be loaded, got %+v", notes)
+	}
+
+	// An empty note removes only that resource's note
+	if err := SaveNote(dir, db, ""); err != nil {
+		t.Fatalf("SaveNote failed: %v", err)
+	}
+	if notes, _ := LoadNotes(dir); len(notes) != 1 || notes[queue] == "" {
+		t.Errorf("expected only the queue note to remain, got %+v", notes)
+	}
+
+	// A project without notes has none
+	if notes, err := LoadNotes(t.TempDir()); err != nil || notes != nil {
+		t.Errorf("expected no notes, got %+v, %v", notes, err)
+	}
+}
+
+// TestNotesFlow verifies notes are loaded with the stack and edited with N
+func TestNotesFlow(t *testing.T) {
+	dir := t.TempDir()
+	logs := "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	if err := SaveNote(dir, logs, "shared with the audit account"); err != nil {
+		t.Fatal(err)
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: dir, StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	result, _ = m.Update(stackResourcesMsg{
+		{URN: "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev", Type: "pulumi:pulumi:Stack", Name: "app-dev"},
+		{URN: logs, Type: "aws:s3/bucket:Bucket", Name: "logs"},
+	})
+	m = result.(Model)
+	if m.state.NotesLoadedFor != dir {
+		t.Fatal("expected notes to be loaded with the stack")
+	}
+	result, _ = m.Update(m.loadNotes()())
+	m = result.(Model)
+	if m.state.Notes[logs] != "shared with the audit account" {
+		t.Fatalf("expected the saved note to be loaded, got %+v", m.state.Notes)
+	}
+
+	// N opens the note of the selected resource for editing
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	m = result.(Model)
+	if !m.ui.Focus.Has(ui.FocusNoteModal) {
+		t.Fatal("expected the note modal to open")
+	}
+
+	// Clearing the note and saving removes it
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlU})
+	m = result.(Model)
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.ui.Focus.Has(ui.FocusNoteModal) || cmd == nil {
+		t.Fatal("expected the note modal to close and save")
+	}
+	result, _ = m.Update(cmd())
+	m = result.(Model)
+	if _, ok := m.state.Notes[logs]; ok {
+		t.Errorf("expected the note to be removed, got %+v", m.state.Notes)
+	}
+	if notes, _ := LoadNotes(dir); len(notes) != 0 {
+		t.Errorf("expected the saved note to be removed, got %+v", notes)
+	}
+}
+
+// TestPinnedFlow verifies pins are loaded with the stack, listed at the top and
+// saved when toggled
+func TestPinnedFlow(t *testing.T) {
+	dir := t.TempDir()
+	db := "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+	logs := "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	if err := SavePinned(dir, map[string]bool{logs: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: dir, StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	result, _ = m.Update(stackResourcesMsg{
+		{URN: "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev", Type: "pulumi:pulumi:Stack", Name: "app-dev"},
+		{URN: db, Type: "aws:rds/instance:Instance", Name: "db", Parent: "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"},
+		{URN: logs, Type: "aws:s3/bucket:Bucket", Name: "logs", Parent: "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"},
+	})
+	m = result.(Model)
+	if m.state.PinnedLoadedFor != dir {
+		t.Fatal("expected pinned resources to be loaded with the stack")
+	}
+	result, _ = m.Update(m.loadPinned()())
+	m = result.(Model)
+	if item := m.ui.ResourceList.SelectedItem(); item == nil || item.URN != logs {
+		t.Fatalf("expected the pinned bucket listed first, got %+v", item)
+	}
+
+	// Pinning the database keeps the cursor on its row in the tree
+	if !m.ui.ResourceList.SelectURN(db) {
+		t.Fatal("expected the database to be listed")
+	}
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p"), Alt: true})
+	m = result.(Model)
+	if !m.state.Pinned[db] || cmd == nil {
+		t.Fatalf("expected the database to be pinned and saved, got %v", m.state.Pinned)
+	}
+	if item := m.ui.ResourceList.SelectedItem(); item == nil || item.URN != db {
+		t.Errorf("expected the cursor to stay on the database, got %+v", item)
+	}
+	if !strings.Contains(m.renderFooter(), "alt+p unpin") {
+		t.Errorf("expected the footer to offer unpinning, got %q", m.renderFooter())
+	}
+	runCmds(cmd)
+	if pinned, _ := LoadPinned(dir); len(pinned) != 2 || !pinned[db] {
+		t.Errorf("expected both pins to be saved, got %v", pinned)
+	}
+}
+
+// TestGotoResourceFlow verifies the goto prompt moves the cursor to the matching
+// resource and the change keys jump between resources with changes
+func TestGotoResourceFlow(t *testing.T) {
+	const (
+		stack = "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"
+		db    = "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+		logs  = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	)
+	m := initialModel(context.Background(), AppContext{WorkDir: t.TempDir(), StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{
+		{URN: stack, Type: "pulumi:pulumi:Stack", Name: "app-dev", Op: ui.OpSame},
+		{URN: db, Type: "aws:rds/instance:Instance", Name: "db", Op: ui.OpUpdate, Parent: stack},
+		{URN: logs, Type: "aws:s3/bucket:Bucket", Name: "logs", Op: ui.OpSame, Parent: stack},
+	})
+	typeQuery := func(m Model, query string) Model {
+		result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlU})
+		m = result.(Model)
+		for _, r := range query {
+			result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			m = result.(Model)
+		}
+		result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+		return result.(Model)
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(":")})
+	m = result.(Model)
+	if !m.ui.Focus.Has(ui.FocusGotoModal) {
+		t.Fatal("expected the goto prompt to open")
+	}
+
+	// Without a match the prompt stays open to correct the query
+	m = typeQuery(m, "queue")
+	if !m.ui.Focus.Has(ui.FocusGotoModal) || !strings.Contains(m.ui.GotoModal.View(), "No resource matches queue") {
+		t.Fatalf("expected the prompt to stay open with an error, got %q", m.ui.GotoModal.View())
+	}
+	m = typeQuery(m, "s3/bucket")
+	if m.ui.Focus.Has(ui.FocusGotoModal) {
+		t.Fatal("expected the prompt to close on a match")
+	}
+	if item := m.ui.ResourceList.SelectedItem(); item == nil || item.URN != logs {
+		t.Fatalf("expected the cursor on the bucket, got %+v", item)
+	}
+
+	// [ moves back to the database, the only resource with changes
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("[")})
+	m = result.(Model)
+	if item := m.ui.ResourceList.SelectedItem(); item == nil || item.URN != db {
+		t.Fatalf("expected the cursor on the database, got %+v", item)
+	}
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")})
+	m = result.(Model)
+	runCmds(cmd)
+	if !strings.Contains(m.ui.Toast.View(120), "No more changes") {
+		t.Errorf("expected a toast at the last change, got %q", m.ui.Toast.View(120))
+	}
+}
+
+// TestIdleLockFlow verifies the UI locks after inactivity on protected stacks and
+// unlocks with a key press or the passphrase
+func TestIdleLockFlow(t *testing.T) {
+	newModel := func(stack string, cfg *plugins.IdleLockConfig) Model {
+		ctx := AppContext{WorkDir: "/fake/path", StackName: stack, IdleLock: cfg}
+		m := initialModel(context.Background(), ctx, newTestDependencies())
+		result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		return result.(Model)
+	}
+	idle := func(t *testing.T, m Model) Model {
+		t.Helper()
+		m.state.LastActivity = time.Now().Add(-time.Hour)
+		result, cmd := m.Update(idleCheckMsg{})
+		if cmd == nil {
+			t.Fatal("expected the next idle check to be scheduled")
+		}
+		return result.(Model)
+	}
+	press := func(t *testing.T, m Model, msg tea.KeyMsg) Model {
+		t.Helper()
+		result, _ := m.handleKeyPress(msg)
+		return result.(Model)
+	}
+
+	t.Run("recent activity does not lock", func(t *testing.T) {
+		m := newModel("prod", &plugins.IdleLockConfig{Timeout: "10m"})
+		result, _ := m.Update(idleCheckMsg{})
+		m = result.(Model)
+		if m.ui.LockScreen.Visible() {
+			t.Error("expected no lock before the timeout")
+		}
+	})
+
+	t.Run("unprotected stacks do not lock", func(t *testing.T) {
+		m := idle(t, newModel("dev", &plugins.IdleLockConfig{Timeout: "10m", Stacks: []string{"prod"}}))
+		if m.ui.LockScreen.Visible() {
+			t.Error("expected dev not to lock")
+		}
+	})
+
+	t.Run("any key unlocks without passphrase", func(t *testing.T) {
+		m := idle(t, newModel("prod", &plugins.IdleLockConfig{Timeout: "10m", Stacks: []string{"prod"}}))
+		if !m.ui.LockScreen.Visible() {
+			t.Fatal("expected prod to lock")
+		}
+		if !strings.Contains(m.View(), "p5 is locked") {
+			t.Error("expected the lock screen to replace the view")
+		}
+		// The key only unlocks, it is not handled by the main view
+		m = press(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+		if m.ui.LockScreen.Visible() || m.ui.Focus.Has(ui.FocusHelp) {
+			t.Error("expected the key to unlock without opening help")
+		}
+	})
+
+	t.Run("passphrase is required", func(t *testing.T) {
+		t.Setenv("P5_TEST_LOCK_PASSPHRASE", "hunter2")
+		m := idle(t, newModel("prod", &plugins.IdleLockConfig{Timeout: "10m", PassphraseEnv: "P5_TEST_LOCK_PASSPHRASE"}))
+
+		m = press(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+		m = press(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("guess")})
+		m = press(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+		if !m.ui.LockScreen.Visible() {
+			t.Fatal("expected a wrong passphrase to keep the lock")
+		}
+
+		m = press(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("hunter2")})
+		m = press(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+		if m.ui.LockScreen.Visible() {
+			t.Error("expected the passphrase to unlock")
+		}
+	})
+}
+
+// TestStatePolling verifies polling marks the stack outdated once another update
+// lands after it was loaded, and that reloading clears the badge
+func TestStatePolling(t *testing.T) {
+	t.Run("versioned backend", func(t *testing.T) {
+		version := 3
+		testStatePolling(t, func() pulumi.UpdateSummary {
+			return pulumi.UpdateSummary{Version: version, StartTime: "2024-01-01T00:00:00Z"}
+		}, func() { version++ })
+	})
+	// Local and diy backends report every update as version 0
+	t.Run("local backend", func(t *testing.T) {
+		start := "2024-01-01T00:00:00Z"
+		testStatePolling(t, func() pulumi.UpdateSummary {
+			return pulumi.UpdateSummary{Version: 0, StartTime: start}
+		}, func() { start = "2024-01-02T00:00:00Z" })
+	})
+}
+
+// testStatePolling loads a stack whose latest update is read from latest, then
+// calls update to simulate an update made elsewhere
+func testStatePolling(t *testing.T, latest func() pulumi.UpdateSummary, update func()) {
+	t.Helper()
+	deps := newTestDependencies()
+	deps.StackReader = &pulumi.FakeStackReader{
+		GetHistoryFunc: func(ctx context.Context, workDir, stackName string, pageSize, page int, opts pulumi.ReadOptions) ([]pulumi.UpdateSummary, error) {
+			return []pulumi.UpdateSummary{latest()}, nil
+		},
+	}
+	ctx := AppContext{WorkDir: "/fake/path", StackName: "dev", PollInterval: time.Minute}
+	m := initialModel(context.Background(), ctx, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.transitionTo(InitLoadingResources)
+
+	deliver := func(cmd tea.Cmd) {
+		t.Helper()
+		for _, msg := range runCmds(cmd) {
+			if msg, ok := msg.(stateVersionMsg); ok {
+				result, _ := m.Update(msg)
+				m = result.(Model)
+			}
+		}
+	}
+	poll := func() {
+		t.Helper()
+		result, cmd := m.Update(statePollMsg{})
+		m = result.(Model)
+		deliver(cmd)
+	}
+
+	result, cmd := m.Update(stackResourcesMsg{{URN: "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev", Type: "pulumi:pulumi:Stack"}})
+	m = result.(Model)
+	deliver(cmd)
+	loaded := updateRevision(latest())
+	if m.state.LoadedRevision != loaded {
+		t.Fatalf("expected the loaded revision to be recorded, got %q", m.state.LoadedRevision)
+	}
+
+	poll()
+	if m.state.StateOutdated {
+		t.Fatal("expected the stack not to be outdated without new updates")
+	}
+
+	update()
+	poll()
+	if !m.state.StateOutdated || !strings.Contains(m.ui.Header.View(), "[outdated]") {
+		t.Fatal("expected the stack to be marked outdated after another update")
+	}
+
+	result, cmd, handled := m.handleViewToggles(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	m = result.(Model)
+	if !handled {
+		t.Fatal("expected L to reload the stack")
+	}
+	for _, msg := range runCmds(cmd) {
+		result, cmd := m.Update(msg)
+		m = result.(Model)
+		deliver(cmd)
+	}
+	if m.state.StateOutdated || m.state.LoadedRevision == loaded {
+		t.Errorf("expected reloading to clear the badge and record the new revision, got outdated=%v revision=%q", m.state.StateOutdated, m.state.LoadedRevision)
+	}
+}
+
+// TestStateExportImport verifies state is exported to the entered file, and that a
+// deployment file is validated and confirmed before it replaces the stack's state
+func TestStateExportImport(t *testing.T) {
+	workDir := t.TempDir()
+	deps := newTestDependencies()
+	transferer := deps.StateTransferer.(*pulumi.FakeStateTransferer)
+
+	m := initialModel(context.Background(), AppContext{WorkDir: workDir, StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	typePath := func(p string) {
+		t.Helper()
+		result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(p)})
+		m = result.(Model)
+	}
+	update := func(msgs []tea.Msg) {
+		t.Helper()
+		for _, msg := range msgs {
+			result, _ := m.Update(msg)
+			m = result.(Model)
+		}
+	}
+
+	// Export defaults to a timestamped file under the project
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlE})
+	m = result.(Model)
+	if !m.ui.StateFileModal.Visible() || m.ui.StateFileModal.Importing() {
+		t.Fatal("expected the export prompt")
+	}
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	update(runCmds(cmd))
+	if len(transferer.Calls.ExportDeployment) != 1 {
+		t.Fatalf("expected one export, got %d", len(transferer.Calls.ExportDeployment))
+	}
+	if got := transferer.Calls.ExportDeployment[0].Path; !strings.HasPrefix(got, filepath.Join(workDir, StatesDir)) || !strings.HasSuffix(got, "-dev.json") {
+		t.Errorf("expected the default export file under %s, got %s", StatesDir, got)
+	}
+	if m.ui.StateFileModal.Visible() || !strings.Contains(m.ui.Toast.View(120), "Exported 0 resources") {
+		t.Errorf("expected a toast reporting the export, got %q", m.ui.Toast.View(120))
+	}
+
+	// Invalid files are reported in the prompt, which stays open
+	if err := os.WriteFile(filepath.Join(workDir, "bad.json"), []byte(`{"version": 9, "deployment": {}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlO})
+	m = result.(Model)
+	if !m.ui.StateFileModal.Importing() {
+		t.Fatal("expected the import prompt")
+	}
+	typePath("bad.json")
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	update(runCmds(cmd))
+	if !m.ui.StateFileModal.Visible() || !strings.Contains(m.ui.StateFileModal.View(), "unsupported deployment version 9") {
+		t.Fatalf("expected the prompt to show the validation error, got:\n%s", m.ui.StateFileModal.View())
+	}
+	if len(transferer.Calls.ImportDeployment) != 0 {
+		t.Fatal("expected an invalid file not to be imported")
+	}
+
+	// Valid files are confirmed, with a warning when they hold another stack's state
+	good := `{"version": 3, "deployment": {"manifest": {"time": "2024-01-01T00:00:00Z"}, "resources": [
+		{"urn": "urn:pulumi:prod::app::pulumi:pulumi:Stack::app-prod"},
+		{"urn": "urn:pulumi:prod::app::random:index/randomString:RandomString::s"}]}}`
+	if err := os.WriteFile(filepath.Join(workDir, "good.json"), []byte(good), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m.hideStateFileModal()
+	m.openStateFileModal(true)
+	typePath("good.json")
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	update(runCmds(cmd))
+	if !m.ui.ConfirmModal.Visible() || m.state.PendingStateImport != filepath.Join(workDir, "good.json") {
+		t.Fatal("expected a confirmation before importing")
+	}
+	if view := m.ui.ConfirmModal.View(); !strings.Contains(view, "2 resources") || !strings.Contains(view, "app/prod") {
+		t.Errorf("expected the confirmation to describe the file, got:\n%s", view)
+	}
+
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = result.(Model)
+	update(runCmds(cmd))
+	if len(transferer.Calls.ImportDeployment) != 1 || transferer.Calls.ImportDeployment[0].StackName != "dev" {
+		t.Fatalf("expected the file to be imported into dev once, got %+v", transferer.Calls.ImportDeployment)
+	}
+	if m.state.PendingStateImport != "" || m.ui.ConfirmModal.Visible() {
+		t.Error("expected the confirmation to be closed")
+	}
+}
+
+func TestOperationQueueFlow(t *testing.T) {
+	press := func(t *testing.T, m Model, r rune) Model {
+		t.Helper()
+		result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		return result.(Model)
+	}
+	newModel := func() (Model, *pulumi.FakeStackOperator) {
+		deps := newTestDependencies()
+		operator := &pulumi.FakeStackOperator{}
+		deps.StackOperator = operator
+		m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+		result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		return result.(Model), operator
+	}
+
+	t.Run("runs each step after confirmation", func(t *testing.T) {
+		m, operator := newModel()
+
+		m = press(t, m, 'Q')
+		if !m.ui.Focus.Has(ui.FocusConfirmModal) || m.state.OperationQueue == nil {
+			t.Fatal("expected the first step to ask for confirmation")
+		}
+		m = press(t, m, 'y')
+		if len(operator.Calls.Refresh) != 1 {
+			t.Fatalf("expected refresh to run, got %d calls", len(operator.Calls.Refresh))
+		}
+
+		result, _ := m.Update(operationEventMsg{Done: true})
+		m = result.(Model)
+		if !m.ui.Focus.Has(ui.FocusConfirmModal) || m.state.OperationQueue.Current != 1 {
+			t.Fatal("expected to pause before the preview")
+		}
+		m = press(t, m, 'y')
+		if len(operator.Calls.Preview) != 1 || operator.Calls.Preview[0].OpType != pulumi.OperationUp {
+			t.Fatalf("expected up preview to run, got %+v", operator.Calls.Preview)
+		}
+
+		result, _ = m.Update(previewEventMsg{Done: true})
+		m = result.(Model)
+		if !m.ui.Focus.Has(ui.FocusConfirmModal) || m.state.OperationQueue.Current != 2 {
+			t.Fatal("expected to pause before the update")
+		}
+		m = press(t, m, 'y')
+		if len(operator.Calls.Up) != 1 {
+			t.Fatalf("expected up to run, got %d calls", len(operator.Calls.Up))
+		}
+
+		result, _ = m.Update(operationEventMsg{Done: true})
+		m = result.(Model)
+		if m.state.OperationQueue != nil || m.ui.Focus.Has(ui.FocusConfirmModal) {
+			t.Error("expected the queue to finish")
+		}
+	})
+
+	t.Run("stops when a step fails", func(t *testing.T) {
+		m, operator := newModel()
+
+		m = press(t, m, 'Q')
+		m = press(t, m, 'y')
+		result, _ := m.Update(operationEventMsg{Error: errors.New("refresh failed")})
+		m = result.(Model)
+		if m.state.OperationQueue != nil || m.ui.Focus.Has(ui.FocusConfirmModal) {
+			t.Error("expected the queue to stop")
+		}
+		if len(operator.Calls.Preview) != 0 {
+			t.Error("expected no further steps to run")
+		}
+	})
+
+	t.Run("stops when a step is declined", func(t *testing.T) {
+		m, operator := newModel()
+
+		m = press(t, m, 'Q')
+		m = press(t, m, 'n')
+		if m.state.OperationQueue != nil || m.ui.Focus.Has(ui.FocusConfirmModal) {
+			t.Error("expected the queue to stop")
+		}
+		if len(operator.Calls.Refresh) != 0 {
+			t.Error("expected refresh not to run")
+		}
+	})
+}
+
+func TestWorkflowFlow(t *testing.T) {
+	const apiURN = "urn:pulumi:dev::app::aws:lambda/function:Function::api"
+	newModel := func(t *testing.T, config string) (Model, *pulumi.FakeStackOperator) {
+		t.Helper()
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "p5.toml"), []byte(config), 0o600); err != nil {
+			t.Fatalf("failed to write p5.toml: %v", err)
+		}
+		deps := newTestDependencies()
+		operator := &pulumi.FakeStackOperator{}
+		deps.StackOperator = operator
+		deps.StackReader = &pulumi.FakeStackReader{Resources: []pulumi.ResourceInfo{{URN: apiURN, Name: "api"}}}
+		m := initialModel(context.Background(), AppContext{WorkDir: dir, StackName: "dev"}, deps)
+		result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		return result.(Model), operator
+	}
+	// run selects the only workflow and starts it
+	run := func(t *testing.T, m Model) Model {
+		t.Helper()
+		result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+		m = result.(Model)
+		if !m.ui.Focus.Has(ui.FocusWorkflowSelector) || cmd == nil {
+			t.Fatal("expected the workflow selector to open")
+		}
+		result, _ = m.Update(cmd())
+		m = result.(Model)
+		result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+		m = result.(Model)
+		if m.ui.Focus.Has(ui.FocusWorkflowSelector) || cmd == nil {
+			t.Fatal("expected the selector to close and the workflow to start")
+		}
+		result, _ = m.Update(cmd())
+		return result.(Model)
+	}
+
+	t.Run("runs steps with their own flags and stops for approval", func(t *testing.T) {
+		m, operator := newModel(t, `
+[[workflows.deploy.steps]]
+op = "refresh"
+
+[[workflows.deploy.steps]]
+op = "up"
+preview = true
+targets = ["api"]
+
+[[workflows.deploy.steps]]
+op = "up"
+approve = true
+targets = ["api"]
+`)
+		m = run(t, m)
+		if len(operator.Calls.Refresh) != 1 || m.ui.Focus.Has(ui.FocusConfirmModal) {
+			t.Fatal("expected refresh to run without confirmation")
+		}
+		if m.state.OperationQueue == nil || m.state.OperationQueue.Name != "deploy" {
+			t.Fatalf("expected the deploy workflow to be queued, got %+v", m.state.OperationQueue)
+		}
+
+		result, _ := m.Update(operationEventMsg{Done: true})
+		m = result.(Model)
+		if len(operator.Calls.Preview) != 1 || !slices.Equal(operator.Calls.Preview[0].Opts.Targets, []string{apiURN}) {
+			t.Fatalf("expected a targeted up preview, got %+v", operator.Calls.Preview)
+		}
+
+		result, _ = m.Update(previewEventMsg{Done: true})
+		m = result.(Model)
+		if !m.ui.Focus.Has(ui.FocusConfirmModal) || len(operator.Calls.Up) != 0 {
+			t.Fatal("expected to pause for approval before the update")
+		}
+		result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+		m = result.(Model)
+		if len(operator.Calls.Up) != 1 || !slices.Equal(operator.Calls.Up[0].Opts.Targets, []string{apiURN}) {
+			t.Fatalf("expected a targeted up, got %+v", operator.Calls.Up)
+		}
+
+		result, _ = m.Update(operationEventMsg{Done: true})
+		m = result.(Model)
+		if m.state.OperationQueue != nil {
+			t.Error("expected the workflow to finish")
+		}
+	})
+
+	t.Run("reports unknown resources", func(t *testing.T) {
+		m, operator := newModel(t, "[[workflows.deploy.steps]]\nop = \"up\"\ntargets = [\"missing\"]\n")
+		m = run(t, m)
+		if !m.ui.Focus.Has(ui.FocusErrorModal) || m.state.OperationQueue != nil {
+			t.Error("expected an error instead of starting the workflow")
+		}
+		if len(operator.Calls.Up) != 0 {
+			t.Error("expected up not to run")
+		}
+	})
+}
+
+func TestDiffStateSnapshots_IncludeUnchanged(t *testing.T) {
+	const (
+		stackURN  = "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"
+		keptURN   = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::kept"
+		changeURN = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::changed"
+		goneURN   = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::gone"
+		newURN    = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::new"
+	)
+	older := []pulumi.ResourceInfo{
+		{URN: stackURN, Type: "pulumi:pulumi:Stack"},
+		{URN: keptURN, Inputs: map[string]any{"acl": "private"}},
+		{URN: changeURN, Inputs: map[string]any{"acl": "private"}},
+		{URN: changeURN, Inputs: map[string]any{"acl": "old"}, PendingDelete: true},
+		{URN: goneURN, Inputs: map[string]any{"acl": "private"}},
+	}
+	newer := []pulumi.ResourceInfo{
+		{URN: stackURN, Type: "pulumi:pulumi:Stack"},
+		{URN: keptURN, Inputs: map[string]any{"acl": "private"}},
+		{URN: changeURN, Inputs: map[string]any{"acl": "public-read"}},
+		{URN: newURN, Inputs: map[string]any{"acl": "private"}},
+	}
+
+	items := DiffStateSnapshots(older, newer, true)
+	ops := make(map[string]ui.ResourceOp)
+	for _, item := range items {
+		ops[item.URN] = item.Op
+	}
+	want := map[string]ui.ResourceOp{
+		stackURN:  pulumi.OpSame,
+		keptURN:   pulumi.OpSame,
+		changeURN: pulumi.OpUpdate,
+		goneURN:   pulumi.OpDelete,
+		newURN:    pulumi.OpCreate,
+	}
+	if len(items) != len(want) || !maps.Equal(ops, want) {
+		t.Fatalf("unexpected ops %v", ops)
+	}
+	for _, item := range items {
+		switch item.URN {
+		case changeURN:
+			if item.OldInputs["acl"] != "private" || item.Inputs["acl"] != "public-read" {
+				t.Errorf("expected update to carry old and new inputs, got %+v", item)
+			}
+		case goneURN:
+			if item.OldInputs["acl"] != "private" || item.Inputs != nil {
+				t.Errorf("expected delete to carry old inputs only, got %+v", item)
+			}
+		}
+	}
+}
+
+func TestParseStackURN(t *testing.T) {
+	stack, project, ok := pulumi.ParseStackURN("urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev")
+	if !ok || stack != "dev" || project != "app" {
+		t.Errorf("expected dev and app, got %q and %q", stack, project)
+	}
+	if stack, project, ok := pulumi.ParseStackURN(""); ok || stack != "" || project != "" {
+		t.Errorf("expected empty names, got %q and %q", stack, project)
+	}
+}
+
+func TestStateBrowserFlow(t *testing.T) {
+	dir := t.TempDir()
+	const bucket = `{"urn": "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", "type": "aws:s3/bucket:Bucket", "parent": "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev", "inputs": {"acl": "%s"}}`
+	const stack = `{"urn": "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev", "type": "pulumi:pulumi:Stack"}`
+	files := map[string]string{
+		// Output of `pulumi stack export`
+		"before.json": `{"version": 3, "deployment": {"resources": [` + stack + `, ` + fmt.Sprintf(bucket, "private") + `]}}`,
+		// Checkpoint kept by a file backend
+		"after.json": `{"version": 3, "checkpoint": {"stack": "dev", "latest": {"resources": [` + stack + `, ` + fmt.Sprintf(bucket, "public-read") + `]}}}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deps := newTestDependencies()
+	operator := &pulumi.FakeStackOperator{}
+	deps.StackOperator = operator
+	ctx := AppContext{
+		WorkDir:    dir,
+		StartView:  "state",
+		StateFiles: []string{filepath.Join(dir, "before.json"), filepath.Join(dir, "after.json")},
+	}
+	m := initialModel(context.Background(), ctx, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	result, _ = m.Update(m.loadStateFiles()())
+	m = result.(Model)
+
+	if m.state.InitState != InitComplete {
+		t.Fatalf("expected init to complete, got %v", m.state.InitState)
+	}
+	items := m.ui.ResourceList.Items()
+	if len(items) != 2 || items[1].Op != pulumi.OpUpdate {
+		t.Fatalf("expected the bucket to show as updated, got %+v", items)
+	}
+	if !strings.Contains(m.ui.Header.View(), "before.json → after.json") {
+		t.Error("expected the header to name the state files")
+	}
+
+	// Operations and flags are not available while browsing state
+	for _, r := range []rune{'u', 'T'} {
+		result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+	}
+	if len(operator.Calls.Preview) != 0 || len(m.state.Flags) != 0 {
+		t.Error("expected keys that change the stack to be ignored")
+	}
+
+	// The details panel still opens
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	m = result.(Model)
+	if !m.ui.Details.Visible() {
+		t.Error("expected the details panel to open")
+	}
+
+	// Unreadable state shows an error
+	m = initialModel(context.Background(), AppContext{WorkDir: dir, StartView: "state", StateFiles: []string{filepath.Join(dir, "missing.json")}}, deps)
+	result, _ = m.Update(m.loadStateFiles()())
+	m = result.(Model)
+	if !strings.Contains(m.ui.Header.View(), "failed to read state file") {
+		t.Error("expected the header to show the read error")
+	}
+}
+
+// TestPluginIndexFlow verifies browsing the plugin index and installing a plugin from it.
+func TestPluginIndexFlow(t *testing.T) {
+	newModel := func(installer *plugins.FakePluginInstaller) Model {
+		deps := newTestDependencies()
+		deps.PluginInstaller = installer
+		deps.PluginProvider = &plugins.FakePluginProvider{
+			MergedConfig: &plugins.P5Config{Plugins: map[string]plugins.PluginConfig{"vault": {Cmd: "p5-vault"}}},
+		}
+		m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+		m.state.InitState = InitComplete
+		result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		m = result.(Model)
+
+		result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+		m = result.(Model)
+		if !m.ui.Focus.Has(ui.FocusPluginIndexModal) || cmd == nil {
+			t.Fatal("expected the plugin index to open and be fetched")
+		}
+		result, _ = m.Update(cmd())
+		return result.(Model)
+	}
+	index := &plugins.PluginIndex{Plugins: []plugins.IndexEntry{
+		{Name: "vault", Package: "example.com/p5-vault"},
+		{Name: "sops", Package: "example.com/p5-sops", Version: "v1.2.0"},
+	}}
+
+	t.Run("installs the selected plugin", func(t *testing.T) {
+		installer := &plugins.FakePluginInstaller{Index: index}
+		m := newModel(installer)
+
+		// Configured plugins can't be installed again
+		result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+		m = result.(Model)
+		if cmd != nil {
+			t.Fatal("expected an installed plugin not to be installed again")
+		}
+
+		result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+		m = result.(Model)
+		result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+		m = result.(Model)
+		if cmd == nil {
+			t.Fatal("expected the install to start")
+		}
+		result, _ = m.Update(cmd())
+		m = result.(Model)
+
+		if len(installer.Calls.Install) != 1 || installer.Calls.Install[0].Version != "v1.2.0" {
+			t.Fatalf("expected sops to be installed at its indexed version, got %+v", installer.Calls.Install)
+		}
+		if entry := m.ui.PluginIndexModal.SelectedEntry(); entry == nil || !entry.Installed {
+			t.Error("expected sops to be marked installed")
+		}
+		if !m.state.IsBusy() {
+			t.Error("expected plugins to be reloaded")
+		}
+	})
+
+	t.Run("shows install errors", func(t *testing.T) {
+		installer := &plugins.FakePluginInstaller{
+			Index: index,
+			InstallFunc: func(ctx context.Context, workDir string, entry plugins.IndexEntry) (*plugins.InstallResult, error) {
+				return nil, errors.New("go install failed")
+			},
+		}
+		m := newModel(installer)
+
+		result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+		m = result.(Model)
+		result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+		m = result.(Model)
+		result, _ = m.Update(cmd())
+		m = result.(Model)
+
+		if !strings.Contains(m.ui.PluginIndexModal.View(), "go install failed") {
+			t.Error("expected the install error to be shown")
+		}
+		if m.state.IsBusy() {
+			t.Error("expected plugins not to be reloaded after a failed install")
+		}
+
+		result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+		m = result.(Model)
+		if m.ui.Focus.Has(ui.FocusPluginIndexModal) {
+			t.Error("expected esc to close the plugin index")
+		}
+	})
+}
+
+func TestDriftedKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		item ui.ResourceItem
+		want []string
+	}{
+		{"no state", ui.ResourceItem{Op: ui.OpRefresh, Outputs: map[string]any{"size": 1}}, nil},
+		{"unchanged", ui.ResourceItem{
+			Op:         ui.OpRefresh,
+			OldOutputs: map[string]any{"size": 1, "tags": map[string]any{"env": "dev"}},
+			Outputs:    map[string]any{"size": 1, "tags": map[string]any{"env": "dev"}},
+		}, nil},
+		{"changed, added and removed", ui.ResourceItem{
+			Op:         ui.OpRefresh,
+			OldOutputs: map[string]any{"size": 1, "tags": map[string]any{"env": "dev"}, "name": "a"},
+			Outputs:    map[string]any{"size": 2, "tags": map[string]any{"env": "prod"}, "arn": "x"},
+		}, []string{"arn", "name", "size", "tags"}},
+		{"internal properties", ui.ResourceItem{
+			Op:         ui.OpRefresh,
+			OldOutputs: map[string]any{"__meta": "a"},
+			Outputs:    map[string]any{"__meta": "b"},
+		}, nil},
+		{"deleted", ui.ResourceItem{Op: ui.OpDelete, OldOutputs: map[string]any{"size": 1}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DriftedKeys(tt.item); !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestDriftIgnoresProperties verifies properties ignored in p5.toml don't count as
+// drift, so a resource whose only drift is ignored isn't listed
+func TestDriftIgnoresProperties(t *testing.T) {
+	const (
+		bucketURN = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets"
+		queueURN  = "urn:pulumi:dev::app::aws:sqs/queue:Queue::jobs"
+	)
+	workDir := t.TempDir()
+	config := "[diff_ignore]\n\"aws:*\" = [\"tags.last-*\"]\n"
+	if err := os.WriteFile(filepath.Join(workDir, "p5.toml"), []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m := initialModel(context.Background(), AppContext{WorkDir: workDir, StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	result, _ = m.Update(m.loadDiffIgnore()())
+	m = result.(Model)
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	m = result.(Model)
+	step := func(urn string, old, live map[string]any) previewEventMsg {
+		return previewEventMsg(pulumi.PreviewEvent{Step: &pulumi.PreviewStep{
+			URN: urn, Op: pulumi.OpRefresh, Type: "aws:test:Resource", Name: pulumi.ExtractResourceName(urn),
+			Outputs: live, Old: &pulumi.StepState{Outputs: old},
+		}})
+	}
+	for _, msg := range []tea.Msg{
+		step(bucketURN,
+			map[string]any{"acl": "private", "tags": map[string]any{"env": "dev", "last-scan": "mon"}},
+			map[string]any{"acl": "public-read", "tags": map[string]any{"env": "dev", "last-scan": "tue"}}),
+		step(queueURN,
+			map[string]any{"tags": map[string]any{"last-seen": "mon"}},
+			map[string]any{"tags": map[string]any{"last-seen": "tue"}}),
+		previewEventMsg(pulumi.PreviewEvent{Done: true}),
+	} {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+
+	want := ui.DriftSummary{Resources: 1, Properties: 1}
+	if got := SummarizeDrift(m.ui.ResourceList.Items()); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	if view := m.ui.ResourceList.View(); !strings.Contains(view, "[drift: acl]") || strings.Contains(view, "jobs") {
+		t.Errorf("expected only the bucket's acl to drift, got:\n%s", view)
+	}
+}
+
+func TestDriftDetectionFlow(t *testing.T) {
+	const (
+		bucketURN = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets"
+		queueURN  = "urn:pulumi:dev::app::aws:sqs/queue:Queue::jobs"
+		topicURN  = "urn:pulumi:dev::app::aws:sns/topic:Topic::events"
+	)
+	step := func(urn string, op pulumi.ResourceOp, old, live map[string]any) pulumi.PreviewEvent {
+		return pulumi.PreviewEvent{Step: &pulumi.PreviewStep{
+			URN:     urn,
+			Op:      op,
+			Type:    "aws:test:Resource",
+			Name:    pulumi.ExtractResourceName(urn),
+			Outputs: live,
+			Old:     &pulumi.StepState{Outputs: old},
+		}}
+	}
+	press := func(t *testing.T, m Model, r rune) Model {
+		t.Helper()
+		result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		return result.(Model)
+	}
+	newModel := func(t *testing.T) (Model, *pulumi.FakeStackOperator) {
+		t.Helper()
+		deps := newTestDependencies()
+		operator := &pulumi.FakeStackOperator{}
+		deps.StackOperator = operator
+		m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+		result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		m = press(t, result.(Model), 'f')
+		if len(operator.Calls.Preview) != 1 || operator.Calls.Preview[0].OpType != pulumi.OperationRefresh {
+			t.Fatalf("expected a refresh preview, got %+v", operator.Calls.Preview)
+		}
+		if !m.state.DriftMode {
+			t.Fatal("expected drift mode")
+		}
+		for _, event := range []pulumi.PreviewEvent{
+			step(bucketURN, pulumi.OpRefresh, map[string]any{"acl": "private", "versioning": true}, map[string]any{"acl": "public-read", "versioning": true}),
+			step(queueURN, pulumi.OpRefresh, map[string]any{"delay": 0}, map[string]any{"delay": 0}),
+			step(topicURN, pulumi.OpDelete, map[string]any{"name": "events"}, nil),
+			{Done: true},
+		} {
+			result, _ = m.Update(previewEventMsg(event))
+			m = result.(Model)
+		}
+		return m, operator
+	}
+
+	t.Run("shows only drifted resources", func(t *testing.T) {
+		m, _ := newModel(t)
+
+		want := ui.DriftSummary{Resources: 2, Properties: 1, Deleted: 1}
+		if got := SummarizeDrift(m.ui.ResourceList.Items()); got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+		view := m.ui.ResourceList.View()
+		if !strings.Contains(view, "assets") || !strings.Contains(view, "events") || strings.Contains(view, "jobs") {
+			t.Errorf("expected only the drifted bucket and topic to be listed, got:\n%s", view)
+		}
+		if !strings.Contains(view, "[drift: acl]") {
+			t.Errorf("expected the drifted property to be named, got:\n%s", view)
+		}
+	})
+
+	t.Run("accepts drift with a targeted refresh", func(t *testing.T) {
+		m, operator := newModel(t)
+
+		m = press(t, m, 'a')
+		if !m.ui.Focus.Has(ui.FocusConfirmModal) || len(operator.Calls.Refresh) != 0 {
+			t.Fatal("expected accepting drift to ask for confirmation")
+		}
+		m = press(t, m, 'y')
+		if len(operator.Calls.Refresh) != 1 || !slices.Equal(operator.Calls.Refresh[0].Opts.Targets, []string{bucketURN, topicURN}) {
+			t.Fatalf("expected a refresh targeting the drifted resources, got %+v", operator.Calls.Refresh)
+		}
+		if m.state.DriftMode {
+			t.Error("expected drift mode to end")
+		}
+	})
+
+	t.Run("reverts drift with a targeted up", func(t *testing.T) {
+		m, operator := newModel(t)
+
+		// Only the selected drifted resource is reverted
+		m.ui.ResourceList.SelectURN(bucketURN)
+		m = press(t, m, ' ')
+		m = press(t, m, 'U')
+		m = press(t, m, 'y')
+		if len(operator.Calls.Up) != 1 {
+			t.Fatalf("expected up to run, got %d calls", len(operator.Calls.Up))
+		}
+		if opts := operator.Calls.Up[0].Opts; !slices.Equal(opts.Targets, []string{bucketURN}) || !opts.Refresh {
+			t.Errorf("expected a refreshing up targeting the bucket, got %+v", opts)
+		}
+	})
+}
+
+func TestToggleRawJSONDiff(t *testing.T) {
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	m.showDetailsPanel()
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}})
+	m = result.(Model)
+	if !m.ui.Details.RawJSON() || !m.ui.Focus.Has(ui.FocusDetailsPanel) {
+		t.Fatal("expected raw JSON diffs with the details panel still open")
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}})
+	m = result.(Model)
+	if m.ui.Details.RawJSON() {
+		t.Error("expected structured JSON diffs again")
+	}
+}
+
+// TestRevealSecretFlow verifies alt+s in the details panel asks which secret to
+// reveal when there are several, and shows the value decrypted from state
+func TestRevealSecretFlow(t *testing.T) {
+	const db = "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+	secret := map[string]any{"4dabf18193072939515e22adb298388d": "1b47061264138c4ac30d75fd1eb44270"}
+	deps := newTestDependencies()
+	reader := deps.StackReader.(*pulumi.FakeStackReader)
+	reader.Secrets = map[string]map[string]any{db: {"password": "hunter2"}}
+	m := initialModel(context.Background(), AppContext{WorkDir: t.TempDir(), StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{{
+		URN: db, Type: "aws:rds/instance:Instance", Name: "db", Op: ui.OpSame,
+		Outputs: map[string]any{"password": secret, "masterToken": secret},
+	}})
+	m.showDetailsPanel()
+	altS := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}, Alt: true}
+
+	result, _ = m.handleKeyPress(altS)
+	m = result.(Model)
+	if !m.ui.Focus.Has(ui.FocusSecretSelector) {
+		t.Fatal("expected the secret selector with two hidden secrets")
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.ui.Focus.Has(ui.FocusSecretSelector) {
+		t.Fatal("expected the selector to close")
+	}
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if calls := reader.Calls.RevealSecret; len(calls) != 1 || calls[0].URN != db || calls[0].Path != "password" {
+		t.Fatalf("expected the password to be decrypted, got %+v", calls)
+	}
+	if view := m.ui.Details.View(); !strings.Contains(view, "hunter2") {
+		t.Errorf("expected the revealed password in the details panel, got:\n%s", view)
+	}
+
+	// The last hidden secret is fetched without asking; it isn't in the fake state
+	result, cmd = m.handleKeyPress(altS)
+	m = result.(Model)
+	if m.ui.Focus.Has(ui.FocusSecretSelector) {
+		t.Fatal("expected no selector for a single hidden secret")
+	}
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if calls := reader.Calls.RevealSecret; len(calls) != 2 || calls[1].Path != "masterToken" {
+		t.Fatalf("expected the token to be decrypted, got %+v", calls)
+	}
+	if !strings.Contains(m.View(), "Failed to reveal secret") {
+		t.Error("expected a toast for the failed reveal")
+	}
+}
+
+// TestDetailsPanelSearch verifies keys typed into the details search don't trigger
+// commands, and that esc clears an applied search before closing the panel
+func TestDetailsPanelSearch(t *testing.T) {
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	m.showDetailsPanel()
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'/'}},
+		{Type: tea.KeyRunes, Runes: []rune{'q'}},
+	} {
+		result, _ = m.handleKeyPress(k)
+		m = result.(Model)
+	}
+	if m.quitting || !m.ui.Details.SearchActive() {
+		t.Fatal("expected q to be typed into the search")
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.ui.Details.SearchActive() || !strings.Contains(m.ui.Details.View(), "/q") {
+		t.Fatal("expected enter to apply the search")
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEscape})
+	m = result.(Model)
+	if !m.ui.Focus.Has(ui.FocusDetailsPanel) || strings.Contains(m.ui.Details.View(), "/q") {
+		t.Fatal("expected esc to clear the search and keep the panel open")
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEscape})
+	m = result.(Model)
+	if m.ui.Focus.Has(ui.FocusDetailsPanel) {
+		t.Error("expected esc to close the panel once the search is cleared")
+	}
+}
+
+// TestHandleKeyBindings verifies a workspace's keybindings are applied, and that
+// conflicting ones fall back to the defaults with a toast
+func TestHandleKeyBindings(t *testing.T) {
+	t.Cleanup(func() { _ = ui.ApplyKeyBindings(nil) })
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	result, _ = m.Update(keyBindingsMsg{WorkDir: "/fake/path", Bindings: map[string][]string{"preview_up": {"ctrl+p"}}})
+	m = result.(Model)
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyCtrlP}, ui.Keys.PreviewUp) {
+		t.Fatal("expected ctrl+p to preview up")
+	}
+
+	result, _ = m.Update(keyBindingsMsg{WorkDir: "/fake/path", Bindings: map[string][]string{"preview_up": {"U"}}})
+	m = result.(Model)
+	if !m.ui.Toast.Visible() {
+		t.Error("expected a toast for conflicting keybindings")
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}}, ui.Keys.PreviewUp) {
+		t.Error("expected the default keys after a conflict")
+	}
+}
+
+// TestMouseFlow verifies clicking selects the resource under the pointer, a double
+// click opens its details and the wheel moves the selection
+func TestMouseFlow(t *testing.T) {
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{
+		{URN: "urn:alpha", Type: "test:index:Thing", Name: "alpha"},
+		{URN: "urn:bravo", Type: "test:index:Thing", Name: "bravo"},
+		{URN: "urn:charlie", Type: "test:index:Thing", Name: "charlie"},
+	})
+
+	y := -1
+	for i, line := range strings.Split(m.View(), "\n") {
+		if strings.Contains(line, "bravo") {
+			y = i
+			break
+		}
+	}
+	if y < 0 {
+		t.Fatal("expected bravo to be rendered")
+	}
+	click := tea.MouseMsg{X: 10, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+
+	result, _ = m.Update(click)
+	m = result.(Model)
+	if item := m.ui.ResourceList.SelectedItem(); item == nil || item.Name != "bravo" {
+		t.Fatalf("expected the click to select bravo, got %v", item)
+	}
+	if m.ui.Focus.Has(ui.FocusDetailsPanel) {
+		t.Fatal("expected a single click to leave the details panel closed")
+	}
+
+	result, _ = m.Update(click)
+	m = result.(Model)
+	if !m.ui.Focus.Has(ui.FocusDetailsPanel) {
+		t.Fatal("expected a double click to open the details panel")
+	}
+
+	result, _ = m.Update(tea.MouseMsg{X: 10, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+	m = result.(Model)
+	if item := m.ui.ResourceList.SelectedItem(); item == nil || item.Name != "charlie" {
+		t.Fatalf("expected the wheel to select charlie, got %v", item)
+	}
+	if !strings.Contains(m.ui.Details.View(), "charlie") {
+		t.Error("expected the details panel to follow the selection")
+	}
+}
+
+// TestResizeDetailsPanel verifies the details panel is resized with < and > and by
+// dragging its border, within the allowed range
+func TestResizeDetailsPanel(t *testing.T) {
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev", DetailsWidth: 60}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = result.(Model)
+	m.showDetailsPanel()
+	if m.detailsPanelX() != 40 {
+		t.Fatalf("expected the saved width to place the panel at column 40, got %d", m.detailsPanelX())
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'<'}})
+	m = result.(Model)
+	if m.ui.DetailsWidth != 65 {
+		t.Fatalf("expected < to widen the panel to 65%%, got %d%%", m.ui.DetailsWidth)
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	m = result.(Model)
+	if m.ui.DetailsWidth != 60 {
+		t.Fatalf("expected > to narrow the panel to 60%%, got %d%%", m.ui.DetailsWidth)
+	}
+	// The width is saved once, after the last key press
+	if _, cmd := m.Update(detailsResizeEndedMsg{Seq: m.state.DetailsResizeSeq - 1}); cmd != nil {
+		t.Error("expected no save for a key press followed by another")
+	}
+	if _, cmd := m.Update(detailsResizeEndedMsg{Seq: m.state.DetailsResizeSeq}); cmd == nil {
+		t.Error("expected the width to be saved after the last key press")
+	}
+
+	result, cmd := m.Update(tea.MouseMsg{X: 40, Y: 5, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	m = result.(Model)
+	result, cmd = m.Update(tea.MouseMsg{X: 70, Y: 5, Action: tea.MouseActionMotion, Button: tea.MouseButtonLeft})
+	m = result.(Model)
+	if m.ui.DetailsWidth != 30 || cmd != nil {
+		t.Fatalf("expected dragging to column 70 to resize the panel to 30%% without saving, got %d%%", m.ui.DetailsWidth)
+	}
+	result, _ = m.Update(tea.MouseMsg{X: 95, Y: 5, Action: tea.MouseActionMotion, Button: tea.MouseButtonLeft})
+	m = result.(Model)
+	if m.ui.DetailsWidth != plugins.MinDetailsWidth {
+		t.Fatalf("expected the width to stop at %d%%, got %d%%", plugins.MinDetailsWidth, m.ui.DetailsWidth)
+	}
+	result, cmd = m.Update(tea.MouseMsg{X: 95, Y: 5, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft})
+	m = result.(Model)
+	if m.state.ResizingDetails || cmd == nil {
+		t.Error("expected releasing the border to end the drag and save the width")
+	}
+	if !m.ui.Focus.Has(ui.FocusDetailsPanel) {
+		t.Error("expected the panel to stay open")
+	}
+}
+
+// runCmds runs cmd and the commands it batches, returning the messages they produce.
+// Commands that don't finish quickly, like toast timers, are skipped.
+func runCmds(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	ch := make(chan tea.Msg, 1)
+	go func() { ch <- cmd() }()
+	select {
+	case msg := <-ch:
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			var msgs []tea.Msg
+			for _, c := range batch {
+				msgs = append(msgs, runCmds(c)...)
+			}
+			return msgs
+		}
+		return []tea.Msg{msg}
+	case <-time.After(100 * time.Millisecond):
+		return nil
+	}
+}
+
+// TestCancelOperationFlow verifies cancelling an update interrupts it, asks the
+// backend to cancel it, and offers to clear the pending operations it left behind
+func TestCancelOperationFlow(t *testing.T) {
+	deps := newTestDependencies()
+	operator := deps.StackOperator.(*pulumi.FakeStackOperator)
+	operator.UpFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+	deps.StackReader.(*pulumi.FakeStackReader).PendingOps = []pulumi.PendingOperation{
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Type: "aws:s3/bucket:Bucket", Kind: "creating"},
+	}
+	stateManager := deps.StackStateManager.(*pulumi.FakeStackStateManager)
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.startExecution(pulumi.OperationUp)
+	result, _ = m.Update(operationEventMsg{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Type: "aws:s3/bucket:Bucket", Name: "logs", Op: pulumi.OpCreate, Status: pulumi.StepRunning})
+	m = result.(Model)
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEscape})
+	m = result.(Model)
+	if m.state.OpState != OpCancelling {
+		t.Fatalf("expected the operation to be cancelling, got %v", m.state.OpState)
+	}
+	if m.operationCtx.Err() == nil {
+		t.Error("expected the operation context to be cancelled")
+	}
+	if !strings.Contains(m.ui.Header.View(), "cancelling") {
+		t.Error("expected the header to show the operation cancelling")
+	}
+	runCmds(cmd)
+	if len(operator.Calls.Cancel) != 1 {
+		t.Fatalf("expected the backend to be asked to cancel the update once, got %d", len(operator.Calls.Cancel))
+	}
+
+	result, cmd = m.Update(operationEventMsg{Error: context.Canceled, Done: true})
+	m = result.(Model)
+	for _, msg := range runCmds(cmd) {
+		if _, ok := msg.(pendingOperationsMsg); ok {
+			result, _ = m.Update(msg)
+			m = result.(Model)
+		}
+	}
+	if !m.ui.ConfirmModal.Visible() || !strings.Contains(m.ui.ConfirmModal.View(), "creating") {
+		t.Fatal("expected a confirmation listing the pending operations")
+	}
+
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = result.(Model)
+	runCmds(cmd)
+	if len(stateManager.Calls.ClearPendingOperations) != 1 {
+		t.Errorf("expected the pending operations to be cleared, got %d calls", len(stateManager.Calls.ClearPendingOperations))
+	}
+	// The update holding the lock by now may not be ours
+	if len(stateManager.Calls.CancelPending) != 0 {
+		t.Error("expected no update to be cancelled when clearing pending operations")
+	}
+	if m.state.PendingClearOperations || m.ui.ConfirmModal.Visible() {
+		t.Error("expected the confirmation to be closed")
+	}
+}
+
+// TestStackLockedFlow verifies an update refused by a locked stack offers to
+// recover it, and reports what the recovery did
+func TestStackLockedFlow(t *testing.T) {
+	deps := newTestDependencies()
+	deps.StackOperator.(*pulumi.FakeStackOperator).UpFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+	stateManager := deps.StackStateManager.(*pulumi.FakeStackStateManager)
+	stateManager.CancelPendingResult = &pulumi.CancelPendingResult{Cancelled: true, Cleared: 2}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.startExecution(pulumi.OperationUp)
+
+	lockErr := errors.New("code: 255\nstderr: error: [409] Conflict: Another update is currently in progress.")
+	result, _ = m.Update(operationEventMsg{Error: lockErr, Done: true})
+	m = result.(Model)
+	if !m.state.PendingStackRecovery || !m.ui.ConfirmModal.Visible() {
+		t.Fatal("expected a confirmation offering to recover the locked stack")
+	}
+	if !strings.Contains(m.ui.ConfirmModal.View(), "Stack Locked") {
+		t.Error("expected the confirmation to explain the stack is locked")
+	}
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = result.(Model)
+	msgs := runCmds(cmd)
+	if len(stateManager.Calls.CancelPending) != 1 || stateManager.Calls.CancelPending[0].StackName != "dev" {
+		t.Fatalf("expected the stack to be recovered once, got %+v", stateManager.Calls.CancelPending)
+	}
+	for _, msg := range msgs {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if m.ui.ConfirmModal.Visible() || !strings.Contains(m.ui.Toast.View(120), "cleared 2 pending operations") {
+		t.Errorf("expected a toast reporting the recovery, got %q", m.ui.Toast.View(120))
+	}
+
+	// Other failures are shown without offering recovery
+	m.startExecution(pulumi.OperationUp)
+	result, _ = m.Update(operationEventMsg{Error: errors.New("provider error"), Done: true})
+	m = result.(Model)
+	if m.state.PendingStackRecovery || m.ui.ConfirmModal.Visible() {
+		t.Error("expected no recovery to be offered for other errors")
+	}
+}
+
+// TestPostOperationHooks verifies finished operations are summarized for plugins
+// with post-operation hooks, and hook failures are reported
+func TestPostOperationHooks(t *testing.T) {
+	deps := newTestDependencies()
+	deps.StackOperator.(*pulumi.FakeStackOperator).UpFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+	provider := deps.PluginProvider.(*plugins.FakePluginProvider)
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	result, _ = m.Update(projectInfoMsg(&pulumi.ProjectInfo{ProgramName: "app", StackName: "dev"}))
+	m = result.(Model)
+
+	// Without hooks enabled, nothing is sent
+	m.startExecution(pulumi.OperationUp)
+	result, cmd := m.Update(operationEventMsg{Done: true})
+	m = result.(Model)
+	runCmds(cmd)
+	if len(provider.Calls.RunPostOperationHooks) != 0 {
+		t.Fatalf("expected no hooks to run, got %d calls", len(provider.Calls.RunPostOperationHooks))
+	}
+
+	provider.HasPostOperationHook = true
+	provider.PostOperationResults = []plugins.PostOperationResult{{PluginName: "notify", Error: errors.New("endpoint returned 500")}}
+	m.startExecution(pulumi.OperationUp)
+	result, _ = m.Update(operationEventMsg{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Type: "aws:s3/bucket:Bucket", Name: "logs", Op: pulumi.OpCreate, Status: pulumi.StepSuccess})
+	m = result.(Model)
+	result, cmd = m.Update(operationEventMsg{Error: errors.New("provider error"), Done: true})
+	m = result.(Model)
+	for _, msg := range runCmds(cmd) {
+		if _, ok := msg.(postOperationHooksMsg); ok {
+			result, _ = m.Update(msg)
+			m = result.(Model)
+		}
+	}
+
+	if len(provider.Calls.RunPostOperationHooks) != 1 {
+		t.Fatalf("expected the hooks to run once, got %d calls", len(provider.Calls.RunPostOperationHooks))
+	}
+	summary := provider.Calls.RunPostOperationHooks[0]
+	if summary.ProgramName != "app" || summary.StackName != "dev" || summary.Operation != "up" {
+		t.Errorf("unexpected summary target %+v", summary)
+	}
+	if summary.Result != plugins.OperationFailed || summary.Error != "provider error" {
+		t.Errorf("expected a failed result with its error, got %q %q", summary.Result, summary.Error)
+	}
+	if summary.Changes["create"] != 1 {
+		t.Errorf("expected 1 create, got %v", summary.Changes)
+	}
+	if !strings.Contains(m.ui.Toast.View(120), "Post-operation hook notify failed") {
+		t.Errorf("expected a toast reporting the failed hook, got %q", m.ui.Toast.View(120))
+	}
+}
+
+// TestSavePlanFlow verifies saving a plan runs an up preview writing it, and
+// executing up from that preview applies it
+func TestSavePlanFlow(t *testing.T) {
+	deps := newTestDependencies()
+	operator := deps.StackOperator.(*pulumi.FakeStackOperator)
+	operator.UpFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = result.(Model)
+	if len(operator.Calls.Preview) != 1 || operator.Calls.Preview[0].OpType != pulumi.OperationUp {
+		t.Fatalf("expected an up preview, got %+v", operator.Calls.Preview)
+	}
+	planPath := operator.Calls.Preview[0].Opts.Plan
+	if !strings.HasPrefix(planPath, filepath.Join("/fake/path", PlansDir)) || !strings.HasSuffix(planPath, "-dev.json") {
+		t.Fatalf("expected the plan to be written to the plans directory, got %q", planPath)
+	}
+	if strings.Contains(m.renderFooter(), "PLAN SAVED") {
+		t.Error("expected no plan indicator before the preview finished")
+	}
+
+	result, _ = m.Update(previewEventMsg{Done: true})
+	m = result.(Model)
+	if !strings.Contains(m.renderFooter(), "PLAN SAVED") {
+		t.Error("expected the footer to show the plan was saved")
+	}
+	if !strings.Contains(m.ui.Toast.View(120), filepath.Join(PlansDir, filepath.Base(planPath))) {
+		t.Errorf("expected a toast with the plan path, got %q", m.ui.Toast.View(120))
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlU})
+	m = result.(Model)
+	if len(operator.Calls.Up) != 1 || operator.Calls.Up[0].Opts.Plan != planPath {
+		t.Fatalf("expected up to apply the saved plan, got %+v", operator.Calls.Up)
+	}
+
+	// Changing flags after the preview drops the plan
+	result, _ = m.Update(operationEventMsg{Done: true})
+	m = result.(Model)
+	m.startPlanPreview()
+	m.ui.ResourceList.AddItem(ui.ResourceItem{URN: "urn:pulumi:dev::app::aws:s3:Bucket::b", Type: "aws:s3:Bucket", Name: "b", Op: ui.OpCreate})
+	result, _ = m.Update(previewEventMsg{Done: true})
+	m = result.(Model)
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	m = result.(Model)
+	if m.state.PlanSaved || strings.Contains(m.renderFooter(), "PLAN SAVED") {
+		t.Error("expected toggling a target to drop the plan")
+	}
+	m.startExecution(pulumi.OperationUp)
+	if operator.Calls.Up[1].Opts.Plan != "" || len(operator.Calls.Up[1].Opts.Targets) != 1 {
+		t.Errorf("expected a targeted up without the plan, got %+v", operator.Calls.Up[1].Opts)
+	}
+
+	// A regular preview drops the plan
+	result, _ = m.Update(operationEventMsg{Done: true})
+	m = result.(Model)
+	m.startPreview(pulumi.OperationUp)
+	result, _ = m.Update(previewEventMsg{Done: true})
+	m = result.(Model)
+	if operator.Calls.Preview[2].Opts.Plan != "" || strings.Contains(m.renderFooter(), "PLAN SAVED") {
+		t.Error("expected a regular preview not to save a plan")
+	}
+	m.startExecution(pulumi.OperationUp)
+	if operator.Calls.Up[2].Opts.Plan != "" {
+		t.Errorf("expected up without a plan, got %q", operator.Calls.Up[2].Opts.Plan)
+	}
+}
+
+// TestToggleRefreshOnUp verifies the refresh toggle starts from p5.toml, is passed
+// to up and its preview, and drops a plan saved without it
+func TestToggleRefreshOnUp(t *testing.T) {
+	deps := newTestDependencies()
+	operator := deps.StackOperator.(*pulumi.FakeStackOperator)
+	operator.UpFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev", RefreshOnUp: true}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = result.(Model)
+	result, _ = m.Update(previewEventMsg{Done: true})
+	m = result.(Model)
+	if !operator.Calls.Preview[0].Opts.Refresh {
+		t.Error("expected the up preview to refresh")
+	}
+	if !strings.Contains(m.ui.Header.View(), "[refresh]") {
+		t.Error("expected the header to show the refresh flag")
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r"), Alt: true})
+	m = result.(Model)
+	if m.state.RefreshOnUp || strings.Contains(m.ui.Header.View(), "[refresh]") {
+		t.Error("expected alt+r to turn refresh off")
+	}
+
+	m.startExecution(pulumi.OperationUp)
+	if opts := operator.Calls.Up[0].Opts; opts.Refresh || opts.Plan != "" {
+		t.Errorf("expected up without refresh or the plan previewed with it, got %+v", opts)
+	}
+}
+
+// TestContinueOnError verifies the toggle is passed to up, and failed resources stay
+// listed when the operation ends with an error
+func TestContinueOnError(t *testing.T) {
+	deps := newTestDependencies()
+	operator := deps.StackOperator.(*pulumi.FakeStackOperator)
+	operator.UpFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e"), Alt: true})
+	m = result.(Model)
+	if !m.state.ContinueOnError {
+		t.Fatal("expected alt+e to turn on continue on error")
+	}
+	m.startExecution(pulumi.OperationUp)
+	if !operator.Calls.Up[0].Opts.ContinueOnError {
+		t.Errorf("expected up to continue on error, got %+v", operator.Calls.Up[0].Opts)
+	}
+
+	failed := "urn:pulumi:dev::app::aws:s3:Bucket::failed"
+	created := "urn:pulumi:dev::app::aws:s3:Bucket::created"
+	for _, event := range []pulumi.OperationEvent{
+		{URN: failed, Type: "aws:s3:Bucket", Name: "failed", Op: pulumi.OpCreate, Status: pulumi.StepFailed},
+		{URN: created, Type: "aws:s3:Bucket", Name: "created", Op: pulumi.OpCreate, Status: pulumi.StepSuccess},
+	} {
+		result, _ = m.Update(operationEventMsg(event))
+		m = result.(Model)
+	}
+	if !strings.Contains(m.ui.Header.View(), "[continue on error]") {
+		t.Error("expected the header to show the continue on error flag")
+	}
+	result, _ = m.Update(operationEventMsg{Error: errors.New("up failed: 1 resource failed"), Done: true})
+	m = result.(Model)
+
+	if m.ui.ResourceList.Error() != nil {
+		t.Errorf("expected the list to be kept, got error %v", m.ui.ResourceList.Error())
+	}
+	if m.failedResourceCount() != 1 || len(m.ui.ResourceList.Items()) != 2 {
+		t.Errorf("expected the failed and created resources listed, got %+v", m.ui.ResourceList.Items())
+	}
+	if !strings.Contains(m.ui.Toast.View(120), "1 resources failed") {
+		t.Errorf("expected a toast with the failed count, got %q", m.ui.Toast.View(120))
+	}
+}
+
+// TestUpdateMessagePrompt verifies up asks for a message when configured and passes it
+// on, and that cancelling the prompt doesn't run up
+func TestUpdateMessagePrompt(t *testing.T) {
+	deps := newTestDependencies()
+	operator := deps.StackOperator.(*pulumi.FakeStackOperator)
+	operator.UpFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev", UpdateMessage: true}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	m.startExecution(pulumi.OperationUp)
+	if m.ui.Focus.Current() != ui.FocusUpdateMessageModal || len(operator.Calls.Up) != 0 {
+		t.Fatal("expected up to wait for its message")
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.ui.Focus.Current() == ui.FocusUpdateMessageModal || m.state.PendingExecution != nil || len(operator.Calls.Up) != 0 {
+		t.Fatal("expected escape to cancel up")
+	}
+
+	m.startExecution(pulumi.OperationUp)
+	for _, r := range "bump replicas" {
+		result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if len(operator.Calls.Up) != 1 || operator.Calls.Up[0].Opts.Message != "bump replicas" {
+		t.Fatalf("expected up with the message, got %+v", operator.Calls.Up)
+	}
+}
+
+// TestGitInfo verifies the checkout is read when the stack loads, shown in the
+// header, and recorded with the update message
+func TestGitInfo(t *testing.T) {
+	deps := newTestDependencies()
+	gitReader := deps.GitReader.(*pulumi.FakeGitReader)
+	gitReader.Info = &pulumi.GitInfo{Branch: "main", Commit: "1a2b3c4", Dirty: true}
+	operator := deps.StackOperator.(*pulumi.FakeStackOperator)
+	operator.UpFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = result.(Model)
+	result, _ = m.Update(projectInfoMsg(&pulumi.ProjectInfo{ProgramName: "app", StackName: "dev"}))
+	m = result.(Model)
+
+	_, cmd := m.Update(stackResourcesMsg{})
+	for _, msg := range runCmds(cmd) {
+		if msg, ok := msg.(gitInfoMsg); ok {
+			result, _ = m.Update(msg)
+			m = result.(Model)
+		}
+	}
+	if len(gitReader.Calls.ReadGitInfo) != 1 || gitReader.Calls.ReadGitInfo[0] != "/fake/path" {
+		t.Fatalf("expected the workspace checkout to be read, got %v", gitReader.Calls.ReadGitInfo)
+	}
+	if header := m.ui.Header.View(); !strings.Contains(header, "main@1a2b3c4") || !strings.Contains(header, "dirty") {
+		t.Errorf("expected the branch, commit and dirty state in the header, got:\n%s", header)
+	}
+
+	m.startExecutionWithOptions(pulumi.OperationUp, pulumi.OperationOptions{Message: "bump replicas"})
+	if msg := operator.Calls.Up[0].Opts.Message; msg != "bump replicas (main@1a2b3c4-dirty)" {
+		t.Errorf("expected the commit in the update message, got %q", msg)
+	}
+}
+
+// TestGitGuardProblems verifies guarded stacks warn about a dirty tree and a branch
+// other than the expected one
+func TestGitGuardProblems(t *testing.T) {
+	guard := &plugins.GitGuardConfig{Stacks: []string{"*prod"}}
+	clean := &pulumi.GitInfo{Branch: "main", Commit: "1a2b3c4"}
+	tests := []struct {
+		name     string
+		guard    *plugins.GitGuardConfig
+		git      *pulumi.GitInfo
+		stack    string
+		problems int
+	}{
+		{"no guard", nil, &pulumi.GitInfo{Branch: "feature", Dirty: true}, "prod", 0},
+		{"no checkout", guard, nil, "prod", 0},
+		{"unguarded stack", guard, &pulumi.GitInfo{Branch: "feature", Dirty: true}, "dev", 0},
+		{"clean main", guard, clean, "org/app/prod", 0},
+		{"dirty", guard, &pulumi.GitInfo{Branch: "main", Dirty: true}, "prod", 1},
+		{"dirty allowed", &plugins.GitGuardConfig{AllowDirty: true}, &pulumi.GitInfo{Branch: "main", Dirty: true}, "prod", 0},
+		{"other branch", guard, &pulumi.GitInfo{Branch: "feature"}, "prod", 1},
+		{"detached and dirty", guard, &pulumi.GitInfo{Dirty: true}, "prod", 2},
+		{"expected branch", &plugins.GitGuardConfig{Branch: "release"}, &pulumi.GitInfo{Branch: "release"}, "prod", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GitGuardProblems(tt.guard, tt.git, tt.stack); len(got) != tt.problems {
+				t.Errorf("expected %d problems, got %v", tt.problems, got)
+			}
+		})
+	}
+}
+
+// TestGitGuard verifies up on a guarded stack from a dirty checkout waits for the
+// stack name to be typed
+func TestGitGuard(t *testing.T) {
+	deps := newTestDependencies()
+	operator := deps.StackOperator.(*pulumi.FakeStackOperator)
+	operator.UpFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "prod"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	result, _ = m.Update(gitInfoMsg{
+		WorkDir: "/fake/path",
+		Info:    &pulumi.GitInfo{Branch: "main", Commit: "1a2b3c4", Dirty: true},
+		Guard:   &plugins.GitGuardConfig{Stacks: []string{"prod"}},
+	})
+	m = result.(Model)
+
+	m.startExecution(pulumi.OperationUp)
+	if m.ui.Focus.Current() != ui.FocusGitGuardModal || len(operator.Calls.Up) != 0 {
+		t.Fatal("expected up to wait for the guard")
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.ui.Focus.Current() == ui.FocusGitGuardModal || m.state.PendingExecution != nil || len(operator.Calls.Up) != 0 {
+		t.Fatal("expected escape to cancel up")
+	}
+
+	m.startExecution(pulumi.OperationUp)
+	for _, r := range "prod" {
+		result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if len(operator.Calls.Up) != 1 {
+		t.Fatalf("expected up once the stack name was typed, got %d calls", len(operator.Calls.Up))
+	}
+}
+
+// TestProtectedStack verifies up and destroy on a highly protected stack wait for
+// the stack name to be typed, even from their preview
+func TestProtectedStack(t *testing.T) {
+	deps := newTestDependencies()
+	operator := deps.StackOperator.(*pulumi.FakeStackOperator)
+	operator.UpFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "prod"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	result, _ = m.Update(stackProtectionMsg{WorkDir: "/fake/path", StackName: "prod", Level: plugins.ProtectionHigh})
+	m = result.(Model)
+
+	m.ui.ViewMode = ui.ViewPreview
+	m.state.Operation = pulumi.OperationUp
+	m.maybeConfirmExecution(pulumi.OperationUp)
+	if m.ui.Focus.Current() != ui.FocusConfirmModal || !m.ui.ConfirmModal.RequiresPhrase() || len(operator.Calls.Up) != 0 {
+		t.Fatal("expected up to wait for the stack name")
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = result.(Model)
+	if len(operator.Calls.Up) != 0 {
+		t.Fatal("expected y not to confirm")
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.ui.Focus.Current() == ui.FocusConfirmModal || m.state.PendingExecution != nil {
+		t.Fatal("expected escape to cancel up")
+	}
+
+	m.maybeConfirmExecution(pulumi.OperationUp)
+	for _, r := range "prod" {
+		result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if len(operator.Calls.Up) != 1 {
+		t.Fatalf("expected up once the stack name was typed, got %d calls", len(operator.Calls.Up))
+	}
+}
+
+// TestConfirmModes verifies the stack's confirm modes decide whether operations
+// ask for confirmation, from their preview or not.
+func TestConfirmModes(t *testing.T) {
+	deps := newTestDependencies()
+	operator := deps.StackOperator.(*pulumi.FakeStackOperator)
+	operator.UpFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+	operator.RefreshFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "prod"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	confirm := plugins.ConfirmConfig{Up: plugins.ConfirmAlways, Refresh: plugins.ConfirmNever}
+	result, _ = m.Update(stackProtectionMsg{WorkDir: "/fake/path", StackName: "prod", Level: plugins.ProtectionNormal, Confirm: confirm})
+	m = result.(Model)
+
+	m.maybeConfirmExecution(pulumi.OperationRefresh)
+	if m.ui.Focus.Current() == ui.FocusConfirmModal || len(operator.Calls.Refresh) != 1 {
+		t.Fatal("expected refresh to run without confirmation")
+	}
+
+	m = initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "prod"}, deps)
+	result, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	result, _ = m.Update(stackProtectionMsg{WorkDir: "/fake/path", StackName: "prod", Level: plugins.ProtectionNormal, Confirm: confirm})
+	m = result.(Model)
+	m.ui.ViewMode = ui.ViewPreview
+	m.state.Operation = pulumi.OperationUp
+	m.maybeConfirmExecution(pulumi.OperationUp)
+	if m.ui.Focus.Current() != ui.FocusConfirmModal || len(operator.Calls.Up) != 0 {
+		t.Fatal("expected up to ask for confirmation even from its preview")
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = result.(Model)
+	if len(operator.Calls.Up) != 1 {
+		t.Fatalf("expected up once confirmed, got %d calls", len(operator.Calls.Up))
+	}
+}
+
+// TestAutoApproveProblems verifies which changes break the auto-approval rules
+func TestAutoApproveProblems(t *testing.T) {
+	item := func(name string, op ui.ResourceOp) ui.ResourceItem {
+		return ui.ResourceItem{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::" + name, Name: name, Op: op}
+	}
+	updates := []ui.ResourceItem{item("a", ui.OpUpdate), item("b", ui.OpUpdate), item("c", ui.OpSame)}
+	replace := []ui.ResourceItem{item("a", ui.OpCreateReplace), item("a", ui.OpDeleteReplace)}
+	tests := []struct {
+		name     string
+		rule     plugins.AutoApproveConfig
+		items    []ui.ResourceItem
+		problems int
+	}{
+		{"only updates", plugins.AutoApproveConfig{}, updates, 0},
+		{"too many changes", plugins.AutoApproveConfig{MaxChanges: 1}, updates, 1},
+		{"at the limit", plugins.AutoApproveConfig{MaxChanges: 2}, updates, 0},
+		{"create", plugins.AutoApproveConfig{}, []ui.ResourceItem{item("a", ui.OpCreate)}, 1},
+		{"creates allowed", plugins.AutoApproveConfig{Creates: true}, []ui.ResourceItem{item("a", ui.OpCreate)}, 0},
+		{"delete", plugins.AutoApproveConfig{Creates: true}, []ui.ResourceItem{item("a", ui.OpDelete)}, 1},
+		{"replacement counted once", plugins.AutoApproveConfig{MaxChanges: 1}, replace, 1},
+		{"replaces allowed", plugins.AutoApproveConfig{MaxChanges: 1, Replaces: true}, replace, 0},
+		{"delete and replace", plugins.AutoApproveConfig{MaxChanges: 1}, append([]ui.ResourceItem{item("b", ui.OpDelete)}, replace...), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AutoApproveProblems(&tt.rule, tt.items); len(got) != tt.problems {
+				t.Errorf("expected %d problems, got %v", tt.problems, got)
+			}
+		})
+	}
+}
+
+// TestAutoApprove verifies up from a finished preview runs right away when its
+// changes are small, and otherwise explains why it asks. Flags changed after the
+// preview and the "always" confirm mode still ask.
+func TestAutoApprove(t *testing.T) {
+	deps := newTestDependencies()
+	operator := deps.StackOperator.(*pulumi.FakeStackOperator)
+	operator.UpFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+	previewUp := func(confirm plugins.ConfirmConfig, items ...ui.ResourceItem) Model {
+		m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+		result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		m = result.(Model)
+		result, _ = m.Update(stackProtectionMsg{
+			WorkDir:     "/fake/path",
+			StackName:   "dev",
+			Level:       plugins.ProtectionNormal,
+			Confirm:     confirm,
+			AutoApprove: &plugins.AutoApproveConfig{Stacks: []string{"dev"}},
+		})
+		m = result.(Model)
+		m.ui.ViewMode = ui.ViewPreview
+		m.state.Operation = pulumi.OperationUp
+		m.state.OpState = OpComplete
+		m.ui.ResourceList.SetItems(items)
+		return m
+	}
+
+	const logs = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	update := ui.ResourceItem{URN: logs, Name: "logs", Op: ui.OpUpdate}
+	m := previewUp(plugins.ConfirmConfig{}, update)
+	m.maybeConfirmExecution(pulumi.OperationUp)
+	if m.ui.Focus.Current() == ui.FocusConfirmModal || len(operator.Calls.Up) != 1 {
+		t.Fatal("expected an update-only up to be auto-approved")
+	}
+
+	m = previewUp(plugins.ConfirmConfig{Up: plugins.ConfirmAlways}, update)
+	m.maybeConfirmExecution(pulumi.OperationUp)
+	if m.ui.Focus.Current() != ui.FocusConfirmModal || len(operator.Calls.Up) != 1 {
+		t.Fatal("expected up to always ask when its confirm mode is always")
+	}
+
+	// Replacing the resource after the preview isn't what was approved
+	m = previewUp(plugins.ConfirmConfig{}, update)
+	m.ui.ResourceList.ReplaceFlags(map[string]ui.ResourceFlags{logs: {Replace: true}})
+	m.maybeConfirmExecution(pulumi.OperationUp)
+	if m.ui.Focus.Current() != ui.FocusConfirmModal || len(operator.Calls.Up) != 1 {
+		t.Fatal("expected up to ask once a replace was flagged after the preview")
+	}
+	if view := m.ui.ConfirmModal.View(); !strings.Contains(view, "Flags changed since the preview") {
+		t.Errorf("expected the modal to explain the flags changed, got:\n%s", view)
+	}
+
+	m = previewUp(plugins.ConfirmConfig{}, ui.ResourceItem{URN: logs, Name: "logs", Op: ui.OpDelete})
+	m.maybeConfirmExecution(pulumi.OperationUp)
+	if m.ui.Focus.Current() != ui.FocusConfirmModal || len(operator.Calls.Up) != 1 {
+		t.Fatal("expected an up deleting resources to ask for confirmation")
+	}
+	if view := m.ui.ConfirmModal.View(); !strings.Contains(view, "Not auto-approved") || !strings.Contains(view, "1 to delete") {
+		t.Errorf("expected the modal to explain the broken rule, got:\n%s", view)
+	}
+}
+
+// TestRemovePlans verifies stale plans of a stack are deleted while other stacks'
+// plans and the plan to keep remain
+func TestRemovePlans(t *testing.T) {
+	dir := t.TempDir()
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	old := PlanFile(dir, "dev", at)
+	keep := PlanFile(dir, "dev", at.Add(time.Minute))
+	other := PlanFile(dir, "my-dev", at)
+	if err := os.MkdirAll(filepath.Dir(old), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{old, keep, other} {
+		if err := os.WriteFile(p, []byte("{}"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := RemovePlans(dir, "dev", keep); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for p, exists := range map[string]bool{old: false, keep: true, other: true} {
+		if _, err := os.Stat(p); (err == nil) != exists {
+			t.Errorf("expected %s to exist: %v, got err %v", filepath.Base(p), exists, err)
+		}
+	}
+	if err := RemovePlans(filepath.Join(dir, "missing"), "dev", ""); err != nil {
+		t.Errorf("expected no error without a plans directory, got %v", err)
+	}
+}
+
+// TestStackTagsFlow verifies the stack tags modal opens from the header, lists the
+// stack's tags and saves edits through the stack reader
+func TestStackTagsFlow(t *testing.T) {
+	deps := newTestDependencies()
+	reader := deps.StackReader.(*pulumi.FakeStackReader)
+	reader.Tags = map[string]string{"owner": "platform", "pulumi:project": "app"}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	result, cmd := m.Update(tea.MouseMsg{X: 10, Y: 1, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	m = result.(Model)
+	if m.ui.Focus.Current() != ui.FocusTagsModal {
+		t.Fatalf("expected clicking the header to open the tags modal, got focus %v", m.ui.Focus.Current())
+	}
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if !strings.Contains(m.View(), "platform") {
+		t.Fatal("expected the stack's tags to be listed")
+	}
+
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'a'}},
+		{Type: tea.KeyRunes, Runes: []rune("cost-center=1234")},
+		{Type: tea.KeyEnter},
+	} {
+		result, cmd = m.Update(key)
+		m = result.(Model)
+	}
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if len(reader.Calls.SetTag) != 1 || reader.Tags["cost-center"] != "1234" {
+		t.Fatalf("expected cost-center to be tagged, got calls %+v", reader.Calls.SetTag)
+	}
+	if !strings.Contains(m.View(), "1234") {
+		t.Error("expected the new tag to be listed")
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	m = result.(Model)
+	if m.ui.TagsModal.Visible() || m.ui.Focus.Current() != ui.FocusMain {
+		t.Error("expected escape to close the tags modal")
+	}
+
+	result, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m = result.(Model)
+	if !m.ui.TagsModal.Visible() || cmd == nil {
+		t.Error("expected the tags key to open the tags modal")
+	}
+}
+
+// TestCredentialRefresh verifies expiring plugin credentials are refreshed on
+// schedule, stale refresh ticks are ignored and failures are reported
+func TestCredentialRefresh(t *testing.T) {
+	deps := newTestDependencies()
+	provider := deps.PluginProvider.(*plugins.FakePluginProvider)
+	provider.NextRefresh = time.Now().Add(time.Hour)
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	if cmd := m.scheduleCredentialRefresh(); cmd == nil {
+		t.Fatal("expected a refresh to be scheduled for expiring credentials")
+	}
+	seq := m.state.CredentialRefreshSeq
+
+	result, cmd := m.Update(credentialRefreshMsg{Seq: seq - 1})
+	m = result.(Model)
+	if cmd != nil {
+		t.Error("expected a stale refresh tick to be ignored")
+	}
+
+	provider.RefreshResults = []plugins.AuthenticateResult{{PluginName: "aws", Error: errors.New("token expired")}}
+	result, cmd = m.Update(credentialRefreshMsg{Seq: seq})
+	m = result.(Model)
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if provider.Calls.RefreshCredentials != 1 {
+		t.Fatalf("expected credentials to be refreshed once, got %d", provider.Calls.RefreshCredentials)
+	}
+	if provider.Calls.ApplyEnvToProcess == 0 {
+		t.Error("expected refreshed credentials to be applied to the process")
+	}
+	if m.state.CredentialRefreshSeq == seq {
+		t.Error("expected the next refresh to be scheduled")
+	}
+	if !m.ui.Toast.Visible() {
+		t.Error("expected the failed refresh to be reported")
+	}
+
+	provider.NextRefresh = time.Time{}
+	if cmd := m.scheduleCredentialRefresh(); cmd != nil {
+		t.Error("expected no refresh for credentials that never expire")
+	}
+}
+
+// TestStackInitWizard verifies the stack init wizard creates the stack with the
+// chosen secrets provider, copied config and initial config values
+func TestStackInitWizard(t *testing.T) {
+	deps := newTestDependencies()
+	initializer := deps.StackInitializer.(*pulumi.FakeStackInitializer)
+	initializer.CopyResult = &pulumi.ConfigCopyResult{From: "dev", Copied: 2, SkippedSecrets: 1, SecretsErr: errors.New("incorrect passphrase")}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.showStackInitModal()
+	result, _ = m.Update(stackFilesMsg{{Name: "dev"}})
+	m = result.(Model)
+
+	press := func(keys ...tea.KeyMsg) {
+		for _, k := range keys {
+			result, _ := m.handleKeyPress(k)
+			m = result.(Model)
+		}
+	}
+	typeText := func(text string) {
+		for _, r := range text {
+			press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	typeText("prod")
+	press(enter)
+	typeText("awskms://alias/prod")
+	press(enter)
+	press(tea.KeyMsg{Type: tea.KeyDown}, enter)
+	typeText("app:replicas=3")
+	press(enter)
+	result, cmd := m.handleKeyPress(enter)
+	m = result.(Model)
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+
+	if len(initializer.Calls.InitStack) != 1 {
+		t.Fatalf("expected the stack to be created once, got %d", len(initializer.Calls.InitStack))
+	}
+	call := initializer.Calls.InitStack[0]
+	if call.StackName != "prod" || call.Opts.SecretsProvider != "awskms://alias/prod" || call.Opts.CopyConfigFrom != "dev" {
+		t.Errorf("unexpected init options %+v", call)
+	}
+	if call.Opts.Config["app:replicas"] != "3" {
+		t.Errorf("expected the initial config value, got %v", call.Opts.Config)
+	}
+	if m.ui.StackInitModal.Visible() || m.ctx.StackName != "prod" {
+		t.Error("expected the new stack to be selected")
+	}
+	if toast := m.ui.Toast.View(120); !strings.Contains(toast, "1 secrets couldn't be decrypted") {
+		t.Errorf("expected a toast reporting the skipped secret, got %q", toast)
+	}
+}
+
+// TestConfigCopy verifies config is copied to the current stack from a stack
+// picked in the config copy prompt
+func TestConfigCopy(t *testing.T) {
+	deps := newTestDependencies()
+	initializer := deps.StackInitializer.(*pulumi.FakeStackInitializer)
+	deps.WorkspaceReader.(*pulumi.FakeWorkspaceReader).ListStackFilesFunc = func(string) ([]pulumi.StackFileInfo, error) {
+		return []pulumi.StackFileInfo{{Name: "dev"}, {Name: "prod", SecretCount: 1}}, nil
+	}
+	initializer.CopyConfigFunc = func(_ context.Context, _, from, _ string, _ pulumi.ConfigCopyOptions) (*pulumi.ConfigCopyResult, error) {
+		return &pulumi.ConfigCopyResult{From: from, Copied: 3, Secrets: 1}, nil
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	m = result.(Model)
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if !m.ui.ConfigCopyModal.Visible() {
+		t.Fatal("expected the config copy prompt")
+	}
+	// The current stack isn't offered as a source
+	if view := m.ui.ConfigCopyModal.View(); strings.Contains(view, "Pulumi.dev.yaml") || !strings.Contains(view, "1 secrets to re-encrypt") {
+		t.Errorf("expected only prod to be offered, got:\n%s", view)
+	}
+
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if len(initializer.Calls.CopyConfig) != 1 {
+		t.Fatalf("expected config to be copied once, got %d", len(initializer.Calls.CopyConfig))
+	}
+	if call := initializer.Calls.CopyConfig[0]; call.FromStack != "prod" || call.ToStack != "dev" {
+		t.Errorf("unexpected copy %+v", call)
+	}
+	if m.ui.ConfigCopyModal.Visible() || !strings.Contains(m.ui.Toast.View(120), "Copied 3 config values from prod to dev") {
+		t.Errorf("expected a toast reporting the copy, got %q", m.ui.Toast.View(120))
+	}
+}
+
+// TestStatusBadges verifies plugin status badges are shown in the header once
+// plugins authenticate, polled again, and cleared when the stack changes
+func TestStatusBadges(t *testing.T) {
+	deps := newTestDependencies()
+	provider := deps.PluginProvider.(*plugins.FakePluginProvider)
+	provider.HasStatusBadge = true
+	provider.StatusBadges = []plugins.StatusBadge{
+		{PluginName: "aws", Text: "AWS: prod-account", Level: plugins.StatusBadgeLevelDanger},
+		{PluginName: "kube", Error: errors.New("no kubeconfig")},
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "prod"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.Header.SetData(&ui.HeaderData{ProgramName: "app", StackName: "prod", Runtime: "go"})
+
+	cmd := m.refreshStatusBadges()
+	if cmd == nil {
+		t.Fatal("expected badges to be fetched")
+	}
+	seq := m.state.StatusBadgeSeq
+	result, cmd = m.Update(cmd())
+	m = result.(Model)
+	if cmd == nil {
+		t.Error("expected the next poll to be scheduled")
+	}
+	if view := m.ui.Header.View(); !strings.Contains(view, "AWS: prod-account") {
+		t.Errorf("expected the badge in the header, got:\n%s", view)
+	}
+
+	// Polls are skipped while plugins authenticate
+	m.state.SetBusy("auth")
+	result, cmd = m.Update(statusBadgeTickMsg{Seq: seq})
+	m = result.(Model)
+	if cmd == nil || provider.Calls.GetStatusBadges != 1 {
+		t.Errorf("expected the poll to wait, got %d calls", provider.Calls.GetStatusBadges)
+	}
+	m.state.ClearBusy()
+
+	// Switching stacks hides the badges and ignores results fetched for the old stack
+	stale := m.fetchStatusBadges()
+	m.clearStatusBadges()
+	result, _ = m.Update(stale())
+	m = result.(Model)
+	if view := m.ui.Header.View(); strings.Contains(view, "AWS: prod-account") {
+		t.Errorf("expected stale badges to be ignored, got:\n%s", view)
+	}
+	if _, cmd := m.Update(statusBadgeTickMsg{Seq: seq}); cmd != nil {
+		t.Error("expected a stale poll to be ignored")
+	}
+
+	provider.HasStatusBadge = false
+	if cmd := m.refreshStatusBadges(); cmd != nil {
+		t.Error("expected no polling without status badge plugins")
+	}
+}
+
+// TestPluginStatusFlow verifies the plugin status modal lists each plugin's
+// authentication state and re-authenticates the selected plugin
+func TestPluginStatusFlow(t *testing.T) {
+	deps := newTestDependencies()
+	provider := deps.PluginProvider.(*plugins.FakePluginProvider)
+	provider.CredentialsSummary = []plugins.CredentialsSummary{
+		{PluginName: "env", EnvVars: []string{"API_URL"}, IssuedAt: time.Now()},
+		{PluginName: "aws", HasError: true, Error: "sso session expired"},
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	m.state.InitState = InitComplete
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+	m = result.(Model)
+	if !m.ui.Focus.Has(ui.FocusPluginStatusModal) {
+		t.Fatal("expected the plugin status modal to open")
+	}
+	if view := m.View(); !strings.Contains(view, "never expires") || !strings.Contains(view, "error") {
+		t.Errorf("expected each plugin's state to be listed, got:\n%s", view)
+	}
+
+	provider.CredentialsSummary[1] = plugins.CredentialsSummary{PluginName: "aws", EnvVars: []string{"AWS_PROFILE"}, IssuedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = result.(Model)
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if len(provider.Calls.ReauthenticatePlugin) != 1 || provider.Calls.ReauthenticatePlugin[0] != "aws" {
+		t.Fatalf("expected aws to be re-authenticated, got %v", provider.Calls.ReauthenticatePlugin)
+	}
+	if provider.Calls.ApplyEnvToProcess == 0 {
+		t.Error("expected the new credentials to be applied to the process")
+	}
+	if !strings.Contains(m.View(), "expires in") {
+		t.Error("expected the modal to show the new expiry")
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEscape})
+	m = result.(Model)
+	if m.ui.PluginStatusModal.Visible() || m.ui.Focus.Has(ui.FocusPluginStatusModal) {
+		t.Error("expected escape to close the plugin status modal")
+	}
+}
+
+// TestBackendLogin verifies a missing backend login prompts for one, logs in to the
+// backend entered and starts loading the workspace over
+func TestBackendLogin(t *testing.T) {
+	deps := newTestDependencies()
+	authenticator := deps.BackendAuthenticator.(*pulumi.FakeBackendAuthenticator)
+	deps.WorkspaceReader.(*pulumi.FakeWorkspaceReader).GetWhoAmIFunc = func(context.Context, string, pulumi.ReadOptions) (*pulumi.WhoAmIInfo, error) {
+		return nil, errors.New("failed to get whoami: error: PULUMI_ACCESS_TOKEN must be set for login during non-interactive CLI sessions")
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.transitionTo(InitLoadingStacks)
+	for _, msg := range runCmds(m.fetchInitData()) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if !m.ui.LoginModal.Visible() || !strings.Contains(m.ui.LoginModal.View(), "PULUMI_ACCESS_TOKEN must be set") {
+		t.Fatalf("expected the login prompt with the error, got:\n%s", m.ui.LoginModal.View())
+	}
+
+	// A self-managed backend logs in without asking for a token
+	for _, r := range "s3://state" {
+		result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+	}
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if len(authenticator.Calls.Login) != 1 {
+		t.Fatalf("expected a login, got %+v", authenticator.Calls.Login)
+	}
+	if opts := authenticator.Calls.Login[0].Opts; opts.URL != "s3://state" || opts.Token != "" {
+		t.Errorf("unexpected login options %+v", opts)
+	}
+	if m.ui.LoginModal.Visible() || m.state.InitState != InitLoadingPlugins {
+		t.Errorf("expected init to start over, got state %v", m.state.InitState)
+	}
+	if !strings.Contains(m.ui.Toast.View(120), "Logged in to s3://state as test-user") {
+		t.Errorf("expected a login toast, got %q", m.ui.Toast.View(120))
+	}
+
+	// Pulumi Cloud takes a token, or hands the terminal to pulumi login without one
+	m.showLoginModal(nil)
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if !m.ui.LoginModal.Visible() || m.ui.LoginModal.CurrentStep() != 1 {
+		t.Fatal("expected to be asked for a token")
+	}
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if cmd == nil || len(authenticator.Calls.LoginCommand) != 1 || len(authenticator.Calls.Login) != 1 {
+		t.Fatalf("expected an interactive login, got %+v", authenticator.Calls)
+	}
+	if opts := authenticator.Calls.LoginCommand[0].Opts; opts.URL != "https://api.pulumi.com" {
+		t.Errorf("unexpected login options %+v", opts)
+	}
+
+	// A failed login prompts again
+	result, _ = m.Update(loginDoneMsg{Err: errors.New("invalid access token")})
+	m = result.(Model)
+	if !m.ui.LoginModal.Visible() || !strings.Contains(m.ui.LoginModal.View(), "invalid access token") {
+		t.Errorf("expected the login prompt with the error, got:\n%s", m.ui.LoginModal.View())
+	}
+}
+
+// TestRecentWorkspaces verifies opened stacks are remembered, listed in a Recent
+// section of the workspace selector, and can be pinned from it
+func TestRecentWorkspaces(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	deps := newTestDependencies()
+	reader := deps.WorkspaceReader.(*pulumi.FakeWorkspaceReader)
+	reader.Workspaces = []pulumi.WorkspaceInfo{
+		{Path: "/repo/app", Name: "app"},
+		{Path: "/repo/infra", Name: "infra", Current: true},
+	}
+	reader.ProjectInfo = &pulumi.ProjectInfo{ProgramName: "infra", StackName: "prod"}
+	reader.IsWorkspaceFunc = func(dir string) bool { return dir != "/gone" }
+	if err := plugins.RecordRecentWorkspace("/gone", "dev", time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	m := initialModel(context.Background(), AppContext{WorkDir: "/repo/infra", Cwd: "/repo", StartView: "stack"}, deps)
+	m.transitionTo(InitComplete)
+
+	result, cmd := m.handleStackSelected(stackSelectedMsg("prod"))
+	m = result.(Model)
+	runCmds(cmd)
+
+	m.showWorkspaceSelector()
+	for _, msg := range runCmds(m.fetchWorkspacesList()) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	workspaces := func() []ui.WorkspaceItem {
+		return slices.DeleteFunc(slices.Clone(m.ui.WorkspaceSelector.Items()), func(item ui.WorkspaceItem) bool { return item.Header || item.IsNewItem })
+	}
+	items := workspaces()
+	if len(items) != 2 {
+		t.Fatalf("expected the recent workspace listed once and moved ones skipped, got %+v", items)
+	}
+	if items[0].Path != "/repo/infra" || items[0].Section != "Recent" || items[0].LastStack != "prod" {
+		t.Errorf("expected the opened workspace under Recent with its stack, got %+v", items[0])
+	}
+	if items[1].Path != "/repo/app" || items[1].Section != "Workspaces" {
+		t.Errorf("expected the other workspace after the recent ones, got %+v", items[1])
+	}
+
+	// Pin the other workspace, below the Workspaces header
+	for range 2 {
+		result, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = result.(Model)
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*")})
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	items = workspaces()
+	if len(items) != 2 || items[0].Path != "/repo/app" || !items[0].Pinned || items[0].Section != "Recent" {
+		t.Fatalf("expected the pinned workspace first, got %+v", items)
+	}
+	if selected := m.ui.WorkspaceSelector.SelectedWorkspace(); selected == nil || selected.Path != "/repo/app" {
+		t.Errorf("expected the cursor to stay on the pinned workspace, got %+v", selected)
+	}
+}
+
+// TestLogViewer verifies the log viewer shows captured records and refreshes
+// while open, and points to --debug when nothing is captured
+func TestLogViewer(t *testing.T) {
+	deps := newTestDependencies()
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	tilde := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'~'}}
+	result, _ = m.handleKeyPress(tilde)
+	m = result.(Model)
+	if m.ui.Focus.Has(ui.FocusLogs) || !m.ui.Toast.Visible() {
+		t.Fatal("expected a toast instead of the log viewer without --debug")
+	}
+
+	logs := telemetry.NewLogBuffer(telemetry.DefaultLogBufferSize)
+	deps.Logs = logs
+	deps.Logger = slog.New(logs.Handler())
+	deps.Logger.Info("loading stack", "stack", "dev")
+
+	result, _ = m.handleKeyPress(tilde)
+	m = result.(Model)
+	if !m.ui.Focus.Has(ui.FocusLogs) || len(m.ui.Logs.VisibleEntries()) != 1 {
+		t.Fatalf("expected the log viewer with one record, got %+v", m.ui.Logs.VisibleEntries())
+	}
+
+	deps.Logger.Warn("plugin status badge failed")
+	result, cmd := m.Update(logsTickMsg{Seq: m.state.LogsRefreshSeq})
+	m = result.(Model)
+	if len(m.ui.Logs.VisibleEntries()) != 2 || cmd == nil {
+		t.Fatal("expected the tick to show the new record and schedule the next refresh")
+	}
+
+	result, _ = m.handleKeyPress(tilde)
+	m = result.(Model)
+	if m.ui.Focus.Has(ui.FocusLogs) {
+		t.Fatal("expected ~ to close the log viewer")
+	}
+	if _, cmd = m.Update(logsTickMsg{Seq: m.state.LogsRefreshSeq}); cmd != nil {
+		t.Error("expected refreshing to stop once closed")
+	}
+}
+
+// TestStackBusy verifies operations don't start while another p5 holds the
+// stack, and that the stack is locked while an operation runs
+func TestStackBusy(t *testing.T) {
+	deps := newTestDependencies()
+	locker := deps.StackLocker.(*pulumi.FakeStackLocker)
+	events := make(chan pulumi.OperationEvent, 1)
+	operator := deps.StackOperator.(*pulumi.FakeStackOperator)
+	operator.UpFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return events
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	locker.Held = &pulumi.StackLock{Stack: "dev", Operation: "destroy", PID: 4242, Host: "laptop", Started: time.Now()}
+	m.startExecution(pulumi.OperationUp)
+	if m.ui.Focus.Current() != ui.FocusErrorModal || m.ui.Focus.Has(ui.FocusConfirmModal) || len(operator.Calls.Up) != 0 {
+		t.Fatal("expected the stack busy dialog instead of confirming up")
+	}
+	m.hideErrorModal()
+
+	locker.Held = nil
+	m.runExecution(pulumi.OperationUp, pulumi.OperationOptions{})
+	if len(operator.Calls.Up) != 1 || len(locker.Calls.Acquire) != 1 || len(locker.Calls.Release) != 0 {
+		t.Fatal("expected up to run with the stack locked")
+	}
+
+	result, _ = m.Update(operationEventMsg(pulumi.OperationEvent{Done: true}))
+	m = result.(Model)
+	if len(locker.Calls.Release) != 1 {
+		t.Error("expected the lock to be released once up finished")
+	}
+}
+
+// TestImportIDValidation verifies plugins check the typed import ID once typing
+// pauses, and that verdicts on IDs changed since are ignored
+func TestImportIDValidation(t *testing.T) {
+	deps := newTestDependencies()
+	provider := deps.PluginProvider.(*plugins.FakePluginProvider)
+	provider.HasImportHelper = true
+	provider.ImportIDValidation = &plugins.ImportIDValidation{PluginName: "aws", Message: "bucket missing does not exist"}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.showImportModal("aws:s3/bucket:Bucket", "logs", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", "")
+
+	var cmd tea.Cmd
+	for _, r := range "missing" {
+		result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+	}
+	if cmd == nil || len(provider.Calls.ValidateImportID) != 0 {
+		t.Fatal("expected the check to wait for typing to pause")
+	}
+	staleSeq := m.state.ImportValidationSeq - 1
+	if _, cmd := m.Update(importValidateTickMsg{Seq: staleSeq}); cmd != nil {
+		t.Error("expected ticks for earlier IDs to be ignored")
+	}
+
+	result, cmd = m.Update(importValidateTickMsg{Seq: m.state.ImportValidationSeq})
+	m = result.(Model)
+	msgs := runCmds(cmd)
+	if len(msgs) != 1 || len(provider.Calls.ValidateImportID) != 1 || provider.Calls.ValidateImportID[0].ImportId != "missing" {
+		t.Fatalf("expected plugins to check the typed ID, got %+v", provider.Calls.ValidateImportID)
+	}
+	result, _ = m.Update(msgs[0])
+	m = result.(Model)
+
+	// A rejected ID isn't imported
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if !m.ui.Focus.Has(ui.FocusImportModal) {
+		t.Fatal("expected the import modal to stay open for a rejected ID")
+	}
+	if !strings.Contains(m.View(), "bucket missing does not exist") {
+		t.Error("expected the rejection to be shown inline")
+	}
+}
+
+// TestImportPreview verifies the import is previewed before it runs, and that a
+// preview finishing after the ID changed is discarded
+func TestImportPreview(t *testing.T) {
+	deps := newTestDependencies()
+	importer := &pulumi.FakeResourceImporter{
+		ImportPreview: &pulumi.ImportPreview{Properties: []string{`bucket: "logs-123"`}},
+	}
+	deps.ResourceImporter = importer
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.showImportModal("aws:s3/bucket:Bucket", "logs", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", "")
+	for _, r := range "logs-123" {
+		result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = result.(Model)
+	}
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	msgs := runCmds(cmd)
+	if len(importer.Calls.PreviewImport) != 1 || len(importer.Calls.Import) != 0 {
+		t.Fatalf("expected enter to preview the import, got %d previews and %d imports",
+			len(importer.Calls.PreviewImport), len(importer.Calls.Import))
+	}
+	if spec := importer.Calls.PreviewImport[0].Spec; spec.ID != "logs-123" || spec.Name != "logs" {
+		t.Errorf("unexpected preview spec %+v", spec)
+	}
+
+	// A preview of an earlier ID is ignored
+	result, _ = m.Update(importPreviewMsg{ImportID: "logs-12", Preview: &pulumi.ImportPreview{}})
+	m = result.(Model)
+	if m.ui.ImportModal.Previewed() {
+		t.Fatal("expected a preview of another ID to be ignored")
+	}
+
+	for _, msg := range msgs {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if !strings.Contains(m.View(), `bucket: "logs-123"`) {
+		t.Error("expected the adopted inputs to be shown")
+	}
+
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	_ = result.(Model)
+	runCmds(cmd)
+	if len(importer.Calls.Import) != 1 || importer.Calls.Import[0].ImportID != "logs-123" {
+		t.Errorf("expected enter to import the previewed ID, got %+v", importer.Calls.Import)
+	}
+}
+
+// TestImportGeneratedCode verifies the code generated by an import is shown and
+// can be saved, without overwriting an existing file
+func TestImportGeneratedCode(t *testing.T) {
+	workDir := t.TempDir()
+	deps := newTestDependencies()
+	code := "const logs = new aws.s3.Bucket(\"logs\", {bucket: \"logs-123\"});\n"
+	deps.ResourceImporter = &pulumi.FakeResourceImporter{
+		ImportResult: &pulumi.CommandResult{Success: true, GeneratedCode: code},
+	}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: workDir, StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.state.Runtime = "nodejs"
+	m.showImportModal("aws:s3/bucket:Bucket", "logs", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", "")
+
+	for _, msg := range runCmds(m.executeImport()) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if !m.ui.Focus.Has(ui.FocusCode) || !strings.Contains(m.View(), "TypeScript") {
+		t.Fatal("expected the generated code to be shown after the import")
+	}
+
+	save := func() Model {
+		result, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+		m := result.(Model)
+		if got := m.ui.SaveFileModal.Path(); got != filepath.Join(".p5", "generated", "logs.ts") {
+			t.Fatalf("unexpected default file %q", got)
+		}
+		result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+		m = result.(Model)
+		for _, msg := range runCmds(cmd) {
+			result, _ = m.Update(msg)
+			m = result.(Model)
+		}
+		return m
+	}
+
+	m = save()
+	data, err := os.ReadFile(filepath.Join(workDir, ".p5", "generated", "logs.ts"))
+	if err != nil || string(data) != code {
+		t.Fatalf("expected the code to be saved, got %q (%v)", data, err)
+	}
+	if m.ui.SaveFileModal.Visible() {
+		t.Error("expected the prompt to close once saved")
+	}
+
+	m = save()
+	if !m.ui.SaveFileModal.Visible() || !strings.Contains(m.View(), "already exists") {
+		t.Error("expected saving over an existing file to keep the prompt open with an error")
+	}
+}
+
+// TestBulkStateDelete verifies a visual selection is removed from state in one
+// confirmation listing the resources, one resource at a time
+func TestBulkStateDelete(t *testing.T) {
+	deps := newTestDependencies()
+	importer := &pulumi.FakeResourceImporter{
+		StateDeleteFunc: func(_ context.Context, _, _, urn string, _ pulumi.StateDeleteOptions) (*pulumi.CommandResult, error) {
+			if strings.HasSuffix(urn, "bucket-2") {
+				return &pulumi.CommandResult{Error: errors.New("resource has dependents")}, nil
+			}
+			return &pulumi.CommandResult{Success: true}, nil
+		},
+	}
+	deps.ResourceImporter = importer
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ViewMode = ui.ViewStack
+	var items []ui.ResourceItem
+	for _, name := range []string{"bucket-1", "bucket-2", "bucket-3"} {
+		items = append(items, ui.ResourceItem{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::" + name, Type: "aws:s3/bucket:Bucket", Name: name})
+	}
+	m.ui.ResourceList.SetItems(items)
+
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'v'}},
+		{Type: tea.KeyDown},
+		{Type: tea.KeyDown},
+		{Type: tea.KeyRunes, Runes: []rune{'x'}},
+	} {
+		result, _ = m.handleKeyPress(k)
+		m = result.(Model)
+	}
+	view := m.View()
+	for _, name := range []string{"bucket-1", "bucket-2", "bucket-3"} {
+		if !strings.Contains(view, name) {
+			t.Errorf("expected the confirmation to list %s", name)
+		}
+	}
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = result.(Model)
+	if m.ui.Focus.Has(ui.FocusConfirmModal) {
+		t.Fatal("expected the confirmation to close while resources are removed")
+	}
+	for step := 1; cmd != nil; step++ {
+		if len(importer.Calls.StateDelete) != step-1 {
+			t.Fatalf("expected one resource removed per step, got %d calls at step %d", len(importer.Calls.StateDelete), step)
+		}
+		if step <= 3 && !strings.Contains(m.ui.Toast.View(120), fmt.Sprintf("(%d/3)", step)) {
+			t.Errorf("expected progress %d/3, got %q", step, m.ui.Toast.View(120))
+		}
+		var next tea.Cmd
+		for _, msg := range runCmds(cmd) {
+			if _, ok := msg.(stateDeleteStepMsg); ok {
+				result, next = m.Update(msg)
+				m = result.(Model)
+			}
+		}
+		cmd = next
+		if step > 3 {
+			break
+		}
+	}
+	if len(importer.Calls.StateDelete) != 3 || m.state.BulkStateDelete != nil {
+		t.Fatalf("expected all 3 resources to be processed, got %d", len(importer.Calls.StateDelete))
+	}
+	if !m.ui.ErrorModal.Visible() || !strings.Contains(m.View(), "bucket-2: resource has dependents") {
+		t.Error("expected the failed resource to be reported")
+	}
+}
+
+// TestStateDeleteDependents verifies the resources depending on a state delete
+// selection can be included, and are removed before what they depend on
+func TestStateDeleteDependents(t *testing.T) {
+	const (
+		vpc    = "urn:pulumi:dev::app::my:index:Network::vpc"
+		subnet = "urn:pulumi:dev::app::my:index:Network$aws:ec2/subnet:Subnet::subnet"
+		db     = "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+	)
+	deps := newTestDependencies()
+	importer := &pulumi.FakeResourceImporter{}
+	deps.ResourceImporter = importer
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ViewMode = ui.ViewStack
+	m.state.StackResources = []pulumi.ResourceInfo{
+		{URN: vpc, Type: "my:index:Network", Name: "vpc"},
+		{URN: subnet, Type: "aws:ec2/subnet:Subnet", Name: "subnet", Parent: vpc},
+		{URN: db, Type: "aws:rds/instance:Instance", Name: "db", Dependencies: []string{subnet}},
+	}
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{{URN: vpc, Type: "my:index:Network", Name: "vpc"}})
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = result.(Model)
+	if !strings.Contains(m.View(), "2 resources depend on the selection") {
+		t.Fatal("expected the confirmation to mention the dependents")
+	}
+	if m.ui.ConfirmModal.IsBulkOperation() {
+		t.Fatal("expected only the selection to be removed by default")
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = result.(Model)
+	if got := len(m.ui.ConfirmModal.GetBulkResources()); got != 3 {
+		t.Fatalf("expected the dependents to be included, got %d resources", got)
+	}
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = result.(Model)
+	for cmd != nil {
+		var next tea.Cmd
+		for _, msg := range runCmds(cmd) {
+			if _, ok := msg.(stateDeleteStepMsg); ok {
+				result, next = m.Update(msg)
+				m = result.(Model)
+			}
+		}
+		if m.state.BulkStateDelete == nil {
+			break
+		}
+		cmd = next
+	}
+	var got []string
+	for _, call := range importer.Calls.StateDelete {
+		got = append(got, call.URN)
+	}
+	if want := []string{db, subnet, vpc}; !slices.Equal(got, want) {
+		t.Errorf("expected dependents to be removed first, got %v", got)
+	}
+}
+
+// TestEditResourceState verifies an edited resource state is validated when the
+// editor exits and written back once confirmed
+func TestEditResourceState(t *testing.T) {
+	const urn = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	original := []byte(`{"urn": "` + urn + `", "type": "aws:s3/bucket:Bucket", "id": "logs-1"}` + "\n")
+	deps := newTestDependencies()
+	importer := &pulumi.FakeResourceImporter{ResourceStates: map[string][]byte{urn: original}}
+	deps.ResourceImporter = importer
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ViewMode = ui.ViewStack
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{{URN: urn, Type: "aws:s3/bucket:Bucket", Name: "logs"}})
+
+	// edit opens the state in the editor, then reads it back when the editor exits
+	edit := func(content string) {
+		t.Helper()
+		result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlX})
+		m = result.(Model)
+		for _, msg := range runCmds(cmd) {
+			if msg, ok := msg.(resourceStateMsg); ok {
+				result, _ = m.Update(msg)
+				m = result.(Model)
+			}
+		}
+		pending := m.state.PendingResourceState
+		if pending == nil {
+			t.Fatal("expected the resource state to be opened for editing")
+		}
+		if err := os.WriteFile(pending.Path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		result, _ = m.Update(resourceStateEditedMsg{})
+		m = result.(Model)
+	}
+
+	edit(`{"urn": "urn:pulumi:dev::app::aws:s3/bucket:Bucket::other", "type": "aws:s3/bucket:Bucket"}`)
+	if !strings.Contains(m.View(), "Invalid Resource State") {
+		t.Fatal("expected a changed urn to be rejected")
+	}
+	path := m.state.PendingResourceState.Path
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = result.(Model)
+	if m.state.PendingResourceState != nil {
+		t.Fatal("expected discarding to clear the pending edit")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the temporary file to be removed, got %v", err)
+	}
+
+	edited := `{"urn": "` + urn + `", "type": "aws:s3/bucket:Bucket", "id": "logs-2"}`
+	edit(edited)
+	if !strings.Contains(m.View(), "Edit Resource State") {
+		t.Fatal("expected the valid edit to be confirmed")
+	}
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = result.(Model)
+	for _, msg := range runCmds(cmd) {
+		if msg, ok := msg.(resourceStateWrittenMsg); ok {
+			result, _ = m.Update(msg)
+			m = result.(Model)
+		}
+	}
+	if len(importer.Calls.SetResourceState) != 1 {
+		t.Fatalf("expected the state to be written once, got %d", len(importer.Calls.SetResourceState))
+	}
+	if call := importer.Calls.SetResourceState[0]; call.URN != urn || string(call.Original) != string(original) || string(call.Edited) != edited {
+		t.Errorf("unexpected write: %+v", call)
+	}
+	if !strings.Contains(m.ui.Toast.View(120), "Updated the state of 'logs'") {
+		t.Error("expected a toast confirming the write")
+	}
+}
+
+// TestViewValueFlow verifies alt+v in the details panel shows a large value in the
+// pager, asking which one when there are several, and that $PAGER is run with it
+func TestViewValueFlow(t *testing.T) {
+	t.Setenv("PAGER", "less -R")
+	if cmd := pagerCommand("value"); !slices.Equal(cmd.Args, []string{"less", "-R"}) || cmd.Stdin == nil {
+		t.Errorf("unexpected pager command %q", cmd.Args)
+	}
+
+	const cluster = "urn:pulumi:dev::app::aws:eks/cluster:Cluster::main"
+	large := strings.Repeat("line\n", 300)
+	m := initialModel(context.Background(), AppContext{WorkDir: t.TempDir(), StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{{
+		URN: cluster, Type: "aws:eks/cluster:Cluster", Name: "main", Op: ui.OpSame,
+		Outputs: map[string]any{"kubeconfig": "apiVersion: v1\n" + large, "certificateAuthority": large},
+	}})
+	m.showDetailsPanel()
+	altV := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}, Alt: true}
+
+	result, _ = m.handleKeyPress(altV)
+	m = result.(Model)
+	if !m.ui.Focus.Has(ui.FocusValueSelector) {
+		t.Fatal("expected the value selector with two large values")
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(Model)
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.ui.Focus.Has(ui.FocusValueSelector) || !m.ui.Focus.Has(ui.FocusValuePager) {
+		t.Fatal("expected the pager to replace the selector")
+	}
+	if !strings.HasPrefix(m.ui.ValuePager.Text(), "apiVersion: v1") || !strings.Contains(m.View(), "kubeconfig") {
+		t.Errorf("expected the kubeconfig in the pager, got:\n%s", m.View())
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.ui.Focus.Has(ui.FocusValuePager) || !m.ui.Focus.Has(ui.FocusDetailsPanel) {
+		t.Error("expected esc to return to the details panel")
+	}
+}
+
+// TestExportResourcesFlow verifies alt+y prompts for a file in .p5/exports and
+// writes the listed resources to it
+func TestExportResourcesFlow(t *testing.T) {
+	workDir := t.TempDir()
+	m := initialModel(context.Background(), AppContext{WorkDir: workDir, StackName: "org/dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{{
+		URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Type: "aws:s3/bucket:Bucket", Name: "logs", Op: ui.OpCreate,
+	}})
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}, Alt: true})
+	m = result.(Model)
+	path := m.ui.SaveFileModal.Path()
+	if !m.ui.SaveFileModal.Visible() || !strings.HasPrefix(path, ExportsDir) || !strings.HasSuffix(path, "-org_dev.csv") {
+		t.Fatalf("expected a prompt for a CSV file in %s, got %q", ExportsDir, path)
+	}
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+
+	data, err := os.ReadFile(filepath.Join(workDir, path))
+	if err != nil || !strings.Contains(string(data), "logs,aws:s3/bucket:Bucket,create,,urn:") {
+		t.Fatalf("expected the resources to be exported, got %q (%v)", data, err)
+	}
+	if m.ui.SaveFileModal.Visible() || m.state.ExportingResources {
+		t.Error("expected the prompt to close once exported")
+	}
+}
+
+// TestCopyPreviewMarkdown verifies alt+m copies a finished preview and does
+// nothing outside the preview view
+func TestCopyPreviewMarkdown(t *testing.T) {
+	m := initialModel(context.Background(), AppContext{WorkDir: t.TempDir(), StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	altM := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}, Alt: true}
+
+	m.ui.ViewMode = ui.ViewStack
+	if _, cmd := m.handleKeyPress(altM); cmd != nil {
+		t.Error("expected nothing to be copied outside the preview view")
+	}
+
+	m.ui.ViewMode = ui.ViewPreview
+	m.state.OpState = OpRunning
+	result, _ = m.handleKeyPress(altM)
+	m = result.(Model)
+	if !strings.Contains(m.View(), "Wait for the preview to finish") {
+		t.Error("expected a running preview not to be copied")
+	}
+
+	m.state.OpState = OpComplete
+	if _, cmd := m.handleKeyPress(altM); cmd == nil {
+		t.Error("expected the finished preview to be copied")
+	}
+}
+
+// TestOpenSource verifies the editor opens at the source position recorded in state
+// and that resources without one report it
+func TestOpenSource(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	if got := editorCommand("/work/index.ts", 12).Args; !slices.Equal(got, []string{"code", "--wait", "+12", "/work/index.ts"}) {
+		t.Errorf("unexpected editor command %q", got)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.ts"), []byte("new Bucket(\"logs\")\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	const (
+		logs = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+		db   = "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+	)
+	m := initialModel(context.Background(), AppContext{WorkDir: dir, StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ViewMode = ui.ViewStack
+	m.state.StackResources = []pulumi.ResourceInfo{
+		{URN: logs, Type: "aws:s3/bucket:Bucket", Name: "logs", SourcePosition: "project:///index.ts#1,1"},
+		{URN: db, Type: "aws:rds/instance:Instance", Name: "db"},
+	}
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{
+		{URN: logs, Type: "aws:s3/bucket:Bucket", Name: "logs"},
+		{URN: db, Type: "aws:rds/instance:Instance", Name: "db"},
+	})
+	m.ui.ResourceList.SelectURN(logs)
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlL})
+	m = result.(Model)
+	if cmd == nil || strings.Contains(m.ui.Toast.View(120), "source") {
+		t.Fatal("expected the editor to open for a resource with a source position")
+	}
+
+	m.ui.ResourceList.SelectURN(db)
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlL})
+	m = result.(Model)
+	runCmds(cmd)
+	if !strings.Contains(m.ui.Toast.View(120), "No source position recorded for 'db'") {
+		t.Errorf("expected a toast for a resource without a source position, got %q", m.ui.Toast.View(120))
+	}
+}
+
+// TestOpenResourceExec verifies exec open actions check the program exists before
+// suspending the TUI, and report its error output once it fails
+func TestOpenResourceExec(t *testing.T) {
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	missing := &plugins.OpenResourceResponse{CanOpen: true, Action: &proto.OpenAction{
+		Type:    proto.OpenActionType_OPEN_ACTION_TYPE_EXEC,
+		Command: "p5-missing-program",
+	}}
+	result, _ = m.Update(openResourceActionMsg{Response: missing, PluginName: "k9s"})
+	m = result.(Model)
+	if !strings.Contains(m.ui.Toast.View(120), "p5-missing-program not found") {
+		t.Errorf("expected a toast for a missing program, got %q", m.ui.Toast.View(120))
+	}
+
+	stderr := &tailWriter{max: 8}
+	fmt.Fprint(stderr, "line one\nerror: no context\n")
+	if got := stderr.String(); got != "context" {
+		t.Errorf("expected only the end of the output to be kept, got %q", got)
+	}
+
+	result, _ = m.Update(openResourceExecDoneMsg{Command: "k9s", Stderr: "error: no context", Error: errors.New("exit status 1")})
+	m = result.(Model)
+	if !m.ui.ErrorModal.Visible() || !strings.Contains(m.View(), "error: no context") {
+		t.Error("expected the program's error output to be shown")
+	}
+}
+
+// TestAbout verifies the about panel shows the Pulumi CLI details once fetched,
+// along with the configured p5 plugins
+func TestAbout(t *testing.T) {
+	deps := newTestDependencies()
+	deps.WorkspaceReader = &pulumi.FakeWorkspaceReader{ValidWorkDir: true, About: &pulumi.AboutInfo{
+		CLIVersion: "v3.150.0",
+		BackendURL: "https://api.pulumi.com",
+		User:       "alice",
+		Plugins:    []pulumi.PluginVersion{{Name: "aws", Kind: "resource", Version: "6.66.0"}},
+	}}
+	deps.PluginProvider = &plugins.FakePluginProvider{MergedConfig: &plugins.P5Config{Plugins: map[string]plugins.PluginConfig{
+		"k9s": {ResourceOpener: true},
+	}}}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlA})
+	m = result.(Model)
+	if !strings.Contains(m.View(), "Loading...") {
+		t.Fatal("expected the about panel to show while the Pulumi details load")
+	}
+	for _, msg := range runCmds(cmd) {
+		if msg, ok := msg.(aboutMsg); ok {
+			result, _ = m.Update(msg)
+			m = result.(Model)
+		}
+	}
+
+	text := m.ui.About.Text()
+	for _, want := range []string{"v3.150.0", "https://api.pulumi.com", "resource 6.66.0", "k9s:", "builtin resource_opener"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in the about panel:\n%s", want, text)
+		}
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.ui.Focus.Has(ui.FocusAbout) {
+		t.Error("expected esc to close the about panel")
+	}
+}
+
+// TestProviderVersionMismatch verifies a provider version in state that diverges
+// from the installed plugins is flagged in the header and listed in the about panel
+func TestProviderVersionMismatch(t *testing.T) {
+	deps := newTestDependencies()
+	deps.WorkspaceReader = &pulumi.FakeWorkspaceReader{ValidWorkDir: true, About: &pulumi.AboutInfo{
+		CLIVersion: "v3.150.0",
+		Plugins:    []pulumi.PluginVersion{{Name: "aws", Kind: "resource", Version: "5.43.0"}},
+	}}
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = result.(Model)
+
+	result, cmd := m.Update(stackResourcesMsg{
+		{URN: "urn:pulumi:dev::proj::pulumi:providers:aws::default", Type: "pulumi:providers:aws", Name: "default",
+			Inputs: map[string]any{"version": "6.52.0"}},
+	})
+	m = result.(Model)
+	for _, msg := range runCmds(cmd) {
+		if msg, ok := msg.(aboutMsg); ok {
+			result, _ = m.Update(msg)
+			m = result.(Model)
+		}
+	}
+
+	if len(m.state.ProviderMismatches) != 1 || !strings.Contains(m.ui.Header.View(), "[provider versions]") {
+		t.Fatalf("expected the aws provider to be flagged, got %+v:\n%s", m.state.ProviderMismatches, m.ui.Header.View())
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlA})
+	m = result.(Model)
+	if text := m.ui.About.Text(); !strings.Contains(text, "aws 6.52.0 in state, plugin 5.43.0") {
+		t.Errorf("expected the mismatch in the about panel:\n%s", text)
+	}
+}
+
+// TestDestroyRemovesStack verifies a destroy of the whole stack offers to remove
+// it, returning to the stack selector, while a targeted destroy does not
+func TestDestroyRemovesStack(t *testing.T) {
+	deps := newTestDependencies()
+	deps.StackOperator.(*pulumi.FakeStackOperator).DestroyFunc = func(ctx context.Context, workDir, stackName string, opts pulumi.OperationOptions) <-chan pulumi.OperationEvent {
+		return make(chan pulumi.OperationEvent)
+	}
+	initializer := deps.StackInitializer.(*pulumi.FakeStackInitializer)
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	m.startExecutionWithOptions(pulumi.OperationDestroy, pulumi.OperationOptions{Targets: []string{"urn:pulumi:dev::proj::aws:s3/bucket:Bucket::logs"}})
+	result, _ = m.Update(operationEventMsg{Done: true})
+	m = result.(Model)
+	if m.state.PendingStackRemoval || m.ui.ConfirmModal.Visible() {
+		t.Fatal("expected no stack removal to be offered after a targeted destroy")
+	}
+
+	m.startExecution(pulumi.OperationDestroy)
+	result, _ = m.Update(operationEventMsg{Done: true})
+	m = result.(Model)
+	if !m.state.PendingStackRemoval || !strings.Contains(m.ui.ConfirmModal.View(), "Remove Stack") {
+		t.Fatal("expected a confirmation offering to remove the destroyed stack")
+	}
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = result.(Model)
+	msgs := runCmds(cmd)
+	if len(initializer.Calls.RemoveStack) != 1 || initializer.Calls.RemoveStack[0].StackName != "dev" {
+		t.Fatalf("expected the stack to be removed once, got %+v", initializer.Calls.RemoveStack)
+	}
+	for _, msg := range msgs {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if !m.ui.Focus.Has(ui.FocusStackSelector) || m.ui.ViewMode != ui.ViewStack {
+		t.Error("expected the stack selector to open over the stack view")
+	}
+	if !strings.Contains(m.ui.Toast.View(120), "Removed stack dev") {
+		t.Errorf("expected a toast reporting the removal, got %q", m.ui.Toast.View(120))
+	}
+}
+
+// TestNewProject verifies a project scaffolded from a template when starting
+// outside of a workspace is opened as the workspace
+func TestNewProject(t *testing.T) {
+	cwd := t.TempDir()
+	deps := newTestDependencies()
+	initializer := deps.StackInitializer.(*pulumi.FakeStackInitializer)
+
+	m := initialModel(context.Background(), AppContext{WorkDir: cwd, Cwd: cwd, StartView: "stack"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	result, _ = m.handleWorkspaceCheck(workspaceCheckMsg(false))
+	m = result.(Model)
+	result, _ = m.Update(workspacesListMsg{})
+	m = result.(Model)
+
+	keys := []tea.KeyMsg{{Type: tea.KeyEnter}, {Type: tea.KeyEnter}} // New project, typescript
+	keys = append(keys, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("web")}, tea.KeyMsg{Type: tea.KeyEnter})
+	keys = append(keys, tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyEnter}) // Suggested directory, dev
+	var cmd tea.Cmd
+	for _, k := range keys {
+		result, cmd = m.handleKeyPress(k)
+		m = result.(Model)
+	}
+	msgs := runCmds(cmd)
+	if len(initializer.Calls.NewProject) != 1 {
+		t.Fatalf("expected a project to be created, got %+v", initializer.Calls.NewProject)
+	}
+	call := initializer.Calls.NewProject[0]
+	dir := filepath.Join(cwd, "web")
+	if call.Dir != dir || call.Opts.Template != "typescript" || call.Opts.Name != "web" || call.Opts.StackName != "dev" {
+		t.Errorf("unexpected project %+v", call)
+	}
+
+	for _, msg := range msgs {
+		result, cmd = m.Update(msg)
+		m = result.(Model)
+		for _, msg := range runCmds(cmd) {
+			if msg, ok := msg.(workspaceSelectedMsg); ok {
+				result, _ = m.Update(msg)
+				m = result.(Model)
+			}
+		}
+	}
+	if m.ctx.WorkDir != dir || m.ui.Focus.Has(ui.FocusNewProjectModal) {
+		t.Errorf("expected the new project to be opened, got %q", m.ctx.WorkDir)
+	}
+}
+
+// TestHistoryLoadsMorePages verifies the history view loads the next page of
+// updates once scrolled near the end, until a short page ends the history
+func TestHistoryLoadsMorePages(t *testing.T) {
+	history := make([]pulumi.UpdateSummary, 25)
+	for i := range history {
+		history[i] = pulumi.UpdateSummary{Kind: "update", Result: "succeeded"}
+	}
+	deps := newTestDependencies()
+	reader := &pulumi.FakeStackReader{}
+	reader.GetHistoryFunc = func(ctx context.Context, workDir, stackName string, pageSize, page int, opts pulumi.ReadOptions) ([]pulumi.UpdateSummary, error) {
+		start := min((page-1)*pageSize, len(history))
+		return history[start:min(start+pageSize, len(history))], nil
+	}
+	deps.StackReader = reader
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev", HistoryPageSize: 10}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+
+	update := func(cmd tea.Cmd) {
+		t.Helper()
+		for _, msg := range runCmds(cmd) {
+			result, _ := m.Update(msg)
+			m = result.(Model)
+		}
+	}
+	update(m.switchToHistoryView())
+	m.View() // Sizes the history list
+	if m.ui.HistoryList.TotalItems() != 10 || m.ui.HistoryList.LoadingMore() {
+		t.Fatalf("expected the first page only, got %d items", m.ui.HistoryList.TotalItems())
+	}
+	if !strings.Contains(m.ui.HistoryList.View(), "10 updates loaded, scroll for more") {
+		t.Error("expected a load more indicator")
+	}
+
+	for range 2 {
+		result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnd})
+		m = result.(Model)
+		update(cmd)
+	}
+	pages := make([]int, 0, len(reader.Calls.GetHistory))
+	for _, call := range reader.Calls.GetHistory {
+		if call.PageSize != 10 {
+			t.Errorf("expected pages of 10 updates, got %d", call.PageSize)
+		}
+		pages = append(pages, call.Page)
+	}
+	if !slices.Equal(pages, []int{1, 2, 3}) {
+		t.Fatalf("expected pages 1-3 to be loaded, got %v", pages)
+	}
+	if m.ui.HistoryList.TotalItems() != 25 || m.ui.HistoryList.NeedsMore() {
+		t.Fatalf("expected the whole history to be loaded, got %d items", m.ui.HistoryList.TotalItems())
+	}
+	if strings.Contains(m.ui.HistoryList.View(), "scroll for more") {
+		t.Error("expected no load more indicator once the history is loaded")
+	}
+	// Local backends number updates by their position in the whole history
+	m.ui.HistoryList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if item := m.ui.HistoryList.SelectedItem(); item == nil || item.Version != 25 {
+		t.Errorf("expected the latest update to be #25, got %+v", item)
+	}
+}
+
+// TestHistoryFilterModal verifies the history filter modal narrows the history
+// list to the chosen kinds and results
+func TestHistoryFilterModal(t *testing.T) {
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ViewMode = ui.ViewHistory
+	m.ui.HistoryList.SetItems([]ui.HistoryItem{
+		{Version: 3, Kind: "update", Result: "failed"},
+		{Version: 2, Kind: "refresh", Result: "succeeded"},
+		{Version: 1, Kind: "update", Result: "succeeded"},
+	}, false)
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	m = result.(Model)
+	if m.ui.Focus.Current() != ui.FocusHistoryFilterModal {
+		t.Fatalf("expected the history filter modal to open, got focus %v", m.ui.Focus.Current())
+	}
+	// Toggle the update kind, then the succeeded result
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeySpace, Runes: []rune{' '}},
+		{Type: tea.KeyDown},
+		{Type: tea.KeySpace, Runes: []rune{' '}},
+		{Type: tea.KeyEnter},
+	} {
+		result, _ = m.handleKeyPress(msg)
+		m = result.(Model)
+	}
+	if m.ui.Focus.Current() != ui.FocusMain {
+		t.Fatalf("expected the modal to close, got focus %v", m.ui.Focus.Current())
+	}
+	if item := m.ui.HistoryList.SelectedItem(); item == nil || item.Version != 1 {
+		t.Errorf("expected only update #1 to match, got %+v", item)
+	}
+	if !strings.Contains(m.ui.HistoryList.View(), "[update] [succeeded]") {
+		t.Error("expected the filter chips below the list")
+	}
+}
+
+// TestHistoryDetailsChangedResources verifies the history details panel lists the
+// resources an update changed and drills into the diff of the selected one
+func TestHistoryDetailsChangedResources(t *testing.T) {
+	deps := newTestDependencies()
+	reader := &pulumi.FakeStackReader{
+		Deployments: map[int][]pulumi.ResourceInfo{
+			2: {{URN: "urn:a", Name: "a"}},
+			3: {{URN: "urn:a", Name: "a"}, {URN: "urn:b", Name: "b"}, {URN: "urn:c", Name: "c"}},
+		},
+	}
+	deps.StackReader = reader
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ViewMode = ui.ViewHistory
+	m.ui.HistoryList.SetItems([]ui.HistoryItem{{Version: 3, BackendVersion: 3, Kind: "update"}}, false)
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	m = result.(Model)
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	changes := m.ui.HistoryDetails.Changes()
+	if len(changes) != 2 || changes[0].URN != "urn:b" || changes[1].URN != "urn:c" {
+		t.Fatalf("expected resources b and c to be listed as changed, got %+v", changes)
+	}
+
+	// Reopening the panel reuses the loaded changes
+	m.hideDetailsPanel()
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	m = result.(Model)
+	if cmd != nil || len(reader.Calls.ExportDeploymentAtVersion) != 2 {
+		t.Fatalf("expected the changes to be reused, got %d exports", len(reader.Calls.ExportDeploymentAtVersion))
+	}
+
+	for _, msg := range []tea.KeyMsg{{Type: tea.KeyDown}, {Type: tea.KeyEnter}} {
+		result, _ = m.handleKeyPress(msg)
+		m = result.(Model)
+	}
+	if m.ui.Focus.Current() != ui.FocusHistoryDiff || m.ui.HistoryDiff.Version() != 3 {
+		t.Fatalf("expected the diff of update 3 to open, got focus %v", m.ui.Focus.Current())
+	}
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEscape})
+	m = result.(Model)
+	if m.ui.Focus.Current() != ui.FocusDetailsPanel {
+		t.Errorf("expected closing the diff to return to the details panel, got focus %v", m.ui.Focus.Current())
+	}
+}
+
+func TestCloudStackBrowser(t *testing.T) {
+	deps := newTestDependencies()
+	reader := deps.StackReader.(*pulumi.FakeStackReader)
+	reader.CloudStacks = []pulumi.CloudStack{
+		{Name: "acme/api/prod", Org: "acme", Project: "api", Stack: "prod", ResourceCount: 1},
+		{Name: "acme/web/dev", Org: "acme", Project: "web", Stack: "dev"},
+	}
+	reader.CloudStates = map[string][]pulumi.ResourceInfo{
+		"acme/api/prod": {{URN: "urn:pulumi:prod::api::aws:s3/bucket:Bucket::assets", Type: "aws:s3/bucket:Bucket", Name: "assets"}},
+	}
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.transitionTo(InitComplete)
+
+	result, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	m = result.(Model)
+	if m.ui.Focus.Current() != ui.FocusCloudStackBrowser {
+		t.Fatalf("expected the cloud stack browser to have focus, got %s", m.ui.Focus.Current())
+	}
+	for _, msg := range runCmds(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(Model)
+	}
+	if got := len(m.ui.CloudStackBrowser.Items()); got != 2 {
+		t.Fatalf("expected 2 stacks in the browser, got %d", got)
+	}
+
+	// Opening a stack reads its state from the backend and browses it read-only
+	result, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	for _, msg := range runCmds(cmd) {
+		if _, ok := msg.(cloudStackStateMsg); ok {
+			result, _ = m.Update(msg)
+			m = result.(Model)
+		}
+	}
+	if len(reader.Calls.ExportCloudStack) != 1 || reader.Calls.ExportCloudStack[0].Name != "acme/api/prod" {
+		t.Fatalf("expected acme/api/prod to be exported, got %+v", reader.Calls.ExportCloudStack)
+	}
+	if m.ctx.StartView != "state" || m.ctx.CloudStack != "acme/api/prod" {
+		t.Fatalf("expected the stack to be browsed read-only, got view %q stack %q", m.ctx.StartView, m.ctx.CloudStack)
+	}
+	if got := len(m.ui.ResourceList.Items()); got != 1 {
+		t.Errorf("expected the stack's resource to be listed, got %d", got)
+	}
+	if !strings.Contains(m.View(), "READ-ONLY") {
+		t.Error("expected the footer to show the stack is read-only")
+	}
+
+	// Flags and operations don't apply to a stack browsed read-only
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	m = result.(Model)
+	if m.ui.ViewMode != ui.ViewStack {
+		t.Errorf("expected no preview from a read-only stack, got view %v", m.ui.ViewMode)
+	}
+
+	// Escape returns to the workspace's stack
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.ctx.StartView != "stack" || m.ctx.CloudStack != "" || m.ctx.StackName != "dev" {
+		t.Errorf("expected to return to the dev stack, got view %q cloud stack %q stack %q", m.ctx.StartView, m.ctx.CloudStack, m.ctx.StackName)
+	}
+}
+
+func TestCloudStackBrowser_SelfManagedBackend(t *testing.T) {
+	deps := newTestDependencies()
+	deps.WorkspaceReader.(*pulumi.FakeWorkspaceReader).WhoAmI = &pulumi.WhoAmIInfo{User: "me", URL: "s3://state-bucket"}
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+
+	msg := m.fetchCloudStacks()()
+	if sm, ok := msg.(cloudStacksMsg); !ok || sm.Err == nil || !strings.Contains(sm.Err.Error(), "s3://state-bucket") {
+		t.Fatalf("expected an error naming the self-managed backend, got %+v", msg)
+	}
+	if calls := len(deps.StackReader.(*pulumi.FakeStackReader).Calls.GetCloudStacks); calls != 0 {
+		t.Errorf("expected no stack list on a self-managed backend, got %d calls", calls)
+	}
+}
+
+func TestFooterHints(t *testing.T) {
+	deps := newTestDependencies()
+	provider := deps.PluginProvider.(*plugins.FakePluginProvider)
+	provider.HasResourceOpenersFunc = func() bool { return true }
+
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, deps)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 40})
+	m = result.(Model)
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{{
+		URN:       "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs",
+		Type:      "aws:s3/bucket:Bucket",
+		Name:      "logs",
+		Protected: true,
+	}})
+
+	// The selected resource decides which actions are offered
+	footer := m.renderFooter()
+	for _, want := range []string{"u up", "z check", "unprotect", "o open", "? help", "q quit"} {
+		if !strings.Contains(footer, want) {
+			t.Errorf("expected the stack view footer to contain %q, got %q", want, footer)
+		}
+	}
+	if strings.Contains(footer, "ctrl+u execute") || strings.Contains(footer, "p protect") {
+		t.Errorf("expected no actions that aren't valid in stack view, got %q", footer)
+	}
+
+	// Hints that don't fit are dropped, and the footer says there are more
+	result, _ = m.Update(tea.WindowSizeMsg{Width: 60, Height: 40})
+	m = result.(Model)
+	footer = m.renderFooter()
+	if lipgloss.Height(footer) != 1 || lipgloss.Width(footer) > 60 {
+		t.Errorf("expected a single line footer within the width, got %q", footer)
+	}
+	if !strings.Contains(footer, ". more") || !strings.Contains(footer, "q quit") {
+		t.Errorf("expected the footer to hint at more keys, got %q", footer)
+	}
+
+	// Expanded, every valid action is described over several lines
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(".")})
+	m = result.(Model)
+	footer = m.renderFooter()
+	if lipgloss.Height(footer) < 2 {
+		t.Errorf("expected the expanded footer to take several lines, got %q", footer)
+	}
+	for _, want := range []string{"preview up", "open resource", "fewer keys"} {
+		if !strings.Contains(footer, want) {
+			t.Errorf("expected the expanded footer to contain %q, got %q", want, footer)
+		}
+	}
+	if !strings.Contains(m.View(), "preview up") {
+		t.Error("expected the expanded footer to be rendered")
+	}
+}
+
+func TestUndoFlags(t *testing.T) {
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Type: "aws:s3/bucket:Bucket", Name: "logs"},
+	})
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	m = result.(Model)
+	if len(m.state.Flags) != 1 {
+		t.Fatalf("expected the resource to be targeted, got %v", m.state.Flags)
+	}
+	if !strings.Contains(m.renderFooter(), "ctrl+z undo") {
+		t.Errorf("expected the footer to offer undo, got %q", m.renderFooter())
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	m = result.(Model)
+	if len(m.state.Flags) != 0 {
+		t.Errorf("expected the target to be undone, got %v", m.state.Flags)
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlY})
+	m = result.(Model)
+	if !m.state.Flags["urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"].Target {
+		t.Errorf("expected the target to be redone, got %v", m.state.Flags)
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	m = result.(Model)
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	m = result.(Model)
+	if !strings.Contains(m.ui.Toast.View(120), "Nothing to undo") {
+		t.Errorf("expected a toast saying there is nothing to undo, got %q", m.ui.Toast.View(120))
+	}
+}
+
+func TestTargetSets(t *testing.T) {
+	workDir := t.TempDir()
+	m := initialModel(context.Background(), AppContext{WorkDir: workDir, StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets", Type: "aws:s3/bucket:Bucket", Name: "assets"},
+		{URN: "urn:pulumi:dev::app::aws:rds/instance:Instance::db", Type: "aws:rds/instance:Instance", Name: "db"},
+	})
+	press := func(msg tea.KeyMsg) {
+		t.Helper()
+		result, cmd := m.handleKeyPress(msg)
+		m = result.(Model)
+		for _, msg := range runCmds(cmd) {
+			result, _ = m.Update(msg)
+			m = result.(Model)
+		}
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// Save the target on assets as a set
+	m.ui.ResourceList.SelectURN("urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets")
+	press(runes("T"))
+	press(runes("m"))
+	if m.ui.Focus.Current() != ui.FocusTargetSetSelector {
+		t.Fatalf("expected the target set selector to have focus, got %s", m.ui.Focus.Current())
+	}
+	if !m.ui.TargetSetSelector.IsNewSelected() {
+		t.Fatal("expected the option to save the current flags to be listed")
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	press(runes("frontend"))
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.ui.TargetSetModal.Visible() {
+		t.Fatal("expected the name prompt to close once the set is saved")
+	}
+	sets, err := plugins.LoadTargetSets(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sets["frontend"].Target; !slices.Equal(got, []string{"app::aws:s3/bucket:Bucket::assets"}) {
+		t.Fatalf("expected the set to target assets without the stack, got %+v", sets)
+	}
+
+	// Applying the set replaces the current flags
+	press(runes("C"))
+	m.ui.ResourceList.SelectURN("urn:pulumi:dev::app::aws:rds/instance:Instance::db")
+	press(runes("E"))
+	press(runes("m"))
+	if m.ui.TargetSetSelector.SelectedTargetSet() != "frontend" {
+		t.Fatalf("expected the saved set to be listed, got %q", m.ui.TargetSetSelector.SelectedTargetSet())
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	want := map[string]ui.ResourceFlags{"urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets": {Target: true}}
+	if !maps.Equal(m.state.Flags, want) {
+		t.Errorf("expected the flags of the set, got %v", m.state.Flags)
+	}
+	if !strings.Contains(m.ui.Toast.View(120), "Applied target set frontend") {
+		t.Errorf("expected a toast naming the applied set, got %q", m.ui.Toast.View(120))
+	}
+
+	// Like any flag change, it can be undone
+	press(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if !m.state.Flags["urn:pulumi:dev::app::aws:rds/instance:Instance::db"].Exclude || len(m.state.Flags) != 1 {
+		t.Errorf("expected the previous flags back, got %v", m.state.Flags)
+	}
+}
+
+func TestCycleGrouping(t *testing.T) {
+	m := initialModel(context.Background(), AppContext{WorkDir: "/fake/path", StackName: "dev"}, newTestDependencies())
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	m.ui.ResourceList.SetItems([]ui.ResourceItem{
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Type: "aws:s3/bucket:Bucket", Name: "logs"},
+	})
+
+	ctrlG := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g"), Alt: true}
+	wants := []struct {
+		grouping ui.ResourceGrouping
+		toast    string
+	}{
+		{ui.GroupByType, "Grouped by type"},
+		{ui.GroupByProvider, "Grouped by provider"},
+		{ui.GroupByTree, "Showing resource tree"},
+	}
+	for _, want := range wants {
+		result, _ = m.handleKeyPress(ctrlG)
+		m = result.(Model)
+		if got := m.ui.ResourceList.Grouping(); got != want.grouping {
+			t.Errorf("expected grouping %d, got %d", want.grouping, got)
+		}
+		if !strings.Contains(m.ui.Toast.View(120), want.toast) {
+			t.Errorf("expected a toast saying %q, got %q", want.toast, m.ui.Toast.View(120))
+		}
+		if grouped := want.grouping != ui.GroupByTree; strings.Contains(m.renderFooter(), "tab collapse") != grouped {
+			t.Errorf("expected the footer to offer collapsing only when grouped, got %q", m.renderFooter())
+		}
+	}
+}
+
+// previewEvents returns a closed channel of preview events, for a fake preview
+func previewEvents(events ...pulumi.PreviewEvent) <-chan pulumi.PreviewEvent {
+	ch := make(chan pulumi.PreviewEvent, len(events))
+	for _, event := range events {
+		ch <- event
+	}
+	close(ch)
+	return ch
+}
+
+// TestCollectPreview verifies a preview run without the TUI collects its steps
+// and warnings through the preview view's event processing, once each.
+func TestCollectPreview(t *testing.T) {
+	warning := pulumi.PreviewWarning{
+		Severity: pulumi.WarningSeverityError,
+		URN:      "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs",
+		Message:  "buckets must be private",
+		Policy:   "security/private-buckets",
+	}
+	report := collectPreview(previewEvents(
+		pulumi.PreviewEvent{Step: &pulumi.PreviewStep{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Op: pulumi.OpCreate, Type: "aws:s3/bucket:Bucket", Name: "logs"}},
+		pulumi.PreviewEvent{Warning: &warning},
+		pulumi.PreviewEvent{Warning: &warning},
+		pulumi.PreviewEvent{Step: &pulumi.PreviewStep{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::old", Op: pulumi.OpDelete, Type: "aws:s3/bucket:Bucket", Name: "old"}},
+		pulumi.PreviewEvent{Done: true},
+	))
+
+	if report.Err != nil {
+		t.Fatalf("unexpected error: %v", report.Err)
+	}
+	if len(report.Items) != 2 {
+		t.Fatalf("expected 2 resources, got %d", len(report.Items))
+	}
+	if len(report.Warnings) != 1 {
+		t.Errorf("expected the repeated warning once, got %d", len(report.Warnings))
+	}
+
+	failed := collectPreview(previewEvents(pulumi.PreviewEvent{Error: errors.New("program crashed")}))
+	if failed.Err == nil || !strings.Contains(failed.Err.Error(), "program crashed") {
+		t.Errorf("expected the preview error, got %v", failed.Err)
+	}
+}
+
+// TestWriteGitHubReport verifies the GitHub report appends the summary to the job
+// summary file and annotates warnings, deletes, replacements and failures.
+func TestWriteGitHubReport(t *testing.T) {
+	report := PreviewReport{
+		StackName: "dev",
+		Operation: pulumi.OperationUp,
+		Items: []ui.ResourceItem{
+			{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::old", Name: "old", Type: "aws:s3/bucket:Bucket", Op: ui.OpDelete},
+			{URN: "urn:pulumi:dev::app::aws:ec2/instance:Instance::web", Name: "web", Type: "aws:ec2/instance:Instance", Op: ui.OpReplace, ReplaceKeys: []string{"ami"}},
+			{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Name: "logs", Type: "aws:s3/bucket:Bucket", Op: ui.OpCreate},
+		},
+		Warnings: []pulumi.PreviewWarning{{
+			Severity: pulumi.WarningSeverityError,
+			URN:      "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs",
+			Message:  "100% of buckets\nmust be private",
+			Policy:   "security/private-buckets",
+		}},
+		Err: errors.New("preview failed"),
+	}
+	summaryPath := filepath.Join(t.TempDir(), "summary.md")
+	if err := os.WriteFile(summaryPath, []byte("earlier step\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := writeGitHubReport(report, nil, summaryPath, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	summary, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"earlier step\n### Pulumi up preview of `dev`", "#### Warnings", "#### Preview failed"} {
+		if !strings.Contains(string(summary), want) {
+			t.Errorf("expected the job summary to contain %q, got:\n%s", want, summary)
+		}
+	}
+
+	wantAnnotations := []string{
+		"::error title=Policy security/private-buckets::logs: 100%25 of buckets%0Amust be private",
+		"::warning title=Resource will be deleted::old (aws:s3/bucket:Bucket)",
+		"::warning title=Resource will be replaced::web (aws:ec2/instance:Instance) forced by ami",
+		"::error title=Preview failed::preview failed",
+	}
+	if got := strings.Split(strings.TrimSpace(out.String()), "\n"); !slices.Equal(got, wantAnnotations) {
+		t.Errorf("expected annotations:\n%s\ngot:\n%s", strings.Join(wantAnnotations, "\n"), out.String())
+	}
+}
+
+// TestRunPreviewReport verifies `p5 preview` runs the requested preview and
+// rejects unknown formats and operations before running anything.
+func TestRunPreviewReport(t *testing.T) {
+	deps := newTestDependencies()
+	deps.WorkspaceReader = &pulumi.FakeWorkspaceReader{
+		ProjectInfo: &pulumi.ProjectInfo{ProgramName: "app", StackName: "dev"},
+	}
+	operator := &pulumi.FakeStackOperator{}
+	deps.StackOperator = operator
+	appCtx := AppContext{WorkDir: t.TempDir()}
+
+	for _, args := range [][]string{{"--format=json"}, {"import"}, {"up", "destroy"}, {"destroy", "--format=json"}} {
+		if code, err := runPreviewReport(context.Background(), appCtx, deps, args); code != 1 || err == nil {
+			t.Errorf("expected %v to fail, got code %d and error %v", args, code, err)
+		}
+	}
+	if len(operator.Calls.Preview) != 0 {
+		t.Fatalf("expected no preview for invalid arguments, got %d", len(operator.Calls.Preview))
+	}
+
+	t.Setenv("GITHUB_STEP_SUMMARY", filepath.Join(t.TempDir(), "summary.md"))
+	code, err := runPreviewReport(context.Background(), appCtx, deps, []string{"destroy"})
+	if code != 0 || err != nil {
+		t.Fatalf("expected success, got code %d and error %v", code, err)
+	}
+	if len(operator.Calls.Preview) != 1 || operator.Calls.Preview[0].OpType != pulumi.OperationDestroy {
+		t.Errorf("expected one destroy preview of the stack, got %+v", operator.Calls.Preview)
+	}
+
+	// Flags may follow the operation, as in `p5 preview refresh --format=github`
+	code, err = runPreviewReport(context.Background(), appCtx, deps, []string{"refresh", "--format=github"})
+	if code != 0 || err != nil {
+		t.Fatalf("expected a flag after the operation to parse, got code %d and error %v", code, err)
+	}
+	if len(operator.Calls.Preview) != 2 || operator.Calls.Preview[1].OpType != pulumi.OperationRefresh {
+		t.Errorf("expected a refresh preview, got %+v", operator.Calls.Preview)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NotesDir is where resource notes are saved, relative to the project directory
+const NotesDir = ".p5/notes"
+
+// savedNote is the on-disk form of a resource's note
+type savedNote struct {
+	URN  string `json:"urn"`
+	Note string `json:"note"`
+}
+
+// noteFile returns the file holding a resource's note. URNs contain characters
+// that are not valid in file names, so files are named by the URN's hash.
+func noteFile(workDir, urn string) string {
+	sum := sha256.Sum256([]byte(urn))
+	return filepath.Join(workDir, NotesDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// LoadNotes returns the notes saved in a project keyed by URN, or nil if none were saved
+func LoadNotes(workDir string) (map[string]string, error) {
+	entries, err := os.ReadDir(filepath.Join(workDir, NotesDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notes: %w", err)
+	}
+
+	notes := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(workDir, NotesDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read note: %w", err)
+		}
+		var note savedNote
+		if err := json.Unmarshal(data, &note); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(NotesDir, entry.Name()), err)
+		}
+		if note.URN != "" && note.Note != "" {
+			notes[note.URN] = note.Note
+		}
+	}
+	return notes, nil
+}
+
+// SaveNote saves the note of a resource. Saving an empty note removes it.
+func SaveNote(workDir, urn, note string) error {
+	path := noteFile(workDir, urn)
+	if note == "" {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove note: %w", err)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(savedNote{URN: urn, Note: note}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode note: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", NotesDir, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write note: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/pkg/browser"
+
+	"github.com/rfhold/p5/internal/plugins/proto"
+	"github.com/rfhold/p5/internal/pulumi"
+)
+
+// runOpen implements `p5 open <resource-name-or-urn>`. It resolves the resource in
+// the stack and runs the plugin-provided open action without starting the TUI.
+func runOpen(ctx context.Context, appCtx AppContext, deps *Dependencies, query string) error {
+	if deps.PluginProvider != nil {
+		defer deps.PluginProvider.Close(ctx)
+	}
+	action, err := resolveOpenAction(ctx, appCtx, deps, query, os.Stderr)
+	if err != nil {
+		return err
+	}
+	return runOpenAction(ctx, action, openActionEnv(deps, action))
+}
+
+// resolveOpenAction authenticates plugins, loads the stack's resources and asks
+// plugins for an action to open the resource matching query. Non-fatal plugin
+// problems are reported to warnings.
+func resolveOpenAction(ctx context.Context, appCtx AppContext, deps *Dependencies, query string, warnings io.Writer) (*proto.OpenAction, error) {
+	if deps.PluginProvider == nil {
+		return nil, errors.New("plugins are not available")
+	}
+
+	stackName, env, err := authenticateStack(ctx, appCtx, deps, warnings)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := pulumi.ReadOptions{Env: env}
+	resources, err := deps.StackReader.GetResources(ctx, appCtx.WorkDir, stackName, opts)
+	if err != nil {
+		return nil, err
+	}
+	res, err := ResolveResource(resources, query)
+	if err != nil {
+		return nil, err
+	}
+
+	req := buildOpenResourceRequest(res.Type, res.Name, res.URN, res.Provider, res.Inputs, res.Outputs, res.ProviderInputs)
+	resp, _, err := deps.PluginProvider.OpenResource(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("open resource failed: %w", err)
+	}
+	switch {
+	case resp == nil, !resp.CanOpen:
+		return nil, fmt.Errorf("no plugin can open resource type %s", res.Type)
+	case resp.Error != "":
+		return nil, fmt.Errorf("open resource failed: %s", resp.Error)
+	case resp.Action == nil:
+		return nil, errors.New("plugin returned no action")
+	}
+	return resp.Action, nil
+}
+
+// authenticateStack resolves the selected stack, authenticates plugins for it and
+// returns the env to run Pulumi with, matching what the TUI does on stack selection.
+// Non-fatal plugin problems are reported to warnings.
+func authenticateStack(ctx context.Context, appCtx AppContext, deps *Dependencies, warnings io.Writer) (string, map[string]string, error) {
+	info, err := deps.WorkspaceReader.GetProjectInfo(ctx, appCtx.WorkDir, appCtx.StackName, pulumi.ReadOptions{Env: deps.Env})
+	if err != nil {
+		return "", nil, err
+	}
+	if info == nil || info.StackName == "" {
+		return "", nil, errors.New("no stack selected, use --stack")
+	}
+	if deps.PluginProvider == nil {
+		return info.StackName, deps.Env, nil
+	}
+
+	results, err := deps.PluginProvider.Initialize(ctx, appCtx.WorkDir, info.ProgramName, info.StackName)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load plugins: %w", err)
+	}
+	for _, r := range results {
+		if r.Error != nil {
+			fmt.Fprintf(warnings, "Warning: plugin %s failed to authenticate: %v\n", r.PluginName, r.Error)
+		}
+	}
+	shareStackEnvironments(ctx, appCtx.WorkDir, info.StackName, deps, warnings)
+	deps.PluginProvider.ApplyEnvToProcess()
+	return info.StackName, mergeEnvMaps(deps.Env, deps.PluginProvider.GetAllEnv()), nil
+}
+
+// shareStackEnvironments resolves the stack's ESC environments and passes their
+// environment variables to plugins, matching what the TUI does on stack selection
+func shareStackEnvironments(ctx context.Context, workDir, stackName string, deps *Dependencies, warnings io.Writer) {
+	if deps.EnvironmentReader == nil {
+		return
+	}
+	names, err := deps.EnvironmentReader.ListEnvironments(workDir, stackName)
+	if err != nil {
+		fmt.Fprintf(warnings, "Warning: %v\n", err)
+		return
+	}
+	envs := make([]*pulumi.StackEnvironment, 0, len(names))
+	for _, name := range names {
+		env, err := deps.EnvironmentReader.OpenEnvironment(ctx, workDir, name, pulumi.ReadOptions{Env: deps.Env})
+		if err != nil {
+			fmt.Fprintf(warnings, "Warning: %v\n", err)
+			return
+		}
+		envs = append(envs, env)
+	}
+	deps.PluginProvider.SetEnvironmentEnv(MergeEnvironmentVariables(envs))
+}
+
+// openActionEnv returns the env vars an exec open action runs with: the workspace's,
+// then the plugins' auth env, then the action's own
+func openActionEnv(deps *Dependencies, action *proto.OpenAction) map[string]string {
+	var pluginEnv map[string]string
+	if deps.PluginProvider != nil {
+		pluginEnv = deps.PluginProvider.GetAllEnv()
+	}
+	return mergeEnvMaps(deps.Env, pluginEnv, action.Env)
+}
+
+// runOpenAction performs a plugin open action, attaching exec actions to the terminal
+func runOpenAction(ctx context.Context, action *proto.OpenAction, env map[string]string) error {
+	switch action.Type {
+	case proto.OpenActionType_OPEN_ACTION_TYPE_BROWSER:
+		fmt.Fprintf(os.Stderr, "Opening %s\n", action.Url)
+		if err := browser.OpenURL(action.Url); err != nil {
+			return fmt.Errorf("failed to open browser: %w", err)
+		}
+		return nil
+	case proto.OpenActionType_OPEN_ACTION_TYPE_EXEC:
+		cmd := exec.CommandContext(ctx, action.Command, action.Args...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if len(env) > 0 {
+			cmd.Env = append(cmd.Environ(), mapToEnvSlice(env)...)
+		}
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("program exited with error: %w", err)
+		}
+		return nil
+	default:
+		return errors.New("unknown open action type")
+	}
+}
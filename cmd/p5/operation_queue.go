@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/pulumi"
+)
+
+// QueuedStep is one operation in an operation queue
+type QueuedStep struct {
+	Op      pulumi.OperationType
+	Execute bool // Run the operation; otherwise only preview it
+	Approve bool // Wait for the user to confirm the step before it starts
+
+	// URNs the step targets, replaces or excludes in place of the selected flags
+	Targets  []string
+	Replaces []string
+	Excludes []string
+}
+
+// Label names the step for display
+func (s QueuedStep) Label() string {
+	if s.Execute {
+		return s.Op.String()
+	}
+	return i18n.Tf("Preview %s", s.Op.String())
+}
+
+// HasFlags reports whether the step replaces the selected flags with its own
+func (s QueuedStep) HasFlags() bool {
+	return len(s.Targets) > 0 || len(s.Replaces) > 0 || len(s.Excludes) > 0
+}
+
+// OperationQueue runs a chain of operations one after another. Steps that
+// need approval wait for the user to confirm them before they start.
+type OperationQueue struct {
+	Name     string // Workflow the queue runs, empty for the built-in queue
+	Steps    []QueuedStep
+	Current  int  // Index of the running step, or of the next one while awaiting confirmation
+	Awaiting bool // The step at Current has not started yet
+}
+
+// NewRefreshUpQueue returns a queue that refreshes, previews an update and then
+// runs it, confirming every step
+func NewRefreshUpQueue() *OperationQueue {
+	return &OperationQueue{
+		Steps: []QueuedStep{
+			{Op: pulumi.OperationRefresh, Execute: true, Approve: true},
+			{Op: pulumi.OperationUp, Approve: true},
+			{Op: pulumi.OperationUp, Execute: true, Approve: true},
+		},
+		Awaiting: true,
+	}
+}
+
+// NewWorkflowQueue returns a queue running the steps of a workflow from p5.toml.
+// Resource names in step flags are resolved to URNs using the stack's resources.
+func NewWorkflowQueue(name string, workflow plugins.WorkflowConfig, resources []pulumi.ResourceInfo) (*OperationQueue, error) {
+	if err := workflow.Validate(); err != nil {
+		return nil, fmt.Errorf("workflow %q: %w", name, err)
+	}
+
+	q := &OperationQueue{Name: name, Awaiting: true}
+	for i, config := range workflow.Steps {
+		step := QueuedStep{
+			Op:      parseWorkflowOp(config.Op),
+			Execute: !config.Preview,
+			Approve: config.Approve,
+		}
+		var err error
+		if step.Targets, err = resolveStepFlags(resources, config.Targets); err != nil {
+			return nil, fmt.Errorf("workflow %q step %d: %w", name, i+1, err)
+		}
+		if step.Replaces, err = resolveStepFlags(resources, config.Replaces); err != nil {
+			return nil, fmt.Errorf("workflow %q step %d: %w", name, i+1, err)
+		}
+		if step.Excludes, err = resolveStepFlags(resources, config.Excludes); err != nil {
+			return nil, fmt.Errorf("workflow %q step %d: %w", name, i+1, err)
+		}
+		q.Steps = append(q.Steps, step)
+	}
+	return q, nil
+}
+
+// parseWorkflowOp converts a validated workflow op to its operation type
+func parseWorkflowOp(op string) pulumi.OperationType {
+	switch op {
+	case "refresh":
+		return pulumi.OperationRefresh
+	case "destroy":
+		return pulumi.OperationDestroy
+	default:
+		return pulumi.OperationUp
+	}
+}
+
+// resolveStepFlags resolves resource names or URNs to URNs
+func resolveStepFlags(resources []pulumi.ResourceInfo, queries []string) ([]string, error) {
+	var urns []string
+	for _, query := range queries {
+		resource, err := ResolveResource(resources, query)
+		if err != nil {
+			return nil, err
+		}
+		urns = append(urns, resource.URN)
+	}
+	return urns, nil
+}
+
+// Step returns the running step, or the next one while awaiting confirmation
+func (q *OperationQueue) Step() QueuedStep {
+	return q.Steps[q.Current]
+}
+
+// Start marks the step at Current as running and returns it
+func (q *OperationQueue) Start() QueuedStep {
+	q.Awaiting = false
+	return q.Step()
+}
+
+// Running reports whether the given preview or execution is the running step
+func (q *OperationQueue) Running(op pulumi.OperationType, execute bool) bool {
+	return q != nil && !q.Awaiting && q.Step().Op == op && q.Step().Execute == execute
+}
+
+// Advance finishes the running step and moves to the next, which has not started yet.
+// Returns false when no steps are left.
+func (q *OperationQueue) Advance() bool {
+	q.Current++
+	q.Awaiting = q.Current < len(q.Steps)
+	return q.Awaiting
+}
+
+// Summary lists the steps in order, e.g. "Refresh → Preview Up → Up"
+func (q *OperationQueue) Summary() string {
+	labels := make([]string, len(q.Steps))
+	for i, step := range q.Steps {
+		labels[i] = step.Label()
+	}
+	return strings.Join(labels, " → ")
+}
@@ -30,6 +30,9 @@ func (m *Model) executePendingOp(op PendingOperation) tea.Cmd {
 		return m.loadStackResources()
 	case "init_load_resources":
 		return m.initLoadStackResources()
+	case "reload_plugins":
+		// Plugins were loaded by the auth itself; nothing else to run
+		return nil
 	default:
 		return nil
 	}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// PinnedFile is where pinned resources are saved, relative to the project directory
+const PinnedFile = ".p5/pinned.json"
+
+// savedPins is the on-disk form of the pinned resources of a project. URNs
+// include the stack, so each stack has its own pins.
+type savedPins struct {
+	URNs []string `json:"urns"`
+}
+
+// LoadPinned returns the URNs of the resources pinned in a project, or nil if none were pinned
+func LoadPinned(workDir string) (map[string]bool, error) {
+	data, err := os.ReadFile(filepath.Join(workDir, PinnedFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pinned resources: %w", err)
+	}
+	var saved savedPins
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", PinnedFile, err)
+	}
+	pinned := make(map[string]bool, len(saved.URNs))
+	for _, urn := range saved.URNs {
+		pinned[urn] = true
+	}
+	return pinned, nil
+}
+
+// SavePinned replaces the pinned resources of a project. Saving no pins removes the file.
+func SavePinned(workDir string, pinned map[string]bool) error {
+	path := filepath.Join(workDir, PinnedFile)
+	saved := savedPins{URNs: make([]string, 0, len(pinned))}
+	for urn, ok := range pinned {
+		if ok {
+			saved.URNs = append(saved.URNs, urn)
+		}
+	}
+	if len(saved.URNs) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove pinned resources: %w", err)
+		}
+		return nil
+	}
+	slices.Sort(saved.URNs)
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode pinned resources: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(PinnedFile), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write pinned resources: %w", err)
+	}
+	return nil
+}
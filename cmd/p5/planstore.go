@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PlansDir is where update plans are saved, relative to the project directory
+const PlansDir = ".p5/plans"
+
+// StatesDir is where stack state is exported by default, relative to the project directory
+const StatesDir = ".p5/state"
+
+// ExportsDir is where the resource list is exported by default, relative to the project directory
+const ExportsDir = ".p5/exports"
+
+// planTimeFormat prefixes plan file names with the time they were saved
+const planTimeFormat = "20060102-150405"
+
+// PlanFile returns a new timestamped plan file for the stack
+func PlanFile(workDir, stackName string, at time.Time) string {
+	stack := strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(stackName)
+	return filepath.Join(workDir, PlansDir, fmt.Sprintf("%s-%s.json", at.Format(planTimeFormat), stack))
+}
+
+// RemovePlans deletes the plans saved for the stack other than keep. They can't be
+// applied once the stack was updated, and a newer plan replaces them.
+func RemovePlans(workDir, stackName, keep string) error {
+	dir := filepath.Join(workDir, PlansDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	stack := strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(stackName)
+	for _, entry := range entries {
+		name := entry.Name()
+		if len(name) <= len(planTimeFormat)+1 || name[len(planTimeFormat)+1:] != stack+".json" {
+			continue
+		}
+		if p := filepath.Join(dir, name); p != keep {
+			if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// StateExportFile returns a new timestamped file, relative to the project directory,
+// to export the stack's state to
+func StateExportFile(stackName string, at time.Time) string {
+	stack := strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(stackName)
+	return filepath.Join(StatesDir, fmt.Sprintf("%s-%s.json", at.Format("20060102-150405"), stack))
+}
+
+// ResourceExportFile returns a new timestamped CSV file, relative to the project
+// directory, to export the resource list to
+func ResourceExportFile(stackName string, at time.Time) string {
+	stack := strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(stackName)
+	return filepath.Join(ExportsDir, fmt.Sprintf("%s-%s.csv", at.Format("20060102-150405"), stack))
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// fetchPluginIndex fetches the plugin index configured for the workspace
+func (m *Model) fetchPluginIndex() tea.Cmd {
+	ctx := m.appCtx
+	workDir := m.ctx.WorkDir
+	installer := m.deps.PluginInstaller
+
+	return func() tea.Msg {
+		index, err := installer.FetchIndex(ctx, workDir)
+		return pluginIndexMsg{Index: index, Err: err}
+	}
+}
+
+// installPlugin installs a plugin from the index and adds it to p5.toml
+func (m *Model) installPlugin(entry plugins.IndexEntry) tea.Cmd {
+	ctx := m.appCtx
+	workDir := m.ctx.WorkDir
+	installer := m.deps.PluginInstaller
+
+	return func() tea.Msg {
+		result, err := installer.Install(ctx, workDir, entry)
+		return pluginInstalledMsg{Name: entry.Name, Result: result, Err: err}
+	}
+}
+
+// handlePluginIndex lists the fetched index, marking plugins that are already configured
+func (m Model) handlePluginIndex(msg pluginIndexMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if msg.Err != nil {
+		m.ui.PluginIndexModal.SetError(msg.Err)
+		return m, nil
+	}
+
+	var configured map[string]plugins.PluginConfig
+	if m.deps.PluginProvider != nil {
+		if config := m.deps.PluginProvider.GetMergedConfig(); config != nil {
+			configured = config.Plugins
+		}
+	}
+
+	m.state.PluginIndex = msg.Index.Plugins
+	entries := make([]ui.PluginIndexEntry, len(msg.Index.Plugins))
+	for i, plugin := range msg.Index.Plugins {
+		_, installed := configured[plugin.Name]
+		entries[i] = ui.PluginIndexEntry{
+			Name:         plugin.Name,
+			Description:  plugin.Description,
+			Package:      plugin.Package,
+			Homepage:     plugin.Homepage,
+			Capabilities: plugin.Capabilities,
+			Installed:    installed,
+		}
+	}
+	m.ui.PluginIndexModal.SetEntries(entries)
+	return m, nil
+}
+
+// handlePluginInstalled reports an install and reloads plugins so the new one is
+// authenticated right away
+func (m Model) handlePluginInstalled(msg pluginInstalledMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		if m.ui.PluginIndexModal.Visible() {
+			m.ui.PluginIndexModal.SetError(msg.Err)
+			return m, nil
+		}
+		return m, m.ui.Toast.Show(i18n.Tf("Plugin install failed: %v", msg.Err))
+	}
+
+	m.ui.PluginIndexModal.SetInstalled(msg.Name)
+	cmds := []tea.Cmd{m.ui.Toast.Show(i18n.Tf("Installed %s, added to %s", msg.Name, msg.Result.ConfigPath))}
+	if m.state.InitState == InitComplete && m.ctx.StackName != "" && !m.state.IsBusy() {
+		cmds = append(cmds, m.authenticatePluginsWithLock(PendingOperation{Type: "reload_plugins"}))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// updatePluginIndexModal handles keys when the plugin index modal has focus
+func (m Model) updatePluginIndexModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.PluginIndexModal.Update(msg)
+	switch action {
+	case ui.PluginIndexActionInstall:
+		selected := m.ui.PluginIndexModal.SelectedEntry()
+		for _, entry := range m.state.PluginIndex {
+			if entry.Name == selected.Name {
+				return m, m.installPlugin(entry)
+			}
+		}
+	case ui.PluginIndexActionCancel:
+		m.hidePluginIndexModal()
+	}
+	return m, cmd
+}
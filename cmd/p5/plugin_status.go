@@ -0,0 +1,70 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// pluginStatusEntries lists the authentication state of each loaded plugin
+func (m *Model) pluginStatusEntries() []ui.PluginStatusEntry {
+	summaries := m.deps.PluginProvider.GetCredentialsSummary()
+	entries := make([]ui.PluginStatusEntry, len(summaries))
+	for i, s := range summaries {
+		entries[i] = ui.PluginStatusEntry{
+			Name:       s.PluginName,
+			EnvVars:    s.EnvVars,
+			IssuedAt:   s.IssuedAt,
+			ExpiresAt:  s.ExpiresAt,
+			AlwaysCall: s.AlwaysCall,
+			Error:      s.Error,
+		}
+	}
+	return entries
+}
+
+// reauthenticatePlugin authenticates a single plugin again for the current stack
+func (m *Model) reauthenticatePlugin(name string) tea.Cmd {
+	pluginProvider := m.deps.PluginProvider
+	appCtx := m.appCtx
+
+	return func() tea.Msg {
+		return pluginReauthenticatedMsg(pluginProvider.ReauthenticatePlugin(appCtx, name))
+	}
+}
+
+// updatePluginStatusModal handles keys when the plugin status modal has focus
+func (m Model) updatePluginStatusModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.PluginStatusModal.Update(msg)
+	switch action {
+	case ui.PluginStatusActionReauth:
+		// Block while all plugins authenticate
+		if m.state.IsBusy() {
+			m.ui.PluginStatusModal.ClearAuthenticating()
+			return m, nil
+		}
+		return m, m.reauthenticatePlugin(m.ui.PluginStatusModal.SelectedEntry().Name)
+	case ui.PluginStatusActionCancel:
+		m.hidePluginStatusModal()
+	}
+	return m, cmd
+}
+
+// handlePluginReauthenticated applies a plugin's new credentials and reschedules
+// the credential refresh, as they expire at a different time
+func (m Model) handlePluginReauthenticated(msg pluginReauthenticatedMsg) (tea.Model, tea.Cmd) {
+	m.deps.PluginProvider.ApplyEnvToProcess()
+	if m.ui.PluginStatusModal.Visible() {
+		m.ui.PluginStatusModal.ClearAuthenticating()
+		m.ui.PluginStatusModal.SetEntries(m.pluginStatusEntries())
+	}
+
+	cmds := []tea.Cmd{m.scheduleCredentialRefresh(), m.refreshStatusBadges()}
+	if msg.Error != nil {
+		cmds = append(cmds, m.ui.Toast.Show(i18n.Tf("Failed to authenticate %s: %v", msg.PluginName, msg.Error)))
+	} else {
+		cmds = append(cmds, m.ui.Toast.Show(i18n.Tf("Authenticated %s", msg.PluginName)))
+	}
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// Output formats of `p5 preview`
+const (
+	// PreviewFormatGitHub appends a job summary to $GITHUB_STEP_SUMMARY and prints
+	// workflow commands annotating warnings, for GitHub Actions
+	PreviewFormatGitHub = "github"
+	// PreviewFormatMarkdown prints the markdown summary
+	PreviewFormatMarkdown = "markdown"
+)
+
+// PreviewReport is the outcome of a preview run without the TUI
+type PreviewReport struct {
+	StackName string
+	Operation pulumi.OperationType
+	Items     []ui.ResourceItem // Resources in the order the preview view lists them
+	Warnings  []pulumi.PreviewWarning
+	Err       error // Why the preview failed
+}
+
+// runPreviewReport implements `p5 preview [up|refresh|destroy] [--format=github]`.
+// It runs a preview without starting the TUI and reports its changes in format.
+func runPreviewReport(ctx context.Context, appCtx AppContext, deps *Dependencies, args []string) (int, error) {
+	fs := flag.NewFlagSet("preview", flag.ContinueOnError)
+	format := fs.String("format", PreviewFormatGitHub, "Report as `github` or markdown")
+	// Parsing stops at the operation, so flags after it are parsed again
+	var operation string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return 0, nil
+			}
+			return 1, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		if operation != "" {
+			return 1, errors.New("preview takes at most one operation")
+		}
+		operation, args = fs.Arg(0), fs.Args()[1:]
+	}
+	if *format != PreviewFormatGitHub && *format != PreviewFormatMarkdown {
+		return 1, fmt.Errorf("format must be %q or %q, got %q", PreviewFormatGitHub, PreviewFormatMarkdown, *format)
+	}
+	op := pulumi.OperationUp
+	switch operation {
+	case "", "up":
+	case "refresh":
+		op = pulumi.OperationRefresh
+	case "destroy":
+		op = pulumi.OperationDestroy
+	default:
+		return 1, fmt.Errorf("unknown operation %q, expected up, refresh or destroy", operation)
+	}
+
+	if deps.PluginProvider != nil {
+		defer deps.PluginProvider.Close(ctx)
+	}
+	stackName, env, err := authenticateStack(ctx, appCtx, deps, os.Stderr)
+	if err != nil {
+		return 1, err
+	}
+	rules, err := plugins.LoadDiffIgnore(appCtx.WorkDir)
+	if err != nil {
+		return 1, err
+	}
+
+	opts := pulumi.OperationOptions{Env: env, Refresh: op == pulumi.OperationUp && appCtx.RefreshOnUp}
+	report := collectPreview(deps.StackOperator.Preview(ctx, appCtx.WorkDir, stackName, op, opts))
+	report.StackName = stackName
+	report.Operation = op
+
+	if *format == PreviewFormatGitHub {
+		err = writeGitHubReport(report, rules, os.Getenv("GITHUB_STEP_SUMMARY"), os.Stdout)
+	} else {
+		_, err = io.WriteString(os.Stdout, previewReportMarkdown(report, rules))
+	}
+	if err != nil {
+		return 1, err
+	}
+	if report.Err != nil {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// collectPreview reads a preview's events until it is done, through the same event
+// processing and resource list as the preview view
+func collectPreview(ch <-chan pulumi.PreviewEvent) PreviewReport {
+	var report PreviewReport
+	list := ui.NewResourceList(make(map[string]ui.ResourceFlags))
+	state := OpStarting
+	for event := range ch {
+		result := ProcessPreviewEvent(event, state, InitComplete)
+		state = result.NewOpState
+		if result.HasError {
+			report.Err = result.Error
+			break
+		}
+		if event.Done {
+			break
+		}
+		// The engine repeats some diagnostics, keep one copy of each
+		if result.Warning != nil && !slices.Contains(report.Warnings, *result.Warning) {
+			report.Warnings = append(report.Warnings, *result.Warning)
+		}
+		if result.Item != nil {
+			list.AddItem(*result.Item)
+		}
+	}
+	report.Items = list.Items()
+	return report
+}
+
+// previewReportMarkdown formats the preview's changes like alt+m in the preview
+// view, followed by its warnings and why it failed
+func previewReportMarkdown(report PreviewReport, rules ui.DiffIgnoreRules) string {
+	var b strings.Builder
+	b.WriteString(ui.PreviewMarkdown(report.StackName, report.Operation, report.Items, rules))
+	if len(report.Warnings) > 0 {
+		b.WriteString("\n#### Warnings\n\n")
+		for _, w := range report.Warnings {
+			fmt.Fprintf(&b, "- **%s** %s\n", warningLevel(w), strings.ReplaceAll(warningText(w), "\n", " "))
+		}
+	}
+	if report.Err != nil {
+		fmt.Fprintf(&b, "\n#### Preview failed\n\n```\n%s\n```\n", report.Err)
+	}
+	return b.String()
+}
+
+// writeGitHubReport appends the preview's markdown summary to the job summary at
+// summaryPath, or prints it when unset, and prints workflow commands to out
+// annotating warnings, deletes and replacements
+func writeGitHubReport(report PreviewReport, rules ui.DiffIgnoreRules, summaryPath string, out io.Writer) error {
+	summary := previewReportMarkdown(report, rules)
+	if summaryPath == "" {
+		if _, err := io.WriteString(out, summary); err != nil {
+			return err
+		}
+	} else if err := appendFile(summaryPath, summary); err != nil {
+		return fmt.Errorf("failed to write job summary: %w", err)
+	}
+
+	for _, w := range report.Warnings {
+		title := "Pulumi"
+		if w.Policy != "" {
+			title = "Policy " + w.Policy
+		}
+		fmt.Fprintf(out, "::%s title=%s::%s\n", warningLevel(w), escapeProperty(title), escapeData(warningText(w)))
+	}
+	for _, item := range report.Items {
+		var title string
+		switch item.Op {
+		case ui.OpDelete:
+			title = "Resource will be deleted"
+		case ui.OpReplace, ui.OpCreateReplace, ui.OpDeleteReplace:
+			title = "Resource will be replaced"
+		default:
+			continue
+		}
+		message := fmt.Sprintf("%s (%s)", item.Name, item.Type)
+		if len(item.ReplaceKeys) > 0 {
+			message += " forced by " + strings.Join(item.ReplaceKeys, ", ")
+		}
+		fmt.Fprintf(out, "::warning title=%s::%s\n", escapeProperty(title), escapeData(message))
+	}
+	if report.Err != nil {
+		fmt.Fprintf(out, "::error title=Preview failed::%s\n", escapeData(report.Err.Error()))
+	}
+	return nil
+}
+
+// appendFile appends content to the file at path, creating it if needed
+func appendFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600) //nolint:gosec // G304: path set by the CI runner
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// warningLevel returns the workflow command level of a preview warning
+func warningLevel(w pulumi.PreviewWarning) string {
+	if w.Severity == pulumi.WarningSeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// warningText returns a preview warning's message, prefixed with the resource it
+// is about
+func warningText(w pulumi.PreviewWarning) string {
+	message := strings.TrimSpace(w.Message)
+	if w.URN != "" {
+		return pulumi.ExtractResourceName(w.URN) + ": " + message
+	}
+	return message
+}
+
+// escapeData escapes the message of a workflow command
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a property value of a workflow command
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
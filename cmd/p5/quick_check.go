@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// QuickCheck tracks a summary-only up preview run from stack view, which only
+// counts the pending changes instead of listing them
+type QuickCheck struct {
+	Ops    map[string]pulumi.ResourceOp // Latest operation of each resource, by URN
+	Done   bool
+	Failed bool
+}
+
+// Summary counts the pending changes found so far
+func (q *QuickCheck) Summary() ui.ResourceSummary {
+	summary := ui.ResourceSummary{}
+	for _, op := range q.Ops {
+		switch op {
+		case pulumi.OpSame:
+			summary.Same++
+		case pulumi.OpCreate:
+			summary.Create++
+		case pulumi.OpUpdate:
+			summary.Update++
+		case pulumi.OpDelete:
+			summary.Delete++
+		case pulumi.OpReplace, pulumi.OpCreateReplace, pulumi.OpDeleteReplace:
+			summary.Replace++
+		case pulumi.OpRefresh:
+			summary.Refresh++
+		}
+		summary.Total++
+	}
+	return summary
+}
+
+// headerQuickCheck returns the quick check as shown in the header
+func (q *QuickCheck) headerQuickCheck() *ui.QuickCheck {
+	return &ui.QuickCheck{Running: !q.Done, Failed: q.Failed, Summary: q.Summary()}
+}
+
+// startQuickCheck runs an up preview in the background, with the current flags,
+// whose changes are only counted in the header, leaving the stack view as is
+func (m *Model) startQuickCheck() tea.Cmd {
+	m.cancelQuickCheck()
+
+	opts := m.operationOptions()
+	opts.Env = mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())
+
+	checkCtx, cancel := context.WithCancel(m.appCtx)
+	m.quickCheckCancel = cancel
+	m.quickCheckCh = m.deps.StackOperator.Preview(checkCtx, m.ctx.WorkDir, m.ctx.StackName, pulumi.OperationUp, opts)
+	m.state.QuickCheck = &QuickCheck{Ops: make(map[string]pulumi.ResourceOp)}
+
+	// The header spinner only ticks while it has something loading
+	var tick tea.Cmd
+	if !m.ui.Header.IsLoading() {
+		tick = m.ui.Header.Spinner().Tick
+	}
+	m.ui.Header.SetQuickCheck(m.state.QuickCheck.headerQuickCheck())
+	return tea.Batch(tick, waitForQuickCheckEvent(m.quickCheckCh))
+}
+
+// cancelQuickCheck stops a running quick check and clears its result, as when
+// the stack changes
+func (m *Model) cancelQuickCheck() {
+	if m.quickCheckCancel != nil {
+		m.quickCheckCancel()
+		m.quickCheckCancel = nil
+	}
+	m.quickCheckCh = nil
+	m.state.QuickCheck = nil
+	m.ui.Header.SetQuickCheck(nil)
+}
+
+// waitForQuickCheckEvent waits for the next event of a quick check
+func waitForQuickCheckEvent(ch <-chan pulumi.PreviewEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-ch
+		if !ok {
+			event = pulumi.PreviewEvent{Done: true}
+		}
+		return quickCheckEventMsg{ch: ch, Event: event}
+	}
+}
+
+// handleQuickCheckEvent counts the changes of a quick check, announcing them
+// once it finishes
+func (m Model) handleQuickCheckEvent(msg quickCheckEventMsg) (tea.Model, tea.Cmd) {
+	// Drain the events of a check that was replaced or cancelled
+	if msg.ch != m.quickCheckCh || m.state.QuickCheck == nil {
+		if msg.Event.Done || msg.Event.Error != nil {
+			return m, nil
+		}
+		return m, waitForQuickCheckEvent(msg.ch)
+	}
+
+	check := m.state.QuickCheck
+	event := msg.Event
+	switch {
+	case event.Error != nil:
+		check.Done, check.Failed = true, true
+		m.quickCheckCancel = nil
+		m.ui.Header.SetQuickCheck(check.headerQuickCheck())
+		return m, m.ui.Toast.Show(i18n.Tf("Quick check failed: %v", event.Error))
+	case event.Done:
+		check.Done = true
+		m.quickCheckCancel = nil
+		m.ui.Header.SetQuickCheck(check.headerQuickCheck())
+		return m, m.ui.Toast.Show(quickCheckMessage(check.Summary()))
+	case event.Step != nil:
+		check.Ops[event.Step.URN] = event.Step.Op
+		m.ui.Header.SetQuickCheck(check.headerQuickCheck())
+	}
+	return m, waitForQuickCheckEvent(msg.ch)
+}
+
+// quickCheckMessage describes the pending changes of a finished quick check
+func quickCheckMessage(summary ui.ResourceSummary) string {
+	var parts []string
+	for _, count := range []struct {
+		n      int
+		format string
+	}{
+		{summary.Create, "%d to create"},
+		{summary.Update, "%d to update"},
+		{summary.Replace, "%d to replace"},
+		{summary.Delete, "%d to delete"},
+		{summary.Refresh, "%d to refresh"},
+	} {
+		if count.n > 0 {
+			parts = append(parts, i18n.Tf(count.format, count.n))
+		}
+	}
+	if len(parts) == 0 {
+		return i18n.T("No changes pending")
+	}
+	return i18n.Tf("Pending changes: %s", strings.Join(parts, ", "))
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"time"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// showResourceExport prompts for the file to export the listed resources to, as
+// CSV or JSON by its extension
+func (m *Model) showResourceExport() {
+	m.state.ExportingResources = true
+	m.ui.SaveFileModal.Show(
+		i18n.T("Export Resources"),
+		i18n.T("Export the listed resources to a .csv or .json file"),
+		ResourceExportFile(m.ctx.StackName, time.Now()),
+	)
+	m.ui.Focus.Push(ui.FocusSaveFileModal)
+}
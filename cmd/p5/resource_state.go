@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// editResourceState fetches the selected resource's state to edit it, or returns
+// false when the stack view has no resource selected
+func (m *Model) editResourceState() (tea.Cmd, bool) {
+	if m.ui.ViewMode != ui.ViewStack || m.state.OpState.IsActive() || m.state.IsBusy() ||
+		m.state.PendingResourceState != nil {
+		return nil, false
+	}
+	item := m.ui.ResourceList.SelectedItem()
+	if item == nil || item.URN == "" {
+		return nil, false
+	}
+
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	resourceImporter := m.deps.ResourceImporter
+	appCtx := m.appCtx
+	opts := m.resourceStateOptions()
+	urn, name := item.URN, item.Name
+
+	return func() tea.Msg {
+		state, err := resourceImporter.ResourceState(appCtx, workDir, stackName, urn, opts)
+		return resourceStateMsg{URN: urn, Name: name, State: state, Err: err}
+	}, true
+}
+
+// resourceStateOptions returns the state repair options with plugin env vars
+func (m *Model) resourceStateOptions() pulumi.StateRepairOptions {
+	var opts pulumi.StateRepairOptions
+	if m.deps != nil && m.deps.PluginProvider != nil {
+		opts.Env = m.deps.PluginProvider.GetAllEnv()
+	}
+	return opts
+}
+
+// handleResourceState writes the fetched state to a temporary file and opens it
+// in the user's editor, suspending the TUI
+func (m Model) handleResourceState(msg resourceStateMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to read resource state: %v", msg.Err))
+	}
+	f, err := os.CreateTemp("", "p5-state-*.json")
+	if err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to read resource state: %v", err))
+	}
+	_, err = f.Write(msg.State)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to read resource state: %v", err))
+	}
+	m.state.PendingResourceState = &PendingResourceState{
+		URN:      msg.URN,
+		Name:     msg.Name,
+		Path:     f.Name(),
+		Original: msg.State,
+	}
+	return m, m.openResourceStateEditor()
+}
+
+// openResourceStateEditor opens the pending resource state file in the editor
+func (m *Model) openResourceStateEditor() tea.Cmd {
+	return tea.ExecProcess(editorCommand(m.state.PendingResourceState.Path, 0), func(err error) tea.Msg {
+		return resourceStateEditedMsg{Err: err}
+	})
+}
+
+// handleResourceStateEdited validates the edited state once the editor exits and
+// asks to confirm writing it, or to edit again when it is invalid
+func (m Model) handleResourceStateEdited(msg resourceStateEditedMsg) (tea.Model, tea.Cmd) {
+	pending := m.state.PendingResourceState
+	if pending == nil {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.discardResourceState()
+		return m, m.ui.Toast.Show(i18n.Tf("Editor failed: %v", msg.Err))
+	}
+	edited, err := os.ReadFile(pending.Path)
+	if err != nil {
+		m.discardResourceState()
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to read edited state: %v", err))
+	}
+	if bytes.Equal(bytes.TrimSpace(edited), bytes.TrimSpace(pending.Original)) {
+		m.discardResourceState()
+		return m, m.ui.Toast.Show(i18n.T("No changes to resource state"))
+	}
+
+	pending.Edited = edited
+	if err := pulumi.ValidateResourceState(pending.Original, edited); err != nil {
+		pending.Invalid = true
+		m.ui.ConfirmModal.SetLabels(i18n.T("Discard"), i18n.T("Edit again"))
+		m.ui.ConfirmModal.SetKeys("n", "y")
+		m.ui.ConfirmModal.Show(
+			i18n.T("Invalid Resource State"),
+			i18n.Tf("The edited state of '%s' can't be written:\n\n%v", pending.Name, err),
+			"",
+		)
+		m.showConfirmModal()
+		return m, nil
+	}
+
+	pending.Invalid = false
+	m.ui.ConfirmModal.SetLabels(i18n.T("Cancel"), i18n.T("Write"))
+	m.ui.ConfirmModal.SetKeys("n", "y")
+	m.ui.ConfirmModal.Show(
+		i18n.T("Edit Resource State"),
+		i18n.Tf("Write the edited state of '%s' to %s?", pending.Name, m.ctx.StackName),
+		i18n.T("The state is imported as edited. Pulumi doesn't check it against the cloud resource."),
+	)
+	m.showConfirmModal()
+	return m, nil
+}
+
+// confirmResourceState writes the edited state, or reopens the editor when the
+// edit was invalid
+func (m *Model) confirmResourceState() tea.Cmd {
+	pending := m.state.PendingResourceState
+	if pending.Invalid {
+		return m.openResourceStateEditor()
+	}
+	m.discardResourceState()
+
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	resourceImporter := m.deps.ResourceImporter
+	appCtx := m.appCtx
+	opts := m.resourceStateOptions()
+
+	return tea.Batch(
+		m.ui.Toast.Show(i18n.T("Writing resource state...")),
+		func() tea.Msg {
+			err := resourceImporter.SetResourceState(appCtx, workDir, stackName, pending.URN, pending.Original, pending.Edited, opts)
+			return resourceStateWrittenMsg{Name: pending.Name, Err: err}
+		},
+	)
+}
+
+// discardResourceState removes the pending resource state and its temporary file
+func (m *Model) discardResourceState() {
+	if pending := m.state.PendingResourceState; pending != nil {
+		os.Remove(pending.Path)
+		m.state.PendingResourceState = nil
+	}
+}
+
+// handleResourceStateWritten reloads the stack once the edited state was written
+func (m Model) handleResourceStateWritten(msg resourceStateWrittenMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to write resource state: %v", msg.Err))
+	}
+	return m, tea.Batch(
+		m.ui.Toast.Show(i18n.Tf("Updated the state of '%s'", msg.Name)),
+		m.reloadStack(),
+	)
+}
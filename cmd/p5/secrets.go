@@ -0,0 +1,66 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// revealSecret decrypts a secret of the resource in the details panel. With
+// several hidden secrets the user picks one first.
+func (m *Model) revealSecret() tea.Cmd {
+	item := m.ui.ResourceList.SelectedItem()
+	paths := m.ui.Details.HiddenSecrets()
+	if item == nil || len(paths) == 0 {
+		return m.ui.Toast.Show(i18n.T("No secrets to reveal"))
+	}
+	if len(paths) == 1 {
+		return m.fetchSecret(item.URN, paths[0])
+	}
+	m.showSecretSelector(item.URN, paths)
+	return nil
+}
+
+// fetchSecret decrypts the secret at path of the resource with urn from the
+// stack's state
+func (m *Model) fetchSecret(urn, path string) tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	stackReader := m.deps.StackReader
+	appCtx := m.appCtx
+	opts := pulumi.ReadOptions{Env: mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())}
+
+	return func() tea.Msg {
+		value, err := stackReader.RevealSecret(appCtx, workDir, stackName, urn, path, opts)
+		return secretRevealedMsg{URN: urn, Path: path, Value: value, Err: err}
+	}
+}
+
+// handleSecretRevealed shows a decrypted secret in the details panel
+func (m Model) handleSecretRevealed(msg secretRevealedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to reveal secret: %v", msg.Err))
+	}
+	m.ui.Details.RevealSecret(msg.URN, msg.Path, msg.Value)
+	return m, nil
+}
+
+// updateSecretSelector handles keys when the secret selector has focus
+func (m Model) updateSecretSelector(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	selected, cmd := m.ui.SecretSelector.Update(msg)
+	if selected {
+		urn := m.ui.SecretSelector.URN()
+		path := m.ui.SecretSelector.SelectedPath()
+		m.hideSecretSelector()
+		if path != "" {
+			return m, m.fetchSecret(urn, path)
+		}
+	}
+	// Check if selector was dismissed (ESC pressed)
+	if !m.ui.SecretSelector.Visible() {
+		m.ui.Focus.Remove(ui.FocusSecretSelector)
+	}
+	return m, cmd
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// followStackReference opens the stack read by a StackReference resource. Stacks of
+// other projects are opened in the workspace of that project, found like the
+// workspace selector does.
+func (m *Model) followStackReference(item *ui.ResourceItem) tea.Cmd {
+	ref := pulumi.ReadStackReference(item.Inputs, item.Outputs)
+	if ref == nil {
+		return m.ui.Toast.Show(i18n.T("Stack reference has no stack name"))
+	}
+
+	workDir := m.ctx.WorkDir
+	if ref.Project == "" || ref.Project == m.state.ProgramName {
+		return func() tea.Msg {
+			return stackReferenceResolvedMsg{WorkDir: workDir, StackName: ref.Name}
+		}
+	}
+
+	cwd := m.ctx.Cwd
+	search := m.ctx.WorkspaceSearch
+	workspaceReader := m.deps.WorkspaceReader
+	return func() tea.Msg {
+		msg := stackReferenceResolvedMsg{StackName: ref.Name}
+		workspaces, err := workspaceReader.FindWorkspaces(cwd, workDir, search)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		for _, ws := range workspaces {
+			if ws.Name == ref.Project {
+				msg.WorkDir = ws.Path
+				return msg
+			}
+		}
+		msg.Err = fmt.Errorf("no workspace found for project %s", ref.Project)
+		return msg
+	}
+}
+
+// handleStackReferenceResolved opens the referenced stack once its workspace is known
+func (m Model) handleStackReferenceResolved(msg stackReferenceResolvedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to follow stack reference %s: %v", msg.StackName, msg.Err))
+	}
+	return m.openStack(msg.WorkDir, msg.StackName)
+}
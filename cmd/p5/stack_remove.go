@@ -0,0 +1,67 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// destroyedWholeStack reports whether the operation that just finished destroyed
+// every resource of the stack: an untargeted destroy outside of a queue without
+// failed resources
+func (m *Model) destroyedWholeStack() bool {
+	run := m.state.CurrentRun
+	return m.state.Operation == pulumi.OperationDestroy && run != nil &&
+		len(run.Targets) == 0 && len(run.Excludes) == 0 &&
+		m.state.OperationQueue == nil && m.failedResourceCount() == 0
+}
+
+// offerStackRemoval offers to remove the stack a destroy emptied, like `pulumi stack rm`
+func (m *Model) offerStackRemoval() {
+	m.state.PendingStackRemoval = true
+	m.ui.ConfirmModal.SetLabels(i18n.T("Keep"), i18n.T("Remove"))
+	m.ui.ConfirmModal.SetKeys("n", "y")
+	m.ui.ConfirmModal.Show(
+		i18n.T("Remove Stack"),
+		i18n.Tf("Destroyed all resources of %s. Remove the stack and its Pulumi.%s.yaml config as well, like `pulumi stack rm`?",
+			m.ctx.StackName, m.ctx.StackName),
+		i18n.T("The stack's update history is removed with it."),
+	)
+	m.showConfirmModal()
+}
+
+// removeStack returns a command removing the current stack
+func (m *Model) removeStack() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	stackInitializer := m.deps.StackInitializer
+	appCtx := m.appCtx
+	opts := pulumi.RemoveStackOptions{Env: mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())}
+
+	return func() tea.Msg {
+		err := stackInitializer.RemoveStack(appCtx, workDir, stackName, opts)
+		return stackRemovedMsg{StackName: stackName, Err: err}
+	}
+}
+
+// handleStackRemoved returns to the stack selector once the stack is removed
+func (m Model) handleStackRemoved(msg stackRemovedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to remove stack: %v", msg.Err))
+	}
+
+	m.resetOperation()
+	m.ui.ViewMode = ui.ViewStack
+	m.ui.Header.SetViewMode(m.ui.ViewMode)
+	m.hideDetailsPanel()
+	m.ui.ResourceList.Clear()
+	m.state.StackResources = nil
+	m.updateProviderMismatches()
+	m.showStackSelector()
+	return m, tea.Batch(
+		m.ui.Toast.Show(i18n.Tf("Removed stack %s", msg.StackName)),
+		m.fetchStacksList(),
+	)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/pulumi"
+)
+
+// fetchStackResults returns a command reading the result of each stack's most
+// recent update for the stack badges, running at most dashboardConcurrency reads
+// at once. Stacks without updates or whose history can't be read are left out.
+func (m *Model) fetchStackResults(stacks []pulumi.StackInfo) tea.Cmd {
+	if len(stacks) == 0 {
+		return nil
+	}
+	workDir := m.ctx.WorkDir
+	stackReader := m.deps.StackReader
+	appCtx := m.appCtx
+	opts := pulumi.ReadOptions{Env: m.deps.Env}
+	return func() tea.Msg {
+		return stackResultsMsg{WorkDir: workDir, Results: loadStackResults(appCtx, stackReader, workDir, stacks, opts)}
+	}
+}
+
+// loadStackResults reads the latest update of each stack concurrently
+func loadStackResults(ctx context.Context, reader pulumi.StackReader, workDir string, stacks []pulumi.StackInfo, opts pulumi.ReadOptions) map[string]string {
+	sem := make(chan struct{}, dashboardConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	results := make(map[string]string)
+	for _, stack := range stacks {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			history, err := reader.GetHistory(ctx, workDir, stack.Name, 1, pulumi.DefaultHistoryPage, opts)
+			if err != nil || len(history) == 0 {
+				return
+			}
+			mu.Lock()
+			results[stack.Name] = history[0].Result
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+	return results
+}
+
+// handleStackResults shows the loaded stack badges, unless the workspace changed
+// while they were read
+func (m Model) handleStackResults(msg stackResultsMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if msg.WorkDir != m.ctx.WorkDir {
+		return m, nil
+	}
+	m.state.StackResults = msg.Results
+	m.showStackResults()
+	return m, nil
+}
+
+// setStackResult records the result of an update of the current stack run from p5,
+// so its badge is current without reading the history again
+func (m *Model) setStackResult(result string) {
+	if m.state.StackResults == nil {
+		m.state.StackResults = make(map[string]string)
+	}
+	m.state.StackResults[m.ctx.StackName] = result
+	m.showStackResults()
+}
+
+// showStackResults shows the stack badges in the stack selector and the header
+func (m *Model) showStackResults() {
+	m.ui.StackSelector.SetLastResults(m.state.StackResults)
+	m.ui.Header.SetLastResult(m.state.StackResults[m.ctx.StackName])
+}
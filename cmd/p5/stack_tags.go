@@ -0,0 +1,87 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// openStackTags shows the stack tags modal and fetches the tags, or returns nil
+// when there is no stack to tag
+func (m *Model) openStackTags() tea.Cmd {
+	// Block while busy (e.g., waiting for auth or stack selection)
+	if m.state.IsBusy() || m.ctx.StackName == "" || m.ctx.StartView == "state" {
+		return nil
+	}
+	m.showTagsModal()
+	return m.fetchStackTags()
+}
+
+// fetchStackTags fetches the tags of the current stack
+func (m *Model) fetchStackTags() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	stackReader := m.deps.StackReader
+	appCtx := m.appCtx
+	opts := pulumi.ReadOptions{Env: mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())}
+
+	return func() tea.Msg {
+		tags, err := stackReader.GetTags(appCtx, workDir, stackName, opts)
+		return stackTagsMsg{StackName: stackName, Tags: tags, Err: err}
+	}
+}
+
+// setStackTag sets a tag on the current stack, removing it when value is empty
+func (m *Model) setStackTag(tag ui.StackTag) tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	stackReader := m.deps.StackReader
+	appCtx := m.appCtx
+	opts := pulumi.ReadOptions{Env: mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())}
+
+	return func() tea.Msg {
+		err := stackReader.SetTag(appCtx, workDir, stackName, tag.Key, tag.Value, opts)
+		return stackTagSetMsg{Key: tag.Key, Value: tag.Value, Err: err}
+	}
+}
+
+// handleStackTags lists the fetched tags
+func (m Model) handleStackTags(msg stackTagsMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	// Ignore results for a stack that is no longer selected
+	if msg.StackName != m.ctx.StackName {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.ui.TagsModal.SetError(msg.Err)
+		return m, nil
+	}
+	m.ui.TagsModal.SetTags(msg.Tags)
+	return m, nil
+}
+
+// handleStackTagSet updates the listed tags once a tag was saved
+func (m Model) handleStackTagSet(msg stackTagSetMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		if m.ui.TagsModal.Visible() {
+			m.ui.TagsModal.SetError(msg.Err)
+			return m, nil
+		}
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to save stack tag %s: %v", msg.Key, msg.Err))
+	}
+	m.ui.TagsModal.SetTag(msg.Key, msg.Value)
+	return m, nil
+}
+
+// updateTagsModal handles keys when the stack tags modal has focus
+func (m Model) updateTagsModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.TagsModal.Update(msg)
+	switch action {
+	case ui.TagsActionSet:
+		return m, m.setStackTag(m.ui.TagsModal.PendingTag())
+	case ui.TagsActionCancel:
+		m.hideTagsModal()
+	}
+	return m, cmd
+}
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"time"
+
+	"github.com/rfhold/p5/internal/plugins"
 	"github.com/rfhold/p5/internal/pulumi"
 	"github.com/rfhold/p5/internal/ui"
 )
@@ -13,9 +16,54 @@ type PendingOperation struct {
 
 // PendingProtectAction represents a protect/unprotect action awaiting confirmation
 type PendingProtectAction struct {
-	URN     string
-	Name    string
-	Protect bool // true = protect, false = unprotect
+	Resources []ui.SelectedResource
+	Protect   bool // true = protect, false = unprotect
+}
+
+// PendingExecution is an up or destroy waiting for its update message
+type PendingExecution struct {
+	Op   pulumi.OperationType
+	Opts pulumi.OperationOptions
+}
+
+// PendingBulkImport tracks resource discovery for the open bulk import modal
+type PendingBulkImport struct {
+	Item       ui.ResourceItem   // Pending create whose type is being discovered
+	PageTokens map[string]string // Per-plugin token for the next page
+}
+
+// PendingStateDelete is a selection of resources awaiting confirmation to be
+// removed from state
+type PendingStateDelete struct {
+	Selected          []ui.SelectedResource
+	IncludeDependents bool // Also remove the resources that depend on the selection
+}
+
+// PendingResourceState is a resource's state being edited in the user's editor
+type PendingResourceState struct {
+	URN      string
+	Name     string
+	Path     string // Temporary file holding the state
+	Original []byte // State as exported
+	Edited   []byte // State read back from the editor
+	Invalid  bool   // Whether Edited failed validation, so confirming edits again
+}
+
+// AboutDiagnostics is what the about panel shows beyond the loaded workspace
+type AboutDiagnostics struct {
+	Info       *pulumi.AboutInfo
+	ConfigPath string            // p5.toml in use, empty when there is none
+	GoMod      map[string]string // Provider SDK versions in go.mod, nil outside Go programs
+	Err        error
+}
+
+// BulkStateDelete tracks a multi-resource state delete, which removes one resource
+// at a time
+type BulkStateDelete struct {
+	Resources []ui.SelectedResource // Resources to remove, in order
+	Done      int                   // Resources processed so far
+	Succeeded int
+	Errors    []string // Error messages for failed deletions
 }
 
 // AppState holds pure application state (no UI components).
@@ -32,12 +80,177 @@ type AppState struct {
 	// Pending operation confirmation (operation awaiting user confirm)
 	PendingOperation *pulumi.OperationType
 
+	// Update plan written by the current up preview, empty when not saving one
+	PlanPath string
+	// Whether the preview finished writing PlanPath, so executing up applies it
+	PlanSaved bool
+	// Flags the current preview ran with; changing them drops its plan and auto-approval
+	PlanFlags pulumi.OperationOptions
+
+	// Git checkout of the workspace, nil outside of git or until it is read
+	Git *pulumi.GitInfo
+	// Guard checking the checkout before up, nil when not configured
+	GitGuard *plugins.GitGuardConfig
+	// How carefully up and destroy on the stack are confirmed
+	Protection plugins.ProtectionLevel
+	// When up, refresh and destroy on the stack ask for confirmation
+	Confirm plugins.ConfirmConfig
+	// Rules running small changes from their preview without confirmation, nil when off
+	AutoApprove *plugins.AutoApproveConfig
+
+	// Whether up and its preview refresh the state first, like pulumi up --refresh
+	RefreshOnUp bool
+	// Whether up and destroy carry on past failed resources, like --continue-on-error
+	ContinueOnError bool
+
+	// Up or destroy waiting for its update message (nil when not prompting)
+	PendingExecution *PendingExecution
+
+	// Queued chain of operations (nil when no queue is running)
+	OperationQueue *OperationQueue
+
+	// Whether the refresh preview is shown as drift detection results
+	DriftMode bool
+	// Drift action awaiting confirmation
+	PendingDriftAction *DriftAction
+
+	// Record of the running up/refresh/destroy for operation artifacts
+	CurrentRun *RunRecord
+
 	// Pending protect action (awaiting confirmation)
 	PendingProtectAction *PendingProtectAction
 
+	// Cancelling the stack's update and clearing its pending operations is awaiting confirmation
+	PendingStackRecovery bool
+
+	// Clearing the pending operations a cancelled update left in state is awaiting
+	// confirmation. Unlike PendingStackRecovery, no update is cancelled.
+	PendingClearOperations bool
+
+	// Removing the stack a destroy emptied is awaiting confirmation
+	PendingStackRemoval bool
+
+	// A project is being scaffolded from a template
+	CreatingProject bool
+
+	// Deployment file to replace the stack's state with, awaiting confirmation
+	PendingStateImport string
+
+	// Bulk import discovery (nil when the bulk import modal is closed)
+	PendingBulkImport *PendingBulkImport
+
+	// Inconsistencies found in the last loaded stack state
+	StateIssues []pulumi.StateIssue
+	// Root stack resource in the last loaded state, the target when re-parenting orphans
+	StackURN string
+	// Resources in the last loaded state, for finding what an operation affects
+	StackResources []pulumi.ResourceInfo
+	// Name of the Pulumi program, from the project info
+	ProgramName string
+	// State delete awaiting confirmation (nil when none is)
+	PendingStateDelete *PendingStateDelete
+	// Running multi-resource state delete (nil when none is running)
+	BulkStateDelete *BulkStateDelete
+	// Resource state being edited (nil when none is)
+	PendingResourceState *PendingResourceState
+	// Pulumi diagnostics for the about panel (nil until fetched)
+	About *AboutDiagnostics
+	// Workspace the about diagnostics were last fetched for, to check provider versions
+	AboutFetchedFor string
+	// Providers whose version in state diverges from the installed plugins or go.mod
+	ProviderMismatches []pulumi.ProviderVersionMismatch
+
+	// Runtime of the Pulumi program (e.g., "nodejs"), from the project info
+	Runtime string
+	// Name of the resources the shown generated code was imported as, for the default file name
+	GeneratedCodeName string
+	// Whether the save file prompt exports the resource list rather than generated code
+	ExportingResources bool
+
 	// Resource flags (persists across all views)
 	// Maps URN to flags for each resource
 	Flags map[string]ui.ResourceFlags
+	// Whether flags are saved to the project's .p5/flags.json, per its config
+	PersistFlags bool
+	// Project directory and stack whose saved flags have been loaded
+	SavedFlagsLoadedFor string
+
+	// Notes attached to resources, mapping URN to note (shared with the UI)
+	Notes map[string]string
+	// Project directory whose notes have been loaded from .p5/notes
+	NotesLoadedFor string
+
+	// URNs of resources pinned to the top of the resource list (shared with the UI)
+	Pinned map[string]bool
+	// Project directory whose pinned resources have been loaded from .p5/pinned.json
+	PinnedLoadedFor string
+
+	// Property paths de-emphasized in diffs and left out of drift, by resource type pattern
+	DiffIgnore ui.DiffIgnoreRules
+	// Project directory whose diff ignore rules have been loaded
+	DiffIgnoreLoadedFor string
+
+	// Plugins listed in the plugin index modal
+	PluginIndex []plugins.IndexEntry
+
+	// Target sets listed in the target set selector, by name
+	TargetSets map[string]plugins.TargetSetConfig
+
+	// Workspaces found for the workspace selector, relisted when one is pinned
+	Workspaces []pulumi.WorkspaceInfo
+
+	// Warnings and errors reported by the engine and policies during the last preview
+	PreviewWarnings []pulumi.PreviewWarning
+
+	// Last quick check of the stack (nil when none ran since it was selected)
+	QuickCheck *QuickCheck
+
+	// Result of the most recent update of each stack of the workspace, by stack
+	// name, for the stack badges
+	StackResults map[string]string
+
+	// Preview hashes from the last completed preview of each operation type,
+	// used to highlight resources whose diff changed between previews
+	PreviewHashes map[pulumi.OperationType]map[string]string
+
+	// Revision of the stack's latest update when it was loaded, and the project and
+	// stack it was read for, for detecting updates made elsewhere
+	LoadedRevision    string
+	LoadedRevisionFor string
+	// Whether the stack was updated elsewhere since it was loaded
+	StateOutdated bool
+
+	// Updates loaded in the history view, newest first, and the last page loaded
+	History     []pulumi.UpdateSummary
+	HistoryPage int
+	// Resources changed by each update diffed in the history view, by version
+	HistoryChanges map[int][]ui.ResourceItem
+
+	// Identifies the pending plugin credential refresh; ticks from earlier schedules are ignored
+	CredentialRefreshSeq int
+
+	// Identifies the current plugin status badge polling loop; results and ticks
+	// from earlier loops are ignored
+	StatusBadgeSeq int
+
+	// Identifies the latest import ID typed in the import modal; checks of earlier
+	// IDs are ignored
+	ImportValidationSeq int
+
+	// Identifies the refresh loop of the open log viewer; ticks from earlier
+	// openings are ignored
+	LogsRefreshSeq int
+
+	// Time of the last key press or mouse event, for the idle lock
+	LastActivity time.Time
+
+	// Last click on a list item, for detecting double clicks
+	LastClick MouseClick
+
+	// Whether the border between the list and the details panel is being dragged
+	ResizingDetails bool
+	// Counts details panel resize key presses, so the width is saved once they stop
+	DetailsResizeSeq int
 
 	// Error state
 	Err error
@@ -51,9 +264,13 @@ type AppState struct {
 // NewAppState creates initial application state with default values
 func NewAppState() *AppState {
 	return &AppState{
-		InitState: InitCheckingWorkspace,
-		OpState:   OpIdle,
-		Flags:     make(map[string]ui.ResourceFlags),
+		InitState:     InitCheckingWorkspace,
+		OpState:       OpIdle,
+		Flags:         make(map[string]ui.ResourceFlags),
+		Notes:         make(map[string]string),
+		Pinned:        make(map[string]bool),
+		PreviewHashes: make(map[pulumi.OperationType]map[string]string),
+		LastActivity:  time.Now(),
 	}
 }
 
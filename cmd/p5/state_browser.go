@@ -0,0 +1,80 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// loadStateFiles reads the exported state given on the command line
+func (m *Model) loadStateFiles() tea.Cmd {
+	ctx := m.appCtx
+	files := m.ctx.StateFiles
+
+	return func() tea.Msg {
+		states := make([][]pulumi.ResourceInfo, len(files))
+		for i, file := range files {
+			resources, err := pulumi.ReadStateFile(ctx, file)
+			if err != nil {
+				return stateFilesMsg{Err: err}
+			}
+			states[i] = resources
+		}
+		return stateFilesMsg{States: states}
+	}
+}
+
+// handleStateFiles shows the resources of a state file, or the diff between two
+func (m Model) handleStateFiles(msg stateFilesMsg) (tea.Model, tea.Cmd) {
+	m.transitionTo(InitComplete)
+	if msg.Err != nil {
+		m.ui.Header.SetError(msg.Err)
+		m.ui.ResourceList.SetError(msg.Err)
+		return m, nil
+	}
+
+	resources := msg.States[len(msg.States)-1]
+	var items []ui.ResourceItem
+	if len(msg.States) == 2 {
+		items = DiffStateSnapshots(msg.States[0], msg.States[1], true)
+	} else {
+		items = ConvertResourcesToItems(resources)
+	}
+
+	sources := make([]string, len(m.ctx.StateFiles))
+	for i, file := range m.ctx.StateFiles {
+		sources[i] = filepath.Base(file)
+	}
+	stackName, programName, _ := pulumi.ParseStackURN(pulumi.StackResourceURN(resources))
+	m.ui.Header.SetData(&ui.HeaderData{
+		ProgramName: programName,
+		StackName:   stackName,
+		Source:      strings.Join(sources, " → "),
+	})
+
+	m.ui.ResourceList.SetItems(items)
+	m.ui.Header.SetSummary(m.ui.ResourceList.Summary(), ui.HeaderDone)
+	return m, nil
+}
+
+// handleStateBrowserKeys handles keys while browsing exported state. Only the
+// details panel, copying, list navigation and opening another stack of the
+// organization apply; flags have nothing to target.
+func (m Model) handleStateBrowserKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, ui.Keys.ToggleDetails):
+		m.toggleDetailsPanel()
+		return m, nil
+	case key.Matches(msg, ui.Keys.BrowseCloudStacks):
+		m.showCloudStackBrowser()
+		return m, m.fetchCloudStacks()
+	case isFlagKey(msg):
+		return m, nil
+	}
+	return m.handleListNavigation(msg)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// pollStateAfter checks the stack for updates made elsewhere once d has passed
+func pollStateAfter(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return statePollMsg{}
+	})
+}
+
+// loadedStackKey identifies the project and stack whose resources are shown
+func (m *Model) loadedStackKey() string {
+	return m.ctx.WorkDir + "\x00" + m.ctx.StackName
+}
+
+// updateRevision identifies an update in the stack's history. Local and diy
+// backends report every update as version 0, so its start time is included.
+func updateRevision(h pulumi.UpdateSummary) string {
+	return strconv.Itoa(h.Version) + "@" + h.StartTime
+}
+
+// fetchStateVersion reads the revision of the stack's latest update. Failures are
+// ignored; the next poll tries again.
+func (m *Model) fetchStateVersion() tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	stackReader := m.deps.StackReader
+	appCtx := m.appCtx
+	opts := pulumi.ReadOptions{Env: mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())}
+
+	return func() tea.Msg {
+		history, err := stackReader.GetHistory(appCtx, workDir, stackName, 1, pulumi.DefaultHistoryPage, opts)
+		if err != nil {
+			return nil
+		}
+		revision := ""
+		if len(history) > 0 {
+			revision = updateRevision(history[0])
+		}
+		return stateVersionMsg{WorkDir: workDir, StackName: stackName, Revision: revision}
+	}
+}
+
+// recordLoadedState starts tracking the stack's latest update after its resources
+// were loaded, so later polls can tell whether it was updated elsewhere
+func (m *Model) recordLoadedState() tea.Cmd {
+	if m.ctx.PollInterval == 0 || m.ctx.StartView == "state" {
+		return nil
+	}
+	m.state.LoadedRevisionFor = ""
+	m.state.StateOutdated = false
+	m.ui.Header.SetOutdated(false)
+	return m.fetchStateVersion()
+}
+
+// handleStatePoll checks the stack's latest update while idle in the stack view. A
+// single poll is pending at a time; the next one is scheduled whatever this one does.
+func (m Model) handleStatePoll() (tea.Model, tea.Cmd) {
+	next := pollStateAfter(m.ctx.PollInterval)
+	idle := m.state.InitState == InitComplete &&
+		m.ui.ViewMode == ui.ViewStack &&
+		!m.state.OpState.IsActive() &&
+		!m.state.IsBusy() &&
+		m.ctx.StackName != "" &&
+		m.ctx.StartView != "state"
+	if !idle || m.state.StateOutdated {
+		return m, next
+	}
+	return m, tea.Batch(next, m.fetchStateVersion())
+}
+
+// handleStateVersion compares the stack's latest update with the one it had when
+// loaded. The first revision read after a load becomes the baseline.
+func (m Model) handleStateVersion(msg stateVersionMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if msg.WorkDir != m.ctx.WorkDir || msg.StackName != m.ctx.StackName {
+		return m, nil
+	}
+	if key := m.loadedStackKey(); m.state.LoadedRevisionFor != key {
+		m.state.LoadedRevisionFor = key
+		m.state.LoadedRevision = msg.Revision
+		return m, nil
+	}
+	if msg.Revision != m.state.LoadedRevision {
+		m.state.StateOutdated = true
+		m.ui.Header.SetOutdated(true)
+	}
+	return m, nil
+}
+
+// reloadStack reloads the stack's resources, or returns nil outside the stack view
+func (m *Model) reloadStack() tea.Cmd {
+	if m.state.IsBusy() || m.state.OpState.IsActive() || m.ui.ViewMode != ui.ViewStack ||
+		m.ctx.StackName == "" || m.ctx.StartView == "state" {
+		return nil
+	}
+	return m.loadStackResources()
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"path"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// openStateFileModal prompts for the file to export the stack's state to or import
+// it from, or returns false when the stack view isn't showing a stack
+func (m *Model) openStateFileModal(importing bool) bool {
+	if m.ui.ViewMode != ui.ViewStack || m.state.OpState.IsActive() ||
+		m.ctx.StackName == "" || m.ctx.StartView == "state" {
+		return false
+	}
+	m.showStateFileModal(importing)
+	return true
+}
+
+// resolveStatePath resolves a state file path entered relative to the project directory
+func resolveStatePath(workDir, p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(workDir, p)
+}
+
+// exportState writes the stack's deployment to path
+func (m *Model) exportState(p string) tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	transferer := m.deps.StateTransferer
+	appCtx := m.appCtx
+	opts := pulumi.DeploymentTransferOptions{Env: mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())}
+	p = resolveStatePath(workDir, p)
+
+	return func() tea.Msg {
+		file, err := transferer.ExportDeployment(appCtx, workDir, stackName, p, opts)
+		return stateExportedMsg{File: file, Err: err}
+	}
+}
+
+// checkStateFile validates the deployment file to import before asking for confirmation
+func (m *Model) checkStateFile(p string) tea.Cmd {
+	p = resolveStatePath(m.ctx.WorkDir, p)
+
+	return func() tea.Msg {
+		file, err := pulumi.ReadDeploymentFile(p)
+		return stateFileCheckedMsg{File: file, Err: err}
+	}
+}
+
+// importState replaces the stack's state with the deployment file at path
+func (m *Model) importState(p string) tea.Cmd {
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	transferer := m.deps.StateTransferer
+	appCtx := m.appCtx
+	opts := pulumi.DeploymentTransferOptions{Env: mergeEnvMaps(m.deps.Env, m.deps.PluginProvider.GetAllEnv())}
+
+	return func() tea.Msg {
+		file, err := transferer.ImportDeployment(appCtx, workDir, stackName, p, opts)
+		return stateImportedMsg{StackName: stackName, File: file, Err: err}
+	}
+}
+
+// updateStateFileModal handles keys when the state file prompt has focus
+func (m Model) updateStateFileModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.StateFileModal.Update(msg)
+	switch action {
+	case ui.StepModalActionConfirm:
+		// Block export and import while busy (e.g., waiting for auth)
+		if m.state.IsBusy() {
+			return m, nil
+		}
+		p := m.ui.StateFileModal.Path()
+		if m.ui.StateFileModal.Importing() {
+			// Keep the prompt open until the file is known to be importable
+			m.ui.StateFileModal.ClearError()
+			return m, m.checkStateFile(p)
+		}
+		m.hideStateFileModal()
+		return m, tea.Batch(
+			m.ui.Toast.Show(i18n.T("Exporting state...")),
+			m.exportState(p),
+		)
+	case ui.StepModalActionCancel:
+		m.hideStateFileModal()
+	}
+	return m, cmd
+}
+
+// handleStateExported reports where the stack's state was exported
+func (m Model) handleStateExported(msg stateExportedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to export state: %v", msg.Err))
+	}
+	return m, m.ui.Toast.Show(i18n.Tf("Exported %d resources to %s", msg.File.Resources, msg.File.Path))
+}
+
+// handleStateFileChecked asks to confirm importing a valid deployment file, or shows
+// why the file can't be imported
+func (m Model) handleStateFileChecked(msg stateFileCheckedMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if !m.ui.StateFileModal.Visible() {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.ui.StateFileModal.SetError(msg.Err)
+		return m, nil
+	}
+	m.hideStateFileModal()
+
+	file := msg.File
+	warning := i18n.T("The stack's current state will be overwritten.")
+	if file.Stack != "" && file.Stack != path.Base(m.ctx.StackName) {
+		warning = i18n.Tf("The file holds the state of stack %s, not %s.", file.Project+"/"+file.Stack, m.ctx.StackName)
+	}
+	m.state.PendingStateImport = file.Path
+	m.ui.ConfirmModal.SetLabels(i18n.T("Cancel"), i18n.T("Import"))
+	m.ui.ConfirmModal.SetKeys("n", "y")
+	m.ui.ConfirmModal.Show(
+		i18n.T("Import State"),
+		i18n.Tf("Replace the state of %s with %d resources from %s?", m.ctx.StackName, file.Resources, file.Path),
+		warning,
+	)
+	m.showConfirmModal()
+	return m, nil
+}
+
+// handleStateImported reloads the stack once its state was replaced
+func (m Model) handleStateImported(msg stateImportedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to import state: %v", msg.Err))
+	}
+	toast := m.ui.Toast.Show(i18n.Tf("Imported %d resources into %s", msg.File.Resources, msg.StackName))
+	if msg.StackName != m.ctx.StackName {
+		return m, toast
+	}
+	return m, tea.Batch(toast, m.reloadStack())
+}
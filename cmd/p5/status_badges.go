@@ -0,0 +1,91 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// statusBadgeInterval is how often plugins are asked for their status badge
+const statusBadgeInterval = 30 * time.Second
+
+// clearStatusBadges hides the plugin badges and stops the polling loop, as they
+// describe a stack that is no longer current
+func (m *Model) clearStatusBadges() {
+	m.state.StatusBadgeSeq++
+	m.ui.Header.SetBadges(nil)
+}
+
+// refreshStatusBadges starts a new polling loop for plugin status badges, fetching
+// them right away. It returns nil if no plugin provides badges.
+func (m *Model) refreshStatusBadges() tea.Cmd {
+	m.clearStatusBadges()
+	if m.deps == nil || m.deps.PluginProvider == nil || !m.deps.PluginProvider.HasStatusBadges() {
+		return nil
+	}
+	return m.fetchStatusBadges()
+}
+
+// fetchStatusBadges asks plugins for their status badges
+func (m *Model) fetchStatusBadges() tea.Cmd {
+	seq := m.state.StatusBadgeSeq
+	pluginProvider := m.deps.PluginProvider
+	appCtx := m.appCtx
+
+	return func() tea.Msg {
+		return statusBadgesMsg{Seq: seq, Badges: pluginProvider.GetStatusBadges(appCtx)}
+	}
+}
+
+// handleStatusBadgeTick polls plugins for their badges, waiting for the next tick
+// while plugins authenticate
+func (m Model) handleStatusBadgeTick(msg statusBadgeTickMsg) (tea.Model, tea.Cmd) {
+	if msg.Seq != m.state.StatusBadgeSeq {
+		return m, nil
+	}
+	if m.state.IsBusy() {
+		return m, pollStatusBadgesAfter(statusBadgeInterval, msg.Seq)
+	}
+	return m, m.fetchStatusBadges()
+}
+
+// handleStatusBadges shows the badges in the header and schedules the next poll.
+// Plugins that failed keep no badge; the failure is logged.
+func (m Model) handleStatusBadges(msg statusBadgesMsg) (tea.Model, tea.Cmd) {
+	if msg.Seq != m.state.StatusBadgeSeq {
+		return m, nil
+	}
+
+	var badges []ui.HeaderBadge
+	for _, badge := range msg.Badges {
+		if badge.Error != nil {
+			m.deps.Logger.Warn("plugin status badge failed", "plugin", badge.PluginName, "error", badge.Error)
+			continue
+		}
+		badges = append(badges, ui.HeaderBadge{Text: badge.Text, Level: headerBadgeLevel(badge.Level)})
+	}
+	m.ui.Header.SetBadges(badges)
+	return m, pollStatusBadgesAfter(statusBadgeInterval, msg.Seq)
+}
+
+// pollStatusBadgesAfter polls plugin status badges once d has passed
+func pollStatusBadgesAfter(d time.Duration, seq int) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return statusBadgeTickMsg{Seq: seq}
+	})
+}
+
+// headerBadgeLevel converts a plugin badge level to the header's
+func headerBadgeLevel(level plugins.StatusBadgeLevel) ui.BadgeLevel {
+	switch level {
+	case plugins.StatusBadgeLevelWarning:
+		return ui.BadgeWarning
+	case plugins.StatusBadgeLevelDanger:
+		return ui.BadgeDanger
+	default:
+		return ui.BadgeInfo
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// fetchTargetSets loads the target sets saved in p5.toml
+func (m *Model) fetchTargetSets() tea.Cmd {
+	workDir := m.ctx.WorkDir
+
+	return func() tea.Msg {
+		sets, err := plugins.LoadTargetSets(workDir)
+		return targetSetsMsg{Sets: sets, Err: err}
+	}
+}
+
+// handleTargetSets lists the loaded target sets in the selector, offering to save
+// the current flags when the stack has any
+func (m Model) handleTargetSets(msg targetSetsMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if msg.Err != nil {
+		m.ui.TargetSetSelector.SetError(msg.Err)
+		return m, nil
+	}
+
+	m.state.TargetSets = msg.Sets
+	items := make([]ui.TargetSetItem, 0, len(msg.Sets))
+	for _, name := range slices.Sorted(maps.Keys(msg.Sets)) {
+		set := msg.Sets[name]
+		items = append(items, ui.TargetSetItem{
+			Name:     name,
+			Targets:  len(set.Target),
+			Replaces: len(set.Replace),
+			Excludes: len(set.Exclude),
+		})
+	}
+	m.ui.TargetSetSelector.SetShowNewOption(m.currentTargetSet().Len() > 0)
+	m.ui.TargetSetSelector.SetTargetSets(items)
+	return m, nil
+}
+
+// updateTargetSetSelector handles keys when the target set selector has focus
+func (m Model) updateTargetSetSelector(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	selected, cmd := m.ui.TargetSetSelector.Update(msg)
+	if selected {
+		newSelected := m.ui.TargetSetSelector.IsNewSelected()
+		name := m.ui.TargetSetSelector.SelectedTargetSet()
+		m.hideTargetSetSelector()
+		if newSelected {
+			m.showTargetSetModal()
+			return m, nil
+		}
+		if name != "" {
+			return m, m.applyTargetSet(name)
+		}
+	}
+	// Check if selector was dismissed (ESC pressed)
+	if !m.ui.TargetSetSelector.Visible() {
+		m.ui.Focus.Remove(ui.FocusTargetSetSelector)
+	}
+	return m, cmd
+}
+
+// applyTargetSet replaces the flags with those of a saved target set, mapped to
+// the resources of the current stack. Like any flag change, it can be undone.
+func (m *Model) applyTargetSet(name string) tea.Cmd {
+	set := m.state.TargetSets[name]
+	flags := flagsFromTargetSet(set, StackURNPrefix(m.state.StackURN, m.ctx.StackName))
+	m.ui.ResourceList.ReplaceFlags(flags)
+	if m.ui.Focus.Has(ui.FocusDetailsPanel) {
+		m.ui.Details.SetResource(m.ui.ResourceList.SelectedItem())
+	}
+	m.dropChangedPlan()
+
+	// Resources of the set may not exist in this stack, or not yet
+	missing := len(flags)
+	for _, item := range m.ui.ResourceList.Items() {
+		if _, ok := flags[item.URN]; ok {
+			missing--
+		}
+	}
+	toast := i18n.Tf("Applied target set %s", name)
+	if missing > 0 {
+		toast = i18n.Tf("Applied target set %s, %d of its resources are not in the stack", name, missing)
+	}
+	cmds := []tea.Cmd{m.ui.Toast.Show(toast)}
+	if m.state.PersistFlags {
+		cmds = append(cmds, m.saveFlags())
+	}
+	return tea.Batch(cmds...)
+}
+
+// currentTargetSet returns the flags of the current stack as a target set
+func (m *Model) currentTargetSet() plugins.TargetSetConfig {
+	return targetSetFromFlags(m.state.Flags, StackURNPrefix(m.state.StackURN, m.ctx.StackName))
+}
+
+// targetSetFromFlags converts the flags of the resources whose URN starts with
+// prefix to a target set, dropping the prefix so the set applies to any stack
+func targetSetFromFlags(flags map[string]ui.ResourceFlags, prefix string) plugins.TargetSetConfig {
+	var set plugins.TargetSetConfig
+	for _, urn := range slices.Sorted(maps.Keys(flags)) {
+		resource, ok := strings.CutPrefix(urn, prefix)
+		if !ok {
+			continue
+		}
+		f := flags[urn]
+		if f.Target {
+			set.Target = append(set.Target, resource)
+		}
+		if f.Replace {
+			set.Replace = append(set.Replace, resource)
+		}
+		if f.Exclude {
+			set.Exclude = append(set.Exclude, resource)
+		}
+	}
+	return set
+}
+
+// flagsFromTargetSet converts a target set to the flags of the resources of the
+// stack whose URNs start with prefix
+func flagsFromTargetSet(set plugins.TargetSetConfig, prefix string) map[string]ui.ResourceFlags {
+	flags := make(map[string]ui.ResourceFlags, set.Len())
+	for _, resource := range set.Target {
+		f := flags[prefix+resource]
+		f.Target = true
+		flags[prefix+resource] = f
+	}
+	for _, resource := range set.Replace {
+		f := flags[prefix+resource]
+		f.Replace = true
+		flags[prefix+resource] = f
+	}
+	for _, resource := range set.Exclude {
+		flags[prefix+resource] = ui.ResourceFlags{Exclude: true}
+	}
+	return flags
+}
+
+// showTargetSetModal prompts for the name to save the current flags under
+func (m *Model) showTargetSetModal() {
+	sets := m.state.TargetSets
+	m.ui.TargetSetModal.ShowForFlags(m.currentTargetSet().Len(), func(name string) error {
+		if err := plugins.ValidateTargetSetName(name); err != nil {
+			return err
+		}
+		if _, ok := sets[strings.TrimSpace(name)]; ok {
+			return fmt.Errorf("%s: %s", i18n.T("a target set with this name already exists"), name)
+		}
+		return nil
+	})
+	m.ui.Focus.Push(ui.FocusTargetSetModal)
+}
+
+// hideTargetSetModal hides the target set name prompt and pops focus
+func (m *Model) hideTargetSetModal() {
+	m.ui.TargetSetModal.Hide()
+	m.ui.Focus.Remove(ui.FocusTargetSetModal)
+}
+
+// updateTargetSetModal handles keys when the target set name prompt has focus
+func (m Model) updateTargetSetModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.TargetSetModal.Update(msg)
+	switch action {
+	case ui.StepModalActionConfirm:
+		m.ui.TargetSetModal.ClearError()
+		return m, m.saveTargetSet(m.ui.TargetSetModal.Name())
+	case ui.StepModalActionCancel:
+		m.hideTargetSetModal()
+	}
+	return m, cmd
+}
+
+// saveTargetSet saves the flags of the current stack to p5.toml under name
+func (m *Model) saveTargetSet(name string) tea.Cmd {
+	workDir := m.ctx.WorkDir
+	set := m.currentTargetSet()
+
+	return func() tea.Msg {
+		path, err := plugins.AddTargetSet(workDir, name, set)
+		return targetSetSavedMsg{Name: name, Path: path, Err: err}
+	}
+}
+
+// handleTargetSetSaved reports where the target set was saved, or keeps the prompt
+// open with the reason it couldn't be
+func (m Model) handleTargetSetSaved(msg targetSetSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		if m.ui.TargetSetModal.Visible() {
+			m.ui.TargetSetModal.SetError(msg.Err)
+			return m, nil
+		}
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to save target set: %v", msg.Err))
+	}
+	m.hideTargetSetModal()
+	return m, m.ui.Toast.Show(i18n.Tf("Saved target set %s to %s", msg.Name, msg.Path))
+}
@@ -1,6 +1,9 @@
 package main
 
-import "github.com/rfhold/p5/internal/ui"
+import (
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/ui"
+)
 
 // UIState holds all UI component state.
 // This groups UI-specific concerns (layout, focus, components) separately
@@ -10,46 +13,121 @@ type UIState struct {
 	Width  int
 	Height int
 
+	// Percentage of the width taken by the details panel
+	DetailsWidth int
+
 	// Focus management
 	Focus ui.FocusStack
 
 	// Current view mode (stack, preview, execute, history)
 	ViewMode ui.ViewMode
 
+	// Whether the footer lists every valid key over several lines
+	ExpandedHints bool
+
 	// UI Components
-	Header            ui.Header
-	ResourceList      *ui.ResourceList
-	HistoryList       *ui.HistoryList
-	Help              *ui.HelpDialog
-	Details           *ui.DetailPanel
-	HistoryDetails    *ui.HistoryDetailPanel
-	StackSelector     *ui.StackSelector
-	WorkspaceSelector *ui.WorkspaceSelector
-	ImportModal       *ui.ImportModal
-	ConfirmModal      *ui.ConfirmModal
-	ErrorModal        *ui.ErrorModal
-	StackInitModal    *ui.StackInitModal
-	Toast             *ui.Toast
+	Header             ui.Header
+	ResourceList       *ui.ResourceList
+	HistoryList        *ui.HistoryList
+	Help               *ui.HelpDialog
+	Details            *ui.DetailPanel
+	HistoryDetails     *ui.HistoryDetailPanel
+	HistoryDiff        *ui.HistoryDiffPanel
+	Environments       *ui.EnvironmentsPanel
+	Warnings           *ui.WarningsPanel
+	Diagnostics        *ui.DiagnosticsStrip
+	Timings            *ui.TimingsPanel
+	Logs               *ui.LogViewer
+	Code               *ui.CodePanel
+	About              *ui.AboutPanel
+	Dashboard          *ui.Dashboard
+	StackSelector      *ui.StackSelector
+	WorkspaceSelector  *ui.WorkspaceSelector
+	WorkflowSelector   *ui.WorkflowSelector
+	CloudStackBrowser  *ui.CloudStackBrowser
+	TargetSetSelector  *ui.TargetSetSelector
+	SecretSelector     *ui.SecretSelector
+	ValueSelector      *ui.ValueSelector
+	ValuePager         *ui.ValuePager
+	ImportModal        *ui.ImportModal
+	BulkImportModal    *ui.BulkImportModal
+	StateRepairModal   *ui.StateRepairModal
+	PluginIndexModal   *ui.PluginIndexModal
+	PluginStatusModal  *ui.PluginStatusModal
+	NoteModal          *ui.NoteModal
+	TagsModal          *ui.TagsModal
+	HistoryFilterModal *ui.HistoryFilterModal
+	StateFileModal     *ui.StateFileModal
+	SaveFileModal      *ui.SaveFileModal
+	TargetSetModal     *ui.TargetSetModal
+	GotoModal          *ui.GotoModal
+	ConfigCopyModal    *ui.ConfigCopyModal
+	UpdateMessageModal *ui.UpdateMessageModal
+	GitGuardModal      *ui.GitGuardModal
+	ConfirmModal       *ui.ConfirmModal
+	ErrorModal         *ui.ErrorModal
+	StackInitModal     *ui.StackInitModal
+	NewProjectModal    *ui.NewProjectModal
+	LoginModal         *ui.LoginModal
+	LockScreen         *ui.LockScreen
+	Toast              *ui.Toast
 }
 
 // NewUIState creates a new UIState with initialized components.
-// The flags parameter is shared with AppState for resource flag persistence.
-func NewUIState(flags map[string]ui.ResourceFlags) *UIState {
-	return &UIState{
-		Focus:             ui.NewFocusStack(),
-		ViewMode:          ui.ViewStack,
-		Header:            ui.NewHeader(),
-		ResourceList:      ui.NewResourceList(flags),
-		HistoryList:       ui.NewHistoryList(),
-		Help:              ui.NewHelpDialog(),
-		Details:           ui.NewDetailPanel(),
-		HistoryDetails:    ui.NewHistoryDetailPanel(),
-		StackSelector:     ui.NewStackSelector(),
-		WorkspaceSelector: ui.NewWorkspaceSelector(),
-		ImportModal:       ui.NewImportModal(),
-		ConfirmModal:      ui.NewConfirmModal(),
-		ErrorModal:        ui.NewErrorModal(),
-		StackInitModal:    ui.NewStackInitModal(),
-		Toast:             ui.NewToast(),
+// The flags and notes parameters are shared with AppState, which updates them.
+func NewUIState(flags map[string]ui.ResourceFlags, notes map[string]string, pinned map[string]bool) *UIState {
+	s := &UIState{
+		DetailsWidth:       plugins.DefaultDetailsWidth,
+		Focus:              ui.NewFocusStack(),
+		ViewMode:           ui.ViewStack,
+		Header:             ui.NewHeader(),
+		ResourceList:       ui.NewResourceList(flags),
+		HistoryList:        ui.NewHistoryList(),
+		Help:               ui.NewHelpDialog(),
+		Details:            ui.NewDetailPanel(),
+		HistoryDetails:     ui.NewHistoryDetailPanel(),
+		HistoryDiff:        ui.NewHistoryDiffPanel(),
+		Environments:       ui.NewEnvironmentsPanel(),
+		Warnings:           ui.NewWarningsPanel(),
+		Diagnostics:        ui.NewDiagnosticsStrip(),
+		Timings:            ui.NewTimingsPanel(),
+		Logs:               ui.NewLogViewer(),
+		Code:               ui.NewCodePanel(),
+		About:              ui.NewAboutPanel(),
+		Dashboard:          ui.NewDashboard(),
+		StackSelector:      ui.NewStackSelector(),
+		WorkspaceSelector:  ui.NewWorkspaceSelector(),
+		WorkflowSelector:   ui.NewWorkflowSelector(),
+		CloudStackBrowser:  ui.NewCloudStackBrowser(),
+		TargetSetSelector:  ui.NewTargetSetSelector(),
+		SecretSelector:     ui.NewSecretSelector(),
+		ValueSelector:      ui.NewValueSelector(),
+		ValuePager:         ui.NewValuePager(),
+		ImportModal:        ui.NewImportModal(),
+		BulkImportModal:    ui.NewBulkImportModal(),
+		StateRepairModal:   ui.NewStateRepairModal(),
+		PluginIndexModal:   ui.NewPluginIndexModal(),
+		PluginStatusModal:  ui.NewPluginStatusModal(),
+		NoteModal:          ui.NewNoteModal(),
+		TagsModal:          ui.NewTagsModal(),
+		HistoryFilterModal: ui.NewHistoryFilterModal(),
+		StateFileModal:     ui.NewStateFileModal(),
+		SaveFileModal:      ui.NewSaveFileModal(),
+		TargetSetModal:     ui.NewTargetSetModal(),
+		GotoModal:          ui.NewGotoModal(),
+		ConfigCopyModal:    ui.NewConfigCopyModal(),
+		UpdateMessageModal: ui.NewUpdateMessageModal(),
+		GitGuardModal:      ui.NewGitGuardModal(),
+		ConfirmModal:       ui.NewConfirmModal(),
+		ErrorModal:         ui.NewErrorModal(),
+		StackInitModal:     ui.NewStackInitModal(),
+		NewProjectModal:    ui.NewNewProjectModal(),
+		LoginModal:         ui.NewLoginModal(),
+		LockScreen:         ui.NewLockScreen(),
+		Toast:              ui.NewToast(),
 	}
+	s.ResourceList.SetNotes(notes)
+	s.ResourceList.SetPinned(pinned)
+	s.Details.SetNotes(notes)
+	return s
 }
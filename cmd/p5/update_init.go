@@ -1,11 +1,12 @@
 package main
 
 import (
-	"fmt"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
 	"github.com/rfhold/p5/internal/ui"
 )
 
@@ -42,17 +43,17 @@ func (m Model) handlePluginInitDone(msg pluginInitDoneMsg) (tea.Model, tea.Cmd)
 	}
 
 	if msg.err != nil {
-		cmds = append(cmds, m.ui.Toast.Show(fmt.Sprintf("Plugin error: %v", msg.err)))
+		cmds = append(cmds, m.ui.Toast.Show(i18n.Tf("Plugin error: %v", msg.err)))
 	} else if len(msg.results) > 0 {
 		summary := SummarizePluginAuthResults(msg.results)
 		if len(summary.AuthenticatedPlugins) > 0 {
-			cmds = append(cmds, m.ui.Toast.Show("Authenticated: "+strings.Join(summary.AuthenticatedPlugins, ", ")))
+			cmds = append(cmds, m.ui.Toast.Show(i18n.T("Authenticated: ")+strings.Join(summary.AuthenticatedPlugins, ", ")))
 		}
 	}
 
 	if m.ctx.StackName == "" {
 		m.transitionTo(InitLoadingStacks)
-		cmds = append(cmds, m.fetchStacksList())
+		cmds = append(cmds, m.fetchInitData(), m.scheduleCredentialRefresh(), m.refreshStatusBadges())
 	} else {
 		m.transitionTo(InitLoadingResources)
 		if m.deps != nil && m.deps.PluginProvider != nil {
@@ -68,7 +69,7 @@ func (m Model) handlePluginInitDone(msg pluginInitDoneMsg) (tea.Model, tea.Cmd)
 		}
 
 		// Start auth with lock - pending ops will execute when auth completes
-		cmds = append(cmds, m.fetchProjectInfo(), m.authenticatePluginsWithLock(pendingOp))
+		cmds = append(cmds, m.fetchProjectInfo(), m.fetchStackEnvironments(), m.authenticatePluginsWithLock(pendingOp), m.recordRecentWorkspace())
 	}
 
 	return m, tea.Batch(cmds...)
@@ -84,44 +85,37 @@ func (m Model) handlePluginAuthResult(msg pluginAuthResultMsg) (tea.Model, tea.C
 
 	summary := SummarizePluginAuthResults(msg)
 
-	var cmds []tea.Cmd
+	cmds := []tea.Cmd{m.scheduleCredentialRefresh()}
 
 	if summary.HasErrors {
-		cmds = append(cmds, m.ui.Toast.Show("Plugin auth failed: "+strings.Join(summary.ErrorMessages, "; ")))
+		cmds = append(cmds, m.ui.Toast.Show(i18n.T("Plugin auth failed: ")+strings.Join(summary.ErrorMessages, "; ")))
 	} else if len(summary.AuthenticatedPlugins) > 0 {
-		cmds = append(cmds, m.ui.Toast.Show("Authenticated: "+strings.Join(summary.AuthenticatedPlugins, ", ")))
+		cmds = append(cmds, m.ui.Toast.Show(i18n.T("Authenticated: ")+strings.Join(summary.AuthenticatedPlugins, ", ")))
 	}
 
-	if len(cmds) == 0 {
-		return m, nil
-	}
 	return m, tea.Batch(cmds...)
 }
 
-// handlePluginAuthError handles plugin system errors (without lock).
-// Note: For auth with busy lock management, use authenticatePluginsWithLock which
-// returns authCompleteMsg instead.
-func (m Model) handlePluginAuthError(msg pluginAuthErrorMsg) (tea.Model, tea.Cmd) {
-	return m, m.ui.Toast.Show(fmt.Sprintf("Plugin error: %v", error(msg)))
-}
-
 // handleAuthComplete handles completion of plugin authentication with lock.
 // This always releases the auth busy lock and executes pending operations.
 func (m Model) handleAuthComplete(msg authCompleteMsg) (tea.Model, tea.Cmd) {
 	if m.deps != nil && m.deps.PluginProvider != nil {
 		m.deps.PluginProvider.ApplyEnvToProcess()
+		if m.ui.PluginStatusModal.Visible() {
+			m.ui.PluginStatusModal.SetEntries(m.pluginStatusEntries())
+		}
 	}
 
 	var cmds []tea.Cmd
 
 	if msg.err != nil {
-		cmds = append(cmds, m.ui.Toast.Show(fmt.Sprintf("Plugin error: %v", msg.err)))
+		cmds = append(cmds, m.ui.Toast.Show(i18n.Tf("Plugin error: %v", msg.err)))
 	} else if len(msg.results) > 0 {
 		summary := SummarizePluginAuthResults(msg.results)
 		if summary.HasErrors {
-			cmds = append(cmds, m.ui.Toast.Show("Plugin auth failed: "+strings.Join(summary.ErrorMessages, "; ")))
+			cmds = append(cmds, m.ui.Toast.Show(i18n.T("Plugin auth failed: ")+strings.Join(summary.ErrorMessages, "; ")))
 		} else if len(summary.AuthenticatedPlugins) > 0 {
-			cmds = append(cmds, m.ui.Toast.Show("Authenticated: "+strings.Join(summary.AuthenticatedPlugins, ", ")))
+			cmds = append(cmds, m.ui.Toast.Show(i18n.T("Authenticated: ")+strings.Join(summary.AuthenticatedPlugins, ", ")))
 		}
 	}
 
@@ -130,21 +124,62 @@ func (m Model) handleAuthComplete(msg authCompleteMsg) (tea.Model, tea.Cmd) {
 	if len(pending) > 0 {
 		cmds = append(cmds, m.executePendingOps(pending))
 	}
+	cmds = append(cmds, m.scheduleCredentialRefresh(), m.refreshStatusBadges())
 
-	if len(cmds) == 0 {
-		return m, nil
-	}
 	return m, tea.Batch(cmds...)
 }
 
 // handleProjectInfo handles project info loaded from Pulumi
 func (m Model) handleProjectInfo(msg projectInfoMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	m.setProjectInfo(msg)
+	return m, nil
+}
+
+// handleStackProtection keeps how carefully changes to the stack are confirmed
+func (m Model) handleStackProtection(msg stackProtectionMsg) (tea.Model, tea.Cmd) {
+	// Ignore results for a stack or project that is no longer selected
+	if msg.WorkDir != m.ctx.WorkDir || msg.StackName != m.ctx.StackName {
+		return m, nil
+	}
+	m.state.Protection = msg.Level
+	m.state.Confirm = msg.Confirm
+	m.state.AutoApprove = msg.AutoApprove
+	if msg.Err != nil {
+		m.deps.Logger.Warn("failed to load stack protection", "workDir", msg.WorkDir, "error", msg.Err)
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to load stack protection: %v", msg.Err))
+	}
+	return m, nil
+}
+
+// handleGitInfo shows the workspace's git checkout in the header and keeps the
+// guard checking it before up
+func (m Model) handleGitInfo(msg gitInfoMsg) (tea.Model, tea.Cmd) {
+	// Ignore results for a workspace that is no longer selected
+	if msg.WorkDir != m.ctx.WorkDir {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.deps.Logger.Warn("failed to read git checkout", "workDir", msg.WorkDir, "error", msg.Err)
+	}
+	m.state.Git = msg.Info
+	m.ui.Header.SetGit(msg.Info)
+	m.state.GitGuard = msg.Guard
+	if msg.GuardErr != nil {
+		m.deps.Logger.Warn("failed to load git guard", "workDir", msg.WorkDir, "error", msg.GuardErr)
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to load git guard: %v", msg.GuardErr))
+	}
+	return m, nil
+}
+
+// setProjectInfo shows the project in the header
+func (m *Model) setProjectInfo(info *pulumi.ProjectInfo) {
+	m.state.ProgramName = info.ProgramName
+	m.state.Runtime = info.Runtime
 	m.ui.Header.SetData(&ui.HeaderData{
-		ProgramName: msg.ProgramName,
-		StackName:   msg.StackName,
-		Runtime:     msg.Runtime,
+		ProgramName: info.ProgramName,
+		StackName:   info.StackName,
+		Runtime:     info.Runtime,
 	})
-	return m, nil
 }
 
 // handleError handles general errors.
@@ -159,6 +194,9 @@ func (m Model) handleError(msg errMsg) (tea.Model, tea.Cmd) { //nolint:unparam /
 	if m.state.InitState != InitComplete {
 		m.transitionTo(InitComplete)
 	}
+	if pulumi.IsLoginRequiredError(msg) && !m.ui.LoginModal.Visible() {
+		m.showLoginModal(msg)
+	}
 
 	return m, nil
 }
@@ -174,9 +212,24 @@ func (m Model) handleWhoAmI(msg whoAmIMsg) (tea.Model, tea.Cmd) { //nolint:unpar
 // handleStackFiles handles stack config files list for stack init
 func (m Model) handleStackFiles(msg stackFilesMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
 	m.ui.StackInitModal.SetStackFiles(msg)
+	m.ui.ConfigCopyModal.SetStackFiles(msg)
 	return m, nil
 }
 
+// handleNewProjectResult opens the project scaffolded from a template as the workspace
+func (m Model) handleNewProjectResult(msg newProjectResultMsg) (tea.Model, tea.Cmd) {
+	m.state.CreatingProject = false
+	if msg.Err != nil {
+		m.ui.NewProjectModal.SetError(msg.Err)
+		return m, nil
+	}
+	m.hideNewProjectModal()
+	return m, tea.Batch(
+		m.ui.Toast.Show(i18n.Tf("Created project '%s' in %s", msg.Name, msg.Dir)),
+		selectWorkspace(msg.Dir),
+	)
+}
+
 // handleStackInitResult handles result of stack creation.
 func (m Model) handleStackInitResult(msg stackInitResultMsg) (tea.Model, tea.Cmd) {
 	if msg.Error != nil {
@@ -191,9 +244,15 @@ func (m Model) handleStackInitResult(msg stackInitResultMsg) (tea.Model, tea.Cmd
 		m.transitionTo(InitLoadingResources)
 	}
 
+	toast := i18n.Tf("Created stack '%s'", msg.StackName)
+	if c := msg.Copied; c != nil && c.SkippedSecrets > 0 {
+		m.deps.Logger.Warn("secrets not copied", "from", c.From, "stack", msg.StackName, "error", c.SecretsErr)
+		toast = i18n.Tf("Created stack '%s', but %d secrets couldn't be decrypted", msg.StackName, c.SkippedSecrets)
+	}
 	cmds := []tea.Cmd{
-		m.ui.Toast.Show(fmt.Sprintf("Created stack '%s'", msg.StackName)),
+		m.ui.Toast.Show(toast),
 		m.fetchProjectInfo(),
+		m.recordRecentWorkspace(),
 	}
 	if m.ui.ViewMode == ui.ViewPreview {
 		cmds = append(cmds, m.initPreview(m.state.Operation))
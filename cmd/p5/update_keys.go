@@ -1,17 +1,27 @@
 package main
 
 import (
-	"fmt"
+	"crypto/subtle"
+	"errors"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/rfhold/p5/internal/i18n"
 	"github.com/rfhold/p5/internal/pulumi"
 	"github.com/rfhold/p5/internal/ui"
 )
 
 // handleKeyPress routes keyboard events to the appropriate handler based on focus stack
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.state.LastActivity = time.Now()
+
+	// The lock screen covers every other layer, including modals opened while locked
+	if m.ui.LockScreen.Visible() {
+		return m.updateLockScreen(msg)
+	}
+
 	// Route to current focus owner - O(1) lookup
 	switch m.ui.Focus.Current() {
 	case ui.FocusErrorModal:
@@ -20,14 +30,74 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.updateConfirmModal(msg)
 	case ui.FocusImportModal:
 		return m.updateImportModal(msg)
+	case ui.FocusBulkImportModal:
+		return m.updateBulkImportModal(msg)
+	case ui.FocusStateRepairModal:
+		return m.updateStateRepairModal(msg)
+	case ui.FocusPluginIndexModal:
+		return m.updatePluginIndexModal(msg)
+	case ui.FocusPluginStatusModal:
+		return m.updatePluginStatusModal(msg)
+	case ui.FocusNoteModal:
+		return m.updateNoteModal(msg)
+	case ui.FocusTagsModal:
+		return m.updateTagsModal(msg)
+	case ui.FocusHistoryFilterModal:
+		return m.updateHistoryFilterModal(msg)
+	case ui.FocusStateFileModal:
+		return m.updateStateFileModal(msg)
+	case ui.FocusSaveFileModal:
+		return m.updateSaveFileModal(msg)
+	case ui.FocusTargetSetModal:
+		return m.updateTargetSetModal(msg)
+	case ui.FocusGotoModal:
+		return m.updateGotoModal(msg)
+	case ui.FocusConfigCopyModal:
+		return m.updateConfigCopyModal(msg)
+	case ui.FocusUpdateMessageModal:
+		return m.updateUpdateMessageModal(msg)
+	case ui.FocusGitGuardModal:
+		return m.updateGitGuardModal(msg)
 	case ui.FocusStackInitModal:
 		return m.updateStackInitModal(msg)
+	case ui.FocusNewProjectModal:
+		return m.updateNewProjectModal(msg)
+	case ui.FocusLoginModal:
+		return m.updateLoginModal(msg)
 	case ui.FocusWorkspaceSelector:
 		return m.updateWorkspaceSelector(msg)
+	case ui.FocusWorkflowSelector:
+		return m.updateWorkflowSelector(msg)
+	case ui.FocusCloudStackBrowser:
+		return m.updateCloudStackBrowser(msg)
+	case ui.FocusTargetSetSelector:
+		return m.updateTargetSetSelector(msg)
+	case ui.FocusSecretSelector:
+		return m.updateSecretSelector(msg)
+	case ui.FocusValueSelector:
+		return m.updateValueSelector(msg)
+	case ui.FocusValuePager:
+		return m.updateValuePager(msg)
 	case ui.FocusStackSelector:
 		return m.updateStackSelector(msg)
 	case ui.FocusHelp:
 		return m.updateHelp(msg)
+	case ui.FocusHistoryDiff:
+		return m.updateHistoryDiff(msg)
+	case ui.FocusEnvironments:
+		return m.updateEnvironments(msg)
+	case ui.FocusWarnings:
+		return m.updateWarnings(msg)
+	case ui.FocusTimings:
+		return m.updateTimings(msg)
+	case ui.FocusCode:
+		return m.updateCodePanel(msg)
+	case ui.FocusAbout:
+		return m.updateAboutPanel(msg)
+	case ui.FocusLogs:
+		return m.updateLogs(msg)
+	case ui.FocusDashboard:
+		return m.updateDashboard(msg)
 	case ui.FocusDetailsPanel:
 		return m.updateDetailsPanel(msg)
 	case ui.FocusMain:
@@ -36,6 +106,26 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateLockScreen handles keys while the UI is locked
+func (m Model) updateLockScreen(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Quitting reveals nothing, so it doesn't require unlocking
+	if msg.String() == "ctrl+c" {
+		m.quitting = true
+		return m, tea.Quit
+	}
+	submitted, cmd := m.ui.LockScreen.Update(msg)
+	if !submitted {
+		return m, cmd
+	}
+	passphrase := m.ctx.IdleLock.Passphrase()
+	if passphrase != "" && subtle.ConstantTimeCompare([]byte(m.ui.LockScreen.Passphrase()), []byte(passphrase)) != 1 {
+		m.ui.LockScreen.SetError(i18n.T("Wrong passphrase"))
+		return m, nil
+	}
+	m.ui.LockScreen.Unlock()
+	return m, nil
+}
+
 // updateErrorModal handles keys when error modal has focus
 func (m Model) updateErrorModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	dismissed, cmd := m.ui.ErrorModal.Update(msg)
@@ -47,12 +137,29 @@ func (m Model) updateErrorModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateConfirmModal handles keys when confirm modal has focus
 func (m Model) updateConfirmModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Toggle removing the resources that depend on a state delete selection
+	if pending := m.state.PendingStateDelete; pending != nil && msg.String() == "d" {
+		pending.IncludeDependents = !pending.IncludeDependents
+		m.showStateDeleteConfirm()
+		return m, nil
+	}
 	confirmed, cancelled, cmd := m.ui.ConfirmModal.Update(msg)
 	if confirmed {
 		// Block confirmations while busy (e.g., waiting for auth)
 		if m.state.IsBusy() {
 			return m, nil
 		}
+		// Check if this is the next step of the operation queue
+		if q := m.state.OperationQueue; q != nil && q.Awaiting {
+			m.hideConfirmModal()
+			return m, m.runQueueStep()
+		}
+		// Check if the stack name was typed to change a protected stack
+		if pending := m.state.PendingExecution; pending != nil {
+			m.state.PendingExecution = nil
+			m.hideConfirmModal()
+			return m, m.guardExecution(pending.Op, pending.Opts)
+		}
 		// Check if this is a pending operation confirmation
 		if m.state.PendingOperation != nil {
 			op := *m.state.PendingOperation
@@ -60,13 +167,54 @@ func (m Model) updateConfirmModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.hideConfirmModal()
 			return m, m.startExecution(op)
 		}
+		// Check if this is a drift action confirmation
+		if m.state.PendingDriftAction != nil {
+			action := m.state.PendingDriftAction
+			m.state.PendingDriftAction = nil
+			m.hideConfirmModal()
+			return m, m.startExecutionWithOptions(action.Op, action.Options())
+		}
+		// Check if this is recovering a locked stack
+		if m.state.PendingStackRecovery {
+			cmd := m.recoverStack()
+			m.state.PendingStackRecovery = false
+			m.hideConfirmModal()
+			return m, cmd
+		}
+		// Check if this is removing the stack a destroy emptied
+		if m.state.PendingStackRemoval {
+			m.state.PendingStackRemoval = false
+			m.hideConfirmModal()
+			return m, m.removeStack()
+		}
+		// Check if this is clearing the pending operations of a cancelled update
+		if m.state.PendingClearOperations {
+			m.state.PendingClearOperations = false
+			m.hideConfirmModal()
+			return m, m.clearPendingOperations()
+		}
+		// Check if this is replacing the stack's state with a deployment file
+		if p := m.state.PendingStateImport; p != "" {
+			m.state.PendingStateImport = ""
+			m.hideConfirmModal()
+			return m, tea.Batch(
+				m.ui.Toast.Show(i18n.T("Importing state...")),
+				m.importState(p),
+			)
+		}
+		// Check if this is writing an edited resource state
+		if m.state.PendingResourceState != nil {
+			m.hideConfirmModal()
+			return m, m.confirmResourceState()
+		}
 		// Check if this is a pending protect action confirmation
 		if m.state.PendingProtectAction != nil {
 			action := m.state.PendingProtectAction
 			m.state.PendingProtectAction = nil
 			m.hideConfirmModal()
-			return m, m.executeProtect(action.URN, action.Name, action.Protect)
+			return m, m.executeProtect(action.Resources, action.Protect)
 		}
+		m.state.PendingStateDelete = nil
 		// Check if this is a bulk state delete confirmation
 		if m.ui.ConfirmModal.IsBulkOperation() {
 			return m, m.executeBulkStateDelete()
@@ -75,17 +223,33 @@ func (m Model) updateConfirmModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, m.executeStateDelete()
 	}
 	if cancelled {
+		protected := m.state.PendingExecution != nil
+		m.state.PendingExecution = nil
 		m.state.PendingOperation = nil
+		m.state.PendingDriftAction = nil
 		m.state.PendingProtectAction = nil
+		m.state.PendingStackRecovery = false
+		m.state.PendingClearOperations = false
+		m.state.PendingStackRemoval = false
+		m.state.PendingStateImport = ""
+		m.state.PendingStateDelete = nil
+		m.discardResourceState()
 		m.hideConfirmModal()
+		if q := m.state.OperationQueue; q != nil && (q.Awaiting || protected) {
+			return m, tea.Batch(cmd, m.stopQueue(i18n.T("Operation queue stopped")))
+		}
 	}
 	return m, cmd
 }
 
 // updateImportModal handles keys when import modal has focus
 func (m Model) updateImportModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	confirmed, cmd := m.ui.ImportModal.Update(msg)
-	if confirmed {
+	importID := m.ui.ImportModal.GetImportID()
+	action, cmd := m.ui.ImportModal.Update(msg)
+	switch action {
+	case ui.ImportActionPreview:
+		return m, m.previewImport()
+	case ui.ImportActionConfirm:
 		// Block import while busy (e.g., waiting for auth)
 		if m.state.IsBusy() {
 			return m, nil
@@ -96,6 +260,107 @@ func (m Model) updateImportModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Check if modal was dismissed (ESC pressed)
 	if !m.ui.ImportModal.Visible() {
 		m.ui.Focus.Remove(ui.FocusImportModal)
+		return m, cmd
+	}
+	if m.ui.ImportModal.GetImportID() != importID {
+		cmd = tea.Batch(cmd, m.scheduleImportIDValidation())
+	}
+	return m, cmd
+}
+
+// updateNoteModal handles keys when the note modal has focus
+func (m Model) updateNoteModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	saved, cmd := m.ui.NoteModal.Update(msg)
+	if saved {
+		m.hideNoteModal()
+		return m, m.saveNote(m.ui.NoteModal.GetResourceURN(), m.ui.NoteModal.GetNote())
+	}
+	// Check if modal was dismissed (ESC pressed)
+	if !m.ui.NoteModal.Visible() {
+		m.ui.Focus.Remove(ui.FocusNoteModal)
+	}
+	return m, cmd
+}
+
+// updateGotoModal handles keys when the goto prompt has focus. The prompt stays
+// open if no resource matches, so the query can be corrected.
+func (m Model) updateGotoModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.GotoModal.Update(msg)
+	switch action {
+	case ui.StepModalActionConfirm:
+		query := m.ui.GotoModal.Query()
+		if m.ui.ResourceList.JumpTo(query) == nil {
+			m.ui.GotoModal.SetError(errors.New(i18n.Tf("No resource matches %s", query)))
+			return m, cmd
+		}
+		m.hideGotoModal()
+		m.syncDetailsPanel()
+	case ui.StepModalActionCancel:
+		m.hideGotoModal()
+	}
+	return m, cmd
+}
+
+// updateHistoryFilterModal handles keys when the history filter modal has focus
+func (m Model) updateHistoryFilterModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.HistoryFilterModal.Update(msg)
+	switch action {
+	case ui.HistoryFilterActionApply:
+		m.hideHistoryFilterModal()
+		m.ui.HistoryList.SetHistoryFilter(m.ui.HistoryFilterModal.Filter())
+		m.syncDetailsPanel()
+		// Fewer matches may leave the cursor near the end of what is loaded
+		return m, tea.Batch(cmd, m.loadMoreHistory(), m.loadHistoryChanges())
+	case ui.HistoryFilterActionCancel:
+		m.hideHistoryFilterModal()
+	}
+	return m, cmd
+}
+
+// updateStateRepairModal handles keys when the state repair modal has focus
+func (m Model) updateStateRepairModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.StateRepairModal.Update(msg)
+	switch action {
+	case ui.StateRepairActionDryRun:
+		return m, m.executeStateRepair(true)
+	case ui.StateRepairActionApply:
+		// Block writes while busy (e.g., waiting for auth), keeping the report open
+		if m.state.IsBusy() {
+			m.ui.StateRepairModal.SetReport(m.ui.StateRepairModal.Report())
+			return m, nil
+		}
+		return m, m.executeStateRepair(false)
+	case ui.StateRepairActionCancel:
+		m.hideStateRepairModal()
+	}
+	return m, cmd
+}
+
+// updateBulkImportModal handles keys when the bulk import modal has focus
+func (m Model) updateBulkImportModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.BulkImportModal.Update(msg)
+	switch action {
+	case ui.BulkImportActionConfirm:
+		// Block import while busy (e.g., waiting for auth)
+		if m.state.IsBusy() {
+			return m, nil
+		}
+		// Keep focus on the hidden modal until the result arrives
+		count := len(m.ui.BulkImportModal.SelectedItems())
+		m.ui.BulkImportModal.Hide()
+		return m, tea.Batch(
+			m.ui.Toast.Show(i18n.Tf("Importing %d resources...", count)),
+			m.executeBulkImport(),
+		)
+	case ui.BulkImportActionLoadMore:
+		pending := m.state.PendingBulkImport
+		if pending == nil || len(pending.PageTokens) == 0 {
+			return m, nil
+		}
+		m.ui.BulkImportModal.SetLoadingMore()
+		return m, m.fetchImportableResources(pending.Item, pending.PageTokens)
+	case ui.BulkImportActionCancel:
+		m.hideBulkImportModal()
 	}
 	return m, cmd
 }
@@ -109,34 +374,51 @@ func (m Model) updateStackInitModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.state.IsBusy() {
 			return m, nil
 		}
-		// User completed all configured steps, init the stack
-		name := m.ui.StackInitModal.GetStackName()
-		provider := m.ui.StackInitModal.GetSecretsProvider()
-		passphrase := m.ui.StackInitModal.GetPassphrase()
-		return m, m.initStack(name, provider, passphrase)
-	case ui.StepModalActionNext:
-		// After secrets provider step (step 1 -> step 2), check if we should skip passphrase
-		currentStep := m.ui.StackInitModal.CurrentStep()
-		if currentStep == 2 && m.ui.StackInitModal.ShouldSkipPassphrase() {
-			// Block stack init while busy (e.g., waiting for auth)
-			if m.state.IsBusy() {
-				return m, nil
-			}
-			// Skip passphrase step, init directly
-			name := m.ui.StackInitModal.GetStackName()
-			provider := m.ui.StackInitModal.GetSecretsProvider()
-			return m, m.initStack(name, provider, "")
-		}
+		// User completed all steps that apply, init the stack
+		return m, m.initStack(m.ui.StackInitModal.GetStackName(), pulumi.InitStackOptions{
+			SecretsProvider: m.ui.StackInitModal.GetSecretsProvider(),
+			Passphrase:      m.ui.StackInitModal.GetPassphrase(),
+			CopyConfigFrom:  m.ui.StackInitModal.GetCopyConfigFrom(),
+			Config:          m.ui.StackInitModal.GetConfig(),
+		})
 	case ui.StepModalActionCancel:
 		m.hideStackInitModal()
 	}
 	return m, cmd
 }
 
+// updateNewProjectModal handles keys when the new project modal has focus
+func (m Model) updateNewProjectModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action, cmd := m.ui.NewProjectModal.Update(msg)
+	switch action {
+	case ui.StepModalActionConfirm:
+		// Ignore confirming again while the project is being created
+		if m.state.CreatingProject {
+			return m, nil
+		}
+		return m, m.newProject(m.ui.NewProjectModal.Dir(), m.ui.NewProjectModal.Options())
+	case ui.StepModalActionCancel:
+		m.hideNewProjectModal()
+	}
+	return m, cmd
+}
+
 // updateWorkspaceSelector handles keys when workspace selector has focus
 func (m Model) updateWorkspaceSelector(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, ui.Keys.PinWorkspace) && !m.ui.WorkspaceSelector.FilterActive() {
+		if ws := m.ui.WorkspaceSelector.SelectedWorkspace(); ws != nil {
+			return m, m.pinWorkspace(ws.Path, !ws.Pinned)
+		}
+		return m, nil
+	}
 	selected, cmd := m.ui.WorkspaceSelector.Update(msg)
 	if selected {
+		// Check if "new project" was selected
+		if m.ui.WorkspaceSelector.IsNewProjectSelected() {
+			m.hideWorkspaceSelector()
+			m.showNewProjectModal()
+			return m, nil
+		}
 		// Workspace was selected, update and reload
 		selectedWs := m.ui.WorkspaceSelector.SelectedWorkspace()
 		if selectedWs != nil {
@@ -194,6 +476,186 @@ func (m Model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateHistoryDiff handles keys when the history diff panel has focus
+func (m Model) updateHistoryDiff(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	panel := m.ui.HistoryDiff
+	switch {
+	case key.Matches(msg, ui.Keys.Up):
+		panel.ScrollUp(1)
+	case key.Matches(msg, ui.Keys.Down):
+		panel.ScrollDown(1)
+	case key.Matches(msg, ui.Keys.PageUp):
+		panel.ScrollUp(10)
+	case key.Matches(msg, ui.Keys.PageDown):
+		panel.ScrollDown(10)
+	case key.Matches(msg, ui.Keys.Home):
+		panel.SetScrollOffset(0)
+	case key.Matches(msg, ui.Keys.End):
+		// Set to a large value - the render will clamp it
+		panel.SetScrollOffset(9999)
+	case key.Matches(msg, ui.Keys.ToggleRawJSON):
+		m.toggleRawJSONDiff()
+	case key.Matches(msg, ui.Keys.Escape), key.Matches(msg, ui.Keys.HistoryDiff):
+		m.hideHistoryDiff()
+	case key.Matches(msg, ui.Keys.Help):
+		m.showHelp()
+	}
+	return m, nil
+}
+
+// updateEnvironments handles keys when the ESC environments panel has focus
+func (m Model) updateEnvironments(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	panel := m.ui.Environments
+	switch {
+	case key.Matches(msg, ui.Keys.Up):
+		panel.ScrollUp(1)
+	case key.Matches(msg, ui.Keys.Down):
+		panel.ScrollDown(1)
+	case key.Matches(msg, ui.Keys.PageUp):
+		panel.ScrollUp(10)
+	case key.Matches(msg, ui.Keys.PageDown):
+		panel.ScrollDown(10)
+	case key.Matches(msg, ui.Keys.Home):
+		panel.SetScrollOffset(0)
+	case key.Matches(msg, ui.Keys.End):
+		// Set to a large value - the render will clamp it
+		panel.SetScrollOffset(9999)
+	case msg.String() == "s":
+		panel.ToggleReveal()
+	case key.Matches(msg, ui.Keys.Escape), key.Matches(msg, ui.Keys.ViewEnvironments), key.Matches(msg, ui.Keys.Quit):
+		m.hideEnvironments()
+	case key.Matches(msg, ui.Keys.Help):
+		m.showHelp()
+	}
+	return m, nil
+}
+
+// updateWarnings handles keys when the preview warnings panel has focus
+func (m Model) updateWarnings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	panel := m.ui.Warnings
+	switch {
+	case msg.String() == "enter":
+		warning := panel.SelectedWarning()
+		if warning == nil || warning.URN == "" {
+			return m, nil
+		}
+		m.hideWarnings()
+		if !m.ui.ResourceList.SelectURN(warning.URN) {
+			return m, m.ui.Toast.Show(i18n.Tf("'%s' is not shown in the resource list", pulumi.ExtractResourceName(warning.URN)))
+		}
+		if m.ui.Details.Visible() {
+			m.ui.Details.SetResource(m.ui.ResourceList.SelectedItem())
+		}
+	case key.Matches(msg, ui.Keys.Up):
+		panel.MoveCursor(-1)
+	case key.Matches(msg, ui.Keys.Down):
+		panel.MoveCursor(1)
+	case key.Matches(msg, ui.Keys.PageUp):
+		panel.MoveCursor(-5)
+	case key.Matches(msg, ui.Keys.PageDown):
+		panel.MoveCursor(5)
+	case key.Matches(msg, ui.Keys.Home):
+		panel.MoveCursor(-len(panel.Warnings()))
+	case key.Matches(msg, ui.Keys.End):
+		panel.MoveCursor(len(panel.Warnings()))
+	case key.Matches(msg, ui.Keys.Escape), key.Matches(msg, ui.Keys.ViewWarnings), key.Matches(msg, ui.Keys.Quit):
+		m.hideWarnings()
+	case key.Matches(msg, ui.Keys.Help):
+		m.showHelp()
+	}
+	return m, nil
+}
+
+// updateLogs handles keys when the debug log viewer has focus
+func (m Model) updateLogs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	panel := m.ui.Logs
+	switch {
+	case msg.String() == "l":
+		panel.CycleLevel()
+	case key.Matches(msg, ui.Keys.Up):
+		panel.Scroll(-1)
+	case key.Matches(msg, ui.Keys.Down):
+		panel.Scroll(1)
+	case key.Matches(msg, ui.Keys.PageUp):
+		panel.Scroll(-10)
+	case key.Matches(msg, ui.Keys.PageDown):
+		panel.Scroll(10)
+	case key.Matches(msg, ui.Keys.Home):
+		panel.Scroll(-len(panel.VisibleEntries()))
+	case key.Matches(msg, ui.Keys.End):
+		panel.Scroll(len(panel.VisibleEntries()))
+	case key.Matches(msg, ui.Keys.Escape), key.Matches(msg, ui.Keys.ViewLogs), key.Matches(msg, ui.Keys.Quit):
+		m.hideLogs()
+	case key.Matches(msg, ui.Keys.Help):
+		m.showHelp()
+	}
+	return m, nil
+}
+
+// updateTimings handles keys when the slowest resources panel has focus
+func (m Model) updateTimings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	panel := m.ui.Timings
+	switch {
+	case msg.String() == "enter":
+		item := panel.SelectedItem()
+		if item == nil {
+			return m, nil
+		}
+		m.hideTimings()
+		if !m.ui.ResourceList.SelectURN(item.URN) {
+			return m, m.ui.Toast.Show(i18n.Tf("'%s' is not shown in the resource list", item.Name))
+		}
+		if m.ui.Details.Visible() {
+			m.ui.Details.SetResource(m.ui.ResourceList.SelectedItem())
+		}
+	case key.Matches(msg, ui.Keys.Up):
+		panel.MoveCursor(-1)
+	case key.Matches(msg, ui.Keys.Down):
+		panel.MoveCursor(1)
+	case key.Matches(msg, ui.Keys.Home):
+		panel.MoveCursor(-len(panel.Slowest()))
+	case key.Matches(msg, ui.Keys.End):
+		panel.MoveCursor(len(panel.Slowest()))
+	case key.Matches(msg, ui.Keys.Escape), key.Matches(msg, ui.Keys.ViewTimings), key.Matches(msg, ui.Keys.Quit):
+		m.hideTimings()
+	case key.Matches(msg, ui.Keys.Help):
+		m.showHelp()
+	}
+	return m, nil
+}
+
+// updateDashboard handles keys when the multi-stack dashboard has focus
+func (m Model) updateDashboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	dashboard := m.ui.Dashboard
+	switch {
+	case msg.String() == "enter":
+		// Block stack switching while busy (e.g., waiting for auth)
+		if m.state.IsBusy() || dashboard.IsLoading() {
+			return m, nil
+		}
+		row := dashboard.SelectedRow()
+		if row == nil || row.Err != nil {
+			return m, nil
+		}
+		return m, selectDashboardStack(row.WorkDir, row.Stack)
+	case msg.String() == "r":
+		if dashboard.IsLoading() {
+			return m, nil
+		}
+		return m, tea.Batch(dashboard.Spinner().Tick, m.fetchDashboard())
+	case key.Matches(msg, ui.Keys.Escape), key.Matches(msg, ui.Keys.ViewDashboard):
+		if dashboard.Closable() {
+			m.hideDashboard()
+		}
+	case key.Matches(msg, ui.Keys.Quit):
+		m.quitting = true
+		return m, tea.Quit
+	default:
+		dashboard.Update(msg)
+	}
+	return m, nil
+}
+
 // updateDetailsPanel handles keys when details panel has focus
 func (m Model) updateDetailsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Get the appropriate panel based on view mode
@@ -204,13 +666,30 @@ func (m Model) updateDetailsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		panel = m.ui.Details
 	}
 
+	// While a search is typed every key goes to it, and once applied
+	// n/N move between matches and esc clears it before closing the panel
+	if panel.SearchActive() {
+		cmd, _ := panel.Update(msg)
+		return m, cmd
+	}
+	if cmd, handled := panel.Update(msg); handled {
+		return m, cmd
+	}
+
 	// Handle scroll keys
 	switch {
 	case key.Matches(msg, ui.Keys.Up):
-		panel.ScrollUp(1)
+		if m.ui.ViewMode != ui.ViewHistory || !m.ui.HistoryDetails.MoveChangeCursor(-1) {
+			panel.ScrollUp(1)
+		}
 		return m, nil
 	case key.Matches(msg, ui.Keys.Down):
-		panel.ScrollDown(1)
+		if m.ui.ViewMode != ui.ViewHistory || !m.ui.HistoryDetails.MoveChangeCursor(1) {
+			panel.ScrollDown(1)
+		}
+		return m, nil
+	case key.Matches(msg, ui.Keys.HistoryDiff) && m.ui.ViewMode == ui.ViewHistory:
+		m.showHistoryChangeDiff()
 		return m, nil
 	case key.Matches(msg, ui.Keys.PageUp):
 		panel.ScrollUp(10)
@@ -225,6 +704,23 @@ func (m Model) updateDetailsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Set to a large value - the render will clamp it
 		panel.SetScrollOffset(9999)
 		return m, nil
+	case key.Matches(msg, ui.Keys.ToggleRawJSON) && m.ui.ViewMode != ui.ViewHistory:
+		m.toggleRawJSONDiff()
+		return m, nil
+	case key.Matches(msg, ui.Keys.RevealSecret) && m.ui.ViewMode != ui.ViewHistory:
+		return m, m.revealSecret()
+	case key.Matches(msg, ui.Keys.ViewValue) && m.ui.ViewMode != ui.ViewHistory:
+		return m, m.viewValue()
+	case key.Matches(msg, ui.Keys.WidenDetails):
+		if m.resizeDetailsPanel(m.ui.DetailsWidth + detailsWidthStep) {
+			return m, m.awaitDetailsResizeEnd()
+		}
+		return m, nil
+	case key.Matches(msg, ui.Keys.NarrowDetails):
+		if m.resizeDetailsPanel(m.ui.DetailsWidth - detailsWidthStep) {
+			return m, m.awaitDetailsResizeEnd()
+		}
+		return m, nil
 	case key.Matches(msg, ui.Keys.Escape), key.Matches(msg, ui.Keys.ToggleDetails):
 		// Close details panel
 		m.hideDetailsPanel()
@@ -253,6 +749,11 @@ func (m Model) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return model, cmd
 	}
 
+	// Browsing exported state leaves nothing to select or run
+	if m.ctx.StartView == "state" {
+		return m.handleStateBrowserKeys(msg)
+	}
+
 	// View toggles: details, stack selector, workspace selector, history
 	if model, cmd, handled := m.handleViewToggles(msg); handled {
 		return model, cmd
@@ -280,6 +781,9 @@ func (m Model) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 	case key.Matches(msg, ui.Keys.Escape):
 		model, cmd := m.handleEscape()
 		return model, cmd, true
+	case key.Matches(msg, ui.Keys.ToggleHints):
+		m.ui.ExpandedHints = !m.ui.ExpandedHints
+		return m, nil, true
 	case key.Matches(msg, ui.Keys.Quit):
 		m.quitting = true
 		return m, tea.Quit, true
@@ -291,7 +795,7 @@ func (m Model) handleViewToggles(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 	switch {
 	case key.Matches(msg, ui.Keys.ToggleDetails):
 		m.toggleDetailsPanel()
-		return m, nil, true
+		return m, m.loadHistoryChanges(), true
 	case key.Matches(msg, ui.Keys.SelectStack):
 		// Block stack selection while busy (e.g., waiting for auth)
 		if m.state.IsBusy() {
@@ -306,12 +810,103 @@ func (m Model) handleViewToggles(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 		}
 		m.showWorkspaceSelector()
 		return m, m.fetchWorkspacesList(), true
+	case key.Matches(msg, ui.Keys.BrowseCloudStacks):
+		if m.state.IsBusy() || m.state.OpState.IsActive() || m.ui.ViewMode != ui.ViewStack {
+			return m, nil, false
+		}
+		m.showCloudStackBrowser()
+		return m, m.fetchCloudStacks(), true
+	case key.Matches(msg, ui.Keys.ReloadStack):
+		cmd := m.reloadStack()
+		return m, cmd, cmd != nil
 	case key.Matches(msg, ui.Keys.ViewHistory):
 		// Block history view while busy (e.g., waiting for auth)
 		if m.state.IsBusy() {
 			return m, nil, false
 		}
 		return m, m.switchToHistoryView(), true
+	case key.Matches(msg, ui.Keys.FilterHistory):
+		if m.ui.ViewMode != ui.ViewHistory {
+			return m, nil, false
+		}
+		m.showHistoryFilterModal()
+		return m, nil, true
+	case key.Matches(msg, ui.Keys.HistoryDiff):
+		if m.ui.ViewMode != ui.ViewHistory {
+			return m, nil, false
+		}
+		item := m.ui.HistoryList.SelectedItem()
+		if item == nil {
+			return m, nil, false
+		}
+		// Snapshots are exported by version, which local backends don't track
+		if item.BackendVersion == 0 {
+			return m, m.ui.Toast.Show(i18n.T("This backend doesn't track update versions, so updates can't be diffed")), true
+		}
+		m.showHistoryDiff(item.BackendVersion)
+		if items, ok := m.state.HistoryChanges[item.BackendVersion]; ok {
+			m.ui.HistoryDiff.SetDiff(item.BackendVersion, items)
+			return m, nil, true
+		}
+		return m, m.fetchHistoryDiff(item.BackendVersion), true
+	case key.Matches(msg, ui.Keys.ViewEnvironments):
+		// Block while busy (e.g., waiting for auth or stack selection)
+		if m.state.IsBusy() || m.ctx.StackName == "" {
+			return m, nil, false
+		}
+		m.showEnvironments()
+		return m, m.fetchStackEnvironments(), true
+	case key.Matches(msg, ui.Keys.ViewWarnings):
+		if m.ui.ViewMode != ui.ViewPreview {
+			return m, nil, false
+		}
+		m.showWarnings()
+		return m, nil, true
+	case key.Matches(msg, ui.Keys.ToggleDiagnostics):
+		if m.ui.ViewMode != ui.ViewPreview || len(m.ui.Diagnostics.Diagnostics()) == 0 {
+			return m, nil, false
+		}
+		m.ui.Diagnostics.Toggle()
+		return m, nil, true
+	case key.Matches(msg, ui.Keys.ViewTimings):
+		// Available once the operation finished, its resources are timed by then
+		if m.ui.ViewMode != ui.ViewExecute || m.state.OpState.IsActive() {
+			return m, nil, false
+		}
+		m.showTimings()
+		return m, nil, true
+	case key.Matches(msg, ui.Keys.ViewLogs):
+		if m.deps.Logs == nil {
+			return m, m.ui.Toast.Show(i18n.T("Start p5 with --debug to capture logs")), true
+		}
+		return m, m.showLogs(), true
+	case key.Matches(msg, ui.Keys.ViewAbout):
+		return m, m.showAbout(), true
+	case key.Matches(msg, ui.Keys.ViewDashboard):
+		// Block while busy (e.g., waiting for auth)
+		if m.state.IsBusy() {
+			return m, nil, false
+		}
+		m.showDashboard(true)
+		return m, tea.Batch(m.ui.Dashboard.Spinner().Tick, m.fetchDashboard()), true
+	case key.Matches(msg, ui.Keys.PluginIndex):
+		if m.deps.PluginInstaller == nil {
+			return m, nil, false
+		}
+		m.showPluginIndexModal()
+		return m, m.fetchPluginIndex(), true
+	case key.Matches(msg, ui.Keys.PluginStatus):
+		if m.deps.PluginProvider == nil {
+			return m, nil, false
+		}
+		m.showPluginStatusModal()
+		return m, nil, true
+	case key.Matches(msg, ui.Keys.StackTags):
+		cmd := m.openStackTags()
+		return m, cmd, cmd != nil
+	case key.Matches(msg, ui.Keys.CopyConfig):
+		cmd := m.openConfigCopy()
+		return m, cmd, cmd != nil
 	}
 	return m, nil, false
 }
@@ -329,6 +924,22 @@ func (m Model) handleResourceActions(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool)
 			m.showImportModal(item.Type, item.Name, item.URN, item.Parent)
 			return m, m.fetchImportSuggestions(item.Type, item.Name, item.URN, item.Parent, item.Provider, item.Inputs, item.ProviderInputs), true
 		}
+	case key.Matches(msg, ui.Keys.BulkImport):
+		item := m.ui.ResourceList.SelectedItem()
+		if CanImportResource(m.ui.ViewMode, item) {
+			if m.deps.PluginProvider == nil || !m.deps.PluginProvider.HasImportHelpers() {
+				return m, m.ui.Toast.Show(i18n.T("No plugin can discover existing resources")), true
+			}
+			m.showBulkImportModal(*item)
+			return m, m.fetchImportableResources(*item, nil), true
+		}
+	case key.Matches(msg, ui.Keys.EditNote):
+		item := m.ui.ResourceList.SelectedItem()
+		if m.ui.ViewMode == ui.ViewHistory || item == nil || item.URN == "" {
+			return m, nil, false
+		}
+		m.showNoteModal(item)
+		return m, nil, true
 	case key.Matches(msg, ui.Keys.DeleteFromState):
 		// Get all selected resources that can be deleted from state
 		resources := m.ui.ResourceList.GetSelectedResourcesForStateDelete()
@@ -339,59 +950,182 @@ func (m Model) handleResourceActions(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool)
 		if m.ui.ViewMode != ui.ViewStack {
 			return m, nil, false
 		}
-		m.ui.ConfirmModal.SetLabels("Cancel", "Delete")
+		if m.state.BulkStateDelete != nil {
+			return m, m.ui.Toast.Show(i18n.T("Resources are still being removed from state")), true
+		}
+		m.state.PendingStateDelete = &PendingStateDelete{Selected: resources}
+		m.showStateDeleteConfirm()
+		m.showConfirmModal()
+		return m, nil, true
+	case key.Matches(msg, ui.Keys.Protect):
+		if m.ui.ViewMode != ui.ViewStack {
+			return m, nil, false
+		}
+		resources := m.ui.ResourceList.GetSelectedResourcesForProtect(true)
+		if len(resources) == 0 {
+			return m, m.ui.Toast.Show(i18n.T("Nothing to protect in selection")), true
+		}
+		// Protecting executes immediately (it's a safety action)
+		return m, m.executeProtect(resources, true), true
+	case key.Matches(msg, ui.Keys.Unprotect):
+		if m.ui.ViewMode != ui.ViewStack {
+			return m, nil, false
+		}
+		resources := m.ui.ResourceList.GetSelectedResourcesForProtect(false)
+		if len(resources) == 0 {
+			return m, m.ui.Toast.Show(i18n.T("Nothing to unprotect in selection")), true
+		}
+		// Unprotecting requires confirmation (makes resources destroyable)
+		m.ui.ConfirmModal.SetLabels(i18n.T("Cancel"), i18n.T("Unprotect"))
 		if len(resources) == 1 {
-			// Single resource - use existing single-item flow
 			m.ui.ConfirmModal.ShowWithContext(
-				"Delete from State",
-				fmt.Sprintf("Remove '%s' from Pulumi state?\n\nType: %s", resources[0].Name, resources[0].Type),
-				"This will NOT delete the actual resource.\nThe resource will become unmanaged by Pulumi.",
+				i18n.T("Unprotect Resource"),
+				i18n.Tf("Remove protection from '%s'?\n\nType: %s", resources[0].Name, resources[0].Type),
+				i18n.T("This will allow the resource to be destroyed."),
 				resources[0].URN,
 				resources[0].Name,
 				resources[0].Type,
 			)
 		} else {
-			// Multiple resources - use bulk flow
 			m.ui.ConfirmModal.ShowBulkWithContext(
-				"Delete from State",
-				fmt.Sprintf("Remove %d resources from Pulumi state?", len(resources)),
-				"This will NOT delete the actual resources.\nThey will become unmanaged by Pulumi.",
+				i18n.T("Unprotect Resources"),
+				i18n.Tf("Remove protection from %d resources?", len(resources)),
+				i18n.T("This will allow the resources to be destroyed."),
 				resources,
 			)
 		}
 		m.showConfirmModal()
+		m.state.PendingProtectAction = &PendingProtectAction{
+			Resources: resources,
+			Protect:   false,
+		}
 		return m, nil, true
-	case key.Matches(msg, ui.Keys.ToggleProtect):
-		item := m.ui.ResourceList.SelectedItem()
-		if CanProtectResource(m.ui.ViewMode, item) {
-			if item.Protected {
-				// Unprotecting requires confirmation (makes resource destroyable)
-				m.ui.ConfirmModal.SetLabels("Cancel", "Unprotect")
-				m.ui.ConfirmModal.ShowWithContext(
-					"Unprotect Resource",
-					fmt.Sprintf("Remove protection from '%s'?\n\nType: %s", item.Name, item.Type),
-					"This will allow the resource to be destroyed.",
-					item.URN,
-					item.Name,
-					item.Type,
-				)
-				m.showConfirmModal()
-				m.state.PendingProtectAction = &PendingProtectAction{
-					URN:     item.URN,
-					Name:    item.Name,
-					Protect: false,
-				}
-				return m, nil, true
-			}
-			// Protecting executes immediately (it's a safety action)
-			return m, m.executeProtect(item.URN, item.Name, true), true
+	case key.Matches(msg, ui.Keys.ClearSaved):
+		if m.ui.ViewMode == ui.ViewHistory {
+			return m, nil, false
+		}
+		// Clear the flags in memory too, so the next change doesn't save them again
+		m.ui.ResourceList.ClearAllFlags()
+		return m, m.clearSavedFlags(), true
+	case key.Matches(msg, ui.Keys.UndoFlags, ui.Keys.RedoFlags):
+		if m.ui.ViewMode == ui.ViewHistory {
+			return m, nil, false
+		}
+		undo := key.Matches(msg, ui.Keys.UndoFlags)
+		if undo && !m.ui.ResourceList.UndoFlags() {
+			return m, m.ui.Toast.Show(i18n.T("Nothing to undo")), true
+		}
+		if !undo && !m.ui.ResourceList.RedoFlags() {
+			return m, m.ui.Toast.Show(i18n.T("Nothing to redo")), true
+		}
+		if m.ui.Focus.Has(ui.FocusDetailsPanel) {
+			m.ui.Details.SetResource(m.ui.ResourceList.SelectedItem())
+		}
+		m.dropChangedPlan()
+		if m.state.PersistFlags {
+			return m, m.saveFlags(), true
+		}
+		return m, nil, true
+	case key.Matches(msg, ui.Keys.PinResource):
+		if m.ui.ViewMode == ui.ViewHistory {
+			return m, nil, false
+		}
+		item, pinned := m.ui.ResourceList.TogglePinned()
+		if item == nil {
+			return m, nil, false
+		}
+		toast := i18n.Tf("Unpinned %s", item.Name)
+		if pinned {
+			toast = i18n.Tf("Pinned %s", item.Name)
+		}
+		return m, tea.Batch(m.ui.Toast.Show(toast), m.savePinned()), true
+	case key.Matches(msg, ui.Keys.GotoResource):
+		if m.ui.ViewMode == ui.ViewHistory {
+			return m, nil, false
+		}
+		m.showGotoModal()
+		return m, nil, true
+	case key.Matches(msg, ui.Keys.NextChange, ui.Keys.PrevChange):
+		if m.ui.ViewMode == ui.ViewHistory {
+			return m, nil, false
+		}
+		dir := 1
+		if key.Matches(msg, ui.Keys.PrevChange) {
+			dir = -1
+		}
+		if !m.ui.ResourceList.JumpToChange(dir) {
+			return m, m.ui.Toast.Show(i18n.T("No more changes")), true
 		}
+		m.syncDetailsPanel()
+		return m, nil, true
+	case key.Matches(msg, ui.Keys.CycleGrouping):
+		if m.ui.ViewMode == ui.ViewHistory {
+			return m, nil, false
+		}
+		grouping := m.ui.ResourceList.Grouping().Next()
+		m.ui.ResourceList.SetGrouping(grouping)
+		if m.ui.Focus.Has(ui.FocusDetailsPanel) {
+			m.ui.Details.SetResource(m.ui.ResourceList.SelectedItem())
+		}
+		return m, m.ui.Toast.Show(grouping.Label()), true
+	case key.Matches(msg, ui.Keys.TargetSets):
+		if m.ui.ViewMode == ui.ViewHistory {
+			return m, nil, false
+		}
+		m.showTargetSetSelector()
+		return m, m.fetchTargetSets(), true
+	case key.Matches(msg, ui.Keys.RepairState):
+		if m.ui.ViewMode != ui.ViewStack {
+			return m, nil, false
+		}
+		if len(m.state.StateIssues) == 0 {
+			return m, m.ui.Toast.Show(i18n.T("No issues found in stack state")), true
+		}
+		m.showStateRepairModal()
+		return m, nil, true
+	case key.Matches(msg, ui.Keys.CopyMarkdown):
+		if m.ui.ViewMode != ui.ViewPreview {
+			return m, nil, false
+		}
+		if m.state.OpState.IsActive() {
+			return m, m.ui.Toast.Show(i18n.T("Wait for the preview to finish")), true
+		}
+		markdown := ui.PreviewMarkdown(m.ctx.StackName, m.state.Operation, m.ui.ResourceList.Items(), m.state.DiffIgnore)
+		return m, ui.CopyToClipboardWithCountCmd(markdown, 0), true
+	case key.Matches(msg, ui.Keys.ExportResources):
+		if m.ui.ViewMode == ui.ViewHistory {
+			return m, nil, false
+		}
+		m.showResourceExport()
+		return m, nil, true
+	case key.Matches(msg, ui.Keys.ExportState):
+		return m, nil, m.openStateFileModal(false)
+	case key.Matches(msg, ui.Keys.ImportState):
+		return m, nil, m.openStateFileModal(true)
+	case key.Matches(msg, ui.Keys.EditState):
+		cmd, ok := m.editResourceState()
+		return m, cmd, ok
 	case key.Matches(msg, ui.Keys.OpenResource):
 		item := m.ui.ResourceList.SelectedItem()
 		hasOpeners := m.deps != nil && m.deps.PluginProvider != nil && m.deps.PluginProvider.HasResourceOpeners()
 		if CanOpenResource(m.ui.ViewMode, item, hasOpeners) {
 			return m, m.fetchOpenResourceAction(item.Type, item.Name, item.URN, item.Provider, item.Inputs, item.Outputs, item.ProviderInputs), true
 		}
+	case key.Matches(msg, ui.Keys.OpenSource):
+		if m.ui.ViewMode != ui.ViewStack && m.ui.ViewMode != ui.ViewPreview {
+			return m, nil, false
+		}
+		if item := m.ui.ResourceList.SelectedItem(); item != nil {
+			return m, m.openSource(item), true
+		}
+	case key.Matches(msg, ui.Keys.FollowReference):
+		// Block switching stacks while busy or while an operation runs
+		if m.state.IsBusy() || m.state.OpState.IsActive() {
+			return m, nil, false
+		}
+		if item := m.ui.ResourceList.SelectedItem(); item != nil && item.Type == pulumi.StackReferenceType {
+			return m, m.followStackReference(item), true
+		}
 	}
 	return m, nil, false
 }
@@ -409,18 +1143,67 @@ func (m Model) handleOperationKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 		return m, m.startPreview(pulumi.OperationRefresh), true
 	case key.Matches(msg, ui.Keys.PreviewDestroy):
 		return m, m.startPreview(pulumi.OperationDestroy), true
+	case key.Matches(msg, ui.Keys.QuickCheck):
+		// Runs alongside the stack view, one check at a time
+		if m.ui.ViewMode != ui.ViewStack || m.state.OpState.IsActive() || m.ctx.StackName == "" ||
+			(m.state.QuickCheck != nil && !m.state.QuickCheck.Done) {
+			return m, nil, true
+		}
+		return m, m.startQuickCheck(), true
 	case key.Matches(msg, ui.Keys.ExecuteUp):
 		return m, m.maybeConfirmExecution(pulumi.OperationUp), true
 	case key.Matches(msg, ui.Keys.ExecuteRefresh):
 		return m, m.maybeConfirmExecution(pulumi.OperationRefresh), true
 	case key.Matches(msg, ui.Keys.ExecuteDestroy):
 		return m, m.maybeConfirmExecution(pulumi.OperationDestroy), true
+	case key.Matches(msg, ui.Keys.QueueRefreshUp):
+		if m.state.OpState.IsActive() {
+			return m, nil, true
+		}
+		m.state.OperationQueue = NewRefreshUpQueue()
+		m.confirmQueueStep()
+		return m, nil, true
+	case key.Matches(msg, ui.Keys.RunWorkflow):
+		if m.state.OpState.IsActive() {
+			return m, nil, true
+		}
+		m.showWorkflowSelector()
+		return m, m.fetchWorkflows(), true
+	case key.Matches(msg, ui.Keys.SavePlan):
+		if m.state.OpState.IsActive() {
+			return m, nil, true
+		}
+		return m, m.startPlanPreview(), true
+	case key.Matches(msg, ui.Keys.ToggleRefresh):
+		if m.state.OpState.IsActive() {
+			return m, nil, true
+		}
+		return m, m.toggleRefreshOnUp(), true
+	case key.Matches(msg, ui.Keys.ToggleContinueOnError):
+		if m.state.OpState.IsActive() {
+			return m, nil, true
+		}
+		return m, m.toggleContinueOnError(), true
+	case key.Matches(msg, ui.Keys.DetectDrift):
+		if m.state.OpState.IsActive() {
+			return m, nil, true
+		}
+		return m, m.startDriftDetection(), true
+	case key.Matches(msg, ui.Keys.AcceptDrift) && m.state.DriftMode:
+		return m, m.confirmDriftAction(pulumi.OperationRefresh), true
+	case key.Matches(msg, ui.Keys.RevertDrift) && m.state.DriftMode:
+		return m, m.confirmDriftAction(pulumi.OperationUp), true
 	}
 	return m, nil, false
 }
 
 // handleEscape handles escape key presses based on current state
 func (m Model) handleEscape() (tea.Model, tea.Cmd) {
+	// Browsing a stack of the organization returns to the workspace's stack
+	if m.ctx.CloudStack != "" && m.ctx.StackName != "" {
+		return m.leaveCloudStack()
+	}
+
 	// Determine action using pure function
 	action := DetermineEscapeAction(m.ui.ViewMode, m.state.OpState, m.ui.ResourceList.VisualMode())
 
@@ -429,8 +1212,7 @@ func (m Model) handleEscape() (tea.Model, tea.Cmd) {
 		cmd := m.ui.ResourceList.Update(tea.KeyMsg{Type: tea.KeyEscape})
 		return m, cmd
 	case EscapeActionCancelOp:
-		m.cancelOperation()
-		return m, nil
+		return m, m.cancelOperation()
 	case EscapeActionNavigateBack:
 		// Block navigation while busy (e.g., waiting for auth)
 		if m.state.IsBusy() {
@@ -450,23 +1232,37 @@ func (m Model) handleListNavigation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.ui.Focus.Has(ui.FocusDetailsPanel) {
 			m.ui.HistoryDetails.SetItem(m.ui.HistoryList.SelectedItem())
 		}
-		return m, cmd
+		return m, tea.Batch(cmd, m.loadMoreHistory(), m.loadHistoryChanges())
 	}
 
+	changesFlags := !m.isFilterInputActive() && isFlagKey(msg)
 	cmd := m.ui.ResourceList.Update(msg)
 	// Update details panel with newly selected resource if visible
 	if m.ui.Focus.Has(ui.FocusDetailsPanel) {
 		m.ui.Details.SetResource(m.ui.ResourceList.SelectedItem())
 	}
+	if changesFlags {
+		m.dropChangedPlan()
+	}
+	if changesFlags && m.state.PersistFlags {
+		cmd = tea.Batch(cmd, m.saveFlags())
+	}
 	return m, cmd
 }
 
-// scrollablePanel is an interface for panels that support scrolling
+// isFlagKey returns true if the key sets or clears resource flags in the list
+func isFlagKey(msg tea.KeyMsg) bool {
+	return key.Matches(msg, ui.Keys.ToggleTarget, ui.Keys.ToggleReplace, ui.Keys.ToggleExclude, ui.Keys.ClearFlags, ui.Keys.ClearAllFlags)
+}
+
+// scrollablePanel is an interface for panels that support scrolling and search
 type scrollablePanel interface {
 	ScrollUp(lines int)
 	ScrollDown(lines int)
 	SetScrollOffset(offset int)
 	ScrollOffset() int
+	SearchActive() bool
+	Update(msg tea.KeyMsg) (tea.Cmd, bool)
 }
 
 // isFilterInputActive returns true if any list filter is actively receiving input
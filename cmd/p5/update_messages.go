@@ -35,16 +35,34 @@ func (m Model) handleInitMessages(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 	case pluginAuthResultMsg:
 		model, cmd := m.handlePluginAuthResult(msg)
 		return model, cmd, true
-	case pluginAuthErrorMsg:
-		model, cmd := m.handlePluginAuthError(msg)
-		return model, cmd, true
 	case authCompleteMsg:
 		model, cmd := m.handleAuthComplete(msg)
 		return model, cmd, true
+	case credentialRefreshMsg:
+		model, cmd := m.handleCredentialRefresh(msg)
+		return model, cmd, true
+	case credentialsRefreshedMsg:
+		model, cmd := m.handleCredentialsRefreshed(msg)
+		return model, cmd, true
+	case logsTickMsg:
+		model, cmd := m.handleLogsTick(msg)
+		return model, cmd, true
+	case statusBadgeTickMsg:
+		model, cmd := m.handleStatusBadgeTick(msg)
+		return model, cmd, true
+	case statusBadgesMsg:
+		model, cmd := m.handleStatusBadges(msg)
+		return model, cmd, true
 	case projectInfoMsg:
 		model, cmd := m.handleProjectInfo(msg)
 		return model, cmd, true
-	case errMsg: //nolint:staticcheck // SA4020: type aliases to error are dispatched by explicit cast at call site
+	case stackProtectionMsg:
+		model, cmd := m.handleStackProtection(msg)
+		return model, cmd, true
+	case gitInfoMsg:
+		model, cmd := m.handleGitInfo(msg)
+		return model, cmd, true
+	case errMsg:
 		model, cmd := m.handleError(msg)
 		return model, cmd, true
 	case whoAmIMsg:
@@ -53,6 +71,9 @@ func (m Model) handleInitMessages(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 	case stackFilesMsg:
 		model, cmd := m.handleStackFiles(msg)
 		return model, cmd, true
+	case newProjectResultMsg:
+		model, cmd := m.handleNewProjectResult(msg)
+		return model, cmd, true
 	case stackInitResultMsg:
 		model, cmd := m.handleStackInitResult(msg)
 		return model, cmd, true
@@ -80,8 +101,8 @@ func (m Model) handleOperationMessages(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 	case stateDeleteResultMsg:
 		model, cmd := m.handleStateDeleteResult(msg)
 		return model, cmd, true
-	case bulkStateDeleteResultMsg:
-		model, cmd := m.handleBulkStateDeleteResult(msg)
+	case stateDeleteStepMsg:
+		model, cmd := m.handleStateDeleteStep(msg)
 		return model, cmd, true
 	case protectResultMsg:
 		model, cmd := m.handleProtectResult(msg)
@@ -89,21 +110,181 @@ func (m Model) handleOperationMessages(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 	case stackHistoryMsg:
 		model, cmd := m.handleStackHistory(msg)
 		return model, cmd, true
+	case historyDiffMsg:
+		model, cmd := m.handleHistoryDiff(msg)
+		return model, cmd, true
+	case historyDiffErrMsg:
+		model, cmd := m.handleHistoryDiffError(msg)
+		return model, cmd, true
+	case stackEnvironmentsMsg:
+		model, cmd := m.handleStackEnvironments(msg)
+		return model, cmd, true
+	case stackEnvironmentsErrMsg:
+		model, cmd := m.handleStackEnvironmentsError(msg)
+		return model, cmd, true
 	case importSuggestionsMsg:
 		model, cmd := m.handleImportSuggestions(msg)
 		return model, cmd, true
 	case importSuggestionsErrMsg:
 		model, cmd := m.handleImportSuggestionsError(msg)
 		return model, cmd, true
+	case importValidateTickMsg:
+		model, cmd := m.handleImportValidateTick(msg)
+		return model, cmd, true
+	case importIDValidatedMsg:
+		model, cmd := m.handleImportIDValidated(msg)
+		return model, cmd, true
+	case importPreviewMsg:
+		model, cmd := m.handleImportPreview(msg)
+		return model, cmd, true
+	case fileSavedMsg:
+		model, cmd := m.handleFileSaved(msg)
+		return model, cmd, true
+	case importableResourcesMsg:
+		model, cmd := m.handleImportableResources(msg)
+		return model, cmd, true
+	case importableResourcesErrMsg:
+		model, cmd := m.handleImportableResourcesError(msg)
+		return model, cmd, true
+	case bulkImportResultMsg:
+		model, cmd := m.handleBulkImportResult(msg)
+		return model, cmd, true
+	case runArtifactsMsg:
+		model, cmd := m.handleRunArtifacts(msg)
+		return model, cmd, true
+	case postOperationHooksMsg:
+		model, cmd := m.handlePostOperationHooks(msg)
+		return model, cmd, true
+	case stateFilesMsg:
+		model, cmd := m.handleStateFiles(msg)
+		return model, cmd, true
+	case savedFlagsMsg:
+		model, cmd := m.handleSavedFlags(msg)
+		return model, cmd, true
+	case flagsSavedMsg:
+		model, cmd := m.handleFlagsSaved(msg)
+		return model, cmd, true
+	case updateCancelMsg:
+		if msg.Err != nil {
+			// Interrupting the update's process still stops it, backends
+			// without cancel support or a finished update end up here
+			m.deps.Logger.Debug("pulumi cancel failed", "error", msg.Err)
+		}
+		return m, nil, true
+	case pendingOperationsMsg:
+		model, cmd := m.handlePendingOperations(msg)
+		return model, cmd, true
+	case stackRecoveredMsg:
+		model, cmd := m.handleStackRecovered(msg)
+		return model, cmd, true
+	case quickCheckEventMsg:
+		model, cmd := m.handleQuickCheckEvent(msg)
+		return model, cmd, true
+	case stackResultsMsg:
+		model, cmd := m.handleStackResults(msg)
+		return model, cmd, true
+	case stackRemovedMsg:
+		model, cmd := m.handleStackRemoved(msg)
+		return model, cmd, true
+	case stateExportedMsg:
+		model, cmd := m.handleStateExported(msg)
+		return model, cmd, true
+	case stateFileCheckedMsg:
+		model, cmd := m.handleStateFileChecked(msg)
+		return model, cmd, true
+	case stateImportedMsg:
+		model, cmd := m.handleStateImported(msg)
+		return model, cmd, true
+	case configCopiedMsg:
+		model, cmd := m.handleConfigCopied(msg)
+		return model, cmd, true
+	case loginDoneMsg:
+		model, cmd := m.handleLoginDone(msg)
+		return model, cmd, true
+	case detailsResizeEndedMsg:
+		model, cmd := m.handleDetailsResizeEnded(msg)
+		return model, cmd, true
+	case detailsWidthSavedMsg:
+		model, cmd := m.handleDetailsWidthSaved(msg)
+		return model, cmd, true
+	case keyBindingsMsg:
+		model, cmd := m.handleKeyBindings(msg)
+		return model, cmd, true
+	case notesMsg:
+		model, cmd := m.handleNotes(msg)
+		return model, cmd, true
+	case noteSavedMsg:
+		model, cmd := m.handleNoteSaved(msg)
+		return model, cmd, true
+	case diffIgnoreMsg:
+		model, cmd := m.handleDiffIgnore(msg)
+		return model, cmd, true
+	case pinnedMsg:
+		model, cmd := m.handlePinned(msg)
+		return model, cmd, true
+	case pinnedSavedMsg:
+		model, cmd := m.handlePinnedSaved(msg)
+		return model, cmd, true
+	case stackTagsMsg:
+		model, cmd := m.handleStackTags(msg)
+		return model, cmd, true
+	case stackTagSetMsg:
+		model, cmd := m.handleStackTagSet(msg)
+		return model, cmd, true
+	case pluginIndexMsg:
+		model, cmd := m.handlePluginIndex(msg)
+		return model, cmd, true
+	case pluginInstalledMsg:
+		model, cmd := m.handlePluginInstalled(msg)
+		return model, cmd, true
+	case pluginReauthenticatedMsg:
+		model, cmd := m.handlePluginReauthenticated(msg)
+		return model, cmd, true
+	case workflowsMsg:
+		model, cmd := m.handleWorkflows(msg)
+		return model, cmd, true
+	case targetSetsMsg:
+		model, cmd := m.handleTargetSets(msg)
+		return model, cmd, true
+	case targetSetSavedMsg:
+		model, cmd := m.handleTargetSetSaved(msg)
+		return model, cmd, true
+	case secretRevealedMsg:
+		model, cmd := m.handleSecretRevealed(msg)
+		return model, cmd, true
+	case pagerDoneMsg:
+		model, cmd := m.handlePagerDone(msg)
+		return model, cmd, true
+	case workflowReadyMsg:
+		model, cmd := m.handleWorkflowReady(msg)
+		return model, cmd, true
+	case stateRepairResultMsg:
+		model, cmd := m.handleStateRepairResult(msg)
+		return model, cmd, true
 	case openResourceActionMsg:
 		model, cmd := m.handleOpenResourceAction(msg)
 		return model, cmd, true
-	case openResourceErrMsg: //nolint:staticcheck // SA4020: type aliases to error are dispatched by explicit cast at call site
+	case openResourceErrMsg:
 		model, cmd := m.handleOpenResourceError(msg)
 		return model, cmd, true
 	case openResourceExecDoneMsg:
 		model, cmd := m.handleOpenResourceExecDone(msg)
 		return model, cmd, true
+	case resourceStateMsg:
+		model, cmd := m.handleResourceState(msg)
+		return model, cmd, true
+	case resourceStateEditedMsg:
+		model, cmd := m.handleResourceStateEdited(msg)
+		return model, cmd, true
+	case resourceStateWrittenMsg:
+		model, cmd := m.handleResourceStateWritten(msg)
+		return model, cmd, true
+	case aboutMsg:
+		model, cmd := m.handleAbout(msg)
+		return model, cmd, true
+	case sourceEditorDoneMsg:
+		model, cmd := m.handleSourceEditorDone(msg)
+		return model, cmd, true
 	}
 	return m, nil, false
 }
@@ -113,15 +294,33 @@ func (m Model) handleSelectionMessages(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 	case stacksListMsg:
 		model, cmd := m.handleStacksList(msg)
 		return model, cmd, true
+	case initDataMsg:
+		model, cmd := m.handleInitData(msg)
+		return model, cmd, true
 	case stackSelectedMsg:
 		model, cmd := m.handleStackSelected(msg)
 		return model, cmd, true
 	case workspacesListMsg:
 		model, cmd := m.handleWorkspacesList(msg)
 		return model, cmd, true
+	case workspacePinnedMsg:
+		model, cmd := m.handleWorkspacePinned(msg)
+		return model, cmd, true
 	case workspaceSelectedMsg:
 		model, cmd := m.handleWorkspaceSelected(msg)
 		return model, cmd, true
+	case dashboardMsg:
+		model, cmd := m.handleDashboard(msg)
+		return model, cmd, true
+	case dashboardErrMsg:
+		model, cmd := m.handleDashboardError(msg)
+		return model, cmd, true
+	case stackReferenceResolvedMsg:
+		model, cmd := m.handleStackReferenceResolved(msg)
+		return model, cmd, true
+	case dashboardStackSelectedMsg:
+		model, cmd := m.handleDashboardStackSelected(msg)
+		return model, cmd, true
 	}
 	return m, nil, false
 }
@@ -140,6 +339,21 @@ func (m Model) handleUIMessages(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 	case ui.FlashClearMsg:
 		model, cmd := m.handleFlashClear()
 		return model, cmd, true
+	case idleCheckMsg:
+		model, cmd := m.handleIdleCheck()
+		return model, cmd, true
+	case statePollMsg:
+		model, cmd := m.handleStatePoll()
+		return model, cmd, true
+	case cloudStacksMsg:
+		model, cmd := m.handleCloudStacks(msg)
+		return model, cmd, true
+	case cloudStackStateMsg:
+		model, cmd := m.handleCloudStackState(msg)
+		return model, cmd, true
+	case stateVersionMsg:
+		model, cmd := m.handleStateVersion(msg)
+		return model, cmd, true
 	}
 	return m, nil, false
 }
@@ -3,10 +3,15 @@ package main
 import (
 	"fmt"
 	"maps"
+	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/rfhold/p5/internal/i18n"
 	"github.com/rfhold/p5/internal/plugins/proto"
 	"github.com/rfhold/p5/internal/pulumi"
 	"github.com/rfhold/p5/internal/ui"
@@ -33,32 +38,38 @@ func (m *Model) resetOperation() {
 	if m.operationCancel != nil {
 		m.operationCancel = nil
 	}
+	m.state.OperationQueue = nil
 }
 
-// cancelOperation requests cancellation of the current operation.
-// Returns true if cancellation was initiated.
-func (m *Model) cancelOperation() bool {
+// cancelOperation requests cancellation of the current operation: the update's
+// process is interrupted so the engine stops gracefully, and the backend is asked
+// to cancel the update. Returns nil if no operation can be cancelled.
+func (m *Model) cancelOperation() tea.Cmd {
 	if m.state.OpState == OpRunning && m.operationCancel != nil {
 		m.deps.Logger.Debug("operation cancel requested",
 			"from", "Running",
 			"to", "Cancelling")
 		m.state.OpState = OpCancelling
 		m.operationCancel()
-		return true
+		m.ui.Header.SetSummary(m.ui.ResourceList.Summary(), ui.HeaderCancelling)
+		return tea.Batch(
+			m.requestUpdateCancel(),
+			m.ui.Toast.Show(i18n.Tf("Cancelling %s, waiting for pulumi to stop...", m.state.Operation.String())),
+		)
 	}
-	return false
+	return nil
 }
 
 // handleInitPreview handles starting a preview from Init
 func (m Model) handleInitPreview(msg initPreviewMsg) (tea.Model, tea.Cmd) {
 	m.transitionOpTo(OpRunning)
 	m.previewCh = msg.ch
-	m.ui.ResourceList.SetLoading(true, fmt.Sprintf("Running %s preview...", msg.op.String()))
+	m.ui.ResourceList.SetLoading(true, i18n.Tf("Running %s preview...", msg.op.String()))
 	return m, waitForPreviewEvent(m.previewCh)
 }
 
 // handleStackResources handles loaded stack resources.
-func (m Model) handleStackResources(msg stackResourcesMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+func (m Model) handleStackResources(msg stackResourcesMsg) (tea.Model, tea.Cmd) {
 	items := ConvertResourcesToItems(msg)
 
 	m.ui.ResourceList.SetItems(items)
@@ -67,11 +78,57 @@ func (m Model) handleStackResources(msg stackResourcesMsg) (tea.Model, tea.Cmd)
 		m.ui.Details.SetResource(m.ui.ResourceList.SelectedItem())
 	}
 
+	// Re-read the checkout and the stack's protection, as commits or edits may have happened since the last load
+	cmds := []tea.Cmd{m.fetchGitInfo(), m.loadStackProtection()}
 	if m.state.InitState == InitLoadingResources {
 		m.transitionTo(InitComplete)
+		// `p5 run` starts its workflow once, after the first stack has loaded
+		if m.ctx.Workflow != "" {
+			cmds = append(cmds, m.prepareWorkflow(m.ctx.Workflow))
+			m.ctx.Workflow = ""
+		}
 	}
 
-	return m, nil
+	// Announce state issues when they first appear or change, not on every reload
+	issues := pulumi.DiagnoseState(msg)
+	changed := len(issues) != len(m.state.StateIssues)
+	m.state.StateIssues = issues
+	m.state.StackURN = pulumi.StackResourceURN(msg)
+	m.state.StackResources = msg
+	m.updateProviderMismatches()
+
+	if changed && len(issues) > 0 {
+		cmds = append(cmds, m.ui.Toast.Show(i18n.Tf("Found %d issues in stack state, press F to repair", len(issues))))
+	}
+
+	// Restore saved flags the first time each stack of each project is loaded
+	if loadedFor := m.ctx.WorkDir + "\x00" + m.ctx.StackName; m.state.SavedFlagsLoadedFor != loadedFor {
+		m.state.SavedFlagsLoadedFor = loadedFor
+		m.state.PersistFlags = false
+		cmds = append(cmds, m.loadSavedFlags())
+	}
+	// Check provider versions against the installed plugins once per workspace
+	if m.state.AboutFetchedFor != m.ctx.WorkDir {
+		cmds = append(cmds, m.fetchAbout())
+	}
+	// Load the project's resource notes once per project
+	if m.state.NotesLoadedFor != m.ctx.WorkDir {
+		m.state.NotesLoadedFor = m.ctx.WorkDir
+		cmds = append(cmds, m.loadNotes())
+	}
+	// Load the project's diff ignore rules once per project
+	if m.state.DiffIgnoreLoadedFor != m.ctx.WorkDir {
+		m.state.DiffIgnoreLoadedFor = m.ctx.WorkDir
+		cmds = append(cmds, m.loadDiffIgnore())
+	}
+	// Load the project's pinned resources once per project
+	if m.state.PinnedLoadedFor != m.ctx.WorkDir {
+		m.state.PinnedLoadedFor = m.ctx.WorkDir
+		cmds = append(cmds, m.loadPinned())
+	}
+	cmds = append(cmds, m.recordLoadedState())
+
+	return m, tea.Batch(cmds...)
 }
 
 // handlePreviewEvent handles streaming preview events.
@@ -91,21 +148,49 @@ func (m Model) handlePreviewEvent(msg previewEventMsg) (tea.Model, tea.Cmd) {
 		if result.InitDone {
 			m.transitionTo(InitComplete)
 		}
-		return m, nil
+		cmd := m.announcePreviewWarnings()
+		if q := m.state.OperationQueue; q.Running(m.state.Operation, false) {
+			cmd = tea.Batch(cmd, m.stopQueue(i18n.Tf("Operation queue stopped: %s failed", q.Step().Label())))
+		}
+		return m, cmd
 	}
 
 	if event.Done {
 		m.ui.ResourceList.SetLoading(false, "")
 		m.ui.Header.SetSummary(m.ui.ResourceList.Summary(), ui.HeaderDone)
+		m.recordPreviewHashes()
 		m.previewCancel = nil
 		if result.InitDone {
 			m.transitionTo(InitComplete)
 		}
-		return m, nil
+		cmd := m.announcePreviewWarnings()
+		if m.state.PlanPath != "" {
+			m.state.PlanSaved = true
+			path := m.state.PlanPath
+			if rel, err := filepath.Rel(m.ctx.WorkDir, path); err == nil {
+				path = rel
+			}
+			cmd = tea.Batch(cmd, m.ui.Toast.Show(i18n.Tf("Plan saved to %s", path)))
+		}
+		if m.state.OperationQueue.Running(m.state.Operation, false) {
+			cmd = tea.Batch(cmd, m.advanceQueue())
+		}
+		return m, cmd
+	}
+
+	// The engine repeats some diagnostics, keep one copy of each
+	if result.Warning != nil && !slices.Contains(m.state.PreviewWarnings, *result.Warning) {
+		m.state.PreviewWarnings = append(m.state.PreviewWarnings, *result.Warning)
+	}
+	if result.Diagnostic != nil {
+		m.ui.Diagnostics.Add(*result.Diagnostic)
 	}
 
 	if result.Item != nil {
 		m.ui.ResourceList.AddItem(*result.Item)
+		if m.state.DriftMode {
+			m.markDrift(result.Item.URN)
+		}
 		m.ui.Header.SetSummary(m.ui.ResourceList.Summary(), ui.HeaderRunning)
 		if m.ui.Details.Visible() {
 			m.ui.Details.SetResource(m.ui.ResourceList.SelectedItem())
@@ -115,32 +200,107 @@ func (m Model) handlePreviewEvent(msg previewEventMsg) (tea.Model, tea.Cmd) {
 	return m, waitForPreviewEvent(m.previewCh)
 }
 
+// announcePreviewWarnings shows a toast pointing to the warnings panel if the preview reported any
+func (m *Model) announcePreviewWarnings() tea.Cmd {
+	if len(m.state.PreviewWarnings) == 0 {
+		return nil
+	}
+	return m.ui.Toast.Show(i18n.Tf("Preview reported %d warnings, press W to view", len(m.state.PreviewWarnings)))
+}
+
+// recordPreviewHashes marks resources whose diff changed since the previous preview
+// of the same operation and stores the current hashes for the next comparison.
+func (m *Model) recordPreviewHashes() {
+	hashes := HashPreviewItems(m.ui.ResourceList.Items())
+	previous := m.state.PreviewHashes[m.state.Operation]
+	m.ui.ResourceList.SetDiffChanged(DetermineChangedDiffs(previous, hashes))
+	m.state.PreviewHashes[m.state.Operation] = hashes
+}
+
 // handleOperationEvent handles streaming execution events.
 func (m Model) handleOperationEvent(msg operationEventMsg) (tea.Model, tea.Cmd) {
 	event := pulumi.OperationEvent(msg)
+	cancelling := m.state.OpState == OpCancelling
 	result := ProcessOperationEvent(event, m.state.OpState)
 
 	if result.NewOpState != m.state.OpState {
 		m.transitionOpTo(result.NewOpState)
 	}
 
+	if m.state.CurrentRun != nil {
+		m.state.CurrentRun.Record(event, time.Now())
+	}
+
+	if result.HasError || result.Done {
+		m.releaseStackLock()
+	}
+
 	if result.HasError {
-		m.ui.ResourceList.SetError(result.Error)
+		var cmd tea.Cmd
+		if failed := m.failedResourceCount(); m.state.ContinueOnError && failed > 0 {
+			// Keep the failed resources listed alongside the ones that went through
+			m.ui.ResourceList.SetLoading(false, "")
+			cmd = m.ui.Toast.Show(i18n.Tf("%d resources failed, the rest were applied", failed))
+		} else {
+			m.ui.ResourceList.SetError(result.Error)
+		}
 		m.ui.Header.SetSummary(m.ui.ResourceList.Summary(), ui.HeaderError)
 		m.operationCancel = nil
-		return m, nil
+		if m.state.CurrentRun != nil {
+			m.state.CurrentRun.Finish(result.Error, time.Now())
+		}
+		m.setStackResult("failed")
+		cmd = tea.Batch(cmd, m.runPostOperationHooks(cancelling), m.writeRunArtifacts())
+		if q := m.state.OperationQueue; q.Running(m.state.Operation, true) {
+			cmd = tea.Batch(cmd, m.stopQueue(i18n.Tf("Operation queue stopped: %s failed", q.Step().Label())))
+		}
+		if cancelling {
+			cmd = tea.Batch(cmd, m.checkPendingOperations())
+		} else if pulumi.IsStackLockedError(result.Error) {
+			m.offerStackRecovery(result.Error)
+		}
+		return m, cmd
 	}
 
 	if result.Done {
 		m.ui.ResourceList.SetLoading(false, "")
 		m.ui.Header.SetSummary(m.ui.ResourceList.Summary(), ui.HeaderDone)
 		m.operationCancel = nil
-		return m, nil
+		if m.state.CurrentRun != nil {
+			m.state.CurrentRun.Finish(nil, time.Now())
+		}
+		m.setStackResult("succeeded")
+		// Checked before the run record is written out
+		offerRemoval := !cancelling && m.destroyedWholeStack()
+		cmd := tea.Batch(m.runPostOperationHooks(cancelling), m.writeRunArtifacts())
+		if m.state.Operation == pulumi.OperationUp {
+			// Plans saved before the update no longer apply to the stack
+			m.state.PlanSaved = false
+			cmd = tea.Batch(cmd, m.removeStalePlans(""))
+		}
+		if q := m.state.OperationQueue; q.Running(m.state.Operation, true) {
+			// Don't carry on after resources failed, even if the engine reported success
+			if m.failedResourceCount() > 0 {
+				cmd = tea.Batch(cmd, m.stopQueue(i18n.Tf("Operation queue stopped: %s failed", q.Step().Label())))
+			} else {
+				cmd = tea.Batch(cmd, m.advanceQueue())
+			}
+		}
+		if cancelling {
+			cmd = tea.Batch(cmd, m.checkPendingOperations())
+		} else if offerRemoval {
+			m.offerStackRemoval()
+		}
+		return m, cmd
 	}
 
 	if result.Item != nil {
 		m.ui.ResourceList.AddItem(*result.Item)
-		m.ui.Header.SetSummary(m.ui.ResourceList.Summary(), ui.HeaderRunning)
+		headerState := ui.HeaderRunning
+		if cancelling {
+			headerState = ui.HeaderCancelling
+		}
+		m.ui.Header.SetSummary(m.ui.ResourceList.Summary(), headerState)
 		if m.ui.Details.Visible() {
 			m.ui.Details.SetResource(m.ui.ResourceList.SelectedItem())
 		}
@@ -149,32 +309,51 @@ func (m Model) handleOperationEvent(msg operationEventMsg) (tea.Model, tea.Cmd)
 	return m, waitForOperationEvent(m.operationCh)
 }
 
+// releaseStackLock lets other p5 processes operate on the stack once the operation ended
+func (m *Model) releaseStackLock() {
+	if err := m.deps.StackLocker.Release(m.ctx.WorkDir, m.ctx.StackName); err != nil {
+		m.deps.Logger.Warn("failed to release stack lock", "stack", m.ctx.StackName, "error", err)
+	}
+}
+
+// failedResourceCount returns how many resources in the list failed to update
+func (m *Model) failedResourceCount() int {
+	count := 0
+	for _, item := range m.ui.ResourceList.Items() {
+		if item.Status == ui.StatusFailed {
+			count++
+		}
+	}
+	return count
+}
+
 // handleImportResult handles import command result
 func (m Model) handleImportResult(msg importResultMsg) (tea.Model, tea.Cmd) {
 	m.hideImportModal()
 	if msg == nil {
 		m.showErrorModal(
-			"Import Failed",
-			"Unknown error occurred during import",
-			"No additional details available",
+			i18n.T("Import Failed"),
+			i18n.T("Unknown error occurred during import"),
+			i18n.T("No additional details available"),
 		)
 		return m, nil
 	}
 	if msg.Success {
 		cmds := []tea.Cmd{
-			m.ui.Toast.Show(fmt.Sprintf("Imported %s successfully", m.ui.ImportModal.GetResourceName())),
+			m.ui.Toast.Show(i18n.Tf("Imported %s successfully", m.ui.ImportModal.GetResourceName())),
 			m.startPreview(m.state.Operation),
 		}
+		m.showGeneratedCode(m.ui.ImportModal.GetResourceName(), msg.GeneratedCode)
 		return m, tea.Batch(cmds...)
 	}
-	summary := fmt.Sprintf("Failed to import '%s' (%s)",
+	summary := i18n.Tf("Failed to import '%s' (%s)",
 		m.ui.ImportModal.GetResourceName(),
 		m.ui.ImportModal.GetResourceType())
 	details := msg.Output
 	if details == "" && msg.Error != nil {
 		details = msg.Error.Error()
 	}
-	m.showErrorModal("Import Failed", summary, details)
+	m.showErrorModal(i18n.T("Import Failed"), summary, details)
 	return m, nil
 }
 
@@ -184,62 +363,326 @@ func (m Model) handleStateDeleteResult(msg stateDeleteResultMsg) (tea.Model, tea
 	m.hideConfirmModal()
 	if msg == nil {
 		m.showErrorModal(
-			"State Delete Failed",
-			fmt.Sprintf("Failed to remove '%s' from state", resourceName),
-			"Unknown error occurred",
+			i18n.T("State Delete Failed"),
+			i18n.Tf("Failed to remove '%s' from state", resourceName),
+			i18n.T("Unknown error occurred"),
 		)
 		return m, nil
 	}
 	if msg.Success {
 		cmds := []tea.Cmd{
-			m.ui.Toast.Show(fmt.Sprintf("Removed '%s' from state", resourceName)),
+			m.ui.Toast.Show(i18n.Tf("Removed '%s' from state", resourceName)),
 			m.loadStackResources(),
 		}
 		return m, tea.Batch(cmds...)
 	}
-	details := "No additional details available"
+	details := i18n.T("No additional details available")
 	if msg.Error != nil {
 		details = msg.Error.Error()
 	}
 	m.showErrorModal(
-		"State Delete Failed",
-		fmt.Sprintf("Failed to remove '%s' from state", resourceName),
+		i18n.T("State Delete Failed"),
+		i18n.Tf("Failed to remove '%s' from state", resourceName),
 		details,
 	)
 	return m, nil
 }
 
-// handleBulkStateDeleteResult handles bulk state delete command result
-func (m Model) handleBulkStateDeleteResult(msg bulkStateDeleteResultMsg) (tea.Model, tea.Cmd) {
-	m.hideConfirmModal()
+// handleStateDeleteStep records a resource removed by the running bulk state
+// delete, then removes the next one or reports the outcome
+func (m Model) handleStateDeleteStep(msg stateDeleteStepMsg) (tea.Model, tea.Cmd) {
+	bulk := m.state.BulkStateDelete
+	if bulk == nil || bulk.Done >= len(bulk.Resources) || bulk.Resources[bulk.Done].URN != msg.URN {
+		return m, nil
+	}
+	res := bulk.Resources[bulk.Done]
+	bulk.Done++
+	if msg.Err != nil {
+		bulk.Errors = append(bulk.Errors, fmt.Sprintf("%s: %v", res.Name, msg.Err))
+	} else {
+		bulk.Succeeded++
+	}
+	if bulk.Done < len(bulk.Resources) {
+		next := bulk.Resources[bulk.Done]
+		return m, tea.Batch(
+			m.ui.Toast.Show(i18n.Tf("Removing from state (%d/%d): %s", bulk.Done+1, len(bulk.Resources), next.Name)),
+			m.deleteNextFromState(),
+		)
+	}
+	m.state.BulkStateDelete = nil
+	return m.finishBulkStateDelete(bulk)
+}
 
+// finishBulkStateDelete reports the outcome of a bulk state delete
+func (m Model) finishBulkStateDelete(bulk *BulkStateDelete) (tea.Model, tea.Cmd) {
 	// Clear discrete selections after bulk operation
 	m.ui.ResourceList.ClearDiscreteSelections()
 
 	// If there were any failures, show error modal
-	if msg.Failed > 0 {
+	if failed := len(bulk.Errors); failed > 0 {
 		var summary string
-		if msg.Succeeded == 0 {
-			summary = fmt.Sprintf("Failed to remove %d resources from state", msg.Failed)
+		if bulk.Succeeded == 0 {
+			summary = i18n.Tf("Failed to remove %d resources from state", failed)
 		} else {
-			summary = fmt.Sprintf("Removed %d resources, but %d failed", msg.Succeeded, msg.Failed)
+			summary = i18n.Tf("Removed %d resources, but %d failed", bulk.Succeeded, failed)
 		}
 
 		var details strings.Builder
-		details.WriteString("Failed resources:\n\n")
-		for _, errMsg := range msg.Errors {
+		details.WriteString(i18n.T("Failed resources:"))
+		details.WriteString("\n\n")
+		for _, errMsg := range bulk.Errors {
 			details.WriteString("• ")
 			details.WriteString(errMsg)
 			details.WriteString("\n")
 		}
 
-		m.showErrorModal("State Delete Failed", summary, details.String())
+		m.showErrorModal(i18n.T("State Delete Failed"), summary, details.String())
 		return m, m.loadStackResources()
 	}
 
 	// All succeeded - show toast
 	cmds := []tea.Cmd{
-		m.ui.Toast.Show(fmt.Sprintf("Removed %d resources from state", msg.Succeeded)),
+		m.ui.Toast.Show(i18n.Tf("Removed %d resources from state", bulk.Succeeded)),
+		m.loadStackResources(),
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleSavedFlags applies the flags restored for a stack and remembers whether
+// its project saves flags
+func (m Model) handleSavedFlags(msg savedFlagsMsg) (tea.Model, tea.Cmd) {
+	// Ignore results for a stack or project that is no longer selected
+	if msg.WorkDir != m.ctx.WorkDir || msg.StackName != m.ctx.StackName {
+		return m, nil
+	}
+	m.state.PersistFlags = msg.Persist
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to load saved flags: %v", msg.Err))
+	}
+	if len(msg.Flags) == 0 {
+		return m, nil
+	}
+	// Write into the shared map, the resource list holds the same reference
+	maps.Copy(m.state.Flags, msg.Flags)
+	return m, m.ui.Toast.Show(i18n.Tf("Restored %d saved resource flags", len(msg.Flags)))
+}
+
+// handleKeyBindings applies the keybindings of a newly selected workspace, falling
+// back to the defaults when they can't be loaded or conflict
+func (m Model) handleKeyBindings(msg keyBindingsMsg) (tea.Model, tea.Cmd) {
+	// Ignore results for a workspace that is no longer selected
+	if msg.WorkDir != m.ctx.WorkDir {
+		return m, nil
+	}
+	err := msg.Err
+	if err == nil {
+		err = ui.ApplyKeyBindings(msg.Bindings)
+	}
+	if err != nil {
+		_ = ui.ApplyKeyBindings(nil)
+	}
+	// The help dialog lays out its content when sized
+	m.ui.Help.SetSize(m.ui.Width, m.ui.Height)
+	if err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to load keybindings: %v", err))
+	}
+	return m, nil
+}
+
+// handlePendingOperations offers to clear the operations a cancelled update left in state
+func (m Model) handlePendingOperations(msg pendingOperationsMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to check for pending operations: %v", msg.Err))
+	}
+	if len(msg.Ops) == 0 {
+		return m, nil
+	}
+	// Someone else may hold the lock by the time this is confirmed, so their
+	// update must not be cancelled
+	m.state.PendingClearOperations = true
+
+	lines := make([]string, len(msg.Ops))
+	for i, op := range msg.Ops {
+		lines[i] = "  " + op.Kind + " " + op.URN
+	}
+	m.ui.ConfirmModal.SetLabels(i18n.T("Keep"), i18n.T("Clear"))
+	m.ui.ConfirmModal.SetKeys("n", "y")
+	m.ui.ConfirmModal.Show(
+		i18n.T("Pending Operations"),
+		i18n.Tf("The cancelled update left %d pending operations in state:\n\n%s\n\nClear them?", len(msg.Ops), strings.Join(lines, "\n")),
+		i18n.T("Check the cloud provider first: a resource being created may exist without being in state."),
+	)
+	m.showConfirmModal()
+	return m, nil
+}
+
+// offerStackRecovery explains why an operation was refused by the locked stack and
+// offers to cancel the update holding the lock and clear its pending operations
+func (m *Model) offerStackRecovery(err error) {
+	m.state.PendingStackRecovery = true
+	m.ui.ConfirmModal.SetLabels(i18n.T("Keep"), i18n.T("Recover"))
+	m.ui.ConfirmModal.SetKeys("n", "y")
+	m.ui.ConfirmModal.Show(
+		i18n.T("Stack Locked"),
+		i18n.Tf("Stack %s is locked by another update or has pending operations left by an interrupted one:\n\n%v\n\nCancel the update and clear its pending operations, like `pulumi cancel`?", m.ctx.StackName, err),
+		i18n.T("Only recover if no one else is updating the stack: cancelling stops their update."),
+	)
+	m.showConfirmModal()
+}
+
+// handleStackRecovered reports what recovering a locked stack did
+func (m Model) handleStackRecovered(msg stackRecoveredMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to recover stack: %v", msg.Err))
+	}
+	switch {
+	case msg.Result.Cancelled && msg.Result.Cleared > 0:
+		return m, m.ui.Toast.Show(i18n.Tf("Cancelled the update and cleared %d pending operations", msg.Result.Cleared))
+	case msg.Result.Cleared > 0:
+		return m, m.ui.Toast.Show(i18n.Tf("Cleared %d pending operations", msg.Result.Cleared))
+	default:
+		return m, m.ui.Toast.Show(i18n.T("Cancelled the update holding the stack lock"))
+	}
+}
+
+// handleDetailsResizeEnded saves the details panel width unless it was resized again
+func (m Model) handleDetailsResizeEnded(msg detailsResizeEndedMsg) (tea.Model, tea.Cmd) {
+	if msg.Seq != m.state.DetailsResizeSeq {
+		return m, nil
+	}
+	return m, m.saveDetailsWidth()
+}
+
+// handleDetailsWidthSaved reports failures to save the details panel width
+func (m Model) handleDetailsWidthSaved(msg detailsWidthSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to save details panel width: %v", msg.Err))
+	}
+	return m, nil
+}
+
+// handleFlagsSaved reports failures to save flags and confirms clearing them
+func (m Model) handleFlagsSaved(msg flagsSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to save resource flags: %v", msg.Err))
+	}
+	if msg.Cleared {
+		return m, m.ui.Toast.Show(i18n.Tf("Cleared saved flags for %s", msg.StackName))
+	}
+	return m, nil
+}
+
+// handleNotes replaces the resource notes with those loaded for the project
+func (m Model) handleNotes(msg notesMsg) (tea.Model, tea.Cmd) {
+	// Ignore notes of a project that is no longer selected
+	if msg.WorkDir != m.ctx.WorkDir {
+		return m, nil
+	}
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to load resource notes: %v", msg.Err))
+	}
+	// Write into the shared map, the resource list and details hold the same reference
+	clear(m.state.Notes)
+	maps.Copy(m.state.Notes, msg.Notes)
+	return m, nil
+}
+
+// handleDiffIgnore applies the diff ignore rules of the project to the diffs
+func (m Model) handleDiffIgnore(msg diffIgnoreMsg) (tea.Model, tea.Cmd) {
+	// Ignore rules of a project that is no longer selected
+	if msg.WorkDir != m.ctx.WorkDir {
+		return m, nil
+	}
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to load diff ignore rules: %v", msg.Err))
+	}
+	m.state.DiffIgnore = msg.Rules
+	m.ui.Details.SetDiffIgnore(msg.Rules)
+	m.ui.HistoryDiff.SetDiffIgnore(msg.Rules)
+	return m, nil
+}
+
+// handleNoteSaved applies a saved note, or reports the failure to save it
+func (m Model) handleNoteSaved(msg noteSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to save note: %v", msg.Err))
+	}
+	if msg.Note == "" {
+		if _, ok := m.state.Notes[msg.URN]; !ok {
+			return m, nil
+		}
+		delete(m.state.Notes, msg.URN)
+		return m, m.ui.Toast.Show(i18n.T("Note removed"))
+	}
+	m.state.Notes[msg.URN] = msg.Note
+	return m, m.ui.Toast.Show(i18n.T("Note saved"))
+}
+
+// handlePinned replaces the pinned resources with those loaded for the project
+func (m Model) handlePinned(msg pinnedMsg) (tea.Model, tea.Cmd) {
+	// Ignore pins of a project that is no longer selected
+	if msg.WorkDir != m.ctx.WorkDir {
+		return m, nil
+	}
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to load pinned resources: %v", msg.Err))
+	}
+	// Write into the shared map, the resource list holds the same reference
+	clear(m.state.Pinned)
+	maps.Copy(m.state.Pinned, msg.Pinned)
+	m.ui.ResourceList.PinnedChanged()
+	return m, nil
+}
+
+// handlePinnedSaved reports a failure to save the pinned resources
+func (m Model) handlePinnedSaved(msg pinnedSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to save pinned resources: %v", msg.Err))
+	}
+	return m, nil
+}
+
+// handleRunArtifacts reports where the operation artifacts were written
+func (m Model) handleRunArtifacts(msg runArtifactsMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to write run artifacts: %v", msg.Err))
+	}
+	return m, m.ui.Toast.Show(i18n.Tf("Saved run artifacts to %s", msg.Dir))
+}
+
+// handlePostOperationHooks reports post-operation hooks that failed
+func (m Model) handlePostOperationHooks(msg postOperationHooksMsg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, result := range msg.Results {
+		if result.Error != nil {
+			cmds = append(cmds, m.ui.Toast.Show(i18n.Tf("Post-operation hook %s failed: %v", result.PluginName, result.Error)))
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleStateRepairResult handles a state repair dry run or write
+func (m Model) handleStateRepairResult(msg stateRepairResultMsg) (tea.Model, tea.Cmd) {
+	if msg.DryRun {
+		if msg.Err != nil {
+			m.ui.StateRepairModal.SetError(msg.Err)
+			return m, nil
+		}
+		m.ui.StateRepairModal.SetReport(msg.Report)
+		return m, nil
+	}
+
+	m.hideStateRepairModal()
+	if msg.Err != nil {
+		m.showErrorModal(i18n.T("State Repair Failed"), i18n.T("Failed to write repaired state"), msg.Err.Error())
+		return m, nil
+	}
+	changes := 0
+	if msg.Report != nil {
+		changes = len(msg.Report.Changes)
+	}
+	cmds := []tea.Cmd{
+		m.ui.Toast.Show(i18n.Tf("Repaired state (%d changes)", changes)),
 		m.loadStackResources(),
 	}
 	return m, tea.Batch(cmds...)
@@ -248,40 +691,130 @@ func (m Model) handleBulkStateDeleteResult(msg bulkStateDeleteResultMsg) (tea.Mo
 // handleProtectResult handles protect/unprotect command result
 func (m Model) handleProtectResult(msg protectResultMsg) (tea.Model, tea.Cmd) {
 	if msg.Result == nil {
-		action := "protect"
+		errMsg := i18n.T("Failed to protect: unknown error")
 		if !msg.Protected {
-			action = "unprotect"
+			errMsg = i18n.T("Failed to unprotect: unknown error")
 		}
-		return m, m.ui.Toast.Show(fmt.Sprintf("Failed to %s: unknown error", action))
+		return m, m.ui.Toast.Show(errMsg)
 	}
 	if msg.Result.Success {
-		action := "Protected"
-		if !msg.Protected {
-			action = "Unprotected"
-		}
+		// Clear discrete selections after bulk operation
+		m.ui.ResourceList.ClearDiscreteSelections()
 		cmds := []tea.Cmd{
-			m.ui.Toast.Show(fmt.Sprintf("%s '%s'", action, msg.Name)),
+			m.ui.Toast.Show(protectToast(msg)),
 			m.loadStackResources(),
 		}
 		return m, tea.Batch(cmds...)
 	}
-	action := "protect"
-	if !msg.Protected {
-		action = "unprotect"
-	}
-	errMsg := fmt.Sprintf("Failed to %s '%s'", action, msg.Name)
 	if msg.Result.Error != nil {
-		errMsg = msg.Result.Error.Error()
+		return m, m.ui.Toast.Show(msg.Result.Error.Error())
+	}
+	var errMsg string
+	switch {
+	case len(msg.Resources) != 1 && msg.Protected:
+		errMsg = i18n.Tf("Failed to protect %d resources", len(msg.Resources))
+	case len(msg.Resources) != 1:
+		errMsg = i18n.Tf("Failed to unprotect %d resources", len(msg.Resources))
+	case msg.Protected:
+		errMsg = i18n.Tf("Failed to protect '%s'", msg.Resources[0].Name)
+	default:
+		errMsg = i18n.Tf("Failed to unprotect '%s'", msg.Resources[0].Name)
 	}
 	return m, m.ui.Toast.Show(errMsg)
 }
 
+// protectToast returns the success toast for a protect/unprotect result
+func protectToast(msg protectResultMsg) string {
+	switch {
+	case len(msg.Resources) != 1 && msg.Protected:
+		return i18n.Tf("Protected %d resources", len(msg.Resources))
+	case len(msg.Resources) != 1:
+		return i18n.Tf("Unprotected %d resources", len(msg.Resources))
+	case msg.Protected:
+		return i18n.Tf("Protected '%s'", msg.Resources[0].Name)
+	default:
+		return i18n.Tf("Unprotected '%s'", msg.Resources[0].Name)
+	}
+}
+
 // handleStackHistory handles loaded stack history
-func (m Model) handleStackHistory(msg stackHistoryMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
-	items := ConvertHistoryToItems(msg)
+func (m Model) handleStackHistory(msg stackHistoryMsg) (tea.Model, tea.Cmd) {
+	first := msg.Page == pulumi.DefaultHistoryPage
+	// Ignore pages for a history that was reloaded or left since they were requested
+	if !first && (msg.Page != m.state.HistoryPage+1 || !m.ui.HistoryList.LoadingMore()) {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.ui.HistoryList.SetLoadingMore(false)
+		m.ui.HistoryList.SetHasMore(false)
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to load more history: %v", msg.Err))
+	}
 
-	m.ui.HistoryList.SetItems(items)
+	// A short page is the last one
+	hasMore := len(msg.History) == m.historyPageSize()
+	m.state.History = append(m.state.History, msg.History...)
+	m.state.HistoryPage = msg.Page
+	items := ConvertHistoryToItems(m.state.History)
+	if first {
+		m.ui.HistoryList.SetItems(items, hasMore)
+	} else {
+		m.ui.HistoryList.ExtendItems(items, hasMore)
+	}
 	m.ui.Header.SetSummary(ui.ResourceSummary{Total: len(items)}, ui.HeaderDone)
+	m.syncDetailsPanel()
+	// Keep loading while the cursor is still near the end, e.g. after jumping there
+	return m, tea.Batch(m.loadMoreHistory(), m.loadHistoryChanges())
+}
+
+// handleHistoryDiff handles loaded deployment snapshots for the history diff panel
+func (m Model) handleHistoryDiff(msg historyDiffMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	items := DiffStateSnapshots(msg.Before, msg.After, false)
+	if m.state.HistoryChanges == nil {
+		m.state.HistoryChanges = make(map[int][]ui.ResourceItem)
+	}
+	m.state.HistoryChanges[msg.Version] = items
+
+	// Update the panels still showing the version
+	if m.ui.HistoryDiff.Visible() && m.ui.HistoryDiff.Version() == msg.Version {
+		m.ui.HistoryDiff.SetDiff(msg.Version, items)
+	}
+	if m.ui.HistoryDetails.ChangesVersion() == msg.Version {
+		m.ui.HistoryDetails.SetChanges(msg.Version, items)
+	}
+	return m, nil
+}
+
+// handleHistoryDiffError handles a failure to load deployment snapshots
+func (m Model) handleHistoryDiffError(msg historyDiffErrMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if m.ui.HistoryDiff.Visible() && m.ui.HistoryDiff.Version() == msg.Version {
+		m.ui.HistoryDiff.SetError(msg.Error)
+	}
+	if m.ui.HistoryDetails.ChangesVersion() == msg.Version {
+		m.ui.HistoryDetails.SetChangesError(msg.Version, msg.Error)
+	}
+	return m, nil
+}
+
+// handleStackEnvironments handles resolved ESC environments for the current stack
+// and makes their environment variables available to plugins
+func (m Model) handleStackEnvironments(msg stackEnvironmentsMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	// Ignore results for a stack that is no longer selected
+	if msg.StackName != m.ctx.StackName {
+		return m, nil
+	}
+	m.ui.Environments.SetEnvironments(msg.Environments)
+	if m.deps.PluginProvider != nil {
+		m.deps.PluginProvider.SetEnvironmentEnv(MergeEnvironmentVariables(msg.Environments))
+	}
+	return m, nil
+}
+
+// handleStackEnvironmentsError handles a failure to resolve ESC environments
+func (m Model) handleStackEnvironmentsError(msg stackEnvironmentsErrMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if msg.StackName != m.ctx.StackName {
+		return m, nil
+	}
+	m.ui.Environments.SetError(msg.Error)
 	return m, nil
 }
 
@@ -298,52 +831,146 @@ func (m Model) handleImportSuggestionsError(_ importSuggestionsErrMsg) (tea.Mode
 	return m, nil
 }
 
+// handleImportValidateTick checks the import ID once typing paused, unless it changed since
+func (m Model) handleImportValidateTick(msg importValidateTickMsg) (tea.Model, tea.Cmd) {
+	if msg.Seq != m.state.ImportValidationSeq || !m.ui.ImportModal.Visible() {
+		return m, nil
+	}
+	m.ui.ImportModal.SetValidating()
+	return m, m.validateImportID()
+}
+
+// handleImportIDValidated shows plugins' verdict on the import ID in the import modal.
+// A failed check leaves the ID unchecked.
+func (m Model) handleImportIDValidated(msg importIDValidatedMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if msg.Seq != m.state.ImportValidationSeq {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.deps.Logger.Warn("import ID validation failed", "error", msg.Err)
+		m.ui.ImportModal.SetValidation(nil)
+		return m, nil
+	}
+	m.ui.ImportModal.SetValidation(ConvertImportIDValidation(msg.Result))
+	return m, nil
+}
+
+// handleImportPreview shows the inputs the import would adopt in the import modal
+func (m Model) handleImportPreview(msg importPreviewMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if !m.ui.ImportModal.Visible() || msg.ImportID != m.ui.ImportModal.GetImportID() {
+		return m, nil // The ID changed or the modal closed before the preview finished
+	}
+	if msg.Err != nil {
+		m.ui.ImportModal.SetPreview(nil, msg.Err)
+		return m, nil
+	}
+	m.ui.ImportModal.SetPreview(msg.Preview.Properties, nil)
+	return m, nil
+}
+
+// handleImportableResources adds a page of discovered resources to the bulk import modal
+func (m Model) handleImportableResources(msg importableResourcesMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	pending := m.state.PendingBulkImport
+	if pending == nil {
+		return m, nil // Modal was closed before the page arrived
+	}
+	if msg == nil {
+		m.ui.BulkImportModal.AppendItems(nil, false)
+		return m, nil
+	}
+	items := BuildBulkImportItems(msg.Resources, pending.Item.Type, m.ui.ResourceList.Items())
+	pending.PageTokens = msg.NextPageTokens
+	m.ui.BulkImportModal.AppendItems(items, len(msg.NextPageTokens) > 0)
+	return m, nil
+}
+
+// handleImportableResourcesError shows a discovery error in the bulk import modal
+func (m Model) handleImportableResourcesError(msg importableResourcesErrMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if m.state.PendingBulkImport == nil {
+		return m, nil
+	}
+	m.ui.BulkImportModal.SetError(msg.Err)
+	return m, nil
+}
+
+// handleBulkImportResult handles the result of a batched import
+func (m Model) handleBulkImportResult(msg bulkImportResultMsg) (tea.Model, tea.Cmd) {
+	m.hideBulkImportModal()
+	if msg.Result != nil && msg.Result.Success {
+		cmds := []tea.Cmd{
+			m.ui.Toast.Show(i18n.Tf("Imported %d resources successfully", msg.Count)),
+			m.startPreview(m.state.Operation),
+		}
+		m.showGeneratedCode(resourceTypeName(m.ui.BulkImportModal.GetResourceType()), msg.Result.GeneratedCode)
+		return m, tea.Batch(cmds...)
+	}
+	summary := i18n.Tf("Failed to import %d resources (%s)", msg.Count, m.ui.BulkImportModal.GetResourceType())
+	details := i18n.T("No additional details available")
+	if msg.Result != nil {
+		details = msg.Result.Output
+		if details == "" && msg.Result.Error != nil {
+			details = msg.Result.Error.Error()
+		}
+	}
+	m.showErrorModal(i18n.T("Import Failed"), summary, details)
+	return m, nil
+}
+
 // handleOpenResourceAction handles the response from plugin open resource query
 func (m Model) handleOpenResourceAction(msg openResourceActionMsg) (tea.Model, tea.Cmd) {
 	resp := msg.Response
 	if resp == nil {
 		// No plugin could open this resource
-		return m, m.ui.Toast.Show("No plugin can open this resource type")
+		return m, m.ui.Toast.Show(i18n.T("No plugin can open this resource type"))
 	}
 
 	if !resp.CanOpen {
-		return m, m.ui.Toast.Show("Resource type not supported for opening")
+		return m, m.ui.Toast.Show(i18n.T("Resource type not supported for opening"))
 	}
 
 	if resp.Error != "" {
-		return m, m.ui.Toast.Show("Open resource failed: " + resp.Error)
+		return m, m.ui.Toast.Show(i18n.T("Open resource failed: ") + resp.Error)
 	}
 
 	action := resp.Action
 	if action == nil {
-		return m, m.ui.Toast.Show("Plugin returned no action")
+		return m, m.ui.Toast.Show(i18n.T("Plugin returned no action"))
 	}
 
 	switch action.Type {
 	case proto.OpenActionType_OPEN_ACTION_TYPE_BROWSER:
 		return m, tea.Batch(
-			m.ui.Toast.Show("Opening in browser..."),
+			m.ui.Toast.Show(i18n.T("Opening in browser...")),
 			openInBrowser(action.Url),
 		)
 	case proto.OpenActionType_OPEN_ACTION_TYPE_EXEC:
-		// Convert proto env map to Go map
-		env := make(map[string]string)
-		maps.Copy(env, action.Env)
-		return m, openWithExec(action.Command, action.Args, env)
+		if _, err := exec.LookPath(action.Command); err != nil {
+			return m, m.ui.Toast.Show(i18n.Tf("%s not found: %v", action.Command, err))
+		}
+		return m, openWithExec(action.Command, action.Args, openActionEnv(m.deps, action))
 	default:
-		return m, m.ui.Toast.Show("Unknown open action type")
+		return m, m.ui.Toast.Show(i18n.T("Unknown open action type"))
 	}
 }
 
 // handleOpenResourceError handles errors from plugin open resource query
 func (m Model) handleOpenResourceError(msg openResourceErrMsg) (tea.Model, tea.Cmd) {
-	return m, m.ui.Toast.Show("Open resource failed: " + error(msg).Error())
+	return m, m.ui.Toast.Show(i18n.T("Open resource failed: ") + msg.Err.Error())
 }
 
-// handleOpenResourceExecDone handles completion of an exec-based open action
-func (m Model) handleOpenResourceExecDone(msg openResourceExecDoneMsg) (tea.Model, tea.Cmd) {
-	if msg.Error != nil {
-		return m, m.ui.Toast.Show("Program exited with error: " + msg.Error.Error())
+// handleOpenResourceExecDone handles completion of an exec-based open action. The
+// program's error output was hidden when the TUI resumed, so it is shown with the error.
+func (m Model) handleOpenResourceExecDone(msg openResourceExecDoneMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if msg.Error == nil {
+		return m, nil
 	}
+	if msg.Stderr == "" {
+		return m, m.ui.Toast.Show(i18n.T("Program exited with error: ") + msg.Error.Error())
+	}
+	m.showErrorModal(
+		i18n.T("Open Resource Failed"),
+		i18n.Tf("%s exited with error: %v", msg.Command, msg.Error),
+		msg.Stderr,
+	)
 	return m, nil
 }
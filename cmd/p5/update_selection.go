@@ -3,15 +3,36 @@ package main
 import (
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/plugins"
 	"github.com/rfhold/p5/internal/ui"
 )
 
-// handleStacksList handles the loaded list of stacks during initialization.
+// handleStacksList handles the loaded list of stacks for the stack selector.
 func (m Model) handleStacksList(msg stacksListMsg) (tea.Model, tea.Cmd) {
+	return m.handleInitData(initDataMsg{Stacks: msg.Stacks, Files: msg.Files})
+}
+
+// handleInitData handles the stacks, backend and project info loaded during
+// initialization. Anything not in the message is fetched when it is needed. The
+// stack badges are read alongside whatever init does next.
+func (m Model) handleInitData(msg initDataMsg) (tea.Model, tea.Cmd) {
+	resultsCmd := m.fetchStackResults(msg.Stacks)
+	model, cmd := m.applyInitData(msg)
+	return model, tea.Batch(cmd, resultsCmd)
+}
+
+// applyInitData picks the stack to load from the init data, or asks for one
+func (m Model) applyInitData(msg initDataMsg) (tea.Model, tea.Cmd) {
+	if msg.ProjectInfo != nil {
+		m.setProjectInfo(msg.ProjectInfo)
+	}
+
 	result := MergeStacksAndFiles(msg.Stacks, msg.Files)
 	items := result.Items
 	currentStackName := result.CurrentStackName
 	m.ui.StackSelector.SetStacks(items)
+	m.ui.StackSelector.SetLastResults(m.state.StackResults)
 
 	action := DetermineStackInitAction(m.state.InitState, len(items), currentStackName)
 
@@ -23,6 +44,11 @@ func (m Model) handleStacksList(msg stacksListMsg) (tea.Model, tea.Cmd) {
 		if m.deps != nil && m.deps.PluginProvider != nil {
 			m.ui.StackInitModal.SetAuthEnv(m.deps.PluginProvider.GetMergedAuthEnv())
 		}
+		if msg.WhoAmI != nil {
+			m.ui.StackInitModal.SetBackendInfo(msg.WhoAmI.User, msg.WhoAmI.URL)
+			m.ui.StackInitModal.SetStackFiles(msg.Files)
+			return m, nil
+		}
 		return m, tea.Batch(m.fetchWhoAmI(), m.fetchStackFiles())
 
 	case StackInitActionShowSelector:
@@ -48,7 +74,11 @@ func (m Model) handleStacksList(msg stacksListMsg) (tea.Model, tea.Cmd) {
 		}
 
 		// Start auth with lock - pending ops will execute when auth completes
-		return m, tea.Batch(m.fetchProjectInfo(), m.authenticatePluginsWithLock(pendingOp))
+		cmds := []tea.Cmd{m.fetchStackEnvironments(), m.authenticatePluginsWithLock(pendingOp), m.recordRecentWorkspace()}
+		if msg.ProjectInfo == nil || msg.ProjectInfo.StackName != currentStackName {
+			cmds = append(cmds, m.fetchProjectInfo())
+		}
+		return m, tea.Batch(cmds...)
 	}
 
 	return m, nil
@@ -59,9 +89,12 @@ func (m Model) handleStacksList(msg stacksListMsg) (tea.Model, tea.Cmd) {
 // Also handles runtime stack switching (when initState is InitComplete)
 func (m Model) handleStackSelected(msg stackSelectedMsg) (tea.Model, tea.Cmd) {
 	m.ctx.StackName = string(msg)
+	m.ui.Header.SetLastResult(m.state.StackResults[m.ctx.StackName])
 	m.hideDetailsPanel() // Close details panel when stack changes
 	m.hideStackSelector()
 	m.ui.ResourceList.Clear()
+	clear(m.state.PreviewHashes)
+	m.cancelQuickCheck()
 
 	if m.state.InitState == InitSelectingStack {
 		m.transitionTo(InitLoadingResources)
@@ -81,13 +114,78 @@ func (m Model) handleStackSelected(msg stackSelectedMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// Start auth with lock - pending ops will execute when auth completes
-	return m, tea.Batch(m.fetchProjectInfo(), m.authenticatePluginsWithLock(pendingOp))
+	return m, tea.Batch(m.fetchProjectInfo(), m.fetchStackEnvironments(), m.authenticatePluginsWithLock(pendingOp), m.recordRecentWorkspace())
+}
+
+// handleDashboard handles the loaded multi-stack dashboard rows
+func (m Model) handleDashboard(msg dashboardMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	m.ui.Dashboard.SetRows(msg)
+	return m, nil
+}
+
+// handleDashboardError handles a failure to find workspaces for the dashboard
+func (m Model) handleDashboardError(msg dashboardErrMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	m.ui.Dashboard.SetError(msg.Err)
+	return m, nil
+}
+
+// handleDashboardStackSelected opens a stack chosen on the dashboard.
+func (m Model) handleDashboardStackSelected(msg dashboardStackSelectedMsg) (tea.Model, tea.Cmd) {
+	m.hideDashboard()
+	return m.openStack(msg.WorkDir, msg.StackName)
+}
+
+// openStack opens a stack of any workspace. Stacks in the loaded workspace switch
+// like the stack selector; other workspaces restart the init state machine with
+// the stack preselected.
+func (m Model) openStack(workDir, stackName string) (tea.Model, tea.Cmd) {
+	if m.state.InitState == InitComplete && workDir == m.ctx.WorkDir {
+		return m.handleStackSelected(stackSelectedMsg(stackName))
+	}
+
+	m.ctx.WorkDir = workDir
+	m.ctx.StackName = stackName
+	m.hideDetailsPanel()
+	m.ui.ResourceList.Clear()
+	clear(m.state.PreviewHashes)
+	m.cancelQuickCheck()
+	m.state.StackResults = nil
+	m.ui.Header.SetLastResult("")
+
+	m.transitionTo(InitLoadingPlugins)
+
+	if m.deps != nil && m.deps.PluginProvider != nil {
+		mergedConfig := m.deps.PluginProvider.GetMergedConfig()
+		m.deps.PluginProvider.InvalidateCredentialsForContext(m.ctx.WorkDir, m.ctx.StackName, "", mergedConfig)
+	}
+	return m, tea.Batch(m.authenticatePluginsForWorkspace(), m.loadKeyBindings())
 }
 
 // handleWorkspacesList handles the loaded list of workspaces
 func (m Model) handleWorkspacesList(msg workspacesListMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
-	items := ConvertWorkspacesToItems(msg, m.ctx.Cwd)
-	m.ui.WorkspaceSelector.SetWorkspaces(items)
+	m.state.Workspaces = msg.Workspaces
+	m.ui.WorkspaceSelector.SetWorkspaces(m.workspaceItems(msg.Recent))
+	return m, nil
+}
+
+// workspaceItems lists the found workspaces in their groups, below the recent ones
+func (m *Model) workspaceItems(recent []plugins.RecentWorkspace) []ui.WorkspaceItem {
+	items := ConvertWorkspacesToItems(m.state.Workspaces, m.ctx.Cwd)
+	items = GroupWorkspaceItems(items, m.ctx.WorkspaceGroups)
+	return MergeRecentWorkspaces(items, recent, m.ctx.Cwd, m.ctx.WorkDir)
+}
+
+// handleWorkspacePinned relists the workspaces after one was pinned or unpinned,
+// keeping the cursor on it
+func (m Model) handleWorkspacePinned(msg workspacePinnedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Failed to pin workspace: %v", msg.Err))
+	}
+	if !m.ui.WorkspaceSelector.Visible() {
+		return m, nil
+	}
+	m.ui.WorkspaceSelector.SetWorkspaces(m.workspaceItems(msg.Recent))
+	m.ui.WorkspaceSelector.SelectPath(msg.Path)
 	return m, nil
 }
 
@@ -99,6 +197,10 @@ func (m Model) handleWorkspaceSelected(msg workspaceSelectedMsg) (tea.Model, tea
 	m.hideDetailsPanel()
 	m.hideWorkspaceSelector()
 	m.ui.ResourceList.Clear()
+	clear(m.state.PreviewHashes)
+	m.cancelQuickCheck()
+	m.state.StackResults = nil
+	m.ui.Header.SetLastResult("")
 
 	m.transitionTo(InitLoadingPlugins)
 
@@ -106,5 +208,5 @@ func (m Model) handleWorkspaceSelected(msg workspaceSelectedMsg) (tea.Model, tea
 		mergedConfig := m.deps.PluginProvider.GetMergedConfig()
 		m.deps.PluginProvider.InvalidateCredentialsForContext(m.ctx.WorkDir, m.ctx.StackName, "", mergedConfig)
 	}
-	return m, m.authenticatePluginsForWorkspace()
+	return m, tea.Batch(m.authenticatePluginsForWorkspace(), m.loadKeyBindings())
 }
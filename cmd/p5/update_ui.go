@@ -20,13 +20,32 @@ func (m Model) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.ui.Help.SetSize(msg.Width, msg.Height)
 	m.ui.StackSelector.SetSize(msg.Width, msg.Height)
 	m.ui.WorkspaceSelector.SetSize(msg.Width, msg.Height)
+	m.ui.WorkflowSelector.SetSize(msg.Width, msg.Height)
+	m.ui.CloudStackBrowser.SetSize(msg.Width, msg.Height)
+	m.ui.TargetSetSelector.SetSize(msg.Width, msg.Height)
+	m.ui.SecretSelector.SetSize(msg.Width, msg.Height)
+	m.ui.ValueSelector.SetSize(msg.Width, msg.Height)
+	m.ui.TargetSetModal.SetSize(msg.Width, msg.Height)
+	m.ui.GotoModal.SetSize(msg.Width, msg.Height)
 	m.ui.ImportModal.SetSize(msg.Width, msg.Height)
+	m.ui.PluginIndexModal.SetSize(msg.Width, msg.Height)
+	m.ui.PluginStatusModal.SetSize(msg.Width, msg.Height)
+	m.ui.NoteModal.SetSize(msg.Width, msg.Height)
+	m.ui.TagsModal.SetSize(msg.Width, msg.Height)
+	m.ui.HistoryFilterModal.SetSize(msg.Width, msg.Height)
+	m.ui.StateFileModal.SetSize(msg.Width, msg.Height)
+	m.ui.ConfigCopyModal.SetSize(msg.Width, msg.Height)
+	m.ui.UpdateMessageModal.SetSize(msg.Width, msg.Height)
+	m.ui.GitGuardModal.SetSize(msg.Width, msg.Height)
 	m.ui.ConfirmModal.SetSize(msg.Width, msg.Height)
 	m.ui.ErrorModal.SetSize(msg.Width, msg.Height)
 	m.ui.StackInitModal.SetSize(msg.Width, msg.Height)
+	m.ui.NewProjectModal.SetSize(msg.Width, msg.Height)
+	m.ui.LoginModal.SetSize(msg.Width, msg.Height)
+	m.ui.LockScreen.SetSize(msg.Width, msg.Height)
 	// Calculate resource list area height
 	headerHeight := lipgloss.Height(m.ui.Header.View())
-	footerHeight := 1 // single line footer
+	footerHeight := lipgloss.Height(m.renderFooter())
 	listHeight := msg.Height - headerHeight - footerHeight - 1
 	listHeight = max(listHeight, 1)
 	m.ui.ResourceList.SetSize(msg.Width, listHeight)
@@ -36,9 +55,142 @@ func (m Model) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleMouseEvent handles mouse events
+// doubleClickInterval is the longest time between the clicks of a double click
+const doubleClickInterval = 400 * time.Millisecond
+
+// wheelScrollLines is how many lines a panel scrolls per mouse wheel event
+const wheelScrollLines = 3
+
+// MouseClick is a click on a list item
+type MouseClick struct {
+	Time time.Time
+	Row  int
+}
+
+// handleMouseEvent handles mouse events. The wheel scrolls the list or panel under
+// the pointer, a click selects the list item under it and a double click opens
+// the item's details. Dragging the details panel border resizes the panel, and
+// clicking the header opens the stack's tags.
 func (m Model) handleMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
-	return m, nil
+	m.state.LastActivity = time.Now()
+	if m.ui.LockScreen.Visible() {
+		return m, nil
+	}
+	if m.state.ResizingDetails {
+		return m, m.handleDetailsResize(msg)
+	}
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	switch m.ui.Focus.Current() {
+	case ui.FocusMain:
+		// Clicking the header opens the stack's tags
+		if msg.Button == tea.MouseButtonLeft && msg.Y < lipgloss.Height(m.ui.Header.View()) {
+			return m, m.openStackTags()
+		}
+		m.handleListMouse(msg)
+	case ui.FocusDetailsPanel:
+		// The details panel covers the right of the list, with its border
+		// grabbed just left of or on the panel's first column
+		panelX := m.detailsPanelX()
+		if msg.Button == tea.MouseButtonLeft && msg.X >= panelX-1 && msg.X <= panelX {
+			m.state.ResizingDetails = true
+		} else if msg.X < panelX {
+			m.handleListMouse(msg)
+		} else if m.ui.ViewMode == ui.ViewHistory {
+			scrollPanelMouse(m.ui.HistoryDetails, msg)
+		} else {
+			scrollPanelMouse(m.ui.Details, msg)
+		}
+	case ui.FocusHistoryDiff:
+		scrollPanelMouse(m.ui.HistoryDiff, msg)
+	}
+	return m, tea.Batch(m.loadMoreHistory(), m.loadHistoryChanges())
+}
+
+// handleDetailsResize follows a drag of the details panel border, saving the
+// panel width once the button is released
+func (m *Model) handleDetailsResize(msg tea.MouseMsg) tea.Cmd {
+	if m.ui.Width > 0 {
+		m.resizeDetailsPanel((m.ui.Width - msg.X) * 100 / m.ui.Width)
+	}
+	if msg.Action == tea.MouseActionRelease {
+		m.state.ResizingDetails = false
+		return m.saveDetailsWidth()
+	}
+	return nil
+}
+
+// handleListMouse scrolls the list with the wheel and selects the clicked item,
+// opening the details panel on a double click
+func (m *Model) handleListMouse(msg tea.MouseMsg) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.scrollList(-1)
+	case tea.MouseButtonWheelDown:
+		m.scrollList(1)
+	case tea.MouseButtonLeft:
+		row := msg.Y - lipgloss.Height(m.ui.Header.View())
+		var hit bool
+		if m.ui.ViewMode == ui.ViewHistory {
+			hit = m.ui.HistoryList.ClickRow(row)
+		} else {
+			hit = m.ui.ResourceList.ClickRow(row)
+		}
+		if !hit {
+			m.state.LastClick = MouseClick{}
+			return
+		}
+
+		now := time.Now()
+		last := m.state.LastClick
+		m.state.LastClick = MouseClick{Time: now, Row: row}
+		if last.Row == row && now.Sub(last.Time) <= doubleClickInterval && !m.ui.Focus.Has(ui.FocusDetailsPanel) {
+			m.state.LastClick = MouseClick{}
+			m.showDetailsPanel()
+			return
+		}
+		m.syncDetailsPanel()
+	}
+}
+
+// scrollList moves the list cursor by delta, keeping an open details panel on the selected item
+func (m *Model) scrollList(delta int) {
+	if m.ui.ViewMode == ui.ViewHistory {
+		m.ui.HistoryList.Scroll(delta)
+	} else {
+		m.ui.ResourceList.Scroll(delta)
+	}
+	m.syncDetailsPanel()
+}
+
+// syncDetailsPanel shows the selected list item in the details panel when it is open
+func (m *Model) syncDetailsPanel() {
+	if !m.ui.Focus.Has(ui.FocusDetailsPanel) {
+		return
+	}
+	if m.ui.ViewMode == ui.ViewHistory {
+		m.ui.HistoryDetails.SetItem(m.ui.HistoryList.SelectedItem())
+	} else {
+		m.ui.Details.SetResource(m.ui.ResourceList.SelectedItem())
+	}
+}
+
+// wheelScroller is a panel that can be scrolled with the mouse wheel
+type wheelScroller interface {
+	ScrollUp(lines int)
+	ScrollDown(lines int)
+}
+
+// scrollPanelMouse scrolls a panel with the mouse wheel
+func scrollPanelMouse(panel wheelScroller, msg tea.MouseMsg) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		panel.ScrollUp(wheelScrollLines)
+	case tea.MouseButtonWheelDown:
+		panel.ScrollDown(wheelScrollLines)
+	}
 }
 
 // handleSpinnerTick handles spinner animation ticks
@@ -59,6 +211,11 @@ func (m Model) handleSpinnerTick(msg spinner.TickMsg) (tea.Model, tea.Cmd) {
 		m.ui.HistoryList.SetSpinner(s)
 		cmds = append(cmds, cmd)
 	}
+	if m.ui.Dashboard.IsLoading() {
+		s, cmd := m.ui.Dashboard.Spinner().Update(msg)
+		m.ui.Dashboard.SetSpinner(s)
+		cmds = append(cmds, cmd)
+	}
 	return m, tea.Batch(cmds...)
 }
 
@@ -93,6 +250,31 @@ func (m Model) handleToastHide() (tea.Model, tea.Cmd) { //nolint:unparam // Bubb
 	return m, nil
 }
 
+// checkIdleAfter checks for inactivity once d has passed
+func checkIdleAfter(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return idleCheckMsg{}
+	})
+}
+
+// handleIdleCheck locks the UI once it has been idle for the configured timeout
+// on a protected stack. A single check is pending at a time, scheduled for when
+// the timeout would next be reached.
+func (m Model) handleIdleCheck() (tea.Model, tea.Cmd) {
+	cfg := m.ctx.IdleLock
+	if cfg == nil {
+		return m, nil
+	}
+	timeout := cfg.TimeoutDuration()
+	if idle := time.Since(m.state.LastActivity); idle < timeout {
+		return m, checkIdleAfter(timeout - idle)
+	}
+	if !m.ui.LockScreen.Visible() && cfg.Protects(m.ctx.StackName) {
+		m.ui.LockScreen.Lock(m.ctx.StackName, cfg.Passphrase() != "")
+	}
+	return m, checkIdleAfter(timeout)
+}
+
 // handleFlashClear handles clearing the flash highlight
 func (m Model) handleFlashClear() (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
 	m.ui.ResourceList.ClearFlash()
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// defaultPager is run when $PAGER is not set
+const defaultPager = "less"
+
+// pagerCommand returns the command showing text in the user's pager, from $PAGER
+func pagerCommand(text string) *exec.Cmd {
+	fields := strings.Fields(os.Getenv("PAGER"))
+	if len(fields) == 0 {
+		fields = []string{defaultPager}
+	}
+	cmd := exec.Command(fields[0], fields[1:]...) //nolint:gosec // G204: the user's own $PAGER
+	cmd.Stdin = strings.NewReader(text + "\n")
+	return cmd
+}
+
+// viewValue shows a large value of the resource in the details panel in the
+// pager. With several large values the user picks one first.
+func (m *Model) viewValue() tea.Cmd {
+	values := ui.LargeValues(m.ui.ResourceList.SelectedItem())
+	switch len(values) {
+	case 0:
+		return m.ui.Toast.Show(i18n.T("No large values"))
+	case 1:
+		m.showValuePager(values[0])
+	default:
+		m.ui.ValueSelector.ShowValues(values)
+		m.ui.Focus.Push(ui.FocusValueSelector)
+	}
+	return nil
+}
+
+// showValuePager shows a value of the selected resource in full and pushes focus to it
+func (m *Model) showValuePager(value ui.LargeValue) {
+	var name string
+	if item := m.ui.ResourceList.SelectedItem(); item != nil {
+		name = item.Name
+	}
+	m.ui.ValuePager.Show(name, value)
+	m.ui.Focus.Push(ui.FocusValuePager)
+}
+
+// hideValuePager hides the value pager and pops focus
+func (m *Model) hideValuePager() {
+	m.ui.ValuePager.Hide()
+	m.ui.Focus.Remove(ui.FocusValuePager)
+}
+
+// updateValueSelector handles keys when the value selector has focus
+func (m Model) updateValueSelector(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	selected, cmd := m.ui.ValueSelector.Update(msg)
+	if selected {
+		value := m.ui.ValueSelector.SelectedItem()
+		m.ui.ValueSelector.Hide()
+		m.ui.Focus.Remove(ui.FocusValueSelector)
+		if value != nil {
+			m.showValuePager(*value)
+		}
+		return m, cmd
+	}
+	// Check if selector was dismissed (ESC pressed)
+	if !m.ui.ValueSelector.Visible() {
+		m.ui.Focus.Remove(ui.FocusValueSelector)
+	}
+	return m, cmd
+}
+
+// updateValuePager handles keys when the value pager has focus
+func (m Model) updateValuePager(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	pager := m.ui.ValuePager
+	switch {
+	case key.Matches(msg, ui.Keys.CopyResource):
+		return m, ui.CopyToClipboardWithCountCmd(pager.Text(), 0)
+	case msg.String() == "p":
+		return m, tea.ExecProcess(pagerCommand(pager.Text()), func(err error) tea.Msg {
+			return pagerDoneMsg{Err: err}
+		})
+	case key.Matches(msg, ui.Keys.Up):
+		pager.Scroll(-1)
+	case key.Matches(msg, ui.Keys.Down):
+		pager.Scroll(1)
+	case key.Matches(msg, ui.Keys.PageUp):
+		pager.Scroll(-10)
+	case key.Matches(msg, ui.Keys.PageDown):
+		pager.Scroll(10)
+	case key.Matches(msg, ui.Keys.Home):
+		pager.Scroll(-pager.LineCount())
+	case key.Matches(msg, ui.Keys.End):
+		pager.Scroll(pager.LineCount())
+	case key.Matches(msg, ui.Keys.Escape), key.Matches(msg, ui.Keys.Quit):
+		m.hideValuePager()
+	case key.Matches(msg, ui.Keys.Help):
+		m.showHelp()
+	}
+	return m, nil
+}
+
+// handlePagerDone reports a pager that failed to run
+func (m Model) handlePagerDone(msg pagerDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.ui.Toast.Show(i18n.Tf("Pager failed: %v", msg.Err))
+	}
+	return m, nil
+}
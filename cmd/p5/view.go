@@ -3,18 +3,28 @@ package main
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/rfhold/p5/internal/i18n"
 	"github.com/rfhold/p5/internal/ui"
 )
 
 // View renders the UI
 func (m Model) View() string {
+	defer recordRender(time.Now())
+
 	if m.quitting {
 		return ""
 	}
 
+	// The lock screen replaces everything, toasts included, until unlocked
+	if m.ui.LockScreen.Visible() {
+		return m.ui.LockScreen.View()
+	}
+
 	header := m.ui.Header.View()
 	footer := m.renderFooter()
 
@@ -25,10 +35,20 @@ func (m Model) View() string {
 	mainHeight = max(mainHeight, 1)
 
 	var mainContent string
-	if m.ui.ViewMode == ui.ViewHistory {
+	switch {
+	case m.ui.ViewMode == ui.ViewHistory:
 		m.ui.HistoryList.SetSize(m.ui.Width, mainHeight)
 		mainContent = m.ui.HistoryList.View()
-	} else {
+	case m.ui.ViewMode == ui.ViewPreview && m.ui.Diagnostics.Height() > 0 && m.ui.Diagnostics.Height() < mainHeight:
+		// The engine log strip takes the bottom lines of the list area
+		listHeight := mainHeight - m.ui.Diagnostics.Height()
+		m.ui.Diagnostics.SetWidth(m.ui.Width)
+		m.ui.ResourceList.SetSize(m.ui.Width, listHeight)
+		mainContent = lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.NewStyle().Height(listHeight).Render(m.ui.ResourceList.View()),
+			m.ui.Diagnostics.View())
+	default:
+		m.ui.ResourceList.SetSize(m.ui.Width, mainHeight)
 		mainContent = m.ui.ResourceList.View()
 	}
 	mainArea := lipgloss.NewStyle().
@@ -39,16 +59,61 @@ func (m Model) View() string {
 	fullView := lipgloss.JoinVertical(lipgloss.Left, header, mainArea, footer)
 
 	if m.ui.Focus.Has(ui.FocusDetailsPanel) {
-		detailsWidth := m.ui.Width / 2
+		detailsWidth := m.detailsPanelWidth()
 		if m.ui.ViewMode == ui.ViewHistory {
 			m.ui.HistoryDetails.SetSize(detailsWidth, mainHeight)
-			fullView = placeOverlay(m.ui.Width/2, headerHeight, m.ui.HistoryDetails.View(), fullView)
+			fullView = placeOverlay(m.detailsPanelX(), headerHeight, m.ui.HistoryDetails.View(), fullView)
 		} else {
 			m.ui.Details.SetSize(detailsWidth, mainHeight)
-			fullView = placeOverlay(m.ui.Width/2, headerHeight, m.ui.Details.View(), fullView)
+			fullView = placeOverlay(m.detailsPanelX(), headerHeight, m.ui.Details.View(), fullView)
 		}
 	}
 
+	if m.ui.Focus.Has(ui.FocusHistoryDiff) {
+		m.ui.HistoryDiff.SetSize(m.ui.Width, mainHeight)
+		fullView = placeOverlay(0, headerHeight, m.ui.HistoryDiff.View(), fullView)
+	}
+
+	if m.ui.Focus.Has(ui.FocusEnvironments) {
+		m.ui.Environments.SetSize(m.ui.Width, mainHeight)
+		fullView = placeOverlay(0, headerHeight, m.ui.Environments.View(), fullView)
+	}
+
+	if m.ui.Focus.Has(ui.FocusWarnings) {
+		m.ui.Warnings.SetSize(m.ui.Width, mainHeight)
+		fullView = placeOverlay(0, headerHeight, m.ui.Warnings.View(), fullView)
+	}
+
+	if m.ui.Focus.Has(ui.FocusTimings) {
+		m.ui.Timings.SetSize(m.ui.Width, mainHeight)
+		fullView = placeOverlay(0, headerHeight, m.ui.Timings.View(), fullView)
+	}
+
+	if m.ui.Focus.Has(ui.FocusLogs) {
+		m.ui.Logs.SetSize(m.ui.Width, mainHeight)
+		fullView = placeOverlay(0, headerHeight, m.ui.Logs.View(), fullView)
+	}
+
+	if m.ui.Focus.Has(ui.FocusCode) {
+		m.ui.Code.SetSize(m.ui.Width, mainHeight)
+		fullView = placeOverlay(0, headerHeight, m.ui.Code.View(), fullView)
+	}
+
+	if m.ui.Focus.Has(ui.FocusValuePager) {
+		m.ui.ValuePager.SetSize(m.ui.Width, mainHeight)
+		fullView = placeOverlay(0, headerHeight, m.ui.ValuePager.View(), fullView)
+	}
+
+	if m.ui.Focus.Has(ui.FocusAbout) {
+		m.ui.About.SetSize(m.ui.Width, mainHeight)
+		fullView = placeOverlay(0, headerHeight, m.ui.About.View(), fullView)
+	}
+
+	if m.ui.Focus.Has(ui.FocusDashboard) {
+		m.ui.Dashboard.SetSize(m.ui.Width, m.ui.Height)
+		fullView = m.ui.Dashboard.View()
+	}
+
 	if m.ui.Focus.Has(ui.FocusHelp) {
 		fullView = m.ui.Help.View()
 	}
@@ -61,14 +126,98 @@ func (m Model) View() string {
 		fullView = m.ui.WorkspaceSelector.View()
 	}
 
+	if m.ui.WorkflowSelector.Visible() {
+		fullView = m.ui.WorkflowSelector.View()
+	}
+
+	if m.ui.CloudStackBrowser.Visible() {
+		fullView = m.ui.CloudStackBrowser.View()
+	}
+
+	if m.ui.TargetSetSelector.Visible() {
+		fullView = m.ui.TargetSetSelector.View()
+	}
+
+	if m.ui.SecretSelector.Visible() {
+		fullView = m.ui.SecretSelector.View()
+	}
+
+	if m.ui.ValueSelector.Visible() {
+		fullView = m.ui.ValueSelector.View()
+	}
+
 	if m.ui.ImportModal.Visible() {
 		fullView = m.ui.ImportModal.View()
 	}
 
+	if m.ui.BulkImportModal.Visible() {
+		fullView = m.ui.BulkImportModal.View()
+	}
+
+	if m.ui.StateRepairModal.Visible() {
+		fullView = m.ui.StateRepairModal.View()
+	}
+
+	if m.ui.PluginIndexModal.Visible() {
+		fullView = m.ui.PluginIndexModal.View()
+	}
+
+	if m.ui.PluginStatusModal.Visible() {
+		fullView = m.ui.PluginStatusModal.View()
+	}
+
+	if m.ui.NoteModal.Visible() {
+		fullView = m.ui.NoteModal.View()
+	}
+
+	if m.ui.TagsModal.Visible() {
+		fullView = m.ui.TagsModal.View()
+	}
+
+	if m.ui.HistoryFilterModal.Visible() {
+		fullView = m.ui.HistoryFilterModal.View()
+	}
+
+	if m.ui.StateFileModal.Visible() {
+		fullView = m.ui.StateFileModal.View()
+	}
+
+	if m.ui.SaveFileModal.Visible() {
+		fullView = m.ui.SaveFileModal.View()
+	}
+
+	if m.ui.TargetSetModal.Visible() {
+		fullView = m.ui.TargetSetModal.View()
+	}
+
+	if m.ui.GotoModal.Visible() {
+		fullView = m.ui.GotoModal.View()
+	}
+
+	if m.ui.ConfigCopyModal.Visible() {
+		fullView = m.ui.ConfigCopyModal.View()
+	}
+
+	if m.ui.UpdateMessageModal.Visible() {
+		fullView = m.ui.UpdateMessageModal.View()
+	}
+
+	if m.ui.GitGuardModal.Visible() {
+		fullView = m.ui.GitGuardModal.View()
+	}
+
 	if m.ui.StackInitModal.Visible() {
 		fullView = m.ui.StackInitModal.View()
 	}
 
+	if m.ui.NewProjectModal.Visible() {
+		fullView = m.ui.NewProjectModal.View()
+	}
+
+	if m.ui.LoginModal.Visible() {
+		fullView = m.ui.LoginModal.View()
+	}
+
 	if m.ui.ConfirmModal.Visible() {
 		fullView = m.ui.ConfirmModal.View()
 	}
@@ -88,13 +237,17 @@ func (m Model) View() string {
 	return fullView
 }
 
-// renderFooter renders the bottom footer with keybind hints
+// renderFooter renders the bottom footer: the status of the flags, queue and plan
+// on the left, and hints of the keys valid now on the right
 func (m Model) renderFooter() string {
 	var leftParts []string
-	var rightParts []string
+
+	if m.ctx.StartView == "state" {
+		leftParts = append(leftParts, ui.LabelStyle.Render(i18n.T("READ-ONLY")))
+	}
 
 	if m.ui.ResourceList.VisualMode() {
-		leftParts = append(leftParts, ui.LabelStyle.Render("VISUAL"))
+		leftParts = append(leftParts, ui.LabelStyle.Render(i18n.T("VISUAL")))
 	}
 
 	if m.ui.ResourceList.HasFlags() {
@@ -113,56 +266,66 @@ func (m Model) renderFooter() string {
 			flagParts = append(flagParts, ui.FlagExcludeStyle.Render(fmt.Sprintf("E:%d", excludes)))
 		}
 		if len(flagParts) > 0 {
-			leftParts = append(leftParts, strings.Join(flagParts, " "), ui.DimStyle.Render("C clear all"))
+			leftParts = append(leftParts, strings.Join(flagParts, " "), footerHint(ui.Keys.ClearAllFlags, "clear all"))
 		}
 	}
 
-	if m.ui.ResourceList.VisualMode() {
-		rightParts = append(rightParts,
-			ui.DimStyle.Render("T target"),
-			ui.DimStyle.Render("R replace"),
-			ui.DimStyle.Render("E exclude"),
-			ui.DimStyle.Render("esc cancel"),
-		)
-	} else {
-		switch m.ui.ViewMode {
-		case ui.ViewStack:
-			rightParts = append(rightParts,
-				ui.DimStyle.Render("u up"),
-				ui.DimStyle.Render("r refresh"),
-				ui.DimStyle.Render("d destroy"),
-				ui.DimStyle.Render("x delete"),
-			)
-		case ui.ViewPreview:
-			rightParts = append(rightParts,
-				ui.DimStyle.Render("ctrl+u execute"),
-				ui.DimStyle.Render("I import"),
-				ui.DimStyle.Render("esc back"),
-			)
-		case ui.ViewExecute:
-			rightParts = append(rightParts, ui.DimStyle.Render("esc cancel"))
-		case ui.ViewHistory:
-			rightParts = append(rightParts, ui.DimStyle.Render("esc back"))
-		}
-		rightParts = append(rightParts,
-			ui.DimStyle.Render("v select"),
-			ui.DimStyle.Render("D details"),
-			ui.DimStyle.Render("s stack"),
-			ui.DimStyle.Render("w workspace"),
-			ui.DimStyle.Render("h history"),
-			ui.DimStyle.Render("? help"),
-			ui.DimStyle.Render("q quit"),
-		)
+	if q := m.state.OperationQueue; q != nil {
+		leftParts = append(leftParts, renderOperationQueue(q))
 	}
 
-	left := joinWithSeparator(leftParts, "  ")
-	right := joinWithSeparator(rightParts, "  ")
+	if m.savedPlan() != "" {
+		leftParts = append(leftParts, ui.LabelStyle.Render(i18n.T("PLAN SAVED")))
+	}
 
+	if m.ui.ViewMode == ui.ViewPreview && len(m.state.PreviewWarnings) > 0 {
+		leftParts = append(leftParts, ui.WarningStyle.Render(fmt.Sprintf("W:%d", len(m.state.PreviewWarnings))), footerHint(ui.Keys.ViewWarnings, "warnings"))
+	}
+
+	left := joinWithSeparator(leftParts, "  ")
 	leftWidth := lipgloss.Width(left)
+
+	// Expanded, the hints take the lines below the status
+	if m.ui.ExpandedHints {
+		lines := m.renderFooterHints(m.footerActions(), m.ui.Width-2)
+		if left != "" {
+			lines = append([]string{left}, lines...)
+		}
+		for i, line := range lines {
+			lines[i] = " " + line
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	// -2 for margins and 1 for the space between the status and the hints
+	right := m.renderFooterHints(m.footerActions(), m.ui.Width-leftWidth-3)[0]
 	rightWidth := lipgloss.Width(right)
-	padding := max(
-		// -2 for margins
-		m.ui.Width-leftWidth-rightWidth-2, 1)
+	padding := max(m.ui.Width-leftWidth-rightWidth-2, 1)
 
 	return " " + left + strings.Repeat(" ", padding) + right + " "
 }
+
+// renderOperationQueue renders the queued steps, marking finished ones and highlighting the current one
+func renderOperationQueue(q *OperationQueue) string {
+	steps := make([]string, len(q.Steps))
+	for i, step := range q.Steps {
+		switch {
+		case i < q.Current:
+			steps[i] = ui.DimStyle.Render("✓ " + step.Label())
+		case i == q.Current:
+			steps[i] = ui.LabelStyle.Render(step.Label())
+		default:
+			steps[i] = ui.DimStyle.Render(step.Label())
+		}
+	}
+	label := i18n.T("Queue:")
+	if q.Name != "" {
+		label = q.Name + ":"
+	}
+	return ui.DimStyle.Render(label+" ") + strings.Join(steps, ui.DimStyle.Render(" → "))
+}
+
+// footerHint renders a dimmed hint of a binding's keys with a translated description
+func footerHint(binding key.Binding, desc string) string {
+	return ui.DimStyle.Render(binding.Help().Key + " " + i18n.T(desc))
+}
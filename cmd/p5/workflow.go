@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/ui"
+)
+
+// fetchWorkflows loads the workflows defined in p5.toml
+func (m *Model) fetchWorkflows() tea.Cmd {
+	workDir := m.ctx.WorkDir
+
+	return func() tea.Msg {
+		workflows, err := plugins.LoadWorkflows(workDir)
+		return workflowsMsg{Workflows: workflows, Err: err}
+	}
+}
+
+// prepareWorkflow builds the queue for a workflow, loading the stack's resources
+// first when its steps name resources to target, replace or exclude
+func (m *Model) prepareWorkflow(name string) tea.Cmd {
+	ctx := m.appCtx
+	workDir := m.ctx.WorkDir
+	stackName := m.ctx.StackName
+	stackReader := m.deps.StackReader
+	opts := pulumi.ReadOptions{Env: m.deps.Env}
+
+	return func() tea.Msg {
+		workflows, err := plugins.LoadWorkflows(workDir)
+		if err != nil {
+			return workflowReadyMsg{Name: name, Err: err}
+		}
+		workflow, ok := workflows[name]
+		if !ok {
+			return workflowReadyMsg{Name: name, Err: fmt.Errorf("workflow %q is not defined in p5.toml", name)}
+		}
+
+		var resources []pulumi.ResourceInfo
+		if slices.ContainsFunc(workflow.Steps, plugins.WorkflowStep.HasFlags) {
+			resources, err = stackReader.GetResources(ctx, workDir, stackName, opts)
+			if err != nil {
+				return workflowReadyMsg{Name: name, Err: fmt.Errorf("failed to load stack resources: %w", err)}
+			}
+		}
+
+		queue, err := NewWorkflowQueue(name, workflow, resources)
+		return workflowReadyMsg{Name: name, Queue: queue, Err: err}
+	}
+}
+
+// handleWorkflows lists the loaded workflows in the selector
+func (m Model) handleWorkflows(msg workflowsMsg) (tea.Model, tea.Cmd) { //nolint:unparam // Bubble Tea handler signature
+	if msg.Err != nil {
+		m.ui.WorkflowSelector.SetError(msg.Err)
+		return m, nil
+	}
+
+	items := make([]ui.WorkflowItem, 0, len(msg.Workflows))
+	for name, workflow := range msg.Workflows {
+		items = append(items, ui.WorkflowItem{
+			Name:        name,
+			Description: workflow.Description,
+			Steps:       workflowSummary(workflow),
+		})
+	}
+	slices.SortFunc(items, func(a, b ui.WorkflowItem) int { return strings.Compare(a.Name, b.Name) })
+	m.ui.WorkflowSelector.SetWorkflows(items)
+	return m, nil
+}
+
+// handleWorkflowReady starts a workflow once its queue is built
+func (m Model) handleWorkflowReady(msg workflowReadyMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.showErrorModal(i18n.T("Workflow Failed"), i18n.Tf("Could not start workflow %s", msg.Name), msg.Err.Error())
+		return m, nil
+	}
+	if m.state.OpState.IsActive() || m.state.OperationQueue != nil || m.state.IsBusy() {
+		return m, m.ui.Toast.Show(i18n.Tf("Workflow %s not started, another operation is running", msg.Name))
+	}
+
+	m.state.OperationQueue = msg.Queue
+	return m, m.nextQueueStep()
+}
+
+// updateWorkflowSelector handles keys when the workflow selector has focus
+func (m Model) updateWorkflowSelector(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	selected, cmd := m.ui.WorkflowSelector.Update(msg)
+	if selected {
+		name := m.ui.WorkflowSelector.SelectedWorkflow()
+		m.hideWorkflowSelector()
+		if name != "" {
+			return m, m.prepareWorkflow(name)
+		}
+	}
+	// Check if selector was dismissed (ESC pressed)
+	if !m.ui.WorkflowSelector.Visible() {
+		m.ui.Focus.Remove(ui.FocusWorkflowSelector)
+	}
+	return m, cmd
+}
+
+// workflowSummary lists a workflow's steps in order, e.g. "Refresh → Preview Up → Up"
+func workflowSummary(workflow plugins.WorkflowConfig) string {
+	labels := make([]string, len(workflow.Steps))
+	for i, step := range workflow.Steps {
+		labels[i] = QueuedStep{Op: parseWorkflowOp(step.Op), Execute: !step.Preview}.Label()
+	}
+	return strings.Join(labels, " → ")
+}
@@ -0,0 +1,775 @@
+package i18n
+
+// spanish is the Spanish (es) translation catalog, keyed by English source text
+var spanish = map[string]string{
+	// Footer and key hints
+	"VISUAL":      "VISUAL",
+	"PLAN SAVED":  "PLAN GUARDADO",
+	"READ-ONLY":   "SOLO LECTURA",
+	"copy":        "copiar",
+	"filter":      "filtrar",
+	"accept":      "aceptar",
+	"back":        "volver",
+	"cancel":      "cancelar",
+	"clear all":   "limpiar todo",
+	"confirm":     "confirmar",
+	"delete":      "eliminar",
+	"destroy":     "destruir",
+	"details":     "detalles",
+	"diff":        "diferencias",
+	"exclude":     "excluir",
+	"execute":     "ejecutar",
+	"help":        "ayuda",
+	"history":     "historial",
+	"import":      "importar",
+	"next":        "siguiente",
+	"quit":        "salir",
+	"refresh":     "refrescar",
+	"replace":     "reemplazar",
+	"revert":      "revertir",
+	"scroll":      "desplazar",
+	"select":      "seleccionar",
+	"select all":  "seleccionar todo",
+	"change fix":  "cambiar arreglo",
+	"dry run":     "simular",
+	"write state": "escribir estado",
+	"skip":        "omitir",
+	"root":        "raíz",
+	"stack":       "stack",
+	"suggestions": "sugerencias",
+	"target":      "objetivo",
+	"up":          "up",
+	"workspace":   "espacio de trabajo",
+	"warnings":    "advertencias",
+
+	"enter/esc dismiss  j/k scroll  g/G top/bottom": "enter/esc cerrar  j/k desplazar  g/G inicio/final",
+
+	// Help dialog
+	"Keyboard Shortcuts":                      "Atajos de teclado",
+	"Navigation":                              "Navegación",
+	"Selection":                               "Selección",
+	"Operations":                              "Operaciones",
+	"General":                                 "General",
+	"Move up":                                 "Subir",
+	"Move down":                               "Bajar",
+	"Page up":                                 "Página anterior",
+	"Page down":                               "Página siguiente",
+	"Go to top":                               "Ir al inicio",
+	"Go to bottom":                            "Ir al final",
+	"Filter list":                             "Filtrar lista",
+	"Visual select mode":                      "Modo de selección visual",
+	"Toggle select":                           "Alternar selección",
+	"Toggle target flag":                      "Alternar marca de objetivo",
+	"Toggle replace flag":                     "Alternar marca de reemplazo",
+	"Toggle exclude flag":                     "Alternar marca de exclusión",
+	"Clear flags on selection":                "Limpiar marcas de la selección",
+	"Clear all flags":                         "Limpiar todas las marcas",
+	"Clear saved flags":                       "Limpiar marcas guardadas",
+	"Cancel selection / back":                 "Cancelar selección / volver",
+	"Preview up":                              "Previsualizar up",
+	"Preview refresh":                         "Previsualizar refresh",
+	"Preview destroy":                         "Previsualizar destroy",
+	"Execute up":                              "Ejecutar up",
+	"Execute refresh":                         "Ejecutar refresh",
+	"Execute destroy":                         "Ejecutar destroy",
+	"Queue refresh → preview → up":            "Encolar refresh → vista previa → up",
+	"Import resource (in preview)":            "Importar recurso (en previsualización)",
+	"Bulk import (in preview)":                "Importación masiva (en previsualización)",
+	"Delete from state":                       "Eliminar del estado",
+	"Protect selected":                        "Proteger selección",
+	"Unprotect selected":                      "Desproteger selección",
+	"Repair state issues":                     "Reparar problemas del estado",
+	"Export stack state to a file":            "Exportar el estado del stack a un archivo",
+	"Import stack state from a file":          "Importar el estado del stack desde un archivo",
+	"Edit resource note":                      "Editar la nota del recurso",
+	"Open resource (external tool)":           "Abrir recurso (herramienta externa)",
+	"Follow stack reference":                  "Seguir referencia de stack",
+	"Copy resource JSON":                      "Copiar JSON del recurso",
+	"Copy all resources JSON":                 "Copiar JSON de todos los recursos",
+	"Select stack":                            "Seleccionar stack",
+	"Select workspace":                        "Seleccionar espacio de trabajo",
+	"Reload stack":                            "Recargar stack",
+	"View stack history":                      "Ver historial del stack",
+	"Diff update with previous (history)":     "Comparar actualización con la anterior (historial)",
+	"View ESC environments":                   "Ver entornos de ESC",
+	"Preview warnings":                        "Advertencias de la vista previa",
+	"Stacks dashboard":                        "Panel de stacks",
+	"Browse plugin index":                     "Explorar el índice de plugins",
+	"Plugin credential status":                "Estado de las credenciales de los plugins",
+	"View and edit stack tags":                "Ver y editar las etiquetas del stack",
+	"Run workflow from p5.toml":               "Ejecutar un flujo de trabajo de p5.toml",
+	"Preview up and save plan":                "Previsualizar up y guardar el plan",
+	"Detect drift":                            "Detectar desviaciones",
+	"Accept drift (in drift view)":            "Aceptar desviaciones (en la vista de desviaciones)",
+	"Revert drift (in drift view)":            "Revertir desviaciones (en la vista de desviaciones)",
+	"Toggle details panel":                    "Alternar panel de detalles",
+	"Widen details panel":                     "Ensanchar panel de detalles",
+	"Narrow details panel":                    "Estrechar panel de detalles",
+	"Toggle raw JSON diff (in details)":       "Alternar diff JSON sin procesar (en detalles)",
+	"Search details and jump between matches": "Buscar en detalles y saltar entre coincidencias",
+	"Toggle help":                             "Alternar ayuda",
+	"Quit":                                    "Salir",
+
+	// Header and panels
+	"no matches":                          "sin coincidencias",
+	"Program:":                            "Programa:",
+	"Stack:":                              "Stack:",
+	"Runtime:":                            "Entorno:",
+	"State:":                              "Estado:",
+	"Loading exported state...":           "Cargando estado exportado...",
+	"Loading...":                          "Cargando...",
+	"%s cancelling...":                    "Cancelando %s...",
+	"No changes":                          "Sin cambios",
+	"no changes":                          "sin cambios",
+	"Drift":                               "Desviaciones",
+	"No drift":                            "Sin desviaciones",
+	"%d drifted":                          "%d desviados",
+	"%d properties":                       "%d propiedades",
+	"%d deleted":                          "%d eliminados",
+	"Error: %v":                           "Error: %v",
+	"No resource selected":                "Ningún recurso seleccionado",
+	"Type: ":                              "Tipo: ",
+	"Op: ":                                "Op: ",
+	"Status: ":                            "Estado: ",
+	"Name: ":                              "Nombre: ",
+	"No properties available":             "No hay propiedades disponibles",
+	"No matches":                          "Sin coincidencias",
+	"No resources":                        "No hay recursos",
+	"No history":                          "No hay historial",
+	"No items found":                      "No se encontraron elementos",
+	"Details:":                            "Detalles:",
+	"by %s":                               "por %s",
+	"creating...":                         "creando...",
+	"updating...":                         "actualizando...",
+	"deleting...":                         "eliminando...",
+	"replacing...":                        "reemplazando...",
+	"creating replacement...":             "creando reemplazo...",
+	"deleting original...":                "eliminando original...",
+	"refreshing...":                       "refrescando...",
+	"reading...":                          "leyendo...",
+	"running...":                          "ejecutando...",
+	"Update Details":                      "Detalles de la actualización",
+	"Update #%d":                          "Actualización #%d",
+	"Update #%d vs #%d":                   "Actualización #%d vs #%d",
+	"No update selected":                  "Ninguna actualización seleccionada",
+	"Kind: ":                              "Tipo: ",
+	"Result: ":                            "Resultado: ",
+	"User: ":                              "Usuario: ",
+	"Started: ":                           "Inicio: ",
+	"Ended: ":                             "Fin: ",
+	"Duration: ":                          "Duración: ",
+	"Message:":                            "Mensaje:",
+	"Total: %d resources":                 "Total: %d recursos",
+	"Diff changed since previous preview": "Las diferencias cambiaron desde la previsualización anterior",
+
+	"ESC Environments":          "Entornos de ESC",
+	"Environment Variables":     "Variables de entorno",
+	"Pulumi Config":             "Configuración de Pulumi",
+	"Resolving environments...": "Resolviendo entornos...",
+	"show values":               "mostrar valores",
+	"hide values":               "ocultar valores",
+	"none":                      "ninguno",
+
+	"No ESC environments imported by this stack": "Este stack no importa entornos de ESC",
+
+	"Stacks Dashboard":                  "Panel de stacks",
+	"PROJECT":                           "PROYECTO",
+	"STACK":                             "STACK",
+	"RESOURCES":                         "RECURSOS",
+	"DRIFT":                             "DESVÍO",
+	"LAST UPDATE":                       "ÚLTIMA ACTUALIZACIÓN",
+	"never":                             "nunca",
+	"drifted":                           "desviado",
+	"in sync":                           "sincronizado",
+	"unknown":                           "desconocido",
+	"open stack":                        "abrir stack",
+	"reload":                            "recargar",
+	"No resource information available": "No hay información de recursos disponible",
+	"Loading deployment snapshots...":   "Cargando instantáneas del despliegue...",
+	"No resource changes between these versions": "No hay cambios de recursos entre estas versiones",
+
+	// Loading states
+	"Loading stack resources...":       "Cargando recursos del stack...",
+	"Loading stack history...":         "Cargando historial del stack...",
+	"Loading stacks...":                "Cargando stacks...",
+	"Running %s preview...":            "Ejecutando previsualización de %s...",
+	"Executing %s...":                  "Ejecutando %s...",
+	"Detecting drift...":               "Detectando desviaciones...",
+	"Searching for Pulumi projects...": "Buscando proyectos de Pulumi...",
+
+	// Selectors and modals
+	"Select Stack":             "Seleccionar stack",
+	"Select Workspace":         "Seleccionar espacio de trabajo",
+	"No stacks found":          "No se encontraron stacks",
+	"No Pulumi projects found": "No se encontraron proyectos de Pulumi",
+	"Cancel":                   "Cancelar",
+	"Confirm":                  "Confirmar",
+	"Execute":                  "Ejecutar",
+	"Execute %s":               "Ejecutar %s",
+	"Preview %s":               "Vista previa de %s",
+	"Stop":                     "Detener",
+	"Continue":                 "Continuar",
+	"Keep":                     "Conservar",
+	"Clear":                    "Eliminar",
+	"Pending Operations":       "Operaciones pendientes",
+	"The cancelled update left %d pending operations in state:\n\n%s\n\nClear them?":             "La actualización cancelada dejó %d operaciones pendientes en el estado:\n\n%s\n\n¿Eliminarlas?",
+	"Check the cloud provider first: a resource being created may exist without being in state.": "Revisa primero el proveedor de nube: un recurso que se estaba creando puede existir sin estar en el estado.",
+	"Recover":      "Recuperar",
+	"Stack Locked": "Stack bloqueado",
+	"Stack %s is locked by another update or has pending operations left by an interrupted one:\n\n%v\n\nCancel the update and clear its pending operations, like `pulumi cancel`?": "El stack %s está bloqueado por otra actualización o tiene operaciones pendientes de una interrumpida:\n\n%v\n\n¿Cancelar la actualización y eliminar sus operaciones pendientes, como `pulumi cancel`?",
+	"Only recover if no one else is updating the stack: cancelling stops their update.":                                                                                             "Recupera solo si nadie más está actualizando el stack: cancelar detiene su actualización.",
+	"Operation Queue (%d/%d)":            "Cola de operaciones (%d/%d)",
+	"Run %s next?\n\n%s":                 "¿Ejecutar %s a continuación?\n\n%s",
+	"Operation queue finished":           "Cola de operaciones completada",
+	"Operation queue stopped":            "Cola de operaciones detenida",
+	"Operation queue stopped: %s failed": "Cola de operaciones detenida: %s falló",
+	"Queue:":                             "Cola:",
+	"Workflow %s (%d/%d)":                "Flujo de trabajo %s (%d/%d)",
+	"Delete":                             "Eliminar",
+	"Unprotect":                          "Desproteger",
+	"Delete from State":                  "Eliminar del estado",
+	"Unprotect Resource":                 "Desproteger recurso",
+	"Unprotect Resources":                "Desproteger recursos",
+	"Import Resource":                    "Importar recurso",
+	"Import ID":                          "ID de importación",
+	"Enter import ID...":                 "Introduce el ID de importación...",
+	"Bulk Import":                        "Importación masiva",
+	"Discovering resources...":           "Descubriendo recursos...",
+	"No importable resources found":      "No se encontraron recursos importables",
+	"%d of %d selected":                  "%d de %d seleccionados",
+	"Loading more...":                    "Cargando más...",
+	"more available":                     "hay más",
+	"(in program)":                       "(en el programa)",
+
+	"Run %s without previewing changes first?":                                                 "¿Ejecutar %s sin previsualizar los cambios primero?",
+	"This will apply changes to your infrastructure.":                                          "Esto aplicará cambios a tu infraestructura.",
+	"Remove '%s' from Pulumi state?\n\nType: %s":                                               "¿Quitar '%s' del estado de Pulumi?\n\nTipo: %s",
+	"Remove %d resources from Pulumi state?":                                                   "¿Quitar %d recursos del estado de Pulumi?",
+	"Remove protection from '%s'?\n\nType: %s":                                                 "¿Quitar la protección de '%s'?\n\nTipo: %s",
+	"This will allow the resource to be destroyed.":                                            "Esto permitirá que el recurso sea destruido.",
+	"Remove protection from %d resources?":                                                     "¿Quitar la protección de %d recursos?",
+	"This will allow the resources to be destroyed.":                                           "Esto permitirá que los recursos sean destruidos.",
+	"This will NOT delete the actual resource.\nThe resource will become unmanaged by Pulumi.": "Esto NO eliminará el recurso real.\nEl recurso dejará de ser gestionado por Pulumi.",
+	"This will NOT delete the actual resources.\nThey will become unmanaged by Pulumi.":        "Esto NO eliminará los recursos reales.\nDejarán de ser gestionados por Pulumi.",
+
+	"Plugin Index":             "Índice de plugins",
+	"Fetching plugin index...": "Obteniendo el índice de plugins...",
+	"No plugins in the index":  "No hay plugins en el índice",
+	"Installing %s...":         "Instalando %s...",
+	"install":                  "instalar",
+	"installed":                "instalado",
+	"close":                    "cerrar",
+	"Package: ":                "Paquete: ",
+	"Capabilities: ":           "Capacidades: ",
+	"Homepage: ":               "Página web: ",
+
+	"Plugin Status":                 "Estado de los plugins",
+	"No plugins configured":         "No hay plugins configurados",
+	"Authenticating %s...":          "Autenticando %s...",
+	"re-authenticate":               "reautenticar",
+	"Last refresh: ":                "Última renovación: ",
+	"Env vars: ":                    "Variables de entorno: ",
+	"not authenticated":             "sin autenticar",
+	"ok":                            "ok",
+	"renewed every operation":       "se renueva en cada operación",
+	"never expires":                 "no caduca",
+	"expired":                       "caducadas",
+	"refresh failed":                "falló la renovación",
+	"expires in %s":                 "caducan en %s",
+	"Failed to authenticate %s: %v": "Error al autenticar %s: %v",
+	"Authenticated %s":              "%s autenticado",
+
+	"Stack Tags":                      "Etiquetas del stack",
+	"Loading tags...":                 "Cargando etiquetas...",
+	"No tags":                         "Sin etiquetas",
+	"New tag":                         "Nueva etiqueta",
+	"value":                           "valor",
+	"key=value":                       "clave=valor",
+	"expected key=value":              "se esperaba clave=valor",
+	"Saving %s...":                    "Guardando %s...",
+	"Set by Pulumi, can't be edited":  "Establecida por Pulumi, no se puede editar",
+	"(empty removes the tag)":         "(vacía elimina la etiqueta)",
+	"add":                             "añadir",
+	"edit":                            "editar",
+	"remove":                          "eliminar",
+	"save":                            "guardar",
+	"Failed to save stack tag %s: %v": "Error al guardar la etiqueta %s del stack: %v",
+
+	"follow":                            "seguir",
+	"No outputs":                        "Sin salidas",
+	"Stack reference has no stack name": "La referencia de stack no tiene nombre de stack",
+	"Failed to follow stack reference %s: %v": "Error al seguir la referencia de stack %s: %v",
+
+	"[outdated]": "[desactualizado]",
+	"%s reload":  "%s recargar",
+
+	"Export State": "Exportar estado",
+	"Import State": "Importar estado",
+	"Save the stack's deployment to a JSON file":       "Guardar el despliegue del stack en un archivo JSON",
+	"Replace the stack's state with a deployment file": "Reemplazar el estado del stack con un archivo de despliegue",
+	"File":               "Archivo",
+	"Enter file path...": "Introduce la ruta del archivo...",
+	"Importing overwrites the stack's current state": "Importar sobrescribe el estado actual del stack",
+	"Import":                                             "Importar",
+	"Exporting state...":                                 "Exportando estado...",
+	"Importing state...":                                 "Importando estado...",
+	"Failed to export state: %v":                         "Error al exportar el estado: %v",
+	"Failed to import state: %v":                         "Error al importar el estado: %v",
+	"Exported %d resources to %s":                        "%d recursos exportados a %s",
+	"Imported %d resources into %s":                      "%d recursos importados en %s",
+	"The stack's current state will be overwritten.":     "El estado actual del stack se sobrescribirá.",
+	"The file holds the state of stack %s, not %s.":      "El archivo contiene el estado del stack %s, no de %s.",
+	"Replace the state of %s with %d resources from %s?": "¿Reemplazar el estado de %s con %d recursos de %s?",
+
+	"Run Workflow":                    "Ejecutar flujo de trabajo",
+	"Loading workflows...":            "Cargando flujos de trabajo...",
+	"No workflows defined in p5.toml": "No hay flujos de trabajo definidos en p5.toml",
+
+	"Stack: ":                               "Stack: ",
+	"p5 is locked":                          "p5 está bloqueado",
+	"Locked after a period of inactivity":   "Bloqueado tras un periodo de inactividad",
+	"Enter passphrase...":                   "Introduce la frase de contraseña...",
+	"enter unlock  esc back":                "enter desbloquear  esc volver",
+	"press any key to enter the passphrase": "pulsa cualquier tecla para introducir la frase de contraseña",
+	"press any key to unlock":               "pulsa cualquier tecla para desbloquear",
+
+	"Resource Note":                         "Nota del recurso",
+	"Note":                                  "Nota",
+	"e.g. manually resized, do not replace": "p. ej. redimensionado a mano, no reemplazar",
+	"enter save  esc cancel  (empty removes the note)": "enter guardar  esc cancelar  (vacía elimina la nota)",
+
+	"Accept Drift": "Aceptar desviaciones",
+	"Refresh %d drifted resources, updating the state to match their live configuration?": "¿Hacer refresh de %d recursos desviados, actualizando el estado para que coincida con su configuración real?",
+	"Revert Drift": "Revertir desviaciones",
+	"Update %d drifted resources to match the program?": "¿Actualizar %d recursos desviados para que coincidan con el programa?",
+
+	// Stack initialization
+	"Initialize Stack":                           "Inicializar stack",
+	"Select or enter stack name":                 "Selecciona o introduce el nombre del stack",
+	"Stack name":                                 "Nombre del stack",
+	"Enter stack name...":                        "Introduce el nombre del stack...",
+	"Select secrets provider":                    "Selecciona el proveedor de secretos",
+	"Provider URL":                               "URL del proveedor",
+	"Enter provider URL...":                      "Introduce la URL del proveedor...",
+	"Enter passphrase":                           "Introduce la frase de contraseña",
+	"Passphrase":                                 "Frase de contraseña",
+	"Enter passphrase for encrypting secrets...": "Introduce la frase de contraseña para cifrar secretos...",
+	"Default passphrase-based encryption":        "Cifrado predeterminado basado en frase de contraseña",
+	"from %s":                                    "de %s",
+	"has existing encryption":                    "ya tiene cifrado",
+	"Backend":                                    "Backend",
+	"User":                                       "Usuario",
+	"Stack":                                      "Stack",
+	"Secrets Provider":                           "Proveedor de secretos",
+
+	"Stack '%s' already has encryption configured. Re-initializing may cause issues with existing secrets.": "El stack '%s' ya tiene cifrado configurado. Reinicializarlo puede causar problemas con los secretos existentes.",
+
+	// Toasts and errors
+	"Copied %s":                                                         "Copiado %s",
+	"Copied resource":                                                   "Recurso copiado",
+	"Copied %d resources":                                               "%d recursos copiados",
+	"Copied to clipboard":                                               "Copiado al portapapeles",
+	"Created stack '%s'":                                                "Stack '%s' creado",
+	"Authenticated: ":                                                   "Autenticado: ",
+	"Plugin auth failed: ":                                              "Falló la autenticación del plugin: ",
+	"Credential refresh failed: ":                                       "Falló la renovación de credenciales: ",
+	"Plugin error: %v":                                                  "Error del plugin: %v",
+	"Import Failed":                                                     "Importación fallida",
+	"Unknown error occurred during import":                              "Ocurrió un error desconocido durante la importación",
+	"No additional details available":                                   "No hay más detalles disponibles",
+	"Imported %s successfully":                                          "%s importado correctamente",
+	"Failed to import '%s' (%s)":                                        "No se pudo importar '%s' (%s)",
+	"Importing %d resources...":                                         "Importando %d recursos...",
+	"Imported %d resources successfully":                                "%d recursos importados correctamente",
+	"Failed to import %d resources (%s)":                                "No se pudieron importar %d recursos (%s)",
+	"No plugin can discover existing resources":                         "Ningún plugin puede descubrir recursos existentes",
+	"State Delete Failed":                                               "Falló la eliminación del estado",
+	"Failed to remove '%s' from state":                                  "No se pudo quitar '%s' del estado",
+	"Unknown error occurred":                                            "Ocurrió un error desconocido",
+	"Removed '%s' from state":                                           "'%s' quitado del estado",
+	"Failed to remove %d resources from state":                          "No se pudieron quitar %d recursos del estado",
+	"Removed %d resources, but %d failed":                               "Se quitaron %d recursos, pero %d fallaron",
+	"Failed resources:":                                                 "Recursos fallidos:",
+	"Removed %d resources from state":                                   "%d recursos quitados del estado",
+	"Failed to protect: unknown error":                                  "No se pudo proteger: error desconocido",
+	"Failed to unprotect: unknown error":                                "No se pudo desproteger: error desconocido",
+	"Protected '%s'":                                                    "'%s' protegido",
+	"Unprotected '%s'":                                                  "'%s' desprotegido",
+	"Protected %d resources":                                            "%d recursos protegidos",
+	"Unprotected %d resources":                                          "%d recursos desprotegidos",
+	"Failed to protect %d resources":                                    "No se pudieron proteger %d recursos",
+	"Failed to unprotect %d resources":                                  "No se pudieron desproteger %d recursos",
+	"Nothing to protect in selection":                                   "No hay nada que proteger en la selección",
+	"Nothing to unprotect in selection":                                 "No hay nada que desproteger en la selección",
+	"Failed to load keybindings: %v":                                    "Error al cargar los atajos de teclado: %v",
+	"Failed to save details panel width: %v":                            "Error al guardar el ancho del panel de detalles: %v",
+	"Cancelling %s, waiting for pulumi to stop...":                      "Cancelando %s, esperando a que pulumi se detenga...",
+	"Failed to check for pending operations: %v":                        "Error al buscar operaciones pendientes: %v",
+	"Failed to recover stack: %v":                                       "Error al recuperar el stack: %v",
+	"Cancelled the update and cleared %d pending operations":            "Actualización cancelada y %d operaciones pendientes eliminadas",
+	"Cancelled the update holding the stack lock":                       "Cancelada la actualización que bloqueaba el stack",
+	"Cleared %d pending operations":                                     "Eliminadas %d operaciones pendientes",
+	"Failed to load saved flags: %v":                                    "Error al cargar las marcas guardadas: %v",
+	"Restored %d saved resource flags":                                  "Restauradas %d marcas de recursos guardadas",
+	"Failed to save resource flags: %v":                                 "Error al guardar las marcas de recursos: %v",
+	"Cleared saved flags for %s":                                        "Marcas guardadas de %s eliminadas",
+	"Saved run artifacts to %s":                                         "Artefactos de la ejecución guardados en %s",
+	"Plan saved to %s":                                                  "Plan guardado en %s",
+	"Failed to write run artifacts: %v":                                 "No se pudieron escribir los artefactos de la ejecución: %v",
+	"Post-operation hook %s failed: %v":                                 "Falló el hook posterior a la operación %s: %v",
+	"Found %d issues in stack state, press F to repair":                 "Se encontraron %d problemas en el estado del stack, pulsa F para reparar",
+	"No issues found in stack state":                                    "No se encontraron problemas en el estado del stack",
+	"State Repair Failed":                                               "Falló la reparación del estado",
+	"Failed to write repaired state":                                    "No se pudo escribir el estado reparado",
+	"Repaired state (%d changes)":                                       "Estado reparado (%d cambios)",
+	"Repair State":                                                      "Reparar estado",
+	"Checking fixes against state...":                                   "Comprobando arreglos contra el estado...",
+	"Found %d issues in stack state":                                    "Se encontraron %d problemas en el estado del stack",
+	"Dry run":                                                           "Simulación",
+	" (%d resources → %d)":                                              " (%d recursos → %d)",
+	"  ... and %d more":                                                 "  ... y %d más",
+	"remove %s (entries: %d)":                                           "quitar %s (entradas: %d)",
+	"re-parent %s to %s":                                                "cambiar el padre de %s a %s",
+	"State will still reference missing resources:":                     "El estado seguirá referenciando recursos inexistentes:",
+	"Pulumi will reject the repaired state unless these are fixed too.": "Pulumi rechazará el estado reparado si no se arreglan también.",
+	"duplicate URN (%d copies)":                                         "URN duplicado (%d copias)",
+	"parent %s is missing":                                              "falta el padre %s",
+	"provider %s is missing":                                            "falta el proveedor %s",
+	"re-parent to %s":                                                   "cambiar el padre a %s",
+	"delete extra copies":                                               "eliminar copias sobrantes",
+	"delete from state":                                                 "eliminar del estado",
+	"Failed to protect '%s'":                                            "No se pudo proteger '%s'",
+	"Failed to unprotect '%s'":                                          "No se pudo desproteger '%s'",
+	"No plugin can open this resource type":                             "Ningún plugin puede abrir este tipo de recurso",
+	"Resource type not supported for opening":                           "Tipo de recurso no soportado para abrir",
+	"Open resource failed: ":                                            "No se pudo abrir el recurso: ",
+	"Plugin returned no action":                                         "El plugin no devolvió ninguna acción",
+	"Opening in browser...":                                             "Abriendo en el navegador...",
+	"Unknown open action type":                                          "Tipo de acción de apertura desconocido",
+	"Program exited with error: ":                                       "El programa terminó con error: ",
+	"Preview Warnings":                                                  "Advertencias de la vista previa",
+	"No warnings reported by the last preview":                          "La última vista previa no reportó advertencias",
+	"%d errors, %d warnings":                                            "%d errores, %d advertencias",
+	"jump to resource":                                                  "ir al recurso",
+	"error":                                                             "error",
+	"warning":                                                           "advertencia",
+	"'%s' is not shown in the resource list":                            "'%s' no se muestra en la lista de recursos",
+	"Preview reported %d warnings, press W to view":                     "La vista previa reportó %d advertencias, pulsa W para verlas",
+	"Plugin install failed: %v":                                         "No se pudo instalar el plugin: %v",
+	"Installed %s, added to %s":                                         "%s instalado, añadido a %s",
+	"Workflow %s finished":                                              "Flujo de trabajo %s completado",
+	"Workflow Failed":                                                   "Flujo de trabajo fallido",
+	"Could not start workflow %s":                                       "No se pudo iniciar el flujo de trabajo %s",
+	"Workflow %s not started, another operation is running":             "El flujo de trabajo %s no se inició, hay otra operación en curso",
+	"Failed to load resource notes: %v":                                 "No se pudieron cargar las notas de los recursos: %v",
+	"Failed to save note: %v":                                           "No se pudo guardar la nota: %v",
+	"Note saved":                                                        "Nota guardada",
+	"Note removed":                                                      "Nota eliminada",
+	"Wrong passphrase":                                                  "Frase de contraseña incorrecta",
+	"No drifted resources":                                              "No hay recursos desviados",
+	"Copy config from another stack":                                    "Copiar configuración de otro stack",
+	"Config value":                                                      "Valor de configuración",
+	"add / create stack":                                                "añadir / crear stack",
+	"Set initial config":                                                "Configuración inicial",
+	"No Pulumi.%s.yaml to copy config from":                             "No hay Pulumi.%s.yaml del que copiar la configuración",
+	"Copy config from":                                                  "Copiar configuración de",
+	"(none)":                                                            "(ninguno)",
+	"Start with empty config":                                           "Empezar con la configuración vacía",
+	"%d secrets to re-encrypt":                                          "%d secretos por volver a cifrar",
+	"Copy Config":                                                       "Copiar configuración",
+	"Values already set in %s are overwritten":                          "Los valores ya definidos en %s se sobrescriben",
+	"Copying config from %s...":                                         "Copiando configuración de %s...",
+	"Failed to copy config: %v":                                         "Error al copiar la configuración: %v",
+	"Copied %d config values from %s to %s":                             "Copiados %d valores de configuración de %s a %s",
+	"Copied %d config values from %s, but %d secrets couldn't be decrypted":  "Copiados %d valores de configuración de %s, pero no se pudieron descifrar %d secretos",
+	"Created stack '%s', but %d secrets couldn't be decrypted":               "Stack '%s' creado, pero no se pudieron descifrar %d secretos",
+	"This backend doesn't track update versions, so updates can't be diffed": "Este backend no registra versiones de actualizaciones, así que no se pueden comparar",
+	"Backend Login":         "Inicio de sesión en backend",
+	"Error":                 "Error",
+	"Select backend":        "Seleccionar backend",
+	"Backend URL":           "URL del backend",
+	"Local":                 "Local",
+	"State in ~/.pulumi":    "Estado en ~/.pulumi",
+	"Enter access token":    "Introducir token de acceso",
+	"Access token":          "Token de acceso",
+	"Paste access token...": "Pega el token de acceso...",
+	"Leave empty to log in with your browser":     "Déjalo vacío para iniciar sesión con el navegador",
+	"Logging in to %s...":                         "Iniciando sesión en %s...",
+	"Logged in":                                   "Sesión iniciada",
+	"Logged in to %s as %s":                       "Sesión iniciada en %s como %s",
+	"Recent":                                      "Recientes",
+	"Workspaces":                                  "Espacios de trabajo",
+	"Failed to pin workspace: %v":                 "No se pudo fijar el espacio de trabajo: %v",
+	"Pin workspace (in selector)":                 "Fijar espacio de trabajo (en el selector)",
+	"Other":                                       "Otros",
+	"Slowest resources (after execute)":           "Recursos más lentos (tras ejecutar)",
+	"No resources finished in the last operation": "Ningún recurso terminó en la última operación",
+	"Slowest Resources":                           "Recursos más lentos",
+	"Operation took %s":                           "La operación tardó %s",
+	"show list":                                   "mostrar lista",
+	"hide list":                                   "ocultar lista",
+	"Destroys all %d resources in the stack":      "Destruye los %d recursos del stack",
+	"Destroys %d resources: %d targeted, %d children and dependents": "Destruye %d recursos: %d objetivos, %d hijos y dependientes",
+	"%d are protected, which stops the destroy":                      "%d están protegidos, lo que detiene la destrucción",
+	"[refresh]":                                        "[refresh]",
+	"Toggle refresh with up":                           "Alternar refresh con up",
+	"Up refreshes the state first":                     "Up refresca el estado primero",
+	"Up no longer refreshes the state first":           "Up ya no refresca el estado primero",
+	"[continue on error]":                              "[continuar tras errores]",
+	"Toggle continue on error":                         "Alternar continuar tras errores",
+	"Up and destroy continue past failed resources":    "Up y destroy continúan tras recursos fallidos",
+	"Up and destroy stop at the first failed resource": "Up y destroy se detienen en el primer recurso fallido",
+	"%d resources failed, the rest were applied":       "%d recursos fallaron, el resto se aplicó",
+	"Update Message":                                   "Mensaje de actualización",
+	"Describe this update":                             "Describe esta actualización",
+	"Operation":                                        "Operación",
+	"Message":                                          "Mensaje",
+	"Why is this update being run? (optional)":         "¿Por qué se ejecuta esta actualización? (opcional)",
+	"Git:":                        "Git:",
+	"dirty":                       "con cambios",
+	"(detached)":                  "(desacoplado)",
+	"Check Git Checkout":          "Comprobar checkout de git",
+	"Deploy from this checkout?":  "¿Desplegar desde este checkout?",
+	"Git":                         "Git",
+	"Type %s to deploy anyway":    "Escribe %s para desplegar de todos modos",
+	"Type %s exactly to continue": "Escribe %s exactamente para continuar",
+	"The working tree has uncommitted changes":      "El árbol de trabajo tiene cambios sin confirmar",
+	"Deploying from %s instead of %s":               "Desplegando desde %s en lugar de %s",
+	"Failed to load git guard: %v":                  "Error al cargar la protección de git: %v",
+	"Operation queue stopped: up was not confirmed": "Cola de operaciones detenida: no se confirmó el up",
+	"%s is a protected stack. Run %s on it?":        "%s es un stack protegido. ¿Ejecutar %s en él?",
+	"Type %s to confirm":                            "Escribe %s para confirmar",
+	"Failed to load stack protection: %v":           "Error al cargar la protección del stack: %v",
+	"Debug logs (with --debug)":                     "Registros de depuración (con --debug)",
+	"Debug Logs":                                    "Registros de depuración",
+	"No log records at this level yet":              "Aún no hay registros en este nivel",
+	"level":                                         "nivel",
+	"change level":                                  "cambiar nivel",
+	"Start p5 with --debug to capture logs":         "Inicia p5 con --debug para capturar registros",
+	"Stack Busy":                                    "Stack ocupado",
+	"Another p5 is running %s on %s, so %s can't run until it finishes.": "Otro p5 está ejecutando %s en %s, así que %s no puede ejecutarse hasta que termine.",
+	"Held by %s\nStarted %s ago\nLock file: %s":                          "Bloqueado por %s\nIniciado hace %s\nArchivo de bloqueo: %s",
+	"Operation queue stopped: %s is busy":                                "Cola de operaciones detenida: %s está ocupado",
+	"Failed to lock stack: %v":                                           "Error al bloquear el stack: %v",
+	"Checking import ID...":                                              "Comprobando el ID de importación...",
+	"Import ID is valid":                                                 "El ID de importación es válido",
+	"Import Preview":                                                     "Vista previa de importación",
+	"Previewing import...":                                               "Previsualizando importación...",
+	"No inputs reported":                                                 "No se informaron entradas",
+	"Generated Code for %s":                                              "Código generado para %s",
+	"Pulumi generated no code":                                           "Pulumi no generó código",
+	"Save Generated Code":                                                "Guardar código generado",
+	"Save the generated code to a file":                                  "Guardar el código generado en un archivo",
+	"Save File":                                                          "Guardar archivo",
+	"%s already exists":                                                  "%s ya existe",
+	"Failed to save file: %v":                                            "Error al guardar el archivo: %v",
+	"Saved %s":                                                           "Guardado %s",
+	"Removing from state (%d/%d): %s":                                    "Eliminando del estado (%d/%d): %s",
+	"Resources are still being removed from state":                       "Todavía se están eliminando recursos del estado",
+	"Includes %d resources that depend on the selection. Press d to leave them out.": "Incluye %d recursos que dependen de la selección. Pulsa d para excluirlos.",
+	"%d resources depend on the selection. Press d to remove them too.":              "%d recursos dependen de la selección. Pulsa d para eliminarlos también.",
+	"Edit resource state in $EDITOR":                                                 "Editar el estado del recurso en $EDITOR",
+	"Failed to read resource state: %v":                                              "No se pudo leer el estado del recurso: %v",
+	"Editor failed: %v":                                                              "Falló el editor: %v",
+	"Failed to read edited state: %v":                                                "No se pudo leer el estado editado: %v",
+	"No changes to resource state":                                                   "Sin cambios en el estado del recurso",
+	"Discard":                                                                        "Descartar",
+	"Edit again":                                                                     "Editar de nuevo",
+	"Invalid Resource State":                                                         "Estado de recurso no válido",
+	"The edited state of '%s' can't be written:\n\n%v":                               "El estado editado de '%s' no se puede escribir:\n\n%v",
+	"Write":                                 "Escribir",
+	"Edit Resource State":                   "Editar estado del recurso",
+	"Write the edited state of '%s' to %s?": "¿Escribir el estado editado de '%s' en %s?",
+	"The state is imported as edited. Pulumi doesn't check it against the cloud resource.": "El estado se importa tal como se editó. Pulumi no lo comprueba con el recurso en la nube.",
+	"Writing resource state...":            "Escribiendo el estado del recurso...",
+	"Failed to write resource state: %v":   "No se pudo escribir el estado del recurso: %v",
+	"Updated the state of '%s'":            "Se actualizó el estado de '%s'",
+	"Open resource source in $EDITOR":      "Abrir el código fuente del recurso en $EDITOR",
+	"No source position recorded for '%s'": "No hay posición de código registrada para '%s'",
+	"Source file not found: %s":            "No se encontró el archivo fuente: %s",
+	"%s not found: %v":                     "No se encontró %s: %v",
+	"Open Resource Failed":                 "Error al abrir el recurso",
+	"%s exited with error: %v":             "%s terminó con error: %v",
+	"About p5, Pulumi and the workspace":   "Acerca de p5, Pulumi y el espacio de trabajo",
+	"About":                                "Acerca de",
+	"copy all":                             "copiar todo",
+	"Version":                              "Versión",
+	"Failed to read Pulumi details: %v":    "No se pudieron leer los detalles de Pulumi: %v",
+	"not logged in":                        "sin sesión iniciada",
+	"Project":                              "Proyecto",
+	"Name":                                 "Nombre",
+	"Runtime":                              "Runtime",
+	"Directory":                            "Directorio",
+	"Pulumi Plugins":                       "Plugins de Pulumi",
+	"No plugins installed":                 "No hay plugins instalados",
+	"p5 Config":                            "Configuración de p5",
+	"Data":                                 "Datos",
+	"p5 Plugins":                           "Plugins de p5",
+	"Provider Versions":                    "Versiones de proveedores",
+	"%s %s in state, %s %s":                "%s %s en el estado, %s %s",
+	"[provider versions]":                  "[versiones de proveedores]",
+	"%s details":                           "%s detalles",
+	"Remove":                               "Eliminar",
+	"Remove Stack":                         "Eliminar stack",
+	"Destroyed all resources of %s. Remove the stack and its Pulumi.%s.yaml config as well, like `pulumi stack rm`?": "Se destruyeron todos los recursos de %s. ¿Eliminar también el stack y su configuración Pulumi.%s.yaml, como `pulumi stack rm`?",
+	"The stack's update history is removed with it.":                                                                 "El historial de actualizaciones del stack se elimina con él.",
+	"Failed to remove stack: %v":             "No se pudo eliminar el stack: %v",
+	"Removed stack %s":                       "Stack %s eliminado",
+	"New Project":                            "Nuevo proyecto",
+	"Select or enter a template":             "Selecciona o introduce una plantilla",
+	"Template":                               "Plantilla",
+	"Enter template name, URL or path...":    "Introduce el nombre, URL o ruta de la plantilla...",
+	"Enter project name":                     "Introduce el nombre del proyecto",
+	"Project name":                           "Nombre del proyecto",
+	"Enter project name...":                  "Introduce el nombre del proyecto...",
+	"Select or enter project directory":      "Selecciona o introduce el directorio del proyecto",
+	"Enter an empty or new directory...":     "Introduce un directorio vacío o nuevo...",
+	"Enter stack name":                       "Introduce el nombre del stack",
+	"create project":                         "crear proyecto",
+	"Templates":                              "Plantillas",
+	"Creating project from %s...":            "Creando proyecto desde %s...",
+	"Created project '%s' in %s":             "Proyecto '%s' creado en %s",
+	"Loading more updates...":                "Cargando más actualizaciones...",
+	"%d updates loaded, scroll for more":     "%d actualizaciones cargadas, desplázate para ver más",
+	"Failed to load more history: %v":        "No se pudo cargar más historial: %v",
+	"Filter History":                         "Filtrar historial",
+	"Kind":                                   "Tipo",
+	"Result":                                 "Resultado",
+	"From":                                   "Desde",
+	"To":                                     "Hasta",
+	"anyone":                                 "cualquiera",
+	"toggle":                                 "alternar",
+	"choose":                                 "elegir",
+	"move":                                   "mover",
+	"apply":                                  "aplicar",
+	"reset":                                  "restablecer",
+	"since %s":                               "desde %s",
+	"until %s":                               "hasta %s",
+	"expected a date like 2024-01-31":        "se esperaba una fecha como 2024-01-31",
+	"the start date is after the end date":   "la fecha de inicio es posterior a la de fin",
+	"Filter by kind, result, user (history)": "Filtrar por tipo, resultado, usuario (historial)",
+	"filter history":                         "filtrar historial",
+	"Loading changed resources...":           "Cargando recursos cambiados...",
+	"No resources changed":                   "Ningún recurso cambió",
+	"diff selected resource":                 "ver diferencias del recurso seleccionado",
+	"Engine log":                             "Registro del motor",
+	"expand":                                 "expandir",
+	"collapse":                               "contraer",
+	"checking for changes...":                "buscando cambios...",
+	"[check failed]":                         "[comprobación fallida]",
+	"pending:":                               "pendiente:",
+	"✓ up to date":                           "✓ al día",
+	"Quick check failed: %v":                 "La comprobación rápida falló: %v",
+	"%d to create":                           "%d por crear",
+	"%d to update":                           "%d por actualizar",
+	"%d to replace":                          "%d por reemplazar",
+	"%d to delete":                           "%d por eliminar",
+	"%d to refresh":                          "%d por refrescar",
+	"No changes pending":                     "No hay cambios pendientes",
+	"Pending changes: %s":                    "Cambios pendientes: %s",
+	"org stacks":                             "stacks de la org",
+	"Browse Organization Stacks":             "Explorar stacks de la organización",
+	"Loading stacks of all projects...":      "Cargando stacks de todos los proyectos...",
+	"updating":                               "actualizando",
+	"never updated":                          "nunca actualizado",
+	"%d resources":                           "%d recursos",
+	"Loading stack state...":                 "Cargando estado del stack...",
+	"stacks of other projects can only be browsed on Pulumi Cloud": "los stacks de otros proyectos solo se pueden explorar en Pulumi Cloud",
+	"Browse org stacks (read-only)":                                "Explorar stacks de la org (solo lectura)",
+	"check":                                                        "comprobar",
+	"repair":                                                       "reparar",
+	"unprotect":                                                    "desproteger",
+	"protect":                                                      "proteger",
+	"bulk import":                                                  "importar en lote",
+	"timings":                                                      "tiempos",
+	"engine log":                                                   "registro del motor",
+	"open":                                                         "abrir",
+	"clear":                                                        "limpiar",
+	"more":                                                         "más",
+	"fewer keys":                                                   "menos teclas",
+	"all footer keys":                                              "todas las teclas",
+	"Show all footer keys":                                         "Mostrar todas las teclas en el pie",
+	"undo":                                                         "deshacer",
+	"Nothing to undo":                                              "Nada que deshacer",
+	"Nothing to redo":                                              "Nada que rehacer",
+	"Undo flag or selection change":                                "Deshacer cambio de marcas o selección",
+	"Redo flag or selection change":                                "Rehacer cambio de marcas o selección",
+	"undo flags":                                                   "deshacer marcas",
+	"redo flags":                                                   "rehacer marcas",
+	"Target Sets":                                                  "Conjuntos de objetivos",
+	"Loading target sets...":                                       "Cargando conjuntos de objetivos...",
+	"No target sets saved in p5.toml. Flag resources to save one.": "No hay conjuntos de objetivos guardados en p5.toml. Marca recursos para guardar uno.",
+	"%d target":            "%d objetivo",
+	"%d replace":           "%d reemplazo",
+	"%d exclude":           "%d excluido",
+	"+ Save Current Flags": "+ Guardar marcas actuales",
+	"Save Target Set":      "Guardar conjunto de objetivos",
+	"Save the %d flagged resources to p5.toml to re-apply them later": "Guarda los %d recursos marcados en p5.toml para volver a aplicarlos más tarde",
+	"e.g. frontend-only":    "p. ej. solo-frontend",
+	"Applied target set %s": "Conjunto de objetivos %s aplicado",
+	"Applied target set %s, %d of its resources are not in the stack": "Conjunto de objetivos %s aplicado, %d de sus recursos no están en el stack",
+	"a target set with this name already exists":                      "ya existe un conjunto de objetivos con este nombre",
+	"Failed to save target set: %v":                                   "Error al guardar el conjunto de objetivos: %v",
+	"Saved target set %s to %s":                                       "Conjunto de objetivos %s guardado en %s",
+	"Save or apply target sets":                                       "Guardar o aplicar conjuntos de objetivos",
+	"target sets":                                                     "conjuntos",
+	"group":                                                           "agrupar",
+	"group by":                                                        "agrupar por",
+	"collapse/expand":                                                 "contraer/expandir",
+	"Group by type or provider":                                       "Agrupar por tipo o proveedor",
+	"Grouped by type":                                                 "Agrupado por tipo",
+	"Grouped by provider":                                             "Agrupado por proveedor",
+	"Showing resource tree":                                           "Mostrando el árbol de recursos",
+	"collapse/expand all":                                             "contraer/expandir todo",
+	"Collapse or expand group or children":                            "Contraer o expandir grupo o hijos",
+	"Collapse or expand all":                                          "Contraer o expandir todo",
+	"pin":                                                             "fijar",
+	"unpin":                                                           "desfijar",
+	"Pin resource to the top":                                         "Fijar recurso arriba",
+	"Pinned %s":                                                       "%s fijado",
+	"Unpinned %s":                                                     "%s desfijado",
+	"Failed to load pinned resources: %v":                             "Error al cargar los recursos fijados: %v",
+	"Failed to save pinned resources: %v":                             "Error al guardar los recursos fijados: %v",
+	"next change":                                                     "siguiente cambio",
+	"previous change":                                                 "cambio anterior",
+	"Go to resource by name or URN":                                   "Ir a un recurso por nombre o URN",
+	"Next resource with changes":                                      "Siguiente recurso con cambios",
+	"Previous resource with changes":                                  "Recurso anterior con cambios",
+	"No more changes":                                                 "No hay más cambios",
+	"No resource matches %s":                                          "Ningún recurso coincide con %s",
+	"Go to Resource":                                                  "Ir a Recurso",
+	"Jump to the resource best matching a name or URN":                "Saltar al recurso que mejor coincide con un nombre o URN",
+	"Resource":                                                        "Recurso",
+	"e.g. db or rds/instance":                                         "p. ej. db o rds/instance",
+	"a name or URN is required":                                       "se requiere un nombre o URN",
+	"go to":                                                           "ir a",
+	"Replaced because these properties changed:":                      "Se reemplaza porque cambiaron estas propiedades:",
+	"reveal secret":                                                   "revelar secreto",
+	"Reveal a secret (in details)":                                    "Revelar un secreto (en detalles)",
+	"Reveal Secret":                                                   "Revelar secreto",
+	"No secrets to reveal":                                            "No hay secretos para revelar",
+	"Failed to reveal secret: %v":                                     "Error al revelar el secreto: %v",
+	"Failed to load diff ignore rules: %v":                            "Error al cargar las reglas para ignorar propiedades: %v",
+	"view value":                                                      "ver valor",
+	"View a large value in a pager (in details)":                      "Ver un valor grande en un paginador (en detalles)",
+	"View Value":                                                      "Ver valor",
+	"No large values":                                                 "No hay valores grandes",
+	"%s (before)":                                                     "%s (antes)",
+	"[+%d chars]":                                                     "[+%d caracteres]",
+	"[+%d lines]":                                                     "[+%d líneas]",
+	"%d lines":                                                        "%d líneas",
+	"Pager failed: %v":                                                "Error del paginador: %v",
+	"export resource list":                                            "exportar lista de recursos",
+	"Export listed resources to CSV or JSON":                          "Exportar los recursos listados a CSV o JSON",
+	"Export Resources":                                                "Exportar recursos",
+	"Export the listed resources to a .csv or .json file": "Exportar los recursos listados a un archivo .csv o .json",
+	"Export to a .csv or .json file":                      "Exporta a un archivo .csv o .json",
+	"copy preview as markdown":                            "copiar vista previa como markdown",
+	"Copy preview as markdown (in preview)":               "Copiar vista previa como markdown (en vista previa)",
+	"Wait for the preview to finish":                      "Espera a que termine la vista previa",
+	"Run %s with the changes previewed?":                  "¿Ejecutar %s con los cambios previsualizados?",
+	"%d changes, more than the %d allowed":                "%d cambios, más de los %d permitidos",
+	"Not auto-approved:":                                  "Sin aprobación automática:",
+	"The preview did not finish":                          "La previsualización no terminó",
+	"Flags changed since the preview":                     "Las marcas cambiaron desde la previsualización",
+}
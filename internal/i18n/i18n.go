@@ -0,0 +1,119 @@
+// Package i18n provides translation of user-facing strings.
+//
+// Messages are keyed by their English source text, so untranslated strings
+// (and the default English locale) render exactly as written in the code.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Locale identifies a supported language
+type Locale string
+
+const (
+	// English is the source language and the default locale
+	English Locale = "en"
+	// Spanish is the Spanish translation
+	Spanish Locale = "es"
+)
+
+// catalogs maps each non-English locale to its translations, keyed by English source text
+var catalogs = map[Locale]map[string]string{
+	Spanish: spanish,
+}
+
+var current atomic.Value
+
+func init() {
+	current.Store(English)
+}
+
+// SetLocale sets the active locale. Unsupported locales fall back to English.
+func SetLocale(l Locale) {
+	if !Supported(l) {
+		l = English
+	}
+	current.Store(l)
+}
+
+// Current returns the active locale
+func Current() Locale {
+	l, _ := current.Load().(Locale)
+	return l
+}
+
+// Supported reports whether a locale has a translation catalog
+func Supported(l Locale) bool {
+	if l == English {
+		return true
+	}
+	_, ok := catalogs[l]
+	return ok
+}
+
+// Locales returns all supported locales
+func Locales() []Locale {
+	return []Locale{English, Spanish}
+}
+
+// Detect resolves the locale to use. A configured value (from p5.toml) takes
+// precedence, followed by the LC_ALL, LC_MESSAGES and LANG environment variables.
+// Unsupported or missing values resolve to English.
+func Detect(configured string) Locale {
+	candidates := []string{
+		configured,
+		os.Getenv("LC_ALL"),
+		os.Getenv("LC_MESSAGES"),
+		os.Getenv("LANG"),
+	}
+	for _, c := range candidates {
+		if c == "" {
+			continue
+		}
+		l := Parse(c)
+		if Supported(l) {
+			return l
+		}
+		// An explicit but unsupported setting stops the search, matching how
+		// LC_ALL overrides LANG even when no catalog exists for it
+		return English
+	}
+	return English
+}
+
+// Parse extracts the language from a POSIX locale string such as "es_ES.UTF-8"
+func Parse(s string) Locale {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexAny(s, ".@"); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexAny(s, "_-"); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.ToLower(s)
+	if s == "c" || s == "posix" {
+		return English
+	}
+	return Locale(s)
+}
+
+// T translates a message into the active locale
+func T(msg string) string {
+	l := Current()
+	if l == English {
+		return msg
+	}
+	if translated, ok := catalogs[l][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Tf translates a format string into the active locale and formats it
+func Tf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
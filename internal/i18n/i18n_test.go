@@ -0,0 +1,112 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// TestParse verifies POSIX locale strings are reduced to their language.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Locale
+	}{
+		{"es", Spanish},
+		{"es_ES.UTF-8", Spanish},
+		{"es-MX", Spanish},
+		{"en_US.UTF-8", English},
+		{"de_DE@euro", Locale("de")},
+		{"C", English},
+		{"POSIX", English},
+		{"C.UTF-8", English},
+	}
+
+	for _, tt := range tests {
+		if got := Parse(tt.input); got != tt.want {
+			t.Errorf("Parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestDetect_ConfiguredWins verifies the configured locale overrides the environment.
+func TestDetect_ConfiguredWins(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	if got := Detect("es"); got != Spanish {
+		t.Errorf("expected %q, got %q", Spanish, got)
+	}
+}
+
+// TestDetect_Environment verifies LC_ALL takes precedence over LANG.
+func TestDetect_Environment(t *testing.T) {
+	t.Setenv("LC_ALL", "es_ES.UTF-8")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	if got := Detect(""); got != Spanish {
+		t.Errorf("expected %q, got %q", Spanish, got)
+	}
+}
+
+// TestDetect_Unsupported verifies unsupported locales fall back to English.
+func TestDetect_Unsupported(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "es_ES.UTF-8")
+
+	if got := Detect("fr"); got != English {
+		t.Errorf("expected %q, got %q", English, got)
+	}
+}
+
+// TestSetLocale_Unsupported verifies unsupported locales are stored as English.
+func TestSetLocale_Unsupported(t *testing.T) {
+	t.Cleanup(func() { SetLocale(English) })
+
+	SetLocale(Locale("fr"))
+	if got := Current(); got != English {
+		t.Errorf("expected %q, got %q", English, got)
+	}
+}
+
+// TestT verifies translation lookup and fallback to the source text.
+func TestT(t *testing.T) {
+	t.Cleanup(func() { SetLocale(English) })
+
+	if got := T("Loading..."); got != "Loading..." {
+		t.Errorf("expected English source text, got %q", got)
+	}
+
+	SetLocale(Spanish)
+	if got := T("Loading..."); got != "Cargando..." {
+		t.Errorf("expected Spanish translation, got %q", got)
+	}
+	if got := T("untranslated message"); got != "untranslated message" {
+		t.Errorf("expected fallback to source text, got %q", got)
+	}
+	if got := Tf("Total: %d resources", 3); got != "Total: 3 recursos" {
+		t.Errorf("expected formatted translation, got %q", got)
+	}
+}
+
+var verbPattern = regexp.MustCompile(`%[-+# 0]*[0-9]*[a-zA-Z%]`)
+
+// TestCatalogs_FormatVerbs verifies every translation keeps the format verbs of its source.
+func TestCatalogs_FormatVerbs(t *testing.T) {
+	for locale, catalog := range catalogs {
+		for src, translated := range catalog {
+			if translated == "" {
+				t.Errorf("%s: empty translation for %q", locale, src)
+				continue
+			}
+			want := verbPattern.FindAllString(src, -1)
+			got := verbPattern.FindAllString(translated, -1)
+			if !slices.Equal(want, got) {
+				t.Errorf("%s: %q has verbs %v, translation %q has %v", locale, src, want, translated, got)
+			}
+		}
+	}
+}
@@ -10,10 +10,14 @@ import (
 	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/rfhold/p5/internal/plugins/proto"
+	"github.com/rfhold/p5/internal/telemetry"
 )
 
 var ErrAuthenticationFailed = errors.New("authentication failed")
@@ -24,6 +28,7 @@ type Credentials struct {
 	Env        map[string]string
 	ExpiresAt  time.Time // Zero time means never expires (TTL = -1 means always refresh)
 	AlwaysCall bool      // True if TTL was -1
+	IssuedAt   time.Time // When the plugin authenticated
 }
 
 // IsExpired returns true if the credentials have expired
@@ -50,6 +55,7 @@ type CredentialsSummary struct {
 	EnvVars    []string  // List of env var names (not values for security)
 	ExpiresAt  time.Time // Zero if never expires
 	AlwaysCall bool
+	IssuedAt   time.Time // Zero if the plugin hasn't authenticated
 	HasError   bool
 	Error      string
 }
@@ -98,11 +104,9 @@ func (m *Manager) AuthenticateAll(ctx context.Context, programName, stackName st
 		allResults = append(allResults, result)
 
 		// Cache successful credentials immediately so subsequent plugins can use them
-		if result.Error == nil && result.Credentials != nil {
-			m.mu.Lock()
-			m.credentials[result.PluginName] = result.Credentials
-			m.mu.Unlock()
-		}
+		m.mu.Lock()
+		m.storeResultLocked(result)
+		m.mu.Unlock()
 	}
 
 	// Phase 2: Authenticate remaining plugins in parallel
@@ -153,11 +157,9 @@ func (m *Manager) AuthenticateAll(ctx context.Context, programName, stackName st
 
 		for result := range results {
 			allResults = append(allResults, result)
-			if result.Error == nil && result.Credentials != nil {
-				m.mu.Lock()
-				m.credentials[result.PluginName] = result.Credentials
-				m.mu.Unlock()
-			}
+			m.mu.Lock()
+			m.storeResultLocked(result)
+			m.mu.Unlock()
 		}
 	}
 
@@ -167,6 +169,22 @@ func (m *Manager) AuthenticateAll(ctx context.Context, programName, stackName st
 	return allResults, nil
 }
 
+// storeResultLocked caches the credentials of a successful authentication, or
+// records why it failed. Callers must hold the write lock.
+func (m *Manager) storeResultLocked(result AuthenticateResult) {
+	if m.authErrors == nil {
+		m.authErrors = make(map[string]error)
+	}
+	if result.Error != nil {
+		m.authErrors[result.PluginName] = result.Error
+		return
+	}
+	if result.Credentials != nil {
+		m.credentials[result.PluginName] = result.Credentials
+	}
+	delete(m.authErrors, result.PluginName)
+}
+
 // checkCachedCredentials returns cached credentials if valid, and whether cache was used
 func (m *Manager) checkCachedCredentials(name string) (AuthenticateResult, bool) {
 	m.mu.RLock()
@@ -193,6 +211,12 @@ func (m *Manager) authenticatePlugin(ctx context.Context, name string, pluginIns
 
 // authenticateWithHash runs authentication for a single plugin and returns the config hash
 func (m *Manager) authenticateWithHash(ctx context.Context, name string, pluginInst *PluginInstance, programName, stackName string, p5Config *P5Config, workDir string) (result AuthenticateResult, configHash string) {
+	ctx, span := telemetry.Tracer().Start(ctx, "plugin.authenticate", trace.WithAttributes(
+		telemetry.AttrPlugin.String(name),
+		telemetry.AttrStack.String(stackName),
+	))
+	defer func() { telemetry.EndSpan(span, result.Error) }()
+
 	// Get program-level config
 	programConfig := make(map[string]any)
 	if pluginCfg, ok := p5Config.Plugins[name]; ok {
@@ -246,6 +270,7 @@ func (m *Manager) authenticateWithHash(ctx context.Context, name string, pluginI
 	creds := &Credentials{
 		PluginName: name,
 		Env:        resp.Env,
+		IssuedAt:   time.Now(),
 	}
 
 	if resp.TtlSeconds < 0 {
@@ -261,12 +286,14 @@ func (m *Manager) authenticateWithHash(ctx context.Context, name string, pluginI
 	}, cfgHash
 }
 
-// GetAllEnv returns all environment variables from all valid credentials
+// GetAllEnv returns all environment variables from all valid credentials,
+// layered over any ESC environment variables
 func (m *Manager) GetAllEnv() map[string]string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	env := make(map[string]string)
+	maps.Copy(env, m.environmentEnv)
 	for _, creds := range m.credentials {
 		if !creds.IsExpired() || creds.AlwaysCall {
 			maps.Copy(env, creds.Env)
@@ -295,6 +322,7 @@ func (m *Manager) InvalidateCredentials(pluginName string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	delete(m.credentials, pluginName)
+	delete(m.refreshRetryAt, pluginName)
 }
 
 // InvalidateAllCredentials clears all cached credentials
@@ -302,26 +330,41 @@ func (m *Manager) InvalidateAllCredentials() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.credentials = make(map[string]*Credentials)
+	m.refreshRetryAt = make(map[string]time.Time)
 }
 
-// GetCredentialsSummary returns a summary of all credentials for UI display
+// GetCredentialsSummary returns a summary of the credentials of every loaded plugin
+// for UI display, in the configured order
 func (m *Manager) GetCredentialsSummary() []CredentialsSummary {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	var summaries []CredentialsSummary
-	for name, creds := range m.credentials {
-		envVars := make([]string, 0, len(creds.Env))
-		for k := range creds.Env {
-			envVars = append(envVars, k)
+	// Plugins in the order list come first, the rest by name
+	names := slices.Sorted(maps.Keys(m.plugins))
+	if m.mergedConfig != nil {
+		rank := func(name string) int {
+			if i := slices.Index(m.mergedConfig.Order, name); i >= 0 {
+				return i
+			}
+			return len(m.mergedConfig.Order)
 		}
+		slices.SortStableFunc(names, func(a, b string) int { return rank(a) - rank(b) })
+	}
 
-		summaries = append(summaries, CredentialsSummary{
-			PluginName: name,
-			EnvVars:    envVars,
-			ExpiresAt:  creds.ExpiresAt,
-			AlwaysCall: creds.AlwaysCall,
-		})
+	summaries := make([]CredentialsSummary, 0, len(names))
+	for _, name := range names {
+		summary := CredentialsSummary{PluginName: name}
+		if creds, ok := m.credentials[name]; ok {
+			summary.EnvVars = slices.Sorted(maps.Keys(creds.Env))
+			summary.ExpiresAt = creds.ExpiresAt
+			summary.AlwaysCall = creds.AlwaysCall
+			summary.IssuedAt = creds.IssuedAt
+		}
+		if err := m.authErrors[name]; err != nil {
+			summary.HasError = true
+			summary.Error = err.Error()
+		}
+		summaries = append(summaries, summary)
 	}
 
 	return summaries
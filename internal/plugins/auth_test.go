@@ -228,3 +228,31 @@ func TestCredentials_IsExpired_Past(t *testing.T) {
 		t.Error("expected past ExpiresAt to be expired")
 	}
 }
+
+// TestManager_EnvironmentEnv_PluginCredentialsOverride verifies ESC variables are merged
+// beneath plugin credentials.
+func TestManager_EnvironmentEnv_PluginCredentialsOverride(t *testing.T) {
+	m, _ := NewManager(t.TempDir())
+	m.credentials["aws"] = &Credentials{
+		PluginName: "aws",
+		Env:        map[string]string{"AWS_PROFILE": "plugin"},
+	}
+	m.SetEnvironmentEnv(map[string]string{"AWS_PROFILE": "esc", "ESC_TOKEN": "token"})
+
+	for name, env := range map[string]map[string]string{
+		"GetAllEnv":        m.GetAllEnv(),
+		"GetMergedAuthEnv": m.GetMergedAuthEnv(),
+	} {
+		if env["AWS_PROFILE"] != "plugin" {
+			t.Errorf("%s: expected plugin credentials to win, got %q", name, env["AWS_PROFILE"])
+		}
+		if env["ESC_TOKEN"] != "token" {
+			t.Errorf("%s: expected ESC variable to be included, got %q", name, env["ESC_TOKEN"])
+		}
+	}
+
+	m.SetEnvironmentEnv(nil)
+	if _, ok := m.GetAllEnv()["ESC_TOKEN"]; ok {
+		t.Error("expected ESC variables to be cleared")
+	}
+}
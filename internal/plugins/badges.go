@@ -0,0 +1,85 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// StatusBadge is the status a plugin shows in the header
+type StatusBadge struct {
+	PluginName string
+	Text       string
+	Level      StatusBadgeLevel
+	Error      error
+}
+
+// HasStatusBadges returns true if any plugin has status badges enabled
+func (m *Manager) HasStatusBadges() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, instance := range m.plugins {
+		if instance.HasStatusBadge() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStatusBadges asks every plugin with status badges enabled for its badge, in
+// name order, for the stack plugins last authenticated for. Plugins returning an
+// empty text are left out; failures are returned with their error set.
+func (m *Manager) GetStatusBadges(ctx context.Context) []StatusBadge {
+	m.mu.RLock()
+	authCtx := m.currentContext
+	instances := maps.Clone(m.plugins)
+	var pluginConfigs map[string]PluginConfig
+	if m.mergedConfig != nil {
+		pluginConfigs = m.mergedConfig.Plugins
+	}
+	authEnv := m.getMergedAuthEnvLocked()
+	m.mu.RUnlock()
+
+	if authCtx == nil {
+		return nil
+	}
+
+	var badges []StatusBadge
+	for _, name := range slices.Sorted(maps.Keys(instances)) {
+		instance := instances[name]
+		if !instance.HasStatusBadge() {
+			continue
+		}
+
+		req := &StatusBadgeRequest{
+			ProgramConfig: convertToStringMap(pluginConfigs[name].Config),
+			StackConfig:   map[string]string{},
+			StackName:     authCtx.StackName,
+			ProgramName:   authCtx.ProgramName,
+		}
+		if pluginConfigs[name].UseAuthEnv {
+			req.AuthEnv = authEnv
+		}
+		stackResult, err := LoadStackPluginConfig(authCtx.WorkDir, authCtx.StackName, name)
+		if err != nil {
+			badges = append(badges, StatusBadge{PluginName: name, Error: fmt.Errorf("failed to load stack config: %w", err)})
+			continue
+		}
+		if stackResult != nil {
+			req.StackConfig = convertToStringMap(stackResult.Config)
+		}
+
+		resp, err := instance.statusBadge.GetStatusBadge(ctx, req)
+		switch {
+		case err != nil:
+			badges = append(badges, StatusBadge{PluginName: name, Error: err})
+		case resp.Error != "":
+			badges = append(badges, StatusBadge{PluginName: name, Error: fmt.Errorf("%s", resp.Error)})
+		case resp.Text != "":
+			badges = append(badges, StatusBadge{PluginName: name, Text: resp.Text, Level: resp.Level})
+		}
+	}
+	return badges
+}
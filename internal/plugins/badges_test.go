@@ -0,0 +1,102 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// mockBadgePlugin is a builtin plugin returning a fixed status badge
+type mockBadgePlugin struct {
+	mockBuiltinPlugin
+	badge    *StatusBadgeResponse
+	err      error
+	requests []*StatusBadgeRequest
+}
+
+func (m *mockBadgePlugin) GetStatusBadge(ctx context.Context, req *StatusBadgeRequest) (*StatusBadgeResponse, error) {
+	m.requests = append(m.requests, req)
+	return m.badge, m.err
+}
+
+// TestManager_GetStatusBadges verifies only plugins with status_badge enabled are
+// asked for a badge, with their configuration for the authenticated stack
+func TestManager_GetStatusBadges(t *testing.T) {
+	originalRegistry := builtinRegistry
+	defer func() { builtinRegistry = originalRegistry }()
+	builtinRegistry = make(map[string]BuiltinPlugin)
+
+	aws := &mockBadgePlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("aws")}, badge: NewStatusBadge("AWS: prod-account", StatusBadgeLevelDanger)}
+	empty := &mockBadgePlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("empty")}, badge: NewStatusBadge("", StatusBadgeLevelInfo)}
+	failing := &mockBadgePlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("failing")}, err: errors.New("no kubeconfig")}
+	disabled := &mockBadgePlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("disabled")}, badge: NewStatusBadge("hidden", StatusBadgeLevelInfo)}
+	for _, p := range []BuiltinPlugin{aws, empty, failing, disabled} {
+		RegisterBuiltin(p)
+	}
+
+	workDir := t.TempDir()
+	stackYAML := "config:\n  p5:plugins:\n    aws:\n      config:\n        profile: prod\n"
+	if err := os.WriteFile(filepath.Join(workDir, "Pulumi.prod.yaml"), []byte(stackYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &P5Config{Plugins: map[string]PluginConfig{
+		"aws":      {StatusBadge: true, UseAuthEnv: true, Config: map[string]any{"region": "us-east-1"}},
+		"empty":    {StatusBadge: true},
+		"failing":  {StatusBadge: true},
+		"disabled": {},
+	}}
+	mgr, _ := NewManager("")
+	if err := mgr.LoadPlugins(context.Background(), cfg); err != nil {
+		t.Fatalf("LoadPlugins failed: %v", err)
+	}
+	mgr.mergedConfig = cfg
+
+	if !mgr.HasStatusBadges() {
+		t.Fatal("expected HasStatusBadges=true")
+	}
+	if badges := mgr.GetStatusBadges(context.Background()); badges != nil {
+		t.Errorf("expected no badges before plugins authenticated for a stack, got %+v", badges)
+	}
+	if _, err := mgr.AuthenticateAll(context.Background(), "app", "prod", cfg, workDir); err != nil {
+		t.Fatalf("AuthenticateAll failed: %v", err)
+	}
+
+	badges := mgr.GetStatusBadges(context.Background())
+	if len(badges) != 2 || badges[0].PluginName != "aws" || badges[1].PluginName != "failing" {
+		t.Fatalf("expected badges for aws and failing in name order, got %+v", badges)
+	}
+	if badges[0].Text != "AWS: prod-account" || badges[0].Level != StatusBadgeLevelDanger || badges[0].Error != nil {
+		t.Errorf("unexpected aws badge %+v", badges[0])
+	}
+	if badges[1].Error == nil {
+		t.Error("expected the failing plugin to report its error")
+	}
+	if len(disabled.requests) != 0 {
+		t.Error("expected the disabled plugin not to be asked for a badge")
+	}
+
+	req := aws.requests[0]
+	if req.StackName != "prod" || req.ProgramName != "app" {
+		t.Errorf("expected the authenticated stack, got %q/%q", req.ProgramName, req.StackName)
+	}
+	if req.ProgramConfig["region"] != "us-east-1" || req.StackConfig["profile"] != "prod" {
+		t.Errorf("expected program and stack config, got %v and %v", req.ProgramConfig, req.StackConfig)
+	}
+	if req.AuthEnv["TEST_VAR"] != "test_value" {
+		t.Errorf("expected the auth env with use_auth_env, got %v", req.AuthEnv)
+	}
+	if empty.requests[0].AuthEnv != nil {
+		t.Error("expected no auth env without use_auth_env")
+	}
+}
+
+func TestManager_HasStatusBadges_NoPlugins(t *testing.T) {
+	mgr, _ := NewManager("")
+
+	if mgr.HasStatusBadges() {
+		t.Error("expected HasStatusBadges=false when no plugins")
+	}
+}
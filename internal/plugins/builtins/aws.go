@@ -0,0 +1,217 @@
+package builtins
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/plugins/proto"
+	"github.com/rfhold/p5/pkg/plugin"
+)
+
+var (
+	errAWSRegionNotFound       = errors.New("aws region not found in provider inputs, config or ARN")
+	errS3BucketNameMissing     = errors.New("s3 bucket name not found in outputs")
+	errLambdaNameMissing       = errors.New("lambda function name not found in outputs")
+	errIAMRoleNameMissing      = errors.New("iam role name not found in outputs")
+	errEC2InstanceIDMissing    = errors.New("ec2 instance id not found in outputs")
+	errRDSIdentifierMissing    = errors.New("rds instance identifier not found in outputs")
+	errAWSUnsupportedPartition = errors.New("unsupported aws partition")
+)
+
+func init() {
+	plugins.RegisterBuiltin(&AWSPlugin{
+		BuiltinPluginBase: plugins.NewBuiltinPluginBase("aws"),
+	})
+}
+
+// AWSPlugin provides resource opening capabilities for AWS resources
+// by generating URLs to the AWS console.
+type AWSPlugin struct {
+	plugins.BuiltinPluginBase
+}
+
+// Authenticate returns a no-op success response.
+// This plugin is primarily for resource opening, not auth.
+func (p *AWSPlugin) Authenticate(ctx context.Context, req *proto.AuthenticateRequest) (*proto.AuthenticateResponse, error) {
+	return plugins.SuccessResponse(nil, 0), nil
+}
+
+// GetSupportedOpenTypes returns regex patterns for AWS resource types.
+func (p *AWSPlugin) GetSupportedOpenTypes(ctx context.Context, req *plugin.SupportedOpenTypesRequest) (*plugin.SupportedOpenTypesResponse, error) {
+	return plugin.SupportedOpenTypesPatterns(
+		`^aws:s3/bucket:Bucket$`,
+		`^aws:s3/bucketV2:BucketV2$`,
+		`^aws:lambda/function:Function$`,
+		`^aws:iam/role:Role$`,
+		`^aws:ec2/instance:Instance$`,
+		`^aws:rds/instance:Instance$`,
+	), nil
+}
+
+// OpenResource returns a browser URL to open an AWS resource in the console.
+func (p *AWSPlugin) OpenResource(ctx context.Context, req *plugin.OpenResourceRequest) (*plugin.OpenResourceResponse, error) {
+	consoleURL, err := p.buildResourceURL(req)
+	if err != nil {
+		return plugin.OpenError("%v", err), nil
+	}
+	if consoleURL == "" {
+		return plugin.OpenNotSupported(), nil
+	}
+
+	return plugin.OpenBrowserResponse(consoleURL), nil
+}
+
+func (p *AWSPlugin) buildResourceURL(req *plugin.OpenResourceRequest) (string, error) {
+	switch req.ResourceType {
+	case "aws:s3/bucket:Bucket", "aws:s3/bucketV2:BucketV2":
+		return p.buildS3BucketURL(req)
+	case "aws:lambda/function:Function":
+		return p.buildLambdaFunctionURL(req)
+	case "aws:iam/role:Role":
+		return p.buildIAMRoleURL(req)
+	case "aws:ec2/instance:Instance":
+		return p.buildEC2InstanceURL(req)
+	case "aws:rds/instance:Instance":
+		return p.buildRDSInstanceURL(req)
+	default:
+		return "", nil
+	}
+}
+
+func (p *AWSPlugin) buildS3BucketURL(req *plugin.OpenResourceRequest) (string, error) {
+	bucket := cmp.Or(req.Outputs["bucket"], req.Outputs["id"])
+	if bucket == "" {
+		return "", errS3BucketNameMissing
+	}
+	// S3 ARNs carry no region, but buckets report the one they live in
+	region := cmp.Or(req.Outputs["region"], awsRegion(req))
+	if region == "" {
+		return "", errAWSRegionNotFound
+	}
+	host, err := awsConsoleHost(awsPartition(req, region), region)
+	if err != nil {
+		return "", err
+	}
+	return host + "/s3/buckets/" + url.PathEscape(bucket) + "?region=" + region, nil
+}
+
+func (p *AWSPlugin) buildLambdaFunctionURL(req *plugin.OpenResourceRequest) (string, error) {
+	name := cmp.Or(req.Outputs["name"], req.Outputs["id"])
+	if name == "" {
+		return "", errLambdaNameMissing
+	}
+	return awsRegionalURL(req, "lambda", "/functions/"+url.PathEscape(name))
+}
+
+func (p *AWSPlugin) buildIAMRoleURL(req *plugin.OpenResourceRequest) (string, error) {
+	name := cmp.Or(req.Outputs["name"], req.Outputs["id"])
+	if name == "" {
+		return "", errIAMRoleNameMissing
+	}
+	// IAM is global, so the console URL has no region
+	host, err := awsConsoleHost(awsPartition(req, awsRegion(req)), "")
+	if err != nil {
+		return "", err
+	}
+	return host + "/iam/home#/roles/details/" + url.PathEscape(name), nil
+}
+
+func (p *AWSPlugin) buildEC2InstanceURL(req *plugin.OpenResourceRequest) (string, error) {
+	id := req.Outputs["id"]
+	if id == "" {
+		return "", errEC2InstanceIDMissing
+	}
+	return awsRegionalURL(req, "ec2", "InstanceDetails:instanceId="+id)
+}
+
+func (p *AWSPlugin) buildRDSInstanceURL(req *plugin.OpenResourceRequest) (string, error) {
+	// Since v6 of the provider, id is the resource ID; identifier is the name
+	identifier := cmp.Or(req.Outputs["identifier"], req.Outputs["id"])
+	if identifier == "" {
+		return "", errRDSIdentifierMissing
+	}
+	return awsRegionalURL(req, "rds", "database:id="+identifier+";is-cluster=false")
+}
+
+// awsRegionalURL builds a console URL for a regional service, with the page in the fragment
+func awsRegionalURL(req *plugin.OpenResourceRequest, service, fragment string) (string, error) {
+	region := awsRegion(req)
+	if region == "" {
+		return "", errAWSRegionNotFound
+	}
+	host, err := awsConsoleHost(awsPartition(req, region), region)
+	if err != nil {
+		return "", err
+	}
+	return host + "/" + service + "/home?region=" + region + "#" + fragment, nil
+}
+
+// awsRegion returns the resource's region - priority: provider inputs > stack config > program config > ARN
+func awsRegion(req *plugin.OpenResourceRequest) string {
+	region := req.ProviderInputs["region"]
+	if region == "" {
+		region = req.StackConfig["aws:region"]
+	}
+	if region == "" {
+		region = req.ProgramConfig["aws:region"]
+	}
+	if region == "" {
+		region = parseARN(req.Outputs["arn"]).region
+	}
+	return region
+}
+
+// awsPartition returns the partition the resource lives in, taken from its ARN
+// or else inferred from the region
+func awsPartition(req *plugin.OpenResourceRequest, region string) string {
+	if partition := parseARN(req.Outputs["arn"]).partition; partition != "" {
+		return partition
+	}
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	default:
+		return "aws"
+	}
+}
+
+// awsConsoleHost returns the console base URL of a partition. An empty region
+// gives the URL of global services.
+func awsConsoleHost(partition, region string) (string, error) {
+	switch partition {
+	case "aws":
+		if region == "" {
+			return "https://console.aws.amazon.com", nil
+		}
+		return "https://" + region + ".console.aws.amazon.com", nil
+	case "aws-cn":
+		return "https://console.amazonaws.cn", nil
+	case "aws-us-gov":
+		return "https://console.amazonaws-us-gov.com", nil
+	default:
+		return "", errAWSUnsupportedPartition
+	}
+}
+
+// awsARN holds the fields of an ARN used to build console URLs
+type awsARN struct {
+	partition string
+	region    string
+}
+
+// parseARN reads the partition and region of an ARN
+// (arn:partition:service:region:account:resource), returning zero values when
+// arn is not one
+func parseARN(arn string) awsARN {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 || parts[0] != "arn" {
+		return awsARN{}
+	}
+	return awsARN{partition: parts[1], region: parts[3]}
+}
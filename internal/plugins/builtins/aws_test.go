@@ -0,0 +1,202 @@
+package builtins
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/plugins/proto"
+	"github.com/rfhold/p5/pkg/plugin"
+	"github.com/rfhold/p5/pkg/plugin/plugintest"
+)
+
+func newAWSPlugin() *AWSPlugin {
+	return &AWSPlugin{
+		BuiltinPluginBase: plugins.NewBuiltinPluginBase("aws"),
+	}
+}
+
+func TestAWSPlugin_Name(t *testing.T) {
+	p := newAWSPlugin()
+
+	if p.Name() != "aws" {
+		t.Errorf("expected Name=%q, got %q", "aws", p.Name())
+	}
+}
+
+func TestAWSPlugin_GetSupportedOpenTypes(t *testing.T) {
+	p := newAWSPlugin()
+
+	resp, err := p.GetSupportedOpenTypes(context.Background(), &plugin.SupportedOpenTypesRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, pattern := range []string{
+		`^aws:s3/bucket:Bucket$`,
+		`^aws:lambda/function:Function$`,
+		`^aws:iam/role:Role$`,
+		`^aws:ec2/instance:Instance$`,
+		`^aws:rds/instance:Instance$`,
+	} {
+		if !slices.Contains(resp.ResourceTypePatterns, pattern) {
+			t.Errorf("expected pattern %s in %v", pattern, resp.ResourceTypePatterns)
+		}
+	}
+}
+
+func TestAWSPlugin_OpenResource(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *plugin.OpenResourceRequest
+		expected string
+	}{
+		{
+			name: "s3 bucket",
+			req: &plugin.OpenResourceRequest{
+				ResourceType: "aws:s3/bucketV2:BucketV2",
+				Outputs:      map[string]string{"bucket": "logs-1234", "region": "eu-west-1", "arn": "arn:aws:s3:::logs-1234"},
+			},
+			expected: "https://eu-west-1.console.aws.amazon.com/s3/buckets/logs-1234?region=eu-west-1",
+		},
+		{
+			name: "lambda function",
+			req: &plugin.OpenResourceRequest{
+				ResourceType:   "aws:lambda/function:Function",
+				ProviderInputs: map[string]string{"region": "us-west-2"},
+				Outputs:        map[string]string{"name": "handler-abc"},
+			},
+			expected: "https://us-west-2.console.aws.amazon.com/lambda/home?region=us-west-2#/functions/handler-abc",
+		},
+		{
+			name: "iam role",
+			req: &plugin.OpenResourceRequest{
+				ResourceType: "aws:iam/role:Role",
+				Outputs:      map[string]string{"name": "deployer", "arn": "arn:aws:iam::123456789012:role/deployer"},
+			},
+			expected: "https://console.aws.amazon.com/iam/home#/roles/details/deployer",
+		},
+		{
+			name: "ec2 instance",
+			req: &plugin.OpenResourceRequest{
+				ResourceType: "aws:ec2/instance:Instance",
+				StackConfig:  map[string]string{"aws:region": "us-east-2"},
+				Outputs:      map[string]string{"id": "i-0abc123"},
+			},
+			expected: "https://us-east-2.console.aws.amazon.com/ec2/home?region=us-east-2#InstanceDetails:instanceId=i-0abc123",
+		},
+		{
+			name: "rds instance",
+			req: &plugin.OpenResourceRequest{
+				ResourceType: "aws:rds/instance:Instance",
+				Outputs: map[string]string{
+					"id":         "db-ABCDEFGHIJ",
+					"identifier": "orders",
+					"arn":        "arn:aws:rds:ap-southeast-2:123456789012:db:orders",
+				},
+			},
+			expected: "https://ap-southeast-2.console.aws.amazon.com/rds/home?region=ap-southeast-2#database:id=orders;is-cluster=false",
+		},
+		{
+			name: "china partition",
+			req: &plugin.OpenResourceRequest{
+				ResourceType: "aws:lambda/function:Function",
+				Outputs:      map[string]string{"name": "handler", "arn": "arn:aws-cn:lambda:cn-north-1:123456789012:function:handler"},
+			},
+			expected: "https://console.amazonaws.cn/lambda/home?region=cn-north-1#/functions/handler",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := newAWSPlugin().OpenResource(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.CanOpen {
+				t.Fatalf("expected CanOpen=true, got error: %s", resp.Error)
+			}
+			if resp.Action.Type != proto.OpenActionType_OPEN_ACTION_TYPE_BROWSER {
+				t.Errorf("expected browser action, got %v", resp.Action.Type)
+			}
+			if resp.Action.Url != tt.expected {
+				t.Errorf("expected URL=%q, got %q", tt.expected, resp.Action.Url)
+			}
+		})
+	}
+}
+
+func TestAWSPlugin_OpenResource_RegionPriority(t *testing.T) {
+	p := newAWSPlugin()
+
+	req := &plugin.OpenResourceRequest{
+		ResourceType:   "aws:ec2/instance:Instance",
+		ProviderInputs: map[string]string{"region": "us-west-1"},
+		StackConfig:    map[string]string{"aws:region": "us-east-1"},
+		ProgramConfig:  map[string]string{"aws:region": "eu-central-1"},
+		Outputs:        map[string]string{"id": "i-1", "arn": "arn:aws:ec2:sa-east-1:123456789012:instance/i-1"},
+	}
+
+	resp, err := p.OpenResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "https://us-west-1.console.aws.amazon.com/ec2/home?region=us-west-1#InstanceDetails:instanceId=i-1"
+	if resp.Action.Url != expected {
+		t.Errorf("expected provider region to win, got %q", resp.Action.Url)
+	}
+}
+
+func TestAWSPlugin_OpenResource_MissingRegion(t *testing.T) {
+	p := newAWSPlugin()
+
+	req := &plugin.OpenResourceRequest{
+		ResourceType: "aws:ec2/instance:Instance",
+		Outputs:      map[string]string{"id": "i-1"},
+	}
+
+	resp, err := p.OpenResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Error == "" {
+		t.Error("expected an error when no region is known")
+	}
+}
+
+func TestAWSPlugin_OpenResource_NotSupported(t *testing.T) {
+	p := newAWSPlugin()
+
+	req := &plugin.OpenResourceRequest{
+		ResourceType: "aws:sqs/queue:Queue",
+		Outputs:      map[string]string{"id": "https://sqs.us-east-1.amazonaws.com/123456789012/jobs"},
+	}
+
+	resp, err := p.OpenResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.CanOpen {
+		t.Error("expected CanOpen=false for unsupported type")
+	}
+}
+
+func TestAWSPlugin_OpenResource_Golden(t *testing.T) {
+	p := newAWSPlugin()
+
+	req := &plugin.OpenResourceRequest{
+		ResourceType: "aws:lambda/function:Function",
+		ResourceName: "handler",
+		Outputs: map[string]string{
+			"name": "handler-7f3a",
+			"arn":  "arn:aws:lambda:us-east-1:123456789012:function:handler-7f3a",
+		},
+	}
+
+	resp, err := p.OpenResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	plugintest.RequireOpenResourceGolden(t, resp)
+}
@@ -0,0 +1,267 @@
+package builtins
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/plugins/proto"
+	"github.com/rfhold/p5/pkg/plugin"
+)
+
+var (
+	errGitHubOwnerNotFound      = errors.New("github owner not found in provider inputs or config")
+	errGitHubRepoNameMissing    = errors.New("repository name not found in outputs")
+	errGitHubTeamSlugMissing    = errors.New("team slug not found in outputs")
+	errGitHubWebhookRepoMissing = errors.New("webhook repository not found in inputs")
+	errGitHubWebhookIDMissing   = errors.New("webhook id not found in outputs")
+	errGitHubTokenNotAvailable  = errors.New("GITHUB_TOKEN not found in auth env, enable use_auth_env for the github plugin")
+	errGitHubNotFound           = errors.New("github api returned 404 Not Found")
+)
+
+const (
+	defaultGitHubAPIURL = "https://api.github.com/"
+	defaultGitHubWebURL = "https://github.com"
+
+	// gitHubReposPerPage is the page size used when listing repositories
+	gitHubReposPerPage = 100
+	// gitHubMaxRepoPages caps how many pages of repositories are listed
+	gitHubMaxRepoPages = 10
+)
+
+func init() {
+	plugins.RegisterBuiltin(&GitHubPlugin{
+		BuiltinPluginBase: plugins.NewBuiltinPluginBase("github"),
+	})
+}
+
+// GitHubPlugin opens GitHub resources in the browser and provides import
+// suggestions for repositories by listing them through the GitHub API.
+type GitHubPlugin struct {
+	plugins.BuiltinPluginBase
+}
+
+// Authenticate returns a no-op success response.
+// This plugin is primarily for resource opening and import help, not auth.
+func (p *GitHubPlugin) Authenticate(ctx context.Context, req *proto.AuthenticateRequest) (*proto.AuthenticateResponse, error) {
+	return plugins.SuccessResponse(nil, 0), nil
+}
+
+// GetSupportedOpenTypes returns regex patterns for GitHub resource types.
+func (p *GitHubPlugin) GetSupportedOpenTypes(ctx context.Context, req *plugin.SupportedOpenTypesRequest) (*plugin.SupportedOpenTypesResponse, error) {
+	return plugin.SupportedOpenTypesPatterns(
+		`^github:index/repository:Repository$`,
+		`^github:index/team:Team$`,
+		`^github:index/repositoryWebhook:RepositoryWebhook$`,
+	), nil
+}
+
+// OpenResource returns a browser URL to open a GitHub resource.
+func (p *GitHubPlugin) OpenResource(ctx context.Context, req *plugin.OpenResourceRequest) (*plugin.OpenResourceResponse, error) {
+	webURL, err := p.buildResourceURL(req)
+	if err != nil {
+		return plugin.OpenError("%v", err), nil
+	}
+	if webURL == "" {
+		return plugin.OpenNotSupported(), nil
+	}
+
+	return plugin.OpenBrowserResponse(webURL), nil
+}
+
+func (p *GitHubPlugin) buildResourceURL(req *plugin.OpenResourceRequest) (string, error) {
+	switch req.ResourceType {
+	case "github:index/repository:Repository":
+		return p.buildRepositoryURL(req)
+	case "github:index/team:Team":
+		return p.buildTeamURL(req)
+	case "github:index/repositoryWebhook:RepositoryWebhook":
+		return p.buildWebhookURL(req)
+	default:
+		return "", nil
+	}
+}
+
+func (p *GitHubPlugin) buildRepositoryURL(req *plugin.OpenResourceRequest) (string, error) {
+	if htmlURL := req.Outputs["htmlUrl"]; htmlURL != "" {
+		return htmlURL, nil
+	}
+	if fullName := req.Outputs["fullName"]; fullName != "" {
+		return gitHubWebURL(req.ProviderInputs) + "/" + fullName, nil
+	}
+
+	name := cmp.Or(req.Outputs["name"], req.Inputs["name"])
+	if name == "" {
+		return "", errGitHubRepoNameMissing
+	}
+	owner := gitHubOwner(req.ProviderInputs, req.StackConfig, req.ProgramConfig)
+	if owner == "" {
+		return "", errGitHubOwnerNotFound
+	}
+	return gitHubWebURL(req.ProviderInputs) + "/" + url.PathEscape(owner) + "/" + url.PathEscape(name), nil
+}
+
+func (p *GitHubPlugin) buildTeamURL(req *plugin.OpenResourceRequest) (string, error) {
+	slug := req.Outputs["slug"]
+	if slug == "" {
+		return "", errGitHubTeamSlugMissing
+	}
+	owner := gitHubOwner(req.ProviderInputs, req.StackConfig, req.ProgramConfig)
+	if owner == "" {
+		return "", errGitHubOwnerNotFound
+	}
+	return gitHubWebURL(req.ProviderInputs) + "/orgs/" + url.PathEscape(owner) + "/teams/" + url.PathEscape(slug), nil
+}
+
+func (p *GitHubPlugin) buildWebhookURL(req *plugin.OpenResourceRequest) (string, error) {
+	repo := req.Inputs["repository"]
+	if repo == "" {
+		return "", errGitHubWebhookRepoMissing
+	}
+	id := req.Outputs["id"]
+	if id == "" {
+		return "", errGitHubWebhookIDMissing
+	}
+	owner := gitHubOwner(req.ProviderInputs, req.StackConfig, req.ProgramConfig)
+	if owner == "" {
+		return "", errGitHubOwnerNotFound
+	}
+	return gitHubWebURL(req.ProviderInputs) + "/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/settings/hooks/" + url.PathEscape(id), nil
+}
+
+// gitHubRepo is a repository from the GitHub API
+type gitHubRepo struct {
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description"`
+	Private     bool   `json:"private"`
+	Archived    bool   `json:"archived"`
+}
+
+// GetImportSuggestions lists the owner's repositories as import suggestions for
+// github:index/repository:Repository resources
+func (p *GitHubPlugin) GetImportSuggestions(ctx context.Context, req *plugin.ImportSuggestionsRequest) (*plugin.ImportSuggestionsResponse, error) {
+	if req.ResourceType != "github:index/repository:Repository" {
+		return plugin.ImportSuggestionsNotSupported(), nil
+	}
+
+	token := cmp.Or(req.AuthEnv["GITHUB_TOKEN"], req.AuthEnv["GH_TOKEN"])
+	if token == "" {
+		return plugin.ImportSuggestionsError("%v", errGitHubTokenNotAvailable), nil
+	}
+
+	owner := gitHubOwner(req.ProviderInputs, req.StackConfig, req.ProgramConfig)
+	repos, err := p.listRepos(ctx, gitHubAPIURL(req.ProviderInputs), owner, token)
+	if err != nil {
+		return plugin.ImportSuggestionsError("failed to list repositories: %v", err), nil
+	}
+
+	suggestions := make([]*plugin.ImportSuggestion, 0, len(repos))
+	for _, repo := range repos {
+		// The provider imports repositories by name, within the provider's owner
+		suggestions = append(suggestions, plugin.NewImportSuggestion(repo.Name, repo.FullName, gitHubRepoDescription(repo)))
+	}
+	return plugin.ImportSuggestionsSuccess(suggestions), nil
+}
+
+// listRepos lists the repositories of an organization or user, or of the
+// authenticated user when owner is empty
+func (p *GitHubPlugin) listRepos(ctx context.Context, apiURL, owner, token string) ([]gitHubRepo, error) {
+	if owner == "" {
+		return p.listRepoPages(ctx, apiURL+"user/repos?affiliation=owner", token)
+	}
+	repos, err := p.listRepoPages(ctx, apiURL+"orgs/"+url.PathEscape(owner)+"/repos?type=all", token)
+	if errors.Is(err, errGitHubNotFound) {
+		// Not an organization, so list the user's repositories
+		return p.listRepoPages(ctx, apiURL+"users/"+url.PathEscape(owner)+"/repos?type=owner", token)
+	}
+	return repos, err
+}
+
+// listRepoPages fetches pages of repositories from endpoint until a short page
+func (p *GitHubPlugin) listRepoPages(ctx context.Context, endpoint, token string) ([]gitHubRepo, error) {
+	var repos []gitHubRepo
+	for page := 1; page <= gitHubMaxRepoPages; page++ {
+		pageURL := fmt.Sprintf("%s&per_page=%d&page=%d", endpoint, gitHubReposPerPage, page)
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, http.NoBody)
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Accept", "application/vnd.github+json")
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(httpReq)
+		if err != nil {
+			return nil, err
+		}
+		var pageRepos []gitHubRepo
+		switch {
+		case resp.StatusCode == http.StatusNotFound:
+			err = errGitHubNotFound
+		case resp.StatusCode != http.StatusOK:
+			err = fmt.Errorf("github api returned %s", resp.Status)
+		default:
+			err = json.NewDecoder(resp.Body).Decode(&pageRepos)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		repos = append(repos, pageRepos...)
+		if len(pageRepos) < gitHubReposPerPage {
+			break
+		}
+	}
+	return repos, nil
+}
+
+// gitHubRepoDescription describes a repository suggestion, noting private and archived repositories
+func gitHubRepoDescription(repo gitHubRepo) string {
+	var tags []string
+	if repo.Private {
+		tags = append(tags, "private")
+	}
+	if repo.Archived {
+		tags = append(tags, "archived")
+	}
+	if len(tags) == 0 {
+		return repo.Description
+	}
+	if repo.Description == "" {
+		return "(" + strings.Join(tags, ", ") + ")"
+	}
+	return "(" + strings.Join(tags, ", ") + ") " + repo.Description
+}
+
+// gitHubOwner returns the organization or user that owns the resources - priority:
+// provider inputs > stack config > program config
+func gitHubOwner(providerInputs, stackConfig, programConfig map[string]string) string {
+	return cmp.Or(providerInputs["owner"], stackConfig["github:owner"], programConfig["github:owner"])
+}
+
+// gitHubAPIURL returns the API base URL with a trailing slash, honouring the
+// provider's baseUrl for GitHub Enterprise Server
+func gitHubAPIURL(providerInputs map[string]string) string {
+	baseURL := providerInputs["baseUrl"]
+	if baseURL == "" {
+		return defaultGitHubAPIURL
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/"
+}
+
+// gitHubWebURL returns the web URL of the GitHub instance. GitHub Enterprise
+// Server serves its API under /api/v3 of the web host.
+func gitHubWebURL(providerInputs map[string]string) string {
+	baseURL := strings.TrimSuffix(providerInputs["baseUrl"], "/")
+	if baseURL == "" || strings.TrimSuffix(defaultGitHubAPIURL, "/") == baseURL {
+		return defaultGitHubWebURL
+	}
+	return strings.TrimSuffix(baseURL, "/api/v3")
+}
@@ -0,0 +1,206 @@
+package builtins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/plugins/proto"
+	"github.com/rfhold/p5/pkg/plugin"
+	"github.com/rfhold/p5/pkg/plugin/plugintest"
+)
+
+func newGitHubPlugin() *GitHubPlugin {
+	return &GitHubPlugin{
+		BuiltinPluginBase: plugins.NewBuiltinPluginBase("github"),
+	}
+}
+
+func TestGitHubPlugin_Name(t *testing.T) {
+	p := newGitHubPlugin()
+
+	if p.Name() != "github" {
+		t.Errorf("expected Name=%q, got %q", "github", p.Name())
+	}
+}
+
+func TestGitHubPlugin_OpenResource(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *plugin.OpenResourceRequest
+		expected string
+	}{
+		{
+			name: "repository html url",
+			req: &plugin.OpenResourceRequest{
+				ResourceType: "github:index/repository:Repository",
+				Outputs:      map[string]string{"name": "api", "htmlUrl": "https://github.com/acme/api"},
+			},
+			expected: "https://github.com/acme/api",
+		},
+		{
+			name: "repository from owner",
+			req: &plugin.OpenResourceRequest{
+				ResourceType: "github:index/repository:Repository",
+				StackConfig:  map[string]string{"github:owner": "acme"},
+				Outputs:      map[string]string{"name": "api"},
+			},
+			expected: "https://github.com/acme/api",
+		},
+		{
+			name: "team",
+			req: &plugin.OpenResourceRequest{
+				ResourceType:   "github:index/team:Team",
+				ProviderInputs: map[string]string{"owner": "acme"},
+				Outputs:        map[string]string{"id": "123", "slug": "platform"},
+			},
+			expected: "https://github.com/orgs/acme/teams/platform",
+		},
+		{
+			name: "webhook on enterprise server",
+			req: &plugin.OpenResourceRequest{
+				ResourceType:   "github:index/repositoryWebhook:RepositoryWebhook",
+				ProviderInputs: map[string]string{"owner": "acme", "baseUrl": "https://git.acme.dev/api/v3/"},
+				Inputs:         map[string]string{"repository": "api"},
+				Outputs:        map[string]string{"id": "4567"},
+			},
+			expected: "https://git.acme.dev/acme/api/settings/hooks/4567",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := newGitHubPlugin().OpenResource(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.CanOpen {
+				t.Fatalf("expected CanOpen=true, got error: %s", resp.Error)
+			}
+			if resp.Action.Type != proto.OpenActionType_OPEN_ACTION_TYPE_BROWSER {
+				t.Errorf("expected browser action, got %v", resp.Action.Type)
+			}
+			if resp.Action.Url != tt.expected {
+				t.Errorf("expected URL=%q, got %q", tt.expected, resp.Action.Url)
+			}
+		})
+	}
+}
+
+func TestGitHubPlugin_OpenResource_MissingOwner(t *testing.T) {
+	p := newGitHubPlugin()
+
+	req := &plugin.OpenResourceRequest{
+		ResourceType: "github:index/team:Team",
+		Outputs:      map[string]string{"slug": "platform"},
+	}
+
+	resp, err := p.OpenResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Error == "" {
+		t.Error("expected an error when no owner is known")
+	}
+}
+
+func TestGitHubPlugin_OpenResource_Golden(t *testing.T) {
+	p := newGitHubPlugin()
+
+	req := &plugin.OpenResourceRequest{
+		ResourceType:   "github:index/team:Team",
+		ResourceName:   "platform",
+		ProviderInputs: map[string]string{"owner": "acme"},
+		Outputs:        map[string]string{"id": "123", "slug": "platform-team"},
+	}
+
+	resp, err := p.OpenResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	plugintest.RequireOpenResourceGolden(t, resp)
+}
+
+func TestGitHubPlugin_GetImportSuggestions(t *testing.T) {
+	var authHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/orgs/someone/repos":
+			// Users are not organizations
+			http.NotFound(w, r)
+		case "/users/someone/repos", "/orgs/acme/repos":
+			repos := []gitHubRepo{
+				{Name: "api", FullName: "acme/api", Description: "Public API"},
+				{Name: "infra", FullName: "acme/infra", Private: true},
+			}
+			if r.URL.Query().Get("page") != "1" {
+				repos = nil
+			}
+			_ = json.NewEncoder(w).Encode(repos)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	request := func(owner string) *plugin.ImportSuggestionsRequest {
+		return &plugin.ImportSuggestionsRequest{
+			ResourceType:   "github:index/repository:Repository",
+			ProviderInputs: map[string]string{"owner": owner, "baseUrl": server.URL},
+			AuthEnv:        map[string]string{"GITHUB_TOKEN": "ghp_test"},
+		}
+	}
+
+	for _, owner := range []string{"acme", "someone"} {
+		t.Run(owner, func(t *testing.T) {
+			resp, err := newGitHubPlugin().GetImportSuggestions(context.Background(), request(owner))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Error != "" {
+				t.Fatalf("unexpected error response: %s", resp.Error)
+			}
+			if len(resp.Suggestions) != 2 {
+				t.Fatalf("expected 2 suggestions, got %d", len(resp.Suggestions))
+			}
+			if s := resp.Suggestions[0]; s.Id != "api" || s.Label != "acme/api" || s.Description != "Public API" {
+				t.Errorf("unexpected suggestion: %+v", s)
+			}
+			if s := resp.Suggestions[1]; s.Description != "(private)" {
+				t.Errorf("expected private repository to be marked, got %q", s.Description)
+			}
+		})
+	}
+	if !slices.Equal(slices.Compact(authHeaders), []string{"Bearer ghp_test"}) {
+		t.Errorf("expected the token to be sent, got %v", authHeaders)
+	}
+
+	t.Run("no token", func(t *testing.T) {
+		req := request("acme")
+		req.AuthEnv = nil
+		resp, err := newGitHubPlugin().GetImportSuggestions(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.CanProvide || resp.Error == "" {
+			t.Errorf("expected an error without a token, got %+v", resp)
+		}
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		req := request("acme")
+		req.ResourceType = "github:index/team:Team"
+		resp, err := newGitHubPlugin().GetImportSuggestions(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.CanProvide {
+			t.Error("expected CanProvide=false for unsupported type")
+		}
+	})
+}
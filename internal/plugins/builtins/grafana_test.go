@@ -8,6 +8,7 @@ import (
 	"github.com/rfhold/p5/internal/plugins"
 	"github.com/rfhold/p5/internal/plugins/proto"
 	"github.com/rfhold/p5/pkg/plugin"
+	"github.com/rfhold/p5/pkg/plugin/plugintest"
 )
 
 func TestGrafanaPlugin_Name(t *testing.T) {
@@ -868,3 +869,22 @@ func TestGrafanaPlugin_OpenResource_TrailingSlashRemoval(t *testing.T) {
 		t.Errorf("expected URL=%q (trailing slash removed), got %q", expected, resp.Action.Url)
 	}
 }
+
+func TestGrafanaPlugin_OpenResource_Golden(t *testing.T) {
+	p := &GrafanaPlugin{
+		BuiltinPluginBase: plugins.NewBuiltinPluginBase("grafana"),
+	}
+
+	req := &plugin.OpenResourceRequest{
+		ResourceType:   "grafana:oss/dashboard:Dashboard",
+		ResourceName:   "my-dashboard",
+		ProviderInputs: map[string]string{"url": "https://example.grafana.net"},
+		Outputs:        map[string]string{"url": "https://example.grafana.net/d/my-dashboard/my-dashboard-title", "uid": "my-dashboard"},
+	}
+
+	resp, err := p.OpenResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	plugintest.RequireOpenResourceGolden(t, resp)
+}
@@ -47,8 +47,23 @@ func (p *K9sPlugin) OpenResource(ctx context.Context, req *plugin.OpenResourceRe
 	}
 
 	// Build k9s command arguments
-	args := []string{}
 	env := make(map[string]string)
+	args := kubeClientArgs(req)
+
+	// Use --command to navigate to the specific resource type
+	// k9s --command <resource-kind> opens k9s directly to that resource view
+	args = append(args, "--command", kind)
+
+	// Pass through auth environment if provided
+	maps.Copy(env, req.AuthEnv)
+
+	return plugin.OpenExecResponse("k9s", args, env), nil
+}
+
+// kubeClientArgs returns the --kubeconfig, --context and --namespace flags that point
+// a Kubernetes client like k9s or kubectl at the resource's cluster and namespace
+func kubeClientArgs(req *plugin.OpenResourceRequest) []string {
+	args := []string{}
 
 	// Get kubeconfig - priority: provider inputs > stack config > program config
 	kubeconfig := req.ProviderInputs["kubeconfig"]
@@ -67,8 +82,8 @@ func (p *K9sPlugin) OpenResource(ctx context.Context, req *plugin.OpenResourceRe
 			if err == nil {
 				_, _ = tmpFile.WriteString(kubeconfig)
 				tmpFile.Close()
-				// Note: This temp file will persist until k9s exits
-				// k9s runs in foreground so cleanup would happen after
+				// Note: This temp file will persist until the client exits
+				// The client runs in foreground so cleanup would happen after
 				args = append(args, "--kubeconfig", tmpFile.Name())
 			}
 		} else {
@@ -104,15 +119,7 @@ func (p *K9sPlugin) OpenResource(ctx context.Context, req *plugin.OpenResourceRe
 	if namespace != "" {
 		args = append(args, "--namespace", namespace)
 	}
-
-	// Use --command to navigate to the specific resource type
-	// k9s --command <resource-kind> opens k9s directly to that resource view
-	args = append(args, "--command", kind)
-
-	// Pass through auth environment if provided
-	maps.Copy(env, req.AuthEnv)
-
-	return plugin.OpenExecResponse("k9s", args, env), nil
+	return args
 }
 
 // extractK8sKind extracts the Kubernetes kind from a Pulumi resource type.
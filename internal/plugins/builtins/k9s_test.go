@@ -8,6 +8,7 @@ import (
 	"github.com/rfhold/p5/internal/plugins"
 	"github.com/rfhold/p5/internal/plugins/proto"
 	"github.com/rfhold/p5/pkg/plugin"
+	"github.com/rfhold/p5/pkg/plugin/plugintest"
 )
 
 func TestExtractK8sKind_ValidResourceTypes(t *testing.T) {
@@ -684,3 +685,22 @@ func TestK9sPlugin_OpenResource_ResourceKinds(t *testing.T) {
 		})
 	}
 }
+
+func TestK9sPlugin_OpenResource_Golden(t *testing.T) {
+	p := &K9sPlugin{
+		BuiltinPluginBase: plugins.NewBuiltinPluginBase("k9s"),
+	}
+
+	req := &plugin.OpenResourceRequest{
+		ResourceType:   "kubernetes:apps/v1:Deployment",
+		ResourceName:   "web",
+		Inputs:         map[string]string{"metadata": `{"name":"web","namespace":"apps"}`},
+		ProviderInputs: map[string]string{"kubeconfig": "/home/user/.kube/config", "context": "dev"},
+	}
+
+	resp, err := p.OpenResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	plugintest.RequireOpenResourceGolden(t, resp)
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/rfhold/p5/internal/plugins"
@@ -181,6 +183,70 @@ func (p *KubernetesPlugin) GetImportSuggestions(ctx context.Context, req *plugin
 	return plugin.ImportSuggestionsSuccess(suggestions), nil
 }
 
+// defaultImportablePageSize is the page size used when a bulk import request doesn't set one
+const defaultImportablePageSize = 50
+
+// ListImportableResources lists existing Kubernetes resources of the requested kind for
+// bulk import. kubectl returns every resource at once, so pages are slices of that list
+// and the page token is the offset of the next page.
+func (p *KubernetesPlugin) ListImportableResources(ctx context.Context, req *plugin.ListImportableResourcesRequest) (*plugin.ListImportableResourcesResponse, error) {
+	resp, err := p.GetImportSuggestions(ctx, &plugin.ImportSuggestionsRequest{
+		ResourceType:   req.ResourceType,
+		Inputs:         req.Inputs,
+		ProgramConfig:  req.ProgramConfig,
+		StackConfig:    req.StackConfig,
+		StackName:      req.StackName,
+		ProgramName:    req.ProgramName,
+		AuthEnv:        req.AuthEnv,
+		ProviderUrn:    req.ProviderUrn,
+		ProviderInputs: req.ProviderInputs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.CanProvide {
+		return plugin.ListImportableResourcesNotSupported(), nil
+	}
+	if resp.Error != "" {
+		return plugin.ListImportableResourcesError("%s", resp.Error), nil
+	}
+
+	resources := make([]*plugin.ImportableResource, 0, len(resp.Suggestions))
+	for _, s := range resp.Suggestions {
+		// The label is the resource's metadata.name, a natural logical name
+		resources = append(resources, plugin.NewImportableResource(s.Id, s.Label, s.Label, s.Description))
+	}
+
+	page, next, err := pageImportableResources(resources, req.PageSize, req.PageToken)
+	if err != nil {
+		return plugin.ListImportableResourcesError("%v", err), nil
+	}
+	return plugin.ListImportableResourcesSuccess(page, next), nil
+}
+
+// pageImportableResources returns the page of resources starting at the offset in pageToken,
+// and the token for the page after it (empty if this is the last page)
+func pageImportableResources(resources []*plugin.ImportableResource, pageSize int32, pageToken string) (page []*plugin.ImportableResource, nextPageToken string, err error) {
+	size := int(pageSize)
+	if size <= 0 {
+		size = defaultImportablePageSize
+	}
+
+	offset := 0
+	if pageToken != "" {
+		offset, err = strconv.Atoi(pageToken)
+		if err != nil || offset < 0 || offset > len(resources) {
+			return nil, "", fmt.Errorf("invalid page token %q", pageToken)
+		}
+	}
+
+	end := min(offset+size, len(resources))
+	if end < len(resources) {
+		nextPageToken = strconv.Itoa(end)
+	}
+	return resources[offset:end], nextPageToken, nil
+}
+
 func appendNamespaceArgs(args []string, req *plugin.ImportSuggestionsRequest) []string {
 	namespace := resolveK8sNamespace(req)
 	if namespace != "" {
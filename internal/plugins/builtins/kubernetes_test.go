@@ -2,6 +2,8 @@ package builtins
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/rfhold/p5/internal/plugins"
@@ -369,3 +371,64 @@ func TestKubernetesPlugin_GetImportSuggestions_ClusterScoped(t *testing.T) {
 		t.Error("expected CanProvide=true (even with error)")
 	}
 }
+
+func TestKubernetesPlugin_ListImportableResources_NotSupported(t *testing.T) {
+	p := &KubernetesPlugin{
+		BuiltinPluginBase: plugins.NewBuiltinPluginBase("kubernetes"),
+	}
+
+	req := &plugin.ListImportableResourcesRequest{
+		ResourceType: "aws:s3/bucket:Bucket",
+		Inputs:       map[string]string{"bucket": "my-bucket"},
+	}
+
+	resp, err := p.ListImportableResources(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.CanProvide {
+		t.Error("expected CanProvide=false for non-Kubernetes resource")
+	}
+}
+
+func TestPageImportableResources(t *testing.T) {
+	resources := make([]*plugin.ImportableResource, 5)
+	for i := range resources {
+		resources[i] = plugin.NewImportableResource(fmt.Sprintf("default/app-%d", i), fmt.Sprintf("app-%d", i), fmt.Sprintf("app-%d", i), "")
+	}
+
+	tests := []struct {
+		name      string
+		pageSize  int32
+		pageToken string
+		wantIDs   []string
+		wantNext  string
+		wantErr   bool
+	}{
+		{"first page", 2, "", []string{"default/app-0", "default/app-1"}, "2", false},
+		{"middle page", 2, "2", []string{"default/app-2", "default/app-3"}, "4", false},
+		{"last page", 2, "4", []string{"default/app-4"}, "", false},
+		{"default size", 0, "", []string{"default/app-0", "default/app-1", "default/app-2", "default/app-3", "default/app-4"}, "", false},
+		{"invalid token", 2, "abc", nil, "", true},
+		{"token past end", 2, "6", nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, next, err := pageImportableResources(resources, tt.pageSize, tt.pageToken)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			ids := make([]string, 0, len(page))
+			for _, r := range page {
+				ids = append(ids, r.Id)
+			}
+			if tt.wantIDs != nil && strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("expected %v, got %v", tt.wantIDs, ids)
+			}
+			if next != tt.wantNext {
+				t.Errorf("expected next token %q, got %q", tt.wantNext, next)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package builtins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/plugins/proto"
+)
+
+func init() {
+	plugins.RegisterBuiltin(&NotifyPlugin{
+		BuiltinPluginBase: plugins.NewBuiltinPluginBase("notify"),
+	})
+}
+
+// notifyTimeout bounds each webhook request so a slow endpoint can't hold up p5
+const notifyTimeout = 10 * time.Second
+
+// Payload formats supported by the notify plugin
+const (
+	notifyFormatSlack = "slack"
+	notifyFormatJSON  = "json"
+)
+
+// notifyChangeOrder is the order change counts are listed in messages
+var notifyChangeOrder = []struct {
+	key  string
+	verb string
+}{
+	{"create", "created"},
+	{"update", "updated"},
+	{"replace", "replaced"},
+	{"delete", "deleted"},
+}
+
+// NotifyPlugin posts operation summaries to a Slack webhook or a generic HTTP endpoint.
+type NotifyPlugin struct {
+	plugins.BuiltinPluginBase
+}
+
+// notifyConfig is the plugin configuration, with stack config overriding program config
+type notifyConfig struct {
+	URL          string
+	Format       string
+	OnlyFailures bool
+}
+
+// Authenticate validates the webhook configuration. It provides no environment variables.
+func (p *NotifyPlugin) Authenticate(ctx context.Context, req *proto.AuthenticateRequest) (*proto.AuthenticateResponse, error) {
+	if _, err := parseNotifyConfig(req.ProgramConfig, req.StackConfig); err != nil {
+		return plugins.ErrorResponse("%v", err), nil
+	}
+	return plugins.SuccessResponse(nil, 0), nil
+}
+
+// AfterOperation posts the operation summary to the configured endpoint.
+func (p *NotifyPlugin) AfterOperation(ctx context.Context, summary *plugins.OperationSummary) error {
+	cfg, err := parseNotifyConfig(summary.ProgramConfig, summary.StackConfig)
+	if err != nil {
+		return err
+	}
+	if cfg.OnlyFailures && summary.Result != plugins.OperationFailed {
+		return nil
+	}
+
+	var payload any
+	if cfg.Format == notifyFormatSlack {
+		payload = map[string]string{"text": notifyText(summary)}
+	} else {
+		payload = newNotifyPayload(summary)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// parseNotifyConfig reads the plugin configuration. The URL is taken from url, or
+// from the environment variable named by url_env to keep it out of config files.
+func parseNotifyConfig(programConfig, stackConfig map[string]string) (*notifyConfig, error) {
+	get := func(key string) string {
+		if v, ok := stackConfig[key]; ok {
+			return v
+		}
+		return programConfig[key]
+	}
+
+	cfg := &notifyConfig{
+		URL:          get("url"),
+		Format:       get("format"),
+		OnlyFailures: get("only_failures") == "true",
+	}
+	if cfg.URL == "" {
+		if env := get("url_env"); env != "" {
+			cfg.URL = os.Getenv(env)
+			if cfg.URL == "" {
+				return nil, fmt.Errorf("environment variable %s is not set", env)
+			}
+		}
+	}
+	if cfg.URL == "" {
+		return nil, errors.New("url or url_env is required")
+	}
+
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid url %q", cfg.URL)
+	}
+
+	switch cfg.Format {
+	case "":
+		cfg.Format = notifyFormatJSON
+		if u.Host == "hooks.slack.com" {
+			cfg.Format = notifyFormatSlack
+		}
+	case notifyFormatSlack, notifyFormatJSON:
+	default:
+		return nil, fmt.Errorf("unknown format %q (expected %q or %q)", cfg.Format, notifyFormatSlack, notifyFormatJSON)
+	}
+	return cfg, nil
+}
+
+// notifyPayload is the body posted in the json format
+type notifyPayload struct {
+	Program         string         `json:"program"`
+	Stack           string         `json:"stack"`
+	Operation       string         `json:"operation"`
+	Result          string         `json:"result"`
+	Error           string         `json:"error,omitempty"`
+	DurationSeconds float64        `json:"duration_seconds"`
+	Changes         map[string]int `json:"changes"`
+}
+
+func newNotifyPayload(summary *plugins.OperationSummary) notifyPayload {
+	changes := summary.Changes
+	if changes == nil {
+		changes = map[string]int{}
+	}
+	return notifyPayload{
+		Program:         summary.ProgramName,
+		Stack:           summary.StackName,
+		Operation:       summary.Operation,
+		Result:          summary.Result,
+		Error:           summary.Error,
+		DurationSeconds: summary.Duration.Seconds(),
+		Changes:         changes,
+	}
+}
+
+// notifyText formats the summary as a Slack message, e.g.
+// "✅ up succeeded on app/dev in 1m5s: 2 created, 1 updated"
+func notifyText(summary *plugins.OperationSummary) string {
+	icon := "✅"
+	switch summary.Result {
+	case plugins.OperationFailed:
+		icon = "❌"
+	case plugins.OperationCancelled:
+		icon = "⚠️"
+	}
+
+	target := summary.StackName
+	if summary.ProgramName != "" {
+		target = summary.ProgramName + "/" + summary.StackName
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s %s on %s in %s", icon, summary.Operation, summary.Result, target, summary.Duration.Round(time.Second))
+
+	var changes []string
+	for _, c := range notifyChangeOrder {
+		if n := summary.Changes[c.key]; n > 0 {
+			changes = append(changes, fmt.Sprintf("%d %s", n, c.verb))
+		}
+	}
+	if len(changes) > 0 {
+		b.WriteString(": " + strings.Join(changes, ", "))
+	} else if summary.Result == plugins.OperationSucceeded {
+		b.WriteString(": no changes")
+	}
+
+	if summary.Error != "" {
+		b.WriteString("\n" + summary.Error)
+	}
+	return b.String()
+}
@@ -0,0 +1,197 @@
+package builtins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/plugins/proto"
+)
+
+func newNotifyPlugin() *NotifyPlugin {
+	return &NotifyPlugin{
+		BuiltinPluginBase: plugins.NewBuiltinPluginBase("notify"),
+	}
+}
+
+// notifyServer records the bodies posted to it and replies with status
+func notifyServer(t *testing.T, status int) (*httptest.Server, *[]map[string]any) {
+	t.Helper()
+	var bodies []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected Content-Type application/json, got %q", ct)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		bodies = append(bodies, body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &bodies
+}
+
+func testSummary(result string) *plugins.OperationSummary {
+	return &plugins.OperationSummary{
+		ProgramName: "app",
+		StackName:   "dev",
+		Operation:   "up",
+		Result:      result,
+		Changes:     map[string]int{"create": 2, "update": 1},
+		Duration:    65 * time.Second,
+	}
+}
+
+func TestNotifyPlugin_Authenticate(t *testing.T) {
+	t.Setenv("P5_TEST_NOTIFY_URL", "https://example.com/hook")
+
+	tests := []struct {
+		name        string
+		program     map[string]string
+		stack       map[string]string
+		wantSuccess bool
+	}{
+		{"url", map[string]string{"url": "https://example.com/hook"}, nil, true},
+		{"url_env", map[string]string{"url_env": "P5_TEST_NOTIFY_URL"}, nil, true},
+		{"stack url", nil, map[string]string{"url": "https://example.com/hook"}, true},
+		{"missing url", map[string]string{}, nil, false},
+		{"unset url_env", map[string]string{"url_env": "P5_TEST_NOTIFY_MISSING"}, nil, false},
+		{"invalid url", map[string]string{"url": "not a url"}, nil, false},
+		{"unknown format", map[string]string{"url": "https://example.com/hook", "format": "xml"}, nil, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := newNotifyPlugin().Authenticate(context.Background(), &proto.AuthenticateRequest{
+				ProgramConfig: tc.program,
+				StackConfig:   tc.stack,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tc.wantSuccess {
+				t.Errorf("expected Success=%v, got %v (error %q)", tc.wantSuccess, resp.Success, resp.Error)
+			}
+		})
+	}
+}
+
+func TestNotifyPlugin_AfterOperationJSON(t *testing.T) {
+	srv, bodies := notifyServer(t, http.StatusOK)
+
+	summary := testSummary(plugins.OperationFailed)
+	summary.Error = "boom"
+	summary.ProgramConfig = map[string]string{"url": srv.URL}
+
+	if err := newNotifyPlugin().AfterOperation(context.Background(), summary); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*bodies) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(*bodies))
+	}
+	body := (*bodies)[0]
+	for key, want := range map[string]any{
+		"program":          "app",
+		"stack":            "dev",
+		"operation":        "up",
+		"result":           "failed",
+		"error":            "boom",
+		"duration_seconds": 65.0,
+	} {
+		if body[key] != want {
+			t.Errorf("expected %s=%v, got %v", key, want, body[key])
+		}
+	}
+	changes, _ := body["changes"].(map[string]any)
+	if changes["create"] != 2.0 || changes["update"] != 1.0 {
+		t.Errorf("unexpected changes %v", body["changes"])
+	}
+}
+
+func TestNotifyPlugin_AfterOperationSlack(t *testing.T) {
+	srv, bodies := notifyServer(t, http.StatusOK)
+
+	summary := testSummary(plugins.OperationSucceeded)
+	summary.ProgramConfig = map[string]string{"url": srv.URL, "format": "slack"}
+
+	if err := newNotifyPlugin().AfterOperation(context.Background(), summary); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*bodies) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(*bodies))
+	}
+	want := "✅ up succeeded on app/dev in 1m5s: 2 created, 1 updated"
+	if got := (*bodies)[0]["text"]; got != want {
+		t.Errorf("expected text %q, got %q", want, got)
+	}
+}
+
+func TestNotifyPlugin_OnlyFailures(t *testing.T) {
+	srv, bodies := notifyServer(t, http.StatusOK)
+
+	for _, result := range []string{plugins.OperationSucceeded, plugins.OperationCancelled, plugins.OperationFailed} {
+		summary := testSummary(result)
+		summary.ProgramConfig = map[string]string{"url": srv.URL, "only_failures": "true"}
+		if err := newNotifyPlugin().AfterOperation(context.Background(), summary); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(*bodies) != 1 {
+		t.Fatalf("expected only the failure to be posted, got %d requests", len(*bodies))
+	}
+	if (*bodies)[0]["result"] != "failed" {
+		t.Errorf("expected the failed result, got %v", (*bodies)[0]["result"])
+	}
+}
+
+func TestNotifyPlugin_ErrorStatus(t *testing.T) {
+	srv, _ := notifyServer(t, http.StatusInternalServerError)
+
+	summary := testSummary(plugins.OperationSucceeded)
+	summary.ProgramConfig = map[string]string{"url": srv.URL}
+
+	if err := newNotifyPlugin().AfterOperation(context.Background(), summary); err == nil {
+		t.Error("expected an error for a 500 response")
+	}
+}
+
+func TestNotifyText(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary *plugins.OperationSummary
+		want    string
+	}{
+		{
+			name:    "no changes",
+			summary: &plugins.OperationSummary{StackName: "dev", Operation: "refresh", Result: plugins.OperationSucceeded, Duration: 3 * time.Second},
+			want:    "✅ refresh succeeded on dev in 3s: no changes",
+		},
+		{
+			name:    "failed",
+			summary: &plugins.OperationSummary{ProgramName: "app", StackName: "dev", Operation: "destroy", Result: plugins.OperationFailed, Error: "boom", Changes: map[string]int{"delete": 1}},
+			want:    "❌ destroy failed on app/dev in 0s: 1 deleted\nboom",
+		},
+		{
+			name:    "cancelled",
+			summary: &plugins.OperationSummary{ProgramName: "app", StackName: "dev", Operation: "up", Result: plugins.OperationCancelled},
+			want:    "⚠️ up cancelled on app/dev in 0s",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := notifyText(tc.summary); got != tc.want {
+				t.Errorf("notifyText() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
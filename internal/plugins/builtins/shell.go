@@ -0,0 +1,193 @@
+package builtins
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"os"
+	"strconv"
+
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/internal/plugins/proto"
+	"github.com/rfhold/p5/pkg/plugin"
+)
+
+func init() {
+	plugins.RegisterBuiltin(&ShellPlugin{
+		BuiltinPluginBase: plugins.NewBuiltinPluginBase("shell"),
+	})
+}
+
+// ShellPlugin opens an interactive shell on compute resources: ssh for EC2 instances
+// and remote commands, kubectl exec for pods, and a local shell for local commands.
+type ShellPlugin struct {
+	plugins.BuiltinPluginBase
+}
+
+// podShell is the shell started in a pod's container
+const podShell = "sh"
+
+// sshWithKeyScript runs ssh with the key file given as its first argument, deleting
+// the file once ssh exits
+const sshWithKeyScript = `key=$1; shift; trap 'rm -f "$key"' EXIT; ssh -i "$key" "$@"`
+
+// Authenticate returns a no-op success response.
+// This plugin is primarily for resource opening, not auth.
+func (p *ShellPlugin) Authenticate(ctx context.Context, req *proto.AuthenticateRequest) (*proto.AuthenticateResponse, error) {
+	return plugins.SuccessResponse(nil, 0), nil
+}
+
+// GetSupportedOpenTypes returns the compute resource types a shell can be opened on.
+func (p *ShellPlugin) GetSupportedOpenTypes(ctx context.Context, req *plugin.SupportedOpenTypesRequest) (*plugin.SupportedOpenTypesResponse, error) {
+	return plugin.SupportedOpenTypesPatterns(
+		`^aws:ec2/instance:Instance$`,
+		`^kubernetes:core/v1:Pod$`,
+		`^command:remote:Command$`,
+		`^command:local:Command$`,
+	), nil
+}
+
+// OpenResource returns the command opening a shell on the resource.
+func (p *ShellPlugin) OpenResource(ctx context.Context, req *plugin.OpenResourceRequest) (*plugin.OpenResourceResponse, error) {
+	// Pass through auth environment if provided
+	env := make(map[string]string)
+	maps.Copy(env, req.AuthEnv)
+
+	switch req.ResourceType {
+	case "aws:ec2/instance:Instance":
+		return ec2Shell(req, env), nil
+	case "kubernetes:core/v1:Pod":
+		return podExec(req, env), nil
+	case "command:remote:Command":
+		return remoteCommandShell(req, env), nil
+	case "command:local:Command":
+		return localCommandShell(req, env), nil
+	}
+	return plugin.OpenNotSupported(), nil
+}
+
+// ec2Shell connects to an instance with ssh when it has a public address, or with
+// an SSM session otherwise. The ssh user and key come from the user's ssh config.
+func ec2Shell(req *plugin.OpenResourceRequest, env map[string]string) *plugin.OpenResourceResponse {
+	for _, key := range []string{"publicDns", "publicIp"} {
+		if host := req.Outputs[key]; host != "" {
+			return plugin.OpenExecResponse("ssh", []string{"--", host}, env)
+		}
+	}
+
+	id := req.Outputs["id"]
+	if id == "" {
+		return plugin.OpenError("instance %s has no public address or ID", req.ResourceName)
+	}
+	args := []string{"ssm", "start-session", "--target", id}
+	region := req.ProviderInputs["region"]
+	if region == "" {
+		region = req.StackConfig["aws:region"]
+	}
+	if region != "" {
+		args = append(args, "--region", region)
+	}
+	return plugin.OpenExecResponse("aws", args, env)
+}
+
+// podExec starts a shell in a pod's default container with kubectl exec
+func podExec(req *plugin.OpenResourceRequest, env map[string]string) *plugin.OpenResourceResponse {
+	name := extractK8sName(req.Outputs["metadata"])
+	if name == "" {
+		name = extractK8sName(req.Inputs["metadata"])
+	}
+	if name == "" {
+		return plugin.OpenError("pod %s has no name in state", req.ResourceName)
+	}
+	args := append([]string{"exec", "-it", name}, kubeClientArgs(req)...)
+	args = append(args, "--", podShell)
+	return plugin.OpenExecResponse("kubectl", args, env)
+}
+
+// remoteConnection is the connection input of a command:remote:Command
+type remoteConnection struct {
+	Host       string          `json:"host"`
+	Port       json.RawMessage `json:"port"`
+	User       string          `json:"user"`
+	PrivateKey string          `json:"privateKey"`
+}
+
+// remoteCommandShell connects with ssh to the host a remote command runs on
+func remoteCommandShell(req *plugin.OpenResourceRequest, env map[string]string) *plugin.OpenResourceResponse {
+	var conn remoteConnection
+	if err := json.Unmarshal([]byte(req.Inputs["connection"]), &conn); err != nil || conn.Host == "" {
+		return plugin.OpenError("command %s has no connection host", req.ResourceName)
+	}
+
+	var args []string
+	if port := sshPort(conn.Port); port != "" {
+		args = append(args, "-p", port)
+	}
+	host := conn.Host
+	if conn.User != "" {
+		host = conn.User + "@" + host
+	}
+	args = append(args, "--", host)
+	if conn.PrivateKey == "" {
+		return plugin.OpenExecResponse("ssh", args, env)
+	}
+
+	// The key is written to a temp file that the wrapping shell deletes when the
+	// session ends
+	keyFile, err := os.CreateTemp("", "p5-ssh-key-*")
+	if err != nil {
+		return plugin.OpenError("failed to write private key: %v", err)
+	}
+	_, err = keyFile.WriteString(conn.PrivateKey)
+	if closeErr := keyFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(keyFile.Name())
+		return plugin.OpenError("failed to write private key: %v", err)
+	}
+	return plugin.OpenExecResponse("sh", append([]string{"-c", sshWithKeyScript, "ssh", keyFile.Name()}, args...), env)
+}
+
+// sshPort returns the port of a remote connection, which is a number or a string,
+// or empty for the default port
+func sshPort(raw json.RawMessage) string {
+	var port float64
+	if err := json.Unmarshal(raw, &port); err == nil && port > 0 && port != 22 {
+		return strconv.Itoa(int(port))
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil && s != "" && s != "22" {
+		return s
+	}
+	return ""
+}
+
+// localCommandShell opens the user's shell in a local command's directory, with the
+// command's environment
+func localCommandShell(req *plugin.OpenResourceRequest, env map[string]string) *plugin.OpenResourceResponse {
+	var environment map[string]string
+	if err := json.Unmarshal([]byte(req.Inputs["environment"]), &environment); err == nil {
+		maps.Copy(env, environment)
+	}
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "sh"
+	}
+	if dir := req.Inputs["dir"]; dir != "" {
+		// Change directory in a wrapper shell, since actions have no working directory
+		return plugin.OpenExecResponse("sh", []string{"-c", `cd "$1" && exec "$2"`, "sh", dir, shell}, env)
+	}
+	return plugin.OpenExecResponse(shell, nil, env)
+}
+
+// extractK8sName extracts the name from a Kubernetes metadata JSON string.
+func extractK8sName(metadataJSON string) string {
+	var metadata struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+		return ""
+	}
+	return metadata.Name
+}
@@ -0,0 +1,182 @@
+package builtins
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/rfhold/p5/internal/plugins"
+	"github.com/rfhold/p5/pkg/plugin"
+	"github.com/rfhold/p5/pkg/plugin/plugintest"
+)
+
+func newShellPlugin() *ShellPlugin {
+	return &ShellPlugin{BuiltinPluginBase: plugins.NewBuiltinPluginBase("shell")}
+}
+
+func TestShellPlugin_OpenResource(t *testing.T) {
+	p := newShellPlugin()
+	authEnv := map[string]string{"AWS_PROFILE": "prod"}
+
+	tests := []struct {
+		name        string
+		req         *plugin.OpenResourceRequest
+		wantCommand string
+		wantArgs    []string
+	}{
+		{
+			name: "ec2 with public address",
+			req: &plugin.OpenResourceRequest{
+				ResourceType: "aws:ec2/instance:Instance",
+				Outputs:      map[string]string{"id": "i-0abc", "publicIp": "203.0.113.7"},
+			},
+			wantCommand: "ssh",
+			wantArgs:    []string{"--", "203.0.113.7"},
+		},
+		{
+			name: "private ec2 over ssm",
+			req: &plugin.OpenResourceRequest{
+				ResourceType:   "aws:ec2/instance:Instance",
+				Outputs:        map[string]string{"id": "i-0abc", "privateIp": "10.0.0.4"},
+				ProviderInputs: map[string]string{"region": "eu-west-1"},
+			},
+			wantCommand: "aws",
+			wantArgs:    []string{"ssm", "start-session", "--target", "i-0abc", "--region", "eu-west-1"},
+		},
+		{
+			name: "pod",
+			req: &plugin.OpenResourceRequest{
+				ResourceType: "kubernetes:core/v1:Pod",
+				Inputs:       map[string]string{"metadata": `{"namespace":"web"}`},
+				Outputs:      map[string]string{"metadata": `{"name":"api-x7k2","namespace":"web"}`},
+				StackConfig:  map[string]string{"kubernetes:context": "prod"},
+			},
+			wantCommand: "kubectl",
+			wantArgs:    []string{"exec", "-it", "api-x7k2", "--context", "prod", "--namespace", "web", "--", "sh"},
+		},
+		{
+			name: "remote command",
+			req: &plugin.OpenResourceRequest{
+				ResourceType: "command:remote:Command",
+				Inputs:       map[string]string{"connection": `{"host":"10.0.0.4","port":2222,"user":"admin"}`},
+			},
+			wantCommand: "ssh",
+			wantArgs:    []string{"-p", "2222", "--", "admin@10.0.0.4"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.req.AuthEnv = authEnv
+			resp, err := p.OpenResource(context.Background(), tc.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Action == nil {
+				t.Fatalf("expected an action, got %+v", resp)
+			}
+			if resp.Action.Command != tc.wantCommand || !slices.Equal(resp.Action.Args, tc.wantArgs) {
+				t.Errorf("got %s %q, want %s %q", resp.Action.Command, resp.Action.Args, tc.wantCommand, tc.wantArgs)
+			}
+			if resp.Action.Env["AWS_PROFILE"] != "prod" {
+				t.Errorf("expected the auth env to be passed through, got %v", resp.Action.Env)
+			}
+		})
+	}
+}
+
+func TestShellPlugin_OpenResource_Errors(t *testing.T) {
+	p := newShellPlugin()
+
+	for _, req := range []*plugin.OpenResourceRequest{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceName: "web"},
+		{ResourceType: "kubernetes:core/v1:Pod", ResourceName: "api"},
+		{ResourceType: "command:remote:Command", ResourceName: "setup", Inputs: map[string]string{"connection": `{"port":22}`}},
+	} {
+		resp, err := p.OpenResource(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Error == "" {
+			t.Errorf("%s: expected an error for missing connection details", req.ResourceType)
+		}
+	}
+
+	resp, err := p.OpenResource(context.Background(), &plugin.OpenResourceRequest{ResourceType: "aws:s3/bucket:Bucket"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.CanOpen {
+		t.Error("expected CanOpen=false for unsupported type")
+	}
+}
+
+func TestShellPlugin_OpenResource_Golden(t *testing.T) {
+	p := newShellPlugin()
+	t.Setenv("SHELL", "/bin/zsh")
+
+	req := &plugin.OpenResourceRequest{
+		ResourceType: "command:local:Command",
+		ResourceName: "build",
+		Inputs: map[string]string{
+			"dir":         "/work/app",
+			"environment": `{"STAGE":"dev"}`,
+		},
+	}
+
+	resp, err := p.OpenResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	plugintest.RequireOpenResourceGolden(t, resp)
+}
+
+func TestShellPlugin_OpenResource_PrivateKey(t *testing.T) {
+	p := newShellPlugin()
+
+	req := &plugin.OpenResourceRequest{
+		ResourceType: "command:remote:Command",
+		Inputs:       map[string]string{"connection": `{"host":"10.0.0.4","privateKey":"-----BEGIN KEY-----"}`},
+	}
+	resp, err := p.OpenResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := resp.Action.Args
+	if resp.Action.Command != "sh" || len(args) != 6 || args[1] != sshWithKeyScript || !slices.Equal(args[4:], []string{"--", "10.0.0.4"}) {
+		t.Fatalf("expected sh -c <script> ssh <key> -- host, got %s %q", resp.Action.Command, args)
+	}
+	keyFile := args[3]
+	defer os.Remove(keyFile)
+	if key, err := os.ReadFile(keyFile); err != nil || string(key) != "-----BEGIN KEY-----" {
+		t.Errorf("expected the private key in %s, got %q (%v)", keyFile, key, err)
+	}
+}
+
+func TestSSHWithKeyScript(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+	if err := os.WriteFile(keyFile, []byte("key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// A fake ssh recording its arguments
+	fakeSSH := "#!/bin/sh\necho \"$@\" > \"" + filepath.Join(dir, "args") + "\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "ssh"), []byte(fakeSSH), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	cmd := exec.Command("sh", "-c", sshWithKeyScript, "ssh", keyFile, "--", "10.0.0.4")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("script failed: %v: %s", err, out)
+	}
+	if _, err := os.Stat(keyFile); !os.IsNotExist(err) {
+		t.Errorf("expected the key file to be deleted, got %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "args")); string(got) != "-i "+keyFile+" -- 10.0.0.4\n" {
+		t.Errorf("unexpected ssh arguments %q", got)
+	}
+}
@@ -2,6 +2,7 @@ package plugins
 
 import (
 	"context"
+	"time"
 )
 
 // FakePluginProvider implements PluginProvider for testing.
@@ -14,15 +15,29 @@ type FakePluginProvider struct {
 	GetCredentialsSummaryFunc    func() []CredentialsSummary
 	InvalidateCredentialsFunc    func(pluginName string)
 	InvalidateAllCredentialsFunc func()
+	SetEnvironmentEnvFunc        func(env map[string]string)
+	NextCredentialRefreshFunc    func() time.Time
+	RefreshCredentialsFunc       func(ctx context.Context) []AuthenticateResult
+	ReauthenticatePluginFunc     func(ctx context.Context, name string) AuthenticateResult
 
 	// ImportHelper methods
-	GetImportSuggestionsFunc func(ctx context.Context, req *ImportSuggestionsRequest) ([]*AggregatedImportSuggestion, error)
-	HasImportHelpersFunc     func() bool
+	GetImportSuggestionsFunc    func(ctx context.Context, req *ImportSuggestionsRequest) ([]*AggregatedImportSuggestion, error)
+	ListImportableResourcesFunc func(ctx context.Context, req *ListImportableResourcesRequest, pageTokens map[string]string) (*ImportableResourcesPage, error)
+	ValidateImportIDFunc        func(ctx context.Context, req *ValidateImportIdRequest) (*ImportIDValidation, error)
+	HasImportHelpersFunc        func() bool
 
 	// ResourceOpener methods
 	OpenResourceFunc       func(ctx context.Context, req *OpenResourceRequest) (*OpenResourceResponse, string, error)
 	HasResourceOpenersFunc func() bool
 
+	// PostOperationNotifier methods
+	RunPostOperationHooksFunc func(ctx context.Context, summary *OperationSummary) []PostOperationResult
+	HasPostOperationHooksFunc func() bool
+
+	// StatusBadgeProvider methods
+	GetStatusBadgesFunc func(ctx context.Context) []StatusBadge
+	HasStatusBadgesFunc func() bool
+
 	// PluginProvider methods
 	InitializeFunc                      func(ctx context.Context, workDir, programName, stackName string) ([]AuthenticateResult, error)
 	CloseFunc                           func(ctx context.Context)
@@ -36,11 +51,19 @@ type FakePluginProvider struct {
 	AllEnv               map[string]string
 	CredentialsSummary   []CredentialsSummary
 	ImportSuggestions    []*AggregatedImportSuggestion
+	ImportableResources  *ImportableResourcesPage
+	ImportIDValidation   *ImportIDValidation
 	HasImportHelper      bool
 	OpenResourceResponse *OpenResourceResponse
 	OpenResourcePlugin   string
 	HasResourceOpener    bool
+	PostOperationResults []PostOperationResult
+	HasPostOperationHook bool
+	StatusBadges         []StatusBadge
+	HasStatusBadge       bool
 	AuthResults          []AuthenticateResult
+	NextRefresh          time.Time
+	RefreshResults       []AuthenticateResult
 	MergedConfig         *P5Config
 	ShouldRefresh        bool
 
@@ -52,10 +75,20 @@ type FakePluginProvider struct {
 		GetCredentialsSummary           int
 		InvalidateCredentials           []string
 		InvalidateAllCredentials        int
+		SetEnvironmentEnv               []map[string]string
+		NextCredentialRefresh           int
+		RefreshCredentials              int
+		ReauthenticatePlugin            []string
 		GetImportSuggestions            []*ImportSuggestionsRequest
+		ListImportableResources         []ListImportableResourcesCall
+		ValidateImportID                []*ValidateImportIdRequest
 		HasImportHelpers                int
 		OpenResource                    []*OpenResourceRequest
 		HasResourceOpeners              int
+		RunPostOperationHooks           []*OperationSummary
+		HasPostOperationHooks           int
+		GetStatusBadges                 int
+		HasStatusBadges                 int
 		Initialize                      []InitializeCall
 		Close                           int
 		GetMergedConfig                 int
@@ -65,6 +98,11 @@ type FakePluginProvider struct {
 	}
 }
 
+type ListImportableResourcesCall struct {
+	Req        *ListImportableResourcesRequest
+	PageTokens map[string]string
+}
+
 type InitializeCall struct {
 	WorkDir     string
 	ProgramName string
@@ -118,6 +156,13 @@ func (f *FakePluginProvider) GetAllEnv() map[string]string {
 	return f.AllEnv
 }
 
+func (f *FakePluginProvider) SetEnvironmentEnv(env map[string]string) {
+	f.Calls.SetEnvironmentEnv = append(f.Calls.SetEnvironmentEnv, env)
+	if f.SetEnvironmentEnvFunc != nil {
+		f.SetEnvironmentEnvFunc(env)
+	}
+}
+
 func (f *FakePluginProvider) ApplyEnvToProcess() {
 	f.Calls.ApplyEnvToProcess++
 	if f.ApplyEnvToProcessFunc != nil {
@@ -147,6 +192,30 @@ func (f *FakePluginProvider) InvalidateAllCredentials() {
 	}
 }
 
+func (f *FakePluginProvider) NextCredentialRefresh() time.Time {
+	f.Calls.NextCredentialRefresh++
+	if f.NextCredentialRefreshFunc != nil {
+		return f.NextCredentialRefreshFunc()
+	}
+	return f.NextRefresh
+}
+
+func (f *FakePluginProvider) RefreshCredentials(ctx context.Context) []AuthenticateResult {
+	f.Calls.RefreshCredentials++
+	if f.RefreshCredentialsFunc != nil {
+		return f.RefreshCredentialsFunc(ctx)
+	}
+	return f.RefreshResults
+}
+
+func (f *FakePluginProvider) ReauthenticatePlugin(ctx context.Context, name string) AuthenticateResult {
+	f.Calls.ReauthenticatePlugin = append(f.Calls.ReauthenticatePlugin, name)
+	if f.ReauthenticatePluginFunc != nil {
+		return f.ReauthenticatePluginFunc(ctx, name)
+	}
+	return AuthenticateResult{PluginName: name, Credentials: &Credentials{PluginName: name}}
+}
+
 // ImportHelper interface implementation
 
 func (f *FakePluginProvider) GetImportSuggestions(ctx context.Context, req *ImportSuggestionsRequest) ([]*AggregatedImportSuggestion, error) {
@@ -157,6 +226,25 @@ func (f *FakePluginProvider) GetImportSuggestions(ctx context.Context, req *Impo
 	return f.ImportSuggestions, nil
 }
 
+func (f *FakePluginProvider) ListImportableResources(ctx context.Context, req *ListImportableResourcesRequest, pageTokens map[string]string) (*ImportableResourcesPage, error) {
+	f.Calls.ListImportableResources = append(f.Calls.ListImportableResources, ListImportableResourcesCall{req, pageTokens})
+	if f.ListImportableResourcesFunc != nil {
+		return f.ListImportableResourcesFunc(ctx, req, pageTokens)
+	}
+	if f.ImportableResources != nil {
+		return f.ImportableResources, nil
+	}
+	return &ImportableResourcesPage{}, nil
+}
+
+func (f *FakePluginProvider) ValidateImportID(ctx context.Context, req *ValidateImportIdRequest) (*ImportIDValidation, error) {
+	f.Calls.ValidateImportID = append(f.Calls.ValidateImportID, req)
+	if f.ValidateImportIDFunc != nil {
+		return f.ValidateImportIDFunc(ctx, req)
+	}
+	return f.ImportIDValidation, nil
+}
+
 func (f *FakePluginProvider) HasImportHelpers() bool {
 	f.Calls.HasImportHelpers++
 	if f.HasImportHelpersFunc != nil {
@@ -183,6 +271,42 @@ func (f *FakePluginProvider) HasResourceOpeners() bool {
 	return f.HasResourceOpener
 }
 
+// PostOperationNotifier interface implementation
+
+func (f *FakePluginProvider) RunPostOperationHooks(ctx context.Context, summary *OperationSummary) []PostOperationResult {
+	f.Calls.RunPostOperationHooks = append(f.Calls.RunPostOperationHooks, summary)
+	if f.RunPostOperationHooksFunc != nil {
+		return f.RunPostOperationHooksFunc(ctx, summary)
+	}
+	return f.PostOperationResults
+}
+
+func (f *FakePluginProvider) HasPostOperationHooks() bool {
+	f.Calls.HasPostOperationHooks++
+	if f.HasPostOperationHooksFunc != nil {
+		return f.HasPostOperationHooksFunc()
+	}
+	return f.HasPostOperationHook
+}
+
+// StatusBadgeProvider interface implementation
+
+func (f *FakePluginProvider) GetStatusBadges(ctx context.Context) []StatusBadge {
+	f.Calls.GetStatusBadges++
+	if f.GetStatusBadgesFunc != nil {
+		return f.GetStatusBadgesFunc(ctx)
+	}
+	return f.StatusBadges
+}
+
+func (f *FakePluginProvider) HasStatusBadges() bool {
+	f.Calls.HasStatusBadges++
+	if f.HasStatusBadgesFunc != nil {
+		return f.HasStatusBadgesFunc()
+	}
+	return f.HasStatusBadge
+}
+
 // PluginProvider interface implementation
 
 func (f *FakePluginProvider) Initialize(ctx context.Context, workDir, programName, stackName string) ([]AuthenticateResult, error) {
@@ -251,5 +375,42 @@ func (f *FakePluginProvider) AuthenticateAll(ctx context.Context, programName, s
 	return f.AuthResults, nil
 }
 
-// Compile-time interface compliance check
-var _ PluginProvider = (*FakePluginProvider)(nil)
+// FakePluginInstaller implements PluginInstaller for testing.
+type FakePluginInstaller struct {
+	FetchIndexFunc func(ctx context.Context, workDir string) (*PluginIndex, error)
+	InstallFunc    func(ctx context.Context, workDir string, entry IndexEntry) (*InstallResult, error)
+
+	// Default return values
+	Index *PluginIndex
+
+	// Calls tracks all method invocations.
+	Calls struct {
+		FetchIndex []string
+		Install    []IndexEntry
+	}
+}
+
+func (f *FakePluginInstaller) FetchIndex(ctx context.Context, workDir string) (*PluginIndex, error) {
+	f.Calls.FetchIndex = append(f.Calls.FetchIndex, workDir)
+	if f.FetchIndexFunc != nil {
+		return f.FetchIndexFunc(ctx, workDir)
+	}
+	if f.Index != nil {
+		return f.Index, nil
+	}
+	return &PluginIndex{}, nil
+}
+
+func (f *FakePluginInstaller) Install(ctx context.Context, workDir string, entry IndexEntry) (*InstallResult, error) {
+	f.Calls.Install = append(f.Calls.Install, entry)
+	if f.InstallFunc != nil {
+		return f.InstallFunc(ctx, workDir, entry)
+	}
+	return &InstallResult{Name: entry.Name, ConfigPath: "p5.toml"}, nil
+}
+
+// Compile-time interface compliance checks
+var (
+	_ PluginProvider  = (*FakePluginProvider)(nil)
+	_ PluginInstaller = (*FakePluginInstaller)(nil)
+)
@@ -34,4 +34,10 @@ type (
 	ResourceOpenerGRPCClient = p5plugin.ResourceOpenerGRPCClient
 	// ResourceOpenerGRPCServer is the server-side implementation that wraps the actual resource opener plugin
 	ResourceOpenerGRPCServer = p5plugin.ResourceOpenerGRPCServer
+	// StatusBadgePluginGRPC is the implementation of goplugin.GRPCPlugin for StatusBadgePlugin
+	StatusBadgePluginGRPC = p5plugin.StatusBadgePluginGRPC
+	// StatusBadgeGRPCClient is the client-side implementation of StatusBadgePlugin over gRPC
+	StatusBadgeGRPCClient = p5plugin.StatusBadgeGRPCClient
+	// StatusBadgeGRPCServer is the server-side implementation that wraps the actual status badge plugin
+	StatusBadgeGRPCServer = p5plugin.StatusBadgeGRPCServer
 )
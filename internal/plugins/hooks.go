@@ -0,0 +1,100 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Results of an operation reported to post-operation hooks
+const (
+	OperationSucceeded = "succeeded"
+	OperationFailed    = "failed"
+	OperationCancelled = "cancelled"
+)
+
+// OperationSummary describes a finished up, refresh or destroy
+type OperationSummary struct {
+	WorkDir     string
+	ProgramName string
+	StackName   string
+	Operation   string         // "up", "refresh" or "destroy"
+	Result      string         // OperationSucceeded, OperationFailed or OperationCancelled
+	Error       string         // Set when the operation failed
+	Changes     map[string]int // Resources changed, by "create", "update", "delete" and "replace"
+	Duration    time.Duration
+
+	// Plugin configuration, filled in for each plugin the summary is sent to
+	ProgramConfig map[string]string
+	StackConfig   map[string]string
+}
+
+// PostOperationHook is an optional interface for plugins notified after each up,
+// refresh or destroy. Hooks have no gRPC service yet, so only builtin plugins can
+// provide them.
+type PostOperationHook interface {
+	// AfterOperation is called once the operation finished, whatever its result
+	AfterOperation(ctx context.Context, summary *OperationSummary) error
+}
+
+// PostOperationResult is the outcome of a plugin's post-operation hook
+type PostOperationResult struct {
+	PluginName string
+	Error      error
+}
+
+// HasPostOperationHooks returns true if any plugin has post-operation hooks enabled
+func (m *Manager) HasPostOperationHooks() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, instance := range m.plugins {
+		if instance.HasPostOperationHook() {
+			return true
+		}
+	}
+	return false
+}
+
+// RunPostOperationHooks sends the summary to every plugin with post-operation hooks
+// enabled, in name order. Each plugin gets its own copy with its configuration.
+func (m *Manager) RunPostOperationHooks(ctx context.Context, summary *OperationSummary) []PostOperationResult {
+	m.mu.RLock()
+	instances := maps.Clone(m.plugins)
+	var pluginConfigs map[string]PluginConfig
+	if m.mergedConfig != nil {
+		pluginConfigs = m.mergedConfig.Plugins
+	}
+	m.mu.RUnlock()
+
+	var results []PostOperationResult
+	for _, name := range slices.Sorted(maps.Keys(instances)) {
+		instance := instances[name]
+		if !instance.HasPostOperationHook() {
+			continue
+		}
+
+		pluginSummary := *summary
+		pluginSummary.ProgramConfig = convertToStringMap(pluginConfigs[name].Config)
+		stackResult, err := LoadStackPluginConfig(summary.WorkDir, summary.StackName, name)
+		if err != nil {
+			results = append(results, PostOperationResult{
+				PluginName: name,
+				Error:      fmt.Errorf("failed to load stack config: %w", err),
+			})
+			continue
+		}
+		pluginSummary.StackConfig = map[string]string{}
+		if stackResult != nil {
+			pluginSummary.StackConfig = convertToStringMap(stackResult.Config)
+		}
+
+		results = append(results, PostOperationResult{
+			PluginName: name,
+			Error:      instance.postOperation.AfterOperation(ctx, &pluginSummary),
+		})
+	}
+	return results
+}
@@ -0,0 +1,88 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// mockHookPlugin is a builtin plugin recording the summaries it is sent
+type mockHookPlugin struct {
+	mockBuiltinPlugin
+	summaries []*OperationSummary
+	err       error
+}
+
+func (m *mockHookPlugin) AfterOperation(ctx context.Context, summary *OperationSummary) error {
+	m.summaries = append(m.summaries, summary)
+	return m.err
+}
+
+// TestManager_RunPostOperationHooks verifies only plugins with post_operation enabled
+// are notified, each with its own configuration
+func TestManager_RunPostOperationHooks(t *testing.T) {
+	originalRegistry := builtinRegistry
+	defer func() { builtinRegistry = originalRegistry }()
+	builtinRegistry = make(map[string]BuiltinPlugin)
+
+	enabled := &mockHookPlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("enabled")}}
+	failing := &mockHookPlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("failing")}, err: errors.New("boom")}
+	disabled := &mockHookPlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("disabled")}}
+	RegisterBuiltin(enabled)
+	RegisterBuiltin(failing)
+	RegisterBuiltin(disabled)
+
+	workDir := t.TempDir()
+	stackYAML := "config:\n  p5:plugins:\n    enabled:\n      config:\n        url: https://stack.example.com\n"
+	if err := os.WriteFile(filepath.Join(workDir, "Pulumi.dev.yaml"), []byte(stackYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &P5Config{Plugins: map[string]PluginConfig{
+		"enabled":  {PostOperation: true, Config: map[string]any{"url": "https://program.example.com"}},
+		"failing":  {PostOperation: true},
+		"disabled": {},
+	}}
+	mgr, _ := NewManager("")
+	if err := mgr.LoadPlugins(context.Background(), cfg); err != nil {
+		t.Fatalf("LoadPlugins failed: %v", err)
+	}
+	mgr.mergedConfig = cfg
+
+	if !mgr.HasPostOperationHooks() {
+		t.Fatal("expected HasPostOperationHooks=true")
+	}
+
+	summary := &OperationSummary{WorkDir: workDir, StackName: "dev", Operation: "up", Result: OperationSucceeded}
+	results := mgr.RunPostOperationHooks(context.Background(), summary)
+
+	if len(results) != 2 || results[0].PluginName != "enabled" || results[1].PluginName != "failing" {
+		t.Fatalf("expected results for enabled and failing in order, got %+v", results)
+	}
+	if results[0].Error != nil || results[1].Error == nil {
+		t.Errorf("expected only the failing hook to report an error, got %+v", results)
+	}
+	if len(disabled.summaries) != 0 {
+		t.Error("expected the disabled plugin not to be notified")
+	}
+	if len(enabled.summaries) != 1 {
+		t.Fatalf("expected the enabled plugin to be notified once, got %d", len(enabled.summaries))
+	}
+	got := enabled.summaries[0]
+	if got.ProgramConfig["url"] != "https://program.example.com" || got.StackConfig["url"] != "https://stack.example.com" {
+		t.Errorf("expected program and stack config, got %v and %v", got.ProgramConfig, got.StackConfig)
+	}
+	if summary.ProgramConfig != nil {
+		t.Error("expected the caller's summary to be left unchanged")
+	}
+}
+
+func TestManager_HasPostOperationHooks_NoPlugins(t *testing.T) {
+	mgr, _ := NewManager("")
+
+	if mgr.HasPostOperationHooks() {
+		t.Error("expected HasPostOperationHooks=false when no plugins")
+	}
+}
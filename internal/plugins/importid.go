@@ -0,0 +1,72 @@
+package plugins
+
+import (
+	"context"
+	"maps"
+	"slices"
+)
+
+// ImportIDValidation is a plugin's verdict on an import ID
+type ImportIDValidation struct {
+	PluginName string
+	Valid      bool
+	Message    string // Why the ID is invalid, or a note on a valid one
+}
+
+// ValidateImportID asks import helper plugins to check an import ID. The first plugin,
+// in name order, that rejects the ID decides; otherwise the first that accepts it does.
+// Returns nil if no plugin could check the ID.
+func (m *Manager) ValidateImportID(ctx context.Context, req *ValidateImportIdRequest) (*ImportIDValidation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var accepted *ImportIDValidation
+	for _, name := range slices.Sorted(maps.Keys(m.plugins)) {
+		instance := m.plugins[name]
+		validator, ok := instance.importHelper.(ImportIDValidator)
+		if !instance.HasImportHelper() || !ok {
+			continue
+		}
+
+		// Clone the request so each plugin gets its own auth env
+		pluginReq := &ValidateImportIdRequest{
+			ResourceType:   req.ResourceType,
+			ResourceName:   req.ResourceName,
+			ResourceUrn:    req.ResourceUrn,
+			ImportId:       req.ImportId,
+			Inputs:         req.Inputs,
+			ProgramConfig:  req.ProgramConfig,
+			StackConfig:    req.StackConfig,
+			StackName:      req.StackName,
+			ProgramName:    req.ProgramName,
+			ProviderUrn:    req.ProviderUrn,
+			ProviderInputs: req.ProviderInputs,
+		}
+
+		// If use_auth_env is enabled for this plugin, populate auth_env
+		if config, ok := m.mergedConfig.Plugins[name]; ok && config.UseAuthEnv {
+			pluginReq.AuthEnv = m.getMergedAuthEnvLocked()
+		}
+
+		resp, err := validator.ValidateImportId(ctx, pluginReq)
+		if err != nil {
+			// Log error but continue with other plugins
+			continue
+		}
+
+		// Skip if plugin can't check this resource type or the check failed
+		if !resp.CanValidate || resp.Error != "" {
+			continue
+		}
+
+		result := &ImportIDValidation{PluginName: name, Valid: resp.Valid, Message: resp.Message}
+		if !resp.Valid {
+			return result, nil
+		}
+		if accepted == nil {
+			accepted = result
+		}
+	}
+
+	return accepted, nil
+}
@@ -0,0 +1,81 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// mockValidatorPlugin is a builtin import helper that checks import IDs by prefix
+type mockValidatorPlugin struct {
+	mockBuiltinPlugin
+	prefix   string // IDs of the handled type must start with it
+	err      error
+	requests []*ValidateImportIdRequest
+}
+
+func (m *mockValidatorPlugin) GetImportSuggestions(ctx context.Context, req *ImportSuggestionsRequest) (*ImportSuggestionsResponse, error) {
+	return ImportSuggestionsNotSupported(), nil
+}
+
+func (m *mockValidatorPlugin) ValidateImportId(ctx context.Context, req *ValidateImportIdRequest) (*ValidateImportIdResponse, error) {
+	m.requests = append(m.requests, req)
+	switch {
+	case m.err != nil:
+		return nil, m.err
+	case m.prefix == "":
+		return ValidateImportIdNotSupported(), nil
+	case !strings.HasPrefix(req.ImportId, m.prefix):
+		return InvalidImportId("expected an ID starting with %q", m.prefix), nil
+	}
+	return ValidImportId("found in us-east-1"), nil
+}
+
+// TestManager_ValidateImportID verifies a rejection from any plugin wins over
+// acceptances, and plugins that can't check the ID are skipped
+func TestManager_ValidateImportID(t *testing.T) {
+	originalRegistry := builtinRegistry
+	defer func() { builtinRegistry = originalRegistry }()
+	builtinRegistry = make(map[string]BuiltinPlugin)
+
+	aws := &mockValidatorPlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("aws")}, prefix: "arn:"}
+	failing := &mockValidatorPlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("failing")}, err: errors.New("unreachable")}
+	other := &mockValidatorPlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("other")}}
+	disabled := &mockValidatorPlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("disabled")}, prefix: "never"}
+	for _, p := range []BuiltinPlugin{aws, failing, other, disabled} {
+		RegisterBuiltin(p)
+	}
+
+	cfg := &P5Config{Plugins: map[string]PluginConfig{
+		"aws":      {ImportHelper: true},
+		"failing":  {ImportHelper: true},
+		"other":    {ImportHelper: true},
+		"disabled": {},
+	}}
+	mgr, _ := NewManager("")
+	if err := mgr.LoadPlugins(context.Background(), cfg); err != nil {
+		t.Fatalf("LoadPlugins failed: %v", err)
+	}
+	mgr.mergedConfig = cfg
+
+	req := &ValidateImportIdRequest{ResourceType: "aws:s3/bucket:Bucket", ImportId: "my-bucket"}
+	result, err := mgr.ValidateImportID(context.Background(), req)
+	if err != nil || result == nil || result.Valid || result.PluginName != "aws" || result.Message != `expected an ID starting with "arn:"` {
+		t.Fatalf("expected aws to reject the ID, got %+v, %v", result, err)
+	}
+	if len(disabled.requests) != 0 {
+		t.Error("expected plugins without import_helper enabled to be skipped")
+	}
+
+	req.ImportId = "arn:aws:s3:::my-bucket"
+	result, err = mgr.ValidateImportID(context.Background(), req)
+	if err != nil || result == nil || !result.Valid || result.Message != "found in us-east-1" {
+		t.Fatalf("expected aws to accept the ID, got %+v, %v", result, err)
+	}
+
+	aws.prefix = ""
+	if result, err := mgr.ValidateImportID(context.Background(), req); err != nil || result != nil {
+		t.Errorf("expected no verdict when no plugin can check the ID, got %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,303 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// DefaultPluginIndexURL is the curated plugin index browsed when p5.toml sets no plugin_index
+const DefaultPluginIndexURL = "https://raw.githubusercontent.com/rfhold/p5/main/docs/plugins/index.json"
+
+// Capabilities a plugin in the index can declare. Import helpers, resource
+// openers and status badges are enabled in p5.toml when the plugin is installed.
+const (
+	CapabilityAuth           = "auth"
+	CapabilityImportHelper   = "import_helper"
+	CapabilityResourceOpener = "resource_opener"
+	CapabilityStatusBadge    = "status_badge"
+)
+
+// pluginNamePattern matches names usable as a bare key in p5.toml
+var pluginNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// PluginIndex is a curated list of plugins that can be installed from p5
+type PluginIndex struct {
+	Plugins []IndexEntry `json:"plugins"`
+}
+
+// IndexEntry describes a plugin in the index
+type IndexEntry struct {
+	// Name is the key the plugin is configured under in p5.toml
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Package is the Go package path of the plugin's main package, installed with go install
+	Package string `json:"package"`
+	// Version is the module version to install (default: latest)
+	Version      string   `json:"version,omitempty"`
+	Homepage     string   `json:"homepage,omitempty"`
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// HasCapability reports whether the plugin declares the given capability
+func (e IndexEntry) HasCapability(capability string) bool {
+	return slices.Contains(e.Capabilities, capability)
+}
+
+// InstallResult describes an installed plugin
+type InstallResult struct {
+	Name       string
+	Cmd        string // Path of the installed executable
+	ConfigPath string // p5.toml the plugin was added to
+}
+
+// PluginInstaller fetches the plugin index and installs plugins from it
+type PluginInstaller interface {
+	// FetchIndex fetches the plugin index configured for workDir.
+	FetchIndex(ctx context.Context, workDir string) (*PluginIndex, error)
+
+	// Install builds the plugin and adds it to the p5.toml used by workDir.
+	Install(ctx context.Context, workDir string, entry IndexEntry) (*InstallResult, error)
+}
+
+// Installer installs plugins from the index with the Go toolchain
+type Installer struct{}
+
+// NewInstaller creates a new plugin installer
+func NewInstaller() *Installer {
+	return &Installer{}
+}
+
+// FetchIndex fetches the index set by plugin_index in p5.toml, or the default index
+func (i *Installer) FetchIndex(ctx context.Context, workDir string) (*PluginIndex, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return nil, err
+	}
+	source := global.PluginIndex
+	if source == "" {
+		source = DefaultPluginIndexURL
+	}
+	return FetchPluginIndex(ctx, source)
+}
+
+// Install runs go install for the plugin, placing the executable in PluginBinDir,
+// and adds it to p5.toml
+func (i *Installer) Install(ctx context.Context, workDir string, entry IndexEntry) (*InstallResult, error) {
+	global, configPath, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := global.Plugins[entry.Name]; ok {
+		return nil, fmt.Errorf("plugin %s is already configured in %s", entry.Name, configPath)
+	}
+	if configPath == "" {
+		configPath = newGlobalConfigPath(workDir)
+	}
+	binDir, err := PluginBinDir()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		return nil, fmt.Errorf("installing plugins requires the Go toolchain: %w", err)
+	}
+
+	version := entry.Version
+	if version == "" {
+		version = "latest"
+	}
+	cmd := exec.CommandContext(ctx, "go", "install", "--", entry.Package+"@"+version)
+	cmd.Env = append(os.Environ(), "GOBIN="+binDir)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("go install %s failed: %w\n%s", entry.Package, err, bytes.TrimSpace(output))
+	}
+
+	binName := installedBinaryName(entry.Package)
+	if runtime.GOOS == "windows" {
+		binName += ".exe"
+	}
+	result := &InstallResult{
+		Name:       entry.Name,
+		Cmd:        filepath.Join(binDir, binName),
+		ConfigPath: configPath,
+	}
+	if err := AddPluginConfig(configPath, entry, result.Cmd); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// majorVersionPattern matches the major version suffix of a module path, like v2
+var majorVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// installedBinaryName returns the name go install gives the binary of pkg: its
+// last path element, or the one before a major version suffix
+func installedBinaryName(pkg string) string {
+	name := path.Base(pkg)
+	if majorVersionPattern.MatchString(name) && path.Dir(pkg) != "." {
+		name = path.Base(path.Dir(pkg))
+	}
+	return name
+}
+
+// FetchPluginIndex reads a plugin index from a local path or an http(s) URL
+func FetchPluginIndex(ctx context.Context, source string) (*PluginIndex, error) {
+	data, err := readIndexSource(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	var index PluginIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin index: %w", err)
+	}
+	for _, entry := range index.Plugins {
+		if !pluginNamePattern.MatchString(entry.Name) {
+			return nil, fmt.Errorf("invalid plugin name in index: %q", entry.Name)
+		}
+		if IsBuiltin(entry.Name) {
+			return nil, fmt.Errorf("plugin %s in index uses the name of a builtin plugin", entry.Name)
+		}
+		if entry.Package == "" {
+			return nil, fmt.Errorf("plugin %s in index has no package", entry.Name)
+		}
+		// Both are passed to go install, so a leading dash mustn't read as a flag
+		if err := module.CheckImportPath(entry.Package); err != nil || strings.HasPrefix(entry.Package, "-") {
+			return nil, fmt.Errorf("plugin %s in index has an invalid package %q", entry.Name, entry.Package)
+		}
+		if entry.Version != "" && entry.Version != "latest" && !semver.IsValid(entry.Version) {
+			return nil, fmt.Errorf("plugin %s in index has an invalid version %q", entry.Name, entry.Version)
+		}
+	}
+	return &index, nil
+}
+
+// maxIndexSize bounds how much of a plugin index is read from a URL
+const maxIndexSize = 4 << 20
+
+// indexClient fetches plugin indexes, giving up on servers that stop responding
+var indexClient = &http.Client{Timeout: 30 * time.Second}
+
+// readIndexSource reads a local file, or fetches it when source is an http(s) URL
+func readIndexSource(ctx context.Context, source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read plugin index: %w", err)
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plugin index URL: %w", err)
+	}
+	resp, err := indexClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch plugin index: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch plugin index: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxIndexSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch plugin index: %w", err)
+	}
+	if len(data) > maxIndexSize {
+		return nil, fmt.Errorf("failed to fetch plugin index: larger than %d MiB", maxIndexSize>>20)
+	}
+	return data, nil
+}
+
+// PluginBinDir returns the directory installed plugins are placed in
+func PluginBinDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find plugin directory: %w", err)
+	}
+	return filepath.Join(configDir, "p5", "plugins"), nil
+}
+
+// newGlobalConfigPath returns where p5.toml is created for workDir when none
+// exists: the git root, falling back to workDir itself
+func newGlobalConfigPath(workDir string) string {
+	if gitRoot, err := findGitRoot(workDir); err == nil && gitRoot != "" {
+		return filepath.Join(gitRoot, "p5.toml")
+	}
+	return filepath.Join(workDir, "p5.toml")
+}
+
+// AddPluginConfig appends a plugin section running cmd to a p5.toml, creating the
+// file if needed. The rest of the file, including comments, is left untouched.
+func AddPluginConfig(configPath string, entry IndexEntry, cmd string) error {
+	existing, err := os.ReadFile(configPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", configPath, err)
+	}
+	if len(existing) > 0 {
+		config, err := loadGlobalConfigFile(configPath)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", configPath, err)
+		}
+		if _, ok := config.Plugins[entry.Name]; ok {
+			return fmt.Errorf("plugin %s is already configured in %s", entry.Name, configPath)
+		}
+	}
+
+	var section strings.Builder
+	fmt.Fprintf(&section, "[plugins.%s]\n", entry.Name)
+	fmt.Fprintf(&section, "cmd = %s\n", strconv.Quote(cmd))
+	if entry.HasCapability(CapabilityImportHelper) {
+		section.WriteString("import_helper = true\n")
+	}
+	if entry.HasCapability(CapabilityResourceOpener) {
+		section.WriteString("resource_opener = true\n")
+	}
+	if entry.HasCapability(CapabilityStatusBadge) {
+		section.WriteString("status_badge = true\n")
+	}
+	return appendConfigSection(configPath, existing, section.String())
+}
+
+// appendConfigSection appends a section to a p5.toml whose current content is
+// existing, separated from it by a blank line, creating the file if needed
+func appendConfigSection(configPath string, existing []byte, section string) error {
+	if len(existing) > 0 {
+		separator := "\n"
+		if !bytes.HasSuffix(existing, []byte("\n")) {
+			separator = "\n\n"
+		}
+		section = separator + section
+	}
+
+	f, err := os.OpenFile(configPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", configPath, err)
+	}
+	if _, err := f.WriteString(section); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %s: %w", configPath, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", configPath, err)
+	}
+	return nil
+}
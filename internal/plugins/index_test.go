@@ -0,0 +1,160 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFetchPluginIndex(t *testing.T) {
+	const index = `{"plugins": [{"name": "vault", "description": "Vault tokens", "package": "example.com/p5-vault/cmd/p5-vault", "version": "v1.2.0", "capabilities": ["auth"]}]}`
+
+	t.Run("local file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "index.json")
+		if err := os.WriteFile(path, []byte(index), 0o600); err != nil {
+			t.Fatalf("failed to write index: %v", err)
+		}
+		got, err := FetchPluginIndex(context.Background(), path)
+		if err != nil {
+			t.Fatalf("FetchPluginIndex() error = %v", err)
+		}
+		if len(got.Plugins) != 1 || got.Plugins[0].Name != "vault" || !got.Plugins[0].HasCapability(CapabilityAuth) {
+			t.Errorf("unexpected index: %+v", got)
+		}
+	})
+
+	t.Run("url", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/index.json" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(index))
+		}))
+		defer server.Close()
+
+		got, err := FetchPluginIndex(context.Background(), server.URL+"/index.json")
+		if err != nil {
+			t.Fatalf("FetchPluginIndex() error = %v", err)
+		}
+		if len(got.Plugins) != 1 {
+			t.Errorf("expected 1 plugin, got %d", len(got.Plugins))
+		}
+
+		if _, err := FetchPluginIndex(context.Background(), server.URL+"/missing.json"); err == nil {
+			t.Error("expected an error for a missing index")
+		}
+
+		huge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(bytes.Repeat([]byte(" "), maxIndexSize+1))
+		}))
+		defer huge.Close()
+		if _, err := FetchPluginIndex(context.Background(), huge.URL); err == nil || !strings.Contains(err.Error(), "larger than") {
+			t.Errorf("expected an oversized index to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("invalid entries", func(t *testing.T) {
+		originalRegistry := builtinRegistry
+		defer func() { builtinRegistry = originalRegistry }()
+		builtinRegistry = make(map[string]BuiltinPlugin)
+		RegisterBuiltin(&mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("grafana")})
+
+		tests := map[string]string{
+			"name with spaces": `{"plugins": [{"name": "my plugin", "package": "example.com/p"}]}`,
+			"builtin name":     `{"plugins": [{"name": "grafana", "package": "example.com/p"}]}`,
+			"no package":       `{"plugins": [{"name": "vault"}]}`,
+			"flag as package":  `{"plugins": [{"name": "vault", "package": "-toolexec=/bin/sh"}]}`,
+			"flag as version":  `{"plugins": [{"name": "vault", "package": "example.com/p", "version": "-toolexec=/bin/sh"}]}`,
+			"not semver":       `{"plugins": [{"name": "vault", "package": "example.com/p", "version": "1.2"}]}`,
+		}
+		for name, content := range tests {
+			path := filepath.Join(t.TempDir(), "index.json")
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatalf("failed to write index: %v", err)
+			}
+			if _, err := FetchPluginIndex(context.Background(), path); err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+		}
+	})
+}
+
+func TestAddPluginConfig(t *testing.T) {
+	entry := IndexEntry{
+		Name:         "vault",
+		Package:      "example.com/p5-vault/cmd/p5-vault",
+		Capabilities: []string{CapabilityAuth, CapabilityImportHelper, CapabilityStatusBadge},
+	}
+
+	t.Run("creates file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "p5.toml")
+		if err := AddPluginConfig(path, entry, "/plugins/p5-vault"); err != nil {
+			t.Fatalf("AddPluginConfig() error = %v", err)
+		}
+		config, err := loadGlobalConfigFile(path)
+		if err != nil {
+			t.Fatalf("failed to load written config: %v", err)
+		}
+		got := config.Plugins["vault"]
+		if got.Cmd != "/plugins/p5-vault" || !got.ImportHelper || got.ResourceOpener || !got.StatusBadge {
+			t.Errorf("unexpected plugin config: %+v", got)
+		}
+	})
+
+	t.Run("keeps existing content", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "p5.toml")
+		existing := "# shared plugins\norder = [\"env\"]\n\n[plugins.env]"
+		if err := os.WriteFile(path, []byte(existing), 0o600); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		if err := AddPluginConfig(path, entry, "/plugins/p5-vault"); err != nil {
+			t.Fatalf("AddPluginConfig() error = %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read config: %v", err)
+		}
+		if !strings.HasPrefix(string(data), existing+"\n") {
+			t.Errorf("existing content was changed:\n%s", data)
+		}
+		config, err := loadGlobalConfigFile(path)
+		if err != nil {
+			t.Fatalf("failed to load written config: %v", err)
+		}
+		if _, ok := config.Plugins["env"]; !ok {
+			t.Error("expected env plugin to remain configured")
+		}
+		if config.Plugins["vault"].Cmd != "/plugins/p5-vault" {
+			t.Errorf("expected vault plugin to be added, got %+v", config.Plugins)
+		}
+	})
+
+	t.Run("already configured", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "p5.toml")
+		if err := os.WriteFile(path, []byte("[plugins.vault]\ncmd = \"vault\"\n"), 0o600); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		if err := AddPluginConfig(path, entry, "/plugins/p5-vault"); err == nil {
+			t.Error("expected an error for a plugin that is already configured")
+		}
+	})
+}
+
+func TestInstalledBinaryName(t *testing.T) {
+	for pkg, want := range map[string]string{
+		"github.com/acme/p5-aws":              "p5-aws",
+		"github.com/acme/p5-aws/v2":           "p5-aws",
+		"github.com/acme/tools/cmd/p5-vault":  "p5-vault",
+		"github.com/acme/tools/v3/cmd/p5-gcp": "p5-gcp",
+	} {
+		if got := installedBinaryName(pkg); got != want {
+			t.Errorf("installedBinaryName(%q) = %q, want %q", pkg, got, want)
+		}
+	}
+}
@@ -13,11 +13,26 @@ type AuthPlugin = p5plugin.AuthPlugin
 // This is re-exported from pkg/plugin for internal use.
 type ImportHelperPlugin = p5plugin.ImportHelperPlugin
 
+// ImportableResourceLister is an optional interface that import helper plugins can
+// implement to list existing resources for bulk import.
+// This is re-exported from pkg/plugin for internal use.
+type ImportableResourceLister = p5plugin.ImportableResourceLister
+
+// ImportIDValidator is an optional interface that import helper plugins can
+// implement to check import IDs before an import runs.
+// This is re-exported from pkg/plugin for internal use.
+type ImportIDValidator = p5plugin.ImportIDValidator
+
 // ResourceOpenerPlugin is an optional interface that plugins can implement
 // to provide resource opening capabilities (browser URLs or alternate screen programs).
 // This is re-exported from pkg/plugin for internal use.
 type ResourceOpenerPlugin = p5plugin.ResourceOpenerPlugin
 
+// StatusBadgePlugin is an optional interface that plugins can implement
+// to show a short status in the header.
+// This is re-exported from pkg/plugin for internal use.
+type StatusBadgePlugin = p5plugin.StatusBadgePlugin
+
 // Re-export import suggestion types from pkg/plugin for internal use.
 type (
 	ImportSuggestionsRequest  = p5plugin.ImportSuggestionsRequest
@@ -25,6 +40,19 @@ type (
 	ImportSuggestion          = p5plugin.ImportSuggestion
 )
 
+// Re-export bulk import types from pkg/plugin for internal use.
+type (
+	ListImportableResourcesRequest  = p5plugin.ListImportableResourcesRequest
+	ListImportableResourcesResponse = p5plugin.ListImportableResourcesResponse
+	ImportableResource              = p5plugin.ImportableResource
+)
+
+// Re-export import ID validation types from pkg/plugin for internal use.
+type (
+	ValidateImportIdRequest  = p5plugin.ValidateImportIdRequest
+	ValidateImportIdResponse = p5plugin.ValidateImportIdResponse
+)
+
 // Re-export resource opener types from pkg/plugin for internal use.
 type (
 	SupportedOpenTypesRequest  = p5plugin.SupportedOpenTypesRequest
@@ -35,6 +63,20 @@ type (
 	OpenActionType             = p5plugin.OpenActionType
 )
 
+// Re-export status badge types from pkg/plugin for internal use.
+type (
+	StatusBadgeRequest  = p5plugin.StatusBadgeRequest
+	StatusBadgeResponse = p5plugin.StatusBadgeResponse
+	StatusBadgeLevel    = p5plugin.StatusBadgeLevel
+)
+
+// Re-export status badge levels from pkg/plugin for internal use.
+const (
+	StatusBadgeLevelInfo    = p5plugin.StatusBadgeLevelInfo
+	StatusBadgeLevelWarning = p5plugin.StatusBadgeLevelWarning
+	StatusBadgeLevelDanger  = p5plugin.StatusBadgeLevelDanger
+)
+
 // Re-export import suggestion helper functions from pkg/plugin for internal use.
 var (
 	ImportSuggestionsNotSupported = p5plugin.ImportSuggestionsNotSupported
@@ -43,6 +85,22 @@ var (
 	NewImportSuggestion           = p5plugin.NewImportSuggestion
 )
 
+// Re-export bulk import helper functions from pkg/plugin for internal use.
+var (
+	ListImportableResourcesNotSupported = p5plugin.ListImportableResourcesNotSupported
+	ListImportableResourcesSuccess      = p5plugin.ListImportableResourcesSuccess
+	ListImportableResourcesError        = p5plugin.ListImportableResourcesError
+	NewImportableResource               = p5plugin.NewImportableResource
+)
+
+// Re-export import ID validation helper functions from pkg/plugin for internal use.
+var (
+	ValidateImportIdNotSupported = p5plugin.ValidateImportIdNotSupported
+	ValidImportId                = p5plugin.ValidImportId
+	InvalidImportId              = p5plugin.InvalidImportId
+	ValidateImportIdError        = p5plugin.ValidateImportIdError
+)
+
 // Re-export resource opener helper functions from pkg/plugin for internal use.
 var (
 	OpenNotSupported           = p5plugin.OpenNotSupported
@@ -51,3 +109,9 @@ var (
 	OpenError                  = p5plugin.OpenError
 	SupportedOpenTypesPatterns = p5plugin.SupportedOpenTypesPatterns
 )
+
+// Re-export status badge helper functions from pkg/plugin for internal use.
+var (
+	NewStatusBadge   = p5plugin.NewStatusBadge
+	StatusBadgeError = p5plugin.StatusBadgeError
+)
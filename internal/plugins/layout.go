@@ -0,0 +1,115 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Details panel width bounds, as a percentage of the screen width
+const (
+	DefaultDetailsWidth = 50
+	MinDetailsWidth     = 20
+	MaxDetailsWidth     = 80
+)
+
+// UserPreferences are settings p5 saves for the user, rather than for the project,
+// as p5.toml is usually committed and shared
+type UserPreferences struct {
+	// DetailsWidth is the details panel width the user last resized it to
+	DetailsWidth int `toml:"details_width,omitempty"`
+
+	// Workspaces are the workspaces the user opened or pinned
+	Workspaces []RecentWorkspace `toml:"workspaces,omitempty"`
+}
+
+// UserPreferencesPath returns where the user's preferences are saved
+func UserPreferencesPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "p5", "preferences.toml"), nil
+}
+
+// LoadUserPreferences loads the user's preferences. Returns empty preferences when
+// none are saved.
+func LoadUserPreferences() (*UserPreferences, error) {
+	prefs := &UserPreferences{}
+	path, err := UserPreferencesPath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := toml.DecodeFile(path, prefs); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	return prefs, nil
+}
+
+// UpdateUserPreferences applies update to the user's saved preferences and saves
+// them, creating the file if needed
+func UpdateUserPreferences(update func(*UserPreferences)) error {
+	prefs, err := LoadUserPreferences()
+	if err != nil {
+		return err
+	}
+	update(prefs)
+
+	path, err := UserPreferencesPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := toml.NewEncoder(file).Encode(prefs); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadDetailsWidth loads the details panel width the user last resized it to, falling
+// back to the details_width set in p5.toml for the project in workDir. Returns
+// DefaultDetailsWidth when neither is set.
+func LoadDetailsWidth(workDir string) (int, error) {
+	prefs, err := LoadUserPreferences()
+	if err != nil {
+		return 0, err
+	}
+	width := prefs.DetailsWidth
+	if width == 0 {
+		global, _, err := LoadGlobalConfig(workDir)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load global config: %w", err)
+		}
+		width = global.DetailsWidth
+	}
+	if width == 0 {
+		return DefaultDetailsWidth, nil
+	}
+	if width < MinDetailsWidth || width > MaxDetailsWidth {
+		return 0, fmt.Errorf("details_width must be between %d and %d, got %d", MinDetailsWidth, MaxDetailsWidth, width)
+	}
+	return width, nil
+}
+
+// SaveDetailsWidth saves the details panel width to the user's preferences
+func SaveDetailsWidth(width int) error {
+	if width < MinDetailsWidth || width > MaxDetailsWidth {
+		return fmt.Errorf("details_width must be between %d and %d, got %d", MinDetailsWidth, MaxDetailsWidth, width)
+	}
+	return UpdateUserPreferences(func(prefs *UserPreferences) {
+		prefs.DetailsWidth = width
+	})
+}
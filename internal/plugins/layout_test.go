@@ -0,0 +1,51 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestDetailsWidth verifies the details panel width round-trips through the user's
+// preferences, which take precedence over p5.toml and leave it untouched
+func TestDetailsWidth(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "p5.toml")
+
+	if width, err := LoadDetailsWidth(tmpDir); err != nil || width != DefaultDetailsWidth {
+		t.Fatalf("expected the default width without preferences or p5.toml, got %d, %v", width, err)
+	}
+
+	const project = "details_width = 40\n\n[plugins.env]\ncmd = \"env\"\n"
+	if err := os.WriteFile(configPath, []byte(project), 0o600); err != nil {
+		t.Fatalf("failed to create p5.toml: %v", err)
+	}
+	if width, err := LoadDetailsWidth(tmpDir); err != nil || width != 40 {
+		t.Fatalf("expected the p5.toml width without preferences, got %d, %v", width, err)
+	}
+
+	if err := SaveDetailsWidth(65); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if width, err := LoadDetailsWidth(tmpDir); err != nil || width != 65 {
+		t.Errorf("expected the saved width 65, got %d, %v", width, err)
+	}
+	if data, err := os.ReadFile(configPath); err != nil || string(data) != project {
+		t.Errorf("expected p5.toml to be left untouched, got %q, %v", data, err)
+	}
+
+	if err := SaveDetailsWidth(95); err == nil {
+		t.Error("expected an error for a width out of range")
+	}
+	path, err := UserPreferencesPath()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("details_width = 5\n"), 0o600); err != nil {
+		t.Fatalf("failed to write preferences: %v", err)
+	}
+	if _, err := LoadDetailsWidth(tmpDir); err == nil {
+		t.Error("expected an error for a saved width out of range")
+	}
+}
@@ -23,6 +23,8 @@ type PluginInstance struct {
 	auth           AuthPlugin
 	importHelper   ImportHelperPlugin   // nil if not supported or not enabled
 	resourceOpener ResourceOpenerPlugin // nil if not supported or not enabled
+	postOperation  PostOperationHook    // nil if not supported or not enabled
+	statusBadge    StatusBadgePlugin    // nil if not supported or not enabled
 	builtin        bool                 // true if this is a builtin plugin
 }
 
@@ -36,6 +38,16 @@ func (p *PluginInstance) HasResourceOpener() bool {
 	return p.resourceOpener != nil
 }
 
+// HasPostOperationHook returns true if this plugin is notified after operations
+func (p *PluginInstance) HasPostOperationHook() bool {
+	return p.postOperation != nil
+}
+
+// HasStatusBadge returns true if this plugin shows a status badge in the header
+func (p *PluginInstance) HasStatusBadge() bool {
+	return p.statusBadge != nil
+}
+
 // Close shuts down the plugin
 func (p *PluginInstance) Close() {
 	// Only external plugins have a client to kill
@@ -104,6 +116,20 @@ func (m *Manager) loadBuiltinPlugin(name string, config PluginConfig) error {
 		}
 	}
 
+	// Check if plugin implements PostOperationHook and is enabled
+	if config.PostOperation {
+		if hook, ok := builtinPlugin.(PostOperationHook); ok {
+			instance.postOperation = hook
+		}
+	}
+
+	// Check if plugin implements StatusBadgePlugin and is enabled
+	if config.StatusBadge {
+		if statusBadge, ok := builtinPlugin.(StatusBadgePlugin); ok {
+			instance.statusBadge = statusBadge
+		}
+	}
+
 	m.plugins[name] = instance
 	return nil
 }
@@ -183,6 +209,17 @@ func (m *Manager) loadPlugin(ctx context.Context, name string, config PluginConf
 		// If dispensing fails, just continue without resource opener capability
 	}
 
+	// Try to load status badge if enabled in config
+	if config.StatusBadge {
+		rawStatusBadge, err := rpcClient.Dispense("status_badge")
+		if err == nil {
+			if statusBadge, ok := rawStatusBadge.(StatusBadgePlugin); ok {
+				instance.statusBadge = statusBadge
+			}
+		}
+		// If dispensing fails, just continue without status badge capability
+	}
+
 	m.plugins[name] = instance
 	return nil
 }
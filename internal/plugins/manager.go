@@ -3,7 +3,14 @@ package plugins
 import (
 	"context"
 	"maps"
+	"slices"
 	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rfhold/p5/internal/telemetry"
 )
 
 // Compile-time check that Manager implements PluginProvider
@@ -23,15 +30,23 @@ type Manager struct {
 	globalConfigPath string
 	// Launch directory (for finding p5.toml)
 	launchDir string
+	// Environment variables resolved from the stack's Pulumi ESC environments
+	environmentEnv map[string]string
+	// When plugins whose credential refresh failed are retried
+	refreshRetryAt map[string]time.Time
+	// Last authentication error of each plugin, cleared when it authenticates
+	authErrors map[string]error
 }
 
 // NewManager creates a new plugin manager
 // launchDir is the directory p5 was launched from (used to find p5.toml)
 func NewManager(launchDir string) (*Manager, error) {
 	return &Manager{
-		plugins:     make(map[string]*PluginInstance),
-		credentials: make(map[string]*Credentials),
-		launchDir:   launchDir,
+		plugins:        make(map[string]*PluginInstance),
+		credentials:    make(map[string]*Credentials),
+		launchDir:      launchDir,
+		refreshRetryAt: make(map[string]time.Time),
+		authErrors:     make(map[string]error),
 	}, nil
 }
 
@@ -45,6 +60,8 @@ func (m *Manager) Close(ctx context.Context) {
 	}
 	m.plugins = make(map[string]*PluginInstance)
 	m.credentials = make(map[string]*Credentials)
+	m.refreshRetryAt = make(map[string]time.Time)
+	m.authErrors = make(map[string]error)
 }
 
 // GetMergedConfig returns the current merged configuration
@@ -101,7 +118,16 @@ func (m *Manager) GetImportSuggestions(ctx context.Context, req *ImportSuggestio
 			}
 		}
 
-		resp, err := instance.importHelper.GetImportSuggestions(ctx, pluginReq)
+		spanCtx, span := telemetry.Tracer().Start(ctx, "plugin.import_suggestions", trace.WithAttributes(
+			telemetry.AttrPlugin.String(name),
+			telemetry.AttrURN.String(req.ResourceUrn),
+			telemetry.AttrResourceType.String(req.ResourceType),
+		))
+		resp, err := instance.importHelper.GetImportSuggestions(spanCtx, pluginReq)
+		if err == nil {
+			span.SetAttributes(attribute.Int("p5.suggestions", len(resp.Suggestions)))
+		}
+		telemetry.EndSpan(span, err)
 		if err != nil {
 			// Log error but continue with other plugins
 			continue
@@ -129,6 +155,90 @@ func (m *Manager) GetImportSuggestions(ctx context.Context, req *ImportSuggestio
 	return results, nil
 }
 
+// AggregatedImportableResource includes the source plugin name
+type AggregatedImportableResource struct {
+	PluginName string
+	Resource   *ImportableResource
+}
+
+// ImportableResourcesPage is one page of importable resources from all plugins
+type ImportableResourcesPage struct {
+	Resources []*AggregatedImportableResource
+	// NextPageTokens maps plugin name to the token for its next page.
+	// Plugins with no more pages are omitted.
+	NextPageTokens map[string]string
+}
+
+// ListImportableResources queries import helper plugins for existing resources to bulk import.
+// A nil pageTokens fetches the first page from every plugin; otherwise only the plugins
+// in pageTokens are queried, each for the page its token points to.
+func (m *Manager) ListImportableResources(ctx context.Context, req *ListImportableResourcesRequest, pageTokens map[string]string) (*ImportableResourcesPage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	page := &ImportableResourcesPage{NextPageTokens: make(map[string]string)}
+
+	// Query plugins in name order so pages are stable
+	for _, name := range slices.Sorted(maps.Keys(m.plugins)) {
+		instance := m.plugins[name]
+		lister, ok := instance.importHelper.(ImportableResourceLister)
+		if !instance.HasImportHelper() || !ok {
+			continue
+		}
+
+		pageToken := ""
+		if pageTokens != nil {
+			token, more := pageTokens[name]
+			if !more {
+				continue
+			}
+			pageToken = token
+		}
+
+		// Clone the request so each plugin gets its own page token and auth env
+		pluginReq := &ListImportableResourcesRequest{
+			ResourceType:   req.ResourceType,
+			Inputs:         req.Inputs,
+			ProgramConfig:  req.ProgramConfig,
+			StackConfig:    req.StackConfig,
+			StackName:      req.StackName,
+			ProgramName:    req.ProgramName,
+			ProviderUrn:    req.ProviderUrn,
+			ProviderInputs: req.ProviderInputs,
+			PageSize:       req.PageSize,
+			PageToken:      pageToken,
+		}
+
+		// If use_auth_env is enabled for this plugin, populate auth_env
+		if config, ok := m.mergedConfig.Plugins[name]; ok && config.UseAuthEnv {
+			pluginReq.AuthEnv = m.getMergedAuthEnvLocked()
+		}
+
+		resp, err := lister.ListImportableResources(ctx, pluginReq)
+		if err != nil {
+			// Log error but continue with other plugins
+			continue
+		}
+
+		// Skip if plugin can't list this resource type or failed
+		if !resp.CanProvide || resp.Error != "" {
+			continue
+		}
+
+		for _, resource := range resp.Resources {
+			page.Resources = append(page.Resources, &AggregatedImportableResource{
+				PluginName: name,
+				Resource:   resource,
+			})
+		}
+		if resp.NextPageToken != "" {
+			page.NextPageTokens[name] = resp.NextPageToken
+		}
+	}
+
+	return page, nil
+}
+
 // GetMergedAuthEnv returns all auth environment variables from all plugins
 func (m *Manager) GetMergedAuthEnv() map[string]string {
 	m.mu.RLock()
@@ -136,9 +246,18 @@ func (m *Manager) GetMergedAuthEnv() map[string]string {
 	return m.getMergedAuthEnvLocked()
 }
 
-// getMergedAuthEnvLocked returns all auth environment variables from all plugins (must hold lock)
+// SetEnvironmentEnv sets environment variables resolved from the stack's Pulumi ESC environments
+func (m *Manager) SetEnvironmentEnv(env map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.environmentEnv = maps.Clone(env)
+}
+
+// getMergedAuthEnvLocked returns all auth environment variables from all plugins (must hold lock).
+// ESC environment variables are included first so plugin credentials override them.
 func (m *Manager) getMergedAuthEnvLocked() map[string]string {
 	env := make(map[string]string)
+	maps.Copy(env, m.environmentEnv)
 	for _, creds := range m.credentials {
 		if creds != nil && creds.Env != nil {
 			maps.Copy(env, creds.Env)
@@ -206,7 +325,16 @@ func (m *Manager) OpenResource(ctx context.Context, req *OpenResourceRequest) (r
 			}
 		}
 
-		resp, err := instance.resourceOpener.OpenResource(ctx, pluginReq)
+		spanCtx, span := telemetry.Tracer().Start(ctx, "plugin.open_resource", trace.WithAttributes(
+			telemetry.AttrPlugin.String(name),
+			telemetry.AttrURN.String(req.ResourceUrn),
+			telemetry.AttrResourceType.String(req.ResourceType),
+		))
+		resp, err := instance.resourceOpener.OpenResource(spanCtx, pluginReq)
+		if err == nil {
+			span.SetAttributes(attribute.Bool("p5.can_open", resp.CanOpen))
+		}
+		telemetry.EndSpan(span, err)
 		if err != nil {
 			// Log error but continue with other plugins
 			continue
@@ -1,12 +1,18 @@
 package plugins
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"io/fs"
 	"maps"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
@@ -70,8 +76,28 @@ type PluginConfig struct {
 	// Resource opener settings
 	// ResourceOpener enables the resource opener capability for this plugin (default: false)
 	ResourceOpener bool `yaml:"resource_opener,omitempty" toml:"resource_opener,omitempty"`
+
+	// Post-operation hook settings
+	// PostOperation notifies this plugin after each up, refresh or destroy (default: false).
+	// Only builtin plugins provide post-operation hooks.
+	PostOperation bool `yaml:"post_operation,omitempty" toml:"post_operation,omitempty"`
+
+	// Status badge settings
+	// StatusBadge shows this plugin's status badge in the header (default: false)
+	StatusBadge bool `yaml:"status_badge,omitempty" toml:"status_badge,omitempty"`
 }
 
+// ArtifactsConfig controls writing per-operation artifacts for later review
+type ArtifactsConfig struct {
+	// Enabled writes artifacts after each up, refresh or destroy (default: false)
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+	// Dir is where run directories are created, relative to the project directory (default: .p5/runs)
+	Dir string `yaml:"dir,omitempty" toml:"dir,omitempty"`
+}
+
+// DefaultArtifactsDir is where run directories are created when no dir is configured
+const DefaultArtifactsDir = ".p5/runs"
+
 // P5Config represents the p5 configuration section in Pulumi.yaml
 type P5Config struct {
 	Plugins map[string]PluginConfig `yaml:"plugins,omitempty"`
@@ -79,6 +105,23 @@ type P5Config struct {
 	// Plugins are authenticated sequentially in this order.
 	// Plugins not listed in order will run after ordered plugins (in non-deterministic order).
 	Order []string `yaml:"order,omitempty" toml:"order,omitempty"`
+	// Artifacts configures operation artifacts for this project
+	Artifacts *ArtifactsConfig `yaml:"artifacts,omitempty" toml:"artifacts,omitempty"`
+	// PersistFlags saves resource flags to .p5/flags.json so they survive restarts (default: false)
+	PersistFlags *bool `yaml:"persist_flags,omitempty" toml:"persist_flags,omitempty"`
+	// Keys remaps keybindings for this workspace, overriding p5.toml per action
+	Keys map[string]KeyList `yaml:"keys,omitempty" toml:"keys,omitempty"`
+	// GitGuard warns before up on stacks deployed from a dirty or unexpected git checkout
+	GitGuard *GitGuardConfig `yaml:"git_guard,omitempty" toml:"git_guard,omitempty"`
+	// Stacks configures stacks by name or pattern, overriding p5.toml per pattern
+	Stacks map[string]StackConfig `yaml:"stacks,omitempty" toml:"stacks,omitempty"`
+	// Confirm is when up, refresh and destroy ask for confirmation, overriding p5.toml
+	Confirm *ConfirmConfig `yaml:"confirm,omitempty" toml:"confirm,omitempty"`
+	// AutoApprove runs small changes from their preview without confirmation, overriding p5.toml
+	AutoApprove *AutoApproveConfig `yaml:"auto_approve,omitempty" toml:"auto_approve,omitempty"`
+	// DiffIgnore de-emphasizes property paths in diffs, by resource type pattern,
+	// overriding p5.toml per pattern
+	DiffIgnore map[string][]string `yaml:"diff_ignore,omitempty" toml:"diff_ignore,omitempty"`
 }
 
 // LoadP5Config loads p5 configuration from a Pulumi.yaml file
@@ -184,6 +227,499 @@ type GlobalConfig struct {
 	// Plugins are authenticated sequentially in this order.
 	// Plugins not listed in order will run after ordered plugins (in non-deterministic order).
 	Order []string `toml:"order,omitempty"`
+	// Locale selects the UI language (e.g. "en", "es").
+	// When empty, the locale is detected from LC_ALL, LC_MESSAGES or LANG.
+	Locale string `toml:"locale,omitempty"`
+	// Artifacts configures operation artifacts for projects that don't configure them
+	Artifacts *ArtifactsConfig `toml:"artifacts,omitempty"`
+	// PersistFlags saves resource flags for projects that don't configure it
+	PersistFlags *bool `toml:"persist_flags,omitempty"`
+	// GitGuard guards up for projects that don't configure it
+	GitGuard *GitGuardConfig `toml:"git_guard,omitempty"`
+	// Stacks configures stacks by name or pattern (e.g. "prod" or "*-prod")
+	Stacks map[string]StackConfig `toml:"stacks,omitempty"`
+	// Confirm is when up, refresh and destroy ask for confirmation in projects that don't configure it
+	Confirm *ConfirmConfig `toml:"confirm,omitempty"`
+	// AutoApprove runs small changes from their preview without confirmation in
+	// projects that don't configure it
+	AutoApprove *AutoApproveConfig `toml:"auto_approve,omitempty"`
+	// DiffIgnore de-emphasizes property paths in diffs, by resource type pattern
+	// (e.g. "kubernetes:*" = ["metadata.annotations"]), where * matches any text
+	DiffIgnore map[string][]string `toml:"diff_ignore,omitempty"`
+	// PluginIndex is the path or URL of the plugin index browsed in p5 (default: DefaultPluginIndexURL)
+	PluginIndex string `toml:"plugin_index,omitempty"`
+	// Workflows are named sequences of operations run with `p5 run <name>` or from the workflow selector
+	Workflows map[string]WorkflowConfig `toml:"workflows,omitempty"`
+	// IdleLock locks the TUI after a period of inactivity on protected stacks
+	IdleLock *IdleLockConfig `toml:"idle_lock,omitempty"`
+	// PollInterval checks the stack for updates made elsewhere this often while idle
+	// in the stack view, e.g. "1m" (at least MinPollInterval, disabled when empty)
+	PollInterval string `toml:"poll_interval,omitempty"`
+	// HistoryPageSize is how many updates the history view loads at a time, loading
+	// the next page when scrolling near the end (default 50)
+	HistoryPageSize int `toml:"history_page_size,omitempty"`
+	// Keys remaps keybindings, by action name (e.g. preview_up = "u")
+	Keys map[string]KeyList `toml:"keys,omitempty"`
+	// Theme selects the color theme and overrides its colors
+	Theme ThemeConfig `toml:"theme,omitempty"`
+	// DetailsWidth is the percentage of the screen width taken by the details panel
+	// (MinDetailsWidth-MaxDetailsWidth, default DefaultDetailsWidth)
+	DetailsWidth int `toml:"details_width,omitempty"`
+	// WorkspaceSearch configures how workspaces are found for the workspace selector,
+	// dashboard and stack references
+	WorkspaceSearch *WorkspaceSearchConfig `toml:"workspace_search,omitempty"`
+	// WorkspaceGroups list related workspaces together in the workspace selector
+	WorkspaceGroups []WorkspaceGroupConfig `toml:"workspace_groups,omitempty"`
+	// TargetSets are named sets of resource flags, saved from p5 to re-apply later
+	TargetSets map[string]TargetSetConfig `toml:"target_sets,omitempty"`
+	// FuzzyFilter matches list filters as a fuzzy subsequence, ranking the best
+	// matches first, instead of as a substring
+	FuzzyFilter bool `toml:"fuzzy_filter,omitempty"`
+	// Icons shows a Nerd Font glyph for the provider of each resource type
+	Icons bool `toml:"icons,omitempty"`
+	// SyntaxHighlight lays out and highlights JSON, YAML and HCL values in diffs and
+	// the value pager (default: true)
+	SyntaxHighlight *bool `toml:"syntax_highlight,omitempty"`
+	// Clipboard is how copied text reaches the clipboard: "auto" (default), "native"
+	// or "osc52", which works over SSH and in tmux
+	Clipboard string `toml:"clipboard,omitempty"`
+	// RefreshOnUp refreshes the state as part of up and its preview by default, like
+	// pulumi up --refresh
+	RefreshOnUp bool `toml:"refresh_on_up,omitempty"`
+	// UpdateMessage asks for a message to record with each up and destroy
+	UpdateMessage bool `toml:"update_message,omitempty"`
+	// Metrics exports UI responsiveness and operation metrics over OTLP, when
+	// OTEL_EXPORTER_OTLP_ENDPOINT is set
+	Metrics bool `toml:"metrics,omitempty"`
+}
+
+// WorkspaceGroupConfig is a named group of workspaces in a monorepo, e.g. "platform"
+type WorkspaceGroupConfig struct {
+	// Name is shown as the group's header in the workspace selector
+	Name string `toml:"name"`
+	// Paths are glob patterns of the group's directories, relative to p5.toml
+	// (e.g. "infra/*"). Workspaces in or below a matching directory belong to the group.
+	Paths []string `toml:"paths"`
+}
+
+// Validate checks that the group is named and its paths are valid patterns
+func (c *WorkspaceGroupConfig) Validate() error {
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(c.Paths) == 0 {
+		return errors.New("paths is required")
+	}
+	for _, pattern := range c.Paths {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Contains returns whether the workspace at path is in the group: in or below a
+// directory matching one of its absolute path patterns
+func (c *WorkspaceGroupConfig) Contains(path string) bool {
+	for dir := filepath.Clean(path); ; dir = filepath.Dir(dir) {
+		for _, pattern := range c.Paths {
+			if matched, _ := filepath.Match(pattern, dir); matched {
+				return true
+			}
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return false
+		}
+	}
+}
+
+// WorkspaceSearchConfig configures the search for Pulumi projects below the directory
+// p5 was started in
+type WorkspaceSearchConfig struct {
+	// MaxDepth is how many directories deep to search (default: the whole tree)
+	MaxDepth int `toml:"max_depth,omitempty"`
+	// Ignore are .gitignore-style patterns of directories to skip, e.g. "dist" or
+	// "examples/*". Hidden directories, node_modules, vendor and __pycache__ are
+	// always skipped.
+	Ignore []string `toml:"ignore,omitempty"`
+	// Parallelism is how many directories are read at once (default: the number of CPUs)
+	Parallelism int `toml:"parallelism,omitempty"`
+}
+
+// Validate checks that the search depth and parallelism are not negative
+func (c *WorkspaceSearchConfig) Validate() error {
+	if c.MaxDepth < 0 {
+		return fmt.Errorf("max_depth must not be negative, got %d", c.MaxDepth)
+	}
+	if c.Parallelism < 0 {
+		return fmt.Errorf("parallelism must not be negative, got %d", c.Parallelism)
+	}
+	return nil
+}
+
+// ThemeConfig selects a built-in theme with "name" and overrides individual colors
+// by their names (e.g. primary = "#ff79c6")
+type ThemeConfig map[string]string
+
+// Name returns the built-in theme to start from, empty for the default
+func (c ThemeConfig) Name() string {
+	return c["name"]
+}
+
+// Colors returns the color overrides by color name
+func (c ThemeConfig) Colors() map[string]string {
+	colors := make(map[string]string, len(c))
+	for k, v := range c {
+		if k != "name" {
+			colors[k] = v
+		}
+	}
+	return colors
+}
+
+// KeyList is the keys bound to an action, written as a single key or a list of keys
+type KeyList []string
+
+// UnmarshalTOML decodes a single key or a list of keys
+func (l *KeyList) UnmarshalTOML(data any) error {
+	switch v := data.(type) {
+	case string:
+		*l = KeyList{v}
+	case []any:
+		keys := make(KeyList, 0, len(v))
+		for _, item := range v {
+			k, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("expected key string, got %T", item)
+			}
+			keys = append(keys, k)
+		}
+		*l = keys
+	default:
+		return fmt.Errorf("expected key or list of keys, got %T", data)
+	}
+	return nil
+}
+
+// UnmarshalYAML decodes a single key or a list of keys
+func (l *KeyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = KeyList{node.Value}
+		return nil
+	}
+	var keys []string
+	if err := node.Decode(&keys); err != nil {
+		return fmt.Errorf("expected key or list of keys: %w", err)
+	}
+	*l = keys
+	return nil
+}
+
+// IdleLockConfig locks the TUI after inactivity, hiding stack details until a key
+// is pressed and, optionally, a passphrase is entered
+type IdleLockConfig struct {
+	// Timeout is how long p5 may be idle before locking, e.g. "10m"
+	Timeout string `toml:"timeout"`
+	// Stacks are the protected stacks, as patterns matched against the stack name
+	// (e.g. "prod" or "*-prod"). When empty, every stack is protected.
+	Stacks []string `toml:"stacks,omitempty"`
+	// PassphraseEnv names an environment variable holding a passphrase required to unlock
+	PassphraseEnv string `toml:"passphrase_env,omitempty"`
+}
+
+// GitGuardConfig asks for the stack name to be typed before up on matching stacks
+// when the workspace's git checkout is dirty or on another branch
+type GitGuardConfig struct {
+	// Stacks are the guarded stacks, as patterns matched against the stack name
+	// (e.g. "prod" or "*-prod"). When empty, every stack is guarded.
+	Stacks []string `yaml:"stacks,omitempty" toml:"stacks,omitempty"`
+	// Branch is the branch deploys are expected from (default: DefaultGitGuardBranch)
+	Branch string `yaml:"branch,omitempty" toml:"branch,omitempty"`
+	// AllowDirty doesn't warn about uncommitted changes (default: false)
+	AllowDirty bool `yaml:"allow_dirty,omitempty" toml:"allow_dirty,omitempty"`
+}
+
+// ProtectionLevel is how carefully changes to a stack are confirmed
+type ProtectionLevel string
+
+const (
+	// ProtectionNormal confirms up and destroy with a key press (default)
+	ProtectionNormal ProtectionLevel = "normal"
+	// ProtectionHigh requires the stack name to be typed before up and destroy
+	ProtectionHigh ProtectionLevel = "high"
+)
+
+// StackConfig is the configuration of the stacks matching a name or pattern
+type StackConfig struct {
+	// Protection is how up and destroy are confirmed (default: ProtectionNormal)
+	Protection ProtectionLevel `yaml:"protection,omitempty" toml:"protection,omitempty"`
+	// Confirm overrides when operations on the stacks ask for confirmation
+	Confirm *ConfirmConfig `yaml:"confirm,omitempty" toml:"confirm,omitempty"`
+}
+
+// Validate checks the protection level and confirmation modes
+func (c StackConfig) Validate() error {
+	switch c.Protection {
+	case "", ProtectionNormal, ProtectionHigh:
+	default:
+		return fmt.Errorf("protection must be %q or %q, got %q", ProtectionNormal, ProtectionHigh, c.Protection)
+	}
+	if c.Confirm != nil {
+		if err := c.Confirm.Validate(); err != nil {
+			return fmt.Errorf("confirm: %w", err)
+		}
+	}
+	return nil
+}
+
+// ConfirmMode is when an operation asks for confirmation before it runs
+type ConfirmMode string
+
+const (
+	// ConfirmUnpreviewed asks unless the operation is run from its own preview (default)
+	ConfirmUnpreviewed ConfirmMode = "unpreviewed"
+	// ConfirmAlways asks even after previewing the operation
+	ConfirmAlways ConfirmMode = "always"
+	// ConfirmNever runs the operation right away, previewed or not
+	ConfirmNever ConfirmMode = "never"
+)
+
+// strictness ranks confirmation modes, so the strictest of several matching stack
+// patterns applies
+func (m ConfirmMode) strictness() int {
+	switch m {
+	case ConfirmNever:
+		return 1
+	case ConfirmUnpreviewed:
+		return 2
+	case ConfirmAlways:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// ConfirmConfig is when up, refresh and destroy ask for confirmation. Typing the
+// name of a highly protected stack is still required whatever the mode.
+type ConfirmConfig struct {
+	Up      ConfirmMode `yaml:"up,omitempty" toml:"up,omitempty"`
+	Refresh ConfirmMode `yaml:"refresh,omitempty" toml:"refresh,omitempty"`
+	Destroy ConfirmMode `yaml:"destroy,omitempty" toml:"destroy,omitempty"`
+}
+
+// Validate checks the mode of each operation
+func (c ConfirmConfig) Validate() error {
+	for _, op := range []struct {
+		name string
+		mode ConfirmMode
+	}{{"up", c.Up}, {"refresh", c.Refresh}, {"destroy", c.Destroy}} {
+		switch op.mode {
+		case "", ConfirmUnpreviewed, ConfirmAlways, ConfirmNever:
+		default:
+			return fmt.Errorf("%s must be %q, %q or %q, got %q", op.name, ConfirmAlways, ConfirmUnpreviewed, ConfirmNever, op.mode)
+		}
+	}
+	return nil
+}
+
+// Mode returns when the operation named op ("up", "refresh" or "destroy") asks
+// for confirmation
+func (c ConfirmConfig) Mode(op string) ConfirmMode {
+	var mode ConfirmMode
+	switch op {
+	case "up":
+		mode = c.Up
+	case "refresh":
+		mode = c.Refresh
+	case "destroy":
+		mode = c.Destroy
+	}
+	if mode == "" {
+		return ConfirmUnpreviewed
+	}
+	return mode
+}
+
+// override returns c with the modes set in other replacing its own
+func (c ConfirmConfig) override(other ConfirmConfig) ConfirmConfig {
+	c.Up = cmp.Or(other.Up, c.Up)
+	c.Refresh = cmp.Or(other.Refresh, c.Refresh)
+	c.Destroy = cmp.Or(other.Destroy, c.Destroy)
+	return c
+}
+
+// strictest returns the strictest mode of each operation in c and other
+func (c ConfirmConfig) strictest(other ConfirmConfig) ConfirmConfig {
+	pick := func(a, b ConfirmMode) ConfirmMode {
+		if b.strictness() > a.strictness() {
+			return b
+		}
+		return a
+	}
+	return ConfirmConfig{Up: pick(c.Up, other.Up), Refresh: pick(c.Refresh, other.Refresh), Destroy: pick(c.Destroy, other.Destroy)}
+}
+
+// AutoApproveConfig approves running an operation from its finished preview
+// without confirmation when the preview changes little: by default only updates.
+// Larger changes are confirmed, the modal explaining which rule they broke.
+type AutoApproveConfig struct {
+	// Stacks auto-approved, by name or pattern (required)
+	Stacks []string `yaml:"stacks,omitempty" toml:"stacks,omitempty"`
+	// MaxChanges is the most resources the preview may change (default: no limit)
+	MaxChanges int `yaml:"max_changes,omitempty" toml:"max_changes,omitempty"`
+	// Creates, Deletes and Replaces allow previews creating, deleting or replacing resources
+	Creates  bool `yaml:"creates,omitempty" toml:"creates,omitempty"`
+	Deletes  bool `yaml:"deletes,omitempty" toml:"deletes,omitempty"`
+	Replaces bool `yaml:"replaces,omitempty" toml:"replaces,omitempty"`
+}
+
+// Approves reports whether changes to the stack may be auto-approved
+func (c *AutoApproveConfig) Approves(stackName string) bool {
+	return len(c.Stacks) > 0 && matchesStack(c.Stacks, stackName)
+}
+
+// Validate checks the stack patterns and the change limit
+func (c *AutoApproveConfig) Validate() error {
+	if len(c.Stacks) == 0 {
+		return errors.New("stacks is required")
+	}
+	if c.MaxChanges < 0 {
+		return fmt.Errorf("max_changes must not be negative, got %d", c.MaxChanges)
+	}
+	for _, pattern := range c.Stacks {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid stack pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// DefaultGitGuardBranch is the branch guarded stacks are expected to be deployed from
+const DefaultGitGuardBranch = "main"
+
+// Guards reports whether up on the stack is guarded
+func (c *GitGuardConfig) Guards(stackName string) bool {
+	return matchesStack(c.Stacks, stackName)
+}
+
+// ExpectedBranch returns the branch deploys are expected from
+func (c *GitGuardConfig) ExpectedBranch() string {
+	if c.Branch == "" {
+		return DefaultGitGuardBranch
+	}
+	return c.Branch
+}
+
+// Validate checks the stack patterns
+func (c *GitGuardConfig) Validate() error {
+	for _, pattern := range c.Stacks {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid stack pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// WorkflowConfig is a named sequence of operations defined in p5.toml
+type WorkflowConfig struct {
+	// Description is shown next to the workflow in the selector
+	Description string `toml:"description,omitempty"`
+	// Steps run in order; the workflow stops at the first step that fails or is declined
+	Steps []WorkflowStep `toml:"steps"`
+}
+
+// WorkflowStep is one operation of a workflow
+type WorkflowStep struct {
+	// Op is the operation to run: "up", "refresh" or "destroy"
+	Op string `toml:"op"`
+	// Preview only previews the operation instead of running it (default: false)
+	Preview bool `toml:"preview,omitempty"`
+	// Approve waits for the user to confirm the step before it starts (default: false)
+	Approve bool `toml:"approve,omitempty"`
+	// Targets, Replaces and Excludes are resource names or URNs. When any is set they
+	// replace the flags selected in p5 for this step.
+	Targets  []string `toml:"targets,omitempty"`
+	Replaces []string `toml:"replaces,omitempty"`
+	Excludes []string `toml:"excludes,omitempty"`
+}
+
+// HasFlags reports whether the step sets its own targets, replaces or excludes
+func (s WorkflowStep) HasFlags() bool {
+	return len(s.Targets) > 0 || len(s.Replaces) > 0 || len(s.Excludes) > 0
+}
+
+// Validate checks that the workflow has steps and that each names a known operation
+func (w WorkflowConfig) Validate() error {
+	if len(w.Steps) == 0 {
+		return errors.New("workflow has no steps")
+	}
+	for i, step := range w.Steps {
+		switch step.Op {
+		case "up", "refresh", "destroy":
+		default:
+			return fmt.Errorf("step %d: unknown op %q, expected up, refresh or destroy", i+1, step.Op)
+		}
+	}
+	return nil
+}
+
+// TimeoutDuration returns the idle time before locking
+func (c *IdleLockConfig) TimeoutDuration() time.Duration {
+	d, _ := time.ParseDuration(c.Timeout)
+	return d
+}
+
+// Protects reports whether the stack is locked when idle. Patterns match either
+// the full stack name or, for fully qualified names (org/project/stack), the stack alone.
+func (c *IdleLockConfig) Protects(stackName string) bool {
+	return matchesStack(c.Stacks, stackName)
+}
+
+// matchesStack reports whether any of patterns matches the stack, or true when
+// there are no patterns. Patterns match either the full stack name or, for fully
+// qualified names (org/project/stack), the stack alone.
+func matchesStack(patterns []string, stackName string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	short := stackName[strings.LastIndex(stackName, "/")+1:]
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, stackName); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, short); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Passphrase returns the passphrase required to unlock, or empty if none is configured
+func (c *IdleLockConfig) Passphrase() string {
+	if c.PassphraseEnv == "" {
+		return ""
+	}
+	return os.Getenv(c.PassphraseEnv)
+}
+
+// Validate checks the timeout, the stack patterns and that the passphrase is available
+func (c *IdleLockConfig) Validate() error {
+	d, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		return fmt.Errorf("invalid timeout %q: %w", c.Timeout, err)
+	}
+	if d <= 0 {
+		return fmt.Errorf("timeout must be positive, got %q", c.Timeout)
+	}
+	for _, pattern := range c.Stacks {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid stack pattern %q: %w", pattern, err)
+		}
+	}
+	if c.PassphraseEnv != "" && c.Passphrase() == "" {
+		return fmt.Errorf("passphrase_env %s is not set", c.PassphraseEnv)
+	}
+	return nil
 }
 
 // LoadGlobalConfig loads p5.toml from either git root or launch directory
@@ -247,11 +783,58 @@ func MergeConfigs(global *GlobalConfig, program *P5Config) *P5Config {
 	}
 	if global == nil || len(global.Plugins) == 0 {
 		// Still need to handle order even if no global plugins
+		if global != nil && program.Artifacts == nil {
+			program.Artifacts = global.Artifacts
+		}
+		if global != nil && program.PersistFlags == nil {
+			program.PersistFlags = global.PersistFlags
+		}
+		if global != nil && program.GitGuard == nil {
+			program.GitGuard = global.GitGuard
+		}
+		if global != nil && program.Confirm == nil {
+			program.Confirm = global.Confirm
+		}
+		if global != nil && program.AutoApprove == nil {
+			program.AutoApprove = global.AutoApprove
+		}
+		if global != nil && len(global.Stacks) > 0 {
+			stacks := maps.Clone(global.Stacks)
+			maps.Copy(stacks, program.Stacks)
+			program.Stacks = stacks
+		}
 		return program
 	}
 
 	merged := &P5Config{
-		Plugins: make(map[string]PluginConfig),
+		Plugins:      make(map[string]PluginConfig),
+		Artifacts:    program.Artifacts,
+		PersistFlags: program.PersistFlags,
+		GitGuard:     program.GitGuard,
+		Confirm:      program.Confirm,
+		AutoApprove:  program.AutoApprove,
+		Stacks:       maps.Clone(global.Stacks),
+	}
+	if merged.Artifacts == nil {
+		merged.Artifacts = global.Artifacts
+	}
+	if merged.PersistFlags == nil {
+		merged.PersistFlags = global.PersistFlags
+	}
+	if merged.GitGuard == nil {
+		merged.GitGuard = global.GitGuard
+	}
+	if merged.Confirm == nil {
+		merged.Confirm = global.Confirm
+	}
+	if merged.AutoApprove == nil {
+		merged.AutoApprove = global.AutoApprove
+	}
+	if len(program.Stacks) > 0 {
+		if merged.Stacks == nil {
+			merged.Stacks = make(map[string]StackConfig, len(program.Stacks))
+		}
+		maps.Copy(merged.Stacks, program.Stacks)
 	}
 
 	// Start with global config
@@ -276,6 +859,343 @@ func MergeConfigs(global *GlobalConfig, program *P5Config) *P5Config {
 	return merged
 }
 
+// LoadArtifactsConfig loads the artifacts configuration for the project in workDir.
+// Pulumi.yaml takes precedence over p5.toml. Returns nil when neither configures artifacts.
+func LoadArtifactsConfig(workDir string) (*ArtifactsConfig, error) {
+	config, err := loadProjectConfig(workDir)
+	if err != nil {
+		return nil, err
+	}
+	return config.Artifacts, nil
+}
+
+// LoadPersistFlags reports whether resource flags should be saved for the project in workDir.
+// Pulumi.yaml takes precedence over p5.toml.
+func LoadPersistFlags(workDir string) (bool, error) {
+	config, err := loadProjectConfig(workDir)
+	if err != nil {
+		return false, err
+	}
+	return config.PersistFlags != nil && *config.PersistFlags, nil
+}
+
+// LoadGitGuard loads the git guard for up in the project in workDir. Pulumi.yaml
+// takes precedence over p5.toml. Returns nil when neither configures one.
+func LoadGitGuard(workDir string) (*GitGuardConfig, error) {
+	config, err := loadProjectConfig(workDir)
+	if err != nil {
+		return nil, err
+	}
+	if config.GitGuard == nil {
+		return nil, nil
+	}
+	if err := config.GitGuard.Validate(); err != nil {
+		return nil, fmt.Errorf("git_guard: %w", err)
+	}
+	return config.GitGuard, nil
+}
+
+// LoadStackProtection loads the protection level of the stack in the project in
+// workDir. Stacks are configured by name or pattern, with Pulumi.yaml overriding
+// p5.toml per pattern. When several patterns match, the highest level applies.
+func LoadStackProtection(workDir, stackName string) (ProtectionLevel, error) {
+	config, err := loadProjectConfig(workDir)
+	if err != nil {
+		return "", err
+	}
+	level := ProtectionNormal
+	for pattern, stack := range config.Stacks {
+		if err := stack.Validate(); err != nil {
+			return "", fmt.Errorf("stacks.%s: %w", pattern, err)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return "", fmt.Errorf("invalid stack pattern %q: %w", pattern, err)
+		}
+		if stack.Protection == ProtectionHigh && matchesStack([]string{pattern}, stackName) {
+			level = ProtectionHigh
+		}
+	}
+	return level, nil
+}
+
+// LoadStackConfirm loads when operations on the stack in the project in workDir
+// ask for confirmation. Modes set for the stack's patterns override the top-level
+// confirm table, with Pulumi.yaml overriding p5.toml. When several patterns set an
+// operation's mode, the strictest applies.
+func LoadStackConfirm(workDir, stackName string) (ConfirmConfig, error) {
+	config, err := loadProjectConfig(workDir)
+	if err != nil {
+		return ConfirmConfig{}, err
+	}
+	var confirm ConfirmConfig
+	if config.Confirm != nil {
+		if err := config.Confirm.Validate(); err != nil {
+			return ConfirmConfig{}, fmt.Errorf("confirm: %w", err)
+		}
+		confirm = *config.Confirm
+	}
+	var stackConfirm ConfirmConfig
+	for pattern, stack := range config.Stacks {
+		if err := stack.Validate(); err != nil {
+			return ConfirmConfig{}, fmt.Errorf("stacks.%s: %w", pattern, err)
+		}
+		if stack.Confirm != nil && matchesStack([]string{pattern}, stackName) {
+			stackConfirm = stackConfirm.strictest(*stack.Confirm)
+		}
+	}
+	return confirm.override(stackConfirm), nil
+}
+
+// LoadAutoApprove loads the auto-approval of small changes configured for the
+// stack in the project in workDir, or nil when its changes aren't auto-approved
+func LoadAutoApprove(workDir, stackName string) (*AutoApproveConfig, error) {
+	config, err := loadProjectConfig(workDir)
+	if err != nil {
+		return nil, err
+	}
+	if config.AutoApprove == nil {
+		return nil, nil
+	}
+	if err := config.AutoApprove.Validate(); err != nil {
+		return nil, fmt.Errorf("auto_approve: %w", err)
+	}
+	if !config.AutoApprove.Approves(stackName) {
+		return nil, nil
+	}
+	return config.AutoApprove, nil
+}
+
+// LoadFuzzyFilter reports whether list filters match fuzzily for the project in workDir
+func LoadFuzzyFilter(workDir string) (bool, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to load global config: %w", err)
+	}
+	return global.FuzzyFilter, nil
+}
+
+// LoadClipboard returns how copied text reaches the clipboard for the project in workDir
+func LoadClipboard(workDir string) (string, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to load global config: %w", err)
+	}
+	return global.Clipboard, nil
+}
+
+// LoadRefreshOnUp reports whether up refreshes the state by default for the project in workDir
+func LoadRefreshOnUp(workDir string) (bool, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to load global config: %w", err)
+	}
+	return global.RefreshOnUp, nil
+}
+
+// LoadUpdateMessage reports whether up and destroy ask for an update message for
+// the project in workDir
+func LoadUpdateMessage(workDir string) (bool, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to load global config: %w", err)
+	}
+	return global.UpdateMessage, nil
+}
+
+// LoadMetrics reports whether metrics are exported for the project in workDir
+func LoadMetrics(workDir string) (bool, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to load global config: %w", err)
+	}
+	return global.Metrics, nil
+}
+
+// loadProjectConfig loads p5.toml and the project's Pulumi.yaml and merges them
+func loadProjectConfig(workDir string) (*P5Config, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load global config: %w", err)
+	}
+	program, err := LoadP5Config(filepath.Join(workDir, "Pulumi.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load p5 config: %w", err)
+	}
+	return MergeConfigs(global, program), nil
+}
+
+// LoadWorkflows loads the workflows defined in p5.toml for the project in workDir
+func LoadWorkflows(workDir string) (map[string]WorkflowConfig, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load global config: %w", err)
+	}
+	for name, workflow := range global.Workflows {
+		if err := workflow.Validate(); err != nil {
+			return nil, fmt.Errorf("workflow %q: %w", name, err)
+		}
+	}
+	return global.Workflows, nil
+}
+
+// LoadKeyBindings loads the keybindings configured for the project in workDir, by
+// action name. Actions remapped in Pulumi.yaml replace those remapped in p5.toml.
+func LoadKeyBindings(workDir string) (map[string][]string, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load global config: %w", err)
+	}
+	program, err := LoadP5Config(filepath.Join(workDir, "Pulumi.yaml"))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to load p5 config: %w", err)
+		}
+		program = &P5Config{}
+	}
+
+	bindings := make(map[string][]string, len(global.Keys)+len(program.Keys))
+	for action, keys := range global.Keys {
+		bindings[action] = keys
+	}
+	for action, keys := range program.Keys {
+		bindings[action] = keys
+	}
+	return bindings, nil
+}
+
+// LoadDiffIgnore loads the property paths de-emphasized in diffs for the project in
+// workDir, by resource type pattern. Patterns set in Pulumi.yaml replace those set
+// in p5.toml.
+func LoadDiffIgnore(workDir string) (map[string][]string, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load global config: %w", err)
+	}
+	program, err := LoadP5Config(filepath.Join(workDir, "Pulumi.yaml"))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to load p5 config: %w", err)
+		}
+		program = &P5Config{}
+	}
+
+	rules := maps.Clone(global.DiffIgnore)
+	if rules == nil {
+		rules = make(map[string][]string, len(program.DiffIgnore))
+	}
+	maps.Copy(rules, program.DiffIgnore)
+	for pattern, paths := range rules {
+		if pattern == "" {
+			return nil, errors.New("diff_ignore: empty resource type pattern")
+		}
+		if slices.Contains(paths, "") {
+			return nil, fmt.Errorf("diff_ignore.%s: empty property path", pattern)
+		}
+	}
+	return rules, nil
+}
+
+// LoadIdleLockConfig loads the idle lock configured in p5.toml for the project in
+// workDir. Returns nil when idle locking is not configured.
+func LoadIdleLockConfig(workDir string) (*IdleLockConfig, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load global config: %w", err)
+	}
+	if global.IdleLock == nil {
+		return nil, nil
+	}
+	if err := global.IdleLock.Validate(); err != nil {
+		return nil, fmt.Errorf("idle_lock: %w", err)
+	}
+	return global.IdleLock, nil
+}
+
+// LoadWorkspaceSearch loads the workspace search configured in p5.toml for the project
+// in workDir. Returns an empty config, searching the whole tree, when not configured.
+func LoadWorkspaceSearch(workDir string) (*WorkspaceSearchConfig, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load global config: %w", err)
+	}
+	if global.WorkspaceSearch == nil {
+		return &WorkspaceSearchConfig{}, nil
+	}
+	if err := global.WorkspaceSearch.Validate(); err != nil {
+		return nil, fmt.Errorf("workspace_search: %w", err)
+	}
+	return global.WorkspaceSearch, nil
+}
+
+// LoadWorkspaceGroups loads the workspace groups defined in p5.toml for the project
+// in workDir, in the order they are defined, with their path patterns made absolute
+func LoadWorkspaceGroups(workDir string) ([]WorkspaceGroupConfig, error) {
+	global, configPath, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load global config: %w", err)
+	}
+	groups := make([]WorkspaceGroupConfig, 0, len(global.WorkspaceGroups))
+	for i, group := range global.WorkspaceGroups {
+		if err := group.Validate(); err != nil {
+			return nil, fmt.Errorf("workspace_groups[%d]: %w", i, err)
+		}
+		paths := make([]string, len(group.Paths))
+		for j, pattern := range group.Paths {
+			paths[j] = filepath.Join(filepath.Dir(configPath), pattern)
+		}
+		groups = append(groups, WorkspaceGroupConfig{Name: group.Name, Paths: paths})
+	}
+	return groups, nil
+}
+
+// MinPollInterval is the shortest poll_interval, to keep polling off the backend's rate limits
+const MinPollInterval = 10 * time.Second
+
+// LoadPollInterval loads how often the stack is checked for updates made elsewhere,
+// from p5.toml for the project in workDir. Returns 0 when polling is not configured.
+func LoadPollInterval(workDir string) (time.Duration, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load global config: %w", err)
+	}
+	if global.PollInterval == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(global.PollInterval)
+	if err != nil {
+		return 0, fmt.Errorf("poll_interval: invalid duration %q: %w", global.PollInterval, err)
+	}
+	if d < MinPollInterval {
+		return 0, fmt.Errorf("poll_interval must be at least %s, got %q", MinPollInterval, global.PollInterval)
+	}
+	return d, nil
+}
+
+// LoadHistoryPageSize loads how many updates the history view loads at a time,
+// from p5.toml for the project in workDir. Returns 0 when not configured.
+func LoadHistoryPageSize(workDir string) (int, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load global config: %w", err)
+	}
+	if global.HistoryPageSize < 0 {
+		return 0, fmt.Errorf("history_page_size must be positive, got %d", global.HistoryPageSize)
+	}
+	return global.HistoryPageSize, nil
+}
+
+// ArtifactsDir returns the directory run directories are created in for a project
+func (c *ArtifactsConfig) ArtifactsDir(workDir string) string {
+	dir := DefaultArtifactsDir
+	if c != nil && c.Dir != "" {
+		dir = c.Dir
+	}
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(workDir, dir)
+}
+
 // GetOrderedPluginNames returns plugin names in execution order.
 // Plugins specified in Order come first (in that order), followed by
 // any remaining plugins not in the order list (in non-deterministic order).
@@ -329,5 +1249,11 @@ func mergePluginConfig(base, override PluginConfig) PluginConfig {
 	if override.ResourceOpener {
 		base.ResourceOpener = override.ResourceOpener
 	}
+	if override.PostOperation {
+		base.PostOperation = override.PostOperation
+	}
+	if override.StatusBadge {
+		base.StatusBadge = override.StatusBadge
+	}
 	return base
 }
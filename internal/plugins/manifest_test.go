@@ -3,7 +3,9 @@ package plugins
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 )
 
 // TestMergeConfigs_GlobalOnly verifies merging with only global config.
@@ -876,3 +878,634 @@ func TestMergeConfigs_NoOrder(t *testing.T) {
 		t.Errorf("expected empty order, got %v", result.Order)
 	}
 }
+
+// Artifacts Config Tests
+
+// TestMergeConfigs_Artifacts verifies program artifacts config overrides global.
+func TestMergeConfigs_Artifacts(t *testing.T) {
+	global := &GlobalConfig{Artifacts: &ArtifactsConfig{Enabled: true}}
+
+	result := MergeConfigs(global, &P5Config{})
+	if result.Artifacts == nil || !result.Artifacts.Enabled {
+		t.Errorf("expected global artifacts config, got %+v", result.Artifacts)
+	}
+
+	global.Plugins = map[string]PluginConfig{"aws": {Cmd: "/aws"}}
+	result = MergeConfigs(global, &P5Config{Artifacts: &ArtifactsConfig{Enabled: false, Dir: "runs"}})
+	if result.Artifacts == nil || result.Artifacts.Enabled || result.Artifacts.Dir != "runs" {
+		t.Errorf("expected program artifacts config, got %+v", result.Artifacts)
+	}
+}
+
+// TestLoadArtifactsConfig verifies loading artifacts config from Pulumi.yaml and p5.toml.
+func TestLoadArtifactsConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\n")
+	cfg, err := LoadArtifactsConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg != nil {
+		t.Errorf("expected no artifacts config, got %+v", cfg)
+	}
+	if got := cfg.ArtifactsDir(tmpDir); got != filepath.Join(tmpDir, DefaultArtifactsDir) {
+		t.Errorf("expected default dir, got %q", got)
+	}
+
+	write("p5.toml", "[artifacts]\nenabled = true\n")
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  artifacts:\n    enabled: true\n    dir: build/runs\n")
+	cfg, err = LoadArtifactsConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg == nil || !cfg.Enabled {
+		t.Fatalf("expected artifacts to be enabled, got %+v", cfg)
+	}
+	if got := cfg.ArtifactsDir(tmpDir); got != filepath.Join(tmpDir, "build/runs") {
+		t.Errorf("expected project dir, got %q", got)
+	}
+}
+
+// TestLoadPersistFlags verifies Pulumi.yaml overrides the p5.toml persist_flags setting.
+func TestLoadPersistFlags(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\n")
+	if persist, err := LoadPersistFlags(tmpDir); err != nil || persist {
+		t.Errorf("expected flags not to persist by default, got %v, %v", persist, err)
+	}
+
+	write("p5.toml", "persist_flags = true\n")
+	if persist, err := LoadPersistFlags(tmpDir); err != nil || !persist {
+		t.Errorf("expected p5.toml to enable persisting flags, got %v, %v", persist, err)
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  persist_flags: false\n")
+	if persist, err := LoadPersistFlags(tmpDir); err != nil || persist {
+		t.Errorf("expected Pulumi.yaml to disable persisting flags, got %v, %v", persist, err)
+	}
+}
+
+// TestLoadFuzzyFilter verifies fuzzy filtering is off unless enabled in p5.toml.
+func TestLoadFuzzyFilter(t *testing.T) {
+	tmpDir := t.TempDir()
+	if fuzzy, err := LoadFuzzyFilter(tmpDir); err != nil || fuzzy {
+		t.Errorf("expected substring filtering by default, got %v, %v", fuzzy, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "p5.toml"), []byte("fuzzy_filter = true\n"), 0o600); err != nil {
+		t.Fatalf("failed to create p5.toml: %v", err)
+	}
+	if fuzzy, err := LoadFuzzyFilter(tmpDir); err != nil || !fuzzy {
+		t.Errorf("expected p5.toml to enable fuzzy filtering, got %v, %v", fuzzy, err)
+	}
+}
+
+func TestLoadClipboard(t *testing.T) {
+	tmpDir := t.TempDir()
+	if mode, err := LoadClipboard(tmpDir); err != nil || mode != "" {
+		t.Errorf("expected no clipboard mode by default, got %q, %v", mode, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "p5.toml"), []byte("clipboard = \"osc52\"\n"), 0o600); err != nil {
+		t.Fatalf("failed to create p5.toml: %v", err)
+	}
+	if mode, err := LoadClipboard(tmpDir); err != nil || mode != "osc52" {
+		t.Errorf("expected p5.toml to set the clipboard mode, got %q, %v", mode, err)
+	}
+}
+
+func TestLoadRefreshOnUp(t *testing.T) {
+	tmpDir := t.TempDir()
+	if refresh, err := LoadRefreshOnUp(tmpDir); err != nil || refresh {
+		t.Errorf("expected up not to refresh by default, got %v, %v", refresh, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "p5.toml"), []byte("refresh_on_up = true\n"), 0o600); err != nil {
+		t.Fatalf("failed to create p5.toml: %v", err)
+	}
+	if refresh, err := LoadRefreshOnUp(tmpDir); err != nil || !refresh {
+		t.Errorf("expected p5.toml to enable refresh on up, got %v, %v", refresh, err)
+	}
+}
+
+func TestLoadUpdateMessage(t *testing.T) {
+	tmpDir := t.TempDir()
+	if prompt, err := LoadUpdateMessage(tmpDir); err != nil || prompt {
+		t.Errorf("expected no update message prompt by default, got %v, %v", prompt, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "p5.toml"), []byte("update_message = true\n"), 0o600); err != nil {
+		t.Fatalf("failed to create p5.toml: %v", err)
+	}
+	if prompt, err := LoadUpdateMessage(tmpDir); err != nil || !prompt {
+		t.Errorf("expected p5.toml to enable the update message prompt, got %v, %v", prompt, err)
+	}
+}
+
+// TestLoadGitGuard verifies the guard from p5.toml is overridden by Pulumi.yaml and
+// its stack patterns are validated
+func TestLoadGitGuard(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\n")
+	if guard, err := LoadGitGuard(tmpDir); err != nil || guard != nil {
+		t.Errorf("expected no guard without config, got %v, %v", guard, err)
+	}
+
+	write("p5.toml", "[git_guard]\nstacks = [\"prod\"]\n")
+	guard, err := LoadGitGuard(tmpDir)
+	if err != nil || guard == nil || !guard.Guards("org/app/prod") || guard.Guards("dev") || guard.ExpectedBranch() != DefaultGitGuardBranch {
+		t.Errorf("expected p5.toml to guard prod from main, got %+v, %v", guard, err)
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  git_guard:\n    branch: release\n    allow_dirty: true\n")
+	guard, err = LoadGitGuard(tmpDir)
+	if err != nil || guard == nil || !guard.Guards("dev") || guard.ExpectedBranch() != "release" || !guard.AllowDirty {
+		t.Errorf("expected Pulumi.yaml to guard every stack from release, got %+v, %v", guard, err)
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  git_guard:\n    stacks: [\"[prod\"]\n")
+	if _, err := LoadGitGuard(tmpDir); err == nil {
+		t.Error("expected an invalid stack pattern to fail")
+	}
+}
+
+// TestLoadStackProtection verifies stacks are protected by name or pattern, with
+// Pulumi.yaml overriding p5.toml per pattern
+func TestLoadStackProtection(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\n")
+	if level, err := LoadStackProtection(tmpDir, "prod"); err != nil || level != ProtectionNormal {
+		t.Errorf("expected normal protection by default, got %q, %v", level, err)
+	}
+
+	write("p5.toml", "[stacks.prod]\nprotection = \"high\"\n\n[stacks.\"*-prod\"]\nprotection = \"high\"\n")
+	for stack, want := range map[string]ProtectionLevel{
+		"prod":         ProtectionHigh,
+		"org/app/prod": ProtectionHigh,
+		"eu-prod":      ProtectionHigh,
+		"dev":          ProtectionNormal,
+	} {
+		if level, err := LoadStackProtection(tmpDir, stack); err != nil || level != want {
+			t.Errorf("expected %s to have %q protection, got %q, %v", stack, want, level, err)
+		}
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  stacks:\n    prod:\n      protection: normal\n")
+	if level, err := LoadStackProtection(tmpDir, "prod"); err != nil || level != ProtectionNormal {
+		t.Errorf("expected Pulumi.yaml to lower prod's protection, got %q, %v", level, err)
+	}
+	if level, err := LoadStackProtection(tmpDir, "eu-prod"); err != nil || level != ProtectionHigh {
+		t.Errorf("expected p5.toml patterns to still apply, got %q, %v", level, err)
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  stacks:\n    prod:\n      protection: extreme\n")
+	if _, err := LoadStackProtection(tmpDir, "prod"); err == nil {
+		t.Error("expected an unknown protection level to fail")
+	}
+}
+
+// TestLoadStackConfirm verifies stack patterns override the top-level confirm
+// table per operation, the strictest matching pattern winning.
+func TestLoadStackConfirm(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\n")
+	confirm, err := LoadStackConfirm(tmpDir, "dev")
+	if err != nil || confirm.Mode("up") != ConfirmUnpreviewed || confirm.Mode("destroy") != ConfirmUnpreviewed {
+		t.Errorf("expected unpreviewed operations to be confirmed by default, got %+v, %v", confirm, err)
+	}
+
+	write("p5.toml", `[confirm]
+up = "never"
+refresh = "never"
+destroy = "always"
+
+[stacks."*prod".confirm]
+up = "unpreviewed"
+
+[stacks.prod.confirm]
+up = "always"
+`)
+	for stack, want := range map[string]ConfirmConfig{
+		"dev":     {Up: ConfirmNever, Refresh: ConfirmNever, Destroy: ConfirmAlways},
+		"eu-prod": {Up: ConfirmUnpreviewed, Refresh: ConfirmNever, Destroy: ConfirmAlways},
+		"prod":    {Up: ConfirmAlways, Refresh: ConfirmNever, Destroy: ConfirmAlways},
+	} {
+		if got, err := LoadStackConfirm(tmpDir, stack); err != nil || got != want {
+			t.Errorf("expected %s to confirm %+v, got %+v, %v", stack, want, got, err)
+		}
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  confirm:\n    refresh: always\n")
+	if got, err := LoadStackConfirm(tmpDir, "dev"); err != nil || got.Mode("refresh") != ConfirmAlways || got.Mode("up") != ConfirmUnpreviewed {
+		t.Errorf("expected Pulumi.yaml's confirm table to replace p5.toml's, got %+v, %v", got, err)
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  stacks:\n    prod:\n      confirm:\n        up: sometimes\n")
+	if _, err := LoadStackConfirm(tmpDir, "dev"); err == nil {
+		t.Error("expected an unknown confirmation mode to fail")
+	}
+}
+
+// TestLoadAutoApprove verifies auto-approval applies only to the stacks it lists,
+// with Pulumi.yaml replacing p5.toml's rules.
+func TestLoadAutoApprove(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\n")
+	if rule, err := LoadAutoApprove(tmpDir, "dev"); err != nil || rule != nil {
+		t.Errorf("expected no auto-approval by default, got %+v, %v", rule, err)
+	}
+
+	write("p5.toml", "[auto_approve]\nstacks = [\"dev\", \"*-staging\"]\nmax_changes = 5\ncreates = true\n")
+	rule, err := LoadAutoApprove(tmpDir, "org/app/eu-staging")
+	if err != nil || rule == nil || rule.MaxChanges != 5 || !rule.Creates || rule.Deletes {
+		t.Errorf("expected p5.toml's rules for a matching stack, got %+v, %v", rule, err)
+	}
+	if rule, err := LoadAutoApprove(tmpDir, "prod"); err != nil || rule != nil {
+		t.Errorf("expected no auto-approval for an unlisted stack, got %+v, %v", rule, err)
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  auto_approve:\n    stacks: [prod]\n    replaces: true\n")
+	if rule, err := LoadAutoApprove(tmpDir, "prod"); err != nil || rule == nil || !rule.Replaces || rule.MaxChanges != 0 {
+		t.Errorf("expected Pulumi.yaml's rules to replace p5.toml's, got %+v, %v", rule, err)
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  auto_approve:\n    stacks: [dev]\n    max_changes: -1\n")
+	if _, err := LoadAutoApprove(tmpDir, "dev"); err == nil {
+		t.Error("expected a negative change limit to fail")
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  auto_approve:\n    max_changes: 3\n")
+	if _, err := LoadAutoApprove(tmpDir, "dev"); err == nil {
+		t.Error("expected auto-approval without stacks to fail")
+	}
+}
+
+func TestLoadMetrics(t *testing.T) {
+	tmpDir := t.TempDir()
+	if enabled, err := LoadMetrics(tmpDir); err != nil || enabled {
+		t.Errorf("expected metrics to be off by default, got %v, %v", enabled, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "p5.toml"), []byte("metrics = true\n"), 0o600); err != nil {
+		t.Fatalf("failed to create p5.toml: %v", err)
+	}
+	if enabled, err := LoadMetrics(tmpDir); err != nil || !enabled {
+		t.Errorf("expected p5.toml to enable metrics, got %v, %v", enabled, err)
+	}
+}
+
+// TestLoadKeyBindings verifies keys from p5.toml are overridden per action by Pulumi.yaml,
+// and that a single key or a list of keys can be given.
+func TestLoadKeyBindings(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	if bindings, err := LoadKeyBindings(tmpDir); err != nil || len(bindings) != 0 {
+		t.Errorf("expected no bindings without config, got %v, %v", bindings, err)
+	}
+
+	write("p5.toml", "[keys]\npreview_up = \"U\"\nup = [\"up\", \"i\"]\n")
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  keys:\n    preview_up: [\"ctrl+p\", \"u\"]\n")
+	bindings, err := LoadKeyBindings(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]string{
+		"preview_up": {"ctrl+p", "u"},
+		"up":         {"up", "i"},
+	}
+	if !reflect.DeepEqual(bindings, want) {
+		t.Errorf("expected %v, got %v", want, bindings)
+	}
+
+	write("p5.toml", "[keys]\nup = 1\n")
+	if _, err := LoadKeyBindings(tmpDir); err == nil {
+		t.Error("expected an error for a key that isn't a string")
+	}
+}
+
+func TestLoadDiffIgnore(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	if rules, err := LoadDiffIgnore(tmpDir); err != nil || len(rules) != 0 {
+		t.Errorf("expected no rules without config, got %v, %v", rules, err)
+	}
+
+	write("p5.toml", "[diff_ignore]\n\"kubernetes:*\" = [\"metadata.annotations\"]\n\"*\" = [\"tags.aws:*\"]\n")
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  diff_ignore:\n    \"*\": [\"tags.managed-by\"]\n")
+	rules, err := LoadDiffIgnore(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]string{
+		"kubernetes:*": {"metadata.annotations"},
+		"*":            {"tags.managed-by"},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("expected %v, got %v", want, rules)
+	}
+
+	write("Pulumi.yaml", "name: test\nruntime: go\np5:\n  diff_ignore:\n    \"*\": [\"\"]\n")
+	if _, err := LoadDiffIgnore(tmpDir); err == nil {
+		t.Error("expected an error for an empty property path")
+	}
+}
+
+// TestLoadGlobalConfig_Theme verifies the theme name is separated from color overrides.
+func TestLoadGlobalConfig_Theme(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := "[theme]\nname = \"solarized\"\nprimary = \"#ff79c6\"\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "p5.toml"), []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to create p5.toml: %v", err)
+	}
+
+	config, _, err := LoadGlobalConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Theme.Name() != "solarized" {
+		t.Errorf("expected theme solarized, got %q", config.Theme.Name())
+	}
+	if want := map[string]string{"primary": "#ff79c6"}; !reflect.DeepEqual(config.Theme.Colors(), want) {
+		t.Errorf("expected colors %v, got %v", want, config.Theme.Colors())
+	}
+}
+
+// TestLoadWorkflows verifies workflows are read from p5.toml and validated.
+func TestLoadWorkflows(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, "p5.toml"), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create p5.toml: %v", err)
+		}
+	}
+
+	if workflows, err := LoadWorkflows(tmpDir); err != nil || len(workflows) != 0 {
+		t.Errorf("expected no workflows without p5.toml, got %v, %v", workflows, err)
+	}
+
+	write(`
+[workflows.deploy]
+description = "Refresh and deploy"
+
+[[workflows.deploy.steps]]
+op = "refresh"
+
+[[workflows.deploy.steps]]
+op = "up"
+preview = true
+targets = ["api"]
+
+[[workflows.deploy.steps]]
+op = "up"
+approve = true
+targets = ["api"]
+`)
+	workflows, err := LoadWorkflows(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deploy, ok := workflows["deploy"]
+	if !ok || deploy.Description != "Refresh and deploy" || len(deploy.Steps) != 3 {
+		t.Fatalf("unexpected workflows: %+v", workflows)
+	}
+	if step := deploy.Steps[1]; step.Op != "up" || !step.Preview || step.Approve || !step.HasFlags() {
+		t.Errorf("unexpected preview step: %+v", step)
+	}
+	if step := deploy.Steps[2]; !step.Approve || step.Preview {
+		t.Errorf("unexpected up step: %+v", step)
+	}
+
+	write("[workflows.broken]\nsteps = [{ op = \"deploy\" }]\n")
+	if _, err := LoadWorkflows(tmpDir); err == nil {
+		t.Error("expected an error for an unknown op")
+	}
+
+	write("[workflows.empty]\ndescription = \"nothing\"\n")
+	if _, err := LoadWorkflows(tmpDir); err == nil {
+		t.Error("expected an error for a workflow without steps")
+	}
+}
+
+func TestLoadIdleLockConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, "p5.toml"), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create p5.toml: %v", err)
+		}
+	}
+
+	if cfg, err := LoadIdleLockConfig(tmpDir); err != nil || cfg != nil {
+		t.Errorf("expected no idle lock without p5.toml, got %v, %v", cfg, err)
+	}
+
+	t.Setenv("P5_TEST_LOCK_PASSPHRASE", "hunter2")
+	write(`
+[idle_lock]
+timeout = "10m"
+stacks = ["prod", "*-prod"]
+passphrase_env = "P5_TEST_LOCK_PASSPHRASE"
+`)
+	cfg, err := LoadIdleLockConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TimeoutDuration().Minutes() != 10 || cfg.Passphrase() != "hunter2" {
+		t.Errorf("unexpected idle lock: %+v", cfg)
+	}
+	for stack, want := range map[string]bool{
+		"prod":          true,
+		"acme/app/prod": true,
+		"eu-prod":       true,
+		"dev":           false,
+		"prod-preview":  false,
+	} {
+		if got := cfg.Protects(stack); got != want {
+			t.Errorf("Protects(%q) = %v, want %v", stack, got, want)
+		}
+	}
+	if !(&IdleLockConfig{Timeout: "1m"}).Protects("dev") {
+		t.Error("expected every stack to be protected without patterns")
+	}
+
+	for _, content := range []string{
+		"[idle_lock]\ntimeout = \"soon\"\n",
+		"[idle_lock]\ntimeout = \"0s\"\n",
+		"[idle_lock]\ntimeout = \"5m\"\nstacks = [\"[prod\"]\n",
+		"[idle_lock]\ntimeout = \"5m\"\npassphrase_env = \"P5_TEST_LOCK_UNSET\"\n",
+	} {
+		write(content)
+		if _, err := LoadIdleLockConfig(tmpDir); err == nil {
+			t.Errorf("expected an error for %q", content)
+		}
+	}
+}
+
+func TestLoadPollInterval(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, "p5.toml"), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create p5.toml: %v", err)
+		}
+	}
+
+	if d, err := LoadPollInterval(tmpDir); err != nil || d != 0 {
+		t.Errorf("expected polling disabled without p5.toml, got %v, %v", d, err)
+	}
+
+	write(`poll_interval = "1m"` + "\n")
+	if d, err := LoadPollInterval(tmpDir); err != nil || d != time.Minute {
+		t.Errorf("expected 1m, got %v, %v", d, err)
+	}
+
+	for _, content := range []string{
+		"poll_interval = \"often\"\n",
+		"poll_interval = \"1s\"\n",
+	} {
+		write(content)
+		if _, err := LoadPollInterval(tmpDir); err == nil {
+			t.Errorf("expected an error for %q", content)
+		}
+	}
+}
+
+// TestLoadWorkspaceSearch verifies loading and validating the workspace search options.
+func TestLoadWorkspaceSearch(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, "p5.toml"), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create p5.toml: %v", err)
+		}
+	}
+
+	if cfg, err := LoadWorkspaceSearch(tmpDir); err != nil || cfg.MaxDepth != 0 || len(cfg.Ignore) != 0 {
+		t.Errorf("expected an unlimited search without p5.toml, got %+v, %v", cfg, err)
+	}
+
+	write(`
+[workspace_search]
+max_depth = 3
+ignore = ["dist", "examples/*"]
+parallelism = 4
+`)
+	cfg, err := LoadWorkspaceSearch(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MaxDepth != 3 || cfg.Parallelism != 4 || len(cfg.Ignore) != 2 || cfg.Ignore[1] != "examples/*" {
+		t.Errorf("unexpected workspace search: %+v", cfg)
+	}
+
+	for _, content := range []string{
+		"[workspace_search]\nmax_depth = -1\n",
+		"[workspace_search]\nparallelism = -2\n",
+	} {
+		write(content)
+		if _, err := LoadWorkspaceSearch(tmpDir); err == nil {
+			t.Errorf("expected an error for %q", content)
+		}
+	}
+}
+
+// TestLoadWorkspaceGroups verifies groups keep their order and their paths are made
+// relative to p5.toml.
+func TestLoadWorkspaceGroups(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, "p5.toml"), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create p5.toml: %v", err)
+		}
+	}
+
+	write(`
+[[workspace_groups]]
+name = "platform"
+paths = ["infra"]
+
+[[workspace_groups]]
+name = "apps"
+paths = ["apps/*"]
+`)
+	groups, err := LoadWorkspaceGroups(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 2 || groups[0].Name != "platform" || groups[1].Paths[0] != filepath.Join(tmpDir, "apps/*") {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+	if !groups[0].Contains(filepath.Join(tmpDir, "infra", "network", "dns")) || groups[0].Contains(filepath.Join(tmpDir, "infrastructure")) {
+		t.Error("expected workspaces below a group directory to be in the group")
+	}
+	if !groups[1].Contains(filepath.Join(tmpDir, "apps", "web")) || groups[1].Contains(filepath.Join(tmpDir, "apps")) {
+		t.Error("expected only workspaces matching the pattern to be in the group")
+	}
+
+	for _, content := range []string{
+		"[[workspace_groups]]\npaths = [\"infra\"]\n",
+		"[[workspace_groups]]\nname = \"platform\"\n",
+		"[[workspace_groups]]\nname = \"platform\"\npaths = [\"[\"]\n",
+	} {
+		write(content)
+		if _, err := LoadWorkspaceGroups(tmpDir); err == nil {
+			t.Errorf("expected an error for %q", content)
+		}
+	}
+}
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.4
+// 	protoc-gen-go v1.36.11
 // 	protoc        v5.29.3
 // source: internal/plugins/proto/plugin.proto
 
@@ -70,6 +70,55 @@ func (OpenActionType) EnumDescriptor() ([]byte, []int) {
 	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{0}
 }
 
+type StatusBadgeLevel int32
+
+const (
+	StatusBadgeLevel_STATUS_BADGE_LEVEL_INFO    StatusBadgeLevel = 0
+	StatusBadgeLevel_STATUS_BADGE_LEVEL_WARNING StatusBadgeLevel = 1 // e.g., a shared environment
+	StatusBadgeLevel_STATUS_BADGE_LEVEL_DANGER  StatusBadgeLevel = 2 // e.g., production
+)
+
+// Enum value maps for StatusBadgeLevel.
+var (
+	StatusBadgeLevel_name = map[int32]string{
+		0: "STATUS_BADGE_LEVEL_INFO",
+		1: "STATUS_BADGE_LEVEL_WARNING",
+		2: "STATUS_BADGE_LEVEL_DANGER",
+	}
+	StatusBadgeLevel_value = map[string]int32{
+		"STATUS_BADGE_LEVEL_INFO":    0,
+		"STATUS_BADGE_LEVEL_WARNING": 1,
+		"STATUS_BADGE_LEVEL_DANGER":  2,
+	}
+)
+
+func (x StatusBadgeLevel) Enum() *StatusBadgeLevel {
+	p := new(StatusBadgeLevel)
+	*p = x
+	return p
+}
+
+func (x StatusBadgeLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatusBadgeLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_plugins_proto_plugin_proto_enumTypes[1].Descriptor()
+}
+
+func (StatusBadgeLevel) Type() protoreflect.EnumType {
+	return &file_internal_plugins_proto_plugin_proto_enumTypes[1]
+}
+
+func (x StatusBadgeLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatusBadgeLevel.Descriptor instead.
+func (StatusBadgeLevel) EnumDescriptor() ([]byte, []int) {
+	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{1}
+}
+
 type AuthenticateRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	ProgramConfig   map[string]string      `protobuf:"bytes,1,rep,name=program_config,json=programConfig,proto3" json:"program_config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -472,6 +521,478 @@ func (x *ImportSuggestionsResponse) GetError() string {
 	return ""
 }
 
+// Bulk import messages
+type ListImportableResourcesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Resource information (a pending create of the type to discover)
+	ResourceType string            `protobuf:"bytes,1,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`                                           // e.g., "aws:s3/bucket:Bucket"
+	Inputs       map[string]string `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Resource inputs (serialized as JSON strings for complex values)
+	// Context
+	ProgramConfig map[string]string `protobuf:"bytes,3,rep,name=program_config,json=programConfig,proto3" json:"program_config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StackConfig   map[string]string `protobuf:"bytes,4,rep,name=stack_config,json=stackConfig,proto3" json:"stack_config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StackName     string            `protobuf:"bytes,5,opt,name=stack_name,json=stackName,proto3" json:"stack_name,omitempty"`
+	ProgramName   string            `protobuf:"bytes,6,opt,name=program_name,json=programName,proto3" json:"program_name,omitempty"`
+	// Auth environment (only populated if use_auth_env: true)
+	AuthEnv map[string]string `protobuf:"bytes,7,rep,name=auth_env,json=authEnv,proto3" json:"auth_env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Provider configuration (if resource uses an explicit provider)
+	ProviderUrn    string            `protobuf:"bytes,8,opt,name=provider_urn,json=providerUrn,proto3" json:"provider_urn,omitempty"`
+	ProviderInputs map[string]string `protobuf:"bytes,9,rep,name=provider_inputs,json=providerInputs,proto3" json:"provider_inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Pagination
+	PageSize      int32  `protobuf:"varint,10,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Maximum resources to return (0 = plugin default)
+	PageToken     string `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Token from a previous response's next_page_token (empty = first page)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListImportableResourcesRequest) Reset() {
+	*x = ListImportableResourcesRequest{}
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListImportableResourcesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListImportableResourcesRequest) ProtoMessage() {}
+
+func (x *ListImportableResourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListImportableResourcesRequest.ProtoReflect.Descriptor instead.
+func (*ListImportableResourcesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListImportableResourcesRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *ListImportableResourcesRequest) GetInputs() map[string]string {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *ListImportableResourcesRequest) GetProgramConfig() map[string]string {
+	if x != nil {
+		return x.ProgramConfig
+	}
+	return nil
+}
+
+func (x *ListImportableResourcesRequest) GetStackConfig() map[string]string {
+	if x != nil {
+		return x.StackConfig
+	}
+	return nil
+}
+
+func (x *ListImportableResourcesRequest) GetStackName() string {
+	if x != nil {
+		return x.StackName
+	}
+	return ""
+}
+
+func (x *ListImportableResourcesRequest) GetProgramName() string {
+	if x != nil {
+		return x.ProgramName
+	}
+	return ""
+}
+
+func (x *ListImportableResourcesRequest) GetAuthEnv() map[string]string {
+	if x != nil {
+		return x.AuthEnv
+	}
+	return nil
+}
+
+func (x *ListImportableResourcesRequest) GetProviderUrn() string {
+	if x != nil {
+		return x.ProviderUrn
+	}
+	return ""
+}
+
+func (x *ListImportableResourcesRequest) GetProviderInputs() map[string]string {
+	if x != nil {
+		return x.ProviderInputs
+	}
+	return nil
+}
+
+func (x *ListImportableResourcesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListImportableResourcesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ImportableResource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                   // The import ID to use (e.g., "arn:aws:s3:::my-bucket")
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`               // Suggested logical name in the Pulumi program (e.g., "my-bucket")
+	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`             // Short display label
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"` // Optional longer description
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportableResource) Reset() {
+	*x = ImportableResource{}
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportableResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportableResource) ProtoMessage() {}
+
+func (x *ImportableResource) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportableResource.ProtoReflect.Descriptor instead.
+func (*ImportableResource) Descriptor() ([]byte, []int) {
+	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImportableResource) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportableResource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImportableResource) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *ImportableResource) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ListImportableResourcesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CanProvide    bool                   `protobuf:"varint,1,opt,name=can_provide,json=canProvide,proto3" json:"can_provide,omitempty"`           // False if plugin doesn't handle this resource type
+	Resources     []*ImportableResource  `protobuf:"bytes,2,rep,name=resources,proto3" json:"resources,omitempty"`                                // Resources on this page (can be empty)
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token for the next page (empty = no more pages)
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                                        // Error message if something went wrong
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListImportableResourcesResponse) Reset() {
+	*x = ListImportableResourcesResponse{}
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListImportableResourcesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListImportableResourcesResponse) ProtoMessage() {}
+
+func (x *ListImportableResourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListImportableResourcesResponse.ProtoReflect.Descriptor instead.
+func (*ListImportableResourcesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListImportableResourcesResponse) GetCanProvide() bool {
+	if x != nil {
+		return x.CanProvide
+	}
+	return false
+}
+
+func (x *ListImportableResourcesResponse) GetResources() []*ImportableResource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *ListImportableResourcesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListImportableResourcesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Import ID validation messages
+type ValidateImportIdRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Resource information
+	ResourceType string `protobuf:"bytes,1,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"` // e.g., "aws:s3/bucket:Bucket"
+	ResourceName string `protobuf:"bytes,2,opt,name=resource_name,json=resourceName,proto3" json:"resource_name,omitempty"` // Logical name in Pulumi program
+	ResourceUrn  string `protobuf:"bytes,3,opt,name=resource_urn,json=resourceUrn,proto3" json:"resource_urn,omitempty"`    // Full Pulumi URN
+	ImportId     string `protobuf:"bytes,4,opt,name=import_id,json=importId,proto3" json:"import_id,omitempty"`             // The import ID to check
+	// Resource inputs (serialized as JSON strings for complex values)
+	Inputs map[string]string `protobuf:"bytes,5,rep,name=inputs,proto3" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Context
+	ProgramConfig map[string]string `protobuf:"bytes,6,rep,name=program_config,json=programConfig,proto3" json:"program_config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StackConfig   map[string]string `protobuf:"bytes,7,rep,name=stack_config,json=stackConfig,proto3" json:"stack_config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StackName     string            `protobuf:"bytes,8,opt,name=stack_name,json=stackName,proto3" json:"stack_name,omitempty"`
+	ProgramName   string            `protobuf:"bytes,9,opt,name=program_name,json=programName,proto3" json:"program_name,omitempty"`
+	// Auth environment (only populated if use_auth_env: true)
+	AuthEnv map[string]string `protobuf:"bytes,10,rep,name=auth_env,json=authEnv,proto3" json:"auth_env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Provider configuration (if resource uses an explicit provider)
+	ProviderUrn    string            `protobuf:"bytes,11,opt,name=provider_urn,json=providerUrn,proto3" json:"provider_urn,omitempty"`
+	ProviderInputs map[string]string `protobuf:"bytes,12,rep,name=provider_inputs,json=providerInputs,proto3" json:"provider_inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ValidateImportIdRequest) Reset() {
+	*x = ValidateImportIdRequest{}
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateImportIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateImportIdRequest) ProtoMessage() {}
+
+func (x *ValidateImportIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateImportIdRequest.ProtoReflect.Descriptor instead.
+func (*ValidateImportIdRequest) Descriptor() ([]byte, []int) {
+	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ValidateImportIdRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *ValidateImportIdRequest) GetResourceName() string {
+	if x != nil {
+		return x.ResourceName
+	}
+	return ""
+}
+
+func (x *ValidateImportIdRequest) GetResourceUrn() string {
+	if x != nil {
+		return x.ResourceUrn
+	}
+	return ""
+}
+
+func (x *ValidateImportIdRequest) GetImportId() string {
+	if x != nil {
+		return x.ImportId
+	}
+	return ""
+}
+
+func (x *ValidateImportIdRequest) GetInputs() map[string]string {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *ValidateImportIdRequest) GetProgramConfig() map[string]string {
+	if x != nil {
+		return x.ProgramConfig
+	}
+	return nil
+}
+
+func (x *ValidateImportIdRequest) GetStackConfig() map[string]string {
+	if x != nil {
+		return x.StackConfig
+	}
+	return nil
+}
+
+func (x *ValidateImportIdRequest) GetStackName() string {
+	if x != nil {
+		return x.StackName
+	}
+	return ""
+}
+
+func (x *ValidateImportIdRequest) GetProgramName() string {
+	if x != nil {
+		return x.ProgramName
+	}
+	return ""
+}
+
+func (x *ValidateImportIdRequest) GetAuthEnv() map[string]string {
+	if x != nil {
+		return x.AuthEnv
+	}
+	return nil
+}
+
+func (x *ValidateImportIdRequest) GetProviderUrn() string {
+	if x != nil {
+		return x.ProviderUrn
+	}
+	return ""
+}
+
+func (x *ValidateImportIdRequest) GetProviderInputs() map[string]string {
+	if x != nil {
+		return x.ProviderInputs
+	}
+	return nil
+}
+
+type ValidateImportIdResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CanValidate   bool                   `protobuf:"varint,1,opt,name=can_validate,json=canValidate,proto3" json:"can_validate,omitempty"` // False if plugin doesn't handle this resource type
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`                                // Whether the ID can be imported
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                             // Why the ID is invalid, or an optional note on a valid one (e.g., "found in us-east-1")
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                                 // Error message if the check itself failed (e.g., the API was unreachable)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateImportIdResponse) Reset() {
+	*x = ValidateImportIdResponse{}
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateImportIdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateImportIdResponse) ProtoMessage() {}
+
+func (x *ValidateImportIdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateImportIdResponse.ProtoReflect.Descriptor instead.
+func (*ValidateImportIdResponse) Descriptor() ([]byte, []int) {
+	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ValidateImportIdResponse) GetCanValidate() bool {
+	if x != nil {
+		return x.CanValidate
+	}
+	return false
+}
+
+func (x *ValidateImportIdResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateImportIdResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ValidateImportIdResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 // Resource opener messages
 type SupportedOpenTypesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -481,7 +1002,7 @@ type SupportedOpenTypesRequest struct {
 
 func (x *SupportedOpenTypesRequest) Reset() {
 	*x = SupportedOpenTypesRequest{}
-	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[5]
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -493,7 +1014,7 @@ func (x *SupportedOpenTypesRequest) String() string {
 func (*SupportedOpenTypesRequest) ProtoMessage() {}
 
 func (x *SupportedOpenTypesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[5]
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,7 +1027,7 @@ func (x *SupportedOpenTypesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SupportedOpenTypesRequest.ProtoReflect.Descriptor instead.
 func (*SupportedOpenTypesRequest) Descriptor() ([]byte, []int) {
-	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{5}
+	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{10}
 }
 
 type SupportedOpenTypesResponse struct {
@@ -518,7 +1039,7 @@ type SupportedOpenTypesResponse struct {
 
 func (x *SupportedOpenTypesResponse) Reset() {
 	*x = SupportedOpenTypesResponse{}
-	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[6]
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -530,7 +1051,7 @@ func (x *SupportedOpenTypesResponse) String() string {
 func (*SupportedOpenTypesResponse) ProtoMessage() {}
 
 func (x *SupportedOpenTypesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[6]
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -543,7 +1064,7 @@ func (x *SupportedOpenTypesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SupportedOpenTypesResponse.ProtoReflect.Descriptor instead.
 func (*SupportedOpenTypesResponse) Descriptor() ([]byte, []int) {
-	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{6}
+	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SupportedOpenTypesResponse) GetResourceTypePatterns() []string {
@@ -578,7 +1099,7 @@ type OpenResourceRequest struct {
 
 func (x *OpenResourceRequest) Reset() {
 	*x = OpenResourceRequest{}
-	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[7]
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -590,7 +1111,7 @@ func (x *OpenResourceRequest) String() string {
 func (*OpenResourceRequest) ProtoMessage() {}
 
 func (x *OpenResourceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[7]
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -603,7 +1124,7 @@ func (x *OpenResourceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenResourceRequest.ProtoReflect.Descriptor instead.
 func (*OpenResourceRequest) Descriptor() ([]byte, []int) {
-	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{7}
+	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *OpenResourceRequest) GetResourceType() string {
@@ -701,7 +1222,7 @@ type OpenResourceResponse struct {
 
 func (x *OpenResourceResponse) Reset() {
 	*x = OpenResourceResponse{}
-	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[8]
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -713,7 +1234,7 @@ func (x *OpenResourceResponse) String() string {
 func (*OpenResourceResponse) ProtoMessage() {}
 
 func (x *OpenResourceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[8]
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -726,7 +1247,7 @@ func (x *OpenResourceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenResourceResponse.ProtoReflect.Descriptor instead.
 func (*OpenResourceResponse) Descriptor() ([]byte, []int) {
-	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{8}
+	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *OpenResourceResponse) GetCanOpen() bool {
@@ -763,7 +1284,7 @@ type OpenAction struct {
 
 func (x *OpenAction) Reset() {
 	*x = OpenAction{}
-	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[9]
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -775,7 +1296,7 @@ func (x *OpenAction) String() string {
 func (*OpenAction) ProtoMessage() {}
 
 func (x *OpenAction) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[9]
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -788,7 +1309,7 @@ func (x *OpenAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenAction.ProtoReflect.Descriptor instead.
 func (*OpenAction) Descriptor() ([]byte, []int) {
-	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{9}
+	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *OpenAction) GetType() OpenActionType {
@@ -826,272 +1347,378 @@ func (x *OpenAction) GetEnv() map[string]string {
 	return nil
 }
 
+// Status badge messages
+type StatusBadgeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Context
+	ProgramConfig map[string]string `protobuf:"bytes,1,rep,name=program_config,json=programConfig,proto3" json:"program_config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StackConfig   map[string]string `protobuf:"bytes,2,rep,name=stack_config,json=stackConfig,proto3" json:"stack_config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StackName     string            `protobuf:"bytes,3,opt,name=stack_name,json=stackName,proto3" json:"stack_name,omitempty"`
+	ProgramName   string            `protobuf:"bytes,4,opt,name=program_name,json=programName,proto3" json:"program_name,omitempty"`
+	// Auth environment (only populated if use_auth_env: true)
+	AuthEnv       map[string]string `protobuf:"bytes,5,rep,name=auth_env,json=authEnv,proto3" json:"auth_env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusBadgeRequest) Reset() {
+	*x = StatusBadgeRequest{}
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusBadgeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusBadgeRequest) ProtoMessage() {}
+
+func (x *StatusBadgeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusBadgeRequest.ProtoReflect.Descriptor instead.
+func (*StatusBadgeRequest) Descriptor() ([]byte, []int) {
+	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StatusBadgeRequest) GetProgramConfig() map[string]string {
+	if x != nil {
+		return x.ProgramConfig
+	}
+	return nil
+}
+
+func (x *StatusBadgeRequest) GetStackConfig() map[string]string {
+	if x != nil {
+		return x.StackConfig
+	}
+	return nil
+}
+
+func (x *StatusBadgeRequest) GetStackName() string {
+	if x != nil {
+		return x.StackName
+	}
+	return ""
+}
+
+func (x *StatusBadgeRequest) GetProgramName() string {
+	if x != nil {
+		return x.ProgramName
+	}
+	return ""
+}
+
+func (x *StatusBadgeRequest) GetAuthEnv() map[string]string {
+	if x != nil {
+		return x.AuthEnv
+	}
+	return nil
+}
+
+type StatusBadgeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`                                       // Short status (e.g., "AWS: prod-account"); empty hides the badge
+	Level         StatusBadgeLevel       `protobuf:"varint,2,opt,name=level,proto3,enum=p5.plugin.v0.StatusBadgeLevel" json:"level,omitempty"` // How prominently the badge is shown
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                                     // Error message if something went wrong
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusBadgeResponse) Reset() {
+	*x = StatusBadgeResponse{}
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusBadgeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusBadgeResponse) ProtoMessage() {}
+
+func (x *StatusBadgeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugins_proto_plugin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusBadgeResponse.ProtoReflect.Descriptor instead.
+func (*StatusBadgeResponse) Descriptor() ([]byte, []int) {
+	return file_internal_plugins_proto_plugin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StatusBadgeResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *StatusBadgeResponse) GetLevel() StatusBadgeLevel {
+	if x != nil {
+		return x.Level
+	}
+	return StatusBadgeLevel_STATUS_BADGE_LEVEL_INFO
+}
+
+func (x *StatusBadgeResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 var File_internal_plugins_proto_plugin_proto protoreflect.FileDescriptor
 
-var file_internal_plugins_proto_plugin_proto_rawDesc = string([]byte{
-	0x0a, 0x23, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69,
-	0x6e, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x2e, 0x76, 0x30, 0x22, 0xb8, 0x03, 0x0a, 0x13, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69,
-	0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5b, 0x0a, 0x0e, 0x70,
-	0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
-	0x76, 0x30, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x67, 0x72,
-	0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x55, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x63,
-	0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32,
-	0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x41, 0x75,
-	0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x2e, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
-	0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21,
-	0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x5f, 0x70, 0x72, 0x6f,
-	0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x73, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x1a, 0x40, 0x0a, 0x12,
-	0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e,
-	0x0a, 0x10, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xde,
-	0x01, 0x0a, 0x14, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
-	0x73, 0x12, 0x3d, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b,
-	0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x41, 0x75,
-	0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e, 0x76,
-	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x74, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
-	0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0x86, 0x08, 0x0a, 0x18, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d,
-	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x5f, 0x75, 0x72, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x72, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x72,
-	0x65, 0x6e, 0x74, 0x5f, 0x75, 0x72, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
-	0x61, 0x72, 0x65, 0x6e, 0x74, 0x55, 0x72, 0x6e, 0x12, 0x4a, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75,
-	0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c,
-	0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75,
-	0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x69, 0x6e,
-	0x70, 0x75, 0x74, 0x73, 0x12, 0x60, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x5f,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x70,
-	0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x49, 0x6d, 0x70, 0x6f,
-	0x72, 0x74, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x5a, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x5f,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x70,
-	0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x49, 0x6d, 0x70, 0x6f,
-	0x72, 0x74, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x5f, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d,
-	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x4e, 0x0a, 0x08, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x65, 0x6e, 0x76,
-	0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67,
-	0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75, 0x67, 0x67,
-	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x41,
-	0x75, 0x74, 0x68, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x61, 0x75, 0x74,
-	0x68, 0x45, 0x6e, 0x76, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
-	0x5f, 0x75, 0x72, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x55, 0x72, 0x6e, 0x12, 0x63, 0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x76, 0x69,
-	0x64, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x3a, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e,
-	0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
-	0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x70, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x1a, 0x39, 0x0a, 0x0b,
-	0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x40, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x67, 0x72,
-	0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x53, 0x74, 0x61,
-	0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3a, 0x0a, 0x0c, 0x41, 0x75, 0x74,
-	0x68, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x41, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
-	0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5a, 0x0a, 0x10, 0x49, 0x6d, 0x70, 0x6f,
-	0x72, 0x74, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
-	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62,
-	0x65, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x94, 0x01, 0x0a, 0x19, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53,
-	0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x12, 0x40, 0x0a, 0x0b, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c,
-	0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75,
-	0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x1b, 0x0a, 0x19, 0x53,
-	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x4f, 0x70, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x52, 0x0a, 0x1a, 0x53, 0x75, 0x70, 0x70,
-	0x6f, 0x72, 0x74, 0x65, 0x64, 0x4f, 0x70, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x54, 0x79, 0x70, 0x65, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x22, 0xcf, 0x08, 0x0a,
-	0x13, 0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21,
-	0x0a, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x75, 0x72, 0x6e, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x72,
-	0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x75, 0x72,
-	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
-	0x72, 0x55, 0x72, 0x6e, 0x12, 0x5e, 0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
-	0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e,
-	0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x4f, 0x70, 0x65,
-	0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x6e,
-	0x70, 0x75, 0x74, 0x73, 0x12, 0x45, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x06,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x2e, 0x76, 0x30, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x48, 0x0a, 0x07, 0x6f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x70,
-	0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x4f, 0x70, 0x65, 0x6e,
-	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x5b, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d,
-	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e,
-	0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x4f, 0x70, 0x65,
-	0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x12, 0x55, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c,
-	0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x63,
-	0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x73, 0x74,
-	0x61, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61,
-	0x63, 0x6b, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
-	0x74, 0x61, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x67,
-	0x72, 0x61, 0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x49, 0x0a, 0x08, 0x61,
-	0x75, 0x74, 0x68, 0x5f, 0x65, 0x6e, 0x76, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e,
-	0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x4f, 0x70, 0x65,
-	0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x41, 0x75, 0x74, 0x68, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x61,
-	0x75, 0x74, 0x68, 0x45, 0x6e, 0x76, 0x1a, 0x41, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
-	0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x49, 0x6e, 0x70,
-	0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3a, 0x0a, 0x0c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x1a, 0x40, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x1a, 0x3a, 0x0a, 0x0c, 0x41, 0x75, 0x74, 0x68, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x79,
-	0x0a, 0x14, 0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x61, 0x6e, 0x5f, 0x6f, 0x70,
-	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x61, 0x6e, 0x4f, 0x70, 0x65,
-	0x6e, 0x12, 0x30, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x18, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30,
-	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xeb, 0x01, 0x0a, 0x0a, 0x4f, 0x70,
-	0x65, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67,
-	0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72,
-	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x18, 0x0a, 0x07,
-	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63,
-	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x33, 0x0a, 0x03, 0x65, 0x6e,
-	0x76, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75,
-	0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x1a,
-	0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x6b, 0x0a, 0x0e, 0x4f, 0x70, 0x65, 0x6e, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x1c, 0x4f, 0x50, 0x45,
-	0x4e, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e,
-	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x4f,
-	0x50, 0x45, 0x4e, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
-	0x42, 0x52, 0x4f, 0x57, 0x53, 0x45, 0x52, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x50, 0x45,
-	0x4e, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x58,
-	0x45, 0x43, 0x10, 0x02, 0x32, 0x63, 0x0a, 0x0a, 0x41, 0x75, 0x74, 0x68, 0x50, 0x6c, 0x75, 0x67,
-	0x69, 0x6e, 0x12, 0x55, 0x0a, 0x0c, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
-	0x74, 0x65, 0x12, 0x21, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76,
-	0x30, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
-	0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x7d, 0x0a, 0x12, 0x49, 0x6d, 0x70,
-	0x6f, 0x72, 0x74, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12,
-	0x67, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75, 0x67, 0x67,
-	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x26, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75,
-	0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75, 0x67,
-	0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x27, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x49,
-	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xd9, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x72, 0x50, 0x6c, 0x75, 0x67, 0x69,
-	0x6e, 0x12, 0x6a, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65,
-	0x64, 0x4f, 0x70, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x27, 0x2e, 0x70, 0x35, 0x2e,
-	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72,
-	0x74, 0x65, 0x64, 0x4f, 0x70, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
-	0x76, 0x30, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x4f, 0x70, 0x65, 0x6e,
-	0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a,
-	0x0c, 0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x21, 0x2e,
-	0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e, 0x4f, 0x70, 0x65,
-	0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x22, 0x2e, 0x70, 0x35, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x30, 0x2e,
-	0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x72, 0x66, 0x68, 0x6f, 0x6c, 0x64, 0x2f, 0x70, 0x35, 0x2f, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-})
+const file_internal_plugins_proto_plugin_proto_rawDesc = "" +
+	"\n" +
+	"#internal/plugins/proto/plugin.proto\x12\fp5.plugin.v0\"\xb8\x03\n" +
+	"\x13AuthenticateRequest\x12[\n" +
+	"\x0eprogram_config\x18\x01 \x03(\v24.p5.plugin.v0.AuthenticateRequest.ProgramConfigEntryR\rprogramConfig\x12U\n" +
+	"\fstack_config\x18\x02 \x03(\v22.p5.plugin.v0.AuthenticateRequest.StackConfigEntryR\vstackConfig\x12\x1d\n" +
+	"\n" +
+	"stack_name\x18\x03 \x01(\tR\tstackName\x12!\n" +
+	"\fprogram_name\x18\x04 \x01(\tR\vprogramName\x12)\n" +
+	"\x10secrets_provider\x18\x05 \x01(\tR\x0fsecretsProvider\x1a@\n" +
+	"\x12ProgramConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10StackConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xde\x01\n" +
+	"\x14AuthenticateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12=\n" +
+	"\x03env\x18\x02 \x03(\v2+.p5.plugin.v0.AuthenticateResponse.EnvEntryR\x03env\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x05R\n" +
+	"ttlSeconds\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x86\b\n" +
+	"\x18ImportSuggestionsRequest\x12#\n" +
+	"\rresource_type\x18\x01 \x01(\tR\fresourceType\x12#\n" +
+	"\rresource_name\x18\x02 \x01(\tR\fresourceName\x12!\n" +
+	"\fresource_urn\x18\x03 \x01(\tR\vresourceUrn\x12\x1d\n" +
+	"\n" +
+	"parent_urn\x18\x04 \x01(\tR\tparentUrn\x12J\n" +
+	"\x06inputs\x18\x05 \x03(\v22.p5.plugin.v0.ImportSuggestionsRequest.InputsEntryR\x06inputs\x12`\n" +
+	"\x0eprogram_config\x18\x06 \x03(\v29.p5.plugin.v0.ImportSuggestionsRequest.ProgramConfigEntryR\rprogramConfig\x12Z\n" +
+	"\fstack_config\x18\a \x03(\v27.p5.plugin.v0.ImportSuggestionsRequest.StackConfigEntryR\vstackConfig\x12\x1d\n" +
+	"\n" +
+	"stack_name\x18\b \x01(\tR\tstackName\x12!\n" +
+	"\fprogram_name\x18\t \x01(\tR\vprogramName\x12N\n" +
+	"\bauth_env\x18\n" +
+	" \x03(\v23.p5.plugin.v0.ImportSuggestionsRequest.AuthEnvEntryR\aauthEnv\x12!\n" +
+	"\fprovider_urn\x18\v \x01(\tR\vproviderUrn\x12c\n" +
+	"\x0fprovider_inputs\x18\f \x03(\v2:.p5.plugin.v0.ImportSuggestionsRequest.ProviderInputsEntryR\x0eproviderInputs\x1a9\n" +
+	"\vInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a@\n" +
+	"\x12ProgramConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10StackConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fAuthEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aA\n" +
+	"\x13ProviderInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Z\n" +
+	"\x10ImportSuggestion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"\x94\x01\n" +
+	"\x19ImportSuggestionsResponse\x12\x1f\n" +
+	"\vcan_provide\x18\x01 \x01(\bR\n" +
+	"canProvide\x12@\n" +
+	"\vsuggestions\x18\x02 \x03(\v2\x1e.p5.plugin.v0.ImportSuggestionR\vsuggestions\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xff\a\n" +
+	"\x1eListImportableResourcesRequest\x12#\n" +
+	"\rresource_type\x18\x01 \x01(\tR\fresourceType\x12P\n" +
+	"\x06inputs\x18\x02 \x03(\v28.p5.plugin.v0.ListImportableResourcesRequest.InputsEntryR\x06inputs\x12f\n" +
+	"\x0eprogram_config\x18\x03 \x03(\v2?.p5.plugin.v0.ListImportableResourcesRequest.ProgramConfigEntryR\rprogramConfig\x12`\n" +
+	"\fstack_config\x18\x04 \x03(\v2=.p5.plugin.v0.ListImportableResourcesRequest.StackConfigEntryR\vstackConfig\x12\x1d\n" +
+	"\n" +
+	"stack_name\x18\x05 \x01(\tR\tstackName\x12!\n" +
+	"\fprogram_name\x18\x06 \x01(\tR\vprogramName\x12T\n" +
+	"\bauth_env\x18\a \x03(\v29.p5.plugin.v0.ListImportableResourcesRequest.AuthEnvEntryR\aauthEnv\x12!\n" +
+	"\fprovider_urn\x18\b \x01(\tR\vproviderUrn\x12i\n" +
+	"\x0fprovider_inputs\x18\t \x03(\v2@.p5.plugin.v0.ListImportableResourcesRequest.ProviderInputsEntryR\x0eproviderInputs\x12\x1b\n" +
+	"\tpage_size\x18\n" +
+	" \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\v \x01(\tR\tpageToken\x1a9\n" +
+	"\vInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a@\n" +
+	"\x12ProgramConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10StackConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fAuthEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aA\n" +
+	"\x13ProviderInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"p\n" +
+	"\x12ImportableResource\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05label\x18\x03 \x01(\tR\x05label\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\xc0\x01\n" +
+	"\x1fListImportableResourcesResponse\x12\x1f\n" +
+	"\vcan_provide\x18\x01 \x01(\bR\n" +
+	"canProvide\x12>\n" +
+	"\tresources\x18\x02 \x03(\v2 .p5.plugin.v0.ImportableResourceR\tresources\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xfe\a\n" +
+	"\x17ValidateImportIdRequest\x12#\n" +
+	"\rresource_type\x18\x01 \x01(\tR\fresourceType\x12#\n" +
+	"\rresource_name\x18\x02 \x01(\tR\fresourceName\x12!\n" +
+	"\fresource_urn\x18\x03 \x01(\tR\vresourceUrn\x12\x1b\n" +
+	"\timport_id\x18\x04 \x01(\tR\bimportId\x12I\n" +
+	"\x06inputs\x18\x05 \x03(\v21.p5.plugin.v0.ValidateImportIdRequest.InputsEntryR\x06inputs\x12_\n" +
+	"\x0eprogram_config\x18\x06 \x03(\v28.p5.plugin.v0.ValidateImportIdRequest.ProgramConfigEntryR\rprogramConfig\x12Y\n" +
+	"\fstack_config\x18\a \x03(\v26.p5.plugin.v0.ValidateImportIdRequest.StackConfigEntryR\vstackConfig\x12\x1d\n" +
+	"\n" +
+	"stack_name\x18\b \x01(\tR\tstackName\x12!\n" +
+	"\fprogram_name\x18\t \x01(\tR\vprogramName\x12M\n" +
+	"\bauth_env\x18\n" +
+	" \x03(\v22.p5.plugin.v0.ValidateImportIdRequest.AuthEnvEntryR\aauthEnv\x12!\n" +
+	"\fprovider_urn\x18\v \x01(\tR\vproviderUrn\x12b\n" +
+	"\x0fprovider_inputs\x18\f \x03(\v29.p5.plugin.v0.ValidateImportIdRequest.ProviderInputsEntryR\x0eproviderInputs\x1a9\n" +
+	"\vInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a@\n" +
+	"\x12ProgramConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10StackConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fAuthEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aA\n" +
+	"\x13ProviderInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x83\x01\n" +
+	"\x18ValidateImportIdResponse\x12!\n" +
+	"\fcan_validate\x18\x01 \x01(\bR\vcanValidate\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\x1b\n" +
+	"\x19SupportedOpenTypesRequest\"R\n" +
+	"\x1aSupportedOpenTypesResponse\x124\n" +
+	"\x16resource_type_patterns\x18\x01 \x03(\tR\x14resourceTypePatterns\"\xcf\b\n" +
+	"\x13OpenResourceRequest\x12#\n" +
+	"\rresource_type\x18\x01 \x01(\tR\fresourceType\x12#\n" +
+	"\rresource_name\x18\x02 \x01(\tR\fresourceName\x12!\n" +
+	"\fresource_urn\x18\x03 \x01(\tR\vresourceUrn\x12!\n" +
+	"\fprovider_urn\x18\x04 \x01(\tR\vproviderUrn\x12^\n" +
+	"\x0fprovider_inputs\x18\x05 \x03(\v25.p5.plugin.v0.OpenResourceRequest.ProviderInputsEntryR\x0eproviderInputs\x12E\n" +
+	"\x06inputs\x18\x06 \x03(\v2-.p5.plugin.v0.OpenResourceRequest.InputsEntryR\x06inputs\x12H\n" +
+	"\aoutputs\x18\a \x03(\v2..p5.plugin.v0.OpenResourceRequest.OutputsEntryR\aoutputs\x12[\n" +
+	"\x0eprogram_config\x18\b \x03(\v24.p5.plugin.v0.OpenResourceRequest.ProgramConfigEntryR\rprogramConfig\x12U\n" +
+	"\fstack_config\x18\t \x03(\v22.p5.plugin.v0.OpenResourceRequest.StackConfigEntryR\vstackConfig\x12\x1d\n" +
+	"\n" +
+	"stack_name\x18\n" +
+	" \x01(\tR\tstackName\x12!\n" +
+	"\fprogram_name\x18\v \x01(\tR\vprogramName\x12I\n" +
+	"\bauth_env\x18\f \x03(\v2..p5.plugin.v0.OpenResourceRequest.AuthEnvEntryR\aauthEnv\x1aA\n" +
+	"\x13ProviderInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fOutputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a@\n" +
+	"\x12ProgramConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10StackConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fAuthEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"y\n" +
+	"\x14OpenResourceResponse\x12\x19\n" +
+	"\bcan_open\x18\x01 \x01(\bR\acanOpen\x120\n" +
+	"\x06action\x18\x02 \x01(\v2\x18.p5.plugin.v0.OpenActionR\x06action\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xeb\x01\n" +
+	"\n" +
+	"OpenAction\x120\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1c.p5.plugin.v0.OpenActionTypeR\x04type\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x18\n" +
+	"\acommand\x18\x03 \x01(\tR\acommand\x12\x12\n" +
+	"\x04args\x18\x04 \x03(\tR\x04args\x123\n" +
+	"\x03env\x18\x05 \x03(\v2!.p5.plugin.v0.OpenAction.EnvEntryR\x03env\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x90\x04\n" +
+	"\x12StatusBadgeRequest\x12Z\n" +
+	"\x0eprogram_config\x18\x01 \x03(\v23.p5.plugin.v0.StatusBadgeRequest.ProgramConfigEntryR\rprogramConfig\x12T\n" +
+	"\fstack_config\x18\x02 \x03(\v21.p5.plugin.v0.StatusBadgeRequest.StackConfigEntryR\vstackConfig\x12\x1d\n" +
+	"\n" +
+	"stack_name\x18\x03 \x01(\tR\tstackName\x12!\n" +
+	"\fprogram_name\x18\x04 \x01(\tR\vprogramName\x12H\n" +
+	"\bauth_env\x18\x05 \x03(\v2-.p5.plugin.v0.StatusBadgeRequest.AuthEnvEntryR\aauthEnv\x1a@\n" +
+	"\x12ProgramConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10StackConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fAuthEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"u\n" +
+	"\x13StatusBadgeResponse\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x124\n" +
+	"\x05level\x18\x02 \x01(\x0e2\x1e.p5.plugin.v0.StatusBadgeLevelR\x05level\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error*k\n" +
+	"\x0eOpenActionType\x12 \n" +
+	"\x1cOPEN_ACTION_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18OPEN_ACTION_TYPE_BROWSER\x10\x01\x12\x19\n" +
+	"\x15OPEN_ACTION_TYPE_EXEC\x10\x02*n\n" +
+	"\x10StatusBadgeLevel\x12\x1b\n" +
+	"\x17STATUS_BADGE_LEVEL_INFO\x10\x00\x12\x1e\n" +
+	"\x1aSTATUS_BADGE_LEVEL_WARNING\x10\x01\x12\x1d\n" +
+	"\x19STATUS_BADGE_LEVEL_DANGER\x10\x022c\n" +
+	"\n" +
+	"AuthPlugin\x12U\n" +
+	"\fAuthenticate\x12!.p5.plugin.v0.AuthenticateRequest\x1a\".p5.plugin.v0.AuthenticateResponse2\xd8\x02\n" +
+	"\x12ImportHelperPlugin\x12g\n" +
+	"\x14GetImportSuggestions\x12&.p5.plugin.v0.ImportSuggestionsRequest\x1a'.p5.plugin.v0.ImportSuggestionsResponse\x12v\n" +
+	"\x17ListImportableResources\x12,.p5.plugin.v0.ListImportableResourcesRequest\x1a-.p5.plugin.v0.ListImportableResourcesResponse\x12a\n" +
+	"\x10ValidateImportId\x12%.p5.plugin.v0.ValidateImportIdRequest\x1a&.p5.plugin.v0.ValidateImportIdResponse2\xd9\x01\n" +
+	"\x14ResourceOpenerPlugin\x12j\n" +
+	"\x15GetSupportedOpenTypes\x12'.p5.plugin.v0.SupportedOpenTypesRequest\x1a(.p5.plugin.v0.SupportedOpenTypesResponse\x12U\n" +
+	"\fOpenResource\x12!.p5.plugin.v0.OpenResourceRequest\x1a\".p5.plugin.v0.OpenResourceResponse2j\n" +
+	"\x11StatusBadgePlugin\x12U\n" +
+	"\x0eGetStatusBadge\x12 .p5.plugin.v0.StatusBadgeRequest\x1a!.p5.plugin.v0.StatusBadgeResponseB-Z+github.com/rfhold/p5/internal/plugins/protob\x06proto3"
 
 var (
 	file_internal_plugins_proto_plugin_proto_rawDescOnce sync.Once
@@ -1105,68 +1732,110 @@ func file_internal_plugins_proto_plugin_proto_rawDescGZIP() []byte {
 	return file_internal_plugins_proto_plugin_proto_rawDescData
 }
 
-var file_internal_plugins_proto_plugin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_plugins_proto_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_internal_plugins_proto_plugin_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_internal_plugins_proto_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_internal_plugins_proto_plugin_proto_goTypes = []any{
-	(OpenActionType)(0),                // 0: p5.plugin.v0.OpenActionType
-	(*AuthenticateRequest)(nil),        // 1: p5.plugin.v0.AuthenticateRequest
-	(*AuthenticateResponse)(nil),       // 2: p5.plugin.v0.AuthenticateResponse
-	(*ImportSuggestionsRequest)(nil),   // 3: p5.plugin.v0.ImportSuggestionsRequest
-	(*ImportSuggestion)(nil),           // 4: p5.plugin.v0.ImportSuggestion
-	(*ImportSuggestionsResponse)(nil),  // 5: p5.plugin.v0.ImportSuggestionsResponse
-	(*SupportedOpenTypesRequest)(nil),  // 6: p5.plugin.v0.SupportedOpenTypesRequest
-	(*SupportedOpenTypesResponse)(nil), // 7: p5.plugin.v0.SupportedOpenTypesResponse
-	(*OpenResourceRequest)(nil),        // 8: p5.plugin.v0.OpenResourceRequest
-	(*OpenResourceResponse)(nil),       // 9: p5.plugin.v0.OpenResourceResponse
-	(*OpenAction)(nil),                 // 10: p5.plugin.v0.OpenAction
-	nil,                                // 11: p5.plugin.v0.AuthenticateRequest.ProgramConfigEntry
-	nil,                                // 12: p5.plugin.v0.AuthenticateRequest.StackConfigEntry
-	nil,                                // 13: p5.plugin.v0.AuthenticateResponse.EnvEntry
-	nil,                                // 14: p5.plugin.v0.ImportSuggestionsRequest.InputsEntry
-	nil,                                // 15: p5.plugin.v0.ImportSuggestionsRequest.ProgramConfigEntry
-	nil,                                // 16: p5.plugin.v0.ImportSuggestionsRequest.StackConfigEntry
-	nil,                                // 17: p5.plugin.v0.ImportSuggestionsRequest.AuthEnvEntry
-	nil,                                // 18: p5.plugin.v0.ImportSuggestionsRequest.ProviderInputsEntry
-	nil,                                // 19: p5.plugin.v0.OpenResourceRequest.ProviderInputsEntry
-	nil,                                // 20: p5.plugin.v0.OpenResourceRequest.InputsEntry
-	nil,                                // 21: p5.plugin.v0.OpenResourceRequest.OutputsEntry
-	nil,                                // 22: p5.plugin.v0.OpenResourceRequest.ProgramConfigEntry
-	nil,                                // 23: p5.plugin.v0.OpenResourceRequest.StackConfigEntry
-	nil,                                // 24: p5.plugin.v0.OpenResourceRequest.AuthEnvEntry
-	nil,                                // 25: p5.plugin.v0.OpenAction.EnvEntry
+	(OpenActionType)(0),                     // 0: p5.plugin.v0.OpenActionType
+	(StatusBadgeLevel)(0),                   // 1: p5.plugin.v0.StatusBadgeLevel
+	(*AuthenticateRequest)(nil),             // 2: p5.plugin.v0.AuthenticateRequest
+	(*AuthenticateResponse)(nil),            // 3: p5.plugin.v0.AuthenticateResponse
+	(*ImportSuggestionsRequest)(nil),        // 4: p5.plugin.v0.ImportSuggestionsRequest
+	(*ImportSuggestion)(nil),                // 5: p5.plugin.v0.ImportSuggestion
+	(*ImportSuggestionsResponse)(nil),       // 6: p5.plugin.v0.ImportSuggestionsResponse
+	(*ListImportableResourcesRequest)(nil),  // 7: p5.plugin.v0.ListImportableResourcesRequest
+	(*ImportableResource)(nil),              // 8: p5.plugin.v0.ImportableResource
+	(*ListImportableResourcesResponse)(nil), // 9: p5.plugin.v0.ListImportableResourcesResponse
+	(*ValidateImportIdRequest)(nil),         // 10: p5.plugin.v0.ValidateImportIdRequest
+	(*ValidateImportIdResponse)(nil),        // 11: p5.plugin.v0.ValidateImportIdResponse
+	(*SupportedOpenTypesRequest)(nil),       // 12: p5.plugin.v0.SupportedOpenTypesRequest
+	(*SupportedOpenTypesResponse)(nil),      // 13: p5.plugin.v0.SupportedOpenTypesResponse
+	(*OpenResourceRequest)(nil),             // 14: p5.plugin.v0.OpenResourceRequest
+	(*OpenResourceResponse)(nil),            // 15: p5.plugin.v0.OpenResourceResponse
+	(*OpenAction)(nil),                      // 16: p5.plugin.v0.OpenAction
+	(*StatusBadgeRequest)(nil),              // 17: p5.plugin.v0.StatusBadgeRequest
+	(*StatusBadgeResponse)(nil),             // 18: p5.plugin.v0.StatusBadgeResponse
+	nil,                                     // 19: p5.plugin.v0.AuthenticateRequest.ProgramConfigEntry
+	nil,                                     // 20: p5.plugin.v0.AuthenticateRequest.StackConfigEntry
+	nil,                                     // 21: p5.plugin.v0.AuthenticateResponse.EnvEntry
+	nil,                                     // 22: p5.plugin.v0.ImportSuggestionsRequest.InputsEntry
+	nil,                                     // 23: p5.plugin.v0.ImportSuggestionsRequest.ProgramConfigEntry
+	nil,                                     // 24: p5.plugin.v0.ImportSuggestionsRequest.StackConfigEntry
+	nil,                                     // 25: p5.plugin.v0.ImportSuggestionsRequest.AuthEnvEntry
+	nil,                                     // 26: p5.plugin.v0.ImportSuggestionsRequest.ProviderInputsEntry
+	nil,                                     // 27: p5.plugin.v0.ListImportableResourcesRequest.InputsEntry
+	nil,                                     // 28: p5.plugin.v0.ListImportableResourcesRequest.ProgramConfigEntry
+	nil,                                     // 29: p5.plugin.v0.ListImportableResourcesRequest.StackConfigEntry
+	nil,                                     // 30: p5.plugin.v0.ListImportableResourcesRequest.AuthEnvEntry
+	nil,                                     // 31: p5.plugin.v0.ListImportableResourcesRequest.ProviderInputsEntry
+	nil,                                     // 32: p5.plugin.v0.ValidateImportIdRequest.InputsEntry
+	nil,                                     // 33: p5.plugin.v0.ValidateImportIdRequest.ProgramConfigEntry
+	nil,                                     // 34: p5.plugin.v0.ValidateImportIdRequest.StackConfigEntry
+	nil,                                     // 35: p5.plugin.v0.ValidateImportIdRequest.AuthEnvEntry
+	nil,                                     // 36: p5.plugin.v0.ValidateImportIdRequest.ProviderInputsEntry
+	nil,                                     // 37: p5.plugin.v0.OpenResourceRequest.ProviderInputsEntry
+	nil,                                     // 38: p5.plugin.v0.OpenResourceRequest.InputsEntry
+	nil,                                     // 39: p5.plugin.v0.OpenResourceRequest.OutputsEntry
+	nil,                                     // 40: p5.plugin.v0.OpenResourceRequest.ProgramConfigEntry
+	nil,                                     // 41: p5.plugin.v0.OpenResourceRequest.StackConfigEntry
+	nil,                                     // 42: p5.plugin.v0.OpenResourceRequest.AuthEnvEntry
+	nil,                                     // 43: p5.plugin.v0.OpenAction.EnvEntry
+	nil,                                     // 44: p5.plugin.v0.StatusBadgeRequest.ProgramConfigEntry
+	nil,                                     // 45: p5.plugin.v0.StatusBadgeRequest.StackConfigEntry
+	nil,                                     // 46: p5.plugin.v0.StatusBadgeRequest.AuthEnvEntry
 }
 var file_internal_plugins_proto_plugin_proto_depIdxs = []int32{
-	11, // 0: p5.plugin.v0.AuthenticateRequest.program_config:type_name -> p5.plugin.v0.AuthenticateRequest.ProgramConfigEntry
-	12, // 1: p5.plugin.v0.AuthenticateRequest.stack_config:type_name -> p5.plugin.v0.AuthenticateRequest.StackConfigEntry
-	13, // 2: p5.plugin.v0.AuthenticateResponse.env:type_name -> p5.plugin.v0.AuthenticateResponse.EnvEntry
-	14, // 3: p5.plugin.v0.ImportSuggestionsRequest.inputs:type_name -> p5.plugin.v0.ImportSuggestionsRequest.InputsEntry
-	15, // 4: p5.plugin.v0.ImportSuggestionsRequest.program_config:type_name -> p5.plugin.v0.ImportSuggestionsRequest.ProgramConfigEntry
-	16, // 5: p5.plugin.v0.ImportSuggestionsRequest.stack_config:type_name -> p5.plugin.v0.ImportSuggestionsRequest.StackConfigEntry
-	17, // 6: p5.plugin.v0.ImportSuggestionsRequest.auth_env:type_name -> p5.plugin.v0.ImportSuggestionsRequest.AuthEnvEntry
-	18, // 7: p5.plugin.v0.ImportSuggestionsRequest.provider_inputs:type_name -> p5.plugin.v0.ImportSuggestionsRequest.ProviderInputsEntry
-	4,  // 8: p5.plugin.v0.ImportSuggestionsResponse.suggestions:type_name -> p5.plugin.v0.ImportSuggestion
-	19, // 9: p5.plugin.v0.OpenResourceRequest.provider_inputs:type_name -> p5.plugin.v0.OpenResourceRequest.ProviderInputsEntry
-	20, // 10: p5.plugin.v0.OpenResourceRequest.inputs:type_name -> p5.plugin.v0.OpenResourceRequest.InputsEntry
-	21, // 11: p5.plugin.v0.OpenResourceRequest.outputs:type_name -> p5.plugin.v0.OpenResourceRequest.OutputsEntry
-	22, // 12: p5.plugin.v0.OpenResourceRequest.program_config:type_name -> p5.plugin.v0.OpenResourceRequest.ProgramConfigEntry
-	23, // 13: p5.plugin.v0.OpenResourceRequest.stack_config:type_name -> p5.plugin.v0.OpenResourceRequest.StackConfigEntry
-	24, // 14: p5.plugin.v0.OpenResourceRequest.auth_env:type_name -> p5.plugin.v0.OpenResourceRequest.AuthEnvEntry
-	10, // 15: p5.plugin.v0.OpenResourceResponse.action:type_name -> p5.plugin.v0.OpenAction
-	0,  // 16: p5.plugin.v0.OpenAction.type:type_name -> p5.plugin.v0.OpenActionType
-	25, // 17: p5.plugin.v0.OpenAction.env:type_name -> p5.plugin.v0.OpenAction.EnvEntry
-	1,  // 18: p5.plugin.v0.AuthPlugin.Authenticate:input_type -> p5.plugin.v0.AuthenticateRequest
-	3,  // 19: p5.plugin.v0.ImportHelperPlugin.GetImportSuggestions:input_type -> p5.plugin.v0.ImportSuggestionsRequest
-	6,  // 20: p5.plugin.v0.ResourceOpenerPlugin.GetSupportedOpenTypes:input_type -> p5.plugin.v0.SupportedOpenTypesRequest
-	8,  // 21: p5.plugin.v0.ResourceOpenerPlugin.OpenResource:input_type -> p5.plugin.v0.OpenResourceRequest
-	2,  // 22: p5.plugin.v0.AuthPlugin.Authenticate:output_type -> p5.plugin.v0.AuthenticateResponse
-	5,  // 23: p5.plugin.v0.ImportHelperPlugin.GetImportSuggestions:output_type -> p5.plugin.v0.ImportSuggestionsResponse
-	7,  // 24: p5.plugin.v0.ResourceOpenerPlugin.GetSupportedOpenTypes:output_type -> p5.plugin.v0.SupportedOpenTypesResponse
-	9,  // 25: p5.plugin.v0.ResourceOpenerPlugin.OpenResource:output_type -> p5.plugin.v0.OpenResourceResponse
-	22, // [22:26] is the sub-list for method output_type
-	18, // [18:22] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	19, // 0: p5.plugin.v0.AuthenticateRequest.program_config:type_name -> p5.plugin.v0.AuthenticateRequest.ProgramConfigEntry
+	20, // 1: p5.plugin.v0.AuthenticateRequest.stack_config:type_name -> p5.plugin.v0.AuthenticateRequest.StackConfigEntry
+	21, // 2: p5.plugin.v0.AuthenticateResponse.env:type_name -> p5.plugin.v0.AuthenticateResponse.EnvEntry
+	22, // 3: p5.plugin.v0.ImportSuggestionsRequest.inputs:type_name -> p5.plugin.v0.ImportSuggestionsRequest.InputsEntry
+	23, // 4: p5.plugin.v0.ImportSuggestionsRequest.program_config:type_name -> p5.plugin.v0.ImportSuggestionsRequest.ProgramConfigEntry
+	24, // 5: p5.plugin.v0.ImportSuggestionsRequest.stack_config:type_name -> p5.plugin.v0.ImportSuggestionsRequest.StackConfigEntry
+	25, // 6: p5.plugin.v0.ImportSuggestionsRequest.auth_env:type_name -> p5.plugin.v0.ImportSuggestionsRequest.AuthEnvEntry
+	26, // 7: p5.plugin.v0.ImportSuggestionsRequest.provider_inputs:type_name -> p5.plugin.v0.ImportSuggestionsRequest.ProviderInputsEntry
+	5,  // 8: p5.plugin.v0.ImportSuggestionsResponse.suggestions:type_name -> p5.plugin.v0.ImportSuggestion
+	27, // 9: p5.plugin.v0.ListImportableResourcesRequest.inputs:type_name -> p5.plugin.v0.ListImportableResourcesRequest.InputsEntry
+	28, // 10: p5.plugin.v0.ListImportableResourcesRequest.program_config:type_name -> p5.plugin.v0.ListImportableResourcesRequest.ProgramConfigEntry
+	29, // 11: p5.plugin.v0.ListImportableResourcesRequest.stack_config:type_name -> p5.plugin.v0.ListImportableResourcesRequest.StackConfigEntry
+	30, // 12: p5.plugin.v0.ListImportableResourcesRequest.auth_env:type_name -> p5.plugin.v0.ListImportableResourcesRequest.AuthEnvEntry
+	31, // 13: p5.plugin.v0.ListImportableResourcesRequest.provider_inputs:type_name -> p5.plugin.v0.ListImportableResourcesRequest.ProviderInputsEntry
+	8,  // 14: p5.plugin.v0.ListImportableResourcesResponse.resources:type_name -> p5.plugin.v0.ImportableResource
+	32, // 15: p5.plugin.v0.ValidateImportIdRequest.inputs:type_name -> p5.plugin.v0.ValidateImportIdRequest.InputsEntry
+	33, // 16: p5.plugin.v0.ValidateImportIdRequest.program_config:type_name -> p5.plugin.v0.ValidateImportIdRequest.ProgramConfigEntry
+	34, // 17: p5.plugin.v0.ValidateImportIdRequest.stack_config:type_name -> p5.plugin.v0.ValidateImportIdRequest.StackConfigEntry
+	35, // 18: p5.plugin.v0.ValidateImportIdRequest.auth_env:type_name -> p5.plugin.v0.ValidateImportIdRequest.AuthEnvEntry
+	36, // 19: p5.plugin.v0.ValidateImportIdRequest.provider_inputs:type_name -> p5.plugin.v0.ValidateImportIdRequest.ProviderInputsEntry
+	37, // 20: p5.plugin.v0.OpenResourceRequest.provider_inputs:type_name -> p5.plugin.v0.OpenResourceRequest.ProviderInputsEntry
+	38, // 21: p5.plugin.v0.OpenResourceRequest.inputs:type_name -> p5.plugin.v0.OpenResourceRequest.InputsEntry
+	39, // 22: p5.plugin.v0.OpenResourceRequest.outputs:type_name -> p5.plugin.v0.OpenResourceRequest.OutputsEntry
+	40, // 23: p5.plugin.v0.OpenResourceRequest.program_config:type_name -> p5.plugin.v0.OpenResourceRequest.ProgramConfigEntry
+	41, // 24: p5.plugin.v0.OpenResourceRequest.stack_config:type_name -> p5.plugin.v0.OpenResourceRequest.StackConfigEntry
+	42, // 25: p5.plugin.v0.OpenResourceRequest.auth_env:type_name -> p5.plugin.v0.OpenResourceRequest.AuthEnvEntry
+	16, // 26: p5.plugin.v0.OpenResourceResponse.action:type_name -> p5.plugin.v0.OpenAction
+	0,  // 27: p5.plugin.v0.OpenAction.type:type_name -> p5.plugin.v0.OpenActionType
+	43, // 28: p5.plugin.v0.OpenAction.env:type_name -> p5.plugin.v0.OpenAction.EnvEntry
+	44, // 29: p5.plugin.v0.StatusBadgeRequest.program_config:type_name -> p5.plugin.v0.StatusBadgeRequest.ProgramConfigEntry
+	45, // 30: p5.plugin.v0.StatusBadgeRequest.stack_config:type_name -> p5.plugin.v0.StatusBadgeRequest.StackConfigEntry
+	46, // 31: p5.plugin.v0.StatusBadgeRequest.auth_env:type_name -> p5.plugin.v0.StatusBadgeRequest.AuthEnvEntry
+	1,  // 32: p5.plugin.v0.StatusBadgeResponse.level:type_name -> p5.plugin.v0.StatusBadgeLevel
+	2,  // 33: p5.plugin.v0.AuthPlugin.Authenticate:input_type -> p5.plugin.v0.AuthenticateRequest
+	4,  // 34: p5.plugin.v0.ImportHelperPlugin.GetImportSuggestions:input_type -> p5.plugin.v0.ImportSuggestionsRequest
+	7,  // 35: p5.plugin.v0.ImportHelperPlugin.ListImportableResources:input_type -> p5.plugin.v0.ListImportableResourcesRequest
+	10, // 36: p5.plugin.v0.ImportHelperPlugin.ValidateImportId:input_type -> p5.plugin.v0.ValidateImportIdRequest
+	12, // 37: p5.plugin.v0.ResourceOpenerPlugin.GetSupportedOpenTypes:input_type -> p5.plugin.v0.SupportedOpenTypesRequest
+	14, // 38: p5.plugin.v0.ResourceOpenerPlugin.OpenResource:input_type -> p5.plugin.v0.OpenResourceRequest
+	17, // 39: p5.plugin.v0.StatusBadgePlugin.GetStatusBadge:input_type -> p5.plugin.v0.StatusBadgeRequest
+	3,  // 40: p5.plugin.v0.AuthPlugin.Authenticate:output_type -> p5.plugin.v0.AuthenticateResponse
+	6,  // 41: p5.plugin.v0.ImportHelperPlugin.GetImportSuggestions:output_type -> p5.plugin.v0.ImportSuggestionsResponse
+	9,  // 42: p5.plugin.v0.ImportHelperPlugin.ListImportableResources:output_type -> p5.plugin.v0.ListImportableResourcesResponse
+	11, // 43: p5.plugin.v0.ImportHelperPlugin.ValidateImportId:output_type -> p5.plugin.v0.ValidateImportIdResponse
+	13, // 44: p5.plugin.v0.ResourceOpenerPlugin.GetSupportedOpenTypes:output_type -> p5.plugin.v0.SupportedOpenTypesResponse
+	15, // 45: p5.plugin.v0.ResourceOpenerPlugin.OpenResource:output_type -> p5.plugin.v0.OpenResourceResponse
+	18, // 46: p5.plugin.v0.StatusBadgePlugin.GetStatusBadge:output_type -> p5.plugin.v0.StatusBadgeResponse
+	40, // [40:47] is the sub-list for method output_type
+	33, // [33:40] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_internal_plugins_proto_plugin_proto_init() }
@@ -1179,10 +1848,10 @@ func file_internal_plugins_proto_plugin_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_plugins_proto_plugin_proto_rawDesc), len(file_internal_plugins_proto_plugin_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   25,
+			NumEnums:      2,
+			NumMessages:   45,
 			NumExtensions: 0,
-			NumServices:   3,
+			NumServices:   4,
 		},
 		GoTypes:           file_internal_plugins_proto_plugin_proto_goTypes,
 		DependencyIndexes: file_internal_plugins_proto_plugin_proto_depIdxs,
@@ -125,7 +125,9 @@ var AuthPlugin_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	ImportHelperPlugin_GetImportSuggestions_FullMethodName = "/p5.plugin.v0.ImportHelperPlugin/GetImportSuggestions"
+	ImportHelperPlugin_GetImportSuggestions_FullMethodName    = "/p5.plugin.v0.ImportHelperPlugin/GetImportSuggestions"
+	ImportHelperPlugin_ListImportableResources_FullMethodName = "/p5.plugin.v0.ImportHelperPlugin/ListImportableResources"
+	ImportHelperPlugin_ValidateImportId_FullMethodName        = "/p5.plugin.v0.ImportHelperPlugin/ValidateImportId"
 )
 
 // ImportHelperPluginClient is the client API for ImportHelperPlugin service.
@@ -135,6 +137,12 @@ const (
 // ImportHelperPlugin provides import ID suggestions (optional capability)
 type ImportHelperPluginClient interface {
 	GetImportSuggestions(ctx context.Context, in *ImportSuggestionsRequest, opts ...grpc.CallOption) (*ImportSuggestionsResponse, error)
+	// ListImportableResources returns a page of existing resources that can be bulk imported.
+	// Plugins built before this RPC existed return Unimplemented, which the host treats as unsupported.
+	ListImportableResources(ctx context.Context, in *ListImportableResourcesRequest, opts ...grpc.CallOption) (*ListImportableResourcesResponse, error)
+	// ValidateImportId checks an import ID typed in the import modal before the import runs.
+	// Plugins built before this RPC existed return Unimplemented, which the host treats as unsupported.
+	ValidateImportId(ctx context.Context, in *ValidateImportIdRequest, opts ...grpc.CallOption) (*ValidateImportIdResponse, error)
 }
 
 type importHelperPluginClient struct {
@@ -155,6 +163,26 @@ func (c *importHelperPluginClient) GetImportSuggestions(ctx context.Context, in
 	return out, nil
 }
 
+func (c *importHelperPluginClient) ListImportableResources(ctx context.Context, in *ListImportableResourcesRequest, opts ...grpc.CallOption) (*ListImportableResourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListImportableResourcesResponse)
+	err := c.cc.Invoke(ctx, ImportHelperPlugin_ListImportableResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *importHelperPluginClient) ValidateImportId(ctx context.Context, in *ValidateImportIdRequest, opts ...grpc.CallOption) (*ValidateImportIdResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateImportIdResponse)
+	err := c.cc.Invoke(ctx, ImportHelperPlugin_ValidateImportId_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ImportHelperPluginServer is the server API for ImportHelperPlugin service.
 // All implementations must embed UnimplementedImportHelperPluginServer
 // for forward compatibility.
@@ -162,6 +190,12 @@ func (c *importHelperPluginClient) GetImportSuggestions(ctx context.Context, in
 // ImportHelperPlugin provides import ID suggestions (optional capability)
 type ImportHelperPluginServer interface {
 	GetImportSuggestions(context.Context, *ImportSuggestionsRequest) (*ImportSuggestionsResponse, error)
+	// ListImportableResources returns a page of existing resources that can be bulk imported.
+	// Plugins built before this RPC existed return Unimplemented, which the host treats as unsupported.
+	ListImportableResources(context.Context, *ListImportableResourcesRequest) (*ListImportableResourcesResponse, error)
+	// ValidateImportId checks an import ID typed in the import modal before the import runs.
+	// Plugins built before this RPC existed return Unimplemented, which the host treats as unsupported.
+	ValidateImportId(context.Context, *ValidateImportIdRequest) (*ValidateImportIdResponse, error)
 	mustEmbedUnimplementedImportHelperPluginServer()
 }
 
@@ -175,6 +209,12 @@ type UnimplementedImportHelperPluginServer struct{}
 func (UnimplementedImportHelperPluginServer) GetImportSuggestions(context.Context, *ImportSuggestionsRequest) (*ImportSuggestionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetImportSuggestions not implemented")
 }
+func (UnimplementedImportHelperPluginServer) ListImportableResources(context.Context, *ListImportableResourcesRequest) (*ListImportableResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListImportableResources not implemented")
+}
+func (UnimplementedImportHelperPluginServer) ValidateImportId(context.Context, *ValidateImportIdRequest) (*ValidateImportIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateImportId not implemented")
+}
 func (UnimplementedImportHelperPluginServer) mustEmbedUnimplementedImportHelperPluginServer() {}
 func (UnimplementedImportHelperPluginServer) testEmbeddedByValue()                            {}
 
@@ -214,6 +254,42 @@ func _ImportHelperPlugin_GetImportSuggestions_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ImportHelperPlugin_ListImportableResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListImportableResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImportHelperPluginServer).ListImportableResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ImportHelperPlugin_ListImportableResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImportHelperPluginServer).ListImportableResources(ctx, req.(*ListImportableResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImportHelperPlugin_ValidateImportId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateImportIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImportHelperPluginServer).ValidateImportId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ImportHelperPlugin_ValidateImportId_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImportHelperPluginServer).ValidateImportId(ctx, req.(*ValidateImportIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ImportHelperPlugin_ServiceDesc is the grpc.ServiceDesc for ImportHelperPlugin service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -225,6 +301,14 @@ var ImportHelperPlugin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetImportSuggestions",
 			Handler:    _ImportHelperPlugin_GetImportSuggestions_Handler,
 		},
+		{
+			MethodName: "ListImportableResources",
+			Handler:    _ImportHelperPlugin_ListImportableResources_Handler,
+		},
+		{
+			MethodName: "ValidateImportId",
+			Handler:    _ImportHelperPlugin_ValidateImportId_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/plugins/proto/plugin.proto",
@@ -379,3 +463,111 @@ var ResourceOpenerPlugin_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/plugins/proto/plugin.proto",
 }
+
+const (
+	StatusBadgePlugin_GetStatusBadge_FullMethodName = "/p5.plugin.v0.StatusBadgePlugin/GetStatusBadge"
+)
+
+// StatusBadgePluginClient is the client API for StatusBadgePlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StatusBadgePlugin provides a short status shown in the header (optional capability)
+// The host polls it periodically, e.g. to show the cloud account or cluster in use
+type StatusBadgePluginClient interface {
+	GetStatusBadge(ctx context.Context, in *StatusBadgeRequest, opts ...grpc.CallOption) (*StatusBadgeResponse, error)
+}
+
+type statusBadgePluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStatusBadgePluginClient(cc grpc.ClientConnInterface) StatusBadgePluginClient {
+	return &statusBadgePluginClient{cc}
+}
+
+func (c *statusBadgePluginClient) GetStatusBadge(ctx context.Context, in *StatusBadgeRequest, opts ...grpc.CallOption) (*StatusBadgeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusBadgeResponse)
+	err := c.cc.Invoke(ctx, StatusBadgePlugin_GetStatusBadge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StatusBadgePluginServer is the server API for StatusBadgePlugin service.
+// All implementations must embed UnimplementedStatusBadgePluginServer
+// for forward compatibility.
+//
+// StatusBadgePlugin provides a short status shown in the header (optional capability)
+// The host polls it periodically, e.g. to show the cloud account or cluster in use
+type StatusBadgePluginServer interface {
+	GetStatusBadge(context.Context, *StatusBadgeRequest) (*StatusBadgeResponse, error)
+	mustEmbedUnimplementedStatusBadgePluginServer()
+}
+
+// UnimplementedStatusBadgePluginServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStatusBadgePluginServer struct{}
+
+func (UnimplementedStatusBadgePluginServer) GetStatusBadge(context.Context, *StatusBadgeRequest) (*StatusBadgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatusBadge not implemented")
+}
+func (UnimplementedStatusBadgePluginServer) mustEmbedUnimplementedStatusBadgePluginServer() {}
+func (UnimplementedStatusBadgePluginServer) testEmbeddedByValue()                           {}
+
+// UnsafeStatusBadgePluginServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StatusBadgePluginServer will
+// result in compilation errors.
+type UnsafeStatusBadgePluginServer interface {
+	mustEmbedUnimplementedStatusBadgePluginServer()
+}
+
+func RegisterStatusBadgePluginServer(s grpc.ServiceRegistrar, srv StatusBadgePluginServer) {
+	// If the following call pancis, it indicates UnimplementedStatusBadgePluginServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StatusBadgePlugin_ServiceDesc, srv)
+}
+
+func _StatusBadgePlugin_GetStatusBadge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusBadgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusBadgePluginServer).GetStatusBadge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatusBadgePlugin_GetStatusBadge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusBadgePluginServer).GetStatusBadge(ctx, req.(*StatusBadgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StatusBadgePlugin_ServiceDesc is the grpc.ServiceDesc for StatusBadgePlugin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StatusBadgePlugin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "p5.plugin.v0.StatusBadgePlugin",
+	HandlerType: (*StatusBadgePluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatusBadge",
+			Handler:    _StatusBadgePlugin_GetStatusBadge_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/plugins/proto/plugin.proto",
+}
@@ -1,6 +1,9 @@
 package plugins
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // AuthProvider handles authentication credentials from plugins.
 type AuthProvider interface {
@@ -10,6 +13,11 @@ type AuthProvider interface {
 	// GetAllEnv returns all environment variables from all valid credentials.
 	GetAllEnv() map[string]string
 
+	// SetEnvironmentEnv sets environment variables resolved from the stack's Pulumi ESC
+	// environments. They are included in the merged auth env, with plugin credentials
+	// taking precedence.
+	SetEnvironmentEnv(env map[string]string)
+
 	// ApplyEnvToProcess sets all credential env vars in the current process environment.
 	ApplyEnvToProcess()
 
@@ -21,6 +29,16 @@ type AuthProvider interface {
 
 	// InvalidateAllCredentials clears all cached credentials.
 	InvalidateAllCredentials()
+
+	// NextCredentialRefresh returns when the next credentials are due for refresh,
+	// or the zero time if none expire.
+	NextCredentialRefresh() time.Time
+
+	// RefreshCredentials re-authenticates plugins whose credentials are due for refresh.
+	RefreshCredentials(ctx context.Context) []AuthenticateResult
+
+	// ReauthenticatePlugin discards a plugin's credentials and authenticates it again.
+	ReauthenticatePlugin(ctx context.Context, name string) AuthenticateResult
 }
 
 // ImportHelper provides import ID suggestions.
@@ -28,6 +46,15 @@ type ImportHelper interface {
 	// GetImportSuggestions queries plugins for import ID suggestions.
 	GetImportSuggestions(ctx context.Context, req *ImportSuggestionsRequest) ([]*AggregatedImportSuggestion, error)
 
+	// ListImportableResources queries plugins for existing resources to bulk import.
+	// A nil pageTokens fetches the first page; otherwise it holds the per-plugin tokens
+	// from the previous page's NextPageTokens.
+	ListImportableResources(ctx context.Context, req *ListImportableResourcesRequest, pageTokens map[string]string) (*ImportableResourcesPage, error)
+
+	// ValidateImportID asks plugins to check an import ID before it is imported.
+	// Returns nil if no plugin could check it.
+	ValidateImportID(ctx context.Context, req *ValidateImportIdRequest) (*ImportIDValidation, error)
+
 	// HasImportHelpers returns true if any plugin provides import suggestions.
 	HasImportHelpers() bool
 }
@@ -43,12 +70,32 @@ type ResourceOpener interface {
 	HasResourceOpeners() bool
 }
 
+// PostOperationNotifier runs plugin hooks after operations finish.
+type PostOperationNotifier interface {
+	// RunPostOperationHooks sends an operation summary to plugins with post-operation hooks.
+	RunPostOperationHooks(ctx context.Context, summary *OperationSummary) []PostOperationResult
+
+	// HasPostOperationHooks returns true if any plugin has post-operation hooks enabled.
+	HasPostOperationHooks() bool
+}
+
+// StatusBadgeProvider provides status badges shown in the header.
+type StatusBadgeProvider interface {
+	// GetStatusBadges asks plugins with status badges enabled for their badge.
+	GetStatusBadges(ctx context.Context) []StatusBadge
+
+	// HasStatusBadges returns true if any plugin has status badges enabled.
+	HasStatusBadges() bool
+}
+
 // PluginProvider combines all plugin capabilities needed by the application.
 // This is the main interface used by the TUI to interact with the plugin system.
 type PluginProvider interface {
 	AuthProvider
 	ImportHelper
 	ResourceOpener
+	PostOperationNotifier
+	StatusBadgeProvider
 
 	// Initialize loads and authenticates plugins based on the current context.
 	// This is a convenience method that loads plugins from config and authenticates.
@@ -0,0 +1,79 @@
+package plugins
+
+import (
+	"slices"
+	"time"
+)
+
+// MaxRecentWorkspaces is how many workspaces are remembered besides pinned ones
+const MaxRecentWorkspaces = 10
+
+// RecentWorkspace is a workspace the user opened, saved to their preferences
+type RecentWorkspace struct {
+	Path     string    `toml:"path"`
+	Stack    string    `toml:"stack,omitempty"`     // Stack last opened in the workspace
+	OpenedAt time.Time `toml:"opened_at,omitempty"` // Zero for a workspace pinned before it was opened
+	Pinned   bool      `toml:"pinned,omitempty"`
+}
+
+// LoadRecentWorkspaces returns the workspaces the user opened, pinned ones first and
+// then the most recently opened
+func LoadRecentWorkspaces() ([]RecentWorkspace, error) {
+	prefs, err := LoadUserPreferences()
+	if err != nil {
+		return nil, err
+	}
+	return sortRecentWorkspaces(prefs.Workspaces), nil
+}
+
+// RecordRecentWorkspace remembers that stack was opened in the workspace at path.
+// Only the MaxRecentWorkspaces most recent workspaces are kept unless pinned.
+func RecordRecentWorkspace(path, stack string, at time.Time) error {
+	return UpdateUserPreferences(func(prefs *UserPreferences) {
+		recent := RecentWorkspace{Path: path, Stack: stack, OpenedAt: at}
+		if i := slices.IndexFunc(prefs.Workspaces, func(w RecentWorkspace) bool { return w.Path == path }); i >= 0 {
+			recent.Pinned = prefs.Workspaces[i].Pinned
+			prefs.Workspaces = slices.Delete(prefs.Workspaces, i, i+1)
+		}
+		workspaces := sortRecentWorkspaces(append(prefs.Workspaces, recent))
+
+		// Drop the least recently opened workspaces past the limit
+		unpinned := 0
+		prefs.Workspaces = slices.DeleteFunc(workspaces, func(w RecentWorkspace) bool {
+			if w.Pinned {
+				return false
+			}
+			unpinned++
+			return unpinned > MaxRecentWorkspaces
+		})
+	})
+}
+
+// SetWorkspacePinned pins the workspace at path to the top of the recent
+// workspaces, or unpins it
+func SetWorkspacePinned(path string, pinned bool) error {
+	return UpdateUserPreferences(func(prefs *UserPreferences) {
+		i := slices.IndexFunc(prefs.Workspaces, func(w RecentWorkspace) bool { return w.Path == path })
+		switch {
+		case i >= 0:
+			prefs.Workspaces[i].Pinned = pinned
+		case pinned:
+			prefs.Workspaces = append(prefs.Workspaces, RecentWorkspace{Path: path, Pinned: true})
+		}
+		prefs.Workspaces = sortRecentWorkspaces(prefs.Workspaces)
+	})
+}
+
+// sortRecentWorkspaces orders workspaces pinned first, then most recently opened
+func sortRecentWorkspaces(workspaces []RecentWorkspace) []RecentWorkspace {
+	slices.SortStableFunc(workspaces, func(a, b RecentWorkspace) int {
+		if a.Pinned != b.Pinned {
+			if a.Pinned {
+				return -1
+			}
+			return 1
+		}
+		return b.OpenedAt.Compare(a.OpenedAt)
+	})
+	return workspaces
+}
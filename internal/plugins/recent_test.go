@@ -0,0 +1,58 @@
+package plugins
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestRecentWorkspaces verifies opened workspaces are remembered most recent first,
+// pinned ones stay on top, and only the most recent unpinned ones are kept
+func TestRecentWorkspaces(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if recent, err := LoadRecentWorkspaces(); err != nil || len(recent) != 0 {
+		t.Fatalf("expected no recent workspaces, got %+v, %v", recent, err)
+	}
+
+	for i := range MaxRecentWorkspaces + 2 {
+		if err := RecordRecentWorkspace(fmt.Sprintf("/ws/%d", i), "dev", start.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := SetWorkspacePinned("/ws/2", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Opening a workspace again moves it up and keeps it pinned
+	if err := RecordRecentWorkspace("/ws/2", "prod", start.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := RecordRecentWorkspace("/ws/new", "dev", start.Add(2*time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	recent, err := LoadRecentWorkspaces()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recent) != MaxRecentWorkspaces+1 {
+		t.Fatalf("expected %d workspaces, got %d: %+v", MaxRecentWorkspaces+1, len(recent), recent)
+	}
+	if first := recent[0]; first.Path != "/ws/2" || !first.Pinned || first.Stack != "prod" {
+		t.Errorf("expected the pinned workspace first, got %+v", first)
+	}
+	if recent[1].Path != "/ws/new" || recent[2].Path != "/ws/11" {
+		t.Errorf("expected the rest most recent first, got %+v", recent[1:3])
+	}
+	if last := recent[len(recent)-1]; last.Path != "/ws/3" {
+		t.Errorf("expected the oldest workspaces to be dropped, got %+v", last)
+	}
+
+	if err := SetWorkspacePinned("/ws/2", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recent, _ := LoadRecentWorkspaces(); recent[0].Path != "/ws/new" || recent[1].Path != "/ws/2" {
+		t.Errorf("expected the unpinned workspace in order of opening, got %+v", recent[:2])
+	}
+}
@@ -0,0 +1,139 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotAuthenticated is returned when re-authenticating a plugin before plugins
+// authenticated for a stack
+var ErrNotAuthenticated = errors.New("plugins have not authenticated for a stack")
+
+// CredentialRefreshLead is how long before expiry credentials are refreshed. Short-lived
+// credentials are refreshed once four fifths of their TTL has passed instead.
+const CredentialRefreshLead = time.Minute
+
+// CredentialRetryDelay is how long a plugin whose credential refresh failed waits
+// before it is retried
+const CredentialRetryDelay = 30 * time.Second
+
+// RefreshAt returns when the credentials should be refreshed, or the zero time if
+// they never expire or are requested before every operation anyway
+func (c *Credentials) RefreshAt() time.Time {
+	if c.AlwaysCall || c.ExpiresAt.IsZero() {
+		return time.Time{}
+	}
+	lead := CredentialRefreshLead
+	if !c.IssuedAt.IsZero() {
+		lead = min(lead, c.ExpiresAt.Sub(c.IssuedAt)/5)
+	}
+	return c.ExpiresAt.Add(-lead)
+}
+
+// NextCredentialRefresh returns when the next plugin credentials are due for refresh,
+// or the zero time if none expire
+func (m *Manager) NextCredentialRefresh() time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var next time.Time
+	for name, creds := range m.credentials {
+		at := creds.RefreshAt()
+		if at.IsZero() {
+			continue
+		}
+		if retry := m.refreshRetryAt[name]; retry.After(at) {
+			at = retry
+		}
+		if next.IsZero() || at.Before(next) {
+			next = at
+		}
+	}
+	return next
+}
+
+// RefreshCredentials re-authenticates the plugins whose credentials are due for
+// refresh, in the configured order, using the context of the last authentication.
+// New credentials replace the cached ones as each plugin succeeds, so GetAllEnv
+// returns them to operations started afterwards. Plugins that fail keep their
+// current credentials until they expire and are retried after CredentialRetryDelay.
+func (m *Manager) RefreshCredentials(ctx context.Context) []AuthenticateResult {
+	now := time.Now()
+
+	m.mu.RLock()
+	authCtx := m.currentContext
+	cfg := m.mergedConfig
+	due := make(map[string]*PluginInstance)
+	for name, creds := range m.credentials {
+		at := creds.RefreshAt()
+		if at.IsZero() || now.Before(at) || now.Before(m.refreshRetryAt[name]) {
+			continue
+		}
+		if inst, ok := m.plugins[name]; ok {
+			due[name] = inst
+		}
+	}
+	m.mu.RUnlock()
+
+	if authCtx == nil || cfg == nil || len(due) == 0 {
+		return nil
+	}
+
+	var results []AuthenticateResult
+	for _, name := range cfg.GetOrderedPluginNames() {
+		inst, ok := due[name]
+		if !ok {
+			continue
+		}
+		result, current := m.reauthenticate(ctx, name, inst, authCtx, cfg)
+		if !current {
+			return results
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// ReauthenticatePlugin discards a plugin's credentials and authenticates it again
+// for the current stack, whether or not they expired
+func (m *Manager) ReauthenticatePlugin(ctx context.Context, name string) AuthenticateResult {
+	m.mu.RLock()
+	authCtx := m.currentContext
+	cfg := m.mergedConfig
+	inst, ok := m.plugins[name]
+	m.mu.RUnlock()
+
+	if !ok {
+		return AuthenticateResult{PluginName: name, Error: fmt.Errorf("plugin %s is not loaded", name)}
+	}
+	if authCtx == nil || cfg == nil {
+		return AuthenticateResult{PluginName: name, Error: ErrNotAuthenticated}
+	}
+	result, _ := m.reauthenticate(ctx, name, inst, authCtx, cfg)
+	return result
+}
+
+// reauthenticate authenticates a plugin in authCtx and caches the result. Plugins
+// that fail keep their current credentials and are retried after CredentialRetryDelay.
+// It returns false, dropping the result, if the context changed while authenticating.
+func (m *Manager) reauthenticate(ctx context.Context, name string, inst *PluginInstance, authCtx *AuthContext, cfg *P5Config) (AuthenticateResult, bool) {
+	result, _ := m.authenticateWithHash(ctx, name, inst, authCtx.ProgramName, authCtx.StackName, cfg, authCtx.WorkDir)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.currentContext != authCtx {
+		return result, false
+	}
+	m.storeResultLocked(result)
+	if result.Error == nil {
+		delete(m.refreshRetryAt, name)
+		return result, true
+	}
+	if m.refreshRetryAt == nil {
+		m.refreshRetryAt = make(map[string]time.Time)
+	}
+	m.refreshRetryAt[name] = time.Now().Add(CredentialRetryDelay)
+	return result, true
+}
@@ -0,0 +1,163 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rfhold/p5/internal/plugins/proto"
+)
+
+// mockRefreshPlugin is a builtin plugin issuing numbered credentials with a TTL
+type mockRefreshPlugin struct {
+	mockBuiltinPlugin
+	calls int
+	fail  bool
+}
+
+func (m *mockRefreshPlugin) Authenticate(ctx context.Context, req *proto.AuthenticateRequest) (*proto.AuthenticateResponse, error) {
+	m.calls++
+	if m.fail && m.calls > 1 {
+		return nil, errors.New("token service unavailable")
+	}
+	return SuccessResponse(map[string]string{"TOKEN": req.StackName + "-" + string(rune('0'+m.calls))}, 3600), nil
+}
+
+func TestCredentials_RefreshAt(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name  string
+		creds Credentials
+		want  time.Time
+	}{
+		{"never expires", Credentials{}, time.Time{}},
+		{"always called", Credentials{AlwaysCall: true, ExpiresAt: now}, time.Time{}},
+		{"long ttl", Credentials{IssuedAt: now, ExpiresAt: now.Add(time.Hour)}, now.Add(time.Hour - CredentialRefreshLead)},
+		{"short ttl", Credentials{IssuedAt: now, ExpiresAt: now.Add(time.Minute)}, now.Add(48 * time.Second)},
+		{"unknown issue time", Credentials{ExpiresAt: now.Add(time.Hour)}, now.Add(time.Hour - CredentialRefreshLead)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.creds.RefreshAt(); !got.Equal(tc.want) {
+				t.Errorf("RefreshAt() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestManager_RefreshCredentials verifies only credentials due for refresh are
+// renewed, in the context they were issued for, and failures are retried later
+func TestManager_RefreshCredentials(t *testing.T) {
+	originalRegistry := builtinRegistry
+	defer func() { builtinRegistry = originalRegistry }()
+	builtinRegistry = make(map[string]BuiltinPlugin)
+
+	due := &mockRefreshPlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("due")}}
+	fresh := &mockRefreshPlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("fresh")}}
+	failing := &mockRefreshPlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("failing")}, fail: true}
+	RegisterBuiltin(due)
+	RegisterBuiltin(fresh)
+	RegisterBuiltin(failing)
+
+	cfg := &P5Config{Plugins: map[string]PluginConfig{"due": {}, "fresh": {}, "failing": {}}}
+	mgr, _ := NewManager("")
+	if err := mgr.LoadPlugins(context.Background(), cfg); err != nil {
+		t.Fatalf("LoadPlugins failed: %v", err)
+	}
+	mgr.mergedConfig = cfg
+	if _, err := mgr.AuthenticateAll(context.Background(), "app", "dev", cfg, t.TempDir()); err != nil {
+		t.Fatalf("AuthenticateAll failed: %v", err)
+	}
+
+	if results := mgr.RefreshCredentials(context.Background()); len(results) != 0 {
+		t.Fatalf("expected no refresh before credentials are due, got %+v", results)
+	}
+
+	// Move the due and failing plugins' credentials close to expiry
+	soon := time.Now().Add(time.Second)
+	for _, name := range []string{"due", "failing"} {
+		mgr.credentials[name].IssuedAt = soon.Add(-time.Hour)
+		mgr.credentials[name].ExpiresAt = soon
+	}
+	if next := mgr.NextCredentialRefresh(); next.After(time.Now()) {
+		t.Errorf("expected credentials to be due, next refresh at %v", next)
+	}
+
+	results := mgr.RefreshCredentials(context.Background())
+	if len(results) != 2 {
+		t.Fatalf("expected the due and failing plugins to refresh, got %+v", results)
+	}
+	if due.calls != 2 || fresh.calls != 1 {
+		t.Errorf("expected only due credentials to be renewed, got due=%d fresh=%d calls", due.calls, fresh.calls)
+	}
+	if got := mgr.credentials["due"].Env["TOKEN"]; got != "dev-2" {
+		t.Errorf("expected the renewed token for the dev stack, got %q", got)
+	}
+	if !mgr.credentials["due"].ExpiresAt.After(soon) {
+		t.Error("expected the renewed credentials to expire later")
+	}
+
+	// The failed plugin keeps its credentials and is retried after a delay
+	if mgr.credentials["failing"].Env["TOKEN"] != "dev-1" {
+		t.Error("expected the failing plugin to keep its credentials")
+	}
+	if next := mgr.NextCredentialRefresh(); next.Before(time.Now().Add(CredentialRetryDelay - time.Second)) {
+		t.Errorf("expected the failed refresh to be retried after %v, next refresh at %v", CredentialRetryDelay, next)
+	}
+	if results := mgr.RefreshCredentials(context.Background()); len(results) != 0 {
+		t.Errorf("expected no retry before the delay, got %+v", results)
+	}
+}
+
+// TestManager_ReauthenticatePlugin verifies a single plugin can be authenticated
+// again and its state is reported in the credentials summary
+func TestManager_ReauthenticatePlugin(t *testing.T) {
+	originalRegistry := builtinRegistry
+	defer func() { builtinRegistry = originalRegistry }()
+	builtinRegistry = make(map[string]BuiltinPlugin)
+
+	stable := &mockRefreshPlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("stable")}}
+	failing := &mockRefreshPlugin{mockBuiltinPlugin: mockBuiltinPlugin{BuiltinPluginBase: NewBuiltinPluginBase("failing")}, fail: true}
+	RegisterBuiltin(stable)
+	RegisterBuiltin(failing)
+
+	cfg := &P5Config{Order: []string{"stable"}, Plugins: map[string]PluginConfig{"stable": {}, "failing": {}}}
+	mgr, _ := NewManager("")
+	if err := mgr.LoadPlugins(context.Background(), cfg); err != nil {
+		t.Fatalf("LoadPlugins failed: %v", err)
+	}
+	mgr.mergedConfig = cfg
+
+	if result := mgr.ReauthenticatePlugin(context.Background(), "stable"); !errors.Is(result.Error, ErrNotAuthenticated) {
+		t.Errorf("expected re-authenticating before authentication to fail, got %v", result.Error)
+	}
+	if _, err := mgr.AuthenticateAll(context.Background(), "app", "dev", cfg, t.TempDir()); err != nil {
+		t.Fatalf("AuthenticateAll failed: %v", err)
+	}
+
+	if result := mgr.ReauthenticatePlugin(context.Background(), "stable"); result.Error != nil {
+		t.Fatalf("ReauthenticatePlugin failed: %v", result.Error)
+	}
+	if stable.calls != 2 || mgr.credentials["stable"].Env["TOKEN"] != "dev-2" {
+		t.Errorf("expected stable to authenticate again, got %d calls", stable.calls)
+	}
+	if result := mgr.ReauthenticatePlugin(context.Background(), "failing"); result.Error == nil {
+		t.Fatal("expected failing plugin to fail")
+	}
+	if result := mgr.ReauthenticatePlugin(context.Background(), "missing"); result.Error == nil {
+		t.Error("expected an unknown plugin to fail")
+	}
+
+	summaries := mgr.GetCredentialsSummary()
+	if len(summaries) != 2 || summaries[0].PluginName != "stable" || summaries[1].PluginName != "failing" {
+		t.Fatalf("expected ordered plugins first, got %+v", summaries)
+	}
+	if summaries[0].HasError || summaries[0].IssuedAt.IsZero() || len(summaries[0].EnvVars) != 1 {
+		t.Errorf("expected stable to be authenticated, got %+v", summaries[0])
+	}
+	if !summaries[1].HasError || summaries[1].Error != "token service unavailable" || summaries[1].IssuedAt.IsZero() {
+		t.Errorf("expected failing to keep its credentials and report the error, got %+v", summaries[1])
+	}
+}
@@ -0,0 +1,92 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TargetSetConfig is a named set of resource flags saved to p5.toml, for recurring
+// partial deployments. Resources are given by their URN without the stack part,
+// "<project>::<type>::<name>", so a set applies to every stack of the project.
+type TargetSetConfig struct {
+	Target  []string `toml:"target,omitempty"`
+	Replace []string `toml:"replace,omitempty"`
+	Exclude []string `toml:"exclude,omitempty"`
+}
+
+// Len returns how many flags the set holds
+func (s TargetSetConfig) Len() int {
+	return len(s.Target) + len(s.Replace) + len(s.Exclude)
+}
+
+// ValidateTargetSetName checks that name can name a target set in p5.toml
+func ValidateTargetSetName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("name is required")
+	}
+	if strings.ContainsFunc(name, unicode.IsControl) {
+		return errors.New("name can't contain control characters")
+	}
+	return nil
+}
+
+// LoadTargetSets loads the target sets saved in p5.toml for the project in workDir
+func LoadTargetSets(workDir string) (map[string]TargetSetConfig, error) {
+	global, _, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load global config: %w", err)
+	}
+	return global.TargetSets, nil
+}
+
+// AddTargetSet saves a target set to the p5.toml of the project in workDir,
+// creating the file at the git root if none exists, and returns its path. The
+// rest of the file, including comments, is left untouched, so a set that already
+// exists must be removed from the file before saving it again.
+func AddTargetSet(workDir, name string, set TargetSetConfig) (string, error) {
+	if err := ValidateTargetSetName(name); err != nil {
+		return "", err
+	}
+	if set.Len() == 0 {
+		return "", errors.New("no resources are flagged")
+	}
+	global, configPath, err := LoadGlobalConfig(workDir)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := global.TargetSets[name]; ok {
+		return "", fmt.Errorf("target set %q is already defined in %s", name, configPath)
+	}
+	if configPath == "" {
+		configPath = newGlobalConfigPath(workDir)
+	}
+	existing, err := os.ReadFile(configPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to read %s: %w", configPath, err)
+	}
+
+	var section strings.Builder
+	fmt.Fprintf(&section, "[target_sets.%s]\n", strconv.Quote(name))
+	writeList := func(key string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = strconv.Quote(v)
+		}
+		fmt.Fprintf(&section, "%s = [%s]\n", key, strings.Join(quoted, ", "))
+	}
+	writeList("target", set.Target)
+	writeList("replace", set.Replace)
+	writeList("exclude", set.Exclude)
+
+	if err := appendConfigSection(configPath, existing, section.String()); err != nil {
+		return "", err
+	}
+	return configPath, nil
+}
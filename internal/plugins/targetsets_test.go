@@ -0,0 +1,56 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAddTargetSet(t *testing.T) {
+	dir := t.TempDir()
+	existing := "# shared settings\npersist_flags = true"
+	if err := os.WriteFile(filepath.Join(dir, "p5.toml"), []byte(existing), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	set := TargetSetConfig{
+		Target:  []string{"app::aws:s3/bucket:Bucket::assets", "app::aws:cloudfront/distribution:Distribution::cdn"},
+		Exclude: []string{"app::aws:rds/instance:Instance::db"},
+	}
+	path, err := AddTargetSet(dir, "frontend only", set)
+	if err != nil {
+		t.Fatalf("AddTargetSet() error = %v", err)
+	}
+	if path != filepath.Join(dir, "p5.toml") {
+		t.Errorf("expected the set to be saved to the existing p5.toml, got %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	if !strings.HasPrefix(string(data), existing+"\n\n[target_sets.") {
+		t.Errorf("existing content was changed:\n%s", data)
+	}
+
+	sets, err := LoadTargetSets(dir)
+	if err != nil {
+		t.Fatalf("LoadTargetSets() error = %v", err)
+	}
+	got := sets["frontend only"]
+	if !slices.Equal(got.Target, set.Target) || !slices.Equal(got.Exclude, set.Exclude) || len(got.Replace) != 0 {
+		t.Errorf("expected the saved set to load back, got %+v", got)
+	}
+
+	if _, err := AddTargetSet(dir, "frontend only", set); err == nil {
+		t.Error("expected an error for a set that is already defined")
+	}
+	if _, err := AddTargetSet(dir, "empty", TargetSetConfig{}); err == nil {
+		t.Error("expected an error for a set without flags")
+	}
+	if _, err := AddTargetSet(dir, " ", set); err == nil {
+		t.Error("expected an error for a blank name")
+	}
+}
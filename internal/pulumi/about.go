@@ -0,0 +1,61 @@
+package pulumi
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/auto"
+)
+
+// AboutInfo is what the Pulumi CLI reports about itself and its environment,
+// like `pulumi about`
+type AboutInfo struct {
+	CLIVersion string
+	User       string // Backend user, empty when not logged in
+	BackendURL string
+	Plugins    []PluginVersion // Installed plugins, by kind then name
+}
+
+// PluginVersion is an installed Pulumi plugin
+type PluginVersion struct {
+	Name    string
+	Kind    string // resource, language, analyzer, ...
+	Version string // Empty for plugins without a version
+}
+
+// GetAbout returns the Pulumi CLI version, backend and installed plugins. A failed
+// whoami, e.g. when not logged in, leaves the backend empty rather than failing.
+func GetAbout(ctx context.Context, workDir string, env map[string]string) (*AboutInfo, error) {
+	wsOpts := []auto.LocalWorkspaceOption{auto.WorkDir(workDir)}
+	if len(env) > 0 {
+		wsOpts = append(wsOpts, auto.EnvVars(env))
+	}
+	ws, err := auto.NewLocalWorkspace(ctx, wsOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	info := &AboutInfo{CLIVersion: ws.PulumiVersion()}
+	if whoami, err := ws.WhoAmIDetails(ctx); err == nil {
+		info.User = whoami.User
+		info.BackendURL = whoami.URL
+	}
+
+	plugins, err := ws.ListPlugins(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plugins: %w", err)
+	}
+	for _, p := range plugins {
+		v := PluginVersion{Name: p.Name, Kind: string(p.Kind)}
+		if p.Version != nil {
+			v.Version = p.Version.String()
+		}
+		info.Plugins = append(info.Plugins, v)
+	}
+	slices.SortFunc(info.Plugins, func(a, b PluginVersion) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name), cmp.Compare(a.Version, b.Version))
+	})
+	return info, nil
+}
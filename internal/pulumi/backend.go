@@ -0,0 +1,78 @@
+package pulumi
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// loginRequiredTexts are found in the errors of commands run without being logged
+// in to a backend, or with credentials the backend no longer accepts
+var loginRequiredTexts = []string{
+	"PULUMI_ACCESS_TOKEN must be set for login",
+	"run `pulumi login`",
+	"[401] Unauthorized",
+}
+
+// IsLoginRequiredError reports whether err is from a command that needs a backend
+// login first
+func IsLoginRequiredError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	for _, text := range loginRequiredTexts {
+		if strings.Contains(msg, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCloudBackend reports whether url is a Pulumi Cloud backend, which logs in with
+// an access token. Self-managed backends use file, s3, azblob or gs URLs instead.
+func IsCloudBackend(url string) bool {
+	return url == "" || strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://")
+}
+
+// Login logs in to the backend at opts.URL with `pulumi login`, without prompting,
+// and returns who is now logged in
+func Login(ctx context.Context, workDir string, opts LoginOptions) (*WhoAmIInfo, error) {
+	env := maps.Clone(opts.Env)
+	if opts.Token != "" {
+		if env == nil {
+			env = make(map[string]string)
+		}
+		env["PULUMI_ACCESS_TOKEN"] = opts.Token
+	}
+	args := []string{"login", "--non-interactive"}
+	if opts.URL != "" {
+		args = append(args, opts.URL)
+	}
+	if output, err := runPulumiCommand(ctx, workDir, env, args...); err != nil {
+		return nil, fmt.Errorf("login failed: %w\n%s", err, strings.TrimSpace(output))
+	}
+	// The login is saved with the Pulumi credentials, so it applies without the token
+	return GetWhoAmI(ctx, workDir, opts.Env)
+}
+
+// LoginCommand returns `pulumi login` for the backend at opts.URL, to run attached
+// to the terminal. For Pulumi Cloud it offers to log in with the browser.
+func LoginCommand(workDir string, opts LoginOptions) *exec.Cmd {
+	args := []string{"login"}
+	if opts.URL != "" {
+		args = append(args, opts.URL)
+	}
+	cmd := exec.Command("pulumi", args...) //nolint:gosec // G204: Pulumi CLI command execution
+	cmd.Dir = workDir
+	if len(opts.Env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range opts.Env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
+	return cmd
+}
@@ -0,0 +1,100 @@
+package pulumi
+
+import "slices"
+
+// BlastRadius returns the resources destroying targets affects, in state order: the
+// targets, their children, and the resources that depend on, are deleted with or are
+// provisioned by any of them, transitively. With no targets every resource is
+// affected. Old copies of replaced resources are left out.
+func BlastRadius(resources []ResourceInfo, targets []string) []ResourceInfo {
+	live := make([]ResourceInfo, 0, len(resources))
+	for _, r := range resources {
+		if !r.PendingDelete {
+			live = append(live, r)
+		}
+	}
+	if len(targets) == 0 {
+		return live
+	}
+
+	affects := dependentsIndex(live)
+	affected := make(map[string]bool)
+	queue := slices.Clone(targets)
+	for len(queue) > 0 {
+		urn := queue[0]
+		queue = queue[1:]
+		if affected[urn] {
+			continue
+		}
+		affected[urn] = true
+		queue = append(queue, affects[urn]...)
+	}
+
+	radius := make([]ResourceInfo, 0, len(affected))
+	for _, r := range live {
+		if affected[r.URN] {
+			radius = append(radius, r)
+		}
+	}
+	return radius
+}
+
+// StateDeleteOrder orders urns for removing them from state one at a time:
+// children before their parents and dependents before the resources they depend
+// on, so no resource is removed while another in urns still refers to it
+func StateDeleteOrder(resources []ResourceInfo, urns []string) []string {
+	live := make([]ResourceInfo, 0, len(resources))
+	for _, r := range resources {
+		if !r.PendingDelete {
+			live = append(live, r)
+		}
+	}
+	affects := dependentsIndex(live)
+
+	selected := make(map[string]bool, len(urns))
+	for _, urn := range urns {
+		selected[urn] = true
+	}
+
+	ordered := make([]string, 0, len(urns))
+	visited := make(map[string]bool, len(urns))
+	var visit func(urn string)
+	visit = func(urn string) {
+		if visited[urn] {
+			return
+		}
+		visited[urn] = true
+		for _, dependent := range affects[urn] {
+			if selected[dependent] {
+				visit(dependent)
+			}
+		}
+		ordered = append(ordered, urn)
+	}
+	for _, urn := range urns {
+		visit(urn)
+	}
+	return ordered
+}
+
+// dependentsIndex maps each resource to the resources affected when it is
+// destroyed: its children, dependents, and the resources deleted with or
+// provisioned by it
+func dependentsIndex(resources []ResourceInfo) map[string][]string {
+	affects := make(map[string][]string)
+	for _, r := range resources {
+		if r.Parent != "" {
+			affects[r.Parent] = append(affects[r.Parent], r.URN)
+		}
+		for _, dep := range r.Dependencies {
+			affects[dep] = append(affects[dep], r.URN)
+		}
+		if r.DeletedWith != "" {
+			affects[r.DeletedWith] = append(affects[r.DeletedWith], r.URN)
+		}
+		if provider := extractProviderURN(r.Provider); provider != "" {
+			affects[provider] = append(affects[provider], r.URN)
+		}
+	}
+	return affects
+}
@@ -0,0 +1,79 @@
+package pulumi
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBlastRadius(t *testing.T) {
+	const (
+		stack    = "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"
+		provider = "urn:pulumi:dev::app::pulumi:providers:aws::east"
+		vpc      = "urn:pulumi:dev::app::my:index:Network::vpc"
+		subnet   = "urn:pulumi:dev::app::my:index:Network$aws:ec2/subnet:Subnet::subnet"
+		db       = "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+		backup   = "urn:pulumi:dev::app::aws:backup/plan:Plan::db-backup"
+		bucket   = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	)
+	resources := []ResourceInfo{
+		{URN: stack},
+		{URN: provider, Parent: stack},
+		{URN: vpc, Parent: stack},
+		{URN: subnet, Parent: vpc, Provider: provider + "::04da6b54-80e4-46f7-96ec-b56ff0331ba9"},
+		{URN: db, Parent: stack, Dependencies: []string{subnet}},
+		{URN: db, Parent: stack, PendingDelete: true},
+		{URN: backup, Parent: stack, DeletedWith: db},
+		{URN: bucket, Parent: stack},
+	}
+
+	urns := func(radius []ResourceInfo) []string {
+		var out []string
+		for _, r := range radius {
+			out = append(out, r.URN)
+		}
+		return out
+	}
+
+	if got := urns(BlastRadius(resources, []string{vpc})); !slices.Equal(got, []string{vpc, subnet, db, backup}) {
+		t.Errorf("expected the vpc's children and their dependents, got %v", got)
+	}
+	if got := urns(BlastRadius(resources, []string{provider})); !slices.Equal(got, []string{provider, subnet, db, backup}) {
+		t.Errorf("expected the provider's resources and their dependents, got %v", got)
+	}
+	if got := BlastRadius(resources, nil); len(got) != len(resources)-1 {
+		t.Errorf("expected every live resource without targets, got %d", len(got))
+	}
+}
+
+// TestStateDeleteOrder verifies children and dependents are removed before the
+// resources they refer to, whatever order they were selected in
+func TestStateDeleteOrder(t *testing.T) {
+	const (
+		provider = "urn:pulumi:dev::app::pulumi:providers:aws::east"
+		vpc      = "urn:pulumi:dev::app::my:index:Network::vpc"
+		subnet   = "urn:pulumi:dev::app::my:index:Network$aws:ec2/subnet:Subnet::subnet"
+		db       = "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+		backup   = "urn:pulumi:dev::app::aws:backup/plan:Plan::db-backup"
+		bucket   = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+		unknown  = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::gone"
+	)
+	resources := []ResourceInfo{
+		{URN: provider},
+		{URN: vpc},
+		{URN: subnet, Parent: vpc, Provider: provider + "::04da6b54-80e4-46f7-96ec-b56ff0331ba9"},
+		{URN: db, Dependencies: []string{subnet}},
+		{URN: backup, DeletedWith: db},
+		{URN: bucket},
+	}
+
+	got := StateDeleteOrder(resources, []string{provider, vpc, bucket, subnet, db, unknown, backup})
+	want := []string{backup, db, subnet, provider, vpc, bucket, unknown}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Resources outside the selection don't affect the order
+	if got := StateDeleteOrder(resources, []string{vpc, db}); !slices.Equal(got, []string{vpc, db}) {
+		t.Errorf("expected unrelated selections to keep their order, got %v", got)
+	}
+}
@@ -0,0 +1,96 @@
+package pulumi
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CloudStack is a stack of any project of the organizations the logged in user
+// belongs to, as listed by `pulumi stack ls --all`
+type CloudStack struct {
+	Name             string // Fully qualified name, e.g. "acme/app/prod"
+	Org              string
+	Project          string
+	Stack            string
+	LastUpdate       string // Time of the latest update, empty if never updated
+	UpdateInProgress bool
+	ResourceCount    int
+	URL              string // Console URL of the stack
+}
+
+// ListCloudStacks lists the stacks of every project of the backend the user is
+// logged in to, whether or not the program of the project is checked out.
+// Only the Pulumi Cloud backend scopes stacks by organization.
+func ListCloudStacks(ctx context.Context, workDir string, env map[string]string) ([]CloudStack, error) {
+	output, err := runPulumiCommandStdout(ctx, workDir, env, "stack", "ls", "--all", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stacks: %w", err)
+	}
+	return parseCloudStacks(output)
+}
+
+// parseCloudStacks parses the output of `pulumi stack ls --all --json`, sorted
+// by organization, project and stack
+func parseCloudStacks(data []byte) ([]CloudStack, error) {
+	var entries []struct {
+		Name             string `json:"name"`
+		LastUpdate       string `json:"lastUpdate"`
+		UpdateInProgress bool   `json:"updateInProgress"`
+		ResourceCount    *int   `json:"resourceCount"`
+		URL              string `json:"url"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse stack list: %w", err)
+	}
+
+	stacks := make([]CloudStack, 0, len(entries))
+	for _, e := range entries {
+		stack := CloudStack{
+			Name:             e.Name,
+			LastUpdate:       e.LastUpdate,
+			UpdateInProgress: e.UpdateInProgress,
+			URL:              e.URL,
+		}
+		if e.ResourceCount != nil {
+			stack.ResourceCount = *e.ResourceCount
+		}
+		// Names are org/project/stack, or project/stack on backends without organizations
+		parts := strings.Split(e.Name, "/")
+		switch len(parts) {
+		case 3:
+			stack.Org, stack.Project, stack.Stack = parts[0], parts[1], parts[2]
+		case 2:
+			stack.Project, stack.Stack = parts[0], parts[1]
+		default:
+			stack.Stack = e.Name
+		}
+		stacks = append(stacks, stack)
+	}
+	slices.SortFunc(stacks, func(a, b CloudStack) int {
+		return cmp.Or(cmp.Compare(a.Org, b.Org), cmp.Compare(a.Project, b.Project), cmp.Compare(a.Stack, b.Stack))
+	})
+	return stacks, nil
+}
+
+// ExportCloudStack returns the resources of the latest deployment of a stack
+// given by its fully qualified name, read from the backend without needing the
+// program of its project
+func ExportCloudStack(ctx context.Context, workDir, name string, env map[string]string) ([]ResourceInfo, error) {
+	output, err := runPulumiCommandStdout(ctx, workDir, env, "stack", "export", "--stack", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export stack %s: %w", name, err)
+	}
+	state, err := decodeState(output, name)
+	if err != nil {
+		return nil, err
+	}
+	// A stack that was created but never updated has no deployment
+	if len(state.Deployment) == 0 {
+		return nil, nil
+	}
+	return parseDeploymentResources(state.Deployment)
+}
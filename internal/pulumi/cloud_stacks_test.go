@@ -0,0 +1,43 @@
+package pulumi
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseCloudStacks(t *testing.T) {
+	data := []byte(`[
+		{"name": "acme/web/prod", "current": false, "lastUpdate": "2024-01-15T10:00:00.000Z", "resourceCount": 12, "url": "https://app.pulumi.com/acme/web/prod"},
+		{"name": "acme/api/dev", "current": true, "updateInProgress": true},
+		{"name": "api/staging"},
+		{"name": "acme/api/prod", "resourceCount": 3}
+	]`)
+
+	stacks, err := parseCloudStacks(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, s := range stacks {
+		names = append(names, s.Name)
+	}
+	want := []string{"api/staging", "acme/api/dev", "acme/api/prod", "acme/web/prod"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("expected stacks sorted by org, project and stack %v, got %v", want, names)
+	}
+
+	if s := stacks[0]; s.Org != "" || s.Project != "api" || s.Stack != "staging" {
+		t.Errorf("expected a project/stack name without org, got %+v", s)
+	}
+	if s := stacks[1]; s.Org != "acme" || s.Project != "api" || s.Stack != "dev" || !s.UpdateInProgress || s.LastUpdate != "" {
+		t.Errorf("unexpected stack %+v", s)
+	}
+	if s := stacks[3]; s.ResourceCount != 12 || s.LastUpdate != "2024-01-15T10:00:00.000Z" || s.URL != "https://app.pulumi.com/acme/web/prod" {
+		t.Errorf("unexpected stack %+v", s)
+	}
+
+	if _, err := parseCloudStacks([]byte("error: not logged in")); err == nil {
+		t.Error("expected an error for output that isn't a stack list")
+	}
+}
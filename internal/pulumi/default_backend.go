@@ -0,0 +1,27 @@
+package pulumi
+
+import (
+	"context"
+	"os/exec"
+)
+
+// DefaultBackendAuthenticator wraps the existing login functions to implement BackendAuthenticator.
+type DefaultBackendAuthenticator struct{}
+
+// NewBackendAuthenticator creates a new DefaultBackendAuthenticator.
+func NewBackendAuthenticator() *DefaultBackendAuthenticator {
+	return &DefaultBackendAuthenticator{}
+}
+
+// Login logs in to a backend without prompting and returns who is logged in.
+func (d *DefaultBackendAuthenticator) Login(ctx context.Context, workDir string, opts LoginOptions) (*WhoAmIInfo, error) {
+	return Login(ctx, workDir, opts)
+}
+
+// LoginCommand returns the interactive login command for a backend.
+func (d *DefaultBackendAuthenticator) LoginCommand(workDir string, opts LoginOptions) *exec.Cmd {
+	return LoginCommand(workDir, opts)
+}
+
+// Compile-time interface compliance check
+var _ BackendAuthenticator = (*DefaultBackendAuthenticator)(nil)
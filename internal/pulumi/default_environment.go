@@ -0,0 +1,24 @@
+package pulumi
+
+import "context"
+
+// DefaultEnvironmentReader wraps the existing free functions to implement EnvironmentReader.
+type DefaultEnvironmentReader struct{}
+
+// NewEnvironmentReader creates a new DefaultEnvironmentReader.
+func NewEnvironmentReader() *DefaultEnvironmentReader {
+	return &DefaultEnvironmentReader{}
+}
+
+// ListEnvironments returns the names of ESC environments imported by the stack config.
+func (d *DefaultEnvironmentReader) ListEnvironments(workDir, stackName string) ([]string, error) {
+	return ListStackEnvironments(workDir, stackName)
+}
+
+// OpenEnvironment resolves an ESC environment's environment variables and Pulumi config.
+func (d *DefaultEnvironmentReader) OpenEnvironment(ctx context.Context, workDir, name string, opts ReadOptions) (*StackEnvironment, error) {
+	return OpenEnvironment(ctx, workDir, name, opts.Env)
+}
+
+// Compile-time interface compliance check
+var _ EnvironmentReader = (*DefaultEnvironmentReader)(nil)
@@ -0,0 +1,19 @@
+package pulumi
+
+import "context"
+
+// DefaultGitReader wraps the existing free functions to implement GitReader.
+type DefaultGitReader struct{}
+
+// NewGitReader creates a new DefaultGitReader.
+func NewGitReader() *DefaultGitReader {
+	return &DefaultGitReader{}
+}
+
+// ReadGitInfo reads the git checkout containing dir, or returns nil outside of one.
+func (d *DefaultGitReader) ReadGitInfo(ctx context.Context, dir string) (*GitInfo, error) {
+	return ReadGitInfo(ctx, dir)
+}
+
+// Compile-time interface compliance check
+var _ GitReader = (*DefaultGitReader)(nil)
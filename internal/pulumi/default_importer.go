@@ -16,25 +16,45 @@ func (d *DefaultResourceImporter) Import(ctx context.Context, workDir, stackName
 	return ImportResource(ctx, workDir, stackName, resourceType, resourceName, importID, parentURN, opts)
 }
 
+// ImportBatch imports several external resources into the stack in one operation.
+func (d *DefaultResourceImporter) ImportBatch(ctx context.Context, workDir, stackName string, specs []ImportSpec, opts ImportOptions) (*CommandResult, error) {
+	return ImportResources(ctx, workDir, stackName, specs, opts)
+}
+
+// PreviewImport previews importing an external resource without changing the state.
+func (d *DefaultResourceImporter) PreviewImport(ctx context.Context, workDir, stackName string, spec ImportSpec, opts ImportOptions) (*ImportPreview, error) {
+	return PreviewImport(ctx, workDir, stackName, spec, opts)
+}
+
 // StateDelete removes a resource from state without deleting the actual resource.
 func (d *DefaultResourceImporter) StateDelete(ctx context.Context, workDir, stackName, urn string, opts StateDeleteOptions) (*CommandResult, error) {
 	return DeleteFromState(ctx, workDir, stackName, urn, opts)
 }
 
-// Protect marks a resource as protected, preventing it from being destroyed.
-func (d *DefaultResourceImporter) Protect(ctx context.Context, workDir, stackName, urn string, opts StateProtectOptions) (*CommandResult, error) {
-	return ProtectResource(ctx, workDir, stackName, urn, opts)
+// SetProtect sets or clears the protected flag on resources.
+func (d *DefaultResourceImporter) SetProtect(ctx context.Context, workDir, stackName string, urns []string, protect bool, opts StateProtectOptions) (*CommandResult, error) {
+	return SetProtect(ctx, workDir, stackName, urns, protect, opts)
+}
+
+// RepairState applies fixes for inconsistent state, or reports them on a dry run.
+func (d *DefaultResourceImporter) RepairState(ctx context.Context, workDir, stackName string, fixes []StateFix, opts StateRepairOptions) (*StateRepairReport, error) {
+	return RepairState(ctx, workDir, stackName, fixes, opts)
+}
+
+// ResourceState returns the state entry of a resource as indented JSON.
+func (d *DefaultResourceImporter) ResourceState(ctx context.Context, workDir, stackName, urn string, opts StateRepairOptions) ([]byte, error) {
+	return ResourceState(ctx, workDir, stackName, urn, opts)
 }
 
-// Unprotect removes the protected flag from a resource, allowing it to be destroyed.
-func (d *DefaultResourceImporter) Unprotect(ctx context.Context, workDir, stackName, urn string, opts StateProtectOptions) (*CommandResult, error) {
-	return UnprotectResource(ctx, workDir, stackName, urn, opts)
+// SetResourceState replaces the state entry of a resource with edited JSON.
+func (d *DefaultResourceImporter) SetResourceState(ctx context.Context, workDir, stackName, urn string, original, edited []byte, opts StateRepairOptions) error {
+	return SetResourceState(ctx, workDir, stackName, urn, original, edited, opts)
 }
 
 // Compile-time interface compliance check
 var _ ResourceImporter = (*DefaultResourceImporter)(nil)
 
-// DefaultStackInitializer wraps the existing InitStack function to implement StackInitializer.
+// DefaultStackInitializer wraps the existing InitStack, CopyConfig, RemoveStack and NewProject functions to implement StackInitializer.
 type DefaultStackInitializer struct{}
 
 // NewStackInitializer creates a new DefaultStackInitializer.
@@ -43,9 +63,66 @@ func NewStackInitializer() *DefaultStackInitializer {
 }
 
 // InitStack creates a new stack with the given configuration.
-func (d *DefaultStackInitializer) InitStack(ctx context.Context, workDir, stackName string, opts InitStackOptions) error {
+func (d *DefaultStackInitializer) InitStack(ctx context.Context, workDir, stackName string, opts InitStackOptions) (*ConfigCopyResult, error) {
 	return InitStack(ctx, workDir, stackName, opts)
 }
 
+// CopyConfig copies another stack's config to a stack, re-encrypting its secrets.
+func (d *DefaultStackInitializer) CopyConfig(ctx context.Context, workDir, fromStack, toStack string, opts ConfigCopyOptions) (*ConfigCopyResult, error) {
+	return CopyConfig(ctx, workDir, fromStack, toStack, opts)
+}
+
+// RemoveStack deletes a stack without resources and its config.
+func (d *DefaultStackInitializer) RemoveStack(ctx context.Context, workDir, stackName string, opts RemoveStackOptions) error {
+	return RemoveStack(ctx, workDir, stackName, opts)
+}
+
+// NewProject scaffolds a project and its stack from a template into dir.
+func (d *DefaultStackInitializer) NewProject(ctx context.Context, dir string, opts NewProjectOptions) error {
+	return NewProject(ctx, dir, opts)
+}
+
 // Compile-time interface compliance check
 var _ StackInitializer = (*DefaultStackInitializer)(nil)
+
+// DefaultStackStateManager wraps the existing CancelPending and ClearPendingOperations functions to implement StackStateManager.
+type DefaultStackStateManager struct{}
+
+// NewStackStateManager creates a new DefaultStackStateManager.
+func NewStackStateManager() *DefaultStackStateManager {
+	return &DefaultStackStateManager{}
+}
+
+// CancelPending cancels the stack's in-progress update and removes its pending operations.
+func (d *DefaultStackStateManager) CancelPending(ctx context.Context, workDir, stackName string, opts CancelPendingOptions) (*CancelPendingResult, error) {
+	return CancelPending(ctx, workDir, stackName, opts)
+}
+
+// ClearPendingOperations removes the stack's pending operations, leaving any update in progress alone.
+func (d *DefaultStackStateManager) ClearPendingOperations(ctx context.Context, workDir, stackName string, opts CancelPendingOptions) (*CancelPendingResult, error) {
+	return ClearPendingOperations(ctx, workDir, stackName, opts)
+}
+
+// Compile-time interface compliance check
+var _ StackStateManager = (*DefaultStackStateManager)(nil)
+
+// DefaultStateTransferer wraps the existing deployment functions to implement StateTransferer.
+type DefaultStateTransferer struct{}
+
+// NewStateTransferer creates a new DefaultStateTransferer.
+func NewStateTransferer() *DefaultStateTransferer {
+	return &DefaultStateTransferer{}
+}
+
+// ExportDeployment writes the stack's deployment to path.
+func (d *DefaultStateTransferer) ExportDeployment(ctx context.Context, workDir, stackName, path string, opts DeploymentTransferOptions) (*DeploymentFile, error) {
+	return ExportDeployment(ctx, workDir, stackName, path, opts)
+}
+
+// ImportDeployment replaces the stack's state with the deployment saved at path.
+func (d *DefaultStateTransferer) ImportDeployment(ctx context.Context, workDir, stackName, path string, opts DeploymentTransferOptions) (*DeploymentFile, error) {
+	return ImportDeployment(ctx, workDir, stackName, path, opts)
+}
+
+// Compile-time interface compliance check
+var _ StateTransferer = (*DefaultStateTransferer)(nil)
@@ -0,0 +1,29 @@
+package pulumi
+
+import "time"
+
+// DefaultStackLocker wraps the existing free functions to implement StackLocker.
+type DefaultStackLocker struct{}
+
+// NewStackLocker creates a new DefaultStackLocker.
+func NewStackLocker() *DefaultStackLocker {
+	return &DefaultStackLocker{}
+}
+
+// Acquire locks the stack while this process runs op on it.
+func (d *DefaultStackLocker) Acquire(workDir, stackName string, op OperationType) (*StackLock, error) {
+	return AcquireStackLock(workDir, stackName, op, time.Now())
+}
+
+// Holder returns the lock another live process holds on the stack, or nil.
+func (d *DefaultStackLocker) Holder(workDir, stackName string) (*StackLock, error) {
+	return ReadStackLock(workDir, stackName)
+}
+
+// Release removes this process's lock on the stack.
+func (d *DefaultStackLocker) Release(workDir, stackName string) error {
+	return ReleaseStackLock(workDir, stackName)
+}
+
+// Compile-time interface compliance check
+var _ StackLocker = (*DefaultStackLocker)(nil)
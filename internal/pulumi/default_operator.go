@@ -3,7 +3,8 @@ package pulumi
 import "context"
 
 // DefaultStackOperator wraps the existing free functions to implement StackOperator.
-// It owns the event channels and returns receive-only channels to callers.
+// It owns the event channels and returns receive-only channels to callers, tracing
+// each preview and operation with a span and metrics.
 type DefaultStackOperator struct{}
 
 // NewStackOperator creates a new DefaultStackOperator.
@@ -14,6 +15,7 @@ func NewStackOperator() *DefaultStackOperator {
 // Preview runs a preview and returns a channel of events.
 // The opType determines which preview variant to run (up, refresh, destroy).
 func (d *DefaultStackOperator) Preview(ctx context.Context, workDir, stackName string, opType OperationType, opts OperationOptions) <-chan PreviewEvent {
+	ctx, span := startOperationSpan(ctx, "pulumi.preview", opType, workDir, stackName, opts)
 	ch := make(chan PreviewEvent)
 	go func() {
 		switch opType {
@@ -26,34 +28,42 @@ func (d *DefaultStackOperator) Preview(ctx context.Context, workDir, stackName s
 			RunUpPreview(ctx, workDir, stackName, opts, ch)
 		}
 	}()
-	return ch
+	return tracePreview(ctx, span, opType, ch)
 }
 
 // Up executes pulumi up and returns a channel of events.
 func (d *DefaultStackOperator) Up(ctx context.Context, workDir, stackName string, opts OperationOptions) <-chan OperationEvent {
+	ctx, span := startOperationSpan(ctx, "pulumi.up", OperationUp, workDir, stackName, opts)
 	ch := make(chan OperationEvent)
 	go func() {
 		RunUp(ctx, workDir, stackName, opts, ch)
 	}()
-	return ch
+	return traceOperation(ctx, span, OperationUp, ch)
 }
 
 // Refresh executes pulumi refresh and returns a channel of events.
 func (d *DefaultStackOperator) Refresh(ctx context.Context, workDir, stackName string, opts OperationOptions) <-chan OperationEvent {
+	ctx, span := startOperationSpan(ctx, "pulumi.refresh", OperationRefresh, workDir, stackName, opts)
 	ch := make(chan OperationEvent)
 	go func() {
 		RunRefresh(ctx, workDir, stackName, opts, ch)
 	}()
-	return ch
+	return traceOperation(ctx, span, OperationRefresh, ch)
 }
 
 // Destroy executes pulumi destroy and returns a channel of events.
 func (d *DefaultStackOperator) Destroy(ctx context.Context, workDir, stackName string, opts OperationOptions) <-chan OperationEvent {
+	ctx, span := startOperationSpan(ctx, "pulumi.destroy", OperationDestroy, workDir, stackName, opts)
 	ch := make(chan OperationEvent)
 	go func() {
 		RunDestroy(ctx, workDir, stackName, opts, ch)
 	}()
-	return ch
+	return traceOperation(ctx, span, OperationDestroy, ch)
+}
+
+// Cancel asks the backend to stop the stack's running update.
+func (d *DefaultStackOperator) Cancel(ctx context.Context, workDir, stackName string, opts OperationOptions) error {
+	return CancelUpdate(ctx, workDir, stackName, opts)
 }
 
 // Compile-time interface compliance check
@@ -21,6 +21,12 @@ func (d *DefaultStackReader) GetHistory(ctx context.Context, workDir, stackName
 	return GetStackHistory(ctx, workDir, stackName, pageSize, page, opts.Env)
 }
 
+// ExportDeploymentAtVersion returns the resources in the deployment snapshot
+// written by the given update version.
+func (d *DefaultStackReader) ExportDeploymentAtVersion(ctx context.Context, workDir, stackName string, version int, opts ReadOptions) ([]ResourceInfo, error) {
+	return ExportStackAtVersion(ctx, workDir, stackName, version, opts.Env)
+}
+
 // GetStacks returns available stacks for a workspace.
 func (d *DefaultStackReader) GetStacks(ctx context.Context, workDir string, opts ReadOptions) ([]StackInfo, error) {
 	return ListStacks(ctx, workDir, opts.Env)
@@ -31,5 +37,37 @@ func (d *DefaultStackReader) SelectStack(ctx context.Context, workDir, stackName
 	return SelectStack(ctx, workDir, stackName, opts.Env)
 }
 
+// GetPendingOperations returns the operations left unfinished in the stack's state.
+func (d *DefaultStackReader) GetPendingOperations(ctx context.Context, workDir, stackName string, opts ReadOptions) ([]PendingOperation, error) {
+	return GetPendingOperations(ctx, workDir, stackName, opts.Env)
+}
+
+// GetTags returns the stack's tags.
+func (d *DefaultStackReader) GetTags(ctx context.Context, workDir, stackName string, opts ReadOptions) (map[string]string, error) {
+	return GetStackTags(ctx, workDir, stackName, opts.Env)
+}
+
+// SetTag sets a tag on the stack. An empty value removes the tag.
+func (d *DefaultStackReader) SetTag(ctx context.Context, workDir, stackName, key, value string, opts ReadOptions) error {
+	return SetStackTag(ctx, workDir, stackName, key, value, opts.Env)
+}
+
+// GetCloudStacks returns the stacks of every project in the backend's organizations.
+func (d *DefaultStackReader) GetCloudStacks(ctx context.Context, workDir string, opts ReadOptions) ([]CloudStack, error) {
+	return ListCloudStacks(ctx, workDir, opts.Env)
+}
+
+// ExportCloudStack returns the resources of a stack given by its fully qualified
+// name, without needing its program.
+func (d *DefaultStackReader) ExportCloudStack(ctx context.Context, workDir, name string, opts ReadOptions) ([]ResourceInfo, error) {
+	return ExportCloudStack(ctx, workDir, name, opts.Env)
+}
+
+// RevealSecret decrypts the secret at a property path of a resource with the
+// stack's secrets manager.
+func (d *DefaultStackReader) RevealSecret(ctx context.Context, workDir, stackName, urn, path string, opts ReadOptions) (any, error) {
+	return RevealSecret(ctx, workDir, stackName, urn, path, opts.Env)
+}
+
 // Compile-time interface compliance check
 var _ StackReader = (*DefaultStackReader)(nil)
@@ -16,8 +16,8 @@ func (d *DefaultWorkspaceReader) GetProjectInfo(ctx context.Context, workDir, st
 }
 
 // FindWorkspaces finds Pulumi workspaces in a directory tree.
-func (d *DefaultWorkspaceReader) FindWorkspaces(startDir, currentWorkDir string) ([]WorkspaceInfo, error) {
-	return FindWorkspaces(startDir, currentWorkDir)
+func (d *DefaultWorkspaceReader) FindWorkspaces(startDir, currentWorkDir string, opts WorkspaceSearchOptions) ([]WorkspaceInfo, error) {
+	return FindWorkspaces(startDir, currentWorkDir, opts)
 }
 
 // IsWorkspace checks if the given directory is a valid Pulumi workspace.
@@ -30,6 +30,11 @@ func (d *DefaultWorkspaceReader) GetWhoAmI(ctx context.Context, workDir string,
 	return GetWhoAmI(ctx, workDir, opts.Env)
 }
 
+// GetAbout returns the Pulumi CLI version, backend and installed plugins.
+func (d *DefaultWorkspaceReader) GetAbout(ctx context.Context, workDir string, opts ReadOptions) (*AboutInfo, error) {
+	return GetAbout(ctx, workDir, opts.Env)
+}
+
 // ListStackFiles finds all Pulumi.<stack>.yaml files in the workspace.
 func (d *DefaultWorkspaceReader) ListStackFiles(workDir string) ([]StackFileInfo, error) {
 	return ListStackFiles(workDir)
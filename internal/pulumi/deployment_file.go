@@ -0,0 +1,127 @@
+package pulumi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+)
+
+// ExportDeployment writes the stack's deployment to path in the format of
+// `pulumi stack export`. Secrets stay encrypted.
+func ExportDeployment(ctx context.Context, workDir, stackName, path string, opts DeploymentTransferOptions) (*DeploymentFile, error) {
+	stack, err := selectStack(ctx, workDir, stackName, opts.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := stack.Export(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export stack: %w", err)
+	}
+	file, err := describeDeployment(state)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(state, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode deployment: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write deployment: %w", err)
+	}
+	file.Path = path
+	return file, nil
+}
+
+// ImportDeployment replaces the stack's state with the deployment saved at path,
+// like `pulumi stack import`. The file is validated before anything is written.
+func ImportDeployment(ctx context.Context, workDir, stackName, path string, opts DeploymentTransferOptions) (*DeploymentFile, error) {
+	state, file, err := readDeploymentFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stack, err := selectStack(ctx, workDir, stackName, opts.Env)
+	if err != nil {
+		return nil, err
+	}
+	if err := stack.Import(ctx, state); err != nil {
+		return nil, fmt.Errorf("failed to import state: %w", err)
+	}
+	return file, nil
+}
+
+// ReadDeploymentFile validates the deployment saved at path, as written by
+// `pulumi stack export`, and describes it
+func ReadDeploymentFile(path string) (*DeploymentFile, error) {
+	_, file, err := readDeploymentFile(path)
+	return file, err
+}
+
+func readDeploymentFile(path string) (apitype.UntypedDeployment, *DeploymentFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return apitype.UntypedDeployment{}, nil, fmt.Errorf("failed to read deployment: %w", err)
+	}
+	state, err := decodeState(data, path)
+	if err != nil {
+		return state, nil, err
+	}
+
+	file, err := describeDeployment(state)
+	if err != nil {
+		return state, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	file.Path = path
+	return state, file, nil
+}
+
+// describeDeployment checks that state is a deployment Pulumi can import and
+// counts its resources. Its resources must all belong to the same stack.
+func describeDeployment(state apitype.UntypedDeployment) (*DeploymentFile, error) {
+	if len(state.Deployment) == 0 {
+		return nil, errors.New("not an exported stack deployment")
+	}
+	if state.Version < 1 || state.Version > apitype.DeploymentSchemaVersionLatest {
+		return nil, fmt.Errorf("unsupported deployment version %d", state.Version)
+	}
+
+	var deployment struct {
+		Manifest json.RawMessage `json:"manifest"`
+	}
+	if err := json.Unmarshal(state.Deployment, &deployment); err != nil {
+		return nil, fmt.Errorf("failed to parse deployment: %w", err)
+	}
+	if len(deployment.Manifest) == 0 {
+		return nil, errors.New("deployment has no manifest")
+	}
+	resources, err := parseDeploymentResources(state.Deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &DeploymentFile{Version: state.Version, Resources: len(resources)}
+	for i, r := range resources {
+		stack, project, ok := ParseStackURN(r.URN)
+		if !ok {
+			return nil, fmt.Errorf("resource %d has invalid URN %q", i, r.URN)
+		}
+		if i == 0 {
+			file.Stack, file.Project = stack, project
+			continue
+		}
+		if stack != file.Stack || project != file.Project {
+			return nil, fmt.Errorf("deployment mixes resources of stacks %s/%s and %s/%s", file.Project, file.Stack, project, stack)
+		}
+	}
+	return file, nil
+}
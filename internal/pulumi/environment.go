@@ -0,0 +1,136 @@
+package pulumi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StackEnvironment is a resolved Pulumi ESC environment imported by a stack
+type StackEnvironment struct {
+	Name                 string
+	EnvironmentVariables map[string]string
+	PulumiConfig         map[string]any
+}
+
+// EnvVarNames returns the environment variable names in sorted order
+func (e *StackEnvironment) EnvVarNames() []string {
+	names := make([]string, 0, len(e.EnvironmentVariables))
+	for k := range e.EnvironmentVariables {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ConfigKeys returns the Pulumi config keys in sorted order
+func (e *StackEnvironment) ConfigKeys() []string {
+	keys := make([]string, 0, len(e.PulumiConfig))
+	for k := range e.PulumiConfig {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ListStackEnvironments returns the ESC environments imported by a stack's
+// Pulumi.<stack>.yaml. Both the list form and the `imports:` form of the
+// `environment` key are supported. A missing stack file yields no environments.
+func ListStackEnvironments(workDir, stackName string) ([]string, error) {
+	if stackName == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Join(workDir, "Pulumi."+stackName+".yaml"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read stack config: %w", err)
+	}
+	return parseStackEnvironments(data)
+}
+
+// parseStackEnvironments extracts environment imports from stack config YAML
+func parseStackEnvironments(data []byte) ([]string, error) {
+	var config struct {
+		Environment yaml.Node `yaml:"environment"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse stack config: %w", err)
+	}
+
+	node := &config.Environment
+	if node.Kind == yaml.MappingNode {
+		var block struct {
+			Imports yaml.Node `yaml:"imports"`
+		}
+		if err := node.Decode(&block); err != nil {
+			return nil, fmt.Errorf("failed to parse environment imports: %w", err)
+		}
+		node = &block.Imports
+	}
+	if node.Kind != yaml.SequenceNode {
+		return nil, nil
+	}
+
+	var names []string
+	for _, item := range node.Content {
+		switch item.Kind {
+		case yaml.ScalarNode:
+			names = append(names, item.Value)
+		case yaml.MappingNode:
+			// Imports with options are written as `- name: {merge: false}`
+			if len(item.Content) > 0 {
+				names = append(names, item.Content[0].Value)
+			}
+		}
+	}
+	return names, nil
+}
+
+// OpenEnvironment resolves an ESC environment via `pulumi env open` and
+// returns its environment variables and Pulumi config values
+func OpenEnvironment(ctx context.Context, workDir, name string, env map[string]string) (*StackEnvironment, error) {
+	output, err := runPulumiCommandStdout(ctx, workDir, env, "env", "open", name, "--format", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open environment %s: %w", name, err)
+	}
+	return parseOpenEnvironment(name, output)
+}
+
+// parseOpenEnvironment parses the JSON values printed by `pulumi env open`
+func parseOpenEnvironment(name string, data []byte) (*StackEnvironment, error) {
+	var values struct {
+		EnvironmentVariables map[string]any `json:"environmentVariables"`
+		PulumiConfig         map[string]any `json:"pulumiConfig"`
+	}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse environment %s: %w", name, err)
+	}
+
+	result := &StackEnvironment{
+		Name:                 name,
+		EnvironmentVariables: make(map[string]string, len(values.EnvironmentVariables)),
+		PulumiConfig:         values.PulumiConfig,
+	}
+	for k, v := range values.EnvironmentVariables {
+		if s, ok := v.(string); ok {
+			result.EnvironmentVariables[k] = s
+			continue
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		result.EnvironmentVariables[k] = string(encoded)
+	}
+	if result.PulumiConfig == nil {
+		result.PulumiConfig = make(map[string]any)
+	}
+	return result, nil
+}
@@ -0,0 +1,193 @@
+package pulumi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StreamEvent is a preview or operation event as written by --events-json, one
+// JSON object per line, for tooling observing p5-driven deployments
+type StreamEvent struct {
+	Time      time.Time `json:"time"`
+	Kind      string    `json:"kind"`      // "preview" or "operation"
+	Operation string    `json:"operation"` // "up", "refresh" or "destroy"
+	Stack     string    `json:"stack"`
+	// What happened: "step", "warning" or "diagnostic" in previews, "resource"
+	// or "message" in operations, and "error" or "done" in both
+	Event       string         `json:"event"`
+	URN         string         `json:"urn,omitempty"`
+	Type        string         `json:"type,omitempty"`
+	Name        string         `json:"name,omitempty"`
+	Parent      string         `json:"parent,omitempty"`
+	Op          ResourceOp     `json:"op,omitempty"`
+	Status      string         `json:"status,omitempty"`   // "pending", "running", "success" or "failed"
+	Severity    string         `json:"severity,omitempty"` // Of warnings and diagnostics
+	Policy      string         `json:"policy,omitempty"`
+	Message     string         `json:"message,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	ReplaceKeys []string       `json:"replaceKeys,omitempty"`
+	Inputs      map[string]any `json:"inputs,omitempty"`
+	Outputs     map[string]any `json:"outputs,omitempty"`
+	OldInputs   map[string]any `json:"oldInputs,omitempty"`
+	OldOutputs  map[string]any `json:"oldOutputs,omitempty"`
+}
+
+// EventStreamOperator wraps a StackOperator, writing each event it forwards as a
+// line of JSON without secret values. Write errors are ignored.
+type EventStreamOperator struct {
+	StackOperator
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewEventStreamOperator creates an EventStreamOperator writing the events of op to w
+func NewEventStreamOperator(op StackOperator, w io.Writer) *EventStreamOperator {
+	return &EventStreamOperator{StackOperator: op, enc: json.NewEncoder(w)}
+}
+
+// Preview runs a preview, streaming its events.
+func (s *EventStreamOperator) Preview(ctx context.Context, workDir, stackName string, opType OperationType, opts OperationOptions) <-chan PreviewEvent {
+	in := s.StackOperator.Preview(ctx, workDir, stackName, opType, opts)
+	out := make(chan PreviewEvent)
+	go func() {
+		defer close(out)
+		for event := range in {
+			s.write(previewStreamEvent(stackName, opType, event))
+			out <- event
+		}
+	}()
+	return out
+}
+
+// Up executes pulumi up, streaming its events.
+func (s *EventStreamOperator) Up(ctx context.Context, workDir, stackName string, opts OperationOptions) <-chan OperationEvent {
+	return s.streamOperation(stackName, OperationUp, s.StackOperator.Up(ctx, workDir, stackName, opts))
+}
+
+// Refresh executes pulumi refresh, streaming its events.
+func (s *EventStreamOperator) Refresh(ctx context.Context, workDir, stackName string, opts OperationOptions) <-chan OperationEvent {
+	return s.streamOperation(stackName, OperationRefresh, s.StackOperator.Refresh(ctx, workDir, stackName, opts))
+}
+
+// Destroy executes pulumi destroy, streaming its events.
+func (s *EventStreamOperator) Destroy(ctx context.Context, workDir, stackName string, opts OperationOptions) <-chan OperationEvent {
+	return s.streamOperation(stackName, OperationDestroy, s.StackOperator.Destroy(ctx, workDir, stackName, opts))
+}
+
+// streamOperation forwards an operation's events, writing each
+func (s *EventStreamOperator) streamOperation(stackName string, op OperationType, in <-chan OperationEvent) <-chan OperationEvent {
+	out := make(chan OperationEvent)
+	go func() {
+		defer close(out)
+		for event := range in {
+			s.write(operationStreamEvent(stackName, op, event))
+			out <- event
+		}
+	}()
+	return out
+}
+
+// write writes an event as a line of JSON. Previews and operations may run at
+// once, like the quick check during an up, so lines are written one at a time.
+func (s *EventStreamOperator) write(event StreamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.enc.Encode(event)
+}
+
+// previewStreamEvent converts a preview event for the stream
+func previewStreamEvent(stackName string, op OperationType, event PreviewEvent) StreamEvent {
+	out := StreamEvent{
+		Time:      time.Now().UTC(),
+		Kind:      "preview",
+		Operation: strings.ToLower(op.String()),
+		Stack:     stackName,
+	}
+	switch {
+	case event.Error != nil:
+		out.Event = "error"
+		out.Error = event.Error.Error()
+	case event.Done:
+		out.Event = "done"
+	case event.Step != nil:
+		step := event.Step
+		out.Event = "step"
+		out.URN, out.Type, out.Name, out.Parent, out.Op = step.URN, step.Type, step.Name, step.Parent, step.Op
+		out.ReplaceKeys, out.Inputs, out.Outputs = step.ReplaceKeys, maskSecrets(step.Inputs), maskSecrets(step.Outputs)
+		if step.Old != nil {
+			out.OldInputs, out.OldOutputs = maskSecrets(step.Old.Inputs), maskSecrets(step.Old.Outputs)
+		}
+	case event.Warning != nil:
+		out.Event = "warning"
+		out.URN, out.Message, out.Policy = event.Warning.URN, event.Warning.Message, event.Warning.Policy
+		out.Severity = "warning"
+		if event.Warning.Severity == WarningSeverityError {
+			out.Severity = "error"
+		}
+	case event.Diagnostic != nil:
+		out.Event = "diagnostic"
+		out.URN, out.Message, out.Severity = event.Diagnostic.URN, event.Diagnostic.Message, event.Diagnostic.Severity
+	}
+	return out
+}
+
+// operationStreamEvent converts an operation event for the stream
+func operationStreamEvent(stackName string, op OperationType, event OperationEvent) StreamEvent {
+	out := StreamEvent{
+		Time:        event.Time.UTC(),
+		Kind:        "operation",
+		Operation:   strings.ToLower(op.String()),
+		Stack:       stackName,
+		URN:         event.URN,
+		Type:        event.Type,
+		Name:        event.Name,
+		Parent:      event.Parent,
+		Op:          event.Op,
+		Message:     event.Message,
+		ReplaceKeys: event.ReplaceKeys,
+		Inputs:      maskSecrets(event.Inputs),
+		Outputs:     maskSecrets(event.Outputs),
+		OldInputs:   maskSecrets(event.OldInputs),
+		OldOutputs:  maskSecrets(event.OldOutputs),
+	}
+	if event.Time.IsZero() {
+		out.Time = time.Now().UTC()
+	}
+	if event.URN != "" {
+		out.Status = stepStatusName(event.Status)
+	}
+	switch {
+	case event.Error != nil:
+		out.Event = "error"
+		out.Error = event.Error.Error()
+	case event.Done:
+		out.Event = "done"
+	case event.URN != "":
+		out.Event = "resource"
+	default:
+		out.Event = "message"
+	}
+	return out
+}
+
+// stepStatusName returns the name of a step status in the stream
+func stepStatusName(status StepStatus) string {
+	switch status {
+	case StepRunning:
+		return "running"
+	case StepSuccess:
+		return "success"
+	case StepFailed:
+		return "failed"
+	default:
+		return "pending"
+	}
+}
+
+// Compile-time interface compliance check
+var _ StackOperator = (*EventStreamOperator)(nil)
@@ -0,0 +1,82 @@
+package pulumi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestEventStreamOperator verifies preview and operation events are forwarded
+// unchanged and written as one line of JSON each
+func TestEventStreamOperator(t *testing.T) {
+	const bucket = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	fake := &FakeStackOperator{
+		PreviewFunc: func(context.Context, string, string, OperationType, OperationOptions) <-chan PreviewEvent {
+			ch := make(chan PreviewEvent, 3)
+			ch <- PreviewEvent{Step: &PreviewStep{URN: bucket, Op: OpCreate, Type: "aws:s3/bucket:Bucket", Name: "logs", Inputs: map[string]any{
+				"token": map[string]any{secretSigKey: secretSig, "plaintext": `"hunter2"`},
+			}}}
+			ch <- PreviewEvent{Warning: &PreviewWarning{Severity: WarningSeverityError, URN: bucket, Message: "must be private", Policy: "security/private"}}
+			ch <- PreviewEvent{Done: true}
+			close(ch)
+			return ch
+		},
+		UpFunc: func(context.Context, string, string, OperationOptions) <-chan OperationEvent {
+			ch := make(chan OperationEvent, 2)
+			ch <- OperationEvent{URN: bucket, Op: OpCreate, Status: StepFailed, Time: start}
+			ch <- OperationEvent{Done: true, Error: errors.New("update failed")}
+			close(ch)
+			return ch
+		},
+	}
+	var buf bytes.Buffer
+	op := NewEventStreamOperator(fake, &buf)
+
+	var previewEvents, upEvents int
+	for range op.Preview(context.Background(), "/fake/path", "dev", OperationUp, OperationOptions{}) {
+		previewEvents++
+	}
+	for range op.Up(context.Background(), "/fake/path", "dev", OperationOptions{}) {
+		upEvents++
+	}
+	if previewEvents != 3 || upEvents != 2 {
+		t.Fatalf("expected all events forwarded, got %d preview and %d up events", previewEvents, upEvents)
+	}
+
+	var got []StreamEvent
+	for line := range strings.SplitSeq(strings.TrimSpace(buf.String()), "\n") {
+		var event StreamEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("expected a JSON object per line, got %q: %v", line, err)
+		}
+		got = append(got, event)
+	}
+	if len(got) != 5 {
+		t.Fatalf("expected 5 events written, got %d", len(got))
+	}
+
+	want := []struct{ kind, event, extra string }{
+		{"preview", "step", string(OpCreate)},
+		{"preview", "warning", "error"},
+		{"preview", "done", ""},
+		{"operation", "resource", "failed"},
+		{"operation", "error", "update failed"},
+	}
+	extras := []string{string(got[0].Op), got[1].Severity, "", got[3].Status, got[4].Error}
+	for i, w := range want {
+		if got[i].Kind != w.kind || got[i].Event != w.event || got[i].Operation != "up" || got[i].Stack != "dev" || extras[i] != w.extra {
+			t.Errorf("event %d: expected %s %s %q, got %+v", i, w.kind, w.event, w.extra, got[i])
+		}
+	}
+	if strings.Contains(buf.String(), "hunter2") || !IsSecret(got[0].Inputs["token"]) {
+		t.Errorf("expected the secret's value dropped, got %v", got[0].Inputs)
+	}
+	if !got[3].Time.Equal(start) {
+		t.Errorf("expected the engine's event time, got %v", got[3].Time)
+	}
+}
@@ -2,6 +2,10 @@ package pulumi
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"os/exec"
+	"sync"
 )
 
 // FakeStackOperator implements StackOperator for testing.
@@ -20,12 +24,16 @@ type FakeStackOperator struct {
 	// DestroyFunc optionally configures Destroy behavior.
 	DestroyFunc func(ctx context.Context, workDir, stackName string, opts OperationOptions) <-chan OperationEvent
 
+	// CancelFunc optionally configures Cancel behavior.
+	CancelFunc func(ctx context.Context, workDir, stackName string, opts OperationOptions) error
+
 	// Calls tracks all method invocations for assertions.
 	Calls struct {
 		Preview []PreviewCall
 		Up      []OperationCall
 		Refresh []OperationCall
 		Destroy []OperationCall
+		Cancel  []OperationCall
 	}
 }
 
@@ -84,6 +92,14 @@ func (f *FakeStackOperator) Destroy(ctx context.Context, workDir, stackName stri
 	return ch
 }
 
+func (f *FakeStackOperator) Cancel(ctx context.Context, workDir, stackName string, opts OperationOptions) error {
+	f.Calls.Cancel = append(f.Calls.Cancel, OperationCall{workDir, stackName, opts})
+	if f.CancelFunc != nil {
+		return f.CancelFunc(ctx, workDir, stackName, opts)
+	}
+	return nil
+}
+
 // WithPreviewEvents is a helper that configures PreviewFunc to return the given events.
 func (f *FakeStackOperator) WithPreviewEvents(events ...PreviewEvent) *FakeStackOperator {
 	f.PreviewFunc = func(ctx context.Context, workDir, stackName string, opType OperationType, opts OperationOptions) <-chan PreviewEvent {
@@ -123,23 +139,60 @@ type FakeStackReader struct {
 	// GetHistoryFunc optionally configures GetHistory behavior.
 	GetHistoryFunc func(ctx context.Context, workDir, stackName string, pageSize, page int, opts ReadOptions) ([]UpdateSummary, error)
 
+	// ExportDeploymentAtVersionFunc optionally configures ExportDeploymentAtVersion behavior.
+	ExportDeploymentAtVersionFunc func(ctx context.Context, workDir, stackName string, version int, opts ReadOptions) ([]ResourceInfo, error)
+
 	// GetStacksFunc optionally configures GetStacks behavior.
 	GetStacksFunc func(ctx context.Context, workDir string, opts ReadOptions) ([]StackInfo, error)
 
 	// SelectStackFunc optionally configures SelectStack behavior.
 	SelectStackFunc func(ctx context.Context, workDir, stackName string, opts ReadOptions) error
 
+	// GetPendingOperationsFunc optionally configures GetPendingOperations behavior.
+	GetPendingOperationsFunc func(ctx context.Context, workDir, stackName string, opts ReadOptions) ([]PendingOperation, error)
+
+	// GetTagsFunc optionally configures GetTags behavior.
+	GetTagsFunc func(ctx context.Context, workDir, stackName string, opts ReadOptions) (map[string]string, error)
+
+	// SetTagFunc optionally configures SetTag behavior.
+	SetTagFunc func(ctx context.Context, workDir, stackName, key, value string, opts ReadOptions) error
+
+	// GetCloudStacksFunc optionally configures GetCloudStacks behavior.
+	GetCloudStacksFunc func(ctx context.Context, workDir string, opts ReadOptions) ([]CloudStack, error)
+
+	// ExportCloudStackFunc optionally configures ExportCloudStack behavior.
+	ExportCloudStackFunc func(ctx context.Context, workDir, name string, opts ReadOptions) ([]ResourceInfo, error)
+
+	// RevealSecretFunc optionally configures RevealSecret behavior.
+	RevealSecretFunc func(ctx context.Context, workDir, stackName, urn, path string, opts ReadOptions) (any, error)
+
 	// Default return values (used when funcs are nil)
-	Resources []ResourceInfo
-	History   []UpdateSummary
-	Stacks    []StackInfo
+	Resources   []ResourceInfo
+	History     []UpdateSummary
+	Stacks      []StackInfo
+	Deployments map[int][]ResourceInfo // Snapshots keyed by update version
+	PendingOps  []PendingOperation
+	Tags        map[string]string // Updated by SetTag when SetTagFunc is nil
+	CloudStacks []CloudStack
+	CloudStates map[string][]ResourceInfo // Resources of cloud stacks, by fully qualified name
+	Secrets     map[string]map[string]any // Values returned by RevealSecret, by URN and path
+
+	// mu guards Calls, since the dashboard reads stacks concurrently
+	mu sync.Mutex
 
 	// Calls tracks all method invocations.
 	Calls struct {
-		GetResources []GetResourcesCall
-		GetHistory   []GetHistoryCall
-		GetStacks    []GetStacksCall
-		SelectStack  []SelectStackCall
+		GetResources              []GetResourcesCall
+		GetHistory                []GetHistoryCall
+		ExportDeploymentAtVersion []ExportDeploymentAtVersionCall
+		GetStacks                 []GetStacksCall
+		SelectStack               []SelectStackCall
+		GetPendingOperations      []GetPendingOperationsCall
+		GetTags                   []GetTagsCall
+		SetTag                    []SetTagCall
+		GetCloudStacks            []GetStacksCall
+		ExportCloudStack          []ExportCloudStackCall
+		RevealSecret              []RevealSecretCall
 	}
 }
 
@@ -157,6 +210,13 @@ type GetHistoryCall struct {
 	Opts      ReadOptions
 }
 
+type ExportDeploymentAtVersionCall struct {
+	WorkDir   string
+	StackName string
+	Version   int
+	Opts      ReadOptions
+}
+
 type GetStacksCall struct {
 	WorkDir string
 	Opts    ReadOptions
@@ -168,8 +228,36 @@ type SelectStackCall struct {
 	Opts      ReadOptions
 }
 
+type GetPendingOperationsCall struct {
+	WorkDir   string
+	StackName string
+	Opts      ReadOptions
+}
+
+type GetTagsCall struct {
+	WorkDir   string
+	StackName string
+	Opts      ReadOptions
+}
+
+type SetTagCall struct {
+	WorkDir   string
+	StackName string
+	Key       string
+	Value     string
+	Opts      ReadOptions
+}
+
+type ExportCloudStackCall struct {
+	WorkDir string
+	Name    string
+	Opts    ReadOptions
+}
+
 func (f *FakeStackReader) GetResources(ctx context.Context, workDir, stackName string, opts ReadOptions) ([]ResourceInfo, error) {
+	f.mu.Lock()
 	f.Calls.GetResources = append(f.Calls.GetResources, GetResourcesCall{workDir, stackName, opts})
+	f.mu.Unlock()
 	if f.GetResourcesFunc != nil {
 		return f.GetResourcesFunc(ctx, workDir, stackName, opts)
 	}
@@ -177,15 +265,29 @@ func (f *FakeStackReader) GetResources(ctx context.Context, workDir, stackName s
 }
 
 func (f *FakeStackReader) GetHistory(ctx context.Context, workDir, stackName string, pageSize, page int, opts ReadOptions) ([]UpdateSummary, error) {
+	f.mu.Lock()
 	f.Calls.GetHistory = append(f.Calls.GetHistory, GetHistoryCall{workDir, stackName, pageSize, page, opts})
+	f.mu.Unlock()
 	if f.GetHistoryFunc != nil {
 		return f.GetHistoryFunc(ctx, workDir, stackName, pageSize, page, opts)
 	}
 	return f.History, nil
 }
 
+func (f *FakeStackReader) ExportDeploymentAtVersion(ctx context.Context, workDir, stackName string, version int, opts ReadOptions) ([]ResourceInfo, error) {
+	f.mu.Lock()
+	f.Calls.ExportDeploymentAtVersion = append(f.Calls.ExportDeploymentAtVersion, ExportDeploymentAtVersionCall{workDir, stackName, version, opts})
+	f.mu.Unlock()
+	if f.ExportDeploymentAtVersionFunc != nil {
+		return f.ExportDeploymentAtVersionFunc(ctx, workDir, stackName, version, opts)
+	}
+	return f.Deployments[version], nil
+}
+
 func (f *FakeStackReader) GetStacks(ctx context.Context, workDir string, opts ReadOptions) ([]StackInfo, error) {
+	f.mu.Lock()
 	f.Calls.GetStacks = append(f.Calls.GetStacks, GetStacksCall{workDir, opts})
+	f.mu.Unlock()
 	if f.GetStacksFunc != nil {
 		return f.GetStacksFunc(ctx, workDir, opts)
 	}
@@ -193,20 +295,106 @@ func (f *FakeStackReader) GetStacks(ctx context.Context, workDir string, opts Re
 }
 
 func (f *FakeStackReader) SelectStack(ctx context.Context, workDir, stackName string, opts ReadOptions) error {
+	f.mu.Lock()
 	f.Calls.SelectStack = append(f.Calls.SelectStack, SelectStackCall{workDir, stackName, opts})
+	f.mu.Unlock()
 	if f.SelectStackFunc != nil {
 		return f.SelectStackFunc(ctx, workDir, stackName, opts)
 	}
 	return nil
 }
 
+func (f *FakeStackReader) GetPendingOperations(ctx context.Context, workDir, stackName string, opts ReadOptions) ([]PendingOperation, error) {
+	f.mu.Lock()
+	f.Calls.GetPendingOperations = append(f.Calls.GetPendingOperations, GetPendingOperationsCall{workDir, stackName, opts})
+	f.mu.Unlock()
+	if f.GetPendingOperationsFunc != nil {
+		return f.GetPendingOperationsFunc(ctx, workDir, stackName, opts)
+	}
+	return f.PendingOps, nil
+}
+
+func (f *FakeStackReader) GetTags(ctx context.Context, workDir, stackName string, opts ReadOptions) (map[string]string, error) {
+	f.mu.Lock()
+	f.Calls.GetTags = append(f.Calls.GetTags, GetTagsCall{workDir, stackName, opts})
+	f.mu.Unlock()
+	if f.GetTagsFunc != nil {
+		return f.GetTagsFunc(ctx, workDir, stackName, opts)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return maps.Clone(f.Tags), nil
+}
+
+func (f *FakeStackReader) SetTag(ctx context.Context, workDir, stackName, key, value string, opts ReadOptions) error {
+	f.mu.Lock()
+	f.Calls.SetTag = append(f.Calls.SetTag, SetTagCall{workDir, stackName, key, value, opts})
+	f.mu.Unlock()
+	if f.SetTagFunc != nil {
+		return f.SetTagFunc(ctx, workDir, stackName, key, value, opts)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if value == "" {
+		delete(f.Tags, key)
+		return nil
+	}
+	if f.Tags == nil {
+		f.Tags = make(map[string]string)
+	}
+	f.Tags[key] = value
+	return nil
+}
+
+func (f *FakeStackReader) GetCloudStacks(ctx context.Context, workDir string, opts ReadOptions) ([]CloudStack, error) {
+	f.mu.Lock()
+	f.Calls.GetCloudStacks = append(f.Calls.GetCloudStacks, GetStacksCall{workDir, opts})
+	f.mu.Unlock()
+	if f.GetCloudStacksFunc != nil {
+		return f.GetCloudStacksFunc(ctx, workDir, opts)
+	}
+	return f.CloudStacks, nil
+}
+
+func (f *FakeStackReader) ExportCloudStack(ctx context.Context, workDir, name string, opts ReadOptions) ([]ResourceInfo, error) {
+	f.mu.Lock()
+	f.Calls.ExportCloudStack = append(f.Calls.ExportCloudStack, ExportCloudStackCall{workDir, name, opts})
+	f.mu.Unlock()
+	if f.ExportCloudStackFunc != nil {
+		return f.ExportCloudStackFunc(ctx, workDir, name, opts)
+	}
+	return f.CloudStates[name], nil
+}
+
+type RevealSecretCall struct {
+	WorkDir   string
+	StackName string
+	URN       string
+	Path      string
+	Opts      ReadOptions
+}
+
+func (f *FakeStackReader) RevealSecret(ctx context.Context, workDir, stackName, urn, path string, opts ReadOptions) (any, error) {
+	f.mu.Lock()
+	f.Calls.RevealSecret = append(f.Calls.RevealSecret, RevealSecretCall{workDir, stackName, urn, path, opts})
+	f.mu.Unlock()
+	if f.RevealSecretFunc != nil {
+		return f.RevealSecretFunc(ctx, workDir, stackName, urn, path, opts)
+	}
+	value, ok := f.Secrets[urn][path]
+	if !ok {
+		return nil, errors.New("no secret " + path + " in the state of " + urn)
+	}
+	return value, nil
+}
+
 // FakeWorkspaceReader implements WorkspaceReader for testing.
 type FakeWorkspaceReader struct {
 	// GetProjectInfoFunc optionally configures GetProjectInfo behavior.
 	GetProjectInfoFunc func(ctx context.Context, workDir, stackName string, opts ReadOptions) (*ProjectInfo, error)
 
 	// FindWorkspacesFunc optionally configures FindWorkspaces behavior.
-	FindWorkspacesFunc func(startDir, currentWorkDir string) ([]WorkspaceInfo, error)
+	FindWorkspacesFunc func(startDir, currentWorkDir string, opts WorkspaceSearchOptions) ([]WorkspaceInfo, error)
 
 	// IsWorkspaceFunc optionally configures IsWorkspace behavior.
 	IsWorkspaceFunc func(dir string) bool
@@ -217,12 +405,19 @@ type FakeWorkspaceReader struct {
 	// ListStackFilesFunc optionally configures ListStackFiles behavior.
 	ListStackFilesFunc func(workDir string) ([]StackFileInfo, error)
 
+	// GetAboutFunc optionally configures GetAbout behavior.
+	GetAboutFunc func(ctx context.Context, workDir string, opts ReadOptions) (*AboutInfo, error)
+
 	// Default return values
 	ProjectInfo  *ProjectInfo
 	Workspaces   []WorkspaceInfo
 	ValidWorkDir bool // Default for IsWorkspace
 	WhoAmI       *WhoAmIInfo
 	StackFiles   []StackFileInfo
+	About        *AboutInfo
+
+	// mu guards Calls, since init reads the workspace concurrently
+	mu sync.Mutex
 
 	// Calls tracks all method invocations.
 	Calls struct {
@@ -231,6 +426,7 @@ type FakeWorkspaceReader struct {
 		IsWorkspace    []string
 		GetWhoAmI      []GetWhoAmICall
 		ListStackFiles []string
+		GetAbout       []string
 	}
 }
 
@@ -243,6 +439,7 @@ type GetProjectInfoCall struct {
 type FindWorkspacesCall struct {
 	StartDir       string
 	CurrentWorkDir string
+	Opts           WorkspaceSearchOptions
 }
 
 type GetWhoAmICall struct {
@@ -251,31 +448,52 @@ type GetWhoAmICall struct {
 }
 
 func (f *FakeWorkspaceReader) GetProjectInfo(ctx context.Context, workDir, stackName string, opts ReadOptions) (*ProjectInfo, error) {
+	f.mu.Lock()
 	f.Calls.GetProjectInfo = append(f.Calls.GetProjectInfo, GetProjectInfoCall{workDir, stackName, opts})
+	f.mu.Unlock()
 	if f.GetProjectInfoFunc != nil {
 		return f.GetProjectInfoFunc(ctx, workDir, stackName, opts)
 	}
 	return f.ProjectInfo, nil
 }
 
-func (f *FakeWorkspaceReader) FindWorkspaces(startDir, currentWorkDir string) ([]WorkspaceInfo, error) {
-	f.Calls.FindWorkspaces = append(f.Calls.FindWorkspaces, FindWorkspacesCall{startDir, currentWorkDir})
+func (f *FakeWorkspaceReader) FindWorkspaces(startDir, currentWorkDir string, opts WorkspaceSearchOptions) ([]WorkspaceInfo, error) {
+	f.mu.Lock()
+	f.Calls.FindWorkspaces = append(f.Calls.FindWorkspaces, FindWorkspacesCall{startDir, currentWorkDir, opts})
+	f.mu.Unlock()
 	if f.FindWorkspacesFunc != nil {
-		return f.FindWorkspacesFunc(startDir, currentWorkDir)
+		return f.FindWorkspacesFunc(startDir, currentWorkDir, opts)
 	}
 	return f.Workspaces, nil
 }
 
 func (f *FakeWorkspaceReader) IsWorkspace(dir string) bool {
+	f.mu.Lock()
 	f.Calls.IsWorkspace = append(f.Calls.IsWorkspace, dir)
+	f.mu.Unlock()
 	if f.IsWorkspaceFunc != nil {
 		return f.IsWorkspaceFunc(dir)
 	}
 	return f.ValidWorkDir
 }
 
+func (f *FakeWorkspaceReader) GetAbout(ctx context.Context, workDir string, opts ReadOptions) (*AboutInfo, error) {
+	f.mu.Lock()
+	f.Calls.GetAbout = append(f.Calls.GetAbout, workDir)
+	f.mu.Unlock()
+	if f.GetAboutFunc != nil {
+		return f.GetAboutFunc(ctx, workDir, opts)
+	}
+	if f.About != nil {
+		return f.About, nil
+	}
+	return &AboutInfo{CLIVersion: "v3.0.0"}, nil
+}
+
 func (f *FakeWorkspaceReader) GetWhoAmI(ctx context.Context, workDir string, opts ReadOptions) (*WhoAmIInfo, error) {
+	f.mu.Lock()
 	f.Calls.GetWhoAmI = append(f.Calls.GetWhoAmI, GetWhoAmICall{workDir, opts})
+	f.mu.Unlock()
 	if f.GetWhoAmIFunc != nil {
 		return f.GetWhoAmIFunc(ctx, workDir, opts)
 	}
@@ -283,24 +501,140 @@ func (f *FakeWorkspaceReader) GetWhoAmI(ctx context.Context, workDir string, opt
 }
 
 func (f *FakeWorkspaceReader) ListStackFiles(workDir string) ([]StackFileInfo, error) {
+	f.mu.Lock()
 	f.Calls.ListStackFiles = append(f.Calls.ListStackFiles, workDir)
+	f.mu.Unlock()
 	if f.ListStackFilesFunc != nil {
 		return f.ListStackFilesFunc(workDir)
 	}
 	return f.StackFiles, nil
 }
 
+// FakeEnvironmentReader implements EnvironmentReader for testing.
+type FakeEnvironmentReader struct {
+	// ListEnvironmentsFunc optionally configures ListEnvironments behavior.
+	ListEnvironmentsFunc func(workDir, stackName string) ([]string, error)
+
+	// OpenEnvironmentFunc optionally configures OpenEnvironment behavior.
+	OpenEnvironmentFunc func(ctx context.Context, workDir, name string, opts ReadOptions) (*StackEnvironment, error)
+
+	// Default return values
+	Names        []string
+	Environments map[string]*StackEnvironment
+
+	// Calls tracks all method invocations.
+	Calls struct {
+		ListEnvironments []ListEnvironmentsCall
+		OpenEnvironment  []OpenEnvironmentCall
+	}
+}
+
+type ListEnvironmentsCall struct {
+	WorkDir   string
+	StackName string
+}
+
+type OpenEnvironmentCall struct {
+	WorkDir string
+	Name    string
+	Opts    ReadOptions
+}
+
+func (f *FakeEnvironmentReader) ListEnvironments(workDir, stackName string) ([]string, error) {
+	f.Calls.ListEnvironments = append(f.Calls.ListEnvironments, ListEnvironmentsCall{workDir, stackName})
+	if f.ListEnvironmentsFunc != nil {
+		return f.ListEnvironmentsFunc(workDir, stackName)
+	}
+	return f.Names, nil
+}
+
+func (f *FakeEnvironmentReader) OpenEnvironment(ctx context.Context, workDir, name string, opts ReadOptions) (*StackEnvironment, error) {
+	f.Calls.OpenEnvironment = append(f.Calls.OpenEnvironment, OpenEnvironmentCall{workDir, name, opts})
+	if f.OpenEnvironmentFunc != nil {
+		return f.OpenEnvironmentFunc(ctx, workDir, name, opts)
+	}
+	if env, ok := f.Environments[name]; ok {
+		return env, nil
+	}
+	return &StackEnvironment{Name: name}, nil
+}
+
+// FakeGitReader implements GitReader for testing.
+type FakeGitReader struct {
+	// ReadGitInfoFunc optionally configures ReadGitInfo behavior.
+	ReadGitInfoFunc func(ctx context.Context, dir string) (*GitInfo, error)
+
+	// Default return value, nil for a directory outside of git
+	Info *GitInfo
+
+	// Calls tracks all method invocations.
+	Calls struct {
+		ReadGitInfo []string // Directories read
+	}
+}
+
+func (f *FakeGitReader) ReadGitInfo(ctx context.Context, dir string) (*GitInfo, error) {
+	f.Calls.ReadGitInfo = append(f.Calls.ReadGitInfo, dir)
+	if f.ReadGitInfoFunc != nil {
+		return f.ReadGitInfoFunc(ctx, dir)
+	}
+	return f.Info, nil
+}
+
+// FakeStackLocker implements StackLocker for testing.
+type FakeStackLocker struct {
+	// Lock held by another process, returned by Acquire and Holder (nil if the stack is free)
+	Held  *StackLock
+	Error error
+
+	// Calls tracks all method invocations.
+	Calls struct {
+		Acquire []OperationType // Operations the stack was locked for
+		Release []string        // Stacks released
+	}
+}
+
+func (f *FakeStackLocker) Acquire(workDir, stackName string, op OperationType) (*StackLock, error) {
+	if f.Held != nil || f.Error != nil {
+		return f.Held, f.Error
+	}
+	f.Calls.Acquire = append(f.Calls.Acquire, op)
+	return nil, nil
+}
+
+func (f *FakeStackLocker) Holder(workDir, stackName string) (*StackLock, error) {
+	return f.Held, f.Error
+}
+
+func (f *FakeStackLocker) Release(workDir, stackName string) error {
+	f.Calls.Release = append(f.Calls.Release, stackName)
+	return nil
+}
+
 // FakeStackInitializer implements StackInitializer for testing.
 type FakeStackInitializer struct {
 	// InitStackFunc optionally configures InitStack behavior.
-	InitStackFunc func(ctx context.Context, workDir, stackName string, opts InitStackOptions) error
+	InitStackFunc func(ctx context.Context, workDir, stackName string, opts InitStackOptions) (*ConfigCopyResult, error)
 
-	// Error is the default error to return (nil for success).
-	Error error
+	// CopyConfigFunc optionally configures CopyConfig behavior.
+	CopyConfigFunc func(ctx context.Context, workDir, fromStack, toStack string, opts ConfigCopyOptions) (*ConfigCopyResult, error)
+
+	// RemoveStackFunc optionally configures RemoveStack behavior.
+	RemoveStackFunc func(ctx context.Context, workDir, stackName string, opts RemoveStackOptions) error
+
+	// NewProjectFunc optionally configures NewProject behavior.
+	NewProjectFunc func(ctx context.Context, dir string, opts NewProjectOptions) error
+
+	// Default return values
+	CopyResult *ConfigCopyResult
+	Error      error
 
 	// Calls tracks all method invocations.
 	Calls struct {
-		InitStack []InitStackCall
+		InitStack   []InitStackCall
+		CopyConfig  []CopyConfigCall
+		RemoveStack []RemoveStackCall
+		NewProject  []NewProjectCall
 	}
 }
 
@@ -310,11 +644,59 @@ type InitStackCall struct {
 	Opts      InitStackOptions
 }
 
-func (f *FakeStackInitializer) InitStack(ctx context.Context, workDir, stackName string, opts InitStackOptions) error {
+type CopyConfigCall struct {
+	WorkDir   string
+	FromStack string
+	ToStack   string
+	Opts      ConfigCopyOptions
+}
+
+type RemoveStackCall struct {
+	WorkDir   string
+	StackName string
+	Opts      RemoveStackOptions
+}
+
+type NewProjectCall struct {
+	Dir  string
+	Opts NewProjectOptions
+}
+
+func (f *FakeStackInitializer) InitStack(ctx context.Context, workDir, stackName string, opts InitStackOptions) (*ConfigCopyResult, error) {
 	f.Calls.InitStack = append(f.Calls.InitStack, InitStackCall{workDir, stackName, opts})
 	if f.InitStackFunc != nil {
 		return f.InitStackFunc(ctx, workDir, stackName, opts)
 	}
+	if opts.CopyConfigFrom == "" {
+		return nil, f.Error
+	}
+	return f.CopyResult, f.Error
+}
+
+func (f *FakeStackInitializer) CopyConfig(ctx context.Context, workDir, fromStack, toStack string, opts ConfigCopyOptions) (*ConfigCopyResult, error) {
+	f.Calls.CopyConfig = append(f.Calls.CopyConfig, CopyConfigCall{workDir, fromStack, toStack, opts})
+	if f.CopyConfigFunc != nil {
+		return f.CopyConfigFunc(ctx, workDir, fromStack, toStack, opts)
+	}
+	if f.CopyResult != nil || f.Error != nil {
+		return f.CopyResult, f.Error
+	}
+	return &ConfigCopyResult{From: fromStack}, nil
+}
+
+func (f *FakeStackInitializer) RemoveStack(ctx context.Context, workDir, stackName string, opts RemoveStackOptions) error {
+	f.Calls.RemoveStack = append(f.Calls.RemoveStack, RemoveStackCall{workDir, stackName, opts})
+	if f.RemoveStackFunc != nil {
+		return f.RemoveStackFunc(ctx, workDir, stackName, opts)
+	}
+	return f.Error
+}
+
+func (f *FakeStackInitializer) NewProject(ctx context.Context, dir string, opts NewProjectOptions) error {
+	f.Calls.NewProject = append(f.Calls.NewProject, NewProjectCall{dir, opts})
+	if f.NewProjectFunc != nil {
+		return f.NewProjectFunc(ctx, dir, opts)
+	}
 	return f.Error
 }
 
@@ -323,27 +705,42 @@ type FakeResourceImporter struct {
 	// ImportFunc optionally configures Import behavior.
 	ImportFunc func(ctx context.Context, workDir, stackName, resourceType, resourceName, importID, parentURN string, opts ImportOptions) (*CommandResult, error)
 
+	// ImportBatchFunc optionally configures ImportBatch behavior.
+	ImportBatchFunc func(ctx context.Context, workDir, stackName string, specs []ImportSpec, opts ImportOptions) (*CommandResult, error)
+
+	// PreviewImportFunc optionally configures PreviewImport behavior.
+	PreviewImportFunc func(ctx context.Context, workDir, stackName string, spec ImportSpec, opts ImportOptions) (*ImportPreview, error)
+
 	// StateDeleteFunc optionally configures StateDelete behavior.
 	StateDeleteFunc func(ctx context.Context, workDir, stackName, urn string, opts StateDeleteOptions) (*CommandResult, error)
 
-	// ProtectFunc optionally configures Protect behavior.
-	ProtectFunc func(ctx context.Context, workDir, stackName, urn string, opts StateProtectOptions) (*CommandResult, error)
+	// SetProtectFunc optionally configures SetProtect behavior.
+	SetProtectFunc func(ctx context.Context, workDir, stackName string, urns []string, protect bool, opts StateProtectOptions) (*CommandResult, error)
 
-	// UnprotectFunc optionally configures Unprotect behavior.
-	UnprotectFunc func(ctx context.Context, workDir, stackName, urn string, opts StateProtectOptions) (*CommandResult, error)
+	// RepairStateFunc optionally configures RepairState behavior.
+	RepairStateFunc func(ctx context.Context, workDir, stackName string, fixes []StateFix, opts StateRepairOptions) (*StateRepairReport, error)
+
+	// SetResourceStateFunc optionally configures SetResourceState behavior.
+	SetResourceStateFunc func(ctx context.Context, workDir, stackName, urn string, original, edited []byte, opts StateRepairOptions) error
 
 	// Default return values
 	ImportResult      *CommandResult
+	ImportBatchResult *CommandResult
+	ImportPreview     *ImportPreview
 	StateDeleteResult *CommandResult
-	ProtectResult     *CommandResult
-	UnprotectResult   *CommandResult
+	SetProtectResult  *CommandResult
+	RepairStateReport *StateRepairReport
+	ResourceStates    map[string][]byte // State JSON returned by ResourceState, by URN
 
 	// Calls tracks all method invocations.
 	Calls struct {
-		Import      []ImportCall
-		StateDelete []StateDeleteCall
-		Protect     []ProtectCall
-		Unprotect   []UnprotectCall
+		Import           []ImportCall
+		ImportBatch      []ImportBatchCall
+		PreviewImport    []PreviewImportCall
+		StateDelete      []StateDeleteCall
+		SetProtect       []SetProtectCall
+		RepairState      []RepairStateCall
+		SetResourceState []SetResourceStateCall
 	}
 }
 
@@ -357,6 +754,20 @@ type ImportCall struct {
 	Opts         ImportOptions
 }
 
+type ImportBatchCall struct {
+	WorkDir   string
+	StackName string
+	Specs     []ImportSpec
+	Opts      ImportOptions
+}
+
+type PreviewImportCall struct {
+	WorkDir   string
+	StackName string
+	Spec      ImportSpec
+	Opts      ImportOptions
+}
+
 type StateDeleteCall struct {
 	WorkDir   string
 	StackName string
@@ -364,18 +775,28 @@ type StateDeleteCall struct {
 	Opts      StateDeleteOptions
 }
 
-type ProtectCall struct {
+type SetProtectCall struct {
 	WorkDir   string
 	StackName string
-	URN       string
+	URNs      []string
+	Protect   bool
 	Opts      StateProtectOptions
 }
 
-type UnprotectCall struct {
+type RepairStateCall struct {
+	WorkDir   string
+	StackName string
+	Fixes     []StateFix
+	Opts      StateRepairOptions
+}
+
+type SetResourceStateCall struct {
 	WorkDir   string
 	StackName string
 	URN       string
-	Opts      StateProtectOptions
+	Original  []byte
+	Edited    []byte
+	Opts      StateRepairOptions
 }
 
 func (f *FakeResourceImporter) Import(ctx context.Context, workDir, stackName, resourceType, resourceName, importID, parentURN string, opts ImportOptions) (*CommandResult, error) {
@@ -389,6 +810,28 @@ func (f *FakeResourceImporter) Import(ctx context.Context, workDir, stackName, r
 	return &CommandResult{Success: true}, nil
 }
 
+func (f *FakeResourceImporter) ImportBatch(ctx context.Context, workDir, stackName string, specs []ImportSpec, opts ImportOptions) (*CommandResult, error) {
+	f.Calls.ImportBatch = append(f.Calls.ImportBatch, ImportBatchCall{workDir, stackName, specs, opts})
+	if f.ImportBatchFunc != nil {
+		return f.ImportBatchFunc(ctx, workDir, stackName, specs, opts)
+	}
+	if f.ImportBatchResult != nil {
+		return f.ImportBatchResult, nil
+	}
+	return &CommandResult{Success: true}, nil
+}
+
+func (f *FakeResourceImporter) PreviewImport(ctx context.Context, workDir, stackName string, spec ImportSpec, opts ImportOptions) (*ImportPreview, error) {
+	f.Calls.PreviewImport = append(f.Calls.PreviewImport, PreviewImportCall{workDir, stackName, spec, opts})
+	if f.PreviewImportFunc != nil {
+		return f.PreviewImportFunc(ctx, workDir, stackName, spec, opts)
+	}
+	if f.ImportPreview != nil {
+		return f.ImportPreview, nil
+	}
+	return &ImportPreview{}, nil
+}
+
 func (f *FakeResourceImporter) StateDelete(ctx context.Context, workDir, stackName, urn string, opts StateDeleteOptions) (*CommandResult, error) {
 	f.Calls.StateDelete = append(f.Calls.StateDelete, StateDeleteCall{workDir, stackName, urn, opts})
 	if f.StateDeleteFunc != nil {
@@ -400,33 +843,169 @@ func (f *FakeResourceImporter) StateDelete(ctx context.Context, workDir, stackNa
 	return &CommandResult{Success: true}, nil
 }
 
-func (f *FakeResourceImporter) Protect(ctx context.Context, workDir, stackName, urn string, opts StateProtectOptions) (*CommandResult, error) {
-	f.Calls.Protect = append(f.Calls.Protect, ProtectCall{workDir, stackName, urn, opts})
-	if f.ProtectFunc != nil {
-		return f.ProtectFunc(ctx, workDir, stackName, urn, opts)
+func (f *FakeResourceImporter) SetProtect(ctx context.Context, workDir, stackName string, urns []string, protect bool, opts StateProtectOptions) (*CommandResult, error) {
+	f.Calls.SetProtect = append(f.Calls.SetProtect, SetProtectCall{workDir, stackName, urns, protect, opts})
+	if f.SetProtectFunc != nil {
+		return f.SetProtectFunc(ctx, workDir, stackName, urns, protect, opts)
 	}
-	if f.ProtectResult != nil {
-		return f.ProtectResult, nil
+	if f.SetProtectResult != nil {
+		return f.SetProtectResult, nil
 	}
 	return &CommandResult{Success: true}, nil
 }
 
-func (f *FakeResourceImporter) Unprotect(ctx context.Context, workDir, stackName, urn string, opts StateProtectOptions) (*CommandResult, error) {
-	f.Calls.Unprotect = append(f.Calls.Unprotect, UnprotectCall{workDir, stackName, urn, opts})
-	if f.UnprotectFunc != nil {
-		return f.UnprotectFunc(ctx, workDir, stackName, urn, opts)
+func (f *FakeResourceImporter) RepairState(ctx context.Context, workDir, stackName string, fixes []StateFix, opts StateRepairOptions) (*StateRepairReport, error) {
+	f.Calls.RepairState = append(f.Calls.RepairState, RepairStateCall{workDir, stackName, fixes, opts})
+	if f.RepairStateFunc != nil {
+		return f.RepairStateFunc(ctx, workDir, stackName, fixes, opts)
 	}
-	if f.UnprotectResult != nil {
-		return f.UnprotectResult, nil
+	if f.RepairStateReport != nil {
+		return f.RepairStateReport, nil
 	}
-	return &CommandResult{Success: true}, nil
+	return &StateRepairReport{DryRun: opts.DryRun}, nil
+}
+
+func (f *FakeResourceImporter) ResourceState(ctx context.Context, workDir, stackName, urn string, opts StateRepairOptions) ([]byte, error) {
+	state, ok := f.ResourceStates[urn]
+	if !ok {
+		return nil, errors.New("resource not found in state: " + urn)
+	}
+	return state, nil
+}
+
+func (f *FakeResourceImporter) SetResourceState(ctx context.Context, workDir, stackName, urn string, original, edited []byte, opts StateRepairOptions) error {
+	f.Calls.SetResourceState = append(f.Calls.SetResourceState, SetResourceStateCall{workDir, stackName, urn, original, edited, opts})
+	if f.SetResourceStateFunc != nil {
+		return f.SetResourceStateFunc(ctx, workDir, stackName, urn, original, edited, opts)
+	}
+	return nil
+}
+
+// FakeStackStateManager implements StackStateManager for testing.
+type FakeStackStateManager struct {
+	// CancelPendingFunc optionally configures CancelPending behavior.
+	CancelPendingFunc func(ctx context.Context, workDir, stackName string, opts CancelPendingOptions) (*CancelPendingResult, error)
+
+	// ClearPendingOperationsFunc optionally configures ClearPendingOperations behavior.
+	ClearPendingOperationsFunc func(ctx context.Context, workDir, stackName string, opts CancelPendingOptions) (*CancelPendingResult, error)
+
+	// Default return value
+	CancelPendingResult *CancelPendingResult
+
+	// Calls tracks all method invocations.
+	Calls struct {
+		CancelPending          []CancelPendingCall
+		ClearPendingOperations []CancelPendingCall
+	}
+}
+
+type CancelPendingCall struct {
+	WorkDir   string
+	StackName string
+	Opts      CancelPendingOptions
+}
+
+func (f *FakeStackStateManager) CancelPending(ctx context.Context, workDir, stackName string, opts CancelPendingOptions) (*CancelPendingResult, error) {
+	f.Calls.CancelPending = append(f.Calls.CancelPending, CancelPendingCall{workDir, stackName, opts})
+	if f.CancelPendingFunc != nil {
+		return f.CancelPendingFunc(ctx, workDir, stackName, opts)
+	}
+	if f.CancelPendingResult != nil {
+		return f.CancelPendingResult, nil
+	}
+	return &CancelPendingResult{}, nil
+}
+
+func (f *FakeStackStateManager) ClearPendingOperations(ctx context.Context, workDir, stackName string, opts CancelPendingOptions) (*CancelPendingResult, error) {
+	f.Calls.ClearPendingOperations = append(f.Calls.ClearPendingOperations, CancelPendingCall{workDir, stackName, opts})
+	if f.ClearPendingOperationsFunc != nil {
+		return f.ClearPendingOperationsFunc(ctx, workDir, stackName, opts)
+	}
+	if f.CancelPendingResult != nil {
+		return f.CancelPendingResult, nil
+	}
+	return &CancelPendingResult{}, nil
+}
+
+// FakeStateTransferer implements StateTransferer for testing.
+type FakeStateTransferer struct {
+	// Optional function overrides
+	ExportDeploymentFunc func(ctx context.Context, workDir, stackName, path string, opts DeploymentTransferOptions) (*DeploymentFile, error)
+	ImportDeploymentFunc func(ctx context.Context, workDir, stackName, path string, opts DeploymentTransferOptions) (*DeploymentFile, error)
+
+	// Calls tracks all method invocations.
+	Calls struct {
+		ExportDeployment []DeploymentTransferCall
+		ImportDeployment []DeploymentTransferCall
+	}
+}
+
+type DeploymentTransferCall struct {
+	WorkDir   string
+	StackName string
+	Path      string
+	Opts      DeploymentTransferOptions
+}
+
+func (f *FakeStateTransferer) ExportDeployment(ctx context.Context, workDir, stackName, path string, opts DeploymentTransferOptions) (*DeploymentFile, error) {
+	f.Calls.ExportDeployment = append(f.Calls.ExportDeployment, DeploymentTransferCall{workDir, stackName, path, opts})
+	if f.ExportDeploymentFunc != nil {
+		return f.ExportDeploymentFunc(ctx, workDir, stackName, path, opts)
+	}
+	return &DeploymentFile{Path: path}, nil
+}
+
+func (f *FakeStateTransferer) ImportDeployment(ctx context.Context, workDir, stackName, path string, opts DeploymentTransferOptions) (*DeploymentFile, error) {
+	f.Calls.ImportDeployment = append(f.Calls.ImportDeployment, DeploymentTransferCall{workDir, stackName, path, opts})
+	if f.ImportDeploymentFunc != nil {
+		return f.ImportDeploymentFunc(ctx, workDir, stackName, path, opts)
+	}
+	return &DeploymentFile{Path: path}, nil
+}
+
+// FakeBackendAuthenticator implements BackendAuthenticator for testing.
+type FakeBackendAuthenticator struct {
+	// Optional function overrides
+	LoginFunc        func(ctx context.Context, workDir string, opts LoginOptions) (*WhoAmIInfo, error)
+	LoginCommandFunc func(workDir string, opts LoginOptions) *exec.Cmd
+
+	// Calls tracks all method invocations.
+	Calls struct {
+		Login        []LoginCall
+		LoginCommand []LoginCall
+	}
+}
+
+type LoginCall struct {
+	WorkDir string
+	Opts    LoginOptions
+}
+
+func (f *FakeBackendAuthenticator) Login(ctx context.Context, workDir string, opts LoginOptions) (*WhoAmIInfo, error) {
+	f.Calls.Login = append(f.Calls.Login, LoginCall{workDir, opts})
+	if f.LoginFunc != nil {
+		return f.LoginFunc(ctx, workDir, opts)
+	}
+	return &WhoAmIInfo{User: "test-user", URL: opts.URL}, nil
+}
+
+func (f *FakeBackendAuthenticator) LoginCommand(workDir string, opts LoginOptions) *exec.Cmd {
+	f.Calls.LoginCommand = append(f.Calls.LoginCommand, LoginCall{workDir, opts})
+	if f.LoginCommandFunc != nil {
+		return f.LoginCommandFunc(workDir, opts)
+	}
+	return exec.Command("true")
 }
 
 // Compile-time interface compliance checks
 var (
-	_ StackOperator    = (*FakeStackOperator)(nil)
-	_ StackReader      = (*FakeStackReader)(nil)
-	_ WorkspaceReader  = (*FakeWorkspaceReader)(nil)
-	_ StackInitializer = (*FakeStackInitializer)(nil)
-	_ ResourceImporter = (*FakeResourceImporter)(nil)
+	_ StackOperator        = (*FakeStackOperator)(nil)
+	_ StackReader          = (*FakeStackReader)(nil)
+	_ WorkspaceReader      = (*FakeWorkspaceReader)(nil)
+	_ EnvironmentReader    = (*FakeEnvironmentReader)(nil)
+	_ StackInitializer     = (*FakeStackInitializer)(nil)
+	_ ResourceImporter     = (*FakeResourceImporter)(nil)
+	_ StackStateManager    = (*FakeStackStateManager)(nil)
+	_ StateTransferer      = (*FakeStateTransferer)(nil)
+	_ BackendAuthenticator = (*FakeBackendAuthenticator)(nil)
 )
@@ -0,0 +1,82 @@
+package pulumi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GitInfo describes the git checkout of a workspace
+type GitInfo struct {
+	Branch string // Current branch, empty when HEAD is detached
+	Commit string // Short SHA of HEAD, empty before the first commit
+	Dirty  bool   // Uncommitted or untracked changes in the working tree
+}
+
+// Ref returns the branch and short SHA, like "main@1a2b3c4", marked when the
+// working tree is dirty
+func (g GitInfo) Ref() string {
+	ref := g.Branch
+	if ref == "" {
+		ref = "(detached)"
+	}
+	if g.Commit != "" {
+		ref += "@" + g.Commit
+	}
+	if g.Dirty {
+		ref += "-dirty"
+	}
+	return ref
+}
+
+// shortCommitLength is how many characters of a SHA are shown, as git does by default
+const shortCommitLength = 7
+
+// ReadGitInfo reads the branch, HEAD commit and dirty state of the git checkout
+// containing dir. Returns nil when dir is not in a git repository or git is not
+// installed.
+func ReadGitInfo(ctx context.Context, dir string) (*GitInfo, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, nil
+	}
+	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain=v2", "--branch")
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if strings.Contains(stderr.String(), "not a git repository") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("git status failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return parseGitStatus(output), nil
+}
+
+// parseGitStatus parses the output of git status --porcelain=v2 --branch
+func parseGitStatus(output []byte) *GitInfo {
+	info := &GitInfo{}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "# branch.oid "):
+			oid := strings.TrimPrefix(line, "# branch.oid ")
+			if oid != "(initial)" {
+				info.Commit = oid[:min(len(oid), shortCommitLength)]
+			}
+		case strings.HasPrefix(line, "# branch.head "):
+			if head := strings.TrimPrefix(line, "# branch.head "); head != "(detached)" {
+				info.Branch = head
+			}
+		case strings.HasPrefix(line, "#"):
+			// Other headers, e.g. the upstream branch
+		case line != "":
+			info.Dirty = true
+		}
+	}
+	return info
+}
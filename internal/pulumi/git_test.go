@@ -0,0 +1,76 @@
+package pulumi
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGitStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   GitInfo
+	}{
+		{
+			name:   "clean branch",
+			output: "# branch.oid 1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b\n# branch.head main\n# branch.upstream origin/main\n# branch.ab +0 -0\n",
+			want:   GitInfo{Branch: "main", Commit: "1a2b3c4"},
+		},
+		{
+			name:   "dirty detached head",
+			output: "# branch.oid 1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b\n# branch.head (detached)\n1 .M N... 100644 100644 100644 abc abc main.go\n",
+			want:   GitInfo{Commit: "1a2b3c4", Dirty: true},
+		},
+		{
+			name:   "untracked files before the first commit",
+			output: "# branch.oid (initial)\n# branch.head main\n? Pulumi.yaml\n",
+			want:   GitInfo{Branch: "main", Dirty: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseGitStatus([]byte(tt.output)); *got != tt.want {
+				t.Errorf("parseGitStatus() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitInfo_Ref(t *testing.T) {
+	if ref := (GitInfo{Branch: "main", Commit: "1a2b3c4"}).Ref(); ref != "main@1a2b3c4" {
+		t.Errorf("expected main@1a2b3c4, got %q", ref)
+	}
+	if ref := (GitInfo{Commit: "1a2b3c4", Dirty: true}).Ref(); ref != "(detached)@1a2b3c4-dirty" {
+		t.Errorf("expected a detached, dirty ref, got %q", ref)
+	}
+}
+
+// TestReadGitInfo verifies a directory outside of git reads as nil, and a new
+// repository with an untracked file as dirty
+func TestReadGitInfo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	if info, err := ReadGitInfo(context.Background(), dir); err != nil || info != nil {
+		t.Skipf("temp dir is inside a git repository: %+v, %v", info, err)
+	}
+
+	if out, err := exec.Command("git", "init", "-b", "main", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v: %s", err, out)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Pulumi.yaml"), []byte("name: test\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	info, err := ReadGitInfo(context.Background(), dir)
+	if err != nil || info == nil {
+		t.Fatalf("expected git info, got %+v, %v", info, err)
+	}
+	if *info != (GitInfo{Branch: "main", Dirty: true}) {
+		t.Errorf("expected a dirty main branch without commits, got %+v", *info)
+	}
+}
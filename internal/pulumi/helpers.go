@@ -1,8 +1,13 @@
 package pulumi
 
 import (
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/events"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
 )
 
 // ExtractResourceName gets the resource name from a URN.
@@ -16,6 +21,20 @@ func ExtractResourceName(urn string) string {
 	return urn
 }
 
+// ParseStackURN returns the stack and project of a resource URN.
+// URN format: urn:pulumi:stack::project::type::name
+func ParseStackURN(urn string) (stack, project string, ok bool) {
+	rest, found := strings.CutPrefix(urn, "urn:pulumi:")
+	if !found {
+		return "", "", false
+	}
+	parts := strings.SplitN(rest, "::", 4)
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
 // extractParent gets the parent URN from step metadata.
 func extractParent(meta apitype.StepEventMetadata) string {
 	if meta.New != nil && meta.New.Parent != "" {
@@ -27,18 +46,37 @@ func extractParent(meta apitype.StepEventMetadata) string {
 	return ""
 }
 
+// replaceKeys returns the property paths whose change forces a resource to be
+// replaced. The detailed diff names nested paths, like tags.Name; when the
+// provider doesn't report one, the top-level keys of the step are used.
+func replaceKeys(meta apitype.StepEventMetadata) []string {
+	var keys []string
+	for path, diff := range meta.DetailedDiff {
+		switch diff.Kind {
+		case apitype.DiffAddReplace, apitype.DiffDeleteReplace, apitype.DiffUpdateReplace:
+			keys = append(keys, path)
+		}
+	}
+	if len(keys) == 0 {
+		keys = slices.Clone(meta.Keys)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
 // processPreviewEvents handles event processing for preview operations.
 func processPreviewEvents(pulumiEvents <-chan events.EngineEvent, eventCh chan<- PreviewEvent) {
 	for e := range pulumiEvents {
 		if e.ResourcePreEvent != nil {
 			meta := e.ResourcePreEvent.Metadata
 			step := &PreviewStep{
-				URN:      meta.URN,
-				Op:       ResourceOp(meta.Op),
-				Type:     meta.Type,
-				Name:     ExtractResourceName(meta.URN),
-				Parent:   extractParent(meta),
-				Sequence: e.Sequence,
+				URN:         meta.URN,
+				Op:          ResourceOp(meta.Op),
+				Type:        meta.Type,
+				Name:        ExtractResourceName(meta.URN),
+				Parent:      extractParent(meta),
+				Sequence:    e.Sequence,
+				ReplaceKeys: replaceKeys(meta),
 			}
 			if meta.New != nil {
 				step.Inputs = meta.New.Inputs
@@ -63,10 +101,78 @@ func processPreviewEvents(pulumiEvents <-chan events.EngineEvent, eventCh chan<-
 			}
 			if meta.New != nil {
 				step.Outputs = meta.New.Outputs
+			} else if step.Op == OpRefresh && meta.Old != nil {
+				// The refresh found that the resource no longer exists
+				step.Op = OpDelete
 			}
 			eventCh <- PreviewEvent{Step: step}
 		}
+		if e.DiagnosticEvent != nil {
+			warning, diagnostic := diagnosticWarning(e.DiagnosticEvent), engineDiagnostic(e.DiagnosticEvent)
+			if warning != nil || diagnostic != nil {
+				eventCh <- PreviewEvent{Warning: warning, Diagnostic: diagnostic}
+			}
+		}
+		if e.PolicyEvent != nil {
+			if warning := policyWarning(e.PolicyEvent); warning != nil {
+				eventCh <- PreviewEvent{Warning: warning}
+			}
+		}
+	}
+}
+
+// diagnosticWarning converts an engine warning or error to a preview warning.
+// Info and debug output is not collected.
+func diagnosticWarning(d *apitype.DiagnosticEvent) *PreviewWarning {
+	var severity WarningSeverity
+	switch d.Severity {
+	case "warning":
+		severity = WarningSeverityWarning
+	case "error":
+		severity = WarningSeverityError
+	default:
+		return nil
+	}
+	message := cleanDiagnosticMessage(d.Message)
+	if d.Ephemeral || message == "" {
+		return nil
 	}
+	return &PreviewWarning{Severity: severity, URN: d.URN, Message: message}
+}
+
+// engineDiagnostic converts a diagnostic of any severity for the engine log.
+// Ephemeral status messages are kept, as the log shows what is happening now.
+func engineDiagnostic(d *apitype.DiagnosticEvent) *EngineDiagnostic {
+	message := cleanDiagnosticMessage(d.Message)
+	if message == "" {
+		return nil
+	}
+	return &EngineDiagnostic{Severity: d.Severity, URN: d.URN, Message: message}
+}
+
+// policyWarning converts a policy violation to a preview warning.
+// Disabled policies are not collected.
+func policyWarning(p *apitype.PolicyEvent) *PreviewWarning {
+	var severity WarningSeverity
+	switch p.EnforcementLevel {
+	case "advisory", "warning":
+		severity = WarningSeverityWarning
+	case "mandatory", "remediate":
+		severity = WarningSeverityError
+	default:
+		return nil
+	}
+	return &PreviewWarning{
+		Severity: severity,
+		URN:      p.ResourceURN,
+		Message:  cleanDiagnosticMessage(p.Message),
+		Policy:   p.PolicyPackName + "/" + p.PolicyName,
+	}
+}
+
+// cleanDiagnosticMessage removes color directives and surrounding whitespace from an engine message
+func cleanDiagnosticMessage(message string) string {
+	return strings.TrimSpace(colors.Never.Colorize(message))
 }
 
 // processOperationEvents handles event processing for operations (up, refresh, destroy).
@@ -75,13 +181,15 @@ func processOperationEvents(pulumiEvents <-chan events.EngineEvent, eventCh chan
 		if e.ResourcePreEvent != nil {
 			meta := e.ResourcePreEvent.Metadata
 			ev := OperationEvent{
-				URN:      meta.URN,
-				Op:       ResourceOp(meta.Op),
-				Type:     meta.Type,
-				Name:     ExtractResourceName(meta.URN),
-				Parent:   extractParent(meta),
-				Sequence: e.Sequence,
-				Status:   StepRunning,
+				URN:         meta.URN,
+				Op:          ResourceOp(meta.Op),
+				Type:        meta.Type,
+				Name:        ExtractResourceName(meta.URN),
+				Parent:      extractParent(meta),
+				Sequence:    e.Sequence,
+				Status:      StepRunning,
+				Time:        time.Now(),
+				ReplaceKeys: replaceKeys(meta),
 			}
 
 			switch mode {
@@ -111,12 +219,26 @@ func processOperationEvents(pulumiEvents <-chan events.EngineEvent, eventCh chan
 				Parent:   extractParent(meta),
 				Sequence: e.Sequence,
 				Status:   StepSuccess,
+				Time:     time.Now(),
 			}
 			if mode != OperationModeDestroy && meta.New != nil {
 				ev.Outputs = meta.New.Outputs
 			}
 			eventCh <- ev
 		}
+		if e.ResOpFailedEvent != nil {
+			meta := e.ResOpFailedEvent.Metadata
+			eventCh <- OperationEvent{
+				URN:      meta.URN,
+				Op:       ResourceOp(meta.Op),
+				Type:     meta.Type,
+				Name:     ExtractResourceName(meta.URN),
+				Parent:   extractParent(meta),
+				Sequence: e.Sequence,
+				Status:   StepFailed,
+				Time:     time.Now(),
+			}
+		}
 		if e.DiagnosticEvent != nil && e.DiagnosticEvent.Severity == "error" {
 			eventCh <- OperationEvent{
 				Message:  e.DiagnosticEvent.Message,
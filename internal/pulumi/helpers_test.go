@@ -0,0 +1,50 @@
+package pulumi
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+)
+
+// TestReplaceKeys verifies the paths forcing a replacement come from the detailed
+// diff when the provider reports one, and from the step's keys otherwise
+func TestReplaceKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		meta apitype.StepEventMetadata
+		want []string
+	}{
+		{
+			name: "detailed diff",
+			meta: apitype.StepEventMetadata{
+				Keys: []string{"tags", "availabilityZone"},
+				DetailedDiff: map[string]apitype.PropertyDiff{
+					"tags.Name":        {Kind: apitype.DiffUpdate},
+					"availabilityZone": {Kind: apitype.DiffUpdateReplace},
+					"subnetId":         {Kind: apitype.DiffAddReplace},
+				},
+			},
+			want: []string{"availabilityZone", "subnetId"},
+		},
+		{
+			name: "keys only",
+			meta: apitype.StepEventMetadata{Keys: []string{"name", "engine"}},
+			want: []string{"engine", "name"},
+		},
+		{
+			name: "update",
+			meta: apitype.StepEventMetadata{
+				DetailedDiff: map[string]apitype.PropertyDiff{"tags": {Kind: apitype.DiffUpdate}},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replaceKeys(tt.meta); !slices.Equal(got, tt.want) {
+				t.Errorf("replaceKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package pulumi
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"maps"
+	"strconv"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/auto"
 )
@@ -64,3 +66,42 @@ func GetStackHistory(ctx context.Context, workDir, stackName string, pageSize, p
 
 	return result, nil
 }
+
+// ExportStackAtVersion returns the resources recorded in the deployment snapshot
+// written by the given update version. Version 0 or less returns an empty snapshot.
+func ExportStackAtVersion(ctx context.Context, workDir, stackName string, version int, env map[string]string) ([]ResourceInfo, error) {
+	if version <= 0 {
+		return nil, nil
+	}
+
+	resolvedStackName, err := resolveStackName(ctx, workDir, stackName, env)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Automation API only exports the latest deployment, so use the CLI
+	// Format: pulumi stack export --stack <stack> --version <version>
+	args := []string{
+		"stack",
+		"export",
+		"--stack", resolvedStackName,
+		"--version", strconv.Itoa(version),
+	}
+
+	output, err := runPulumiCommandStdout(ctx, workDir, env, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export stack at version %d: %w", version, err)
+	}
+
+	var state struct {
+		Deployment json.RawMessage `json:"deployment"`
+	}
+	if err := json.Unmarshal(output, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse exported stack: %w", err)
+	}
+	if len(state.Deployment) == 0 {
+		return nil, nil
+	}
+
+	return parseDeploymentResources(state.Deployment)
+}
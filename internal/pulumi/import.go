@@ -3,10 +3,13 @@ package pulumi
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
+	"github.com/charmbracelet/x/ansi"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optimport"
 )
 
@@ -38,8 +41,15 @@ func (c *execCmd) CombinedOutput() ([]byte, error) {
 	return cmd.CombinedOutput()
 }
 
-// runPulumiCommand executes a pulumi CLI command with environment variables
-func runPulumiCommand(ctx context.Context, workDir string, env map[string]string, args ...string) (string, error) {
+func (c *execCmd) Output() ([]byte, error) {
+	cmd := exec.CommandContext(c.ctx, c.name, c.args...) //nolint:gosec // G204: Pulumi CLI command execution
+	cmd.Dir = c.Dir
+	cmd.Env = c.Env
+	return cmd.Output()
+}
+
+// newPulumiCommand creates a pulumi CLI command with the working directory and environment set
+func newPulumiCommand(ctx context.Context, workDir string, env map[string]string, args ...string) *execCmd {
 	cmd := execCommand(ctx, "pulumi", args...)
 	cmd.Dir = workDir
 
@@ -51,33 +61,64 @@ func runPulumiCommand(ctx context.Context, workDir string, env map[string]string
 		cmd.Env = cmdEnv
 	}
 
-	output, err := cmd.CombinedOutput()
+	return cmd
+}
+
+// runPulumiCommand executes a pulumi CLI command with environment variables
+func runPulumiCommand(ctx context.Context, workDir string, env map[string]string, args ...string) (string, error) {
+	output, err := newPulumiCommand(ctx, workDir, env, args...).CombinedOutput()
 	return string(output), err
 }
 
+// runPulumiCommandStdout executes a pulumi CLI command and returns only its stdout,
+// for commands whose output is parsed (stderr may contain warnings)
+func runPulumiCommandStdout(ctx context.Context, workDir string, env map[string]string, args ...string) ([]byte, error) {
+	output, err := newPulumiCommand(ctx, workDir, env, args...).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return output, fmt.Errorf("%w\n%s", err, bytes.TrimSpace(exitErr.Stderr))
+	}
+	return output, err
+}
+
 // ImportResource imports an existing resource into the Pulumi state using the SDK
 // resourceType is the Pulumi resource type (e.g., "aws:s3/bucket:Bucket")
 // resourceName is the logical name for the resource in Pulumi
 // importID is the provider-specific ID of the existing resource to import
 // parentURN is optional - if provided, the resource will be imported as a child of this resource
 func ImportResource(ctx context.Context, workDir, stackName, resourceType, resourceName, importID, parentURN string, opts ImportOptions) (*CommandResult, error) {
+	return ImportResources(ctx, workDir, stackName, []ImportSpec{{
+		Type:      resourceType,
+		Name:      resourceName,
+		ID:        importID,
+		ParentURN: parentURN,
+	}}, opts)
+}
+
+// ImportResources imports several existing resources into the Pulumi state in a single
+// pulumi import operation. The import is all-or-nothing: if any resource fails, none are imported.
+// The code Pulumi generates for the resources, in the project's language, is returned with the result.
+func ImportResources(ctx context.Context, workDir, stackName string, specs []ImportSpec, opts ImportOptions) (*CommandResult, error) {
 	stack, err := selectStack(ctx, workDir, stackName, opts.Env)
 	if err != nil {
 		return nil, err
 	}
 
-	resource := &optimport.ImportResource{
-		Type:   resourceType,
-		Name:   resourceName,
-		ID:     importID,
-		Parent: parentURN,
+	resources := make([]*optimport.ImportResource, 0, len(specs))
+	for _, spec := range specs {
+		resources = append(resources, &optimport.ImportResource{
+			Type:   spec.Type,
+			Name:   spec.Name,
+			ID:     spec.ID,
+			Parent: spec.ParentURN,
+		})
 	}
 
 	var output bytes.Buffer
-	_, err = stack.ImportResources(ctx,
-		optimport.Resources([]*optimport.ImportResource{resource}),
+	result, err := stack.ImportResources(ctx,
+		optimport.Resources(resources),
 		optimport.Protect(false),
-		optimport.GenerateCode(false),
+		optimport.GenerateCode(true),
 		optimport.ProgressStreams(&output),
 		optimport.ErrorProgressStreams(&output),
 	)
@@ -90,57 +131,102 @@ func ImportResource(ctx context.Context, workDir, stackName, resourceType, resou
 	}
 
 	return &CommandResult{
-		Success: true,
-		Output:  output.String(),
+		Success:       true,
+		Output:        output.String(),
+		GeneratedCode: result.GeneratedCode,
 	}, nil
 }
 
-// DeleteFromState removes a resource from the Pulumi state without deleting the actual resource
-// urn is the full URN of the resource to remove from state
-func DeleteFromState(ctx context.Context, workDir, stackName, urn string, opts StateDeleteOptions) (*CommandResult, error) {
-	resolvedStackName, err := resolveStackName(ctx, workDir, stackName, opts.Env)
+// PreviewImport runs a preview-only import of spec and returns the inputs the
+// resource would be imported with, without writing to the state
+func PreviewImport(ctx context.Context, workDir, stackName string, spec ImportSpec, opts ImportOptions) (*ImportPreview, error) {
+	stack, err := selectStack(ctx, workDir, stackName, opts.Env)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build the pulumi state delete command
-	// Format: pulumi state delete <urn> --stack <stack> --yes
-	args := []string{
-		"state",
-		"delete",
-		urn,
-		"--stack", resolvedStackName,
-		"--yes", // Auto-confirm
-	}
-
-	output, err := runPulumiCommand(ctx, workDir, opts.Env, args...)
+	var output bytes.Buffer
+	_, err = stack.ImportResources(ctx,
+		optimport.Resources([]*optimport.ImportResource{{
+			Type:   spec.Type,
+			Name:   spec.Name,
+			ID:     spec.ID,
+			Parent: spec.ParentURN,
+		}}),
+		optimport.Protect(false),
+		optimport.GenerateCode(false),
+		optimport.PreviewOnly(true),
+		optimport.Diff(true),
+		optimport.ProgressStreams(&output),
+		optimport.ErrorProgressStreams(&output),
+	)
 	if err != nil {
-		return &CommandResult{
-			Success: false,
-			Output:  output,
-			Error:   fmt.Errorf("state delete failed: %w\n%s", err, output),
-		}, nil
+		return nil, fmt.Errorf("import preview failed: %w\n%s", err, strings.TrimSpace(output.String()))
 	}
 
-	return &CommandResult{
-		Success: true,
-		Output:  output,
+	return &ImportPreview{
+		Properties: parseImportPreview(output.String()),
+		Output:     output.String(),
 	}, nil
 }
 
-// ProtectResource marks a resource as protected in the Pulumi state
-// Protected resources cannot be destroyed without first being unprotected
-func ProtectResource(ctx context.Context, workDir, stackName, urn string, opts StateProtectOptions) (*CommandResult, error) {
+// parseImportPreview extracts the property lines of the imported resource from
+// `pulumi import --preview-only --diff` output, skipping the [id=...] style metadata
+func parseImportPreview(output string) []string {
+	var properties []string
+	stepIndent := -1
+	for line := range strings.SplitSeq(ansi.Strip(output), "\n") {
+		line = strings.TrimRight(line, " \r")
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if stepIndent < 0 {
+			if strings.HasPrefix(trimmed, "= ") && strings.HasSuffix(trimmed, "(import)") {
+				stepIndent = indent
+			}
+			continue
+		}
+		if trimmed == "" {
+			continue
+		}
+		if indent <= stepIndent {
+			break
+		}
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			continue
+		}
+		properties = append(properties, line)
+	}
+	return dedent(properties)
+}
+
+// dedent removes the indentation shared by all lines
+func dedent(lines []string) []string {
+	shared := -1
+	for _, line := range lines {
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if shared < 0 || indent < shared {
+			shared = indent
+		}
+	}
+	for i, line := range lines {
+		lines[i] = line[shared:]
+	}
+	return lines
+}
+
+// DeleteFromState removes a resource from the Pulumi state without deleting the actual resource
+// urn is the full URN of the resource to remove from state
+func DeleteFromState(ctx context.Context, workDir, stackName, urn string, opts StateDeleteOptions) (*CommandResult, error) {
 	resolvedStackName, err := resolveStackName(ctx, workDir, stackName, opts.Env)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build the pulumi state protect command
-	// Format: pulumi state protect <urn> --stack <stack> --yes
+	// Build the pulumi state delete command
+	// Format: pulumi state delete <urn> --stack <stack> --yes
 	args := []string{
 		"state",
-		"protect",
+		"delete",
 		urn,
 		"--stack", resolvedStackName,
 		"--yes", // Auto-confirm
@@ -151,7 +237,7 @@ func ProtectResource(ctx context.Context, workDir, stackName, urn string, opts S
 		return &CommandResult{
 			Success: false,
 			Output:  output,
-			Error:   fmt.Errorf("state protect failed: %w\n%s", err, output),
+			Error:   fmt.Errorf("state delete failed: %w\n%s", err, output),
 		}, nil
 	}
 
@@ -161,30 +247,33 @@ func ProtectResource(ctx context.Context, workDir, stackName, urn string, opts S
 	}, nil
 }
 
-// UnprotectResource removes the protected flag from a resource in the Pulumi state
-// This allows the resource to be destroyed
-func UnprotectResource(ctx context.Context, workDir, stackName, urn string, opts StateProtectOptions) (*CommandResult, error) {
+// SetProtect marks resources as protected, or removes the protected flag, in the Pulumi state.
+// Protected resources cannot be destroyed without first being unprotected.
+func SetProtect(ctx context.Context, workDir, stackName string, urns []string, protect bool, opts StateProtectOptions) (*CommandResult, error) {
 	resolvedStackName, err := resolveStackName(ctx, workDir, stackName, opts.Env)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build the pulumi state unprotect command
-	// Format: pulumi state unprotect <urn> --stack <stack> --yes
-	args := []string{
-		"state",
-		"unprotect",
-		urn,
+	command := "protect"
+	if !protect {
+		command = "unprotect"
+	}
+
+	// Build the pulumi state protect/unprotect command
+	// Format: pulumi state protect <urn>... --stack <stack> --yes
+	args := append([]string{"state", command}, urns...)
+	args = append(args,
 		"--stack", resolvedStackName,
 		"--yes", // Auto-confirm
-	}
+	)
 
 	output, err := runPulumiCommand(ctx, workDir, opts.Env, args...)
 	if err != nil {
 		return &CommandResult{
 			Success: false,
 			Output:  output,
-			Error:   fmt.Errorf("state unprotect failed: %w\n%s", err, output),
+			Error:   fmt.Errorf("state %s failed: %w\n%s", command, err, output),
 		}, nil
 	}
 
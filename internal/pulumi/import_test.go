@@ -0,0 +1,37 @@
+package pulumi
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestParseImportPreview verifies the imported resource's inputs are taken from
+// preview output, without metadata, other steps or the summary
+func TestParseImportPreview(t *testing.T) {
+	output := "Previewing import (dev)\n" +
+		"\n" +
+		"     Type                 Name      Plan\n" +
+		"\n" +
+		"  pulumi:pulumi:Stack: (same)\n" +
+		"    [urn=urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev]\n" +
+		"    \x1b[36m= aws:s3/bucket:Bucket: (import)\x1b[0m\n" +
+		"        [id=logs-123]\n" +
+		"        [urn=urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs]\n" +
+		"        acl         : \"private\"\n" +
+		"        tags        : {\n" +
+		"            team: \"infra\"\n" +
+		"        }\n" +
+		"\n" +
+		"Resources:\n" +
+		"    = 1 to import\n"
+
+	got := parseImportPreview(output)
+	want := []string{`acl         : "private"`, "tags        : {", `    team: "infra"`, "}"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got := parseImportPreview("error: no resources to import\n"); got != nil {
+		t.Errorf("expected no properties without an import step, got %q", got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -541,3 +542,202 @@ func TestIntegration_History_AfterOperations(t *testing.T) {
 		t.Errorf("expected Kind=update, got %s", history[0].Kind)
 	}
 }
+
+func TestIntegration_RepairState_DryRunThenApply(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	t.Parallel()
+
+	ts := SetupTestStack(t, "multi")
+	ctx := context.Background()
+
+	operator := NewStackOperator()
+	upCh := operator.Up(ctx, ts.WorkDir, ts.Name(), OperationOptions{Env: ts.Env()})
+	CollectOperationEvents(upCh)
+
+	reader := NewStackReader()
+	readOpts := ReadOptions{Env: ts.Env()}
+	before, err := reader.GetResources(ctx, ts.WorkDir, ts.Name(), readOpts)
+	if err != nil {
+		t.Fatalf("GetResources failed: %v", err)
+	}
+	if issues := DiagnoseState(before); len(issues) != 0 {
+		t.Fatalf("expected healthy state, got %+v", issues)
+	}
+
+	var target StateIssue
+	for _, r := range before {
+		if r.Type == "random:index/randomString:RandomString" {
+			target = StateIssue{Kind: StateIssueOrphanedChild, URN: r.URN, Type: r.Type, Name: r.Name}
+		}
+	}
+	if target.URN == "" {
+		t.Fatal("expected to find RandomString resource")
+	}
+	fixes := []StateFix{{Issue: target, Action: StateFixDelete}}
+
+	// Dry run reports the change without writing state
+	importer := NewResourceImporter()
+	report, err := importer.RepairState(ctx, ts.WorkDir, ts.Name(), fixes, StateRepairOptions{Env: ts.Env(), DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if report.ResourcesAfter != report.ResourcesBefore-1 || len(report.Dangling) != 0 {
+		t.Errorf("unexpected dry-run report: %+v", report)
+	}
+	after, err := reader.GetResources(ctx, ts.WorkDir, ts.Name(), readOpts)
+	if err != nil {
+		t.Fatalf("GetResources failed: %v", err)
+	}
+	if len(after) != len(before) {
+		t.Fatalf("expected dry run to leave state unchanged, got %d resources", len(after))
+	}
+
+	// Applying removes the resource
+	if _, err := importer.RepairState(ctx, ts.WorkDir, ts.Name(), fixes, StateRepairOptions{Env: ts.Env()}); err != nil {
+		t.Fatalf("repair failed: %v", err)
+	}
+	after, err = reader.GetResources(ctx, ts.WorkDir, ts.Name(), readOpts)
+	if err != nil {
+		t.Fatalf("GetResources failed: %v", err)
+	}
+	for _, r := range after {
+		if r.URN == target.URN {
+			t.Error("expected RandomString to be removed from state")
+		}
+	}
+}
+
+func TestIntegration_ExportImportDeployment(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	t.Parallel()
+
+	ts := SetupTestStack(t, "multi")
+	ctx := context.Background()
+
+	operator := NewStackOperator()
+	upCh := operator.Up(ctx, ts.WorkDir, ts.Name(), OperationOptions{Env: ts.Env()})
+	CollectOperationEvents(upCh)
+
+	reader := NewStackReader()
+	readOpts := ReadOptions{Env: ts.Env()}
+	before, err := reader.GetResources(ctx, ts.WorkDir, ts.Name(), readOpts)
+	if err != nil {
+		t.Fatalf("GetResources failed: %v", err)
+	}
+
+	transferer := NewStateTransferer()
+	opts := DeploymentTransferOptions{Env: ts.Env()}
+	path := filepath.Join(t.TempDir(), "state", "deployment.json")
+	exported, err := transferer.ExportDeployment(ctx, ts.WorkDir, ts.Name(), path, opts)
+	if err != nil {
+		t.Fatalf("ExportDeployment failed: %v", err)
+	}
+	if exported.Resources < len(before) || exported.Stack != ts.Name() {
+		t.Errorf("unexpected export: %+v", exported)
+	}
+
+	// Importing the exported file leaves the stack's resources unchanged
+	if _, err := transferer.ImportDeployment(ctx, ts.WorkDir, ts.Name(), path, opts); err != nil {
+		t.Fatalf("ImportDeployment failed: %v", err)
+	}
+	after, err := reader.GetResources(ctx, ts.WorkDir, ts.Name(), readOpts)
+	if err != nil {
+		t.Fatalf("GetResources failed: %v", err)
+	}
+	if len(after) != len(before) {
+		t.Errorf("expected %d resources after import, got %d", len(before), len(after))
+	}
+}
+
+func TestIntegration_InitStack_CopiesConfig(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	t.Parallel()
+
+	ts := SetupTestStack(t, "multi")
+	ctx := context.Background()
+
+	source := "config:\n" +
+		"  integration-test-multi:region: us-west-2\n" +
+		"  integration-test-multi:token:\n    secure: AAABAExample\n" +
+		"  integration-test-multi:tags:\n    team: infra\n    zones: [a, b]\n"
+	if err := os.WriteFile(filepath.Join(ts.WorkDir, "Pulumi.source.yaml"), []byte(source), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	name := ts.Name() + "-copy"
+	copied, err := InitStack(ctx, ts.WorkDir, name, InitStackOptions{
+		SecretsProvider: "passphrase",
+		Env:             ts.Env(),
+		CopyConfigFrom:  "source",
+		Config:          map[string]string{"integration-test-multi:region": "eu-west-1"},
+	})
+	if err != nil {
+		t.Fatalf("InitStack failed: %v", err)
+	}
+	// The source stack doesn't exist in the backend, so its secret can't be decrypted
+	if copied == nil || copied.SkippedSecrets != 1 || copied.SecretsErr == nil {
+		t.Errorf("expected the secret to be skipped, got %+v", copied)
+	}
+
+	ws := ts.Stack.Workspace()
+	t.Cleanup(func() { _ = ws.RemoveStack(context.Background(), name) })
+	config, err := ws.GetAllConfig(ctx, name)
+	if err != nil {
+		t.Fatalf("GetAllConfig failed: %v", err)
+	}
+	if got := config["integration-test-multi:region"].Value; got != "eu-west-1" {
+		t.Errorf("expected the initial config to take precedence, got %q", got)
+	}
+	if got := config["integration-test-multi:tags"].Value; !strings.Contains(got, "infra") || !strings.Contains(got, "zones") {
+		t.Errorf("expected nested config to be copied, got %q", got)
+	}
+	if _, ok := config["integration-test-multi:token"]; ok {
+		t.Error("expected the secret not to be copied")
+	}
+}
+
+func TestIntegration_CopyConfig_ReencryptsSecrets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	t.Parallel()
+
+	ts := SetupTestStack(t, "multi")
+	ctx := context.Background()
+
+	if err := ts.Stack.SetConfig(ctx, "integration-test-multi:region", auto.ConfigValue{Value: "us-west-2"}); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := ts.Stack.SetConfig(ctx, "integration-test-multi:token", auto.ConfigValue{Value: "s3cret", Secret: true}); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	name := ts.Name() + "-copy"
+	if _, err := InitStack(ctx, ts.WorkDir, name, InitStackOptions{SecretsProvider: "passphrase", Env: ts.Env()}); err != nil {
+		t.Fatalf("InitStack failed: %v", err)
+	}
+	ws := ts.Stack.Workspace()
+	t.Cleanup(func() { _ = ws.RemoveStack(context.Background(), name) })
+
+	copied, err := CopyConfig(ctx, ts.WorkDir, ts.Name(), name, ConfigCopyOptions{Env: ts.Env()})
+	if err != nil {
+		t.Fatalf("CopyConfig failed: %v", err)
+	}
+	if copied.Copied != 2 || copied.Secrets != 1 || copied.SkippedSecrets != 0 {
+		t.Errorf("unexpected copy result: %+v", copied)
+	}
+
+	token, err := ws.GetConfig(ctx, name, "integration-test-multi:token")
+	if err != nil {
+		t.Fatalf("GetConfig failed: %v", err)
+	}
+	if token.Value != "s3cret" || !token.Secret {
+		t.Errorf("expected the secret to be re-encrypted, got %+v", token)
+	}
+}
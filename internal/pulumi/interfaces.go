@@ -1,6 +1,9 @@
 package pulumi
 
-import "context"
+import (
+	"context"
+	"os/exec"
+)
 
 // StackOperator handles stack mutation operations (preview, up, refresh, destroy).
 // Implementations own the event channels and return receive-only channels.
@@ -17,6 +20,9 @@ type StackOperator interface {
 
 	// Destroy executes pulumi destroy and returns a channel of events.
 	Destroy(ctx context.Context, workDir, stackName string, opts OperationOptions) <-chan OperationEvent
+
+	// Cancel asks the backend to stop the stack's running update, like `pulumi cancel`.
+	Cancel(ctx context.Context, workDir, stackName string, opts OperationOptions) error
 }
 
 // StackReader handles read-only stack queries.
@@ -28,11 +34,35 @@ type StackReader interface {
 	// pageSize is the number of entries per page, page is 1-indexed.
 	GetHistory(ctx context.Context, workDir, stackName string, pageSize, page int, opts ReadOptions) ([]UpdateSummary, error)
 
+	// ExportDeploymentAtVersion returns the resources in the deployment snapshot
+	// written by the given update version. Version 0 returns an empty snapshot.
+	ExportDeploymentAtVersion(ctx context.Context, workDir, stackName string, version int, opts ReadOptions) ([]ResourceInfo, error)
+
 	// GetStacks returns available stacks for a workspace.
 	GetStacks(ctx context.Context, workDir string, opts ReadOptions) ([]StackInfo, error)
 
 	// SelectStack sets the specified stack as current.
 	SelectStack(ctx context.Context, workDir, stackName string, opts ReadOptions) error
+
+	// GetPendingOperations returns the operations left unfinished in the stack's state.
+	GetPendingOperations(ctx context.Context, workDir, stackName string, opts ReadOptions) ([]PendingOperation, error)
+
+	// GetTags returns the stack's tags.
+	GetTags(ctx context.Context, workDir, stackName string, opts ReadOptions) (map[string]string, error)
+
+	// SetTag sets a tag on the stack. An empty value removes the tag.
+	SetTag(ctx context.Context, workDir, stackName, key, value string, opts ReadOptions) error
+
+	// GetCloudStacks returns the stacks of every project in the backend's organizations.
+	GetCloudStacks(ctx context.Context, workDir string, opts ReadOptions) ([]CloudStack, error)
+
+	// ExportCloudStack returns the resources of a stack given by its fully qualified
+	// name, without needing its program.
+	ExportCloudStack(ctx context.Context, workDir, name string, opts ReadOptions) ([]ResourceInfo, error)
+
+	// RevealSecret decrypts the secret at a property path of a resource with the
+	// stack's secrets manager.
+	RevealSecret(ctx context.Context, workDir, stackName, urn, path string, opts ReadOptions) (any, error)
 }
 
 // WorkspaceReader handles workspace-level queries.
@@ -41,7 +71,7 @@ type WorkspaceReader interface {
 	GetProjectInfo(ctx context.Context, workDir, stackName string, opts ReadOptions) (*ProjectInfo, error)
 
 	// FindWorkspaces finds Pulumi workspaces in a directory tree.
-	FindWorkspaces(startDir, currentWorkDir string) ([]WorkspaceInfo, error)
+	FindWorkspaces(startDir, currentWorkDir string, opts WorkspaceSearchOptions) ([]WorkspaceInfo, error)
 
 	// IsWorkspace checks if the given directory is a valid Pulumi workspace.
 	IsWorkspace(dir string) bool
@@ -49,28 +79,127 @@ type WorkspaceReader interface {
 	// GetWhoAmI returns the current backend user and URL.
 	GetWhoAmI(ctx context.Context, workDir string, opts ReadOptions) (*WhoAmIInfo, error)
 
+	// GetAbout returns the Pulumi CLI version, backend and installed plugins.
+	GetAbout(ctx context.Context, workDir string, opts ReadOptions) (*AboutInfo, error)
+
 	// ListStackFiles finds all Pulumi.<stack>.yaml files in the workspace.
 	ListStackFiles(workDir string) ([]StackFileInfo, error)
 }
 
-// StackInitializer handles stack creation.
+// EnvironmentReader handles Pulumi ESC environments imported by a stack.
+type EnvironmentReader interface {
+	// ListEnvironments returns the names of ESC environments imported by the stack config.
+	ListEnvironments(workDir, stackName string) ([]string, error)
+
+	// OpenEnvironment resolves an ESC environment's environment variables and Pulumi config.
+	OpenEnvironment(ctx context.Context, workDir, name string, opts ReadOptions) (*StackEnvironment, error)
+}
+
+// GitReader reads the git checkout of a workspace.
+type GitReader interface {
+	// ReadGitInfo returns the branch, commit and dirty state of the checkout containing
+	// dir, or nil when dir is not in a git repository.
+	ReadGitInfo(ctx context.Context, dir string) (*GitInfo, error)
+}
+
+// StackInitializer handles project and stack creation and setup.
 type StackInitializer interface {
 	// InitStack creates a new stack with the given configuration.
-	InitStack(ctx context.Context, workDir, stackName string, opts InitStackOptions) error
+	// The result describes the config copied from opts.CopyConfigFrom, if any.
+	InitStack(ctx context.Context, workDir, stackName string, opts InitStackOptions) (*ConfigCopyResult, error)
+
+	// CopyConfig copies another stack's config to a stack, re-encrypting its secrets.
+	CopyConfig(ctx context.Context, workDir, fromStack, toStack string, opts ConfigCopyOptions) (*ConfigCopyResult, error)
+
+	// RemoveStack deletes a stack without resources and its config, like `pulumi stack rm`.
+	RemoveStack(ctx context.Context, workDir, stackName string, opts RemoveStackOptions) error
+
+	// NewProject scaffolds a project and its stack from a template into dir, like `pulumi new`.
+	NewProject(ctx context.Context, dir string, opts NewProjectOptions) error
+}
+
+// StateEditor edits flags on resources already in stack state.
+type StateEditor interface {
+	// SetProtect sets or clears the protected flag on resources in a single command.
+	// Protected resources cannot be destroyed until they are unprotected.
+	SetProtect(ctx context.Context, workDir, stackName string, urns []string, protect bool, opts StateProtectOptions) (*CommandResult, error)
 }
 
 // ResourceImporter handles resource import operations.
 type ResourceImporter interface {
+	StateEditor
+
 	// Import imports an external resource into the stack.
 	// parentURN is optional - if provided, the resource will be imported as a child of this resource.
 	Import(ctx context.Context, workDir, stackName, resourceType, resourceName, importID, parentURN string, opts ImportOptions) (*CommandResult, error)
 
+	// ImportBatch imports several external resources into the stack in a single operation.
+	// The import is all-or-nothing.
+	ImportBatch(ctx context.Context, workDir, stackName string, specs []ImportSpec, opts ImportOptions) (*CommandResult, error)
+
+	// PreviewImport previews importing an external resource, returning the inputs
+	// it would be imported with. The state is not changed.
+	PreviewImport(ctx context.Context, workDir, stackName string, spec ImportSpec, opts ImportOptions) (*ImportPreview, error)
+
 	// StateDelete removes a resource from state without deleting the actual resource.
 	StateDelete(ctx context.Context, workDir, stackName, urn string, opts StateDeleteOptions) (*CommandResult, error)
 
-	// Protect marks a resource as protected, preventing it from being destroyed.
-	Protect(ctx context.Context, workDir, stackName, urn string, opts StateProtectOptions) (*CommandResult, error)
+	// RepairState applies fixes for inconsistent state by editing the exported deployment.
+	// With opts.DryRun the state is not written and only the report is returned.
+	RepairState(ctx context.Context, workDir, stackName string, fixes []StateFix, opts StateRepairOptions) (*StateRepairReport, error)
+
+	// ResourceState returns the state entry of a resource as indented JSON.
+	ResourceState(ctx context.Context, workDir, stackName, urn string, opts StateRepairOptions) ([]byte, error)
+
+	// SetResourceState replaces the state entry of a resource with edited JSON,
+	// failing when the entry is no longer original. The resource's URN and type
+	// can't be changed.
+	SetResourceState(ctx context.Context, workDir, stackName, urn string, original, edited []byte, opts StateRepairOptions) error
+}
+
+// StackStateManager recovers stacks left locked by interrupted updates.
+type StackStateManager interface {
+	// CancelPending cancels the stack's in-progress update and removes the pending
+	// operations left in its state.
+	CancelPending(ctx context.Context, workDir, stackName string, opts CancelPendingOptions) (*CancelPendingResult, error)
+
+	// ClearPendingOperations removes the pending operations left in the stack's state
+	// without cancelling any update in progress.
+	ClearPendingOperations(ctx context.Context, workDir, stackName string, opts CancelPendingOptions) (*CancelPendingResult, error)
+}
+
+// StateTransferer saves stack state to files and restores it, like
+// `pulumi stack export` and `pulumi stack import`.
+type StateTransferer interface {
+	// ExportDeployment writes the stack's deployment to path.
+	ExportDeployment(ctx context.Context, workDir, stackName, path string, opts DeploymentTransferOptions) (*DeploymentFile, error)
+
+	// ImportDeployment validates the deployment saved at path and replaces the
+	// stack's state with it.
+	ImportDeployment(ctx context.Context, workDir, stackName, path string, opts DeploymentTransferOptions) (*DeploymentFile, error)
+}
+
+// BackendAuthenticator logs in to Pulumi backends, like `pulumi login`.
+type BackendAuthenticator interface {
+	// Login logs in to the backend at opts.URL without prompting, using opts.Token
+	// for Pulumi Cloud, and returns who is logged in.
+	Login(ctx context.Context, workDir string, opts LoginOptions) (*WhoAmIInfo, error)
+
+	// LoginCommand returns the interactive login command for the backend at
+	// opts.URL, which must run attached to the terminal.
+	LoginCommand(workDir string, opts LoginOptions) *exec.Cmd
+}
+
+// StackLocker records which p5 process runs an operation on a stack, so another
+// p5 process can show the stack is busy instead of failing mid-way.
+type StackLocker interface {
+	// Acquire locks the stack while this process runs op on it. If another live
+	// process holds the stack, its lock is returned and the stack is left as is.
+	Acquire(workDir, stackName string, op OperationType) (*StackLock, error)
+
+	// Holder returns the lock another live process holds on the stack, or nil.
+	Holder(workDir, stackName string) (*StackLock, error)
 
-	// Unprotect removes the protected flag from a resource, allowing it to be destroyed.
-	Unprotect(ctx context.Context, workDir, stackName, urn string, opts StateProtectOptions) (*CommandResult, error)
+	// Release removes this process's lock on the stack.
+	Release(workDir, stackName string) error
 }
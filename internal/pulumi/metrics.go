@@ -0,0 +1,44 @@
+package pulumi
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/rfhold/p5/internal/telemetry"
+)
+
+// Operation metrics. Creating instruments only fails for invalid names, and a
+// failed instrument still records as a no-op, so errors are ignored.
+var (
+	operationDuration, _ = telemetry.Meter().Float64Histogram("p5.operation.duration",
+		metric.WithDescription("Duration of previews and operations"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(telemetry.DurationBuckets...),
+	)
+	resourcesProcessed, _ = telemetry.Meter().Int64Counter("p5.operation.resources",
+		metric.WithDescription("Resource steps finished by operations"),
+		metric.WithUnit("{resource}"),
+	)
+)
+
+// recordOperation records how long a preview or an operation took and whether it failed
+func recordOperation(ctx context.Context, op OperationType, dryRun bool, started time.Time, err error) {
+	operationDuration.Record(ctx, time.Since(started).Seconds(), metric.WithAttributes(
+		telemetry.AttrOperation.String(strings.ToLower(op.String())),
+		attribute.Bool("p5.dry_run", dryRun),
+		attribute.Bool("p5.failed", err != nil),
+	))
+}
+
+// recordResourceStep counts a finished resource step of an operation
+func recordResourceStep(ctx context.Context, op OperationType, event OperationEvent) {
+	resourcesProcessed.Add(ctx, 1, metric.WithAttributes(
+		telemetry.AttrOperation.String(strings.ToLower(op.String())),
+		telemetry.AttrResourceOp.String(string(event.Op)),
+		attribute.Bool("p5.failed", event.Status == StepFailed),
+	))
+}
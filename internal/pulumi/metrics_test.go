@@ -0,0 +1,47 @@
+package pulumi
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// TestOperationMetrics verifies finished resource steps are counted and the
+// operation's duration is recorded
+func TestOperationMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	const urn = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	in := make(chan OperationEvent, 3)
+	in <- OperationEvent{URN: urn, Op: OpCreate, Status: StepRunning}
+	in <- OperationEvent{URN: urn, Op: OpCreate, Status: StepSuccess}
+	in <- OperationEvent{Done: true}
+	close(in)
+
+	ctx, span := startOperationSpan(context.Background(), "pulumi.up", OperationUp, "/fake/path", "dev", OperationOptions{})
+	for range traceOperation(ctx, span, OperationUp, in) {
+	}
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatal(err)
+	}
+	found := make(map[string]metricdata.Aggregation)
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			found[m.Name] = m.Data
+		}
+	}
+	resources, ok := found["p5.operation.resources"].(metricdata.Sum[int64])
+	if !ok || len(resources.DataPoints) != 1 || resources.DataPoints[0].Value != 1 {
+		t.Errorf("expected one finished resource step, got %+v", found["p5.operation.resources"])
+	}
+	duration, ok := found["p5.operation.duration"].(metricdata.Histogram[float64])
+	if !ok || len(duration.DataPoints) != 1 || duration.DataPoints[0].Count != 1 {
+		t.Errorf("expected the up's duration, got %+v", found["p5.operation.duration"])
+	}
+}
@@ -0,0 +1,69 @@
+package pulumi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// projectNamePattern matches the project names Pulumi accepts
+var projectNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,100}$`)
+
+// NewProjectOptions contains options for scaffolding a project from a template
+type NewProjectOptions struct {
+	Template  string            // Template name, URL or local path, e.g. "aws-typescript"
+	Name      string            // Project name written to Pulumi.yaml
+	StackName string            // Stack created for the project
+	Env       map[string]string // Additional environment variables
+}
+
+// NewProject scaffolds a project from a Pulumi template into dir, creates its stack
+// and installs its dependencies, like `pulumi new --yes`. dir is created if it
+// doesn't exist, and must otherwise be empty.
+func NewProject(ctx context.Context, dir string, opts NewProjectOptions) error {
+	if err := ValidateProjectDir(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	args := []string{"new", opts.Template, "--yes", "--non-interactive", "--dir", dir}
+	if opts.Name != "" {
+		args = append(args, "--name", opts.Name)
+	}
+	if opts.StackName != "" {
+		args = append(args, "--stack", opts.StackName)
+	}
+	if output, err := runPulumiCommand(ctx, dir, opts.Env, args...); err != nil {
+		return fmt.Errorf("pulumi new failed: %w\n%s", err, strings.TrimSpace(output))
+	}
+	return nil
+}
+
+// ValidateProjectName checks a project name is one Pulumi accepts
+func ValidateProjectName(name string) error {
+	if !projectNamePattern.MatchString(name) {
+		return errors.New("project names may only contain letters, digits, '-', '_' and '.'")
+	}
+	return nil
+}
+
+// ValidateProjectDir checks a project can be scaffolded into dir: it must not exist
+// yet or be an empty directory
+func ValidateProjectDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	case err != nil:
+		return fmt.Errorf("%s is not a directory: %w", dir, err)
+	case len(entries) > 0:
+		return fmt.Errorf("%s is not empty", dir)
+	}
+	return nil
+}
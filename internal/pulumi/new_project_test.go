@@ -0,0 +1,44 @@
+package pulumi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestValidateProjectDir verifies projects are only scaffolded into missing or
+// empty directories
+func TestValidateProjectDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := ValidateProjectDir(filepath.Join(dir, "app")); err != nil {
+		t.Errorf("missing directory: %v", err)
+	}
+	if err := ValidateProjectDir(dir); err != nil {
+		t.Errorf("empty directory: %v", err)
+	}
+
+	file := filepath.Join(dir, "Pulumi.yaml")
+	if err := os.WriteFile(file, []byte("name: app\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateProjectDir(dir); err == nil {
+		t.Error("expected an error for a directory that isn't empty")
+	}
+	if err := ValidateProjectDir(file); err == nil {
+		t.Error("expected an error for a file")
+	}
+}
+
+// TestValidateProjectName verifies project names are limited to what Pulumi accepts
+func TestValidateProjectName(t *testing.T) {
+	for _, name := range []string{"app", "my-app_2.0"} {
+		if err := ValidateProjectName(name); err != nil {
+			t.Errorf("%q: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "my app", "app/api"} {
+		if err := ValidateProjectName(name); err == nil {
+			t.Errorf("%q: expected an error", name)
+		}
+	}
+}
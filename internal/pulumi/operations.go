@@ -34,6 +34,18 @@ func RunUp(ctx context.Context, workDir, stackName string, opts OperationOptions
 	if len(opts.Excludes) > 0 {
 		upOpts = append(upOpts, optup.Exclude(opts.Excludes))
 	}
+	if opts.Refresh {
+		upOpts = append(upOpts, optup.Refresh())
+	}
+	if opts.Plan != "" {
+		upOpts = append(upOpts, optup.Plan(opts.Plan))
+	}
+	if opts.ContinueOnError {
+		upOpts = append(upOpts, optup.ContinueOnError())
+	}
+	if opts.Message != "" {
+		upOpts = append(upOpts, optup.Message(opts.Message))
+	}
 
 	_, err = stack.Up(ctx, upOpts...)
 	if err != nil {
@@ -44,6 +56,19 @@ func RunUp(ctx context.Context, workDir, stackName string, opts OperationOptions
 	eventCh <- OperationEvent{Done: true}
 }
 
+// CancelUpdate asks the backend to stop the stack's running update, like `pulumi cancel`.
+// The update's own process is interrupted separately by cancelling its context.
+func CancelUpdate(ctx context.Context, workDir, stackName string, opts OperationOptions) error {
+	stack, err := selectStack(ctx, workDir, stackName, opts.Env)
+	if err != nil {
+		return err
+	}
+	if err := stack.Cancel(ctx); err != nil {
+		return fmt.Errorf("cancel failed: %w", err)
+	}
+	return nil
+}
+
 // RunRefresh executes pulumi refresh
 //
 //nolint:dupl // Similar structure to RunDestroy is intentional - different operation types
@@ -100,6 +125,12 @@ func RunDestroy(ctx context.Context, workDir, stackName string, opts OperationOp
 	if len(opts.Excludes) > 0 {
 		destroyOpts = append(destroyOpts, optdestroy.Exclude(opts.Excludes))
 	}
+	if opts.ContinueOnError {
+		destroyOpts = append(destroyOpts, optdestroy.ContinueOnError())
+	}
+	if opts.Message != "" {
+		destroyOpts = append(destroyOpts, optdestroy.Message(opts.Message))
+	}
 
 	_, err = stack.Destroy(ctx, destroyOpts...)
 	if err != nil {
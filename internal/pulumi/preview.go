@@ -3,6 +3,8 @@ package pulumi
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/events"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/optdestroy"
@@ -43,6 +45,16 @@ func RunUpPreview(ctx context.Context, workDir, stackName string, opts Operation
 	if len(opts.Excludes) > 0 {
 		previewOpts = append(previewOpts, optpreview.Exclude(opts.Excludes))
 	}
+	if opts.Refresh {
+		previewOpts = append(previewOpts, optpreview.Refresh())
+	}
+	if opts.Plan != "" {
+		if err := os.MkdirAll(filepath.Dir(opts.Plan), 0o755); err != nil {
+			eventCh <- PreviewEvent{Error: fmt.Errorf("failed to create plan directory: %w", err)}
+			return
+		}
+		previewOpts = append(previewOpts, optpreview.Plan(opts.Plan))
+	}
 
 	// Run preview
 	_, err = stack.Preview(ctx, previewOpts...)
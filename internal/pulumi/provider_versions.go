@@ -0,0 +1,168 @@
+package pulumi
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// Sources a provider version in state is compared with
+const (
+	VersionSourcePlugin = "plugin"
+	VersionSourceGoMod  = "go.mod"
+)
+
+// ProviderVersionMismatch is a provider package whose version in state diverges
+// from the installed plugins or from what the program depends on
+type ProviderVersionMismatch struct {
+	Package      string   // Provider package, e.g. "aws"
+	StateVersion string   // Version of the provider resource in state
+	Source       string   // VersionSourcePlugin or VersionSourceGoMod
+	Versions     []string // Versions found in Source
+}
+
+// StateProviderVersions returns the distinct versions of each provider package in
+// state, read from the version input of its provider resources
+func StateProviderVersions(resources []ResourceInfo) map[string][]string {
+	versions := make(map[string][]string)
+	for _, r := range resources {
+		pkg, ok := strings.CutPrefix(r.Type, "pulumi:providers:")
+		if !ok {
+			continue
+		}
+		v, _ := r.Inputs["version"].(string)
+		if v == "" || slices.Contains(versions[pkg], v) {
+			continue
+		}
+		versions[pkg] = append(versions[pkg], v)
+	}
+	for _, vs := range versions {
+		slices.SortFunc(vs, compareVersions)
+	}
+	return versions
+}
+
+// GoModProviderVersions returns the provider SDK versions required by the go.mod
+// of a Go program in dir or one of its parents, keyed by package. SDKs are modules
+// of repositories named pulumi-<package>, like github.com/pulumi/pulumi-aws/sdk/v6.
+// Returns nil without an error when there is no go.mod.
+func GoModProviderVersions(dir string) (map[string]string, error) {
+	path, err := findGoMod(dir)
+	if err != nil || path == "" {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	f, err := modfile.ParseLax(path, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	versions := make(map[string]string)
+	for _, req := range f.Require {
+		if pkg := providerSDKPackage(req.Mod.Path); pkg != "" {
+			versions[pkg] = req.Mod.Version
+		}
+	}
+	return versions, nil
+}
+
+// findGoMod returns the go.mod in dir or its nearest parent holding one, or ""
+func findGoMod(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, "go.mod")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// providerSDKPackage returns the provider package of a Go SDK module path, or "".
+// Example: "github.com/pulumi/pulumi-aws/sdk/v6" -> "aws"
+func providerSDKPackage(modPath string) string {
+	parts := strings.Split(modPath, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if pkg, ok := strings.CutPrefix(parts[i], "pulumi-"); ok && pkg != "" && parts[i+1] == "sdk" {
+			return pkg
+		}
+	}
+	return ""
+}
+
+// ProviderVersionMismatches compares the provider versions in state with the
+// installed resource plugins and the go.mod versions. Versions diverge when their
+// major or minor versions differ, since patch releases rarely change diffs. A
+// package without installed plugins is not reported, as Pulumi installs the
+// version it needs on demand.
+func ProviderVersionMismatches(resources []ResourceInfo, plugins []PluginVersion, goMod map[string]string) []ProviderVersionMismatch {
+	installed := make(map[string][]string)
+	for _, p := range plugins {
+		if p.Kind == "resource" && p.Version != "" {
+			installed[p.Name] = append(installed[p.Name], p.Version)
+		}
+	}
+
+	var mismatches []ProviderVersionMismatch
+	for pkg, stateVersions := range StateProviderVersions(resources) {
+		for _, v := range stateVersions {
+			if pluginVersions := installed[pkg]; len(pluginVersions) > 0 && !slices.ContainsFunc(pluginVersions, func(p string) bool {
+				return sameMinorVersion(v, p)
+			}) {
+				mismatches = append(mismatches, ProviderVersionMismatch{
+					Package: pkg, StateVersion: v, Source: VersionSourcePlugin, Versions: pluginVersions,
+				})
+			}
+			if modVersion, ok := goMod[pkg]; ok && !sameMinorVersion(v, modVersion) {
+				mismatches = append(mismatches, ProviderVersionMismatch{
+					Package: pkg, StateVersion: v, Source: VersionSourceGoMod, Versions: []string{modVersion},
+				})
+			}
+		}
+	}
+	slices.SortFunc(mismatches, func(a, b ProviderVersionMismatch) int {
+		return cmp.Or(cmp.Compare(a.Package, b.Package), compareVersions(a.StateVersion, b.StateVersion), cmp.Compare(a.Source, b.Source))
+	})
+	return mismatches
+}
+
+// sameMinorVersion reports whether two versions, with or without a leading "v",
+// share their major and minor versions. Unparsable versions only match themselves.
+func sameMinorVersion(a, b string) bool {
+	a, b = canonicalVersion(a), canonicalVersion(b)
+	if !semver.IsValid(a) || !semver.IsValid(b) {
+		return a == b
+	}
+	return semver.MajorMinor(a) == semver.MajorMinor(b)
+}
+
+func compareVersions(a, b string) int {
+	return semver.Compare(canonicalVersion(a), canonicalVersion(b))
+}
+
+func canonicalVersion(v string) string {
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	return v
+}
@@ -0,0 +1,68 @@
+package pulumi
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestProviderVersionMismatches verifies provider versions in state are reported
+// when their minor version differs from every installed plugin or from go.mod
+func TestProviderVersionMismatches(t *testing.T) {
+	resources := []ResourceInfo{
+		{Type: "pulumi:providers:aws", Inputs: map[string]any{"version": "6.52.0"}},
+		{Type: "pulumi:providers:aws", Inputs: map[string]any{"version": "6.52.0"}},
+		{Type: "pulumi:providers:kubernetes", Inputs: map[string]any{"version": "4.18.1"}},
+		{Type: "pulumi:providers:random", Inputs: map[string]any{"version": "4.16.0"}},
+		{Type: "pulumi:providers:gcp"},
+		{Type: "aws:s3/bucket:Bucket", Inputs: map[string]any{"version": "1.0.0"}},
+	}
+	plugins := []PluginVersion{
+		{Name: "aws", Kind: "resource", Version: "5.43.0"},
+		{Name: "aws", Kind: "resource", Version: "6.66.0"},
+		{Name: "kubernetes", Kind: "resource", Version: "4.18.3"},
+		{Name: "nodejs", Kind: "language", Version: "3.0.0"},
+	}
+	goMod := map[string]string{"kubernetes": "v4.18.3", "random": "v4.17.0"}
+
+	got := ProviderVersionMismatches(resources, plugins, goMod)
+	want := []ProviderVersionMismatch{
+		{Package: "aws", StateVersion: "6.52.0", Source: VersionSourcePlugin, Versions: []string{"5.43.0", "6.66.0"}},
+		{Package: "random", StateVersion: "4.16.0", Source: VersionSourceGoMod, Versions: []string{"v4.17.0"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+// TestGoModProviderVersions verifies provider SDKs are read from the nearest go.mod
+func TestGoModProviderVersions(t *testing.T) {
+	dir := t.TempDir()
+	goMod := `module example.com/infra
+
+go 1.22
+
+require (
+	github.com/pulumi/pulumi-aws/sdk/v6 v6.52.0
+	github.com/pulumi/pulumi/sdk/v3 v3.150.0
+	github.com/pulumiverse/pulumi-grafana/sdk v0.4.2
+)
+`
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	program := filepath.Join(dir, "stacks", "app")
+	if err := os.MkdirAll(program, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GoModProviderVersions(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"aws": "v6.52.0", "grafana": "v0.4.2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
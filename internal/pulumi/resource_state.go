@@ -0,0 +1,163 @@
+package pulumi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ResourceState exports the stack's deployment and returns the state entry of the
+// live resource with urn, indented for editing
+func ResourceState(ctx context.Context, workDir, stackName, urn string, opts StateRepairOptions) ([]byte, error) {
+	stack, err := selectStack(ctx, workDir, stackName, opts.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := stack.Export(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export stack: %w", err)
+	}
+
+	raws, _, err := deploymentResources(state.Deployment)
+	if err != nil {
+		return nil, err
+	}
+	i, err := liveResourceIndex(raws, urn)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, raws[i], "", "  "); err != nil {
+		return nil, fmt.Errorf("failed to format resource state: %w", err)
+	}
+	out.WriteString("\n")
+	return out.Bytes(), nil
+}
+
+// SetResourceState replaces the state entry of the live resource with urn by
+// edited, then imports the deployment. The entry must still be original, as
+// returned by ResourceState, and the edit can't change the resource's URN or type.
+func SetResourceState(ctx context.Context, workDir, stackName, urn string, original, edited []byte, opts StateRepairOptions) error {
+	stack, err := selectStack(ctx, workDir, stackName, opts.Env)
+	if err != nil {
+		return err
+	}
+
+	state, err := stack.Export(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to export stack: %w", err)
+	}
+
+	deployment, err := replaceResourceState(state.Deployment, urn, original, edited)
+	if err != nil {
+		return err
+	}
+	if opts.DryRun {
+		return nil
+	}
+
+	state.Deployment = deployment
+	if err := stack.Import(ctx, state); err != nil {
+		return fmt.Errorf("failed to import edited state: %w", err)
+	}
+	return nil
+}
+
+// ValidateResourceState checks that edited is a resource state entry for the same
+// resource as original: a JSON object with the same URN and type
+func ValidateResourceState(original, edited []byte) error {
+	var before, after stateIdentity
+	if err := json.Unmarshal(original, &before); err != nil {
+		return fmt.Errorf("failed to parse resource state: %w", err)
+	}
+	if err := json.Unmarshal(edited, &after); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if after.URN != before.URN {
+		return fmt.Errorf("the urn can't be changed (was %q, now %q)", before.URN, after.URN)
+	}
+	if after.Type != before.Type {
+		return fmt.Errorf("the type can't be changed (was %q, now %q)", before.Type, after.Type)
+	}
+	return nil
+}
+
+// stateIdentity holds the fields of a deployment resource an edit must keep
+type stateIdentity struct {
+	URN  string `json:"urn"`
+	Type string `json:"type"`
+}
+
+// replaceResourceState replaces the live entry of urn in a raw deployment by edited,
+// failing when the entry is no longer original
+func replaceResourceState(data json.RawMessage, urn string, original, edited []byte) (json.RawMessage, error) {
+	raws, deployment, err := deploymentResources(data)
+	if err != nil {
+		return nil, err
+	}
+	i, err := liveResourceIndex(raws, urn)
+	if err != nil {
+		return nil, err
+	}
+	var current, before bytes.Buffer
+	if err := json.Compact(&current, raws[i]); err != nil {
+		return nil, fmt.Errorf("failed to parse deployment resource: %w", err)
+	}
+	if err := json.Compact(&before, original); err != nil {
+		return nil, fmt.Errorf("failed to parse resource state: %w", err)
+	}
+	if !bytes.Equal(current.Bytes(), before.Bytes()) {
+		return nil, errors.New("the state of the resource changed since it was opened for editing")
+	}
+	if err := ValidateResourceState(raws[i], edited); err != nil {
+		return nil, err
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, edited); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	raws[i] = compact.Bytes()
+
+	resources, err := json.Marshal(raws)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode deployment resources: %w", err)
+	}
+	deployment["resources"] = resources
+	out, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode deployment: %w", err)
+	}
+	return out, nil
+}
+
+// deploymentResources splits a raw deployment into its resources and its fields
+func deploymentResources(data json.RawMessage) ([]json.RawMessage, map[string]json.RawMessage, error) {
+	var deployment map[string]json.RawMessage
+	if err := json.Unmarshal(data, &deployment); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse deployment: %w", err)
+	}
+	var raws []json.RawMessage
+	if len(deployment["resources"]) > 0 {
+		if err := json.Unmarshal(deployment["resources"], &raws); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse deployment resources: %w", err)
+		}
+	}
+	return raws, deployment, nil
+}
+
+// liveResourceIndex returns the index of the live (not pending delete) entry of urn
+func liveResourceIndex(raws []json.RawMessage, urn string) (int, error) {
+	for i, raw := range raws {
+		var e stateEntry
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return 0, fmt.Errorf("failed to parse deployment resource: %w", err)
+		}
+		if e.URN == urn && !e.Delete {
+			return i, nil
+		}
+	}
+	return 0, errors.New("resource not found in state: " + urn)
+}
@@ -0,0 +1,50 @@
+package pulumi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestReplaceResourceState verifies the live entry is replaced, pending deletes and
+// other fields are kept, and edits changing the resource's identity, or made to an
+// entry that changed since, are rejected
+func TestReplaceResourceState(t *testing.T) {
+	const urn = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	deployment := `{"manifest":{"time":"now"},"resources":[` +
+		`{"urn":"` + urn + `","type":"aws:s3/bucket:Bucket","id":"old","delete":true},` +
+		`{"urn":"` + urn + `","type":"aws:s3/bucket:Bucket","id":"logs-1"}]}`
+	original := "{\n  \"urn\": \"" + urn + "\",\n  \"type\": \"aws:s3/bucket:Bucket\",\n  \"id\": \"logs-1\"\n}\n"
+
+	edited := `{"urn":"` + urn + `","type":"aws:s3/bucket:Bucket","id":"logs-2"}`
+	out, err := replaceResourceState(json.RawMessage(deployment), urn, []byte(original), []byte(edited))
+	if err != nil {
+		t.Fatalf("replaceResourceState: %v", err)
+	}
+	got := string(out)
+	for _, want := range []string{`"id":"old","delete":true`, `"id":"logs-2"`, `"manifest":{"time":"now"}`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %s in %s", want, got)
+		}
+	}
+
+	tests := map[string]string{
+		"invalid JSON": `{"urn":`,
+		"urn":          `{"urn":"urn:pulumi:dev::app::aws:s3/bucket:Bucket::other","type":"aws:s3/bucket:Bucket"}`,
+		"type":         `{"urn":"` + urn + `","type":"aws:s3/bucket:BucketV2"}`,
+	}
+	for name, edited := range tests {
+		if _, err := replaceResourceState(json.RawMessage(deployment), urn, []byte(original), []byte(edited)); err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("%s: expected an error mentioning %q, got %v", name, name, err)
+		}
+	}
+
+	if _, err := replaceResourceState(json.RawMessage(deployment), "urn:pulumi:dev::app::x:y:Z::gone", []byte(original), []byte(edited)); err == nil {
+		t.Error("expected an error for a resource missing from state")
+	}
+
+	stale := `{"urn":"` + urn + `","type":"aws:s3/bucket:Bucket","id":"logs-0"}`
+	if _, err := replaceResourceState(json.RawMessage(deployment), urn, []byte(stale), []byte(edited)); err == nil || !strings.Contains(err.Error(), "changed since") {
+		t.Errorf("expected an error for state changed since it was opened, got %v", err)
+	}
+}
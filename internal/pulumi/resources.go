@@ -32,6 +32,13 @@ func GetStackResources(ctx context.Context, workDir, stackName string, env map[s
 		return nil, fmt.Errorf("failed to export stack: %w", err)
 	}
 
+	return parseDeploymentResources(state.Deployment)
+}
+
+// parseDeploymentResources converts the resources of a raw deployment into ResourceInfo,
+// attaching provider inputs to each resource that references a provider. Secrets
+// in inputs and outputs are masked; provider inputs are kept for plugins.
+func parseDeploymentResources(data json.RawMessage) ([]ResourceInfo, error) {
 	// Parse the deployment to get resources with inputs and outputs
 	var deployment struct {
 		Resources []struct {
@@ -40,12 +47,18 @@ func GetStackResources(ctx context.Context, workDir, stackName string, env map[s
 			Provider string         `json:"provider"`
 			Parent   string         `json:"parent"`
 			Protect  bool           `json:"protect"`
+			Delete   bool           `json:"delete"`
 			Inputs   map[string]any `json:"inputs"`
 			Outputs  map[string]any `json:"outputs"`
+			// Dependencies include property dependencies
+			Dependencies []string `json:"dependencies"`
+			DeletedWith  string   `json:"deletedWith"`
+			// Recorded by SDKs that support it, like project:///index.ts#12,5
+			SourcePosition string `json:"sourcePosition"`
 		} `json:"resources"`
 	}
 
-	if err := json.Unmarshal(state.Deployment, &deployment); err != nil {
+	if err := json.Unmarshal(data, &deployment); err != nil {
 		return nil, fmt.Errorf("failed to parse deployment: %w", err)
 	}
 
@@ -62,14 +75,18 @@ func GetStackResources(ctx context.Context, workDir, stackName string, env map[s
 	resources := make([]ResourceInfo, 0, len(deployment.Resources))
 	for _, r := range deployment.Resources {
 		info := ResourceInfo{
-			URN:       r.URN,
-			Type:      r.Type,
-			Name:      ExtractResourceName(r.URN),
-			Provider:  r.Provider,
-			Parent:    r.Parent,
-			Protected: r.Protect,
-			Inputs:    r.Inputs,
-			Outputs:   r.Outputs,
+			URN:            r.URN,
+			Type:           r.Type,
+			Name:           ExtractResourceName(r.URN),
+			Provider:       r.Provider,
+			Parent:         r.Parent,
+			Protected:      r.Protect,
+			Inputs:         maskSecrets(r.Inputs),
+			Outputs:        maskSecrets(r.Outputs),
+			PendingDelete:  r.Delete,
+			Dependencies:   r.Dependencies,
+			DeletedWith:    r.DeletedWith,
+			SourcePosition: r.SourcePosition,
 		}
 
 		// Look up provider inputs if this resource has a provider reference
@@ -0,0 +1,162 @@
+package pulumi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Secret values in state and engine events are objects holding the secret
+// signature under the signature key, with the encrypted value as ciphertext or,
+// when exported with --show-secrets, the JSON encoded value as plaintext
+const (
+	secretSigKey = "4dabf18193072939515e22adb298388d"
+	secretSig    = "1b47061264138c4ac30d75fd1eb44270"
+)
+
+// IsSecret reports whether a property value is a secret
+func IsSecret(value any) bool {
+	m, ok := value.(map[string]any)
+	return ok && m[secretSigKey] == secretSig
+}
+
+// SecretPaths returns the paths of the secrets in properties, like password,
+// tags.token or rules[0].key, sorted
+func SecretPaths(properties ...map[string]any) []string {
+	seen := make(map[string]bool)
+	for _, props := range properties {
+		for key, value := range props {
+			mapSecrets(value, key, func(path string, secret any) any {
+				seen[path] = true
+				return secret
+			})
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// RevealSecrets returns a copy of properties with the secrets at the paths of
+// revealed replaced by their values. Other secrets are left as they are.
+func RevealSecrets(properties map[string]any, revealed map[string]any) map[string]any {
+	if len(revealed) == 0 || properties == nil {
+		return properties
+	}
+	out := make(map[string]any, len(properties))
+	for key, value := range properties {
+		out[key] = mapSecrets(value, key, func(path string, secret any) any {
+			if v, ok := revealed[path]; ok {
+				return v
+			}
+			return secret
+		})
+	}
+	return out
+}
+
+// maskSecrets returns a copy of properties with the values of secrets dropped,
+// so decrypted secrets aren't kept in memory, copied or written to files
+func maskSecrets(properties map[string]any) map[string]any {
+	if properties == nil {
+		return nil
+	}
+	out := make(map[string]any, len(properties))
+	for key, value := range properties {
+		out[key] = mapSecrets(value, key, func(string, any) any {
+			return map[string]any{secretSigKey: secretSig}
+		})
+	}
+	return out
+}
+
+// mapSecrets returns a copy of value with each secret in it replaced by the
+// result of fn, called with the path of the secret
+func mapSecrets(value any, path string, fn func(path string, secret any) any) any {
+	if IsSecret(value) {
+		return fn(path, value)
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, child := range v {
+			out[key] = mapSecrets(child, path+"."+key, fn)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, child := range v {
+			out[i] = mapSecrets(child, fmt.Sprintf("%s[%d]", path, i), fn)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// secretPlaintext decodes the value of a secret exported with --show-secrets
+func secretPlaintext(secret any) (any, error) {
+	plaintext, ok := secret.(map[string]any)["plaintext"].(string)
+	if !ok {
+		return nil, fmt.Errorf("secret was not decrypted")
+	}
+	var value any
+	if err := json.Unmarshal([]byte(plaintext), &value); err != nil {
+		return nil, fmt.Errorf("failed to decode secret: %w", err)
+	}
+	return value, nil
+}
+
+// RevealSecret decrypts the secret at path in the outputs, or else the inputs, of
+// the live resource with urn, using the stack's secrets manager
+func RevealSecret(ctx context.Context, workDir, stackName, urn, path string, env map[string]string) (any, error) {
+	resolvedStackName, err := resolveStackName(ctx, workDir, stackName, env)
+	if err != nil {
+		return nil, err
+	}
+	output, err := runPulumiCommandStdout(ctx, workDir, env, "stack", "export", "--show-secrets", "--stack", resolvedStackName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export stack %s: %w", resolvedStackName, err)
+	}
+	state, err := decodeState(output, resolvedStackName)
+	if err != nil {
+		return nil, err
+	}
+	return findSecret(state.Deployment, urn, path)
+}
+
+// findSecret returns the decrypted value of the secret at path of the live
+// resource with urn in a deployment exported with --show-secrets
+func findSecret(deployment json.RawMessage, urn, path string) (any, error) {
+	raws, _, err := deploymentResources(deployment)
+	if err != nil {
+		return nil, err
+	}
+	i, err := liveResourceIndex(raws, urn)
+	if err != nil {
+		return nil, err
+	}
+	var resource struct {
+		Inputs  map[string]any `json:"inputs"`
+		Outputs map[string]any `json:"outputs"`
+	}
+	if err := json.Unmarshal(raws[i], &resource); err != nil {
+		return nil, fmt.Errorf("failed to parse resource state: %w", err)
+	}
+
+	var found any
+	for _, props := range []map[string]any{resource.Outputs, resource.Inputs} {
+		for key, value := range props {
+			mapSecrets(value, key, func(p string, secret any) any {
+				if p == path && found == nil {
+					found = secret
+				}
+				return secret
+			})
+		}
+		if found != nil {
+			return secretPlaintext(found)
+		}
+	}
+	return nil, fmt.Errorf("no secret %s in the state of %s", path, ExtractResourceName(urn))
+}
@@ -0,0 +1,85 @@
+package pulumi
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func secret(plaintext string) map[string]any {
+	return map[string]any{secretSigKey: secretSig, "plaintext": plaintext}
+}
+
+// TestSecretPaths verifies secrets are found at the top level and nested in
+// objects and arrays, and that masking drops their values but keeps the rest
+func TestSecretPaths(t *testing.T) {
+	props := map[string]any{
+		"name":     "db",
+		"password": secret(`"hunter2"`),
+		"tags":     map[string]any{"env": "dev", "token": secret(`"abc"`)},
+		"rules":    []any{map[string]any{"key": secret(`"k"`)}, "open"},
+	}
+
+	want := []string{"password", "rules[0].key", "tags.token"}
+	if got := SecretPaths(props, map[string]any{"password": secret(`"old"`)}); !slices.Equal(got, want) {
+		t.Errorf("SecretPaths() = %v, want %v", got, want)
+	}
+
+	masked := maskSecrets(props)
+	if !IsSecret(masked["password"]) || masked["password"].(map[string]any)["plaintext"] != nil {
+		t.Errorf("expected the password to be masked, got %v", masked["password"])
+	}
+	if masked["name"] != "db" || masked["tags"].(map[string]any)["env"] != "dev" {
+		t.Errorf("expected other values to be kept, got %v", masked)
+	}
+	if props["password"].(map[string]any)["plaintext"] != `"hunter2"` {
+		t.Error("expected the properties to be left unchanged")
+	}
+
+	revealed := RevealSecrets(masked, map[string]any{"tags.token": "abc"})
+	if revealed["tags"].(map[string]any)["token"] != "abc" {
+		t.Errorf("expected the token to be revealed, got %v", revealed["tags"])
+	}
+	if !IsSecret(revealed["password"]) {
+		t.Errorf("expected the password to stay hidden, got %v", revealed["password"])
+	}
+}
+
+// TestFindSecret verifies a secret is decrypted from the live resource's outputs,
+// or its inputs, in a deployment exported with --show-secrets
+func TestFindSecret(t *testing.T) {
+	const urn = "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+	resources := []map[string]any{
+		{"urn": urn, "delete": true, "outputs": map[string]any{"password": secret(`"stale"`)}},
+		{
+			"urn":     urn,
+			"inputs":  map[string]any{"config": map[string]any{"port": secret(`5432`)}},
+			"outputs": map[string]any{"password": secret(`"hunter2"`)},
+		},
+	}
+	deployment, err := json.Marshal(map[string]any{"resources": resources})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want any
+	}{
+		{"password", "hunter2"},
+		{"config.port", float64(5432)},
+	}
+	for _, tt := range tests {
+		got, err := findSecret(deployment, urn, tt.path)
+		if err != nil {
+			t.Fatalf("findSecret(%s): %v", tt.path, err)
+		}
+		if got != tt.want {
+			t.Errorf("findSecret(%s) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	if _, err := findSecret(deployment, urn, "missing"); err == nil {
+		t.Error("expected an error for a path without a secret")
+	}
+}
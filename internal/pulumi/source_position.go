@@ -0,0 +1,52 @@
+package pulumi
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SourceLocation is where a program registered a resource
+type SourceLocation struct {
+	Path   string // Absolute path of the source file
+	Line   int    // 1-based line, 0 when unknown
+	Column int    // 1-based column, 0 when unknown
+}
+
+// ParseSourcePosition converts a source position recorded in state, like
+// project:///index.ts#12,5, into a location. Paths under the project:// scheme are
+// relative to projectDir, the directory holding Pulumi.yaml.
+func ParseSourcePosition(pos, projectDir string) (SourceLocation, error) {
+	u, err := url.Parse(pos)
+	if err != nil {
+		return SourceLocation{}, fmt.Errorf("invalid source position %q: %w", pos, err)
+	}
+
+	var loc SourceLocation
+	switch u.Scheme {
+	case "project":
+		loc.Path = filepath.Join(projectDir, filepath.FromSlash(strings.TrimPrefix(u.Path, "/")))
+	case "file":
+		loc.Path = filepath.FromSlash(u.Path)
+	default:
+		return SourceLocation{}, fmt.Errorf("unsupported source position %q", pos)
+	}
+	if u.Path == "" || u.Path == "/" {
+		return SourceLocation{}, fmt.Errorf("source position %q has no file", pos)
+	}
+
+	if u.Fragment != "" {
+		line, col, _ := strings.Cut(u.Fragment, ",")
+		if loc.Line, err = strconv.Atoi(line); err != nil {
+			return SourceLocation{}, fmt.Errorf("invalid line in source position %q", pos)
+		}
+		if col != "" {
+			if loc.Column, err = strconv.Atoi(col); err != nil {
+				return SourceLocation{}, fmt.Errorf("invalid column in source position %q", pos)
+			}
+		}
+	}
+	return loc, nil
+}
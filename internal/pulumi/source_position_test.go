@@ -0,0 +1,36 @@
+package pulumi
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestParseSourcePosition verifies project-relative and absolute source positions
+// resolve to a file and line
+func TestParseSourcePosition(t *testing.T) {
+	projectDir := filepath.FromSlash("/work/app")
+	tests := []struct {
+		pos  string
+		want SourceLocation
+	}{
+		{"project:///index.ts#12,5", SourceLocation{Path: filepath.FromSlash("/work/app/index.ts"), Line: 12, Column: 5}},
+		{"project:///src/net/vpc.py#3", SourceLocation{Path: filepath.FromSlash("/work/app/src/net/vpc.py"), Line: 3}},
+		{"file:///opt/lib/index.js", SourceLocation{Path: filepath.FromSlash("/opt/lib/index.js")}},
+	}
+	for _, tt := range tests {
+		got, err := ParseSourcePosition(tt.pos, projectDir)
+		if err != nil {
+			t.Errorf("%s: %v", tt.pos, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.pos, got, tt.want)
+		}
+	}
+
+	for _, pos := range []string{"", "https://example.com/index.ts", "project:///index.ts#x,1", "project://"} {
+		if _, err := ParseSourcePosition(pos, projectDir); err == nil {
+			t.Errorf("%q: expected an error", pos)
+		}
+	}
+}
@@ -119,6 +119,37 @@ func SelectStack(ctx context.Context, workDir, stackName string, env map[string]
 	return nil
 }
 
+// GetStackTags returns the stack's tags. Only the Pulumi Cloud backend supports tags.
+func GetStackTags(ctx context.Context, workDir, stackName string, env map[string]string) (map[string]string, error) {
+	stack, err := selectStack(ctx, workDir, stackName, env)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := stack.Workspace().ListTags(ctx, stack.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stack tags: %w", err)
+	}
+	return tags, nil
+}
+
+// SetStackTag sets a tag on the stack, removing it when value is empty
+func SetStackTag(ctx context.Context, workDir, stackName, key, value string, env map[string]string) error {
+	stack, err := selectStack(ctx, workDir, stackName, env)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		if err := stack.Workspace().RemoveTag(ctx, stack.Name(), key); err != nil {
+			return fmt.Errorf("failed to remove stack tag %s: %w", key, err)
+		}
+		return nil
+	}
+	if err := stack.Workspace().SetTag(ctx, stack.Name(), key, value); err != nil {
+		return fmt.Errorf("failed to set stack tag %s: %w", key, err)
+	}
+	return nil
+}
+
 // resolveStackName resolves the stack name, using current stack if empty
 func resolveStackName(ctx context.Context, workDir, stackName string, env map[string]string) (string, error) {
 	if stackName != "" {
@@ -182,6 +213,7 @@ type StackFileInfo struct {
 	FilePath        string
 	SecretsProvider string
 	HasEncryption   bool
+	SecretCount     int // Encrypted config values, which must be re-encrypted when copied
 }
 
 // ListStackFiles finds all Pulumi.<stack>.yaml files in the workspace
@@ -215,10 +247,13 @@ type InitStackOptions struct {
 	SecretsProvider string
 	Passphrase      string            // For passphrase-based secrets provider
 	Env             map[string]string // Additional environment variables
+	CopyConfigFrom  string            // Stack whose Pulumi.<stack>.yaml config is copied, see CopyConfig
+	Config          map[string]string // Initial config values, set after any copied ones
 }
 
-// InitStack creates a new stack with the given configuration
-func InitStack(ctx context.Context, workDir, stackName string, opts InitStackOptions) error {
+// InitStack creates a new stack with the given configuration. The result describes
+// the config copied from opts.CopyConfigFrom, and is nil when none was copied.
+func InitStack(ctx context.Context, workDir, stackName string, opts InitStackOptions) (*ConfigCopyResult, error) {
 	wsOpts := []auto.LocalWorkspaceOption{auto.WorkDir(workDir)}
 
 	// Build env vars
@@ -240,11 +275,37 @@ func InitStack(ctx context.Context, workDir, stackName string, opts InitStackOpt
 	}
 
 	// Create the stack
-	_, err := auto.NewStackLocalSource(ctx, stackName, workDir, wsOpts...)
+	stack, err := auto.NewStackLocalSource(ctx, stackName, workDir, wsOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stack: %w", err)
+	}
+
+	copied, err := applyInitialConfig(ctx, stack, workDir, opts)
 	if err != nil {
-		return fmt.Errorf("failed to create stack: %w", err)
+		return copied, fmt.Errorf("created stack %s, but %w", stackName, err)
 	}
+	return copied, nil
+}
+
+// RemoveStackOptions contains options for removing a stack
+type RemoveStackOptions struct {
+	Env map[string]string // Additional environment variables
+}
 
+// RemoveStack deletes a stack from its backend along with its Pulumi.<stack>.yaml,
+// like `pulumi stack rm`. Pulumi refuses to remove a stack that still has resources.
+func RemoveStack(ctx context.Context, workDir, stackName string, opts RemoveStackOptions) error {
+	wsOpts := []auto.LocalWorkspaceOption{auto.WorkDir(workDir)}
+	if len(opts.Env) > 0 {
+		wsOpts = append(wsOpts, auto.EnvVars(opts.Env))
+	}
+	ws, err := auto.NewLocalWorkspace(ctx, wsOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to create workspace: %w", err)
+	}
+	if err := ws.RemoveStack(ctx, stackName); err != nil {
+		return fmt.Errorf("failed to remove stack %s: %w", stackName, err)
+	}
 	return nil
 }
 
@@ -285,6 +346,7 @@ func parseStackFileInfo(stackName, filePath string) StackFileInfo {
 	_, hasSalt := config["encryptionsalt"]
 	_, hasKey := config["encryptedkey"]
 	info.HasEncryption = hasSalt || hasKey
+	info.SecretCount = countSecureValues(config["config"])
 
 	return info
 }
@@ -0,0 +1,256 @@
+package pulumi
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/auto"
+	"gopkg.in/yaml.v3"
+)
+
+// gcpKMSKeyPattern matches the path of a gcpkms:// key URL
+var gcpKMSKeyPattern = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`)
+
+// pathSegmentPattern matches config path segments that need no quoting
+var pathSegmentPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// ValidateSecretsProvider checks a secrets provider is one Pulumi supports and that
+// key URLs name a key
+func ValidateSecretsProvider(provider string) error {
+	if provider == "default" || provider == "passphrase" {
+		return nil
+	}
+
+	u, err := url.Parse(provider)
+	if err != nil {
+		return fmt.Errorf("invalid secrets provider URL: %w", err)
+	}
+	key := strings.TrimPrefix(u.Host+u.Path, "/")
+	switch u.Scheme {
+	case "awskms", "hashivault":
+		if key == "" {
+			return fmt.Errorf("%s URL must name a key, e.g. %s://alias/pulumi", u.Scheme, u.Scheme)
+		}
+	case "gcpkms":
+		if !gcpKMSKeyPattern.MatchString(key) {
+			return fmt.Errorf("gcpkms URL must look like gcpkms://projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>")
+		}
+	case "azurekeyvault":
+		if u.Host == "" || !strings.HasPrefix(u.Path, "/keys/") || len(u.Path) == len("/keys/") {
+			return fmt.Errorf("azurekeyvault URL must look like azurekeyvault://<vault>.vault.azure.net/keys/<key>")
+		}
+	default:
+		return fmt.Errorf("unknown secrets provider %q: use passphrase, awskms, gcpkms, azurekeyvault or hashivault", provider)
+	}
+	return nil
+}
+
+// ConfigCopyOptions contains options for copying config between stacks
+type ConfigCopyOptions struct {
+	Env map[string]string // Additional environment variables, e.g. both stacks' passphrases
+}
+
+// ConfigCopyResult describes the config copied from another stack
+type ConfigCopyResult struct {
+	From           string
+	Copied         int   // Values set on the stack, including re-encrypted secrets
+	Secrets        int   // Secrets decrypted and re-encrypted with the stack's key
+	SkippedSecrets int   // Secrets that couldn't be decrypted and were left out
+	SecretsErr     error // Why the secrets couldn't be decrypted
+}
+
+// stackFileValue is a config value read from a stack's Pulumi.<stack>.yaml
+type stackFileValue struct {
+	Key    string // Config key, or config path below the key when Path is set
+	Value  string // Empty for secrets, which are encrypted with the stack's key
+	Path   bool
+	Secret bool
+}
+
+// stackFileConfig reads the config values of a stack's Pulumi.<stack>.yaml.
+// Nested object and list values are flattened into their leaves, keyed by their
+// config path.
+func stackFileConfig(workDir, stackName string) ([]stackFileValue, error) {
+	data, err := os.ReadFile(filepath.Join(workDir, "Pulumi."+stackName+".yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config of stack %s: %w", stackName, err)
+	}
+	var file struct {
+		Config map[string]any `yaml:"config"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse config of stack %s: %w", stackName, err)
+	}
+
+	var values []stackFileValue
+	for _, key := range slices.Sorted(maps.Keys(file.Config)) {
+		switch v := file.Config[key].(type) {
+		case map[string]any, []any:
+			values = flattenConfigValue(values, key, v, false)
+		default:
+			values = append(values, stackFileValue{Key: key, Value: configScalar(v)})
+		}
+	}
+	return values, nil
+}
+
+// flattenConfigValue appends the leaves of a config value, keyed by their config
+// path below key
+func flattenConfigValue(values []stackFileValue, key string, value any, path bool) []stackFileValue {
+	if isSecureValue(value) {
+		return append(values, stackFileValue{Key: key, Path: path, Secret: true})
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			segment := "." + name
+			if !pathSegmentPattern.MatchString(name) {
+				segment = "[" + strconv.Quote(name) + "]"
+			}
+			values = flattenConfigValue(values, key+segment, v[name], true)
+		}
+	case []any:
+		for i, child := range v {
+			values = flattenConfigValue(values, fmt.Sprintf("%s[%d]", key, i), child, true)
+		}
+	default:
+		values = append(values, stackFileValue{Key: key, Value: configScalar(v), Path: path})
+	}
+	return values
+}
+
+// isSecureValue reports whether a config value is an encrypted secret
+func isSecureValue(value any) bool {
+	m, ok := value.(map[string]any)
+	if !ok || len(m) != 1 {
+		return false
+	}
+	_, secure := m["secure"]
+	return secure
+}
+
+// countSecureValues counts the encrypted secrets in a config value
+func countSecureValues(value any) int {
+	if isSecureValue(value) {
+		return 1
+	}
+	count := 0
+	switch v := value.(type) {
+	case map[string]any:
+		for _, child := range v {
+			count += countSecureValues(child)
+		}
+	case []any:
+		for _, child := range v {
+			count += countSecureValues(child)
+		}
+	}
+	return count
+}
+
+// configScalar formats a scalar config value the way the Pulumi CLI stores it
+func configScalar(value any) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+// CopyConfig copies the config in another stack's Pulumi.<stack>.yaml to a stack,
+// overwriting values it already has. Secrets are decrypted with the other stack's
+// key and re-encrypted with the stack's, which needs the other stack to exist in
+// the backend; secrets that can't be decrypted are left out.
+func CopyConfig(ctx context.Context, workDir, fromStack, toStack string, opts ConfigCopyOptions) (*ConfigCopyResult, error) {
+	wsOpts := []auto.LocalWorkspaceOption{auto.WorkDir(workDir)}
+	if len(opts.Env) > 0 {
+		wsOpts = append(wsOpts, auto.EnvVars(opts.Env))
+	}
+	ws, err := auto.NewLocalWorkspace(ctx, wsOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+	return copyStackConfig(ctx, ws, workDir, fromStack, toStack)
+}
+
+// copyStackConfig copies the config in fromStack's file to toStack through ws
+func copyStackConfig(ctx context.Context, ws auto.Workspace, workDir, fromStack, toStack string) (*ConfigCopyResult, error) {
+	values, err := stackFileConfig(workDir, fromStack)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ConfigCopyResult{From: fromStack}
+	plain := auto.ConfigMap{}
+	paths := auto.ConfigMap{}
+	for _, v := range values {
+		value := auto.ConfigValue{Value: v.Value}
+		if v.Secret {
+			// Once a secret fails to decrypt the others will too, so they are skipped
+			if result.SecretsErr != nil {
+				result.SkippedSecrets++
+				continue
+			}
+			decrypted, err := ws.GetConfigWithOptions(ctx, fromStack, v.Key, &auto.ConfigOptions{Path: v.Path})
+			if err != nil {
+				result.SecretsErr = fmt.Errorf("failed to decrypt secrets of stack %s: %w", fromStack, err)
+				result.SkippedSecrets++
+				continue
+			}
+			value = auto.ConfigValue{Value: decrypted.Value, Secret: true}
+			result.Secrets++
+		}
+		if v.Path {
+			paths[v.Key] = value
+		} else {
+			plain[v.Key] = value
+		}
+	}
+
+	if len(plain) > 0 {
+		if err := ws.SetAllConfig(ctx, toStack, plain); err != nil {
+			return nil, fmt.Errorf("failed to copy config from stack %s: %w", fromStack, err)
+		}
+	}
+	if len(paths) > 0 {
+		if err := ws.SetAllConfigWithOptions(ctx, toStack, paths, &auto.ConfigOptions{Path: true}); err != nil {
+			return nil, fmt.Errorf("failed to copy config from stack %s: %w", fromStack, err)
+		}
+	}
+	result.Copied = len(plain) + len(paths)
+	return result, nil
+}
+
+// applyInitialConfig copies config from another stack's file, then sets the
+// initial config values, which take precedence
+func applyInitialConfig(ctx context.Context, stack auto.Stack, workDir string, opts InitStackOptions) (*ConfigCopyResult, error) {
+	var copied *ConfigCopyResult
+	if opts.CopyConfigFrom != "" {
+		var err error
+		copied, err = copyStackConfig(ctx, stack.Workspace(), workDir, opts.CopyConfigFrom, stack.Name())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(opts.Config) > 0 {
+		config := auto.ConfigMap{}
+		for key, value := range opts.Config {
+			config[key] = auto.ConfigValue{Value: value}
+		}
+		if err := stack.SetAllConfig(ctx, config); err != nil {
+			return copied, fmt.Errorf("failed to set config: %w", err)
+		}
+	}
+	return copied, nil
+}
@@ -0,0 +1,159 @@
+package pulumi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+)
+
+// LocksDir is where p5 records the operations it runs, relative to the project
+// directory, so other p5 processes can tell a stack is busy
+const LocksDir = ".p5/locks"
+
+// StackLock records the p5 process running an operation on a stack
+type StackLock struct {
+	Stack     string    `json:"stack"`
+	Operation string    `json:"operation"`
+	PID       int       `json:"pid"`
+	Host      string    `json:"host"`
+	User      string    `json:"user,omitempty"`
+	Started   time.Time `json:"started"`
+}
+
+// Owner describes the process holding the lock, like "alice, pid 4242 on laptop"
+func (l *StackLock) Owner() string {
+	owner := fmt.Sprintf("pid %d on %s", l.PID, l.Host)
+	if l.User != "" {
+		owner = l.User + ", " + owner
+	}
+	return owner
+}
+
+// stackLockFile returns the file locking a stack. Stack names may contain
+// slashes, so files are named by the name's hash.
+func stackLockFile(workDir, stackName string) string {
+	sum := sha256.Sum256([]byte(stackName))
+	return filepath.Join(workDir, LocksDir, hex.EncodeToString(sum[:8])+".json")
+}
+
+// ReadStackLock returns the lock another live p5 process holds on a stack, or nil
+// if there is none. Locks left behind by processes that exited on this host are ignored.
+func ReadStackLock(workDir, stackName string) (*StackLock, error) {
+	data, err := os.ReadFile(stackLockFile(workDir, stackName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stack lock: %w", err)
+	}
+	var lock StackLock
+	if json.Unmarshal(data, &lock) != nil {
+		// A partially written lock is as good as none
+		return nil, nil
+	}
+	if lock.PID == os.Getpid() || !lock.alive() {
+		return nil, nil
+	}
+	return &lock, nil
+}
+
+// alive reports whether the process holding the lock may still be running.
+// Processes on other hosts can't be checked and are assumed to be.
+func (l *StackLock) alive() bool {
+	host, _ := os.Hostname()
+	if l.Host != host {
+		return true
+	}
+	return processAlive(l.PID)
+}
+
+// AcquireStackLock records that this process runs op on a stack. If another live
+// p5 process already holds the stack, its lock is returned and nothing is written.
+// The lock file is created exclusively, so of two processes acquiring a stack at
+// once only one gets it. A stale lock is removed and creating it retried once.
+func AcquireStackLock(workDir, stackName string, op OperationType, now time.Time) (*StackLock, error) {
+	host, _ := os.Hostname()
+	lock := StackLock{
+		Stack:     stackName,
+		Operation: op.String(),
+		PID:       os.Getpid(),
+		Host:      host,
+		Started:   now,
+	}
+	if u, err := user.Current(); err == nil {
+		lock.User = u.Username
+	}
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode stack lock: %w", err)
+	}
+	path := stackLockFile(workDir, stackName)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create locks directory: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := createStackLock(path, append(data, '\n'))
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		held, err := ReadStackLock(workDir, stackName)
+		if err != nil || held != nil {
+			return held, err
+		}
+		if attempt > 0 {
+			return nil, errors.New("failed to write stack lock: another process replaced it")
+		}
+		// The lock is stale, left by an exited process or this one
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove stale stack lock: %w", err)
+		}
+	}
+}
+
+// createStackLock writes a lock file, failing with os.ErrExist if there is one
+func createStackLock(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write stack lock: %w", err)
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to write stack lock: %w", err)
+	}
+	return nil
+}
+
+// ReleaseStackLock removes this process's lock on a stack. Locks held by other
+// processes are left alone.
+func ReleaseStackLock(workDir, stackName string) error {
+	path := stackLockFile(workDir, stackName)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read stack lock: %w", err)
+	}
+	var lock StackLock
+	if err := json.Unmarshal(data, &lock); err == nil && lock.PID != os.Getpid() {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stack lock: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package pulumi
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStackLock(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	// This process's own lock doesn't make the stack busy
+	if held, err := AcquireStackLock(dir, "org/app/prod", OperationUp, now); err != nil || held != nil {
+		t.Fatalf("expected the stack to be locked, got %+v, %v", held, err)
+	}
+	if held, err := ReadStackLock(dir, "org/app/prod"); err != nil || held != nil {
+		t.Fatalf("expected no other holder, got %+v, %v", held, err)
+	}
+	// A lock file is only ever created, never overwritten
+	if err := createStackLock(stackLockFile(dir, "org/app/prod"), []byte("{}")); !errors.Is(err, os.ErrExist) {
+		t.Fatalf("expected an existing lock to be kept, got %v", err)
+	}
+
+	// Another live process on another host holds the stack
+	other := StackLock{Stack: "org/app/prod", Operation: "destroy", PID: 1, Host: "ci-runner", User: "ci", Started: now}
+	data, _ := json.Marshal(other)
+	if err := os.WriteFile(stackLockFile(dir, "org/app/prod"), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	held, err := AcquireStackLock(dir, "org/app/prod", OperationUp, now)
+	if err != nil || held == nil || held.Operation != "destroy" || held.Owner() != "ci, pid 1 on ci-runner" {
+		t.Fatalf("expected the other process's lock, got %+v, %v", held, err)
+	}
+	if err := ReleaseStackLock(dir, "org/app/prod"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(stackLockFile(dir, "org/app/prod")); err != nil {
+		t.Error("expected another process's lock to be kept on release")
+	}
+
+	// A lock left by a process that exited on this host is stale
+	host, _ := os.Hostname()
+	other.Host = host
+	other.PID = 1 << 22
+	data, _ = json.Marshal(other)
+	if err := os.WriteFile(stackLockFile(dir, "org/app/prod"), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if held, err := AcquireStackLock(dir, "org/app/prod", OperationUp, now); err != nil || held != nil {
+		t.Fatalf("expected the stale lock to be replaced, got %+v, %v", held, err)
+	}
+	if err := ReleaseStackLock(dir, "org/app/prod"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(stackLockFile(dir, "org/app/prod")); !os.IsNotExist(err) {
+		t.Error("expected this process's lock to be removed on release")
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package pulumi
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processAlive reports whether a process on this host may still be running
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,29 @@
+//go:build windows
+
+package pulumi
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processQueryLimitedInformation is the PROCESS_QUERY_LIMITED_INFORMATION access right
+const processQueryLimitedInformation = 0x1000
+
+// stillActive is the exit code of a process that hasn't exited
+const stillActive = 259
+
+// processAlive reports whether a process on this host may still be running
+func processAlive(pid int) bool {
+	h, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		// A process of another user can't be opened, but exists
+		return errors.Is(err, syscall.ERROR_ACCESS_DENIED)
+	}
+	defer syscall.CloseHandle(h)
+	var code uint32
+	if err := syscall.GetExitCodeProcess(h, &code); err != nil {
+		return true
+	}
+	return code == stillActive
+}
@@ -0,0 +1,55 @@
+package pulumi
+
+import (
+	"sort"
+	"strings"
+)
+
+// StackReferenceType is the type of resources created by StackReference
+const StackReferenceType = "pulumi:pulumi:StackReference"
+
+// StackReference is a stack read by a StackReference resource
+type StackReference struct {
+	Name          string         // Referenced stack as written, e.g. "org/project/stack"
+	Project       string         // Project of the referenced stack, empty when it is the referencing project
+	Outputs       map[string]any // Outputs read from the referenced stack
+	SecretOutputs []string       // Names of outputs that are secret, sorted
+}
+
+// ReadStackReference returns the stack read by a StackReference resource from its
+// inputs and outputs, or nil if no stack name was recorded
+func ReadStackReference(inputs, outputs map[string]any) *StackReference {
+	name, _ := outputs["name"].(string)
+	if name == "" {
+		name, _ = inputs["name"].(string)
+	}
+	if name == "" {
+		return nil
+	}
+
+	ref := &StackReference{
+		Name:    name,
+		Project: ParseStackReferenceProject(name),
+	}
+	ref.Outputs, _ = outputs["outputs"].(map[string]any)
+	if secrets, ok := outputs["secretOutputNames"].([]any); ok {
+		for _, s := range secrets {
+			if s, ok := s.(string); ok {
+				ref.SecretOutputs = append(ref.SecretOutputs, s)
+			}
+		}
+		sort.Strings(ref.SecretOutputs)
+	}
+	return ref
+}
+
+// ParseStackReferenceProject returns the project of a stack reference name. Only fully
+// qualified names ("org/project/stack") name a project; "stack" and "org/stack" refer
+// to a stack of the referencing project, so "" is returned for them.
+func ParseStackReferenceProject(name string) string {
+	parts := strings.Split(name, "/")
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[1]
+}
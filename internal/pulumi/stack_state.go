@@ -0,0 +1,147 @@
+package pulumi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/auto"
+)
+
+// stackLockedTexts are found in the errors of operations refused because the stack
+// is locked by another update or has pending operations in state
+var stackLockedTexts = []string{
+	"[409] Conflict: Another update is currently in progress.",
+	"the stack is currently locked by",
+	"resource(s) with pending operations",
+}
+
+// IsStackLockedError reports whether err is from an operation refused because the
+// stack is locked by another update or has pending operations in state
+func IsStackLockedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	for _, text := range stackLockedTexts {
+		if strings.Contains(msg, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPendingOperations exports the stack's deployment and returns its pending operations
+func GetPendingOperations(ctx context.Context, workDir, stackName string, env map[string]string) ([]PendingOperation, error) {
+	stack, err := selectStack(ctx, workDir, stackName, env)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := stack.Export(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export stack: %w", err)
+	}
+	return parsePendingOperations(state.Deployment)
+}
+
+// CancelPending recovers a stack left locked by an interrupted update: the update is
+// cancelled, like `pulumi cancel`, and the pending operations it left in state are
+// removed. Fails only if there was neither an update to cancel nor operations to clear.
+func CancelPending(ctx context.Context, workDir, stackName string, opts CancelPendingOptions) (*CancelPendingResult, error) {
+	stack, err := selectStack(ctx, workDir, stackName, opts.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CancelPendingResult{}
+	cancelErr := stack.Cancel(ctx)
+	result.Cancelled = cancelErr == nil
+
+	result.Cleared, err = removePendingOperations(ctx, stack)
+	if err != nil {
+		return nil, err
+	}
+	if result.Cleared == 0 && !result.Cancelled {
+		return nil, fmt.Errorf("no update to cancel or pending operations to clear: %w", cancelErr)
+	}
+	return result, nil
+}
+
+// ClearPendingOperations removes the pending operations an interrupted update left
+// in the stack's state. Unlike CancelPending, an update in progress is left alone.
+func ClearPendingOperations(ctx context.Context, workDir, stackName string, opts CancelPendingOptions) (*CancelPendingResult, error) {
+	stack, err := selectStack(ctx, workDir, stackName, opts.Env)
+	if err != nil {
+		return nil, err
+	}
+	cleared, err := removePendingOperations(ctx, stack)
+	if err != nil {
+		return nil, err
+	}
+	return &CancelPendingResult{Cleared: cleared}, nil
+}
+
+// removePendingOperations removes the pending operations from the stack's state,
+// returning how many there were. The state is only written when there were any.
+func removePendingOperations(ctx context.Context, stack *auto.Stack) (int, error) {
+	state, err := stack.Export(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to export stack: %w", err)
+	}
+	ops, err := parsePendingOperations(state.Deployment)
+	if err != nil || len(ops) == 0 {
+		return 0, err
+	}
+
+	deployment, err := clearPendingOperations(state.Deployment)
+	if err != nil {
+		return 0, err
+	}
+	state.Deployment = deployment
+	if err := stack.Import(ctx, state); err != nil {
+		return 0, fmt.Errorf("failed to import state: %w", err)
+	}
+	return len(ops), nil
+}
+
+// pendingOperationEntry holds the fields of a deployment's pending operation
+type pendingOperationEntry struct {
+	Resource struct {
+		URN  string `json:"urn"`
+		Type string `json:"type"`
+	} `json:"resource"`
+	Type string `json:"type"`
+}
+
+// parsePendingOperations returns the pending operations of a raw deployment
+func parsePendingOperations(data json.RawMessage) ([]PendingOperation, error) {
+	var deployment struct {
+		PendingOperations []pendingOperationEntry `json:"pending_operations"`
+	}
+	if err := json.Unmarshal(data, &deployment); err != nil {
+		return nil, fmt.Errorf("failed to parse deployment: %w", err)
+	}
+
+	ops := make([]PendingOperation, 0, len(deployment.PendingOperations))
+	for _, op := range deployment.PendingOperations {
+		ops = append(ops, PendingOperation{URN: op.Resource.URN, Type: op.Resource.Type, Kind: op.Type})
+	}
+	return ops, nil
+}
+
+// clearPendingOperations removes the pending operations from a raw deployment,
+// leaving its other fields as-is
+func clearPendingOperations(data json.RawMessage) (json.RawMessage, error) {
+	var deployment map[string]json.RawMessage
+	if err := json.Unmarshal(data, &deployment); err != nil {
+		return nil, fmt.Errorf("failed to parse deployment: %w", err)
+	}
+	delete(deployment, "pending_operations")
+	out, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode deployment: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,96 @@
+package pulumi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+)
+
+// maxStateSize bounds how much state is read from a URL
+const maxStateSize = 512 << 20
+
+// stateClient fetches state from URLs, giving up on servers that stop responding
+var stateClient = &http.Client{Timeout: 2 * time.Minute}
+
+// ReadStateFile reads the resources of a stack from exported state, given a
+// local path or an http(s) URL. It accepts the output of `pulumi stack export`
+// as well as the checkpoint files a file or cloud storage backend keeps.
+func ReadStateFile(ctx context.Context, source string) ([]ResourceInfo, error) {
+	data, err := readStateSource(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	state, err := decodeState(data, source)
+	if err != nil {
+		return nil, err
+	}
+	// A stack that was created but never updated has no deployment
+	if len(state.Deployment) == 0 {
+		return nil, nil
+	}
+	return parseDeploymentResources(state.Deployment)
+}
+
+// decodeState decodes exported state: the output of `pulumi stack export`, or a
+// backend checkpoint file, whose latest deployment is returned. The deployment is
+// empty for the checkpoint of a stack that was never updated.
+func decodeState(data []byte, source string) (apitype.UntypedDeployment, error) {
+	var file struct {
+		Version    int             `json:"version"`
+		Deployment json.RawMessage `json:"deployment"`
+		Checkpoint *struct {
+			Latest json.RawMessage `json:"latest"`
+		} `json:"checkpoint"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return apitype.UntypedDeployment{}, fmt.Errorf("failed to parse %s: %w", source, err)
+	}
+
+	switch {
+	case len(file.Deployment) > 0:
+		return apitype.UntypedDeployment{Version: file.Version, Deployment: file.Deployment}, nil
+	case file.Checkpoint != nil:
+		return apitype.UntypedDeployment{Version: file.Version, Deployment: file.Checkpoint.Latest}, nil
+	default:
+		return apitype.UntypedDeployment{}, fmt.Errorf("%s is not an exported stack state", source)
+	}
+}
+
+// readStateSource reads a local file, or fetches it when source is an http(s) URL
+func readStateSource(ctx context.Context, source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read state file: %w", err)
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state URL: %w", err)
+	}
+	resp, err := stateClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch state: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch state: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxStateSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch state: %w", err)
+	}
+	if len(data) > maxStateSize {
+		return nil, fmt.Errorf("failed to fetch state: larger than %d MiB", maxStateSize>>20)
+	}
+	return data, nil
+}
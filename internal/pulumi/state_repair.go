@@ -0,0 +1,229 @@
+package pulumi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// stackResourceType is the type of the root stack resource
+const stackResourceType = "pulumi:pulumi:Stack"
+
+// DiagnoseState finds duplicate URNs, orphaned children and missing providers in
+// the resources of a stack. Old copies of replaced resources awaiting deletion are
+// expected to share a URN and are not reported as duplicates.
+func DiagnoseState(resources []ResourceInfo) []StateIssue {
+	live := make(map[string]int)
+	urns := make(map[string]bool)
+	for _, r := range resources {
+		urns[r.URN] = true
+		if !r.PendingDelete {
+			live[r.URN]++
+		}
+	}
+
+	var issues []StateIssue
+	reported := make(map[string]bool)
+	for _, r := range resources {
+		if live[r.URN] > 1 && !reported[r.URN] {
+			reported[r.URN] = true
+			issues = append(issues, StateIssue{
+				Kind:   StateIssueDuplicateURN,
+				URN:    r.URN,
+				Type:   r.Type,
+				Name:   r.Name,
+				Copies: live[r.URN],
+			})
+		}
+		if r.PendingDelete {
+			continue
+		}
+		if r.Parent != "" && !urns[r.Parent] {
+			issues = append(issues, StateIssue{
+				Kind:      StateIssueOrphanedChild,
+				URN:       r.URN,
+				Type:      r.Type,
+				Name:      r.Name,
+				Reference: r.Parent,
+			})
+		}
+		if providerURN := extractProviderURN(r.Provider); providerURN != "" && !urns[providerURN] {
+			issues = append(issues, StateIssue{
+				Kind:      StateIssueMissingProvider,
+				URN:       r.URN,
+				Type:      r.Type,
+				Name:      r.Name,
+				Reference: providerURN,
+			})
+		}
+	}
+	return issues
+}
+
+// StackResourceURN returns the URN of the root stack resource, or "" if it is not in state
+func StackResourceURN(resources []ResourceInfo) string {
+	for _, r := range resources {
+		if r.Type == stackResourceType && !r.PendingDelete {
+			return r.URN
+		}
+	}
+	return ""
+}
+
+// RepairState exports the stack's deployment, applies the fixes and imports the
+// result. With opts.DryRun the state is not written and only the report is returned.
+func RepairState(ctx context.Context, workDir, stackName string, fixes []StateFix, opts StateRepairOptions) (*StateRepairReport, error) {
+	stack, err := selectStack(ctx, workDir, stackName, opts.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := stack.Export(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export stack: %w", err)
+	}
+
+	deployment, report, err := applyStateFixes(state.Deployment, fixes)
+	if err != nil {
+		return nil, err
+	}
+	report.DryRun = opts.DryRun
+	if opts.DryRun {
+		return report, nil
+	}
+
+	state.Deployment = deployment
+	if err := stack.Import(ctx, state); err != nil {
+		return nil, fmt.Errorf("failed to import repaired state: %w", err)
+	}
+	return report, nil
+}
+
+// stateEntry holds the fields of a deployment resource that repairs read
+type stateEntry struct {
+	URN          string   `json:"urn"`
+	Parent       string   `json:"parent"`
+	Provider     string   `json:"provider"`
+	Delete       bool     `json:"delete"`
+	Dependencies []string `json:"dependencies"` // Includes property dependencies
+	DeletedWith  string   `json:"deletedWith"`
+}
+
+// applyStateFixes applies fixes to a raw deployment and returns the new deployment.
+// Resource fields other than parent are preserved as-is.
+func applyStateFixes(data json.RawMessage, fixes []StateFix) (json.RawMessage, *StateRepairReport, error) {
+	raws, deployment, err := deploymentResources(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries := make([]stateEntry, len(raws))
+	for i, raw := range raws {
+		if err := json.Unmarshal(raw, &entries[i]); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse deployment resource: %w", err)
+		}
+	}
+
+	report := &StateRepairReport{ResourcesBefore: len(raws)}
+	removed := make(map[int]bool)
+	for _, fix := range fixes {
+		change := StateChange{Action: fix.Action, URN: fix.Issue.URN}
+		switch fix.Action {
+		case StateFixDelete:
+			keptLive := false
+			for i, e := range entries {
+				if e.URN != fix.Issue.URN || removed[i] {
+					continue
+				}
+				// For duplicates keep the first live copy and remove the rest
+				if fix.Issue.Kind == StateIssueDuplicateURN && !e.Delete && !keptLive {
+					keptLive = true
+					continue
+				}
+				removed[i] = true
+				change.Removed++
+			}
+		case StateFixReparent:
+			change.NewParent = fix.NewParent
+			for i, e := range entries {
+				if e.URN != fix.Issue.URN || removed[i] {
+					continue
+				}
+				raw, err := setResourceParent(raws[i], fix.NewParent)
+				if err != nil {
+					return nil, nil, err
+				}
+				change.OldParent = e.Parent
+				raws[i] = raw
+				entries[i].Parent = fix.NewParent
+			}
+		}
+		report.Changes = append(report.Changes, change)
+	}
+
+	kept := make([]json.RawMessage, 0, len(raws))
+	remaining := make(map[string]bool)
+	for i, raw := range raws {
+		if !removed[i] {
+			kept = append(kept, raw)
+			remaining[entries[i].URN] = true
+		}
+	}
+	report.ResourcesAfter = len(kept)
+
+	for i, e := range entries {
+		if !removed[i] {
+			report.Dangling = append(report.Dangling, danglingReferences(e, remaining)...)
+		}
+	}
+
+	resources, err := json.Marshal(kept)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode deployment resources: %w", err)
+	}
+	deployment["resources"] = resources
+	out, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode deployment: %w", err)
+	}
+	return out, report, nil
+}
+
+// setResourceParent rewrites the parent of a raw deployment resource.
+// Numbers are decoded as json.Number so outputs round-trip without losing precision.
+func setResourceParent(raw json.RawMessage, parent string) (json.RawMessage, error) {
+	var resource map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&resource); err != nil {
+		return nil, fmt.Errorf("failed to parse deployment resource: %w", err)
+	}
+	if parent == "" {
+		delete(resource, "parent")
+	} else {
+		resource["parent"] = parent
+	}
+	out, err := json.Marshal(resource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode deployment resource: %w", err)
+	}
+	return out, nil
+}
+
+// danglingReferences returns the references of a resource to URNs not in remaining
+func danglingReferences(e stateEntry, remaining map[string]bool) []DanglingReference {
+	var refs []DanglingReference
+	check := func(field, ref string) {
+		if ref != "" && !remaining[ref] {
+			refs = append(refs, DanglingReference{URN: e.URN, Field: field, Reference: ref})
+		}
+	}
+
+	check("parent", e.Parent)
+	check("provider", extractProviderURN(e.Provider))
+	check("deletedWith", e.DeletedWith)
+	for _, dep := range e.Dependencies {
+		check("dependencies", dep)
+	}
+	return refs
+}
@@ -0,0 +1,113 @@
+package pulumi
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rfhold/p5/internal/telemetry"
+)
+
+// startOperationSpan starts the span covering a preview or an operation on a stack
+func startOperationSpan(ctx context.Context, name string, op OperationType, workDir, stackName string, opts OperationOptions) (context.Context, trace.Span) {
+	return telemetry.Tracer().Start(ctx, name, trace.WithAttributes(
+		telemetry.AttrOperation.String(strings.ToLower(op.String())),
+		telemetry.AttrStack.String(stackName),
+		telemetry.AttrWorkDir.String(workDir),
+		attribute.Int("p5.targets", len(opts.Targets)),
+		attribute.Int("p5.replaces", len(opts.Replaces)),
+		attribute.Int("p5.excludes", len(opts.Excludes)),
+		attribute.Bool("p5.refresh", opts.Refresh),
+	))
+}
+
+// tracePreview forwards preview events, ending span and recording the preview's
+// duration once it finishes
+func tracePreview(ctx context.Context, span trace.Span, op OperationType, in <-chan PreviewEvent) <-chan PreviewEvent {
+	started := time.Now()
+	out := make(chan PreviewEvent)
+	go func() {
+		defer close(out)
+		var steps, warnings int
+		var err error
+		for event := range in {
+			switch {
+			case event.Step != nil:
+				steps++
+			case event.Warning != nil:
+				warnings++
+			case event.Error != nil:
+				err = event.Error
+			}
+			out <- event
+		}
+		span.SetAttributes(attribute.Int("p5.steps", steps), attribute.Int("p5.warnings", warnings))
+		telemetry.EndSpan(span, err)
+		recordOperation(ctx, op, true, started, err)
+	}()
+	return out
+}
+
+// traceOperation forwards operation events, recording a child span for each
+// resource step and ending span once the operation finishes. Finished steps and
+// the operation's duration are recorded as metrics.
+func traceOperation(ctx context.Context, span trace.Span, op OperationType, in <-chan OperationEvent) <-chan OperationEvent {
+	started := time.Now()
+	out := make(chan OperationEvent)
+	go func() {
+		defer close(out)
+		// Running steps by URN and op, as a replacement creates and deletes the same URN
+		running := make(map[string]trace.Span)
+		var err error
+		for event := range in {
+			if event.URN != "" {
+				traceStep(ctx, running, event)
+				if event.Status == StepSuccess || event.Status == StepFailed {
+					recordResourceStep(ctx, op, event)
+				}
+			}
+			if event.Error != nil {
+				err = event.Error
+			}
+			out <- event
+		}
+		// Steps left running when the operation stopped
+		for _, step := range running {
+			step.End()
+		}
+		telemetry.EndSpan(span, err)
+		recordOperation(ctx, op, false, started, err)
+	}()
+	return out
+}
+
+// traceStep starts the span of a resource step when it runs and ends it when it
+// succeeds or fails
+func traceStep(ctx context.Context, running map[string]trace.Span, event OperationEvent) {
+	key := event.URN + "\x00" + string(event.Op)
+	switch event.Status {
+	case StepRunning:
+		_, step := telemetry.Tracer().Start(ctx, "pulumi.resource "+string(event.Op),
+			trace.WithTimestamp(event.Time),
+			trace.WithAttributes(
+				telemetry.AttrURN.String(event.URN),
+				telemetry.AttrResourceType.String(event.Type),
+				telemetry.AttrResourceOp.String(string(event.Op)),
+			))
+		running[key] = step
+	case StepSuccess, StepFailed:
+		step, ok := running[key]
+		if !ok {
+			return
+		}
+		delete(running, key)
+		if event.Status == StepFailed {
+			step.SetStatus(codes.Error, "resource step failed")
+		}
+		step.End(trace.WithTimestamp(event.Time))
+	}
+}
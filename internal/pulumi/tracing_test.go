@@ -0,0 +1,61 @@
+package pulumi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestTraceOperation verifies an operation span is recorded with a child span
+// for each resource step, failures marked as errors
+func TestTraceOperation(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	const (
+		bucket = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+		db     = "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+	)
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	in := make(chan OperationEvent, 6)
+	in <- OperationEvent{URN: bucket, Op: OpCreate, Type: "aws:s3/bucket:Bucket", Status: StepRunning, Time: start}
+	in <- OperationEvent{URN: db, Op: OpUpdate, Type: "aws:rds/instance:Instance", Status: StepRunning, Time: start}
+	in <- OperationEvent{URN: bucket, Op: OpCreate, Status: StepSuccess, Time: start.Add(2 * time.Second)}
+	in <- OperationEvent{URN: db, Op: OpUpdate, Status: StepFailed, Time: start.Add(time.Minute)}
+	in <- OperationEvent{Done: true, Error: errors.New("update failed")}
+	close(in)
+
+	ctx, span := startOperationSpan(context.Background(), "pulumi.up", OperationUp, "/fake/path", "dev", OperationOptions{})
+	for range traceOperation(ctx, span, OperationUp, in) {
+	}
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, s := range recorder.Ended() {
+		spans[s.Name()] = s
+	}
+	if len(spans) != 3 {
+		t.Fatalf("expected the operation and two resource spans, got %d", len(recorder.Ended()))
+	}
+	op := spans["pulumi.up"]
+	if op == nil || op.Status().Code != codes.Error {
+		t.Fatal("expected the failed up to be recorded as an error")
+	}
+	create := spans["pulumi.resource create"]
+	if create == nil || create.Parent().SpanID() != op.SpanContext().SpanID() {
+		t.Fatal("expected the create step to be a child of the up")
+	}
+	if d := create.EndTime().Sub(create.StartTime()); d != 2*time.Second || create.Status().Code == codes.Error {
+		t.Errorf("expected a 2s successful create step, got %v, %v", d, create.Status())
+	}
+	if update := spans["pulumi.resource update"]; update == nil || update.Status().Code != codes.Error {
+		t.Error("expected the failed update step to be recorded as an error")
+	}
+}
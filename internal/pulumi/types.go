@@ -1,5 +1,7 @@
 package pulumi
 
+import "time"
+
 // ProjectInfo holds project and stack information
 type ProjectInfo struct {
 	ProgramName string
@@ -25,15 +27,16 @@ const (
 
 // PreviewStep represents a single resource operation in the preview
 type PreviewStep struct {
-	URN      string
-	Op       ResourceOp
-	Type     string
-	Name     string
-	Parent   string
-	Sequence int            // Event sequence number from Pulumi engine (for ordering)
-	Inputs   map[string]any // New state inputs (for create/update)
-	Outputs  map[string]any // New state outputs (for create/update)
-	Old      *StepState     // Old state (for update/delete)
+	URN         string
+	Op          ResourceOp
+	Type        string
+	Name        string
+	Parent      string
+	Sequence    int            // Event sequence number from Pulumi engine (for ordering)
+	Inputs      map[string]any // New state inputs (for create/update)
+	Outputs     map[string]any // New state outputs (for create/update)
+	Old         *StepState     // Old state (for update/delete)
+	ReplaceKeys []string       // Properties whose change forces a replacement (for replace steps)
 }
 
 // StepState holds resource state for old/new comparison
@@ -44,9 +47,35 @@ type StepState struct {
 
 // PreviewEvent is sent for each resource during preview
 type PreviewEvent struct {
-	Step  *PreviewStep
-	Error error
-	Done  bool
+	Step       *PreviewStep
+	Warning    *PreviewWarning   // Non-fatal diagnostic or policy violation
+	Diagnostic *EngineDiagnostic // Engine or provider log output
+	Error      error
+	Done       bool
+}
+
+// EngineDiagnostic is a message logged by the engine, a provider or the program
+// during preview, of any severity
+type EngineDiagnostic struct {
+	Severity string // "debug", "info", "info#err", "warning" or "error"
+	URN      string // Resource the message is about, empty for stack-level messages
+	Message  string
+}
+
+// WarningSeverity ranks diagnostics collected during preview
+type WarningSeverity int
+
+const (
+	WarningSeverityWarning WarningSeverity = iota // Engine warnings and advisory policy violations
+	WarningSeverityError                          // Engine errors and mandatory policy violations
+)
+
+// PreviewWarning is a diagnostic or policy violation reported during preview
+type PreviewWarning struct {
+	Severity WarningSeverity
+	URN      string // Resource the warning is about, empty for stack-level warnings
+	Message  string
+	Policy   string // "pack/policy" for policy violations, empty for engine diagnostics
 }
 
 // PreviewSummary contains the final counts
@@ -85,25 +114,33 @@ type OperationOptions struct {
 	Targets  []string          // --target URNs
 	Replaces []string          // --replace URNs (up only)
 	Excludes []string          // --exclude URNs
+	Refresh  bool              // --refresh, refresh the state before updating (up and its preview)
+	Plan     string            // Update plan file, written by an up preview and enforced by up
 	Env      map[string]string // Environment variables to set for the operation
+	// --message, recorded with the update in the stack's history (up and destroy)
+	Message string
+	// --continue-on-error, keep updating resources that don't depend on a failed one (up and destroy)
+	ContinueOnError bool
 }
 
 // OperationEvent unified event type for execution
 type OperationEvent struct {
-	URN        string     // Resource being operated on
-	Op         ResourceOp // Operation type
-	Type       string     // Resource type
-	Name       string     // Resource name
-	Parent     string     // Parent URN for component hierarchy
-	Sequence   int        // Event sequence number from Pulumi engine (for ordering)
-	Status     StepStatus // pending/running/success/failed
-	Error      error
-	Done       bool
-	Message    string         // Diagnostic/log message
-	Inputs     map[string]any // Resource inputs (from ResourcePreEvent)
-	Outputs    map[string]any // Resource outputs (from ResOutputsEvent)
-	OldInputs  map[string]any // Previous inputs (for updates/deletes)
-	OldOutputs map[string]any // Previous outputs (for updates/deletes)
+	URN         string     // Resource being operated on
+	Op          ResourceOp // Operation type
+	Type        string     // Resource type
+	Name        string     // Resource name
+	Parent      string     // Parent URN for component hierarchy
+	Sequence    int        // Event sequence number from Pulumi engine (for ordering)
+	Status      StepStatus // pending/running/success/failed
+	Error       error
+	Done        bool
+	Message     string         // Diagnostic/log message
+	Inputs      map[string]any // Resource inputs (from ResourcePreEvent)
+	Outputs     map[string]any // Resource outputs (from ResOutputsEvent)
+	OldInputs   map[string]any // Previous inputs (for updates/deletes)
+	OldOutputs  map[string]any // Previous outputs (for updates/deletes)
+	Time        time.Time      // When the event was received from the engine
+	ReplaceKeys []string       // Properties whose change forces a replacement (for replace steps)
 }
 
 // StepStatus represents execution progress status
@@ -137,6 +174,10 @@ type ResourceInfo struct {
 	Inputs         map[string]any // Resource inputs/args
 	Outputs        map[string]any // Resource outputs
 	ProviderInputs map[string]any // Configuration from the provider resource
+	PendingDelete  bool           // Old copy of a replaced resource awaiting deletion
+	Dependencies   []string       // URNs of resources this one depends on, including property dependencies
+	DeletedWith    string         // URN of the resource whose deletion also deletes this one
+	SourcePosition string         // Where the program registered the resource, like project:///index.ts#12,5
 }
 
 // StackInfo holds information about a stack
@@ -145,6 +186,18 @@ type StackInfo struct {
 	Current bool
 }
 
+// WorkspaceSearchOptions configures how FindWorkspaces walks a directory tree
+type WorkspaceSearchOptions struct {
+	// MaxDepth is how many directories deep to search below the start directory
+	// (0 searches the whole tree)
+	MaxDepth int
+	// Ignore are .gitignore-style patterns of directories to skip, in addition to
+	// DefaultWorkspaceIgnore
+	Ignore []string
+	// Parallelism is how many directories are read at once (0 uses the number of CPUs)
+	Parallelism int
+}
+
 // WorkspaceInfo holds information about a Pulumi workspace (project)
 type WorkspaceInfo struct {
 	Path    string // Absolute path to the directory containing Pulumi.yaml
@@ -168,9 +221,10 @@ type UpdateSummary struct {
 
 // CommandResult contains the result of a CLI command operation (import, state delete, etc.)
 type CommandResult struct {
-	Success bool
-	Output  string
-	Error   error
+	Success       bool
+	Output        string
+	Error         error
+	GeneratedCode string // Program code Pulumi generated for imported resources, in the project's language
 }
 
 // ImportOptions for importing a resource
@@ -178,11 +232,130 @@ type ImportOptions struct {
 	Env map[string]string // Environment variables to set for the operation
 }
 
+// ImportSpec describes one existing resource to import in a batch
+type ImportSpec struct {
+	Type      string // Pulumi resource type (e.g., "aws:s3/bucket:Bucket")
+	Name      string // Logical name for the resource in Pulumi
+	ID        string // Provider-specific ID of the existing resource
+	ParentURN string // Optional parent URN for component hierarchy
+}
+
+// ImportPreview is the state an import would adopt, from a preview-only import
+type ImportPreview struct {
+	Properties []string // Input lines of the imported resource, dedented (e.g., `bucket: "logs"`)
+	Output     string   // Full preview output
+}
+
 // StateDeleteOptions for deleting a resource from state
 type StateDeleteOptions struct {
 	Env map[string]string // Environment variables to set for the operation
 }
 
+// PendingOperation is a resource operation the engine started but did not finish,
+// left in state when an update is interrupted
+type PendingOperation struct {
+	URN  string
+	Type string
+	Kind string // creating, updating, deleting, reading or importing
+}
+
+// CancelPendingOptions for recovering a locked stack
+type CancelPendingOptions struct {
+	Env map[string]string // Environment variables to set for the operation
+}
+
+// CancelPendingResult reports what recovering a locked stack did
+type CancelPendingResult struct {
+	Cancelled bool // An in-progress update was cancelled
+	Cleared   int  // Number of pending operations removed from state
+}
+
+// LoginOptions for logging in to a backend
+type LoginOptions struct {
+	URL   string            // Backend URL; empty for Pulumi Cloud
+	Token string            // Pulumi Cloud access token; empty to log in with the browser
+	Env   map[string]string // Environment variables to set for the login
+}
+
+// DeploymentTransferOptions for exporting and importing stack state
+type DeploymentTransferOptions struct {
+	Env map[string]string // Environment variables to set for the operation
+}
+
+// DeploymentFile describes a stack deployment saved to a file
+type DeploymentFile struct {
+	Path      string
+	Version   int    // Deployment schema version
+	Stack     string // Stack the deployment's resources belong to, empty without resources
+	Project   string // Project the deployment's resources belong to, empty without resources
+	Resources int
+}
+
+// StateIssueKind identifies a kind of inconsistency in stack state
+type StateIssueKind int
+
+const (
+	StateIssueDuplicateURN    StateIssueKind = iota // More than one live resource has the same URN
+	StateIssueOrphanedChild                         // Resource's parent is not in state
+	StateIssueMissingProvider                       // Resource's provider is not in state
+)
+
+// StateIssue is an inconsistency found in stack state
+type StateIssue struct {
+	Kind      StateIssueKind
+	URN       string
+	Type      string
+	Name      string
+	Reference string // Missing parent or provider URN (empty for duplicates)
+	Copies    int    // Number of live copies (duplicates only)
+}
+
+// StateFixAction is a targeted fix for a state issue
+type StateFixAction int
+
+const (
+	StateFixDelete   StateFixAction = iota // Remove the resource (or its extra copies) from state
+	StateFixReparent                       // Point the resource's parent at NewParent
+)
+
+// StateFix applies an action to the resource of a state issue
+type StateFix struct {
+	Issue     StateIssue
+	Action    StateFixAction
+	NewParent string // Parent URN for StateFixReparent (empty makes the resource a root)
+}
+
+// StateRepairOptions for repairing stack state
+type StateRepairOptions struct {
+	Env    map[string]string // Environment variables to set for the operation
+	DryRun bool              // Report the changes without writing state
+}
+
+// StateChange is one change made (or that would be made) to state by a repair
+type StateChange struct {
+	Action    StateFixAction
+	URN       string
+	Removed   int    // Number of state entries removed (delete only)
+	OldParent string // Previous parent (reparent only)
+	NewParent string // New parent (reparent only)
+}
+
+// DanglingReference is a reference to a URN that is no longer in state after a repair
+type DanglingReference struct {
+	URN       string // Resource holding the reference
+	Field     string // "parent", "provider", "dependencies", ...
+	Reference string // URN that no longer exists
+}
+
+// StateRepairReport describes the result of a state repair
+type StateRepairReport struct {
+	DryRun          bool
+	Changes         []StateChange
+	Dangling        []DanglingReference // References the repaired state would still break
+	ResourcesBefore int
+	ResourcesAfter  int
+}
+
 // StateProtectOptions for protecting/unprotecting a resource in state
 type StateProtectOptions struct {
 	Env map[string]string // Environment variables to set for the operation
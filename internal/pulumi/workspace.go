@@ -1,9 +1,14 @@
 package pulumi
 
 import (
+	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
 )
 
 // IsWorkspace checks if the given directory is a valid Pulumi workspace
@@ -20,11 +25,14 @@ func IsWorkspace(dir string) bool {
 	return false
 }
 
-// FindWorkspaces searches for Pulumi.yaml files starting from the given directory
-// and returns a list of workspace paths. It searches recursively down the directory tree.
-func FindWorkspaces(startDir, currentWorkDir string) ([]WorkspaceInfo, error) {
-	var workspaces []WorkspaceInfo
+// DefaultWorkspaceIgnore are the directories FindWorkspaces always skips: hidden
+// directories and dependency or cache directories that never hold projects
+var DefaultWorkspaceIgnore = []string{".*", "node_modules", "vendor", "__pycache__"}
 
+// FindWorkspaces searches for Pulumi.yaml files starting from the given directory
+// and returns a list of workspace paths, sorted by path. It searches recursively
+// down the directory tree, reading several directories at once.
+func FindWorkspaces(startDir, currentWorkDir string, opts WorkspaceSearchOptions) ([]WorkspaceInfo, error) {
 	// Resolve absolute paths for comparison
 	absStart, err := filepath.Abs(startDir)
 	if err != nil {
@@ -39,48 +47,163 @@ func FindWorkspaces(startDir, currentWorkDir string) ([]WorkspaceInfo, error) {
 		}
 	}
 
-	err = filepath.Walk(absStart, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			// Skip directories we can't access
-			if info != nil && info.IsDir() {
-				return filepath.SkipDir
+	ignore, err := ParseIgnorePatterns(append(slices.Clone(DefaultWorkspaceIgnore), opts.Ignore...))
+	if err != nil {
+		return nil, err
+	}
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = runtime.NumCPU()
+	}
+
+	w := &workspaceWalker{
+		root:     absStart,
+		current:  absCurrent,
+		maxDepth: opts.MaxDepth,
+		ignore:   ignore,
+		slots:    make(chan struct{}, parallelism-1),
+	}
+	w.walk(absStart, 0)
+	w.wg.Wait()
+
+	slices.SortFunc(w.workspaces, func(a, b WorkspaceInfo) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return w.workspaces, nil
+}
+
+// workspaceWalker walks a directory tree for FindWorkspaces. Subdirectories are
+// walked on another goroutine while a slot is free, and inline otherwise.
+type workspaceWalker struct {
+	root     string
+	current  string
+	maxDepth int
+	ignore   []IgnorePattern
+	slots    chan struct{}
+	wg       sync.WaitGroup
+
+	mu         sync.Mutex
+	workspaces []WorkspaceInfo
+}
+
+// walk searches dir, depth directories below the root
+func (w *workspaceWalker) walk(dir string, depth int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return // Skip directories we can't access
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() {
+			if name == "Pulumi.yaml" || name == "Pulumi.yml" {
+				w.add(dir, filepath.Join(dir, name))
 			}
-			return nil
+			continue
 		}
 
-		// Skip hidden directories and common non-project directories
-		if info.IsDir() {
-			name := info.Name()
-			if strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor" || name == "__pycache__" {
-				return filepath.SkipDir
-			}
+		if w.maxDepth > 0 && depth >= w.maxDepth {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		rel, _ := filepath.Rel(w.root, path)
+		if w.ignored(filepath.ToSlash(rel)) {
+			continue
+		}
+
+		select {
+		case w.slots <- struct{}{}:
+			w.wg.Add(1)
+			go func() {
+				defer w.wg.Done()
+				w.walk(path, depth+1)
+				<-w.slots
+			}()
+		default:
+			w.walk(path, depth+1)
 		}
+	}
+}
 
-		// Check for Pulumi.yaml or Pulumi.yml
-		if !info.IsDir() && (info.Name() == "Pulumi.yaml" || info.Name() == "Pulumi.yml") {
-			dir := filepath.Dir(path)
+// add records the workspace in dir, found from its Pulumi.yaml or Pulumi.yml
+func (w *workspaceWalker) add(dir, pulumiYamlPath string) {
+	// Try to get project name from the file
+	projectName := filepath.Base(dir)
+	if name, err := getProjectName(pulumiYamlPath); err == nil && name != "" {
+		projectName = name
+	}
 
-			// Try to get project name from the file
-			projectName := filepath.Base(dir)
-			if name, err := getProjectName(path); err == nil && name != "" {
-				projectName = name
-			}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.workspaces = append(w.workspaces, WorkspaceInfo{
+		Path:    dir,
+		Name:    projectName,
+		Current: dir == w.current,
+	})
+}
 
-			workspaces = append(workspaces, WorkspaceInfo{
-				Path:    dir,
-				Name:    projectName,
-				Current: dir == absCurrent,
-			})
+// ignored returns whether the directory at rel, relative to the root, is skipped
+func (w *workspaceWalker) ignored(rel string) bool {
+	for _, p := range w.ignore {
+		if p.Match(rel) {
+			return true
 		}
+	}
+	return false
+}
 
-		return nil
-	})
+// IgnorePattern is a .gitignore-style directory pattern. Patterns without a slash,
+// like "node_modules" or "*.cache", match a directory at any depth by name.
+// Patterns with a slash, like "examples/*" or "/build", match the path relative
+// to the search root. A leading "**/" matches in any directory.
+type IgnorePattern struct {
+	glob     string
+	segments int  // Number of path segments the glob matches, when deep
+	anywhere bool // Matches the directory name
+	deep     bool // Matches the trailing segments of the path
+}
 
-	if err != nil {
-		return nil, err
+// ParseIgnorePatterns parses .gitignore-style directory patterns. Negated ("!")
+// patterns are not supported.
+func ParseIgnorePatterns(patterns []string) ([]IgnorePattern, error) {
+	parsed := make([]IgnorePattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		glob := strings.TrimSuffix(strings.TrimSpace(pattern), "/")
+		if glob == "" || strings.HasPrefix(glob, "!") {
+			return nil, fmt.Errorf("invalid ignore pattern %q", pattern)
+		}
+		var p IgnorePattern
+		if rest, ok := strings.CutPrefix(glob, "**/"); ok {
+			glob, p.deep = rest, true
+		}
+		if !strings.Contains(glob, "/") {
+			p.anywhere, p.deep = true, false
+		}
+		p.glob = strings.TrimPrefix(glob, "/")
+		p.segments = strings.Count(p.glob, "/") + 1
+		if _, err := path.Match(p.glob, ""); err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern %q: %w", pattern, err)
+		}
+		parsed = append(parsed, p)
 	}
+	return parsed, nil
+}
 
-	return workspaces, nil
+// Match returns whether the directory at rel, a slash-separated path relative to
+// the search root, matches the pattern
+func (p IgnorePattern) Match(rel string) bool {
+	switch {
+	case p.anywhere:
+		rel = path.Base(rel)
+	case p.deep:
+		parts := strings.Split(rel, "/")
+		if len(parts) < p.segments {
+			return false
+		}
+		rel = strings.Join(parts[len(parts)-p.segments:], "/")
+	}
+	matched, _ := path.Match(p.glob, rel)
+	return matched
 }
 
 // getProjectName reads the project name from a Pulumi.yaml file
@@ -0,0 +1,99 @@
+package pulumi
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestFindWorkspaces verifies workspaces are found in sorted order, skipping ignored
+// directories and those deeper than MaxDepth
+func TestFindWorkspaces(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{
+		"app",
+		"infra/network",
+		"infra/cluster/addons",
+		"examples/demo",
+		"node_modules/pkg",
+		".cache/proj",
+		"dist",
+		"services/api/dist",
+	} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, dir, "Pulumi.yaml"), []byte("name: '"+filepath.Base(dir)+"'\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	paths := func(workspaces []WorkspaceInfo) []string {
+		var rel []string
+		for _, ws := range workspaces {
+			r, _ := filepath.Rel(root, ws.Path)
+			rel = append(rel, filepath.ToSlash(r))
+		}
+		return rel
+	}
+
+	workspaces, err := FindWorkspaces(root, filepath.Join(root, "app"), WorkspaceSearchOptions{Parallelism: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"app", "dist", "examples/demo", "infra/cluster/addons", "infra/network", "services/api/dist"}
+	if got := paths(workspaces); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if !workspaces[0].Current || workspaces[0].Name != "app" || workspaces[1].Current {
+		t.Errorf("expected only app to be current, got %+v", workspaces[:2])
+	}
+
+	workspaces, err = FindWorkspaces(root, "", WorkspaceSearchOptions{
+		MaxDepth: 2,
+		Ignore:   []string{"/dist", "examples/*", "**/cluster"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = []string{"app", "infra/network"}
+	if got := paths(workspaces); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if _, err := FindWorkspaces(root, "", WorkspaceSearchOptions{Ignore: []string{"!keep"}}); err == nil {
+		t.Error("expected an error for a negated pattern")
+	}
+}
+
+// TestIgnorePattern verifies matching directories by name, by path from the root,
+// and by trailing path
+func TestIgnorePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		rel     string
+		want    bool
+	}{
+		{"node_modules", "node_modules", true},
+		{"node_modules", "web/node_modules", true},
+		{"*.cache", "a/b/x.cache", true},
+		{"dist/", "services/dist", true},
+		{"/dist", "dist", true},
+		{"/dist", "services/dist", false},
+		{"examples/*", "examples/demo", true},
+		{"examples/*", "sub/examples/demo", false},
+		{"**/cluster", "infra/cluster", true},
+		{"**/infra/cluster", "a/infra/cluster", true},
+		{"**/infra/cluster", "cluster", false},
+	}
+	for _, tt := range tests {
+		patterns, err := ParseIgnorePatterns([]string{tt.pattern})
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.pattern, err)
+		}
+		if got := patterns[0].Match(tt.rel); got != tt.want {
+			t.Errorf("%q matching %q: expected %v, got %v", tt.pattern, tt.rel, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLogBufferSize is the number of log records kept for the log viewer
+const DefaultLogBufferSize = 1000
+
+// LogEntry is a log record captured for the log viewer
+type LogEntry struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	Attrs   string // Attributes formatted as key=value pairs
+}
+
+// LogBuffer keeps the most recent log records in a ring buffer, so they can be
+// viewed within the TUI instead of tailing redirected stderr
+type LogBuffer struct {
+	mu      sync.Mutex
+	entries []LogEntry
+	next    int // Index the next entry is written to once full
+	full    bool
+}
+
+// NewLogBuffer creates a log buffer keeping the last size records
+func NewLogBuffer(size int) *LogBuffer {
+	return &LogBuffer{entries: make([]LogEntry, 0, size)}
+}
+
+// add records an entry, dropping the oldest once full
+func (b *LogBuffer) add(entry LogEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.full {
+		b.entries = append(b.entries, entry)
+		b.full = len(b.entries) == cap(b.entries)
+		return
+	}
+	b.entries[b.next] = entry
+	b.next = (b.next + 1) % len(b.entries)
+}
+
+// Entries returns the captured records, oldest first
+func (b *LogBuffer) Entries() []LogEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entries := make([]LogEntry, 0, len(b.entries))
+	entries = append(entries, b.entries[b.next:]...)
+	return append(entries, b.entries[:b.next]...)
+}
+
+// Handler returns a slog handler capturing records of every level into the buffer
+func (b *LogBuffer) Handler() slog.Handler {
+	return &bufferHandler{buffer: b}
+}
+
+// bufferHandler is a slog handler writing to a LogBuffer
+type bufferHandler struct {
+	buffer *LogBuffer
+	attrs  string // Attributes added with WithAttrs, already formatted
+	group  string // Group prefix added with WithGroup
+}
+
+func (h *bufferHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *bufferHandler) Handle(_ context.Context, r slog.Record) error {
+	attrs := h.attrs
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = appendAttr(attrs, h.group, a)
+		return true
+	})
+	h.buffer.add(LogEntry{Time: r.Time, Level: r.Level, Message: r.Message, Attrs: attrs})
+	return nil
+}
+
+func (h *bufferHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	for _, a := range attrs {
+		clone.attrs = appendAttr(clone.attrs, h.group, a)
+	}
+	return &clone
+}
+
+func (h *bufferHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.group = h.group + name + "."
+	return &clone
+}
+
+// appendAttr formats a as key=value after attrs, flattening groups into dotted keys
+func appendAttr(attrs, group string, a slog.Attr) string {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		prefix := group
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			attrs = appendAttr(attrs, prefix, ga)
+		}
+		return attrs
+	}
+	if a.Equal(slog.Attr{}) {
+		return attrs
+	}
+	value := a.Value.String()
+	if strings.ContainsAny(value, " \t\n\"=") {
+		value = fmt.Sprintf("%q", value)
+	}
+	if attrs != "" {
+		attrs += " "
+	}
+	return attrs + group + a.Key + "=" + value
+}
+
+// teeHandler sends records to every handler that is enabled for their level
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range t {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var firstErr error
+	for _, h := range t {
+		if !h.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, r.Clone()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(teeHandler, len(t))
+	for i, h := range t {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	handlers := make(teeHandler, len(t))
+	for i, h := range t {
+		handlers[i] = h.WithGroup(name)
+	}
+	return handlers
+}
@@ -0,0 +1,42 @@
+package telemetry
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogBuffer(t *testing.T) {
+	logs := NewLogBuffer(3)
+	var stderr bytes.Buffer
+	logger := slog.New(teeHandler{
+		slog.NewTextHandler(&stderr, &slog.HandlerOptions{Level: slog.LevelInfo}),
+		logs.Handler(),
+	})
+
+	logger.Debug("first")
+	logger.With("stack", "dev").WithGroup("op").Info("second", "kind", "up", "message", "fix bucket")
+	logger.Warn("third")
+	logger.Error("fourth", slog.Group("plugin", "name", "aws"))
+
+	// The oldest record is dropped once full
+	entries := logs.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", entries)
+	}
+	if entries[0].Message != "second" || entries[2].Message != "fourth" {
+		t.Errorf("expected second to fourth, oldest first, got %+v", entries)
+	}
+	if want := `stack=dev op.kind=up op.message="fix bucket"`; entries[0].Attrs != want {
+		t.Errorf("expected attrs %q, got %q", want, entries[0].Attrs)
+	}
+	if want := "plugin.name=aws"; entries[2].Attrs != want {
+		t.Errorf("expected attrs %q, got %q", want, entries[2].Attrs)
+	}
+
+	// The other handler keeps its own level
+	if strings.Contains(stderr.String(), "first") || !strings.Contains(stderr.String(), "fourth") {
+		t.Errorf("expected debug records filtered from the text handler, got %q", stderr.String())
+	}
+}
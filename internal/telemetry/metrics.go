@@ -0,0 +1,15 @@
+package telemetry
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Meter returns the meter p5 records metrics with. Metrics are only exported
+// once StartMetrics is called, otherwise the global meter drops them.
+func Meter() metric.Meter {
+	return otel.Meter(instrumentationName)
+}
+
+// DurationBuckets are histogram bounds in seconds, from a slow frame to a long up
+var DurationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 5, 30, 60, 300, 900, 1800}
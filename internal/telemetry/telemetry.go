@@ -10,10 +10,12 @@ import (
 	"go.opentelemetry.io/contrib/bridges/otelslog"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/propagation"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
@@ -28,7 +30,10 @@ var version = "dev"
 type Telemetry struct {
 	tracerProvider *sdktrace.TracerProvider
 	loggerProvider *sdklog.LoggerProvider
+	meterProvider  *sdkmetric.MeterProvider
+	resource       *resource.Resource
 	Logger         *slog.Logger
+	Logs           *LogBuffer // Recent log records, only captured when debugging
 }
 
 func SetVersion(v string) {
@@ -68,19 +73,42 @@ func Setup(ctx context.Context, opts Options) (*Telemetry, error) {
 	))
 	global.SetLoggerProvider(loggerProvider)
 
-	logger := otelslog.NewLogger(serviceName,
+	var handler slog.Handler = otelslog.NewHandler(serviceName,
 		otelslog.WithLoggerProvider(loggerProvider),
 	)
+	var logs *LogBuffer
+	if opts.Debug {
+		logs = NewLogBuffer(DefaultLogBufferSize)
+		handler = teeHandler{handler, logs.Handler()}
+	}
 
 	return &Telemetry{
 		tracerProvider: tracerProvider,
 		loggerProvider: loggerProvider,
-		Logger:         logger,
+		resource:       res,
+		Logger:         slog.New(handler),
+		Logs:           logs,
 	}, nil
 }
 
+// StartMetrics exports metrics over OTLP alongside traces and logs. Metrics are
+// opt-in, as they are only needed to diagnose slowness. Does nothing when
+// OTEL_EXPORTER_OTLP_ENDPOINT isn't set.
+func (t *Telemetry) StartMetrics(ctx context.Context) error {
+	if t.resource == nil || t.meterProvider != nil {
+		return nil
+	}
+	meterProvider, err := newMeterProvider(ctx, t.resource)
+	if err != nil {
+		return err
+	}
+	otel.SetMeterProvider(meterProvider)
+	t.meterProvider = meterProvider
+	return nil
+}
+
 func (t *Telemetry) Shutdown(ctx context.Context) error {
-	if t.tracerProvider == nil && t.loggerProvider == nil {
+	if t.tracerProvider == nil && t.loggerProvider == nil && t.meterProvider == nil {
 		return nil
 	}
 
@@ -95,6 +123,12 @@ func (t *Telemetry) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	if t.meterProvider != nil {
+		if err := t.meterProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	if t.tracerProvider != nil {
 		if err := t.tracerProvider.Shutdown(ctx); err != nil {
 			errs = append(errs, err)
@@ -141,17 +175,36 @@ func newLoggerProvider(ctx context.Context, res *resource.Resource) (*sdklog.Log
 	), nil
 }
 
+func newMeterProvider(ctx context.Context, res *resource.Resource) (*sdkmetric.MeterProvider, error) {
+	exporter, err := otlpmetricgrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter,
+			sdkmetric.WithInterval(15*time.Second),
+		)),
+	), nil
+}
+
 func newNoopTelemetry(debug bool) *Telemetry {
-	var handler slog.Handler
-	if debug {
-		handler = slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+	if !debug {
+		return &Telemetry{
+			Logger: slog.New(slog.NewTextHandler(discard{}, nil)),
+		}
+	}
+	logs := NewLogBuffer(DefaultLogBufferSize)
+	handler := teeHandler{
+		slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
 			Level: slog.LevelDebug,
-		})
-	} else {
-		handler = slog.NewTextHandler(discard{}, nil)
+		}),
+		logs.Handler(),
 	}
 	return &Telemetry{
 		Logger: slog.New(handler),
+		Logs:   logs,
 	}
 }
 
@@ -0,0 +1,36 @@
+package telemetry
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/rfhold/p5"
+
+// Span attribute keys shared by pulumi operations and plugin calls
+const (
+	AttrOperation    = attribute.Key("p5.operation")
+	AttrStack        = attribute.Key("p5.stack")
+	AttrWorkDir      = attribute.Key("p5.work_dir")
+	AttrPlugin       = attribute.Key("p5.plugin")
+	AttrURN          = attribute.Key("p5.resource.urn")
+	AttrResourceType = attribute.Key("p5.resource.type")
+	AttrResourceOp   = attribute.Key("p5.resource.op")
+)
+
+// Tracer returns the tracer p5 records spans with. Spans are only exported when
+// OTEL_EXPORTER_OTLP_ENDPOINT is set, otherwise the global tracer drops them.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// EndSpan marks the span as failed when err is set, then ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
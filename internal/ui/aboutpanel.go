@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// AboutSection is a titled group of fields in the about panel
+type AboutSection struct {
+	Title  string
+	Fields []AboutField
+}
+
+// AboutField is a named value in the about panel. A field without a name is shown
+// as a note.
+type AboutField struct {
+	Name    string
+	Value   string
+	Warning bool // Highlights the field as something that needs attention
+}
+
+// AboutPanel is a floating panel with diagnostics about p5, the Pulumi CLI and the
+// workspace, like `pulumi about`, to copy into support requests and bug reports
+type AboutPanel struct {
+	PanelBase // Embed common panel functionality
+
+	sections []AboutSection
+}
+
+// NewAboutPanel creates a new about panel component
+func NewAboutPanel() *AboutPanel {
+	return &AboutPanel{}
+}
+
+// SetSections sets the shown sections, keeping the scroll position
+func (p *AboutPanel) SetSections(sections []AboutSection) {
+	p.sections = sections
+}
+
+// Text returns the sections as plain text
+func (p *AboutPanel) Text() string {
+	var b strings.Builder
+	for i, section := range p.sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(section.Title + "\n")
+		width := fieldNameWidth(section.Fields)
+		for _, f := range section.Fields {
+			if f.Name == "" {
+				b.WriteString("  " + f.Value + "\n")
+				continue
+			}
+			b.WriteString("  " + padRight(f.Name+":", width+1) + " " + f.Value + "\n")
+		}
+	}
+	return b.String()
+}
+
+// LineCount returns the number of lines of the sections
+func (p *AboutPanel) LineCount() int {
+	return strings.Count(p.Text(), "\n")
+}
+
+// Scroll moves the view by delta lines
+func (p *AboutPanel) Scroll(delta int) {
+	p.scrollLines(delta, p.LineCount())
+}
+
+// View renders the about panel
+func (p *AboutPanel) View() string {
+	if !p.Visible() || p.Width() == 0 || p.Height() == 0 {
+		return ""
+	}
+
+	// Content width inside border(2) and padding(4)
+	width := max(p.Width()-6, 20)
+	var lines []string
+	for i, section := range p.sections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, LabelStyle.Render(section.Title))
+		nameWidth := fieldNameWidth(section.Fields)
+		for _, f := range section.Fields {
+			valueStyle, noteStyle := ValueStyle, DimStyle
+			if f.Warning {
+				valueStyle, noteStyle = WarningStyle, WarningStyle
+			}
+			if f.Name == "" {
+				lines = append(lines, "  "+noteStyle.Render(ansi.Truncate(f.Value, width-2, "...")))
+				continue
+			}
+			value := ansi.Truncate(f.Value, max(width-nameWidth-4, 10), "...")
+			lines = append(lines, "  "+DimStyle.Render(padRight(f.Name+":", nameWidth+1))+" "+valueStyle.Render(value))
+		}
+	}
+
+	result := RenderDetailPanel(DetailPanelContent{
+		Header:       i18n.T("About") + DimStyle.Render("  ·  y "+i18n.T("copy all")),
+		Content:      strings.Join(lines, "\n"),
+		Width:        p.Width(),
+		Height:       p.Height(),
+		ScrollOffset: p.ScrollOffset(),
+	})
+	if result.NewScrollOffset != p.ScrollOffset() {
+		p.SetScrollOffset(result.NewScrollOffset)
+	}
+
+	return result.Rendered
+}
+
+// fieldNameWidth returns the width of the longest field name
+func fieldNameWidth(fields []AboutField) int {
+	width := 0
+	for _, f := range fields {
+		width = max(width, ansi.StringWidth(f.Name))
+	}
+	return width
+}
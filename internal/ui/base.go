@@ -79,6 +79,22 @@ func (p *PanelBase) ResetScroll() {
 	p.scrollOffset = 0
 }
 
+// contentHeight is the number of lines of a floating panel inside the header,
+// blank line, border(2) and padding(2)
+func (p *PanelBase) contentHeight() int {
+	return max(p.height-6, 1)
+}
+
+// maxScrollOffset returns the offset showing the last of lineCount lines
+func (p *PanelBase) maxScrollOffset(lineCount int) int {
+	return max(lineCount-p.contentHeight(), 0)
+}
+
+// scrollLines moves the view by delta lines, keeping it within lineCount lines
+func (p *PanelBase) scrollLines(delta, lineCount int) {
+	p.scrollOffset = min(max(p.scrollOffset+delta, 0), p.maxScrollOffset(lineCount))
+}
+
 // ListBase provides common list functionality for list components.
 // Note: This is a partial base - lists have complex state that varies significantly.
 // Use this for common loading/error state management.
@@ -0,0 +1,260 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// BulkImportItem is an existing resource discovered by a plugin that can be bulk imported
+type BulkImportItem struct {
+	ID          string // Import ID
+	Name        string // Logical name the resource is imported as
+	Label       string
+	Description string
+	PluginName  string
+	ParentURN   string // Parent of the matching pending create, if any
+	InProgram   bool   // True if Name matches a pending create in the preview
+}
+
+// BulkImportAction represents an action taken by the user in the bulk import modal
+type BulkImportAction int
+
+const (
+	BulkImportActionNone     BulkImportAction = iota
+	BulkImportActionConfirm                   // Import the selected resources
+	BulkImportActionCancel                    // Close the modal
+	BulkImportActionLoadMore                  // Cursor reached the end and more pages are available
+)
+
+// maxVisibleBulkImportItems is the max number of resources shown at once
+const maxVisibleBulkImportItems = 10
+
+// BulkImportModal lists existing resources of one type from plugins and lets the
+// user select several of them to import in a single operation
+type BulkImportModal struct {
+	ModalBase // Embedded modal base for common functionality
+
+	resourceType string
+
+	items    []BulkImportItem
+	selected map[int]bool
+	cursor   int
+
+	loading     bool // Loading the first page
+	loadingMore bool // Loading a subsequent page
+	hasMore     bool // More pages are available
+	err         error
+}
+
+// NewBulkImportModal creates a new bulk import modal
+func NewBulkImportModal() *BulkImportModal {
+	return &BulkImportModal{
+		selected: make(map[int]bool),
+	}
+}
+
+// Show shows the modal for resources of the given type and starts loading
+func (m *BulkImportModal) Show(resourceType string) {
+	m.ModalBase.Show()
+	m.resourceType = resourceType
+	m.items = nil
+	m.selected = make(map[int]bool)
+	m.cursor = 0
+	m.loading = true
+	m.loadingMore = false
+	m.hasMore = false
+	m.err = nil
+}
+
+// AppendItems adds a page of resources. hasMore reports whether another page is available.
+func (m *BulkImportModal) AppendItems(items []BulkImportItem, hasMore bool) {
+	m.items = append(m.items, items...)
+	m.hasMore = hasMore
+	m.loading = false
+	m.loadingMore = false
+}
+
+// SetLoadingMore marks that the next page is being loaded
+func (m *BulkImportModal) SetLoadingMore() {
+	m.loadingMore = true
+}
+
+// SetError sets an error to display
+func (m *BulkImportModal) SetError(err error) {
+	m.err = err
+	m.loading = false
+	m.loadingMore = false
+}
+
+// GetResourceType returns the type of resources being imported
+func (m *BulkImportModal) GetResourceType() string {
+	return m.resourceType
+}
+
+// Items returns all loaded resources
+func (m *BulkImportModal) Items() []BulkImportItem {
+	return m.items
+}
+
+// SelectedItems returns the selected resources in list order
+func (m *BulkImportModal) SelectedItems() []BulkImportItem {
+	var result []BulkImportItem
+	for i, item := range m.items {
+		if m.selected[i] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// toggleAll selects every resource, or clears the selection if all are selected
+func (m *BulkImportModal) toggleAll() {
+	if len(m.selected) == len(m.items) {
+		m.selected = make(map[int]bool)
+		return
+	}
+	for i := range m.items {
+		m.selected[i] = true
+	}
+}
+
+// moveCursor moves the cursor and asks for the next page when it reaches the end
+func (m *BulkImportModal) moveCursor(delta int) BulkImportAction {
+	m.cursor = MoveCursor(m.cursor, delta, len(m.items))
+	m.SetScrollOffset(EnsureCursorVisible(m.cursor, m.ScrollOffset(), len(m.items), maxVisibleBulkImportItems))
+	if m.cursor == len(m.items)-1 && m.hasMore && !m.loadingMore {
+		return BulkImportActionLoadMore
+	}
+	return BulkImportActionNone
+}
+
+// Update handles key events
+func (m *BulkImportModal) Update(msg tea.KeyMsg) (BulkImportAction, tea.Cmd) {
+	if !m.Visible() {
+		return BulkImportActionNone, nil
+	}
+
+	switch {
+	case key.Matches(msg, Keys.Escape):
+		m.Hide()
+		return BulkImportActionCancel, nil
+	case msg.String() == "enter":
+		if len(m.selected) == 0 {
+			return BulkImportActionNone, nil
+		}
+		return BulkImportActionConfirm, nil
+	case key.Matches(msg, Keys.ToggleSelect):
+		if m.cursor < len(m.items) {
+			if m.selected[m.cursor] {
+				delete(m.selected, m.cursor)
+			} else {
+				m.selected[m.cursor] = true
+			}
+		}
+	case msg.String() == "a":
+		m.toggleAll()
+	case key.Matches(msg, Keys.Up):
+		return m.moveCursor(-1), nil
+	case key.Matches(msg, Keys.Down):
+		return m.moveCursor(1), nil
+	case key.Matches(msg, Keys.PageUp):
+		return m.moveCursor(-maxVisibleBulkImportItems), nil
+	case key.Matches(msg, Keys.PageDown):
+		return m.moveCursor(maxVisibleBulkImportItems), nil
+	case key.Matches(msg, Keys.Home):
+		return m.moveCursor(-len(m.items)), nil
+	case key.Matches(msg, Keys.End):
+		return m.moveCursor(len(m.items)), nil
+	}
+	return BulkImportActionNone, nil
+}
+
+// View renders the bulk import modal
+func (m *BulkImportModal) View() string {
+	title := DialogTitleStyle.Render(i18n.T("Bulk Import"))
+
+	var content strings.Builder
+	content.WriteString(DimStyle.Render(i18n.T("Type: ")))
+	content.WriteString(ValueStyle.Render(m.resourceType))
+	content.WriteString("\n\n")
+
+	switch {
+	case m.loading:
+		content.WriteString(DimStyle.Render(i18n.T("Discovering resources...")))
+	case m.err != nil:
+		content.WriteString(ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+	case len(m.items) == 0:
+		content.WriteString(DimStyle.Render(i18n.T("No importable resources found")))
+	default:
+		m.renderItems(&content)
+	}
+
+	footer := DimStyle.Render("\n" + strings.Join([]string{
+		"space " + i18n.T("select"),
+		"a " + i18n.T("select all"),
+		"enter " + i18n.T("import"),
+		"esc " + i18n.T("cancel"),
+	}, "  "))
+
+	return m.RenderDialog(title, content.String(), footer)
+}
+
+// renderItems renders the scrollable resource list and selection summary
+func (m *BulkImportModal) renderItems(content *strings.Builder) {
+	scrollOffset := m.ScrollOffset()
+	endIdx := min(scrollOffset+maxVisibleBulkImportItems, len(m.items))
+
+	for i := scrollOffset; i < endIdx; i++ {
+		m.renderItem(content, i)
+	}
+
+	if hint := RenderScrollHint(scrollOffset > 0, endIdx < len(m.items), "  "); hint != "" {
+		content.WriteString(hint)
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(LabelStyle.Render(i18n.Tf("%d of %d selected", len(m.selected), len(m.items))))
+	switch {
+	case m.loadingMore:
+		content.WriteString(DimStyle.Render("  " + i18n.T("Loading more...")))
+	case m.hasMore:
+		content.WriteString(DimStyle.Render("  " + i18n.T("more available")))
+	}
+}
+
+// renderItem renders a single resource row
+func (m *BulkImportModal) renderItem(content *strings.Builder, i int) {
+	item := m.items[i]
+
+	cursor := "  "
+	if i == m.cursor {
+		cursor = CursorStyle.Render("> ")
+	}
+	check := "[ ] "
+	if m.selected[i] {
+		check = "[x] "
+	}
+
+	content.WriteString(cursor)
+	if i == m.cursor {
+		content.WriteString(ValueStyle.Render(check + item.Label))
+	} else {
+		content.WriteString(DimStyle.Render(check) + item.Label)
+	}
+	if item.Description != "" {
+		content.WriteString(DimStyle.Render(" - " + item.Description))
+	}
+	content.WriteString(DimStyle.Render(" → " + item.Name))
+	if item.InProgram {
+		content.WriteString(StatusSuccessStyle.Render(" " + i18n.T("(in program)")))
+	}
+	if item.PluginName != "" {
+		content.WriteString(DimStyle.Render(" [" + item.PluginName + "]"))
+	}
+	content.WriteString("\n")
+}
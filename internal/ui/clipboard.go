@@ -1,12 +1,48 @@
 package ui
 
 import (
+	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"runtime"
+	"strings"
 
+	"github.com/aymanbagabas/go-osc52/v2"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// Clipboard modes, set with SetClipboard
+const (
+	// ClipboardAuto copies over OSC52 in SSH sessions, and otherwise with the native
+	// clipboard, falling back to OSC52 when it fails (default)
+	ClipboardAuto = "auto"
+	// ClipboardNative copies with pbcopy, xclip, xsel or clip only
+	ClipboardNative = "native"
+	// ClipboardOSC52 copies by asking the terminal over an OSC52 escape sequence only
+	ClipboardOSC52 = "osc52"
+)
+
+// clipboardMode is how copied text reaches the clipboard, from p5.toml
+var clipboardMode = ClipboardAuto
+
+// osc52Output is where OSC52 sequences are written: the terminal, by way of stderr
+var osc52Output io.Writer = os.Stderr
+
+// SetClipboard sets how copied text reaches the clipboard: ClipboardAuto,
+// ClipboardNative or ClipboardOSC52. An empty mode is ClipboardAuto.
+func SetClipboard(mode string) error {
+	switch mode {
+	case "":
+		clipboardMode = ClipboardAuto
+	case ClipboardAuto, ClipboardNative, ClipboardOSC52:
+		clipboardMode = mode
+	default:
+		return fmt.Errorf("must be %q, %q or %q, got %q", ClipboardAuto, ClipboardNative, ClipboardOSC52, mode)
+	}
+	return nil
+}
+
 // CopiedToClipboardMsg is sent after text is copied to the clipboard
 type CopiedToClipboardMsg struct {
 	Success bool
@@ -21,8 +57,39 @@ func CopyToClipboardWithCountCmd(text string, count int) tea.Cmd {
 	}
 }
 
-// copyToClipboard copies text to the system clipboard
+// copyToClipboard copies text to the clipboard in the configured mode
 func copyToClipboard(text string) bool {
+	switch clipboardMode {
+	case ClipboardNative:
+		return copyNative(text)
+	case ClipboardOSC52:
+		return copyOSC52(text)
+	default:
+		// The native clipboard of a remote host isn't the user's, so go straight
+		// to the terminal
+		if os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != "" {
+			return copyOSC52(text)
+		}
+		return copyNative(text) || copyOSC52(text)
+	}
+}
+
+// copyOSC52 asks the terminal to copy text with an OSC52 escape sequence, wrapped
+// to pass through tmux or screen. Terminals don't confirm the copy, so it only
+// fails when the sequence can't be written.
+func copyOSC52(text string) bool {
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	_, err := seq.WriteTo(osc52Output)
+	return err == nil
+}
+
+// copyNative copies text to the system clipboard with the platform's clipboard tool
+func copyNative(text string) bool {
 	var cmd *exec.Cmd
 	switch runtime.GOOS {
 	case "darwin":
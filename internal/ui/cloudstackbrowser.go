@@ -0,0 +1,107 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// CloudStackItem represents a stack of any project of the organization in the browser
+type CloudStackItem struct {
+	Name             string // Fully qualified name, e.g. "acme/app/prod"
+	Org              string
+	Project          string
+	Stack            string
+	LastUpdate       string // RFC3339 time of the latest update, empty if never updated
+	UpdateInProgress bool
+	ResourceCount    int
+	Current          bool // Stack open in p5
+}
+
+// Label implements SelectorItem
+func (c CloudStackItem) Label() string {
+	return c.Name
+}
+
+// IsCurrent implements SelectorItem
+func (c CloudStackItem) IsCurrent() bool {
+	return c.Current
+}
+
+// CloudStackBrowser is a modal dialog for choosing a stack of any project of the
+// organization, whether or not its program is checked out. The fuzzy filter
+// matches the organization, project and stack names.
+type CloudStackBrowser struct {
+	*SelectorDialog[CloudStackItem]
+}
+
+// NewCloudStackBrowser creates a new cloud stack browser
+func NewCloudStackBrowser() *CloudStackBrowser {
+	dialog := NewSelectorDialog[CloudStackItem](i18n.T("Browse Organization Stacks"))
+	dialog.SetLoadingText(i18n.T("Loading stacks of all projects..."))
+	dialog.SetEmptyText(i18n.T("No stacks found"))
+	dialog.SetFuzzyFilter(true)
+	dialog.SetMaxVisible(15)
+
+	// Dim the organization so the project and stack stand out
+	dialog.SetItemRenderer(func(item CloudStackItem, isCursor bool) string {
+		cursor := "  "
+		if isCursor {
+			cursor = CursorStyle.Render("> ")
+		}
+		name := item.Project + "/" + item.Stack
+		if item.Project == "" {
+			name = item.Name
+		}
+		var org string
+		if item.Org != "" {
+			org = DimStyle.Render(item.Org + "/")
+		}
+		switch {
+		case item.Current:
+			name = ValueStyle.Render(name) + DimStyle.Render(" (current)")
+		case isCursor:
+			name = ValueStyle.Render(name)
+		default:
+			name = DimStyle.Render(name)
+		}
+		return cursor + org + name + dialog.renderExtraInfo(item)
+	})
+	dialog.SetExtraInfoRenderer(func(item CloudStackItem) string {
+		if item.UpdateInProgress {
+			return WarningStyle.Render("  " + i18n.T("updating"))
+		}
+		if item.LastUpdate == "" {
+			return DimStyle.Render("  " + i18n.T("never updated"))
+		}
+		return DimStyle.Render("  " + i18n.Tf("%d resources", item.ResourceCount) + " · " + FormatTime(item.LastUpdate, "2006-01-02 15:04"))
+	})
+
+	return &CloudStackBrowser{
+		SelectorDialog: dialog,
+	}
+}
+
+// SetStacks sets the list of stacks
+func (b *CloudStackBrowser) SetStacks(stacks []CloudStackItem) {
+	b.SetItems(stacks)
+}
+
+// SelectedStack returns the fully qualified name of the selected stack, or empty if none
+func (b *CloudStackBrowser) SelectedStack() string {
+	item := b.SelectedItem()
+	if item == nil {
+		return ""
+	}
+	return item.Name
+}
+
+// Update handles key events and returns true if a stack was selected
+func (b *CloudStackBrowser) Update(msg tea.KeyMsg) (selected bool, cmd tea.Cmd) {
+	return b.SelectorDialog.Update(msg)
+}
+
+// View renders the cloud stack browser dialog
+func (b *CloudStackBrowser) View() string {
+	return b.SelectorDialog.View()
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// CodePanel is a floating panel showing the program code Pulumi generated for
+// imported resources, so it can be copied or saved into the program
+type CodePanel struct {
+	PanelBase // Embed common panel functionality
+
+	title    string
+	language string
+	code     string
+}
+
+// NewCodePanel creates a new code panel component
+func NewCodePanel() *CodePanel {
+	return &CodePanel{}
+}
+
+// Show shows code written in language, scrolled to the top
+func (p *CodePanel) Show(title, language, code string) {
+	p.title = title
+	p.language = language
+	p.code = strings.TrimRight(code, "\n")
+	p.ResetScroll()
+	p.PanelBase.Show()
+}
+
+// Code returns the shown code
+func (p *CodePanel) Code() string {
+	return p.code
+}
+
+// LineCount returns the number of lines of code
+func (p *CodePanel) LineCount() int {
+	return strings.Count(p.code, "\n") + 1
+}
+
+// Scroll moves the view by delta lines
+func (p *CodePanel) Scroll(delta int) {
+	p.scrollLines(delta, p.LineCount())
+}
+
+// View renders the code panel
+func (p *CodePanel) View() string {
+	if !p.Visible() || p.Width() == 0 || p.Height() == 0 {
+		return ""
+	}
+
+	var content string
+	if p.code == "" {
+		content = DimStyle.Render(i18n.T("Pulumi generated no code"))
+	} else {
+		// Content width inside border(2) and padding(4)
+		width := max(p.Width()-6, 20)
+		lines := strings.Split(p.code, "\n")
+		for i, line := range lines {
+			lines[i] = ValueStyle.Render(ansi.Truncate(strings.ReplaceAll(line, "\t", "    "), width, "..."))
+		}
+		content = strings.Join(lines, "\n")
+	}
+
+	header := p.title
+	if p.language != "" {
+		header += DimStyle.Render("  ·  " + p.language)
+	}
+	header += DimStyle.Render("  ·  y " + i18n.T("copy") + "  s " + i18n.T("save"))
+	result := RenderDetailPanel(DetailPanelContent{
+		Header:       header,
+		Content:      content,
+		Width:        p.Width(),
+		Height:       p.Height(),
+		ScrollOffset: p.ScrollOffset(),
+	})
+	if result.NewScrollOffset != p.ScrollOffset() {
+		p.SetScrollOffset(result.NewScrollOffset)
+	}
+
+	return result.Rendered
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"errors"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+)
+
+// ConfigCopyModal wraps StepModal to pick the stack whose config is copied to the
+// current stack
+type ConfigCopyModal struct {
+	*StepModal
+
+	stackName  string
+	stackFiles []pulumi.StackFileInfo
+}
+
+// NewConfigCopyModal creates a new config copy modal
+func NewConfigCopyModal() *ConfigCopyModal {
+	return &ConfigCopyModal{StepModal: NewStepModal(i18n.T("Copy Config"))}
+}
+
+// Show prompts for the stack to copy config to stackName from
+func (m *ConfigCopyModal) Show(stackName string) {
+	m.stackName = stackName
+	m.SetSteps([]StepModalStep{{
+		Title:            i18n.T("Copy config from another stack"),
+		InfoLines:        []InfoLine{{Label: i18n.T("Stack"), Value: stackName}},
+		InputLabel:       i18n.T("Copy config from"),
+		InputPlaceholder: i18n.T("Enter stack name..."),
+		Warning:          i18n.Tf("Values already set in %s are overwritten", stackName),
+		Validate:         m.validateSource,
+	}})
+	m.StepModal.Show()
+	m.SetStepSuggestions(0, copyConfigSuggestions(m.sourceStacks()))
+}
+
+// SetStackFiles sets the stack files config can be copied from
+func (m *ConfigCopyModal) SetStackFiles(files []pulumi.StackFileInfo) {
+	m.stackFiles = files
+	m.SetStepSuggestions(0, copyConfigSuggestions(m.sourceStacks()))
+}
+
+// sourceStacks returns the stack files config can be copied from, leaving out the
+// current stack
+func (m *ConfigCopyModal) sourceStacks() []pulumi.StackFileInfo {
+	return otherStackFiles(m.stackFiles, m.stackName)
+}
+
+// validateSource checks config is copied from a stack with a stack file
+func (m *ConfigCopyModal) validateSource(stackName string) error {
+	return validateStackFile(m.sourceStacks(), stackName)
+}
+
+// Source returns the stack to copy config from
+func (m *ConfigCopyModal) Source() string {
+	return m.GetResult(0)
+}
+
+// otherStackFiles returns the stack files other than stackName's
+func otherStackFiles(files []pulumi.StackFileInfo, stackName string) []pulumi.StackFileInfo {
+	var other []pulumi.StackFileInfo
+	for _, f := range files {
+		if f.Name != stackName {
+			other = append(other, f)
+		}
+	}
+	return other
+}
+
+// validateStackFile checks stackName is one of the stack files config can be
+// copied from
+func validateStackFile(files []pulumi.StackFileInfo, stackName string) error {
+	for _, f := range files {
+		if f.Name == stackName {
+			return nil
+		}
+	}
+	return errors.New(i18n.Tf("No Pulumi.%s.yaml to copy config from", stackName))
+}
+
+// copyConfigSuggestions lists the stacks config can be copied from, noting the
+// secrets that must be re-encrypted
+func copyConfigSuggestions(files []pulumi.StackFileInfo) []StepSuggestion {
+	suggestions := make([]StepSuggestion, 0, len(files))
+	for _, f := range files {
+		s := StepSuggestion{ID: f.Name, Label: f.Name, Source: "Pulumi." + f.Name + ".yaml"}
+		if f.SecretCount > 0 {
+			s.Warning = i18n.Tf("%d secrets to re-encrypt", f.SecretCount)
+		}
+		suggestions = append(suggestions, s)
+	}
+	return suggestions
+}
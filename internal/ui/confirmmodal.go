@@ -1,8 +1,14 @@
 package ui
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
 )
 
 // ConfirmModal is a reusable confirmation dialog with keybind actions
@@ -27,15 +33,34 @@ type ConfirmModal struct {
 
 	// Bulk context data (for multi-resource operations)
 	bulkResources []SelectedResource
+
+	// Optional list expanded with tab, e.g. the resources an operation affects
+	details         []string
+	detailsExpanded bool
+	detailsOffset   int
+
+	// Phrase that must be typed to confirm instead of pressing the confirm key,
+	// e.g. the stack name before deploying a production stack
+	phrase    string
+	input     textinput.Model
+	phraseErr string
 }
 
+// confirmDetailsHeight is the number of detail lines shown at once when expanded
+const confirmDetailsHeight = 10
+
 // NewConfirmModal creates a new confirmation modal
 func NewConfirmModal() *ConfirmModal {
+	ti := textinput.New()
+	ti.CharLimit = 256
+	ti.Width = DefaultInputWidth
+
 	return &ConfirmModal{
-		cancelLabel:  "Cancel",
-		confirmLabel: "Confirm",
+		cancelLabel:  i18n.T("Cancel"),
+		confirmLabel: i18n.T("Confirm"),
 		confirmKey:   "y",
 		cancelKey:    "n",
+		input:        ti,
 	}
 }
 
@@ -46,9 +71,58 @@ func (m *ConfirmModal) Show(title, message, warning string) {
 	m.title = title
 	m.message = message
 	m.warning = warning
+	m.clearDetails()
+	m.clearPhrase()
 	m.ModalBase.Show()
 }
 
+// SetDetails sets a list shown below the message when expanded with tab. Call it
+// after showing the modal.
+func (m *ConfirmModal) SetDetails(lines []string) {
+	m.details = lines
+}
+
+// ExpandDetails shows the details list without pressing tab first. Call it after
+// setting the details.
+func (m *ConfirmModal) ExpandDetails() {
+	m.detailsExpanded = len(m.details) > 0
+}
+
+// RequirePhrase asks for phrase to be typed to confirm, like deleting a GitHub
+// repository, instead of pressing the confirm key. Call it after showing the modal.
+func (m *ConfirmModal) RequirePhrase(phrase string) {
+	m.phrase = phrase
+	m.phraseErr = ""
+	m.input.Placeholder = phrase
+	m.input.SetValue("")
+	m.input.Focus()
+}
+
+// RequiresPhrase returns whether a phrase must be typed to confirm
+func (m *ConfirmModal) RequiresPhrase() bool {
+	return m.phrase != ""
+}
+
+// clearPhrase goes back to confirming with the confirm key
+func (m *ConfirmModal) clearPhrase() {
+	m.phrase = ""
+	m.phraseErr = ""
+	m.input.SetValue("")
+	m.input.Blur()
+}
+
+// DetailsExpanded returns whether the details list is expanded
+func (m *ConfirmModal) DetailsExpanded() bool {
+	return m.detailsExpanded
+}
+
+// clearDetails removes the details list
+func (m *ConfirmModal) clearDetails() {
+	m.details = nil
+	m.detailsExpanded = false
+	m.detailsOffset = 0
+}
+
 // ShowWithContext shows the modal and stores context data
 func (m *ConfirmModal) ShowWithContext(title, message, warning, contextURN, contextName, contextType string) {
 	m.Show(title, message, warning)
@@ -83,6 +157,8 @@ func (m *ConfirmModal) ShowBulkWithContext(title, message, warning string, resou
 	m.title = title
 	m.message = message
 	m.warning = warning
+	m.clearDetails()
+	m.clearPhrase()
 	m.bulkResources = resources
 	// Clear single-resource context
 	m.contextURN = ""
@@ -123,6 +199,9 @@ func (m *ConfirmModal) Update(msg tea.KeyMsg) (confirmed, cancelled bool, cmd te
 	if !m.Visible() {
 		return false, false, nil
 	}
+	if m.phrase != "" {
+		return m.updatePhrase(msg)
+	}
 
 	switch {
 	case msg.String() == m.confirmKey:
@@ -132,11 +211,46 @@ func (m *ConfirmModal) Update(msg tea.KeyMsg) (confirmed, cancelled bool, cmd te
 	case msg.String() == m.cancelKey, key.Matches(msg, Keys.Escape):
 		m.ModalBase.Hide()
 		return false, true, nil // Cancelled
+
+	case msg.String() == "tab" && len(m.details) > 0:
+		m.detailsExpanded = !m.detailsExpanded
+
+	case key.Matches(msg, Keys.Up) && m.detailsExpanded:
+		m.detailsOffset = max(m.detailsOffset-1, 0)
+
+	case key.Matches(msg, Keys.Down) && m.detailsExpanded:
+		m.detailsOffset = min(m.detailsOffset+1, max(len(m.details)-confirmDetailsHeight, 0))
 	}
 
 	return false, false, nil
 }
 
+// updatePhrase handles key events while a phrase must be typed to confirm. Only
+// enter confirms and only escape cancels, so every other key goes to the input.
+func (m *ConfirmModal) updatePhrase(msg tea.KeyMsg) (confirmed, cancelled bool, cmd tea.Cmd) {
+	switch {
+	case key.Matches(msg, Keys.Escape):
+		m.ModalBase.Hide()
+		return false, true, nil
+
+	case msg.String() == "enter":
+		if m.input.Value() != m.phrase {
+			m.phraseErr = i18n.Tf("Type %s exactly to continue", m.phrase)
+			return false, false, nil
+		}
+		m.ModalBase.Hide()
+		return true, false, nil
+
+	case msg.String() == "tab" && len(m.details) > 0:
+		m.detailsExpanded = !m.detailsExpanded
+		return false, false, nil
+	}
+
+	m.phraseErr = ""
+	m.input, cmd = m.input.Update(msg)
+	return false, false, cmd
+}
+
 // View renders the confirmation modal
 func (m *ConfirmModal) View() string {
 	title := DialogTitleStyle.Render(m.title)
@@ -144,13 +258,52 @@ func (m *ConfirmModal) View() string {
 	// Build content
 	content := ValueStyle.Render(m.message)
 
+	if m.detailsExpanded {
+		content += "\n\n" + m.renderDetails()
+	}
+
 	// Add warning if present
 	if m.warning != "" {
 		content += "\n\n" + ErrorStyle.Render(m.warning)
 	}
 
+	if m.phrase != "" {
+		content += "\n\n" + LabelStyle.Render(i18n.Tf("Type %s to confirm", m.phrase)) + "\n" + m.input.View()
+		if m.phraseErr != "" {
+			content += "\n\n" + ErrorStyle.Render(m.phraseErr)
+		}
+	}
+
 	// Footer hints showing keybinds
-	footer := DimStyle.Render("\n" + m.confirmKey + " " + m.confirmLabel + "  " + m.cancelKey + "/" + "esc " + m.cancelLabel)
+	hints := m.confirmKey + " " + m.confirmLabel + "  " + m.cancelKey + "/" + "esc " + m.cancelLabel
+	if m.phrase != "" {
+		hints = "enter " + m.confirmLabel + "  esc " + m.cancelLabel
+	}
+	switch {
+	case m.detailsExpanded:
+		hints += "  tab " + i18n.T("hide list")
+	case len(m.details) > 0:
+		hints += "  tab " + i18n.T("show list")
+	}
+	footer := DimStyle.Render("\n" + hints)
 
 	return m.RenderDialog(title, content, footer)
 }
+
+// renderDetails renders the visible part of the expanded details list, with the
+// range shown when it scrolls
+func (m *ConfirmModal) renderDetails() string {
+	end := min(m.detailsOffset+confirmDetailsHeight, len(m.details))
+	lines := make([]string, 0, end-m.detailsOffset+1)
+	for _, line := range m.details[m.detailsOffset:end] {
+		lines = append(lines, "  "+line)
+	}
+	if len(m.details) > confirmDetailsHeight {
+		hint := fmt.Sprintf("  [%d-%d/%d]", m.detailsOffset+1, end, len(m.details))
+		if scroll := RenderScrollHint(m.detailsOffset > 0, end < len(m.details), ""); scroll != "" {
+			hint += " " + scroll
+		}
+		lines = append(lines, DimStyle.Render(hint))
+	}
+	return strings.Join(lines, "\n")
+}
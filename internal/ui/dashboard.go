@@ -0,0 +1,326 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// DriftStatus describes what the most recent refresh says about a stack's drift
+type DriftStatus int
+
+const (
+	DriftUnknown  DriftStatus = iota // No refresh since the last update
+	DriftNone                        // Last refresh found no changes
+	DriftDetected                    // Last refresh changed resources
+)
+
+// DashboardRow is a single stack in the multi-stack dashboard
+type DashboardRow struct {
+	WorkDir       string // Absolute path of the stack's workspace
+	Workspace     string // Workspace path relative to where p5 was launched
+	Project       string
+	Stack         string
+	Current       bool   // True if this is the workspace's selected stack
+	LastKind      string // Kind of the most recent update ("update", "refresh", ...)
+	LastResult    string // Result of the most recent update
+	LastTime      string // Start time of the most recent update (RFC3339)
+	ResourceCount int
+	Drift         DriftStatus
+	Err           error // Set when the stack could not be loaded
+}
+
+// Dashboard is a full-screen table of every stack in the workspace(s)
+type Dashboard struct {
+	ListBase // Embed common list functionality for loading/error state
+
+	rows     []DashboardRow
+	visible  bool
+	closable bool // False when p5 was started on the dashboard and there is nothing to go back to
+
+	// Cursor & scrolling
+	cursor       int
+	scrollOffset int
+}
+
+// NewDashboard creates a new Dashboard component
+func NewDashboard() *Dashboard {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(ColorPrimary)
+	d := &Dashboard{}
+	d.SetSpinner(s)
+	return d
+}
+
+// Show makes the dashboard visible
+func (d *Dashboard) Show() {
+	d.visible = true
+}
+
+// Hide hides the dashboard
+func (d *Dashboard) Hide() {
+	d.visible = false
+}
+
+// SetClosable sets whether esc returns to the previous view
+func (d *Dashboard) SetClosable(closable bool) {
+	d.closable = closable
+}
+
+// Closable returns whether esc returns to the previous view
+func (d *Dashboard) Closable() bool {
+	return d.closable
+}
+
+// Visible returns whether the dashboard is visible
+func (d *Dashboard) Visible() bool {
+	return d.visible
+}
+
+// SetSize sets the dimensions for the dashboard and ensures cursor is visible
+func (d *Dashboard) SetSize(width, height int) {
+	d.ListBase.SetSize(width, height)
+	d.ensureCursorVisible()
+}
+
+// SetRows replaces all rows and resets the cursor
+func (d *Dashboard) SetRows(rows []DashboardRow) {
+	d.rows = rows
+	d.cursor = 0
+	d.scrollOffset = 0
+	d.SetLoading(false, "")
+	d.ClearError()
+}
+
+// Rows returns all dashboard rows
+func (d *Dashboard) Rows() []DashboardRow {
+	return d.rows
+}
+
+// SelectedRow returns the row under the cursor, or nil if none
+func (d *Dashboard) SelectedRow() *DashboardRow {
+	if d.cursor < 0 || d.cursor >= len(d.rows) {
+		return nil
+	}
+	return &d.rows[d.cursor]
+}
+
+// visibleHeight returns the number of lines available for rows
+func (d *Dashboard) visibleHeight() int {
+	padding := 6 // title, column header, footer and vertical padding
+	return CalculateVisibleHeight(d.Height(), len(d.rows), padding)
+}
+
+// ensureCursorVisible adjusts scroll offset to keep cursor visible
+func (d *Dashboard) ensureCursorVisible() {
+	d.scrollOffset = EnsureCursorVisible(d.cursor, d.scrollOffset, len(d.rows), d.visibleHeight())
+}
+
+// moveCursor moves the cursor by delta, clamping to valid range
+func (d *Dashboard) moveCursor(delta int) {
+	d.cursor = MoveCursor(d.cursor, delta, len(d.rows))
+	d.ensureCursorVisible()
+}
+
+// Update handles navigation keys
+func (d *Dashboard) Update(msg tea.KeyMsg) {
+	if len(d.rows) == 0 {
+		return
+	}
+	switch {
+	case key.Matches(msg, Keys.Up):
+		d.moveCursor(-1)
+	case key.Matches(msg, Keys.Down):
+		d.moveCursor(1)
+	case key.Matches(msg, Keys.PageUp):
+		d.moveCursor(-d.visibleHeight())
+	case key.Matches(msg, Keys.PageDown):
+		d.moveCursor(d.visibleHeight())
+	case key.Matches(msg, Keys.Home):
+		d.cursor = 0
+		d.ensureCursorVisible()
+	case key.Matches(msg, Keys.End):
+		d.cursor = len(d.rows) - 1
+		d.ensureCursorVisible()
+	}
+}
+
+// View renders the dashboard
+func (d *Dashboard) View() string {
+	if !d.visible || d.Width() == 0 || d.Height() == 0 {
+		return ""
+	}
+
+	title := DialogTitleStyle.Render(i18n.T("Stacks Dashboard"))
+	footer := d.renderFooter()
+
+	var body string
+	switch {
+	case d.IsLoading():
+		body = d.Spinner().View() + " " + DimStyle.Render(i18n.T("Loading stacks..."))
+	case d.Error() != nil:
+		body = ErrorStyle.Render(i18n.Tf("Error: %v", d.Error()))
+	case len(d.rows) == 0:
+		body = DimStyle.Render(i18n.T("No stacks found"))
+	default:
+		body = d.renderTable()
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, title, body)
+	mainHeight := max(d.Height()-2, 1)
+	// Long rows are cut at the screen edge rather than wrapped
+	main := lipgloss.NewStyle().
+		Padding(1, 2, 0).
+		Height(mainHeight).
+		MaxHeight(mainHeight).
+		MaxWidth(d.Width()).
+		Render(content)
+	return lipgloss.JoinVertical(lipgloss.Left, main, "", footer)
+}
+
+// renderTable renders the column header and the visible rows
+func (d *Dashboard) renderTable() string {
+	headers := []string{
+		i18n.T("PROJECT"),
+		i18n.T("STACK"),
+		i18n.T("RESOURCES"),
+		i18n.T("DRIFT"),
+		i18n.T("LAST UPDATE"),
+	}
+
+	// Size the text columns to the widest value
+	widths := []int{lipgloss.Width(headers[0]), lipgloss.Width(headers[1]), lipgloss.Width(headers[2]), lipgloss.Width(headers[3])}
+	for _, row := range d.rows {
+		widths[0] = max(widths[0], lipgloss.Width(row.Project))
+		widths[1] = max(widths[1], lipgloss.Width(d.stackLabel(row)))
+		widths[3] = max(widths[3], lipgloss.Width(d.driftLabel(row)))
+	}
+
+	var b strings.Builder
+	b.WriteString("  ")
+	for i, h := range headers {
+		if i < len(widths) {
+			h = padRight(h, widths[i])
+		}
+		b.WriteString(DimStyle.Render(h))
+		if i < len(headers)-1 {
+			b.WriteString("  ")
+		}
+	}
+	b.WriteString("\n")
+
+	scrollable := IsScrollable(d.Height(), len(d.rows), 6)
+	if scrollable {
+		b.WriteString(RenderScrollUpIndicator(d.scrollOffset > 0))
+	}
+
+	visible := d.visibleHeight()
+	endIdx := min(d.scrollOffset+visible, len(d.rows))
+	for i := d.scrollOffset; i < endIdx; i++ {
+		b.WriteString(d.renderRow(d.rows[i], widths, i == d.cursor))
+		b.WriteString("\n")
+	}
+
+	if scrollable {
+		b.WriteString(RenderScrollDownIndicator(endIdx < len(d.rows)))
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// renderRow renders one stack row
+func (d *Dashboard) renderRow(row DashboardRow, widths []int, isCursor bool) string {
+	cursor := "  "
+	if isCursor {
+		cursor = CursorStyle.Render("> ")
+	}
+
+	project := ValueStyle.Render(padRight(row.Project, widths[0]))
+	stack := padRight(d.stackLabel(row), widths[1])
+	if row.Current {
+		stack = LabelStyle.Render(stack)
+	}
+
+	if row.Err != nil {
+		return fmt.Sprintf("%s%s  %s  %s", cursor, project, stack, ErrorStyle.Render(i18n.Tf("Error: %v", row.Err)))
+	}
+
+	resources := padLeft(fmt.Sprintf("%d", row.ResourceCount), widths[2])
+
+	var drift string
+	label := padRight(d.driftLabel(row), widths[3])
+	switch row.Drift {
+	case DriftDetected:
+		drift = StatusFailedStyle.Render(label)
+	case DriftNone:
+		drift = StatusSuccessStyle.Render(label)
+	default:
+		drift = DimStyle.Render(label)
+	}
+
+	last := DimStyle.Render(i18n.T("never"))
+	if row.LastKind != "" {
+		last = fmt.Sprintf("%s %s  %s",
+			RenderHistoryKind(row.LastKind),
+			RenderHistoryResult(row.LastResult),
+			FormatTimeStyled(row.LastTime, "2006-01-02 15:04", 16, DimStyle),
+		)
+	}
+
+	return fmt.Sprintf("%s%s  %s  %s  %s  %s", cursor, project, stack, resources, drift, last)
+}
+
+// stackLabel returns the stack name, followed by its workspace path when the
+// workspace is not the directory p5 was launched from
+func (d *Dashboard) stackLabel(row DashboardRow) string {
+	name := row.Stack
+	if name == "" {
+		name = "-" // The workspace's stacks could not be listed
+	}
+	if row.Workspace == "" || row.Workspace == "." {
+		return name
+	}
+	return name + " (" + row.Workspace + ")"
+}
+
+// driftLabel returns the translated drift status
+func (d *Dashboard) driftLabel(row DashboardRow) string {
+	switch row.Drift {
+	case DriftDetected:
+		return i18n.T("drifted")
+	case DriftNone:
+		return i18n.T("in sync")
+	default:
+		return i18n.T("unknown")
+	}
+}
+
+// renderFooter renders the key hints
+func (d *Dashboard) renderFooter() string {
+	hints := []string{
+		DimStyle.Render("enter " + i18n.T("open stack")),
+		DimStyle.Render("r " + i18n.T("reload")),
+	}
+	if d.closable {
+		hints = append(hints, DimStyle.Render("esc "+i18n.T("back")))
+	}
+	hints = append(hints, DimStyle.Render("q "+i18n.T("quit")))
+	return " " + strings.Join(hints, "  ")
+}
+
+// padRight pads s with spaces to the given display width
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-lipgloss.Width(s), 0))
+}
+
+// padLeft right-aligns s to the given display width
+func padLeft(s string, width int) string {
+	return strings.Repeat(" ", max(width-lipgloss.Width(s), 0)) + s
+}
@@ -1,10 +1,15 @@
 package ui
 
 import (
+	"maps"
+	"slices"
 	"strings"
 
-	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
 )
 
 // DetailPanel is a floating panel showing resource details
@@ -14,50 +19,106 @@ type DetailPanel struct {
 	// Current resource being displayed
 	resource *ResourceItem
 
-	// Filter state for property keys
-	filter FilterState
+	// Resource notes by URN (shared reference from parent)
+	notes map[string]string
+
+	// Search within the panel content
+	search PanelSearch
+
+	// Diff JSON strings as strings instead of structurally
+	rawJSON bool
+
+	// Property paths de-emphasized in diffs, by resource type pattern
+	diffIgnore DiffIgnoreRules
+
+	// Decrypted secrets of the resource, by property path. Forgotten when another
+	// resource is shown or the panel is hidden.
+	revealed map[string]any
 }
 
 // NewDetailPanel creates a new detail panel component
 func NewDetailPanel() *DetailPanel {
 	return &DetailPanel{
-		filter: NewFilterState(),
+		search: NewPanelSearch(),
 	}
 }
 
 // SetResource sets the resource to display details for
 func (d *DetailPanel) SetResource(resource *ResourceItem) {
+	if resource == nil || d.resource == nil || resource.URN != d.resource.URN {
+		d.revealed = nil
+	}
 	d.resource = resource
 	d.ResetScroll()
-	// Don't reset filter when changing resources - user might want to keep filtering
+	// Don't reset the search when changing resources - user might want to keep searching
 }
 
-// FilterActive returns whether the filter is currently active
-func (d *DetailPanel) FilterActive() bool {
-	return d.filter.Active()
+// SetNotes sets the resource notes to show, keyed by URN
+func (d *DetailPanel) SetNotes(notes map[string]string) {
+	d.notes = notes
 }
 
-// Update handles key events for the detail panel
-func (d *DetailPanel) Update(msg tea.KeyMsg) tea.Cmd {
-	if !d.Visible() {
-		return nil
-	}
+// SetRawJSON sets whether changed JSON strings are diffed as plain strings
+func (d *DetailPanel) SetRawJSON(raw bool) {
+	d.rawJSON = raw
+}
 
-	// Handle filter activation with "/"
-	if key.Matches(msg, Keys.Filter) && !d.filter.Active() {
-		d.filter.Activate()
+// RawJSON returns whether changed JSON strings are diffed as plain strings
+func (d *DetailPanel) RawJSON() bool {
+	return d.rawJSON
+}
+
+// SetDiffIgnore sets the property paths de-emphasized in diffs
+func (d *DetailPanel) SetDiffIgnore(rules DiffIgnoreRules) {
+	d.diffIgnore = rules
+}
+
+// Hide hides the panel, clears its search and hides revealed secrets again
+func (d *DetailPanel) Hide() {
+	d.PanelBase.Hide()
+	d.search.Clear()
+	d.revealed = nil
+}
+
+// HiddenSecrets returns the paths of the secrets of the shown resource that
+// haven't been revealed, sorted
+func (d *DetailPanel) HiddenSecrets() []string {
+	if d.resource == nil {
 		return nil
 	}
-
-	// Forward to filter if active
-	if d.filter.Active() {
-		cmd, handled := d.filter.Update(msg)
-		if handled {
-			return cmd
+	r := d.resource
+	var hidden []string
+	for _, path := range pulumi.SecretPaths(r.Inputs, r.Outputs, r.OldInputs, r.OldOutputs) {
+		if _, ok := d.revealed[path]; !ok {
+			hidden = append(hidden, path)
 		}
 	}
+	return hidden
+}
+
+// RevealSecret shows the decrypted value of the secret at path of the resource
+// with urn, if it is still shown
+func (d *DetailPanel) RevealSecret(urn, path string, value any) {
+	if d.resource == nil || d.resource.URN != urn {
+		return
+	}
+	if d.revealed == nil {
+		d.revealed = make(map[string]any)
+	}
+	d.revealed[path] = value
+}
+
+// SearchActive returns whether a search query is being typed
+func (d *DetailPanel) SearchActive() bool {
+	return d.search.Active()
+}
 
-	return nil
+// Update handles search keys for the detail panel and returns true if the key was handled
+func (d *DetailPanel) Update(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if !d.Visible() {
+		return nil, false
+	}
+	return d.search.Update(msg)
 }
 
 // View renders the detail panel
@@ -72,23 +133,24 @@ func (d *DetailPanel) View() string {
 		header = d.resource.Name
 	}
 
-	// Add filter indicator to header
-	if d.filter.Active() || d.filter.Applied() {
-		header += DimStyle.Render(" [filtered]")
+	if d.rawJSON {
+		header += DimStyle.Render(" [raw json]")
 	}
 
 	// Build unified content
 	var content string
 	if d.resource == nil {
-		content = DimStyle.Render("No resource selected")
+		content = DimStyle.Render(i18n.T("No resource selected"))
 	} else {
 		content = d.renderUnified()
 	}
 
-	// Add filter bar at end of content if active or applied
-	if d.filter.Active() || d.filter.Applied() {
-		content += "\n\n" + d.filter.View()
+	// Highlight search matches and scroll to the selected one
+	content = d.search.Highlight(content)
+	if offset, ok := d.search.ScrollTarget(); ok {
+		d.SetScrollOffset(offset)
 	}
+	header += d.search.HeaderSuffix()
 
 	// Use shared helper for common panel rendering
 	result := RenderDetailPanel(DetailPanelContent{
@@ -110,23 +172,23 @@ func (d *DetailPanel) View() string {
 // renderUnified renders a unified view with metadata and combined inputs/outputs diff
 func (d *DetailPanel) renderUnified() string {
 	if d.resource == nil {
-		return DimStyle.Render("No resource selected")
+		return DimStyle.Render(i18n.T("No resource selected"))
 	}
 
 	var b strings.Builder
 	maxWidth := d.Width() - 8
 
 	// Compact metadata header
-	b.WriteString(DimStyle.Render("Type: "))
-	b.WriteString(ValueStyle.Render(d.resource.Type))
+	b.WriteString(DimStyle.Render(i18n.T("Type: ")))
+	b.WriteString(withResourceIcon(d.resource.Type, ValueStyle.Render(d.resource.Type)))
 	b.WriteString("\n")
 
 	// Operation and status on same line
-	b.WriteString(DimStyle.Render("Op: "))
+	b.WriteString(DimStyle.Render(i18n.T("Op: ")))
 	b.WriteString(RenderOp(d.resource.Op))
 	if d.resource.Status != StatusNone {
 		b.WriteString("  ")
-		b.WriteString(DimStyle.Render("Status: "))
+		b.WriteString(DimStyle.Render(i18n.T("Status: ")))
 		b.WriteString(RenderStatus(d.resource.Status))
 		if d.resource.Status == StatusRunning && d.resource.CurrentOp != "" {
 			b.WriteString(" (")
@@ -136,6 +198,41 @@ func (d *DetailPanel) renderUnified() string {
 	}
 	b.WriteString("\n")
 
+	if d.resource.DiffChanged {
+		b.WriteString(DiffChangedStyle.Render(i18n.T("Diff changed since previous preview")))
+		b.WriteString("\n")
+	}
+
+	// Properties that made Pulumi recreate the resource rather than update it
+	if keys := d.resource.ReplaceKeys; len(keys) > 0 {
+		b.WriteString("\n")
+		b.WriteString(DimStyle.Render("─── Replacement ───"))
+		b.WriteString("\n\n")
+		b.WriteString(DimStyle.Render(i18n.T("Replaced because these properties changed:")))
+		b.WriteString("\n")
+		for _, key := range keys {
+			b.WriteString("  ")
+			b.WriteString(OpReplaceStyle.Render(key))
+			b.WriteString("\n")
+		}
+	}
+
+	// Notes the user attached to the resource
+	if note := d.notes[d.resource.URN]; note != "" {
+		b.WriteString("\n")
+		b.WriteString(DimStyle.Render("─── Notes ───"))
+		b.WriteString("\n\n")
+		b.WriteString(lipgloss.NewStyle().Width(maxWidth).Render(note))
+		b.WriteString("\n")
+	}
+
+	// Outputs read from the referenced stack
+	if d.resource.Type == pulumi.StackReferenceType {
+		if ref := pulumi.ReadStackReference(d.resource.Inputs, d.resource.Outputs); ref != nil {
+			d.renderStackReference(&b, ref, maxWidth)
+		}
+	}
+
 	// Combined properties section
 	b.WriteString("\n")
 	b.WriteString(DimStyle.Render("─── Properties ───"))
@@ -143,20 +240,60 @@ func (d *DetailPanel) renderUnified() string {
 
 	// Use the DiffRenderer for property rendering
 	renderer := NewDiffRenderer(maxWidth)
+	renderer.SetRawJSON(d.rawJSON)
+	renderer.SetIgnoredPaths(d.diffIgnore.PathsFor(d.resource.Type))
 
-	// Apply key filter if filter is applied
-	if d.filter.Applied() {
+	// Show only drifted properties when drift detection found any
+	if drifted := d.resource.DriftedKeys; len(drifted) > 0 {
 		renderer.SetKeyFilter(func(key string) bool {
-			return d.filter.Matches(key)
+			return slices.Contains(drifted, key)
 		})
 	}
 
-	content := renderer.RenderCombinedProperties(d.resource)
-	if d.filter.Applied() && strings.TrimSpace(content) == "" {
-		b.WriteString(DimStyle.Render("No matching properties"))
-	} else {
-		b.WriteString(content)
+	resource := d.resource
+	if len(d.revealed) > 0 {
+		withSecrets := *resource
+		withSecrets.Inputs = pulumi.RevealSecrets(resource.Inputs, d.revealed)
+		withSecrets.Outputs = pulumi.RevealSecrets(resource.Outputs, d.revealed)
+		withSecrets.OldInputs = pulumi.RevealSecrets(resource.OldInputs, d.revealed)
+		withSecrets.OldOutputs = pulumi.RevealSecrets(resource.OldOutputs, d.revealed)
+		resource = &withSecrets
 	}
+	b.WriteString(renderer.RenderCombinedProperties(resource))
 
 	return b.String()
 }
+
+// renderStackReference renders the referenced stack and the outputs read from it
+func (d *DetailPanel) renderStackReference(b *strings.Builder, ref *pulumi.StackReference, maxWidth int) {
+	b.WriteString("\n")
+	b.WriteString(DimStyle.Render("─── Referenced Outputs ───"))
+	b.WriteString("\n\n")
+	b.WriteString(DimStyle.Render(i18n.T("Stack: ")))
+	b.WriteString(ValueStyle.Render(ref.Name))
+	b.WriteString("  ")
+	b.WriteString(DimStyle.Render(Keys.FollowReference.Help().Key + " " + i18n.T("follow")))
+	b.WriteString("\n")
+
+	if len(ref.Outputs) == 0 {
+		b.WriteString(DimStyle.Render(i18n.T("No outputs")))
+		b.WriteString("\n")
+		return
+	}
+	keys := slices.Sorted(maps.Keys(ref.Outputs))
+	keyWidth := 0
+	for _, k := range keys {
+		keyWidth = max(keyWidth, len(k))
+	}
+	for _, k := range keys {
+		b.WriteString("  ")
+		b.WriteString(LabelStyle.Render(k + strings.Repeat(" ", keyWidth-len(k))))
+		b.WriteString("  ")
+		if slices.Contains(ref.SecretOutputs, k) {
+			b.WriteString(DimStyle.Render("[secret]"))
+		} else {
+			b.WriteString(formatDiffValue(ref.Outputs[k], ValueStyle, maxWidth-keyWidth, 2))
+		}
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+)
+
+const (
+	// diagnosticsStripLines is how many of the latest lines the expanded strip shows
+	diagnosticsStripLines = 6
+	// maxDiagnostics caps the diagnostics kept for a preview, dropping the oldest
+	maxDiagnostics = 1000
+)
+
+// DiagnosticsStrip is a log strip at the bottom of the preview view tailing the
+// engine diagnostics of the running preview: provider warnings, program output
+// and debug messages. Collapsed, it shows the latest message on a single line.
+type DiagnosticsStrip struct {
+	diagnostics []pulumi.EngineDiagnostic
+	expanded    bool
+	width       int
+}
+
+// NewDiagnosticsStrip creates a new, collapsed diagnostics strip
+func NewDiagnosticsStrip() *DiagnosticsStrip {
+	return &DiagnosticsStrip{}
+}
+
+// Clear removes the diagnostics of the previous preview
+func (s *DiagnosticsStrip) Clear() {
+	s.diagnostics = nil
+}
+
+// Add appends a diagnostic, dropping the oldest past maxDiagnostics
+func (s *DiagnosticsStrip) Add(d pulumi.EngineDiagnostic) {
+	s.diagnostics = append(s.diagnostics, d)
+	if len(s.diagnostics) > maxDiagnostics {
+		s.diagnostics = s.diagnostics[len(s.diagnostics)-maxDiagnostics:]
+	}
+}
+
+// Diagnostics returns the diagnostics kept, oldest first
+func (s *DiagnosticsStrip) Diagnostics() []pulumi.EngineDiagnostic {
+	return s.diagnostics
+}
+
+// Toggle expands or collapses the strip
+func (s *DiagnosticsStrip) Toggle() {
+	s.expanded = !s.expanded
+}
+
+// Expanded returns whether the strip shows several lines
+func (s *DiagnosticsStrip) Expanded() bool {
+	return s.expanded
+}
+
+// SetWidth sets the width of the strip
+func (s *DiagnosticsStrip) SetWidth(width int) {
+	s.width = width
+}
+
+// Height returns the number of lines the strip takes, 0 without diagnostics
+func (s *DiagnosticsStrip) Height() int {
+	if len(s.diagnostics) == 0 {
+		return 0
+	}
+	if !s.expanded {
+		return 1
+	}
+	return 1 + len(s.tail(diagnosticsStripLines))
+}
+
+// tail returns up to n of the latest lines, messages spanning several lines
+// being split
+func (s *DiagnosticsStrip) tail(n int) []string {
+	var lines []string
+	for i := len(s.diagnostics) - 1; i >= 0 && len(lines) < n; i-- {
+		d := s.diagnostics[i]
+		msgLines := strings.Split(d.Message, "\n")
+		for j := len(msgLines) - 1; j >= 0 && len(lines) < n; j-- {
+			lines = append(lines, renderDiagnosticLine(d, msgLines[j], max(s.width, 20)))
+		}
+	}
+	// Collected newest first
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return lines
+}
+
+// View renders the strip, empty without diagnostics
+func (s *DiagnosticsStrip) View() string {
+	if len(s.diagnostics) == 0 {
+		return ""
+	}
+
+	title := LabelStyle.Render(i18n.T("Engine log")) + DimStyle.Render(fmt.Sprintf(" (%d)", len(s.diagnostics)))
+	if !s.expanded {
+		latest := s.diagnostics[len(s.diagnostics)-1]
+		message, _, _ := strings.Cut(latest.Message, "\n")
+		hint := DimStyle.Render("  " + Keys.ToggleDiagnostics.Help().Key + " " + i18n.T("expand"))
+		width := max(s.width-ansi.StringWidth(title)-ansi.StringWidth(hint)-1, 10)
+		return title + " " + renderDiagnosticLine(latest, message, width) + hint
+	}
+
+	hint := DimStyle.Render("  " + Keys.ToggleDiagnostics.Help().Key + " " + i18n.T("collapse"))
+	rule := max(s.width-ansi.StringWidth(title)-ansi.StringWidth(hint)-1, 0)
+	lines := []string{title + " " + TreeLineStyle.Render(strings.Repeat("─", rule)) + hint}
+	lines = append(lines, s.tail(diagnosticsStripLines)...)
+	return strings.Join(lines, "\n")
+}
+
+// renderDiagnosticLine renders one line of a diagnostic, prefixed with its
+// severity and resource, truncated to width
+func renderDiagnosticLine(d pulumi.EngineDiagnostic, message string, width int) string {
+	line := fmt.Sprintf("%-7s ", diagnosticLabel(d.Severity))
+	if d.URN != "" {
+		line += pulumi.ExtractResourceName(d.URN) + ": "
+	}
+	line = ansi.Truncate(line+message, width, "...")
+
+	switch d.Severity {
+	case "error":
+		return ErrorStyle.Render(line)
+	case "warning", "info#err":
+		return WarningStyle.Render(line)
+	case "debug":
+		return DimStyle.Render(line)
+	}
+	return ValueStyle.Render(line)
+}
+
+// diagnosticLabel returns the label of a severity
+func diagnosticLabel(severity string) string {
+	switch severity {
+	case "info#err":
+		return "stderr"
+	case "warning":
+		return "warn"
+	}
+	return severity
+}
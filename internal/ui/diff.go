@@ -1,10 +1,14 @@
 package ui
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
 )
 
 // DiffType represents the type of change for a value
@@ -21,6 +25,8 @@ const (
 type DiffRenderer struct {
 	maxWidth  int
 	keyFilter func(key string) bool // Optional filter function for property keys
+	rawJSON   bool                  // Diff JSON strings as strings instead of structurally
+	ignored   []string              // Path patterns of properties listed apart, without values
 }
 
 // NewDiffRenderer creates a new diff renderer with the specified max width
@@ -34,6 +40,18 @@ func (r *DiffRenderer) SetKeyFilter(filter func(key string) bool) {
 	r.keyFilter = filter
 }
 
+// SetRawJSON sets whether changed JSON strings are diffed as plain strings
+// instead of parsed and diffed property by property
+func (r *DiffRenderer) SetRawJSON(raw bool) {
+	r.rawJSON = raw
+}
+
+// SetIgnoredPaths sets the path patterns of properties to de-emphasize. They are
+// listed dimmed after the other properties, without their values.
+func (r *DiffRenderer) SetIgnoredPaths(patterns []string) {
+	r.ignored = patterns
+}
+
 // ClearKeyFilter removes the key filter
 func (r *DiffRenderer) ClearKeyFilter() {
 	r.keyFilter = nil
@@ -119,11 +137,15 @@ func (r *DiffRenderer) RenderCombinedProperties(resource *ResourceItem) string {
 	state := getDiffStateForOperation(resource)
 
 	if state.oldInputs == nil && state.newInputs == nil && state.oldOutputs == nil && state.newOutputs == nil {
-		return DimStyle.Render("No properties available")
+		return DimStyle.Render(i18n.T("No properties available"))
 	}
 
 	inputKeys := collectKeys(state.oldInputs, state.newInputs)
 	outputKeys := collectKeys(state.oldOutputs, state.newOutputs)
+	var oldIgnored, newIgnored map[string]any
+	if len(r.ignored) > 0 {
+		state, oldIgnored, newIgnored = r.stripIgnoredState(state, inputKeys)
+	}
 
 	var b strings.Builder
 
@@ -133,9 +155,16 @@ func (r *DiffRenderer) RenderCombinedProperties(resource *ResourceItem) string {
 
 	b.WriteString(r.renderOutputOnlyProperties(state, inputKeys, outputKeys))
 
+	if ignored := r.renderIgnored(oldIgnored, newIgnored); ignored != "" {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(ignored)
+	}
+
 	result := b.String()
 	if result == "" {
-		return DimStyle.Render("No properties available")
+		return DimStyle.Render(i18n.T("No properties available"))
 	}
 	return result
 }
@@ -231,7 +260,7 @@ func (r *DiffRenderer) renderDiffMap(oldMap, newMap map[string]any, indent int)
 
 // renderStyledValue renders a value with consistent styling for add/remove/unchanged operations
 func (r *DiffRenderer) renderStyledValue(b *strings.Builder, key string, val any, style lipgloss.Style, prefix, indentStr string, indent int) {
-	if valMap, isMap := val.(map[string]any); isMap && len(valMap) > 0 {
+	if valMap, isMap := val.(map[string]any); isMap && len(valMap) > 0 && !pulumi.IsSecret(val) {
 		b.WriteString(style.Render(indentStr + prefix + " "))
 		b.WriteString(style.Render(key + ":"))
 		b.WriteString("\n")
@@ -241,6 +270,21 @@ func (r *DiffRenderer) renderStyledValue(b *strings.Builder, key string, val any
 		b.WriteString(style.Render(key + ":"))
 		b.WriteString("\n")
 		b.WriteString(r.renderArrayExpanded(valArr, style, prefix, indent+1))
+	} else if lines, format, ok := r.valueBlock(val); ok {
+		b.WriteString(style.Render(indentStr + prefix + " "))
+		b.WriteString(style.Render(key + ":"))
+		b.WriteString(DimStyle.Render(" (" + format.String() + ")"))
+		b.WriteString("\n")
+		childIndent := strings.Repeat("  ", indent+1)
+		for _, line := range lines {
+			b.WriteString(style.Render(childIndent + prefix + " "))
+			if prefix == " " {
+				b.WriteString(style.Render(line))
+			} else {
+				b.WriteString(highlightLine(line, format))
+			}
+			b.WriteString("\n")
+		}
 	} else {
 		b.WriteString(style.Render(indentStr + prefix + " "))
 		b.WriteString(style.Render(key + ": "))
@@ -261,11 +305,31 @@ func (r *DiffRenderer) renderDiffValue(key string, oldVal, newVal any, diffType
 		r.renderStyledValue(&b, key, oldVal, OpDeleteStyle, "-", indentStr, indent)
 
 	case DiffModified:
+		// Diff JSON documents stored as strings structurally
+		if oldJSON, newJSON, ok := r.parseJSONPair(oldVal, newVal); ok {
+			b.WriteString(OpUpdateStyle.Render(indentStr + "~ "))
+			b.WriteString(OpUpdateStyle.Render(key + ":"))
+			b.WriteString(DimStyle.Render(" (json)"))
+			b.WriteString("\n")
+			b.WriteString(r.renderJSONDiff(oldJSON, newJSON, indent+1))
+			break
+		}
+
+		// Diff multi-line values in a recognized format line by line
+		if oldLines, newLines, format, ok := r.valueBlockPair(oldVal, newVal); ok {
+			b.WriteString(OpUpdateStyle.Render(indentStr + "~ "))
+			b.WriteString(OpUpdateStyle.Render(key + ":"))
+			b.WriteString(DimStyle.Render(" (" + format.String() + ")"))
+			b.WriteString("\n")
+			b.WriteString(renderLineDiff(oldLines, newLines, format, indent+1))
+			break
+		}
+
 		// Check if both are maps - if so, recurse
 		oldMap, oldIsMap := oldVal.(map[string]any)
 		newMap, newIsMap := newVal.(map[string]any)
 
-		if oldIsMap && newIsMap {
+		if oldIsMap && newIsMap && !pulumi.IsSecret(oldVal) && !pulumi.IsSecret(newVal) {
 			// Recurse into nested maps
 			b.WriteString(OpUpdateStyle.Render(indentStr + "~ "))
 			b.WriteString(OpUpdateStyle.Render(key + ":"))
@@ -300,6 +364,110 @@ func (r *DiffRenderer) renderDiffValue(key string, oldVal, newVal any, diffType
 	return b.String()
 }
 
+// valueBlock returns the lines of a value to show as a highlighted block, when it
+// is a string in a recognized format
+func (r *DiffRenderer) valueBlock(val any) ([]string, valueFormat, bool) {
+	s, ok := val.(string)
+	if !ok {
+		return nil, formatPlain, false
+	}
+	return formatValueBlock(s, r.rawJSON)
+}
+
+// valueBlockPair returns the lines of two string values to diff line by line, when
+// either is a YAML or HCL block and neither is too large to show. JSON documents
+// are diffed structurally instead, or as strings when of different kinds.
+func (r *DiffRenderer) valueBlockPair(oldVal, newVal any) (oldLines, newLines []string, format valueFormat, ok bool) {
+	oldStr, oldIsStr := oldVal.(string)
+	newStr, newIsStr := newVal.(string)
+	if !oldIsStr || !newIsStr || isLargeValue(oldStr) || isLargeValue(newStr) {
+		return nil, nil, formatPlain, false
+	}
+	oldLines, oldFormat, oldOK := formatValueBlock(oldStr, r.rawJSON)
+	newLines, format, newOK := formatValueBlock(newStr, r.rawJSON)
+	switch {
+	case !oldOK && !newOK, oldFormat == formatJSON, format == formatJSON:
+		return nil, nil, formatPlain, false
+	case !newOK:
+		format = oldFormat
+		newLines = splitValueLines(newStr)
+	case !oldOK:
+		oldLines = splitValueLines(oldStr)
+	}
+	return oldLines, newLines, format, true
+}
+
+// splitValueLines splits a string value outside a recognized format into lines
+func splitValueLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimRight(s, "\n"), "\n")
+}
+
+// renderLineDiff renders a line-by-line diff of two values in format, with the
+// added and removed lines highlighted
+func renderLineDiff(oldLines, newLines []string, format valueFormat, indent int) string {
+	var b strings.Builder
+	indentStr := strings.Repeat("  ", indent)
+	for _, change := range diffLines(oldLines, newLines) {
+		switch change.op {
+		case "+":
+			b.WriteString(OpCreateStyle.Render(indentStr + "+ "))
+			b.WriteString(highlightLine(change.text, format))
+		case "-":
+			b.WriteString(OpDeleteStyle.Render(indentStr + "- "))
+			b.WriteString(highlightLine(change.text, format))
+		default:
+			b.WriteString(DimStyle.Render(strings.TrimRight(indentStr+"  "+change.text, " ")))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// parseJSONPair parses two string values as JSON documents of the same kind,
+// both objects or both arrays, unless raw JSON diffs are enabled
+func (r *DiffRenderer) parseJSONPair(oldVal, newVal any) (oldJSON, newJSON any, ok bool) {
+	if r.rawJSON {
+		return nil, nil, false
+	}
+	oldStr, oldIsStr := oldVal.(string)
+	newStr, newIsStr := newVal.(string)
+	if !oldIsStr || !newIsStr {
+		return nil, nil, false
+	}
+	oldJSON, oldOK := parseJSONDocument(oldStr)
+	newJSON, newOK := parseJSONDocument(newStr)
+	if !oldOK || !newOK {
+		return nil, nil, false
+	}
+	_, oldIsMap := oldJSON.(map[string]any)
+	_, newIsMap := newJSON.(map[string]any)
+	return oldJSON, newJSON, oldIsMap == newIsMap
+}
+
+// parseJSONDocument parses a string holding a JSON object or array
+func parseJSONDocument(s string) (any, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "{") && !strings.HasPrefix(s, "[") {
+		return nil, false
+	}
+	var doc any
+	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+		return nil, false
+	}
+	return doc, true
+}
+
+// renderJSONDiff renders a diff between two parsed JSON documents of the same kind
+func (r *DiffRenderer) renderJSONDiff(oldJSON, newJSON any, indent int) string {
+	if oldMap, isMap := oldJSON.(map[string]any); isMap {
+		return r.renderDiffMap(oldMap, newJSON.(map[string]any), indent)
+	}
+	return r.renderArrayDiff(oldJSON.([]any), newJSON.([]any), indent)
+}
+
 // renderArrayDiff renders a diff between two arrays showing element-level changes
 func (r *DiffRenderer) renderArrayDiff(oldArr, newArr []any, indent int) string {
 	var b strings.Builder
@@ -326,7 +494,7 @@ func (r *DiffRenderer) renderArrayDiff(oldArr, newArr []any, indent int) string
 				oldMap, oldIsMap := oldVal.(map[string]any)
 				newMap, newIsMap := newVal.(map[string]any)
 
-				if oldIsMap && newIsMap {
+				if oldIsMap && newIsMap && !pulumi.IsSecret(oldVal) && !pulumi.IsSecret(newVal) {
 					b.WriteString(OpUpdateStyle.Render(fmt.Sprintf("%s~ [%d]:", indentStr, i)))
 					b.WriteString("\n")
 					b.WriteString(r.renderDiffMap(oldMap, newMap, indent+2))
@@ -383,7 +551,7 @@ func (r *DiffRenderer) renderArrayExpanded(arr []any, style lipgloss.Style, pref
 
 	for i, val := range arr {
 		// Check if value is a nested map
-		if nestedMap, isMap := val.(map[string]any); isMap && len(nestedMap) > 0 {
+		if nestedMap, isMap := val.(map[string]any); isMap && len(nestedMap) > 0 && !pulumi.IsSecret(val) {
 			b.WriteString(style.Render(fmt.Sprintf("%s%s [%d]:", indentStr, prefix, i)))
 			b.WriteString("\n")
 			b.WriteString(r.renderObjectExpanded(nestedMap, style, prefix, indent+1))
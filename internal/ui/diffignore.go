@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rfhold/p5/internal/pulumi"
+)
+
+// DiffIgnoreRules are the property paths de-emphasized in diffs, like
+// metadata.annotations or tags.aws:*, by resource type pattern. In both, *
+// matches any text.
+type DiffIgnoreRules map[string][]string
+
+// PathsFor returns the ignored path patterns for resources of type resourceType
+func (rules DiffIgnoreRules) PathsFor(resourceType string) []string {
+	var paths []string
+	for _, pattern := range slices.Sorted(maps.Keys(rules)) {
+		if wildcardMatch(pattern, resourceType) {
+			paths = append(paths, rules[pattern]...)
+		}
+	}
+	return paths
+}
+
+// WithoutIgnored returns a copy of item without its ignored properties, so
+// changes to them aren't counted
+func (rules DiffIgnoreRules) WithoutIgnored(item ResourceItem) ResourceItem {
+	paths := rules.PathsFor(item.Type)
+	if len(paths) == 0 {
+		return item
+	}
+	item.Inputs, _ = stripIgnored(item.Inputs, paths)
+	item.Outputs, _ = stripIgnored(item.Outputs, paths)
+	item.OldInputs, _ = stripIgnored(item.OldInputs, paths)
+	item.OldOutputs, _ = stripIgnored(item.OldOutputs, paths)
+	return item
+}
+
+// stripIgnored returns a copy of properties without the properties whose path
+// matches one of patterns, and the values of those dropped, by path
+func stripIgnored(properties map[string]any, patterns []string) (map[string]any, map[string]any) {
+	if properties == nil {
+		return nil, nil
+	}
+	ignored := make(map[string]any)
+	return stripIgnoredMap(properties, "", patterns, ignored), ignored
+}
+
+func stripIgnoredMap(m map[string]any, prefix string, patterns []string, ignored map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for key, value := range m {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if slices.ContainsFunc(patterns, func(pattern string) bool { return wildcardMatch(pattern, path) }) {
+			ignored[path] = value
+			continue
+		}
+		out[key] = stripIgnoredValue(value, path, patterns, ignored)
+	}
+	return out
+}
+
+func stripIgnoredValue(value any, path string, patterns []string, ignored map[string]any) any {
+	if pulumi.IsSecret(value) {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		return stripIgnoredMap(v, path, patterns, ignored)
+	case []any:
+		out := make([]any, len(v))
+		for i, child := range v {
+			out[i] = stripIgnoredValue(child, fmt.Sprintf("%s[%d]", path, i), patterns, ignored)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// renderIgnored lists the ignored properties dimmed under their own heading,
+// marked like a diff but without their values
+func (r *DiffRenderer) renderIgnored(oldIgnored, newIgnored map[string]any) string {
+	paths := make([]string, 0, len(oldIgnored)+len(newIgnored))
+	for path := range collectKeys(oldIgnored, newIgnored) {
+		if r.shouldShowKey(topLevelKey(path)) {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return ""
+	}
+	slices.Sort(paths)
+
+	var b strings.Builder
+	b.WriteString(DimStyle.Render("── Ignored ──"))
+	b.WriteString("\n")
+	for _, path := range paths {
+		oldVal, hasOld := oldIgnored[path]
+		newVal, hasNew := newIgnored[path]
+		prefix := " "
+		switch {
+		case !hasOld:
+			prefix = "+"
+		case !hasNew:
+			prefix = "-"
+		case !valuesEqual(oldVal, newVal):
+			prefix = "~"
+		}
+		b.WriteString(DimStyle.Render(prefix + " " + path))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// topLevelKey returns the top-level property of a property path
+func topLevelKey(path string) string {
+	if i := strings.IndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
+
+// wildcardMatch reports whether s matches pattern, where * matches any text,
+// including none
+func wildcardMatch(pattern, s string) bool {
+	star, match := -1, 0
+	p, i := 0, 0
+	for i < len(s) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star, match = p, i
+			p++
+		case p < len(pattern) && pattern[p] == s[i]:
+			p++
+			i++
+		case star >= 0:
+			p = star + 1
+			match++
+			i = match
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// stripIgnoredState drops the ignored properties from the diffed state and returns
+// them by path, before and after. Outputs are only listed when they aren't inputs.
+func (r *DiffRenderer) stripIgnoredState(state diffState, inputKeys map[string]bool) (diffState, map[string]any, map[string]any) {
+	oldIgnored := make(map[string]any)
+	newIgnored := make(map[string]any)
+	addOutputs := func(dst, ignored map[string]any) {
+		for path, value := range ignored {
+			if !inputKeys[topLevelKey(path)] {
+				dst[path] = value
+			}
+		}
+	}
+
+	var ignored map[string]any
+	state.oldInputs, ignored = stripIgnored(state.oldInputs, r.ignored)
+	maps.Copy(oldIgnored, ignored)
+	state.newInputs, ignored = stripIgnored(state.newInputs, r.ignored)
+	maps.Copy(newIgnored, ignored)
+	state.oldOutputs, ignored = stripIgnored(state.oldOutputs, r.ignored)
+	addOutputs(oldIgnored, ignored)
+	state.newOutputs, ignored = stripIgnored(state.newOutputs, r.ignored)
+	addOutputs(newIgnored, ignored)
+	return state, oldIgnored, newIgnored
+}
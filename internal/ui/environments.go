@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+)
+
+// maskedValue replaces environment values while they are hidden
+const maskedValue = "********"
+
+// EnvironmentsPanel is a floating panel showing the Pulumi ESC environments
+// imported by the current stack and their resolved values
+type EnvironmentsPanel struct {
+	PanelBase // Embed common panel functionality
+
+	environments []*pulumi.StackEnvironment
+	loading      bool
+	err          error
+	revealed     bool
+}
+
+// NewEnvironmentsPanel creates a new environments panel component
+func NewEnvironmentsPanel() *EnvironmentsPanel {
+	return &EnvironmentsPanel{}
+}
+
+// SetLoading shows the loading state while environments are resolved
+func (p *EnvironmentsPanel) SetLoading() {
+	p.environments = nil
+	p.err = nil
+	p.loading = true
+	p.ResetScroll()
+}
+
+// SetEnvironments sets the resolved environments
+func (p *EnvironmentsPanel) SetEnvironments(envs []*pulumi.StackEnvironment) {
+	p.environments = envs
+	p.err = nil
+	p.loading = false
+	p.ResetScroll()
+}
+
+// SetError shows an error instead of the environments
+func (p *EnvironmentsPanel) SetError(err error) {
+	p.environments = nil
+	p.err = err
+	p.loading = false
+	p.ResetScroll()
+}
+
+// Environments returns the resolved environments
+func (p *EnvironmentsPanel) Environments() []*pulumi.StackEnvironment {
+	return p.environments
+}
+
+// ToggleReveal shows or hides resolved values
+func (p *EnvironmentsPanel) ToggleReveal() {
+	p.revealed = !p.revealed
+}
+
+// Revealed returns whether resolved values are shown
+func (p *EnvironmentsPanel) Revealed() bool {
+	return p.revealed
+}
+
+// Hide hides the panel and masks values again
+func (p *EnvironmentsPanel) Hide() {
+	p.PanelBase.Hide()
+	p.revealed = false
+}
+
+// View renders the environments panel
+func (p *EnvironmentsPanel) View() string {
+	if !p.Visible() || p.Width() == 0 || p.Height() == 0 {
+		return ""
+	}
+
+	var content string
+	switch {
+	case p.loading:
+		content = DimStyle.Render(i18n.T("Resolving environments..."))
+	case p.err != nil:
+		content = ErrorStyle.Render(i18n.Tf("Error: %v", p.err))
+	case len(p.environments) == 0:
+		content = DimStyle.Render(i18n.T("No ESC environments imported by this stack"))
+	default:
+		content = p.renderContent()
+	}
+
+	result := RenderDetailPanel(DetailPanelContent{
+		Header:       i18n.T("ESC Environments"),
+		Content:      content,
+		Width:        p.Width(),
+		Height:       p.Height(),
+		ScrollOffset: p.ScrollOffset(),
+	})
+
+	if result.NewScrollOffset != p.ScrollOffset() {
+		p.SetScrollOffset(result.NewScrollOffset)
+	}
+
+	return result.Rendered
+}
+
+// renderContent renders each environment's variables and config values
+func (p *EnvironmentsPanel) renderContent() string {
+	var b strings.Builder
+
+	if p.revealed {
+		b.WriteString(DimStyle.Render("s " + i18n.T("hide values")))
+	} else {
+		b.WriteString(DimStyle.Render("s " + i18n.T("show values")))
+	}
+	b.WriteString("\n")
+
+	for _, env := range p.environments {
+		b.WriteString("\n")
+		b.WriteString(LabelStyle.Render(env.Name))
+		b.WriteString("\n")
+
+		b.WriteString(DimStyle.Render(i18n.T("Environment Variables")))
+		b.WriteString("\n")
+		names := env.EnvVarNames()
+		if len(names) == 0 {
+			b.WriteString("  " + DimStyle.Render(i18n.T("none")) + "\n")
+		}
+		for _, name := range names {
+			p.writeEntry(&b, name, env.EnvironmentVariables[name])
+		}
+
+		b.WriteString(DimStyle.Render(i18n.T("Pulumi Config")))
+		b.WriteString("\n")
+		keys := env.ConfigKeys()
+		if len(keys) == 0 {
+			b.WriteString("  " + DimStyle.Render(i18n.T("none")) + "\n")
+		}
+		for _, k := range keys {
+			p.writeEntry(&b, k, formatEnvironmentValue(env.PulumiConfig[k]))
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// writeEntry writes a key/value line, masking the value unless revealed
+func (p *EnvironmentsPanel) writeEntry(b *strings.Builder, key, value string) {
+	if !p.revealed {
+		value = maskedValue
+	}
+	b.WriteString("  ")
+	b.WriteString(ValueStyle.Render(key))
+	b.WriteString(DimStyle.Render(" = "))
+	b.WriteString(value)
+	b.WriteString("\n")
+}
+
+// formatEnvironmentValue renders a config value, encoding non-string values as JSON
+func formatEnvironmentValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
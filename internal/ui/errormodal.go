@@ -8,6 +8,8 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
 )
 
 // ErrorModal is a modal dialog for displaying detailed error information
@@ -118,7 +120,7 @@ func (m *ErrorModal) View() string {
 	summary := summaryStyle.Render(m.summary)
 
 	// Details label
-	detailsLabel := DimStyle.Render("Details:")
+	detailsLabel := DimStyle.Render(i18n.T("Details:"))
 
 	// Viewport with border
 	viewportStyle := lipgloss.NewStyle().
@@ -137,13 +139,13 @@ func (m *ErrorModal) View() string {
 			ValueStyle.Render(strings.Repeat("j", 1)) +
 			DimStyle.Render("/") +
 			ValueStyle.Render(strings.Repeat("k", 1)) +
-			DimStyle.Render(" scroll ") +
+			DimStyle.Render(" "+i18n.T("scroll")+" ") +
 			ValueStyle.Render(strconv.Itoa(percent)) +
 			DimStyle.Render("%]")
 	}
 
 	// Footer hints
-	footer := DimStyle.Render("\nenter/esc dismiss  j/k scroll  g/G top/bottom")
+	footer := DimStyle.Render("\n" + i18n.T("enter/esc dismiss  j/k scroll  g/G top/bottom"))
 
 	// Combine all parts
 	content := lipgloss.JoinVertical(lipgloss.Left,
@@ -2,15 +2,27 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// fuzzyFilters is whether filters created from now on match fuzzily, from p5.toml
+var fuzzyFilters bool
+
+// SetFuzzyFilters sets whether filters created from now on match their characters in
+// order rather than as a substring, ranking the best matches first. Call it before
+// components are constructed.
+func SetFuzzyFilters(fuzzy bool) {
+	fuzzyFilters = fuzzy
+}
+
 // FilterState manages filter state for list components
 type FilterState struct {
 	active bool
+	fuzzy  bool // Match characters in order rather than as a substring
 	input  textinput.Model
 }
 
@@ -22,7 +34,7 @@ func NewFilterState() FilterState {
 	ti.Width = 30
 	ti.PromptStyle = CursorStyle
 	ti.TextStyle = ValueStyle
-	return FilterState{input: ti}
+	return FilterState{input: ti, fuzzy: fuzzyFilters}
 }
 
 // Active returns whether filter input mode is active (user is typing)
@@ -45,6 +57,12 @@ func (f *FilterState) Text() string {
 	return f.input.Value()
 }
 
+// SetFuzzy sets whether the filter matches its characters in order anywhere in the
+// text, e.g. "pfw" matching "platform/firewall", rather than as a substring
+func (f *FilterState) SetFuzzy(fuzzy bool) {
+	f.fuzzy = fuzzy
+}
+
 // Activate enters filter mode, resetting any previous filter text
 func (f *FilterState) Activate() {
 	f.active = true
@@ -90,24 +108,58 @@ func (f *FilterState) Update(msg tea.KeyMsg) (tea.Cmd, bool) {
 
 // Matches returns true if the given text matches the filter (case-insensitive)
 func (f *FilterState) Matches(text string) bool {
-	if f.input.Value() == "" {
-		return true
-	}
-	return strings.Contains(strings.ToLower(text), strings.ToLower(f.input.Value()))
+	return f.MatchesAny(text)
 }
 
 // MatchesAny returns true if any of the given texts match the filter (case-insensitive)
 func (f *FilterState) MatchesAny(texts ...string) bool {
+	_, ok := f.Score(texts...)
+	return ok
+}
+
+// Score returns how well the best matching of texts matches the filter, and whether
+// any matches. Substring matches all score 0, as only fuzzy matches are ranked.
+func (f *FilterState) Score(texts ...string) (score int, ok bool) {
 	if f.input.Value() == "" {
-		return true
+		return 0, true
 	}
 	filter := strings.ToLower(f.input.Value())
 	for _, text := range texts {
-		if strings.Contains(strings.ToLower(text), filter) {
-			return true
+		if !f.fuzzy {
+			if strings.Contains(strings.ToLower(text), filter) {
+				return 0, true
+			}
+			continue
 		}
+		if s, matched := fuzzyScore(filter, text); matched && (!ok || s > score) {
+			score, ok = s, true
+		}
+	}
+	return score, ok
+}
+
+// Fuzzy returns whether the filter matches fuzzily and ranks the best matches first
+func (f *FilterState) Fuzzy() bool {
+	return f.fuzzy
+}
+
+// rankMatches returns the indices of the n items whose texts match the filter. When
+// the filter is fuzzy, the best matches come first; otherwise list order is kept.
+func (f *FilterState) rankMatches(n int, texts func(i int) []string) []int {
+	matches := make([]int, 0)
+	scores := make(map[int]int)
+	for i := range n {
+		if score, ok := f.Score(texts(i)...); ok {
+			matches = append(matches, i)
+			scores[i] = score
+		}
+	}
+	if f.fuzzy {
+		slices.SortStableFunc(matches, func(a, b int) int {
+			return scores[b] - scores[a]
+		})
 	}
-	return false
+	return matches
 }
 
 // View returns the filter input view
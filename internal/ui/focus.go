@@ -6,15 +6,45 @@ import "slices"
 type FocusLayer int
 
 const (
-	FocusMain              FocusLayer = iota // Normal app interaction (resource list, history list)
-	FocusDetailsPanel                        // Details panel is open and capturing scroll keys
-	FocusHelp                                // Help dialog open
-	FocusStackSelector                       // Stack selector modal
-	FocusWorkspaceSelector                   // Workspace selector modal
-	FocusImportModal                         // Import modal
-	FocusStackInitModal                      // Stack creation modal
-	FocusConfirmModal                        // Confirmation dialog
-	FocusErrorModal                          // Error dialog (highest priority)
+	FocusMain               FocusLayer = iota // Normal app interaction (resource list, history list)
+	FocusDetailsPanel                         // Details panel is open and capturing scroll keys
+	FocusHistoryDiff                          // History version diff panel
+	FocusEnvironments                         // ESC environments panel
+	FocusWarnings                             // Preview warnings panel
+	FocusTimings                              // Slowest resources of the last operation
+	FocusDashboard                            // Multi-stack dashboard
+	FocusLogs                                 // Debug log viewer
+	FocusCode                                 // Code generated for imported resources
+	FocusAbout                                // Diagnostics about p5, Pulumi and the workspace
+	FocusHelp                                 // Help dialog open
+	FocusStackSelector                        // Stack selector modal
+	FocusWorkspaceSelector                    // Workspace selector modal
+	FocusWorkflowSelector                     // Workflow selector modal
+	FocusCloudStackBrowser                    // Stacks of every project in the organization
+	FocusTargetSetSelector                    // Saved target sets selector
+	FocusSecretSelector                       // Secret of a resource to reveal
+	FocusValueSelector                        // Large value of a resource to view
+	FocusValuePager                           // Large value shown in full
+	FocusImportModal                          // Import modal
+	FocusBulkImportModal                      // Bulk import modal
+	FocusStateRepairModal                     // State repair modal
+	FocusPluginIndexModal                     // Plugin index modal
+	FocusPluginStatusModal                    // Plugin credential status modal
+	FocusNoteModal                            // Resource note modal
+	FocusTagsModal                            // Stack tags modal
+	FocusHistoryFilterModal                   // History kind, result, user and date filter
+	FocusStateFileModal                       // State export/import file prompt
+	FocusSaveFileModal                        // Save generated content to a file prompt
+	FocusTargetSetModal                       // Name prompt to save the flags as a target set
+	FocusGotoModal                            // Prompt for the resource to move the cursor to
+	FocusConfigCopyModal                      // Copy config from another stack prompt
+	FocusUpdateMessageModal                   // Update message prompt before up or destroy
+	FocusGitGuardModal                        // Warning before up from a dirty or unexpected checkout
+	FocusStackInitModal                       // Stack creation modal
+	FocusNewProjectModal                      // Project from template creation modal
+	FocusLoginModal                           // Backend login prompt
+	FocusConfirmModal                         // Confirmation dialog
+	FocusErrorModal                           // Error dialog (highest priority)
 )
 
 // String returns a human-readable name for the focus layer
@@ -24,16 +54,76 @@ func (f FocusLayer) String() string {
 		return "Main"
 	case FocusDetailsPanel:
 		return "DetailsPanel"
+	case FocusHistoryDiff:
+		return "HistoryDiff"
+	case FocusEnvironments:
+		return "Environments"
+	case FocusWarnings:
+		return "Warnings"
+	case FocusTimings:
+		return "Timings"
+	case FocusDashboard:
+		return "Dashboard"
+	case FocusLogs:
+		return "Logs"
+	case FocusCode:
+		return "Code"
+	case FocusAbout:
+		return "About"
 	case FocusHelp:
 		return "Help"
 	case FocusStackSelector:
 		return "StackSelector"
 	case FocusWorkspaceSelector:
 		return "WorkspaceSelector"
+	case FocusWorkflowSelector:
+		return "WorkflowSelector"
+	case FocusCloudStackBrowser:
+		return "CloudStackBrowser"
+	case FocusTargetSetSelector:
+		return "TargetSetSelector"
+	case FocusSecretSelector:
+		return "SecretSelector"
+	case FocusValueSelector:
+		return "ValueSelector"
+	case FocusValuePager:
+		return "ValuePager"
 	case FocusImportModal:
 		return "ImportModal"
+	case FocusBulkImportModal:
+		return "BulkImportModal"
+	case FocusStateRepairModal:
+		return "StateRepairModal"
+	case FocusPluginIndexModal:
+		return "PluginIndexModal"
+	case FocusPluginStatusModal:
+		return "PluginStatusModal"
+	case FocusNoteModal:
+		return "NoteModal"
+	case FocusTagsModal:
+		return "TagsModal"
+	case FocusHistoryFilterModal:
+		return "HistoryFilterModal"
+	case FocusStateFileModal:
+		return "StateFileModal"
+	case FocusSaveFileModal:
+		return "SaveFileModal"
+	case FocusTargetSetModal:
+		return "TargetSetModal"
+	case FocusGotoModal:
+		return "GotoModal"
+	case FocusConfigCopyModal:
+		return "ConfigCopyModal"
+	case FocusUpdateMessageModal:
+		return "UpdateMessageModal"
+	case FocusGitGuardModal:
+		return "GitGuardModal"
 	case FocusStackInitModal:
 		return "StackInitModal"
+	case FocusNewProjectModal:
+		return "NewProjectModal"
+	case FocusLoginModal:
+		return "LoginModal"
 	case FocusConfirmModal:
 		return "ConfirmModal"
 	case FocusErrorModal:
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/pulumi"
 )
 
 // Pulumi uses specific sentinel UUIDs to represent unknown/computed values.
@@ -33,6 +35,10 @@ func formatDiffValue(value any, style lipgloss.Style, maxWidth, indent int) stri
 		return style.Render("null")
 	}
 
+	if pulumi.IsSecret(value) {
+		return style.Render("[secret]")
+	}
+
 	switch v := value.(type) {
 	case map[string]any:
 		if len(v) == 0 {
@@ -66,6 +72,9 @@ func formatDiffValue(value any, style lipgloss.Style, maxWidth, indent int) stri
 		}
 		// Truncate long strings
 		maxLen := max(maxWidth-(indent*2)-MinFormattedStringLength, MinFormattedStringLength)
+		if isLargeValue(v) {
+			return formatLargeValue(v, style, maxLen)
+		}
 		if len(v) > maxLen {
 			return style.Render(fmt.Sprintf("%q...", v[:maxLen-3]))
 		}
@@ -91,6 +100,9 @@ func formatDiffValue(value any, style lipgloss.Style, maxWidth, indent int) stri
 
 // formatArrayItem formats a single array item for inline display
 func formatArrayItem(item any) string {
+	if pulumi.IsSecret(item) {
+		return "[secret]"
+	}
 	switch v := item.(type) {
 	case map[string]any:
 		if len(v) == 0 {
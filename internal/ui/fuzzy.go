@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"unicode"
+)
+
+// Fuzzy match scoring, loosely following fzf: every matched character scores,
+// runs of consecutive characters and characters starting a word score more, and
+// characters skipped between matches cost a little
+const (
+	fuzzyMatchScore       = 16
+	fuzzyConsecutiveBonus = 24
+	fuzzyBoundaryBonus    = 20
+	fuzzyGapStartPenalty  = 3
+	fuzzyGapPenalty       = 1
+)
+
+// fuzzyScore returns how well pattern matches text as a case-insensitive
+// subsequence. ok is false when the characters of pattern don't all appear in
+// text in order. An empty pattern matches everything with a score of 0.
+func fuzzyScore(pattern, text string) (score int, ok bool) {
+	p := []rune(toLower(pattern))
+	if len(p) == 0 {
+		return 0, true
+	}
+	original := []rune(text)
+	t := []rune(toLower(text))
+
+	// Try each occurrence of the first character, as a later start can match
+	// the rest more tightly
+	best, found := 0, false
+	for start := range t {
+		if t[start] != p[0] {
+			continue
+		}
+		if s, matched := fuzzyScoreFrom(p, t, original, start); matched && (!found || s > best) {
+			best, found = s, true
+		}
+	}
+	return best, found
+}
+
+// fuzzyScoreFrom greedily matches p in t from start, where t[start] matches p[0]
+func fuzzyScoreFrom(p, t, original []rune, start int) (int, bool) {
+	score, prev := 0, -1
+	j := 0
+	for i := start; i < len(t) && j < len(p); i++ {
+		if t[i] != p[j] {
+			continue
+		}
+		score += fuzzyMatchScore
+		if isWordStart(original, i) {
+			score += fuzzyBoundaryBonus
+		}
+		switch {
+		case prev >= 0 && i == prev+1:
+			score += fuzzyConsecutiveBonus
+		case prev >= 0:
+			score -= fuzzyGapStartPenalty + fuzzyGapPenalty*(i-prev-1)
+		}
+		prev = i
+		j++
+	}
+	return score, j == len(p)
+}
+
+// isWordStart returns whether the character at i starts a word: the first
+// character, one after a separator, or an uppercase letter after a lowercase one
+func isWordStart(text []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := text[i-1], text[i]
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
+
+// toLower lowercases s rune by rune, keeping its length in runes so match
+// positions line up with the original text
+func toLower(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return string(runes)
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// GitGuardModal wraps StepModal to warn before deploying a guarded stack from a
+// dirty or unexpected git checkout, asking for the stack name to be typed to continue
+type GitGuardModal struct {
+	*StepModal
+
+	stackName string
+}
+
+// NewGitGuardModal creates a new git guard modal
+func NewGitGuardModal() *GitGuardModal {
+	return &GitGuardModal{StepModal: NewStepModal(i18n.T("Check Git Checkout"))}
+}
+
+// Show warns about the problems with the checkout up on stackName would deploy from
+func (m *GitGuardModal) Show(stackName string, git *GitInfo, problems []string) {
+	m.stackName = stackName
+	m.SetSteps([]StepModalStep{{
+		Title: i18n.T("Deploy from this checkout?"),
+		InfoLines: []InfoLine{
+			{Label: i18n.T("Stack"), Value: stackName},
+			{Label: i18n.T("Git"), Value: git.Ref()},
+		},
+		InputLabel:       i18n.Tf("Type %s to deploy anyway", stackName),
+		InputPlaceholder: i18n.T("Enter stack name..."),
+		Warning:          strings.Join(problems, "\n! "),
+		Validate:         m.validateStackName,
+	}})
+	m.StepModal.Show()
+}
+
+// validateStackName checks the stack name was typed exactly
+func (m *GitGuardModal) validateStackName(value string) error {
+	if value != m.stackName {
+		return errors.New(i18n.Tf("Type %s exactly to continue", m.stackName))
+	}
+	return nil
+}
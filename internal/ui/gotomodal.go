@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// GotoModal wraps StepModal to prompt for the resource to move the cursor to
+type GotoModal struct {
+	*StepModal
+}
+
+// NewGotoModal creates a new goto modal
+func NewGotoModal() *GotoModal {
+	return &GotoModal{StepModal: NewStepModal(i18n.T("Go to Resource"))}
+}
+
+// ShowPrompt prompts for a name or URN, which may be partial
+func (m *GotoModal) ShowPrompt() {
+	m.SetSteps([]StepModalStep{{
+		Title:            i18n.T("Jump to the resource best matching a name or URN"),
+		InputLabel:       i18n.T("Resource"),
+		InputPlaceholder: i18n.T("e.g. db or rds/instance"),
+		Validate: func(query string) error {
+			if strings.TrimSpace(query) == "" {
+				return errors.New(i18n.T("a name or URN is required"))
+			}
+			return nil
+		},
+	}})
+	m.StepModal.Show()
+}
+
+// Query returns the entered name or URN
+func (m *GotoModal) Query() string {
+	return strings.TrimSpace(m.GetResult(0))
+}
@@ -1,11 +1,15 @@
 package ui
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
 )
 
 // ViewMode represents the current view
@@ -38,19 +42,58 @@ type HeaderData struct {
 	ProgramName string
 	StackName   string
 	Runtime     string
+	Source      string // Exported state being browsed, shown instead of the runtime
+}
+
+// DriftSummary counts what drift detection found
+type DriftSummary struct {
+	Resources  int // Resources changed or deleted outside of Pulumi
+	Properties int // Drifted properties across the changed resources
+	Deleted    int // Resources that no longer exist
+}
+
+// QuickCheck is the outcome of a summary-only up preview run from stack view
+type QuickCheck struct {
+	Running bool
+	Failed  bool
+	Summary ResourceSummary // Changes found so far
+}
+
+// BadgeLevel is how prominently a header badge is styled
+type BadgeLevel int
+
+const (
+	BadgeInfo BadgeLevel = iota
+	BadgeWarning
+	BadgeDanger
+)
+
+// HeaderBadge is a short status contributed by a plugin, such as the cloud account in use
+type HeaderBadge struct {
+	Text  string
+	Level BadgeLevel
 }
 
 // Header renders the top header bar
 type Header struct {
-	spinner   spinner.Model
-	data      *HeaderData
-	summary   *ResourceSummary
-	viewMode  ViewMode
-	operation OperationType
-	state     HeaderState
-	err       error
-	loading   bool
-	width     int
+	spinner         spinner.Model
+	data            *HeaderData
+	summary         *ResourceSummary
+	drift           *DriftSummary // Set while showing drift detection results
+	quickCheck      *QuickCheck   // Last quick check of the stack, nil when none ran
+	lastResult      string        // Result of the stack's most recent update, "" when unknown
+	outdated        bool          // Stack was updated elsewhere since it was loaded
+	providerSkew    int           // Providers in state at versions diverging from the installed ones
+	refresh         bool          // Up refreshes the state first
+	continueOnError bool          // Up and destroy carry on past failed resources
+	git             *GitInfo      // Git checkout of the workspace, nil outside of git
+	badges          []HeaderBadge
+	viewMode        ViewMode
+	operation       OperationType
+	state           HeaderState
+	err             error
+	loading         bool
+	width           int
 }
 
 // HeaderState represents the current state of the header
@@ -61,6 +104,7 @@ const (
 	HeaderRunning
 	HeaderDone
 	HeaderError
+	HeaderCancelling // Cancel requested, waiting for the operation to stop
 )
 
 // NewHeader creates a new header component
@@ -89,6 +133,13 @@ func (h *Header) SetError(err error) {
 	h.state = HeaderError
 }
 
+// ClearError clears the error state, loading again
+func (h *Header) ClearError() {
+	h.err = nil
+	h.loading = true
+	h.state = HeaderLoading
+}
+
 // SetWidth sets the header width
 func (h *Header) SetWidth(width int) {
 	h.width = width
@@ -104,6 +155,55 @@ func (h *Header) SetOperation(op OperationType) {
 	h.operation = op
 }
 
+// SetDrift shows drift counts instead of operation counts, or clears them when nil
+func (h *Header) SetDrift(drift *DriftSummary) {
+	h.drift = drift
+}
+
+// SetQuickCheck sets the quick check shown in stack view, nil to hide it
+func (h *Header) SetQuickCheck(check *QuickCheck) {
+	h.quickCheck = check
+}
+
+// SetLastResult sets the result of the stack's most recent update, shown as a
+// badge next to the stack name
+func (h *Header) SetLastResult(result string) {
+	h.lastResult = result
+}
+
+// SetRefresh shows or hides the flag for up refreshing the state first
+func (h *Header) SetRefresh(refresh bool) {
+	h.refresh = refresh
+}
+
+// SetContinueOnError shows or hides the flag for up and destroy carrying on past
+// failed resources
+func (h *Header) SetContinueOnError(continueOnError bool) {
+	h.continueOnError = continueOnError
+}
+
+// SetGit sets the git checkout shown after the runtime, or hides it when nil
+func (h *Header) SetGit(git *GitInfo) {
+	h.git = git
+}
+
+// SetOutdated shows or hides the badge for a stack updated elsewhere since it was loaded
+func (h *Header) SetOutdated(outdated bool) {
+	h.outdated = outdated
+}
+
+// SetProviderMismatches shows or hides the badge for providers whose version in
+// state diverges from the installed plugins or the program's dependencies
+func (h *Header) SetProviderMismatches(count int) {
+	h.providerSkew = count
+}
+
+// SetBadges sets the plugin badges shown after the runtime. Badges that do not fit
+// are left out, keeping the most severe.
+func (h *Header) SetBadges(badges []HeaderBadge) {
+	h.badges = badges
+}
+
 // SetSummary updates the resource summary in the header
 func (h *Header) SetSummary(summary ResourceSummary, state HeaderState) {
 	h.summary = &summary
@@ -134,7 +234,8 @@ func (h *Header) SetPreviewSummary(summary PreviewSummary, state PreviewState) {
 
 // IsLoading returns whether the header is in loading state
 func (h *Header) IsLoading() bool {
-	return h.loading || h.state == HeaderLoading || h.state == HeaderRunning
+	return h.loading || h.state == HeaderLoading || h.state == HeaderRunning || h.state == HeaderCancelling ||
+		(h.quickCheck != nil && h.quickCheck.Running)
 }
 
 // Spinner returns the spinner model for updates
@@ -156,19 +257,27 @@ func (h *Header) View() string {
 	case h.loading:
 		topRow = h.spinner.View() + " Loading..."
 	case h.err != nil:
-		topRow = ErrorStyle.Render(fmt.Sprintf("Error: %v", h.err))
+		topRow = ErrorStyle.Render(i18n.Tf("Error: %v", h.err))
 	case h.data != nil:
 		program := fmt.Sprintf("%s %s",
-			LabelStyle.Render("Program:"),
+			LabelStyle.Render(i18n.T("Program:")),
 			ValueStyle.Render(h.data.ProgramName))
 
 		stack := fmt.Sprintf("%s %s",
-			LabelStyle.Render("Stack:"),
+			LabelStyle.Render(i18n.T("Stack:")),
 			ValueStyle.Render(orDefault(h.data.StackName, "(none)")))
+		if badge := RenderResultBadge(h.lastResult); badge != "" {
+			stack += " " + badge
+		}
 
 		runtime := fmt.Sprintf("%s %s",
-			LabelStyle.Render("Runtime:"),
+			LabelStyle.Render(i18n.T("Runtime:")),
 			ValueStyle.Render(orDefault(h.data.Runtime, "?")))
+		if h.data.Source != "" {
+			runtime = fmt.Sprintf("%s %s",
+				LabelStyle.Render(i18n.T("State:")),
+				ValueStyle.Render(h.data.Source))
+		}
 
 		topRow = lipgloss.JoinHorizontal(lipgloss.Center,
 			program,
@@ -177,6 +286,10 @@ func (h *Header) View() string {
 			DimStyle.Render("  │  "),
 			runtime,
 		)
+		if h.git != nil && h.data.Source == "" {
+			topRow += DimStyle.Render("  │  ") + h.renderGit()
+		}
+		topRow += h.renderBadges(h.width - 4 - lipgloss.Width(topRow))
 	}
 
 	// Render view mode and summary row
@@ -200,14 +313,19 @@ func (h *Header) renderSummaryRow() string {
 	if h.viewMode != ViewStack && h.viewMode != ViewHistory {
 		viewLabel = fmt.Sprintf("%s %s", h.viewMode.String(), h.operation.String())
 	}
+	if h.drift != nil && h.viewMode == ViewPreview {
+		viewLabel = i18n.T("Drift")
+	}
 
 	// Status indicator
 	switch h.state {
 	case HeaderLoading:
-		parts = append(parts, fmt.Sprintf("%s %s", h.spinner.View(), DimStyle.Render("Loading...")))
+		parts = append(parts, fmt.Sprintf("%s %s", h.spinner.View(), DimStyle.Render(i18n.T("Loading..."))))
 		return strings.Join(parts, "  ")
 	case HeaderRunning:
 		parts = append(parts, fmt.Sprintf("%s %s", h.spinner.View(), ViewLabelStyle.Render(viewLabel)))
+	case HeaderCancelling:
+		parts = append(parts, fmt.Sprintf("%s %s", h.spinner.View(), WarningStyle.Render(i18n.Tf("%s cancelling...", viewLabel))))
 	case HeaderDone:
 		parts = append(parts, ViewLabelStyle.Render(viewLabel))
 	case HeaderError:
@@ -221,17 +339,43 @@ func (h *Header) renderSummaryRow() string {
 	}
 
 	// Summary counts
-	if h.summary != nil {
+	if h.drift != nil && h.viewMode == ViewPreview {
+		if driftPart := h.renderDriftCounts(); driftPart != "" {
+			parts = append(parts, driftPart)
+		}
+	} else if h.summary != nil {
 		if summaryPart := h.renderSummaryCounts(); summaryPart != "" {
 			parts = append(parts, summaryPart)
 		}
 	}
 
+	if h.quickCheck != nil && h.viewMode == ViewStack {
+		parts = append(parts, h.renderQuickCheck())
+	}
+
 	// Add "done" indicator for completed preview/execute operations
 	if h.state == HeaderDone && (h.viewMode == ViewPreview || h.viewMode == ViewExecute) {
 		parts = append(parts, DimStyle.Render("done"))
 	}
 
+	// Up refreshes the state first; shown before an up and during one
+	if h.refresh && (h.viewMode == ViewStack || (h.viewMode != ViewHistory && h.operation == OperationUp)) {
+		parts = append(parts, FlagReplaceStyle.Render(i18n.T("[refresh]")))
+	}
+	if h.continueOnError && (h.viewMode == ViewStack ||
+		(h.viewMode != ViewHistory && (h.operation == OperationUp || h.operation == OperationDestroy))) {
+		parts = append(parts, FlagReplaceStyle.Render(i18n.T("[continue on error]")))
+	}
+
+	if h.outdated && h.viewMode == ViewStack {
+		parts = append(parts, WarningStyle.Render(i18n.T("[outdated]"))+" "+
+			DimStyle.Render(i18n.Tf("%s reload", Keys.ReloadStack.Help().Key)))
+	}
+	if h.providerSkew > 0 && h.viewMode == ViewStack {
+		parts = append(parts, WarningStyle.Render(i18n.T("[provider versions]"))+" "+
+			DimStyle.Render(i18n.Tf("%s details", Keys.ViewAbout.Help().Key)))
+	}
+
 	return strings.Join(parts, "  ")
 }
 
@@ -244,33 +388,112 @@ func (h *Header) renderSummaryCounts() string {
 	case h.viewMode == ViewHistory:
 		return DimStyle.Render(fmt.Sprintf("%d updates", h.summary.Total))
 	case total == 0 && h.state == HeaderDone:
-		return DimStyle.Render("No changes")
+		return DimStyle.Render(i18n.T("No changes"))
 	case total > 0:
-		return h.renderOperationCounts()
+		return renderOperationCounts(*h.summary)
 	}
 	return ""
 }
 
-func (h *Header) renderOperationCounts() string {
+// renderQuickCheck renders the pending changes the last quick check found
+func (h *Header) renderQuickCheck() string {
+	switch {
+	case h.quickCheck.Running:
+		return fmt.Sprintf("%s %s", h.spinner.View(), DimStyle.Render(i18n.T("checking for changes...")))
+	case h.quickCheck.Failed:
+		return ErrorStyle.Render(i18n.T("[check failed]"))
+	}
+	if counts := renderOperationCounts(h.quickCheck.Summary); counts != "" {
+		return LabelStyle.Render(i18n.T("pending:")) + " " + counts
+	}
+	return StatusSuccessStyle.Render(i18n.T("✓ up to date"))
+}
+
+func (h *Header) renderDriftCounts() string {
+	if h.drift.Resources == 0 {
+		if h.state == HeaderDone {
+			return DimStyle.Render(i18n.T("No drift"))
+		}
+		return ""
+	}
+	countParts := []string{OpUpdateStyle.Render(i18n.Tf("%d drifted", h.drift.Resources))}
+	if h.drift.Properties > 0 {
+		countParts = append(countParts, DimStyle.Render(i18n.Tf("%d properties", h.drift.Properties)))
+	}
+	if h.drift.Deleted > 0 {
+		countParts = append(countParts, OpDeleteStyle.Render(i18n.Tf("%d deleted", h.drift.Deleted)))
+	}
+	return strings.Join(countParts, " ")
+}
+
+// renderOperationCounts renders the non-zero counts of a summary, like "+2 ~1"
+func renderOperationCounts(summary ResourceSummary) string {
 	var countParts []string
-	if h.summary.Create > 0 {
-		countParts = append(countParts, OpCreateStyle.Render(fmt.Sprintf("+%d", h.summary.Create)))
+	if summary.Create > 0 {
+		countParts = append(countParts, OpCreateStyle.Render(fmt.Sprintf("+%d", summary.Create)))
 	}
-	if h.summary.Update > 0 {
-		countParts = append(countParts, OpUpdateStyle.Render(fmt.Sprintf("~%d", h.summary.Update)))
+	if summary.Update > 0 {
+		countParts = append(countParts, OpUpdateStyle.Render(fmt.Sprintf("~%d", summary.Update)))
 	}
-	if h.summary.Replace > 0 {
-		countParts = append(countParts, OpReplaceStyle.Render(fmt.Sprintf("±%d", h.summary.Replace)))
+	if summary.Replace > 0 {
+		countParts = append(countParts, OpReplaceStyle.Render(fmt.Sprintf("±%d", summary.Replace)))
 	}
-	if h.summary.Delete > 0 {
-		countParts = append(countParts, OpDeleteStyle.Render(fmt.Sprintf("-%d", h.summary.Delete)))
+	if summary.Delete > 0 {
+		countParts = append(countParts, OpDeleteStyle.Render(fmt.Sprintf("-%d", summary.Delete)))
 	}
-	if h.summary.Refresh > 0 {
-		countParts = append(countParts, OpRefreshStyle.Render(fmt.Sprintf("↻%d", h.summary.Refresh)))
+	if summary.Refresh > 0 {
+		countParts = append(countParts, OpRefreshStyle.Render(fmt.Sprintf("↻%d", summary.Refresh)))
 	}
 	return strings.Join(countParts, " ")
 }
 
+// renderBadges renders the plugin badges that fit in width, the most severe first
+// renderGit renders the branch and commit of the checkout, and whether it is dirty
+func (h *Header) renderGit() string {
+	branch := h.git.Branch
+	if branch == "" {
+		branch = i18n.T("(detached)")
+	}
+	git := LabelStyle.Render(i18n.T("Git:")) + " " + ValueStyle.Render(branch)
+	if h.git.Commit != "" {
+		git += DimStyle.Render("@" + h.git.Commit)
+	}
+	if h.git.Dirty {
+		git += " " + WarningStyle.Render(i18n.T("dirty"))
+	}
+	return git
+}
+
+func (h *Header) renderBadges(width int) string {
+	badges := slices.Clone(h.badges)
+	slices.SortStableFunc(badges, func(a, b HeaderBadge) int {
+		return cmp.Compare(b.Level, a.Level)
+	})
+
+	var out strings.Builder
+	separator := DimStyle.Render("  │  ")
+	for _, badge := range badges {
+		part := separator + renderBadge(badge)
+		if lipgloss.Width(part) > width {
+			continue
+		}
+		out.WriteString(part)
+		width -= lipgloss.Width(part)
+	}
+	return out.String()
+}
+
+func renderBadge(badge HeaderBadge) string {
+	switch badge.Level {
+	case BadgeWarning:
+		return WarningStyle.Render(badge.Text)
+	case BadgeDanger:
+		return ErrorStyle.Bold(true).Render(badge.Text)
+	default:
+		return ValueStyle.Render(badge.Text)
+	}
+}
+
 func orDefault(s, def string) string {
 	if s == "" {
 		return def
@@ -4,15 +4,27 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
 )
 
 // HelpItem represents a single help entry
 type HelpItem struct {
-	Key  string
-	Desc string
+	Key     string
+	Binding *key.Binding // Shows the binding's effective keys instead of Key when set
+	Desc    string
+}
+
+// keyLabel returns the keys shown for the item
+func (i HelpItem) keyLabel() string {
+	if i.Binding != nil {
+		return i.Binding.Help().Key
+	}
+	return i.Key
 }
 
 // HelpDialog renders a help overlay
@@ -30,50 +42,105 @@ func NewHelpDialog() *HelpDialog {
 		items: []HelpItem{
 			// Navigation
 			{Key: "", Desc: "Navigation"},
-			{Key: "↑/k", Desc: "Move up"},
-			{Key: "↓/j", Desc: "Move down"},
-			{Key: "pgup", Desc: "Page up"},
-			{Key: "pgdn", Desc: "Page down"},
-			{Key: "g", Desc: "Go to top"},
-			{Key: "G", Desc: "Go to bottom"},
-			{Key: "/", Desc: "Filter list"},
+			{Binding: &Keys.Up, Desc: "Move up"},
+			{Binding: &Keys.Down, Desc: "Move down"},
+			{Binding: &Keys.PageUp, Desc: "Page up"},
+			{Binding: &Keys.PageDown, Desc: "Page down"},
+			{Binding: &Keys.Home, Desc: "Go to top"},
+			{Binding: &Keys.End, Desc: "Go to bottom"},
+			{Binding: &Keys.GotoResource, Desc: "Go to resource by name or URN"},
+			{Binding: &Keys.NextChange, Desc: "Next resource with changes"},
+			{Binding: &Keys.PrevChange, Desc: "Previous resource with changes"},
+			{Binding: &Keys.Filter, Desc: "Filter list"},
+			{Binding: &Keys.CycleGrouping, Desc: "Group by type or provider"},
+			{Binding: &Keys.ToggleCollapse, Desc: "Collapse or expand group or children"},
+			{Binding: &Keys.ToggleCollapseAll, Desc: "Collapse or expand all"},
+			{Binding: &Keys.PinResource, Desc: "Pin resource to the top"},
 			{Key: "", Desc: ""},
 
 			// Selection
 			{Key: "", Desc: "Selection"},
-			{Key: "v", Desc: "Visual select mode"},
-			{Key: "space", Desc: "Toggle select"},
-			{Key: "T", Desc: "Toggle target flag"},
-			{Key: "R", Desc: "Toggle replace flag"},
-			{Key: "E", Desc: "Toggle exclude flag"},
-			{Key: "c", Desc: "Clear flags on selection"},
-			{Key: "C", Desc: "Clear all flags"},
-			{Key: "esc", Desc: "Cancel selection / back"},
+			{Binding: &Keys.VisualMode, Desc: "Visual select mode"},
+			{Binding: &Keys.ToggleSelect, Desc: "Toggle select"},
+			{Binding: &Keys.ToggleTarget, Desc: "Toggle target flag"},
+			{Binding: &Keys.ToggleReplace, Desc: "Toggle replace flag"},
+			{Binding: &Keys.ToggleExclude, Desc: "Toggle exclude flag"},
+			{Binding: &Keys.ClearFlags, Desc: "Clear flags on selection"},
+			{Binding: &Keys.ClearAllFlags, Desc: "Clear all flags"},
+			{Binding: &Keys.ClearSaved, Desc: "Clear saved flags"},
+			{Binding: &Keys.UndoFlags, Desc: "Undo flag or selection change"},
+			{Binding: &Keys.RedoFlags, Desc: "Redo flag or selection change"},
+			{Binding: &Keys.TargetSets, Desc: "Save or apply target sets"},
+			{Binding: &Keys.Escape, Desc: "Cancel selection / back"},
 			{Key: "", Desc: ""},
 
 			// Operations
 			{Key: "", Desc: "Operations"},
-			{Key: "u", Desc: "Preview up"},
-			{Key: "r", Desc: "Preview refresh"},
-			{Key: "d", Desc: "Preview destroy"},
-			{Key: "ctrl+u", Desc: "Execute up"},
-			{Key: "ctrl+r", Desc: "Execute refresh"},
-			{Key: "ctrl+d", Desc: "Execute destroy"},
-			{Key: "I", Desc: "Import resource (in preview)"},
-			{Key: "x", Desc: "Delete from state"},
-			{Key: "o", Desc: "Open resource (external tool)"},
-			{Key: "y", Desc: "Copy resource JSON"},
-			{Key: "Y", Desc: "Copy all resources JSON"},
+			{Binding: &Keys.PreviewUp, Desc: "Preview up"},
+			{Binding: &Keys.PreviewRefresh, Desc: "Preview refresh"},
+			{Binding: &Keys.PreviewDestroy, Desc: "Preview destroy"},
+			{Binding: &Keys.QuickCheck, Desc: "Quick check for pending changes"},
+			{Binding: &Keys.ExecuteUp, Desc: "Execute up"},
+			{Binding: &Keys.ExecuteRefresh, Desc: "Execute refresh"},
+			{Binding: &Keys.ExecuteDestroy, Desc: "Execute destroy"},
+			{Binding: &Keys.QueueRefreshUp, Desc: "Queue refresh → preview → up"},
+			{Binding: &Keys.RunWorkflow, Desc: "Run workflow from p5.toml"},
+			{Binding: &Keys.SavePlan, Desc: "Preview up and save plan"},
+			{Binding: &Keys.ToggleRefresh, Desc: "Toggle refresh with up"},
+			{Binding: &Keys.ToggleContinueOnError, Desc: "Toggle continue on error"},
+			{Binding: &Keys.DetectDrift, Desc: "Detect drift"},
+			{Binding: &Keys.AcceptDrift, Desc: "Accept drift (in drift view)"},
+			{Binding: &Keys.RevertDrift, Desc: "Revert drift (in drift view)"},
+			{Binding: &Keys.Import, Desc: "Import resource (in preview)"},
+			{Binding: &Keys.BulkImport, Desc: "Bulk import (in preview)"},
+			{Binding: &Keys.DeleteFromState, Desc: "Delete from state"},
+			{Binding: &Keys.Protect, Desc: "Protect selected"},
+			{Binding: &Keys.Unprotect, Desc: "Unprotect selected"},
+			{Binding: &Keys.RepairState, Desc: "Repair state issues"},
+			{Binding: &Keys.ExportState, Desc: "Export stack state to a file"},
+			{Binding: &Keys.ImportState, Desc: "Import stack state from a file"},
+			{Binding: &Keys.EditState, Desc: "Edit resource state in $EDITOR"},
+			{Binding: &Keys.EditNote, Desc: "Edit resource note"},
+			{Binding: &Keys.OpenResource, Desc: "Open resource (external tool)"},
+			{Binding: &Keys.OpenSource, Desc: "Open resource source in $EDITOR"},
+			{Binding: &Keys.FollowReference, Desc: "Follow stack reference"},
+			{Binding: &Keys.CopyResource, Desc: "Copy resource JSON"},
+			{Binding: &Keys.CopyAllResources, Desc: "Copy all resources JSON"},
+			{Binding: &Keys.ExportResources, Desc: "Export listed resources to CSV or JSON"},
+			{Binding: &Keys.CopyMarkdown, Desc: "Copy preview as markdown (in preview)"},
 			{Key: "", Desc: ""},
 
 			// General
 			{Key: "", Desc: "General"},
-			{Key: "s", Desc: "Select stack"},
-			{Key: "w", Desc: "Select workspace"},
-			{Key: "h", Desc: "View stack history"},
-			{Key: "D", Desc: "Toggle details panel"},
-			{Key: "?", Desc: "Toggle help"},
-			{Key: "q", Desc: "Quit"},
+			{Binding: &Keys.SelectStack, Desc: "Select stack"},
+			{Binding: &Keys.SelectWorkspace, Desc: "Select workspace"},
+			{Binding: &Keys.PinWorkspace, Desc: "Pin workspace (in selector)"},
+			{Binding: &Keys.BrowseCloudStacks, Desc: "Browse org stacks (read-only)"},
+			{Binding: &Keys.ReloadStack, Desc: "Reload stack"},
+			{Binding: &Keys.ViewHistory, Desc: "View stack history"},
+			{Binding: &Keys.HistoryDiff, Desc: "Diff update with previous (history)"},
+			{Binding: &Keys.FilterHistory, Desc: "Filter by kind, result, user (history)"},
+			{Binding: &Keys.ViewEnvironments, Desc: "View ESC environments"},
+			{Binding: &Keys.ViewWarnings, Desc: "Preview warnings"},
+			{Binding: &Keys.ToggleDiagnostics, Desc: "Expand engine log (preview)"},
+			{Binding: &Keys.ViewTimings, Desc: "Slowest resources (after execute)"},
+			{Binding: &Keys.ViewLogs, Desc: "Debug logs (with --debug)"},
+			{Binding: &Keys.ViewAbout, Desc: "About p5, Pulumi and the workspace"},
+			{Binding: &Keys.ViewDashboard, Desc: "Stacks dashboard"},
+			{Binding: &Keys.PluginIndex, Desc: "Browse plugin index"},
+			{Binding: &Keys.PluginStatus, Desc: "Plugin credential status"},
+			{Binding: &Keys.StackTags, Desc: "View and edit stack tags"},
+			{Binding: &Keys.CopyConfig, Desc: "Copy config from another stack"},
+			{Binding: &Keys.ToggleDetails, Desc: "Toggle details panel"},
+			{Binding: &Keys.WidenDetails, Desc: "Widen details panel"},
+			{Binding: &Keys.NarrowDetails, Desc: "Narrow details panel"},
+			{Binding: &Keys.ToggleRawJSON, Desc: "Toggle raw JSON diff (in details)"},
+			{Binding: &Keys.RevealSecret, Desc: "Reveal a secret (in details)"},
+			{Binding: &Keys.ViewValue, Desc: "View a large value in a pager (in details)"},
+			{Key: "/ n N", Desc: "Search details and jump between matches"},
+			{Binding: &Keys.Help, Desc: "Toggle help"},
+			{Binding: &Keys.ToggleHints, Desc: "Show all footer keys"},
+			{Binding: &Keys.Quit, Desc: "Quit"},
 		},
 	}
 }
@@ -113,15 +180,16 @@ func (h *HelpDialog) SetSize(width, height int) {
 func (h *HelpDialog) buildContent() string {
 	var lines []string
 	for _, item := range h.items {
+		keyLabel := item.keyLabel()
 		switch {
-		case item.Key == "" && item.Desc != "":
-			lines = append(lines, "", LabelStyle.Render(item.Desc))
-		case item.Key == "":
+		case keyLabel == "" && item.Desc != "":
+			lines = append(lines, "", LabelStyle.Render(i18n.T(item.Desc)))
+		case keyLabel == "":
 			lines = append(lines, "")
 		default:
 			lines = append(lines, fmt.Sprintf("  %s  %s",
-				ValueStyle.Render(fmt.Sprintf("%8s", item.Key)),
-				DimStyle.Render(item.Desc)))
+				ValueStyle.Render(fmt.Sprintf("%8s", keyLabel)),
+				DimStyle.Render(i18n.T(item.Desc))))
 		}
 	}
 
@@ -171,7 +239,7 @@ func (h *HelpDialog) View() string {
 }
 
 func (h *HelpDialog) buildViewContent() (titleText, content string) {
-	titleText = "Keyboard Shortcuts"
+	titleText = i18n.T("Keyboard Shortcuts")
 
 	if !h.ready {
 		return titleText, h.buildContent()
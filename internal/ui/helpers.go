@@ -7,6 +7,8 @@ import (
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
 )
 
 // getMapValue safely gets a value from a map
@@ -93,7 +95,7 @@ func truncateMiddle(s string, maxLen int) string {
 // RenderCenteredLoading renders a loading state with spinner centered in the given dimensions
 func RenderCenteredLoading(spin spinner.Model, msg string, width, height int) string {
 	if msg == "" {
-		msg = "Loading..."
+		msg = i18n.T("Loading...")
 	}
 	content := fmt.Sprintf("%s %s", spin.View(), msg)
 	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, content)
@@ -108,7 +110,7 @@ func RenderCenteredMessage(msg string, width, height int) string {
 // RenderPaddedError renders an error with padding
 func RenderPaddedError(err error) string {
 	paddedStyle := lipgloss.NewStyle().Padding(1, 2)
-	errMsg := ErrorStyle.Render(fmt.Sprintf("Error: %v", err))
+	errMsg := ErrorStyle.Render(i18n.Tf("Error: %v", err))
 	return paddedStyle.Render(errMsg)
 }
 
@@ -148,8 +150,11 @@ func CalculateDuration(startStr, endStr string) string {
 		return ""
 	}
 
-	duration := end.Sub(start)
+	return FormatDuration(end.Sub(start))
+}
 
+// FormatDuration returns a human-readable duration, e.g. "850ms", "12.3s" or "2m 5s"
+func FormatDuration(duration time.Duration) string {
 	if duration < time.Second {
 		return fmt.Sprintf("%dms", duration.Milliseconds())
 	}
@@ -276,7 +281,7 @@ const (
 // The changes map typically has keys: "create", "update", "delete", "replace", "same".
 func RenderResourceChanges(changes map[string]int, format ResourceChangesFormat) string {
 	if len(changes) == 0 {
-		return DimStyle.Render("no changes")
+		return DimStyle.Render(i18n.T("no changes"))
 	}
 
 	create := changes["create"]
@@ -306,7 +311,7 @@ func RenderResourceChanges(changes map[string]int, format ResourceChangesFormat)
 			if same > 0 {
 				return DimStyle.Render(fmt.Sprintf("%d unchanged", same))
 			}
-			return DimStyle.Render("no changes")
+			return DimStyle.Render(i18n.T("no changes"))
 		}
 		return strings.Join(parts, " ")
 
@@ -328,12 +333,12 @@ func RenderResourceChanges(changes map[string]int, format ResourceChangesFormat)
 		}
 
 		if len(parts) == 0 {
-			return DimStyle.Render("no changes")
+			return DimStyle.Render(i18n.T("no changes"))
 		}
 		return strings.Join(parts, "\n")
 	}
 
-	return DimStyle.Render("no changes")
+	return DimStyle.Render(i18n.T("no changes"))
 }
 
 // CursorState holds cursor and scroll state for list components
@@ -384,6 +389,23 @@ func EnsureCursorVisible(cursor, scrollOffset, itemCount, visibleHeight int) int
 	return scrollOffset
 }
 
+// listTopPadding is the blank row above the items of a list view
+const listTopPadding = 1
+
+// ItemAtRow returns the cursor position of the item rendered at row of a list view,
+// where firstRow is the row of the first visible item, or -1 when no item is there
+func ItemAtRow(row, firstRow, scrollOffset, visibleHeight, itemCount int) int {
+	pos := row - firstRow
+	if pos < 0 || pos >= visibleHeight {
+		return -1
+	}
+	pos += scrollOffset
+	if pos >= itemCount {
+		return -1
+	}
+	return pos
+}
+
 // CalculateVisibleHeight returns the number of lines available for items.
 // Accounts for padding and scroll indicators if content is scrollable.
 func CalculateVisibleHeight(totalHeight, itemCount, padding int) int {
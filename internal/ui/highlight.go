@@ -0,0 +1,330 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// syntaxHighlighting is whether recognized value formats are highlighted, from p5.toml
+var syntaxHighlighting = true
+
+// SetSyntaxHighlighting sets whether JSON, YAML and HCL values are laid out and
+// highlighted in diffs and the value pager. Off, values are shown as plain quoted
+// strings, as golden tests expect.
+func SetSyntaxHighlighting(enabled bool) {
+	syntaxHighlighting = enabled
+}
+
+// valueFormat is the format of a string value recognized for highlighting
+type valueFormat int
+
+const (
+	formatPlain valueFormat = iota
+	formatJSON
+	formatYAML
+	formatHCL
+)
+
+// String returns the name of the format shown next to highlighted values
+func (f valueFormat) String() string {
+	switch f {
+	case formatJSON:
+		return "json"
+	case formatYAML:
+		return "yaml"
+	case formatHCL:
+		return "hcl"
+	default:
+		return ""
+	}
+}
+
+var (
+	// yamlLineRe matches a YAML mapping entry, list item or document marker
+	yamlLineRe = regexp.MustCompile(`^\s*(---|- |-$|(- )?["']?[\w./-]+["']?:(\s|$))`)
+	// hclLineRe matches an HCL attribute, a block header or a closing brace
+	hclLineRe = regexp.MustCompile(`^\s*([\w-]+\s*=|[\w-]+(\s+"[^"]*")*\s*\{\s*$|\}\s*$)`)
+)
+
+// detectValueFormat returns the format of a string: a JSON object or array, or
+// YAML or HCL when most of its lines, ignoring blank lines and comments, look like it
+func detectValueFormat(s string) valueFormat {
+	if _, ok := parseJSONDocument(s); ok {
+		return formatJSON
+	}
+	var lines, yamlLines, hclLines int
+	for line := range strings.SplitSeq(s, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+		lines++
+		if yamlLineRe.MatchString(line) {
+			yamlLines++
+		}
+		if hclLineRe.MatchString(line) {
+			hclLines++
+		}
+	}
+	switch {
+	case lines < 2:
+		return formatPlain
+	case hclLines*2 > lines && hclLines >= yamlLines:
+		return formatHCL
+	case yamlLines*2 > lines:
+		return formatYAML
+	default:
+		return formatPlain
+	}
+}
+
+// formatValueBlock returns the lines of a string value to show as a highlighted
+// block in diffs, with JSON indented, when highlighting is on and the value is a
+// recognized format too long for one line
+func formatValueBlock(s string, rawJSON bool) ([]string, valueFormat, bool) {
+	if !syntaxHighlighting || isLargeValue(s) {
+		return nil, formatPlain, false
+	}
+	format := detectValueFormat(s)
+	switch format {
+	case formatPlain:
+		return nil, formatPlain, false
+	case formatJSON:
+		if rawJSON {
+			return nil, formatPlain, false
+		}
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, []byte(strings.TrimSpace(s)), "", "  "); err != nil {
+			return nil, formatPlain, false
+		}
+		s = buf.String()
+	}
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) < 2 {
+		return nil, formatPlain, false
+	}
+	return lines, format, true
+}
+
+// tokenKind is the syntax class of a highlighted token
+type tokenKind int
+
+const (
+	tokenText tokenKind = iota
+	tokenKey
+	tokenString
+	tokenNumber
+	tokenLiteral
+	tokenComment
+	tokenPunct
+)
+
+// syntaxToken is a run of a line in one syntax class
+type syntaxToken struct {
+	kind tokenKind
+	text string
+}
+
+var (
+	// yamlKeyRe matches the key of a YAML mapping entry, after any list marker
+	yamlKeyRe = regexp.MustCompile(`^(["'][^"']*["']|[^\s:#"'][^:#]*?):(\s|$)`)
+	// hclKeyRe matches the name of an HCL attribute or block
+	hclKeyRe = regexp.MustCompile(`^[\w-]+`)
+	// numberRe matches a number
+	numberRe = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][+-]?\d+)?`)
+	// wordRe matches a bare word, for true, false and null
+	wordRe = regexp.MustCompile(`^[\w.~-]+`)
+)
+
+// literals are the bare words highlighted as constants
+var literals = map[string]bool{
+	"true": true, "false": true, "null": true, "~": true,
+	"yes": true, "no": true, "True": true, "False": true, "Null": true,
+}
+
+// tokenizeLine splits a line of a value in format into highlighted tokens
+func tokenizeLine(line string, format valueFormat) []syntaxToken {
+	rest := strings.TrimLeft(line, " \t")
+	tokens := []syntaxToken{{tokenText, line[:len(line)-len(rest)]}}
+
+	switch format {
+	case formatYAML:
+		if strings.HasPrefix(rest, "#") {
+			return append(tokens, syntaxToken{tokenComment, rest})
+		}
+		for strings.HasPrefix(rest, "- ") || rest == "-" {
+			tokens = append(tokens, syntaxToken{tokenPunct, "-"})
+			rest = strings.TrimPrefix(rest, "-")
+			trimmed := strings.TrimLeft(rest, " ")
+			tokens = append(tokens, syntaxToken{tokenText, rest[:len(rest)-len(trimmed)]})
+			rest = trimmed
+		}
+		if m := yamlKeyRe.FindStringSubmatch(rest); m != nil {
+			tokens = append(tokens, syntaxToken{tokenKey, m[1]}, syntaxToken{tokenPunct, ":"})
+			rest = rest[len(m[1])+1:]
+		}
+	case formatHCL:
+		if strings.HasPrefix(rest, "#") || strings.HasPrefix(rest, "//") {
+			return append(tokens, syntaxToken{tokenComment, rest})
+		}
+		if key := hclKeyRe.FindString(rest); key != "" && !literals[key] {
+			tokens = append(tokens, syntaxToken{tokenKey, key})
+			rest = rest[len(key):]
+		}
+	}
+	tokens = append(tokens, tokenizeValue(rest, format)...)
+
+	// Drop empty runs, like the indentation of unindented lines
+	out := tokens[:0]
+	for _, token := range tokens {
+		if token.text != "" {
+			out = append(out, token)
+		}
+	}
+	return out
+}
+
+// tokenizeValue splits the rest of a line into strings, numbers, literals,
+// punctuation and comments. In JSON, a string followed by a colon is a key.
+func tokenizeValue(s string, format valueFormat) []syntaxToken {
+	var tokens []syntaxToken
+	for s != "" {
+		c := s[0]
+		switch {
+		case c == '"' || (c == '\'' && format == formatYAML):
+			end := quotedLength(s)
+			kind := tokenString
+			if format == formatJSON && strings.HasPrefix(strings.TrimLeft(s[end:], " "), ":") {
+				kind = tokenKey
+			}
+			tokens = append(tokens, syntaxToken{kind, s[:end]})
+			s = s[end:]
+		case c == '#' && format != formatJSON, strings.HasPrefix(s, "//") && format == formatHCL:
+			tokens = append(tokens, syntaxToken{tokenComment, s})
+			s = ""
+		case strings.ContainsRune("{}[](),:=|>", rune(c)):
+			tokens = append(tokens, syntaxToken{tokenPunct, s[:1]})
+			s = s[1:]
+		case c == ' ' || c == '\t':
+			n := len(s) - len(strings.TrimLeft(s, " \t"))
+			tokens = append(tokens, syntaxToken{tokenText, s[:n]})
+			s = s[n:]
+		default:
+			if m := numberRe.FindString(s); m != "" && isTokenEnd(s[len(m):]) {
+				tokens = append(tokens, syntaxToken{tokenNumber, m})
+				s = s[len(m):]
+				continue
+			}
+			if m := wordRe.FindString(s); m != "" {
+				kind := tokenText
+				if literals[m] && isTokenEnd(s[len(m):]) {
+					kind = tokenLiteral
+				}
+				tokens = append(tokens, syntaxToken{kind, m})
+				s = s[len(m):]
+				continue
+			}
+			tokens = append(tokens, syntaxToken{tokenText, s[:1]})
+			s = s[1:]
+		}
+	}
+	return tokens
+}
+
+// quotedLength returns the length of the quoted string s starts with, including
+// its quotes, or the rest of s when it isn't closed
+func quotedLength(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// isTokenEnd returns whether a number or literal ends where s starts
+func isTokenEnd(s string) bool {
+	return s == "" || strings.ContainsRune(" \t,]}):#", rune(s[0]))
+}
+
+// syntaxStyle returns the style of a token kind
+func syntaxStyle(kind tokenKind) lipgloss.Style {
+	switch kind {
+	case tokenKey:
+		return SyntaxKeyStyle
+	case tokenString:
+		return SyntaxStringStyle
+	case tokenNumber:
+		return SyntaxNumberStyle
+	case tokenLiteral:
+		return SyntaxLiteralStyle
+	case tokenComment, tokenPunct:
+		return DimStyle
+	default:
+		return ValueStyle
+	}
+}
+
+// highlightLine renders a line of a value in format with syntax highlighting
+func highlightLine(line string, format valueFormat) string {
+	if format == formatPlain {
+		return ValueStyle.Render(line)
+	}
+	var b strings.Builder
+	for _, token := range tokenizeLine(line, format) {
+		b.WriteString(syntaxStyle(token.kind).Render(token.text))
+	}
+	return b.String()
+}
+
+// lineChange is a line of a line-by-line diff, marked "+", "-" or " "
+type lineChange struct {
+	op   string
+	text string
+}
+
+// diffLines diffs two texts line by line over their longest common subsequence
+func diffLines(oldLines, newLines []string) []lineChange {
+	// lcs[i][j] is the length of the longest common subsequence of oldLines[i:] and newLines[j:]
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var changes []lineChange
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			changes = append(changes, lineChange{" ", oldLines[i]})
+			i++
+			j++
+		case j < len(newLines) && (i == len(oldLines) || lcs[i][j+1] > lcs[i+1][j]):
+			changes = append(changes, lineChange{"+", newLines[j]})
+			j++
+		default:
+			changes = append(changes, lineChange{"-", oldLines[i]})
+			i++
+		}
+	}
+	return changes
+}
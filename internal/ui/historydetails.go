@@ -1,8 +1,11 @@
 package ui
 
 import (
-	"fmt"
 	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
 )
 
 // HistoryDetailPanel is a floating panel showing history update details
@@ -11,17 +14,118 @@ type HistoryDetailPanel struct {
 
 	// Current history item being displayed
 	item *HistoryItem
+
+	// Resources changed by the update, diffed from its deployment snapshots, with
+	// the one selected for drill-down
+	changesVersion int
+	changes        []ResourceItem
+	changesLoading bool
+	changesErr     error
+	changeCursor   int
+	cursorLine     int  // Content line of the selected resource, -1 if not rendered
+	scrollToCursor bool // Scroll the selected resource into view on the next render
+
+	// Search within the panel content
+	search PanelSearch
 }
 
 // NewHistoryDetailPanel creates a new history detail panel component
 func NewHistoryDetailPanel() *HistoryDetailPanel {
-	return &HistoryDetailPanel{}
+	return &HistoryDetailPanel{
+		search: NewPanelSearch(),
+	}
 }
 
-// SetItem sets the history item to display details for
+// SetItem sets the history item to display details for. Its changed resources
+// are cleared unless they were loaded for the same update.
 func (d *HistoryDetailPanel) SetItem(item *HistoryItem) {
 	d.item = item
 	d.ResetScroll()
+	if item == nil || item.BackendVersion != d.changesVersion {
+		d.setChanges(0, nil, nil)
+	}
+}
+
+// NeedsChanges returns the backend version of the shown update when its changed
+// resources haven't been loaded yet. Updates from backends that don't track
+// versions can't be diffed.
+func (d *HistoryDetailPanel) NeedsChanges() (int, bool) {
+	if !d.Visible() || d.item == nil || d.item.BackendVersion == 0 || d.item.BackendVersion == d.changesVersion {
+		return 0, false
+	}
+	return d.item.BackendVersion, true
+}
+
+// SetChangesLoading shows the changed resources of version as loading
+func (d *HistoryDetailPanel) SetChangesLoading(version int) {
+	d.setChanges(version, nil, nil)
+	d.changesLoading = true
+}
+
+// SetChanges sets the resources changed by version
+func (d *HistoryDetailPanel) SetChanges(version int, items []ResourceItem) {
+	d.setChanges(version, items, nil)
+}
+
+// SetChangesError shows an error instead of the resources changed by version
+func (d *HistoryDetailPanel) SetChangesError(version int, err error) {
+	d.setChanges(version, nil, err)
+}
+
+func (d *HistoryDetailPanel) setChanges(version int, items []ResourceItem, err error) {
+	d.changesVersion = version
+	d.changes = items
+	d.changesErr = err
+	d.changesLoading = false
+	d.changeCursor = 0
+}
+
+// ChangesVersion returns the version whose changed resources are shown or loading
+func (d *HistoryDetailPanel) ChangesVersion() int {
+	return d.changesVersion
+}
+
+// Changes returns the resources changed by the shown update
+func (d *HistoryDetailPanel) Changes() []ResourceItem {
+	return d.changes
+}
+
+// SelectedChange returns the changed resource selected for drill-down, or nil
+func (d *HistoryDetailPanel) SelectedChange() *ResourceItem {
+	if d.changeCursor >= len(d.changes) {
+		return nil
+	}
+	return &d.changes[d.changeCursor]
+}
+
+// MoveChangeCursor selects another changed resource, returning false when there
+// are none to select so the key can scroll the panel instead
+func (d *HistoryDetailPanel) MoveChangeCursor(delta int) bool {
+	if len(d.changes) == 0 {
+		return false
+	}
+	d.changeCursor = MoveCursor(d.changeCursor, delta, len(d.changes))
+	d.scrollToCursor = true
+	return true
+}
+
+// Hide hides the panel and clears its search
+func (d *HistoryDetailPanel) Hide() {
+	d.PanelBase.Hide()
+	d.search.Clear()
+}
+
+// SearchActive returns whether a search query is being typed
+func (d *HistoryDetailPanel) SearchActive() bool {
+	return d.search.Active()
+}
+
+// Update handles search keys for the history detail panel and returns true if the key was handled
+func (d *HistoryDetailPanel) Update(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if !d.Visible() {
+		return nil, false
+	}
+	return d.search.Update(msg)
 }
 
 // View renders the history detail panel
@@ -31,19 +135,40 @@ func (d *HistoryDetailPanel) View() string {
 	}
 
 	// Build header
-	header := "Update Details"
+	header := i18n.T("Update Details")
 	if d.item != nil {
-		header = fmt.Sprintf("Update #%d", d.item.Version)
+		header = i18n.Tf("Update #%d", d.item.Version)
 	}
 
 	// Build content
 	var content string
 	if d.item == nil {
-		content = DimStyle.Render("No update selected")
+		content = DimStyle.Render(i18n.T("No update selected"))
 	} else {
 		content = d.renderContent()
 	}
 
+	// Keep the selected resource in view as it moves
+	if d.scrollToCursor && d.cursorLine >= 0 {
+		// Content height of RenderDetailPanel: header, blank line, border and padding
+		height := max(d.Height()-6, 1)
+		offset := d.ScrollOffset()
+		if d.cursorLine < offset {
+			offset = d.cursorLine
+		} else if d.cursorLine >= offset+height {
+			offset = d.cursorLine - height + 1
+		}
+		d.SetScrollOffset(offset)
+	}
+	d.scrollToCursor = false
+
+	// Highlight search matches and scroll to the selected one
+	content = d.search.Highlight(content)
+	if offset, ok := d.search.ScrollTarget(); ok {
+		d.SetScrollOffset(offset)
+	}
+	header += d.search.HeaderSuffix()
+
 	// Use shared helper for common panel rendering
 	result := RenderDetailPanel(DetailPanelContent{
 		Header:       header,
@@ -64,24 +189,24 @@ func (d *HistoryDetailPanel) View() string {
 // renderContent renders the main content for the history item
 func (d *HistoryDetailPanel) renderContent() string {
 	if d.item == nil {
-		return DimStyle.Render("No update selected")
+		return DimStyle.Render(i18n.T("No update selected"))
 	}
 
 	var b strings.Builder
 
 	// Operation kind with color
-	b.WriteString(DimStyle.Render("Kind: "))
+	b.WriteString(DimStyle.Render(i18n.T("Kind: ")))
 	b.WriteString(RenderHistoryKind(d.item.Kind))
 	b.WriteString("\n")
 
 	// Result status
-	b.WriteString(DimStyle.Render("Result: "))
+	b.WriteString(DimStyle.Render(i18n.T("Result: ")))
 	b.WriteString(RenderHistoryResult(d.item.Result))
 	b.WriteString("\n")
 
 	// User who ran the update
 	if d.item.User != "" {
-		b.WriteString(DimStyle.Render("User: "))
+		b.WriteString(DimStyle.Render(i18n.T("User: ")))
 		userStr := d.item.User
 		if d.item.UserEmail != "" {
 			userStr += " <" + d.item.UserEmail + ">"
@@ -91,19 +216,19 @@ func (d *HistoryDetailPanel) renderContent() string {
 	}
 
 	// Start time
-	b.WriteString(DimStyle.Render("Started: "))
+	b.WriteString(DimStyle.Render(i18n.T("Started: ")))
 	b.WriteString(ValueStyle.Render(d.formatTime(d.item.StartTime)))
 	b.WriteString("\n")
 
 	// End time (if available)
 	if d.item.EndTime != "" {
-		b.WriteString(DimStyle.Render("Ended: "))
+		b.WriteString(DimStyle.Render(i18n.T("Ended: ")))
 		b.WriteString(ValueStyle.Render(d.formatTime(d.item.EndTime)))
 		b.WriteString("\n")
 
 		// Duration
 		if duration := d.calculateDuration(d.item.StartTime, d.item.EndTime); duration != "" {
-			b.WriteString(DimStyle.Render("Duration: "))
+			b.WriteString(DimStyle.Render(i18n.T("Duration: ")))
 			b.WriteString(ValueStyle.Render(duration))
 			b.WriteString("\n")
 		}
@@ -112,7 +237,7 @@ func (d *HistoryDetailPanel) renderContent() string {
 	// Message
 	if d.item.Message != "" {
 		b.WriteString("\n")
-		b.WriteString(DimStyle.Render("Message:"))
+		b.WriteString(DimStyle.Render(i18n.T("Message:")))
 		b.WriteString("\n")
 		b.WriteString(ValueStyle.Render(d.item.Message))
 		b.WriteString("\n")
@@ -124,14 +249,54 @@ func (d *HistoryDetailPanel) renderContent() string {
 	b.WriteString("\n\n")
 
 	if len(d.item.ResourceChanges) == 0 {
-		b.WriteString(DimStyle.Render("No resource information available"))
+		b.WriteString(DimStyle.Render(i18n.T("No resource information available")))
 	} else {
 		d.renderResourceChanges(&b)
 	}
 
+	d.cursorLine = -1
+	if d.item.BackendVersion != 0 && d.item.BackendVersion == d.changesVersion {
+		b.WriteString("\n\n")
+		d.renderChangedResources(&b)
+	}
+
 	return b.String()
 }
 
+// renderChangedResources lists the resources the update changed, marking the one
+// selected for drill-down
+func (d *HistoryDetailPanel) renderChangedResources(b *strings.Builder) {
+	switch {
+	case d.changesLoading:
+		b.WriteString(DimStyle.Render(i18n.T("Loading changed resources...")))
+		return
+	case d.changesErr != nil:
+		b.WriteString(ErrorStyle.Render(i18n.Tf("Error: %v", d.changesErr)))
+		return
+	case len(d.changes) == 0:
+		b.WriteString(DimStyle.Render(i18n.T("No resources changed")))
+		return
+	}
+
+	for i := range d.changes {
+		item := &d.changes[i]
+		cursor := "  "
+		if i == d.changeCursor {
+			cursor = CursorStyle.Render("> ")
+			d.cursorLine = strings.Count(b.String(), "\n")
+		}
+		b.WriteString(cursor)
+		b.WriteString(RenderOp(item.Op))
+		b.WriteString(" ")
+		b.WriteString(ValueStyle.Render(item.Name))
+		b.WriteString(" ")
+		b.WriteString(DimStyle.Render(item.Type))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(DimStyle.Render("enter " + i18n.T("diff selected resource")))
+}
+
 // renderResourceChanges renders detailed resource change information
 func (d *HistoryDetailPanel) renderResourceChanges(b *strings.Builder) {
 	changes := d.item.ResourceChanges
@@ -141,7 +306,7 @@ func (d *HistoryDetailPanel) renderResourceChanges(b *strings.Builder) {
 	// Total
 	total := changes["create"] + changes["update"] + changes["delete"] + changes["replace"] + changes["same"]
 	b.WriteString("\n")
-	b.WriteString(DimStyle.Render(fmt.Sprintf("Total: %d resources", total)))
+	b.WriteString(DimStyle.Render(i18n.Tf("Total: %d resources", total)))
 }
 
 // renderKind and renderResult are now shared functions in styles.go.
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// HistoryDiffPanel is a floating panel showing the resource-level diff between
+// a history update and the update before it
+type HistoryDiffPanel struct {
+	PanelBase // Embed common panel functionality
+
+	version int
+	items   []ResourceItem
+	loading bool
+	err     error
+	rawJSON bool // Diff JSON strings as strings instead of structurally
+
+	// Property paths de-emphasized in diffs, by resource type pattern
+	diffIgnore DiffIgnoreRules
+
+	// Resource to scroll to on the next render, e.g. one drilled into from the
+	// history details panel
+	focusURN string
+}
+
+// NewHistoryDiffPanel creates a new history diff panel component
+func NewHistoryDiffPanel() *HistoryDiffPanel {
+	return &HistoryDiffPanel{}
+}
+
+// SetLoading shows the loading state while snapshots for the version are fetched
+func (d *HistoryDiffPanel) SetLoading(version int) {
+	d.version = version
+	d.items = nil
+	d.err = nil
+	d.loading = true
+	d.ResetScroll()
+}
+
+// SetDiff sets the changed resources between the version and its predecessor
+func (d *HistoryDiffPanel) SetDiff(version int, items []ResourceItem) {
+	d.version = version
+	d.items = items
+	d.err = nil
+	d.loading = false
+	d.ResetScroll()
+}
+
+// SetError shows an error instead of the diff
+func (d *HistoryDiffPanel) SetError(err error) {
+	d.err = err
+	d.loading = false
+	d.ResetScroll()
+}
+
+// FocusResource scrolls the diff to the resource with urn on the next render
+func (d *HistoryDiffPanel) FocusResource(urn string) {
+	d.focusURN = urn
+}
+
+// SetDiffIgnore sets the property paths de-emphasized in diffs
+func (d *HistoryDiffPanel) SetDiffIgnore(rules DiffIgnoreRules) {
+	d.diffIgnore = rules
+}
+
+// SetRawJSON sets whether changed JSON strings are diffed as plain strings
+func (d *HistoryDiffPanel) SetRawJSON(raw bool) {
+	d.rawJSON = raw
+}
+
+// Version returns the update version being diffed
+func (d *HistoryDiffPanel) Version() int {
+	return d.version
+}
+
+// View renders the history diff panel
+func (d *HistoryDiffPanel) View() string {
+	if !d.Visible() || d.Width() == 0 || d.Height() == 0 {
+		return ""
+	}
+
+	header := i18n.Tf("Update #%d", d.version)
+	if d.version > 1 {
+		header = i18n.Tf("Update #%d vs #%d", d.version, d.version-1)
+	}
+	if d.rawJSON {
+		header += DimStyle.Render(" [raw json]")
+	}
+
+	var content string
+	switch {
+	case d.loading:
+		content = DimStyle.Render(i18n.T("Loading deployment snapshots..."))
+	case d.err != nil:
+		content = ErrorStyle.Render(i18n.Tf("Error: %v", d.err))
+	case len(d.items) == 0:
+		content = DimStyle.Render(i18n.T("No resource changes between these versions"))
+	default:
+		var focusLine int
+		content, focusLine = d.renderContent()
+		if focusLine >= 0 {
+			d.SetScrollOffset(focusLine)
+		}
+		d.focusURN = ""
+	}
+
+	result := RenderDetailPanel(DetailPanelContent{
+		Header:       header,
+		Content:      content,
+		Width:        d.Width(),
+		Height:       d.Height(),
+		ScrollOffset: d.ScrollOffset(),
+	})
+
+	if result.NewScrollOffset != d.ScrollOffset() {
+		d.SetScrollOffset(result.NewScrollOffset)
+	}
+
+	return result.Rendered
+}
+
+// renderContent renders a summary line followed by each changed resource and its
+// property diff. Also returns the line of the focused resource, or -1.
+func (d *HistoryDiffPanel) renderContent() (string, int) {
+	var b strings.Builder
+	renderer := NewDiffRenderer(d.Width() - 8)
+	renderer.SetRawJSON(d.rawJSON)
+
+	changes := make(map[string]int)
+	for i := range d.items {
+		changes[string(d.items[i].Op)]++
+	}
+	b.WriteString(RenderResourceChanges(changes, ResourceChangesExpanded))
+	b.WriteString("\n")
+
+	focusLine := -1
+	for i := range d.items {
+		item := &d.items[i]
+		b.WriteString("\n")
+		if item.URN == d.focusURN && d.focusURN != "" {
+			focusLine = strings.Count(b.String(), "\n")
+		}
+		b.WriteString(RenderOp(item.Op))
+		b.WriteString(" ")
+		b.WriteString(ValueStyle.Render(item.Name))
+		b.WriteString(" ")
+		b.WriteString(DimStyle.Render(item.Type))
+		b.WriteString("\n\n")
+		renderer.SetIgnoredPaths(d.diffIgnore.PathsFor(item.Type))
+		b.WriteString(strings.TrimRight(renderer.RenderCombinedProperties(item), "\n"))
+		b.WriteString("\n")
+	}
+
+	return b.String(), focusLine
+}
@@ -0,0 +1,372 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// Kinds and results of updates the history filter chips toggle
+var (
+	historyFilterKinds   = []string{"update", "preview", "refresh", "destroy"}
+	historyFilterResults = []string{"succeeded", "failed"}
+)
+
+// HistoryFilter narrows the history list to updates of some kinds and results,
+// run by a user or started within a date range. It applies on top of the list's
+// text filter.
+type HistoryFilter struct {
+	Kinds   []string // Kinds shown, all when empty
+	Results []string // Results shown, all when empty
+	User    string   // User who ran the update, anyone when empty
+	From    string   // Earliest start date (YYYY-MM-DD), unbounded when empty
+	To      string   // Latest start date (YYYY-MM-DD), inclusive, unbounded when empty
+}
+
+// Active returns whether the filter hides any updates
+func (f HistoryFilter) Active() bool {
+	return len(f.Kinds) > 0 || len(f.Results) > 0 || f.User != "" || f.From != "" || f.To != ""
+}
+
+// Matches returns whether the update passes the filter. Updates without a
+// parsable start time only pass when no date range is set.
+func (f HistoryFilter) Matches(item HistoryItem) bool {
+	if len(f.Kinds) > 0 && !slices.Contains(f.Kinds, item.Kind) {
+		return false
+	}
+	if len(f.Results) > 0 && !slices.Contains(f.Results, item.Result) {
+		return false
+	}
+	if f.User != "" && item.User != f.User {
+		return false
+	}
+	if f.From == "" && f.To == "" {
+		return true
+	}
+	start, err := time.Parse(time.RFC3339, item.StartTime)
+	if err != nil {
+		return false
+	}
+	// Dates are compared as shown in the list, in the time zone of the start time
+	date := start.Format(time.DateOnly)
+	return (f.From == "" || date >= f.From) && (f.To == "" || date <= f.To)
+}
+
+// Chips returns a label for each part of the filter that is set
+func (f HistoryFilter) Chips() []string {
+	var chips []string
+	if len(f.Kinds) > 0 {
+		chips = append(chips, strings.Join(f.Kinds, "|"))
+	}
+	if len(f.Results) > 0 {
+		chips = append(chips, strings.Join(f.Results, "|"))
+	}
+	if f.User != "" {
+		chips = append(chips, i18n.Tf("by %s", f.User))
+	}
+	switch {
+	case f.From != "" && f.To != "":
+		chips = append(chips, f.From+".."+f.To)
+	case f.From != "":
+		chips = append(chips, i18n.Tf("since %s", f.From))
+	case f.To != "":
+		chips = append(chips, i18n.Tf("until %s", f.To))
+	}
+	return chips
+}
+
+// RenderHistoryFilterChips renders the chips of the parts of the filter that are set
+func RenderHistoryFilterChips(f HistoryFilter) string {
+	chips := f.Chips()
+	for i, chip := range chips {
+		chips[i] = LabelStyle.Render("[" + chip + "]")
+	}
+	return strings.Join(chips, " ")
+}
+
+// HistoryFilterAction represents an action taken by the user in the history filter modal
+type HistoryFilterAction int
+
+const (
+	HistoryFilterActionNone   HistoryFilterAction = iota
+	HistoryFilterActionApply                      // Apply the edited filter
+	HistoryFilterActionCancel                     // Close the modal, keeping the filter
+)
+
+// Rows of the history filter modal
+const (
+	historyFilterRowKind = iota
+	historyFilterRowResult
+	historyFilterRowUser
+	historyFilterRowFrom
+	historyFilterRowTo
+	historyFilterRowCount
+)
+
+// HistoryFilterModal edits the history filter: chips toggling kinds and results,
+// a selector of the users who ran the loaded updates and a date range
+type HistoryFilterModal struct {
+	ModalBase // Embedded modal base for common functionality
+
+	row     int
+	chip    int // Chip under the cursor in the kind and result rows
+	kinds   []string
+	results []string
+	users   []string // Users to choose from, "" for anyone first
+	user    int
+	from    textinput.Model
+	to      textinput.Model
+	err     error
+}
+
+// NewHistoryFilterModal creates a new history filter modal
+func NewHistoryFilterModal() *HistoryFilterModal {
+	newDateInput := func() textinput.Model {
+		ti := textinput.New()
+		ti.Prompt = ""
+		ti.Placeholder = "YYYY-MM-DD"
+		ti.CharLimit = len(time.DateOnly)
+		ti.Width = len(time.DateOnly) + 1
+		return ti
+	}
+	return &HistoryFilterModal{from: newDateInput(), to: newDateInput()}
+}
+
+// Show shows the modal editing filter, offering the users who ran the loaded updates
+func (m *HistoryFilterModal) Show(filter HistoryFilter, users []string) {
+	m.ModalBase.Show()
+	m.row = historyFilterRowKind
+	m.chip = 0
+	m.kinds = slices.Clone(filter.Kinds)
+	m.results = slices.Clone(filter.Results)
+	m.users = append([]string{""}, users...)
+	if filter.User != "" && !slices.Contains(users, filter.User) {
+		m.users = append(m.users, filter.User)
+	}
+	m.user = max(slices.Index(m.users, filter.User), 0)
+	m.from.SetValue(filter.From)
+	m.to.SetValue(filter.To)
+	m.err = nil
+	m.focusRow()
+}
+
+// Filter returns the filter as edited
+func (m *HistoryFilterModal) Filter() HistoryFilter {
+	return HistoryFilter{
+		Kinds:   sortedLike(m.kinds, historyFilterKinds),
+		Results: sortedLike(m.results, historyFilterResults),
+		User:    m.users[m.user],
+		From:    strings.TrimSpace(m.from.Value()),
+		To:      strings.TrimSpace(m.to.Value()),
+	}
+}
+
+// sortedLike returns the values in the order they appear in order
+func sortedLike(values, order []string) []string {
+	var sorted []string
+	for _, v := range order {
+		if slices.Contains(values, v) {
+			sorted = append(sorted, v)
+		}
+	}
+	return sorted
+}
+
+// validate checks the date range is made of valid dates in order
+func (m *HistoryFilterModal) validate() error {
+	f := m.Filter()
+	for _, date := range []string{f.From, f.To} {
+		if _, err := time.Parse(time.DateOnly, date); date != "" && err != nil {
+			return fmt.Errorf("%s: %s", date, i18n.T("expected a date like 2024-01-31"))
+		}
+	}
+	if f.From != "" && f.To != "" && f.From > f.To {
+		return errors.New(i18n.T("the start date is after the end date"))
+	}
+	return nil
+}
+
+// chips returns the chips of the current row and the ones toggled on, or nil
+// outside of the chip rows
+func (m *HistoryFilterModal) chips() (all []string, selected *[]string) {
+	switch m.row {
+	case historyFilterRowKind:
+		return historyFilterKinds, &m.kinds
+	case historyFilterRowResult:
+		return historyFilterResults, &m.results
+	}
+	return nil, nil
+}
+
+// moveRow moves the cursor to another row, focusing its date input
+func (m *HistoryFilterModal) moveRow(delta int) {
+	m.row = (m.row + delta + historyFilterRowCount) % historyFilterRowCount
+	if all, _ := m.chips(); all != nil {
+		m.chip = min(m.chip, len(all)-1)
+	}
+	m.focusRow()
+}
+
+// focusRow focuses the date input of the current row, if any
+func (m *HistoryFilterModal) focusRow() {
+	m.from.Blur()
+	m.to.Blur()
+	switch m.row {
+	case historyFilterRowFrom:
+		m.from.Focus()
+	case historyFilterRowTo:
+		m.to.Focus()
+	}
+}
+
+// Update handles key events
+func (m *HistoryFilterModal) Update(msg tea.KeyMsg) (HistoryFilterAction, tea.Cmd) {
+	if !m.Visible() {
+		return HistoryFilterActionNone, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.Hide()
+		return HistoryFilterActionCancel, nil
+	case "enter":
+		if err := m.validate(); err != nil {
+			m.err = err
+			return HistoryFilterActionNone, nil
+		}
+		m.Hide()
+		return HistoryFilterActionApply, nil
+	case "up", "shift+tab":
+		m.moveRow(-1)
+		return HistoryFilterActionNone, nil
+	case "down", "tab":
+		m.moveRow(1)
+		return HistoryFilterActionNone, nil
+	case "ctrl+r":
+		m.kinds, m.results, m.user = nil, nil, 0
+		m.from.SetValue("")
+		m.to.SetValue("")
+		m.err = nil
+		return HistoryFilterActionNone, nil
+	}
+
+	var cmd tea.Cmd
+	switch m.row {
+	case historyFilterRowKind, historyFilterRowResult:
+		all, selected := m.chips()
+		switch {
+		case msg.String() == "left" || msg.String() == "h":
+			m.chip = max(m.chip-1, 0)
+		case msg.String() == "right" || msg.String() == "l":
+			m.chip = min(m.chip+1, len(all)-1)
+		case key.Matches(msg, Keys.ToggleSelect):
+			chip := all[m.chip]
+			if i := slices.Index(*selected, chip); i >= 0 {
+				*selected = slices.Delete(*selected, i, i+1)
+			} else {
+				*selected = append(*selected, chip)
+			}
+		}
+	case historyFilterRowUser:
+		switch msg.String() {
+		case "left", "h":
+			m.user = (m.user - 1 + len(m.users)) % len(m.users)
+		case "right", "l", " ":
+			m.user = (m.user + 1) % len(m.users)
+		}
+	case historyFilterRowFrom:
+		m.from, cmd = m.from.Update(msg)
+		m.err = nil
+	case historyFilterRowTo:
+		m.to, cmd = m.to.Update(msg)
+		m.err = nil
+	}
+	return HistoryFilterActionNone, cmd
+}
+
+// View renders the history filter modal
+func (m *HistoryFilterModal) View() string {
+	title := DialogTitleStyle.Render(i18n.T("Filter History"))
+
+	labels := []string{i18n.T("Kind"), i18n.T("Result"), i18n.T("User"), i18n.T("From"), i18n.T("To")}
+	labelWidth := 0
+	for _, label := range labels {
+		labelWidth = max(labelWidth, len([]rune(label)))
+	}
+
+	var content strings.Builder
+	for row, label := range labels {
+		cursor := "  "
+		if row == m.row {
+			cursor = CursorStyle.Render("> ")
+		}
+		content.WriteString(cursor)
+		content.WriteString(LabelStyle.Render(label + strings.Repeat(" ", labelWidth-len([]rune(label)))))
+		content.WriteString("  ")
+		switch row {
+		case historyFilterRowKind:
+			content.WriteString(m.renderChips(row, historyFilterKinds, m.kinds))
+		case historyFilterRowResult:
+			content.WriteString(m.renderChips(row, historyFilterResults, m.results))
+		case historyFilterRowUser:
+			user := m.users[m.user]
+			if user == "" {
+				user = i18n.T("anyone")
+			}
+			content.WriteString(DimStyle.Render("< ") + ValueStyle.Render(user) + DimStyle.Render(" >"))
+		case historyFilterRowFrom:
+			content.WriteString(m.from.View())
+		case historyFilterRowTo:
+			content.WriteString(m.to.View())
+		}
+		content.WriteString("\n")
+	}
+	if m.err != nil {
+		content.WriteString("\n")
+		content.WriteString(ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+		content.WriteString("\n")
+	}
+
+	var hint string
+	switch m.row {
+	case historyFilterRowKind, historyFilterRowResult:
+		hint = "space " + i18n.T("toggle")
+	case historyFilterRowUser:
+		hint = "←/→ " + i18n.T("choose")
+	}
+	hints := []string{"↑/↓ " + i18n.T("move"), "enter " + i18n.T("apply"), "ctrl+r " + i18n.T("reset"), "esc " + i18n.T("cancel")}
+	if hint != "" {
+		hints = append([]string{hint}, hints...)
+	}
+	footer := DimStyle.Render("\n" + strings.Join(hints, "  "))
+	return m.RenderDialog(title, content.String(), footer)
+}
+
+// renderChips renders a row of chips, marking the ones toggled on and the one
+// under the cursor
+func (m *HistoryFilterModal) renderChips(row int, all, selected []string) string {
+	chips := make([]string, 0, len(all))
+	for i, chip := range all {
+		mark := "[ ] "
+		if slices.Contains(selected, chip) {
+			mark = "[x] "
+		}
+		switch {
+		case row == m.row && i == m.chip:
+			chips = append(chips, CursorStyle.Render(mark+chip))
+		case slices.Contains(selected, chip):
+			chips = append(chips, ValueStyle.Render(mark+chip))
+		default:
+			chips = append(chips, DimStyle.Render(mark+chip))
+		}
+	}
+	return strings.Join(chips, "  ")
+}
@@ -2,17 +2,22 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/rfhold/p5/internal/i18n"
 )
 
 // HistoryItem represents a single update in the history list
 type HistoryItem struct {
-	Version         int
+	Version         int    // Shown update number; its position in history on local backends
+	BackendVersion  int    // Version known to the backend, 0 when it doesn't track versions
 	Kind            string // "update", "preview", "refresh", "destroy"
 	StartTime       string
 	EndTime         string
@@ -33,11 +38,20 @@ type HistoryList struct {
 	cursor       int
 	scrollOffset int
 
-	// Filter state
-	filter      FilterState
-	filteredIdx []int // Indices into items that match filter (nil = no filter active)
+	// Filter state: text filter and kind, result, user and date filter
+	filter        FilterState
+	historyFilter HistoryFilter
+	filteredIdx   []int // Indices into items that match filter (nil = no filter active)
+
+	// Paging: whether older updates remain to be loaded, and are being loaded
+	hasMore     bool
+	loadingMore bool
 }
 
+// historyLoadMoreThreshold is how close to the last item the cursor gets before
+// the next page of history is loaded
+const historyLoadMoreThreshold = 5
+
 // NewHistoryList creates a new HistoryList component
 func NewHistoryList() *HistoryList {
 	s := spinner.New()
@@ -57,17 +71,29 @@ func (h *HistoryList) SetSize(width, height int) {
 	h.ensureCursorVisible()
 }
 
-// SetItems replaces all items
-func (h *HistoryList) SetItems(items []HistoryItem) {
+// SetItems replaces all items. hasMore is whether older updates remain to be loaded.
+func (h *HistoryList) SetItems(items []HistoryItem, hasMore bool) {
 	h.items = items
 	h.cursor = 0
 	h.scrollOffset = 0
 	h.filteredIdx = nil
 	h.filter.Deactivate()
+	h.hasMore = hasMore
+	h.loadingMore = false
 	h.SetLoading(false, "")
 	h.ClearError()
 }
 
+// ExtendItems replaces the items with a longer list starting with the same
+// updates, once another page has loaded, keeping the cursor and filter
+func (h *HistoryList) ExtendItems(items []HistoryItem, hasMore bool) {
+	h.items = items
+	h.hasMore = hasMore
+	h.loadingMore = false
+	h.rebuildFilteredIndex()
+	h.ensureCursorVisible()
+}
+
 // Clear resets the list
 func (h *HistoryList) Clear() {
 	h.items = make([]HistoryItem, 0)
@@ -75,9 +101,72 @@ func (h *HistoryList) Clear() {
 	h.scrollOffset = 0
 	h.filteredIdx = nil
 	h.filter.Deactivate()
+	h.historyFilter = HistoryFilter{}
+	h.hasMore = false
+	h.loadingMore = false
 	h.ClearError()
 }
 
+// SetHistoryFilter sets the kind, result, user and date filter, applied on top of
+// the text filter
+func (h *HistoryList) SetHistoryFilter(filter HistoryFilter) {
+	h.historyFilter = filter
+	h.cursor = 0
+	h.scrollOffset = 0
+	h.rebuildFilteredIndex()
+}
+
+// HistoryFilter returns the kind, result, user and date filter
+func (h *HistoryList) HistoryFilter() HistoryFilter {
+	return h.historyFilter
+}
+
+// Users returns the users who ran the loaded updates, sorted
+func (h *HistoryList) Users() []string {
+	var users []string
+	for _, item := range h.items {
+		if item.User != "" && !slices.Contains(users, item.User) {
+			users = append(users, item.User)
+		}
+	}
+	slices.Sort(users)
+	return users
+}
+
+// filtered returns whether the text filter or the history filter hides any updates
+func (h *HistoryList) filtered() bool {
+	return h.filter.Applied() || h.historyFilter.Active()
+}
+
+// showFilterBar returns whether the filter bar is shown below the items
+func (h *HistoryList) showFilterBar() bool {
+	return h.filter.ActiveOrApplied() || h.historyFilter.Active()
+}
+
+// SetLoadingMore sets whether the next page of history is being loaded
+func (h *HistoryList) SetLoadingMore(loading bool) {
+	h.loadingMore = loading
+}
+
+// LoadingMore returns whether the next page of history is being loaded
+func (h *HistoryList) LoadingMore() bool {
+	return h.loadingMore
+}
+
+// SetHasMore sets whether older updates remain to be loaded
+func (h *HistoryList) SetHasMore(hasMore bool) {
+	h.hasMore = hasMore
+}
+
+// NeedsMore returns whether the next page of history should be loaded: older
+// updates remain and the cursor is near the end of the list
+func (h *HistoryList) NeedsMore() bool {
+	if !h.hasMore || h.loadingMore || !h.IsReady() {
+		return false
+	}
+	return h.cursor >= h.effectiveItemCount()-historyLoadMoreThreshold
+}
+
 // effectiveItemCount returns the number of items being displayed (filtered or all)
 func (h *HistoryList) effectiveItemCount() int {
 	if h.filteredIdx != nil {
@@ -97,24 +186,26 @@ func (h *HistoryList) effectiveIndex(cursorPos int) int {
 	return cursorPos
 }
 
-// visibleHeight returns the number of lines available for items
-func (h *HistoryList) visibleHeight() int {
-	itemCount := h.effectiveItemCount()
+// padding returns the number of lines around the items
+func (h *HistoryList) padding() int {
 	padding := 2 // 1 top, 1 bottom
-	if h.filter.ActiveOrApplied() {
+	if h.showFilterBar() {
 		padding++
 	}
-	return CalculateVisibleHeight(h.Height(), itemCount, padding)
+	if h.hasMore {
+		padding++ // Load more indicator
+	}
+	return padding
+}
+
+// visibleHeight returns the number of lines available for items
+func (h *HistoryList) visibleHeight() int {
+	return CalculateVisibleHeight(h.Height(), h.effectiveItemCount(), h.padding())
 }
 
 // isScrollable returns true if there are more items than can fit
 func (h *HistoryList) isScrollable() bool {
-	itemCount := h.effectiveItemCount()
-	padding := 2
-	if h.filter.ActiveOrApplied() {
-		padding++
-	}
-	return IsScrollable(h.Height(), itemCount, padding)
+	return IsScrollable(h.Height(), h.effectiveItemCount(), h.padding())
 }
 
 // ensureCursorVisible adjusts scroll offset to keep cursor visible
@@ -125,16 +216,20 @@ func (h *HistoryList) ensureCursorVisible() {
 
 // rebuildFilteredIndex applies the current filter to build the filtered index
 func (h *HistoryList) rebuildFilteredIndex() {
-	if !h.filter.Applied() {
+	if !h.filtered() {
 		h.filteredIdx = nil
 		return
 	}
 
-	h.filteredIdx = make([]int, 0)
-	for i := range h.items {
-		if h.filter.MatchesAny(h.items[i].Kind, h.items[i].Message, h.items[i].User, h.items[i].Result) {
-			h.filteredIdx = append(h.filteredIdx, i)
-		}
+	matches := h.filter.rankMatches(len(h.items), func(i int) []string {
+		return []string{h.items[i].Kind, h.items[i].Message, h.items[i].User, h.items[i].Result}
+	})
+	h.filteredIdx = slices.DeleteFunc(matches, func(i int) bool {
+		return !h.historyFilter.Matches(h.items[i])
+	})
+	if h.filter.Fuzzy() && h.filter.Applied() {
+		h.cursor = 0 // Select the best match
+		h.ensureCursorVisible()
 	}
 
 	// Adjust cursor if it's now outside filtered range
@@ -199,6 +294,33 @@ func (h *HistoryList) moveCursor(delta int) {
 	h.ensureCursorVisible()
 }
 
+// ClickRow moves the cursor to the item rendered at row of the list view, counted
+// from its top. Returns false if no item is rendered there.
+func (h *HistoryList) ClickRow(row int) bool {
+	if !h.IsReady() || len(h.items) == 0 {
+		return false
+	}
+	firstRow := listTopPadding
+	if h.isScrollable() {
+		firstRow++ // Up arrow indicator
+	}
+	pos := ItemAtRow(row, firstRow, h.scrollOffset, h.visibleHeight(), h.effectiveItemCount())
+	if pos < 0 {
+		return false
+	}
+	h.cursor = pos
+	h.ensureCursorVisible()
+	return true
+}
+
+// Scroll moves the cursor by delta items, for mouse wheel scrolling
+func (h *HistoryList) Scroll(delta int) {
+	if !h.IsReady() || len(h.items) == 0 {
+		return
+	}
+	h.moveCursor(delta)
+}
+
 // SelectedItem returns the currently selected item, or nil if none
 func (h *HistoryList) SelectedItem() *HistoryItem {
 	itemCount := h.effectiveItemCount()
@@ -240,17 +362,17 @@ func (h *HistoryList) renderItems() string {
 	itemCount := h.effectiveItemCount()
 
 	// Handle filter with no matches
-	if h.filter.Applied() && itemCount == 0 {
+	if h.filtered() && itemCount == 0 {
 		var b strings.Builder
-		b.WriteString(DimStyle.Render("No matches"))
+		b.WriteString(DimStyle.Render(i18n.T("No matches")))
 		b.WriteString("\n\n")
-		b.WriteString(RenderFilterBar(&h.filter, 0, len(h.items), h.Width()))
+		b.WriteString(h.renderFilterBar(0))
 		paddedStyle := lipgloss.NewStyle().Padding(1, 2)
 		return paddedStyle.Render(b.String())
 	}
 
 	if len(h.items) == 0 {
-		return RenderCenteredMessage("No history", h.Width(), h.Height())
+		return RenderCenteredMessage(i18n.T("No history"), h.Width(), h.Height())
 	}
 
 	var b strings.Builder
@@ -285,10 +407,19 @@ func (h *HistoryList) renderItems() string {
 		b.WriteString(RenderScrollDownIndicator(canScrollDown))
 	}
 
+	// Older updates are loaded when scrolling near the end
+	if h.hasMore {
+		if h.loadingMore {
+			b.WriteString(h.Spinner().View() + " " + DimStyle.Render(i18n.T("Loading more updates...")))
+		} else {
+			b.WriteString(DimStyle.Render(i18n.Tf("%d updates loaded, scroll for more", len(h.items))))
+		}
+		b.WriteString("\n")
+	}
+
 	// Add filter bar at bottom when active or applied
-	if h.filter.ActiveOrApplied() {
-		filterBar := RenderFilterBar(&h.filter, itemCount, len(h.items), h.Width())
-		b.WriteString(filterBar)
+	if h.showFilterBar() {
+		b.WriteString(h.renderFilterBar(itemCount))
 		b.WriteString("\n")
 	}
 
@@ -296,6 +427,19 @@ func (h *HistoryList) renderItems() string {
 	return paddedStyle.Render(b.String())
 }
 
+// renderFilterBar renders the history filter chips before the text filter bar
+func (h *HistoryList) renderFilterBar(matchCount int) string {
+	chips := RenderHistoryFilterChips(h.historyFilter)
+	if !h.filter.ActiveOrApplied() {
+		return chips + DimStyle.Render(fmt.Sprintf(" (%d/%d)", matchCount, len(h.items)))
+	}
+	bar := RenderFilterBar(&h.filter, matchCount, len(h.items), h.Width())
+	if chips == "" {
+		return bar
+	}
+	return chips + " " + bar
+}
+
 func (h *HistoryList) renderItem(item HistoryItem, isCursor bool) string {
 	// Cursor indicator
 	cursor := "  "
@@ -321,20 +465,10 @@ func (h *HistoryList) renderItem(item HistoryItem, isCursor bool) string {
 	// User (short form)
 	userStr := ""
 	if item.User != "" {
-		userStr = DimStyle.Render("by " + item.User)
-	}
-
-	// Message (truncated)
-	msgStr := ""
-	if item.Message != "" {
-		msg := item.Message
-		if len(msg) > 30 {
-			msg = msg[:27] + "..."
-		}
-		msgStr = DimStyle.Render(fmt.Sprintf(" %q", msg))
+		userStr = DimStyle.Render(i18n.Tf("by %s", item.User))
 	}
 
-	// Format: > #1  update  succeeded  2024-01-15 10:30  +2 ~1 -0  by user  "commit message"
+	// Format: > #1  update  succeeded  2024-01-15 10:30  +2 ~1 -0  by user  commit message
 	line := fmt.Sprintf("%s%s  %s  %s  %s  %s",
 		cursor,
 		versionStr,
@@ -346,8 +480,12 @@ func (h *HistoryList) renderItem(item HistoryItem, isCursor bool) string {
 	if userStr != "" {
 		line += "  " + userStr
 	}
-	if msgStr != "" {
-		line += msgStr
+
+	// Message, in the rest of the line, as it says what the update was for
+	if item.Message != "" {
+		msg, _, _ := strings.Cut(item.Message, "\n")
+		available := max(h.Width()-4-lipgloss.Width(line)-2, 10)
+		line += "  " + ValueStyle.Render(ansi.Truncate(msg, available, "..."))
 	}
 
 	return line
@@ -363,9 +501,10 @@ func (h *HistoryList) renderChanges(changes map[string]int) string {
 	return RenderResourceChanges(changes, ResourceChangesCompact)
 }
 
-// FilterActive returns whether the filter is currently active (typing) or applied (has text)
+// FilterActive returns whether the filter is currently active (typing) or applied
+// (has text or history filters)
 func (h *HistoryList) FilterActive() bool {
-	return h.filter.ActiveOrApplied()
+	return h.showFilterBar()
 }
 
 // FilterInputActive returns true if the filter is actively receiving input (user is typing)
@@ -0,0 +1,69 @@
+package ui
+
+import "strings"
+
+// iconsEnabled is whether resource types are shown with Nerd Font glyphs, from p5.toml
+var iconsEnabled bool
+
+// SetIcons sets whether resource types are shown with a Nerd Font glyph for their
+// provider. Terminals without a Nerd Font show the glyphs as boxes, so it is off by
+// default.
+func SetIcons(enabled bool) {
+	iconsEnabled = enabled
+}
+
+// Nerd Font glyphs for providers, by package name
+const (
+	iconAWS        = "\uf270"     // nf-fa-aws
+	iconGCP        = "\ue7f1"     // nf-dev-googlecloud
+	iconAzure      = "\uebd8"     // nf-cod-azure
+	iconKubernetes = "\U000f10fe" // nf-md-kubernetes
+	iconRandom     = "\uf074"     // nf-fa-shuffle
+	iconCommand    = "\uf489"     // nf-oct-terminal
+	iconStack      = "\uf1b3"     // nf-fa-cubes
+	iconResource   = "\uf1b2"     // nf-fa-cube, for other providers
+)
+
+// providerIcons maps provider package names to their glyphs
+var providerIcons = map[string]string{
+	"aws":           iconAWS,
+	"aws-native":    iconAWS,
+	"awsx":          iconAWS,
+	"gcp":           iconGCP,
+	"google-native": iconGCP,
+	"azure":         iconAzure,
+	"azure-native":  iconAzure,
+	"azuread":       iconAzure,
+	"kubernetes":    iconKubernetes,
+	"random":        iconRandom,
+	"command":       iconCommand,
+}
+
+// resourceIcon returns the glyph for the provider of a resource type, e.g.
+// "aws:s3/bucket:Bucket", or "" when icons are disabled. Provider resources, e.g.
+// "pulumi:providers:aws", use the glyph of the provider they configure.
+func resourceIcon(resourceType string) string {
+	if !iconsEnabled {
+		return ""
+	}
+	if resourceType == "pulumi:pulumi:Stack" {
+		return iconStack
+	}
+	pkg, _, _ := strings.Cut(resourceType, ":")
+	if provider, ok := strings.CutPrefix(resourceType, "pulumi:providers:"); ok {
+		pkg = provider
+	}
+	if icon, ok := providerIcons[pkg]; ok {
+		return icon
+	}
+	return iconResource
+}
+
+// withResourceIcon prefixes a rendered resource type with its provider glyph, when
+// icons are enabled
+func withResourceIcon(resourceType, rendered string) string {
+	if icon := resourceIcon(resourceType); icon != "" {
+		return icon + " " + rendered
+	}
+	return rendered
+}
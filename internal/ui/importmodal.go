@@ -8,6 +8,9 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/rfhold/p5/internal/i18n"
 )
 
 // ImportSuggestion represents a single import suggestion from a plugin
@@ -18,6 +21,25 @@ type ImportSuggestion struct {
 	PluginName  string
 }
 
+// ImportIDValidation is a plugin's verdict on the typed import ID
+type ImportIDValidation struct {
+	Valid      bool
+	Message    string // Why the ID is invalid, or a note on a valid one
+	PluginName string
+}
+
+// ImportAction represents an action taken by the user in the import modal
+type ImportAction int
+
+const (
+	ImportActionNone    ImportAction = iota
+	ImportActionPreview              // Preview the import of the typed ID
+	ImportActionConfirm              // Import the previewed ID
+)
+
+// maxVisiblePreviewLines is the max number of import preview lines shown at once
+const maxVisiblePreviewLines = 10
+
 // ImportModal is a modal dialog for importing a resource
 type ImportModal struct {
 	ModalBase // Embedded modal base for common functionality
@@ -37,6 +59,17 @@ type ImportModal struct {
 	loadingSuggestions bool
 	showSuggestions    bool
 
+	// Plugin check of the typed import ID (nil until checked)
+	validation *ImportIDValidation
+	validating bool
+
+	// Preview of the state the import would adopt, for previewID
+	previewID     string
+	previewing    bool
+	previewLines  []string
+	previewErr    error
+	previewScroll int
+
 	// State
 	err error
 
@@ -48,7 +81,7 @@ type ImportModal struct {
 // NewImportModal creates a new import modal
 func NewImportModal() *ImportModal {
 	ti := textinput.New()
-	ti.Placeholder = "Enter import ID..."
+	ti.Placeholder = i18n.T("Enter import ID...")
 	ti.CharLimit = 256
 	ti.Width = DefaultInputWidth
 
@@ -74,6 +107,8 @@ func (m *ImportModal) Show(resourceType, resourceName, resourceURN, parentURN st
 	m.selectedIdx = 0
 	m.loadingSuggestions = true
 	m.showSuggestions = false
+	m.ClearValidation()
+	m.ClearPreview()
 }
 
 // SetSuggestions sets the import suggestions from plugins
@@ -91,6 +126,56 @@ func (m *ImportModal) SetLoadingSuggestions(loading bool) {
 	m.loadingSuggestions = loading
 }
 
+// SetValidating marks that plugins are checking the typed import ID
+func (m *ImportModal) SetValidating() {
+	m.validation = nil
+	m.validating = true
+}
+
+// SetValidation shows plugins' verdict on the typed import ID. nil means no
+// plugin could check it.
+func (m *ImportModal) SetValidation(validation *ImportIDValidation) {
+	m.validation = validation
+	m.validating = false
+}
+
+// ClearValidation forgets the verdict, after the import ID changed
+func (m *ImportModal) ClearValidation() {
+	m.validation = nil
+	m.validating = false
+}
+
+// SetPreviewing marks that the import of the typed ID is being previewed
+func (m *ImportModal) SetPreviewing() {
+	m.previewID = m.GetImportID()
+	m.previewing = true
+	m.previewLines = nil
+	m.previewErr = nil
+	m.previewScroll = 0
+}
+
+// SetPreview shows the inputs the import would adopt, or why the preview failed
+func (m *ImportModal) SetPreview(lines []string, err error) {
+	m.previewing = false
+	m.previewLines = lines
+	m.previewErr = err
+	m.previewScroll = 0
+}
+
+// ClearPreview forgets the preview, after the import ID changed
+func (m *ImportModal) ClearPreview() {
+	m.previewID = ""
+	m.previewing = false
+	m.previewLines = nil
+	m.previewErr = nil
+	m.previewScroll = 0
+}
+
+// Previewed returns true when a successful preview of the typed ID is shown
+func (m *ImportModal) Previewed() bool {
+	return m.previewID != "" && m.previewID == m.GetImportID() && !m.previewing && m.previewErr == nil
+}
+
 // Hide hides the import modal
 func (m *ImportModal) Hide() {
 	m.ModalBase.Hide()
@@ -160,11 +245,12 @@ func (m *ImportModal) rebuildFilteredIndex() {
 		return
 	}
 
-	m.filteredIdx = make([]int, 0)
-	for i, s := range m.suggestions {
-		if m.filter.MatchesAny(s.Label, s.Description) {
-			m.filteredIdx = append(m.filteredIdx, i)
-		}
+	m.filteredIdx = m.filter.rankMatches(len(m.suggestions), func(i int) []string {
+		return []string{m.suggestions[i].Label, m.suggestions[i].Description}
+	})
+	if m.filter.Fuzzy() {
+		m.selectedIdx = 0 // Select the best match
+		m.ensureSelectedVisible()
 	}
 
 	// Adjust cursor if it's now outside filtered range
@@ -194,7 +280,7 @@ func (m *ImportModal) ensureSelectedVisible() {
 	}
 }
 
-func (m *ImportModal) handleEnterKey() (confirmed bool) {
+func (m *ImportModal) handleEnterKey() ImportAction {
 	suggestionCount := m.effectiveSuggestionCount()
 	if suggestionCount > 0 && m.showSuggestions {
 		idx := m.effectiveSuggestionIndex(m.selectedIdx)
@@ -204,20 +290,30 @@ func (m *ImportModal) handleEnterKey() (confirmed bool) {
 		m.showSuggestions = false
 		m.filter.Deactivate()
 		m.filteredIdx = nil
-		return false
+		return ImportActionNone
 	}
-	if m.GetImportID() != "" {
-		m.ModalBase.Hide()
-		m.input.Blur()
-		m.filter.Deactivate()
-		return true
+	// An ID a plugin rejected can't be imported until it's changed
+	if m.validation != nil && !m.validation.Valid {
+		return ImportActionNone
+	}
+	if m.GetImportID() == "" || m.previewing {
+		return ImportActionNone
 	}
-	return false
+	// The first enter previews the import, the second confirms it
+	if !m.Previewed() {
+		m.SetPreviewing()
+		return ImportActionPreview
+	}
+	m.ModalBase.Hide()
+	m.input.Blur()
+	m.filter.Deactivate()
+	return ImportActionConfirm
 }
 
 func (m *ImportModal) handleNavigationKey(direction, pageSize int) {
 	suggestionCount := m.effectiveSuggestionCount()
 	if suggestionCount == 0 || !m.showSuggestions {
+		m.scrollPreview(direction * pageSize)
 		return
 	}
 	m.selectedIdx += direction * pageSize
@@ -258,17 +354,23 @@ func (m *ImportModal) handleEscapeKey() {
 	m.input.Blur()
 }
 
-// Update handles key events and returns true if import was confirmed, false if cancelled
-func (m *ImportModal) Update(msg tea.KeyMsg) (confirmed bool, cmd tea.Cmd) {
+// scrollPreview scrolls the import preview, keeping the last page in view
+func (m *ImportModal) scrollPreview(delta int) {
+	maxScroll := max(len(m.previewLines)-maxVisiblePreviewLines, 0)
+	m.previewScroll = min(max(m.previewScroll+delta, 0), maxScroll)
+}
+
+// Update handles key events and returns the action the user took
+func (m *ImportModal) Update(msg tea.KeyMsg) (ImportAction, tea.Cmd) {
 	if !m.Visible() {
-		return false, nil
+		return ImportActionNone, nil
 	}
 
 	// Handle filter activation with "/" when suggestions are showing
 	if key.Matches(msg, Keys.Filter) && m.showSuggestions && !m.filter.Active() {
 		m.filter.Activate()
 		m.rebuildFilteredIndex()
-		return false, nil
+		return ImportActionNone, nil
 	}
 
 	// Forward to filter if active
@@ -276,7 +378,7 @@ func (m *ImportModal) Update(msg tea.KeyMsg) (confirmed bool, cmd tea.Cmd) {
 		cmd, handled := m.filter.Update(msg)
 		if handled {
 			m.rebuildFilteredIndex()
-			return false, cmd
+			return ImportActionNone, cmd
 		}
 	}
 
@@ -285,16 +387,16 @@ func (m *ImportModal) Update(msg tea.KeyMsg) (confirmed bool, cmd tea.Cmd) {
 		return m.handleEnterKey(), nil
 	case "up":
 		m.handleNavigationKey(-1, 1)
-		return false, nil
+		return ImportActionNone, nil
 	case "down":
 		m.handleNavigationKey(1, 1)
-		return false, nil
+		return ImportActionNone, nil
 	case "pgup":
 		m.handleNavigationKey(-1, 8)
-		return false, nil
+		return ImportActionNone, nil
 	case "pgdown":
 		m.handleNavigationKey(1, 8)
-		return false, nil
+		return ImportActionNone, nil
 	case "tab":
 		if len(m.suggestions) > 0 {
 			m.showSuggestions = !m.showSuggestions
@@ -303,16 +405,21 @@ func (m *ImportModal) Update(msg tea.KeyMsg) (confirmed bool, cmd tea.Cmd) {
 				m.filteredIdx = nil
 			}
 		}
-		return false, nil
+		return ImportActionNone, nil
 	}
 
 	if key.Matches(msg, Keys.Escape) {
 		m.handleEscapeKey()
-		return false, nil
+		return ImportActionNone, nil
 	}
 
+	importID := m.GetImportID()
+	var cmd tea.Cmd
 	m.input, cmd = m.input.Update(msg)
-	return false, cmd
+	if m.GetImportID() != importID {
+		m.ClearPreview()
+	}
+	return ImportActionNone, cmd
 }
 
 // renderSuggestionsSection renders the suggestions list with scrolling and filtering
@@ -417,18 +524,82 @@ func (m *ImportModal) renderSuggestionItem(content *strings.Builder, i int) {
 	content.WriteString("\n")
 }
 
+// renderValidation renders plugins' verdict on the typed import ID below the input
+func (m *ImportModal) renderValidation(content *strings.Builder) {
+	var line string
+	switch {
+	case m.validating:
+		line = DimStyle.Render(i18n.T("Checking import ID..."))
+	case m.validation == nil:
+		return
+	case !m.validation.Valid:
+		line = ErrorStyle.Render("✗ " + m.validation.Message)
+	default:
+		message := m.validation.Message
+		if message == "" {
+			message = i18n.T("Import ID is valid")
+		}
+		line = StatusSuccessStyle.Render("✓ " + message)
+	}
+	if m.validation != nil && m.validation.PluginName != "" {
+		line += DimStyle.Render(" [" + m.validation.PluginName + "]")
+	}
+	content.WriteString("\n")
+	content.WriteString(line)
+}
+
+// renderPreview renders the inputs the import would adopt below the validation
+func (m *ImportModal) renderPreview(content *strings.Builder) {
+	if m.previewID == "" || m.previewID != m.GetImportID() {
+		return
+	}
+	content.WriteString("\n\n")
+	content.WriteString(LabelStyle.Render(i18n.T("Import Preview")))
+	switch {
+	case m.previewing:
+		content.WriteString("\n")
+		content.WriteString(DimStyle.Render("  " + i18n.T("Previewing import...")))
+		return
+	case m.previewErr != nil:
+		content.WriteString("\n")
+		content.WriteString(ErrorStyle.Render(m.previewErr.Error()))
+		return
+	case len(m.previewLines) == 0:
+		content.WriteString("\n")
+		content.WriteString(DimStyle.Render("  " + i18n.T("No inputs reported")))
+		return
+	}
+
+	if len(m.previewLines) > maxVisiblePreviewLines {
+		endIdx := min(m.previewScroll+maxVisiblePreviewLines, len(m.previewLines))
+		content.WriteString(DimStyle.Render(fmt.Sprintf(" [%d-%d/%d]", m.previewScroll+1, endIdx, len(m.previewLines))))
+	}
+	endIdx := min(m.previewScroll+maxVisiblePreviewLines, len(m.previewLines))
+	for _, line := range m.previewLines[m.previewScroll:endIdx] {
+		content.WriteString("\n")
+		content.WriteString(ValueStyle.Render("  " + ansi.Truncate(line, DefaultInputWidth+10, "...")))
+	}
+	if len(m.previewLines) > maxVisiblePreviewLines {
+		maxScroll := len(m.previewLines) - maxVisiblePreviewLines
+		if hint := RenderScrollHint(m.previewScroll > 0, m.previewScroll < maxScroll, "  "); hint != "" {
+			content.WriteString("\n")
+			content.WriteString(hint)
+		}
+	}
+}
+
 // View renders the import modal
 func (m *ImportModal) View() string {
-	title := DialogTitleStyle.Render("Import Resource")
+	title := DialogTitleStyle.Render(i18n.T("Import Resource"))
 
 	var content strings.Builder
 
 	// Resource info (always visible, not scrolled)
-	content.WriteString(DimStyle.Render("Type: "))
+	content.WriteString(DimStyle.Render(i18n.T("Type: ")))
 	content.WriteString(ValueStyle.Render(m.resourceType))
 	content.WriteString("\n")
 
-	content.WriteString(DimStyle.Render("Name: "))
+	content.WriteString(DimStyle.Render(i18n.T("Name: ")))
 	content.WriteString(ValueStyle.Render(m.resourceName))
 	content.WriteString("\n\n")
 
@@ -437,9 +608,11 @@ func (m *ImportModal) View() string {
 	content.WriteString("\n")
 
 	// Import ID input (always visible, not scrolled)
-	content.WriteString(LabelStyle.Render("Import ID"))
+	content.WriteString(LabelStyle.Render(i18n.T("Import ID")))
 	content.WriteString("\n")
 	content.WriteString(m.input.View())
+	m.renderValidation(&content)
+	m.renderPreview(&content)
 
 	// Error if any
 	if m.err != nil {
@@ -448,7 +621,10 @@ func (m *ImportModal) View() string {
 	}
 
 	// Footer hints
-	footer := DimStyle.Render("\ntab suggestions  enter select/confirm  esc cancel")
+	footer := DimStyle.Render("\ntab suggestions  enter select/preview  esc cancel")
+	if m.Previewed() {
+		footer = DimStyle.Render("\ntab suggestions  enter import  esc cancel")
+	}
 
 	dialog := DialogStyle.Render(lipgloss.JoinVertical(lipgloss.Left, title, content.String(), footer))
 	return m.CenterDialog(dialog)
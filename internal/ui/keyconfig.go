@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// defaultKeys is the built-in keymap that configured bindings are applied on top of
+var defaultKeys = Keys
+
+// keyAction is a configurable keybinding and the name it is configured under
+type keyAction struct {
+	Name    string
+	Binding *key.Binding
+}
+
+// actions returns the configurable bindings in k, named as in the [keys] section of p5.toml
+func (k *KeyMap) actions() []keyAction {
+	return []keyAction{
+		{"up", &k.Up},
+		{"down", &k.Down},
+		{"page_up", &k.PageUp},
+		{"page_down", &k.PageDown},
+		{"home", &k.Home},
+		{"end", &k.End},
+		{"goto_resource", &k.GotoResource},
+		{"next_change", &k.NextChange},
+		{"prev_change", &k.PrevChange},
+		{"cycle_grouping", &k.CycleGrouping},
+		{"toggle_collapse", &k.ToggleCollapse},
+		{"toggle_collapse_all", &k.ToggleCollapseAll},
+		{"pin_resource", &k.PinResource},
+		{"toggle_target", &k.ToggleTarget},
+		{"toggle_replace", &k.ToggleReplace},
+		{"toggle_exclude", &k.ToggleExclude},
+		{"clear_flags", &k.ClearFlags},
+		{"clear_all_flags", &k.ClearAllFlags},
+		{"clear_saved", &k.ClearSaved},
+		{"undo_flags", &k.UndoFlags},
+		{"redo_flags", &k.RedoFlags},
+		{"target_sets", &k.TargetSets},
+		{"visual_mode", &k.VisualMode},
+		{"toggle_select", &k.ToggleSelect},
+		{"escape", &k.Escape},
+		{"preview_up", &k.PreviewUp},
+		{"preview_refresh", &k.PreviewRefresh},
+		{"preview_destroy", &k.PreviewDestroy},
+		{"quick_check", &k.QuickCheck},
+		{"execute_up", &k.ExecuteUp},
+		{"execute_refresh", &k.ExecuteRefresh},
+		{"execute_destroy", &k.ExecuteDestroy},
+		{"queue_refresh_up", &k.QueueRefreshUp},
+		{"run_workflow", &k.RunWorkflow},
+		{"save_plan", &k.SavePlan},
+		{"toggle_refresh", &k.ToggleRefresh},
+		{"toggle_continue_on_error", &k.ToggleContinueOnError},
+		{"toggle_raw_json", &k.ToggleRawJSON},
+		{"reveal_secret", &k.RevealSecret},
+		{"view_value", &k.ViewValue},
+		{"detect_drift", &k.DetectDrift},
+		{"accept_drift", &k.AcceptDrift},
+		{"revert_drift", &k.RevertDrift},
+		{"copy_resource", &k.CopyResource},
+		{"copy_all_resources", &k.CopyAllResources},
+		{"export_resources", &k.ExportResources},
+		{"copy_markdown", &k.CopyMarkdown},
+		{"toggle_details", &k.ToggleDetails},
+		{"widen_details", &k.WidenDetails},
+		{"narrow_details", &k.NarrowDetails},
+		{"select_stack", &k.SelectStack},
+		{"select_workspace", &k.SelectWorkspace},
+		{"pin_workspace", &k.PinWorkspace},
+		{"browse_cloud_stacks", &k.BrowseCloudStacks},
+		{"reload_stack", &k.ReloadStack},
+		{"view_history", &k.ViewHistory},
+		{"history_diff", &k.HistoryDiff},
+		{"filter_history", &k.FilterHistory},
+		{"view_environments", &k.ViewEnvironments},
+		{"view_warnings", &k.ViewWarnings},
+		{"toggle_diagnostics", &k.ToggleDiagnostics},
+		{"view_timings", &k.ViewTimings},
+		{"view_logs", &k.ViewLogs},
+		{"view_about", &k.ViewAbout},
+		{"view_dashboard", &k.ViewDashboard},
+		{"plugin_index", &k.PluginIndex},
+		{"plugin_status", &k.PluginStatus},
+		{"stack_tags", &k.StackTags},
+		{"copy_config", &k.CopyConfig},
+		{"import", &k.Import},
+		{"bulk_import", &k.BulkImport},
+		{"delete_from_state", &k.DeleteFromState},
+		{"protect", &k.Protect},
+		{"unprotect", &k.Unprotect},
+		{"edit_note", &k.EditNote},
+		{"repair_state", &k.RepairState},
+		{"export_state", &k.ExportState},
+		{"import_state", &k.ImportState},
+		{"edit_state", &k.EditState},
+		{"open_resource", &k.OpenResource},
+		{"open_source", &k.OpenSource},
+		{"follow_reference", &k.FollowReference},
+		{"filter", &k.Filter},
+		{"help", &k.Help},
+		{"toggle_hints", &k.ToggleHints},
+		{"quit", &k.Quit},
+	}
+}
+
+// ApplyKeyBindings replaces Keys with the default keymap remapped by bindings, a map of
+// action names to the keys that trigger them. Keys is left unchanged if an action is
+// unknown, has no keys, or two actions end up sharing a key.
+func ApplyKeyBindings(bindings map[string][]string) error {
+	keys := defaultKeys
+	byName := make(map[string]*key.Binding)
+	for _, action := range keys.actions() {
+		byName[action.Name] = action.Binding
+	}
+
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		binding, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("unknown key action %q", name)
+		}
+		remapped := bindings[name]
+		if len(remapped) == 0 {
+			return fmt.Errorf("key action %q has no keys", name)
+		}
+		*binding = key.NewBinding(
+			key.WithKeys(remapped...),
+			key.WithHelp(helpKeyLabel(remapped), binding.Help().Desc),
+		)
+	}
+
+	if err := keys.checkConflicts(); err != nil {
+		return err
+	}
+	Keys = keys
+	return nil
+}
+
+// checkConflicts returns an error naming the first key bound to more than one action
+func (k *KeyMap) checkConflicts() error {
+	owner := make(map[string]string)
+	for _, action := range k.actions() {
+		for _, bound := range action.Binding.Keys() {
+			if other, ok := owner[bound]; ok {
+				return fmt.Errorf("key %q is bound to both %s and %s", bound, other, action.Name)
+			}
+			owner[bound] = action.Name
+		}
+	}
+	return nil
+}
+
+// helpKeyLabel returns how remapped keys are shown in help, e.g. "↑/k"
+func helpKeyLabel(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		switch k {
+		case "up":
+			labels[i] = "↑"
+		case "down":
+			labels[i] = "↓"
+		case "pgdown":
+			labels[i] = "pgdn"
+		case " ":
+			labels[i] = "space"
+		default:
+			labels[i] = k
+		}
+	}
+	return strings.Join(labels, "/")
+}
@@ -12,12 +12,27 @@ type KeyMap struct {
 	Home     key.Binding
 	End      key.Binding
 
+	// Jumping
+	GotoResource key.Binding
+	NextChange   key.Binding
+	PrevChange   key.Binding
+
+	// Grouping
+	CycleGrouping     key.Binding
+	ToggleCollapse    key.Binding
+	ToggleCollapseAll key.Binding
+	PinResource       key.Binding
+
 	// Selection flags (uppercase)
 	ToggleTarget  key.Binding
 	ToggleReplace key.Binding
 	ToggleExclude key.Binding
 	ClearFlags    key.Binding
 	ClearAllFlags key.Binding
+	ClearSaved    key.Binding
+	UndoFlags     key.Binding
+	RedoFlags     key.Binding
+	TargetSets    key.Binding
 
 	// Visual mode
 	VisualMode   key.Binding
@@ -28,46 +43,123 @@ type KeyMap struct {
 	PreviewUp      key.Binding
 	PreviewRefresh key.Binding
 	PreviewDestroy key.Binding
+	QuickCheck     key.Binding
 
 	// Operations - Execute (ctrl+key)
 	ExecuteUp      key.Binding
 	ExecuteRefresh key.Binding
 	ExecuteDestroy key.Binding
+	QueueRefreshUp key.Binding
+	RunWorkflow    key.Binding
+	SavePlan       key.Binding
+	ToggleRefresh  key.Binding
+	// Continue past failed resources in up and destroy
+	ToggleContinueOnError key.Binding
+
+	// Diff display
+	ToggleRawJSON key.Binding
+	RevealSecret  key.Binding
+	ViewValue     key.Binding
+
+	// Drift detection
+	DetectDrift key.Binding
+	AcceptDrift key.Binding
+	RevertDrift key.Binding
 
 	// Copy resource
 	CopyResource     key.Binding
 	CopyAllResources key.Binding
+	ExportResources  key.Binding
+	CopyMarkdown     key.Binding
 
 	// Details panel
 	ToggleDetails key.Binding
+	WidenDetails  key.Binding
+	NarrowDetails key.Binding
 
 	// Stack selector
 	SelectStack key.Binding
 
 	// Workspace selector
 	SelectWorkspace key.Binding
+	PinWorkspace    key.Binding
+
+	// Browse the stacks of every project in the organization
+	BrowseCloudStacks key.Binding
+
+	// Reload the stack, e.g. after it was updated elsewhere
+	ReloadStack key.Binding
 
 	// History view
-	ViewHistory key.Binding
+	ViewHistory   key.Binding
+	HistoryDiff   key.Binding
+	FilterHistory key.Binding
+
+	// ESC environments
+	ViewEnvironments key.Binding
+
+	// Preview warnings
+	ViewWarnings      key.Binding
+	ToggleDiagnostics key.Binding
+	ViewTimings       key.Binding
+
+	// Debug log viewer
+	ViewLogs key.Binding
+
+	// Diagnostics about p5, Pulumi and the workspace
+	ViewAbout key.Binding
+
+	// Multi-stack dashboard
+	ViewDashboard key.Binding
+
+	// Plugin index
+	PluginIndex key.Binding
+
+	// Plugin credential status
+	PluginStatus key.Binding
+
+	// Stack tags
+	StackTags key.Binding
+
+	// Copy config from another stack
+	CopyConfig key.Binding
 
 	// Import
-	Import key.Binding
+	Import     key.Binding
+	BulkImport key.Binding
 
 	// Delete from state
 	DeleteFromState key.Binding
 
-	// Toggle protection
-	ToggleProtect key.Binding
+	// Protection
+	Protect   key.Binding
+	Unprotect key.Binding
+
+	// Resource notes
+	EditNote key.Binding
+
+	// Repair inconsistent state
+	RepairState key.Binding
+
+	// Export and import stack state
+	ExportState key.Binding
+	ImportState key.Binding
+
+	// Edit a resource's state in $EDITOR
+	EditState key.Binding
 
 	// Open resource
-	OpenResource key.Binding
+	OpenResource    key.Binding
+	OpenSource      key.Binding
+	FollowReference key.Binding
 
 	// Filter
 	Filter key.Binding
 
 	// General
-	Help key.Binding
-	Quit key.Binding
+	Help        key.Binding
+	ToggleHints key.Binding
+	Quit        key.Binding
 }
 
 // Keys is the default keybinding configuration
@@ -98,6 +190,38 @@ var Keys = KeyMap{
 		key.WithHelp("G", "bottom"),
 	),
 
+	// Jumping
+	GotoResource: key.NewBinding(
+		key.WithKeys(":"),
+		key.WithHelp(":", "go to"),
+	),
+	NextChange: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next change"),
+	),
+	PrevChange: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "previous change"),
+	),
+
+	// Grouping
+	CycleGrouping: key.NewBinding(
+		key.WithKeys("alt+g"),
+		key.WithHelp("alt+g", "group by"),
+	),
+	ToggleCollapse: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "collapse/expand"),
+	),
+	ToggleCollapseAll: key.NewBinding(
+		key.WithKeys("Z"),
+		key.WithHelp("Z", "collapse/expand all"),
+	),
+	PinResource: key.NewBinding(
+		key.WithKeys("alt+p"),
+		key.WithHelp("alt+p", "pin"),
+	),
+
 	// Selection flags (uppercase)
 	ToggleTarget: key.NewBinding(
 		key.WithKeys("T"),
@@ -119,6 +243,22 @@ var Keys = KeyMap{
 		key.WithKeys("C"),
 		key.WithHelp("C", "clear all flags"),
 	),
+	ClearSaved: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "clear saved flags"),
+	),
+	UndoFlags: key.NewBinding(
+		key.WithKeys("ctrl+z"),
+		key.WithHelp("ctrl+z", "undo flags"),
+	),
+	RedoFlags: key.NewBinding(
+		key.WithKeys("ctrl+y"),
+		key.WithHelp("ctrl+y", "redo flags"),
+	),
+	TargetSets: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "target sets"),
+	),
 
 	// Visual mode
 	VisualMode: key.NewBinding(
@@ -147,6 +287,10 @@ var Keys = KeyMap{
 		key.WithKeys("d"),
 		key.WithHelp("d", "preview destroy"),
 	),
+	QuickCheck: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "quick check"),
+	),
 
 	// Operations - Execute (ctrl+key)
 	ExecuteUp: key.NewBinding(
@@ -161,6 +305,54 @@ var Keys = KeyMap{
 		key.WithKeys("ctrl+d"),
 		key.WithHelp("ctrl+d", "execute destroy"),
 	),
+	QueueRefreshUp: key.NewBinding(
+		key.WithKeys("Q"),
+		key.WithHelp("Q", "queue refresh, preview, up"),
+	),
+	RunWorkflow: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "run workflow"),
+	),
+	SavePlan: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "preview up and save plan"),
+	),
+	ToggleRefresh: key.NewBinding(
+		key.WithKeys("alt+r"),
+		key.WithHelp("alt+r", "toggle refresh with up"),
+	),
+	ToggleContinueOnError: key.NewBinding(
+		key.WithKeys("alt+e"),
+		key.WithHelp("alt+e", "toggle continue on error"),
+	),
+
+	// Diff display
+	ToggleRawJSON: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "toggle raw json diff"),
+	),
+	RevealSecret: key.NewBinding(
+		key.WithKeys("alt+s"),
+		key.WithHelp("alt+s", "reveal secret"),
+	),
+	ViewValue: key.NewBinding(
+		key.WithKeys("alt+v"),
+		key.WithHelp("alt+v", "view value"),
+	),
+
+	// Drift detection
+	DetectDrift: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "detect drift"),
+	),
+	AcceptDrift: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "accept drift"),
+	),
+	RevertDrift: key.NewBinding(
+		key.WithKeys("U"),
+		key.WithHelp("U", "revert drift"),
+	),
 
 	// Copy resource
 	CopyResource: key.NewBinding(
@@ -171,12 +363,28 @@ var Keys = KeyMap{
 		key.WithKeys("Y"),
 		key.WithHelp("Y", "copy all resources JSON"),
 	),
+	ExportResources: key.NewBinding(
+		key.WithKeys("alt+y"),
+		key.WithHelp("alt+y", "export resource list"),
+	),
+	CopyMarkdown: key.NewBinding(
+		key.WithKeys("alt+m"),
+		key.WithHelp("alt+m", "copy preview as markdown"),
+	),
 
 	// Details panel
 	ToggleDetails: key.NewBinding(
 		key.WithKeys("D"),
 		key.WithHelp("D", "toggle details"),
 	),
+	WidenDetails: key.NewBinding(
+		key.WithKeys("<"),
+		key.WithHelp("<", "widen details"),
+	),
+	NarrowDetails: key.NewBinding(
+		key.WithKeys(">"),
+		key.WithHelp(">", "narrow details"),
+	),
 
 	// Stack selector
 	SelectStack: key.NewBinding(
@@ -189,18 +397,110 @@ var Keys = KeyMap{
 		key.WithKeys("w"),
 		key.WithHelp("w", "select workspace"),
 	),
+	PinWorkspace: key.NewBinding(
+		key.WithKeys("*"),
+		key.WithHelp("*", "pin workspace"),
+	),
+	BrowseCloudStacks: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "browse org stacks"),
+	),
+
+	// Reload stack
+	ReloadStack: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "reload stack"),
+	),
 
 	// History view
 	ViewHistory: key.NewBinding(
 		key.WithKeys("h"),
 		key.WithHelp("h", "view history"),
 	),
+	HistoryDiff: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "diff with previous update"),
+	),
+	FilterHistory: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "filter history"),
+	),
+
+	// ESC environments
+	ViewEnvironments: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "view environments"),
+	),
+
+	// Preview warnings
+	ViewWarnings: key.NewBinding(
+		key.WithKeys("W"),
+		key.WithHelp("W", "preview warnings"),
+	),
+
+	// Engine log strip in preview view
+	ToggleDiagnostics: key.NewBinding(
+		key.WithKeys("l"),
+		key.WithHelp("l", "engine log"),
+	),
+
+	// Slowest resources of the last operation
+	ViewTimings: key.NewBinding(
+		key.WithKeys("ctrl+t"),
+		key.WithHelp("ctrl+t", "slowest resources"),
+	),
+
+	// Debug log viewer
+	ViewLogs: key.NewBinding(
+		key.WithKeys("~"),
+		key.WithHelp("~", "debug logs"),
+	),
+
+	// Diagnostics about p5, Pulumi and the workspace
+	ViewAbout: key.NewBinding(
+		key.WithKeys("ctrl+a"),
+		key.WithHelp("ctrl+a", "about"),
+	),
+
+	// Multi-stack dashboard
+	ViewDashboard: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "stacks dashboard"),
+	),
+
+	// Plugin index
+	PluginIndex: key.NewBinding(
+		key.WithKeys("M"),
+		key.WithHelp("M", "plugin index"),
+	),
+
+	// Plugin credential status
+	PluginStatus: key.NewBinding(
+		key.WithKeys("K"),
+		key.WithHelp("K", "plugin status"),
+	),
+
+	// Stack tags
+	StackTags: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "stack tags"),
+	),
+
+	// Copy config from another stack
+	CopyConfig: key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "copy config"),
+	),
 
 	// Import
 	Import: key.NewBinding(
 		key.WithKeys("I"),
 		key.WithHelp("I", "import resource"),
 	),
+	BulkImport: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "bulk import"),
+	),
 
 	// Delete from state
 	DeleteFromState: key.NewBinding(
@@ -208,10 +508,42 @@ var Keys = KeyMap{
 		key.WithHelp("x", "delete from state"),
 	),
 
-	// Toggle protection
-	ToggleProtect: key.NewBinding(
+	// Protection
+	Protect: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "protect"),
+	),
+	Unprotect: key.NewBinding(
 		key.WithKeys("P"),
-		key.WithHelp("P", "toggle protect"),
+		key.WithHelp("P", "unprotect"),
+	),
+
+	// Resource notes
+	EditNote: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "edit note"),
+	),
+
+	// Repair inconsistent state
+	RepairState: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "repair state"),
+	),
+
+	// Export and import stack state
+	ExportState: key.NewBinding(
+		key.WithKeys("ctrl+e"),
+		key.WithHelp("ctrl+e", "export state"),
+	),
+	ImportState: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("ctrl+o", "import state"),
+	),
+
+	// Edit a resource's state in $EDITOR
+	EditState: key.NewBinding(
+		key.WithKeys("ctrl+x"),
+		key.WithHelp("ctrl+x", "edit state"),
 	),
 
 	// Open resource
@@ -219,6 +551,14 @@ var Keys = KeyMap{
 		key.WithKeys("o"),
 		key.WithHelp("o", "open resource"),
 	),
+	OpenSource: key.NewBinding(
+		key.WithKeys("ctrl+l"),
+		key.WithHelp("ctrl+l", "open source"),
+	),
+	FollowReference: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "follow stack reference"),
+	),
 
 	// Filter
 	Filter: key.NewBinding(
@@ -231,6 +571,10 @@ var Keys = KeyMap{
 		key.WithKeys("?"),
 		key.WithHelp("?", "help"),
 	),
+	ToggleHints: key.NewBinding(
+		key.WithKeys("."),
+		key.WithHelp(".", "all footer keys"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
@@ -245,13 +589,14 @@ func (k *KeyMap) ShortHelp() []key.Binding {
 // FullHelp returns keybindings for the full help view
 func (k *KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.PageUp, k.PageDown, k.Home, k.End},
+		{k.Up, k.Down, k.PageUp, k.PageDown, k.Home, k.End, k.GotoResource, k.NextChange, k.PrevChange, k.CycleGrouping, k.ToggleCollapse, k.ToggleCollapseAll, k.PinResource},
 		{k.VisualMode, k.ToggleSelect, k.Escape},
-		{k.ToggleTarget, k.ToggleReplace, k.ToggleExclude, k.ClearFlags, k.ClearAllFlags},
-		{k.PreviewUp, k.PreviewRefresh, k.PreviewDestroy},
-		{k.ExecuteUp, k.ExecuteRefresh, k.ExecuteDestroy},
-		{k.CopyResource, k.ToggleDetails, k.SelectStack, k.SelectWorkspace, k.ViewHistory},
-		{k.Import, k.DeleteFromState, k.ToggleProtect, k.OpenResource},
-		{k.Help, k.Quit},
+		{k.ToggleTarget, k.ToggleReplace, k.ToggleExclude, k.ClearFlags, k.ClearAllFlags, k.ClearSaved, k.UndoFlags, k.RedoFlags, k.TargetSets},
+		{k.PreviewUp, k.PreviewRefresh, k.PreviewDestroy, k.QuickCheck},
+		{k.ExecuteUp, k.ExecuteRefresh, k.ExecuteDestroy, k.QueueRefreshUp, k.RunWorkflow, k.SavePlan, k.ToggleRefresh, k.ToggleContinueOnError},
+		{k.DetectDrift, k.AcceptDrift, k.RevertDrift},
+		{k.CopyResource, k.ExportResources, k.CopyMarkdown, k.ToggleDetails, k.WidenDetails, k.NarrowDetails, k.ToggleRawJSON, k.RevealSecret, k.ViewValue, k.SelectStack, k.SelectWorkspace, k.PinWorkspace, k.BrowseCloudStacks, k.ReloadStack, k.ViewHistory, k.HistoryDiff, k.FilterHistory, k.ViewEnvironments, k.ViewWarnings, k.ToggleDiagnostics, k.ViewTimings, k.ViewLogs, k.ViewAbout, k.ViewDashboard, k.PluginIndex, k.PluginStatus, k.StackTags, k.CopyConfig},
+		{k.Import, k.BulkImport, k.DeleteFromState, k.Protect, k.Unprotect, k.RepairState, k.ExportState, k.ImportState, k.EditState, k.EditNote, k.OpenResource, k.OpenSource, k.FollowReference},
+		{k.Help, k.ToggleHints, k.Quit},
 	}
 }
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// LockScreen covers the whole UI after inactivity so that stack details and
+// secrets are not left on screen. It unlocks on a key press, or on entering a
+// passphrase when one is required.
+type LockScreen struct {
+	ModalBase // Embedded modal base for common functionality
+
+	stackName         string
+	requirePassphrase bool
+	prompting         bool // Passphrase input is shown
+
+	// Text input for the passphrase
+	input textinput.Model
+
+	err string
+}
+
+// NewLockScreen creates a new lock screen
+func NewLockScreen() *LockScreen {
+	ti := textinput.New()
+	ti.Placeholder = i18n.T("Enter passphrase...")
+	ti.EchoMode = textinput.EchoPassword
+	ti.EchoCharacter = '•'
+	ti.CharLimit = 256
+	ti.Width = DefaultInputWidth
+
+	return &LockScreen{
+		input: ti,
+	}
+}
+
+// Lock shows the lock screen for a stack
+func (l *LockScreen) Lock(stackName string, requirePassphrase bool) {
+	l.stackName = stackName
+	l.requirePassphrase = requirePassphrase
+	l.prompting = false
+	l.err = ""
+	l.input.SetValue("")
+	l.input.Blur()
+	l.ModalBase.Show()
+}
+
+// Unlock hides the lock screen
+func (l *LockScreen) Unlock() {
+	l.ModalBase.Hide()
+	l.prompting = false
+	l.err = ""
+	l.input.SetValue("")
+	l.input.Blur()
+}
+
+// Passphrase returns the entered passphrase
+func (l *LockScreen) Passphrase() string {
+	return l.input.Value()
+}
+
+// SetError shows an error, such as a wrong passphrase, and clears the input
+func (l *LockScreen) SetError(err string) {
+	l.err = err
+	l.input.SetValue("")
+}
+
+// Update handles key events and returns true when an unlock is attempted: on any
+// key without a passphrase, or when the passphrase is submitted
+func (l *LockScreen) Update(msg tea.KeyMsg) (submitted bool, cmd tea.Cmd) {
+	if !l.Visible() {
+		return false, nil
+	}
+	if !l.requirePassphrase {
+		return true, nil
+	}
+
+	// The first key press asks for the passphrase
+	if !l.prompting {
+		l.prompting = true
+		l.err = ""
+		l.input.SetValue("")
+		return false, l.input.Focus()
+	}
+
+	switch {
+	case msg.String() == "enter":
+		return true, nil
+	case key.Matches(msg, Keys.Escape):
+		l.prompting = false
+		l.input.SetValue("")
+		l.input.Blur()
+		return false, nil
+	}
+
+	l.input, cmd = l.input.Update(msg)
+	return false, cmd
+}
+
+// View renders the lock screen over the whole terminal
+func (l *LockScreen) View() string {
+	title := DialogTitleStyle.Render(i18n.T("p5 is locked"))
+
+	var content strings.Builder
+	if l.stackName != "" {
+		content.WriteString(DimStyle.Render(i18n.T("Stack: ")))
+		content.WriteString(ValueStyle.Render(l.stackName))
+		content.WriteString("\n")
+	}
+	content.WriteString(DimStyle.Render(i18n.T("Locked after a period of inactivity")))
+
+	if l.prompting {
+		content.WriteString("\n\n")
+		content.WriteString(LabelStyle.Render(i18n.T("Passphrase")))
+		content.WriteString("\n")
+		content.WriteString(l.input.View())
+	}
+
+	if l.err != "" {
+		content.WriteString("\n\n")
+		content.WriteString(ErrorStyle.Render(l.err))
+	}
+
+	var footer string
+	switch {
+	case l.prompting:
+		footer = i18n.T("enter unlock  esc back")
+	case l.requirePassphrase:
+		footer = i18n.T("press any key to enter the passphrase")
+	default:
+		footer = i18n.T("press any key to unlock")
+	}
+
+	return l.RenderDialog(title, content.String(), DimStyle.Render("\n"+footer))
+}
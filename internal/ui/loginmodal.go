@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+)
+
+// LoginModal wraps StepModal to log in to a backend when Pulumi has no login
+type LoginModal struct {
+	*StepModal
+}
+
+// NewLoginModal creates a new login modal
+func NewLoginModal() *LoginModal {
+	return &LoginModal{StepModal: NewStepModal(i18n.T("Backend Login"))}
+}
+
+// Show prompts for the backend to log in to and its access token. reason is the
+// error that needed the login.
+func (m *LoginModal) Show(reason error) {
+	var info []InfoLine
+	if reason != nil {
+		msg, _, _ := strings.Cut(strings.TrimSpace(reason.Error()), "\n")
+		info = []InfoLine{{Label: i18n.T("Error"), Value: msg}}
+	}
+	m.SetSteps([]StepModalStep{
+		{
+			Title:            i18n.T("Select backend"),
+			InfoLines:        info,
+			InputLabel:       i18n.T("Backend URL"),
+			InputPlaceholder: "s3://bucket, azblob://container, gs://bucket...",
+			Suggestions: []StepSuggestion{
+				{ID: "https://api.pulumi.com", Label: "Pulumi Cloud", Description: "https://api.pulumi.com"},
+				{ID: "file://~", Label: i18n.T("Local"), Description: i18n.T("State in ~/.pulumi")},
+			},
+		},
+		{
+			Title:            i18n.T("Enter access token"),
+			InputLabel:       i18n.T("Access token"),
+			InputPlaceholder: i18n.T("Paste access token..."),
+			Warning:          i18n.T("Leave empty to log in with your browser"),
+			PasswordMode:     true,
+			Optional:         true,
+		},
+	})
+	m.StepModal.Show()
+}
+
+// Backend returns the URL of the backend to log in to
+func (m *LoginModal) Backend() string {
+	return m.GetResult(0)
+}
+
+// NeedsToken reports whether the backend logs in with an access token
+func (m *LoginModal) NeedsToken() bool {
+	return pulumi.IsCloudBackend(m.Backend())
+}
+
+// Token returns the access token entered, empty to log in with the browser
+func (m *LoginModal) Token() string {
+	if !m.NeedsToken() {
+		return ""
+	}
+	return m.GetResult(1)
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/telemetry"
+)
+
+// logLevels are the minimum levels the log viewer cycles through
+var logLevels = []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+
+// LogViewer is a floating panel showing the recent debug log records, newest
+// last, filtered by a minimum level. It follows new records until scrolled up.
+type LogViewer struct {
+	PanelBase // Embed common panel functionality
+
+	entries  []telemetry.LogEntry
+	minLevel slog.Level
+	follow   bool
+}
+
+// NewLogViewer creates a new log viewer component
+func NewLogViewer() *LogViewer {
+	return &LogViewer{minLevel: slog.LevelDebug, follow: true}
+}
+
+// Show shows the viewer following the newest records
+func (p *LogViewer) Show() {
+	p.PanelBase.Show()
+	p.follow = true
+}
+
+// SetEntries sets the captured log records, oldest first
+func (p *LogViewer) SetEntries(entries []telemetry.LogEntry) {
+	p.entries = entries
+}
+
+// MinLevel returns the minimum level of the records shown
+func (p *LogViewer) MinLevel() slog.Level {
+	return p.minLevel
+}
+
+// CycleLevel raises the minimum level shown, wrapping back to debug after error
+func (p *LogViewer) CycleLevel() {
+	next := 0
+	for i, level := range logLevels {
+		if level == p.minLevel {
+			next = (i + 1) % len(logLevels)
+		}
+	}
+	p.minLevel = logLevels[next]
+	p.follow = true
+}
+
+// VisibleEntries returns the records at or above the minimum level
+func (p *LogViewer) VisibleEntries() []telemetry.LogEntry {
+	var visible []telemetry.LogEntry
+	for _, entry := range p.entries {
+		if entry.Level >= p.minLevel {
+			visible = append(visible, entry)
+		}
+	}
+	return visible
+}
+
+// Scroll moves the view by delta lines, following new records again once the
+// end is reached
+func (p *LogViewer) Scroll(delta int) {
+	lineCount := len(p.VisibleEntries())
+	if p.follow {
+		p.SetScrollOffset(p.maxScrollOffset(lineCount))
+	}
+	p.scrollLines(delta, lineCount)
+	p.follow = p.ScrollOffset() == p.maxScrollOffset(lineCount)
+}
+
+// View renders the log viewer
+func (p *LogViewer) View() string {
+	if !p.Visible() || p.Width() == 0 || p.Height() == 0 {
+		return ""
+	}
+
+	entries := p.VisibleEntries()
+	var content string
+	if len(entries) == 0 {
+		content = DimStyle.Render(i18n.T("No log records at this level yet"))
+	} else {
+		// Content width inside border(2) and padding(4)
+		width := max(p.Width()-6, 20)
+		lines := make([]string, len(entries))
+		for i, entry := range entries {
+			lines[i] = renderLogEntry(entry, width)
+		}
+		content = strings.Join(lines, "\n")
+	}
+
+	if p.follow {
+		p.SetScrollOffset(p.maxScrollOffset(len(entries)))
+	}
+
+	header := i18n.T("Debug Logs") + DimStyle.Render("  ·  "+i18n.T("level")+" ≥ "+p.minLevel.String()+"  ·  l "+i18n.T("change level"))
+	result := RenderDetailPanel(DetailPanelContent{
+		Header:       header,
+		Content:      content,
+		Width:        p.Width(),
+		Height:       p.Height(),
+		ScrollOffset: p.ScrollOffset(),
+	})
+
+	if result.NewScrollOffset != p.ScrollOffset() {
+		p.SetScrollOffset(result.NewScrollOffset)
+	}
+
+	return result.Rendered
+}
+
+// renderLogEntry renders a record on one line, truncated to width
+func renderLogEntry(entry telemetry.LogEntry, width int) string {
+	line := entry.Time.Format("15:04:05") + " " + levelLabel(entry.Level) + " " + entry.Message
+	if entry.Attrs != "" {
+		line += " " + entry.Attrs
+	}
+	line = ansi.Truncate(line, width, "...")
+
+	switch {
+	case entry.Level >= slog.LevelError:
+		return ErrorStyle.Render(line)
+	case entry.Level >= slog.LevelWarn:
+		return WarningStyle.Render(line)
+	case entry.Level < slog.LevelInfo:
+		return DimStyle.Render(line)
+	}
+	return ValueStyle.Render(line)
+}
+
+// levelLabel returns the fixed width label of a level
+func levelLabel(level slog.Level) string {
+	return fmt.Sprintf("%-5s", level.String())
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+)
+
+// NewProjectModal wraps StepModal to scaffold a project from a Pulumi template,
+// like `pulumi new`
+type NewProjectModal struct {
+	*StepModal
+
+	// Directory relative project directories are resolved against
+	baseDir string
+}
+
+const (
+	stepTemplate       = 0
+	stepProjectName    = 1
+	stepProjectDir     = 2
+	stepProjectStack   = 3
+	defaultNewStack    = "dev"
+	newProjectTemplate = "https://github.com/pulumi/templates"
+)
+
+// NewNewProjectModal creates a new project modal
+func NewNewProjectModal() *NewProjectModal {
+	m := &NewProjectModal{StepModal: NewStepModal(i18n.T("New Project"))}
+	m.configureSteps()
+	return m
+}
+
+// configureSteps sets up the modal steps
+func (m *NewProjectModal) configureSteps() {
+	steps := []StepModalStep{
+		{
+			Title:            i18n.T("Select or enter a template"),
+			InputLabel:       i18n.T("Template"),
+			InputPlaceholder: i18n.T("Enter template name, URL or path..."),
+			Suggestions:      templateSuggestions(),
+		},
+		{
+			Title:            i18n.T("Enter project name"),
+			InputLabel:       i18n.T("Project name"),
+			InputPlaceholder: i18n.T("Enter project name..."),
+			Validate:         pulumi.ValidateProjectName,
+		},
+		{
+			Title:            i18n.T("Select or enter project directory"),
+			InputLabel:       i18n.T("Directory"),
+			InputPlaceholder: i18n.T("Enter an empty or new directory..."),
+			Validate:         func(dir string) error { return pulumi.ValidateProjectDir(m.resolveDir(dir)) },
+		},
+		{
+			Title:            i18n.T("Enter stack name"),
+			InputLabel:       i18n.T("Stack name"),
+			InputPlaceholder: i18n.T("Enter stack name..."),
+			Suggestions:      []StepSuggestion{{ID: defaultNewStack, Label: defaultNewStack}},
+			FooterHints:      "enter " + i18n.T("create project") + "  backspace " + i18n.T("back") + "  esc " + i18n.T("cancel"),
+		},
+	}
+	m.SetSteps(steps)
+}
+
+// templateSuggestions lists common templates; any template `pulumi new` accepts
+// can be entered instead
+func templateSuggestions() []StepSuggestion {
+	templates := []struct{ name, description string }{
+		{"typescript", "TypeScript"},
+		{"python", "Python"},
+		{"go", "Go"},
+		{"yaml", "YAML"},
+		{"aws-typescript", "AWS, TypeScript"},
+		{"aws-python", "AWS, Python"},
+		{"aws-go", "AWS, Go"},
+		{"azure-typescript", "Azure, TypeScript"},
+		{"gcp-typescript", "Google Cloud, TypeScript"},
+		{"kubernetes-typescript", "Kubernetes, TypeScript"},
+	}
+	suggestions := make([]StepSuggestion, 0, len(templates))
+	for _, t := range templates {
+		suggestions = append(suggestions, StepSuggestion{ID: t.name, Label: t.name, Description: t.description})
+	}
+	return suggestions
+}
+
+// Show shows the modal and resets state. Relative directories are resolved
+// against baseDir, which is also suggested for the project if empty.
+func (m *NewProjectModal) Show(baseDir string) {
+	m.baseDir = baseDir
+	m.StepModal.Show()
+	m.configureSteps()
+	m.SetStepInfoLines(stepTemplate, []InfoLine{{Label: i18n.T("Templates"), Value: newProjectTemplate}})
+}
+
+// Update handles key events and manages step transitions
+func (m *NewProjectModal) Update(msg tea.KeyMsg) (StepModalAction, tea.Cmd) {
+	action, cmd := m.StepModal.Update(msg)
+	if action == StepModalActionNext {
+		m.onStepTransition()
+	}
+	return action, cmd
+}
+
+// onStepTransition suggests values based on the choices made so far
+func (m *NewProjectModal) onStepTransition() {
+	switch m.CurrentStep() {
+	case stepProjectName:
+		m.SetStepInfoLines(stepProjectName, m.projectSummary())
+		if m.baseDir != "" {
+			name := filepath.Base(m.baseDir)
+			m.SetStepSuggestions(stepProjectName, []StepSuggestion{{ID: name, Label: name}})
+		}
+	case stepProjectDir:
+		m.SetStepInfoLines(stepProjectDir, m.projectSummary())
+		var suggestions []StepSuggestion
+		for _, dir := range []string{filepath.Join(m.baseDir, m.GetResult(stepProjectName)), m.baseDir} {
+			if dir != "" && pulumi.ValidateProjectDir(dir) == nil {
+				suggestions = append(suggestions, StepSuggestion{ID: dir, Label: dir})
+			}
+		}
+		m.SetStepSuggestions(stepProjectDir, suggestions)
+	case stepProjectStack:
+		m.SetStepInfoLines(stepProjectStack, m.projectSummary())
+	}
+}
+
+// projectSummary returns the info lines describing the choices made so far
+func (m *NewProjectModal) projectSummary() []InfoLine {
+	info := []InfoLine{{Label: i18n.T("Template"), Value: m.GetResult(stepTemplate)}}
+	if m.CurrentStep() > stepProjectName {
+		info = append(info, InfoLine{Label: i18n.T("Project"), Value: m.GetResult(stepProjectName)})
+	}
+	if m.CurrentStep() > stepProjectDir {
+		info = append(info, InfoLine{Label: i18n.T("Directory"), Value: m.Dir()})
+	}
+	return info
+}
+
+// resolveDir resolves a directory relative to the base directory
+func (m *NewProjectModal) resolveDir(dir string) string {
+	if filepath.IsAbs(dir) || m.baseDir == "" {
+		return filepath.Clean(dir)
+	}
+	return filepath.Join(m.baseDir, dir)
+}
+
+// Dir returns the directory the project is created in
+func (m *NewProjectModal) Dir() string {
+	return m.resolveDir(m.GetResult(stepProjectDir))
+}
+
+// Options returns the template, project and stack names entered
+func (m *NewProjectModal) Options() pulumi.NewProjectOptions {
+	return pulumi.NewProjectOptions{
+		Template:  m.GetResult(stepTemplate),
+		Name:      m.GetResult(stepProjectName),
+		StackName: m.GetResult(stepProjectStack),
+	}
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// NoteModal is a modal dialog for editing the note attached to a resource
+type NoteModal struct {
+	ModalBase // Embedded modal base for common functionality
+
+	// Resource being annotated
+	resourceType string
+	resourceName string
+	resourceURN  string
+
+	// Text input for the note
+	input textinput.Model
+}
+
+// NewNoteModal creates a new note modal
+func NewNoteModal() *NoteModal {
+	ti := textinput.New()
+	ti.Placeholder = i18n.T("e.g. manually resized, do not replace")
+	ti.CharLimit = 512
+	ti.Width = DefaultInputWidth
+
+	return &NoteModal{
+		input: ti,
+	}
+}
+
+// Show shows the note modal for the given resource, starting from its current note
+func (m *NoteModal) Show(resourceType, resourceName, resourceURN, note string) {
+	m.resourceType = resourceType
+	m.resourceName = resourceName
+	m.resourceURN = resourceURN
+	m.ModalBase.Show()
+	m.input.SetValue(note)
+	m.input.CursorEnd()
+	m.input.Focus()
+}
+
+// Hide hides the note modal
+func (m *NoteModal) Hide() {
+	m.ModalBase.Hide()
+	m.input.Blur()
+}
+
+// GetNote returns the entered note, empty when the note should be removed
+func (m *NoteModal) GetNote() string {
+	return strings.TrimSpace(m.input.Value())
+}
+
+// GetResourceURN returns the URN of the resource being annotated
+func (m *NoteModal) GetResourceURN() string {
+	return m.resourceURN
+}
+
+// Update handles key events and returns true if the note was saved, false if cancelled
+func (m *NoteModal) Update(msg tea.KeyMsg) (saved bool, cmd tea.Cmd) {
+	if !m.Visible() {
+		return false, nil
+	}
+
+	if msg.String() == "enter" {
+		m.Hide()
+		return true, nil
+	}
+	if key.Matches(msg, Keys.Escape) {
+		m.Hide()
+		return false, nil
+	}
+
+	m.input, cmd = m.input.Update(msg)
+	return false, cmd
+}
+
+// View renders the note modal
+func (m *NoteModal) View() string {
+	title := DialogTitleStyle.Render(i18n.T("Resource Note"))
+
+	var content strings.Builder
+	content.WriteString(DimStyle.Render(i18n.T("Type: ")))
+	content.WriteString(ValueStyle.Render(m.resourceType))
+	content.WriteString("\n")
+
+	content.WriteString(DimStyle.Render(i18n.T("Name: ")))
+	content.WriteString(ValueStyle.Render(m.resourceName))
+	content.WriteString("\n\n")
+
+	content.WriteString(LabelStyle.Render(i18n.T("Note")))
+	content.WriteString("\n")
+	content.WriteString(m.input.View())
+
+	footer := DimStyle.Render("\n" + i18n.T("enter save  esc cancel  (empty removes the note)"))
+
+	return m.RenderDialog(title, content.String(), footer)
+}
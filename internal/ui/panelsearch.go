@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// searchContextLines is how many lines are kept above the current match when
+// scrolling to it
+const searchContextLines = 2
+
+// PanelSearch finds and highlights text in the content of a scrollable panel.
+// Matches are found on each render, so they follow the content as it changes.
+type PanelSearch struct {
+	input   FilterState
+	matches []int // Content line of each match
+	current int   // Index of the selected match
+	jump    bool  // Scroll to the selected match on the next render
+}
+
+// NewPanelSearch creates a new panel search
+func NewPanelSearch() PanelSearch {
+	return PanelSearch{input: NewFilterState()}
+}
+
+// Active returns whether the search query is being typed
+func (s *PanelSearch) Active() bool {
+	return s.input.Active()
+}
+
+// Applied returns whether a search query is set
+func (s *PanelSearch) Applied() bool {
+	return s.input.Applied()
+}
+
+// Clear removes the search query and its highlights
+func (s *PanelSearch) Clear() {
+	s.input.Clear()
+	s.input.Deactivate()
+	s.matches = nil
+	s.current = 0
+}
+
+// Update handles search keys and returns true if the key was handled.
+// `/` starts a search, `n`/`N` move between matches, and esc clears the search.
+func (s *PanelSearch) Update(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if s.input.Active() {
+		if msg.Type == tea.KeyEscape {
+			s.Clear()
+			return nil, true
+		}
+		before := s.input.Text()
+		cmd, handled := s.input.Update(msg)
+		if s.input.Text() != before {
+			s.current = 0
+			s.jump = true
+		}
+		return cmd, handled
+	}
+
+	switch {
+	case key.Matches(msg, Keys.Filter):
+		s.input.Activate()
+		s.matches = nil
+		return nil, true
+	case !s.Applied():
+		return nil, false
+	case key.Matches(msg, Keys.Escape):
+		s.Clear()
+		return nil, true
+	case msg.String() == "n":
+		s.move(1)
+		return nil, true
+	case msg.String() == "N":
+		s.move(-1)
+		return nil, true
+	}
+	return nil, false
+}
+
+// move selects the next or previous match, wrapping around
+func (s *PanelSearch) move(delta int) {
+	if len(s.matches) == 0 {
+		return
+	}
+	s.current = (s.current + delta + len(s.matches)) % len(s.matches)
+	s.jump = true
+}
+
+// Highlight finds the matches in content and returns it with the matches
+// highlighted. Lines with matches lose their own styling.
+func (s *PanelSearch) Highlight(content string) string {
+	s.matches = nil
+	query := s.input.Text()
+	if query == "" {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		plain := ansi.Strip(line)
+		found := findMatches(plain, query)
+		if len(found) == 0 {
+			continue
+		}
+		var b strings.Builder
+		prev := 0
+		for _, m := range found {
+			style := SearchMatchStyle
+			if len(s.matches) == s.current {
+				style = SearchCurrentStyle
+			}
+			b.WriteString(plain[prev:m[0]])
+			b.WriteString(style.Render(plain[m[0]:m[1]]))
+			prev = m[1]
+			s.matches = append(s.matches, i)
+		}
+		b.WriteString(plain[prev:])
+		lines[i] = b.String()
+	}
+	if s.current >= len(s.matches) {
+		s.current = 0
+	}
+	return strings.Join(lines, "\n")
+}
+
+// findMatches returns the byte ranges of case-insensitive matches of query in text
+func findMatches(text, query string) [][2]int {
+	lowerText, lowerQuery := strings.ToLower(text), strings.ToLower(query)
+	if len(lowerText) != len(text) || len(lowerQuery) != len(query) {
+		// Case folding changed byte offsets, so match case-sensitively
+		lowerText, lowerQuery = text, query
+	}
+	var found [][2]int
+	for offset := 0; ; {
+		idx := strings.Index(lowerText[offset:], lowerQuery)
+		if idx < 0 {
+			return found
+		}
+		start := offset + idx
+		found = append(found, [2]int{start, start + len(lowerQuery)})
+		offset = start + len(lowerQuery)
+	}
+}
+
+// ScrollTarget returns the scroll offset that shows the selected match, and
+// whether the panel should scroll there. It is only reported once per move.
+func (s *PanelSearch) ScrollTarget() (int, bool) {
+	if !s.jump || len(s.matches) == 0 {
+		return 0, false
+	}
+	s.jump = false
+	return max(s.matches[s.current]-searchContextLines, 0), true
+}
+
+// HeaderSuffix returns the search query and match position to show in the panel header
+func (s *PanelSearch) HeaderSuffix() string {
+	if !s.input.ActiveOrApplied() {
+		return ""
+	}
+	suffix := "  " + s.input.View()
+	switch {
+	case !s.Applied():
+		return suffix
+	case len(s.matches) == 0:
+		return suffix + DimStyle.Render(" ["+i18n.T("no matches")+"]")
+	default:
+		return suffix + DimStyle.Render(fmt.Sprintf(" [%d/%d]", s.current+1, len(s.matches)))
+	}
+}
@@ -0,0 +1,220 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// PluginIndexEntry is a plugin listed in the plugin index modal
+type PluginIndexEntry struct {
+	Name         string
+	Description  string
+	Package      string
+	Homepage     string
+	Capabilities []string
+	Installed    bool // Already configured in p5.toml or Pulumi.yaml
+}
+
+// PluginIndexAction represents an action taken by the user in the plugin index modal
+type PluginIndexAction int
+
+const (
+	PluginIndexActionNone    PluginIndexAction = iota
+	PluginIndexActionInstall                   // Install the selected plugin
+	PluginIndexActionCancel                    // Close the modal
+)
+
+// maxVisiblePlugins is the max number of plugins shown at once
+const maxVisiblePlugins = 6
+
+// pluginDescriptionWidth is the width descriptions are wrapped to
+const pluginDescriptionWidth = 60
+
+// PluginIndexModal lists the plugins of the curated plugin index and installs
+// the selected one
+type PluginIndexModal struct {
+	ModalBase // Embedded modal base for common functionality
+
+	entries    []PluginIndexEntry
+	cursor     int
+	loading    bool
+	installing string // Name of the plugin being installed
+	err        error
+}
+
+// NewPluginIndexModal creates a new plugin index modal
+func NewPluginIndexModal() *PluginIndexModal {
+	return &PluginIndexModal{}
+}
+
+// Show shows the modal while the index is fetched
+func (m *PluginIndexModal) Show() {
+	m.ModalBase.Show()
+	m.entries = nil
+	m.cursor = 0
+	m.loading = true
+	m.installing = ""
+	m.err = nil
+}
+
+// SetEntries sets the plugins listed in the index
+func (m *PluginIndexModal) SetEntries(entries []PluginIndexEntry) {
+	m.entries = entries
+	m.cursor = 0
+	m.loading = false
+	m.err = nil
+}
+
+// SetError sets an error to display
+func (m *PluginIndexModal) SetError(err error) {
+	m.err = err
+	m.loading = false
+	m.installing = ""
+}
+
+// SetInstalled marks a plugin as installed once its install finished
+func (m *PluginIndexModal) SetInstalled(name string) {
+	for i := range m.entries {
+		if m.entries[i].Name == name {
+			m.entries[i].Installed = true
+		}
+	}
+	m.installing = ""
+	m.err = nil
+}
+
+// SelectedEntry returns the plugin under the cursor, or nil if the list is empty
+func (m *PluginIndexModal) SelectedEntry() *PluginIndexEntry {
+	if m.cursor >= len(m.entries) {
+		return nil
+	}
+	return &m.entries[m.cursor]
+}
+
+// moveCursor moves the cursor, keeping it visible
+func (m *PluginIndexModal) moveCursor(delta int) {
+	m.cursor = MoveCursor(m.cursor, delta, len(m.entries))
+	m.SetScrollOffset(EnsureCursorVisible(m.cursor, m.ScrollOffset(), len(m.entries), maxVisiblePlugins))
+}
+
+// Update handles key events. Closing the modal does not cancel a running install.
+func (m *PluginIndexModal) Update(msg tea.KeyMsg) (PluginIndexAction, tea.Cmd) {
+	if !m.Visible() {
+		return PluginIndexActionNone, nil
+	}
+	if key.Matches(msg, Keys.Escape) {
+		m.Hide()
+		return PluginIndexActionCancel, nil
+	}
+	if m.loading || m.installing != "" {
+		return PluginIndexActionNone, nil
+	}
+
+	switch {
+	case msg.String() == "enter", msg.String() == "i":
+		entry := m.SelectedEntry()
+		if entry == nil || entry.Installed {
+			return PluginIndexActionNone, nil
+		}
+		m.installing = entry.Name
+		m.err = nil
+		return PluginIndexActionInstall, nil
+	case key.Matches(msg, Keys.Up):
+		m.moveCursor(-1)
+	case key.Matches(msg, Keys.Down):
+		m.moveCursor(1)
+	case key.Matches(msg, Keys.Home):
+		m.moveCursor(-len(m.entries))
+	case key.Matches(msg, Keys.End):
+		m.moveCursor(len(m.entries))
+	}
+	return PluginIndexActionNone, nil
+}
+
+// View renders the plugin index modal
+func (m *PluginIndexModal) View() string {
+	title := DialogTitleStyle.Render(i18n.T("Plugin Index"))
+
+	var content strings.Builder
+	hints := []string{"esc " + i18n.T("close")}
+	switch {
+	case m.loading:
+		content.WriteString(DimStyle.Render(i18n.T("Fetching plugin index...")))
+	case m.err != nil && len(m.entries) == 0:
+		content.WriteString(ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+	case len(m.entries) == 0:
+		content.WriteString(DimStyle.Render(i18n.T("No plugins in the index")))
+	default:
+		if m.err != nil {
+			content.WriteString(ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+			content.WriteString("\n\n")
+		}
+		m.renderEntries(&content)
+		if m.installing != "" {
+			content.WriteString("\n")
+			content.WriteString(LabelStyle.Render(i18n.Tf("Installing %s...", m.installing)))
+		} else if entry := m.SelectedEntry(); entry != nil && !entry.Installed {
+			hints = append([]string{"enter " + i18n.T("install")}, hints...)
+		}
+	}
+
+	footer := DimStyle.Render("\n" + strings.Join(hints, "  "))
+	return m.RenderDialog(title, content.String(), footer)
+}
+
+// renderEntries renders the scrollable plugin list and the details of the selected plugin
+func (m *PluginIndexModal) renderEntries(content *strings.Builder) {
+	descStyle := lipgloss.NewStyle().Width(pluginDescriptionWidth)
+
+	scrollOffset := m.ScrollOffset()
+	endIdx := min(scrollOffset+maxVisiblePlugins, len(m.entries))
+	for i := scrollOffset; i < endIdx; i++ {
+		entry := m.entries[i]
+
+		cursor := "  "
+		if i == m.cursor {
+			cursor = CursorStyle.Render("> ")
+		}
+		content.WriteString(cursor)
+		if i == m.cursor {
+			content.WriteString(ValueStyle.Render(entry.Name))
+		} else {
+			content.WriteString(entry.Name)
+		}
+		if entry.Installed {
+			content.WriteString(StatusSuccessStyle.Render(" ✓ " + i18n.T("installed")))
+		}
+		content.WriteString("\n")
+		if entry.Description != "" {
+			for line := range strings.SplitSeq(descStyle.Render(entry.Description), "\n") {
+				content.WriteString("    ")
+				content.WriteString(DimStyle.Render(strings.TrimRight(line, " ")))
+				content.WriteString("\n")
+			}
+		}
+	}
+
+	if hint := RenderScrollHint(scrollOffset > 0, endIdx < len(m.entries), "  "); hint != "" {
+		content.WriteString(hint)
+	}
+
+	entry := m.SelectedEntry()
+	content.WriteString("\n")
+	content.WriteString(LabelStyle.Render(i18n.T("Package: ")))
+	content.WriteString(entry.Package)
+	if len(entry.Capabilities) > 0 {
+		content.WriteString("\n")
+		content.WriteString(LabelStyle.Render(i18n.T("Capabilities: ")))
+		content.WriteString(strings.Join(entry.Capabilities, ", "))
+	}
+	if entry.Homepage != "" {
+		content.WriteString("\n")
+		content.WriteString(LabelStyle.Render(i18n.T("Homepage: ")))
+		content.WriteString(entry.Homepage)
+	}
+}
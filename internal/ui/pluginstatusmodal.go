@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// PluginStatusEntry is a plugin listed in the plugin status modal
+type PluginStatusEntry struct {
+	Name       string
+	EnvVars    []string  // Names of the env vars the plugin's credentials set
+	IssuedAt   time.Time // Zero if the plugin hasn't authenticated
+	ExpiresAt  time.Time // Zero if the credentials never expire
+	AlwaysCall bool      // Credentials are requested before every operation
+	Error      string    // Why the last authentication failed
+}
+
+// PluginStatusAction represents an action taken by the user in the plugin status modal
+type PluginStatusAction int
+
+const (
+	PluginStatusActionNone   PluginStatusAction = iota
+	PluginStatusActionReauth                    // Re-authenticate the selected plugin
+	PluginStatusActionCancel                    // Close the modal
+)
+
+// maxVisiblePluginStatuses is the max number of plugins shown at once
+const maxVisiblePluginStatuses = 8
+
+// PluginStatusModal lists the authentication state of each loaded plugin and
+// re-authenticates the selected one
+type PluginStatusModal struct {
+	ModalBase // Embedded modal base for common functionality
+
+	entries        []PluginStatusEntry
+	cursor         int
+	authenticating string // Name of the plugin being re-authenticated
+}
+
+// NewPluginStatusModal creates a new plugin status modal
+func NewPluginStatusModal() *PluginStatusModal {
+	return &PluginStatusModal{}
+}
+
+// Show shows the modal listing entries
+func (m *PluginStatusModal) Show(entries []PluginStatusEntry) {
+	m.ModalBase.Show()
+	m.entries = entries
+	m.cursor = 0
+	m.authenticating = ""
+}
+
+// SetEntries updates the listed plugins, keeping the cursor on the same plugin
+func (m *PluginStatusModal) SetEntries(entries []PluginStatusEntry) {
+	selected := m.SelectedEntry()
+	m.entries = entries
+	m.cursor = min(m.cursor, max(len(entries)-1, 0))
+	if selected == nil {
+		return
+	}
+	for i, entry := range entries {
+		if entry.Name == selected.Name {
+			m.cursor = i
+		}
+	}
+}
+
+// ClearAuthenticating marks the re-authentication of the selected plugin as finished
+func (m *PluginStatusModal) ClearAuthenticating() {
+	m.authenticating = ""
+}
+
+// SelectedEntry returns the plugin under the cursor, or nil if the list is empty
+func (m *PluginStatusModal) SelectedEntry() *PluginStatusEntry {
+	if m.cursor >= len(m.entries) {
+		return nil
+	}
+	return &m.entries[m.cursor]
+}
+
+// moveCursor moves the cursor, keeping it visible
+func (m *PluginStatusModal) moveCursor(delta int) {
+	m.cursor = MoveCursor(m.cursor, delta, len(m.entries))
+	m.SetScrollOffset(EnsureCursorVisible(m.cursor, m.ScrollOffset(), len(m.entries), maxVisiblePluginStatuses))
+}
+
+// Update handles key events. Closing the modal does not cancel a running authentication.
+func (m *PluginStatusModal) Update(msg tea.KeyMsg) (PluginStatusAction, tea.Cmd) {
+	if !m.Visible() {
+		return PluginStatusActionNone, nil
+	}
+	if key.Matches(msg, Keys.Escape) {
+		m.Hide()
+		return PluginStatusActionCancel, nil
+	}
+	if m.authenticating != "" {
+		return PluginStatusActionNone, nil
+	}
+
+	switch {
+	case msg.String() == "enter", msg.String() == "r":
+		entry := m.SelectedEntry()
+		if entry == nil {
+			return PluginStatusActionNone, nil
+		}
+		m.authenticating = entry.Name
+		return PluginStatusActionReauth, nil
+	case key.Matches(msg, Keys.Up):
+		m.moveCursor(-1)
+	case key.Matches(msg, Keys.Down):
+		m.moveCursor(1)
+	case key.Matches(msg, Keys.Home):
+		m.moveCursor(-len(m.entries))
+	case key.Matches(msg, Keys.End):
+		m.moveCursor(len(m.entries))
+	}
+	return PluginStatusActionNone, nil
+}
+
+// View renders the plugin status modal
+func (m *PluginStatusModal) View() string {
+	title := DialogTitleStyle.Render(i18n.T("Plugin Status"))
+
+	var content strings.Builder
+	hints := []string{"esc " + i18n.T("close")}
+	if len(m.entries) == 0 {
+		content.WriteString(DimStyle.Render(i18n.T("No plugins configured")))
+	} else {
+		m.renderEntries(&content, time.Now())
+		if m.authenticating != "" {
+			content.WriteString("\n")
+			content.WriteString(LabelStyle.Render(i18n.Tf("Authenticating %s...", m.authenticating)))
+		} else {
+			hints = append([]string{"enter " + i18n.T("re-authenticate")}, hints...)
+		}
+	}
+
+	footer := DimStyle.Render("\n" + strings.Join(hints, "  "))
+	return m.RenderDialog(title, content.String(), footer)
+}
+
+// renderEntries renders the scrollable plugin list and the details of the selected plugin
+func (m *PluginStatusModal) renderEntries(content *strings.Builder, now time.Time) {
+	nameWidth := 0
+	for _, entry := range m.entries {
+		nameWidth = max(nameWidth, len(entry.Name))
+	}
+
+	scrollOffset := m.ScrollOffset()
+	endIdx := min(scrollOffset+maxVisiblePluginStatuses, len(m.entries))
+	for i := scrollOffset; i < endIdx; i++ {
+		entry := m.entries[i]
+
+		cursor := "  "
+		name := fmt.Sprintf("%-*s", nameWidth, entry.Name)
+		if i == m.cursor {
+			cursor = CursorStyle.Render("> ")
+			name = ValueStyle.Render(name)
+		}
+		content.WriteString(cursor)
+		content.WriteString(name)
+		content.WriteString("  ")
+		content.WriteString(pluginAuthState(entry, now))
+		content.WriteString("\n")
+	}
+
+	if hint := RenderScrollHint(scrollOffset > 0, endIdx < len(m.entries), "  "); hint != "" {
+		content.WriteString(hint)
+	}
+
+	entry := m.SelectedEntry()
+	content.WriteString("\n")
+	content.WriteString(LabelStyle.Render(i18n.T("Last refresh: ")))
+	if entry.IssuedAt.IsZero() {
+		content.WriteString(DimStyle.Render(i18n.T("never")))
+	} else {
+		content.WriteString(entry.IssuedAt.Format(time.TimeOnly))
+	}
+	if len(entry.EnvVars) > 0 {
+		content.WriteString("\n")
+		content.WriteString(LabelStyle.Render(i18n.T("Env vars: ")))
+		content.WriteString(strings.Join(entry.EnvVars, ", "))
+	}
+	if entry.Error != "" {
+		content.WriteString("\n")
+		content.WriteString(ErrorStyle.Render(i18n.Tf("Error: %v", entry.Error)))
+	}
+}
+
+// pluginAuthState renders whether a plugin's credentials are usable and when they expire
+func pluginAuthState(entry PluginStatusEntry, now time.Time) string {
+	switch {
+	case entry.Error != "" && entry.IssuedAt.IsZero():
+		return StatusFailedStyle.Render("✗ " + i18n.T("error"))
+	case entry.IssuedAt.IsZero():
+		return DimStyle.Render(i18n.T("not authenticated"))
+	case entry.AlwaysCall:
+		return StatusSuccessStyle.Render("✓ "+i18n.T("ok")) + DimStyle.Render("  "+i18n.T("renewed every operation"))
+	case entry.ExpiresAt.IsZero():
+		return StatusSuccessStyle.Render("✓ "+i18n.T("ok")) + DimStyle.Render("  "+i18n.T("never expires"))
+	case !now.Before(entry.ExpiresAt):
+		return StatusFailedStyle.Render("✗ " + i18n.T("expired"))
+	}
+
+	state := StatusSuccessStyle.Render("✓ " + i18n.T("ok"))
+	if entry.Error != "" {
+		// The last refresh failed but the previous credentials are still valid
+		state = WarningStyle.Render("! " + i18n.T("refresh failed"))
+	}
+	return state + DimStyle.Render("  "+i18n.Tf("expires in %s", formatTimeLeft(entry.ExpiresAt.Sub(now))))
+}
+
+// formatTimeLeft formats a duration in the largest whole unit that fits
+func formatTimeLeft(d time.Duration) string {
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// markdownDiffWidth is the width diffs are rendered at for markdown, wide enough
+// that values are rarely truncated
+const markdownDiffWidth = 120
+
+// PreviewMarkdown formats the changes of a preview as markdown for a pull request
+// comment: a table of the changed resources followed by a collapsible diff of
+// each, rendered like the details panel
+func PreviewMarkdown(stackName string, op OperationType, items []ResourceItem, ignore DiffIgnoreRules) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### Pulumi %s preview of `%s`\n\n", strings.ToLower(op.String()), stackName)
+
+	var changed []ResourceItem
+	var summary ResourceSummary
+	for _, item := range items {
+		if item.Op != OpSame && item.Op != "" {
+			changed = append(changed, item)
+			summary.add(item.Op)
+		}
+	}
+	if len(changed) == 0 {
+		b.WriteString("No changes.\n")
+		return b.String()
+	}
+
+	var counts []string
+	for _, count := range []struct {
+		n    int
+		verb string
+	}{
+		{summary.Create, "create"},
+		{summary.Update, "update"},
+		{summary.Replace, "replace"},
+		{summary.Delete, "delete"},
+		{summary.Refresh, "refresh"},
+	} {
+		if count.n > 0 {
+			counts = append(counts, fmt.Sprintf("%d to %s", count.n, count.verb))
+		}
+	}
+	fmt.Fprintf(&b, "**%s**\n\n", strings.Join(counts, " · "))
+
+	b.WriteString("| Operation | Resource | Type |\n")
+	b.WriteString("|-----------|----------|------|\n")
+	for _, item := range changed {
+		operation := string(item.Op)
+		if len(item.ReplaceKeys) > 0 {
+			operation += " (" + markdownCode(strings.Join(item.ReplaceKeys, ", ")) + ")"
+		}
+		fmt.Fprintf(&b, "| %s %s | %s | %s |\n", getOpSymbolInfo(item.Op).symbol, operation,
+			markdownCode(item.Name), markdownCode(item.Type))
+	}
+
+	for i := range changed {
+		item := &changed[i]
+		r := NewDiffRenderer(markdownDiffWidth)
+		r.SetIgnoredPaths(ignore.PathsFor(item.Type))
+		diff := ansi.Strip(r.RenderCombinedProperties(item))
+		if strings.TrimSpace(diff) == "" {
+			continue
+		}
+
+		var lines []string
+		for line := range strings.SplitSeq(strings.TrimRight(diff, "\n"), "\n") {
+			lines = append(lines, markdownDiffLine(line))
+		}
+		body := strings.Join(lines, "\n")
+		fence := "```"
+		for strings.Contains(body, fence) {
+			fence += "`"
+		}
+		fmt.Fprintf(&b, "\n<details>\n<summary>%s <code>%s</code> %s</summary>\n\n%sdiff\n%s\n%s\n\n</details>\n",
+			html.EscapeString(getOpSymbolInfo(item.Op).symbol), html.EscapeString(item.Name), html.EscapeString(item.Type),
+			fence, body, fence)
+	}
+	return b.String()
+}
+
+// markdownDiffLine moves the +, - or ~ marker of a diff line to its start, so
+// GitHub colors it as a diff. Changed values are marked with ! instead of ~.
+func markdownDiffLine(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(trimmed)]
+	switch {
+	case trimmed == "":
+		return ""
+	case trimmed[0] == '+' || trimmed[0] == '-':
+		return trimmed[:1] + indent + trimmed[1:]
+	case trimmed[0] == '~':
+		return "!" + indent + trimmed[1:]
+	default:
+		return " " + line
+	}
+}
+
+// markdownCode formats text as inline code in a markdown table cell
+func markdownCode(text string) string {
+	return "`" + strings.ReplaceAll(text, "|", `\|`) + "`"
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// toggleCollapsed collapses or expands the group or subtree under the cursor
+func (r *ResourceList) toggleCollapsed() {
+	if r.grouping != GroupByTree {
+		r.toggleGroupCollapsed()
+		return
+	}
+	urn, ok := r.cursorSubtree()
+	if !ok {
+		return
+	}
+	if r.collapsed[urn] {
+		delete(r.collapsed, urn)
+	} else {
+		r.collapsed[urn] = true
+	}
+	r.visualMode = false
+	r.rebuildVisibleIndex()
+	r.rebuildFilteredIndex()
+	r.SelectURN(urn)
+}
+
+// cursorSubtree returns the URN of the resource whose children the collapse key
+// folds: the resource under the cursor if it has children, otherwise its parent,
+// like za in vim
+func (r *ResourceList) cursorSubtree() (string, bool) {
+	item := r.SelectedItem()
+	if item == nil {
+		return "", false
+	}
+	if r.hasChildren(r.urnIdx[item.URN]) {
+		return item.URN, true
+	}
+	if _, ok := r.urnIdx[item.Parent]; ok && item.Parent != "" {
+		return item.Parent, true
+	}
+	return "", false
+}
+
+// CanCollapse returns whether the collapse key folds something at the cursor
+func (r *ResourceList) CanCollapse() bool {
+	if r.grouping != GroupByTree {
+		_, ok := r.cursorGroupKey()
+		return ok
+	}
+	_, ok := r.cursorSubtree()
+	return ok
+}
+
+// ToggleCollapseAll collapses every group, or in the tree every resource with
+// children but the stack, and expands them all if any is collapsed. The cursor
+// stays on its group, or moves up to the closest resource still shown.
+func (r *ResourceList) ToggleCollapseAll() {
+	if r.grouping != GroupByTree {
+		key, ok := r.cursorGroupKey()
+		if len(r.collapsedGroups) > 0 {
+			clear(r.collapsedGroups)
+		} else {
+			for _, group := range r.groups {
+				r.collapsedGroups[group.Key] = true
+			}
+		}
+		r.visualMode = false
+		r.rebuildVisibleIndex()
+		r.rebuildFilteredIndex()
+		if ok {
+			r.selectGroup(key)
+		}
+		return
+	}
+
+	var urn string
+	if item := r.SelectedItem(); item != nil {
+		urn = item.URN
+	}
+	anyCollapsed := false
+	for i := range r.items {
+		if r.collapsed[r.items[i].URN] && r.hasChildren(i) {
+			anyCollapsed = true
+			break
+		}
+	}
+	if anyCollapsed {
+		clear(r.collapsed)
+	} else {
+		for i := range r.items {
+			if r.hasChildren(i) && r.items[i].Type != "pulumi:pulumi:Stack" {
+				r.collapsed[r.items[i].URN] = true
+			}
+		}
+	}
+	r.visualMode = false
+	r.rebuildVisibleIndex()
+	r.rebuildFilteredIndex()
+	for urn != "" && !r.SelectURN(urn) {
+		urn = r.items[r.urnIdx[urn]].Parent
+	}
+}
+
+// hasChildren returns whether the item at i has children
+func (r *ResourceList) hasChildren(i int) bool {
+	return i+1 < len(r.items) && r.items[i+1].Depth > r.items[i].Depth
+}
+
+// hideCollapsedSubtrees drops the descendants of collapsed resources from the
+// visible index, which is in tree order
+func (r *ResourceList) hideCollapsedSubtrees() {
+	if len(r.collapsed) == 0 {
+		return
+	}
+	n, hideBelow := 0, -1
+	for _, i := range r.visibleIdx {
+		depth := r.items[i].Depth
+		if hideBelow >= 0 && depth > hideBelow {
+			continue
+		}
+		hideBelow = -1
+		if r.collapsed[r.items[i].URN] {
+			hideBelow = depth
+		}
+		r.visibleIdx[n] = i
+		n++
+	}
+	r.visibleIdx = r.visibleIdx[:n]
+}
+
+// buildCollapsedBadge renders how many resources a collapsed resource hides and
+// the changes to them, or nothing if the resource isn't collapsed
+func (r *ResourceList) buildCollapsedBadge(item *ResourceItem, styles renderStyles) string {
+	i, ok := r.urnIdx[item.URN]
+	if r.grouping != GroupByTree || !ok || !r.collapsed[item.URN] || !r.hasChildren(i) {
+		return ""
+	}
+	var summary ResourceSummary
+	for j := i + 1; j < r.subtreeEnd(i); j++ {
+		summary.add(r.items[j].Op)
+	}
+	badge := styles.dim.Render(fmt.Sprintf("[▸ %s]", i18n.Tf("%d resources", summary.Total)))
+	if counts := renderOperationCounts(summary); counts != "" {
+		badge += " " + counts
+	}
+	if styles.hasBackground {
+		return lipgloss.NewStyle().Background(styles.bg).Render("  ") + badge
+	}
+	return "  " + badge
+}
@@ -53,7 +53,10 @@ func (r *ResourceList) CopyAllResourcesJSON() tea.Cmd {
 
 	// Build JSON array of all visible resources
 	resources := make([]ResourceJSON, 0, len(r.visibleIdx))
-	for _, idx := range r.visibleIdx {
+	for _, idx := range r.visibleIdx[r.pinnedRows:] {
+		if idx < 0 {
+			continue // Group header
+		}
 		item := &r.items[idx]
 		resources = append(resources, ResourceJSON{
 			URN:     item.URN,
@@ -71,7 +74,8 @@ func (r *ResourceList) CopyAllResourcesJSON() tea.Cmd {
 	return CopyToClipboardWithCountCmd(string(jsonBytes), len(resources))
 }
 
-// VisibleCount returns the number of visible resources
+// VisibleCount returns the number of visible resources, leaving out group headers
+// and the pinned resources listed again at the top
 func (r *ResourceList) VisibleCount() int {
-	return len(r.visibleIdx)
+	return len(r.visibleIdx) - len(r.groups) - r.pinnedRows
 }
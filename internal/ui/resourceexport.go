@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// ResourceExport is a resource as exported from the resource list, for sharing a
+// change review
+type ResourceExport struct {
+	Name  string   `json:"name"`
+	Type  string   `json:"type"`
+	Op    string   `json:"op"`
+	Flags []string `json:"flags,omitempty"` // target, replace, exclude and protected
+	URN   string   `json:"urn"`
+}
+
+// exportColumns is the header row of CSV exports
+var exportColumns = []string{"name", "type", "op", "flags", "urn"}
+
+// ExportResources formats the resources listed, after filters, as CSV or JSON,
+// by the extension of path
+func (r *ResourceList) ExportResources(path string) (string, error) {
+	var resources []ResourceExport
+	for pos := range r.effectiveItemCount() {
+		i := r.effectiveIndex(pos)
+		if i < r.pinnedRows || r.visibleIdx[i] < 0 {
+			continue // Pinned resources listed again at the top, or a group header
+		}
+		item := &r.items[r.visibleIdx[i]]
+		resources = append(resources, ResourceExport{
+			Name:  item.Name,
+			Type:  item.Type,
+			Op:    string(item.Op),
+			Flags: r.exportFlags(item),
+			URN:   item.URN,
+		})
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		var b strings.Builder
+		w := csv.NewWriter(&b)
+		_ = w.Write(exportColumns)
+		for _, res := range resources {
+			_ = w.Write([]string{res.Name, res.Type, res.Op, strings.Join(res.Flags, " "), res.URN})
+		}
+		w.Flush()
+		return strings.TrimSuffix(b.String(), "\n"), w.Error()
+	case ".json":
+		if resources == nil {
+			resources = []ResourceExport{}
+		}
+		data, err := json.MarshalIndent(resources, "", "  ")
+		return string(data), err
+	default:
+		return "", errors.New(i18n.T("Export to a .csv or .json file"))
+	}
+}
+
+// exportFlags returns the names of the flags set on a resource
+func (r *ResourceList) exportFlags(item *ResourceItem) []string {
+	var flags []string
+	f := r.flags[item.URN]
+	if f.Target {
+		flags = append(flags, "target")
+	}
+	if f.Replace {
+		flags = append(flags, "replace")
+	}
+	if f.Exclude {
+		flags = append(flags, "exclude")
+	}
+	if item.Protected {
+		flags = append(flags, "protected")
+	}
+	return flags
+}
@@ -1,5 +1,7 @@
 package ui
 
+import "maps"
+
 // ResourceFlags tracks selection flags for a resource
 type ResourceFlags struct {
 	Target  bool // --target flag for update
@@ -7,6 +9,76 @@ type ResourceFlags struct {
 	Exclude bool // exclude from update (mutually exclusive with Target/Replace)
 }
 
+// maxFlagUndo is how many changes of flags and selections can be undone
+const maxFlagUndo = 50
+
+// flagSnapshot is the flags and discrete selections at some point, to undo changes
+type flagSnapshot struct {
+	flags    map[string]ResourceFlags
+	selected map[string]bool
+}
+
+// snapshot copies the current flags and discrete selections
+func (r *ResourceList) snapshot() flagSnapshot {
+	return flagSnapshot{flags: maps.Clone(r.flags), selected: maps.Clone(r.selected)}
+}
+
+// recordChange runs change, keeping the flags and selections from before it so
+// it can be undone. Changes that leave them as they were aren't kept.
+func (r *ResourceList) recordChange(change func()) {
+	before := r.snapshot()
+	change()
+	if maps.Equal(before.flags, r.flags) && maps.Equal(before.selected, r.selected) {
+		return
+	}
+	r.undo = append(r.undo, before)
+	if len(r.undo) > maxFlagUndo {
+		r.undo = r.undo[len(r.undo)-maxFlagUndo:]
+	}
+	r.redo = nil
+}
+
+// restore sets the flags and discrete selections of a snapshot. The flags map is
+// updated in place, as it is shared with the parent.
+func (r *ResourceList) restore(s flagSnapshot) {
+	clear(r.flags)
+	maps.Copy(r.flags, s.flags)
+	r.selected = maps.Clone(s.selected)
+	if r.selected == nil {
+		r.selected = make(map[string]bool)
+	}
+	r.visualMode = false
+}
+
+// UndoFlags reverts the latest change of flags or selections.
+// Returns false if there is nothing to undo.
+func (r *ResourceList) UndoFlags() bool {
+	if len(r.undo) == 0 {
+		return false
+	}
+	r.redo = append(r.redo, r.snapshot())
+	r.restore(r.undo[len(r.undo)-1])
+	r.undo = r.undo[:len(r.undo)-1]
+	return true
+}
+
+// RedoFlags applies again the latest undone change of flags or selections.
+// Returns false if there is nothing to redo.
+func (r *ResourceList) RedoFlags() bool {
+	if len(r.redo) == 0 {
+		return false
+	}
+	r.undo = append(r.undo, r.snapshot())
+	r.restore(r.redo[len(r.redo)-1])
+	r.redo = r.redo[:len(r.redo)-1]
+	return true
+}
+
+// CanUndoFlags returns whether there is a change of flags or selections to undo
+func (r *ResourceList) CanUndoFlags() bool {
+	return len(r.undo) > 0
+}
+
 // toggleFlag toggles the specified flag for selected resources
 func (r *ResourceList) toggleFlag(flagType string) {
 	indices := r.getSelectedIndices()
@@ -16,11 +88,10 @@ func (r *ResourceList) toggleFlag(flagType string) {
 		if idx < 0 || idx >= itemCount {
 			continue
 		}
-		visIdx := r.effectiveIndex(idx)
-		if visIdx < 0 || visIdx >= len(r.visibleIdx) {
+		item := r.itemAt(idx)
+		if item == nil {
 			continue
 		}
-		item := r.items[r.visibleIdx[visIdx]]
 		urn := item.URN
 
 		flags := r.flags[urn]
@@ -70,12 +141,9 @@ func (r *ResourceList) clearFlags() {
 		if idx < 0 || idx >= itemCount {
 			continue
 		}
-		visIdx := r.effectiveIndex(idx)
-		if visIdx < 0 || visIdx >= len(r.visibleIdx) {
-			continue
+		if item := r.itemAt(idx); item != nil {
+			delete(r.flags, item.URN)
 		}
-		urn := r.items[r.visibleIdx[visIdx]].URN
-		delete(r.flags, urn)
 	}
 
 	// Exit visual mode after clearing
@@ -120,11 +188,21 @@ func (r *ResourceList) HasFlags() bool {
 	return len(r.flags) > 0
 }
 
-// ClearAllFlags clears all flags
+// ClearAllFlags clears all flags. It can be undone.
 func (r *ResourceList) ClearAllFlags() {
-	for k := range r.flags {
-		delete(r.flags, k)
-	}
+	r.recordChange(func() {
+		clear(r.flags)
+	})
+}
+
+// ReplaceFlags replaces all flags with the given ones, e.g. those of a saved
+// target set. It can be undone.
+func (r *ResourceList) ReplaceFlags(flags map[string]ResourceFlags) {
+	r.recordChange(func() {
+		clear(r.flags)
+		maps.Copy(r.flags, flags)
+	})
+	r.visualMode = false
 }
 
 // SelectedResource represents a selected resource with its URN and name
@@ -138,6 +216,40 @@ type SelectedResource struct {
 // It excludes the root stack resource (pulumi:pulumi:Stack) as it cannot be deleted.
 // Returns the union of discrete selections and visual range, or just the cursor item if neither is active.
 func (r *ResourceList) GetSelectedResourcesForStateDelete() []SelectedResource {
+	return r.selectedResources(func(item ResourceItem) bool {
+		return item.Type != "pulumi:pulumi:Stack"
+	})
+}
+
+// GetSelectedResourcesForProtect returns selected resources whose protection would change,
+// i.e. unprotected resources when protect is true and protected resources otherwise.
+// Like state delete it excludes the root stack resource.
+func (r *ResourceList) GetSelectedResourcesForProtect(protect bool) []SelectedResource {
+	return r.selectedResources(func(item ResourceItem) bool {
+		return item.Type != "pulumi:pulumi:Stack" && item.Protected != protect
+	})
+}
+
+// GetDriftedURNs returns the URNs of the selected drifted resources, or of every
+// drifted resource when nothing is selected
+func (r *ResourceList) GetDriftedURNs() []string {
+	var urns []string
+	if len(r.selected) == 0 && !r.visualMode {
+		for _, item := range r.items {
+			if item.Drifted() {
+				urns = append(urns, item.URN)
+			}
+		}
+		return urns
+	}
+	for _, res := range r.selectedResources(ResourceItem.Drifted) {
+		urns = append(urns, res.URN)
+	}
+	return urns
+}
+
+// selectedResources returns the selected resources accepted by include
+func (r *ResourceList) selectedResources(include func(ResourceItem) bool) []SelectedResource {
 	indices := r.getSelectedIndices()
 	itemCount := r.effectiveItemCount()
 	var resources []SelectedResource
@@ -146,13 +258,11 @@ func (r *ResourceList) GetSelectedResourcesForStateDelete() []SelectedResource {
 		if idx < 0 || idx >= itemCount {
 			continue
 		}
-		visIdx := r.effectiveIndex(idx)
-		if visIdx < 0 || visIdx >= len(r.visibleIdx) {
+		item := r.itemAt(idx)
+		if item == nil {
 			continue
 		}
-		item := r.items[r.visibleIdx[visIdx]]
-		// Skip root stack resource - it cannot be deleted from state
-		if item.Type == "pulumi:pulumi:Stack" {
+		if !include(*item) {
 			continue
 		}
 		resources = append(resources, SelectedResource{
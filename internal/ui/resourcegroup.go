@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// ResourceGrouping is how the resource list organizes its items
+type ResourceGrouping int
+
+const (
+	GroupByTree     ResourceGrouping = iota // Parents followed by their children
+	GroupByType                             // Under a header per resource type
+	GroupByProvider                         // Under a header per provider
+)
+
+// Next returns the grouping that follows g when cycling through them
+func (g ResourceGrouping) Next() ResourceGrouping {
+	return (g + 1) % (GroupByProvider + 1)
+}
+
+// Label describes the grouping to the user
+func (g ResourceGrouping) Label() string {
+	switch g {
+	case GroupByType:
+		return i18n.T("Grouped by type")
+	case GroupByProvider:
+		return i18n.T("Grouped by provider")
+	default:
+		return i18n.T("Showing resource tree")
+	}
+}
+
+// resourceGroup is a header row of the grouped list, followed by the rows of its
+// items unless collapsed. Summary counts the operations of the items shown.
+type resourceGroup struct {
+	Key     string
+	Summary ResourceSummary
+}
+
+// groupMarker encodes group g as an entry of visibleIdx. Item indices are never
+// negative, so header rows are told apart by their sign.
+func groupMarker(g int) int {
+	return -g - 1
+}
+
+// SetGrouping sets how the list organizes its items. Collapsed groups are kept, so
+// they stay collapsed when switching back.
+func (r *ResourceList) SetGrouping(g ResourceGrouping) {
+	if g == r.grouping {
+		return
+	}
+	r.grouping = g
+	r.cursor = 0
+	r.scrollOffset = 0
+	r.visualMode = false
+	r.rebuildVisibleIndex()
+	r.rebuildFilteredIndex()
+}
+
+// Grouping returns how the list organizes its items
+func (r *ResourceList) Grouping() ResourceGrouping {
+	return r.grouping
+}
+
+// groupKey returns the key of the group an item is listed under
+func (r *ResourceList) groupKey(item *ResourceItem) string {
+	if r.grouping == GroupByProvider {
+		return providerGroupKey(item)
+	}
+	return item.Type
+}
+
+// providerGroupKey names the provider of an item by its package, e.g. "aws", with
+// the name of explicit providers, e.g. "aws (us-west)". Resources without a
+// provider, such as components, are grouped by the package of their type.
+func providerGroupKey(item *ResourceItem) string {
+	if pkg, ok := strings.CutPrefix(item.Type, "pulumi:providers:"); ok {
+		return pkg
+	}
+	// References are "<urn>::<id>", the URN ending with "<type>::<name>"
+	parts := strings.Split(item.Provider, "::")
+	if len(parts) >= 4 {
+		pkg := strings.TrimPrefix(parts[len(parts)-3], "pulumi:providers:")
+		name := parts[len(parts)-2]
+		if strings.HasPrefix(name, "default") {
+			return pkg
+		}
+		return fmt.Sprintf("%s (%s)", pkg, name)
+	}
+	pkg, _, _ := strings.Cut(item.Type, ":")
+	return pkg
+}
+
+// rebuildGroupedIndex builds the visible index of a grouped list: a header per
+// group, sorted by key, followed by the group's items in tree order unless the
+// group is collapsed. Unlike the tree, unchanged ancestors of changed items are
+// hidden along with the other unchanged items.
+func (r *ResourceList) rebuildGroupedIndex() {
+	type member struct {
+		key string
+		idx int
+	}
+	members := make([]member, 0, len(r.items))
+	for i := range r.items {
+		if r.showAllOps || r.hasChanges(r.items[i]) {
+			members = append(members, member{r.groupKey(&r.items[i]), i})
+		}
+	}
+	slices.SortStableFunc(members, func(a, b member) int {
+		return cmp.Compare(a.key, b.key)
+	})
+
+	for _, m := range members {
+		if len(r.groups) == 0 || r.groups[len(r.groups)-1].Key != m.key {
+			r.groups = append(r.groups, resourceGroup{Key: m.key})
+			r.visibleIdx = append(r.visibleIdx, groupMarker(len(r.groups)-1))
+		}
+		group := &r.groups[len(r.groups)-1]
+		group.Summary.add(r.items[m.idx].Op)
+		if !r.collapsedGroups[m.key] {
+			r.visibleIdx = append(r.visibleIdx, m.idx)
+		}
+	}
+}
+
+// groupAt returns the group whose header is at cursor position pos, or nil if
+// there is no header there
+func (r *ResourceList) groupAt(pos int) *resourceGroup {
+	visIdx := r.effectiveIndex(pos)
+	if visIdx < 0 || visIdx >= len(r.visibleIdx) || r.visibleIdx[visIdx] >= 0 {
+		return nil
+	}
+	return &r.groups[-r.visibleIdx[visIdx]-1]
+}
+
+// itemAt returns the item at cursor position pos, or nil if there is none or the
+// row is a group header
+func (r *ResourceList) itemAt(pos int) *ResourceItem {
+	visIdx := r.effectiveIndex(pos)
+	if visIdx < 0 || visIdx >= len(r.visibleIdx) {
+		return nil
+	}
+	itemIdx := r.visibleIdx[visIdx]
+	if itemIdx < 0 || itemIdx >= len(r.items) {
+		return nil
+	}
+	return &r.items[itemIdx]
+}
+
+// cursorGroupKey returns the key of the group under the cursor: of the header, or
+// of the group the item belongs to
+func (r *ResourceList) cursorGroupKey() (string, bool) {
+	if group := r.groupAt(r.cursor); group != nil {
+		return group.Key, true
+	}
+	if item := r.SelectedItem(); item != nil {
+		return r.groupKey(item), true
+	}
+	return "", false
+}
+
+// toggleGroupCollapsed collapses the group under the cursor, or expands it if
+// collapsed. On an item, the group it belongs to is collapsed and the cursor
+// moves to its header.
+func (r *ResourceList) toggleGroupCollapsed() {
+	key, ok := r.cursorGroupKey()
+	if !ok {
+		return
+	}
+	if r.collapsedGroups[key] {
+		delete(r.collapsedGroups, key)
+	} else {
+		r.collapsedGroups[key] = true
+	}
+	r.visualMode = false
+	r.rebuildVisibleIndex()
+	r.rebuildFilteredIndex()
+	r.selectGroup(key)
+}
+
+// selectGroup moves the cursor to the header of the group with the given key
+func (r *ResourceList) selectGroup(key string) {
+	for pos := range r.effectiveItemCount() {
+		if group := r.groupAt(pos); group != nil && group.Key == key {
+			r.cursor = pos
+			break
+		}
+	}
+	r.ensureCursorVisible()
+}
+
+// renderGroupHeader renders the header row of a group: whether it is collapsed,
+// its key, how many items it holds and the changes to them
+func (r *ResourceList) renderGroupHeader(group *resourceGroup, isCursor, isFlashing bool) string {
+	styles := newRenderStyles(DimStyle, isFlashing, false, false)
+	arrow := "▾"
+	if r.collapsedGroups[group.Key] {
+		arrow = "▸"
+	}
+	parts := []string{
+		r.renderCursor(isCursor, styles) + LabelStyle.Render(arrow+" "+group.Key),
+		styles.dim.Render(fmt.Sprintf("(%d)", group.Summary.Total)),
+	}
+	if counts := renderOperationCounts(group.Summary); counts != "" {
+		parts = append(parts, counts)
+	}
+	line := strings.Join(parts, " ")
+	if styles.hasBackground {
+		return lipgloss.NewStyle().Background(styles.bg).Render(line)
+	}
+	return line
+}
@@ -0,0 +1,60 @@
+package ui
+
+import "strings"
+
+// JumpTo moves the cursor to the shown resource best matching query and returns
+// it, or nil if none matches. A full URN or name matches exactly; otherwise
+// names and URNs are matched fuzzily, ties going to the first in the list.
+func (r *ResourceList) JumpTo(query string) *ResourceItem {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+
+	best, bestScore := -1, 0
+	for pos := range r.effectiveItemCount() {
+		item := r.itemAt(pos)
+		if item == nil {
+			continue
+		}
+		if item.URN == query || strings.EqualFold(item.Name, query) {
+			best = pos
+			break
+		}
+		score, ok := fuzzyScore(query, item.Name)
+		if s, urnOK := fuzzyScore(query, item.URN); urnOK && (!ok || s > score) {
+			score, ok = s, true
+		}
+		if !ok {
+			continue
+		}
+		if best < 0 || score > bestScore {
+			best, bestScore = pos, score
+		}
+	}
+	if best < 0 {
+		return nil
+	}
+	r.visualMode = false
+	r.cursor = best
+	r.ensureCursorVisible()
+	return r.SelectedItem()
+}
+
+// JumpToChange moves the cursor to the next resource with changes below it, or
+// above it when dir is negative. Unchanged resources and group headers are
+// skipped. Returns false if there is no such resource.
+func (r *ResourceList) JumpToChange(dir int) bool {
+	step := 1
+	if dir < 0 {
+		step = -1
+	}
+	for pos := r.cursor + step; pos >= 0 && pos < r.effectiveItemCount(); pos += step {
+		if item := r.itemAt(pos); item != nil && r.hasChanges(*item) {
+			r.cursor = pos
+			r.ensureCursorVisible()
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"sort"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -39,6 +40,28 @@ type ResourceItem struct {
 	OldOutputs     map[string]any // Previous outputs (for updates/deletes)
 	Provider       string         // Provider reference string (URN::ID format)
 	ProviderInputs map[string]any // Provider's configuration inputs
+	DiffChanged    bool           // Diff differs from the previous preview of the same operation
+	DriftedKeys    []string       // Properties whose live value differs from the state (drift detection)
+	ReplaceKeys    []string       // Properties whose change forces the resource to be replaced
+	StartedAt      time.Time      // When the engine started operating on the resource
+	FinishedAt     time.Time      // When the engine finished operating on the resource
+}
+
+// Elapsed returns how long the engine has been operating on the resource, up to now
+// while it is running. ok is false when the resource wasn't operated on.
+func (i ResourceItem) Elapsed(now time.Time) (elapsed time.Duration, ok bool) {
+	if i.StartedAt.IsZero() {
+		return 0, false
+	}
+	if i.FinishedAt.IsZero() {
+		return now.Sub(i.StartedAt), true
+	}
+	return i.FinishedAt.Sub(i.StartedAt), true
+}
+
+// Drifted returns whether drift detection found the resource changed or deleted outside of Pulumi
+func (i ResourceItem) Drifted() bool {
+	return len(i.DriftedKeys) > 0 || i.Op == OpDelete
 }
 
 // PreviewState represents the current state of the preview (for backwards compatibility)
@@ -76,10 +99,33 @@ type ResourceList struct {
 	ListBase // Embed common list functionality for loading/error state
 
 	items      []ResourceItem
+	urnIdx     map[string]int           // Index into items by URN, rebuilt when items change
 	visibleIdx []int                    // Indices of visible items (filtered by showAllOps)
 	flags      map[string]ResourceFlags // Shared reference from parent
+	notes      map[string]string        // Resource notes by URN, shared reference from parent
+	pinned     map[string]bool          // URNs of pinned resources, shared reference from parent
+	pinnedRows int                      // Rows at the top of visibleIdx listing pinned resources
 	selected   map[string]bool          // URNs of discretely selected items (via space key)
 
+	// Grouping of the list. Group headers are in visibleIdx as negative entries,
+	// see groupMarker, indexing groups.
+	grouping        ResourceGrouping
+	groups          []resourceGroup
+	collapsedGroups map[string]bool // Keys of collapsed groups
+
+	// URNs of resources whose children are hidden in the tree. Kept when the list
+	// is reloaded, so refreshing a view keeps its subtrees collapsed.
+	collapsed map[string]bool
+
+	// Flags and selections from before each change, and from before each undo
+	undo []flagSnapshot
+	redo []flagSnapshot
+
+	// Rendered tree prefixes of unhighlighted rows by URN, so large stacks only
+	// format the rows on screen. Cleared when the tree is rebuilt, and only for
+	// the rows whose lines change when items are added or moved.
+	treePrefixes map[string]string
+
 	// Cursor & scrolling
 	cursor       int
 	scrollOffset int
@@ -88,6 +134,7 @@ type ResourceList struct {
 
 	// Configuration
 	showAllOps bool // If false, hide OpSame resources
+	driftOnly  bool // If true, hide resources without drift instead of OpSame resources
 
 	// Flash highlight state (for copy feedback)
 	flashIdx int  // Index of item to flash (-1 = none, or specific index)
@@ -105,12 +152,16 @@ func NewResourceList(flags map[string]ResourceFlags) *ResourceList {
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(ColorPrimary)
 	r := &ResourceList{
-		items:      make([]ResourceItem, 0),
-		visibleIdx: make([]int, 0),
-		flags:      flags,
-		selected:   make(map[string]bool),
-		showAllOps: true,
-		filter:     NewFilterState(),
+		items:           make([]ResourceItem, 0),
+		urnIdx:          make(map[string]int),
+		visibleIdx:      make([]int, 0),
+		flags:           flags,
+		selected:        make(map[string]bool),
+		treePrefixes:    make(map[string]string),
+		collapsedGroups: make(map[string]bool),
+		collapsed:       make(map[string]bool),
+		showAllOps:      true,
+		filter:          NewFilterState(),
 	}
 	r.SetSpinner(s)
 	return r
@@ -122,15 +173,28 @@ func (r *ResourceList) SetSize(width, height int) {
 	r.ensureCursorVisible()
 }
 
+// SetNotes sets the resource notes shown as badges, keyed by URN.
+// The map is shared with the parent, which updates it as notes change.
+func (r *ResourceList) SetNotes(notes map[string]string) {
+	r.notes = notes
+}
+
 // SetShowAllOps sets whether to show all ops or filter out OpSame
 func (r *ResourceList) SetShowAllOps(show bool) {
 	r.showAllOps = show
 	r.rebuildVisibleIndex()
 }
 
+// SetDriftOnly sets whether hiding unchanged resources keeps only drifted ones
+func (r *ResourceList) SetDriftOnly(on bool) {
+	r.driftOnly = on
+	r.rebuildVisibleIndex()
+}
+
 // SetItems replaces all items
 func (r *ResourceList) SetItems(items []ResourceItem) {
 	r.items = organizeItemsAsTree(items)
+	r.treeChanged()
 	r.rebuildVisibleIndex()
 	r.cursor = 0
 	r.scrollOffset = 0
@@ -151,10 +215,7 @@ func (r *ResourceList) AddItem(item ResourceItem) {
 	}
 
 	// Check if item with same URN already exists
-	for i := range r.items {
-		if r.items[i].URN != item.URN {
-			continue
-		}
+	if i, ok := r.urnIdx[item.URN]; ok {
 		// Update existing item - keep the most significant op
 		// Replace-related ops should consolidate to OpReplace
 		if isReplaceOp(item.Op) {
@@ -165,18 +226,31 @@ func (r *ResourceList) AddItem(item ResourceItem) {
 			r.items[i].Op = item.Op
 			r.items[i].CurrentOp = item.Op
 		}
-		// Update parent if set
-		if item.Parent != "" {
+		// Update parent if set; moving an item reorders the tree
+		moved := false
+		if item.Parent != "" && item.Parent != r.items[i].Parent {
 			r.items[i].Parent = item.Parent
+			moved = true
 		}
 		// Update sequence if set (placeholders have Sequence=0)
-		if item.Sequence != 0 {
+		if item.Sequence != 0 && item.Sequence != r.items[i].Sequence {
 			r.items[i].Sequence = item.Sequence
+			moved = true
 		}
 		// Update status if set
 		if item.Status != StatusNone {
 			r.items[i].Status = item.Status
 		}
+		// Time all steps of the resource together, e.g. both steps of a replace
+		if !item.StartedAt.IsZero() {
+			if r.items[i].StartedAt.IsZero() {
+				r.items[i].StartedAt = item.StartedAt
+			}
+			r.items[i].FinishedAt = time.Time{}
+		}
+		if !item.FinishedAt.IsZero() {
+			r.items[i].FinishedAt = item.FinishedAt
+		}
 		// For delete-replaced ops, don't overwrite inputs/outputs since they
 		// contain OLD values (we want to preserve NEW values from create-replacement)
 		isDeleteReplaced := item.Op == OpDeleteReplace
@@ -196,8 +270,14 @@ func (r *ResourceList) AddItem(item ResourceItem) {
 		if item.OldOutputs != nil && r.items[i].OldOutputs == nil {
 			r.items[i].OldOutputs = item.OldOutputs
 		}
-		// Reorganize as tree and rebuild visible index
-		r.items = organizeItemsAsTree(r.items)
+		// Every step of a replace reports the same reasons
+		if item.ReplaceKeys != nil && r.items[i].ReplaceKeys == nil {
+			r.items[i].ReplaceKeys = item.ReplaceKeys
+		}
+		// Most events only change status, which leaves the tree as it is
+		if moved {
+			r.placeSubtree(r.removeSubtree(i))
+		}
 		r.rebuildVisibleIndex()
 		return
 	}
@@ -210,32 +290,49 @@ func (r *ResourceList) AddItem(item ResourceItem) {
 	} else {
 		item.CurrentOp = item.Op
 	}
-	r.items = append(r.items, item)
-
-	// Reorganize as tree and rebuild visible index
-	r.items = organizeItemsAsTree(r.items)
+	r.placeSubtree([]ResourceItem{item})
 	r.rebuildVisibleIndex()
 }
 
+// Items returns all items in the list, including ones hidden by filters
+func (r *ResourceList) Items() []ResourceItem {
+	return r.items
+}
+
+// SetDiffChanged marks which items have a diff that changed since the previous preview
+func (r *ResourceList) SetDiffChanged(urns map[string]bool) {
+	for i := range r.items {
+		r.items[i].DiffChanged = urns[r.items[i].URN]
+	}
+}
+
+// SetDriftedKeys sets the drifted properties of an item
+func (r *ResourceList) SetDriftedKeys(urn string, keys []string) {
+	if i, ok := r.urnIdx[urn]; ok {
+		r.items[i].DriftedKeys = keys
+		r.rebuildVisibleIndex()
+	}
+}
+
 // UpdateItemStatus updates the status of an item by URN
 func (r *ResourceList) UpdateItemStatus(urn string, status ItemStatus) {
-	for i := range r.items {
-		if r.items[i].URN == urn {
-			r.items[i].Status = status
-			return
-		}
+	if i, ok := r.urnIdx[urn]; ok {
+		r.items[i].Status = status
 	}
 }
 
 // Clear resets the list for a new view
 func (r *ResourceList) Clear() {
 	r.items = make([]ResourceItem, 0)
+	r.treeChanged()
 	r.visibleIdx = make([]int, 0)
 	r.filteredIdx = nil
 	r.cursor = 0
 	r.scrollOffset = 0
 	r.visualMode = false
 	r.selected = make(map[string]bool)
+	r.undo = nil
+	r.redo = nil
 	r.filter.Deactivate()
 	r.ClearError()
 }
@@ -332,6 +429,10 @@ func (r *ResourceList) handleNavigationKeys(keyMsg tea.KeyMsg) bool {
 	case key.Matches(keyMsg, Keys.End):
 		r.cursor = itemCount - 1
 		r.ensureCursorVisible()
+	case key.Matches(keyMsg, Keys.ToggleCollapse):
+		r.toggleCollapsed()
+	case key.Matches(keyMsg, Keys.ToggleCollapseAll):
+		r.ToggleCollapseAll()
 	default:
 		return false
 	}
@@ -346,21 +447,21 @@ func (r *ResourceList) handleSelectionKeys(keyMsg tea.KeyMsg) bool {
 			r.visualStart = r.cursor
 		}
 	case key.Matches(keyMsg, Keys.ToggleSelect):
-		r.toggleDiscreteSelect()
+		r.recordChange(r.toggleDiscreteSelect)
 	case key.Matches(keyMsg, Keys.Escape):
 		if r.visualMode {
 			r.visualMode = false
 		} else if len(r.selected) > 0 {
-			r.selected = make(map[string]bool)
+			r.recordChange(r.ClearDiscreteSelections)
 		}
 	case key.Matches(keyMsg, Keys.ToggleTarget):
-		r.toggleFlag("target")
+		r.recordChange(func() { r.toggleFlag("target") })
 	case key.Matches(keyMsg, Keys.ToggleReplace):
-		r.toggleFlag("replace")
+		r.recordChange(func() { r.toggleFlag("replace") })
 	case key.Matches(keyMsg, Keys.ToggleExclude):
-		r.toggleFlag("exclude")
+		r.recordChange(func() { r.toggleFlag("exclude") })
 	case key.Matches(keyMsg, Keys.ClearFlags):
-		r.clearFlags()
+		r.recordChange(r.clearFlags)
 	case key.Matches(keyMsg, Keys.ClearAllFlags):
 		r.ClearAllFlags()
 		r.visualMode = false
@@ -387,6 +488,42 @@ func (r *ResourceList) moveCursor(delta int) {
 	r.ensureCursorVisible()
 }
 
+// ClickRow moves the cursor to the item rendered at row of the list view, counted
+// from its top. Returns false if no item is rendered there.
+func (r *ResourceList) ClickRow(row int) bool {
+	if !r.IsReady() || len(r.visibleIdx) == 0 {
+		return false
+	}
+	pos := ItemAtRow(row, listTopPadding, r.scrollOffset, r.visibleHeight(), r.effectiveItemCount())
+	if pos < 0 {
+		return false
+	}
+	r.cursor = pos
+	r.ensureCursorVisible()
+	return true
+}
+
+// Scroll moves the cursor by delta items, for mouse wheel scrolling
+func (r *ResourceList) Scroll(delta int) {
+	if !r.IsReady() || len(r.visibleIdx) == 0 {
+		return
+	}
+	r.moveCursor(delta)
+}
+
+// SelectURN moves the cursor to the resource with the given URN.
+// Returns false if the resource is not shown (unknown, hidden or filtered out).
+func (r *ResourceList) SelectURN(urn string) bool {
+	for pos := range r.effectiveItemCount() {
+		if item := r.itemAt(pos); item != nil && item.URN == urn {
+			r.cursor = pos
+			r.ensureCursorVisible()
+			return true
+		}
+	}
+	return false
+}
+
 // toggleDiscreteSelect toggles discrete selection for items
 // In visual mode: toggles all items in the visual range
 // Otherwise: toggles just the cursor item
@@ -398,11 +535,10 @@ func (r *ResourceList) toggleDiscreteSelect() {
 			start, end = end, start
 		}
 		for i := start; i <= end; i++ {
-			visIdx := r.effectiveIndex(i)
-			if visIdx < 0 || visIdx >= len(r.visibleIdx) {
+			item := r.itemAt(i)
+			if item == nil {
 				continue
 			}
-			item := r.items[r.visibleIdx[visIdx]]
 			if r.selected[item.URN] {
 				delete(r.selected, item.URN)
 			} else {
@@ -444,12 +580,7 @@ func (r *ResourceList) getSelectedIndices() []int {
 
 	// Add discretely selected items
 	for i := range r.effectiveItemCount() {
-		visIdx := r.effectiveIndex(i)
-		if visIdx < 0 || visIdx >= len(r.visibleIdx) {
-			continue
-		}
-		item := r.items[r.visibleIdx[visIdx]]
-		if r.selected[item.URN] {
+		if item := r.itemAt(i); item != nil && r.selected[item.URN] {
 			selectedSet[i] = true
 		}
 	}
@@ -470,38 +601,55 @@ func (r *ResourceList) getSelectedIndices() []int {
 		return []int{r.cursor}
 	}
 
-	// Convert to sorted slice
+	// Convert to sorted slice. Pinned resources are listed twice, so each
+	// resource is only returned once.
 	indices := make([]int, 0, len(selectedSet))
 	for idx := range selectedSet {
 		indices = append(indices, idx)
 	}
 	sort.Ints(indices)
-	return indices
+	seen := make(map[string]bool, len(indices))
+	unique := indices[:0]
+	for _, idx := range indices {
+		if item := r.itemAt(idx); item != nil {
+			if seen[item.URN] {
+				continue
+			}
+			seen[item.URN] = true
+		}
+		unique = append(unique, idx)
+	}
+	return unique
 }
 
 // Summary returns the current summary
 func (r *ResourceList) Summary() ResourceSummary {
 	summary := ResourceSummary{}
 	for i := range r.items {
-		switch r.items[i].Op {
-		case OpSame:
-			summary.Same++
-		case OpCreate:
-			summary.Create++
-		case OpUpdate:
-			summary.Update++
-		case OpDelete:
-			summary.Delete++
-		case OpReplace, OpCreateReplace, OpDeleteReplace:
-			summary.Replace++
-		case OpRefresh:
-			summary.Refresh++
-		}
-		summary.Total++
+		summary.add(r.items[i].Op)
 	}
 	return summary
 }
 
+// add counts a resource with the given operation
+func (s *ResourceSummary) add(op ResourceOp) {
+	switch op {
+	case OpSame:
+		s.Same++
+	case OpCreate:
+		s.Create++
+	case OpUpdate:
+		s.Update++
+	case OpDelete:
+		s.Delete++
+	case OpReplace, OpCreateReplace, OpDeleteReplace:
+		s.Replace++
+	case OpRefresh:
+		s.Refresh++
+	}
+	s.Total++
+}
+
 // ScrollPercent returns the current scroll percentage (0-100)
 func (r *ResourceList) ScrollPercent() float64 {
 	itemCount := r.effectiveItemCount()
@@ -539,13 +687,5 @@ func (r *ResourceList) SelectedItem() *ResourceItem {
 	if itemCount == 0 || r.cursor < 0 || r.cursor >= itemCount {
 		return nil
 	}
-	visIdx := r.effectiveIndex(r.cursor)
-	if visIdx < 0 || visIdx >= len(r.visibleIdx) {
-		return nil
-	}
-	itemIdx := r.visibleIdx[visIdx]
-	if itemIdx < 0 || itemIdx >= len(r.items) {
-		return nil
-	}
-	return &r.items[itemIdx]
+	return r.itemAt(r.cursor)
 }
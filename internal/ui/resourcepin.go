@@ -0,0 +1,81 @@
+package ui
+
+import "github.com/charmbracelet/lipgloss"
+
+// SetPinned sets the URNs of the resources listed at the top of the list. The
+// map is shared with the parent, which calls PinnedChanged after changing it.
+func (r *ResourceList) SetPinned(pinned map[string]bool) {
+	r.pinned = pinned
+	r.PinnedChanged()
+}
+
+// PinnedChanged lists the pinned resources again after the shared map changed
+func (r *ResourceList) PinnedChanged() {
+	r.rebuildVisibleIndex()
+	r.rebuildFilteredIndex()
+}
+
+// TogglePinned pins the resource under the cursor, or unpins it if pinned, and
+// returns it with whether it is now pinned. The cursor stays on the resource's
+// row in the list below the pinned ones.
+func (r *ResourceList) TogglePinned() (*ResourceItem, bool) {
+	item := r.SelectedItem()
+	if item == nil || r.pinned == nil {
+		return nil, false
+	}
+	urn := item.URN
+	onPinnedRow := r.isPinnedRow(r.cursor)
+	if r.pinned[urn] {
+		delete(r.pinned, urn)
+	} else {
+		r.pinned[urn] = true
+	}
+	r.PinnedChanged()
+
+	if !onPinnedRow {
+		for pos := range r.effectiveItemCount() {
+			if item := r.itemAt(pos); item != nil && item.URN == urn && !r.isPinnedRow(pos) {
+				r.cursor = pos
+				break
+			}
+		}
+		r.ensureCursorVisible()
+	}
+	return &r.items[r.urnIdx[urn]], r.pinned[urn]
+}
+
+// prependPinnedRows lists the pinned resources, in tree order, above the others.
+// They stay in their place below too, so the tree keeps its shape.
+func (r *ResourceList) prependPinnedRows() {
+	r.pinnedRows = 0
+	if len(r.pinned) == 0 {
+		return
+	}
+	var rows []int
+	for i := range r.items {
+		if r.pinned[r.items[i].URN] {
+			rows = append(rows, i)
+		}
+	}
+	if len(rows) > 0 {
+		r.pinnedRows = len(rows)
+		r.visibleIdx = append(rows, r.visibleIdx...)
+	}
+}
+
+// isPinnedRow returns whether the row at cursor position pos lists a pinned
+// resource above the others
+func (r *ResourceList) isPinnedRow(pos int) bool {
+	visIdx := r.effectiveIndex(pos)
+	return visIdx >= 0 && visIdx < r.pinnedRows
+}
+
+func (r *ResourceList) buildPinBadge(urn string, styles renderStyles) string {
+	if !r.pinned[urn] {
+		return ""
+	}
+	if styles.hasBackground {
+		return lipgloss.NewStyle().Background(styles.bg).Render("  ") + styles.dim.Render("[pinned]")
+	}
+	return "  " + styles.dim.Render("[pinned]")
+}
@@ -3,8 +3,11 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
 )
 
 // View renders the resource list component
@@ -21,7 +24,7 @@ func (r *ResourceList) renderItems() string {
 	// Handle filter with no matches
 	if r.filter.Applied() && itemCount == 0 {
 		var b strings.Builder
-		b.WriteString(DimStyle.Render("No matches"))
+		b.WriteString(DimStyle.Render(i18n.T("No matches")))
 		b.WriteString("\n\n")
 		b.WriteString(RenderFilterBar(&r.filter, 0, len(r.visibleIdx), r.Width()))
 		paddedStyle := lipgloss.NewStyle().Padding(1, 2)
@@ -29,7 +32,7 @@ func (r *ResourceList) renderItems() string {
 	}
 
 	if len(r.visibleIdx) == 0 {
-		return RenderCenteredMessage("No resources", r.Width(), r.Height())
+		return RenderCenteredMessage(i18n.T("No resources"), r.Width(), r.Height())
 	}
 
 	var b strings.Builder
@@ -51,22 +54,22 @@ func (r *ResourceList) renderItems() string {
 	}
 
 	for i := r.scrollOffset; i < endIdx; i++ {
-		visIdx := r.effectiveIndex(i)
-		if visIdx < 0 || visIdx >= len(r.visibleIdx) {
+		isCursor := i == r.cursor
+		isFlashing := r.flashing && (r.flashAll || i == r.flashIdx)
+		if group := r.groupAt(i); group != nil {
+			b.WriteString(r.renderGroupHeader(group, isCursor, isFlashing))
+			b.WriteString("\n")
+			continue
+		}
+		item := r.itemAt(i)
+		if item == nil {
 			continue
 		}
-		itemIdx := r.visibleIdx[visIdx]
-		item := r.items[itemIdx]
 
-		isCursor := i == r.cursor
 		isVisualSelected := r.visualMode && i >= visualStart && i <= visualEnd
 		isDiscretelySelected := r.IsDiscretelySelected(item.URN)
-		isFlashing := r.flashing && (r.flashAll || i == r.flashIdx)
-
-		// Build ancestorIsLast by tracing back through parent chain
-		ancestorIsLast := r.buildAncestorIsLast(itemIdx)
 
-		line := r.renderItemWithSelectionType(item, isCursor, isVisualSelected, isDiscretelySelected, isFlashing, ancestorIsLast)
+		line := r.renderItemWithSelectionType(*item, isCursor, isVisualSelected, isDiscretelySelected, isFlashing, r.isPinnedRow(i))
 		b.WriteString(line)
 		b.WriteString("\n")
 	}
@@ -123,6 +126,7 @@ type renderStyles struct {
 	op, dim, value, cursor               lipgloss.Style
 	flagTarget, flagReplace, flagExclude lipgloss.Style
 	flagProtect                          lipgloss.Style
+	diffChanged                          lipgloss.Style
 	tree                                 lipgloss.Style
 	bg                                   lipgloss.Color
 	hasBackground                        bool
@@ -138,6 +142,7 @@ func newRenderStyles(opStyle lipgloss.Style, isFlashing, isVisualSelected, isDis
 		flagReplace: FlagReplaceStyle,
 		flagExclude: FlagExcludeStyle,
 		flagProtect: FlagProtectStyle,
+		diffChanged: DiffChangedStyle,
 		tree:        TreeLineStyle,
 	}
 
@@ -165,6 +170,7 @@ func newRenderStyles(opStyle lipgloss.Style, isFlashing, isVisualSelected, isDis
 		rs.flagReplace = rs.flagReplace.Background(rs.bg)
 		rs.flagExclude = rs.flagExclude.Background(rs.bg)
 		rs.flagProtect = rs.flagProtect.Background(rs.bg)
+		rs.diffChanged = rs.diffChanged.Background(rs.bg)
 		rs.tree = rs.tree.Background(rs.bg)
 	}
 
@@ -202,29 +208,82 @@ func buildProtectBadge(protected bool, styles renderStyles) string {
 	return "  " + styles.flagProtect.Render("[Protected]")
 }
 
-func (r *ResourceList) renderItemWithSelectionType(item ResourceItem, isCursor, isVisualSelected, isDiscretelySelected, isFlashing bool, ancestorIsLast []bool) string {
+func buildDiffChangedBadge(changed bool, styles renderStyles) string {
+	if !changed {
+		return ""
+	}
+	if styles.hasBackground {
+		return lipgloss.NewStyle().Background(styles.bg).Render("  ") + styles.diffChanged.Render("[changed]")
+	}
+	return "  " + styles.diffChanged.Render("[changed]")
+}
+
+func (r *ResourceList) buildNoteBadge(urn string, styles renderStyles) string {
+	if r.notes[urn] == "" {
+		return ""
+	}
+	if styles.hasBackground {
+		return lipgloss.NewStyle().Background(styles.bg).Render("  ") + styles.dim.Render("[note]")
+	}
+	return "  " + styles.dim.Render("[note]")
+}
+
+// maxBadgeKeys is how many properties are named in the drift and replace badges
+const maxBadgeKeys = 3
+
+// buildKeysBadge renders a badge naming the first few of keys after label, like
+// [drift: tags, size +2], or nothing if there are no keys
+func buildKeysBadge(label string, keys []string, styles renderStyles) string {
+	if len(keys) == 0 {
+		return ""
+	}
+	names := strings.Join(keys[:min(len(keys), maxBadgeKeys)], ", ")
+	if len(keys) > maxBadgeKeys {
+		names += fmt.Sprintf(" +%d", len(keys)-maxBadgeKeys)
+	}
+	badge := styles.dim.Render(fmt.Sprintf("[%s: %s]", label, names))
+	if styles.hasBackground {
+		return lipgloss.NewStyle().Background(styles.bg).Render("  ") + badge
+	}
+	return "  " + badge
+}
+
+// renderItemWithSelectionType renders the row of an item. Rows of pinned resources
+// listed at the top are drawn without tree lines.
+func (r *ResourceList) renderItemWithSelectionType(item ResourceItem, isCursor, isVisualSelected, isDiscretelySelected, isFlashing, isPinnedRow bool) string {
 	opInfo := getOpSymbolInfo(item.Op)
 	styles := newRenderStyles(opInfo.style, isFlashing, isVisualSelected, isDiscretelySelected)
 
 	cursor := r.renderCursor(isCursor, styles)
-	treePrefix := buildTreePrefix(item, ancestorIsLast, styles.hasBackground, styles.bg, styles.tree)
+	var treePrefix string
+	if isPinnedRow {
+		item.Depth = 0
+	} else {
+		treePrefix = r.renderTreePrefix(&item, styles)
+	}
 	statusIcon := r.renderStatusIcon(item.Status, item.Op, item.CurrentOp)
 	if statusIcon != "" {
 		statusIcon = " " + statusIcon
+		if elapsed, ok := item.Elapsed(time.Now()); ok && item.Status != StatusPending {
+			statusIcon += styles.dim.Render(" " + FormatDuration(elapsed))
+		}
 	}
 
 	opStr := styles.op.Render(fmt.Sprintf("[%s]", opInfo.symbol))
 	maxTypeLen := r.calculateMaxTypeLen(item)
-	typeStr := styles.dim.Render(truncateMiddle(item.Type, maxTypeLen))
-	nameStr := styles.value.Render(item.Name)
+	typeStr := withResourceIcon(item.Type, styles.dim.Render(truncateMiddle(item.Type, maxTypeLen)))
+	nameStr := styles.value.Render(item.Name) + r.buildCollapsedBadge(&item, styles)
 	protectBadge := buildProtectBadge(item.Protected, styles)
 	flagBadges := r.buildFlagBadges(item.URN, styles)
+	changedBadge := buildDiffChangedBadge(item.DiffChanged, styles)
+	driftBadge := buildKeysBadge("drift", item.DriftedKeys, styles) + buildKeysBadge("replace", item.ReplaceKeys, styles)
+	noteBadge := r.buildNoteBadge(item.URN, styles) + r.buildPinBadge(item.URN, styles)
 
 	if styles.hasBackground {
 		bgStyle := lipgloss.NewStyle().Background(styles.bg)
-		return fmt.Sprintf("%s%s%s%s%s%s%s%s%s%s", cursor, treePrefix, opStr, bgStyle.Render(" "), typeStr, bgStyle.Render("  "), nameStr, protectBadge, flagBadges, statusIcon)
+		return fmt.Sprintf("%s%s%s%s%s%s%s%s%s%s%s%s%s", cursor, treePrefix, opStr, bgStyle.Render(" "), typeStr, bgStyle.Render("  "), nameStr, protectBadge, flagBadges, changedBadge, driftBadge, noteBadge, statusIcon)
 	}
-	return fmt.Sprintf("%s%s%s %s  %s%s%s%s", cursor, treePrefix, opStr, typeStr, nameStr, protectBadge, flagBadges, statusIcon)
+	return fmt.Sprintf("%s%s%s %s  %s%s%s%s%s%s%s", cursor, treePrefix, opStr, typeStr, nameStr, protectBadge, flagBadges, changedBadge, driftBadge, noteBadge, statusIcon)
 }
 
 func (r *ResourceList) renderCursor(isCursor bool, styles renderStyles) string {
@@ -241,7 +300,13 @@ func (r *ResourceList) calculateMaxTypeLen(item ResourceItem) int {
 	maxTypeLen := DefaultMaxTypeLength
 	if r.Width() > 0 {
 		treePrefixLen := item.Depth * 3
+		if r.grouping != GroupByTree {
+			treePrefixLen = len(groupIndent)
+		}
 		otherElements := 2 + treePrefixLen + 4 + 3 + len(item.Name) + 12 + 20 + 4
+		if iconsEnabled {
+			otherElements += 2 // Icon and space before the type
+		}
 		available := r.Width() - otherElements
 		if available > MinTypeLength && available < maxTypeLen {
 			maxTypeLen = available
@@ -271,23 +336,23 @@ func (r *ResourceList) renderStatusIcon(status ItemStatus, op, currentOp Resourc
 func (r *ResourceList) getRunningStatusText(op ResourceOp) string {
 	switch op {
 	case OpCreate:
-		return OpCreateStyle.Render("creating...")
+		return OpCreateStyle.Render(i18n.T("creating..."))
 	case OpUpdate:
-		return OpUpdateStyle.Render("updating...")
+		return OpUpdateStyle.Render(i18n.T("updating..."))
 	case OpDelete:
-		return OpDeleteStyle.Render("deleting...")
+		return OpDeleteStyle.Render(i18n.T("deleting..."))
 	case OpReplace:
-		return OpReplaceStyle.Render("replacing...")
+		return OpReplaceStyle.Render(i18n.T("replacing..."))
 	case OpCreateReplace:
-		return OpCreateStyle.Render("creating replacement...")
+		return OpCreateStyle.Render(i18n.T("creating replacement..."))
 	case OpDeleteReplace:
-		return OpDeleteStyle.Render("deleting original...")
+		return OpDeleteStyle.Render(i18n.T("deleting original..."))
 	case OpRefresh:
-		return OpRefreshStyle.Render("refreshing...")
+		return OpRefreshStyle.Render(i18n.T("refreshing..."))
 	case OpRead:
-		return StatusRunningStyle.Render("reading...")
+		return StatusRunningStyle.Render(i18n.T("reading..."))
 	default:
-		return StatusRunningStyle.Render("running...")
+		return StatusRunningStyle.Render(i18n.T("running..."))
 	}
 }
 
@@ -323,7 +388,31 @@ func (r *ResourceList) ClearFlash() {
 	r.flashAll = false
 }
 
-func buildTreePrefix(item ResourceItem, ancestorIsLast []bool, hasBackground bool, bg lipgloss.Color, treeStyle lipgloss.Style) string {
+// groupIndent is drawn before the items of a grouped list instead of tree lines
+const groupIndent = "  "
+
+// renderTreePrefix returns the tree lines drawn before an item. Prefixes of
+// unhighlighted rows are cached by URN until the tree changes.
+func (r *ResourceList) renderTreePrefix(item *ResourceItem, styles renderStyles) string {
+	// Grouped lists are flat, the items indented under their group's header
+	if r.grouping != GroupByTree {
+		if styles.hasBackground {
+			return lipgloss.NewStyle().Background(styles.bg).Render(groupIndent)
+		}
+		return groupIndent
+	}
+	if styles.hasBackground {
+		return buildTreePrefix(item, r.buildAncestorIsLast(item), true, styles.bg, styles.tree)
+	}
+	if prefix, ok := r.treePrefixes[item.URN]; ok {
+		return prefix
+	}
+	prefix := buildTreePrefix(item, r.buildAncestorIsLast(item), false, "", styles.tree)
+	r.treePrefixes[item.URN] = prefix
+	return prefix
+}
+
+func buildTreePrefix(item *ResourceItem, ancestorIsLast []bool, hasBackground bool, bg lipgloss.Color, treeStyle lipgloss.Style) string {
 	if item.Depth == 0 {
 		return ""
 	}
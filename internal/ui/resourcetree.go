@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"slices"
 	"sort"
 
 	"github.com/rfhold/p5/internal/pulumi"
@@ -87,10 +88,8 @@ func (r *ResourceList) ensureParentExists(parentURN string) {
 	}
 
 	// Check if parent already exists
-	for i := range r.items {
-		if r.items[i].URN == parentURN {
-			return // Parent exists
-		}
+	if _, ok := r.urnIdx[parentURN]; ok {
+		return
 	}
 
 	// Parent doesn't exist - create a placeholder with OpSame
@@ -101,14 +100,112 @@ func (r *ResourceList) ensureParentExists(parentURN string) {
 	// Add the parent placeholder
 	// Note: We don't know the grandparent URN from the URN alone,
 	// but when the parent's event arrives (if ever), it will update with correct parent
-	r.items = append(r.items, ResourceItem{
+	r.placeSubtree([]ResourceItem{{
 		URN:    parentURN,
 		Type:   parentType,
 		Name:   parentName,
 		Op:     OpSame,
 		Status: StatusNone,
 		Parent: "", // Will be updated if parent's event arrives later
-	})
+	}})
+}
+
+// subtreeEnd returns the index after the last descendant of the item at i
+func (r *ResourceList) subtreeEnd(i int) int {
+	end := i + 1
+	for end < len(r.items) && r.items[end].Depth > r.items[i].Depth {
+		end++
+	}
+	return end
+}
+
+// placeSubtree inserts an item followed by its descendants in tree order among
+// the children of its parent, which must already be in the list. Only the rows
+// whose tree lines change are dropped from the prefix cache: the inserted ones,
+// and those of the sibling it replaces as the last child.
+func (r *ResourceList) placeSubtree(block []ResourceItem) {
+	parent, depth := -1, 0
+	if i, ok := r.urnIdx[block[0].Parent]; ok && block[0].Parent != "" {
+		parent, depth = i, r.items[i].Depth+1
+	}
+	start, end := parent+1, len(r.items)
+	if parent >= 0 {
+		end = r.subtreeEnd(parent)
+	}
+
+	// Siblings are the items at depth in the parent's subtree
+	pos, lastSibling := end, -1
+	for j := start; j < end; j = r.subtreeEnd(j) {
+		if compareItems(&block[0], &r.items[j]) {
+			pos = j
+			break
+		}
+		lastSibling = j
+	}
+	if pos == end && lastSibling >= 0 {
+		r.items[lastSibling].IsLast = false
+		r.forgetTreePrefixes(lastSibling, r.subtreeEnd(lastSibling))
+	}
+
+	offset := depth - block[0].Depth
+	for i := range block {
+		block[i].Depth += offset
+	}
+	block[0].IsLast = pos == end
+
+	r.items = slices.Insert(r.items, pos, block...)
+	r.reindexFrom(pos)
+	r.forgetTreePrefixes(pos, pos+len(block))
+}
+
+// removeSubtree removes the item at i and its descendants, returning them in tree
+// order. If the item was the last child, its previous sibling becomes the last.
+func (r *ResourceList) removeSubtree(i int) []ResourceItem {
+	end := r.subtreeEnd(i)
+	block := slices.Clone(r.items[i:end])
+
+	if r.items[i].IsLast {
+		for j := i - 1; j >= 0 && r.items[j].Depth >= r.items[i].Depth; j-- {
+			if r.items[j].Depth == r.items[i].Depth {
+				r.items[j].IsLast = true
+				r.forgetTreePrefixes(j, r.subtreeEnd(j))
+				break
+			}
+		}
+	}
+
+	for _, item := range block {
+		delete(r.urnIdx, item.URN)
+		delete(r.treePrefixes, item.URN)
+	}
+	r.items = slices.Delete(r.items, i, end)
+	r.reindexFrom(i)
+	return block
+}
+
+// reindexFrom updates the URN index for the items from i on, after items were
+// inserted or removed at i
+func (r *ResourceList) reindexFrom(i int) {
+	for ; i < len(r.items); i++ {
+		r.urnIdx[r.items[i].URN] = i
+	}
+}
+
+// forgetTreePrefixes drops the cached tree prefixes of the items from start to end
+func (r *ResourceList) forgetTreePrefixes(start, end int) {
+	for i := start; i < end; i++ {
+		delete(r.treePrefixes, r.items[i].URN)
+	}
+}
+
+// treeChanged rebuilds the URN index and drops cached tree prefixes. It must be
+// called whenever items are replaced, added or reordered.
+func (r *ResourceList) treeChanged() {
+	r.urnIdx = make(map[string]int, len(r.items))
+	for i := range r.items {
+		r.urnIdx[r.items[i].URN] = i
+	}
+	clear(r.treePrefixes)
 }
 
 // extractResourceType gets the resource type from a URN
@@ -151,41 +248,45 @@ func isReplaceOp(op ResourceOp) bool {
 	return op == OpReplace || op == OpCreateReplace || op == OpDeleteReplace
 }
 
-// rebuildVisibleIndex applies filters to build the visible index
+// rebuildVisibleIndex applies filters to build the visible index. It runs on every
+// streamed event, so it makes a single pass over the items without allocating.
 func (r *ResourceList) rebuildVisibleIndex() {
-	r.visibleIdx = make([]int, 0, len(r.items))
+	r.visibleIdx = r.visibleIdx[:0]
+	r.groups = r.groups[:0]
 
-	if r.showAllOps {
+	if r.grouping != GroupByTree {
+		r.rebuildGroupedIndex()
+	} else if r.showAllOps {
 		// Show everything
 		for i := range r.items {
 			r.visibleIdx = append(r.visibleIdx, i)
 		}
 	} else {
-		// Build set of URNs that have changes (not OpSame)
-		// and URNs that are ancestors of changed items
-		visibleURNs := make(map[string]bool)
-
-		// First pass: mark all items with changes
-		for i := range r.items {
-			if r.items[i].Op != OpSame {
-				visibleURNs[r.items[i].URN] = true
+		// Items with changes are shown along with their ancestors. Walking the
+		// tree backwards reaches descendants before their ancestors, so whether
+		// a subtree has changes is carried up by depth.
+		r.visibleIdx = slices.Grow(r.visibleIdx, len(r.items))[:len(r.items)]
+		n := len(r.items)
+		var changedBelow []bool // Whether an item at depth d+1 below the current one is shown
+		for i := len(r.items) - 1; i >= 0; i-- {
+			d := r.items[i].Depth
+			for len(changedBelow) <= d+1 {
+				changedBelow = append(changedBelow, false)
 			}
-		}
-
-		// Second pass: mark all ancestors of changed items
-		for i := range r.items {
-			if r.items[i].Op != OpSame && r.items[i].Parent != "" {
-				r.markAncestorsVisible(r.items[i].Parent, visibleURNs)
-			}
-		}
-
-		// Third pass: add visible items in order
-		for i := range r.items {
-			if visibleURNs[r.items[i].URN] {
-				r.visibleIdx = append(r.visibleIdx, i)
+			visible := r.hasChanges(r.items[i]) || changedBelow[d+1]
+			changedBelow[d+1] = false
+			changedBelow[d] = changedBelow[d] || visible
+			if visible {
+				n--
+				r.visibleIdx[n] = i
 			}
 		}
+		r.visibleIdx = append(r.visibleIdx[:0], r.visibleIdx[n:]...)
 	}
+	if r.grouping == GroupByTree {
+		r.hideCollapsedSubtrees()
+	}
+	r.prependPinnedRows()
 
 	// Clamp cursor
 	if r.cursor >= len(r.visibleIdx) {
@@ -194,25 +295,12 @@ func (r *ResourceList) rebuildVisibleIndex() {
 	r.ensureCursorVisible()
 }
 
-// markAncestorsVisible recursively marks all ancestors as visible
-func (r *ResourceList) markAncestorsVisible(parentURN string, visibleURNs map[string]bool) {
-	if parentURN == "" {
-		return
-	}
-	if visibleURNs[parentURN] {
-		return // Already marked
-	}
-	visibleURNs[parentURN] = true
-
-	// Find the parent item and recurse to its parent
-	for i := range r.items {
-		if r.items[i].URN == parentURN {
-			if r.items[i].Parent != "" {
-				r.markAncestorsVisible(r.items[i].Parent, visibleURNs)
-			}
-			return
-		}
+// hasChanges returns whether an item is shown when unchanged resources are hidden
+func (r *ResourceList) hasChanges(item ResourceItem) bool {
+	if r.driftOnly {
+		return item.Drifted()
 	}
+	return item.Op != OpSame
 }
 
 // rebuildFilteredIndex applies the current filter to build the filtered index
@@ -222,10 +310,24 @@ func (r *ResourceList) rebuildFilteredIndex() {
 		return
 	}
 
+	// Group headers are kept for the matching items under them, or if their key matches
 	r.filteredIdx = make([]int, 0)
+	header := -1
 	for i, idx := range r.visibleIdx {
-		item := &r.items[idx]
-		if r.matchesFilter(item) {
+		if idx < 0 {
+			header = -1
+			if r.filter.MatchesAny(r.groups[-idx-1].Key) {
+				r.filteredIdx = append(r.filteredIdx, i)
+			} else {
+				header = i
+			}
+			continue
+		}
+		if r.matchesFilter(&r.items[idx]) {
+			if header >= 0 {
+				r.filteredIdx = append(r.filteredIdx, header)
+				header = -1
+			}
 			r.filteredIdx = append(r.filteredIdx, i)
 		}
 	}
@@ -281,24 +383,17 @@ func (r *ResourceList) effectiveIndex(cursorPos int) int {
 
 // buildAncestorIsLast traces back through the parent chain to determine
 // which ancestors were the last child of their parent (for tree line drawing)
-func (r *ResourceList) buildAncestorIsLast(itemIdx int) []bool {
-	item := r.items[itemIdx]
+func (r *ResourceList) buildAncestorIsLast(item *ResourceItem) []bool {
 	if item.Depth == 0 {
 		return nil
 	}
 
 	result := make([]bool, item.Depth-1)
 
-	// Build a URN -> item index map for quick lookup
-	urnToIdx := make(map[string]int)
-	for i := range r.items {
-		urnToIdx[r.items[i].URN] = i
-	}
-
 	// Trace back through parent chain
 	currentURN := item.Parent
 	for level := item.Depth - 2; level >= 0; level-- {
-		if parentIdx, ok := urnToIdx[currentURN]; ok {
+		if parentIdx, ok := r.urnIdx[currentURN]; ok {
 			parent := r.items[parentIdx]
 			result[level] = parent.IsLast
 			currentURN = parent.Parent
@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// SaveFileModal wraps StepModal to prompt for the file to save generated
+// content to
+type SaveFileModal struct {
+	*StepModal
+}
+
+// NewSaveFileModal creates a new save file modal
+func NewSaveFileModal() *SaveFileModal {
+	return &SaveFileModal{StepModal: NewStepModal(i18n.T("Save File"))}
+}
+
+// Show prompts for the file to save what description names, prefilled with path
+func (m *SaveFileModal) Show(title, description, path string) {
+	m.title = title
+	m.SetSteps([]StepModalStep{{
+		Title:            description,
+		InputLabel:       i18n.T("File"),
+		InputPlaceholder: i18n.T("Enter file path..."),
+	}})
+	m.StepModal.Show()
+	m.SetResult(0, path)
+	m.updateInputForCurrentStep()
+}
+
+// Path returns the entered file path
+func (m *SaveFileModal) Path() string {
+	return m.GetResult(0)
+}
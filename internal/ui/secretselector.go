@@ -0,0 +1,70 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// SecretItem is a secret property of a resource in the selector
+type SecretItem struct {
+	Path string // Property path, like password or tags.token
+}
+
+// Label implements SelectorItem
+func (s SecretItem) Label() string {
+	return s.Path
+}
+
+// IsCurrent implements SelectorItem
+func (s SecretItem) IsCurrent() bool {
+	return false
+}
+
+// SecretSelector is a modal dialog for choosing the secret of a resource to reveal
+type SecretSelector struct {
+	*SelectorDialog[SecretItem]
+	urn string
+}
+
+// NewSecretSelector creates a new secret selector
+func NewSecretSelector() *SecretSelector {
+	dialog := NewSelectorDialog[SecretItem](i18n.T("Reveal Secret"))
+	dialog.SetEmptyText(i18n.T("No secrets to reveal"))
+	return &SecretSelector{SelectorDialog: dialog}
+}
+
+// ShowSecrets lists the paths of the hidden secrets of the resource with urn
+func (s *SecretSelector) ShowSecrets(urn string, paths []string) {
+	s.urn = urn
+	items := make([]SecretItem, 0, len(paths))
+	for _, path := range paths {
+		items = append(items, SecretItem{Path: path})
+	}
+	s.SetItems(items)
+	s.Show()
+}
+
+// URN returns the URN of the resource whose secrets are listed
+func (s *SecretSelector) URN() string {
+	return s.urn
+}
+
+// SelectedPath returns the path of the selected secret, or empty if none
+func (s *SecretSelector) SelectedPath() string {
+	item := s.SelectedItem()
+	if item == nil {
+		return ""
+	}
+	return item.Path
+}
+
+// Update handles key events and returns true if a secret was selected
+func (s *SecretSelector) Update(msg tea.KeyMsg) (selected bool, cmd tea.Cmd) {
+	return s.SelectorDialog.Update(msg)
+}
+
+// View renders the secret selector dialog
+func (s *SecretSelector) View() string {
+	return s.SelectorDialog.View()
+}
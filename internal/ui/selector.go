@@ -2,11 +2,14 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
 )
 
 // SelectorItem is an interface for items that can be displayed in a SelectorDialog
@@ -34,18 +37,21 @@ type SelectorDialog[T SelectorItem] struct {
 	maxVisible      int
 	renderItem      func(item T, isCursor bool) string // Optional custom item renderer
 	renderExtraInfo func(item T) string                // Optional extra info after item label
+	hidden          func(item T, filtering bool) bool  // Optional check for items left out of the list
+	filterTextsFn   func(item T) []string              // Optional texts the filter matches, instead of the label
+	extraHint       func() string                      // Optional footer hint for extra keys
 
 	// Filter state
 	filter      FilterState
-	filteredIdx []int // Indices into items that match filter (nil = no filter active)
+	filteredIdx []int // Indices into items that are listed (nil = all items listed)
 }
 
 // NewSelectorDialog creates a new selector dialog with the given title
 func NewSelectorDialog[T SelectorItem](title string) *SelectorDialog[T] {
 	return &SelectorDialog[T]{
 		title:       title,
-		loadingText: "Loading...",
-		emptyText:   "No items found",
+		loadingText: i18n.T("Loading..."),
+		emptyText:   i18n.T("No items found"),
 		maxVisible:  10,
 		filter:      NewFilterState(),
 	}
@@ -56,17 +62,31 @@ func (s *SelectorDialog[T]) SetItems(items []T) {
 	s.items = items
 	s.loading = false
 	s.err = nil
-	s.filteredIdx = nil
+	s.filter.Clear()
 	s.filter.Deactivate()
+	s.rebuildFilteredIndex()
 	// Set cursor to current item if found
 	for i, item := range items {
 		if item.IsCurrent() {
-			s.cursor = i
+			s.SelectIndex(i)
 			break
 		}
 	}
 }
 
+// SelectIndex moves the cursor to the item at index i of the items, if listed
+func (s *SelectorDialog[T]) SelectIndex(i int) {
+	if s.filteredIdx == nil {
+		if i >= 0 && i < len(s.items) {
+			s.cursor = i
+		}
+		return
+	}
+	if pos := slices.Index(s.filteredIdx, i); pos >= 0 {
+		s.cursor = pos
+	}
+}
+
 // SetLoading sets the loading state
 func (s *SelectorDialog[T]) SetLoading(loading bool) {
 	s.loading = loading
@@ -92,8 +112,9 @@ func (s *SelectorDialog[T]) Show() {
 // Hide hides the selector dialog
 func (s *SelectorDialog[T]) Hide() {
 	s.visible = false
+	s.filter.Clear()
 	s.filter.Deactivate()
-	s.filteredIdx = nil
+	s.rebuildFilteredIndex()
 }
 
 // Visible returns whether the selector is visible
@@ -131,6 +152,45 @@ func (s *SelectorDialog[T]) SetExtraInfoRenderer(fn func(item T) string) {
 	s.renderExtraInfo = fn
 }
 
+// SetHiddenFunc sets a function returning whether an item is left out of the list,
+// e.g. when it is in a collapsed group. filtering is true while a filter is
+// applied, as all matching items are usually listed then.
+func (s *SelectorDialog[T]) SetHiddenFunc(fn func(item T, filtering bool) bool) {
+	s.hidden = fn
+	s.rebuildFilteredIndex()
+}
+
+// SetFilterTexts sets a function returning the texts the filter matches for an item.
+// By default the filter matches the item's label.
+func (s *SelectorDialog[T]) SetFilterTexts(fn func(item T) []string) {
+	s.filterTextsFn = fn
+}
+
+// SetFuzzyFilter sets whether the filter matches its characters in order rather
+// than as a substring
+func (s *SelectorDialog[T]) SetFuzzyFilter(fuzzy bool) {
+	s.filter.SetFuzzy(fuzzy)
+}
+
+// Relist applies a change to which items are hidden, keeping the cursor on the
+// same item when it is still listed
+func (s *SelectorDialog[T]) Relist() {
+	current := s.effectiveIndex(s.cursor)
+	s.rebuildFilteredIndex()
+	s.SelectIndex(current)
+}
+
+// SetExtraHint sets a function returning a footer hint for keys the caller handles,
+// e.g. "* pin". It is called on each render so remapped keys are shown.
+func (s *SelectorDialog[T]) SetExtraHint(fn func() string) {
+	s.extraHint = fn
+}
+
+// FilterActive returns whether the filter is receiving input, so keys are typed into it
+func (s *SelectorDialog[T]) FilterActive() bool {
+	return s.filter.Active()
+}
+
 // SelectedItem returns the currently selected item, or nil if none
 func (s *SelectorDialog[T]) SelectedItem() *T {
 	itemCount := s.effectiveItemCount()
@@ -163,17 +223,30 @@ func (s *SelectorDialog[T]) effectiveIndex(cursorPos int) int {
 	return cursorPos
 }
 
-// rebuildFilteredIndex applies the current filter to build the filtered index
+// rebuildFilteredIndex applies the current filter and hidden items to build the filtered index
 func (s *SelectorDialog[T]) rebuildFilteredIndex() {
-	if !s.filter.Applied() {
+	filtering := s.filter.Applied()
+	if !filtering && s.hidden == nil {
 		s.filteredIdx = nil
 		return
 	}
 
-	s.filteredIdx = make([]int, 0)
-	for i, item := range s.items {
-		if s.filter.Matches(item.Label()) {
-			s.filteredIdx = append(s.filteredIdx, i)
+	if filtering {
+		s.filteredIdx = s.filter.rankMatches(len(s.items), func(i int) []string {
+			if s.hidden != nil && s.hidden(s.items[i], true) {
+				return nil
+			}
+			return s.filterTexts(s.items[i])
+		})
+		if s.filter.Fuzzy() {
+			s.cursor = 0 // Select the best match
+		}
+	} else {
+		s.filteredIdx = make([]int, 0)
+		for i, item := range s.items {
+			if !s.hidden(item, false) {
+				s.filteredIdx = append(s.filteredIdx, i)
+			}
 		}
 	}
 
@@ -183,6 +256,19 @@ func (s *SelectorDialog[T]) rebuildFilteredIndex() {
 	}
 }
 
+// filterTexts returns the texts the filter matches for the item
+func (s *SelectorDialog[T]) filterTexts(item T) []string {
+	if s.filterTextsFn != nil {
+		return s.filterTextsFn(item)
+	}
+	return []string{item.Label()}
+}
+
+// Items returns the listed items, including those hidden by the filter
+func (s *SelectorDialog[T]) Items() []T {
+	return s.items
+}
+
 // HasItems returns whether any items are available
 func (s *SelectorDialog[T]) HasItems() bool {
 	return len(s.items) > 0
@@ -232,9 +318,7 @@ func (s *SelectorDialog[T]) Update(msg tea.KeyMsg) (selected bool, cmd tea.Cmd)
 			return true, nil
 		}
 	case key.Matches(msg, Keys.Escape):
-		s.visible = false
-		s.filter.Deactivate()
-		s.filteredIdx = nil
+		s.Hide()
 		return false, nil
 	}
 
@@ -255,7 +339,7 @@ func (s *SelectorDialog[T]) View() string {
 	case len(s.items) == 0:
 		content = DimStyle.Render(s.emptyText)
 	case s.filter.Applied() && itemCount == 0:
-		content = DimStyle.Render("No matches")
+		content = DimStyle.Render(i18n.T("No matches"))
 	default:
 		// Add line count hint to title if scrollable
 		if itemCount > s.maxVisible {
@@ -278,10 +362,13 @@ func (s *SelectorDialog[T]) View() string {
 
 	// Footer with filter bar or hints
 	var footer string
-	if s.filter.ActiveOrApplied() {
+	switch {
+	case s.filter.ActiveOrApplied():
 		filterBar := RenderFilterBar(&s.filter, itemCount, len(s.items), s.width)
 		footer = "\n" + filterBar + "\n" + DimStyle.Render("↑/↓ navigate  enter select  esc cancel")
-	} else {
+	case s.extraHint != nil:
+		footer = DimStyle.Render("\n↑/↓ navigate  / filter  " + s.extraHint() + "  enter select  esc cancel")
+	default:
 		footer = DimStyle.Render("\n↑/↓ navigate  / filter  enter select  esc cancel")
 	}
 
@@ -1,10 +1,14 @@
 package ui
 
 import (
+	"errors"
+	"maps"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/rfhold/p5/internal/i18n"
 	"github.com/rfhold/p5/internal/pulumi"
 )
 
@@ -27,21 +31,27 @@ type StackInitModal struct {
 
 	// Auth environment from plugins (may contain PULUMI_CONFIG_PASSPHRASE)
 	authEnv map[string]string
+
+	// Initial config values entered in the last step, in entry order
+	configKeys []string
+	config     map[string]string
 }
 
 const (
 	stepStackName       = 0
 	stepSecretsProvider = 1
 	stepPassphrase      = 2
+	stepCopyConfig      = 3
+	stepConfig          = 4
 )
 
 // NewStackInitModal creates a new stack init modal
 func NewStackInitModal() *StackInitModal {
 	m := &StackInitModal{
-		StepModal:            NewStepModal("Initialize Stack"),
+		StepModal:            NewStepModal(i18n.T("Initialize Stack")),
 		stacksWithEncryption: make(map[string]bool),
 		defaultProviders: []StepSuggestion{
-			{ID: "passphrase", Label: "passphrase", Description: "Default passphrase-based encryption"},
+			{ID: "passphrase", Label: "passphrase", Description: i18n.T("Default passphrase-based encryption")},
 			{ID: "awskms://alias/pulumi", Label: "awskms://alias/pulumi", Description: "AWS KMS"},
 			{ID: "azurekeyvault://", Label: "azurekeyvault://...", Description: "Azure Key Vault"},
 			{ID: "gcpkms://", Label: "gcpkms://...", Description: "Google Cloud KMS"},
@@ -59,24 +69,41 @@ func NewStackInitModal() *StackInitModal {
 func (m *StackInitModal) configureSteps() {
 	steps := []StepModalStep{
 		{
-			Title:            "Select or enter stack name",
-			InputLabel:       "Stack name",
-			InputPlaceholder: "Enter stack name...",
+			Title:            i18n.T("Select or enter stack name"),
+			InputLabel:       i18n.T("Stack name"),
+			InputPlaceholder: i18n.T("Enter stack name..."),
 		},
 		{
-			Title:            "Select secrets provider",
-			InputLabel:       "Provider URL",
-			InputPlaceholder: "Enter provider URL...",
+			Title:            i18n.T("Select secrets provider"),
+			InputLabel:       i18n.T("Provider URL"),
+			InputPlaceholder: i18n.T("Enter provider URL..."),
 		},
 		{
-			Title:            "Enter passphrase",
-			InputLabel:       "Passphrase",
-			InputPlaceholder: "Enter passphrase for encrypting secrets...",
+			Title:            i18n.T("Enter passphrase"),
+			InputLabel:       i18n.T("Passphrase"),
+			InputPlaceholder: i18n.T("Enter passphrase for encrypting secrets..."),
 			PasswordMode:     true,
 		},
+		{
+			Title:            i18n.T("Copy config from another stack"),
+			InputLabel:       i18n.T("Stack"),
+			InputPlaceholder: i18n.T("Enter stack name..."),
+			Optional:         true,
+		},
+		{
+			Title:            i18n.T("Set initial config"),
+			InputLabel:       i18n.T("Config value"),
+			InputPlaceholder: "key=value",
+			Optional:         true,
+			FooterHints:      "enter " + i18n.T("add / create stack") + "  backspace " + i18n.T("back") + "  esc " + i18n.T("cancel"),
+		},
 	}
+	steps[stepSecretsProvider].Validate = pulumi.ValidateSecretsProvider
+	steps[stepCopyConfig].Validate = m.validateCopyConfigFrom
 
 	m.SetSteps(steps)
+	m.configKeys = nil
+	m.config = make(map[string]string)
 }
 
 // Show shows the modal and resets state
@@ -84,6 +111,7 @@ func (m *StackInitModal) Show() {
 	m.StepModal.Show()
 	m.configureSteps()
 	m.updateBackendInfo()
+	m.updateSecretsProviderSuggestions()
 }
 
 // SetBackendInfo sets the backend connection information
@@ -109,10 +137,10 @@ func (m *StackInitModal) SetStackFiles(files []pulumi.StackFileInfo) {
 		s := StepSuggestion{
 			ID:     f.Name,
 			Label:  f.Name,
-			Source: "from Pulumi." + f.Name + ".yaml",
+			Source: i18n.Tf("from %s", "Pulumi."+f.Name+".yaml"),
 		}
 		if f.HasEncryption {
-			s.Warning = "has existing encryption"
+			s.Warning = i18n.T("has existing encryption")
 			m.stacksWithEncryption[f.Name] = true
 		}
 		suggestions = append(suggestions, s)
@@ -126,10 +154,10 @@ func (m *StackInitModal) SetStackFiles(files []pulumi.StackFileInfo) {
 func (m *StackInitModal) updateBackendInfo() {
 	info := []InfoLine{}
 	if m.backendURL != "" {
-		info = append(info, InfoLine{Label: "Backend", Value: m.backendURL})
+		info = append(info, InfoLine{Label: i18n.T("Backend"), Value: m.backendURL})
 	}
 	if m.backendUser != "" {
-		info = append(info, InfoLine{Label: "User", Value: m.backendUser})
+		info = append(info, InfoLine{Label: i18n.T("User"), Value: m.backendUser})
 	}
 	m.SetStepInfoLines(stepStackName, info)
 }
@@ -147,7 +175,7 @@ func (m *StackInitModal) updateSecretsProviderSuggestions() {
 			suggestions = append(suggestions, StepSuggestion{
 				ID:     f.SecretsProvider,
 				Label:  f.SecretsProvider,
-				Source: "from Pulumi." + f.Name + ".yaml",
+				Source: i18n.Tf("from %s", "Pulumi."+f.Name+".yaml"),
 			})
 		}
 	}
@@ -162,18 +190,93 @@ func (m *StackInitModal) updateSecretsProviderSuggestions() {
 	m.SetStepSuggestions(stepSecretsProvider, suggestions)
 }
 
-// Update handles key events and manages step transitions
+// Update handles key events and manages step transitions. Steps that don't apply
+// to the choices made so far are skipped in both directions.
 func (m *StackInitModal) Update(msg tea.KeyMsg) (StepModalAction, tea.Cmd) {
+	// Each value entered in the last step is added to the config; an empty enter
+	// creates the stack
+	if m.CurrentStep() == stepConfig && msg.String() == "enter" {
+		if value := strings.TrimSpace(m.input.Value()); value != "" {
+			m.addConfigValue(value)
+			return StepModalActionNone, nil
+		}
+	}
+
 	action, cmd := m.StepModal.Update(msg)
 
-	// Handle step transitions
-	if action == StepModalActionNext {
+	switch action {
+	case StepModalActionNext:
+		for m.skipStep(m.CurrentStep()) && m.NextStep() {
+		}
 		m.onStepTransition()
+	case StepModalActionPrev:
+		for m.skipStep(m.CurrentStep()) && m.PrevStep() {
+		}
 	}
 
 	return action, cmd
 }
 
+// skipStep returns true if a step doesn't apply to the choices made so far
+func (m *StackInitModal) skipStep(step int) bool {
+	switch step {
+	case stepPassphrase:
+		return !m.NeedsPassphrase()
+	case stepCopyConfig:
+		return len(m.copyableStacks()) == 0
+	}
+	return false
+}
+
+// copyableStacks returns the stack files config can be copied from, leaving out
+// the stack being created
+func (m *StackInitModal) copyableStacks() []pulumi.StackFileInfo {
+	return otherStackFiles(m.stackFiles, m.GetResult(stepStackName))
+}
+
+// validateCopyConfigFrom checks config is copied from a stack with a stack file
+func (m *StackInitModal) validateCopyConfigFrom(stackName string) error {
+	return validateStackFile(m.copyableStacks(), stackName)
+}
+
+// addConfigValue adds a key=value pair entered in the config step
+func (m *StackInitModal) addConfigValue(value string) {
+	key, val, ok := strings.Cut(value, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" || strings.ContainsAny(key, " \t") {
+		m.SetError(errors.New(i18n.T("expected key=value")))
+		return
+	}
+	if _, exists := m.config[key]; !exists {
+		m.configKeys = append(m.configKeys, key)
+	}
+	m.config[key] = strings.TrimSpace(val)
+	m.input.SetValue("")
+	m.ClearError()
+	m.updateConfigInfo()
+}
+
+// stackSummary returns the info lines describing the choices made so far
+func (m *StackInitModal) stackSummary() []InfoLine {
+	info := []InfoLine{{Label: i18n.T("Stack"), Value: m.GetResult(stepStackName)}}
+	if m.CurrentStep() > stepSecretsProvider {
+		info = append(info, InfoLine{Label: i18n.T("Secrets Provider"), Value: m.GetResult(stepSecretsProvider)})
+	}
+	if m.CurrentStep() > stepCopyConfig && m.GetResult(stepCopyConfig) != "" {
+		info = append(info, InfoLine{Label: i18n.T("Copy config from"), Value: m.GetResult(stepCopyConfig)})
+	}
+	return info
+}
+
+// updateConfigInfo lists the config values entered so far in the config step
+func (m *StackInitModal) updateConfigInfo() {
+	info := m.stackSummary()
+	for _, key := range m.configKeys {
+		info = append(info, InfoLine{Label: key, Value: m.config[key]})
+	}
+	m.SetStepInfoLines(stepConfig, info)
+}
+
 // onStepTransition handles updates needed when moving between steps
 func (m *StackInitModal) onStepTransition() {
 	currentStep := m.CurrentStep()
@@ -182,28 +285,26 @@ func (m *StackInitModal) onStepTransition() {
 	case stepSecretsProvider:
 		// Update info for step 2 with selected stack
 		stackName := m.GetResult(stepStackName)
-		info := []InfoLine{
-			{Label: "Stack", Value: stackName},
-		}
-		m.SetStepInfoLines(stepSecretsProvider, info)
+		m.SetStepInfoLines(stepSecretsProvider, m.stackSummary())
 
 		// Set warning if stack has existing encryption
 		if m.stacksWithEncryption[stackName] {
 			m.SetStepWarning(stepSecretsProvider,
-				"Stack '"+stackName+"' already has encryption configured. Re-initializing may cause issues with existing secrets.")
+				i18n.Tf("Stack '%s' already has encryption configured. Re-initializing may cause issues with existing secrets.", stackName))
 		} else {
 			m.SetStepWarning(stepSecretsProvider, "")
 		}
 
 	case stepPassphrase:
-		// Update info for step 3
-		stackName := m.GetResult(stepStackName)
-		provider := m.GetResult(stepSecretsProvider)
-		info := []InfoLine{
-			{Label: "Stack", Value: stackName},
-			{Label: "Secrets Provider", Value: provider},
-		}
-		m.SetStepInfoLines(stepPassphrase, info)
+		m.SetStepInfoLines(stepPassphrase, m.stackSummary())
+
+	case stepCopyConfig:
+		m.SetStepInfoLines(stepCopyConfig, m.stackSummary())
+		suggestions := []StepSuggestion{{ID: "", Label: i18n.T("(none)"), Description: i18n.T("Start with empty config")}}
+		m.SetStepSuggestions(stepCopyConfig, append(suggestions, copyConfigSuggestions(m.copyableStacks())...))
+
+	case stepConfig:
+		m.updateConfigInfo()
 	}
 }
 
@@ -228,11 +329,6 @@ func (m *StackInitModal) NeedsPassphrase() bool {
 	return false
 }
 
-// ShouldSkipPassphrase returns true if we should skip the passphrase step
-func (m *StackInitModal) ShouldSkipPassphrase() bool {
-	return !m.NeedsPassphrase()
-}
-
 // GetStackName returns the selected/entered stack name
 func (m *StackInitModal) GetStackName() string {
 	return m.GetResult(stepStackName)
@@ -243,24 +339,26 @@ func (m *StackInitModal) GetSecretsProvider() string {
 	return m.GetResult(stepSecretsProvider)
 }
 
-// GetPassphrase returns the entered passphrase
+// GetPassphrase returns the entered passphrase, or "" if the step was skipped
 func (m *StackInitModal) GetPassphrase() string {
+	if !m.NeedsPassphrase() {
+		return ""
+	}
 	return m.GetResult(stepPassphrase)
 }
 
-// IsComplete returns true if all required steps have been completed
-func (m *StackInitModal) IsComplete() bool {
-	// Need stack name
-	if m.GetStackName() == "" {
-		return false
-	}
-	// Need secrets provider
-	if m.GetSecretsProvider() == "" {
-		return false
+// GetCopyConfigFrom returns the stack whose config is copied, or "" for none
+func (m *StackInitModal) GetCopyConfigFrom() string {
+	if m.skipStep(stepCopyConfig) {
+		return ""
 	}
-	// Need passphrase if using passphrase provider without env var
-	if m.NeedsPassphrase() && m.GetPassphrase() == "" {
-		return false
+	return m.GetResult(stepCopyConfig)
+}
+
+// GetConfig returns the initial config values entered
+func (m *StackInitModal) GetConfig() map[string]string {
+	if len(m.config) == 0 {
+		return nil
 	}
-	return true
+	return maps.Clone(m.config)
 }
@@ -3,6 +3,8 @@ package ui
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
 )
 
 // StackSource indicates where the stack information comes from
@@ -19,6 +21,8 @@ type StackItem struct {
 	Current   bool
 	IsNewItem bool        // Special flag for "create new stack" option
 	Source    StackSource // Where the stack information comes from
+	// Result of the stack's most recent update, "" until loaded or without updates
+	LastResult string
 }
 
 // Label implements SelectorItem
@@ -39,9 +43,9 @@ type StackSelector struct {
 
 // NewStackSelector creates a new stack selector
 func NewStackSelector() *StackSelector {
-	dialog := NewSelectorDialog[StackItem]("Select Stack")
-	dialog.SetLoadingText("Loading stacks...")
-	dialog.SetEmptyText("No stacks found")
+	dialog := NewSelectorDialog[StackItem](i18n.T("Select Stack"))
+	dialog.SetLoadingText(i18n.T("Loading stacks..."))
+	dialog.SetEmptyText(i18n.T("No stacks found"))
 
 	// Custom renderer for stack items
 	dialog.SetItemRenderer(func(item StackItem, isCursor bool) string {
@@ -51,12 +55,7 @@ func NewStackSelector() *StackSelector {
 		}
 
 		if item.IsNewItem {
-			// Style the "new stack" option distinctly (green for creation)
-			newStyle := lipgloss.NewStyle().Foreground(ColorCreate)
-			if isCursor {
-				return cursor + newStyle.Render(item.Name)
-			}
-			return cursor + DimStyle.Render(item.Name)
+			return renderNewItem(item.Name, isCursor)
 		}
 
 		// Regular stack items
@@ -68,6 +67,10 @@ func NewStackSelector() *StackSelector {
 			suffix = DimStyle.Render(" (from file)")
 		}
 
+		if badge := RenderResultBadge(item.LastResult); badge != "" {
+			suffix += " " + badge
+		}
+
 		switch {
 		case item.Current:
 			name = ValueStyle.Render(item.Name) + DimStyle.Render(" (current)") + suffix
@@ -85,6 +88,15 @@ func NewStackSelector() *StackSelector {
 	}
 }
 
+// renderNewItem renders a selector option creating something new, such as a new
+// stack, distinctly from the existing items (green for creation)
+func renderNewItem(label string, isCursor bool) string {
+	if isCursor {
+		return CursorStyle.Render("> ") + lipgloss.NewStyle().Foreground(ColorCreate).Render(label)
+	}
+	return "  " + DimStyle.Render(label)
+}
+
 // SetShowNewOption controls whether the "new stack" option is shown
 func (s *StackSelector) SetShowNewOption(show bool) {
 	s.showNewOption = show
@@ -106,6 +118,16 @@ func (s *StackSelector) SetStacks(stacks []StackItem) {
 	}
 }
 
+// SetLastResults sets the result of each stack's most recent update, by stack name
+func (s *StackSelector) SetLastResults(results map[string]string) {
+	items := s.Items()
+	for i := range items {
+		if !items[i].IsNewItem {
+			items[i].LastResult = results[items[i].Name]
+		}
+	}
+}
+
 // SelectedStack returns the currently selected stack name
 // Returns empty string if "new stack" option is selected
 func (s *StackSelector) SelectedStack() string {
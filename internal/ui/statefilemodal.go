@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// StateFileModal wraps StepModal to prompt for the file a stack's state is
+// exported to or imported from
+type StateFileModal struct {
+	*StepModal
+
+	importing bool
+}
+
+// NewStateFileModal creates a new state file modal
+func NewStateFileModal() *StateFileModal {
+	return &StateFileModal{StepModal: NewStepModal(i18n.T("Export State"))}
+}
+
+// ShowExport prompts for the file to export the stack's state to, prefilled with path
+func (m *StateFileModal) ShowExport(stackName, path string) {
+	m.importing = false
+	m.title = i18n.T("Export State")
+	m.SetSteps([]StepModalStep{{
+		Title:            i18n.T("Save the stack's deployment to a JSON file"),
+		InfoLines:        []InfoLine{{Label: i18n.T("Stack"), Value: stackName}},
+		InputLabel:       i18n.T("File"),
+		InputPlaceholder: i18n.T("Enter file path..."),
+	}})
+	m.StepModal.Show()
+	m.SetResult(0, path)
+	m.updateInputForCurrentStep()
+}
+
+// ShowImport prompts for the deployment file to replace the stack's state with
+func (m *StateFileModal) ShowImport(stackName string) {
+	m.importing = true
+	m.title = i18n.T("Import State")
+	m.SetSteps([]StepModalStep{{
+		Title:            i18n.T("Replace the stack's state with a deployment file"),
+		InfoLines:        []InfoLine{{Label: i18n.T("Stack"), Value: stackName}},
+		InputLabel:       i18n.T("File"),
+		InputPlaceholder: i18n.T("Enter file path..."),
+		Warning:          i18n.T("Importing overwrites the stack's current state"),
+	}})
+	m.StepModal.Show()
+}
+
+// Importing reports whether the modal prompts for a file to import
+func (m *StateFileModal) Importing() bool {
+	return m.importing
+}
+
+// Path returns the entered file path
+func (m *StateFileModal) Path() string {
+	return m.GetResult(0)
+}
@@ -0,0 +1,317 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+)
+
+// StateRepairAction represents an action taken by the user in the state repair modal
+type StateRepairAction int
+
+const (
+	StateRepairActionNone   StateRepairAction = iota
+	StateRepairActionDryRun                   // Build a dry-run report for the chosen fixes
+	StateRepairActionApply                    // Write the fixes from the report to state
+	StateRepairActionCancel                   // Close the modal
+)
+
+// maxVisibleStateIssues is the max number of issues shown at once
+const maxVisibleStateIssues = 8
+
+// maxReportLines is the max number of changes or dangling references listed in the report
+const maxReportLines = 8
+
+// StateRepairModal lists inconsistencies found in stack state and lets the user
+// choose a fix for each, review a dry-run report and then write the result
+type StateRepairModal struct {
+	ModalBase // Embedded modal base for common functionality
+
+	issues   []pulumi.StateIssue
+	choices  []int  // Index into stateFixOptions for each issue, -1 to skip
+	stackURN string // Root stack resource that orphans are re-parented to
+	cursor   int
+
+	report  *pulumi.StateRepairReport // Dry-run report awaiting confirmation
+	loading bool
+	err     error
+}
+
+// NewStateRepairModal creates a new state repair modal
+func NewStateRepairModal() *StateRepairModal {
+	return &StateRepairModal{}
+}
+
+// Show shows the modal for the given issues. Every issue starts out skipped.
+func (m *StateRepairModal) Show(issues []pulumi.StateIssue, stackURN string) {
+	m.ModalBase.Show()
+	m.issues = issues
+	m.choices = make([]int, len(issues))
+	for i := range m.choices {
+		m.choices[i] = -1
+	}
+	m.stackURN = stackURN
+	m.cursor = 0
+	m.report = nil
+	m.loading = false
+	m.err = nil
+}
+
+// SetLoading marks that a dry run or repair is in progress
+func (m *StateRepairModal) SetLoading() {
+	m.loading = true
+	m.err = nil
+}
+
+// SetReport shows a dry-run report for confirmation
+func (m *StateRepairModal) SetReport(report *pulumi.StateRepairReport) {
+	m.report = report
+	m.loading = false
+	m.err = nil
+}
+
+// SetError sets an error to display
+func (m *StateRepairModal) SetError(err error) {
+	m.err = err
+	m.loading = false
+}
+
+// Report returns the dry-run report, or nil if none is shown
+func (m *StateRepairModal) Report() *pulumi.StateRepairReport {
+	return m.report
+}
+
+// Fixes returns the fixes chosen for each issue that is not skipped
+func (m *StateRepairModal) Fixes() []pulumi.StateFix {
+	var fixes []pulumi.StateFix
+	for i, issue := range m.issues {
+		if m.choices[i] < 0 {
+			continue
+		}
+		fix := pulumi.StateFix{Issue: issue, Action: stateFixOptions(issue)[m.choices[i]]}
+		if fix.Action == pulumi.StateFixReparent {
+			fix.NewParent = m.stackURN
+		}
+		fixes = append(fixes, fix)
+	}
+	return fixes
+}
+
+// stateFixOptions returns the fixes that apply to an issue. Only orphans can be
+// re-parented; duplicates and resources with a missing provider can only be deleted.
+func stateFixOptions(issue pulumi.StateIssue) []pulumi.StateFixAction {
+	if issue.Kind == pulumi.StateIssueOrphanedChild {
+		return []pulumi.StateFixAction{pulumi.StateFixReparent, pulumi.StateFixDelete}
+	}
+	return []pulumi.StateFixAction{pulumi.StateFixDelete}
+}
+
+// cycleChoice moves the issue under the cursor to its next fix, wrapping back to skip
+func (m *StateRepairModal) cycleChoice() {
+	if m.cursor >= len(m.issues) {
+		return
+	}
+	m.choices[m.cursor]++
+	if m.choices[m.cursor] >= len(stateFixOptions(m.issues[m.cursor])) {
+		m.choices[m.cursor] = -1
+	}
+}
+
+// moveCursor moves the cursor, keeping it visible
+func (m *StateRepairModal) moveCursor(delta int) {
+	m.cursor = MoveCursor(m.cursor, delta, len(m.issues))
+	m.SetScrollOffset(EnsureCursorVisible(m.cursor, m.ScrollOffset(), len(m.issues), maxVisibleStateIssues))
+}
+
+// Update handles key events
+func (m *StateRepairModal) Update(msg tea.KeyMsg) (StateRepairAction, tea.Cmd) {
+	if !m.Visible() || m.loading {
+		return StateRepairActionNone, nil
+	}
+
+	// Report view: confirm or go back to the issue list
+	if m.report != nil {
+		switch {
+		case key.Matches(msg, Keys.Escape):
+			m.report = nil
+		case msg.String() == "enter":
+			if len(m.report.Changes) > 0 {
+				m.SetLoading()
+				return StateRepairActionApply, nil
+			}
+		}
+		return StateRepairActionNone, nil
+	}
+
+	switch {
+	case key.Matches(msg, Keys.Escape):
+		m.Hide()
+		return StateRepairActionCancel, nil
+	case msg.String() == "enter":
+		if len(m.Fixes()) == 0 {
+			return StateRepairActionNone, nil
+		}
+		m.SetLoading()
+		return StateRepairActionDryRun, nil
+	case key.Matches(msg, Keys.ToggleSelect), msg.String() == "right", msg.String() == "l":
+		m.cycleChoice()
+	case key.Matches(msg, Keys.Up):
+		m.moveCursor(-1)
+	case key.Matches(msg, Keys.Down):
+		m.moveCursor(1)
+	case key.Matches(msg, Keys.Home):
+		m.moveCursor(-len(m.issues))
+	case key.Matches(msg, Keys.End):
+		m.moveCursor(len(m.issues))
+	}
+	return StateRepairActionNone, nil
+}
+
+// View renders the state repair modal
+func (m *StateRepairModal) View() string {
+	title := DialogTitleStyle.Render(i18n.T("Repair State"))
+
+	var content strings.Builder
+	var hints []string
+	switch {
+	case m.loading:
+		content.WriteString(DimStyle.Render(i18n.T("Checking fixes against state...")))
+	case m.report != nil:
+		m.renderReport(&content)
+		hints = []string{"enter " + i18n.T("write state"), "esc " + i18n.T("back")}
+	default:
+		if m.err != nil {
+			content.WriteString(ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+			content.WriteString("\n\n")
+		}
+		m.renderIssues(&content)
+		hints = []string{"space " + i18n.T("change fix"), "enter " + i18n.T("dry run"), "esc " + i18n.T("cancel")}
+	}
+
+	footer := DimStyle.Render("\n" + strings.Join(hints, "  "))
+	return m.RenderDialog(title, content.String(), footer)
+}
+
+// renderIssues renders the scrollable issue list with the fix chosen for each
+func (m *StateRepairModal) renderIssues(content *strings.Builder) {
+	content.WriteString(i18n.Tf("Found %d issues in stack state", len(m.issues)))
+	content.WriteString("\n\n")
+
+	scrollOffset := m.ScrollOffset()
+	endIdx := min(scrollOffset+maxVisibleStateIssues, len(m.issues))
+	for i := scrollOffset; i < endIdx; i++ {
+		issue := m.issues[i]
+
+		cursor := "  "
+		if i == m.cursor {
+			cursor = CursorStyle.Render("> ")
+		}
+		content.WriteString(cursor)
+		if i == m.cursor {
+			content.WriteString(ValueStyle.Render(issue.Name))
+		} else {
+			content.WriteString(issue.Name)
+		}
+		content.WriteString(DimStyle.Render(" (" + issue.Type + ")"))
+		content.WriteString("\n    ")
+		content.WriteString(ErrorStyle.Render(m.issueLabel(issue)))
+		content.WriteString(DimStyle.Render(" → "))
+		if m.choices[i] < 0 {
+			content.WriteString(DimStyle.Render(i18n.T("skip")))
+		} else {
+			content.WriteString(LabelStyle.Render(m.fixLabel(issue, stateFixOptions(issue)[m.choices[i]])))
+		}
+		content.WriteString("\n")
+	}
+
+	if hint := RenderScrollHint(scrollOffset > 0, endIdx < len(m.issues), "  "); hint != "" {
+		content.WriteString(hint)
+	}
+}
+
+// renderReport renders the dry-run report
+func (m *StateRepairModal) renderReport(content *strings.Builder) {
+	report := m.report
+	content.WriteString(LabelStyle.Render(i18n.T("Dry run")))
+	content.WriteString(DimStyle.Render(i18n.Tf(" (%d resources → %d)", report.ResourcesBefore, report.ResourcesAfter)))
+	content.WriteString("\n\n")
+
+	if len(report.Changes) == 0 {
+		content.WriteString(DimStyle.Render(i18n.T("No changes")))
+		content.WriteString("\n")
+	}
+	for i, change := range report.Changes {
+		if i == maxReportLines {
+			content.WriteString(DimStyle.Render(i18n.Tf("  ... and %d more", len(report.Changes)-maxReportLines)))
+			content.WriteString("\n")
+			break
+		}
+		name := pulumi.ExtractResourceName(change.URN)
+		switch change.Action {
+		case pulumi.StateFixDelete:
+			content.WriteString(StatusFailedStyle.Render("  - "))
+			content.WriteString(i18n.Tf("remove %s (entries: %d)", name, change.Removed))
+		case pulumi.StateFixReparent:
+			content.WriteString(StatusSuccessStyle.Render("  ~ "))
+			content.WriteString(i18n.Tf("re-parent %s to %s", name, parentLabel(change.NewParent)))
+		}
+		content.WriteString("\n")
+	}
+
+	if len(report.Dangling) > 0 {
+		content.WriteString("\n")
+		content.WriteString(ErrorStyle.Render(i18n.T("State will still reference missing resources:")))
+		content.WriteString("\n")
+		for i, ref := range report.Dangling {
+			if i == maxReportLines {
+				content.WriteString(DimStyle.Render(i18n.Tf("  ... and %d more", len(report.Dangling)-maxReportLines)))
+				content.WriteString("\n")
+				break
+			}
+			content.WriteString("  ")
+			content.WriteString(pulumi.ExtractResourceName(ref.URN))
+			content.WriteString(DimStyle.Render(" " + ref.Field + " → "))
+			content.WriteString(pulumi.ExtractResourceName(ref.Reference))
+			content.WriteString("\n")
+		}
+		content.WriteString(DimStyle.Render(i18n.T("Pulumi will reject the repaired state unless these are fixed too.")))
+		content.WriteString("\n")
+	}
+}
+
+// issueLabel describes an issue
+func (m *StateRepairModal) issueLabel(issue pulumi.StateIssue) string {
+	switch issue.Kind {
+	case pulumi.StateIssueDuplicateURN:
+		return i18n.Tf("duplicate URN (%d copies)", issue.Copies)
+	case pulumi.StateIssueOrphanedChild:
+		return i18n.Tf("parent %s is missing", pulumi.ExtractResourceName(issue.Reference))
+	case pulumi.StateIssueMissingProvider:
+		return i18n.Tf("provider %s is missing", pulumi.ExtractResourceName(issue.Reference))
+	}
+	return ""
+}
+
+// fixLabel describes a fix for an issue
+func (m *StateRepairModal) fixLabel(issue pulumi.StateIssue, action pulumi.StateFixAction) string {
+	switch {
+	case action == pulumi.StateFixReparent:
+		return i18n.Tf("re-parent to %s", parentLabel(m.stackURN))
+	case issue.Kind == pulumi.StateIssueDuplicateURN:
+		return i18n.T("delete extra copies")
+	default:
+		return i18n.T("delete from state")
+	}
+}
+
+// parentLabel names a re-parent target
+func parentLabel(urn string) string {
+	if urn == "" {
+		return i18n.T("root")
+	}
+	return pulumi.ExtractResourceName(urn)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
 )
 
 // StepModalAction represents an action taken by the user in a step modal
@@ -46,6 +48,11 @@ type StepModalStep struct {
 	Warning          string // Warning message (shown in yellow)
 	FooterHints      string // Custom footer hints
 	PasswordMode     bool   // Mask input like a password
+	Optional         bool   // Allow continuing with an empty value
+
+	// Validate checks the step's value before continuing. On error the value is
+	// kept in the input for editing.
+	Validate func(value string) error
 }
 
 // StepModal is a multi-step modal dialog with navigation support
@@ -254,9 +261,19 @@ func (m *StepModal) handleEnterKey(step StepModalStep) StepModalAction {
 		}
 	}
 
-	if m.results[m.currentStep] == "" {
+	value := m.results[m.currentStep]
+	if value == "" && !step.Optional {
 		return StepModalActionNone
 	}
+	if step.Validate != nil && value != "" {
+		if err := step.Validate(value); err != nil {
+			m.err = err
+			m.input.SetValue(value)
+			m.input.CursorEnd()
+			m.showSuggestions = false
+			return StepModalActionNone
+		}
+	}
 
 	if m.IsLastStep() {
 		return StepModalActionConfirm
@@ -367,8 +384,7 @@ func (m *StepModal) View() string {
 
 	// Warning (if any)
 	if step.Warning != "" {
-		warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#e0af68")) // yellow/orange
-		content.WriteString(warningStyle.Render("! " + step.Warning))
+		content.WriteString(WarningStyle.Render("! " + step.Warning))
 		content.WriteString("\n\n")
 	}
 
@@ -404,17 +420,17 @@ func (m *StepModal) buildFooterHints(step StepModalStep) string {
 
 	var hints []string
 	if len(step.Suggestions) > 0 {
-		hints = append(hints, "tab suggestions")
+		hints = append(hints, "tab "+i18n.T("suggestions"))
 	}
 	if m.IsLastStep() {
-		hints = append(hints, "enter confirm")
+		hints = append(hints, "enter "+i18n.T("confirm"))
 	} else {
-		hints = append(hints, "enter next")
+		hints = append(hints, "enter "+i18n.T("next"))
 	}
 	if m.currentStep > 0 {
-		hints = append(hints, "backspace back")
+		hints = append(hints, "backspace "+i18n.T("back"))
 	}
-	hints = append(hints, "esc cancel")
+	hints = append(hints, "esc "+i18n.T("cancel"))
 	return DimStyle.Render("\n" + strings.Join(hints, "  "))
 }
 
@@ -452,15 +468,14 @@ func (m *StepModal) clampScrollOffset(maxOffset int) {
 
 func (m *StepModal) renderVisibleSuggestions(content *strings.Builder, suggestions []StepSuggestion) {
 	endIdx := min(m.scrollOffset+maxVisibleStepSuggestions, len(suggestions))
-	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#e0af68"))
 
 	for i := m.scrollOffset; i < endIdx; i++ {
 		s := suggestions[i]
-		m.renderSuggestionLine(content, s, i, warningStyle)
+		m.renderSuggestionLine(content, s, i)
 	}
 }
 
-func (m *StepModal) renderSuggestionLine(content *strings.Builder, s StepSuggestion, idx int, warningStyle lipgloss.Style) {
+func (m *StepModal) renderSuggestionLine(content *strings.Builder, s StepSuggestion, idx int) {
 	if idx == m.selectedIdx && m.showSuggestions {
 		content.WriteString(ValueStyle.Render("> " + s.Label))
 	} else {
@@ -473,7 +488,7 @@ func (m *StepModal) renderSuggestionLine(content *strings.Builder, s StepSuggest
 		content.WriteString(DimStyle.Render(" [" + s.Source + "]"))
 	}
 	if s.Warning != "" {
-		content.WriteString(warningStyle.Render(" !" + s.Warning))
+		content.WriteString(WarningStyle.Render(" !" + s.Warning))
 	}
 	content.WriteString("\n")
 }
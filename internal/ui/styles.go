@@ -2,132 +2,198 @@ package ui
 
 import "github.com/charmbracelet/lipgloss"
 
-// Color palette (Tokyo Night)
+// Color palette, set from the active theme by ApplyTheme
 var (
-	ColorPrimary           = lipgloss.Color("#7aa2f7")
-	ColorSecondary         = lipgloss.Color("#bb9af7")
-	ColorText              = lipgloss.Color("#c0caf5")
-	ColorDim               = lipgloss.Color("#565f89")
-	ColorError             = lipgloss.Color("#f7768e")
-	ColorBg                = lipgloss.Color("#1a1b26")
-	ColorSelection         = lipgloss.Color("#283457") // subtle selection highlight (visual range)
-	ColorDiscreteSelection = lipgloss.Color("#3d4f2f") // discrete selection (green-ish)
-	ColorBothSelection     = lipgloss.Color("#4a3f5c") // both visual and discrete (purple-ish)
-	ColorFlash             = lipgloss.Color("#3d59a1") // brighter flash highlight
+	ColorPrimary           lipgloss.Color
+	ColorSecondary         lipgloss.Color
+	ColorText              lipgloss.Color
+	ColorDim               lipgloss.Color
+	ColorError             lipgloss.Color
+	ColorBg                lipgloss.Color
+	ColorSelection         lipgloss.Color // subtle selection highlight (visual range)
+	ColorDiscreteSelection lipgloss.Color // discrete selection
+	ColorBothSelection     lipgloss.Color // both visual and discrete
+	ColorFlash             lipgloss.Color // brighter flash highlight
+	ColorToastBg           lipgloss.Color
+	ColorToastFg           lipgloss.Color
 
 	// Operation colors
-	ColorCreate  = lipgloss.Color("#9ece6a") // green
-	ColorUpdate  = lipgloss.Color("#e0af68") // yellow/orange
-	ColorDelete  = lipgloss.Color("#f7768e") // red
-	ColorReplace = lipgloss.Color("#bb9af7") // purple
-	ColorRefresh = lipgloss.Color("#7dcfff") // cyan
-	ColorSuccess = lipgloss.Color("#9ece6a") // green (same as create)
+	ColorCreate  lipgloss.Color
+	ColorUpdate  lipgloss.Color
+	ColorDelete  lipgloss.Color
+	ColorReplace lipgloss.Color
+	ColorRefresh lipgloss.Color
+	ColorSuccess lipgloss.Color
 
 	// Flag colors
-	ColorTarget  = lipgloss.Color("#7dcfff") // cyan
-	ColorExclude = lipgloss.Color("#f7768e") // red (same as error/delete)
-	ColorProtect = lipgloss.Color("#f5a623") // masterlock yellow
+	ColorTarget  lipgloss.Color
+	ColorExclude lipgloss.Color
+	ColorProtect lipgloss.Color
+	ColorChanged lipgloss.Color
 )
 
-// Styles
+// Styles, built from the color palette by buildStyles
 var (
+	LabelStyle           lipgloss.Style
+	ValueStyle           lipgloss.Style
+	DimStyle             lipgloss.Style
+	ErrorStyle           lipgloss.Style
+	WarningStyle         lipgloss.Style
+	BoxStyle             lipgloss.Style
+	DialogStyle          lipgloss.Style
+	DialogTitleStyle     lipgloss.Style
+	OpCreateStyle        lipgloss.Style
+	OpUpdateStyle        lipgloss.Style
+	OpDeleteStyle        lipgloss.Style
+	OpReplaceStyle       lipgloss.Style
+	OpRefreshStyle       lipgloss.Style
+	StatusPendingStyle   lipgloss.Style
+	StatusRunningStyle   lipgloss.Style
+	StatusSuccessStyle   lipgloss.Style
+	StatusFailedStyle    lipgloss.Style
+	ScrollIndicatorStyle lipgloss.Style
+	CursorStyle          lipgloss.Style
+	SelectionStyle       lipgloss.Style
+	SearchMatchStyle     lipgloss.Style
+	SearchCurrentStyle   lipgloss.Style
+	FlagTargetStyle      lipgloss.Style
+	FlagReplaceStyle     lipgloss.Style
+	FlagExcludeStyle     lipgloss.Style
+	FlagProtectStyle     lipgloss.Style
+	DiffChangedStyle     lipgloss.Style
+	ViewLabelStyle       lipgloss.Style
+	TreeLineStyle        lipgloss.Style
+	SyntaxKeyStyle       lipgloss.Style
+	SyntaxStringStyle    lipgloss.Style
+	SyntaxNumberStyle    lipgloss.Style
+	SyntaxLiteralStyle   lipgloss.Style
+)
+
+// buildStyles builds the styles from the current color palette
+func buildStyles() {
 	// Text styles
 	LabelStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorSecondary)
+		Bold(true).
+		Foreground(ColorSecondary)
 
 	ValueStyle = lipgloss.NewStyle().
-			Foreground(ColorText)
+		Foreground(ColorText)
 
 	DimStyle = lipgloss.NewStyle().
-			Foreground(ColorDim)
+		Foreground(ColorDim)
 
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(ColorError)
+		Foreground(ColorError)
+
+	WarningStyle = lipgloss.NewStyle().
+		Foreground(ColorUpdate)
 
 	// Box styles
 	BoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorDim).
-			Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1)
 
 	// Dialog styles
 	DialogStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorPrimary).
-			Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary).
+		Padding(1, 2)
 
 	DialogTitleStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(ColorPrimary).
-				MarginBottom(1)
+		Bold(true).
+		Foreground(ColorPrimary).
+		MarginBottom(1)
 
 	// Operation styles
 	OpCreateStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorCreate)
+		Bold(true).
+		Foreground(ColorCreate)
 
 	OpUpdateStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorUpdate)
+		Bold(true).
+		Foreground(ColorUpdate)
 
 	OpDeleteStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorDelete)
+		Bold(true).
+		Foreground(ColorDelete)
 
 	OpReplaceStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorReplace)
+		Bold(true).
+		Foreground(ColorReplace)
 
 	OpRefreshStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorRefresh)
+		Bold(true).
+		Foreground(ColorRefresh)
 
 	// Execution status styles
 	StatusPendingStyle = lipgloss.NewStyle().Foreground(ColorDim)
 	StatusRunningStyle = lipgloss.NewStyle().Foreground(ColorPrimary)
 	StatusSuccessStyle = lipgloss.NewStyle().Foreground(ColorSuccess)
-	StatusFailedStyle  = lipgloss.NewStyle().Foreground(ColorError)
+	StatusFailedStyle = lipgloss.NewStyle().Foreground(ColorError)
 
 	// Scroll indicator styles - bright cyan for high visibility
 	ScrollIndicatorStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(ColorRefresh) // Use bright cyan for better visibility
+		Bold(true).
+		Foreground(ColorRefresh) // Use bright cyan for better visibility
 
 	// Cursor and selection styles
 	CursorStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary)
+		Bold(true).
+		Foreground(ColorPrimary)
 
 	SelectionStyle = lipgloss.NewStyle().
-			Background(ColorSelection)
+		Background(ColorSelection)
+
+	// Search match styles for panel search
+	SearchMatchStyle = lipgloss.NewStyle().
+		Foreground(ColorText).
+		Background(ColorSelection)
+
+	SearchCurrentStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorText).
+		Background(ColorFlash)
 
 	// Flag badge styles
 	FlagTargetStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorTarget)
+		Bold(true).
+		Foreground(ColorTarget)
 
 	FlagReplaceStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(ColorReplace)
+		Bold(true).
+		Foreground(ColorReplace)
 
 	FlagExcludeStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(ColorExclude)
+		Bold(true).
+		Foreground(ColorExclude)
 
 	FlagProtectStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(ColorProtect)
+		Bold(true).
+		Foreground(ColorProtect)
+	DiffChangedStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorChanged)
 
 	// View mode label styles
 	ViewLabelStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary)
+		Bold(true).
+		Foreground(ColorPrimary)
 
 	// Tree connector style for component resources
 	TreeLineStyle = lipgloss.NewStyle().
-			Foreground(ColorDim)
-)
+		Foreground(ColorDim)
+
+	// Syntax highlighting styles for JSON, YAML and HCL values
+	SyntaxKeyStyle = lipgloss.NewStyle().
+		Foreground(ColorPrimary)
+	SyntaxStringStyle = lipgloss.NewStyle().
+		Foreground(ColorSuccess)
+	SyntaxNumberStyle = lipgloss.NewStyle().
+		Foreground(ColorRefresh)
+	SyntaxLiteralStyle = lipgloss.NewStyle().
+		Foreground(ColorReplace)
+}
 
 // Status icons
 const (
@@ -160,7 +226,8 @@ const (
 	DefaultMaxStringLength   = 30
 	MinFormattedStringLength = 20
 	ArrayItemTruncateLength  = 30
-	ArrayItemTruncateDisplay = 27 // Length to show before "..."
+	ArrayItemTruncateDisplay = 27   // Length to show before "..."
+	LargeValueLength         = 1024 // Strings this long show their first line and a [+N lines] marker
 )
 
 // RenderOp renders a resource operation with appropriate styling
@@ -234,3 +301,17 @@ func RenderHistoryResult(result string) string {
 		return DimStyle.Render(result)
 	}
 }
+
+// RenderResultBadge renders an update result as a single symbol: ✓ succeeded,
+// ✗ failed or … in progress. Unknown results render empty.
+func RenderResultBadge(result string) string {
+	switch result {
+	case "succeeded":
+		return StatusSuccessStyle.Render("✓")
+	case "failed":
+		return StatusFailedStyle.Render("✗")
+	case "in-progress":
+		return StatusRunningStyle.Render("…")
+	}
+	return ""
+}
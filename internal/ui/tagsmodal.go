@@ -0,0 +1,346 @@
+package ui
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// StackTag is a tag listed in the stack tags modal
+type StackTag struct {
+	Key   string
+	Value string
+}
+
+// ReadOnly reports whether the tag is managed by Pulumi and can't be edited
+func (t StackTag) ReadOnly() bool {
+	return strings.HasPrefix(t.Key, "pulumi:")
+}
+
+// TagsAction represents an action taken by the user in the stack tags modal
+type TagsAction int
+
+const (
+	TagsActionNone   TagsAction = iota
+	TagsActionSet               // Set the pending tag, removing it if its value is empty
+	TagsActionCancel            // Close the modal
+)
+
+// maxVisibleTags is the max number of tags shown at once
+const maxVisibleTags = 10
+
+// TagsModal lists the stack's tags and edits them
+type TagsModal struct {
+	ModalBase // Embedded modal base for common functionality
+
+	stackName string
+	tags      []StackTag
+	cursor    int
+	loading   bool
+	saving    bool
+	err       error
+
+	// Tag being added or edited, with its value entered in input
+	editing bool
+	adding  bool
+	editKey string
+	pending StackTag
+	input   textinput.Model
+}
+
+// NewTagsModal creates a new stack tags modal
+func NewTagsModal() *TagsModal {
+	ti := textinput.New()
+	ti.CharLimit = 256
+	ti.Width = DefaultInputWidth
+
+	return &TagsModal{
+		input: ti,
+	}
+}
+
+// Show shows the modal for the stack while its tags are fetched
+func (m *TagsModal) Show(stackName string) {
+	m.ModalBase.Show()
+	m.stackName = stackName
+	m.tags = nil
+	m.cursor = 0
+	m.loading = true
+	m.saving = false
+	m.err = nil
+	m.stopEditing()
+}
+
+// Hide hides the modal
+func (m *TagsModal) Hide() {
+	m.ModalBase.Hide()
+	m.stopEditing()
+}
+
+// SetTags sets the stack's tags, sorted by key
+func (m *TagsModal) SetTags(tags map[string]string) {
+	m.tags = make([]StackTag, 0, len(tags))
+	for k, v := range tags {
+		m.tags = append(m.tags, StackTag{Key: k, Value: v})
+	}
+	sort.Slice(m.tags, func(i, j int) bool { return m.tags[i].Key < m.tags[j].Key })
+	m.cursor = 0
+	m.SetScrollOffset(0)
+	m.loading = false
+	m.err = nil
+}
+
+// SetError sets an error to display
+func (m *TagsModal) SetError(err error) {
+	m.err = err
+	m.loading = false
+	m.saving = false
+}
+
+// SetTag records a saved tag, removing it if value is empty, and moves the cursor to it
+func (m *TagsModal) SetTag(key, value string) {
+	m.saving = false
+	m.err = nil
+	idx := -1
+	for i, tag := range m.tags {
+		if tag.Key == key {
+			idx = i
+			break
+		}
+	}
+	switch {
+	case value == "" && idx >= 0:
+		m.tags = append(m.tags[:idx], m.tags[idx+1:]...)
+		idx = min(idx, len(m.tags)-1)
+	case value == "":
+	case idx >= 0:
+		m.tags[idx].Value = value
+	default:
+		m.tags = append(m.tags, StackTag{Key: key, Value: value})
+		sort.Slice(m.tags, func(i, j int) bool { return m.tags[i].Key < m.tags[j].Key })
+		for i, tag := range m.tags {
+			if tag.Key == key {
+				idx = i
+			}
+		}
+	}
+	m.moveCursor(max(idx, 0) - m.cursor)
+}
+
+// PendingTag returns the tag to set after TagsActionSet
+func (m *TagsModal) PendingTag() StackTag {
+	return m.pending
+}
+
+// SelectedTag returns the tag under the cursor, or nil if there are none
+func (m *TagsModal) SelectedTag() *StackTag {
+	if m.cursor >= len(m.tags) {
+		return nil
+	}
+	return &m.tags[m.cursor]
+}
+
+// moveCursor moves the cursor, keeping it visible
+func (m *TagsModal) moveCursor(delta int) {
+	m.cursor = MoveCursor(m.cursor, delta, len(m.tags))
+	m.SetScrollOffset(EnsureCursorVisible(m.cursor, m.ScrollOffset(), len(m.tags), maxVisibleTags))
+}
+
+// startEditing focuses the input to edit the value of key, or a new key=value tag if key is empty
+func (m *TagsModal) startEditing(key, value string) {
+	m.editing = true
+	m.adding = key == ""
+	m.editKey = key
+	m.err = nil
+	m.input.Placeholder = i18n.T("value")
+	if m.adding {
+		m.input.Placeholder = i18n.T("key=value")
+	}
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	m.input.Focus()
+}
+
+// stopEditing returns to the tag list
+func (m *TagsModal) stopEditing() {
+	m.editing = false
+	m.adding = false
+	m.editKey = ""
+	m.input.Blur()
+}
+
+// set requests the tag to be set, marking the modal as saving
+func (m *TagsModal) set(key, value string) TagsAction {
+	m.pending = StackTag{Key: key, Value: value}
+	m.saving = true
+	m.err = nil
+	return TagsActionSet
+}
+
+// Update handles key events. Closing the modal does not cancel a running save.
+func (m *TagsModal) Update(msg tea.KeyMsg) (TagsAction, tea.Cmd) {
+	if !m.Visible() {
+		return TagsActionNone, nil
+	}
+	if m.editing {
+		return m.updateInput(msg)
+	}
+	if key.Matches(msg, Keys.Escape) {
+		m.Hide()
+		return TagsActionCancel, nil
+	}
+	if m.loading || m.saving {
+		return TagsActionNone, nil
+	}
+
+	switch {
+	case msg.String() == "a":
+		m.startEditing("", "")
+	case msg.String() == "enter", msg.String() == "e":
+		if tag := m.SelectedTag(); tag != nil && !tag.ReadOnly() {
+			m.startEditing(tag.Key, tag.Value)
+		}
+	case msg.String() == "x":
+		if tag := m.SelectedTag(); tag != nil && !tag.ReadOnly() {
+			return m.set(tag.Key, ""), nil
+		}
+	case key.Matches(msg, Keys.Up):
+		m.moveCursor(-1)
+	case key.Matches(msg, Keys.Down):
+		m.moveCursor(1)
+	case key.Matches(msg, Keys.Home):
+		m.moveCursor(-len(m.tags))
+	case key.Matches(msg, Keys.End):
+		m.moveCursor(len(m.tags))
+	}
+	return TagsActionNone, nil
+}
+
+// updateInput handles key events while a tag is being added or edited
+func (m *TagsModal) updateInput(msg tea.KeyMsg) (TagsAction, tea.Cmd) {
+	switch {
+	case key.Matches(msg, Keys.Escape):
+		m.stopEditing()
+		return TagsActionNone, nil
+	case msg.String() == "enter":
+		value := strings.TrimSpace(m.input.Value())
+		tagKey := m.editKey
+		if m.adding {
+			k, v, ok := strings.Cut(value, "=")
+			tagKey, value = strings.TrimSpace(k), strings.TrimSpace(v)
+			if !ok || tagKey == "" || value == "" {
+				m.err = errors.New(i18n.T("expected key=value"))
+				return TagsActionNone, nil
+			}
+		}
+		m.stopEditing()
+		return m.set(tagKey, value), nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return TagsActionNone, cmd
+}
+
+// View renders the stack tags modal
+func (m *TagsModal) View() string {
+	title := DialogTitleStyle.Render(i18n.T("Stack Tags"))
+
+	var content strings.Builder
+	content.WriteString(DimStyle.Render(i18n.T("Stack: ")))
+	content.WriteString(ValueStyle.Render(m.stackName))
+	content.WriteString("\n\n")
+
+	hints := []string{"esc " + i18n.T("close")}
+	switch {
+	case m.loading:
+		content.WriteString(DimStyle.Render(i18n.T("Loading tags...")))
+	case m.err != nil && m.tags == nil && !m.editing:
+		content.WriteString(ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+	default:
+		if len(m.tags) == 0 {
+			content.WriteString(DimStyle.Render(i18n.T("No tags")))
+			content.WriteString("\n")
+		} else {
+			m.renderTags(&content)
+		}
+		if m.err != nil {
+			content.WriteString("\n")
+			content.WriteString(ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+			content.WriteString("\n")
+		}
+		switch {
+		case m.editing:
+			content.WriteString("\n")
+			if m.adding {
+				content.WriteString(LabelStyle.Render(i18n.T("New tag")))
+			} else {
+				content.WriteString(LabelStyle.Render(m.editKey))
+			}
+			content.WriteString("\n")
+			content.WriteString(m.input.View())
+			hints = []string{"enter " + i18n.T("save"), "esc " + i18n.T("back")}
+			if !m.adding {
+				hints = append(hints, i18n.T("(empty removes the tag)"))
+			}
+		case m.saving:
+			content.WriteString("\n")
+			content.WriteString(LabelStyle.Render(i18n.Tf("Saving %s...", m.pending.Key)))
+		default:
+			editHints := []string{"a " + i18n.T("add")}
+			if tag := m.SelectedTag(); tag != nil && !tag.ReadOnly() {
+				editHints = append(editHints, "e "+i18n.T("edit"), "x "+i18n.T("remove"))
+			}
+			hints = append(editHints, hints...)
+		}
+	}
+
+	footer := DimStyle.Render("\n" + strings.Join(hints, "  "))
+	return m.RenderDialog(title, content.String(), footer)
+}
+
+// renderTags renders the scrollable tag list with aligned values
+func (m *TagsModal) renderTags(content *strings.Builder) {
+	keyWidth := 0
+	for _, tag := range m.tags {
+		keyWidth = max(keyWidth, len(tag.Key))
+	}
+
+	scrollOffset := m.ScrollOffset()
+	endIdx := min(scrollOffset+maxVisibleTags, len(m.tags))
+	for i := scrollOffset; i < endIdx; i++ {
+		tag := m.tags[i]
+
+		cursor := "  "
+		if i == m.cursor {
+			cursor = CursorStyle.Render("> ")
+		}
+		content.WriteString(cursor)
+		keyText := tag.Key + strings.Repeat(" ", keyWidth-len(tag.Key))
+		switch {
+		case tag.ReadOnly():
+			content.WriteString(DimStyle.Render(keyText))
+		case i == m.cursor:
+			content.WriteString(LabelStyle.Render(keyText))
+		default:
+			content.WriteString(keyText)
+		}
+		content.WriteString("  ")
+		content.WriteString(ValueStyle.Render(tag.Value))
+		content.WriteString("\n")
+	}
+
+	if hint := RenderScrollHint(scrollOffset > 0, endIdx < len(m.tags), "  "); hint != "" {
+		content.WriteString(hint)
+	}
+	if tag := m.SelectedTag(); tag != nil && tag.ReadOnly() {
+		content.WriteString(DimStyle.Render(i18n.T("Set by Pulumi, can't be edited")))
+		content.WriteString("\n")
+	}
+}
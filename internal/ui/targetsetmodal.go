@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// TargetSetModal wraps StepModal to prompt for the name to save the current
+// flags under as a target set
+type TargetSetModal struct {
+	*StepModal
+}
+
+// NewTargetSetModal creates a new target set modal
+func NewTargetSetModal() *TargetSetModal {
+	return &TargetSetModal{StepModal: NewStepModal(i18n.T("Save Target Set"))}
+}
+
+// ShowForFlags prompts for the name of a set holding the given number of flags.
+// validate checks the entered name before the set is saved.
+func (m *TargetSetModal) ShowForFlags(count int, validate func(string) error) {
+	m.SetSteps([]StepModalStep{{
+		Title:            i18n.Tf("Save the %d flagged resources to p5.toml to re-apply them later", count),
+		InputLabel:       i18n.T("Name"),
+		InputPlaceholder: i18n.T("e.g. frontend-only"),
+		Validate:         validate,
+	}})
+	m.StepModal.Show()
+}
+
+// Name returns the entered name
+func (m *TargetSetModal) Name() string {
+	return strings.TrimSpace(m.GetResult(0))
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// TargetSetItem represents a named set of resource flags from p5.toml in the
+// selector, or the option saving the current flags as a new set
+type TargetSetItem struct {
+	Name      string
+	Targets   int
+	Replaces  int
+	Excludes  int
+	IsNewItem bool // Special flag for the "save current flags" option
+}
+
+// Label implements SelectorItem
+func (t TargetSetItem) Label() string {
+	return t.Name
+}
+
+// IsCurrent implements SelectorItem
+func (t TargetSetItem) IsCurrent() bool {
+	return false
+}
+
+// TargetSetSelector is a modal dialog for choosing a target set to apply. An option
+// after the sets saves the current flags as a new set.
+type TargetSetSelector struct {
+	*SelectorDialog[TargetSetItem]
+	showNewOption bool
+}
+
+// NewTargetSetSelector creates a new target set selector
+func NewTargetSetSelector() *TargetSetSelector {
+	dialog := NewSelectorDialog[TargetSetItem](i18n.T("Target Sets"))
+	dialog.SetLoadingText(i18n.T("Loading target sets..."))
+	dialog.SetEmptyText(i18n.T("No target sets saved in p5.toml. Flag resources to save one."))
+
+	dialog.SetItemRenderer(func(item TargetSetItem, isCursor bool) string {
+		if item.IsNewItem {
+			return renderNewItem(item.Name, isCursor)
+		}
+		return dialog.defaultRenderItem(item, isCursor)
+	})
+	// Show how many resources each flag applies to
+	dialog.SetExtraInfoRenderer(func(item TargetSetItem) string {
+		var counts []string
+		if item.Targets > 0 {
+			counts = append(counts, i18n.Tf("%d target", item.Targets))
+		}
+		if item.Replaces > 0 {
+			counts = append(counts, i18n.Tf("%d replace", item.Replaces))
+		}
+		if item.Excludes > 0 {
+			counts = append(counts, i18n.Tf("%d exclude", item.Excludes))
+		}
+		if len(counts) == 0 {
+			return ""
+		}
+		return DimStyle.Render("  " + strings.Join(counts, " · "))
+	})
+	dialog.SetHiddenFunc(func(item TargetSetItem, filtering bool) bool {
+		return filtering && item.IsNewItem
+	})
+
+	return &TargetSetSelector{
+		SelectorDialog: dialog,
+	}
+}
+
+// SetShowNewOption controls whether the "save current flags" option is shown,
+// as there is nothing to save without flags
+func (s *TargetSetSelector) SetShowNewOption(show bool) {
+	s.showNewOption = show
+}
+
+// SetTargetSets sets the list of saved target sets
+func (s *TargetSetSelector) SetTargetSets(sets []TargetSetItem) {
+	items := make([]TargetSetItem, 0, len(sets)+1)
+	items = append(items, sets...)
+	if s.showNewOption {
+		items = append(items, TargetSetItem{Name: i18n.T("+ Save Current Flags"), IsNewItem: true})
+	}
+	s.SetItems(items)
+}
+
+// SelectedTargetSet returns the name of the selected target set, or empty when
+// none or the "save current flags" option is selected
+func (s *TargetSetSelector) SelectedTargetSet() string {
+	item := s.SelectedItem()
+	if item == nil || item.IsNewItem {
+		return ""
+	}
+	return item.Name
+}
+
+// IsNewSelected returns true if the "save current flags" option is selected
+func (s *TargetSetSelector) IsNewSelected() bool {
+	item := s.SelectedItem()
+	return item != nil && item.IsNewItem
+}
+
+// Update handles key events and returns true if a target set or the option was selected
+func (s *TargetSetSelector) Update(msg tea.KeyMsg) (selected bool, cmd tea.Cmd) {
+	return s.SelectorDialog.Update(msg)
+}
+
+// View renders the target set selector dialog
+func (s *TargetSetSelector) View() string {
+	return s.SelectorDialog.View()
+}
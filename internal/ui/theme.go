@@ -0,0 +1,264 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultTheme is the theme used when none is configured
+const DefaultTheme = "dark"
+
+// Theme is a color palette for the UI
+type Theme struct {
+	Primary           lipgloss.Color
+	Secondary         lipgloss.Color
+	Text              lipgloss.Color
+	Dim               lipgloss.Color
+	Error             lipgloss.Color
+	Bg                lipgloss.Color
+	Selection         lipgloss.Color
+	DiscreteSelection lipgloss.Color
+	BothSelection     lipgloss.Color
+	Flash             lipgloss.Color
+	ToastBg           lipgloss.Color
+	ToastFg           lipgloss.Color
+
+	// Operation colors
+	Create  lipgloss.Color
+	Update  lipgloss.Color
+	Delete  lipgloss.Color
+	Replace lipgloss.Color
+	Refresh lipgloss.Color
+	Success lipgloss.Color
+
+	// Flag colors
+	Target  lipgloss.Color
+	Exclude lipgloss.Color
+	Protect lipgloss.Color
+	Changed lipgloss.Color
+}
+
+// Themes are the built-in themes by name
+var Themes = map[string]Theme{
+	// Tokyo Night
+	"dark": {
+		Primary:           "#7aa2f7",
+		Secondary:         "#bb9af7",
+		Text:              "#c0caf5",
+		Dim:               "#565f89",
+		Error:             "#f7768e",
+		Bg:                "#1a1b26",
+		Selection:         "#283457",
+		DiscreteSelection: "#3d4f2f", // green-ish
+		BothSelection:     "#4a3f5c", // purple-ish
+		Flash:             "#3d59a1",
+		ToastBg:           "235",
+		ToastFg:           "252",
+		Create:            "#9ece6a", // green
+		Update:            "#e0af68", // yellow/orange
+		Delete:            "#f7768e", // red
+		Replace:           "#bb9af7", // purple
+		Refresh:           "#7dcfff", // cyan
+		Success:           "#9ece6a", // green (same as create)
+		Target:            "#7dcfff", // cyan
+		Exclude:           "#f7768e", // red (same as error/delete)
+		Protect:           "#f5a623", // masterlock yellow
+		Changed:           "#ff9e64", // orange
+	},
+	// Tokyo Night Day
+	"light": {
+		Primary:           "#2e7de9",
+		Secondary:         "#9854f1",
+		Text:              "#3760bf",
+		Dim:               "#848cb5",
+		Error:             "#f52a65",
+		Bg:                "#e1e2e7",
+		Selection:         "#b7c1e3",
+		DiscreteSelection: "#c4dcb4",
+		BothSelection:     "#d3c4e8",
+		Flash:             "#99a7df",
+		ToastBg:           "#c4c8da",
+		ToastFg:           "#3760bf",
+		Create:            "#587539",
+		Update:            "#8c6c3e",
+		Delete:            "#f52a65",
+		Replace:           "#9854f1",
+		Refresh:           "#007197",
+		Success:           "#587539",
+		Target:            "#007197",
+		Exclude:           "#f52a65",
+		Protect:           "#965027",
+		Changed:           "#b15c00",
+	},
+	// Solarized dark
+	"solarized": {
+		Primary:           "#268bd2",
+		Secondary:         "#6c71c4",
+		Text:              "#93a1a1",
+		Dim:               "#586e75",
+		Error:             "#dc322f",
+		Bg:                "#002b36",
+		Selection:         "#073642",
+		DiscreteSelection: "#2b3a14",
+		BothSelection:     "#2f2a4a",
+		Flash:             "#0d4a6b",
+		ToastBg:           "#073642",
+		ToastFg:           "#93a1a1",
+		Create:            "#859900",
+		Update:            "#b58900",
+		Delete:            "#dc322f",
+		Replace:           "#d33682",
+		Refresh:           "#2aa198",
+		Success:           "#859900",
+		Target:            "#2aa198",
+		Exclude:           "#dc322f",
+		Protect:           "#b58900",
+		Changed:           "#cb4b16",
+	},
+	// Saturated colors on black for low-vision use and washed-out displays
+	"high-contrast": {
+		Primary:           "#00afff",
+		Secondary:         "#ff87ff",
+		Text:              "#ffffff",
+		Dim:               "#a8a8a8",
+		Error:             "#ff5f5f",
+		Bg:                "#000000",
+		Selection:         "#005f87",
+		DiscreteSelection: "#005f00",
+		BothSelection:     "#5f005f",
+		Flash:             "#0087d7",
+		ToastBg:           "#ffffff",
+		ToastFg:           "#000000",
+		Create:            "#00ff00",
+		Update:            "#ffff00",
+		Delete:            "#ff5f5f",
+		Replace:           "#ff87ff",
+		Refresh:           "#00ffff",
+		Success:           "#00ff00",
+		Target:            "#00ffff",
+		Exclude:           "#ff5f5f",
+		Protect:           "#ffaf00",
+		Changed:           "#ff8700",
+	},
+}
+
+func init() {
+	setTheme(Themes[DefaultTheme])
+}
+
+// themeColor is a theme color and the name it is configured under
+type themeColor struct {
+	Name  string
+	Color *lipgloss.Color
+}
+
+// colors returns the colors of t, named as in the [theme] section of p5.toml
+func (t *Theme) colors() []themeColor {
+	return []themeColor{
+		{"primary", &t.Primary},
+		{"secondary", &t.Secondary},
+		{"text", &t.Text},
+		{"dim", &t.Dim},
+		{"error", &t.Error},
+		{"background", &t.Bg},
+		{"selection", &t.Selection},
+		{"discrete_selection", &t.DiscreteSelection},
+		{"both_selection", &t.BothSelection},
+		{"flash", &t.Flash},
+		{"toast_background", &t.ToastBg},
+		{"toast_text", &t.ToastFg},
+		{"create", &t.Create},
+		{"update", &t.Update},
+		{"delete", &t.Delete},
+		{"replace", &t.Replace},
+		{"refresh", &t.Refresh},
+		{"success", &t.Success},
+		{"target", &t.Target},
+		{"exclude", &t.Exclude},
+		{"protect", &t.Protect},
+		{"changed", &t.Changed},
+	}
+}
+
+// hexColorPattern matches #rgb and #rrggbb colors
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// validColor reports whether value is a hex color or an ANSI 256 color number
+func validColor(value string) bool {
+	if hexColorPattern.MatchString(value) {
+		return true
+	}
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// ApplyTheme switches the UI to the named built-in theme with colors overridden by
+// name. An empty name selects DefaultTheme. The current theme is left unchanged if
+// the theme or a color name is unknown, or a color is invalid.
+// Components copy styles when constructed, so the theme should be applied before them.
+func ApplyTheme(name string, overrides map[string]string) error {
+	if name == "" {
+		name = DefaultTheme
+	}
+	theme, ok := Themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q", name)
+	}
+
+	byName := make(map[string]*lipgloss.Color)
+	for _, c := range theme.colors() {
+		byName[c.Name] = c.Color
+	}
+
+	names := make([]string, 0, len(overrides))
+	for colorName := range overrides {
+		names = append(names, colorName)
+	}
+	sort.Strings(names)
+
+	for _, colorName := range names {
+		color, ok := byName[colorName]
+		if !ok {
+			return fmt.Errorf("unknown theme color %q", colorName)
+		}
+		value := overrides[colorName]
+		if !validColor(value) {
+			return fmt.Errorf("invalid %s color %q, expected #rrggbb or 0-255", colorName, value)
+		}
+		*color = lipgloss.Color(value)
+	}
+
+	setTheme(theme)
+	return nil
+}
+
+// setTheme sets the color palette from theme and rebuilds the styles
+func setTheme(theme Theme) {
+	ColorPrimary = theme.Primary
+	ColorSecondary = theme.Secondary
+	ColorText = theme.Text
+	ColorDim = theme.Dim
+	ColorError = theme.Error
+	ColorBg = theme.Bg
+	ColorSelection = theme.Selection
+	ColorDiscreteSelection = theme.DiscreteSelection
+	ColorBothSelection = theme.BothSelection
+	ColorFlash = theme.Flash
+	ColorToastBg = theme.ToastBg
+	ColorToastFg = theme.ToastFg
+	ColorCreate = theme.Create
+	ColorUpdate = theme.Update
+	ColorDelete = theme.Delete
+	ColorReplace = theme.Replace
+	ColorRefresh = theme.Refresh
+	ColorSuccess = theme.Success
+	ColorTarget = theme.Target
+	ColorExclude = theme.Exclude
+	ColorProtect = theme.Protect
+	ColorChanged = theme.Changed
+	buildStyles()
+}
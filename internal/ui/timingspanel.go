@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// SlowestResourcesCount is the number of resources listed in the timings panel
+const SlowestResourcesCount = 10
+
+// TimingsPanel is a floating panel summarizing how long the last operation took,
+// listing the slowest resources first with a cursor to jump to one
+type TimingsPanel struct {
+	PanelBase // Embed common panel functionality
+
+	slowest []ResourceItem
+	total   time.Duration
+	cursor  int
+}
+
+// NewTimingsPanel creates a new timings panel component
+func NewTimingsPanel() *TimingsPanel {
+	return &TimingsPanel{}
+}
+
+// SetItems sets the resources of the operation, keeping the SlowestResourcesCount
+// finished resources that took the longest
+func (p *TimingsPanel) SetItems(items []ResourceItem) {
+	p.slowest = SlowestResources(items, SlowestResourcesCount)
+	p.total = operationDuration(items)
+	p.cursor = 0
+	p.ResetScroll()
+}
+
+// Slowest returns the listed resources, slowest first
+func (p *TimingsPanel) Slowest() []ResourceItem {
+	return p.slowest
+}
+
+// SelectedItem returns the resource under the cursor, or nil if there are none
+func (p *TimingsPanel) SelectedItem() *ResourceItem {
+	if p.cursor >= len(p.slowest) {
+		return nil
+	}
+	return &p.slowest[p.cursor]
+}
+
+// MoveCursor moves the cursor by delta resources
+func (p *TimingsPanel) MoveCursor(delta int) {
+	p.cursor = MoveCursor(p.cursor, delta, len(p.slowest))
+}
+
+// SlowestResources returns up to n resources the engine finished operating on,
+// slowest first
+func SlowestResources(items []ResourceItem, n int) []ResourceItem {
+	finished := make([]ResourceItem, 0, len(items))
+	for _, item := range items {
+		if !item.StartedAt.IsZero() && !item.FinishedAt.IsZero() {
+			finished = append(finished, item)
+		}
+	}
+	slices.SortStableFunc(finished, func(a, b ResourceItem) int {
+		return cmp.Compare(b.FinishedAt.Sub(b.StartedAt), a.FinishedAt.Sub(a.StartedAt))
+	})
+	return finished[:min(n, len(finished))]
+}
+
+// operationDuration returns the time from the first resource started to the last
+// one finished
+func operationDuration(items []ResourceItem) time.Duration {
+	var first, last time.Time
+	for _, item := range items {
+		if !item.StartedAt.IsZero() && (first.IsZero() || item.StartedAt.Before(first)) {
+			first = item.StartedAt
+		}
+		if item.FinishedAt.After(last) {
+			last = item.FinishedAt
+		}
+	}
+	if first.IsZero() || last.Before(first) {
+		return 0
+	}
+	return last.Sub(first)
+}
+
+// View renders the timings panel
+func (p *TimingsPanel) View() string {
+	if !p.Visible() || p.Width() == 0 || p.Height() == 0 {
+		return ""
+	}
+
+	var content string
+	if len(p.slowest) == 0 {
+		content = DimStyle.Render(i18n.T("No resources finished in the last operation"))
+	} else {
+		content = p.renderContent()
+	}
+
+	result := RenderDetailPanel(DetailPanelContent{
+		Header:       i18n.T("Slowest Resources"),
+		Content:      content,
+		Width:        p.Width(),
+		Height:       p.Height(),
+		ScrollOffset: p.ScrollOffset(),
+	})
+
+	if result.NewScrollOffset != p.ScrollOffset() {
+		p.SetScrollOffset(result.NewScrollOffset)
+	}
+
+	return result.Rendered
+}
+
+// renderContent renders the total duration and a line per resource with its
+// elapsed time, operation, type and name
+func (p *TimingsPanel) renderContent() string {
+	lines := []string{
+		DimStyle.Render(i18n.Tf("Operation took %s", FormatDuration(p.total)) + "  ·  enter " + i18n.T("jump to resource")),
+		"",
+	}
+
+	durationWidth := 0
+	for _, item := range p.slowest {
+		durationWidth = max(durationWidth, len(FormatDuration(item.FinishedAt.Sub(item.StartedAt))))
+	}
+	for i, item := range p.slowest {
+		var line strings.Builder
+		if i == p.cursor {
+			line.WriteString(CursorStyle.Render("> "))
+		} else {
+			line.WriteString("  ")
+		}
+		line.WriteString(DimStyle.Render(fmt.Sprintf("%2d. ", i+1)))
+		line.WriteString(ValueStyle.Render(fmt.Sprintf("%*s", durationWidth, FormatDuration(item.FinishedAt.Sub(item.StartedAt)))))
+		line.WriteString("  ")
+		line.WriteString(RenderOp(item.Op))
+		line.WriteString(" ")
+		line.WriteString(DimStyle.Render(item.Type))
+		line.WriteString("  ")
+		line.WriteString(ValueStyle.Render(item.Name))
+		if item.Status == StatusFailed {
+			line.WriteString(" " + StatusFailedStyle.Render("failed"))
+		}
+		lines = append(lines, line.String())
+	}
+
+	contentHeight := p.contentHeight()
+	cursorLine := p.cursor + 2
+	offset := p.ScrollOffset()
+	if cursorLine >= offset+contentHeight {
+		offset = cursorLine - contentHeight + 1
+	}
+	if cursorLine < offset {
+		offset = cursorLine
+	}
+	if p.cursor == 0 {
+		offset = 0
+	}
+	p.SetScrollOffset(offset)
+
+	return strings.Join(lines, "\n")
+}
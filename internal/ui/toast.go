@@ -63,8 +63,8 @@ func (t *Toast) View(width int) string {
 	}
 
 	style := lipgloss.NewStyle().
-		Background(lipgloss.Color("235")).
-		Foreground(lipgloss.Color("252")).
+		Background(ColorToastBg).
+		Foreground(ColorToastFg).
 		Padding(0, 2).
 		Bold(true)
 
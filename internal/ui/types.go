@@ -11,6 +11,9 @@ type ResourceOp = pulumi.ResourceOp
 // OperationType represents an operation type (up, refresh, destroy)
 type OperationType = pulumi.OperationType
 
+// GitInfo describes the git checkout of a workspace
+type GitInfo = pulumi.GitInfo
+
 // ResourceOp constants - aliased from pulumi package
 const (
 	OpCreate        = pulumi.OpCreate
@@ -1,14 +1,24 @@
 package ui
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/exp/golden"
 	"github.com/rfhold/p5/internal/pulumi"
+	"github.com/rfhold/p5/internal/telemetry"
 )
 
 // Test dimensions for consistent golden file output
@@ -62,6 +72,95 @@ func TestHeader_StackView(t *testing.T) {
 	golden.RequireEqual(t, []byte(h.View()))
 }
 
+func TestHeader_Outdated(t *testing.T) {
+	h := NewHeader()
+	h.SetWidth(testWidth)
+	h.SetData(&HeaderData{
+		ProgramName: "my-app",
+		StackName:   "dev",
+		Runtime:     "go",
+	})
+	h.SetViewMode(ViewStack)
+	h.SetSummary(ResourceSummary{
+		Total: 10,
+		Same:  10,
+	}, HeaderDone)
+	h.SetOutdated(true)
+
+	golden.RequireEqual(t, []byte(h.View()))
+}
+
+func TestHeader_QuickCheck(t *testing.T) {
+	h := NewHeader()
+	h.SetWidth(testWidth)
+	h.SetData(&HeaderData{
+		ProgramName: "my-app",
+		StackName:   "dev",
+		Runtime:     "go",
+	})
+	h.SetViewMode(ViewStack)
+	h.SetSummary(ResourceSummary{Total: 10, Same: 10}, HeaderDone)
+
+	h.SetQuickCheck(&QuickCheck{Summary: ResourceSummary{Total: 12, Same: 9, Create: 2, Delete: 1}})
+	pending := h.View()
+	h.SetQuickCheck(&QuickCheck{Summary: ResourceSummary{Total: 10, Same: 10}})
+	upToDate := h.View()
+
+	golden.RequireEqual(t, []byte(pending+"\n"+upToDate))
+}
+
+func TestHeader_Refresh(t *testing.T) {
+	h := NewHeader()
+	h.SetWidth(testWidth)
+	h.SetData(&HeaderData{
+		ProgramName: "my-app",
+		StackName:   "dev",
+		Runtime:     "go",
+	})
+	h.SetViewMode(ViewPreview)
+	h.SetOperation(OperationUp)
+	h.SetSummary(ResourceSummary{Create: 1}, HeaderRunning)
+	h.SetRefresh(true)
+
+	golden.RequireEqual(t, []byte(h.View()))
+}
+
+func TestHeader_Git(t *testing.T) {
+	h := NewHeader()
+	h.SetWidth(testWidth)
+	h.SetData(&HeaderData{
+		ProgramName: "my-app",
+		StackName:   "dev",
+		Runtime:     "go",
+	})
+	h.SetViewMode(ViewStack)
+	h.SetSummary(ResourceSummary{Total: 10, Same: 10}, HeaderDone)
+	h.SetGit(&GitInfo{Branch: "main", Commit: "1a2b3c4", Dirty: true})
+
+	golden.RequireEqual(t, []byte(h.View()))
+}
+
+func TestHeader_WithBadges(t *testing.T) {
+	h := NewHeader()
+	h.SetWidth(testWidth)
+	h.SetData(&HeaderData{
+		ProgramName: "my-app",
+		StackName:   "prod",
+		Runtime:     "go",
+	})
+	h.SetViewMode(ViewStack)
+	h.SetSummary(ResourceSummary{
+		Total: 10,
+		Same:  10,
+	}, HeaderDone)
+	h.SetBadges([]HeaderBadge{
+		{Text: "AWS: prod-account", Level: BadgeDanger},
+		{Text: "kube ctx: staging", Level: BadgeInfo},
+	})
+
+	golden.RequireEqual(t, []byte(h.View()))
+}
+
 func TestHeader_PreviewRunning(t *testing.T) {
 	h := NewHeader()
 	h.SetWidth(testWidth)
@@ -82,6 +181,25 @@ func TestHeader_PreviewRunning(t *testing.T) {
 	golden.RequireEqual(t, []byte(h.View()))
 }
 
+func TestHeader_ExecuteCancelling(t *testing.T) {
+	h := NewHeader()
+	h.SetWidth(testWidth)
+	h.SetData(&HeaderData{
+		ProgramName: "my-app",
+		StackName:   "dev",
+		Runtime:     "go",
+	})
+	h.SetViewMode(ViewExecute)
+	h.SetOperation(OperationUp)
+	h.SetSummary(ResourceSummary{
+		Total:  3,
+		Create: 2,
+		Update: 1,
+	}, HeaderCancelling)
+
+	golden.RequireEqual(t, []byte(h.View()))
+}
+
 func TestHeader_PreviewDone(t *testing.T) {
 	h := NewHeader()
 	h.SetWidth(testWidth)
@@ -120,6 +238,22 @@ func TestHeader_PreviewNoChanges(t *testing.T) {
 	golden.RequireEqual(t, []byte(h.View()))
 }
 
+func TestHeader_DriftDone(t *testing.T) {
+	h := NewHeader()
+	h.SetWidth(testWidth)
+	h.SetData(&HeaderData{
+		ProgramName: "my-app",
+		StackName:   "dev",
+		Runtime:     "go",
+	})
+	h.SetViewMode(ViewPreview)
+	h.SetOperation(OperationRefresh)
+	h.SetSummary(ResourceSummary{Total: 3, Refresh: 2, Delete: 1}, HeaderDone)
+	h.SetDrift(&DriftSummary{Resources: 3, Properties: 4, Deleted: 1})
+
+	golden.RequireEqual(t, []byte(h.View()))
+}
+
 func TestHeader_HistoryView(t *testing.T) {
 	h := NewHeader()
 	h.SetWidth(testWidth)
@@ -168,6 +302,124 @@ func TestResourceList_SingleItem(t *testing.T) {
 	golden.RequireEqual(t, []byte(r.View()))
 }
 
+func TestResourceList_DiffChanged(t *testing.T) {
+	flags := make(map[string]ResourceFlags)
+	r := NewResourceList(flags)
+	r.SetSize(testWidth, testHeight)
+	r.SetItems([]ResourceItem{
+		{
+			URN:  "urn:pulumi:dev::my-app::aws:s3/bucket:Bucket::my-bucket",
+			Type: "aws:s3/bucket:Bucket",
+			Name: "my-bucket",
+			Op:   OpUpdate,
+		},
+		{
+			URN:  "urn:pulumi:dev::my-app::aws:sqs/queue:Queue::my-queue",
+			Type: "aws:sqs/queue:Queue",
+			Name: "my-queue",
+			Op:   OpCreate,
+		},
+	})
+	r.SetDiffChanged(map[string]bool{"urn:pulumi:dev::my-app::aws:s3/bucket:Bucket::my-bucket": true})
+
+	golden.RequireEqual(t, []byte(r.View()))
+}
+
+func TestResourceList_WithNotes(t *testing.T) {
+	flags := make(map[string]ResourceFlags)
+	r := NewResourceList(flags)
+	r.SetSize(testWidth, testHeight)
+	r.SetItems([]ResourceItem{
+		{
+			URN:  "urn:pulumi:dev::my-app::aws:rds/instance:Instance::db",
+			Type: "aws:rds/instance:Instance",
+			Name: "db",
+			Op:   OpSame,
+		},
+		{
+			URN:  "urn:pulumi:dev::my-app::aws:sqs/queue:Queue::my-queue",
+			Type: "aws:sqs/queue:Queue",
+			Name: "my-queue",
+			Op:   OpSame,
+		},
+	})
+	r.SetNotes(map[string]string{"urn:pulumi:dev::my-app::aws:rds/instance:Instance::db": "manually resized, do not replace"})
+
+	golden.RequireEqual(t, []byte(r.View()))
+}
+
+func TestResourceList_Icons(t *testing.T) {
+	SetIcons(true)
+	t.Cleanup(func() { SetIcons(false) })
+
+	flags := make(map[string]ResourceFlags)
+	r := NewResourceList(flags)
+	r.SetSize(testWidth, testHeight)
+	r.SetItems([]ResourceItem{
+		{URN: "urn:pulumi:dev::my-app::pulumi:pulumi:Stack::my-app-dev", Type: "pulumi:pulumi:Stack", Name: "my-app-dev", Op: OpSame},
+		{URN: "urn:pulumi:dev::my-app::aws:s3/bucket:Bucket::my-bucket", Type: "aws:s3/bucket:Bucket", Name: "my-bucket", Op: OpCreate},
+		{URN: "urn:pulumi:dev::my-app::kubernetes:apps/v1:Deployment::web", Type: "kubernetes:apps/v1:Deployment", Name: "web", Op: OpUpdate},
+		{URN: "urn:pulumi:dev::my-app::random:index/randomId:RandomId::suffix", Type: "random:index/randomId:RandomId", Name: "suffix", Op: OpSame},
+		{URN: "urn:pulumi:dev::my-app::tls:index/privateKey:PrivateKey::key", Type: "tls:index/privateKey:PrivateKey", Name: "key", Op: OpSame},
+	})
+
+	golden.RequireEqual(t, []byte(r.View()))
+}
+
+func TestResourceIcon(t *testing.T) {
+	if icon := resourceIcon("aws:s3/bucket:Bucket"); icon != "" {
+		t.Errorf("expected no icon while icons are disabled, got %q", icon)
+	}
+
+	SetIcons(true)
+	t.Cleanup(func() { SetIcons(false) })
+	tests := map[string]string{
+		"aws:s3/bucket:Bucket":                iconAWS,
+		"azure-native:storage:StorageAccount": iconAzure,
+		"gcp:storage/bucket:Bucket":           iconGCP,
+		"command:local:Command":               iconCommand,
+		"pulumi:providers:kubernetes":         iconKubernetes,
+		"pulumi:pulumi:Stack":                 iconStack,
+		"tls:index/privateKey:PrivateKey":     iconResource,
+	}
+	for resourceType, want := range tests {
+		if icon := resourceIcon(resourceType); icon != want {
+			t.Errorf("resourceIcon(%q) = %q, want %q", resourceType, icon, want)
+		}
+	}
+}
+
+func TestResourceList_DriftOnly(t *testing.T) {
+	flags := make(map[string]ResourceFlags)
+	r := NewResourceList(flags)
+	r.SetSize(testWidth, testHeight)
+	r.SetShowAllOps(false)
+	r.SetDriftOnly(true)
+	r.SetItems([]ResourceItem{
+		{
+			URN:         "urn:pulumi:dev::my-app::aws:s3/bucket:Bucket::assets",
+			Type:        "aws:s3/bucket:Bucket",
+			Name:        "assets",
+			Op:          OpRefresh,
+			DriftedKeys: []string{"acl", "policy", "tags", "versioning"},
+		},
+		{
+			URN:  "urn:pulumi:dev::my-app::aws:sqs/queue:Queue::jobs",
+			Type: "aws:sqs/queue:Queue",
+			Name: "jobs",
+			Op:   OpRefresh,
+		},
+		{
+			URN:  "urn:pulumi:dev::my-app::aws:sns/topic:Topic::events",
+			Type: "aws:sns/topic:Topic",
+			Name: "events",
+			Op:   OpDelete,
+		},
+	})
+
+	golden.RequireEqual(t, []byte(r.View()))
+}
+
 func TestResourceList_MultipleOps(t *testing.T) {
 	flags := make(map[string]ResourceFlags)
 	r := NewResourceList(flags)
@@ -323,6 +575,131 @@ func TestResourceList_TreeStructure(t *testing.T) {
 	golden.RequireEqual(t, []byte(r.View()))
 }
 
+func TestResourceList_GroupedView(t *testing.T) {
+	r := NewResourceList(make(map[string]ResourceFlags))
+	r.SetSize(testWidth, testHeight)
+	r.SetShowAllOps(false)
+	r.SetItems([]ResourceItem{
+		{URN: "urn:pulumi:dev::my-app::pulumi:pulumi:Stack::my-stack", Type: "pulumi:pulumi:Stack", Name: "my-stack", Op: OpSame},
+		{URN: "urn:pulumi:dev::my-app::aws:s3/bucket:Bucket::assets", Type: "aws:s3/bucket:Bucket", Name: "assets", Op: OpCreate, Parent: "urn:pulumi:dev::my-app::pulumi:pulumi:Stack::my-stack"},
+		{URN: "urn:pulumi:dev::my-app::aws:s3/bucket:Bucket::logs", Type: "aws:s3/bucket:Bucket", Name: "logs", Op: OpUpdate, Parent: "urn:pulumi:dev::my-app::pulumi:pulumi:Stack::my-stack"},
+		{URN: "urn:pulumi:dev::my-app::aws:rds/instance:Instance::db", Type: "aws:rds/instance:Instance", Name: "db", Op: OpReplace, Parent: "urn:pulumi:dev::my-app::pulumi:pulumi:Stack::my-stack"},
+	})
+	r.SetGrouping(GroupByType)
+
+	golden.RequireEqual(t, []byte(r.View()))
+}
+
+// TestResourceList_TreePrefixCache verifies cached tree prefixes are redrawn when
+// a streamed item changes the shape of the tree.
+func TestResourceList_TreePrefixCache(t *testing.T) {
+	r := NewResourceList(make(map[string]ResourceFlags))
+	r.SetSize(testWidth, testHeight)
+	r.SetItems([]ResourceItem{
+		{URN: "urn:pulumi:dev::my-app::pulumi:pulumi:Stack::my-stack", Type: "pulumi:pulumi:Stack", Name: "my-stack"},
+		{URN: "urn:pulumi:dev::my-app::aws:s3/bucket:Bucket::child-1", Type: "aws:s3/bucket:Bucket", Name: "child-1", Sequence: 1, Parent: "urn:pulumi:dev::my-app::pulumi:pulumi:Stack::my-stack"},
+	})
+
+	view := r.View()
+	if strings.Count(view, "└─") != 1 || strings.Contains(view, "├─") {
+		t.Fatalf("expected child-1 to be drawn as the last child:\n%s", view)
+	}
+
+	r.AddItem(ResourceItem{URN: "urn:pulumi:dev::my-app::aws:s3/bucket:Bucket::child-2", Type: "aws:s3/bucket:Bucket", Name: "child-2", Sequence: 2, Op: OpCreate, Parent: "urn:pulumi:dev::my-app::pulumi:pulumi:Stack::my-stack"})
+
+	view = r.View()
+	if strings.Count(view, "├─") != 1 || strings.Count(view, "└─") != 1 {
+		t.Errorf("expected child-1 to be redrawn with a sibling below it:\n%s", view)
+	}
+}
+
+// treeItems builds a stack with nested components, in an order where children
+// are often streamed before their parents
+func treeItems(n int) []ResourceItem {
+	const prefix = "urn:pulumi:dev::my-app::"
+	stack := prefix + "pulumi:pulumi:Stack::my-stack"
+	items := []ResourceItem{{URN: stack, Type: "pulumi:pulumi:Stack", Name: "my-stack", Op: OpSame}}
+	for i := 1; i < n; i++ {
+		parent := stack
+		if i%3 != 0 && i > 3 {
+			parent = fmt.Sprintf("%scustom:Component::c-%d", prefix, i/3*3)
+		}
+		typ := "aws:s3/bucket:Bucket"
+		if i%3 == 0 {
+			typ = "custom:Component"
+		}
+		items = append(items, ResourceItem{
+			URN:      fmt.Sprintf("%s%s::c-%d", prefix, typ, i),
+			Type:     typ,
+			Name:     fmt.Sprintf("c-%d", i),
+			Op:       []ResourceOp{OpSame, OpCreate, OpUpdate}[i%3],
+			Sequence: (i * 7919) % n,
+			Parent:   parent,
+		})
+	}
+	return items
+}
+
+func TestResourceList_AddItemMatchesSetItems(t *testing.T) {
+	items := treeItems(200)
+
+	streamed := NewResourceList(make(map[string]ResourceFlags))
+	for i := len(items) - 1; i >= 0; i-- {
+		streamed.AddItem(items[i])
+	}
+	// Moving a component takes its children along
+	moved := items[3]
+	moved.Parent = items[6].URN
+	streamed.AddItem(moved)
+
+	items[3] = moved
+	want := NewResourceList(make(map[string]ResourceFlags))
+	want.SetItems(items)
+
+	for _, showAll := range []bool{false, true} {
+		streamed.SetShowAllOps(showAll)
+		want.SetShowAllOps(showAll)
+		if len(streamed.visibleIdx) != len(want.visibleIdx) {
+			t.Fatalf("showAll=%v: expected %d visible items, got %d", showAll, len(want.visibleIdx), len(streamed.visibleIdx))
+		}
+		for i := range want.visibleIdx {
+			got, exp := streamed.items[streamed.visibleIdx[i]], want.items[want.visibleIdx[i]]
+			if got.URN != exp.URN || got.Depth != exp.Depth || got.IsLast != exp.IsLast {
+				t.Fatalf("showAll=%v: row %d is %s (depth %d, last %v), expected %s (depth %d, last %v)",
+					showAll, i, got.Name, got.Depth, got.IsLast, exp.Name, exp.Depth, exp.IsLast)
+			}
+		}
+	}
+	for urn, i := range streamed.urnIdx {
+		if streamed.items[i].URN != urn {
+			t.Fatalf("URN index points %s at %s", urn, streamed.items[i].URN)
+		}
+	}
+}
+
+// BenchmarkResourceList_AddItem streams the last create of a 10k resource stack,
+// which must fit in a frame (16ms) to keep the UI responsive during large updates
+func BenchmarkResourceList_AddItem(b *testing.B) {
+	items := treeItems(10_000)
+	r := NewResourceList(make(map[string]ResourceFlags))
+	r.SetSize(testWidth, testHeight)
+	for _, item := range items[:len(items)-1] {
+		r.AddItem(item)
+	}
+	last := items[len(items)-1]
+
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		last.URN = fmt.Sprintf("%s-%d", items[len(items)-1].URN, i)
+		r.AddItem(last)
+		_ = r.View()
+	}
+	if perOp := time.Since(start) / time.Duration(b.N); perOp > 16*time.Millisecond {
+		b.Errorf("AddItem took %v per item, over the 16ms frame budget", perOp)
+	}
+}
+
 func TestHelpDialog_View(t *testing.T) {
 	h := NewHelpDialog()
 	h.SetSize(testWidth, testHeight)
@@ -330,6 +707,86 @@ func TestHelpDialog_View(t *testing.T) {
 	golden.RequireEqual(t, []byte(h.View()))
 }
 
+func TestHelpDialog_RemappedKeys(t *testing.T) {
+	t.Cleanup(func() { _ = ApplyKeyBindings(nil) })
+	if err := ApplyKeyBindings(map[string][]string{"up": {"up", "i"}, "home": {"ctrl+g"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	h := NewHelpDialog()
+	h.SetSize(testWidth, testHeight)
+
+	golden.RequireEqual(t, []byte(h.View()))
+}
+
+func TestApplyKeyBindings(t *testing.T) {
+	t.Cleanup(func() { _ = ApplyKeyBindings(nil) })
+
+	if err := defaultKeys.checkConflicts(); err != nil {
+		t.Fatalf("expected the default keys not to conflict: %v", err)
+	}
+
+	if err := ApplyKeyBindings(map[string][]string{"preview_up": {"U"}}); err == nil {
+		t.Error("expected an error for a key bound to two actions")
+	}
+	if err := ApplyKeyBindings(map[string][]string{"launch": {"L"}}); err == nil {
+		t.Error("expected an error for an unknown action")
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}}, Keys.PreviewUp) {
+		t.Error("expected failed remaps to leave the keys unchanged")
+	}
+
+	// Swapping keys between actions doesn't conflict
+	if err := ApplyKeyBindings(map[string][]string{"preview_up": {"U"}, "revert_drift": {"u"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}}, Keys.PreviewUp) {
+		t.Error("expected U to preview up")
+	}
+	if got := Keys.PreviewUp.Help(); got.Key != "U" || got.Desc != "preview up" {
+		t.Errorf("expected help for the remapped key, got %+v", got)
+	}
+}
+
+func TestApplyTheme(t *testing.T) {
+	t.Cleanup(func() { _ = ApplyTheme(DefaultTheme, nil) })
+
+	for name, theme := range Themes {
+		for _, c := range theme.colors() {
+			if !validColor(string(*c.Color)) {
+				t.Errorf("theme %s has invalid %s color %q", name, c.Name, *c.Color)
+			}
+		}
+	}
+
+	if err := ApplyTheme("light", map[string]string{"primary": "#ff79c6"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ColorPrimary != "#ff79c6" || ColorText != Themes["light"].Text {
+		t.Errorf("expected the light theme with the primary override, got primary %q text %q", ColorPrimary, ColorText)
+	}
+	if got := CursorStyle.GetForeground(); got != lipgloss.Color("#ff79c6") {
+		t.Errorf("expected styles rebuilt from the theme, got %v", got)
+	}
+
+	for _, tt := range []struct {
+		name      string
+		overrides map[string]string
+	}{
+		{"neon", nil},
+		{"dark", map[string]string{"sparkle": "#ffffff"}},
+		{"dark", map[string]string{"primary": "blue"}},
+		{"dark", map[string]string{"primary": "256"}},
+	} {
+		if err := ApplyTheme(tt.name, tt.overrides); err == nil {
+			t.Errorf("expected an error for theme %q with %v", tt.name, tt.overrides)
+		}
+	}
+	if ColorPrimary != "#ff79c6" {
+		t.Error("expected failed themes to leave the colors unchanged")
+	}
+}
+
 func TestToast_Hidden(t *testing.T) {
 	toast := NewToast()
 	golden.RequireEqual(t, []byte(toast.View(testWidth)))
@@ -407,6 +864,37 @@ func TestDiffRenderer_Update(t *testing.T) {
 	golden.RequireEqual(t, []byte(r.RenderCombinedProperties(resource)))
 }
 
+func TestDiffRenderer_IgnoredPaths(t *testing.T) {
+	r := NewDiffRenderer(testWidth)
+	r.SetIgnoredPaths(DiffIgnoreRules{
+		"kubernetes:*":              {"metadata.annotations"},
+		"*":                         {"metadata.labels.managed-*"},
+		"aws:s3/bucket:Bucket":      {"tags"},
+		"kubernetes:apps/v1:Deploy": {"spec"},
+	}.PathsFor("kubernetes:apps/v1:Deployment"))
+	resource := &ResourceItem{
+		Op: OpUpdate,
+		OldInputs: map[string]any{
+			"metadata": map[string]any{
+				"name":        "web",
+				"annotations": map[string]any{"deployed-at": "1704067200"},
+				"labels":      map[string]any{"app": "web", "managed-by": "ci"},
+			},
+			"spec": map[string]any{"replicas": 2},
+		},
+		Inputs: map[string]any{
+			"metadata": map[string]any{
+				"name":        "web",
+				"annotations": map[string]any{"deployed-at": "1717200000"},
+				"labels":      map[string]any{"app": "web", "managed-by": "ci"},
+			},
+			"spec": map[string]any{"replicas": 3},
+		},
+	}
+
+	golden.RequireEqual(t, []byte(r.RenderCombinedProperties(resource)))
+}
+
 func TestDiffRenderer_UpdateAddRemoveKeys(t *testing.T) {
 	r := NewDiffRenderer(testWidth)
 	resource := &ResourceItem{
@@ -441,15 +929,263 @@ func TestDiffRenderer_ArrayDiff(t *testing.T) {
 	golden.RequireEqual(t, []byte(r.RenderCombinedProperties(resource)))
 }
 
-func TestDiffRenderer_NoProperties(t *testing.T) {
-	r := NewDiffRenderer(testWidth)
-	resource := &ResourceItem{
-		Op: OpSame,
-	}
-
+func jsonStringResource() *ResourceItem {
+	return &ResourceItem{
+		Op: OpUpdate,
+		OldInputs: map[string]any{
+			"environment": map[string]any{
+				"CONFIG_JSON": `{"version":"1.0","updated":"2024-01-01T00:00:00Z","nested":{"time":"1704067200"},"hosts":["a","b"]}`,
+			},
+		},
+		Inputs: map[string]any{
+			"environment": map[string]any{
+				"CONFIG_JSON": `{"version":"1.0","updated":"2024-06-01T00:00:00Z","nested":{"time":"1717200000"},"hosts":["a","c"]}`,
+			},
+		},
+	}
+}
+
+func TestDiffRenderer_JSONString(t *testing.T) {
+	r := NewDiffRenderer(testWidth)
+
+	golden.RequireEqual(t, []byte(r.RenderCombinedProperties(jsonStringResource())))
+}
+
+func TestDiffRenderer_JSONStringRaw(t *testing.T) {
+	r := NewDiffRenderer(testWidth)
+	r.SetRawJSON(true)
+
+	golden.RequireEqual(t, []byte(r.RenderCombinedProperties(jsonStringResource())))
+}
+
+func TestDiffRenderer_JSONStringKindChange(t *testing.T) {
+	r := NewDiffRenderer(testWidth)
+	resource := &ResourceItem{
+		Op:        OpUpdate,
+		OldInputs: map[string]any{"policy": `{"Version":"2012-10-17"}`},
+		Inputs:    map[string]any{"policy": `["not", "an", "object"]`},
+	}
+
+	// Documents of different kinds fall back to the string diff
+	if got := r.RenderCombinedProperties(resource); strings.Contains(got, "(json)") {
+		t.Errorf("expected a string diff, got:\n%s", got)
+	}
+}
+
+func TestDiffRenderer_NoProperties(t *testing.T) {
+	r := NewDiffRenderer(testWidth)
+	resource := &ResourceItem{
+		Op: OpSame,
+	}
+
 	golden.RequireEqual(t, []byte(r.RenderCombinedProperties(resource)))
 }
 
+// TestLargeValues verifies large strings are truncated with a marker in diffs, and
+// listed for the pager with the values a change replaces after the new ones
+func TestLargeValues(t *testing.T) {
+	kubeconfig := "apiVersion: v1\n" + strings.Repeat("clusters:\n- cluster:\n    server: https://example.com\n", 30)
+	policy := `{"Version":"2012-10-17","Statement":[` + strings.Repeat(`{"Effect":"Allow","Action":"s3:GetObject"},`, 30) + `{}]}`
+	resource := &ResourceItem{
+		Op:        OpUpdate,
+		OldInputs: map[string]any{"kubeconfig": kubeconfig, "policy": policy + " "},
+		Inputs:    map[string]any{"kubeconfig": kubeconfig, "policy": policy, "name": "cluster"},
+		Outputs:   map[string]any{"auth": map[string]any{"kubeconfig": kubeconfig}},
+	}
+
+	view := NewDiffRenderer(testWidth).RenderCombinedProperties(resource)
+	if !strings.Contains(view, `"apiVersion: v1"... [+90 lines]`) {
+		t.Errorf("expected the kubeconfig's first line and a line count, got:\n%s", view)
+	}
+	if strings.Contains(view, "https://example.com") {
+		t.Errorf("expected the kubeconfig to be truncated, got:\n%s", view)
+	}
+
+	var labels []string
+	for _, v := range LargeValues(resource) {
+		labels = append(labels, v.Label())
+	}
+	want := []string{"auth.kubeconfig", "kubeconfig", "policy", "policy (before)"}
+	if !slices.Equal(labels, want) {
+		t.Errorf("expected %v, got %v", want, labels)
+	}
+
+	p := NewValuePager()
+	p.SetSize(testWidth, testHeight)
+	p.Show("cluster", LargeValue{Path: "policy", Value: policy})
+	if !strings.HasPrefix(p.Text(), "{\n  \"Version\": \"2012-10-17\"") {
+		t.Errorf("expected the policy to be indented, got:\n%s", p.Text())
+	}
+	p.Scroll(p.LineCount())
+	if view := p.View(); !strings.Contains(view, "]") || strings.Contains(view, "Version") {
+		t.Errorf("expected the end of the policy, got:\n%s", view)
+	}
+}
+
+func TestDiffRenderer_HighlightedValues(t *testing.T) {
+	resource := &ResourceItem{
+		Op: OpUpdate,
+		OldInputs: map[string]any{
+			"config": "bucket = \"logs\"\nacl    = \"private\"\n\nversioning {\n  enabled = false\n}",
+		},
+		Inputs: map[string]any{
+			"config":   "bucket = \"logs\"\nacl    = \"private\"\n\nversioning {\n  enabled = true\n}",
+			"policy":   `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject"}]}`,
+			"userData": "# cloud-config\npackages:\n  - nginx\nruncmd:\n  - systemctl start nginx",
+		},
+	}
+
+	golden.RequireEqual(t, []byte(NewDiffRenderer(testWidth).RenderCombinedProperties(resource)))
+
+	SetSyntaxHighlighting(false)
+	t.Cleanup(func() { SetSyntaxHighlighting(true) })
+	view := NewDiffRenderer(testWidth).RenderCombinedProperties(resource)
+	if !strings.Contains(view, `"# cloud-config\npackages:`) || strings.Contains(view, "(yaml)") {
+		t.Errorf("expected plain quoted values with highlighting off, got:\n%s", view)
+	}
+}
+
+func TestDetectValueFormat(t *testing.T) {
+	tests := []struct {
+		value string
+		want  valueFormat
+	}{
+		{`{"a": 1}`, formatJSON},
+		{"apiVersion: v1\nkind: Pod\nmetadata:\n  name: web", formatYAML},
+		{"- name: a\n- name: b", formatYAML},
+		{"resource \"aws_s3_bucket\" \"b\" {\n  bucket = \"logs\"\n}", formatHCL},
+		{"#!/bin/bash\necho hello\nexit 0", formatPlain},
+		{"key: value", formatPlain},
+	}
+	for _, tt := range tests {
+		if got := detectValueFormat(tt.value); got != tt.want {
+			t.Errorf("detectValueFormat(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestTokenizeLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		format valueFormat
+		want   []syntaxToken
+	}{
+		{`  "Effect": "Allow",`, formatJSON, []syntaxToken{
+			{tokenText, "  "}, {tokenKey, `"Effect"`}, {tokenPunct, ":"}, {tokenText, " "},
+			{tokenString, `"Allow"`}, {tokenPunct, ","},
+		}},
+		{"- port: 8080 # http", formatYAML, []syntaxToken{
+			{tokenPunct, "-"}, {tokenText, " "}, {tokenKey, "port"}, {tokenPunct, ":"},
+			{tokenText, " "}, {tokenNumber, "8080"}, {tokenText, " "}, {tokenComment, "# http"},
+		}},
+		{"  enabled = true", formatHCL, []syntaxToken{
+			{tokenText, "  "}, {tokenKey, "enabled"}, {tokenText, " "}, {tokenPunct, "="},
+			{tokenText, " "}, {tokenLiteral, "true"},
+		}},
+	}
+	for _, tt := range tests {
+		if got := tokenizeLine(tt.line, tt.format); !slices.Equal(got, tt.want) {
+			t.Errorf("tokenizeLine(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestCopyToClipboard_OSC52(t *testing.T) {
+	var out strings.Builder
+	osc52Output = &out
+	t.Cleanup(func() { osc52Output = os.Stderr })
+	if err := SetClipboard(ClipboardOSC52); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = SetClipboard(ClipboardAuto) })
+
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm-256color")
+	if !copyToClipboard("hello") || out.String() != "\x1b]52;c;aGVsbG8=\x07" {
+		t.Errorf("expected an OSC52 sequence, got %q", out.String())
+	}
+
+	out.Reset()
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	if !copyToClipboard("hello") || !strings.HasPrefix(out.String(), "\x1bPtmux;") {
+		t.Errorf("expected the sequence to pass through tmux, got %q", out.String())
+	}
+
+	if err := SetClipboard("pbcopy"); err == nil {
+		t.Error("expected an error for an unknown clipboard mode")
+	}
+}
+
+func TestResourceList_ExportResources(t *testing.T) {
+	const bucket = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	flags := map[string]ResourceFlags{bucket: {Target: true, Replace: true}}
+	r := NewResourceList(flags)
+	r.SetSize(testWidth, testHeight)
+	r.SetItems([]ResourceItem{
+		{URN: bucket, Type: "aws:s3/bucket:Bucket", Name: "logs", Op: OpUpdate, Protected: true},
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets, old", Type: "aws:s3/bucket:Bucket", Name: "assets, old", Op: OpDelete},
+		{URN: "urn:pulumi:dev::app::aws:iam/role:Role::app", Type: "aws:iam/role:Role", Name: "app", Op: OpSame},
+	})
+	r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	for _, char := range "bucket" {
+		r.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{char}})
+	}
+	r.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	csv, err := r.ExportResources("review.CSV")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `name,type,op,flags,urn
+"assets, old",aws:s3/bucket:Bucket,delete,,"urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets, old"
+logs,aws:s3/bucket:Bucket,update,target replace protected,urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs`
+	if csv != want {
+		t.Errorf("unexpected CSV export:\n%s", csv)
+	}
+
+	data, err := r.ExportResources("review.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resources []ResourceExport
+	if err := json.Unmarshal([]byte(data), &resources); err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 2 || resources[1].Name != "logs" || !slices.Equal(resources[1].Flags, []string{"target", "replace", "protected"}) {
+		t.Errorf("unexpected JSON export: %s", data)
+	}
+
+	if _, err := r.ExportResources("review.txt"); err == nil {
+		t.Error("expected an error for an unsupported file extension")
+	}
+}
+
+func TestPreviewMarkdown(t *testing.T) {
+	items := []ResourceItem{
+		{URN: "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev", Type: "pulumi:pulumi:Stack", Name: "app-dev", Op: OpSame},
+		{
+			URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Type: "aws:s3/bucket:Bucket", Name: "logs", Op: OpUpdate,
+			OldInputs: map[string]any{"acl": "private", "tags": map[string]any{"env": "dev", "managed-by": "ci"}},
+			Inputs:    map[string]any{"acl": "public-read", "tags": map[string]any{"env": "dev", "managed-by": "ci2"}},
+		},
+		{
+			URN: "urn:pulumi:dev::app::aws:rds/instance:Instance::db", Type: "aws:rds/instance:Instance", Name: "db", Op: OpReplace,
+			OldInputs: map[string]any{"engine": "postgres"}, Inputs: map[string]any{"engine": "mysql"}, ReplaceKeys: []string{"engine"},
+		},
+		{
+			URN: "urn:pulumi:dev::app::aws:sqs/queue:Queue::jobs|old", Type: "aws:sqs/queue:Queue", Name: "jobs|old", Op: OpCreate,
+			Inputs: map[string]any{"delaySeconds": 5},
+		},
+	}
+	ignore := DiffIgnoreRules{"aws:s3/*": {"tags.managed-by"}}
+
+	golden.RequireEqual(t, []byte(PreviewMarkdown("org/dev", OperationUp, items, ignore)))
+
+	if got := PreviewMarkdown("dev", OperationUp, items[:1], nil); !strings.Contains(got, "No changes.") {
+		t.Errorf("expected no changes, got:\n%s", got)
+	}
+}
+
 func TestDetailPanel_NotVisible(t *testing.T) {
 	d := NewDetailPanel()
 	d.SetSize(testWidth, testHeight)
@@ -489,6 +1225,30 @@ func TestDetailPanel_WithResource(t *testing.T) {
 	golden.RequireEqual(t, []byte(d.View()))
 }
 
+func TestDetailPanel_StackReference(t *testing.T) {
+	d := NewDetailPanel()
+	d.SetSize(testWidth, testHeight)
+	d.Show()
+	d.SetResource(&ResourceItem{
+		URN:    "urn:pulumi:dev::my-app::pulumi:pulumi:StackReference::network",
+		Type:   "pulumi:pulumi:StackReference",
+		Name:   "network",
+		Op:     OpSame,
+		Inputs: map[string]any{"name": "acme/network/dev"},
+		Outputs: map[string]any{
+			"name": "acme/network/dev",
+			"outputs": map[string]any{
+				"vpcId":    "vpc-123",
+				"subnets":  []any{"subnet-a", "subnet-b"},
+				"dbSecret": "hunter2",
+			},
+			"secretOutputNames": []any{"dbSecret"},
+		},
+	})
+
+	golden.RequireEqual(t, []byte(d.View()))
+}
+
 func TestDetailPanel_WithRunningStatus(t *testing.T) {
 	d := NewDetailPanel()
 	d.SetSize(testWidth, testHeight)
@@ -511,6 +1271,106 @@ func TestDetailPanel_WithRunningStatus(t *testing.T) {
 	golden.RequireEqual(t, []byte(d.View()))
 }
 
+func TestDetailPanel_WithNote(t *testing.T) {
+	d := NewDetailPanel()
+	d.SetSize(testWidth, testHeight)
+	d.Show()
+	d.SetNotes(map[string]string{"urn:pulumi:dev::my-app::aws:rds/instance:Instance::db": "manually resized, do not replace"})
+	d.SetResource(&ResourceItem{
+		URN:  "urn:pulumi:dev::my-app::aws:rds/instance:Instance::db",
+		Type: "aws:rds/instance:Instance",
+		Name: "db",
+		Op:   OpSame,
+		Inputs: map[string]any{
+			"instanceClass": "db.r6g.xlarge",
+		},
+	})
+
+	golden.RequireEqual(t, []byte(d.View()))
+}
+
+func TestDetailPanel_WithReplaceKeys(t *testing.T) {
+	d := NewDetailPanel()
+	d.SetSize(testWidth, testHeight)
+	d.Show()
+	d.SetResource(&ResourceItem{
+		URN:         "urn:pulumi:dev::my-app::aws:rds/instance:Instance::db",
+		Type:        "aws:rds/instance:Instance",
+		Name:        "db",
+		Op:          OpReplace,
+		ReplaceKeys: []string{"availabilityZone", "engine"},
+		Inputs:      map[string]any{"availabilityZone": "us-west-2b", "engine": "postgres"},
+		OldInputs:   map[string]any{"availabilityZone": "us-west-2a", "engine": "mysql"},
+	})
+
+	golden.RequireEqual(t, []byte(d.View()))
+}
+
+func TestDetailPanel_Secrets(t *testing.T) {
+	const urn = "urn:pulumi:dev::my-app::aws:rds/instance:Instance::db"
+	secret := map[string]any{"4dabf18193072939515e22adb298388d": "1b47061264138c4ac30d75fd1eb44270"}
+	d := NewDetailPanel()
+	d.SetSize(testWidth, testHeight)
+	d.Show()
+	d.SetResource(&ResourceItem{
+		URN:    urn,
+		Type:   "aws:rds/instance:Instance",
+		Name:   "db",
+		Op:     OpCreate,
+		Inputs: map[string]any{"engine": "postgres", "password": secret, "tags": map[string]any{"token": secret}},
+	})
+
+	if got := d.HiddenSecrets(); !slices.Equal(got, []string{"password", "tags.token"}) {
+		t.Fatalf("expected both secrets hidden, got %v", got)
+	}
+	if view := d.View(); !strings.Contains(view, "[secret]") || strings.Contains(view, "1b47061264138c4ac30d75fd1eb44270") {
+		t.Fatalf("expected secrets to render as [secret], got:\n%s", view)
+	}
+
+	d.RevealSecret(urn, "password", "hunter2")
+	if view := d.View(); !strings.Contains(view, "hunter2") {
+		t.Errorf("expected the revealed password, got:\n%s", view)
+	}
+	if got := d.HiddenSecrets(); !slices.Equal(got, []string{"tags.token"}) {
+		t.Errorf("expected only the token hidden, got %v", got)
+	}
+
+	d.Hide()
+	d.Show()
+	if got := d.HiddenSecrets(); len(got) != 2 {
+		t.Errorf("expected secrets hidden again after closing the panel, got %v", got)
+	}
+}
+
+func TestDetailPanel_Search(t *testing.T) {
+	d := NewDetailPanel()
+	d.SetSize(testWidth, testHeight)
+	d.Show()
+	d.SetResource(&ResourceItem{
+		URN:  "urn:pulumi:dev::my-app::aws:s3/bucket:Bucket::my-bucket",
+		Type: "aws:s3/bucket:Bucket",
+		Name: "my-bucket",
+		Op:   OpCreate,
+		Inputs: map[string]any{
+			"bucketName": "my-bucket",
+			"region":     "us-west-2",
+		},
+		Outputs: map[string]any{
+			"arn": "arn:aws:s3:::my-bucket",
+		},
+	})
+
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	for _, r := range "bucket" {
+		d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	d.View()
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+
+	golden.RequireEqual(t, []byte(d.View()))
+}
+
 func TestConfirmModal_Basic(t *testing.T) {
 	m := NewConfirmModal()
 	m.SetSize(testWidth, testHeight)
@@ -527,6 +1387,59 @@ func TestConfirmModal_WithWarning(t *testing.T) {
 	golden.RequireEqual(t, []byte(m.View()))
 }
 
+func TestConfirmModal_Details(t *testing.T) {
+	m := NewConfirmModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("Execute destroy", "Run destroy without previewing changes first?\n\nDestroys 12 resources: 1 targeted, 11 children and dependents", "")
+	var lines []string
+	for i := range 12 {
+		lines = append(lines, fmt.Sprintf("aws:s3/bucket:Bucket  bucket-%d", i+1))
+	}
+	m.SetDetails(lines)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if !m.DetailsExpanded() {
+		t.Fatal("expected tab to expand the list")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	golden.RequireEqual(t, []byte(m.View()))
+
+	m.Show("Confirm Action", "Are you sure you want to proceed?", "")
+	if m.DetailsExpanded() {
+		t.Error("expected showing the modal again to clear the list")
+	}
+}
+
+func TestConfirmModal_Phrase(t *testing.T) {
+	m := NewConfirmModal()
+	m.SetSize(testWidth, testHeight)
+	m.SetLabels("Cancel", "Execute")
+	m.Show("Execute up", "prod is a protected stack. Run up on it?", "This will apply changes to your infrastructure.")
+	m.RequirePhrase("prod")
+	golden.RequireEqual(t, []byte(m.View()))
+
+	if confirmed, cancelled, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}}); confirmed || cancelled {
+		t.Fatal("expected the confirm key to be typed rather than confirm")
+	}
+	if confirmed, _, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); confirmed {
+		t.Fatal("expected the wrong phrase to be rejected")
+	}
+	for range 3 {
+		m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	for _, r := range "prod" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if confirmed, _, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); !confirmed {
+		t.Fatal("expected the stack name to confirm")
+	}
+
+	m.Show("Confirm Action", "Are you sure you want to proceed?", "")
+	if m.RequiresPhrase() {
+		t.Error("expected showing the modal again to clear the phrase")
+	}
+}
+
 func TestConfirmModal_CustomLabels(t *testing.T) {
 	m := NewConfirmModal()
 	m.SetSize(testWidth, testHeight)
@@ -568,6 +1481,8 @@ func TestConfirmModal_BulkStateDelete(t *testing.T) {
 		"This will NOT delete the actual resources.\nThey will become unmanaged by Pulumi.",
 		resources,
 	)
+	m.SetDetails([]string{"bucket-1", "bucket-2", "bucket-3"})
+	m.ExpandDetails()
 
 	golden.RequireEqual(t, []byte(m.View()))
 }
@@ -635,7 +1550,7 @@ func TestErrorModal_LongDetails(t *testing.T) {
 func TestHistoryList_Empty(t *testing.T) {
 	h := NewHistoryList()
 	h.SetSize(testWidth, testHeight)
-	h.SetItems([]HistoryItem{})
+	h.SetItems([]HistoryItem{}, false)
 
 	golden.RequireEqual(t, []byte(h.View()))
 }
@@ -664,7 +1579,7 @@ func TestHistoryList_SingleItem(t *testing.T) {
 				"create": 5,
 			},
 		},
-	})
+	}, false)
 
 	golden.RequireEqual(t, []byte(h.View()))
 }
@@ -702,7 +1617,7 @@ func TestHistoryList_MultipleItems(t *testing.T) {
 				"create": 5,
 			},
 		},
-	})
+	}, false)
 
 	golden.RequireEqual(t, []byte(h.View()))
 }
@@ -715,7 +1630,25 @@ func TestHistoryList_DifferentKinds(t *testing.T) {
 		{Version: 3, Kind: "refresh", StartTime: "2024-01-19T10:00:00Z", Result: "succeeded"},
 		{Version: 2, Kind: "preview", StartTime: "2024-01-18T10:00:00Z", Result: "succeeded"},
 		{Version: 1, Kind: "update", StartTime: "2024-01-17T10:00:00Z", Result: "in-progress"},
-	})
+	}, false)
+
+	golden.RequireEqual(t, []byte(h.View()))
+}
+
+func TestHistoryList_LoadingMore(t *testing.T) {
+	h := NewHistoryList()
+	h.SetSize(testWidth, testHeight)
+	h.SetItems([]HistoryItem{
+		{Version: 2, Kind: "update", StartTime: "2024-01-18T10:00:00Z", Result: "succeeded"},
+		{Version: 1, Kind: "update", StartTime: "2024-01-17T10:00:00Z", Result: "succeeded"},
+	}, true)
+	if !h.NeedsMore() {
+		t.Fatal("expected the next page to be needed near the end of the list")
+	}
+	h.SetLoadingMore(true)
+	if h.NeedsMore() {
+		t.Error("expected no other page to be needed while one is loading")
+	}
 
 	golden.RequireEqual(t, []byte(h.View()))
 }
@@ -758,6 +1691,36 @@ func TestHistoryDetailPanel_WithItem(t *testing.T) {
 	golden.RequireEqual(t, []byte(d.View()))
 }
 
+func TestHistoryDetailPanel_ChangedResources(t *testing.T) {
+	d := NewHistoryDetailPanel()
+	d.SetSize(testWidth, testHeight+10)
+	d.Show()
+	d.SetItem(&HistoryItem{
+		Version:         5,
+		BackendVersion:  5,
+		Kind:            "update",
+		StartTime:       "2024-01-15T10:30:00Z",
+		Result:          "succeeded",
+		ResourceChanges: map[string]int{"create": 1, "update": 1},
+	})
+	if version, ok := d.NeedsChanges(); !ok || version != 5 {
+		t.Fatalf("expected the changes of version 5 to be needed, got %d", version)
+	}
+	d.SetChangesLoading(5)
+	if _, ok := d.NeedsChanges(); ok {
+		t.Error("expected no changes to be needed while they load")
+	}
+	d.SetChanges(5, []ResourceItem{
+		{URN: "urn:a", Type: "aws:s3/bucket:Bucket", Name: "assets", Op: OpCreate},
+		{URN: "urn:b", Type: "aws:iam/role:Role", Name: "deployer", Op: OpUpdate},
+	})
+	if !d.MoveChangeCursor(1) || d.SelectedChange().URN != "urn:b" {
+		t.Fatal("expected the second resource to be selected")
+	}
+
+	golden.RequireEqual(t, []byte(d.View()))
+}
+
 func TestHistoryDetailPanel_FailedUpdate(t *testing.T) {
 	d := NewHistoryDetailPanel()
 	d.SetSize(testWidth, testHeight)
@@ -779,45 +1742,916 @@ func TestHistoryDetailPanel_FailedUpdate(t *testing.T) {
 	golden.RequireEqual(t, []byte(d.View()))
 }
 
-func TestImportModal_Basic(t *testing.T) {
-	m := NewImportModal()
-	m.SetSize(testWidth, testHeight)
-	m.Show("aws:s3/bucket:Bucket", "my-bucket", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::my-bucket", "")
-	m.SetSuggestions([]ImportSuggestion{}) // No suggestions
+func TestHistoryDiffPanel_Loading(t *testing.T) {
+	d := NewHistoryDiffPanel()
+	d.SetSize(testWidth, testHeight)
+	d.Show()
+	d.SetLoading(4)
 
-	golden.RequireEqual(t, []byte(m.View()))
+	golden.RequireEqual(t, []byte(d.View()))
 }
 
-func TestImportModal_WithSuggestions(t *testing.T) {
-	m := NewImportModal()
-	m.SetSize(testWidth, testHeight)
-	m.Show("aws:s3/bucket:Bucket", "my-bucket", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::my-bucket", "")
-	m.SetSuggestions([]ImportSuggestion{
-		{ID: "bucket-123", Label: "bucket-123", Description: "Production bucket", PluginName: "aws"},
-		{ID: "bucket-456", Label: "bucket-456", Description: "Staging bucket", PluginName: "aws"},
-		{ID: "bucket-789", Label: "bucket-789", Description: "Dev bucket", PluginName: "aws"},
+func TestHistoryDiffPanel_WithChanges(t *testing.T) {
+	d := NewHistoryDiffPanel()
+	d.SetSize(testWidth, testHeight)
+	d.Show()
+	d.SetDiff(4, []ResourceItem{
+		{
+			URN:       "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets",
+			Type:      "aws:s3/bucket:Bucket",
+			Name:      "assets",
+			Op:        OpUpdate,
+			OldInputs: map[string]any{"acl": "private"},
+			Inputs:    map[string]any{"acl": "public-read"},
+		},
+		{
+			URN:    "urn:pulumi:dev::app::aws:sqs/queue:Queue::jobs",
+			Type:   "aws:sqs/queue:Queue",
+			Name:   "jobs",
+			Op:     OpCreate,
+			Inputs: map[string]any{"delaySeconds": 0},
+		},
 	})
 
-	golden.RequireEqual(t, []byte(m.View()))
+	golden.RequireEqual(t, []byte(d.View()))
 }
 
-func TestImportModal_Loading(t *testing.T) {
-	m := NewImportModal()
-	m.SetSize(testWidth, testHeight)
-	m.Show("aws:s3/bucket:Bucket", "my-bucket", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::my-bucket", "")
+func TestHistoryDiffPanel_NoChanges(t *testing.T) {
+	d := NewHistoryDiffPanel()
+	d.SetSize(testWidth, testHeight)
+	d.Show()
+	d.SetDiff(1, nil)
+
+	golden.RequireEqual(t, []byte(d.View()))
+}
+
+func TestHistoryDiffPanel_WithError(t *testing.T) {
+	d := NewHistoryDiffPanel()
+	d.SetSize(testWidth, testHeight)
+	d.Show()
+	d.SetLoading(7)
+	d.SetError(errors.New("export of specific versions is not supported"))
+
+	golden.RequireEqual(t, []byte(d.View()))
+}
+
+func testStackEnvironments() []*pulumi.StackEnvironment {
+	return []*pulumi.StackEnvironment{
+		{
+			Name: "aws/dev",
+			EnvironmentVariables: map[string]string{
+				"AWS_ACCESS_KEY_ID": "AKIAEXAMPLE",
+				"AWS_REGION":        "us-west-2",
+			},
+			PulumiConfig: map[string]any{
+				"aws:region": "us-west-2",
+				"app:tags":   map[string]any{"team": "infra"},
+			},
+		},
+		{Name: "shared"},
+	}
+}
+
+func TestEnvironmentsPanel_Masked(t *testing.T) {
+	p := NewEnvironmentsPanel()
+	p.SetSize(testWidth, testHeight)
+	p.Show()
+	p.SetEnvironments(testStackEnvironments())
+
+	golden.RequireEqual(t, []byte(p.View()))
+}
+
+func TestEnvironmentsPanel_Revealed(t *testing.T) {
+	p := NewEnvironmentsPanel()
+	p.SetSize(testWidth, testHeight)
+	p.Show()
+	p.SetEnvironments(testStackEnvironments())
+	p.ToggleReveal()
+
+	golden.RequireEqual(t, []byte(p.View()))
+}
+
+func TestEnvironmentsPanel_Empty(t *testing.T) {
+	p := NewEnvironmentsPanel()
+	p.SetSize(testWidth, testHeight)
+	p.Show()
+	p.SetEnvironments(nil)
+
+	golden.RequireEqual(t, []byte(p.View()))
+}
+
+func TestWarningsPanel_View(t *testing.T) {
+	p := NewWarningsPanel()
+	p.SetSize(testWidth, testHeight)
+	p.Show()
+	p.SetWarnings([]pulumi.PreviewWarning{
+		{Severity: pulumi.WarningSeverityWarning, URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Message: "aws:s3/bucket:Bucket is deprecated: use aws:s3/bucketV2:BucketV2 instead"},
+		{Severity: pulumi.WarningSeverityWarning, Message: "Attempting to deploy or update resources with 1 pending operations from previous deployment."},
+		{Severity: pulumi.WarningSeverityError, URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::data", Message: "Buckets must have encryption enabled", Policy: "security/s3-encryption"},
+	})
+	p.MoveCursor(1)
+
+	// Errors sort first, the cursor is on the first warning
+	if w := p.SelectedWarning(); w == nil || w.Message != "aws:s3/bucket:Bucket is deprecated: use aws:s3/bucketV2:BucketV2 instead" {
+		t.Fatalf("expected deprecation warning under cursor, got %+v", w)
+	}
+	golden.RequireEqual(t, []byte(p.View()))
+}
+
+func TestWarningsPanel_Empty(t *testing.T) {
+	p := NewWarningsPanel()
+	p.SetSize(testWidth, testHeight)
+	p.Show()
+	p.SetWarnings(nil)
+
+	if p.SelectedWarning() != nil {
+		t.Error("expected no selected warning")
+	}
+	golden.RequireEqual(t, []byte(p.View()))
+}
+
+func TestDiagnosticsStrip_View(t *testing.T) {
+	s := NewDiagnosticsStrip()
+	s.SetWidth(testWidth)
+	if s.Height() != 0 || s.View() != "" {
+		t.Fatal("expected an empty strip to take no space")
+	}
+	for _, d := range []pulumi.EngineDiagnostic{
+		{Severity: "debug", Message: "Registering resource monitor"},
+		{Severity: "info", Message: "Synthesizing stack\nReading config"},
+		{Severity: "warning", URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Message: "aws:s3/bucket:Bucket is deprecated"},
+		{Severity: "info#err", Message: "npm WARN deprecated glob@7.2.3"},
+		{Severity: "error", URN: "urn:pulumi:dev::app::aws:iam/role:Role::app", Message: "expected assume_role_policy to be valid JSON"},
+	} {
+		s.Add(d)
+	}
+	if s.Height() != 1 {
+		t.Errorf("expected the collapsed strip to take one line, got %d", s.Height())
+	}
+	collapsed := s.View()
+
+	s.Toggle()
+	if s.Height() != 1+diagnosticsStripLines {
+		t.Errorf("expected the expanded strip to take %d lines, got %d", 1+diagnosticsStripLines, s.Height())
+	}
+	golden.RequireEqual(t, []byte(collapsed+"\n\n"+s.View()))
+}
+
+func TestTimingsPanel_View(t *testing.T) {
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	items := []ResourceItem{
+		{URN: "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev", Type: "pulumi:pulumi:Stack", Name: "app-dev", Op: OpSame},
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Type: "aws:s3/bucket:Bucket", Name: "logs", Op: OpCreate, Status: StatusSuccess, StartedAt: start, FinishedAt: start.Add(3 * time.Second)},
+		{URN: "urn:pulumi:dev::app::aws:rds/instance:Instance::db", Type: "aws:rds/instance:Instance", Name: "db", Op: OpUpdate, Status: StatusSuccess, StartedAt: start, FinishedAt: start.Add(6*time.Minute + 12*time.Second)},
+		{URN: "urn:pulumi:dev::app::aws:sqs/queue:Queue::jobs", Type: "aws:sqs/queue:Queue", Name: "jobs", Op: OpCreate, Status: StatusFailed, StartedAt: start.Add(time.Second), FinishedAt: start.Add(1500 * time.Millisecond)},
+	}
+	if slowest := SlowestResources(items, 2); len(slowest) != 2 || slowest[0].Name != "db" || slowest[1].Name != "logs" {
+		t.Fatalf("expected db then logs as the slowest resources, got %+v", slowest)
+	}
+
+	p := NewTimingsPanel()
+	p.SetSize(testWidth, testHeight)
+	p.Show()
+	p.SetItems(items)
+	p.MoveCursor(1)
+
+	if item := p.SelectedItem(); item == nil || item.Name != "logs" {
+		t.Fatalf("expected logs under cursor, got %+v", item)
+	}
+	golden.RequireEqual(t, []byte(p.View()))
+}
+
+func TestCodePanel_View(t *testing.T) {
+	p := NewCodePanel()
+	p.SetSize(testWidth, testHeight)
+	p.Show("Generated Code for logs", "TypeScript", `import * as aws from "@pulumi/aws";
+
+const logs = new aws.s3.Bucket("logs", {
+	bucket: "logs-123",
+	acl: "private",
+}, {
+	protect: true,
+});
+`)
+	if p.LineCount() != 8 {
+		t.Errorf("expected the trailing newline to be dropped, got %d lines", p.LineCount())
+	}
+	golden.RequireEqual(t, []byte(p.View()))
+}
+
+func TestAboutPanel_View(t *testing.T) {
+	p := NewAboutPanel()
+	p.SetSize(testWidth, testHeight)
+	p.Show()
+	p.SetSections([]AboutSection{
+		{Title: "Pulumi", Fields: []AboutField{
+			{Name: "CLI", Value: "v3.150.0"},
+			{Name: "Backend", Value: "https://api.pulumi.com"},
+		}},
+		{Title: "Pulumi Plugins", Fields: []AboutField{
+			{Name: "aws", Value: "resource 6.66.0"},
+			{Name: "nodejs", Value: "language"},
+		}},
+		{Title: "p5 Plugins", Fields: []AboutField{{Value: "No plugins configured"}}},
+	})
+
+	want := "Pulumi\n  CLI:     v3.150.0\n  Backend: https://api.pulumi.com\n\n" +
+		"Pulumi Plugins\n  aws:    resource 6.66.0\n  nodejs: language\n\n" +
+		"p5 Plugins\n  No plugins configured\n"
+	if got := p.Text(); got != want {
+		t.Errorf("unexpected text:\n%s", got)
+	}
+	golden.RequireEqual(t, []byte(p.View()))
+}
+
+func TestLogViewer_View(t *testing.T) {
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	p := NewLogViewer()
+	p.SetSize(testWidth, testHeight)
+	p.Show()
+	p.SetEntries([]telemetry.LogEntry{
+		{Time: start, Level: slog.LevelDebug, Message: "loading stack", Attrs: "stack=dev"},
+		{Time: start.Add(time.Second), Level: slog.LevelInfo, Message: "preview started", Attrs: "op=up"},
+		{Time: start.Add(2 * time.Second), Level: slog.LevelWarn, Message: "plugin status badge failed", Attrs: `plugin=aws error="token expired"`},
+		{Time: start.Add(3 * time.Second), Level: slog.LevelError, Message: "operation failed", Attrs: "op=up"},
+	})
+
+	// Debug is shown at first, each cycle raises the minimum level
+	p.CycleLevel()
+	p.CycleLevel()
+	if p.MinLevel() != slog.LevelWarn || len(p.VisibleEntries()) != 2 {
+		t.Fatalf("expected the warn and error records at warn level, got %v %+v", p.MinLevel(), p.VisibleEntries())
+	}
+	golden.RequireEqual(t, []byte(p.View()))
+
+	p.CycleLevel()
+	p.CycleLevel()
+	if p.MinLevel() != slog.LevelDebug {
+		t.Errorf("expected the level to wrap back to debug, got %v", p.MinLevel())
+	}
+}
+
+func TestResourceList_Elapsed(t *testing.T) {
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	rl := NewResourceList(make(map[string]ResourceFlags))
+	rl.SetSize(testWidth, testHeight)
+	rl.SetItems([]ResourceItem{
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Type: "aws:s3/bucket:Bucket", Name: "logs", Op: OpCreate, Status: StatusSuccess, StartedAt: start, FinishedAt: start.Add(2300 * time.Millisecond)},
+		{URN: "urn:pulumi:dev::app::aws:sqs/queue:Queue::jobs", Type: "aws:sqs/queue:Queue", Name: "jobs", Op: OpCreate, Status: StatusPending},
+	})
+
+	golden.RequireEqual(t, []byte(rl.View()))
+}
+
+func TestResourceList_SelectURN(t *testing.T) {
+	rl := NewResourceList(make(map[string]ResourceFlags))
+	rl.SetSize(testWidth, testHeight)
+	rl.SetItems([]ResourceItem{
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::bucket-1", Type: "aws:s3/bucket:Bucket", Name: "bucket-1", Op: OpCreate},
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::bucket-2", Type: "aws:s3/bucket:Bucket", Name: "bucket-2", Op: OpCreate},
+	})
+
+	if !rl.SelectURN("urn:pulumi:dev::app::aws:s3/bucket:Bucket::bucket-2") {
+		t.Fatal("expected bucket-2 to be selected")
+	}
+	if item := rl.SelectedItem(); item == nil || item.Name != "bucket-2" {
+		t.Errorf("expected cursor on bucket-2, got %+v", item)
+	}
+	if rl.SelectURN("urn:pulumi:dev::app::aws:s3/bucket:Bucket::missing") {
+		t.Error("expected unknown URN not to be selected")
+	}
+}
+
+func TestResourceList_UndoFlags(t *testing.T) {
+	flags := make(map[string]ResourceFlags)
+	rl := NewResourceList(flags)
+	rl.SetSize(testWidth, testHeight)
+	rl.SetItems([]ResourceItem{
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::bucket-1", Type: "aws:s3/bucket:Bucket", Name: "bucket-1", Op: OpCreate},
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::bucket-2", Type: "aws:s3/bucket:Bucket", Name: "bucket-2", Op: OpCreate},
+	})
+	press := func(keys string) {
+		rl.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keys)})
+	}
+
+	if rl.UndoFlags() || rl.CanUndoFlags() {
+		t.Fatal("expected nothing to undo before any change")
+	}
+
+	// Target both resources with a visual range, then exclude the second one
+	press("v")
+	press("j")
+	press("T")
+	press("E")
+	if len(flags) != 2 || !flags["urn:pulumi:dev::app::aws:s3/bucket:Bucket::bucket-2"].Exclude {
+		t.Fatalf("expected bucket-2 excluded and bucket-1 targeted, got %v", flags)
+	}
+
+	if !rl.UndoFlags() {
+		t.Fatal("expected the exclude to be undone")
+	}
+	if f := flags["urn:pulumi:dev::app::aws:s3/bucket:Bucket::bucket-2"]; !f.Target || f.Exclude {
+		t.Errorf("expected bucket-2 targeted again, got %+v", f)
+	}
+	if !rl.UndoFlags() || len(flags) != 0 {
+		t.Errorf("expected the range target to be undone, got %v", flags)
+	}
+	if rl.UndoFlags() {
+		t.Error("expected nothing left to undo")
+	}
+
+	if !rl.RedoFlags() || len(flags) != 2 {
+		t.Errorf("expected the range target to be redone, got %v", flags)
+	}
+
+	// A new change drops what was undone
+	press(" ")
+	if !rl.IsDiscretelySelected("urn:pulumi:dev::app::aws:s3/bucket:Bucket::bucket-2") {
+		t.Fatal("expected bucket-2 to be selected")
+	}
+	if rl.RedoFlags() {
+		t.Error("expected nothing to redo after a new change")
+	}
+	if !rl.UndoFlags() || rl.HasDiscreteSelections() {
+		t.Error("expected the selection to be undone")
+	}
+
+	// Clearing all flags can be undone too, and switching stacks starts over
+	rl.ClearAllFlags()
+	if !rl.UndoFlags() || len(flags) != 2 {
+		t.Errorf("expected clearing all flags to be undone, got %v", flags)
+	}
+	rl.Clear()
+	if rl.CanUndoFlags() {
+		t.Error("expected no changes to undo after the list was cleared")
+	}
+}
+
+func TestResourceList_Grouping(t *testing.T) {
+	flags := make(map[string]ResourceFlags)
+	rl := NewResourceList(flags)
+	rl.SetSize(testWidth, testHeight)
+	rl.SetItems([]ResourceItem{
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::west", Type: "aws:s3/bucket:Bucket", Name: "west", Op: OpCreate,
+			Provider: "urn:pulumi:dev::app::pulumi:providers:aws::us-west::1b2c3d4e-0000-0000-0000-000000000000"},
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::east", Type: "aws:s3/bucket:Bucket", Name: "east", Op: OpSame,
+			Provider: "urn:pulumi:dev::app::pulumi:providers:aws::default_6_0_0::1b2c3d4e-0000-0000-0000-000000000001"},
+		{URN: "urn:pulumi:dev::app::aws:rds/instance:Instance::db", Type: "aws:rds/instance:Instance", Name: "db", Op: OpUpdate,
+			Provider: "urn:pulumi:dev::app::pulumi:providers:aws::default_6_0_0::1b2c3d4e-0000-0000-0000-000000000001"},
+		{URN: "urn:pulumi:dev::app::my:web:Site::site", Type: "my:web:Site", Name: "site", Op: OpSame},
+	})
+	press := func(msg tea.KeyMsg) {
+		rl.Update(msg)
+	}
+	down := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+
+	rows := func() []string {
+		var got []string
+		for pos := range rl.effectiveItemCount() {
+			if group := rl.groupAt(pos); group != nil {
+				got = append(got, "# "+group.Key)
+			} else {
+				got = append(got, rl.itemAt(pos).Name)
+			}
+		}
+		return got
+	}
+
+	rl.SetGrouping(GroupByType)
+	want := []string{"# aws:rds/instance:Instance", "db", "# aws:s3/bucket:Bucket", "east", "west", "# my:web:Site", "site"}
+	if got := rows(); !slices.Equal(got, want) {
+		t.Fatalf("expected rows grouped by type %v, got %v", want, got)
+	}
+	if rl.SelectedItem() != nil {
+		t.Error("expected no item selected on a group header")
+	}
+
+	// Flags skip group headers
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	press(down)
+	press(down)
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	if len(flags) != 1 || !flags["urn:pulumi:dev::app::aws:rds/instance:Instance::db"].Target {
+		t.Errorf("expected only db targeted, got %v", flags)
+	}
+
+	// Collapsing from an item of the group moves the cursor to its header
+	press(down)
+	press(tab)
+	want = []string{"# aws:rds/instance:Instance", "db", "# aws:s3/bucket:Bucket", "# my:web:Site", "site"}
+	if got := rows(); !slices.Equal(got, want) {
+		t.Fatalf("expected the bucket group collapsed %v, got %v", want, got)
+	}
+	if group := rl.groupAt(rl.cursor); group == nil || group.Key != "aws:s3/bucket:Bucket" || group.Summary.Total != 2 || group.Summary.Create != 1 {
+		t.Errorf("expected the cursor on the bucket header counting both buckets, got %+v", group)
+	}
+	press(tab)
+	if rl.VisibleCount() != 4 {
+		t.Errorf("expected the bucket group expanded again, got %d resources", rl.VisibleCount())
+	}
+
+	rl.SetGrouping(GroupByProvider)
+	want = []string{"# aws", "db", "east", "# aws (us-west)", "west", "# my", "site"}
+	if got := rows(); !slices.Equal(got, want) {
+		t.Fatalf("expected rows grouped by provider %v, got %v", want, got)
+	}
+
+	// Hiding unchanged resources empties groups without changes
+	rl.SetShowAllOps(false)
+	want = []string{"# aws", "db", "# aws (us-west)", "west"}
+	if got := rows(); !slices.Equal(got, want) {
+		t.Errorf("expected only changed resources %v, got %v", want, got)
+	}
+
+	rl.SetGrouping(GroupByTree)
+	if rl.VisibleCount() != 2 || rl.groupAt(0) != nil {
+		t.Errorf("expected the tree without headers, got %v", rows())
+	}
+}
+
+func TestResourceList_CollapseSubtrees(t *testing.T) {
+	const (
+		stack  = "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"
+		bundle = "urn:pulumi:dev::app::my:index:RandomBundle::bundle"
+	)
+	items := []ResourceItem{
+		{URN: stack, Type: "pulumi:pulumi:Stack", Name: "app-dev"},
+		{URN: bundle, Type: "my:index:RandomBundle", Name: "bundle", Parent: stack},
+		{URN: "urn:pulumi:dev::app::my:index:RandomBundle$random:index/randomId:RandomId::id", Type: "random:index/randomId:RandomId", Name: "id", Op: OpCreate, Parent: bundle},
+		{URN: "urn:pulumi:dev::app::my:index:RandomBundle$random:index/randomPet:RandomPet::pet", Type: "random:index/randomPet:RandomPet", Name: "pet", Op: OpUpdate, Parent: bundle},
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", Type: "aws:s3/bucket:Bucket", Name: "logs", Parent: stack},
+	}
+	rl := NewResourceList(make(map[string]ResourceFlags))
+	rl.SetSize(testWidth, testHeight)
+	rl.SetItems(items)
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+	names := func() []string {
+		var got []string
+		for pos := range rl.effectiveItemCount() {
+			got = append(got, rl.itemAt(pos).Name)
+		}
+		return got
+	}
+
+	// On a resource without children, its parent is collapsed
+	rl.SelectURN("urn:pulumi:dev::app::my:index:RandomBundle$random:index/randomPet:RandomPet::pet")
+	rl.Update(tab)
+	if got, want := names(), []string{"app-dev", "logs", "bundle"}; !slices.Equal(got, want) {
+		t.Fatalf("expected the bundle collapsed %v, got %v", want, got)
+	}
+	if item := rl.SelectedItem(); item == nil || item.URN != bundle {
+		t.Errorf("expected the cursor on the bundle, got %v", item)
+	}
+	if view := rl.View(); !strings.Contains(view, "[▸ 2 resources] +1 ~1") {
+		t.Errorf("expected the bundle to summarize its hidden children, got:\n%s", view)
+	}
+
+	// Collapsed resources stay collapsed when the view is reloaded
+	rl.SetItems(items)
+	if got := names(); len(got) != 3 {
+		t.Errorf("expected the bundle still collapsed after a reload, got %v", got)
+	}
+	rl.SelectURN(bundle)
+	rl.Update(tab)
+	if got := names(); len(got) != 5 {
+		t.Errorf("expected the bundle expanded, got %v", got)
+	}
+
+	// Collapsing all leaves the stack expanded, and the cursor moves up to a shown resource
+	rl.SelectURN("urn:pulumi:dev::app::my:index:RandomBundle$random:index/randomId:RandomId::id")
+	rl.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+	if got, want := names(), []string{"app-dev", "logs", "bundle"}; !slices.Equal(got, want) {
+		t.Errorf("expected everything but the stack collapsed %v, got %v", want, got)
+	}
+	if item := rl.SelectedItem(); item == nil || item.URN != bundle {
+		t.Errorf("expected the cursor on the bundle, got %v", item)
+	}
+	rl.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+	if got := names(); len(got) != 5 {
+		t.Errorf("expected everything expanded, got %v", got)
+	}
+}
+
+func TestResourceList_Pinned(t *testing.T) {
+	const (
+		stack = "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"
+		db    = "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+		logs  = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	)
+	flags := make(map[string]ResourceFlags)
+	pinned := map[string]bool{logs: true}
+	rl := NewResourceList(flags)
+	rl.SetSize(testWidth, testHeight)
+	rl.SetPinned(pinned)
+	rl.SetItems([]ResourceItem{
+		{URN: stack, Type: "pulumi:pulumi:Stack", Name: "app-dev", Op: OpSame},
+		{URN: db, Type: "aws:rds/instance:Instance", Name: "db", Op: OpUpdate, Parent: stack},
+		{URN: logs, Type: "aws:s3/bucket:Bucket", Name: "logs", Op: OpSame, Parent: stack},
+	})
+
+	golden.RequireEqual(t, []byte(rl.View()))
+
+	// Pinned resources are listed even when unchanged ones are hidden
+	rl.SetShowAllOps(false)
+	if item := rl.SelectedItem(); item == nil || item.URN != logs || rl.VisibleCount() != 2 {
+		t.Fatalf("expected the unchanged pinned bucket first and 2 resources, got %+v and %d", item, rl.VisibleCount())
+	}
+
+	// Flags act once on resources selected on both of their rows
+	rl.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	rl.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	rl.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	rl.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	if !flags[logs].Target || !flags[db].Target || !flags[stack].Target {
+		t.Errorf("expected every resource targeted once, got %v", flags)
+	}
+
+	// Unpinning from the pinned row drops it from the top
+	rl.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if item, ok := rl.TogglePinned(); item == nil || item.URN != logs || ok {
+		t.Fatalf("expected the bucket unpinned, got %+v, %v", item, ok)
+	}
+	if len(pinned) != 0 || rl.isPinnedRow(0) {
+		t.Errorf("expected no pinned rows left, got %v", pinned)
+	}
+}
+
+func TestResourceList_JumpTo(t *testing.T) {
+	const (
+		stack = "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"
+		cache = "urn:pulumi:dev::app::aws:elasticache/cluster:Cluster::cache"
+		db    = "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+		logs  = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+	)
+	rl := NewResourceList(make(map[string]ResourceFlags))
+	rl.SetSize(testWidth, testHeight)
+	rl.SetItems([]ResourceItem{
+		{URN: stack, Type: "pulumi:pulumi:Stack", Name: "app-dev", Op: OpSame},
+		{URN: cache, Type: "aws:elasticache/cluster:Cluster", Name: "cache", Op: OpCreate, Parent: stack},
+		{URN: db, Type: "aws:rds/instance:Instance", Name: "db", Op: OpUpdate, Parent: stack},
+		{URN: logs, Type: "aws:s3/bucket:Bucket", Name: "logs", Op: OpSame, Parent: stack},
+	})
+
+	for _, tt := range []struct{ query, want string }{
+		{"DB", db},
+		{logs, logs},
+		{"s3/bucket", logs},
+		{"cch", cache},
+	} {
+		if item := rl.JumpTo(tt.query); item == nil || item.URN != tt.want {
+			t.Errorf("JumpTo(%q) = %+v, want %s", tt.query, item, tt.want)
+		}
+	}
+	if item := rl.JumpTo("nothing-like-it"); item != nil {
+		t.Errorf("expected no match, got %+v", item)
+	}
+	if item := rl.SelectedItem(); item == nil || item.URN != cache {
+		t.Errorf("expected the cursor to stay put without a match, got %+v", item)
+	}
+
+	// Change jumps skip unchanged resources and stop at either end
+	rl.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	var got []string
+	for rl.JumpToChange(1) {
+		got = append(got, rl.SelectedItem().Name)
+	}
+	for rl.JumpToChange(-1) {
+		got = append(got, rl.SelectedItem().Name)
+	}
+	if want := []string{"cache", "db", "cache"}; !slices.Equal(got, want) {
+		t.Errorf("expected jumps through %v, got %v", want, got)
+	}
+}
+
+func TestResourceList_ReplaceKeys(t *testing.T) {
+	const db = "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+	rl := NewResourceList(make(map[string]ResourceFlags))
+	rl.SetSize(testWidth, testHeight)
+
+	// Each step of a replace carries the reasons; the first ones are kept
+	rl.AddItem(ResourceItem{URN: db, Type: "aws:rds/instance:Instance", Name: "db", Op: OpCreateReplace, ReplaceKeys: []string{"availabilityZone"}})
+	rl.AddItem(ResourceItem{URN: db, Type: "aws:rds/instance:Instance", Name: "db", Op: OpDeleteReplace})
+	if item := rl.SelectedItem(); item == nil || !slices.Equal(item.ReplaceKeys, []string{"availabilityZone"}) {
+		t.Fatalf("expected the replace reasons kept, got %+v", item)
+	}
+	if view := rl.View(); !strings.Contains(view, "[replace: availabilityZone]") {
+		t.Errorf("expected the row to name the replace reason, got:\n%s", view)
+	}
+}
+
+func TestDashboard_View(t *testing.T) {
+	d := NewDashboard()
+	d.SetSize(testWidth, testHeight)
+	d.Show()
+	d.SetClosable(true)
+	d.SetRows([]DashboardRow{
+		{Workspace: ".", Project: "app", Stack: "dev", Current: true, LastKind: "update", LastResult: "succeeded", LastTime: "2024-01-15T10:30:00Z", ResourceCount: 12, Drift: DriftNone},
+		{Workspace: ".", Project: "app", Stack: "prod", LastKind: "refresh", LastResult: "succeeded", LastTime: "2024-01-14T09:00:00Z", ResourceCount: 30, Drift: DriftDetected},
+		{Workspace: "net", Project: "network", Stack: "dev"},
+		{Workspace: "dns", Project: "dns", Err: errors.New("no credentials")},
+	})
+
+	golden.RequireEqual(t, []byte(d.View()))
+}
+
+func TestDashboard_Empty(t *testing.T) {
+	d := NewDashboard()
+	d.SetSize(testWidth, testHeight)
+	d.Show()
+	d.SetRows(nil)
+
+	golden.RequireEqual(t, []byte(d.View()))
+}
+
+func TestImportModal_Basic(t *testing.T) {
+	m := NewImportModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("aws:s3/bucket:Bucket", "my-bucket", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::my-bucket", "")
+	m.SetSuggestions([]ImportSuggestion{}) // No suggestions
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestImportModal_WithSuggestions(t *testing.T) {
+	m := NewImportModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("aws:s3/bucket:Bucket", "my-bucket", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::my-bucket", "")
+	m.SetSuggestions([]ImportSuggestion{
+		{ID: "bucket-123", Label: "bucket-123", Description: "Production bucket", PluginName: "aws"},
+		{ID: "bucket-456", Label: "bucket-456", Description: "Staging bucket", PluginName: "aws"},
+		{ID: "bucket-789", Label: "bucket-789", Description: "Dev bucket", PluginName: "aws"},
+	})
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestImportModal_Loading(t *testing.T) {
+	m := NewImportModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("aws:s3/bucket:Bucket", "my-bucket", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::my-bucket", "")
 	m.SetLoadingSuggestions(true)
 
 	golden.RequireEqual(t, []byte(m.View()))
 }
 
-func TestImportModal_WithError(t *testing.T) {
-	m := NewImportModal()
+func TestImportModal_WithError(t *testing.T) {
+	m := NewImportModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("aws:s3/bucket:Bucket", "my-bucket", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::my-bucket", "")
+	m.SetSuggestions([]ImportSuggestion{})
+	m.SetError(errors.New("invalid import ID format"))
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestImportModal_InvalidID(t *testing.T) {
+	m := NewImportModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("aws:s3/bucket:Bucket", "my-bucket", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::my-bucket", "")
+	m.SetSuggestions([]ImportSuggestion{})
+	for _, r := range "arn:aws:s3:::missing" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m.SetValidation(&ImportIDValidation{Message: "bucket missing does not exist", PluginName: "aws"})
+
+	// A rejected ID can't be confirmed until it changes
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != ImportActionNone || !m.Visible() {
+		t.Fatal("expected enter to keep the modal open for a rejected ID")
+	}
+	golden.RequireEqual(t, []byte(m.View()))
+
+	m.SetValidation(&ImportIDValidation{Valid: true, Message: "found in us-east-1", PluginName: "aws"})
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != ImportActionPreview {
+		t.Error("expected enter to preview an accepted ID")
+	}
+}
+
+// TestImportModal_Preview verifies the import is previewed before it can be
+// confirmed, and that changing the ID discards the preview
+func TestImportModal_Preview(t *testing.T) {
+	m := NewImportModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("aws:s3/bucket:Bucket", "my-bucket", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::my-bucket", "")
+	m.SetSuggestions([]ImportSuggestion{})
+	for _, r := range "logs-123" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != ImportActionPreview {
+		t.Fatalf("expected enter to preview the import, got %v", action)
+	}
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != ImportActionNone {
+		t.Errorf("expected enter to wait for the preview, got %v", action)
+	}
+
+	lines := []string{`acl          : "private"`, `bucket       : "logs-123"`, "forceDestroy : false", "tags         : {"}
+	for i := range 8 {
+		lines = append(lines, fmt.Sprintf(`    tag%d: "value"`, i))
+	}
+	m.SetPreview(append(lines, "}"), nil)
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	golden.RequireEqual(t, []byte(m.View()))
+
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != ImportActionConfirm || m.Visible() {
+		t.Errorf("expected enter to confirm the previewed import, got %v", action)
+	}
+
+	m.Show("aws:s3/bucket:Bucket", "my-bucket", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::my-bucket", "")
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.SetPreview(nil, errors.New("import preview failed: resource 'x' does not exist"))
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != ImportActionPreview {
+		t.Errorf("expected enter to retry a failed preview, got %v", action)
+	}
+	m.SetPreview(nil, nil)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if m.Previewed() {
+		t.Error("expected changing the ID to discard the preview")
+	}
+}
+
+func TestNoteModal_Basic(t *testing.T) {
+	m := NewNoteModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("aws:rds/instance:Instance", "db", "urn:pulumi:dev::app::aws:rds/instance:Instance::db", "manually resized, do not replace")
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestLockScreen_Locked(t *testing.T) {
+	l := NewLockScreen()
+	l.SetSize(testWidth, testHeight)
+	l.Lock("prod", false)
+
+	golden.RequireEqual(t, []byte(l.View()))
+}
+
+func TestLockScreen_WrongPassphrase(t *testing.T) {
+	l := NewLockScreen()
+	l.SetSize(testWidth, testHeight)
+	l.Lock("prod", true)
+
+	// The first key asks for the passphrase
+	if submitted, _ := l.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}); submitted {
+		t.Fatal("expected the first key to show the passphrase prompt")
+	}
+	l.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("guess")})
+	if submitted, _ := l.Update(tea.KeyMsg{Type: tea.KeyEnter}); !submitted || l.Passphrase() != "guess" {
+		t.Fatalf("expected the passphrase to be submitted, got %q", l.Passphrase())
+	}
+	l.SetError("Wrong passphrase")
+
+	golden.RequireEqual(t, []byte(l.View()))
+}
+
+func TestBulkImportModal_Loading(t *testing.T) {
+	m := NewBulkImportModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("kubernetes:apps/v1:Deployment")
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestBulkImportModal_WithItems(t *testing.T) {
+	m := NewBulkImportModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("kubernetes:apps/v1:Deployment")
+	m.AppendItems([]BulkImportItem{
+		{ID: "default/api", Name: "api", Label: "api", Description: "Namespace: default", PluginName: "kubernetes", InProgram: true},
+		{ID: "default/web", Name: "web", Label: "web", Description: "Namespace: default", PluginName: "kubernetes"},
+		{ID: "jobs/worker", Name: "worker", Label: "worker", Description: "Namespace: jobs", PluginName: "kubernetes"},
+	}, true)
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+// TestBulkImportModal_Selection verifies selection, select all and load more requests.
+func TestBulkImportModal_Selection(t *testing.T) {
+	m := NewBulkImportModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("kubernetes:apps/v1:Deployment")
+
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != BulkImportActionNone {
+		t.Errorf("expected enter without selection to do nothing, got %v", action)
+	}
+
+	m.AppendItems([]BulkImportItem{{ID: "a", Name: "a"}, {ID: "b", Name: "b"}}, true)
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown}); action != BulkImportActionLoadMore {
+		t.Errorf("expected moving to the last row to request more, got %v", action)
+	}
+	m.SetLoadingMore()
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown}); action != BulkImportActionNone {
+		t.Errorf("expected no second request while loading, got %v", action)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if got := len(m.SelectedItems()); got != 2 {
+		t.Fatalf("expected 2 selected after select all, got %d", got)
+	}
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != BulkImportActionConfirm {
+		t.Errorf("expected confirm, got %v", action)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if got := len(m.SelectedItems()); got != 0 {
+		t.Errorf("expected select all to clear a full selection, got %d", got)
+	}
+}
+
+func testStateIssues() []pulumi.StateIssue {
+	return []pulumi.StateIssue{
+		{Kind: pulumi.StateIssueDuplicateURN, URN: "urn:pulumi:dev::app::random:index/randomId:RandomId::id", Type: "random:index/randomId:RandomId", Name: "id", Copies: 2},
+		{Kind: pulumi.StateIssueOrphanedChild, URN: "urn:pulumi:dev::app::my:Component$random:index/randomString:RandomString::suffix", Type: "random:index/randomString:RandomString", Name: "suffix", Reference: "urn:pulumi:dev::app::my:Component::web"},
+		{Kind: pulumi.StateIssueMissingProvider, URN: "urn:pulumi:dev::app::kubernetes:core/v1:Namespace::apps", Type: "kubernetes:core/v1:Namespace", Name: "apps", Reference: "urn:pulumi:dev::app::pulumi:providers:kubernetes::k8s"},
+	}
+}
+
+func TestStateRepairModal_Issues(t *testing.T) {
+	m := NewStateRepairModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show(testStateIssues(), "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev")
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestStateRepairModal_Report(t *testing.T) {
+	m := NewStateRepairModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show(testStateIssues(), "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev")
+	m.SetReport(&pulumi.StateRepairReport{
+		DryRun: true,
+		Changes: []pulumi.StateChange{
+			{Action: pulumi.StateFixDelete, URN: "urn:pulumi:dev::app::random:index/randomId:RandomId::id", Removed: 1},
+			{Action: pulumi.StateFixReparent, URN: "urn:pulumi:dev::app::my:Component$random:index/randomString:RandomString::suffix", NewParent: "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"},
+		},
+		Dangling: []pulumi.DanglingReference{
+			{URN: "urn:pulumi:dev::app::kubernetes:core/v1:Namespace::apps", Field: "provider", Reference: "urn:pulumi:dev::app::pulumi:providers:kubernetes::k8s"},
+		},
+		ResourcesBefore: 5,
+		ResourcesAfter:  4,
+	})
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+// TestStateRepairModal_Fixes verifies fix cycling and the dry run and apply steps.
+func TestStateRepairModal_Fixes(t *testing.T) {
+	const stackURN = "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"
+	m := NewStateRepairModal()
 	m.SetSize(testWidth, testHeight)
-	m.Show("aws:s3/bucket:Bucket", "my-bucket", "urn:pulumi:dev::app::aws:s3/bucket:Bucket::my-bucket", "")
-	m.SetSuggestions([]ImportSuggestion{})
-	m.SetError(errors.New("invalid import ID format"))
+	m.Show(testStateIssues(), stackURN)
 
-	golden.RequireEqual(t, []byte(m.View()))
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != StateRepairActionNone {
+		t.Errorf("expected enter with every issue skipped to do nothing, got %v", action)
+	}
+
+	// Orphans cycle re-parent -> delete -> skip
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(space)
+	if fixes := m.Fixes(); len(fixes) != 1 || fixes[0].Action != pulumi.StateFixReparent || fixes[0].NewParent != stackURN {
+		t.Fatalf("expected re-parent to stack, got %+v", fixes)
+	}
+	m.Update(space)
+	if fixes := m.Fixes(); len(fixes) != 1 || fixes[0].Action != pulumi.StateFixDelete {
+		t.Fatalf("expected delete, got %+v", fixes)
+	}
+	m.Update(space)
+	if fixes := m.Fixes(); len(fixes) != 0 {
+		t.Fatalf("expected orphan to be skipped again, got %+v", fixes)
+	}
+
+	// Duplicates can only be deleted
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.Update(space)
+	m.Update(space)
+	if fixes := m.Fixes(); len(fixes) != 0 {
+		t.Fatalf("expected duplicate to cycle back to skip, got %+v", fixes)
+	}
+	m.Update(space)
+
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != StateRepairActionDryRun {
+		t.Fatalf("expected dry run, got %v", action)
+	}
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != StateRepairActionNone {
+		t.Errorf("expected keys to be ignored while loading, got %v", action)
+	}
+
+	m.SetReport(&pulumi.StateRepairReport{DryRun: true, Changes: []pulumi.StateChange{{Action: pulumi.StateFixDelete}}})
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Report() != nil || !m.Visible() {
+		t.Fatal("expected esc to return from the report to the issue list")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.SetReport(&pulumi.StateRepairReport{DryRun: true, Changes: []pulumi.StateChange{{Action: pulumi.StateFixDelete}}})
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != StateRepairActionApply {
+		t.Errorf("expected apply, got %v", action)
+	}
 }
 
 // testSelectorItem implements SelectorItem for testing
@@ -905,6 +2739,47 @@ func TestStackSelector_WithStacks(t *testing.T) {
 	golden.RequireEqual(t, []byte(s.View()))
 }
 
+func TestStackSelector_LastResults(t *testing.T) {
+	s := NewStackSelector()
+	s.SetSize(testWidth, testHeight)
+	s.Show()
+	s.SetStacks([]StackItem{
+		{Name: "dev", Current: true},
+		{Name: "staging"},
+		{Name: "production"},
+		{Name: "sandbox", Source: StackSourceFile},
+	})
+	s.SetLastResults(map[string]string{"dev": "succeeded", "staging": "failed", "production": "in-progress"})
+
+	golden.RequireEqual(t, []byte(s.View()))
+}
+
+func TestCloudStackBrowser_View(t *testing.T) {
+	b := NewCloudStackBrowser()
+	b.SetSize(testWidth, testHeight)
+	b.Show()
+	b.SetStacks([]CloudStackItem{
+		{Name: "acme/api/dev", Org: "acme", Project: "api", Stack: "dev", UpdateInProgress: true},
+		{Name: "acme/api/prod", Org: "acme", Project: "api", Stack: "prod", LastUpdate: "2024-01-15T10:00:00.000Z", ResourceCount: 42, Current: true},
+		{Name: "acme/web/preview", Org: "acme", Project: "web", Stack: "preview"},
+	})
+
+	golden.RequireEqual(t, []byte(b.View()))
+}
+
+func TestTargetSetSelector_View(t *testing.T) {
+	s := NewTargetSetSelector()
+	s.SetSize(testWidth, testHeight)
+	s.SetShowNewOption(true)
+	s.Show()
+	s.SetTargetSets([]TargetSetItem{
+		{Name: "database", Replaces: 1},
+		{Name: "frontend-only", Targets: 3, Excludes: 1},
+	})
+
+	golden.RequireEqual(t, []byte(s.View()))
+}
+
 func TestStackSelector_NoNewOption(t *testing.T) {
 	s := NewStackSelector()
 	s.SetSize(testWidth, testHeight)
@@ -940,6 +2815,46 @@ func TestWorkspaceSelector_WithWorkspaces(t *testing.T) {
 	golden.RequireEqual(t, []byte(s.View()))
 }
 
+func TestWorkspaceSelector_Recent(t *testing.T) {
+	s := NewWorkspaceSelector()
+	s.SetSize(testWidth, testHeight)
+	s.Show()
+	s.SetWorkspaces([]WorkspaceItem{
+		{Name: "infra", Path: "/home/user/infra", RelativePath: "../infra", Section: "Recent", Pinned: true, LastStack: "prod"},
+		{Name: "my-app", Path: "/home/user/projects/my-app", RelativePath: "./my-app", Current: true, Section: "Recent", LastStack: "dev"},
+		{Name: "another-app", Path: "/home/user/projects/another-app", RelativePath: "./another-app", Section: "Workspaces"},
+	})
+
+	golden.RequireEqual(t, []byte(s.View()))
+}
+
+func TestWorkspaceSelector_Groups(t *testing.T) {
+	s := NewWorkspaceSelector()
+	s.SetSize(testWidth, testHeight)
+	s.Show()
+	s.SetWorkspaces([]WorkspaceItem{
+		{Name: "network", Path: "/repo/platform/network", RelativePath: "platform/network", Section: "platform"},
+		{Name: "dns", Path: "/repo/platform/dns", RelativePath: "platform/dns", Section: "platform"},
+		{Name: "web", Path: "/repo/apps/web", RelativePath: "apps/web", Section: "apps", Current: true},
+	})
+
+	// Collapse the platform group from its header
+	s.Update(tea.KeyMsg{Type: tea.KeyHome})
+	if _, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || !s.Visible() || s.SelectedWorkspace() != nil {
+		t.Fatal("expected enter on a header to collapse it without selecting")
+	}
+	golden.RequireEqual(t, []byte(s.View()))
+
+	// The filter searches collapsed groups too
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range "pfdns" {
+		s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if ws := s.SelectedWorkspace(); ws == nil || ws.Name != "dns" {
+		t.Errorf("expected the fuzzy filter to find dns in the collapsed group, got %+v", ws)
+	}
+}
+
 func TestStepModal_SingleStep(t *testing.T) {
 	m := NewStepModal("Configure")
 	m.SetSize(testWidth, testHeight)
@@ -1074,6 +2989,222 @@ func TestStackInitModal_WithStackFiles(t *testing.T) {
 	golden.RequireEqual(t, []byte(m.View()))
 }
 
+// TestStackInitModal_Wizard verifies the secrets provider is validated, steps
+// that don't apply are skipped, and config values are collected
+func TestStackInitModal_Wizard(t *testing.T) {
+	m := NewStackInitModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show()
+	m.SetStackFiles([]pulumi.StackFileInfo{
+		{Name: "dev", SecretCount: 2},
+		{Name: "prod"},
+	})
+
+	typeText := func(text string) {
+		for _, r := range text {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+	enter := func() StepModalAction {
+		action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		return action
+	}
+
+	typeText("staging")
+	enter()
+
+	// An incomplete key URL stays in the input for editing
+	typeText("gcpkms://")
+	if enter() != StepModalActionNone || m.CurrentStep() != stepSecretsProvider {
+		t.Fatal("expected an incomplete gcpkms URL to be rejected")
+	}
+	if !strings.Contains(m.View(), "gcpkms URL must look like") {
+		t.Error("expected the validation error to be shown")
+	}
+	typeText("projects/p/locations/global/keyRings/r/cryptoKeys/k")
+	if enter() != StepModalActionNext {
+		t.Fatal("expected a complete gcpkms URL to be accepted")
+	}
+
+	// The passphrase step is skipped for KMS providers
+	if m.CurrentStep() != stepCopyConfig {
+		t.Fatalf("expected the copy config step, got step %d", m.CurrentStep())
+	}
+	golden.RequireEqual(t, []byte(m.View()))
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	enter()
+	if m.CurrentStep() != stepConfig || m.GetCopyConfigFrom() != "dev" {
+		t.Fatalf("expected config to be copied from dev, got %q", m.GetCopyConfigFrom())
+	}
+
+	typeText("region")
+	enter()
+	if m.GetConfig() != nil || !strings.Contains(m.View(), "expected key=value") {
+		t.Error("expected a value without = to be rejected")
+	}
+	m.SetResult(stepConfig, "")
+	m.input.SetValue("")
+	typeText("aws:region=us-west-2")
+	enter()
+	if got := m.GetConfig(); got["aws:region"] != "us-west-2" {
+		t.Errorf("expected the config value to be added, got %v", got)
+	}
+	if enter() != StepModalActionConfirm {
+		t.Error("expected an empty enter to create the stack")
+	}
+	if m.GetPassphrase() != "" || m.GetSecretsProvider() != "gcpkms://projects/p/locations/global/keyRings/r/cryptoKeys/k" {
+		t.Errorf("unexpected provider %q", m.GetSecretsProvider())
+	}
+
+	// Going back skips the passphrase step too
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.CurrentStep() != stepCopyConfig {
+		t.Fatalf("expected to go back to copying config, got step %d", m.CurrentStep())
+	}
+	m.input.SetValue("")
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.CurrentStep() != stepSecretsProvider {
+		t.Errorf("expected to go back to the secrets provider, got step %d", m.CurrentStep())
+	}
+}
+
+func TestNewProjectModal_Initial(t *testing.T) {
+	m := NewNewProjectModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("/home/user/projects")
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+// TestNewProjectModal_Wizard verifies project names and directories are validated
+// and the directory is resolved against the base directory
+func TestNewProjectModal_Wizard(t *testing.T) {
+	base := t.TempDir()
+	if err := os.WriteFile(filepath.Join(base, "README.md"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m := NewNewProjectModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show(base)
+
+	typeText := func(text string) {
+		for _, r := range text {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+	enter := func() StepModalAction {
+		action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		return action
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	enter()
+	if m.CurrentStep() != stepProjectName || m.Options().Template != "python" {
+		t.Fatalf("expected the python template to be picked, got %q", m.Options().Template)
+	}
+
+	typeText("my app")
+	if enter() != StepModalActionNone || !strings.Contains(m.View(), "project names may only contain") {
+		t.Fatal("expected a project name with a space to be rejected")
+	}
+	m.input.SetValue("")
+	typeText("infra")
+	enter()
+
+	// The base directory isn't empty, so only a new directory is suggested
+	if !strings.Contains(m.View(), filepath.Join(base, "infra")) {
+		t.Errorf("expected the project directory to be suggested:\n%s", m.View())
+	}
+	typeText(".")
+	if enter() != StepModalActionNone || !strings.Contains(m.View(), "is not empty") {
+		t.Fatal("expected a directory that isn't empty to be rejected")
+	}
+	m.input.SetValue("")
+	typeText("stacks/infra")
+	enter()
+	if m.Dir() != filepath.Join(base, "stacks", "infra") {
+		t.Errorf("expected the directory to be resolved against the base, got %q", m.Dir())
+	}
+
+	if enter() != StepModalActionConfirm {
+		t.Fatal("expected the default stack to create the project")
+	}
+	if got := m.Options(); got.Name != "infra" || got.StackName != "dev" {
+		t.Errorf("unexpected options %+v", got)
+	}
+}
+
+func TestConfigCopyModal(t *testing.T) {
+	m := NewConfigCopyModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("staging")
+	m.SetStackFiles([]pulumi.StackFileInfo{
+		{Name: "dev", SecretCount: 2},
+		{Name: "prod"},
+		{Name: "staging"},
+	})
+	golden.RequireEqual(t, []byte(m.View()))
+
+	for _, r := range "qa" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != StepModalActionNone {
+		t.Fatal("expected a stack without a stack file to be rejected")
+	}
+	if !strings.Contains(m.View(), "No Pulumi.qa.yaml to copy config from") {
+		t.Error("expected the validation error to be shown")
+	}
+
+	for range "qa" {
+		m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != StepModalActionConfirm || m.Source() != "prod" {
+		t.Errorf("expected config to be copied from prod, got %q", m.Source())
+	}
+}
+
+func TestUpdateMessageModal(t *testing.T) {
+	m := NewUpdateMessageModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show(OperationUp, "prod")
+	golden.RequireEqual(t, []byte(m.View()))
+
+	for _, r := range "rotate keys" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != StepModalActionConfirm || m.Message() != "rotate keys" {
+		t.Errorf("expected the message to be entered, got %q", m.Message())
+	}
+
+	m.Show(OperationDestroy, "prod")
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != StepModalActionConfirm || m.Message() != "" {
+		t.Errorf("expected an empty message to be allowed, got %q", m.Message())
+	}
+}
+
+func TestGitGuardModal(t *testing.T) {
+	m := NewGitGuardModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("prod", &GitInfo{Branch: "feature", Commit: "1a2b3c4", Dirty: true}, []string{
+		"The working tree has uncommitted changes",
+		"Deploying from feature instead of main",
+	})
+	golden.RequireEqual(t, []byte(m.View()))
+
+	for _, r := range "pro" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action == StepModalActionConfirm {
+		t.Error("expected a partial stack name to be rejected")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != StepModalActionConfirm {
+		t.Error("expected the stack name to confirm")
+	}
+}
+
 // errTest is a simple test error
 type testError struct{}
 
@@ -1264,7 +3395,7 @@ func TestHistoryList_Filter(t *testing.T) {
 		{Version: 2, Kind: "preview", StartTime: "2024-01-16T10:00:00Z", Result: "succeeded", User: "admin"},
 		{Version: 3, Kind: "update", StartTime: "2024-01-17T10:00:00Z", Result: "failed", User: "dev"},
 		{Version: 4, Kind: "destroy", StartTime: "2024-01-18T10:00:00Z", Result: "succeeded", User: "admin"},
-	})
+	}, false)
 
 	// Simulate pressing "/" to activate filter
 	h.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
@@ -1277,6 +3408,70 @@ func TestHistoryList_Filter(t *testing.T) {
 	golden.RequireEqual(t, []byte(h.View()))
 }
 
+// TestHistoryList_HistoryFilter verifies kind, result, user and date filters
+// compose with the text filter
+func TestHistoryList_HistoryFilter(t *testing.T) {
+	h := NewHistoryList()
+	h.SetSize(testWidth, testHeight)
+	h.SetItems([]HistoryItem{
+		{Version: 4, Kind: "destroy", StartTime: "2024-02-18T10:00:00Z", Result: "succeeded", User: "admin"},
+		{Version: 3, Kind: "update", StartTime: "2024-01-17T10:00:00Z", Result: "failed", User: "dev", Message: "fix bucket"},
+		{Version: 2, Kind: "update", StartTime: "2024-01-16T10:00:00Z", Result: "succeeded", User: "admin", Message: "fix bucket"},
+		{Version: 1, Kind: "update", StartTime: "2024-01-15T10:00:00Z", Result: "succeeded", User: "dev"},
+	}, false)
+	if users := h.Users(); !slices.Equal(users, []string{"admin", "dev"}) {
+		t.Errorf("expected users admin and dev, got %v", users)
+	}
+
+	h.SetHistoryFilter(HistoryFilter{Kinds: []string{"update"}, Results: []string{"succeeded"}, To: "2024-01-31"})
+	if h.effectiveItemCount() != 2 {
+		t.Fatalf("expected 2 matches, got %d", h.effectiveItemCount())
+	}
+	h.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	for _, char := range "fix" {
+		h.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{char}})
+	}
+	if item := h.SelectedItem(); h.effectiveItemCount() != 1 || item == nil || item.Version != 2 {
+		t.Fatalf("expected only update #2 to match, got %d matches", h.effectiveItemCount())
+	}
+
+	golden.RequireEqual(t, []byte(h.View()))
+}
+
+func TestHistoryFilterModal_View(t *testing.T) {
+	m := NewHistoryFilterModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show(HistoryFilter{Results: []string{"failed"}, From: "2024-01-01"}, []string{"admin", "dev"})
+
+	// Toggle the "preview" chip and select the first user
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+	want := HistoryFilter{Kinds: []string{"preview"}, Results: []string{"failed"}, User: "admin", From: "2024-01-01"}
+	if got := m.Filter(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestHistoryFilterModal_InvalidDate(t *testing.T) {
+	m := NewHistoryFilterModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show(HistoryFilter{From: "2024-02-01", To: "2024-01-01"}, nil)
+
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != HistoryFilterActionNone {
+		t.Fatal("expected a date range ending before it starts not to be applied")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != HistoryFilterActionApply || m.Filter().Active() {
+		t.Error("expected the reset filter to be applied")
+	}
+}
+
 func TestResourceList_DiscreteSelect_Toggle(t *testing.T) {
 	flags := make(map[string]ResourceFlags)
 	rl := NewResourceList(flags)
@@ -1940,3 +4135,182 @@ func TestResourceList_GetSelectedResourcesForStateDelete_Union(t *testing.T) {
 		t.Errorf("expected bucket-1, bucket-3, bucket-4, got %v", names)
 	}
 }
+
+func TestResourceList_GetSelectedResourcesForProtect_VisualMode(t *testing.T) {
+	flags := make(map[string]ResourceFlags)
+	rl := NewResourceList(flags)
+	rl.SetSize(testWidth, testHeight)
+	rl.SetItems([]ResourceItem{
+		{URN: "urn:pulumi:dev::app::pulumi:pulumi:Stack::my-stack", Type: "pulumi:pulumi:Stack", Name: "my-stack", Op: OpSame},
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::bucket-1", Type: "aws:s3/bucket:Bucket", Name: "bucket-1", Op: OpSame},
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::bucket-2", Type: "aws:s3/bucket:Bucket", Name: "bucket-2", Op: OpSame, Protected: true},
+		{URN: "urn:pulumi:dev::app::aws:s3/bucket:Bucket::bucket-3", Type: "aws:s3/bucket:Bucket", Name: "bucket-3", Op: OpSame},
+	})
+
+	// Select everything from the stack resource to bucket-3
+	rl.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	rl.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+
+	// Protecting skips the stack resource and the already protected bucket
+	toProtect := rl.GetSelectedResourcesForProtect(true)
+	if len(toProtect) != 2 || toProtect[0].Name != "bucket-1" || toProtect[1].Name != "bucket-3" {
+		t.Errorf("expected bucket-1 and bucket-3 to protect, got %v", toProtect)
+	}
+
+	// Unprotecting only includes the protected bucket
+	toUnprotect := rl.GetSelectedResourcesForProtect(false)
+	if len(toUnprotect) != 1 || toUnprotect[0].Name != "bucket-2" {
+		t.Errorf("expected bucket-2 to unprotect, got %v", toUnprotect)
+	}
+}
+
+func TestPluginIndexModal_WithEntries(t *testing.T) {
+	m := NewPluginIndexModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show()
+	m.SetEntries([]PluginIndexEntry{
+		{Name: "vault", Description: "Reads short-lived credentials from HashiCorp Vault before each operation", Package: "example.com/p5-vault", Capabilities: []string{"auth"}, Installed: true},
+		{Name: "sops", Description: "Decrypts SOPS files into the stack environment", Package: "example.com/p5-sops", Homepage: "https://example.com/p5-sops", Capabilities: []string{"auth", "import_helper"}},
+	})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestPluginStatusModal_WithEntries(t *testing.T) {
+	issued := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	m := NewPluginStatusModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show([]PluginStatusEntry{
+		{Name: "env", EnvVars: []string{"API_URL"}, IssuedAt: issued},
+		{Name: "vault", EnvVars: []string{"VAULT_TOKEN"}, IssuedAt: issued, AlwaysCall: true},
+		{Name: "aws", Error: "sso session expired"},
+		{Name: "sops"},
+	})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestPluginAuthState_Expiry(t *testing.T) {
+	now := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		entry PluginStatusEntry
+		want  string
+	}{
+		{"expiring", PluginStatusEntry{IssuedAt: now, ExpiresAt: now.Add(42*time.Minute + 10*time.Second)}, "expires in 42m"},
+		{"hours left", PluginStatusEntry{IssuedAt: now, ExpiresAt: now.Add(90 * time.Minute)}, "expires in 1h30m"},
+		{"refresh failed", PluginStatusEntry{IssuedAt: now, ExpiresAt: now.Add(30 * time.Second), Error: "timeout"}, "refresh failed"},
+		{"expired", PluginStatusEntry{IssuedAt: now.Add(-time.Hour), ExpiresAt: now}, "expired"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := pluginAuthState(tc.entry, now); !strings.Contains(got, tc.want) {
+				t.Errorf("pluginAuthState() = %q, want it to contain %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTagsModal_WithTags(t *testing.T) {
+	m := NewTagsModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("dev")
+	m.SetTags(map[string]string{
+		"pulumi:project": "app",
+		"owner":          "platform",
+		"cost-center":    "1234",
+	})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestTagsModal_Adding(t *testing.T) {
+	m := NewTagsModal()
+	m.SetSize(testWidth, testHeight)
+	m.Show("dev")
+	m.SetTags(map[string]string{"owner": "platform"})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("environment")})
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != TagsActionNone {
+		t.Fatalf("expected a tag without a value to be rejected, got %v", action)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("=staging")})
+	if action, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); action != TagsActionSet {
+		t.Fatalf("expected the tag to be set, got %v", action)
+	}
+	if tag := m.PendingTag(); tag.Key != "environment" || tag.Value != "staging" {
+		t.Fatalf("expected environment=staging, got %+v", tag)
+	}
+	m.SetTag("environment", "staging")
+
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+// TestFuzzyScore verifies fuzzy matching requires the characters in order and prefers
+// tight matches at word starts
+func TestFuzzyScore(t *testing.T) {
+	if _, ok := fuzzyScore("pfdns", "platform/dns"); !ok {
+		t.Error("expected characters in order to match")
+	}
+	if _, ok := fuzzyScore("snd", "platform/dns"); ok {
+		t.Error("expected characters out of order not to match")
+	}
+	if score, ok := fuzzyScore("", "anything"); !ok || score != 0 {
+		t.Errorf("expected an empty pattern to match with no score, got %d, %v", score, ok)
+	}
+
+	tight, _ := fuzzyScore("bucket", "aws:s3/bucket:Bucket")
+	loose, _ := fuzzyScore("bucket", "bigUsersCacheKeyExport")
+	scattered, _ := fuzzyScore("bkt", "bookkeepingtool")
+	wordStarts, _ := fuzzyScore("bkt", "BucketKeyTimeout")
+	if tight <= loose || wordStarts <= scattered {
+		t.Errorf("expected tight and word start matches to score higher, got %d/%d and %d/%d", tight, loose, wordStarts, scattered)
+	}
+	if upper, _ := fuzzyScore("DNS", "platform/dns"); upper == 0 {
+		t.Error("expected matching to ignore case")
+	}
+}
+
+func TestFilterState_FuzzyRanking(t *testing.T) {
+	SetFuzzyFilters(true)
+	t.Cleanup(func() { SetFuzzyFilters(false) })
+
+	s := NewSelectorDialog[testSelectorItem]("Select Item")
+	s.SetSize(testWidth, testHeight)
+	s.Show()
+	s.SetItems([]testSelectorItem{
+		{name: "prod-db"},
+		{name: "table"},
+		{name: "pdb-cache"},
+	})
+	s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	for _, char := range "pdb" {
+		s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{char}})
+	}
+	if s.effectiveItemCount() != 2 {
+		t.Fatalf("expected 2 fuzzy matches, got %d", s.effectiveItemCount())
+	}
+	if item := s.SelectedItem(); item == nil || item.name != "pdb-cache" {
+		t.Errorf("expected the best match to be listed first and selected, got %v", item)
+	}
+
+	h := NewHistoryList()
+	h.SetSize(testWidth, testHeight)
+	h.SetItems([]HistoryItem{
+		{Version: 2, Kind: "update", Message: "rotate keys", User: "dev"},
+		{Version: 1, Kind: "update", Message: "revoke token", User: "dev"},
+	}, false)
+	h.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	for _, char := range "rt" {
+		h.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{char}})
+	}
+	if item := h.SelectedItem(); item == nil || item.Version != 1 {
+		t.Errorf("expected the tighter history match first, got %v", item)
+	}
+}
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// UpdateMessageModal wraps StepModal to ask for the message recorded with an up or
+// destroy in the stack's history
+type UpdateMessageModal struct {
+	*StepModal
+}
+
+// NewUpdateMessageModal creates a new update message modal
+func NewUpdateMessageModal() *UpdateMessageModal {
+	return &UpdateMessageModal{StepModal: NewStepModal(i18n.T("Update Message"))}
+}
+
+// Show prompts for the message of the operation about to run on stackName
+func (m *UpdateMessageModal) Show(operation OperationType, stackName string) {
+	m.SetSteps([]StepModalStep{{
+		Title: i18n.T("Describe this update"),
+		InfoLines: []InfoLine{
+			{Label: i18n.T("Stack"), Value: stackName},
+			{Label: i18n.T("Operation"), Value: operation.String()},
+		},
+		InputLabel:       i18n.T("Message"),
+		InputPlaceholder: i18n.T("Why is this update being run? (optional)"),
+		Optional:         true,
+	}})
+	m.StepModal.Show()
+}
+
+// Message returns the message entered, or "" to run without one
+func (m *UpdateMessageModal) Message() string {
+	return m.GetResult(0)
+}
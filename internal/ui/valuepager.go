@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+)
+
+// LargeValue is a string property too large to show in full in diffs, like an
+// IAM policy or a kubeconfig
+type LargeValue struct {
+	Path   string // Property path, like policy or spec.template
+	Value  string
+	Before bool // Value the change replaces or deletes
+}
+
+// Label implements SelectorItem
+func (v LargeValue) Label() string {
+	if v.Before {
+		return i18n.Tf("%s (before)", v.Path)
+	}
+	return v.Path
+}
+
+// IsCurrent implements SelectorItem
+func (v LargeValue) IsCurrent() bool {
+	return false
+}
+
+// Text returns the value to page through, with JSON documents indented
+func (v LargeValue) Text() string {
+	if _, ok := parseJSONDocument(v.Value); ok {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, []byte(strings.TrimSpace(v.Value)), "", "  "); err == nil {
+			return buf.String()
+		}
+	}
+	return v.Value
+}
+
+// LargeValues returns the large values of a resource, sorted by path. Values the
+// change replaces or deletes follow the value after the change.
+func LargeValues(resource *ResourceItem) []LargeValue {
+	if resource == nil {
+		return nil
+	}
+	state := getDiffStateForOperation(resource)
+	after := make(map[string]string)
+	collectLargeValues(after, state.newInputs, state.newOutputs)
+	before := make(map[string]string)
+	collectLargeValues(before, state.oldInputs, state.oldOutputs)
+
+	var values []LargeValue
+	for path, value := range after {
+		values = append(values, LargeValue{Path: path, Value: value})
+	}
+	for path, value := range before {
+		if v, ok := after[path]; !ok || v != value {
+			values = append(values, LargeValue{Path: path, Value: value, Before: true})
+		}
+	}
+	slices.SortFunc(values, func(a, b LargeValue) int {
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		if a.Before == b.Before {
+			return 0
+		}
+		if a.Before {
+			return 1
+		}
+		return -1
+	})
+	return values
+}
+
+// collectLargeValues adds the large strings in properties to values by path,
+// keeping the first of a path found in several property maps
+func collectLargeValues(values map[string]string, properties ...map[string]any) {
+	for _, props := range properties {
+		for key, value := range props {
+			if strings.HasPrefix(key, "__") {
+				continue
+			}
+			collectLargeValue(values, key, value)
+		}
+	}
+}
+
+func collectLargeValue(values map[string]string, path string, value any) {
+	if pulumi.IsSecret(value) {
+		return
+	}
+	switch v := value.(type) {
+	case string:
+		if _, ok := values[path]; !ok && isLargeValue(v) {
+			values[path] = v
+		}
+	case map[string]any:
+		for key, child := range v {
+			collectLargeValue(values, path+"."+key, child)
+		}
+	case []any:
+		for i, child := range v {
+			collectLargeValue(values, fmt.Sprintf("%s[%d]", path, i), child)
+		}
+	}
+}
+
+// isLargeValue returns whether a string is truncated with a marker in diffs
+func isLargeValue(s string) bool {
+	return len(s) >= LargeValueLength
+}
+
+// formatLargeValue formats the first line of a large string, truncated to maxLen,
+// followed by how many lines, or characters of a single line, were left out
+func formatLargeValue(s string, style lipgloss.Style, maxLen int) string {
+	s = strings.TrimRight(s, "\n")
+	first, rest, multiline := strings.Cut(s, "\n")
+	shown := first
+	if len(shown) > maxLen-3 {
+		shown = shown[:max(maxLen-3, 0)]
+	}
+	marker := i18n.Tf("[+%d chars]", len(first)-len(shown))
+	if multiline {
+		marker = i18n.Tf("[+%d lines]", strings.Count(rest, "\n")+1)
+	}
+	return style.Render(fmt.Sprintf("%q...", shown)) + " " + DimStyle.Render(marker)
+}
+
+// ValueSelector is a modal dialog for choosing the large value of a resource to
+// view in the pager
+type ValueSelector struct {
+	*SelectorDialog[LargeValue]
+}
+
+// NewValueSelector creates a new value selector
+func NewValueSelector() *ValueSelector {
+	dialog := NewSelectorDialog[LargeValue](i18n.T("View Value"))
+	dialog.SetEmptyText(i18n.T("No large values"))
+	return &ValueSelector{SelectorDialog: dialog}
+}
+
+// ShowValues lists the large values of a resource
+func (s *ValueSelector) ShowValues(values []LargeValue) {
+	s.SetItems(values)
+	s.Show()
+}
+
+// Update handles key events and returns true if a value was selected
+func (s *ValueSelector) Update(msg tea.KeyMsg) (selected bool, cmd tea.Cmd) {
+	return s.SelectorDialog.Update(msg)
+}
+
+// View renders the value selector dialog
+func (s *ValueSelector) View() string {
+	return s.SelectorDialog.View()
+}
+
+// ValuePager is a floating panel for scrolling through a large value in full
+type ValuePager struct {
+	PanelBase // Embed common panel functionality
+
+	title  string
+	text   string
+	format valueFormat // Format the value is highlighted as
+}
+
+// NewValuePager creates a new value pager component
+func NewValuePager() *ValuePager {
+	return &ValuePager{}
+}
+
+// Show shows a value of the resource named name, scrolled to the top
+func (p *ValuePager) Show(name string, value LargeValue) {
+	p.title = name + DimStyle.Render("  ·  "+value.Label())
+	p.text = strings.TrimRight(value.Text(), "\n")
+	p.format = formatPlain
+	if syntaxHighlighting {
+		p.format = detectValueFormat(p.text)
+	}
+	p.ResetScroll()
+	p.PanelBase.Show()
+}
+
+// Text returns the shown value
+func (p *ValuePager) Text() string {
+	return p.text
+}
+
+// LineCount returns the number of lines shown, with long lines wrapped
+func (p *ValuePager) LineCount() int {
+	return len(p.lines())
+}
+
+// lines returns the highlighted lines of the value, wrapped to the width inside
+// the border(2) and padding(4)
+func (p *ValuePager) lines() []string {
+	width := max(p.Width()-6, 20)
+	var lines []string
+	for _, line := range strings.Split(p.text, "\n") {
+		line = highlightLine(strings.ReplaceAll(line, "\t", "    "), p.format)
+		wrapped := ansi.Hardwrap(line, width, false)
+		lines = append(lines, strings.Split(wrapped, "\n")...)
+	}
+	return lines
+}
+
+// Scroll moves the view by delta lines
+func (p *ValuePager) Scroll(delta int) {
+	p.scrollLines(delta, p.LineCount())
+}
+
+// View renders the value pager
+func (p *ValuePager) View() string {
+	if !p.Visible() || p.Width() == 0 || p.Height() == 0 {
+		return ""
+	}
+
+	lines := p.lines()
+
+	header := p.title
+	header += DimStyle.Render(fmt.Sprintf("  ·  %s  ·  y %s  p $PAGER", i18n.Tf("%d lines", strings.Count(p.text, "\n")+1), i18n.T("copy")))
+	result := RenderDetailPanel(DetailPanelContent{
+		Header:       header,
+		Content:      strings.Join(lines, "\n"),
+		Width:        p.Width(),
+		Height:       p.Height(),
+		ScrollOffset: p.ScrollOffset(),
+	})
+	if result.NewScrollOffset != p.ScrollOffset() {
+		p.SetScrollOffset(result.NewScrollOffset)
+	}
+
+	return result.Rendered
+}
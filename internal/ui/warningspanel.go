@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rfhold/p5/internal/i18n"
+	"github.com/rfhold/p5/internal/pulumi"
+)
+
+// WarningsPanel is a floating panel listing the warnings and errors reported
+// during the last preview, most severe first, with a cursor to jump to the
+// resource a warning is about
+type WarningsPanel struct {
+	PanelBase // Embed common panel functionality
+
+	warnings []pulumi.PreviewWarning
+	cursor   int
+}
+
+// NewWarningsPanel creates a new warnings panel component
+func NewWarningsPanel() *WarningsPanel {
+	return &WarningsPanel{}
+}
+
+// SetWarnings sets the warnings to list, ordered by severity
+func (p *WarningsPanel) SetWarnings(warnings []pulumi.PreviewWarning) {
+	p.warnings = slices.Clone(warnings)
+	slices.SortStableFunc(p.warnings, func(a, b pulumi.PreviewWarning) int {
+		return cmp.Compare(b.Severity, a.Severity)
+	})
+	p.cursor = 0
+	p.ResetScroll()
+}
+
+// Warnings returns the listed warnings in display order
+func (p *WarningsPanel) Warnings() []pulumi.PreviewWarning {
+	return p.warnings
+}
+
+// SelectedWarning returns the warning under the cursor, or nil if there are none
+func (p *WarningsPanel) SelectedWarning() *pulumi.PreviewWarning {
+	if p.cursor >= len(p.warnings) {
+		return nil
+	}
+	return &p.warnings[p.cursor]
+}
+
+// MoveCursor moves the cursor by delta warnings
+func (p *WarningsPanel) MoveCursor(delta int) {
+	p.cursor = MoveCursor(p.cursor, delta, len(p.warnings))
+}
+
+// View renders the warnings panel
+func (p *WarningsPanel) View() string {
+	if !p.Visible() || p.Width() == 0 || p.Height() == 0 {
+		return ""
+	}
+
+	var content string
+	if len(p.warnings) == 0 {
+		content = DimStyle.Render(i18n.T("No warnings reported by the last preview"))
+	} else {
+		content = p.renderContent()
+	}
+
+	result := RenderDetailPanel(DetailPanelContent{
+		Header:       i18n.T("Preview Warnings"),
+		Content:      content,
+		Width:        p.Width(),
+		Height:       p.Height(),
+		ScrollOffset: p.ScrollOffset(),
+	})
+
+	if result.NewScrollOffset != p.ScrollOffset() {
+		p.SetScrollOffset(result.NewScrollOffset)
+	}
+
+	return result.Rendered
+}
+
+// renderContent renders the severity counts and each warning, keeping the
+// warning under the cursor in view
+func (p *WarningsPanel) renderContent() string {
+	var errors int
+	for _, w := range p.warnings {
+		if w.Severity == pulumi.WarningSeverityError {
+			errors++
+		}
+	}
+
+	lines := []string{
+		DimStyle.Render(i18n.Tf("%d errors, %d warnings", errors, len(p.warnings)-errors) + "  ·  enter " + i18n.T("jump to resource")),
+	}
+
+	// Content width inside border(2) and padding(4), less the message indent
+	wrapWidth := max(p.Width()-6-4, 20)
+	cursorStart, cursorEnd := 0, 0
+	for i, w := range p.warnings {
+		lines = append(lines, "")
+		if i == p.cursor {
+			cursorStart = len(lines)
+		}
+
+		var line strings.Builder
+		if i == p.cursor {
+			line.WriteString(CursorStyle.Render("> "))
+		} else {
+			line.WriteString("  ")
+		}
+		line.WriteString(severityTag(w.Severity))
+		line.WriteString(" ")
+		switch {
+		case w.URN != "":
+			line.WriteString(ValueStyle.Render(pulumi.ExtractResourceName(w.URN)))
+		default:
+			line.WriteString(DimStyle.Render(i18n.T("stack")))
+		}
+		if w.Policy != "" {
+			line.WriteString(DimStyle.Render(" [" + w.Policy + "]"))
+		}
+		lines = append(lines, line.String())
+
+		wrapped := lipgloss.NewStyle().Width(wrapWidth).Render(w.Message)
+		for _, msgLine := range strings.Split(wrapped, "\n") {
+			lines = append(lines, "    "+strings.TrimRight(msgLine, " "))
+		}
+		if i == p.cursor {
+			cursorEnd = len(lines) - 1
+		}
+	}
+
+	contentHeight := p.contentHeight()
+	offset := p.ScrollOffset()
+	if cursorEnd >= offset+contentHeight {
+		offset = cursorEnd - contentHeight + 1
+	}
+	if cursorStart < offset {
+		offset = cursorStart
+	}
+	if p.cursor == 0 {
+		offset = 0
+	}
+	p.SetScrollOffset(offset)
+
+	return strings.Join(lines, "\n")
+}
+
+// severityTag renders the label for a warning severity
+func severityTag(severity pulumi.WarningSeverity) string {
+	if severity == pulumi.WarningSeverityError {
+		return ErrorStyle.Render("[" + i18n.T("error") + "]")
+	}
+	return WarningStyle.Render("[" + i18n.T("warning") + "]")
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
+)
+
+// WorkflowItem represents a workflow from p5.toml in the selector
+type WorkflowItem struct {
+	Name        string
+	Description string
+	Steps       string // Summary of the steps, e.g. "Refresh → Preview Up → Up"
+}
+
+// Label implements SelectorItem
+func (w WorkflowItem) Label() string {
+	return w.Name
+}
+
+// IsCurrent implements SelectorItem
+func (w WorkflowItem) IsCurrent() bool {
+	return false
+}
+
+// WorkflowSelector is a modal dialog for choosing a workflow to run
+type WorkflowSelector struct {
+	*SelectorDialog[WorkflowItem]
+}
+
+// NewWorkflowSelector creates a new workflow selector
+func NewWorkflowSelector() *WorkflowSelector {
+	dialog := NewSelectorDialog[WorkflowItem](i18n.T("Run Workflow"))
+	dialog.SetLoadingText(i18n.T("Loading workflows..."))
+	dialog.SetEmptyText(i18n.T("No workflows defined in p5.toml"))
+
+	// Show the description, or the steps when there is none
+	dialog.SetExtraInfoRenderer(func(item WorkflowItem) string {
+		if item.Description != "" {
+			return DimStyle.Render("  " + item.Description)
+		}
+		return DimStyle.Render("  " + item.Steps)
+	})
+
+	return &WorkflowSelector{
+		SelectorDialog: dialog,
+	}
+}
+
+// SetWorkflows sets the list of available workflows
+func (s *WorkflowSelector) SetWorkflows(workflows []WorkflowItem) {
+	s.SetItems(workflows)
+}
+
+// SelectedWorkflow returns the name of the selected workflow, or empty if none
+func (s *WorkflowSelector) SelectedWorkflow() string {
+	item := s.SelectedItem()
+	if item == nil {
+		return ""
+	}
+	return item.Name
+}
+
+// Update handles key events and returns true if a workflow was selected
+func (s *WorkflowSelector) Update(msg tea.KeyMsg) (selected bool, cmd tea.Cmd) {
+	return s.SelectorDialog.Update(msg)
+}
+
+// View renders the workflow selector dialog
+func (s *WorkflowSelector) View() string {
+	return s.SelectorDialog.View()
+}
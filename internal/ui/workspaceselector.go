@@ -1,15 +1,26 @@
 package ui
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rfhold/p5/internal/i18n"
 )
 
-// WorkspaceItem represents a workspace in the selector
+// WorkspaceItem represents a workspace in the selector, or the header of a group
+// of workspaces
 type WorkspaceItem struct {
 	Path         string
 	RelativePath string // Path relative to current working directory
 	Name         string
 	Current      bool
+	Section      string // Group the workspace is listed under, e.g. "Recent"
+	Pinned       bool   // Pinned by the user, so it is always listed as recent
+	LastStack    string // Stack last opened in the workspace, if recent
+	Header       bool   // Header of the Section group rather than a workspace
+	Count        int    // Number of workspaces in the group, for headers
+	IsNewItem    bool   // Special flag for the "new project" option
 }
 
 // Label implements SelectorItem
@@ -22,42 +33,137 @@ func (w WorkspaceItem) IsCurrent() bool {
 	return w.Current
 }
 
-// WorkspaceSelector is a modal dialog for selecting a workspace
+// WorkspaceSelector is a modal dialog for selecting a workspace. Workspaces are
+// listed under a header for each group, which collapses and expands with enter.
+// The filter searches all groups, collapsed or not. A "new project" option after
+// the workspaces scaffolds one from a template.
 type WorkspaceSelector struct {
 	*SelectorDialog[WorkspaceItem]
+	collapsed     map[string]bool // Collapsed groups by name
+	showNewOption bool
 }
 
 // NewWorkspaceSelector creates a new workspace selector
 func NewWorkspaceSelector() *WorkspaceSelector {
-	dialog := NewSelectorDialog[WorkspaceItem]("Select Workspace")
-	dialog.SetLoadingText("Searching for Pulumi projects...")
-	dialog.SetEmptyText("No Pulumi projects found")
+	dialog := NewSelectorDialog[WorkspaceItem](i18n.T("Select Workspace"))
+	dialog.SetLoadingText(i18n.T("Searching for Pulumi projects..."))
+	dialog.SetEmptyText(i18n.T("No Pulumi projects found"))
+	dialog.SetFuzzyFilter(true)
+	s := &WorkspaceSelector{
+		SelectorDialog: dialog,
+		collapsed:      make(map[string]bool),
+		showNewOption:  true, // Show "new project" option by default
+	}
 
-	// Custom extra info renderer to show path after name
+	dialog.SetItemRenderer(func(item WorkspaceItem, isCursor bool) string {
+		if item.Header {
+			return s.renderHeader(item, isCursor)
+		}
+		if item.IsNewItem {
+			return renderNewItem(item.Name, isCursor)
+		}
+		return dialog.defaultRenderItem(item, isCursor)
+	})
+	// Custom extra info renderer to show the pin, last stack and path after name
 	dialog.SetExtraInfoRenderer(func(item WorkspaceItem) string {
+		if item.IsNewItem {
+			return ""
+		}
+		var extra string
+		if item.Pinned {
+			extra += WarningStyle.Render(" ★")
+		}
+		if item.LastStack != "" {
+			extra += DimStyle.Render(" [" + item.LastStack + "]")
+		}
 		if item.Current {
-			return "" // Don't show path for current item (already shows "(current)")
+			return extra // Don't show path for current item (already shows "(current)")
 		}
 		displayPath := item.RelativePath
 		if displayPath == "" {
 			displayPath = item.Path
 		}
-		return DimStyle.Render(" " + displayPath)
+		return extra + DimStyle.Render(" "+displayPath)
+	})
+	dialog.SetHiddenFunc(func(item WorkspaceItem, filtering bool) bool {
+		if filtering {
+			return item.Header || item.IsNewItem
+		}
+		return !item.Header && s.collapsed[item.Section]
+	})
+	dialog.SetFilterTexts(func(item WorkspaceItem) []string {
+		return []string{item.Name, item.RelativePath, item.Section}
+	})
+	dialog.SetExtraHint(func() string {
+		return Keys.PinWorkspace.Help().Key + " pin"
 	})
 
-	return &WorkspaceSelector{
-		SelectorDialog: dialog,
+	return s
+}
+
+// renderHeader renders a group header with its fold marker and, when collapsed,
+// the number of workspaces in it
+func (s *WorkspaceSelector) renderHeader(item WorkspaceItem, isCursor bool) string {
+	cursor := "  "
+	if isCursor {
+		cursor = CursorStyle.Render("> ")
 	}
+	if s.collapsed[item.Section] {
+		return cursor + LabelStyle.Render("▸ "+item.Section) + DimStyle.Render(fmt.Sprintf(" (%d)", item.Count))
+	}
+	return cursor + LabelStyle.Render("▾ "+item.Section)
+}
+
+// SetShowNewOption controls whether the "new project" option is shown
+func (s *WorkspaceSelector) SetShowNewOption(show bool) {
+	s.showNewOption = show
 }
 
-// SetWorkspaces sets the list of available workspaces
+// SetWorkspaces sets the list of available workspaces, adding a header above
+// each group of workspaces with the same Section
 func (s *WorkspaceSelector) SetWorkspaces(workspaces []WorkspaceItem) {
-	s.SetItems(workspaces)
+	items := make([]WorkspaceItem, 0, len(workspaces)+1)
+	header := -1
+	for _, w := range workspaces {
+		if w.Section != "" && (header < 0 || items[header].Section != w.Section) {
+			header = len(items)
+			items = append(items, WorkspaceItem{Name: w.Section, Section: w.Section, Header: true})
+		}
+		if header >= 0 && items[header].Section == w.Section {
+			items[header].Count++
+		}
+		items = append(items, w)
+	}
+	if s.showNewOption {
+		items = append(items, WorkspaceItem{Name: "+ New Project", IsNewItem: true})
+	}
+	s.SetItems(items)
 }
 
-// SelectedWorkspace returns the currently selected workspace
+// SelectedWorkspace returns the currently selected workspace, or nil when the
+// cursor is on a group header or the "new project" option
 func (s *WorkspaceSelector) SelectedWorkspace() *WorkspaceItem {
-	return s.SelectedItem()
+	item := s.SelectedItem()
+	if item == nil || item.Header || item.IsNewItem {
+		return nil
+	}
+	return item
+}
+
+// IsNewProjectSelected returns true if the "new project" option is selected
+func (s *WorkspaceSelector) IsNewProjectSelected() bool {
+	item := s.SelectedItem()
+	return item != nil && item.IsNewItem
+}
+
+// SelectPath moves the cursor to the first workspace at path, if listed
+func (s *WorkspaceSelector) SelectPath(path string) {
+	for i, item := range s.items {
+		if !item.Header && !item.IsNewItem && item.Path == path {
+			s.SelectIndex(i)
+			return
+		}
+	}
 }
 
 // HasWorkspaces returns whether any workspaces are available
@@ -65,8 +171,16 @@ func (s *WorkspaceSelector) HasWorkspaces() bool {
 	return s.HasItems()
 }
 
-// Update handles key events and returns true if a workspace was selected
+// Update handles key events and returns true if a workspace was selected. Enter on
+// a group header collapses or expands the group.
 func (s *WorkspaceSelector) Update(msg tea.KeyMsg) (selected bool, cmd tea.Cmd) {
+	if msg.String() == "enter" && !s.FilterActive() {
+		if item := s.SelectedItem(); item != nil && item.Header {
+			s.collapsed[item.Section] = !s.collapsed[item.Section]
+			s.Relist()
+			return false, nil
+		}
+	}
 	return s.SelectorDialog.Update(msg)
 }
 
@@ -12,6 +12,8 @@ import (
 	goplugin "github.com/hashicorp/go-plugin"
 	"github.com/rfhold/p5/internal/plugins/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Re-export proto types for plugin authors
@@ -26,6 +28,16 @@ type (
 	ImportSuggestionsResponse = proto.ImportSuggestionsResponse
 	// ImportSuggestion represents a single import suggestion
 	ImportSuggestion = proto.ImportSuggestion
+	// ListImportableResourcesRequest is the request sent to the ListImportableResources RPC
+	ListImportableResourcesRequest = proto.ListImportableResourcesRequest
+	// ListImportableResourcesResponse is the response from the ListImportableResources RPC
+	ListImportableResourcesResponse = proto.ListImportableResourcesResponse
+	// ImportableResource represents an existing resource that can be bulk imported
+	ImportableResource = proto.ImportableResource
+	// ValidateImportIdRequest is the request sent to the ValidateImportId RPC
+	ValidateImportIdRequest = proto.ValidateImportIdRequest
+	// ValidateImportIdResponse is the response from the ValidateImportId RPC
+	ValidateImportIdResponse = proto.ValidateImportIdResponse
 	// SupportedOpenTypesRequest is the request sent to the GetSupportedOpenTypes RPC
 	SupportedOpenTypesRequest = proto.SupportedOpenTypesRequest
 	// SupportedOpenTypesResponse is the response from the GetSupportedOpenTypes RPC
@@ -38,6 +50,22 @@ type (
 	OpenAction = proto.OpenAction
 	// OpenActionType is the type of open action
 	OpenActionType = proto.OpenActionType
+	// StatusBadgeRequest is the request sent to the GetStatusBadge RPC
+	StatusBadgeRequest = proto.StatusBadgeRequest
+	// StatusBadgeResponse is the response from the GetStatusBadge RPC
+	StatusBadgeResponse = proto.StatusBadgeResponse
+	// StatusBadgeLevel is how prominently a status badge is shown
+	StatusBadgeLevel = proto.StatusBadgeLevel
+)
+
+// Status badge levels
+const (
+	// StatusBadgeLevelInfo shows the badge like the rest of the header
+	StatusBadgeLevelInfo = proto.StatusBadgeLevel_STATUS_BADGE_LEVEL_INFO
+	// StatusBadgeLevelWarning highlights the badge, e.g. for a shared environment
+	StatusBadgeLevelWarning = proto.StatusBadgeLevel_STATUS_BADGE_LEVEL_WARNING
+	// StatusBadgeLevelDanger makes the badge stand out, e.g. for production
+	StatusBadgeLevelDanger = proto.StatusBadgeLevel_STATUS_BADGE_LEVEL_DANGER
 )
 
 // AuthPlugin is the interface that plugins must implement.
@@ -55,6 +83,23 @@ type ImportHelperPlugin interface {
 	GetImportSuggestions(ctx context.Context, req *ImportSuggestionsRequest) (*ImportSuggestionsResponse, error)
 }
 
+// ImportableResourceLister is an optional interface that import helper plugins can
+// implement to list existing resources for bulk import.
+type ImportableResourceLister interface {
+	// ListImportableResources returns a page of existing resources of the requested type.
+	// Plugins should return CanProvide: false if they don't handle the resource type.
+	ListImportableResources(ctx context.Context, req *ListImportableResourcesRequest) (*ListImportableResourcesResponse, error)
+}
+
+// ImportIDValidator is an optional interface that import helper plugins can
+// implement to check import IDs before an import runs.
+type ImportIDValidator interface {
+	// ValidateImportId checks the format of an import ID, and optionally that the
+	// resource exists. Plugins should return CanValidate: false if they don't handle
+	// the resource type.
+	ValidateImportId(ctx context.Context, req *ValidateImportIdRequest) (*ValidateImportIdResponse, error)
+}
+
 // ResourceOpenerPlugin is an optional interface that plugins can implement
 // to provide resource opening capabilities (browser URLs or alternate screen programs).
 type ResourceOpenerPlugin interface {
@@ -65,6 +110,14 @@ type ResourceOpenerPlugin interface {
 	OpenResource(ctx context.Context, req *OpenResourceRequest) (*OpenResourceResponse, error)
 }
 
+// StatusBadgePlugin is an optional interface that plugins can implement to show
+// a short status in the header, such as the cloud account or cluster in use.
+type StatusBadgePlugin interface {
+	// GetStatusBadge returns the badge to show. It is polled periodically, so it
+	// should be fast. An empty text hides the badge.
+	GetStatusBadge(ctx context.Context, req *StatusBadgeRequest) (*StatusBadgeResponse, error)
+}
+
 // Handshake is the handshake config for plugins.
 // Both the host and plugin must agree on this configuration.
 // This is the canonical definition - do not duplicate elsewhere.
@@ -80,6 +133,7 @@ var PluginMap = map[string]goplugin.Plugin{
 	"auth":            &AuthPluginGRPC{},
 	"import_helper":   &ImportHelperPluginGRPC{},
 	"resource_opener": &ResourceOpenerPluginGRPC{},
+	"status_badge":    &StatusBadgePluginGRPC{},
 }
 
 // SuccessResponse creates a successful authentication response.
@@ -131,6 +185,71 @@ func NewImportSuggestion(id, label, description string) *ImportSuggestion {
 	}
 }
 
+// ListImportableResourcesNotSupported returns a response indicating the plugin doesn't handle this resource type.
+func ListImportableResourcesNotSupported() *ListImportableResourcesResponse {
+	return &ListImportableResourcesResponse{CanProvide: false}
+}
+
+// ListImportableResourcesSuccess creates a successful importable resources response.
+// nextPageToken is empty when there are no more pages.
+func ListImportableResourcesSuccess(resources []*ImportableResource, nextPageToken string) *ListImportableResourcesResponse {
+	return &ListImportableResourcesResponse{
+		CanProvide:    true,
+		Resources:     resources,
+		NextPageToken: nextPageToken,
+	}
+}
+
+// ListImportableResourcesError creates an error importable resources response.
+func ListImportableResourcesError(format string, args ...any) *ListImportableResourcesResponse {
+	return &ListImportableResourcesResponse{
+		CanProvide: true, // We can provide, but encountered an error
+		Error:      fmt.Sprintf(format, args...),
+	}
+}
+
+// ValidateImportIdNotSupported returns a response indicating the plugin doesn't handle this resource type.
+func ValidateImportIdNotSupported() *ValidateImportIdResponse {
+	return &ValidateImportIdResponse{CanValidate: false}
+}
+
+// ValidImportId creates a response accepting the import ID, with an optional note
+// shown next to it (e.g., where the resource was found).
+func ValidImportId(message string) *ValidateImportIdResponse {
+	return &ValidateImportIdResponse{
+		CanValidate: true,
+		Valid:       true,
+		Message:     message,
+	}
+}
+
+// InvalidImportId creates a response rejecting the import ID with the reason.
+func InvalidImportId(format string, args ...any) *ValidateImportIdResponse {
+	return &ValidateImportIdResponse{
+		CanValidate: true,
+		Message:     fmt.Sprintf(format, args...),
+	}
+}
+
+// ValidateImportIdError creates a response for a check that could not be completed.
+// The import ID is neither accepted nor rejected.
+func ValidateImportIdError(format string, args ...any) *ValidateImportIdResponse {
+	return &ValidateImportIdResponse{
+		CanValidate: true, // We can validate, but encountered an error
+		Error:       fmt.Sprintf(format, args...),
+	}
+}
+
+// NewImportableResource creates a new importable resource.
+func NewImportableResource(id, name, label, description string) *ImportableResource {
+	return &ImportableResource{
+		Id:          id,
+		Name:        name,
+		Label:       label,
+		Description: description,
+	}
+}
+
 // OpenNotSupported returns a response indicating the plugin doesn't handle this resource type.
 func OpenNotSupported() *OpenResourceResponse {
 	return &OpenResourceResponse{CanOpen: false}
@@ -175,6 +294,21 @@ func SupportedOpenTypesPatterns(patterns ...string) *SupportedOpenTypesResponse
 	}
 }
 
+// NewStatusBadge creates a status badge response shown at the given level.
+func NewStatusBadge(text string, level StatusBadgeLevel) *StatusBadgeResponse {
+	return &StatusBadgeResponse{
+		Text:  text,
+		Level: level,
+	}
+}
+
+// StatusBadgeError creates an error status badge response.
+func StatusBadgeError(format string, args ...any) *StatusBadgeResponse {
+	return &StatusBadgeResponse{
+		Error: fmt.Sprintf(format, args...),
+	}
+}
+
 // Serve starts the plugin server with the given implementation.
 // This should be called from the plugin's main() function.
 //
@@ -194,6 +328,11 @@ func Serve(impl AuthPlugin) {
 		plugins["resource_opener"] = &ResourceOpenerPluginGRPC{Impl: resourceOpener}
 	}
 
+	// If the plugin also implements StatusBadgePlugin, register it
+	if statusBadge, ok := impl.(StatusBadgePlugin); ok {
+		plugins["status_badge"] = &StatusBadgePluginGRPC{Impl: statusBadge}
+	}
+
 	goplugin.Serve(&goplugin.ServeConfig{
 		HandshakeConfig: Handshake,
 		Plugins:         plugins,
@@ -268,6 +407,26 @@ func (c *ImportHelperGRPCClient) GetImportSuggestions(ctx context.Context, req *
 	return c.client.GetImportSuggestions(ctx, req)
 }
 
+// ListImportableResources calls the plugin's ListImportableResources RPC.
+// Plugins built before the RPC existed are reported as not supporting it.
+func (c *ImportHelperGRPCClient) ListImportableResources(ctx context.Context, req *ListImportableResourcesRequest) (*ListImportableResourcesResponse, error) {
+	resp, err := c.client.ListImportableResources(ctx, req)
+	if status.Code(err) == codes.Unimplemented {
+		return ListImportableResourcesNotSupported(), nil
+	}
+	return resp, err
+}
+
+// ValidateImportId calls the plugin's ValidateImportId RPC.
+// Plugins built before the RPC existed are reported as not supporting it.
+func (c *ImportHelperGRPCClient) ValidateImportId(ctx context.Context, req *ValidateImportIdRequest) (*ValidateImportIdResponse, error) {
+	resp, err := c.client.ValidateImportId(ctx, req)
+	if status.Code(err) == codes.Unimplemented {
+		return ValidateImportIdNotSupported(), nil
+	}
+	return resp, err
+}
+
 // ImportHelperGRPCServer is the server-side implementation that wraps the actual plugin
 type ImportHelperGRPCServer struct {
 	proto.UnimplementedImportHelperPluginServer
@@ -279,6 +438,24 @@ func (s *ImportHelperGRPCServer) GetImportSuggestions(ctx context.Context, req *
 	return s.Impl.GetImportSuggestions(ctx, req)
 }
 
+// ListImportableResources handles the ListImportableResources RPC
+func (s *ImportHelperGRPCServer) ListImportableResources(ctx context.Context, req *ListImportableResourcesRequest) (*ListImportableResourcesResponse, error) {
+	lister, ok := s.Impl.(ImportableResourceLister)
+	if !ok {
+		return ListImportableResourcesNotSupported(), nil
+	}
+	return lister.ListImportableResources(ctx, req)
+}
+
+// ValidateImportId handles the ValidateImportId RPC
+func (s *ImportHelperGRPCServer) ValidateImportId(ctx context.Context, req *ValidateImportIdRequest) (*ValidateImportIdResponse, error) {
+	validator, ok := s.Impl.(ImportIDValidator)
+	if !ok {
+		return ValidateImportIdNotSupported(), nil
+	}
+	return validator.ValidateImportId(ctx, req)
+}
+
 // ResourceOpenerPluginGRPC is the implementation of goplugin.GRPCPlugin for ResourceOpenerPlugin
 type ResourceOpenerPluginGRPC struct {
 	goplugin.Plugin
@@ -327,3 +504,47 @@ func (s *ResourceOpenerGRPCServer) GetSupportedOpenTypes(ctx context.Context, re
 func (s *ResourceOpenerGRPCServer) OpenResource(ctx context.Context, req *OpenResourceRequest) (*OpenResourceResponse, error) {
 	return s.Impl.OpenResource(ctx, req)
 }
+
+// StatusBadgePluginGRPC is the implementation of goplugin.GRPCPlugin for StatusBadgePlugin
+type StatusBadgePluginGRPC struct {
+	goplugin.Plugin
+	// Impl is the actual plugin implementation
+	Impl StatusBadgePlugin
+}
+
+// GRPCServer registers the gRPC server (plugin side)
+func (p *StatusBadgePluginGRPC) GRPCServer(broker *goplugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterStatusBadgePluginServer(s, &StatusBadgeGRPCServer{Impl: p.Impl})
+	return nil
+}
+
+// GRPCClient returns the gRPC client (host side)
+func (p *StatusBadgePluginGRPC) GRPCClient(ctx context.Context, broker *goplugin.GRPCBroker, c *grpc.ClientConn) (any, error) {
+	return &StatusBadgeGRPCClient{client: proto.NewStatusBadgePluginClient(c)}, nil
+}
+
+// StatusBadgeGRPCClient is the client-side implementation of StatusBadgePlugin over gRPC
+type StatusBadgeGRPCClient struct {
+	client proto.StatusBadgePluginClient
+}
+
+// GetStatusBadge calls the plugin's GetStatusBadge RPC.
+// Plugins that don't serve status badges show no badge.
+func (c *StatusBadgeGRPCClient) GetStatusBadge(ctx context.Context, req *StatusBadgeRequest) (*StatusBadgeResponse, error) {
+	resp, err := c.client.GetStatusBadge(ctx, req)
+	if status.Code(err) == codes.Unimplemented {
+		return &StatusBadgeResponse{}, nil
+	}
+	return resp, err
+}
+
+// StatusBadgeGRPCServer is the server-side implementation that wraps the actual plugin
+type StatusBadgeGRPCServer struct {
+	proto.UnimplementedStatusBadgePluginServer
+	Impl StatusBadgePlugin
+}
+
+// GetStatusBadge handles the GetStatusBadge RPC
+func (s *StatusBadgeGRPCServer) GetStatusBadge(ctx context.Context, req *StatusBadgeRequest) (*StatusBadgeResponse, error) {
+	return s.Impl.GetStatusBadge(ctx, req)
+}
@@ -0,0 +1,130 @@
+// Package plugintest provides golden-file helpers for testing p5 plugins.
+//
+// Responses are rendered into a stable, human-readable text form (map keys are
+// sorted) and compared against testdata/<TestName>.golden, the same layout used
+// by p5's own UI tests. Run `go test ./... -update` to regenerate golden files.
+//
+//	func TestOpenResource_Bucket(t *testing.T) {
+//		resp, err := myPlugin.OpenResource(ctx, req)
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//		plugintest.RequireOpenResourceGolden(t, resp)
+//	}
+package plugintest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/exp/golden"
+
+	"github.com/rfhold/p5/internal/plugins/proto"
+	"github.com/rfhold/p5/pkg/plugin"
+)
+
+// RequireOpenResourceGolden renders an OpenResource response and compares it
+// with the test's golden file
+func RequireOpenResourceGolden(tb testing.TB, resp *plugin.OpenResourceResponse) {
+	tb.Helper()
+	golden.RequireEqual(tb, []byte(RenderOpenResource(resp)))
+}
+
+// RequireImportSuggestionsGolden renders a GetImportSuggestions response and
+// compares it with the test's golden file
+func RequireImportSuggestionsGolden(tb testing.TB, resp *plugin.ImportSuggestionsResponse) {
+	tb.Helper()
+	golden.RequireEqual(tb, []byte(RenderImportSuggestions(resp)))
+}
+
+// RenderOpenResource renders an OpenResource response deterministically
+func RenderOpenResource(resp *plugin.OpenResourceResponse) string {
+	var b strings.Builder
+	if resp == nil {
+		b.WriteString("response: nil\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "can_open: %t\n", resp.CanOpen)
+	if resp.Error != "" {
+		fmt.Fprintf(&b, "error: %s\n", resp.Error)
+	}
+
+	action := resp.Action
+	if action == nil {
+		return b.String()
+	}
+	fmt.Fprintf(&b, "action: %s\n", actionTypeName(action.Type))
+	if action.Url != "" {
+		fmt.Fprintf(&b, "url: %s\n", action.Url)
+	}
+	if action.Command != "" {
+		fmt.Fprintf(&b, "command: %s\n", action.Command)
+	}
+	if len(action.Args) > 0 {
+		b.WriteString("args:\n")
+		for _, arg := range action.Args {
+			fmt.Fprintf(&b, "  - %s\n", arg)
+		}
+	}
+	if len(action.Env) > 0 {
+		b.WriteString("env:\n")
+		for _, k := range sortedKeys(action.Env) {
+			fmt.Fprintf(&b, "  %s=%s\n", k, action.Env[k])
+		}
+	}
+	return b.String()
+}
+
+// RenderImportSuggestions renders a GetImportSuggestions response deterministically.
+// Suggestions keep the order returned by the plugin, since p5 displays them as-is.
+func RenderImportSuggestions(resp *plugin.ImportSuggestionsResponse) string {
+	var b strings.Builder
+	if resp == nil {
+		b.WriteString("response: nil\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "can_provide: %t\n", resp.CanProvide)
+	if resp.Error != "" {
+		fmt.Fprintf(&b, "error: %s\n", resp.Error)
+	}
+	if len(resp.Suggestions) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("suggestions:\n")
+	for _, s := range resp.Suggestions {
+		fmt.Fprintf(&b, "  - id: %s\n", s.Id)
+		if s.Label != "" {
+			fmt.Fprintf(&b, "    label: %s\n", s.Label)
+		}
+		if s.Description != "" {
+			fmt.Fprintf(&b, "    description: %s\n", s.Description)
+		}
+	}
+	return b.String()
+}
+
+// actionTypeName returns a short name for an open action type
+func actionTypeName(t plugin.OpenActionType) string {
+	switch t {
+	case proto.OpenActionType_OPEN_ACTION_TYPE_BROWSER:
+		return "browser"
+	case proto.OpenActionType_OPEN_ACTION_TYPE_EXEC:
+		return "exec"
+	default:
+		return t.String()
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
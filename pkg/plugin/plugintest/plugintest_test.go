@@ -0,0 +1,38 @@
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/rfhold/p5/pkg/plugin"
+)
+
+func TestRenderOpenResource_Exec(t *testing.T) {
+	resp := plugin.OpenExecResponse("k9s", []string{"--context", "dev"}, map[string]string{"ZED": "1", "ALPHA": "2"})
+	RequireOpenResourceGolden(t, resp)
+}
+
+func TestRenderOpenResource_Browser(t *testing.T) {
+	RequireOpenResourceGolden(t, plugin.OpenBrowserResponse("https://example.com/resource"))
+}
+
+func TestRenderOpenResource_Error(t *testing.T) {
+	RequireOpenResourceGolden(t, plugin.OpenError("missing %s", "url"))
+}
+
+func TestRenderOpenResource_Nil(t *testing.T) {
+	if got := RenderOpenResource(nil); got != "response: nil\n" {
+		t.Errorf("unexpected render for nil response: %q", got)
+	}
+}
+
+func TestRenderImportSuggestions(t *testing.T) {
+	resp := plugin.ImportSuggestionsSuccess([]*plugin.ImportSuggestion{
+		plugin.NewImportSuggestion("default/web", "web", "Deployment in default"),
+		plugin.NewImportSuggestion("kube-system/dns", "", ""),
+	})
+	RequireImportSuggestionsGolden(t, resp)
+}
+
+func TestRenderImportSuggestions_NotSupported(t *testing.T) {
+	RequireImportSuggestionsGolden(t, plugin.ImportSuggestionsNotSupported())
+}